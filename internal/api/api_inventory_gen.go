@@ -22,6 +22,7 @@ func registerInventoryRoutes(
 	authorizer *authz.Authorizer,
 	inventoryRouter Router,
 	inventoryInventoryAggregateSvc inventory.InventoryAggregateService,
+	siteSvc provisioning.SiteService,
 ) map[domain.ResourceType]provisioning.InventorySyncer {
 	// Service
 
@@ -413,7 +414,7 @@ func registerInventoryRoutes(
 
 	// API routes
 	inventoryQueryRouter := inventoryRouter.SubGroup("/query")
-	registerInventoryQueryHandler(inventoryQueryRouter, authorizer, inventoryInventoryAggregateSvc)
+	registerInventoryQueryHandler(inventoryQueryRouter, authorizer, inventoryInventoryAggregateSvc, siteSvc)
 
 	inventoryImageRouter := inventoryRouter.SubGroup("/images")
 	registerInventoryImageHandler(inventoryImageRouter, authorizer, inventoryImageSvc)
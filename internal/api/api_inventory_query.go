@@ -1,10 +1,13 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/FuturFusion/operations-center/internal/inventory"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
 	"github.com/FuturFusion/operations-center/internal/security/authz"
 	"github.com/FuturFusion/operations-center/internal/util/ptr"
 	"github.com/FuturFusion/operations-center/internal/util/response"
@@ -12,11 +15,13 @@ import (
 
 type queryHandler struct {
 	service inventory.InventoryAggregateService
+	siteSvc provisioning.SiteService
 }
 
-func registerInventoryQueryHandler(router Router, authorizer *authz.Authorizer, service inventory.InventoryAggregateService) {
+func registerInventoryQueryHandler(router Router, authorizer *authz.Authorizer, service inventory.InventoryAggregateService, siteSvc provisioning.SiteService) {
 	handler := &queryHandler{
 		service: service,
+		siteSvc: siteSvc,
 	}
 
 	router.HandleFunc("GET /{$}", response.With(handler.querysGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
@@ -60,6 +65,11 @@ func registerInventoryQueryHandler(router Router, authorizer *authz.Authorizer,
 //	    description: Filter expression
 //	    type: string
 //	    x-example: name == "value"
+//	  - in: query
+//	    name: site
+//	    description: Site name, limits the resources to the clusters belonging to the site
+//	    type: string
+//	    x-example: site
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/InventoryAggregatesResponse"
@@ -80,6 +90,27 @@ func (i *queryHandler) querysGet(r *http.Request) response.Response {
 		filter.Clusters = clusters
 	}
 
+	site := r.URL.Query().Get("site")
+	if site != "" {
+		members, err := i.siteSvc.GetMembersByName(r.Context(), site)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed to get members of site %q: %w", site, err))
+		}
+
+		siteClusters := members.Clusters
+		if len(filter.Clusters) > 0 {
+			siteClusters = slices.DeleteFunc(slices.Clone(filter.Clusters), func(cluster string) bool {
+				return !slices.Contains(members.Clusters, cluster)
+			})
+		}
+
+		if len(siteClusters) == 0 {
+			return response.SyncResponse(true, mapInventoryAggregateToAPITypes(nil))
+		}
+
+		filter.Clusters = siteClusters
+	}
+
 	servers, ok := r.URL.Query()["server"]
 	if ok {
 		filter.Servers = servers
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -30,17 +31,24 @@ func registerProvisioningClusterHandler(router Router, authorizer *authz.Authori
 
 	router.HandleFunc("GET /{$}", response.With(handler.clustersGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("POST /{$}", response.With(handler.clustersPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanCreate)))
+	router.HandleFunc("POST /:adopt", response.With(handler.clustersAdoptPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanCreate)))
+	router.HandleFunc("POST /:apply", response.With(handler.clustersApplyPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanCreate)))
 	router.HandleFunc("GET /{name}", response.With(handler.clusterGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("PUT /{name}", response.With(handler.clusterPut, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("DELETE /{name}", response.With(handler.clusterDelete, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
 	router.HandleFunc("POST /{name}", response.With(handler.clusterPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:add-servers", response.With(handler.clusterAddServersPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:remove-servers", response.With(handler.clusterRemoveServerPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("POST /{name}/:replace-server", response.With(handler.clusterReplaceServerPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:bulk-update", response.With(handler.clusterBulkUpdatePost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:resync-inventory", response.With(handler.clusterResyncInventoryPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:update", response.With(handler.clusterUpdatePost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:reboot", response.With(handler.clusterRebootPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("POST /{name}/:firmware-update", response.With(handler.clusterFirmwareUpdatePost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/:cancel-operation", response.With(handler.clusterCancelOperationPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("GET /{name}/operations", response.With(handler.clusterOperationsGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/template-drift", response.With(handler.clusterTemplateDriftGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/consistency", response.With(handler.clusterConsistencyGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("PUT /{name}/certificate", response.With(handler.clusterCertificatePut, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("GET /{clusterName}/artifacts", response.With(handler.clusterArtifactsGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("GET /{clusterName}/artifacts/{artifactName}", response.With(handler.clusterArtifactGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
@@ -62,6 +70,11 @@ func registerProvisioningClusterHandler(router Router, authorizer *authz.Authori
 //	    description: Filter expression
 //	    type: string
 //	    x-example: name == "value"
+//	  - in: query
+//	    name: site
+//	    description: Site name
+//	    type: string
+//	    x-example: site
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/URLsResponse"
@@ -85,6 +98,11 @@ func registerProvisioningClusterHandler(router Router, authorizer *authz.Authori
 //	    description: Filter expression
 //	    type: string
 //	    x-example: name == "value"
+//	  - in: query
+//	    name: site
+//	    description: Site name
+//	    type: string
+//	    x-example: site
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/ClustersResponse"
@@ -101,6 +119,10 @@ func (c *clusterHandler) clustersGet(r *http.Request) response.Response {
 
 	var filter provisioning.ClusterFilter
 
+	if r.URL.Query().Get("site") != "" {
+		filter.Site = ptr.To(r.URL.Query().Get("site"))
+	}
+
 	if r.URL.Query().Get("filter") != "" {
 		filter.Expression = ptr.To(r.URL.Query().Get("filter"))
 	}
@@ -118,6 +140,7 @@ func (c *clusterHandler) clustersGet(r *http.Request) response.Response {
 				ClusterPut: api.ClusterPut{
 					ConnectionURL: cluster.ConnectionURL,
 					Channel:       cluster.Channel,
+					Site:          ptr.From(cluster.Site),
 					Description:   cluster.Description,
 					Properties:    cluster.Properties,
 					Config:        cluster.Config,
@@ -127,6 +150,7 @@ func (c *clusterHandler) clustersGet(r *http.Request) response.Response {
 				Status:       cluster.Status,
 				LastUpdated:  cluster.LastUpdated,
 				UpdateStatus: cluster.UpdateStatus,
+				Template:     cluster.TemplateReference(c.clusterTemplateVariables(r.Context(), cluster)),
 			})
 		}
 
@@ -183,6 +207,13 @@ func (c *clusterHandler) clustersPost(r *http.Request) response.Response {
 	}
 
 	if cluster.ClusterTemplate != "" {
+		// Apply fills in the default values of the variables, which are not
+		// provided, such that the variable values recorded on the cluster are
+		// complete.
+		if cluster.ClusterTemplateVariableValues == nil {
+			cluster.ClusterTemplateVariableValues = api.ConfigMap{}
+		}
+
 		cluster.ServicesConfig, cluster.ApplicationSeedConfig, err = c.clusterTemplateSvc.Apply(r.Context(), cluster.ClusterTemplate, cluster.ClusterTemplateVariableValues)
 		if err != nil {
 			return response.SmartError(fmt.Errorf("Failed creating cluster from template %q: %w", cluster.ClusterTemplate, err))
@@ -197,9 +228,13 @@ func (c *clusterHandler) clustersPost(r *http.Request) response.Response {
 		ServicesConfig:        cluster.ServicesConfig,
 		ApplicationSeedConfig: cluster.ApplicationSeedConfig,
 		Channel:               cluster.Channel,
+		Site:                  siteFromAPI(cluster.Site),
 		Description:           cluster.Description,
 		Properties:            cluster.Properties,
 		Config:                cluster.Config,
+
+		ClusterTemplate:               cluster.ClusterTemplate,
+		ClusterTemplateVariableValues: cluster.ClusterTemplateVariableValues,
 	})
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed creating cluster: %w", err))
@@ -208,6 +243,130 @@ func (c *clusterHandler) clustersPost(r *http.Request) response.Response {
 	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/clusters/"+cluster.Name)
 }
 
+// swagger:operation POST /1.0/provisioning/clusters/:adopt clusters clusters_adopt_post
+//
+//	Adopt a cluster
+//
+//	Adopts an existing cluster, which has not been created by Operations
+//	Center. All the members of the cluster need to be registered already.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: cluster
+//	    description: Cluster configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterAdoptPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clustersAdoptPost(r *http.Request) response.Response {
+	var cluster api.ClusterAdoptPost
+
+	// Decode into the adopted cluster.
+	err := json.NewDecoder(r.Body).Decode(&cluster)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	_, err = c.service.Adopt(r.Context(), provisioning.Cluster{
+		Name:          cluster.Name,
+		ConnectionURL: cluster.ConnectionURL,
+		ServerNames:   cluster.ServerNames,
+		Channel:       cluster.Channel,
+		Site:          siteFromAPI(cluster.Site),
+		Description:   cluster.Description,
+		Properties:    cluster.Properties,
+		Config:        cluster.Config,
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed adopting cluster: %w", err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/clusters/"+cluster.Name)
+}
+
+// swagger:operation POST /1.0/provisioning/clusters/:apply clusters clusters_apply_post
+//
+//	Apply a cluster spec
+//
+//	Reconciles the live state of a cluster with the given declarative cluster
+//	spec. The cluster is created, if it does not exist. Otherwise the servers,
+//	storage targets, network VLAN tags, logging and kernel configuration as
+//	well as the configuration of the cluster are updated to match the spec.
+//	The returned plan contains the changes, which have been applied.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: dry-run
+//	    description: |-
+//	      Boolean indicating, if only the plan of the changes should be returned
+//	      without applying them.
+//	      Defaults to false.
+//	      The parameter without a value is treated as true.
+//	    type: boolean
+//	    x-example: true
+//	  - in: body
+//	    name: cluster_spec
+//	    description: Cluster spec
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterSpec"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterSpecPlanResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clustersApplyPost(r *http.Request) response.Response {
+	var spec api.ClusterSpec
+
+	err := json.NewDecoder(r.Body).Decode(&spec)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	dryRun, err := dryRunQueryParam(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if dryRun {
+		plan, err := c.service.PlanClusterSpec(r.Context(), spec)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed to plan cluster spec: %w", err))
+		}
+
+		return response.SyncResponse(true, plan)
+	}
+
+	plan, err := c.service.ApplyClusterSpec(r.Context(), spec)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to apply cluster spec: %w", err))
+	}
+
+	return response.SyncResponse(true, plan)
+}
+
 // swagger:operation GET /1.0/provisioning/clusters/{name} clusters cluster_get
 //
 //	Get the cluster
@@ -247,6 +406,7 @@ func (c *clusterHandler) clusterGet(r *http.Request) response.Response {
 			ClusterPut: api.ClusterPut{
 				ConnectionURL: cluster.ConnectionURL,
 				Channel:       cluster.Channel,
+				Site:          ptr.From(cluster.Site),
 				Description:   cluster.Description,
 				Properties:    cluster.Properties,
 				Config:        cluster.Config,
@@ -256,6 +416,7 @@ func (c *clusterHandler) clusterGet(r *http.Request) response.Response {
 			Status:       cluster.Status,
 			LastUpdated:  cluster.LastUpdated,
 			UpdateStatus: cluster.UpdateStatus,
+			Template:     cluster.TemplateReference(c.clusterTemplateVariables(r.Context(), *cluster)),
 		},
 		cluster,
 	)
@@ -321,6 +482,7 @@ func (c *clusterHandler) clusterPut(r *http.Request) response.Response {
 
 	currentCluster.ConnectionURL = cluster.ConnectionURL
 	currentCluster.Channel = cluster.Channel
+	currentCluster.Site = siteFromAPI(cluster.Site)
 	currentCluster.Description = cluster.Description
 	currentCluster.Properties = cluster.Properties
 	currentCluster.Config = cluster.Config
@@ -605,6 +767,61 @@ func (c *clusterHandler) clusterRemoveServerPost(r *http.Request) response.Respo
 	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/clusters/"+name)
 }
 
+// swagger:operation POST /1.0/provisioning/clusters/{name}/:replace-server clusters clusters_replace_server_post
+//
+//	Replace a failed server of a cluster
+//
+//	Removes a failed (offline) server from the cluster and joins the
+//	replacement server in its place. The services config is copied from one of
+//	the remaining cluster members and the member specific server configuration
+//	is applied to the replacement server.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: cluster
+//	    description: Replace server request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterReplaceServerPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clusterReplaceServerPost(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var replaceServerRequest api.ClusterReplaceServerPost
+
+	err := json.NewDecoder(r.Body).Decode(&replaceServerRequest)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = c.service.ReplaceServer(r.Context(), name, replaceServerRequest.ServerName, replaceServerRequest.ReplacementServerName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to replace server %q in cluster %q: %w", replaceServerRequest.ServerName, name, err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/clusters/"+name)
+}
+
 // swagger:operation POST /1.0/provisioning/clusters/{name}/:bulk-update clusters cluster_bulk_update_inventory_post
 //
 //	Bulk update to all cluster members
@@ -764,6 +981,47 @@ func (c *clusterHandler) clusterBulkUpdatePost(r *http.Request) response.Respons
 
 		err = c.service.RemoveStorageTargetNVME(ctx, name, nvmeTarget)
 
+	case api.ClusterBulkUpdateActionAddCephCluster:
+		var cephCluster struct {
+			Name string `json:"name"`
+			incusosapi.ServiceCephCluster
+		}
+		err = json.Unmarshal(*request.Arguments, &cephCluster)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		err = c.service.AddServiceCephCluster(ctx, name, cephCluster.Name, cephCluster.ServiceCephCluster)
+
+	case api.ClusterBulkUpdateActionRemoveCephCluster:
+		var cephCluster struct {
+			Name string `json:"name"`
+		}
+		err = json.Unmarshal(*request.Arguments, &cephCluster)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		err = c.service.RemoveServiceCephCluster(ctx, name, cephCluster.Name)
+
+	case api.ClusterBulkUpdateActionUpdateOVNConfig:
+		var ovnConfig incusosapi.ServiceOVNConfig
+		err = json.Unmarshal(*request.Arguments, &ovnConfig)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		err = c.service.UpdateServiceOVN(ctx, name, ovnConfig)
+
+	case api.ClusterBulkUpdateActionUpdateLinstorConfig:
+		var linstorConfig incusosapi.ServiceLinstorConfig
+		err = json.Unmarshal(*request.Arguments, &linstorConfig)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		err = c.service.UpdateServiceLinstor(ctx, name, linstorConfig)
+
 	default:
 		return response.BadRequest(fmt.Errorf("Invalid action %q", request.Action))
 	}
@@ -829,6 +1087,15 @@ func (c *clusterHandler) clusterResyncInventoryPost(r *http.Request) response.Re
 //	    description: Name of the cluster
 //	    type: string
 //	    required: true
+//	  - in: query
+//	    name: dry-run
+//	    description: |-
+//	      Boolean indicating, if only the plan of the cluster wide update should
+//	      be returned without launching the update.
+//	      Defaults to false.
+//	      The parameter without a value is treated as true.
+//	    type: boolean
+//	    x-example: true
 //	  - in: body
 //	    name: cluster_update_post
 //	    description: Cluster update request.
@@ -837,7 +1104,9 @@ func (c *clusterHandler) clusterResyncInventoryPost(r *http.Request) response.Re
 //	      $ref: "#/definitions/ClusterUpdatePost"
 //	responses:
 //	  "200":
-//	    $ref: "#/responses/EmptySyncResponse"
+//	    $ref: "#/responses/ClusterUpdatePlanResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
 //	  "403":
 //	    $ref: "#/responses/Forbidden"
 //	  "404":
@@ -854,6 +1123,20 @@ func (c *clusterHandler) clusterUpdatePost(r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	dryRun, err := dryRunQueryParam(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if dryRun {
+		plan, err := c.service.PlanClusterUpdate(r.Context(), name, request.Reboot)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed to plan cluster wide update: %w", err))
+		}
+
+		return response.SyncResponse(true, plan)
+	}
+
 	err = c.service.LaunchClusterUpdate(r.Context(), name, request.Reboot)
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed to launch cluster wide update: %w", err))
@@ -883,9 +1166,20 @@ func (c *clusterHandler) clusterUpdatePost(r *http.Request) response.Response {
 //	    description: Name of the cluster
 //	    type: string
 //	    required: true
+//	  - in: query
+//	    name: dry-run
+//	    description: |-
+//	      Boolean indicating, if only the plan of the cluster wide reboot should
+//	      be returned without launching the reboot.
+//	      Defaults to false.
+//	      The parameter without a value is treated as true.
+//	    type: boolean
+//	    x-example: true
 //	responses:
 //	  "200":
-//	    $ref: "#/responses/EmptySyncResponse"
+//	    $ref: "#/responses/ClusterUpdatePlanResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
 //	  "403":
 //	    $ref: "#/responses/Forbidden"
 //	  "404":
@@ -895,7 +1189,21 @@ func (c *clusterHandler) clusterUpdatePost(r *http.Request) response.Response {
 func (c *clusterHandler) clusterRebootPost(r *http.Request) response.Response {
 	name := r.PathValue("name")
 
-	err := c.service.LaunchClusterReboot(r.Context(), name)
+	dryRun, err := dryRunQueryParam(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if dryRun {
+		plan, err := c.service.PlanClusterReboot(r.Context(), name)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed to plan cluster wide reboot: %w", err))
+		}
+
+		return response.SyncResponse(true, plan)
+	}
+
+	err = c.service.LaunchClusterReboot(r.Context(), name)
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed to launch cluster wide reboot: %w", err))
 	}
@@ -903,6 +1211,63 @@ func (c *clusterHandler) clusterRebootPost(r *http.Request) response.Response {
 	return response.EmptySyncResponse
 }
 
+// swagger:operation POST /1.0/provisioning/clusters/{name}/:firmware-update clusters cluster_firmware_update_post
+//
+//	Perform cluster wide firmware update of servers
+//
+//	Perform a cluster wide firmware update of all servers of the cluster using
+//	the update service of their BMC. The servers are evacuated, updated,
+//	rebooted and restored one at a time, such that the workload of the cluster
+//	remains available. All servers of the cluster need to have a BMC configured.
+//	A cluster can only run a single cluster wide operation at a time, so the
+//	request fails, if an update or a reboot is already ongoing.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: update
+//	    description: Firmware update
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/BMCFirmwareUpdatePost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clusterFirmwareUpdatePost(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var req api.BMCFirmwareUpdatePost
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Request decoding: %v", err))
+	}
+
+	err = c.service.LaunchClusterFirmwareUpdate(r.Context(), name, req)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to launch cluster wide firmware update: %w", err))
+	}
+
+	return response.EmptySyncResponse
+}
+
 // swagger:operation POST /1.0/provisioning/clusters/{name}/:cancel-operation clusters cluster_cancel_operation_post
 //
 //	Cancel the ongoing cluster wide operation
@@ -939,6 +1304,146 @@ func (c *clusterHandler) clusterCancelOperationPost(r *http.Request) response.Re
 	return response.EmptySyncResponse
 }
 
+// swagger:operation GET /1.0/provisioning/clusters/{name}/operations clusters cluster_operations_get
+//
+//	Get the history of the cluster wide operations
+//
+//	Returns the cluster wide operations, which have been performed on the
+//	cluster, most recent first, including the steps performed on each server.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterOperationsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clusterOperationsGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	operations, err := c.service.GetOperationAll(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.ClusterOperation, 0, len(operations))
+	for _, operation := range operations {
+		events := make([]api.ClusterOperationEvent, 0, len(operation.Events))
+		for _, event := range operation.Events {
+			events = append(events, api.ClusterOperationEvent{
+				Server:     event.Server,
+				Action:     event.Action,
+				StartedAt:  event.StartedAt,
+				FinishedAt: event.FinishedAt,
+			})
+		}
+
+		result = append(result, api.ClusterOperation{
+			UUID:       operation.UUID,
+			Cluster:    operation.Cluster,
+			Type:       operation.Type,
+			Status:     operation.Status,
+			Error:      operation.Error,
+			StartedAt:  operation.StartedAt,
+			FinishedAt: operation.FinishedAt,
+			Events:     events,
+		})
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation GET /1.0/provisioning/clusters/{name}/template-drift clusters cluster_template_drift_get
+//
+//	Get the drift of the cluster from its cluster template
+//
+//	Re-renders the current revision of the cluster template, the cluster has
+//	been created from, with the recorded variable values and reports the
+//	differences to the live service configuration of the servers of the
+//	cluster.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterTemplateDriftResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clusterTemplateDriftGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	drift, err := c.service.GetClusterTemplateDrift(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, drift)
+}
+
+// swagger:operation GET /1.0/provisioning/clusters/{name}/consistency clusters cluster_consistency_get
+//
+//	Get the consistency of the cluster members
+//
+//	Compares the OS and application versions, the network VLAN tags, the
+//	kernel and logging configuration and the OS services configuration of the
+//	ready members of the cluster and reports the settings, in which the
+//	members diverge from each other.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterConsistencyResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterHandler) clusterConsistencyGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	consistency, err := c.service.GetClusterConsistency(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, consistency)
+}
+
 // swagger:operation PUT /1.0/provisioning/clusters/{name}/certificate clusters cluster_certificate_put
 //
 //	Update the cluster's certificate and key
@@ -1261,3 +1766,41 @@ func (c *clusterHandler) clusterArtifactFileGet(r *http.Request) response.Respon
 
 	return response.ReadCloserResponse(r, rc, false, filename, int(file.Size), headers)
 }
+
+// clusterTemplateVariables returns the variable definitions of the cluster
+// template revision, the cluster has been created from. If the revision is not
+// available, nil is returned, which causes all the recorded variable values to
+// be masked.
+func (c *clusterHandler) clusterTemplateVariables(ctx context.Context, cluster provisioning.Cluster) api.ClusterTemplateVariables {
+	if cluster.ClusterTemplate == "" {
+		return nil
+	}
+
+	revision, err := c.clusterTemplateSvc.GetRevision(ctx, cluster.ClusterTemplate, cluster.ClusterTemplateRevision)
+	if err != nil {
+		return nil
+	}
+
+	return revision.Variables
+}
+
+// dryRunQueryParam returns, if the dry-run query parameter is set. The bare
+// parameter without a value (e.g. "?dry-run") is treated as true, such that
+// a request for a plan never performs the actual operation.
+func dryRunQueryParam(r *http.Request) (bool, error) {
+	if !r.URL.Query().Has("dry-run") {
+		return false, nil
+	}
+
+	value := r.URL.Query().Get("dry-run")
+	if value == "" {
+		return true, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("Invalid value for query parameter %q: %q", "dry-run", value)
+	}
+
+	return dryRun, nil
+}
@@ -0,0 +1,341 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/security/authz"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/response"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type clusterBlueprintHandler struct {
+	service provisioning.ClusterBlueprintService
+}
+
+func registerProvisioningClusterBlueprintHandler(router Router, authorizer *authz.Authorizer, service provisioning.ClusterBlueprintService) {
+	handler := &clusterBlueprintHandler{
+		service: service,
+	}
+
+	router.HandleFunc("GET /{$}", response.With(handler.clusterBlueprintsGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("POST /{$}", response.With(handler.clusterBlueprintsPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanCreate)))
+	router.HandleFunc("GET /{name}", response.With(handler.clusterBlueprintGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("PUT /{name}", response.With(handler.clusterBlueprintPut, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("DELETE /{name}", response.With(handler.clusterBlueprintDelete, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
+}
+
+// swagger:operation GET /1.0/provisioning/cluster-blueprints cluster_blueprints cluster_blueprints_get
+//
+//	Get the cluster blueprints
+//
+//	Returns a list of cluster blueprints (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/URLsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/provisioning/cluster-blueprints?recursion=1 cluster_blueprints cluster_blueprints_get_recursion
+//
+//	Get the cluster blueprints
+//
+//	Returns a list of cluster blueprints (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterBlueprintsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *clusterBlueprintHandler) clusterBlueprintsGet(r *http.Request) response.Response {
+	// Parse the recursion field.
+	recursion, err := strconv.Atoi(r.FormValue("recursion"))
+	if err != nil {
+		recursion = 0
+	}
+
+	if recursion == 1 {
+		clusterBlueprints, err := h.service.GetAll(r.Context())
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		result := make([]api.ClusterBlueprint, 0, len(clusterBlueprints))
+		for _, clusterBlueprint := range clusterBlueprints {
+			result = append(result, toAPIClusterBlueprint(clusterBlueprint))
+		}
+
+		return response.SyncResponse(true, result)
+	}
+
+	clusterBlueprintNames, err := h.service.GetAllNames(r.Context())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]string, 0, len(clusterBlueprintNames))
+	for _, name := range clusterBlueprintNames {
+		result = append(result, fmt.Sprintf("/%s/provisioning/cluster-blueprints/%s", api.APIVersion, name))
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation POST /1.0/provisioning/cluster-blueprints cluster_blueprints cluster_blueprints_post
+//
+//	Add a cluster blueprint
+//
+//	Creates a new cluster blueprint. The cluster is formed automatically, as
+//	soon as enough servers matching the server filter are ready and not yet
+//	part of a cluster.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: cluster_blueprint
+//	    description: Cluster blueprint definition
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterBlueprintPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *clusterBlueprintHandler) clusterBlueprintsPost(r *http.Request) response.Response {
+	var clusterBlueprint api.ClusterBlueprintPost
+
+	// Decode into the new cluster blueprint.
+	err := json.NewDecoder(r.Body).Decode(&clusterBlueprint)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	_, err = h.service.Create(r.Context(), provisioning.ClusterBlueprint{
+		Name:                          clusterBlueprint.Name,
+		Description:                   clusterBlueprint.Description,
+		ClusterTemplate:               clusterBlueprint.ClusterTemplate,
+		ClusterTemplateVariableValues: clusterBlueprint.ClusterTemplateVariableValues,
+		ServerFilter:                  clusterBlueprint.ServerFilter,
+		MemberCount:                   clusterBlueprint.MemberCount,
+		ConnectionURL:                 clusterBlueprint.ConnectionURL,
+		ServerType:                    clusterBlueprint.ServerType,
+		Channel:                       clusterBlueprint.Channel,
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating cluster blueprint: %w", err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/cluster-blueprints/"+clusterBlueprint.Name)
+}
+
+// swagger:operation GET /1.0/provisioning/cluster-blueprints/{name} cluster_blueprints cluster_blueprint_get
+//
+//	Get the cluster blueprint
+//
+//	Gets a specific cluster blueprint including its status.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster blueprint
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterBlueprintResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *clusterBlueprintHandler) clusterBlueprintGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	clusterBlueprint, err := h.service.GetByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(
+		true,
+		toAPIClusterBlueprint(*clusterBlueprint),
+		clusterBlueprint,
+	)
+}
+
+// swagger:operation PUT /1.0/provisioning/cluster-blueprints/{name} cluster_blueprints cluster_blueprint_put
+//
+//	Update the cluster blueprint
+//
+//	Updates the cluster blueprint definition. Only waiting or failed cluster
+//	blueprints can be updated. A failed cluster blueprint is put back into
+//	waiting state, such that the cluster formation is retried.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster blueprint
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: cluster_blueprint
+//	    description: Cluster blueprint definition
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterBlueprintPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *clusterBlueprintHandler) clusterBlueprintPut(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var clusterBlueprint api.ClusterBlueprintPut
+
+	err := json.NewDecoder(r.Body).Decode(&clusterBlueprint)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	ctx, trans := transaction.Begin(r.Context())
+	defer func() {
+		rollbackErr := trans.Rollback()
+		if rollbackErr != nil {
+			response.SmartError(fmt.Errorf("Transaction rollback failed: %v, reason: %w", rollbackErr, err))
+		}
+	}()
+
+	currentClusterBlueprint, err := h.service.GetByName(ctx, name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to get cluster blueprint %q: %w", name, err))
+	}
+
+	// Validate ETag
+	err = response.EtagCheck(r, currentClusterBlueprint)
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	currentClusterBlueprint.Description = clusterBlueprint.Description
+	currentClusterBlueprint.ClusterTemplate = clusterBlueprint.ClusterTemplate
+	currentClusterBlueprint.ClusterTemplateVariableValues = clusterBlueprint.ClusterTemplateVariableValues
+	currentClusterBlueprint.ServerFilter = clusterBlueprint.ServerFilter
+	currentClusterBlueprint.MemberCount = clusterBlueprint.MemberCount
+	currentClusterBlueprint.ConnectionURL = clusterBlueprint.ConnectionURL
+	currentClusterBlueprint.ServerType = clusterBlueprint.ServerType
+	currentClusterBlueprint.Channel = clusterBlueprint.Channel
+
+	err = h.service.Update(ctx, *currentClusterBlueprint)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed updating cluster blueprint %q: %w", name, err))
+	}
+
+	err = trans.Commit()
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed commit transaction: %w", err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/cluster-blueprints/"+name)
+}
+
+// swagger:operation DELETE /1.0/provisioning/cluster-blueprints/{name} cluster_blueprints cluster_blueprint_delete
+//
+//	Delete the cluster blueprint
+//
+//	Removes the cluster blueprint. A cluster, which has already been formed
+//	from the blueprint, is not affected.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster blueprint
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *clusterBlueprintHandler) clusterBlueprintDelete(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	err := h.service.DeleteByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+func toAPIClusterBlueprint(clusterBlueprint provisioning.ClusterBlueprint) api.ClusterBlueprint {
+	return api.ClusterBlueprint{
+		ClusterBlueprintPost: api.ClusterBlueprintPost{
+			Name: clusterBlueprint.Name,
+			ClusterBlueprintPut: api.ClusterBlueprintPut{
+				Description:                   clusterBlueprint.Description,
+				ClusterTemplate:               clusterBlueprint.ClusterTemplate,
+				ClusterTemplateVariableValues: clusterBlueprint.ClusterTemplateVariableValues,
+				ServerFilter:                  clusterBlueprint.ServerFilter,
+				MemberCount:                   clusterBlueprint.MemberCount,
+				ConnectionURL:                 clusterBlueprint.ConnectionURL,
+				ServerType:                    clusterBlueprint.ServerType,
+				Channel:                       clusterBlueprint.Channel,
+			},
+		},
+		Status:      clusterBlueprint.Status,
+		ServerNames: clusterBlueprint.ServerNames,
+		Error:       clusterBlueprint.Error,
+		LastUpdated: clusterBlueprint.LastUpdated,
+	}
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	provisioningMock "github.com/FuturFusion/operations-center/internal/provisioning/mock"
+	"github.com/FuturFusion/operations-center/internal/security/authn"
+	"github.com/FuturFusion/operations-center/internal/security/authz"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func Test_clusterHandler_dryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		requestBody string
+
+		wantStatus              int
+		wantResponseBodyContain string
+		wantPlanned             bool
+		wantLaunched            bool
+	}{
+		{
+			name:        "update - without dry-run",
+			target:      "/one/:update",
+			requestBody: `{"reboot": false}`,
+
+			wantStatus:   http.StatusOK,
+			wantLaunched: true,
+		},
+		{
+			name:        "update - dry-run without value",
+			target:      "/one/:update?dry-run",
+			requestBody: `{"reboot": false}`,
+
+			wantStatus:  http.StatusOK,
+			wantPlanned: true,
+		},
+		{
+			name:        "update - dry-run=true",
+			target:      "/one/:update?dry-run=true",
+			requestBody: `{"reboot": false}`,
+
+			wantStatus:  http.StatusOK,
+			wantPlanned: true,
+		},
+		{
+			name:        "update - dry-run=false",
+			target:      "/one/:update?dry-run=false",
+			requestBody: `{"reboot": false}`,
+
+			wantStatus:   http.StatusOK,
+			wantLaunched: true,
+		},
+		{
+			name:        "update - error - dry-run=bogus",
+			target:      "/one/:update?dry-run=bogus",
+			requestBody: `{"reboot": false}`,
+
+			wantStatus:              http.StatusBadRequest,
+			wantResponseBodyContain: `Invalid value for query parameter \"dry-run\": \"bogus\"`,
+		},
+		{
+			name:        "apply - without dry-run",
+			target:      "/:apply",
+			requestBody: `{"name": "one", "server_names": ["serverOne"]}`,
+
+			wantStatus:   http.StatusOK,
+			wantLaunched: true,
+		},
+		{
+			name:        "apply - dry-run without value",
+			target:      "/:apply?dry-run",
+			requestBody: `{"name": "one", "server_names": ["serverOne"]}`,
+
+			wantStatus:  http.StatusOK,
+			wantPlanned: true,
+		},
+		{
+			name:        "apply - dry-run=true",
+			target:      "/:apply?dry-run=true",
+			requestBody: `{"name": "one", "server_names": ["serverOne"]}`,
+
+			wantStatus:  http.StatusOK,
+			wantPlanned: true,
+		},
+		{
+			name:        "apply - dry-run=false",
+			target:      "/:apply?dry-run=false",
+			requestBody: `{"name": "one", "server_names": ["serverOne"]}`,
+
+			wantStatus:   http.StatusOK,
+			wantLaunched: true,
+		},
+		{
+			name:        "apply - error - dry-run=bogus",
+			target:      "/:apply?dry-run=bogus",
+			requestBody: `{"name": "one", "server_names": ["serverOne"]}`,
+
+			wantStatus:              http.StatusBadRequest,
+			wantResponseBodyContain: `Invalid value for query parameter \"dry-run\": \"bogus\"`,
+		},
+		{
+			name:   "reboot - without dry-run",
+			target: "/one/:reboot",
+
+			wantStatus:   http.StatusOK,
+			wantLaunched: true,
+		},
+		{
+			name:   "reboot - dry-run without value",
+			target: "/one/:reboot?dry-run",
+
+			wantStatus:  http.StatusOK,
+			wantPlanned: true,
+		},
+		{
+			name:   "reboot - dry-run=true",
+			target: "/one/:reboot?dry-run=true",
+
+			wantStatus:  http.StatusOK,
+			wantPlanned: true,
+		},
+		{
+			name:   "reboot - error - dry-run=bogus",
+			target: "/one/:reboot?dry-run=bogus",
+
+			wantStatus:              http.StatusBadRequest,
+			wantResponseBodyContain: `Invalid value for query parameter \"dry-run\": \"bogus\"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var planned, launched bool
+
+			clusterService := &provisioningMock.ClusterServiceMock{
+				PlanClusterUpdateFunc: func(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error) {
+					planned = true
+					return api.ClusterUpdatePlan{}, nil
+				},
+				LaunchClusterUpdateFunc: func(ctx context.Context, name string, reboot bool) error {
+					launched = true
+					return nil
+				},
+				PlanClusterRebootFunc: func(ctx context.Context, name string) (api.ClusterUpdatePlan, error) {
+					planned = true
+					return api.ClusterUpdatePlan{}, nil
+				},
+				LaunchClusterRebootFunc: func(ctx context.Context, name string) error {
+					launched = true
+					return nil
+				},
+				PlanClusterSpecFunc: func(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+					planned = true
+					return api.ClusterSpecPlan{}, nil
+				},
+				ApplyClusterSpecFunc: func(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+					launched = true
+					return api.ClusterSpecPlan{}, nil
+				},
+			}
+
+			body, status := doClusterRequest(t, clusterService, http.MethodPost, tc.target, tc.requestBody)
+
+			require.Equal(t, tc.wantStatus, status)
+			require.Equal(t, tc.wantPlanned, planned)
+			require.Equal(t, tc.wantLaunched, launched)
+			require.Contains(t, body, tc.wantResponseBodyContain)
+		})
+	}
+}
+
+func doClusterRequest(t *testing.T, clusterService provisioning.ClusterService, method string, target string, requestBody string) (string, int) {
+	t.Helper()
+
+	authenticator := authn.New([]authn.Auther{dummyAuthenticator{}})
+
+	serveMux := http.NewServeMux()
+	router := newRouter(serveMux).AddMiddlewares(
+		authenticator.Middleware(),
+	)
+
+	var authorizer authz.Authorizer = noopAuthorizer{}
+	registerProvisioningClusterHandler(router, &authorizer, clusterService, nil)
+
+	server := httptest.NewServer(serveMux)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequest(method, server.URL+target, bytes.NewBufferString(requestBody))
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body), resp.StatusCode
+}
@@ -29,6 +29,9 @@ func registerProvisioningClusterTemplateHandler(router Router, authorizer *authz
 	router.HandleFunc("PUT /{name}", response.With(handler.clusterTemplatePut, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("DELETE /{name}", response.With(handler.clusterTemplateDelete, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
 	router.HandleFunc("POST /{name}", response.With(handler.clusterTemplatePost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("GET /{name}/revisions", response.With(handler.clusterTemplateRevisionsGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/revisions/{revision}", response.With(handler.clusterTemplateRevisionGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/diff", response.With(handler.clusterTemplateDiffGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 }
 
 // swagger:operation GET /1.0/provisioning/cluster-templates cluster_templates cluster_config_templates_get
@@ -89,6 +92,7 @@ func (c *clusterConfigTemplateHandler) clusterTemplatesGet(r *http.Request) resp
 						Variables:                 clusterConfigTemplate.Variables,
 					},
 				},
+				Revision:    clusterConfigTemplate.Revision,
 				LastUpdated: clusterConfigTemplate.LastUpdated,
 			})
 		}
@@ -203,6 +207,7 @@ func (c *clusterConfigTemplateHandler) clusterTemplateGet(r *http.Request) respo
 					Variables:                 clusterConfigTemplate.Variables,
 				},
 			},
+			Revision:    clusterConfigTemplate.Revision,
 			LastUpdated: clusterConfigTemplate.LastUpdated,
 		},
 		clusterConfigTemplate,
@@ -406,3 +411,191 @@ func (c *clusterConfigTemplateHandler) clusterTemplatePost(r *http.Request) resp
 
 	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/cluster-templates/"+clusterConfigTemplate.Name)
 }
+
+// swagger:operation GET /1.0/provisioning/cluster-templates/{name}/revisions cluster_templates cluster_config_template_revisions_get
+//
+//	Get the revisions of the cluster config template
+//
+//	Returns the list of all the revisions of a specific cluster config
+//	template, oldest first.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster template
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterTemplateRevisionsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterConfigTemplateHandler) clusterTemplateRevisionsGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	revisions, err := c.service.GetRevisionAll(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.ClusterTemplateRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		result = append(result, api.ClusterTemplateRevision{
+			ClusterTemplatePut: api.ClusterTemplatePut{
+				Description:               revision.Description,
+				ServiceConfigTemplate:     revision.ServiceConfigTemplate,
+				ApplicationConfigTemplate: revision.ApplicationConfigTemplate,
+				Variables:                 revision.Variables,
+			},
+			Name:      revision.ClusterTemplate,
+			Revision:  revision.Revision,
+			CreatedAt: revision.CreatedAt,
+		})
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation GET /1.0/provisioning/cluster-templates/{name}/revisions/{revision} cluster_templates cluster_config_template_revision_get
+//
+//	Get the revision of the cluster config template
+//
+//	Gets a specific revision of a cluster config template.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster template
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: revision
+//	    description: Revision of the cluster template
+//	    type: integer
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterTemplateRevisionResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterConfigTemplateHandler) clusterTemplateRevisionGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	revisionNumber, err := strconv.ParseInt(r.PathValue("revision"), 10, 64)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid revision %q: %w", r.PathValue("revision"), err))
+	}
+
+	revision, err := c.service.GetRevision(r.Context(), name, revisionNumber)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(
+		true,
+		api.ClusterTemplateRevision{
+			ClusterTemplatePut: api.ClusterTemplatePut{
+				Description:               revision.Description,
+				ServiceConfigTemplate:     revision.ServiceConfigTemplate,
+				ApplicationConfigTemplate: revision.ApplicationConfigTemplate,
+				Variables:                 revision.Variables,
+			},
+			Name:      revision.ClusterTemplate,
+			Revision:  revision.Revision,
+			CreatedAt: revision.CreatedAt,
+		},
+	)
+}
+
+// swagger:operation GET /1.0/provisioning/cluster-templates/{name}/diff cluster_templates cluster_config_template_diff_get
+//
+//	Get the differences between two revisions of the cluster config template
+//
+//	Returns the differences between two revisions of a specific cluster config
+//	template in unified diff format.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the cluster template
+//	    type: string
+//	    required: true
+//	  - in: query
+//	    name: from
+//	    description: Revision the diff starts from, defaults to the revision preceding the "to" revision
+//	    type: integer
+//	    required: false
+//	  - in: query
+//	    name: to
+//	    description: Revision the diff leads to, defaults to the current revision
+//	    type: integer
+//	    required: false
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ClusterTemplateRevisionDiffResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (c *clusterConfigTemplateHandler) clusterTemplateDiffGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var toRevision int64
+	if r.URL.Query().Get("to") != "" {
+		var err error
+		toRevision, err = strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid revision %q: %w", r.URL.Query().Get("to"), err))
+		}
+	} else {
+		clusterConfigTemplate, err := c.service.GetByName(r.Context(), name)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		toRevision = clusterConfigTemplate.Revision
+	}
+
+	fromRevision := toRevision - 1
+	if r.URL.Query().Get("from") != "" {
+		var err error
+		fromRevision, err = strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid revision %q: %w", r.URL.Query().Get("from"), err))
+		}
+	}
+
+	if fromRevision < 1 {
+		return response.BadRequest(fmt.Errorf("Cluster config template %q has no revision preceding revision %d", name, toRevision))
+	}
+
+	diff, err := c.service.GetRevisionDiff(r.Context(), name, fromRevision, toRevision)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, diff)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/security/authz"
+	"github.com/FuturFusion/operations-center/internal/util/response"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type decommissionHandler struct {
+	service provisioning.ServerService
+}
+
+func registerProvisioningDecommissionHandler(router Router, authorizer *authz.Authorizer, service provisioning.ServerService) {
+	handler := &decommissionHandler{
+		service: service,
+	}
+
+	router.HandleFunc("GET /{$}", response.With(handler.decommissionsGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{uuid}", response.With(handler.decommissionGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+}
+
+// swagger:operation GET /1.0/provisioning/decommissions decommissions decommissions_get
+//
+//	Get the server decommissions
+//
+//	Returns the audit records of the decommissions of servers in the order
+//	they have been started.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: server
+//	    description: Server name
+//	    type: string
+//	    x-example: server01
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerDecommissionsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (d *decommissionHandler) decommissionsGet(r *http.Request) response.Response {
+	var decommissions provisioning.ServerDecommissions
+	var err error
+
+	if r.URL.Query().Get("server") != "" {
+		decommissions, err = d.service.GetDecommissionsByName(r.Context(), r.URL.Query().Get("server"))
+	} else {
+		decommissions, err = d.service.GetDecommissions(r.Context())
+	}
+
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.ServerDecommission, 0, len(decommissions))
+	for _, decommission := range decommissions {
+		result = append(result, toAPIServerDecommission(decommission))
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation GET /1.0/provisioning/decommissions/{uuid} decommissions decommission_get
+//
+//	Get the server decommission
+//
+//	Gets the audit record of a specific server decommission.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: uuid
+//	    description: UUID of the decommission
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerDecommissionResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (d *decommissionHandler) decommissionGet(r *http.Request) response.Response {
+	id, err := uuid.Parse(r.PathValue("uuid"))
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid decommission UUID: %w", err))
+	}
+
+	decommission, err := d.service.GetDecommissionByUUID(r.Context(), id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, toAPIServerDecommission(*decommission))
+}
+
+func toAPIServerDecommission(decommission provisioning.ServerDecommission) api.ServerDecommission {
+	return api.ServerDecommission{
+		UUID:       decommission.UUID,
+		Server:     decommission.Server,
+		Options:    decommission.Options,
+		Status:     decommission.Status,
+		Steps:      decommission.Steps,
+		Error:      decommission.Error,
+		StartedAt:  decommission.StartedAt,
+		FinishedAt: decommission.FinishedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/security/authz"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/internal/util/response"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type diskHealthHandler struct {
+	service provisioning.ServerService
+}
+
+func registerProvisioningDiskHealthHandler(router Router, authorizer *authz.Authorizer, service provisioning.ServerService) {
+	handler := &diskHealthHandler{
+		service: service,
+	}
+
+	router.HandleFunc("GET /{$}", response.With(handler.diskHealthGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+}
+
+// swagger:operation GET /1.0/provisioning/disk-health disk-health disk_health_get
+//
+//	Get the disk health
+//
+//	Returns the evaluated health of the disks of all servers.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: cluster
+//	    description: Cluster name
+//	    type: string
+//	    x-example: cluster
+//	  - in: query
+//	    name: site
+//	    description: Site name
+//	    type: string
+//	    x-example: site
+//	  - in: query
+//	    name: server
+//	    description: Server name
+//	    type: string
+//	    x-example: server01
+//	  - in: query
+//	    name: status
+//	    description: Disk health status to filter for.
+//	    type: string
+//	    x-example: warning
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerDiskHealthsResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (d *diskHealthHandler) diskHealthGet(r *http.Request) response.Response {
+	var filter provisioning.ServerFilter
+
+	if r.URL.Query().Get("cluster") != "" {
+		filter.Cluster = ptr.To(r.URL.Query().Get("cluster"))
+	}
+
+	if r.URL.Query().Get("site") != "" {
+		filter.Site = ptr.To(r.URL.Query().Get("site"))
+	}
+
+	if r.URL.Query().Get("server") != "" {
+		filter.Name = ptr.To(r.URL.Query().Get("server"))
+	}
+
+	var status *api.DiskHealthStatus
+	if r.URL.Query().Get("status") != "" {
+		status = new(api.DiskHealthStatus)
+		err := status.UnmarshalText([]byte(r.URL.Query().Get("status")))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid status: %w", err))
+		}
+	}
+
+	diskHealth, err := d.service.GetDiskHealthWithFilter(r.Context(), filter)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.ServerDiskHealth, 0, len(diskHealth))
+	for _, health := range diskHealth {
+		if status != nil && health.Status != *status {
+			continue
+		}
+
+		result = append(result, health)
+	}
+
+	return response.SyncResponse(true, result)
+}
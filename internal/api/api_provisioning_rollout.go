@@ -0,0 +1,287 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/security/authz"
+	"github.com/FuturFusion/operations-center/internal/util/response"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type rolloutHandler struct {
+	service provisioning.RolloutService
+}
+
+func registerProvisioningRolloutHandler(router Router, authorizer *authz.Authorizer, service provisioning.RolloutService) {
+	handler := &rolloutHandler{
+		service: service,
+	}
+
+	router.HandleFunc("GET /{$}", response.With(handler.rolloutsGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("POST /{$}", response.With(handler.rolloutsPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanCreate)))
+	router.HandleFunc("GET /{name}", response.With(handler.rolloutGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("DELETE /{name}", response.With(handler.rolloutDelete, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
+	router.HandleFunc("POST /{name}/:cancel", response.With(handler.rolloutCancelPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+}
+
+// swagger:operation GET /1.0/provisioning/rollouts rollouts rollouts_get
+//
+//	Get the rollouts
+//
+//	Returns a list of rollouts (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/URLsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/provisioning/rollouts?recursion=1 rollouts rollouts_get_recursion
+//
+//	Get the rollouts
+//
+//	Returns a list of rollouts (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/RolloutsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *rolloutHandler) rolloutsGet(r *http.Request) response.Response {
+	// Parse the recursion field.
+	recursion, err := strconv.Atoi(r.FormValue("recursion"))
+	if err != nil {
+		recursion = 0
+	}
+
+	if recursion == 1 {
+		rollouts, err := h.service.GetAll(r.Context())
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		result := make([]api.Rollout, 0, len(rollouts))
+		for _, rollout := range rollouts {
+			result = append(result, api.Rollout{
+				RolloutPost: api.RolloutPost{
+					Name:        rollout.Name,
+					Description: rollout.Description,
+					Reboot:      rollout.Reboot,
+					Waves:       rollout.Waves,
+				},
+				TargetVersion:  rollout.TargetVersion,
+				Status:         rollout.Status,
+				CurrentWave:    rollout.CurrentWave,
+				WaveStatus:     rollout.WaveStatus,
+				WaveClusters:   rollout.WaveClusters,
+				WaveFinishedAt: rollout.WaveFinishedAt,
+				Error:          rollout.Error,
+				LastUpdated:    rollout.LastUpdated,
+			})
+		}
+
+		return response.SyncResponse(true, result)
+	}
+
+	rolloutNames, err := h.service.GetAllNames(r.Context())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]string, 0, len(rolloutNames))
+	for _, name := range rolloutNames {
+		result = append(result, fmt.Sprintf("/%s/provisioning/rollouts/%s", api.APIVersion, name))
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation POST /1.0/provisioning/rollouts rollouts rollouts_post
+//
+//	Start a rollout
+//
+//	Creates and starts a new rollout, which updates the clusters in waves.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: rollout
+//	    description: Rollout definition
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/RolloutPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *rolloutHandler) rolloutsPost(r *http.Request) response.Response {
+	var rollout api.RolloutPost
+
+	// Decode into the new rollout.
+	err := json.NewDecoder(r.Body).Decode(&rollout)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	_, err = h.service.Create(r.Context(), provisioning.Rollout{
+		Name:        rollout.Name,
+		Description: rollout.Description,
+		Reboot:      rollout.Reboot,
+		Waves:       rollout.Waves,
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating rollout: %w", err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/rollouts/"+rollout.Name)
+}
+
+// swagger:operation GET /1.0/provisioning/rollouts/{name} rollouts rollout_get
+//
+//	Get the rollout
+//
+//	Gets a specific rollout including its progress.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the rollout
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/RolloutResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *rolloutHandler) rolloutGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	rollout, err := h.service.GetByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(
+		true,
+		api.Rollout{
+			RolloutPost: api.RolloutPost{
+				Name:        rollout.Name,
+				Description: rollout.Description,
+				Reboot:      rollout.Reboot,
+				Waves:       rollout.Waves,
+			},
+			TargetVersion:  rollout.TargetVersion,
+			Status:         rollout.Status,
+			CurrentWave:    rollout.CurrentWave,
+			WaveStatus:     rollout.WaveStatus,
+			WaveClusters:   rollout.WaveClusters,
+			WaveFinishedAt: rollout.WaveFinishedAt,
+			Error:          rollout.Error,
+			LastUpdated:    rollout.LastUpdated,
+		},
+		rollout,
+	)
+}
+
+// swagger:operation DELETE /1.0/provisioning/rollouts/{name} rollouts rollout_delete
+//
+//	Delete the rollout
+//
+//	Removes the rollout. Running rollouts need to be canceled first.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the rollout
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *rolloutHandler) rolloutDelete(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	err := h.service.DeleteByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/provisioning/rollouts/{name}/:cancel rollouts rollout_cancel_post
+//
+//	Cancel the rollout
+//
+//	Stops the running rollout. Cluster updates, which have already been
+//	launched by the rollout, are not affected.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the rollout
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (h *rolloutHandler) rolloutCancelPost(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	err := h.service.Cancel(r.Context(), name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to cancel rollout: %w", err))
+	}
+
+	return response.EmptySyncResponse
+}
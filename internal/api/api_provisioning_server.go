@@ -10,6 +10,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	localtls "github.com/lxc/incus/v7/shared/tls"
@@ -69,13 +70,23 @@ func registerProvisioningServerHandler(
 	// authentication and authorization is performed for these requests.
 	router.HandleFunc("POST /:self_register", response.With(handler.serverPostSelfRegister))
 
+	// The install image for the installation of a server via its BMC is fetched
+	// by the BMC, which is not able to authenticate. The URL is only valid,
+	// while the installation is in progress, and contains the ID of the
+	// installation as secret. Therefore no authentication and authorization is
+	// performed for these requests.
+	router.HandleFunc("GET /{name}/bmc/install-media/{id}/{filename}", response.With(handler.serverBMCInstallMediaGet))
+
 	router.HandleFunc("GET /{$}", response.With(handler.serversGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("POST /:bulk-action", response.With(handler.serversBulkActionPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("GET /{name}", response.With(handler.serverGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("PUT /{name}", response.With(handler.serverPut, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("DELETE /{name}", response.With(handler.serverDelete, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
 	router.HandleFunc("POST /{name}", response.With(handler.serverPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("POST /{name}/:decommission", response.With(handler.serverDecommissionPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
 	router.HandleFunc("POST /{name}/:resync", response.With(handler.serverResyncPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/bmc/:dump", response.With(handler.serverBMCDumpPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("POST /{name}/bmc/:install", response.With(handler.serverBMCInstallPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/bmc/:refresh", response.With(handler.serverBMCRefreshPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/bmc/:server-power-on", response.With(handler.serverBMCServerPowerOnPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/bmc/:server-power-off", response.With(handler.serverBMCServerPowerOffPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
@@ -84,9 +95,13 @@ func registerProvisioningServerHandler(
 	router.HandleFunc("POST /{name}/bmc/:apply-bios-attributes", response.With(handler.serverBMCApplyBIOSAttributesPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("GET /{name}/bmc/bios-attributes", response.With(handler.serverBMCBIOSAttributesGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("GET /{name}/bmc/bios-attributes/{attributeName...}", response.With(handler.serverBMCBIOSAttributeGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/bmc/firmware", response.With(handler.serverBMCFirmwareGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("POST /{name}/bmc/firmware/:update", response.With(handler.serverBMCFirmwareUpdatePost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("GET /{name}/bmc/logs", response.With(handler.serverBMCLogSourcesGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("GET /{name}/bmc/logs/{logSource...}", response.With(handler.serverBMCLogEntriesGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("GET /{name}/changelog", response.With(handler.serverChangelogGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/hardware/history", response.With(handler.serverHardwareHistoryGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("GET /{name}/disk-health", response.With(handler.serverDiskHealthGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
 	router.HandleFunc("/{name}/os", response.With(handler.serverOSProxy, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("/{name}/os/", response.With(handler.serverOSProxy, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
 	router.HandleFunc("POST /{name}/system/:evacuate", response.With(handler.serverSystemEvacuatePost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
@@ -128,6 +143,11 @@ func registerProvisioningServerHandler(
 //	    description: Filter expression
 //	    type: string
 //	    x-example: name == "value"
+//	  - in: query
+//	    name: site
+//	    description: Site name
+//	    type: string
+//	    x-example: site
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/URLsResponse"
@@ -161,6 +181,11 @@ func registerProvisioningServerHandler(
 //	    description: Filter expression
 //	    type: string
 //	    x-example: name == "value"
+//	  - in: query
+//	    name: site
+//	    description: Site name
+//	    type: string
+//	    x-example: site
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/ServersResponse"
@@ -181,6 +206,10 @@ func (s *serverHandler) serversGet(r *http.Request) response.Response {
 		filter.Cluster = ptr.To(r.URL.Query().Get("cluster"))
 	}
 
+	if r.URL.Query().Get("site") != "" {
+		filter.Site = ptr.To(r.URL.Query().Get("site"))
+	}
+
 	if r.URL.Query().Get("status") != "" {
 		var status api.ServerStatus
 		err = status.UnmarshalText([]byte(r.URL.Query().Get("status")))
@@ -212,6 +241,7 @@ func (s *serverHandler) serversGet(r *http.Request) response.Response {
 					ServerPut: api.ServerPut{
 						PublicConnectionURL: server.PublicConnectionURL,
 						Channel:             server.Channel,
+						Site:                ptr.From(server.Site),
 						Description:         server.Description,
 						Properties:          server.Properties,
 						BMCConfig:           server.BMCConfig,
@@ -226,6 +256,8 @@ func (s *serverHandler) serversGet(r *http.Request) response.Response {
 				VersionData:          server.VersionData,
 				Status:               server.Status,
 				StatusDetail:         server.StatusDetail,
+				ClusterJoin:          server.ClusterJoin,
+				BMCInstall:           server.BMCInstall,
 				BMCData:              server.BMCData,
 				LastUpdated:          server.LastUpdated,
 				LastSeen:             server.LastSeen,
@@ -381,6 +413,7 @@ func (s *serverHandler) serversPostPreRegister(r *http.Request) response.Respons
 		Properties:          server.Properties,
 		PublicConnectionURL: server.PublicConnectionURL,
 		Channel:             server.Channel,
+		Site:                siteFromAPI(server.Site),
 		BMCConfig:           server.BMCConfig,
 	})
 	if err != nil {
@@ -437,6 +470,7 @@ func (s *serverHandler) serverGet(r *http.Request) response.Response {
 				ServerPut: api.ServerPut{
 					PublicConnectionURL: server.PublicConnectionURL,
 					Channel:             server.Channel,
+					Site:                ptr.From(server.Site),
 					Description:         server.Description,
 					Properties:          server.Properties,
 					BMCConfig:           server.BMCConfig,
@@ -451,6 +485,8 @@ func (s *serverHandler) serverGet(r *http.Request) response.Response {
 			VersionData:          server.VersionData,
 			Status:               server.Status,
 			StatusDetail:         server.StatusDetail,
+			ClusterJoin:          server.ClusterJoin,
+			BMCInstall:           server.BMCInstall,
 			BMCData:              server.BMCData,
 			LastUpdated:          server.LastUpdated,
 			LastSeen:             server.LastSeen,
@@ -530,14 +566,15 @@ func (s *serverHandler) serverPut(r *http.Request) response.Response {
 	currentServer.Properties = server.Properties
 	currentServer.BMCConfig = server.BMCConfig
 
-	// Only allow changing of Channel, if server is not clustered. Otherwise
-	// the change of the channel needs to happen through the cluster.
+	// Only allow changing of Channel and Site, if server is not clustered.
+	// Otherwise the change needs to happen through the cluster.
 	var updateServer bool
 	if currentServer.Cluster == nil {
 		// Only trigger update of server, when the channel, the server is following,
 		// has changed.
 		updateServer = currentServer.Channel != server.Channel
 		currentServer.Channel = server.Channel
+		currentServer.Site = siteFromAPI(server.Site)
 	}
 
 	err = s.service.Update(ctx, *currentServer, false, updateServer, true)
@@ -671,6 +708,123 @@ func (s *serverHandler) serverPostSelfRegister(r *http.Request) response.Respons
 	return response.EmptySyncResponse
 }
 
+// swagger:operation POST /1.0/provisioning/servers/:bulk-action servers servers_bulk_action_post
+//
+//	Bulk action on servers
+//
+//	Performs an action on all the servers matching the filter expression. The
+//	number of servers, the action is performed on at the same time, is limited
+//	by the concurrency. Reboots and updates are only permitted for standalone
+//	servers, cluster members need to be rebooted and updated by the cluster
+//	wide operations. The action is completed, even if the client disconnects.
+//	With dry run, only the servers selected by the filter are listed. The
+//	result of the action is reported for each server.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: dry-run
+//	    description: |-
+//	      Boolean indicating, if only the servers selected by the filter should
+//	      be listed without performing the action on them.
+//	      Defaults to false.
+//	      The parameter without a value is treated as true.
+//	    type: boolean
+//	    x-example: true
+//	  - in: body
+//	    name: server_bulk_action_post
+//	    description: Server bulk action request with filter, action and arguments.
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ServerBulkActionPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerBulkActionResultsResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serversBulkActionPost(r *http.Request) response.Response {
+	var request api.ServerBulkActionPost
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Request decoding: %v", err))
+	}
+
+	dryRun, err := dryRunQueryParam(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	action := provisioning.ServerBulkAction{
+		Filter:      request.Filter,
+		Action:      request.Action,
+		Concurrency: request.Concurrency,
+		DryRun:      dryRun,
+	}
+
+	unmarshalArguments := func(v any) error {
+		if request.Arguments == nil {
+			return nil
+		}
+
+		return json.Unmarshal(*request.Arguments, v)
+	}
+
+	switch request.Action {
+	case api.ServerBulkActionReboot:
+		var reboot struct {
+			Force bool `json:"force"`
+		}
+
+		err = unmarshalArguments(&reboot)
+		action.Force = reboot.Force
+
+	case api.ServerBulkActionUpdate:
+		var update struct {
+			api.ServerUpdatePost
+
+			Force bool `json:"force"`
+		}
+
+		err = unmarshalArguments(&update)
+		action.UpdateRequest = update.ServerUpdatePost
+		action.Force = update.Force
+
+	case api.ServerBulkActionUpdateSystemLogging:
+		err = unmarshalArguments(&action.LoggingConfig)
+
+	case api.ServerBulkActionAddApplication:
+		var addApplication struct {
+			Name string `json:"name"`
+		}
+
+		err = unmarshalArguments(&addApplication)
+		action.ApplicationName = addApplication.Name
+
+	default:
+		return response.BadRequest(fmt.Errorf("Invalid action %q", request.Action))
+	}
+
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid arguments for action %q: %v", request.Action, err))
+	}
+
+	results, err := s.service.BulkAction(r.Context(), action)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to perform bulk action %q on servers: %w", request.Action, err))
+	}
+
+	return response.SyncResponse(true, results)
+}
+
 // swagger:operation DELETE /1.0/provisioning/servers/{name} servers server_delete
 //
 //	Delete the server
@@ -1123,6 +1277,130 @@ func (s *serverHandler) serverBMCApplyBIOSAttributesPost(r *http.Request) respon
 	return response.EmptySyncResponse
 }
 
+// swagger:operation POST /1.0/provisioning/servers/{name}/bmc/:install servers_bmc server_bmc_install_post
+//
+//	Install IncusOS via BMC
+//
+//	Installs IncusOS on the unregistered server using the virtual media of its
+//	BMC. The install image is pre-seeded with the given token seed and served
+//	by Operations Center. The installation is performed in the background, the
+//	progress is reported in the bmc_install property of the server.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: install
+//	    description: Installation via BMC
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ServerBMCInstallPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverBMCInstallPost(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var req api.ServerBMCInstallPost
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Request decoding: %v", err))
+	}
+
+	err = s.service.BMCInstallByName(r.Context(), name, req)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to install server %q via BMC: %w", name, err))
+	}
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/provisioning/servers/{name}/bmc/install-media/{id}/{filename} servers_bmc server_bmc_install_media_get
+//
+//	Get the install image for the installation via BMC
+//
+//	Returns the install image, the BMC of the server boots from during the
+//	installation via BMC. The image is only available, while the installation
+//	is in progress and until the media has expired. The media expires one
+//	hour after the installation has been initiated or as soon as the image
+//	has been downloaded completely.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	  - in: path
+//	    name: id
+//	    description: ID of the installation
+//	    type: string
+//	    format: uuid
+//	    required: true
+//	  - in: path
+//	    name: filename
+//	    description: File name of the install image, the value is ignored
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Install image
+//	    schema:
+//	      type: file
+//	  "206":
+//	    description: Partial content of the install image
+//	    schema:
+//	      type: file
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverBMCInstallMediaGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid installation ID %q: %w", r.PathValue("id"), err))
+	}
+
+	media, err := s.service.GetBMCInstallMediaByName(r.Context(), name, id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The BMC might fetch the image in chunks using range requests, which are
+	// handled by http.ServeContent.
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		defer media.Close()
+
+		http.ServeContent(w, r, r.PathValue("filename"), time.Time{}, media)
+
+		return nil
+	})
+}
+
 // swagger:operation GET /1.0/provisioning/servers/{name}/bmc/bios-attributes servers_bmc server_bmc_bios_attributes_get
 //
 //	Get the BIOS attributes known to the BMC
@@ -1205,6 +1483,102 @@ func (s *serverHandler) serverBMCBIOSAttributeGet(r *http.Request) response.Resp
 	return response.SyncResponse(true, values)
 }
 
+// swagger:operation GET /1.0/provisioning/servers/{name}/bmc/firmware servers_bmc server_bmc_firmware_get
+//
+//	Get the firmware inventory of the server
+//
+//	Returns the firmware inventory (e.g. BIOS, BMC, NIC and RAID controller)
+//	as reported by the update service of the server's BMC, keyed by the ID of
+//	the inventory entry.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerBMCFirmwareResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverBMCFirmwareGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	firmware, err := s.service.BMCFirmwareByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to get firmware inventory of server %q: %w", name, err))
+	}
+
+	return response.SyncResponse(true, firmware)
+}
+
+// swagger:operation POST /1.0/provisioning/servers/{name}/bmc/firmware/:update servers_bmc server_bmc_firmware_update_post
+//
+//	Update the firmware of the server
+//
+//	Updates the firmware of the server using the update service of its BMC.
+//	The update is performed in the background, the firmware inventory of the
+//	server is refreshed once the BMC reports the update as completed.
+//
+//	The firmware of cluster members can not be updated individually, use the
+//	rolling firmware update of the cluster instead.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: update
+//	    description: Firmware update
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/BMCFirmwareUpdatePost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverBMCFirmwareUpdatePost(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var req api.BMCFirmwareUpdatePost
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Request decoding: %v", err))
+	}
+
+	err = s.service.BMCFirmwareUpdateByName(r.Context(), name, req, false)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to update firmware of server %q via BMC: %w", name, err))
+	}
+
+	return response.EmptySyncResponse
+}
+
 // swagger:operation GET /1.0/provisioning/servers/{name}/bmc/logs servers_bmc server_bmc_logs_get
 //
 //	Get the available BMC log sources
@@ -1406,6 +1780,152 @@ func (s *serverHandler) serverChangelogGet(r *http.Request) response.Response {
 	)
 }
 
+// swagger:operation GET /1.0/provisioning/servers/{name}/hardware/history servers server_hardware_history_get
+//
+//	Get the server's hardware history
+//
+//	Gets the recorded hardware snapshots of a specific server, including the
+//	hardware changes compared to the respective previous snapshot.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerHardwareSnapshotsResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverHardwareHistoryGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	snapshots, err := s.service.GetHardwareHistoryByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.ServerHardwareSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, api.ServerHardwareSnapshot{
+			HardwareData: snapshot.HardwareData,
+			Changes:      snapshot.Changes,
+			CreatedAt:    snapshot.CreatedAt,
+		})
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation POST /1.0/provisioning/servers/{name}/:decommission servers server_decommission_post
+//
+//	Decommission the server
+//
+//	Retires the server by performing the following steps in order: evacuate,
+//	remove from cluster, factory reset, power off via BMC, erase the disks via
+//	BMC and remove from the inventory. Each of the steps is optional. The
+//	steps are performed in the background and the initiated decommission
+//	record is returned immediately. The progress is recorded in the
+//	decommission record, which is kept after the server has been removed and
+//	can be followed through the decommissions of the server. A failed
+//	decommission can be resumed.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: decommission
+//	    description: Decommission options
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ServerDecommissionPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerDecommissionResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverDecommissionPost(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var req api.ServerDecommissionPost
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Request decoding: %v", err))
+	}
+
+	decommission, err := s.service.DecommissionByName(r.Context(), name, req.ServerDecommissionOptions, req.ConfirmDiskWipe, req.Resume)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to decommission server %q: %w", name, err))
+	}
+
+	return response.SyncResponse(true, toAPIServerDecommission(*decommission))
+}
+
+// swagger:operation GET /1.0/provisioning/servers/{name}/disk-health servers server_disk_health_get
+//
+//	Get the server's disk health
+//
+//	Gets the evaluated health of the disks of a specific server, including the
+//	recorded history of the health attributes of each disk.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the server
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/ServerDiskHealthsResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *serverHandler) serverDiskHealthGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	diskHealth, err := s.service.GetDiskHealthByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, diskHealth)
+}
+
 func (s *serverHandler) serverOSProxy(r *http.Request) response.Response {
 	name := r.PathValue("name")
 
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/security/authz"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/response"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type siteHandler struct {
+	service provisioning.SiteService
+}
+
+func registerProvisioningSiteHandler(router Router, authorizer *authz.Authorizer, service provisioning.SiteService) {
+	handler := &siteHandler{
+		service: service,
+	}
+
+	router.HandleFunc("GET /{$}", response.With(handler.sitesGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("POST /{$}", response.With(handler.sitesPost, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanCreate)))
+	router.HandleFunc("GET /{name}", response.With(handler.siteGet, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanView)))
+	router.HandleFunc("PUT /{name}", response.With(handler.sitePut, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanEdit)))
+	router.HandleFunc("DELETE /{name}", response.With(handler.siteDelete, assertPermission(authorizer, authz.ObjectTypeServer, authz.EntitlementCanDelete)))
+}
+
+// swagger:operation GET /1.0/provisioning/sites sites sites_get
+//
+//	Get the sites
+//
+//	Returns a list of sites (URLs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/URLsResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/provisioning/sites?recursion=1 sites sites_get_recursion
+//
+//	Get the sites
+//
+//	Returns a list of sites (structs) including their aggregated status.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/SitesResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *siteHandler) sitesGet(r *http.Request) response.Response {
+	// Parse the recursion field.
+	recursion, err := strconv.Atoi(r.FormValue("recursion"))
+	if err != nil {
+		recursion = 0
+	}
+
+	if recursion == 1 {
+		sites, err := s.service.GetAll(r.Context())
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		result := make([]api.Site, 0, len(sites))
+		for _, site := range sites {
+			status, err := s.service.GetStatusByName(r.Context(), site.Name)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			result = append(result, toAPISite(site, status))
+		}
+
+		return response.SyncResponse(true, result)
+	}
+
+	siteNames, err := s.service.GetAllNames(r.Context())
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]string, 0, len(siteNames))
+	for _, name := range siteNames {
+		result = append(result, fmt.Sprintf("/%s/provisioning/sites/%s", api.APIVersion, name))
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation POST /1.0/provisioning/sites sites sites_post
+//
+//	Add a site
+//
+//	Creates a new site. Clusters and standalone servers can be assigned to
+//	the site afterwards.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: site
+//	    description: Site configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/SitePost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *siteHandler) sitesPost(r *http.Request) response.Response {
+	var site api.SitePost
+
+	// Decode into the new site.
+	err := json.NewDecoder(r.Body).Decode(&site)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	_, err = s.service.Create(r.Context(), provisioning.Site{
+		Name:        site.Name,
+		Description: site.Description,
+		Channel:     site.Channel,
+		Properties:  site.Properties,
+		Config:      site.Config,
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating site: %w", err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/sites/"+site.Name)
+}
+
+// swagger:operation GET /1.0/provisioning/sites/{name} sites site_get
+//
+//	Get the site
+//
+//	Gets a specific site including its aggregated status.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the site
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/SiteResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *siteHandler) siteGet(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	site, err := s.service.GetByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	status, err := s.service.GetStatusByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(
+		true,
+		toAPISite(*site, status),
+		site,
+	)
+}
+
+// swagger:operation PUT /1.0/provisioning/sites/{name} sites site_put
+//
+//	Update the site
+//
+//	Updates the site definition.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the site
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: site
+//	    description: Site definition
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/SitePut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *siteHandler) sitePut(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	var site api.SitePut
+
+	err := json.NewDecoder(r.Body).Decode(&site)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	ctx, trans := transaction.Begin(r.Context())
+	defer func() {
+		rollbackErr := trans.Rollback()
+		if rollbackErr != nil {
+			response.SmartError(fmt.Errorf("Transaction rollback failed: %v, reason: %w", rollbackErr, err))
+		}
+	}()
+
+	currentSite, err := s.service.GetByName(ctx, name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to get site %q: %w", name, err))
+	}
+
+	// Validate ETag
+	err = response.EtagCheck(r, currentSite)
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	currentSite.Description = site.Description
+	currentSite.Channel = site.Channel
+	currentSite.Properties = site.Properties
+	currentSite.Config = site.Config
+
+	err = s.service.Update(ctx, *currentSite)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed updating site %q: %w", name, err))
+	}
+
+	err = trans.Commit()
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed commit transaction: %w", err))
+	}
+
+	return response.SyncResponseLocation(true, nil, "/"+api.APIVersion+"/provisioning/sites/"+name)
+}
+
+// swagger:operation DELETE /1.0/provisioning/sites/{name} sites site_delete
+//
+//	Delete the site
+//
+//	Removes the site. A site can only be removed, if no clusters or servers
+//	are assigned to it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: path
+//	    name: name
+//	    description: Name of the site
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func (s *siteHandler) siteDelete(r *http.Request) response.Response {
+	name := r.PathValue("name")
+
+	err := s.service.DeleteByName(r.Context(), name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+func toAPISite(site provisioning.Site, status api.SiteStatus) api.Site {
+	return api.Site{
+		SitePost: api.SitePost{
+			Name: site.Name,
+			SitePut: api.SitePut{
+				Description: site.Description,
+				Channel:     site.Channel,
+				Properties:  site.Properties,
+				Config:      site.Config,
+			},
+		},
+		Status:      status,
+		LastUpdated: site.LastUpdated,
+	}
+}
+
+// siteFromAPI converts the site name as used in the API, where an empty
+// string signals, that no site is assigned, to the site reference of the
+// provisioning models.
+func siteFromAPI(site string) *string {
+	if site == "" {
+		return nil
+	}
+
+	return &site
+}
@@ -101,6 +101,7 @@ func (t *tokenHandler) tokensGet(r *http.Request) response.Response {
 					ExpireAt:      token.ExpireAt,
 					Description:   token.Description,
 					Channel:       token.Channel,
+					ClusterJoin:   token.ClusterJoin,
 				},
 			})
 		}
@@ -162,6 +163,7 @@ func (t *tokenHandler) tokensPost(r *http.Request) response.Response {
 		ExpireAt:      token.ExpireAt,
 		Description:   token.Description,
 		Channel:       token.Channel,
+		ClusterJoin:   token.ClusterJoin,
 	})
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed creating token: %w", err))
@@ -217,6 +219,7 @@ func (t *tokenHandler) tokenGet(r *http.Request) response.Response {
 				ExpireAt:      token.ExpireAt,
 				Description:   token.Description,
 				Channel:       token.Channel,
+				ClusterJoin:   token.ClusterJoin,
 			},
 		},
 		token,
@@ -300,6 +303,7 @@ func (t *tokenHandler) tokenPut(r *http.Request) response.Response {
 		ExpireAt:      token.ExpireAt,
 		Description:   token.Description,
 		Channel:       token.Channel,
+		ClusterJoin:   token.ClusterJoin,
 	})
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed updating token %q: %w", UUID.String(), err))
@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -23,12 +24,16 @@ import (
 )
 
 type updateHandler struct {
-	service provisioning.UpdateService
+	service    provisioning.UpdateService
+	serverSvc  provisioning.ServerService
+	rolloutSvc provisioning.RolloutService
 }
 
-func registerUpdateHandler(router Router, authorizer *authz.Authorizer, service provisioning.UpdateService) {
+func registerUpdateHandler(router Router, authorizer *authz.Authorizer, service provisioning.UpdateService, serverSvc provisioning.ServerService, rolloutSvc provisioning.RolloutService) {
 	handler := &updateHandler{
-		service: service,
+		service:    service,
+		serverSvc:  serverSvc,
+		rolloutSvc: rolloutSvc,
 	}
 
 	// no authentication required for all GET routes
@@ -51,6 +56,9 @@ func registerUpdateHandler(router Router, authorizer *authz.Authorizer, service
 //
 //	Returns a list of updates (URLs) sorted by version.
 //	Versions are date strings of the form yyyymmddHHMM, most recent date first.
+//	If the request is made by a server, whose cluster is part of a running
+//	rollout, only the updates up to the target version of the rollout are
+//	returned.
 //
 //	---
 //	produces:
@@ -83,6 +91,9 @@ func registerUpdateHandler(router Router, authorizer *authz.Authorizer, service
 //
 //	Returns a list of updates (structs) sorted by version.
 //	Versions are date strings of the form yyyymmddHHMM, most recent date first.
+//	If the request is made by a server, whose cluster is part of a running
+//	rollout, only the updates up to the target version of the rollout are
+//	returned.
 //
 //	---
 //	produces:
@@ -135,6 +146,15 @@ func (u *updateHandler) updatesGet(r *http.Request) response.Response {
 		filter.Status = &status
 	}
 
+	maxVersion, err := u.rolloutTargetVersion(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if maxVersion != "" {
+		filter.MaxVersion = &maxVersion
+	}
+
 	if recursion == 1 {
 		updates, err := u.service.GetAllWithFilter(r.Context(), filter)
 		if err != nil {
@@ -182,6 +202,35 @@ func (u *updateHandler) updatesGet(r *http.Request) response.Response {
 	return response.SyncResponse(true, result)
 }
 
+// rolloutTargetVersion returns the target version of the running rollout, the
+// cluster of the requesting server is part of. If the request is not made by
+// a known server or the cluster of the server is not part of a running
+// rollout, an empty string is returned.
+func (u *updateHandler) rolloutTargetVersion(r *http.Request) (string, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return "", nil
+	}
+
+	// Encode client certificate in pem format
+	certificate := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: r.TLS.PeerCertificates[0].Raw,
+	}))
+
+	servers, err := u.serverSvc.GetAllWithFilter(r.Context(), provisioning.ServerFilter{
+		Certificate: &certificate,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to get server by certificate: %w", err)
+	}
+
+	if len(servers) == 0 || servers[0].Cluster == nil {
+		return "", nil
+	}
+
+	return u.rolloutSvc.GetTargetVersionByCluster(r.Context(), *servers[0].Cluster)
+}
+
 // swagger:operation POST /1.0/provisioning/updates updates updates_post
 //
 //	Add a update
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 
+	"github.com/FuturFusion/operations-center/internal/provisioning"
 	"github.com/FuturFusion/operations-center/internal/security/authz"
 	"github.com/FuturFusion/operations-center/internal/sql/transaction"
 	"github.com/FuturFusion/operations-center/internal/util/response"
@@ -16,12 +18,14 @@ import (
 
 type warningHandler struct {
 	service    warning.WarningService
+	siteSvc    provisioning.SiteService
 	authorizer *authz.Authorizer
 }
 
-func registerWarningHandler(router Router, authorizer *authz.Authorizer, service warning.WarningService) {
+func registerWarningHandler(router Router, authorizer *authz.Authorizer, service warning.WarningService, siteSvc provisioning.SiteService) {
 	handler := &warningHandler{
 		service:    service,
+		siteSvc:    siteSvc,
 		authorizer: authorizer,
 	}
 
@@ -40,6 +44,14 @@ func registerWarningHandler(router Router, authorizer *authz.Authorizer, service
 //	---
 //	produces:
 //	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: site
+//	    description: |-
+//	      Site name, limits the warnings to the ones related to the clusters and
+//	      servers belonging to the site
+//	    type: string
+//	    x-example: site
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/WarningsResponse"
@@ -53,8 +65,26 @@ func (t *warningHandler) warningsGet(r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	var siteEntities map[string][]string
+	site := r.URL.Query().Get("site")
+	if site != "" {
+		members, err := t.siteSvc.GetMembersByName(r.Context(), site)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed to get members of site %q: %w", site, err))
+		}
+
+		siteEntities = map[string][]string{
+			"cluster": members.Clusters,
+			"server":  members.Servers,
+		}
+	}
+
 	result := make([]api.Warning, 0, len(warnings))
 	for _, warn := range warnings {
+		if siteEntities != nil && !slices.Contains(siteEntities[warn.EntityType], warn.Entity) {
+			continue
+		}
+
 		result = append(result, api.Warning{
 			UUID: warn.UUID,
 			Scope: api.WarningScope{
@@ -50,12 +50,14 @@ import (
 	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/bmc/redfish"
 	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/flasher"
 	provisioningIncusAdapter "github.com/FuturFusion/operations-center/internal/provisioning/adapter/incus"
+	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/incusprovisioner"
 	provisioningAdapterMiddleware "github.com/FuturFusion/operations-center/internal/provisioning/adapter/middleware"
 	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/scriptlet"
 	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/terraform"
 	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/updateserver"
 	provisioningChannel "github.com/FuturFusion/operations-center/internal/provisioning/channel"
 	provisioningCluster "github.com/FuturFusion/operations-center/internal/provisioning/cluster"
+	provisioningClusterBlueprint "github.com/FuturFusion/operations-center/internal/provisioning/cluster_blueprint"
 	provisioningClusterTemplate "github.com/FuturFusion/operations-center/internal/provisioning/cluster_template"
 	provisioningServiceMiddleware "github.com/FuturFusion/operations-center/internal/provisioning/middleware"
 	provisioningClusterArtifactRepo "github.com/FuturFusion/operations-center/internal/provisioning/repo/localartifact"
@@ -64,7 +66,9 @@ import (
 	provisioningRepoMiddleware "github.com/FuturFusion/operations-center/internal/provisioning/repo/middleware"
 	provisioningSqlite "github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite"
 	provisioningEntities "github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	provisioningRollout "github.com/FuturFusion/operations-center/internal/provisioning/rollout"
 	provisioningServer "github.com/FuturFusion/operations-center/internal/provisioning/server"
+	provisioningSite "github.com/FuturFusion/operations-center/internal/provisioning/site"
 	provisioningToken "github.com/FuturFusion/operations-center/internal/provisioning/token"
 	provisioningUpdate "github.com/FuturFusion/operations-center/internal/provisioning/update"
 	"github.com/FuturFusion/operations-center/internal/security/authn"
@@ -245,17 +249,6 @@ func (d *Daemon) Start(ctx context.Context) error {
 		d.env,
 	)
 
-	loader := incusScriptlet.NewLoader()
-	runner, err := scriptlet.New(
-		loader,
-		provisioningAdapterMiddleware.NewScriptletClientPortWithSlog(
-			client,
-		),
-	)
-	if err != nil {
-		return err
-	}
-
 	// Setup Services
 	incusImageSvc, err := d.setupIncusImageService(dbWithTransaction)
 	if err != nil {
@@ -272,6 +265,18 @@ func (d *Daemon) Start(ctx context.Context) error {
 	// warningLogEmitter := provisioning.LogWarningService{}
 	warningLogEmitter := warningSvc
 
+	loader := incusScriptlet.NewLoader()
+	runner, err := scriptlet.New(
+		loader,
+		provisioningAdapterMiddleware.NewScriptletClientPortWithSlog(
+			client,
+		),
+		warningSvc,
+	)
+	if err != nil {
+		return err
+	}
+
 	inventoryInventoryAggregateSvc := inventoryServiceMiddleware.NewInventoryAggregateServiceWithSlog(
 		inventory.NewInventoryAggregateService(
 			inventoryRepoMiddleware.NewInventoryAggregateRepoWithSlog(
@@ -303,16 +308,23 @@ func (d *Daemon) Start(ctx context.Context) error {
 	channelSvc := d.setupChannelService(dbWithTransaction, updateSvc)
 
 	tokenSvc := d.setupTokenService(dbWithTransaction, client, updateSvc, channelSvc)
-	serverSvc := d.setupServerService(dbWithTransaction, client, runner, tokenSvc, nil, channelSvc, updateSvc, warningLogEmitter)
-	clusterSvc, err := d.setupClusterService(dbWithTransaction, client, serverSvc, tokenSvc, inventoryInventoryAggregateSvc)
+	serverSvc := d.setupServerService(ctx, dbWithTransaction, client, runner, tokenSvc, nil, channelSvc, updateSvc, warningLogEmitter)
+	siteSvc := d.setupSiteService(dbWithTransaction, serverSvc, warningSvc)
+	clusterTemplateSvc := d.setupClusterTemplateService(dbWithTransaction)
+	clusterSvc, err := d.setupClusterService(dbWithTransaction, client, runner, serverSvc, tokenSvc, inventoryInventoryAggregateSvc, updateSvc, clusterTemplateSvc, siteSvc, warningLogEmitter)
 	if err != nil {
 		return err
 	}
 
 	updateSvc.SetServerService(serverSvc)
 	channelSvc.SetServerService(serverSvc)
+	tokenSvc.SetClusterService(clusterSvc)
 	serverSvc.SetClusterService(clusterSvc)
-	clusterTemplateSvc := d.setupClusterTemplateService(dbWithTransaction)
+	serverSvc.SetSiteService(siteSvc)
+	siteSvc.SetClusterService(clusterSvc)
+	rolloutSvc := d.setupRolloutService(dbWithTransaction, clusterSvc, updateSvc)
+	serverSvc.SetRolloutService(rolloutSvc)
+	clusterBlueprintSvc := d.setupClusterBlueprintService(ctx, dbWithTransaction, clusterSvc, clusterTemplateSvc, serverSvc)
 
 	d.systemSvc = d.setupSystemService(serverSvc)
 
@@ -323,7 +335,10 @@ func (d *Daemon) Start(ctx context.Context) error {
 		serverSvc,
 		clusterSvc,
 		clusterTemplateSvc,
+		clusterBlueprintSvc,
+		rolloutSvc,
 		channelSvc,
+		siteSvc,
 		warningSvc,
 		inventoryInventoryAggregateSvc,
 		imageSourceSvc,
@@ -391,7 +406,7 @@ func (d *Daemon) Start(ctx context.Context) error {
 	}
 
 	// Background tasks
-	d.setupBackgroundTasks(ctx, updateSvc, imageSourceSvc, serverSvc, clusterSvc, warningLogEmitter)
+	d.setupBackgroundTasks(ctx, updateSvc, imageSourceSvc, serverSvc, clusterSvc, rolloutSvc, clusterBlueprintSvc, warningLogEmitter)
 
 	// Finalize daemon start
 	// Wait for immediate errors during startup.
@@ -723,6 +738,7 @@ func (d *Daemon) setupTokenService(db dbdriver.DBTX, client provisioning.TokenCl
 }
 
 func (d *Daemon) setupServerService(
+	ctx context.Context,
 	db dbdriver.DBTX,
 	client provisioning.ServerClientPort,
 	runner scriptlet.Runner,
@@ -772,6 +788,7 @@ func (d *Daemon) setupServerService(
 		updateSvc,
 		d.serverCertificate,
 		provisioningServer.WithWarningEmitter(warningSvc),
+		provisioningServer.WithBMCInstallMediaDir(filepath.Join(d.env.VarDir(), "bmc-install")),
 		provisioningServer.AddBMCServerClient(
 			api.BMCAPITypeRedfishV1Generic,
 			provisioningAdapterMiddleware.NewBMCServerClientPortWithSlog(
@@ -780,6 +797,11 @@ func (d *Daemon) setupServerService(
 		),
 	)
 
+	err := serverSvc.Prune(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to prune interrupted server operations", logger.Err(err))
+	}
+
 	// Server service needs to learn about updates of the public Operations Center
 	// address.
 	lifecycle.NetworkUpdateSignal.AddListener(func(ctx context.Context, cfg apisystem.Network) {
@@ -813,9 +835,14 @@ func (d *Daemon) setupServerService(
 func (d *Daemon) setupClusterService(
 	db dbdriver.DBTX,
 	client provisioning.ClusterClientPort,
+	runner scriptlet.Runner,
 	serverSvc provisioning.ServerService,
 	tokenSvc provisioning.TokenService,
 	inventoryAggregateSvc inventory.InventoryAggregateService,
+	updateSvc provisioning.UpdateService,
+	clusterTemplateSvc provisioning.ClusterTemplateService,
+	siteSvc provisioning.SiteService,
+	warningSvc provisioning.WarningServicePort,
 ) (provisioning.ClusterService, error) {
 	localClusterArtifactRepo, err := provisioningClusterArtifactRepo.New(db, filepath.Join(d.env.VarDir(), "artifacts"))
 	if err != nil {
@@ -836,6 +863,23 @@ func (d *Daemon) setupClusterService(
 		return nil, err
 	}
 
+	tmpIncusDir, err := os.MkdirTemp("", "operations-center-incus-*")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create temporary directory for incus provisioner: %w", err)
+	}
+
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return os.RemoveAll(tmpIncusDir)
+	})
+
+	incusProvisioner, err := incusprovisioner.New(
+		tmpIncusDir,
+		client,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return provisioningServiceMiddleware.NewClusterServiceWithSlog(
 		provisioningCluster.New(
 			provisioningRepoMiddleware.NewClusterRepoWithSlog(
@@ -863,6 +907,33 @@ func (d *Daemon) setupClusterService(
 			nil,
 			terraformProvisioner,
 			inventoryAggregateSvc,
+			provisioningCluster.WithUpdateService(updateSvc),
+			provisioningCluster.WithClusterTemplateService(clusterTemplateSvc),
+			provisioningCluster.WithSiteService(siteSvc),
+			provisioningCluster.WithWarningEmitter(warningSvc),
+			provisioningCluster.WithScriptlet(
+				provisioningAdapterMiddleware.NewClusterScriptletPortWithSlog(
+					runner,
+				),
+			),
+			provisioningCluster.WithOperationRepo(
+				provisioningRepoMiddleware.NewClusterOperationRepoWithSlog(
+					provisioningSqlite.NewClusterOperation(db),
+				),
+			),
+			provisioningCluster.WithClusterProvisioner(apisystem.ClusterProvisioningBackendIncus, incusProvisioner),
+			provisioningCluster.WithConfigurationDriftDetector(
+				apisystem.ClusterProvisioningBackendTerraform,
+				provisioningAdapterMiddleware.NewClusterProvisioningDriftPortWithSlog(
+					terraformProvisioner,
+				),
+			),
+			provisioningCluster.WithConfigurationDriftDetector(
+				apisystem.ClusterProvisioningBackendIncus,
+				provisioningAdapterMiddleware.NewClusterProvisioningDriftPortWithSlog(
+					incusProvisioner,
+				),
+			),
 		),
 		provisioningServiceMiddleware.ClusterServiceWithSlogWithInformativeErrFunc(
 			func(err error) bool {
@@ -897,6 +968,58 @@ func (d *Daemon) setupClusterTemplateService(db dbdriver.DBTX) provisioning.Clus
 	)
 }
 
+func (d *Daemon) setupRolloutService(db dbdriver.DBTX, clusterSvc provisioning.ClusterService, updateSvc provisioning.UpdateService) provisioning.RolloutService {
+	return provisioningServiceMiddleware.NewRolloutServiceWithSlog(
+		provisioningRollout.New(
+			provisioningRepoMiddleware.NewRolloutRepoWithSlog(
+				provisioningSqlite.NewRollout(db),
+			),
+			clusterSvc,
+			updateSvc,
+		),
+		provisioningServiceMiddleware.RolloutServiceWithSlogWithInformativeErrFunc(
+			func(err error) bool {
+				// Treat retryable errors as informational.
+				if domain.IsRetryableError(err) {
+					return true
+				}
+
+				return false
+			},
+		),
+	)
+}
+
+func (d *Daemon) setupClusterBlueprintService(ctx context.Context, db dbdriver.DBTX, clusterSvc provisioning.ClusterService, clusterTemplateSvc provisioning.ClusterTemplateService, serverSvc provisioning.ServerService) provisioning.ClusterBlueprintService {
+	clusterBlueprintSvc := provisioningServiceMiddleware.NewClusterBlueprintServiceWithSlog(
+		provisioningClusterBlueprint.New(
+			provisioningRepoMiddleware.NewClusterBlueprintRepoWithSlog(
+				provisioningSqlite.NewClusterBlueprint(db),
+			),
+			clusterSvc,
+			clusterTemplateSvc,
+			serverSvc,
+		),
+		provisioningServiceMiddleware.ClusterBlueprintServiceWithSlogWithInformativeErrFunc(
+			func(err error) bool {
+				// Treat retryable errors as informational.
+				if domain.IsRetryableError(err) {
+					return true
+				}
+
+				return false
+			},
+		),
+	)
+
+	err := clusterBlueprintSvc.Prune(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to prune interrupted cluster blueprints", logger.Err(err))
+	}
+
+	return clusterBlueprintSvc
+}
+
 func (d *Daemon) setupChannelService(db dbdriver.DBTX, updateSvc provisioning.UpdateService) provisioning.ChannelService {
 	return provisioningServiceMiddleware.NewChannelServiceWithSlog(
 		provisioningChannel.New(
@@ -918,6 +1041,28 @@ func (d *Daemon) setupChannelService(db dbdriver.DBTX, updateSvc provisioning.Up
 	)
 }
 
+func (d *Daemon) setupSiteService(db dbdriver.DBTX, serverSvc provisioning.ServerService, warningSvc provisioning.SiteWarningPort) provisioning.SiteService {
+	return provisioningServiceMiddleware.NewSiteServiceWithSlog(
+		provisioningSite.New(
+			provisioningRepoMiddleware.NewSiteRepoWithSlog(
+				provisioningSqlite.NewSite(db),
+			),
+			serverSvc,
+			warningSvc,
+		),
+		provisioningServiceMiddleware.SiteServiceWithSlogWithInformativeErrFunc(
+			func(err error) bool {
+				// Treat retryable errors as informational.
+				if domain.IsRetryableError(err) {
+					return true
+				}
+
+				return false
+			},
+		),
+	)
+}
+
 func (d *Daemon) setupSystemService(serverSvc provisioning.ServerService) system.SystemService {
 	return systemServiceMiddleware.NewSystemServiceWithSlog(
 		system.NewSystemService(d.env, serverSvc),
@@ -940,7 +1085,10 @@ func (d *Daemon) setupAPIRoutes(
 	serverSvc provisioning.ServerService,
 	clusterSvc provisioning.ClusterService,
 	clusterTemplateSvc provisioning.ClusterTemplateService,
+	clusterBlueprintSvc provisioning.ClusterBlueprintService,
+	rolloutSvc provisioning.RolloutService,
 	channelSvc provisioning.ChannelService,
+	siteSvc provisioning.SiteService,
 	warningSvc warning.WarningService,
 	inventoryInventoryAggregateSvc inventory.InventoryAggregateService,
 	imageSourceSvc image.IncusImageSourceService,
@@ -1017,6 +1165,13 @@ func (d *Daemon) setupAPIRoutes(
 			return false
 		}
 
+		// GET /1.0/provisioning/servers/{name}/bmc/install-media/{id}/{filename}
+		// is fetched by the BMC of the server, the ID of the installation acts
+		// as secret.
+		if r.Pattern == "GET /1.0/provisioning/servers/{name}/bmc/install-media/{id}/{filename}" {
+			return false
+		}
+
 		return true
 	}
 
@@ -1055,6 +1210,12 @@ func (d *Daemon) setupAPIRoutes(
 	provisioningClusterTemplateRouter := provisioningRouter.SubGroup("/cluster-templates")
 	registerProvisioningClusterTemplateHandler(provisioningClusterTemplateRouter, d.authorizer, clusterTemplateSvc)
 
+	provisioningClusterBlueprintRouter := provisioningRouter.SubGroup("/cluster-blueprints")
+	registerProvisioningClusterBlueprintHandler(provisioningClusterBlueprintRouter, d.authorizer, clusterBlueprintSvc)
+
+	provisioningRolloutRouter := provisioningRouter.SubGroup("/rollouts")
+	registerProvisioningRolloutHandler(provisioningRolloutRouter, d.authorizer, rolloutSvc)
+
 	provisioningServerRouter := provisioningRouter.SubGroup("/servers")
 	registerProvisioningServerHandler(
 		provisioningServerRouter,
@@ -1066,20 +1227,29 @@ func (d *Daemon) setupAPIRoutes(
 	)
 
 	provisioningUpdateRouter := provisioningRouter.SubGroup("/updates")
-	registerUpdateHandler(provisioningUpdateRouter, d.authorizer, updateSvc)
+	registerUpdateHandler(provisioningUpdateRouter, d.authorizer, updateSvc, serverSvc, rolloutSvc)
 
 	provisioningChannelRouter := provisioningRouter.SubGroup("/channels")
 	registerChannelsHandler(provisioningChannelRouter, d.authorizer, channelSvc)
 
+	provisioningSiteRouter := provisioningRouter.SubGroup("/sites")
+	registerProvisioningSiteHandler(provisioningSiteRouter, d.authorizer, siteSvc)
+
+	provisioningDiskHealthRouter := provisioningRouter.SubGroup("/disk-health")
+	registerProvisioningDiskHealthHandler(provisioningDiskHealthRouter, d.authorizer, serverSvc)
+
+	provisioningDecommissionRouter := provisioningRouter.SubGroup("/decommissions")
+	registerProvisioningDecommissionHandler(provisioningDecommissionRouter, d.authorizer, serverSvc)
+
 	systemRouter := api10router.SubGroup("/system")
 	registerSystemHandler(systemRouter, d.authorizer, d.systemSvc)
 
 	warningRouter := api10router.SubGroup("/warnings")
-	registerWarningHandler(warningRouter, d.authorizer, warningSvc)
+	registerWarningHandler(warningRouter, d.authorizer, warningSvc, siteSvc)
 
 	inventoryRouter := api10router.SubGroup("/inventory")
 
-	inventorySyncers := registerInventoryRoutes(db, clusterSvc, serverClientProvider, d.authorizer, inventoryRouter, inventoryInventoryAggregateSvc)
+	inventorySyncers := registerInventoryRoutes(db, clusterSvc, serverClientProvider, d.authorizer, inventoryRouter, inventoryInventoryAggregateSvc, siteSvc)
 
 	return serveMux, inventorySyncers
 }
@@ -1090,6 +1260,8 @@ func (d *Daemon) setupBackgroundTasks(
 	imageSourceSvc image.IncusImageSourceService,
 	serverSvc provisioning.ServerService,
 	clusterSvc provisioning.ClusterService,
+	rolloutSvc provisioning.RolloutService,
+	clusterBlueprintSvc provisioning.ClusterBlueprintService,
 	warningSvc warning.WarningEmitter,
 ) {
 	if config.IsBackgroundTasksDisabled() {
@@ -1210,6 +1382,120 @@ func (d *Daemon) setupBackgroundTasks(
 		return clusterUpdateControlLoopStop(deadlineFrom(ctx, 5*time.Second))
 	})
 
+	// Start background task to launch cluster updates automatically.
+	clusterAutoUpdateTask := func(ctx context.Context) {
+		slog.InfoContext(ctx, "Cluster auto update triggered")
+		err := clusterSvc.LaunchAutomaticClusterUpdates(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Cluster auto update failed", logger.Err(err))
+			return
+		}
+
+		slog.InfoContext(ctx, "Cluster auto update completed")
+	}
+
+	clusterAutoUpdateTaskStop, _ := task.Start(ctx, clusterAutoUpdateTask, task.Every(config.ClusterAutoUpdateInterval))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return clusterAutoUpdateTaskStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
+	// Start background task to detect drift of the cluster configuration.
+	clusterConfigurationDriftTask := func(ctx context.Context) {
+		slog.InfoContext(ctx, "Cluster configuration drift detection triggered")
+		err := clusterSvc.DetectConfigurationDrift(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Cluster configuration drift detection failed", logger.Err(err))
+			return
+		}
+
+		slog.InfoContext(ctx, "Cluster configuration drift detection completed")
+	}
+
+	clusterConfigurationDriftTaskStop, _ := task.Start(ctx, clusterConfigurationDriftTask, task.Every(config.ClusterConfigurationDriftCheckInterval, task.SkipFirst))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return clusterConfigurationDriftTaskStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
+	// Start background task to check the consistency of the cluster members.
+	clusterConsistencyTask := func(ctx context.Context) {
+		slog.InfoContext(ctx, "Cluster consistency check triggered")
+		err := clusterSvc.CheckConsistency(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Cluster consistency check failed", logger.Err(err))
+			return
+		}
+
+		slog.InfoContext(ctx, "Cluster consistency check completed")
+	}
+
+	clusterConsistencyTaskStop, _ := task.Start(ctx, clusterConsistencyTask, task.Every(config.ClusterConsistencyCheckInterval, task.SkipFirst))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return clusterConsistencyTaskStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
+	// Start background task for the rollout control loop.
+	rolloutControlLoop := func(ctx context.Context) {
+		slog.InfoContext(ctx, "Rollout control loop triggered")
+		err := rolloutSvc.RolloutControlLoop(ctx)
+		if err != nil {
+			logCtx := slog.ErrorContext
+			if domain.IsRetryableError(err) {
+				logCtx = slog.InfoContext
+			}
+
+			logCtx(ctx, "Rollout control loop failed", logger.Err(err))
+
+			return
+		}
+
+		slog.InfoContext(ctx, "Rollout control loop completed")
+	}
+
+	rolloutControlLoopStop, _ := task.Start(ctx, rolloutControlLoop, task.Every(config.PendingServerPollInterval))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return rolloutControlLoopStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
+	// Start background task for the cluster blueprint control loop.
+	clusterBlueprintControlLoop := func(ctx context.Context) {
+		slog.InfoContext(ctx, "Cluster blueprint control loop triggered")
+		err := clusterBlueprintSvc.ClusterBlueprintControlLoop(ctx)
+		if err != nil {
+			logCtx := slog.ErrorContext
+			if domain.IsRetryableError(err) {
+				logCtx = slog.InfoContext
+			}
+
+			logCtx(ctx, "Cluster blueprint control loop failed", logger.Err(err))
+
+			return
+		}
+
+		slog.InfoContext(ctx, "Cluster blueprint control loop completed")
+	}
+
+	clusterBlueprintControlLoopStop, _ := task.Start(ctx, clusterBlueprintControlLoop, task.Every(config.PendingServerPollInterval))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return clusterBlueprintControlLoopStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
+	// Start background task for the cluster join control loop.
+	clusterJoinControlLoop := func(ctx context.Context) {
+		slog.InfoContext(ctx, "Cluster join control loop triggered")
+		err := serverSvc.ClusterJoinControlLoop(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Cluster join control loop failed", logger.Err(err))
+			return
+		}
+
+		slog.InfoContext(ctx, "Cluster join control loop completed")
+	}
+
+	clusterJoinControlLoopStop, _ := task.Start(ctx, clusterJoinControlLoop, task.Every(config.PendingServerPollInterval))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return clusterJoinControlLoopStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
 	// Trigger ClusterUpdateControlLoop also from server lifecycle events.
 	lifecycle.ServerLifecycleSignal.AddListener(func(ctx context.Context, slm lifecycle.ServerLifecycleMessage) {
 		slog.InfoContext(ctx, "Server lifecycle event triggered", slog.String("server", slm.Server), slog.String("cluster", ptr.From(slm.Cluster)), slog.String("update_state", slm.ServerUpdateState.String()))
@@ -1380,6 +1666,21 @@ func (d *Daemon) setupBackgroundTasks(
 		return refreshBMCDataTaskStop(deadlineFrom(ctx, 10*time.Second))
 	})
 
+	// Start background task to remove install images, which are no longer
+	// served for an installation via BMC.
+	pruneBMCInstallMediaTask := func(ctx context.Context) {
+		err := serverSvc.PruneBMCInstallMedia(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Pruning of install images for installation via BMC failed", logger.Err(err))
+			return
+		}
+	}
+
+	pruneBMCInstallMediaTaskStop, _ := task.Start(ctx, pruneBMCInstallMediaTask, task.Every(config.BMCInstallMediaPruneInterval, task.SkipFirst))
+	d.shutdownFuncs = append(d.shutdownFuncs, func(ctx context.Context) error {
+		return pruneBMCInstallMediaTaskStop(deadlineFrom(ctx, 5*time.Second))
+	})
+
 	// Start background task to renew ACME server certificate.
 	renewACMEServerCertificateTask := func(ctx context.Context) {
 		slog.InfoContext(ctx, "ACME server certificate renewal triggered")
@@ -99,6 +99,39 @@ type swaggerClustersResponse struct {
 	}
 }
 
+// The plan of a cluster wide operation, only returned for dry-run requests
+//
+// swagger:response ClusterUpdatePlanResponse
+type swaggerClusterUpdatePlanResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterUpdatePlan `json:"metadata"`
+	}
+}
+
+// The plan of the changes of a cluster spec
+//
+// swagger:response ClusterSpecPlanResponse
+type swaggerClusterSpecPlanResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterSpecPlan `json:"metadata"`
+	}
+}
+
+// The history of the cluster wide operations
+//
+// swagger:response ClusterOperationsResponse
+type swaggerClusterOperationsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ClusterOperation `json:"metadata"`
+	}
+}
+
 // The cluster artifact
 //
 // swagger:response ClusterArtifactResponse
@@ -121,6 +154,28 @@ type swaggerClusterArtifactsResponse struct {
 	}
 }
 
+// The cluster blueprint
+//
+// swagger:response ClusterBlueprintResponse
+type swaggerClusterBlueprintResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterBlueprint `json:"metadata"`
+	}
+}
+
+// The cluster blueprints
+//
+// swagger:response ClusterBlueprintsResponse
+type swaggerClusterBlueprintsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ClusterBlueprint `json:"metadata"`
+	}
+}
+
 // The cluster template
 //
 // swagger:response ClusterTemplateResponse
@@ -143,6 +198,61 @@ type swaggerClusterTemplatesResponse struct {
 	}
 }
 
+// The revision of a cluster template
+//
+// swagger:response ClusterTemplateRevisionResponse
+type swaggerClusterTemplateRevisionResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterTemplateRevision `json:"metadata"`
+	}
+}
+
+// The revisions of a cluster template
+//
+// swagger:response ClusterTemplateRevisionsResponse
+type swaggerClusterTemplateRevisionsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ClusterTemplateRevision `json:"metadata"`
+	}
+}
+
+// The differences between two revisions of a cluster template
+//
+// swagger:response ClusterTemplateRevisionDiffResponse
+type swaggerClusterTemplateRevisionDiffResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterTemplateRevisionDiff `json:"metadata"`
+	}
+}
+
+// The drift of a cluster from its cluster template
+//
+// swagger:response ClusterTemplateDriftResponse
+type swaggerClusterTemplateDriftResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterTemplateDrift `json:"metadata"`
+	}
+}
+
+// The consistency of the members of a cluster
+//
+// swagger:response ClusterConsistencyResponse
+type swaggerClusterConsistencyResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ClusterConsistency `json:"metadata"`
+	}
+}
+
 // The image source
 //
 // swagger:response ImageSourceResponse
@@ -198,6 +308,50 @@ type swaggerInventoryAggregatesResponse struct {
 	}
 }
 
+// The rollout
+//
+// swagger:response RolloutResponse
+type swaggerRolloutResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.Rollout `json:"metadata"`
+	}
+}
+
+// The rollouts
+//
+// swagger:response RolloutsResponse
+type swaggerRolloutsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.Rollout `json:"metadata"`
+	}
+}
+
+// The site
+//
+// swagger:response SiteResponse
+type swaggerSiteResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.Site `json:"metadata"`
+	}
+}
+
+// The sites
+//
+// swagger:response SitesResponse
+type swaggerSitesResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.Site `json:"metadata"`
+	}
+}
+
 // The server
 //
 // swagger:response ServerResponse
@@ -220,6 +374,61 @@ type swaggerServersResponse struct {
 	}
 }
 
+// The hardware history of the server
+//
+// swagger:response ServerHardwareSnapshotsResponse
+type swaggerServerHardwareSnapshotsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ServerHardwareSnapshot `json:"metadata"`
+	}
+}
+
+// The disk health of servers
+//
+// swagger:response ServerDiskHealthsResponse
+type swaggerServerDiskHealthsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ServerDiskHealth `json:"metadata"`
+	}
+}
+
+// The results of a bulk action on servers
+//
+// swagger:response ServerBulkActionResultsResponse
+type swaggerServerBulkActionResultsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ServerBulkActionResult `json:"metadata"`
+	}
+}
+
+// The decommission of a server
+//
+// swagger:response ServerDecommissionResponse
+type swaggerServerDecommissionResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata api.ServerDecommission `json:"metadata"`
+	}
+}
+
+// The decommissions of servers
+//
+// swagger:response ServerDecommissionsResponse
+type swaggerServerDecommissionsResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata []api.ServerDecommission `json:"metadata"`
+	}
+}
+
 // The result of a server registration
 //
 // swagger:response ServerRegistrationResultResponse
@@ -275,6 +484,17 @@ type swaggerServerBMCBIOSAttributeValuesResponse struct {
 	}
 }
 
+// The firmware inventory reported by the BMC
+//
+// swagger:response ServerBMCFirmwareResponse
+type swaggerServerBMCFirmwareResponse struct {
+	// in: body
+	Body struct {
+		swaggerSyncResponseBody
+		Metadata map[string]api.BMCFirmware `json:"metadata"`
+	}
+}
+
 // The BMC dump
 //
 // swagger:response ServerBMCDumpResponse
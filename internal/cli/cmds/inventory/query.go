@@ -31,6 +31,7 @@ type CmdQuery struct {
 
 	flagFilterKinds              []string
 	flagFilterCluster            []string
+	flagFilterSite               string
 	flagFilterServer             []string
 	flagFilterServerIncludeNull  bool
 	flagFilterProject            []string
@@ -55,6 +56,7 @@ func (c *CmdQuery) Command() *cobra.Command {
 
 	cmd.Flags().StringSliceVar(&c.flagFilterKinds, "kind", nil, "list of resource kinds to filter for")
 	cmd.Flags().StringSliceVar(&c.flagFilterCluster, "cluster", nil, "cluster name to filter for")
+	cmd.Flags().StringVar(&c.flagFilterSite, "site", "", "site name to filter for")
 	cmd.Flags().StringSliceVar(&c.flagFilterServer, "server", nil, "server name to filter for")
 	cmd.Flags().BoolVar(&c.flagFilterServerIncludeNull, "server-include-empty", false, "include resources where server is not set")
 	cmd.Flags().StringSliceVar(&c.flagFilterProject, "project", nil, "project name to filter for")
@@ -81,6 +83,7 @@ func (c *CmdQuery) Run(cmd *cobra.Command, args []string) error {
 
 	if len(c.flagFilterKinds) == 0 &&
 		len(c.flagFilterCluster) == 0 &&
+		c.flagFilterSite == "" &&
 		len(c.flagFilterProject) == 0 &&
 		len(c.flagFilterServer) == 0 &&
 		len(c.flagFilterParent) == 0 &&
@@ -134,7 +137,7 @@ func (c *CmdQuery) Run(cmd *cobra.Command, args []string) error {
 		filter.Expression = ptr.To(c.flagFilterExpression)
 	}
 
-	inventoryAggregates, err := c.OCClient.GetWithFilterInventoryAggregates(cmd.Context(), filter)
+	inventoryAggregates, err := c.OCClient.GetWithFilterInventoryAggregatesBySite(cmd.Context(), c.flagFilterSite, filter)
 	if err != nil {
 		return err
 	}
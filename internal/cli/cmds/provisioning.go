@@ -37,18 +37,36 @@ func (c *CmdProvisioning) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterCmd.Command())
 
+	clusterBlueprintCmd := provisioning.CmdClusterBlueprint{
+		OCClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterBlueprintCmd.Command())
+
 	clusterTemplateCmd := provisioning.CmdClusterTemplate{
 		OCClient: c.OCClient,
 	}
 
 	cmd.AddCommand(clusterTemplateCmd.Command())
 
+	rolloutCmd := provisioning.CmdRollout{
+		OCClient: c.OCClient,
+	}
+
+	cmd.AddCommand(rolloutCmd.Command())
+
 	serverCmd := provisioning.CmdServer{
 		OCClient: c.OCClient,
 	}
 
 	cmd.AddCommand(serverCmd.Command())
 
+	siteCmd := provisioning.CmdSite{
+		OCClient: c.OCClient,
+	}
+
+	cmd.AddCommand(siteCmd.Command())
+
 	tokenCmd := provisioning.CmdToken{
 		OCClient: c.OCClient,
 	}
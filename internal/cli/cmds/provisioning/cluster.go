@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -52,6 +53,20 @@ func (c *CmdCluster) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterAddCmd.Command())
 
+	// Adopt
+	clusterAdoptCmd := cmdClusterAdopt{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterAdoptCmd.Command())
+
+	// Apply
+	clusterApplyCmd := cmdClusterApply{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterApplyCmd.Command())
+
 	// List
 	clusterListCmd := cmdClusterList{
 		ocClient: c.OCClient,
@@ -108,6 +123,13 @@ func (c *CmdCluster) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterRemoveServerCmd.Command())
 
+	// Replace server in cluster
+	clusterReplaceServerCmd := cmdClusterReplaceServer{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterReplaceServerCmd.Command())
+
 	// Resync
 	clusterResyncCmd := cmdClusterResync{
 		ocClient: c.OCClient,
@@ -129,6 +151,13 @@ func (c *CmdCluster) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterWideUpdateCmd.Command())
 
+	// Services
+	clusterServiceCmd := cmdClusterService{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterServiceCmd.Command())
+
 	// Cluster wide update
 	clusterUpdateCmd := cmdClusterUpdate{
 		ocClient: c.OCClient,
@@ -143,6 +172,13 @@ func (c *CmdCluster) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterRebootCmd.Command())
 
+	// Cluster wide firmware update
+	clusterFirmwareUpdateCmd := cmdClusterFirmwareUpdate{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterFirmwareUpdateCmd.Command())
+
 	// Cancel cluster wide operation
 	clusterCancelOperationCmd := cmdClusterCancelOperation{
 		ocClient: c.OCClient,
@@ -150,6 +186,27 @@ func (c *CmdCluster) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterCancelOperationCmd.Command())
 
+	// History of cluster wide operations
+	clusterHistoryCmd := cmdClusterHistory{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterHistoryCmd.Command())
+
+	// Drift from cluster template
+	clusterTemplateDriftCmd := cmdClusterTemplateDrift{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterTemplateDriftCmd.Command())
+
+	// Consistency
+	clusterConsistencyCmd := cmdClusterConsistency{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterConsistencyCmd.Command())
+
 	// artifact sub-command
 	clusterArtifactCmd := cmdClusterArtifact{
 		ocClient: c.OCClient,
@@ -171,6 +228,7 @@ type cmdClusterAdd struct {
 	flagClusterTemplate              string
 	flagClusterTemplateVariablesFile string
 	flagChannel                      string
+	flagSite                         string
 	flagDescription                  string
 	flagPropertiesFile               string
 }
@@ -195,6 +253,7 @@ func (c *cmdClusterAdd) Command() *cobra.Command {
 	cmd.Flags().StringVar(&c.flagClusterTemplate, "cluster-template", "", "Name of the cluster template to be applied. Mutual exclusive with --services-config and --application-seed-config")
 	cmd.Flags().StringVar(&c.flagClusterTemplateVariablesFile, "cluster-template-variables", "", "Name of the variables.yaml file containing the values to be applied in the cluster template. Required, if --cluster-template is provided")
 	cmd.Flags().StringVar(&c.flagChannel, "channel", "", "Name of the channel, the cluster follows for updates")
+	cmd.Flags().StringVar(&c.flagSite, "site", "", "Name of the site, the cluster belongs to")
 	cmd.Flags().StringVar(&c.flagDescription, "description", "", "Description of the cluster")
 	cmd.Flags().StringVar(&c.flagPropertiesFile, "properties", "", "Filename of the file containing the properties of the cluster")
 
@@ -304,6 +363,7 @@ func (c *cmdClusterAdd) run(cmd *cobra.Command, args []string) error {
 			ClusterPut: api.ClusterPut{
 				ConnectionURL: connectionURL,
 				Channel:       c.flagChannel,
+				Site:          c.flagSite,
 				Description:   c.flagDescription,
 				Properties:    clusterProperties,
 			},
@@ -322,11 +382,212 @@ func (c *cmdClusterAdd) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Adopt cluster.
+type cmdClusterAdopt struct {
+	ocClient *client.OperationsCenterClient
+
+	flagServerNames    []string
+	flagChannel        string
+	flagSite           string
+	flagDescription    string
+	flagPropertiesFile string
+}
+
+func (c *cmdClusterAdopt) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "adopt <name> <connection-url>"
+	cmd.Short = "Adopt an existing cluster"
+	cmd.Long = `Description:
+  Adopt an existing cluster
+
+  Adopts an existing cluster, which has not been created by operations center,
+  e.g. because it has been clustered by hand. All the members of the cluster
+  need to be registered in operations center already and need to be listed
+  with --server-names.
+`
+
+	const flagServerNames = "server-names"
+	cmd.Flags().StringSliceVarP(&c.flagServerNames, flagServerNames, "s", nil, "Server names of the cluster members")
+	_ = cmd.MarkFlagRequired(flagServerNames)
+
+	cmd.Flags().StringVar(&c.flagChannel, "channel", "", "Name of the channel, the cluster follows for updates")
+	cmd.Flags().StringVar(&c.flagSite, "site", "", "Name of the site, the cluster belongs to")
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", "Description of the cluster")
+	cmd.Flags().StringVar(&c.flagPropertiesFile, "properties", "", "Filename of the file containing the properties of the cluster")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterAdopt) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterAdopt) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	connectionURL := args[1]
+
+	clusterProperties := api.ConfigMap{}
+
+	if c.flagPropertiesFile != "" {
+		body, err := os.ReadFile(c.flagPropertiesFile)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(body, &clusterProperties)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.ocClient.AdoptCluster(cmd.Context(), api.ClusterAdoptPost{
+		Name: name,
+		ClusterPut: api.ClusterPut{
+			ConnectionURL: connectionURL,
+			Channel:       c.flagChannel,
+			Site:          c.flagSite,
+			Description:   c.flagDescription,
+			Properties:    clusterProperties,
+		},
+		ServerNames: c.flagServerNames,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Apply cluster spec.
+type cmdClusterApply struct {
+	ocClient *client.OperationsCenterClient
+
+	flagDryRun bool
+}
+
+func (c *cmdClusterApply) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "apply [<spec.yaml>]"
+	cmd.Short = "Apply a declarative cluster spec"
+	cmd.Long = `Description:
+  Apply a declarative cluster spec. Provide the spec through a file or through
+  stdin.
+
+  Reconciles the live state of a cluster with the cluster spec. The cluster is
+  created, if it does not exist yet. Otherwise the servers, storage targets,
+  network VLAN tags, logging and kernel configuration as well as the
+  configuration of the cluster are updated to match the spec. Parts of the
+  spec, which are not set, are not managed.
+
+  The applied changes are shown as plan. Use --dry-run to only show the plan
+  without applying it.
+
+  Example spec:
+
+    name: cluster1
+    server_names:
+      - server1
+      - server2
+      - server3
+    channel: stable
+    storage_targets:
+      multipath:
+        - 360014051e5ef4a6d7a7a4e4d7c1e3e05
+    network_vlan_tags:
+      eth0: [10, 20]
+`
+
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, "only show the changes the apply would perform without applying them")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterApply) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterApply) run(cmd *cobra.Command, args []string) error {
+	var err error
+	specReader := os.Stdin
+
+	if len(args) > 0 {
+		specFile := args[0]
+
+		specReader, err = os.Open(specFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read file %q: %w", specFile, err)
+		}
+
+		defer func() {
+			_ = specReader.Close()
+		}()
+	}
+
+	var spec api.ClusterSpec
+	err = yaml.NewDecoder(specReader).Decode(&spec)
+	if err != nil {
+		return fmt.Errorf("Failed to decode cluster spec: %w", err)
+	}
+
+	var plan api.ClusterSpecPlan
+	if c.flagDryRun {
+		plan, err = c.ocClient.PlanClusterSpec(cmd.Context(), spec)
+	} else {
+		plan, err = c.ocClient.ApplyClusterSpec(cmd.Context(), spec)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	printClusterSpecPlan(plan)
+
+	return nil
+}
+
+func printClusterSpecPlan(plan api.ClusterSpecPlan) {
+	fmt.Printf("Cluster: %s\n", plan.Name)
+
+	if len(plan.Changes) == 0 {
+		fmt.Println("Changes: none, the cluster matches its spec")
+		return
+	}
+
+	fmt.Println("Changes:")
+	for i, change := range plan.Changes {
+		switch {
+		case change.Current != "" || change.Desired != "":
+			fmt.Printf("  %d. %s %s: %q -> %q\n", i+1, change.Action, change.Subject, change.Current, change.Desired)
+		default:
+			fmt.Printf("  %d. %s %s\n", i+1, change.Action, change.Subject)
+		}
+	}
+}
+
 // List clusters.
 type cmdClusterList struct {
 	ocClient *client.OperationsCenterClient
 
 	flagFilterExpression string
+	flagFilterSite       string
 
 	flagFormat string
 }
@@ -340,6 +601,7 @@ func (c *cmdClusterList) Command() *cobra.Command {
 `
 
 	cmd.Flags().StringVar(&c.flagFilterExpression, "filter", "", "filter expression to apply")
+	cmd.Flags().StringVar(&c.flagFilterSite, "site", "", "site name to filter for")
 	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
 
 	cmd.PreRunE = c.validateArgsAndFlags
@@ -361,6 +623,10 @@ func (c *cmdClusterList) validateArgsAndFlags(cmd *cobra.Command, args []string)
 func (c *cmdClusterList) run(cmd *cobra.Command, args []string) error {
 	var filter provisioning.ClusterFilter
 
+	if c.flagFilterSite != "" {
+		filter.Site = ptr.To(c.flagFilterSite)
+	}
+
 	if c.flagFilterExpression != "" {
 		filter.Expression = ptr.To(c.flagFilterExpression)
 	}
@@ -371,7 +637,7 @@ func (c *cmdClusterList) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Render the table.
-	header := []string{"Name", "Connection URL", "Description", "Certificate Fingerprint", "Channel", "Status", "Update Status", "Last Updated"}
+	header := []string{"Name", "Connection URL", "Description", "Certificate Fingerprint", "Channel", "Site", "Status", "Update Status", "Last Updated"}
 	data := [][]string{}
 
 	for _, cluster := range clusters {
@@ -386,6 +652,7 @@ func (c *cmdClusterList) run(cmd *cobra.Command, args []string) error {
 			cluster.Description,
 			cluster.Fingerprint[:min(len(cluster.Fingerprint), 12)],
 			cluster.Channel,
+			cluster.Site,
 			cluster.Status.String(),
 			fmt.Sprintf("%d / %d / %d%s", len(cluster.UpdateStatus.NeedsUpdate), len(cluster.UpdateStatus.NeedsReboot), len(cluster.UpdateStatus.InMaintenance), updateStatusDescription),
 			cluster.LastUpdated.Truncate(time.Second).String(),
@@ -548,6 +815,7 @@ func (c *cmdClusterEdit) helpTemplate() string {
 ###
 ### connection_url: ""
 ### channel: stable
+### site: ""
 ### description: ""
 ### properties: {}
 `
@@ -771,11 +1039,38 @@ func (c *cmdClusterShow) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Certificate:\n%s", indent("  ", strings.TrimSpace(cluster.Certificate)))
 		fmt.Printf("Certificate Fingerprint: %s\n", cluster.Fingerprint)
 		fmt.Printf("Channel: %s\n", cluster.Channel)
+		fmt.Printf("Site: %s\n", cluster.Site)
 		fmt.Printf("Status: %s\n", cluster.Status.String())
 		fmt.Printf("Update Status:\n")
 		fmt.Printf("  Need Update: %s\n", needUpdate)
 		fmt.Printf("  Need Reboot: %s\n", needReboot)
 		fmt.Printf("  In Maintenance: %s\n", inMaintenance)
+
+		serverStates := cluster.UpdateStatus.InProgressStatus.ServerStates
+		if len(serverStates) > 0 {
+			fmt.Printf("  Server States:\n")
+			for _, serverName := range slices.Sorted(maps.Keys(serverStates)) {
+				fmt.Printf("    %s: %s\n", serverName, serverStates[serverName])
+			}
+		}
+
+		autoUpdateLaunches := cluster.UpdateStatus.AutoUpdateLaunches
+		if len(autoUpdateLaunches) > 0 {
+			fmt.Printf("  Auto Updates:\n")
+			for _, launch := range autoUpdateLaunches {
+				result := "launched"
+				if launch.Error != "" {
+					result = "failed: " + launch.Error
+				}
+
+				fmt.Printf("    %s: update %s (%s) %s\n", launch.LaunchedAt.Truncate(time.Second).String(), launch.Version, launch.Severity, result)
+			}
+		}
+
+		if cluster.Template != nil {
+			fmt.Printf("Cluster Template: %s (revision %d)\n", cluster.Template.Name, cluster.Template.Revision)
+		}
+
 		fmt.Printf("Last Updated: %s\n", cluster.LastUpdated.Truncate(time.Second).String())
 
 		if c.flagShowProperties {
@@ -888,6 +1183,53 @@ func (c *cmdClusterRemoveServer) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Cluster replace server.
+type cmdClusterReplaceServer struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterReplaceServer) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "replace-server <name> <server> <replacement-server>"
+	cmd.Short = "Replace a failed server of a cluster"
+	cmd.Long = `Description:
+  Replace a failed server of a cluster.
+
+  The failed server, which needs to be offline, is removed from the cluster
+  and the replacement server is joined to the cluster in its place. The
+  services config is copied from one of the remaining cluster members and the
+  member specific server configuration is applied to the replacement server.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterReplaceServer) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterReplaceServer) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	serverName := args[1]
+	replacementServerName := args[2]
+
+	err := c.ocClient.ReplaceServerInCluster(cmd.Context(), name, serverName, replacementServerName)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Resync cluster.
 type cmdClusterResync struct {
 	ocClient *client.OperationsCenterClient
@@ -1055,6 +1397,7 @@ type cmdClusterUpdate struct {
 	ocClient *client.OperationsCenterClient
 
 	flagReboot bool
+	flagDryRun bool
 }
 
 func (c *cmdClusterUpdate) Command() *cobra.Command {
@@ -1070,6 +1413,7 @@ func (c *cmdClusterUpdate) Command() *cobra.Command {
 `
 
 	cmd.Flags().BoolVar(&c.flagReboot, "reboot", false, "perform rolling reboot after applying the update")
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, "only show the steps the update would perform without launching it")
 
 	cmd.PreRunE = c.validateArgsAndFlags
 	cmd.RunE = c.run
@@ -1090,9 +1434,20 @@ func (c *cmdClusterUpdate) validateArgsAndFlags(cmd *cobra.Command, args []strin
 func (c *cmdClusterUpdate) run(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	err := c.ocClient.LaunchClusterWideUpdate(cmd.Context(), name, api.ClusterUpdatePost{
+	request := api.ClusterUpdatePost{
 		Reboot: c.flagReboot,
-	})
+	}
+
+	if c.flagDryRun {
+		plan, err := c.ocClient.PlanClusterWideUpdate(cmd.Context(), name, request)
+		if err != nil {
+			return err
+		}
+
+		return printClusterUpdatePlan(plan)
+	}
+
+	err := c.ocClient.LaunchClusterWideUpdate(cmd.Context(), name, request)
 	if err != nil {
 		return err
 	}
@@ -1103,6 +1458,8 @@ func (c *cmdClusterUpdate) run(cmd *cobra.Command, args []string) error {
 // Cluster wide reboot.
 type cmdClusterReboot struct {
 	ocClient *client.OperationsCenterClient
+
+	flagDryRun bool
 }
 
 func (c *cmdClusterReboot) Command() *cobra.Command {
@@ -1120,6 +1477,8 @@ func (c *cmdClusterReboot) Command() *cobra.Command {
   operation.
 `
 
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, "only show the steps the reboot would perform without launching it")
+
 	cmd.PreRunE = c.validateArgsAndFlags
 	cmd.RunE = c.run
 
@@ -1139,6 +1498,15 @@ func (c *cmdClusterReboot) validateArgsAndFlags(cmd *cobra.Command, args []strin
 func (c *cmdClusterReboot) run(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
+	if c.flagDryRun {
+		plan, err := c.ocClient.PlanClusterWideReboot(cmd.Context(), name)
+		if err != nil {
+			return err
+		}
+
+		return printClusterUpdatePlan(plan)
+	}
+
 	err := c.ocClient.LaunchClusterWideReboot(cmd.Context(), name)
 	if err != nil {
 		return err
@@ -1147,6 +1515,128 @@ func (c *cmdClusterReboot) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Cluster wide firmware update.
+type cmdClusterFirmwareUpdate struct {
+	ocClient *client.OperationsCenterClient
+
+	flagMethod  string
+	flagSHA256  string
+	flagTargets []string
+}
+
+func (c *cmdClusterFirmwareUpdate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "firmware-update <name> <image-uri>"
+	cmd.Short = "Launch cluster wide firmware update for all servers"
+	cmd.Long = `Description:
+  Perform a cluster wide firmware update of all servers via their BMC.
+
+  Every server of the cluster is evacuated, its firmware is updated using the
+  update service of its BMC, and the server is rebooted and restored again,
+  one server at a time. All servers of the cluster need to have a BMC
+  configured.
+
+  A cluster can only run a single cluster wide operation at a time. Use
+  "cluster cancel-operation" to cancel the ongoing operation.
+`
+
+	cmd.Flags().StringVar(&c.flagMethod, "method", api.BMCFirmwareUpdateMethodSimpleUpdate.String(), "method used to transfer the firmware image to the BMC (simple-update|push)")
+	cmd.Flags().StringVar(&c.flagSHA256, "sha256", "", "SHA-256 checksum of the firmware image, required for the method push")
+	cmd.Flags().StringSliceVar(&c.flagTargets, "target", nil, "ID of the firmware inventory entry the image is applied to, can be given multiple times, the BMC determines the applicable components if not set")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterFirmwareUpdate) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	return validateBMCFirmwareUpdateMethodFlag(c.flagMethod)
+}
+
+func (c *cmdClusterFirmwareUpdate) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	err := c.ocClient.LaunchClusterWideFirmwareUpdate(cmd.Context(), name, api.BMCFirmwareUpdatePost{
+		ImageURI: args[1],
+		Method:   api.BMCFirmwareUpdateMethod(c.flagMethod),
+		SHA256:   c.flagSHA256,
+		Targets:  c.flagTargets,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func printClusterUpdatePlan(plan api.ClusterUpdatePlan) error {
+	fmt.Printf("Operation: %s\n", plan.Operation)
+	fmt.Printf("In maintenance window: %t\n", plan.InMaintenanceWindow)
+
+	if len(plan.Steps) == 0 {
+		fmt.Println("Steps: none, the cluster is up to date")
+	} else {
+		fmt.Println("Steps:")
+		batch := 0
+		for i, step := range plan.Steps {
+			if step.Batch != batch {
+				batch = step.Batch
+				fmt.Printf("  Batch %d:\n", batch)
+			}
+
+			if step.Skipped {
+				fmt.Printf("    %d. %s %s (skipped: %s)\n", i+1, step.Action, step.Server, step.Reason)
+				continue
+			}
+
+			if step.WaitForMaintenanceWindow {
+				fmt.Printf("    %d. %s %s (waits for maintenance window)\n", i+1, step.Action, step.Server)
+				continue
+			}
+
+			fmt.Printf("    %d. %s %s\n", i+1, step.Action, step.Server)
+		}
+	}
+
+	fmt.Println("Servers:")
+	for _, server := range plan.Servers {
+		fmt.Printf("  %s:\n", server.Name)
+		if server.EvacuatedBefore {
+			fmt.Println("    Evacuated before: true")
+		}
+
+		fmt.Printf("    %s: %s -> %s\n", server.OS.Name, server.OS.CurrentVersion, server.OS.TargetVersion)
+		for _, application := range server.Applications {
+			fmt.Printf("    %s: %s -> %s\n", application.Name, application.CurrentVersion, application.TargetVersion)
+		}
+
+		if len(server.Instances) > 0 {
+			fmt.Println("    Instances:")
+			for _, instance := range server.Instances {
+				fmt.Printf("      - %s (project: %s, status: %s)\n", instance.Name, instance.Project, instance.Status)
+			}
+		}
+	}
+
+	if plan.Changelog != nil {
+		changelogYAML, err := yaml.Marshal(plan.Changelog)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Changelog:\n%s\n", render.Indent(4, string(changelogYAML)))
+	}
+
+	return nil
+}
+
 // Cancel cluster wide operation.
 type cmdClusterCancelOperation struct {
 	ocClient *client.OperationsCenterClient
@@ -1187,3 +1677,241 @@ func (c *cmdClusterCancelOperation) run(cmd *cobra.Command, args []string) error
 
 	return nil
 }
+
+// History of cluster wide operations.
+type cmdClusterHistory struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdClusterHistory) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "history <name> [<operation-uuid>]"
+	cmd.Short = "Show the history of the cluster wide operations"
+	cmd.Long = `Description:
+  Show the history of the cluster wide operations, updates and reboots, which
+  have been performed on the cluster, most recent first.
+
+  If the UUID of an operation is provided, the steps, the operation has
+  performed on the servers of the cluster, are shown.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterHistory) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 2)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdClusterHistory) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	operations, err := c.ocClient.GetClusterWideOperations(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 2 {
+		operationUUID, err := uuid.Parse(args[1])
+		if err != nil {
+			return fmt.Errorf("Invalid operation UUID %q: %w", args[1], err)
+		}
+
+		for _, operation := range operations {
+			if operation.UUID != operationUUID {
+				continue
+			}
+
+			// Render the table.
+			header := []string{"Server", "Action", "Started", "Finished", "Duration"}
+			data := [][]string{}
+
+			for _, event := range operation.Events {
+				data = append(data, []string{
+					event.Server,
+					string(event.Action),
+					formatHistoryTime(event.StartedAt),
+					formatHistoryTime(event.FinishedAt),
+					formatHistoryDuration(event.StartedAt, event.FinishedAt),
+				})
+			}
+
+			return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, operation.Events)
+		}
+
+		return fmt.Errorf("Operation %q not found for cluster %q", operationUUID, name)
+	}
+
+	// Render the table.
+	header := []string{"UUID", "Type", "Status", "Started", "Finished", "Duration", "Error"}
+	data := [][]string{}
+
+	for _, operation := range operations {
+		data = append(data, []string{
+			operation.UUID.String(),
+			string(operation.Type),
+			string(operation.Status),
+			formatHistoryTime(operation.StartedAt),
+			formatHistoryTime(operation.FinishedAt),
+			formatHistoryDuration(operation.StartedAt, operation.FinishedAt),
+			operation.Error,
+		})
+	}
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, operations)
+}
+
+func formatHistoryTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Truncate(time.Second).String()
+}
+
+func formatHistoryDuration(startedAt time.Time, finishedAt time.Time) string {
+	if finishedAt.IsZero() {
+		return ""
+	}
+
+	return finishedAt.Sub(startedAt).Truncate(time.Second).String()
+}
+
+// Drift of a cluster from its cluster template.
+type cmdClusterTemplateDrift struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdClusterTemplateDrift) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "template-drift <name>"
+	cmd.Short = "Show the drift of a cluster from its cluster-template"
+	cmd.Long = `Description:
+  Show the drift of a cluster from its cluster-template
+
+  The current revision of the cluster-template, the cluster has been created
+  from, is rendered with the variable values recorded on the cluster and
+  compared with the live service configuration of all the servers of the
+  cluster. Only the configuration keys, which are set by the cluster-template,
+  are compared.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterTemplateDrift) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdClusterTemplateDrift) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	drift, err := c.ocClient.GetClusterTemplateDrift(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	if drift.Outdated {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Cluster %q has been created from revision %d of cluster-template %q, the current revision is %d\n", name, drift.Template.Revision, drift.Template.Name, drift.CurrentRevision)
+	}
+
+	// Render the table.
+	header := []string{"Server", "Service", "Key", "Expected", "Actual"}
+	data := [][]string{}
+
+	for _, difference := range drift.Differences {
+		data = append(data, []string{difference.Server, difference.Service, difference.Key, difference.Expected, difference.Actual})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, drift)
+}
+
+// Consistency of the settings of the cluster members.
+type cmdClusterConsistency struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdClusterConsistency) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "consistency <name>"
+	cmd.Short = "Show the inconsistencies between the members of a cluster"
+	cmd.Long = `Description:
+  Show the inconsistencies between the members of a cluster
+
+  The OS and application versions, the network VLAN tags, the kernel and
+  logging configuration and the OS services configuration of the ready
+  members of the cluster are compared with the ones of the reference server
+  (the first member ordered by name).
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterConsistency) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdClusterConsistency) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	consistency, err := c.ocClient.GetClusterConsistency(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	if consistency.ReferenceServer != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Members of cluster %q compared with reference server %q\n", name, consistency.ReferenceServer)
+	}
+
+	// Render the table.
+	header := []string{"Server", "Setting", "Reference", "Actual"}
+	data := [][]string{}
+
+	for _, inconsistency := range consistency.Inconsistencies {
+		data = append(data, []string{inconsistency.Server, inconsistency.Setting, inconsistency.Reference, inconsistency.Actual})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, consistency)
+}
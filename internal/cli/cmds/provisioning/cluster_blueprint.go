@@ -0,0 +1,501 @@
+package provisioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/termios"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/cli/validate"
+	"github.com/FuturFusion/operations-center/internal/client"
+	"github.com/FuturFusion/operations-center/internal/environment"
+	"github.com/FuturFusion/operations-center/internal/util/editor"
+	"github.com/FuturFusion/operations-center/internal/util/render"
+	"github.com/FuturFusion/operations-center/internal/util/sort"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type CmdClusterBlueprint struct {
+	OCClient *client.OperationsCenterClient
+}
+
+func (c *CmdClusterBlueprint) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "cluster-blueprint"
+	cmd.Short = "Interact with cluster blueprints"
+	cmd.Long = `Description:
+  Interact with cluster blueprints
+
+  Cluster blueprints form a cluster from a cluster template automatically, as
+  soon as enough servers matching the server filter are ready and not yet
+  part of a cluster.
+`
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+
+	// Add
+	clusterBlueprintAddCmd := cmdClusterBlueprintAdd{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterBlueprintAddCmd.Command())
+
+	// Edit
+	clusterBlueprintEditCmd := cmdClusterBlueprintEdit{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterBlueprintEditCmd.Command())
+
+	// List
+	clusterBlueprintListCmd := cmdClusterBlueprintList{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterBlueprintListCmd.Command())
+
+	// Remove
+	clusterBlueprintRemoveCmd := cmdClusterBlueprintRemove{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterBlueprintRemoveCmd.Command())
+
+	// Show
+	clusterBlueprintShowCmd := cmdClusterBlueprintShow{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterBlueprintShowCmd.Command())
+
+	return cmd
+}
+
+// Add cluster blueprint.
+type cmdClusterBlueprintAdd struct {
+	ocClient *client.OperationsCenterClient
+
+	flagClusterTemplate              string
+	flagClusterTemplateVariablesFile string
+	flagServerFilter                 string
+	flagMemberCount                  int
+	flagServerType                   string
+	flagChannel                      string
+	flagDescription                  string
+}
+
+func (c *cmdClusterBlueprintAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "add <name> <connection-url>"
+	cmd.Short = "Add a new cluster blueprint"
+	cmd.Long = `Description:
+  Add a new cluster blueprint
+
+  Adds a new cluster blueprint to the operations center. As soon as the given
+  number of servers matching the server filter are ready and not yet part of
+  a cluster, the cluster with the name of the blueprint is created from them
+  by applying the cluster template.
+`
+
+	cmd.Flags().StringVar(&c.flagClusterTemplate, "cluster-template", "", "Name of the cluster template to be applied")
+	cmd.Flags().StringVar(&c.flagClusterTemplateVariablesFile, "cluster-template-variables", "", "Name of the variables.yaml file containing the values to be applied in the cluster template")
+	cmd.Flags().StringVar(&c.flagServerFilter, "server-filter", "", "Filter expression selecting the servers, the cluster is formed with")
+	cmd.Flags().IntVar(&c.flagMemberCount, "member-count", 0, "Number of servers, the cluster is formed with")
+	cmd.Flags().StringVarP(&c.flagServerType, "server-type", "t", "incus", "Type of servers, that should be clustered, supported values are (incus, migration-manager, operations-center)")
+	cmd.Flags().StringVar(&c.flagChannel, "channel", "", "Name of the channel, the selected servers need to follow")
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", "Description of the cluster blueprint")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterBlueprintAdd) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	if c.flagClusterTemplate == "" {
+		return fmt.Errorf(`Flag "--cluster-template" is required`)
+	}
+
+	if c.flagServerFilter == "" {
+		return fmt.Errorf(`Flag "--server-filter" is required`)
+	}
+
+	if c.flagMemberCount < 1 {
+		return fmt.Errorf(`Flag "--member-count" is required and needs to be greater or equal to 1`)
+	}
+
+	return nil
+}
+
+func (c *cmdClusterBlueprintAdd) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	connectionURL := args[1]
+
+	var serverType api.ServerType
+	err := serverType.UnmarshalText([]byte(c.flagServerType))
+	if err != nil {
+		return err
+	}
+
+	clusterTemplateVariables := api.ConfigMap{}
+
+	if c.flagClusterTemplateVariablesFile != "" {
+		body, err := os.ReadFile(c.flagClusterTemplateVariablesFile)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(body, &clusterTemplateVariables)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.ocClient.CreateClusterBlueprint(cmd.Context(), api.ClusterBlueprintPost{
+		Name: name,
+		ClusterBlueprintPut: api.ClusterBlueprintPut{
+			Description:                   c.flagDescription,
+			ClusterTemplate:               c.flagClusterTemplate,
+			ClusterTemplateVariableValues: clusterTemplateVariables,
+			ServerFilter:                  c.flagServerFilter,
+			MemberCount:                   c.flagMemberCount,
+			ConnectionURL:                 connectionURL,
+			ServerType:                    serverType,
+			Channel:                       c.flagChannel,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Edit cluster blueprint.
+type cmdClusterBlueprintEdit struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterBlueprintEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "edit <name>"
+	cmd.Short = "Edit a cluster blueprint"
+	cmd.Long = `Description:
+  Edit a cluster blueprint
+
+  Edits a waiting or failed cluster blueprint. A failed cluster blueprint is
+  put back into waiting state, such that the cluster formation is retried.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+// helpTemplate returns a sample YAML configuration and guidelines for editing cluster blueprints.
+func (c *cmdClusterBlueprintEdit) helpTemplate() string {
+	return `### This is a YAML representation of the configuration.
+### Any line starting with a '# will be ignored.
+###
+### A sample configuration looks like:
+###
+### description: ""
+### cluster_template: branch-office
+### cluster_template_variable_values:
+###   MGMT_VLAN: "100"
+### server_filter: properties.site_code == "zrh01"
+### member_count: 3
+### connection_url: https://zrh01.local:8443
+### server_type: incus
+### channel: stable
+`
+}
+
+func (c *cmdClusterBlueprintEdit) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterBlueprintEdit) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// If stdin isn't a terminal, read text from it.
+	if !termios.IsTerminal(environment.GetStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		newdata := api.ClusterBlueprintPut{}
+		err = yaml.Unmarshal(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		err = c.ocClient.UpdateClusterBlueprint(cmd.Context(), name, newdata)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	clusterBlueprint, err := c.ocClient.GetClusterBlueprint(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(b)
+	encoder.SetIndent(2)
+	err = encoder.Encode(clusterBlueprint.ClusterBlueprintPut)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := editor.Spawn("", append([]byte(c.helpTemplate()+"\n\n"), b.Bytes()...))
+	if err != nil {
+		return err
+	}
+
+	for {
+		newdata := api.ClusterBlueprintPut{}
+		err = yaml.Unmarshal(content, &newdata)
+		if err == nil {
+			err = c.ocClient.UpdateClusterBlueprint(cmd.Context(), name, newdata)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Config parsing error: %s\n", err)
+			fmt.Println("Press enter to open the editor again or ctrl+c to abort change")
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = editor.Spawn("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// List cluster blueprints.
+type cmdClusterBlueprintList struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdClusterBlueprintList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "list"
+	cmd.Short = "List cluster blueprints"
+	cmd.Long = `Description:
+  List the cluster blueprints
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterBlueprintList) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdClusterBlueprintList) run(cmd *cobra.Command, args []string) error {
+	clusterBlueprints, err := c.ocClient.GetClusterBlueprints(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"Name", "Cluster Template", "Server Filter", "Member Count", "Status", "Last Updated"}
+	data := [][]string{}
+
+	for _, clusterBlueprint := range clusterBlueprints {
+		data = append(data, []string{clusterBlueprint.Name, clusterBlueprint.ClusterTemplate, clusterBlueprint.ServerFilter, strconv.Itoa(clusterBlueprint.MemberCount), clusterBlueprint.Status.String(), clusterBlueprint.LastUpdated.Truncate(time.Second).String()})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, clusterBlueprints)
+}
+
+// Remove cluster blueprint.
+type cmdClusterBlueprintRemove struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterBlueprintRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "remove <name>"
+	cmd.Short = "Remove a cluster blueprint"
+	cmd.Long = `Description:
+  Remove a cluster blueprint
+
+  Removes a cluster blueprint from the operations center. A cluster, which has
+  already been formed from the blueprint, is not affected.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterBlueprintRemove) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterBlueprintRemove) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	err := c.ocClient.DeleteClusterBlueprint(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Show cluster blueprint.
+type cmdClusterBlueprintShow struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdClusterBlueprintShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "show <name>"
+	cmd.Short = "Show information about a cluster blueprint"
+	cmd.Long = `Description:
+  Show information about a cluster blueprint.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "", `Format (json|yaml)`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterBlueprintShow) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	validFormats := []string{"", "json", "yaml"}
+	if !slices.Contains(validFormats, c.flagFormat) {
+		return fmt.Errorf(`Invalid value for flag "--format": %q`, c.flagFormat)
+	}
+
+	return nil
+}
+
+func (c *cmdClusterBlueprintShow) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	clusterBlueprint, err := c.ocClient.GetClusterBlueprint(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	switch c.flagFormat {
+	case "json":
+		enc := json.NewEncoder(c.Command().OutOrStdout())
+		enc.SetIndent("", "  ")
+		err = enc.Encode(clusterBlueprint)
+		if err != nil {
+			return err
+		}
+
+	case "yaml":
+		enc := yaml.NewEncoder(c.Command().OutOrStdout())
+		enc.SetIndent(2)
+		err = enc.Encode(clusterBlueprint)
+		if err != nil {
+			return err
+		}
+
+	default:
+		fmt.Printf("Name: %s\n", clusterBlueprint.Name)
+		fmt.Printf("Description: %s\n", clusterBlueprint.Description)
+		fmt.Printf("Cluster Template: %s\n", clusterBlueprint.ClusterTemplate)
+		if len(clusterBlueprint.ClusterTemplateVariableValues) > 0 {
+			variables, err := yaml.Marshal(clusterBlueprint.ClusterTemplateVariableValues)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Cluster Template Variables:\n%s\n", render.Indent(4, string(variables)))
+		}
+
+		fmt.Printf("Server Filter: %s\n", clusterBlueprint.ServerFilter)
+		fmt.Printf("Member Count: %d\n", clusterBlueprint.MemberCount)
+		fmt.Printf("Connection URL: %s\n", clusterBlueprint.ConnectionURL)
+		fmt.Printf("Server Type: %s\n", clusterBlueprint.ServerType)
+		fmt.Printf("Channel: %s\n", clusterBlueprint.Channel)
+		fmt.Printf("Status: %s\n", clusterBlueprint.Status)
+		fmt.Printf("Server Names: %s\n", strings.Join(clusterBlueprint.ServerNames, ", "))
+		if clusterBlueprint.Error != "" {
+			fmt.Printf("Error: %s\n", clusterBlueprint.Error)
+		}
+
+		fmt.Printf("Last Updated: %s\n", clusterBlueprint.LastUpdated.Truncate(time.Second).String())
+	}
+
+	return nil
+}
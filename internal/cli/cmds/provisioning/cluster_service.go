@@ -0,0 +1,291 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	incusosapi "github.com/lxc/incus-os/incus-osd/api"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/cli/validate"
+	"github.com/FuturFusion/operations-center/internal/client"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// Configure the IncusOS services of all cluster members.
+type cmdClusterService struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterService) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "service"
+	cmd.Short = "Interact with the services configuration of clusters"
+	cmd.Long = `Description:
+  Interact with the services configuration of clusters
+
+  Configure the ceph, ovn and linstor services on all members of a cluster.
+  If the configuration of one of the members fails, the changes already
+  applied to the other members are reverted.
+`
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+
+	// Add ceph cluster
+	clusterServiceAddCephClusterCmd := cmdClusterServiceAddCephCluster{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(clusterServiceAddCephClusterCmd.Command())
+
+	// Remove ceph cluster
+	clusterServiceRemoveCephClusterCmd := cmdClusterServiceRemoveCephCluster{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(clusterServiceRemoveCephClusterCmd.Command())
+
+	// Update ovn
+	clusterServiceUpdateOVNCmd := cmdClusterServiceUpdateOVN{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(clusterServiceUpdateOVNCmd.Command())
+
+	// Update linstor
+	clusterServiceUpdateLinstorCmd := cmdClusterServiceUpdateLinstor{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(clusterServiceUpdateLinstorCmd.Command())
+
+	return cmd
+}
+
+// Add ceph cluster to all cluster members.
+type cmdClusterServiceAddCephCluster struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterServiceAddCephCluster) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "add-ceph-cluster <name> <ceph-cluster-name> [<ceph-cluster.yaml>]"
+	cmd.Short = "Add a ceph cluster to all cluster members"
+	cmd.Long = `Description:
+  Add a ceph cluster (client config and keyrings) to the ceph service of all
+  members of the cluster. Provide the ceph cluster config through a file or
+  through stdin.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterServiceAddCephCluster) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 3)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterServiceAddCephCluster) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cephCluster := struct {
+		Name string `json:"name"`
+		incusosapi.ServiceCephCluster
+	}{
+		Name: args[1],
+	}
+
+	err := decodeServiceConfig(args[2:], &cephCluster.ServiceCephCluster)
+	if err != nil {
+		return err
+	}
+
+	return bulkUpdateCluster(cmd.Context(), c.ocClient, name, api.ClusterBulkUpdateActionAddCephCluster, cephCluster)
+}
+
+// Remove ceph cluster from all cluster members.
+type cmdClusterServiceRemoveCephCluster struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterServiceRemoveCephCluster) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "remove-ceph-cluster <name> <ceph-cluster-name>"
+	cmd.Short = "Remove a ceph cluster from all cluster members"
+	cmd.Long = `Description:
+  Remove a ceph cluster from the ceph service of all members of the cluster.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterServiceRemoveCephCluster) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterServiceRemoveCephCluster) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cephCluster := struct {
+		Name string `json:"name"`
+	}{
+		Name: args[1],
+	}
+
+	return bulkUpdateCluster(cmd.Context(), c.ocClient, name, api.ClusterBulkUpdateActionRemoveCephCluster, cephCluster)
+}
+
+// Update ovn service config of all cluster members.
+type cmdClusterServiceUpdateOVN struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterServiceUpdateOVN) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "update-ovn <name> [<ovn-config.yaml>]"
+	cmd.Short = "Update the ovn service config of all cluster members"
+	cmd.Long = `Description:
+  Update the ovn service config (central database and chassis settings) of
+  all members of the cluster. Provide the ovn config through a file or through
+  stdin.
+
+  The tunnel address is member dependent. If it is omitted, the tunnel
+  address of each member is kept as is.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterServiceUpdateOVN) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 2)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterServiceUpdateOVN) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	var ovnConfig incusosapi.ServiceOVNConfig
+	err := decodeServiceConfig(args[1:], &ovnConfig)
+	if err != nil {
+		return err
+	}
+
+	return bulkUpdateCluster(cmd.Context(), c.ocClient, name, api.ClusterBulkUpdateActionUpdateOVNConfig, ovnConfig)
+}
+
+// Update linstor service config of all cluster members.
+type cmdClusterServiceUpdateLinstor struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdClusterServiceUpdateLinstor) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "update-linstor <name> [<linstor-config.yaml>]"
+	cmd.Short = "Update the linstor service config of all cluster members"
+	cmd.Long = `Description:
+  Update the linstor satellite config of all members of the cluster. Provide
+  the linstor config through a file or through stdin.
+
+  The listen address is member dependent. If it is omitted, the listen
+  address of each member is kept as is.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterServiceUpdateLinstor) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 2)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdClusterServiceUpdateLinstor) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	var linstorConfig incusosapi.ServiceLinstorConfig
+	err := decodeServiceConfig(args[1:], &linstorConfig)
+	if err != nil {
+		return err
+	}
+
+	return bulkUpdateCluster(cmd.Context(), c.ocClient, name, api.ClusterBulkUpdateActionUpdateLinstorConfig, linstorConfig)
+}
+
+// decodeServiceConfig decodes the YAML service config from the file given as
+// the first element of args or from stdin, if args is empty.
+func decodeServiceConfig(args []string, config any) error {
+	var err error
+	var configReader io.ReadCloser = os.Stdin
+
+	if len(args) > 0 {
+		configFile := args[0]
+
+		configReader, err = os.Open(configFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read file %q: %w", configFile, err)
+		}
+
+		defer func() {
+			_ = configReader.Close()
+		}()
+	}
+
+	err = yaml.NewDecoder(configReader).Decode(config)
+	if err != nil {
+		return fmt.Errorf("Failed to decode service config: %w", err)
+	}
+
+	return nil
+}
+
+func bulkUpdateCluster(ctx context.Context, ocClient *client.OperationsCenterClient, name string, action api.ClusterBulkUpdateAction, arguments any) error {
+	body, err := json.Marshal(arguments)
+	if err != nil {
+		return fmt.Errorf("Failed to encode bulk update arguments: %w", err)
+	}
+
+	rawArguments := json.RawMessage(body)
+
+	return ocClient.BulkUpdateCluster(ctx, name, api.ClusterBulkUpdatePost{
+		Action:    action,
+		Arguments: &rawArguments,
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -49,6 +51,13 @@ func (c *CmdClusterTemplate) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterTemplateListCmd.Command())
 
+	// Diff
+	clusterTemplateDiffCmd := cmdClusterTemplateDiff{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterTemplateDiffCmd.Command())
+
 	// Remove
 	clusterTemplateRemoveCmd := cmdClusterTemplateRemove{
 		ocClient: c.OCClient,
@@ -56,6 +65,13 @@ func (c *CmdClusterTemplate) Command() *cobra.Command {
 
 	cmd.AddCommand(clusterTemplateRemoveCmd.Command())
 
+	// Revisions
+	clusterTemplateRevisionsCmd := cmdClusterTemplateRevisions{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(clusterTemplateRevisionsCmd.Command())
+
 	// Show
 	clusterTemplateShowCmd := cmdClusterTemplateShow{
 		ocClient: c.OCClient,
@@ -195,11 +211,11 @@ func (c *cmdClusterTemplateList) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Render the table.
-	header := []string{"Name", "Description", "Last Updated"}
+	header := []string{"Name", "Description", "Revision", "Last Updated"}
 	data := [][]string{}
 
 	for _, clusterTemplate := range clusterTemplates {
-		data = append(data, []string{clusterTemplate.Name, clusterTemplate.Description, clusterTemplate.LastUpdated.Truncate(time.Second).String()})
+		data = append(data, []string{clusterTemplate.Name, clusterTemplate.Description, strconv.FormatInt(clusterTemplate.Revision, 10), clusterTemplate.LastUpdated.Truncate(time.Second).String()})
 	}
 
 	sort.ColumnsNaturally(data)
@@ -323,8 +339,146 @@ func (c *cmdClusterTemplateShow) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Service config template:\n%s\n", render.Indent(4, clusterTemplate.ServiceConfigTemplate))
 		fmt.Printf("Application config template:\n%s\n", render.Indent(4, clusterTemplate.ApplicationConfigTemplate))
 		fmt.Printf("Variables:\n%s\n", render.Indent(4, string(variables)))
+		fmt.Printf("Revision: %d\n", clusterTemplate.Revision)
 		fmt.Printf("Last Updated: %s\n", clusterTemplate.LastUpdated.Truncate(time.Second).String())
 	}
 
 	return nil
 }
+
+// Diff clusterTemplate revisions.
+type cmdClusterTemplateDiff struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFrom int64
+	flagTo   int64
+}
+
+func (c *cmdClusterTemplateDiff) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "diff <name>"
+	cmd.Short = "Show the differences between two revisions of a cluster-template"
+	cmd.Long = `Description:
+  Show the differences between two revisions of a cluster-template
+
+  The differences are shown in unified diff format. Without flags, the current
+  revision is compared with the preceding revision.
+`
+
+	cmd.Flags().Int64Var(&c.flagFrom, "from", 0, "Revision the diff starts from, defaults to the revision preceding the --to revision")
+	cmd.Flags().Int64Var(&c.flagTo, "to", 0, "Revision the diff leads to, defaults to the current revision")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterTemplateDiff) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if c.flagFrom < 0 {
+		return fmt.Errorf(`Invalid value for flag "--from": %d`, c.flagFrom)
+	}
+
+	if c.flagTo < 0 {
+		return fmt.Errorf(`Invalid value for flag "--to": %d`, c.flagTo)
+	}
+
+	return nil
+}
+
+func (c *cmdClusterTemplateDiff) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	diff, err := c.ocClient.GetClusterTemplateRevisionDiff(cmd.Context(), name, c.flagFrom, c.flagTo)
+	if err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name string
+		diff string
+	}{
+		{name: "Description", diff: diff.Description},
+		{name: "Service config template", diff: diff.ServiceConfigTemplate},
+		{name: "Application config template", diff: diff.ApplicationConfigTemplate},
+		{name: "Variables", diff: diff.Variables},
+	}
+
+	hasDifferences := false
+	for _, section := range sections {
+		if section.diff == "" {
+			continue
+		}
+
+		hasDifferences = true
+		fmt.Fprintf(cmd.OutOrStdout(), "%s:\n%s\n", section.name, render.Indent(4, strings.TrimSuffix(section.diff, "\n")))
+	}
+
+	if !hasDifferences {
+		fmt.Fprintf(cmd.OutOrStdout(), "Revisions %d and %d of cluster-template %q are identical\n", diff.FromRevision, diff.ToRevision, name)
+	}
+
+	return nil
+}
+
+// List clusterTemplate revisions.
+type cmdClusterTemplateRevisions struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdClusterTemplateRevisions) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "revisions <name>"
+	cmd.Short = "List the revisions of a cluster-template"
+	cmd.Long = `Description:
+  List the revisions of a cluster-template
+
+  A new revision is recorded, whenever the definition of the cluster-template
+  is changed.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdClusterTemplateRevisions) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdClusterTemplateRevisions) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	revisions, err := c.ocClient.GetClusterTemplateRevisions(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"Revision", "Description", "Created At"}
+	data := [][]string{}
+
+	for _, revision := range revisions {
+		data = append(data, []string{strconv.FormatInt(revision.Revision, 10), revision.Description, revision.CreatedAt.Truncate(time.Second).String()})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, revisions)
+}
@@ -0,0 +1,391 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/cli/validate"
+	"github.com/FuturFusion/operations-center/internal/client"
+	"github.com/FuturFusion/operations-center/internal/util/render"
+	"github.com/FuturFusion/operations-center/internal/util/sort"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type CmdRollout struct {
+	OCClient *client.OperationsCenterClient
+}
+
+func (c *CmdRollout) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "rollout"
+	cmd.Short = "Interact with rollouts"
+	cmd.Long = `Description:
+  Interact with rollouts
+
+  Rollouts update the clusters of the fleet in waves.
+`
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+
+	// Add
+	rolloutAddCmd := cmdRolloutAdd{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(rolloutAddCmd.Command())
+
+	// Cancel
+	rolloutCancelCmd := cmdRolloutCancel{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(rolloutCancelCmd.Command())
+
+	// List
+	rolloutListCmd := cmdRolloutList{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(rolloutListCmd.Command())
+
+	// Remove
+	rolloutRemoveCmd := cmdRolloutRemove{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(rolloutRemoveCmd.Command())
+
+	// Show
+	rolloutShowCmd := cmdRolloutShow{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(rolloutShowCmd.Command())
+
+	return cmd
+}
+
+// Add rollout.
+type cmdRolloutAdd struct {
+	ocClient *client.OperationsCenterClient
+
+	description string
+	reboot      bool
+	wavesFile   string
+}
+
+func (c *cmdRolloutAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "add <name>"
+	cmd.Short = "Start a new rollout"
+	cmd.Long = `Description:
+  Start a new rollout
+
+  Starts a new rollout, which updates the clusters in waves. The waves are
+  read from a YAML file containing a list of waves, e.g.
+
+  - name: lab
+    cluster_filter: properties.stage == "lab"
+    soak_time: 48h
+  - name: prod
+    cluster_filter: properties.stage == "prod"
+`
+
+	cmd.Flags().StringVar(&c.description, "description", "", "Description of the rollout")
+	cmd.Flags().BoolVar(&c.reboot, "reboot", false, "Reboot the servers as part of the cluster updates")
+	cmd.Flags().StringVarP(&c.wavesFile, "waves", "w", "", "File containing the waves of the rollout")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdRolloutAdd) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if c.wavesFile == "" {
+		return fmt.Errorf(`Flag "--waves" is required`)
+	}
+
+	return nil
+}
+
+func (c *cmdRolloutAdd) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	body, err := os.ReadFile(c.wavesFile)
+	if err != nil {
+		return err
+	}
+
+	waves := api.RolloutWaves{}
+	err = yaml.Unmarshal(body, &waves)
+	if err != nil {
+		return err
+	}
+
+	err = c.ocClient.CreateRollout(cmd.Context(), api.RolloutPost{
+		Name:        name,
+		Description: c.description,
+		Reboot:      c.reboot,
+		Waves:       waves,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Cancel rollout.
+type cmdRolloutCancel struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdRolloutCancel) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "cancel <name>"
+	cmd.Short = "Cancel a running rollout"
+	cmd.Long = `Description:
+  Cancel a running rollout
+
+  Cluster updates, which have already been launched by the rollout, are not
+  affected.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdRolloutCancel) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdRolloutCancel) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	err := c.ocClient.CancelRollout(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// List rollouts.
+type cmdRolloutList struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdRolloutList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "list"
+	cmd.Short = "List rollouts"
+	cmd.Long = `Description:
+  List the rollouts
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdRolloutList) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdRolloutList) run(cmd *cobra.Command, args []string) error {
+	rollouts, err := c.ocClient.GetRollouts(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"Name", "Status", "Wave", "Wave Status", "Last Updated"}
+	data := [][]string{}
+
+	for _, rollout := range rollouts {
+		data = append(data, []string{rollout.Name, string(rollout.Status), rolloutWave(rollout), string(rollout.WaveStatus), rollout.LastUpdated.Truncate(time.Second).String()})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, rollouts)
+}
+
+// Remove rollout.
+type cmdRolloutRemove struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdRolloutRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "remove <name>"
+	cmd.Short = "Remove a rollout"
+	cmd.Long = `Description:
+  Remove a rollout
+
+  Removes a rollout from the operations center. Running rollouts need to be
+  canceled first.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdRolloutRemove) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdRolloutRemove) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	err := c.ocClient.DeleteRollout(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Show rollout.
+type cmdRolloutShow struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdRolloutShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "show <name>"
+	cmd.Short = "Show information about a rollout"
+	cmd.Long = `Description:
+  Show information about a rollout.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "", `Format (json|yaml)`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdRolloutShow) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	validFormats := []string{"", "json", "yaml"}
+	if !slices.Contains(validFormats, c.flagFormat) {
+		return fmt.Errorf(`Invalid value for flag "--format": %q`, c.flagFormat)
+	}
+
+	return nil
+}
+
+func (c *cmdRolloutShow) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	rollout, err := c.ocClient.GetRollout(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	switch c.flagFormat {
+	case "json":
+		enc := json.NewEncoder(c.Command().OutOrStdout())
+		enc.SetIndent("", "  ")
+		err = enc.Encode(rollout)
+		if err != nil {
+			return err
+		}
+
+	case "yaml":
+		enc := yaml.NewEncoder(c.Command().OutOrStdout())
+		enc.SetIndent(2)
+		err = enc.Encode(rollout)
+		if err != nil {
+			return err
+		}
+
+	default:
+		waves, err := yaml.Marshal(rollout.Waves)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Name: %s\n", rollout.Name)
+		fmt.Printf("Description: %s\n", rollout.Description)
+		fmt.Printf("Reboot: %t\n", rollout.Reboot)
+		fmt.Printf("Target Version: %s\n", rollout.TargetVersion)
+		fmt.Printf("Waves:\n%s\n", render.Indent(4, string(waves)))
+		fmt.Printf("Status: %s\n", rollout.Status)
+		fmt.Printf("Current Wave: %s\n", rolloutWave(rollout))
+		fmt.Printf("Wave Status: %s\n", rollout.WaveStatus)
+		fmt.Printf("Wave Clusters: %s\n", strings.Join(rollout.WaveClusters, ", "))
+		if !rollout.WaveFinishedAt.IsZero() {
+			fmt.Printf("Wave Finished At: %s\n", rollout.WaveFinishedAt.Truncate(time.Second).String())
+		}
+
+		if rollout.Error != "" {
+			fmt.Printf("Error: %s\n", rollout.Error)
+		}
+
+		fmt.Printf("Last Updated: %s\n", rollout.LastUpdated.Truncate(time.Second).String())
+	}
+
+	return nil
+}
+
+func rolloutWave(rollout api.Rollout) string {
+	wave := strconv.Itoa(rollout.CurrentWave+1) + "/" + strconv.Itoa(len(rollout.Waves))
+	if rollout.CurrentWave < len(rollout.Waves) && rollout.Waves[rollout.CurrentWave].Name != "" {
+		wave += " (" + rollout.Waves[rollout.CurrentWave].Name + ")"
+	}
+
+	return wave
+}
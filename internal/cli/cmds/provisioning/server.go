@@ -103,6 +103,41 @@ func (c *CmdServer) Command() *cobra.Command {
 
 	cmd.AddCommand(serverChangelogCmd.Command())
 
+	// Hardware history
+	serverHardwareHistoryCmd := cmdServerHardwareHistory{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(serverHardwareHistoryCmd.Command())
+
+	// Disk health
+	serverDiskHealthCmd := cmdServerDiskHealth{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(serverDiskHealthCmd.Command())
+
+	// Bulk action
+	serverBulkActionCmd := cmdServerBulkAction{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(serverBulkActionCmd.Command())
+
+	// Decommission
+	serverDecommissionCmd := cmdServerDecommission{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(serverDecommissionCmd.Command())
+
+	// Decommissions
+	serverDecommissionsCmd := cmdServerDecommissions{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(serverDecommissionsCmd.Command())
+
 	// OS
 	serverOSCmd := cmdServerOS{
 		ocClient: c.OCClient,
@@ -132,6 +167,7 @@ type cmdServerList struct {
 	ocClient *client.OperationsCenterClient
 
 	flagFilterCluster    string
+	flagFilterSite       string
 	flagFilterStatus     string
 	flagFilterExpression string
 
@@ -147,6 +183,7 @@ func (c *cmdServerList) Command() *cobra.Command {
 `
 
 	cmd.Flags().StringVar(&c.flagFilterCluster, "cluster", "", "cluster name to filter for")
+	cmd.Flags().StringVar(&c.flagFilterSite, "site", "", "site name to filter for")
 	cmd.Flags().StringVar(&c.flagFilterStatus, "status", "", "status to filter for, valid values: pending, ready")
 	cmd.Flags().StringVar(&c.flagFilterExpression, "filter", "", "filter expression to apply")
 
@@ -175,6 +212,10 @@ func (c *cmdServerList) run(cmd *cobra.Command, args []string) error {
 		filter.Cluster = ptr.To(c.flagFilterCluster)
 	}
 
+	if c.flagFilterSite != "" {
+		filter.Site = ptr.To(c.flagFilterSite)
+	}
+
 	if c.flagFilterStatus != "" {
 		var status api.ServerStatus
 		err := status.UnmarshalText([]byte(c.flagFilterStatus))
@@ -195,7 +236,7 @@ func (c *cmdServerList) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Render the table.
-	header := []string{"Cluster", "Name", "Connection URL", "Description", "Public Connection URL", "Certificate Fingerprint", "Type", "Channel", "Status", "Update Status", "Last Updated", "Last Seen", "Recommended Action"}
+	header := []string{"Cluster", "Name", "Connection URL", "Description", "Public Connection URL", "Certificate Fingerprint", "Type", "Channel", "Site", "Status", "Update Status", "Last Updated", "Last Seen", "Recommended Action"}
 	data := [][]string{}
 
 	for _, server := range servers {
@@ -208,6 +249,7 @@ func (c *cmdServerList) run(cmd *cobra.Command, args []string) error {
 			server.Fingerprint[:min(len(server.Fingerprint), 12)],
 			server.Type.String(),
 			server.Channel,
+			server.Site,
 			server.State(),
 			server.UpdateState().String(),
 			server.LastUpdated.Truncate(time.Second).String(),
@@ -227,6 +269,7 @@ type cmdServerPreRegister struct {
 
 	description           string
 	channel               string
+	site                  string
 	publicConnectionURL   string
 	bmcAPIType            string
 	bmcEndpoint           string
@@ -246,6 +289,7 @@ func (c *cmdServerPreRegister) Command() *cobra.Command {
 
 	cmd.Flags().StringVar(&c.description, "description", "", "Description of the server")
 	cmd.Flags().StringVar(&c.channel, "channel", "stable", "Channel the server should subscribe to")
+	cmd.Flags().StringVar(&c.site, "site", "", "Site the server belongs to")
 	cmd.Flags().StringVar(&c.publicConnectionURL, "public-connection-url", "", "Public connection URL of the server")
 	cmd.Flags().StringVar(&c.bmcAPIType, "bmc-api-type", "", "API type of the BMC of the server (e.g. redfish-v1-generic)")
 	cmd.Flags().StringVar(&c.bmcEndpoint, "bmc-endpoint", "", "Endpoint of the BMC")
@@ -291,6 +335,7 @@ func (c *cmdServerPreRegister) run(cmd *cobra.Command, args []string) error {
 		ServerPut: api.ServerPut{
 			Description:         c.description,
 			Channel:             c.channel,
+			Site:                c.site,
 			PublicConnectionURL: c.publicConnectionURL,
 			BMCConfig: api.BMCConfig{
 				APIType:            api.BMCAPIType(c.bmcAPIType),
@@ -337,6 +382,7 @@ func (c *cmdServerEdit) helpTemplate() string {
 ###
 ### public_connection_url: ""
 ### channel: stable
+### site: ""
 ### description: ""
 ### properties: {}
 `
@@ -640,6 +686,7 @@ func (c *cmdServerShow) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Certificate Fingerprint: %s\n", server.Fingerprint)
 		fmt.Printf("Type: %s\n", server.Type.String())
 		fmt.Printf("Channel: %s\n", server.Channel)
+		fmt.Printf("Site: %s\n", server.Site)
 		fmt.Printf("BMC API Type: %s\n", server.BMCConfig.APIType.String())
 		fmt.Printf("BMC Endpoint: %s\n", server.BMCConfig.Endpoint)
 		fmt.Printf("BMC Username: %s\n", server.BMCConfig.Username)
@@ -647,6 +694,20 @@ func (c *cmdServerShow) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Machine ID: %s\n", server.MachineID)
 		fmt.Printf("Status: %s\n", server.State())
 		fmt.Printf("Update Status: %s\n", server.UpdateState().String())
+		if server.ClusterJoin.Status != api.ServerClusterJoinStatusNone {
+			fmt.Printf("Cluster Join: %s (%s)\n", server.ClusterJoin.Cluster, server.ClusterJoin.Status.String())
+			if server.ClusterJoin.Error != "" {
+				fmt.Printf("Cluster Join Error: %s\n", server.ClusterJoin.Error)
+			}
+		}
+
+		if server.BMCInstall.Status != api.ServerBMCInstallStatusNone {
+			fmt.Printf("BMC Install: %s/%s (%s)\n", server.BMCInstall.Token.String(), server.BMCInstall.TokenSeed, server.BMCInstall.Status.String())
+			if server.BMCInstall.Error != "" {
+				fmt.Printf("BMC Install Error: %s\n", server.BMCInstall.Error)
+			}
+		}
+
 		fmt.Printf("Last Updated: %s\n", server.LastUpdated.Truncate(time.Second).String())
 		fmt.Printf("Last Seen: %s\n", server.LastSeen.Truncate(time.Second).String())
 		fmt.Printf("Recommended Action: %v\n", server.RecommendedAction())
@@ -747,6 +808,469 @@ func (c *cmdServerChangelog) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Hardware history of server.
+type cmdServerHardwareHistory struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdServerHardwareHistory) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "hardware-history <name>"
+	cmd.Short = "List the hardware changes of a server"
+	cmd.Long = `Description:
+  List the hardware changes of a server
+
+  A new hardware snapshot is recorded for the first hardware data received
+  from a server and whenever a change of the CPUs, the memory, the disks or
+  the NICs of the server is detected.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerHardwareHistory) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdServerHardwareHistory) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	snapshots, err := c.ocClient.GetServerHardwareHistory(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Render the table, one row per change in chronological order.
+	header := []string{"Created At", "Component", "Action", "Identifier", "Previous", "Current"}
+	data := [][]string{}
+
+	for _, snapshot := range snapshots {
+		createdAt := snapshot.CreatedAt.Truncate(time.Second).String()
+
+		if len(snapshot.Changes) == 0 {
+			data = append(data, []string{createdAt, "", "initial", "", "", ""})
+			continue
+		}
+
+		for _, change := range snapshot.Changes {
+			data = append(data, []string{createdAt, string(change.Component), string(change.Action), change.Identifier, change.Previous, change.Current})
+		}
+	}
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, snapshots)
+}
+
+// Disk health of servers.
+type cmdServerDiskHealth struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFilterCluster string
+	flagFilterSite    string
+	flagFilterStatus  string
+
+	flagFormat string
+}
+
+func (c *cmdServerDiskHealth) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "disk-health [<name>]"
+	cmd.Short = "Show the disk health of servers"
+	cmd.Long = `Description:
+  Show the disk health of servers
+
+  Without a server name, the health of the disks of all servers is reported.
+  With a server name, the report is limited to this server and the recorded
+  history of the health attributes of each disk is included (only visible
+  with the json and yaml formats).
+`
+
+	cmd.Flags().StringVar(&c.flagFilterCluster, "cluster", "", "cluster name to filter for")
+	cmd.Flags().StringVar(&c.flagFilterSite, "site", "", "site name to filter for")
+	cmd.Flags().StringVar(&c.flagFilterStatus, "status", "", "disk health status to filter for, valid values: ok, warning, failing, unknown")
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerDiskHealth) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdServerDiskHealth) run(cmd *cobra.Command, args []string) error {
+	var status *api.DiskHealthStatus
+	if c.flagFilterStatus != "" {
+		status = new(api.DiskHealthStatus)
+		err := status.UnmarshalText([]byte(c.flagFilterStatus))
+		if err != nil {
+			return fmt.Errorf("Invalid value for status: %v", err)
+		}
+	}
+
+	var diskHealth []api.ServerDiskHealth
+	var err error
+
+	if len(args) == 1 {
+		diskHealth, err = c.ocClient.GetServerDiskHealth(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if status != nil {
+			diskHealth = slices.DeleteFunc(diskHealth, func(health api.ServerDiskHealth) bool {
+				return health.Status != *status
+			})
+		}
+	} else {
+		var filter provisioning.ServerFilter
+
+		if c.flagFilterCluster != "" {
+			filter.Cluster = ptr.To(c.flagFilterCluster)
+		}
+
+		if c.flagFilterSite != "" {
+			filter.Site = ptr.To(c.flagFilterSite)
+		}
+
+		diskHealth, err = c.ocClient.GetDiskHealth(cmd.Context(), filter, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Render the table.
+	header := []string{"Cluster", "Server", "Drive", "Model", "Serial Number", "Bus", "Status", "Used", "Spare", "Reallocated Sectors", "Power On Hours", "Reasons"}
+	data := [][]string{}
+
+	for _, health := range diskHealth {
+		data = append(data, []string{
+			health.Cluster,
+			health.Server,
+			health.Drive,
+			health.ModelName,
+			health.SerialNumber,
+			health.Bus,
+			health.Status.String(),
+			fmt.Sprintf("%d%%", health.PercentageUsed),
+			fmt.Sprintf("%d%%", health.AvailableSpare),
+			fmt.Sprintf("%d", health.ReallocatedSectors),
+			fmt.Sprintf("%d", health.PowerOnHours),
+			strings.Join(health.Reasons, "; "),
+		})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, diskHealth)
+}
+
+// Decommission server.
+type cmdServerDecommission struct {
+	ocClient *client.OperationsCenterClient
+
+	flagSkipEvacuate          bool
+	flagSkipRemoveFromCluster bool
+	flagSkipFactoryReset      bool
+	flagSkipBMCPowerOff       bool
+	flagSkipDelete            bool
+	flagWipeDisks             bool
+	flagConfirmDiskWipe       string
+	flagResume                bool
+
+	flagFormat string
+}
+
+func (c *cmdServerDecommission) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "decommission <name>"
+	cmd.Short = "Decommission a server"
+	cmd.Long = `Description:
+  Decommission a server
+
+  Retires a server by performing the following steps in order: evacuate,
+  remove from cluster, factory reset, power off via BMC, erase the disks via
+  BMC and remove from the inventory. Each step can be skipped with the
+  respective flag. The erasure of the disks is irreversible and therefore
+  needs to be enabled explicitly and confirmed with the name of the server.
+
+  The steps are performed in the background. The progress can be followed
+  with "operations-center provisioning server decommissions <name>".
+
+  If a step fails, the decommission can be continued with --resume, which
+  uses the options of the failed decommission and does not perform the
+  steps again, which have already been completed.
+`
+
+	cmd.Flags().BoolVar(&c.flagSkipEvacuate, "skip-evacuate", false, "skip the evacuation of the server")
+	cmd.Flags().BoolVar(&c.flagSkipRemoveFromCluster, "skip-remove-from-cluster", false, "skip the removal of the server from its cluster")
+	cmd.Flags().BoolVar(&c.flagSkipFactoryReset, "skip-factory-reset", false, "skip the factory reset of the server")
+	cmd.Flags().BoolVar(&c.flagSkipBMCPowerOff, "skip-bmc-power-off", false, "skip the power off of the server via its BMC")
+	cmd.Flags().BoolVar(&c.flagSkipDelete, "skip-delete", false, "skip the removal of the server from the inventory")
+	cmd.Flags().BoolVar(&c.flagWipeDisks, "wipe-disks", false, "securely erase the disks of the server via its BMC")
+	cmd.Flags().StringVar(&c.flagConfirmDiskWipe, "confirm-disk-wipe", "", "name of the server to confirm the erasure of its disks")
+	cmd.Flags().BoolVar(&c.flagResume, "resume", false, "resume the last failed decommission of the server")
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerDecommission) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if c.flagWipeDisks && c.flagConfirmDiskWipe != args[0] {
+		return fmt.Errorf(`The erasure of the disks needs to be confirmed with "--confirm-disk-wipe %s"`, args[0])
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdServerDecommission) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	decommission, err := c.ocClient.DecommissionServer(cmd.Context(), name, api.ServerDecommissionPost{
+		ServerDecommissionOptions: api.ServerDecommissionOptions{
+			Evacuate:          !c.flagSkipEvacuate,
+			RemoveFromCluster: !c.flagSkipRemoveFromCluster,
+			FactoryReset:      !c.flagSkipFactoryReset,
+			BMCPowerOff:       !c.flagSkipBMCPowerOff,
+			WipeDisks:         c.flagWipeDisks,
+			Delete:            !c.flagSkipDelete,
+		},
+		ConfirmDiskWipe: c.flagConfirmDiskWipe,
+		Resume:          c.flagResume,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"UUID", "Server", "Status", "Started At"}
+	data := [][]string{
+		{
+			decommission.UUID.String(),
+			decommission.Server,
+			decommission.Status.String(),
+			decommission.StartedAt.Truncate(time.Second).String(),
+		},
+	}
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, decommission)
+}
+
+// List decommissions of servers.
+type cmdServerDecommissions struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdServerDecommissions) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "decommissions [<name>]"
+	cmd.Short = "List the decommissions of servers"
+	cmd.Long = `Description:
+  List the decommissions of servers
+
+  The decommission records are kept after the servers have been removed from
+  the inventory. With a server name, the list is limited to this server. The
+  details of the single steps are only visible with the json and yaml
+  formats.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerDecommissions) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdServerDecommissions) run(cmd *cobra.Command, args []string) error {
+	var name string
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	decommissions, err := c.ocClient.GetServerDecommissions(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"UUID", "Server", "Status", "Steps Done", "Error", "Started At", "Finished At"}
+	data := [][]string{}
+
+	for _, decommission := range decommissions {
+		var done []string
+		for _, step := range decommission.Steps {
+			if step.Status == api.ServerDecommissionStepStatusDone {
+				done = append(done, string(step.Step))
+			}
+		}
+
+		finishedAt := ""
+		if !decommission.FinishedAt.IsZero() {
+			finishedAt = decommission.FinishedAt.Truncate(time.Second).String()
+		}
+
+		data = append(data, []string{
+			decommission.UUID.String(),
+			decommission.Server,
+			decommission.Status.String(),
+			strings.Join(done, ", "),
+			decommission.Error,
+			decommission.StartedAt.Truncate(time.Second).String(),
+			finishedAt,
+		})
+	}
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, decommissions)
+}
+
+// Bulk action on servers.
+type cmdServerBulkAction struct {
+	ocClient *client.OperationsCenterClient
+
+	flagArguments   string
+	flagConcurrency int
+	flagDryRun      bool
+
+	flagFormat string
+}
+
+func (c *cmdServerBulkAction) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "bulk-action <action> <filter>"
+	cmd.Short = "Perform an action on all servers matching a filter"
+	cmd.Long = `Description:
+  Perform an action on all servers matching a filter
+
+  Performs the action on every server matching the filter expression and
+  reports the result for each server. Reboots and updates are only permitted
+  for standalone servers, cluster members are rebooted and updated with the
+  cluster wide operations. With --dry-run, only the matching servers are
+  listed.
+
+  Supported actions and their arguments (provided as JSON with --arguments):
+    reboot                  {"force": true}
+    update                  {"force": true, "os": {...}, "applications": [...]}
+    update_system_logging   logging configuration
+    add_application         {"name": "incus"}
+`
+
+	cmd.Flags().StringVar(&c.flagArguments, "arguments", "", "arguments for the action as JSON")
+	cmd.Flags().IntVar(&c.flagConcurrency, "concurrency", 0, "maximum number of servers the action is performed on at the same time (server default if not set)")
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, "only list the servers matching the filter")
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerBulkAction) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	if c.flagArguments != "" && !json.Valid([]byte(c.flagArguments)) {
+		return fmt.Errorf("Invalid value for --arguments, not valid JSON")
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdServerBulkAction) run(cmd *cobra.Command, args []string) error {
+	request := api.ServerBulkActionPost{
+		Action:      api.ServerBulkAction(args[0]),
+		Filter:      args[1],
+		Concurrency: c.flagConcurrency,
+	}
+
+	if c.flagArguments != "" {
+		request.Arguments = ptr.To(json.RawMessage(c.flagArguments))
+	}
+
+	bulkAction := c.ocClient.BulkActionServers
+	if c.flagDryRun {
+		bulkAction = c.ocClient.PlanBulkActionServers
+	}
+
+	results, err := bulkAction(cmd.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"Server", "Cluster", "Status", "Error"}
+	data := [][]string{}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == api.ServerBulkActionStatusFailed {
+			failed++
+		}
+
+		data = append(data, []string{result.Server, result.Cluster, result.Status.String(), result.Error})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	err = render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, results)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("Action %q failed on %d of %d servers", request.Action, failed, len(results))
+	}
+
+	return nil
+}
+
 // OS server.
 type cmdServerOS struct {
 	ocClient *client.OperationsCenterClient
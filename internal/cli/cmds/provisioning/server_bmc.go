@@ -3,10 +3,13 @@ package provisioning
 import (
 	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/lxc/incus-os/incus-osd/api/images"
 	"github.com/spf13/cobra"
 
 	"github.com/FuturFusion/operations-center/internal/cli/validate"
 	"github.com/FuturFusion/operations-center/internal/client"
+	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 // Interact with BMC of servers.
@@ -60,6 +63,13 @@ func (c *cmdServerBMC) Command() *cobra.Command {
 
 	cmd.AddCommand(serverBMCServerLocateCmd.Command())
 
+	// Install
+	serverBMCInstallCmd := cmdServerBMCInstall{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(serverBMCInstallCmd.Command())
+
 	// BIOS attributes
 	serverBMCBIOSAttributesCmd := cmdServerBMCBIOSAttributes{
 		ocClient: c.ocClient,
@@ -67,6 +77,13 @@ func (c *cmdServerBMC) Command() *cobra.Command {
 
 	cmd.AddCommand(serverBMCBIOSAttributesCmd.Command())
 
+	// Firmware
+	serverBMCFirmwareCmd := cmdServerBMCFirmware{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(serverBMCFirmwareCmd.Command())
+
 	// Logs
 	serverBMCLogsCmd := cmdServerBMCLogs{
 		ocClient: c.ocClient,
@@ -319,3 +336,88 @@ func (c *cmdServerBMCServerLocate) run(cmd *cobra.Command, args []string) error
 
 	return nil
 }
+
+// Install IncusOS on a server via BMC.
+type cmdServerBMCInstall struct {
+	ocClient *client.OperationsCenterClient
+
+	flagArchitecture string
+	flagChannel      string
+	flagVirtualMedia string
+	flagRebuild      bool
+}
+
+func (c *cmdServerBMCInstall) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "install <name> <token> <seed-name>"
+	cmd.Short = "Install IncusOS on a server via BMC"
+	cmd.Long = `Description:
+  Install IncusOS on a server via BMC
+
+  Installs IncusOS on an unregistered server using the virtual media of its
+  BMC. The install image is pre-seeded with the given token seed and served
+  by Operations Center. The server is booted from the virtual media once.
+
+  The installation is performed in the background, the progress is shown by
+  "server show".
+`
+
+	cmd.Flags().StringVar(&c.flagArchitecture, "architecture", "", "CPU architecture for the image (x86_64|aarch64), derived from the BMC data if not set")
+	cmd.Flags().StringVar(&c.flagChannel, "channel", "", "channel the image is taken from, the channel of the token is used if not set")
+	cmd.Flags().StringVar(&c.flagVirtualMedia, "virtual-media", "", "ID of the virtual media slot as shown in the BMC data, the first slot supporting CD or DVD media is used if not set")
+	cmd.Flags().BoolVar(&c.flagRebuild, "rebuild", false, "build the install image again, even if an image is already available")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerBMCInstall) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	_, err = uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("Failed to parse token: %w", err)
+	}
+
+	if args[2] == "" {
+		return fmt.Errorf("Invalid token seed name: empty string")
+	}
+
+	if c.flagArchitecture != "" {
+		_, ok := images.UpdateFileArchitectures[images.UpdateFileArchitecture(c.flagArchitecture)]
+		if !ok {
+			return fmt.Errorf(`Invalid value for flag "--architecture": %q`, c.flagArchitecture)
+		}
+	}
+
+	return nil
+}
+
+func (c *cmdServerBMCInstall) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	token, err := uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("Failed to parse token: %w", err)
+	}
+
+	err = c.ocClient.BMCInstallServer(cmd.Context(), name, api.ServerBMCInstallPost{
+		Token:        token,
+		TokenSeed:    args[2],
+		Architecture: images.UpdateFileArchitecture(c.flagArchitecture),
+		Channel:      c.flagChannel,
+		VirtualMedia: c.flagVirtualMedia,
+		Rebuild:      c.flagRebuild,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package provisioning
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/FuturFusion/operations-center/internal/cli/validate"
+	"github.com/FuturFusion/operations-center/internal/client"
+	"github.com/FuturFusion/operations-center/internal/util/render"
+	"github.com/FuturFusion/operations-center/internal/util/sort"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// Interact with a server's firmware via BMC.
+type cmdServerBMCFirmware struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdServerBMCFirmware) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "firmware"
+	cmd.Short = "Interact with a server's firmware via BMC"
+	cmd.Long = `Description:
+  Interact with a server's firmware via BMC.
+`
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+
+	// List
+	serverBMCFirmwareListCmd := cmdServerBMCFirmwareList{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(serverBMCFirmwareListCmd.Command())
+
+	// Update
+	serverBMCFirmwareUpdateCmd := cmdServerBMCFirmwareUpdate{
+		ocClient: c.ocClient,
+	}
+
+	cmd.AddCommand(serverBMCFirmwareUpdateCmd.Command())
+
+	return cmd
+}
+
+// List server's firmware inventory.
+type cmdServerBMCFirmwareList struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdServerBMCFirmwareList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "list <name>"
+	cmd.Short = "List a server's firmware inventory"
+	cmd.Long = `Description:
+  List the firmware inventory (e.g. BIOS, BMC, NIC and RAID controller) as
+  reported by the update service of a server's BMC.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerBMCFirmwareList) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdServerBMCFirmwareList) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	firmware, err := c.ocClient.GetServerBMCFirmware(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"ID", "Name", "Component", "Version", "Updateable"}
+	data := [][]string{}
+
+	for _, entry := range firmware {
+		data = append(data, []string{entry.ID, entry.Name, entry.Component.String(), entry.Version, strconv.FormatBool(entry.Updateable)})
+	}
+
+	sort.ColumnsNaturally(data)
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, firmware)
+}
+
+// Update the firmware of a server via BMC.
+type cmdServerBMCFirmwareUpdate struct {
+	ocClient *client.OperationsCenterClient
+
+	flagMethod  string
+	flagSHA256  string
+	flagTargets []string
+}
+
+func (c *cmdServerBMCFirmwareUpdate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "update <name> <image-uri>"
+	cmd.Short = "Update the firmware of a server via BMC"
+	cmd.Long = `Description:
+  Update the firmware of a server via BMC
+
+  Updates the firmware of a server using the update service of its BMC. With
+  the method "simple-update", the BMC fetches the firmware image from the given
+  URI by itself. With the method "push", Operations Center fetches the image
+  and pushes it to the BMC, which is useful, if the BMC can not reach the URI.
+  The image is verified against the checksum given with --sha256 before it is
+  pushed to the BMC.
+
+  The update is performed in the background. The firmware of cluster members
+  is updated using "cluster firmware-update".
+`
+
+	cmd.Flags().StringVar(&c.flagMethod, "method", api.BMCFirmwareUpdateMethodSimpleUpdate.String(), "method used to transfer the firmware image to the BMC (simple-update|push)")
+	cmd.Flags().StringVar(&c.flagSHA256, "sha256", "", "SHA-256 checksum of the firmware image, required for the method push")
+	cmd.Flags().StringSliceVar(&c.flagTargets, "target", nil, "ID of the firmware inventory entry the image is applied to, can be given multiple times, the BMC determines the applicable components if not set")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdServerBMCFirmwareUpdate) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	return validateBMCFirmwareUpdateMethodFlag(c.flagMethod)
+}
+
+func (c *cmdServerBMCFirmwareUpdate) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	err := c.ocClient.UpdateServerBMCFirmware(cmd.Context(), name, api.BMCFirmwareUpdatePost{
+		ImageURI: args[1],
+		Method:   api.BMCFirmwareUpdateMethod(c.flagMethod),
+		SHA256:   c.flagSHA256,
+		Targets:  c.flagTargets,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateBMCFirmwareUpdateMethodFlag(method string) error {
+	var updateMethod api.BMCFirmwareUpdateMethod
+
+	err := updateMethod.UnmarshalText([]byte(method))
+	if err != nil {
+		return fmt.Errorf(`Invalid value for flag "--method": %q`, method)
+	}
+
+	return nil
+}
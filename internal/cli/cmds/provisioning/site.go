@@ -0,0 +1,516 @@
+package provisioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/termios"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/cli/validate"
+	"github.com/FuturFusion/operations-center/internal/client"
+	"github.com/FuturFusion/operations-center/internal/environment"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/editor"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/internal/util/render"
+	"github.com/FuturFusion/operations-center/internal/util/sort"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type CmdSite struct {
+	OCClient *client.OperationsCenterClient
+}
+
+func (c *CmdSite) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "site"
+	cmd.Short = "Interact with sites"
+	cmd.Long = `Description:
+  Interact with sites
+
+  Manage sites, which group clusters and standalone servers.
+`
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+
+	// List
+	siteListCmd := cmdSiteList{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(siteListCmd.Command())
+
+	// Show
+	siteShowCmd := cmdSiteShow{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(siteShowCmd.Command())
+
+	// Add
+	siteAddCmd := cmdSiteAdd{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(siteAddCmd.Command())
+
+	// Edit
+	siteEditCmd := cmdSiteEdit{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(siteEditCmd.Command())
+
+	// Remove
+	siteRemoveCmd := cmdSiteRemove{
+		ocClient: c.OCClient,
+	}
+
+	cmd.AddCommand(siteRemoveCmd.Command())
+
+	return cmd
+}
+
+// List sites.
+type cmdSiteList struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdSiteList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "list"
+	cmd.Short = "List available sites"
+	cmd.Long = `Description:
+  List the available sites
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdSiteList) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	return validate.FormatFlag(cmd.Flag("format").Value.String())
+}
+
+func (c *cmdSiteList) run(cmd *cobra.Command, args []string) error {
+	sites, err := c.ocClient.GetSites(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	header := []string{"Name", "Description", "Channel", "Clusters", "Servers", "Need Update", "Offline", "Open Warnings", "Last Updated"}
+	data := [][]string{}
+
+	for _, site := range sites {
+		data = append(data, []string{
+			site.Name,
+			site.Description,
+			site.Channel,
+			strconv.Itoa(site.Status.Clusters),
+			strconv.Itoa(site.Status.Servers),
+			strconv.Itoa(site.Status.ServersNeedingUpdate),
+			strconv.Itoa(site.Status.ServersOffline),
+			strconv.Itoa(site.Status.OpenWarnings),
+			site.LastUpdated.Truncate(time.Second).String(),
+		})
+	}
+
+	sort.ColumnsSort(data, []sort.ColumnSorter{
+		{
+			Index: 0, // Name
+			Less:  sort.NaturalLess,
+		},
+	})
+
+	return render.Table(cmd.OutOrStdout(), c.flagFormat, header, data, sites)
+}
+
+// Show site.
+type cmdSiteShow struct {
+	ocClient *client.OperationsCenterClient
+
+	flagFormat string
+}
+
+func (c *cmdSiteShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "show <name>"
+	cmd.Short = "Show information about a site"
+	cmd.Long = `Description:
+  Show information about a site including its clusters and standalone
+  servers.
+`
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "", `Format (json|yaml)`)
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdSiteShow) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	validFormats := []string{"", "json", "yaml"}
+	if !slices.Contains(validFormats, c.flagFormat) {
+		return fmt.Errorf(`Invalid value for flag "--format": %q`, c.flagFormat)
+	}
+
+	return nil
+}
+
+func (c *cmdSiteShow) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	site, err := c.ocClient.GetSite(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	switch c.flagFormat {
+	case "json":
+		enc := json.NewEncoder(c.Command().OutOrStdout())
+		enc.SetIndent("", "  ")
+		err = enc.Encode(site)
+		if err != nil {
+			return err
+		}
+
+	case "yaml":
+		enc := yaml.NewEncoder(c.Command().OutOrStdout())
+		enc.SetIndent(2)
+		err = enc.Encode(site)
+		if err != nil {
+			return err
+		}
+
+	default:
+		clusters, err := c.ocClient.GetWithFilterClusters(cmd.Context(), provisioning.ClusterFilter{
+			Site: ptr.To(name),
+		})
+		if err != nil {
+			return err
+		}
+
+		servers, err := c.ocClient.GetWithFilterServers(cmd.Context(), provisioning.ServerFilter{
+			Site: ptr.To(name),
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Name: %s\n", site.Name)
+		fmt.Printf("Description: %s\n", site.Description)
+		fmt.Printf("Channel: %s\n", site.Channel)
+		fmt.Printf("Last Updated: %s\n", site.LastUpdated.Truncate(time.Second).String())
+
+		fmt.Printf("Status:\n")
+		fmt.Printf("  Clusters: %d\n", site.Status.Clusters)
+		fmt.Printf("  Servers: %d\n", site.Status.Servers)
+		fmt.Printf("  Servers Needing Update: %d\n", site.Status.ServersNeedingUpdate)
+		fmt.Printf("  Servers Offline: %d\n", site.Status.ServersOffline)
+		fmt.Printf("  Open Warnings: %d\n", site.Status.OpenWarnings)
+
+		if len(site.Config.MaintenanceWindows) > 0 {
+			fmt.Printf("Maintenance Windows:\n")
+			for _, window := range site.Config.MaintenanceWindows {
+				weekdays := strings.Join(window.Weekdays, ", ")
+				if weekdays == "" {
+					weekdays = "every day"
+				}
+
+				timezone := window.Timezone
+				if timezone == "" {
+					timezone = "UTC"
+				}
+
+				fmt.Printf("- %s, %s - %s (%s)\n", weekdays, window.StartTime, window.EndTime, timezone)
+			}
+		}
+
+		fmt.Printf("Clusters:\n")
+		for _, cluster := range clusters {
+			fmt.Printf("- %s (%s)\n", cluster.Name, cluster.ConnectionURL)
+		}
+
+		fmt.Printf("Standalone Servers:\n")
+		for _, server := range servers {
+			if server.Cluster != "" {
+				continue
+			}
+
+			fmt.Printf("- %s (%s)\n", server.Name, server.ConnectionURL)
+		}
+	}
+
+	return nil
+}
+
+// Add site.
+type cmdSiteAdd struct {
+	ocClient *client.OperationsCenterClient
+
+	flagDescription    string
+	flagChannel        string
+	flagPropertiesFile string
+}
+
+func (c *cmdSiteAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "add <name>"
+	cmd.Short = "Add a site"
+	cmd.Long = `Description:
+  Add a site
+
+  Adds a new site. Clusters and standalone servers are assigned to a site
+  through their "site" property.
+`
+
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", "Description of the site")
+	cmd.Flags().StringVar(&c.flagChannel, "channel", "", "Default channel for the clusters and servers of the site")
+	cmd.Flags().StringVar(&c.flagPropertiesFile, "properties", "", "Filename of the file containing the properties of the site")
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdSiteAdd) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdSiteAdd) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	siteProperties := api.ConfigMap{}
+
+	if c.flagPropertiesFile != "" {
+		body, err := os.ReadFile(c.flagPropertiesFile)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(body, &siteProperties)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.ocClient.CreateSite(cmd.Context(), api.SitePost{
+		Name: name,
+		SitePut: api.SitePut{
+			Description: c.flagDescription,
+			Channel:     c.flagChannel,
+			Properties:  siteProperties,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to create site %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// Edit site.
+type cmdSiteEdit struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdSiteEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "edit <name>"
+	cmd.Short = "Edit a site"
+	cmd.Long = `Description:
+  Edit a site
+
+  Edits a site's description, default channel, properties and maintenance
+  windows.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+// helpTemplate returns a sample YAML configuration and guidelines for editing site configurations.
+func (c *cmdSiteEdit) helpTemplate() string {
+	return `### This is a YAML representation of the configuration.
+### Any line starting with a '# will be ignored.
+###
+### A sample configuration looks like:
+###
+### description: ""
+### channel: stable
+### properties: {}
+### config:
+###   maintenance_windows:
+###   - weekdays: ["saturday"]
+###     start_time: "22:00"
+###     end_time: "04:00"
+###     timezone: UTC
+`
+}
+
+func (c *cmdSiteEdit) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdSiteEdit) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// If stdin isn't a terminal, read text from it.
+	if !termios.IsTerminal(environment.GetStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		newdata := api.SitePut{}
+		err = yaml.Unmarshal(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		err = c.ocClient.UpdateSite(cmd.Context(), name, newdata)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	site, err := c.ocClient.GetSite(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(b)
+	encoder.SetIndent(2)
+	err = encoder.Encode(site.SitePut)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := editor.Spawn("", append([]byte(c.helpTemplate()+"\n\n"), b.Bytes()...))
+	if err != nil {
+		return err
+	}
+
+	for {
+		newdata := api.SitePut{}
+		err = yaml.Unmarshal(content, &newdata)
+		if err == nil {
+			err = c.ocClient.UpdateSite(cmd.Context(), name, newdata)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Config parsing error: %s\n", err)
+			fmt.Println("Press enter to open the editor again or ctrl+c to abort change")
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = editor.Spawn("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// Remove site.
+type cmdSiteRemove struct {
+	ocClient *client.OperationsCenterClient
+}
+
+func (c *cmdSiteRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "remove <name>"
+	cmd.Short = "Remove a site"
+	cmd.Long = `Description:
+  Remove a site
+
+  Removes a site from the operations center. A site can only be removed, if
+  no clusters or servers are assigned to it.
+`
+
+	cmd.PreRunE = c.validateArgsAndFlags
+	cmd.RunE = c.run
+
+	return cmd
+}
+
+func (c *cmdSiteRemove) validateArgsAndFlags(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := validate.Args(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cmdSiteRemove) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	err := c.ocClient.DeleteSite(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -94,6 +94,10 @@ type cmdTokenAdd struct {
 	validDuration time.Duration
 	description   string
 	channel       string
+
+	flagClusterJoin            string
+	flagSkipPostJoinOperations bool
+	flagCopyServicesConfig     bool
 }
 
 func (c *cmdTokenAdd) Command() *cobra.Command {
@@ -110,6 +114,9 @@ func (c *cmdTokenAdd) Command() *cobra.Command {
 	cmd.Flags().DurationVar(&c.validDuration, "lifetime", 24*30*time.Hour, "Lifetime of the token as duration")
 	cmd.Flags().StringVar(&c.description, "description", "", "Description of the token")
 	cmd.Flags().StringVar(&c.channel, "channel", "", "Update channel, servers provisioned using this token should be assigned to")
+	cmd.Flags().StringVar(&c.flagClusterJoin, "cluster-join", "", "Cluster, servers provisioned using this token automatically join as soon as they are ready")
+	cmd.Flags().BoolVar(&c.flagSkipPostJoinOperations, "skip-post-join", false, "if this flag is provided, the post join configuration operations are skipped for the servers joining the cluster")
+	cmd.Flags().BoolVar(&c.flagCopyServicesConfig, "copy-services-config", false, "if this flag is provided, the services config is copied from an existing cluster member to the servers joining the cluster")
 
 	cmd.PreRunE = c.validateArgsAndFlags
 	cmd.RunE = c.run
@@ -132,6 +139,10 @@ func (c *cmdTokenAdd) validateArgsAndFlags(cmd *cobra.Command, args []string) er
 		return fmt.Errorf(`Value for flag "--lifetime" needs to be greater or equal to 1`)
 	}
 
+	if c.flagClusterJoin == "" && (c.flagSkipPostJoinOperations || c.flagCopyServicesConfig) {
+		return fmt.Errorf(`Flags "--skip-post-join" and "--copy-services-config" require flag "--cluster-join"`)
+	}
+
 	return nil
 }
 
@@ -141,6 +152,11 @@ func (c *cmdTokenAdd) run(cmd *cobra.Command, args []string) error {
 		ExpireAt:      time.Now().Add(c.validDuration),
 		Description:   c.description,
 		Channel:       c.channel,
+		ClusterJoin: api.TokenClusterJoin{
+			Cluster:                c.flagClusterJoin,
+			SkipPostJoinOperations: c.flagSkipPostJoinOperations,
+			CopyServicesConfig:     c.flagCopyServicesConfig,
+		},
 	})
 	if err != nil {
 		return err
@@ -317,6 +333,11 @@ func (c *cmdTokenShow) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Expire At: %s\n", token.ExpireAt.Truncate(time.Second).String())
 		fmt.Printf("Channel: %s\n", token.Channel)
 		fmt.Printf("Description: %s\n", token.Description)
+		if token.ClusterJoin.Cluster != "" {
+			fmt.Printf("Cluster Join: %s\n", token.ClusterJoin.Cluster)
+			fmt.Printf("  Skip Post Join Operations: %t\n", token.ClusterJoin.SkipPostJoinOperations)
+			fmt.Printf("  Copy Services Config: %t\n", token.ClusterJoin.CopyServicesConfig)
+		}
 	}
 
 	return nil
@@ -61,6 +61,8 @@ func (c *CmdWarning) Command() *cobra.Command {
 type cmdWarningList struct {
 	ocClient *client.OperationsCenterClient
 
+	flagFilterSite string
+
 	flagFormat string
 }
 
@@ -72,6 +74,7 @@ func (c *cmdWarningList) Command() *cobra.Command {
   List all available warnings.
 `
 
+	cmd.Flags().StringVar(&c.flagFilterSite, "site", "", "site name to filter for")
 	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", `Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)
 
 	cmd.PreRunE = c.validateArgsAndFlags
@@ -91,7 +94,7 @@ func (c *cmdWarningList) validateArgsAndFlags(cmd *cobra.Command, args []string)
 }
 
 func (c *cmdWarningList) run(cmd *cobra.Command, args []string) error {
-	warnings, err := c.ocClient.GetWarnings(cmd.Context())
+	warnings, err := c.ocClient.GetWarningsBySite(cmd.Context(), c.flagFilterSite)
 	if err != nil {
 		return err
 	}
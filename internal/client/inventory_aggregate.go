@@ -11,7 +11,18 @@ import (
 )
 
 func (c OperationsCenterClient) GetWithFilterInventoryAggregates(ctx context.Context, filter inventory.InventoryAggregateFilter) ([]api.InventoryAggregate, error) {
+	return c.GetWithFilterInventoryAggregatesBySite(ctx, "", filter)
+}
+
+// GetWithFilterInventoryAggregatesBySite returns the inventory aggregates
+// matching the filter, limited to the clusters of the given site. If site is
+// empty, the result is not limited to a site.
+func (c OperationsCenterClient) GetWithFilterInventoryAggregatesBySite(ctx context.Context, site string, filter inventory.InventoryAggregateFilter) ([]api.InventoryAggregate, error) {
 	query := url.Values{}
+	if site != "" {
+		query.Add("site", site)
+	}
+
 	query = filter.AppendToURLValues(query)
 
 	response, err := c.DoRequest(ctx, http.MethodGet, "/inventory/query", query, nil)
@@ -65,6 +65,48 @@ func (c OperationsCenterClient) CreateCluster(ctx context.Context, cluster api.C
 	return nil
 }
 
+func (c OperationsCenterClient) AdoptCluster(ctx context.Context, cluster api.ClusterAdoptPost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/clusters/:adopt", nil, cluster)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) ApplyClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+	response, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/clusters/:apply", nil, spec)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	plan := api.ClusterSpecPlan{}
+	err = json.Unmarshal(response.Metadata, &plan)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	return plan, nil
+}
+
+func (c OperationsCenterClient) PlanClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+	query := url.Values{}
+	query.Add("dry-run", "true")
+
+	response, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/clusters/:apply", query, spec)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	plan := api.ClusterSpecPlan{}
+	err = json.Unmarshal(response.Metadata, &plan)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	return plan, nil
+}
+
 func (c OperationsCenterClient) UpdateCluster(ctx context.Context, name string, cluster api.ClusterPut) error {
 	_, err := c.DoRequest(ctx, http.MethodPut, path.Join("/provisioning/clusters", name), nil, cluster)
 	if err != nil {
@@ -153,6 +195,18 @@ func (c OperationsCenterClient) RemoveServerFromCluster(ctx context.Context, nam
 	return nil
 }
 
+func (c OperationsCenterClient) ReplaceServerInCluster(ctx context.Context, name string, serverName string, replacementServerName string) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":replace-server"), nil, api.ClusterReplaceServerPost{
+		ServerName:            serverName,
+		ReplacementServerName: replacementServerName,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c OperationsCenterClient) ResyncCluster(ctx context.Context, name string) error {
 	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":resync-inventory"), nil, nil)
 	if err != nil {
@@ -189,6 +243,24 @@ func (c OperationsCenterClient) LaunchClusterWideUpdate(ctx context.Context, nam
 	return nil
 }
 
+func (c OperationsCenterClient) PlanClusterWideUpdate(ctx context.Context, name string, request api.ClusterUpdatePost) (api.ClusterUpdatePlan, error) {
+	query := url.Values{}
+	query.Add("dry-run", "true")
+
+	response, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":update"), query, request)
+	if err != nil {
+		return api.ClusterUpdatePlan{}, err
+	}
+
+	plan := api.ClusterUpdatePlan{}
+	err = json.Unmarshal(response.Metadata, &plan)
+	if err != nil {
+		return api.ClusterUpdatePlan{}, err
+	}
+
+	return plan, nil
+}
+
 func (c OperationsCenterClient) LaunchClusterWideReboot(ctx context.Context, name string) error {
 	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":reboot"), nil, nil)
 	if err != nil {
@@ -198,6 +270,33 @@ func (c OperationsCenterClient) LaunchClusterWideReboot(ctx context.Context, nam
 	return nil
 }
 
+func (c OperationsCenterClient) PlanClusterWideReboot(ctx context.Context, name string) (api.ClusterUpdatePlan, error) {
+	query := url.Values{}
+	query.Add("dry-run", "true")
+
+	response, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":reboot"), query, nil)
+	if err != nil {
+		return api.ClusterUpdatePlan{}, err
+	}
+
+	plan := api.ClusterUpdatePlan{}
+	err = json.Unmarshal(response.Metadata, &plan)
+	if err != nil {
+		return api.ClusterUpdatePlan{}, err
+	}
+
+	return plan, nil
+}
+
+func (c OperationsCenterClient) LaunchClusterWideFirmwareUpdate(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":firmware-update"), nil, update)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c OperationsCenterClient) CancelClusterWideOperation(ctx context.Context, name string) error {
 	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/clusters", name, ":cancel-operation"), nil, nil)
 	if err != nil {
@@ -207,6 +306,51 @@ func (c OperationsCenterClient) CancelClusterWideOperation(ctx context.Context,
 	return nil
 }
 
+func (c OperationsCenterClient) GetClusterWideOperations(ctx context.Context, name string) ([]api.ClusterOperation, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/clusters", name, "operations"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []api.ClusterOperation{}
+	err = json.Unmarshal(response.Metadata, &operations)
+	if err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
+
+func (c OperationsCenterClient) GetClusterTemplateDrift(ctx context.Context, name string) (api.ClusterTemplateDrift, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/clusters", name, "template-drift"), nil, nil)
+	if err != nil {
+		return api.ClusterTemplateDrift{}, err
+	}
+
+	drift := api.ClusterTemplateDrift{}
+	err = json.Unmarshal(response.Metadata, &drift)
+	if err != nil {
+		return api.ClusterTemplateDrift{}, err
+	}
+
+	return drift, nil
+}
+
+func (c OperationsCenterClient) GetClusterConsistency(ctx context.Context, name string) (api.ClusterConsistency, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/clusters", name, "consistency"), nil, nil)
+	if err != nil {
+		return api.ClusterConsistency{}, err
+	}
+
+	consistency := api.ClusterConsistency{}
+	err = json.Unmarshal(response.Metadata, &consistency)
+	if err != nil {
+		return api.ClusterConsistency{}, err
+	}
+
+	return consistency, nil
+}
+
 func (c OperationsCenterClient) GetClusterArtifacts(ctx context.Context, clusterName string) ([]api.ClusterArtifact, error) {
 	query := url.Values{}
 	query.Add("recursion", "1")
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func (c OperationsCenterClient) GetClusterBlueprints(ctx context.Context) ([]api.ClusterBlueprint, error) {
+	query := url.Values{}
+	query.Add("recursion", "1")
+
+	response, err := c.DoRequest(ctx, http.MethodGet, "/provisioning/cluster-blueprints", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterBlueprints := []api.ClusterBlueprint{}
+	err = json.Unmarshal(response.Metadata, &clusterBlueprints)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterBlueprints, nil
+}
+
+func (c OperationsCenterClient) GetClusterBlueprint(ctx context.Context, name string) (api.ClusterBlueprint, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/cluster-blueprints", name), nil, nil)
+	if err != nil {
+		return api.ClusterBlueprint{}, err
+	}
+
+	clusterBlueprint := api.ClusterBlueprint{}
+	err = json.Unmarshal(response.Metadata, &clusterBlueprint)
+	if err != nil {
+		return api.ClusterBlueprint{}, err
+	}
+
+	return clusterBlueprint, nil
+}
+
+func (c OperationsCenterClient) CreateClusterBlueprint(ctx context.Context, clusterBlueprint api.ClusterBlueprintPost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/cluster-blueprints", nil, clusterBlueprint)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) UpdateClusterBlueprint(ctx context.Context, name string, clusterBlueprint api.ClusterBlueprintPut) error {
+	_, err := c.DoRequest(ctx, http.MethodPut, path.Join("/provisioning/cluster-blueprints", name), nil, clusterBlueprint)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) DeleteClusterBlueprint(ctx context.Context, name string) error {
+	_, err := c.DoRequest(ctx, http.MethodDelete, path.Join("/provisioning/cluster-blueprints", name), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 
 	"github.com/FuturFusion/operations-center/shared/api"
 )
@@ -60,3 +61,61 @@ func (c OperationsCenterClient) DeleteClusterTemplate(ctx context.Context, name
 
 	return nil
 }
+
+func (c OperationsCenterClient) GetClusterTemplateRevisions(ctx context.Context, name string) ([]api.ClusterTemplateRevision, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/cluster-templates", name, "revisions"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []api.ClusterTemplateRevision{}
+	err = json.Unmarshal(response.Metadata, &revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (c OperationsCenterClient) GetClusterTemplateRevision(ctx context.Context, name string, revision int64) (api.ClusterTemplateRevision, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/cluster-templates", name, "revisions", strconv.FormatInt(revision, 10)), nil, nil)
+	if err != nil {
+		return api.ClusterTemplateRevision{}, err
+	}
+
+	clusterTemplateRevision := api.ClusterTemplateRevision{}
+	err = json.Unmarshal(response.Metadata, &clusterTemplateRevision)
+	if err != nil {
+		return api.ClusterTemplateRevision{}, err
+	}
+
+	return clusterTemplateRevision, nil
+}
+
+// GetClusterTemplateRevisionDiff returns the differences between two
+// revisions of a cluster template. A revision of 0 selects the respective
+// default, which is the current revision for toRevision and the revision
+// preceding toRevision for fromRevision.
+func (c OperationsCenterClient) GetClusterTemplateRevisionDiff(ctx context.Context, name string, fromRevision int64, toRevision int64) (api.ClusterTemplateRevisionDiff, error) {
+	query := url.Values{}
+	if fromRevision > 0 {
+		query.Add("from", strconv.FormatInt(fromRevision, 10))
+	}
+
+	if toRevision > 0 {
+		query.Add("to", strconv.FormatInt(toRevision, 10))
+	}
+
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/cluster-templates", name, "diff"), query, nil)
+	if err != nil {
+		return api.ClusterTemplateRevisionDiff{}, err
+	}
+
+	diff := api.ClusterTemplateRevisionDiff{}
+	err = json.Unmarshal(response.Metadata, &diff)
+	if err != nil {
+		return api.ClusterTemplateRevisionDiff{}, err
+	}
+
+	return diff, nil
+}
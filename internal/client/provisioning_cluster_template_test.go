@@ -2,6 +2,7 @@ package client_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -300,3 +301,80 @@ func Test_DeleteClusterTemplate(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetClusterTemplateRevisions(t *testing.T) {
+	socketClient, unauthorizedHTTPClient, db := daemonSetup(t)
+
+	tests := []struct {
+		name       string
+		client     client.OperationsCenterClient
+		dbSeedFunc func(t *testing.T)
+
+		tcNameArg string
+
+		assertErr  require.ErrorAssertionFunc
+		assertFunc func(t *testing.T, result []api.ClusterTemplateRevision)
+	}{
+		{
+			name:   "success - two revisions",
+			client: socketClient,
+			dbSeedFunc: func(t *testing.T) {
+				t.Helper()
+
+				clusterTemplate := provisioning.ClusterTemplate{
+					Name:        "foo",
+					Description: "first",
+					Revision:    1,
+				}
+
+				_, err := entities.CreateClusterTemplate(t.Context(), db, clusterTemplate)
+				require.NoError(t, err)
+				_, err = entities.CreateClusterTemplateRevision(t.Context(), db, provisioning.NewClusterTemplateRevision(clusterTemplate, time.Now().UTC()))
+				require.NoError(t, err)
+
+				clusterTemplate.Description = "second"
+				clusterTemplate.Revision = 2
+				_, err = entities.CreateClusterTemplateRevision(t.Context(), db, provisioning.NewClusterTemplateRevision(clusterTemplate, time.Now().UTC()))
+				require.NoError(t, err)
+			},
+
+			tcNameArg: "foo",
+
+			assertErr: require.NoError,
+			assertFunc: func(t *testing.T, result []api.ClusterTemplateRevision) {
+				t.Helper()
+
+				require.Len(t, result, 2)
+				require.Equal(t, int64(1), result[0].Revision)
+				require.Equal(t, "first", result[0].Description)
+				require.Equal(t, int64(2), result[1].Revision)
+				require.Equal(t, "second", result[1].Description)
+			},
+		},
+		{
+			name:       "error - not authorized",
+			client:     unauthorizedHTTPClient,
+			dbSeedFunc: noop,
+
+			tcNameArg: "foo",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrNotAuthenticated)
+			},
+			assertFunc: func(t *testing.T, result []api.ClusterTemplateRevision) {
+				t.Helper()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.dbSeedFunc(t)
+
+			result, err := tc.client.GetClusterTemplateRevisions(t.Context(), tc.tcNameArg)
+
+			tc.assertErr(t, err)
+			tc.assertFunc(t, result)
+		})
+	}
+}
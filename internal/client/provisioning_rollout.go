@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func (c OperationsCenterClient) GetRollouts(ctx context.Context) ([]api.Rollout, error) {
+	query := url.Values{}
+	query.Add("recursion", "1")
+
+	response, err := c.DoRequest(ctx, http.MethodGet, "/provisioning/rollouts", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts := []api.Rollout{}
+	err = json.Unmarshal(response.Metadata, &rollouts)
+	if err != nil {
+		return nil, err
+	}
+
+	return rollouts, nil
+}
+
+func (c OperationsCenterClient) GetRollout(ctx context.Context, name string) (api.Rollout, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/rollouts", name), nil, nil)
+	if err != nil {
+		return api.Rollout{}, err
+	}
+
+	rollout := api.Rollout{}
+	err = json.Unmarshal(response.Metadata, &rollout)
+	if err != nil {
+		return api.Rollout{}, err
+	}
+
+	return rollout, nil
+}
+
+func (c OperationsCenterClient) CreateRollout(ctx context.Context, rollout api.RolloutPost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/rollouts", nil, rollout)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) CancelRollout(ctx context.Context, name string) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/rollouts", name, ":cancel"), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) DeleteRollout(ctx context.Context, name string) error {
+	_, err := c.DoRequest(ctx, http.MethodDelete, path.Join("/provisioning/rollouts", name), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
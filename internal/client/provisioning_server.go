@@ -111,6 +111,151 @@ func (c OperationsCenterClient) GetServerChangelog(ctx context.Context, name str
 	return changelog, nil
 }
 
+func (c OperationsCenterClient) GetServerHardwareHistory(ctx context.Context, name string) ([]api.ServerHardwareSnapshot, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/servers", name, "hardware/history"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []api.ServerHardwareSnapshot{}
+	err = json.Unmarshal(response.Metadata, &snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+func (c OperationsCenterClient) GetServerDiskHealth(ctx context.Context, name string) ([]api.ServerDiskHealth, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/servers", name, "disk-health"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	diskHealth := []api.ServerDiskHealth{}
+	err = json.Unmarshal(response.Metadata, &diskHealth)
+	if err != nil {
+		return nil, err
+	}
+
+	return diskHealth, nil
+}
+
+func (c OperationsCenterClient) GetDiskHealth(ctx context.Context, filter provisioning.ServerFilter, status *api.DiskHealthStatus) ([]api.ServerDiskHealth, error) {
+	query := url.Values{}
+	if filter.Cluster != nil {
+		query.Add("cluster", *filter.Cluster)
+	}
+
+	if filter.Site != nil {
+		query.Add("site", *filter.Site)
+	}
+
+	if filter.Name != nil {
+		query.Add("server", *filter.Name)
+	}
+
+	if status != nil {
+		query.Add("status", status.String())
+	}
+
+	response, err := c.DoRequest(ctx, http.MethodGet, "/provisioning/disk-health", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	diskHealth := []api.ServerDiskHealth{}
+	err = json.Unmarshal(response.Metadata, &diskHealth)
+	if err != nil {
+		return nil, err
+	}
+
+	return diskHealth, nil
+}
+
+func (c OperationsCenterClient) BulkActionServers(ctx context.Context, request api.ServerBulkActionPost) ([]api.ServerBulkActionResult, error) {
+	response, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/servers/:bulk-action", nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []api.ServerBulkActionResult{}
+	err = json.Unmarshal(response.Metadata, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (c OperationsCenterClient) PlanBulkActionServers(ctx context.Context, request api.ServerBulkActionPost) ([]api.ServerBulkActionResult, error) {
+	query := url.Values{}
+	query.Add("dry-run", "true")
+
+	response, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/servers/:bulk-action", query, request)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []api.ServerBulkActionResult{}
+	err = json.Unmarshal(response.Metadata, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (c OperationsCenterClient) DecommissionServer(ctx context.Context, name string, decommission api.ServerDecommissionPost) (api.ServerDecommission, error) {
+	response, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/servers", name, ":decommission"), nil, decommission)
+	if err != nil {
+		return api.ServerDecommission{}, err
+	}
+
+	result := api.ServerDecommission{}
+	err = json.Unmarshal(response.Metadata, &result)
+	if err != nil {
+		return api.ServerDecommission{}, err
+	}
+
+	return result, nil
+}
+
+func (c OperationsCenterClient) GetServerDecommissions(ctx context.Context, server string) ([]api.ServerDecommission, error) {
+	query := url.Values{}
+	if server != "" {
+		query.Add("server", server)
+	}
+
+	response, err := c.DoRequest(ctx, http.MethodGet, "/provisioning/decommissions", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	decommissions := []api.ServerDecommission{}
+	err = json.Unmarshal(response.Metadata, &decommissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return decommissions, nil
+}
+
+func (c OperationsCenterClient) GetServerDecommission(ctx context.Context, id string) (api.ServerDecommission, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/decommissions", id), nil, nil)
+	if err != nil {
+		return api.ServerDecommission{}, err
+	}
+
+	decommission := api.ServerDecommission{}
+	err = json.Unmarshal(response.Metadata, &decommission)
+	if err != nil {
+		return api.ServerDecommission{}, err
+	}
+
+	return decommission, nil
+}
+
 func (c OperationsCenterClient) EvacuateServerSystem(ctx context.Context, name string, force bool) error {
 	query := url.Values{}
 	if force {
@@ -327,6 +472,15 @@ func (c OperationsCenterClient) ApplyBIOSAttributes(ctx context.Context, name st
 	return nil
 }
 
+func (c OperationsCenterClient) BMCInstallServer(ctx context.Context, name string, install api.ServerBMCInstallPost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/servers", name, "bmc/:install"), nil, install)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c OperationsCenterClient) GetServerBMCBIOSAttributes(ctx context.Context, name string) ([]api.BIOSAttribute, error) {
 	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/servers", name, "bmc/bios-attributes"), nil, nil)
 	if err != nil {
@@ -358,6 +512,30 @@ func (c OperationsCenterClient) GetServerBMCBIOSAttributeAcceptableValues(ctx co
 	return values, nil
 }
 
+func (c OperationsCenterClient) GetServerBMCFirmware(ctx context.Context, name string) (map[string]api.BMCFirmware, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/servers", name, "bmc/firmware"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	firmware := map[string]api.BMCFirmware{}
+	err = json.Unmarshal(response.Metadata, &firmware)
+	if err != nil {
+		return nil, err
+	}
+
+	return firmware, nil
+}
+
+func (c OperationsCenterClient) UpdateServerBMCFirmware(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, path.Join("/provisioning/servers", name, "bmc/firmware/:update"), nil, update)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c OperationsCenterClient) GetServerBMCLogSources(ctx context.Context, name string) ([]string, error) {
 	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/servers", name, "bmc/logs"), nil, nil)
 	if err != nil {
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func (c OperationsCenterClient) GetSites(ctx context.Context) ([]api.Site, error) {
+	query := url.Values{}
+	query.Add("recursion", "1")
+
+	response, err := c.DoRequest(ctx, http.MethodGet, "/provisioning/sites", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sites := []api.Site{}
+	err = json.Unmarshal(response.Metadata, &sites)
+	if err != nil {
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+func (c OperationsCenterClient) GetSite(ctx context.Context, name string) (api.Site, error) {
+	response, err := c.DoRequest(ctx, http.MethodGet, path.Join("/provisioning/sites", name), nil, nil)
+	if err != nil {
+		return api.Site{}, err
+	}
+
+	site := api.Site{}
+	err = json.Unmarshal(response.Metadata, &site)
+	if err != nil {
+		return api.Site{}, err
+	}
+
+	return site, nil
+}
+
+func (c OperationsCenterClient) CreateSite(ctx context.Context, site api.SitePost) error {
+	_, err := c.DoRequest(ctx, http.MethodPost, "/provisioning/sites", nil, site)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) UpdateSite(ctx context.Context, name string, site api.SitePut) error {
+	_, err := c.DoRequest(ctx, http.MethodPut, path.Join("/provisioning/sites", name), nil, site)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c OperationsCenterClient) DeleteSite(ctx context.Context, name string) error {
+	_, err := c.DoRequest(ctx, http.MethodDelete, path.Join("/provisioning/sites", name), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -4,13 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"path"
 
 	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 func (c OperationsCenterClient) GetWarnings(ctx context.Context) ([]api.Warning, error) {
-	response, err := c.DoRequest(ctx, http.MethodGet, "/warnings", nil, nil)
+	return c.GetWarningsBySite(ctx, "")
+}
+
+// GetWarningsBySite returns the warnings related to the clusters and servers
+// of the given site. If site is empty, all warnings are returned.
+func (c OperationsCenterClient) GetWarningsBySite(ctx context.Context, site string) ([]api.Warning, error) {
+	query := url.Values{}
+	if site != "" {
+		query.Add("site", site)
+	}
+
+	response, err := c.DoRequest(ctx, http.MethodGet, "/warnings", query, nil)
 	if err != nil {
 		return nil, err
 	}
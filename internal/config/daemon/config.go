@@ -466,6 +466,12 @@ func validate(ctx context.Context, cfg config) error {
 		return err
 	}
 
+	switch cfg.Settings.ClusterProvisioningBackend {
+	case "", system.ClusterProvisioningBackendTerraform, system.ClusterProvisioningBackendIncus:
+	default:
+		return domain.NewValidationErrf(`Invalid config, "settings.cluster_provisioning_backend" property is expected to be one of %q or %q`, system.ClusterProvisioningBackendTerraform, system.ClusterProvisioningBackendIncus)
+	}
+
 	isOIDCChanged := globalConfigInstance.Security.OIDC != cfg.Security.OIDC
 	isOpenFGAChanged := globalConfigInstance.Security.OpenFGA != cfg.Security.OpenFGA
 
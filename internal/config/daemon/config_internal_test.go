@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/environment/mock"
 	"github.com/FuturFusion/operations-center/internal/lifecycle"
 	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
@@ -362,6 +363,22 @@ func Test_validate(t *testing.T) {
 
 			assertErr: require.Error,
 		},
+		{
+			name: "invalid cluster provisioning backend",
+			cfg: config{
+				Settings: system.Settings{
+					SettingsPut: system.SettingsPut{
+						ClusterProvisioningBackend: "invalid", // invalid backend
+					},
+				},
+				Updates: defaultUpdates,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
 		{
 			name: "settings validation signal error",
 			cfg: config{
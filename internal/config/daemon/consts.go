@@ -34,6 +34,18 @@ const (
 	// Interval in which servers in pending state are queried.
 	PendingServerPollInterval = 1 * time.Minute
 
+	// Interval in which clusters with enabled auto update policy are checked
+	// for matching updates.
+	ClusterAutoUpdateInterval = 15 * time.Minute
+
+	// Interval in which clusters are checked for drift from the Terraform
+	// configuration, they have been provisioned with.
+	ClusterConfigurationDriftCheckInterval = 24 * time.Hour
+
+	// Interval in which the settings of the members of the clusters are checked
+	// for consistency.
+	ClusterConsistencyCheckInterval = 6 * time.Hour
+
 	// Interval in which servers in updating state are queried.
 	UpdatingServerPollInterval = 30 * time.Second
 
@@ -58,6 +70,10 @@ const (
 	// Interval in which the BMC data is resynced.
 	BMCDataResyncInterval = 1 * time.Hour
 
+	// Interval in which install images, which are no longer served for an
+	// installation via BMC, are removed.
+	BMCInstallMediaPruneInterval = 5 * time.Minute
+
 	// ACME server certificate renew interval.
 	ACMEServerCertificateRenewInterval = 24 * time.Hour
 
@@ -1,6 +1,7 @@
 package redfish
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"crypto/tls"
@@ -15,6 +16,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"slices"
 	"sort"
@@ -302,6 +304,13 @@ func (r redfish) GetData(ctx context.Context, server provisioning.Server) (api.B
 
 	bmcData.VirtualMedia = virtualMedia
 
+	firmware, _, err := getFirmwareInventory(client)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to get firmware inventory of BMC", logger.Err(err))
+	}
+
+	bmcData.Firmware = firmware
+
 	return bmcData, nil
 }
 
@@ -668,6 +677,474 @@ func (r redfish) ServerSetLocationIndicator(ctx context.Context, server provisio
 	return nil
 }
 
+// SecureEraseDrives triggers the secure erase of all the drives reported by
+// the BMC. The returned map is keyed by the serial number of the drive (or
+// its ID, if the serial number is not reported) and holds the task monitor
+// for each drive, which is erased asynchronously.
+func (r redfish) SecureEraseDrives(ctx context.Context, server provisioning.Server) (map[string]*provisioning.BMCTaskMonitor, error) {
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	system, err := getFirstSystem(client)
+	if err != nil {
+		return nil, fmt.Errorf("Failed get BMC system: %w", err)
+	}
+
+	storages, err := system.Storage()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get storage from BMC: %w", err)
+	}
+
+	taskMonitors := map[string]*provisioning.BMCTaskMonitor{}
+	for _, storage := range storages {
+		drives, err := storage.Drives()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get drives of storage %q from BMC: %w", storage.ID, err)
+		}
+
+		for _, drive := range drives {
+			name := cmp.Or(drive.SerialNumber, drive.ID)
+
+			taskMonitor, err := drive.SecureErase(0, schemas.BlockEraseDataSanitizationType)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to secure erase drive %q via BMC: %w", name, err)
+			}
+
+			// If taskMonitor is nil, the BMC completed synchronously.
+			if taskMonitor == nil {
+				taskMonitors[name] = nil
+				continue
+			}
+
+			taskMonitors[name] = &provisioning.BMCTaskMonitor{
+				URI: taskMonitor.TaskMonitor,
+			}
+		}
+	}
+
+	if len(taskMonitors) == 0 {
+		return nil, fmt.Errorf("No drives reported by the BMC: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return taskMonitors, nil
+}
+
+// InsertVirtualMedia inserts the image served from the given URL into the
+// virtual media slot with the given ID ("<service>:<redfish-id>", as reported
+// in the BMC data). If the ID is empty, the first slot supporting CD or DVD
+// media is used. Media already inserted into the slot is ejected first. The ID
+// of the slot, the image has been inserted into, is returned.
+func (r redfish) InsertVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (insertedVirtualMediaID string, _ *provisioning.BMCTaskMonitor, _ error) {
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	id, virtualMedia, err := findVirtualMedia(client, virtualMediaID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if ptr.From(virtualMedia.Inserted) {
+		_, err = virtualMedia.EjectMedia()
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed to eject media from virtual media %q via BMC: %w", id, err)
+		}
+	}
+
+	taskMonitor, err := virtualMedia.InsertMedia(&schemas.VirtualMediaInsertMediaParameters{
+		Image:          imageURL,
+		Inserted:       ptr.To(true),
+		WriteProtected: ptr.To(true),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to insert media into virtual media %q via BMC: %w", id, err)
+	}
+
+	// If taskMonitor is nil, the BMC completed synchronously.
+	if taskMonitor == nil {
+		return id, nil, nil
+	}
+
+	return id, &provisioning.BMCTaskMonitor{
+		URI: taskMonitor.TaskMonitor,
+	}, nil
+}
+
+func (r redfish) EjectVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string) error {
+	if virtualMediaID == "" {
+		return fmt.Errorf("Virtual media ID cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	_, virtualMedia, err := findVirtualMedia(client, virtualMediaID)
+	if err != nil {
+		return err
+	}
+
+	if !ptr.From(virtualMedia.Inserted) {
+		return nil
+	}
+
+	_, err = virtualMedia.EjectMedia()
+	if err != nil {
+		return fmt.Errorf("Failed to eject media from virtual media %q via BMC: %w", virtualMediaID, err)
+	}
+
+	return nil
+}
+
+// SetOneTimeBootFromVirtualMedia makes the server boot from the virtual CD or
+// DVD on its next boot. Subsequent boots use the regular boot order again.
+func (r redfish) SetOneTimeBootFromVirtualMedia(ctx context.Context, server provisioning.Server) error {
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	system, err := getFirstSystem(client)
+	if err != nil {
+		return fmt.Errorf("Failed get BMC system: %w", err)
+	}
+
+	err = system.SetBoot(&schemas.Boot{
+		BootSourceOverrideEnabled: schemas.OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  schemas.CdBootSource,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to set one-time boot from virtual media via BMC: %w", err)
+	}
+
+	return nil
+}
+
+// findVirtualMedia returns the virtual media slot with the given ID. If the ID
+// is empty, the first slot supporting CD or DVD media is returned, where the
+// slots of the system take precedence over the ones of the manager.
+func findVirtualMedia(client *gofish.APIClient, virtualMediaID string) (id string, _ *schemas.VirtualMedia, _ error) {
+	system, err := getFirstSystem(client)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed get BMC system: %w", err)
+	}
+
+	systemVirtualMedia, err := system.VirtualMedia()
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to get virtual media of BMC system: %w", err)
+	}
+
+	var managerVirtualMedia []*schemas.VirtualMedia
+
+	// Not all BMCs report a manager, in which case only the virtual media of
+	// the system are considered.
+	manager, err := getFirstManager(client)
+	if err == nil {
+		managerVirtualMedia, err = manager.VirtualMedia()
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed to get virtual media of BMC manager: %w", err)
+		}
+	}
+
+	for service, virtualMedia := range map[string][]*schemas.VirtualMedia{
+		"system":  systemVirtualMedia,
+		"manager": managerVirtualMedia,
+	} {
+		for _, vm := range virtualMedia {
+			if virtualMediaID == service+":"+vm.ID {
+				return virtualMediaID, vm, nil
+			}
+		}
+	}
+
+	if virtualMediaID != "" {
+		return "", nil, fmt.Errorf("Virtual media %q not found: %w", virtualMediaID, domain.ErrNotFound)
+	}
+
+	for _, vm := range systemVirtualMedia {
+		if isOpticalVirtualMedia(vm) {
+			return "system:" + vm.ID, vm, nil
+		}
+	}
+
+	for _, vm := range managerVirtualMedia {
+		if isOpticalVirtualMedia(vm) {
+			return "manager:" + vm.ID, vm, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("No virtual media supporting CD or DVD media found: %w", domain.ErrNotFound)
+}
+
+func isOpticalVirtualMedia(vm *schemas.VirtualMedia) bool {
+	return slices.Contains(vm.MediaTypes, schemas.CDVirtualMediaType) || slices.Contains(vm.MediaTypes, schemas.DVDVirtualMediaType)
+}
+
+// FirmwareInventory returns the firmware reported in the inventory of the
+// update service of the BMC, keyed by the ID of the inventory entry.
+func (r redfish) FirmwareInventory(ctx context.Context, server provisioning.Server) (map[string]api.BMCFirmware, error) {
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	firmware, _, err := getFirmwareInventory(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return firmware, nil
+}
+
+// UpdateFirmwareFromURI instructs the BMC to fetch the firmware image from the
+// given URI and to apply it using the SimpleUpdate action of the update
+// service. Targets are IDs of the firmware inventory. If no targets are given,
+// the BMC determines the components the image is applied to.
+func (r redfish) UpdateFirmwareFromURI(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	updateService, err := getUpdateService(client)
+	if err != nil {
+		return nil, err
+	}
+
+	targetURIs, err := resolveFirmwareTargets(updateService, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	taskMonitor, err := updateService.SimpleUpdate(&schemas.UpdateServiceSimpleUpdateParameters{
+		ImageURI: imageURI,
+		Targets:  targetURIs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to trigger firmware update via BMC: %w", err)
+	}
+
+	// If taskMonitor is nil, the BMC completed synchronously.
+	if taskMonitor == nil {
+		return nil, nil
+	}
+
+	return &provisioning.BMCTaskMonitor{
+		URI: taskMonitor.TaskMonitor,
+	}, nil
+}
+
+// UpdateFirmwareFromFile pushes the firmware image from the given local file
+// to the BMC using the multipart HTTP push URI of the update service. Targets
+// are IDs of the firmware inventory. If no targets are given, the BMC
+// determines the components the image is applied to.
+func (r redfish) UpdateFirmwareFromFile(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+	client, logout, err := r.getClient(ctx, server)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to BMC %q: %w", server.BMCConfig.Endpoint, err)
+	}
+
+	defer logout()
+
+	updateService, err := getUpdateService(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateService.MultipartHTTPPushURI == "" {
+		return nil, fmt.Errorf("BMC does not support multipart HTTP push of firmware images: %w", domain.ErrOperationNotPermitted)
+	}
+
+	targetURIs, err := resolveFirmwareTargets(updateService, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	updateParameters, err := json.Marshal(struct {
+		Targets []string `json:"Targets"`
+	}{
+		Targets: targetURIs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal firmware update parameters: %w", err)
+	}
+
+	imageFile, err := os.Open(imageFilename)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open firmware image %q: %w", imageFilename, err)
+	}
+
+	defer imageFile.Close()
+
+	// gofish sends *os.File values as file parts and all other readers as
+	// JSON parts of the multipart request.
+	resp, err := client.PostMultipart(updateService.MultipartHTTPPushURI, map[string]io.Reader{
+		"UpdateParameters": strings.NewReader(string(updateParameters)),
+		"UpdateFile":       imageFile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to push firmware image to BMC: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	location := resp.Header.Get("Location")
+	if resp.StatusCode != http.StatusAccepted || location == "" {
+		return nil, nil
+	}
+
+	return &provisioning.BMCTaskMonitor{
+		URI: location,
+	}, nil
+}
+
+func getUpdateService(client *gofish.APIClient) (*schemas.UpdateService, error) {
+	updateService, err := client.Service.UpdateService()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get BMC update service: %w", err)
+	}
+
+	if updateService == nil {
+		return nil, fmt.Errorf("BMC does not provide an update service: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return updateService, nil
+}
+
+// getFirmwareInventory returns the firmware inventory of the BMC converted to
+// the API representation together with the OData IDs of the inventory
+// entries, keyed by the ID of the inventory entry.
+func getFirmwareInventory(client *gofish.APIClient) (map[string]api.BMCFirmware, map[string]string, error) {
+	updateService, err := getUpdateService(client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return convertFirmwareInventory(updateService)
+}
+
+func convertFirmwareInventory(updateService *schemas.UpdateService) (map[string]api.BMCFirmware, map[string]string, error) {
+	inventory, err := updateService.FirmwareInventory()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get firmware inventory of BMC: %w", err)
+	}
+
+	firmware := make(map[string]api.BMCFirmware, len(inventory))
+	odataIDs := make(map[string]string, len(inventory))
+	for _, item := range inventory {
+		// Some BMCs (e.g. Dell iDRAC) list the previously installed as well as
+		// staged, but not yet installed firmware next to the installed one.
+		if strings.HasPrefix(item.ID, "Previous-") || strings.HasPrefix(item.ID, "Available-") {
+			continue
+		}
+
+		firmware[item.ID] = api.BMCFirmware{
+			ID:           item.ID,
+			Name:         item.Name,
+			Component:    firmwareComponent(item),
+			Version:      item.Version,
+			Manufacturer: item.Manufacturer,
+			ReleaseDate:  item.ReleaseDate,
+			Updateable:   item.Updateable,
+		}
+
+		odataIDs[item.ID] = item.ODataID
+	}
+
+	return firmware, odataIDs, nil
+}
+
+// resolveFirmwareTargets translates the given IDs of the firmware inventory
+// into the URIs expected as targets by the update service.
+func resolveFirmwareTargets(updateService *schemas.UpdateService, targets []string) ([]string, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	_, odataIDs, err := convertFirmwareInventory(updateService)
+	if err != nil {
+		return nil, err
+	}
+
+	targetURIs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		odataID, ok := odataIDs[target]
+		if !ok {
+			return nil, fmt.Errorf("Firmware %q not found in firmware inventory of BMC: %w", target, domain.ErrNotFound)
+		}
+
+		targetURIs = append(targetURIs, odataID)
+	}
+
+	return targetURIs, nil
+}
+
+// firmwareComponent classifies an entry of the firmware inventory. The
+// related items reference the resources, the firmware is installed on, and
+// are preferred if reported by the BMC. Otherwise, the classification falls
+// back to well known names of the components.
+func firmwareComponent(item *schemas.SoftwareInventory) api.BMCFirmwareComponent {
+	var raw struct {
+		RelatedItem []struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"RelatedItem"`
+	}
+
+	// The related items are only used to improve the classification, so
+	// errors are ignored.
+	_ = json.Unmarshal(item.RawData, &raw)
+
+	for _, related := range raw.RelatedItem {
+		switch {
+		case strings.HasSuffix(related.ODataID, "/Bios"):
+			return api.BMCFirmwareComponentBIOS
+		case strings.Contains(related.ODataID, "/Managers/"):
+			return api.BMCFirmwareComponentBMC
+		case strings.Contains(related.ODataID, "/NetworkAdapters/"):
+			return api.BMCFirmwareComponentNIC
+		case strings.Contains(related.ODataID, "/Storage/"), strings.Contains(related.ODataID, "/StorageControllers/"):
+			return api.BMCFirmwareComponentRAID
+		}
+	}
+
+	name := strings.ToLower(item.ID + " " + item.Name)
+
+	containsAny := func(s string, substrings ...string) bool {
+		return slices.ContainsFunc(substrings, func(substring string) bool {
+			return strings.Contains(s, substring)
+		})
+	}
+
+	switch {
+	case containsAny(name, "bios", "uefi"):
+		return api.BMCFirmwareComponentBIOS
+	case containsAny(name, "bmc", "idrac", "ilo", "remote access controller"):
+		return api.BMCFirmwareComponentBMC
+	case containsAny(name, "nic", "network", "ethernet"):
+		return api.BMCFirmwareComponentNIC
+	case containsAny(name, "raid", "perc", "storage controller"):
+		return api.BMCFirmwareComponentRAID
+	}
+
+	return api.BMCFirmwareComponentOther
+}
+
 const defaultWaitForTaskRetryAfter = 2 * time.Second
 
 func (r redfish) WaitForTask(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error {
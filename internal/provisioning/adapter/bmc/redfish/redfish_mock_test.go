@@ -77,6 +77,7 @@ type mockRedfishServer struct {
 type mockRedfishRoute struct {
 	statusCode int
 	body       string
+	location   string
 }
 
 const defaultResetActionInfoBody = `{
@@ -112,6 +113,10 @@ func newMockRedfishHandler(cfg mockRedfishServer, gotBody *[]byte) http.HandlerF
 	return func(w http.ResponseWriter, r *http.Request) {
 		route, ok := cfg.extraRoutes[r.URL.Path]
 		if ok {
+			if route.location != "" {
+				w.Header().Set("Location", route.location)
+			}
+
 			w.WriteHeader(route.statusCode)
 			_, _ = w.Write([]byte(route.body))
 
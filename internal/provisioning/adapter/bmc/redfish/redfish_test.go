@@ -9,6 +9,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -2132,6 +2134,513 @@ func TestRedfish_ServerSetLocationIndicator(t *testing.T) {
 	}
 }
 
+const (
+	secureEraseSystemBody = `{
+  "@odata.id": "/redfish/v1/Systems/1",
+  "Id": "1",
+  "Storage": { "@odata.id": "/redfish/v1/Systems/1/Storage" }
+}`
+
+	secureEraseStorageCollectionBody = `{
+  "Members@odata.count": 1,
+  "Members": [
+    { "@odata.id": "/redfish/v1/Systems/1/Storage/1" }
+  ]
+}`
+
+	secureEraseStorageBody = `{
+  "@odata.id": "/redfish/v1/Systems/1/Storage/1",
+  "Id": "1",
+  "Drives": [
+    { "@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/1" },
+    { "@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/2" }
+  ]
+}`
+
+	secureEraseDrive1Body = `{
+  "@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/1",
+  "Id": "1",
+  "SerialNumber": "S1",
+  "Actions": {
+    "#Drive.SecureErase": { "target": "/redfish/v1/Systems/1/Storage/1/Drives/1/Actions/Drive.SecureErase" }
+  }
+}`
+
+	secureEraseDrive2Body = `{
+  "@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/2",
+  "Id": "2",
+  "Actions": {
+    "#Drive.SecureErase": { "target": "/redfish/v1/Systems/1/Storage/1/Drives/2/Actions/Drive.SecureErase" }
+  }
+}`
+)
+
+func TestRedfish_SecureEraseDrives(t *testing.T) {
+	secureEraseExtraRoutes := func(drive1EraseStatusCode int) map[string]mockRedfishRoute {
+		return map[string]mockRedfishRoute{
+			"/redfish/v1/Systems/1/Storage":                                      {statusCode: http.StatusOK, body: secureEraseStorageCollectionBody},
+			"/redfish/v1/Systems/1/Storage/1":                                    {statusCode: http.StatusOK, body: secureEraseStorageBody},
+			"/redfish/v1/Systems/1/Storage/1/Drives/1":                           {statusCode: http.StatusOK, body: secureEraseDrive1Body},
+			"/redfish/v1/Systems/1/Storage/1/Drives/2":                           {statusCode: http.StatusOK, body: secureEraseDrive2Body},
+			"/redfish/v1/Systems/1/Storage/1/Drives/1/Actions/Drive.SecureErase": {statusCode: drive1EraseStatusCode, location: "/redfish/v1/TaskMonitor/1"},
+			"/redfish/v1/Systems/1/Storage/1/Drives/2/Actions/Drive.SecureErase": {statusCode: http.StatusNoContent},
+		}
+	}
+
+	tests := []struct {
+		name string
+
+		serviceRootStatusCode int
+		systemsStatusCode     int
+		systemsBody           string
+		systemStatusCode      int
+		systemBody            string
+		extraRoutes           map[string]mockRedfishRoute
+
+		want      map[string]*provisioning.BMCTaskMonitor
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            secureEraseSystemBody,
+			extraRoutes:           secureEraseExtraRoutes(http.StatusAccepted),
+
+			want: map[string]*provisioning.BMCTaskMonitor{
+				"S1": {URI: "/redfish/v1/TaskMonitor/1"},
+				"2":  nil,
+			},
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - failed to connect to BMC",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - no drives reported",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            resetSystemBody,
+
+			assertErr: errassert.OperationNotPermittedErrorContains("No drives reported by the BMC"),
+		},
+		{
+			name: "error - secure erase failed",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            secureEraseSystemBody,
+			extraRoutes:           secureEraseExtraRoutes(http.StatusInternalServerError),
+
+			assertErr: errassert.Contains(`Failed to secure erase drive "S1" via BMC`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				systemsStatusCode:     tc.systemsStatusCode,
+				systemsBody:           tc.systemsBody,
+				systemStatusCode:      tc.systemStatusCode,
+				systemBody:            tc.systemBody,
+				extraRoutes:           tc.extraRoutes,
+			}, nil)
+
+			client := redfish.New()
+			got, err := client.SecureEraseDrives(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}})
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+const (
+	virtualMediaSystemBody = `{
+  "@odata.id": "/redfish/v1/Systems/1",
+  "Id": "1",
+  "VirtualMedia": { "@odata.id": "/redfish/v1/Systems/1/VirtualMedia" }
+}`
+
+	virtualMediaSystemCollectionBody = `{
+  "Members@odata.count": 2,
+  "Members": [
+    { "@odata.id": "/redfish/v1/Systems/1/VirtualMedia/1" },
+    { "@odata.id": "/redfish/v1/Systems/1/VirtualMedia/2" }
+  ]
+}`
+
+	virtualMediaSystemMember1Body = `{
+  "@odata.id": "/redfish/v1/Systems/1/VirtualMedia/1",
+  "Id": "1",
+  "Inserted": false,
+  "MediaTypes": ["USBStick"],
+  "Actions": {
+    "#VirtualMedia.InsertMedia": { "target": "/redfish/v1/Systems/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia" },
+    "#VirtualMedia.EjectMedia": { "target": "/redfish/v1/Systems/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia" }
+  }
+}`
+
+	virtualMediaSystemMember2Body = `{
+  "@odata.id": "/redfish/v1/Systems/1/VirtualMedia/2",
+  "Id": "2",
+  "Inserted": false,
+  "MediaTypes": ["CD", "DVD"],
+  "Actions": {
+    "#VirtualMedia.InsertMedia": { "target": "/redfish/v1/Systems/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia" },
+    "#VirtualMedia.EjectMedia": { "target": "/redfish/v1/Systems/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia" }
+  }
+}`
+
+	virtualMediaManagersBody = `{
+  "Members@odata.count": 1,
+  "Members": [
+    { "@odata.id": "/redfish/v1/Managers/1" }
+  ]
+}`
+
+	virtualMediaManagerBody = `{
+  "@odata.id": "/redfish/v1/Managers/1",
+  "Id": "1",
+  "VirtualMedia": { "@odata.id": "/redfish/v1/Managers/1/VirtualMedia" }
+}`
+
+	virtualMediaManagerCollectionBody = `{
+  "Members@odata.count": 1,
+  "Members": [
+    { "@odata.id": "/redfish/v1/Managers/1/VirtualMedia/1" }
+  ]
+}`
+
+	virtualMediaManagerMember1Body = `{
+  "@odata.id": "/redfish/v1/Managers/1/VirtualMedia/1",
+  "Id": "1",
+  "Inserted": true,
+  "Image": "http://example.com/image.iso",
+  "MediaTypes": ["CD"],
+  "Actions": {
+    "#VirtualMedia.InsertMedia": { "target": "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia" },
+    "#VirtualMedia.EjectMedia": { "target": "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia" }
+  }
+}`
+)
+
+func virtualMediaExtraRoutes(insertStatusCode int, ejectStatusCode int) map[string]mockRedfishRoute {
+	return map[string]mockRedfishRoute{
+		"/redfish/v1/Systems/1/VirtualMedia":                                     {statusCode: http.StatusOK, body: virtualMediaSystemCollectionBody},
+		"/redfish/v1/Systems/1/VirtualMedia/1":                                   {statusCode: http.StatusOK, body: virtualMediaSystemMember1Body},
+		"/redfish/v1/Systems/1/VirtualMedia/2":                                   {statusCode: http.StatusOK, body: virtualMediaSystemMember2Body},
+		"/redfish/v1/Systems/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia":  {statusCode: insertStatusCode, location: "/redfish/v1/TaskMonitor/1"},
+		"/redfish/v1/Managers":                                                   {statusCode: http.StatusOK, body: virtualMediaManagersBody},
+		"/redfish/v1/Managers/1":                                                 {statusCode: http.StatusOK, body: virtualMediaManagerBody},
+		"/redfish/v1/Managers/1/VirtualMedia":                                    {statusCode: http.StatusOK, body: virtualMediaManagerCollectionBody},
+		"/redfish/v1/Managers/1/VirtualMedia/1":                                  {statusCode: http.StatusOK, body: virtualMediaManagerMember1Body},
+		"/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia": {statusCode: insertStatusCode},
+		"/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia":  {statusCode: ejectStatusCode},
+	}
+}
+
+func TestRedfish_InsertVirtualMedia(t *testing.T) {
+	tests := []struct {
+		name           string
+		virtualMediaID string
+
+		serviceRootStatusCode int
+		systemsStatusCode     int
+		systemsBody           string
+		systemStatusCode      int
+		systemBody            string
+		extraRoutes           map[string]mockRedfishRoute
+
+		wantVirtualMediaID string
+		wantTaskMonitor    *provisioning.BMCTaskMonitor
+		assertErr          require.ErrorAssertionFunc
+	}{
+		{
+			name: "success - first CD or DVD slot",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            virtualMediaSystemBody,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusAccepted, http.StatusNoContent),
+
+			wantVirtualMediaID: "system:2",
+			wantTaskMonitor: &provisioning.BMCTaskMonitor{
+				URI: "/redfish/v1/TaskMonitor/1",
+			},
+			assertErr: require.NoError,
+		},
+		{
+			name:           "success - given slot with media inserted",
+			virtualMediaID: "manager:1",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            virtualMediaSystemBody,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusNoContent, http.StatusNoContent),
+
+			wantVirtualMediaID: "manager:1",
+			assertErr:          require.NoError,
+		},
+		{
+			name: "error - failed to connect to BMC",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - no BMC systems found",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetEmptySystemsBody,
+
+			assertErr: require.Error,
+		},
+		{
+			name:           "error - virtual media not found",
+			virtualMediaID: "system:3",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            virtualMediaSystemBody,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusNoContent, http.StatusNoContent),
+
+			assertErr: errassert.NotFoundErrorContains(`Virtual media "system:3" not found`),
+		},
+		{
+			name: "error - no virtual media supporting CD or DVD media",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            resetSystemBody,
+			extraRoutes: map[string]mockRedfishRoute{
+				"/redfish/v1/Managers": {statusCode: http.StatusOK, body: resetEmptySystemsBody},
+			},
+
+			assertErr: errassert.NotFoundErrorContains("No virtual media supporting CD or DVD media found"),
+		},
+		{
+			name:           "error - eject failed",
+			virtualMediaID: "manager:1",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            virtualMediaSystemBody,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusNoContent, http.StatusInternalServerError),
+
+			assertErr: errassert.Contains(`Failed to eject media from virtual media "manager:1" via BMC`),
+		},
+		{
+			name: "error - insert failed",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemStatusCode:      http.StatusOK,
+			systemBody:            virtualMediaSystemBody,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusInternalServerError, http.StatusNoContent),
+
+			assertErr: errassert.Contains(`Failed to insert media into virtual media "system:2" via BMC`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				systemsStatusCode:     tc.systemsStatusCode,
+				systemsBody:           tc.systemsBody,
+				systemStatusCode:      tc.systemStatusCode,
+				systemBody:            tc.systemBody,
+				extraRoutes:           tc.extraRoutes,
+			}, nil)
+
+			client := redfish.New()
+			gotVirtualMediaID, gotTaskMonitor, err := client.InsertVirtualMedia(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}}, tc.virtualMediaID, "https://operations-center.local:8443/install.iso")
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantVirtualMediaID, gotVirtualMediaID)
+			require.Equal(t, tc.wantTaskMonitor, gotTaskMonitor)
+		})
+	}
+}
+
+func TestRedfish_EjectVirtualMedia(t *testing.T) {
+	tests := []struct {
+		name           string
+		virtualMediaID string
+
+		serviceRootStatusCode int
+		extraRoutes           map[string]mockRedfishRoute
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:           "success - media inserted",
+			virtualMediaID: "manager:1",
+
+			serviceRootStatusCode: http.StatusOK,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusNoContent, http.StatusNoContent),
+
+			assertErr: require.NoError,
+		},
+		{
+			name:           "success - no media inserted",
+			virtualMediaID: "system:2",
+
+			serviceRootStatusCode: http.StatusOK,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusNoContent, http.StatusInternalServerError), // Would fail, if an eject was performed.
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - virtual media ID empty",
+
+			assertErr: errassert.OperationNotPermittedErrorContains("Virtual media ID cannot be empty"),
+		},
+		{
+			name:           "error - failed to connect to BMC",
+			virtualMediaID: "manager:1",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name:           "error - eject failed",
+			virtualMediaID: "manager:1",
+
+			serviceRootStatusCode: http.StatusOK,
+			extraRoutes:           virtualMediaExtraRoutes(http.StatusNoContent, http.StatusInternalServerError),
+
+			assertErr: errassert.Contains(`Failed to eject media from virtual media "manager:1" via BMC`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				systemsStatusCode:     http.StatusOK,
+				systemsBody:           resetSystemsBody,
+				systemStatusCode:      http.StatusOK,
+				systemBody:            virtualMediaSystemBody,
+				extraRoutes:           tc.extraRoutes,
+			}, nil)
+
+			client := redfish.New()
+			err := client.EjectVirtualMedia(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}}, tc.virtualMediaID)
+
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestRedfish_SetOneTimeBootFromVirtualMedia(t *testing.T) {
+	tests := []struct {
+		name string
+
+		serviceRootStatusCode int
+		systemsStatusCode     int
+		systemsBody           string
+		systemPatchStatusCode int
+
+		wantPatchBody string
+		assertErr     require.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemPatchStatusCode: http.StatusNoContent,
+
+			wantPatchBody: `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`,
+			assertErr:     require.NoError,
+		},
+		{
+			name: "error - failed to connect to BMC",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - no BMC systems found",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetEmptySystemsBody,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - update failed",
+
+			serviceRootStatusCode: http.StatusOK,
+			systemsStatusCode:     http.StatusOK,
+			systemsBody:           resetSystemsBody,
+			systemPatchStatusCode: http.StatusInternalServerError,
+
+			wantPatchBody: `{"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`,
+			assertErr:     errassert.Contains("Failed to set one-time boot from virtual media via BMC"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotPatchBody []byte
+
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				systemsStatusCode:     tc.systemsStatusCode,
+				systemsBody:           tc.systemsBody,
+				systemStatusCode:      http.StatusOK,
+				systemBody:            resetSystemBody,
+				systemPatchStatusCode: tc.systemPatchStatusCode,
+				gotSystemPatchBody:    &gotPatchBody,
+			}, nil)
+
+			client := redfish.New()
+			err := client.SetOneTimeBootFromVirtualMedia(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}})
+
+			tc.assertErr(t, err)
+
+			if tc.wantPatchBody != "" {
+				require.JSONEq(t, tc.wantPatchBody, string(gotPatchBody))
+			} else {
+				require.Empty(t, gotPatchBody)
+			}
+		})
+	}
+}
+
 func TestRedfish_WaitForTask(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -3844,3 +4353,383 @@ func TestRedfish_ApplyBIOSAttributes(t *testing.T) {
 		})
 	}
 }
+
+const (
+	firmwareServiceRootBody = `{
+  "Id": "RootService",
+  "Name": "Root Service",
+  "RedfishVersion": "1.16.0",
+  "Vendor": "Dell",
+  "Systems": { "@odata.id": "/redfish/v1/Systems" },
+  "Managers": { "@odata.id": "/redfish/v1/Managers" },
+  "UpdateService": { "@odata.id": "/redfish/v1/UpdateService" }
+}`
+
+	firmwareUpdateServiceBody = `{
+  "@odata.id": "/redfish/v1/UpdateService",
+  "Id": "UpdateService",
+  "Name": "Update Service",
+  "MultipartHttpPushUri": "/redfish/v1/UpdateService/MultipartUpload",
+  "FirmwareInventory": { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory" },
+  "Actions": {
+    "#UpdateService.SimpleUpdate": { "target": "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" }
+  }
+}`
+
+	firmwareUpdateServiceWithoutMultipartPushBody = `{
+  "@odata.id": "/redfish/v1/UpdateService",
+  "Id": "UpdateService",
+  "Name": "Update Service",
+  "FirmwareInventory": { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory" }
+}`
+
+	firmwareInventoryCollectionBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory",
+  "Members": [
+    { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-159-1.2.3" },
+    { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.10.30.00" },
+    { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.5.7" },
+    { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-104226-52.26.0-5179" },
+    { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-0-1.0" },
+    { "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Previous-159-1.2.2" }
+  ]
+}`
+
+	firmwareInventoryBIOSBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-159-1.2.3",
+  "Id": "Installed-159-1.2.3",
+  "Name": "BIOS",
+  "Version": "1.2.3",
+  "Manufacturer": "Dell Inc.",
+  "ReleaseDate": "2025-01-02T00:00:00Z",
+  "Updateable": true,
+  "RelatedItem": [{ "@odata.id": "/redfish/v1/Systems/1/Bios" }]
+}`
+
+	firmwareInventoryBMCBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.10.30.00",
+  "Id": "Installed-25227-7.10.30.00",
+  "Name": "Integrated Dell Remote Access Controller",
+  "Version": "7.10.30.00",
+  "Updateable": true
+}`
+
+	firmwareInventoryNICBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.5.7",
+  "Id": "Installed-101548-22.5.7",
+  "Name": "Broadcom Adv. Dual 25Gb Ethernet",
+  "Version": "22.5.7",
+  "Updateable": true,
+  "RelatedItem": [{ "@odata.id": "/redfish/v1/Chassis/1/NetworkAdapters/NIC.Integrated.1" }]
+}`
+
+	firmwareInventoryRAIDBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-104226-52.26.0-5179",
+  "Id": "Installed-104226-52.26.0-5179",
+  "Name": "PERC H755 Front",
+  "Version": "52.26.0-5179",
+  "Updateable": true
+}`
+
+	firmwareInventoryOtherBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Installed-0-1.0",
+  "Id": "Installed-0-1.0",
+  "Name": "System CPLD",
+  "Version": "1.0",
+  "Updateable": false
+}`
+
+	firmwareInventoryPreviousBody = `{
+  "@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/Previous-159-1.2.2",
+  "Id": "Previous-159-1.2.2",
+  "Name": "BIOS",
+  "Version": "1.2.2",
+  "Updateable": true
+}`
+)
+
+func firmwareExtraRoutes(updateServiceBody string, simpleUpdateStatusCode int, multipartUploadStatusCode int) map[string]mockRedfishRoute {
+	return map[string]mockRedfishRoute{
+		"/redfish/v1/":                                {statusCode: http.StatusOK, body: firmwareServiceRootBody},
+		"/redfish/v1/UpdateService":                   {statusCode: http.StatusOK, body: updateServiceBody},
+		"/redfish/v1/UpdateService/FirmwareInventory": {statusCode: http.StatusOK, body: firmwareInventoryCollectionBody},
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-159-1.2.3":           {statusCode: http.StatusOK, body: firmwareInventoryBIOSBody},
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.10.30.00":    {statusCode: http.StatusOK, body: firmwareInventoryBMCBody},
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-101548-22.5.7":       {statusCode: http.StatusOK, body: firmwareInventoryNICBody},
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-104226-52.26.0-5179": {statusCode: http.StatusOK, body: firmwareInventoryRAIDBody},
+		"/redfish/v1/UpdateService/FirmwareInventory/Installed-0-1.0":               {statusCode: http.StatusOK, body: firmwareInventoryOtherBody},
+		"/redfish/v1/UpdateService/FirmwareInventory/Previous-159-1.2.2":            {statusCode: http.StatusOK, body: firmwareInventoryPreviousBody},
+		"/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate":              {statusCode: simpleUpdateStatusCode, location: "/redfish/v1/TaskMonitor/1"},
+		"/redfish/v1/UpdateService/MultipartUpload":                                 {statusCode: multipartUploadStatusCode, location: "/redfish/v1/TaskMonitor/1"},
+	}
+}
+
+func TestRedfish_FirmwareInventory(t *testing.T) {
+	tests := []struct {
+		name string
+
+		serviceRootStatusCode int
+		extraRoutes           map[string]mockRedfishRoute
+
+		assertErr    require.ErrorAssertionFunc
+		wantFirmware map[string]api.BMCFirmware
+	}{
+		{
+			name: "success",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: require.NoError,
+			wantFirmware: map[string]api.BMCFirmware{
+				"Installed-159-1.2.3": {
+					ID:           "Installed-159-1.2.3",
+					Name:         "BIOS",
+					Component:    api.BMCFirmwareComponentBIOS,
+					Version:      "1.2.3",
+					Manufacturer: "Dell Inc.",
+					ReleaseDate:  "2025-01-02T00:00:00Z",
+					Updateable:   true,
+				},
+				"Installed-25227-7.10.30.00": {
+					ID:         "Installed-25227-7.10.30.00",
+					Name:       "Integrated Dell Remote Access Controller",
+					Component:  api.BMCFirmwareComponentBMC,
+					Version:    "7.10.30.00",
+					Updateable: true,
+				},
+				"Installed-101548-22.5.7": {
+					ID:         "Installed-101548-22.5.7",
+					Name:       "Broadcom Adv. Dual 25Gb Ethernet",
+					Component:  api.BMCFirmwareComponentNIC,
+					Version:    "22.5.7",
+					Updateable: true,
+				},
+				"Installed-104226-52.26.0-5179": {
+					ID:         "Installed-104226-52.26.0-5179",
+					Name:       "PERC H755 Front",
+					Component:  api.BMCFirmwareComponentRAID,
+					Version:    "52.26.0-5179",
+					Updateable: true,
+				},
+				"Installed-0-1.0": {
+					ID:        "Installed-0-1.0",
+					Name:      "System CPLD",
+					Component: api.BMCFirmwareComponentOther,
+					Version:   "1.0",
+				},
+			},
+		},
+		{
+			name: "error - failed to connect to BMC",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - no update service",
+
+			serviceRootStatusCode: http.StatusOK,
+
+			assertErr: errassert.OperationNotPermittedErrorContains("BMC does not provide an update service"),
+		},
+		{
+			name: "error - firmware inventory",
+
+			extraRoutes: map[string]mockRedfishRoute{
+				"/redfish/v1/":                                {statusCode: http.StatusOK, body: firmwareServiceRootBody},
+				"/redfish/v1/UpdateService":                   {statusCode: http.StatusOK, body: firmwareUpdateServiceBody},
+				"/redfish/v1/UpdateService/FirmwareInventory": {statusCode: http.StatusInternalServerError},
+			},
+
+			assertErr: errassert.Contains("Failed to get firmware inventory of BMC"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				extraRoutes:           tc.extraRoutes,
+			}, nil)
+
+			client := redfish.New()
+			firmware, err := client.FirmwareInventory(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}})
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantFirmware, firmware)
+		})
+	}
+}
+
+func TestRedfish_UpdateFirmwareFromURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+
+		serviceRootStatusCode int
+		extraRoutes           map[string]mockRedfishRoute
+
+		assertErr       require.ErrorAssertionFunc
+		wantTaskMonitor *provisioning.BMCTaskMonitor
+	}{
+		{
+			name:    "success",
+			targets: []string{"Installed-159-1.2.3"},
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: require.NoError,
+			wantTaskMonitor: &provisioning.BMCTaskMonitor{
+				URI: "/redfish/v1/TaskMonitor/1",
+			},
+		},
+		{
+			name: "success - without targets, completed synchronously",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusNoContent, http.StatusAccepted),
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - failed to connect to BMC",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - no update service",
+
+			serviceRootStatusCode: http.StatusOK,
+
+			assertErr: errassert.OperationNotPermittedErrorContains("BMC does not provide an update service"),
+		},
+		{
+			name:    "error - target not found",
+			targets: []string{"Installed-1-1.0"},
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: errassert.NotFoundErrorContains(`Firmware "Installed-1-1.0" not found in firmware inventory of BMC`),
+		},
+		{
+			name: "error - simple update failed",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusInternalServerError, http.StatusAccepted),
+
+			assertErr: errassert.Contains("Failed to trigger firmware update via BMC"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				extraRoutes:           tc.extraRoutes,
+			}, nil)
+
+			client := redfish.New()
+			taskMonitor, err := client.UpdateFirmwareFromURI(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}}, "https://firmware.local/bios.exe", tc.targets)
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantTaskMonitor, taskMonitor)
+		})
+	}
+}
+
+func TestRedfish_UpdateFirmwareFromFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		imageFilename string
+		targets       []string
+
+		serviceRootStatusCode int
+		extraRoutes           map[string]mockRedfishRoute
+
+		assertErr       require.ErrorAssertionFunc
+		wantTaskMonitor *provisioning.BMCTaskMonitor
+	}{
+		{
+			name:          "success",
+			imageFilename: "bios.exe",
+			targets:       []string{"Installed-159-1.2.3"},
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: require.NoError,
+			wantTaskMonitor: &provisioning.BMCTaskMonitor{
+				URI: "/redfish/v1/TaskMonitor/1",
+			},
+		},
+		{
+			name:          "success - completed synchronously",
+			imageFilename: "bios.exe",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusOK),
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - failed to connect to BMC",
+
+			serviceRootStatusCode: http.StatusInternalServerError,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - multipart HTTP push not supported",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceWithoutMultipartPushBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: errassert.OperationNotPermittedErrorContains("BMC does not support multipart HTTP push of firmware images"),
+		},
+		{
+			name:          "error - target not found",
+			imageFilename: "bios.exe",
+			targets:       []string{"Installed-1-1.0"},
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: errassert.NotFoundErrorContains(`Firmware "Installed-1-1.0" not found in firmware inventory of BMC`),
+		},
+		{
+			name:          "error - image file not found",
+			imageFilename: "",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusAccepted),
+
+			assertErr: errassert.Contains("Failed to open firmware image"),
+		},
+		{
+			name:          "error - push failed",
+			imageFilename: "bios.exe",
+
+			extraRoutes: firmwareExtraRoutes(firmwareUpdateServiceBody, http.StatusAccepted, http.StatusInternalServerError),
+
+			assertErr: errassert.Contains("Failed to push firmware image to BMC"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := newMockRedfishServer(t, mockRedfishServer{
+				serviceRootStatusCode: tc.serviceRootStatusCode,
+				extraRoutes:           tc.extraRoutes,
+			}, nil)
+
+			imageFilename := filepath.Join(t.TempDir(), "missing.exe")
+			if tc.imageFilename != "" {
+				imageFilename = filepath.Join(t.TempDir(), tc.imageFilename)
+				err := os.WriteFile(imageFilename, []byte("firmware"), 0o600)
+				require.NoError(t, err)
+			}
+
+			client := redfish.New()
+			taskMonitor, err := client.UpdateFirmwareFromFile(t.Context(), provisioning.Server{BMCConfig: api.BMCConfig{Endpoint: svr.URL}}, imageFilename, tc.targets)
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantTaskMonitor, taskMonitor)
+		})
+	}
+}
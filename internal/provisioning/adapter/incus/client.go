@@ -692,6 +692,33 @@ func (c client) RestartApplication(ctx context.Context, server provisioning.Serv
 	return nil
 }
 
+// GetIncusResource performs a GET request against the Incus API of the given
+// server and returns the response metadata. Only paths below /1.0 are allowed.
+func (c client) GetIncusResource(ctx context.Context, server provisioning.Server, resourcePath string) (any, error) {
+	cleanPath := path.Clean("/" + resourcePath)
+	if cleanPath != "/1.0" && !strings.HasPrefix(cleanPath, "/1.0/") {
+		return nil, fmt.Errorf("Resource path %q is not part of the Incus API (/1.0)", resourcePath)
+	}
+
+	client, err := c.getClient(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, _, err := client.RawQuery(http.MethodGet, cleanPath, http.NoBody, "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get Incus resource %q on %q (%s): %w", cleanPath, server.Name, server.GetConnectionURL(), err)
+	}
+
+	var resource any
+	err = json.Unmarshal(resp.Metadata, &resource)
+	if err != nil {
+		return nil, fmt.Errorf("Unexpected response metadata while fetching Incus resource %q from %q (%s): %w", cleanPath, server.Name, server.GetConnectionURL(), err)
+	}
+
+	return resource, nil
+}
+
 func (c client) GetSystem(ctx context.Context, server provisioning.Server, resource string) (map[string]any, error) {
 	if strings.Contains(resource, "/") {
 		return nil, fmt.Errorf(`Resource name must not contain forward slashes ("/")`)
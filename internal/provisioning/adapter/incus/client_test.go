@@ -1899,6 +1899,56 @@ func TestClientServer(t *testing.T) {
 			},
 		},
 
+		{
+			name: "GetIncusResource",
+			clientCall: func(ctx context.Context, client clientPort, target provisioning.Server) (any, error) {
+				return client.GetIncusResource(ctx, target, "/1.0/cluster/members")
+			},
+			testCases: []methodTestCase{
+				{
+					name: "success",
+					response: []queue.Item[response]{
+						// GET /1.0/cluster/members
+						{
+							Value: response{
+								statusCode: http.StatusOK,
+								responseBody: []byte(`{
+  "metadata": [
+    "/1.0/cluster/members/server01"
+  ],
+  "status": "Success",
+  "status_code": 200,
+  "type": "sync"
+}`),
+							},
+						},
+					},
+
+					assertErr: require.NoError,
+					wantPaths: []string{"GET /1.0/cluster/members"},
+					assertResult: func(t *testing.T, res any) {
+						t.Helper()
+
+						require.Equal(t, []any{"/1.0/cluster/members/server01"}, res)
+					},
+				},
+				{
+					name: "error - unexpected http status code",
+					response: []queue.Item[response]{
+						// GET /1.0/cluster/members
+						{
+							Value: response{
+								statusCode: http.StatusInternalServerError,
+							},
+						},
+					},
+
+					assertErr:    require.Error,
+					wantPaths:    []string{"GET /1.0/cluster/members"},
+					assertResult: noResult,
+				},
+			},
+		},
 		{
 			name: "GetSystem",
 			clientCall: func(ctx context.Context, client clientPort, target provisioning.Server) (any, error) {
@@ -4924,7 +4974,13 @@ func setupCerts(t *testing.T) (caPool *x509.CertPool, certPEM string, keyPEM str
 func TestClient_input_validation(t *testing.T) {
 	client := incus.New("", "", nil)
 
-	_, err := client.GetSystem(t.Context(), provisioning.Server{}, "invalid/resource")
+	_, err := client.GetIncusResource(t.Context(), provisioning.Server{}, "/os/1.0/system")
+	require.ErrorContains(t, err, "is not part of the Incus API")
+
+	_, err = client.GetIncusResource(t.Context(), provisioning.Server{}, "/1.0/../os/1.0/system")
+	require.ErrorContains(t, err, "is not part of the Incus API")
+
+	_, err = client.GetSystem(t.Context(), provisioning.Server{}, "invalid/resource")
 	require.ErrorContains(t, err, "must not contain forward slashes")
 
 	err = client.UpdateSystem(t.Context(), provisioning.Server{}, "invalid/resource", nil)
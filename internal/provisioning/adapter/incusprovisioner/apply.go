@@ -0,0 +1,671 @@
+package incusprovisioner
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	incus "github.com/lxc/incus/v7/client"
+	incusapi "github.com/lxc/incus/v7/shared/api"
+	incustls "github.com/lxc/incus/v7/shared/tls"
+)
+
+// remoteStorageDrivers are the storage drivers, for which storage volumes are
+// shared between all the members of the cluster.
+var remoteStorageDrivers = []string{"ceph", "cephfs", "cephobject", "linstor", "lvmcluster", "truenas"}
+
+// apply converges the cluster towards the given configuration. Existing
+// resources are updated in place and the configuration is merged, such that
+// configuration, which is not part of the preseed, is preserved. This allows
+// apply to be re-run safely after a partial failure.
+func apply(client incus.InstanceServer, config clusterConfig) error {
+	members, err := clusterMembers(client)
+	if err != nil {
+		return err
+	}
+
+	preseed := config.preseed
+
+	err = applyProjects(client, preseed.Projects)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range preseed.StoragePools {
+		err = applyStoragePool(client, members, pool, config.nodeSpecificConfigKeys)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, network := range preseed.Networks {
+		err = applyNetwork(client, members, network, config.nodeSpecificConfigKeys)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !config.skipMeshNetwork {
+		err = applyMeshNetwork(client, members, config.clusterID, config.meshTunnelInterfaces)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range preseed.StorageVolumes {
+		err = applyStorageVolume(client, members, volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, profile := range preseed.Profiles {
+		err = applyProfile(client, profile)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = applyServer(client, members, preseed.ServerPut, config.nodeSpecificConfigKeys)
+	if err != nil {
+		return err
+	}
+
+	for _, clusterGroup := range preseed.ClusterGroups {
+		err = applyClusterGroup(client, clusterGroup)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, certificate := range preseed.Certificates {
+		err = applyCertificate(client, certificate)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clusterMembers returns the sorted names of the cluster members. All the
+// members are required to be online, since member specific configuration is
+// applied to each member individually.
+func clusterMembers(client incus.InstanceServer) ([]string, error) {
+	clusterMembers, err := client.GetClusterMembers()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get cluster members: %w", err)
+	}
+
+	members := make([]string, 0, len(clusterMembers))
+	for _, member := range clusterMembers {
+		if member.Status != "Online" {
+			return nil, fmt.Errorf("Cluster member %q is not online (status: %q)", member.ServerName, member.Status)
+		}
+
+		members = append(members, member.ServerName)
+	}
+
+	slices.Sort(members)
+
+	return members, nil
+}
+
+// splitConfig splits the config into the member specific and the global part,
+// based on the node specific config keys of the given entity.
+func splitConfig(nodeSpecificConfigKeys map[string]map[string]bool, config map[string]string, entity string) (specific map[string]string, global map[string]string) {
+	lookup := nodeSpecificConfigKeys[entity]
+
+	specific = map[string]string{}
+	global = map[string]string{}
+
+	for key, value := range config {
+		if lookup[key] {
+			specific[key] = value
+		} else {
+			global[key] = value
+		}
+	}
+
+	return specific, global
+}
+
+// mergeConfig merges the desired config into the current config. Keys, which
+// are only present in the current config, are preserved. Keys in ignore are
+// only set, if they are not yet present in the current config, because they
+// can not be changed after creation or are altered by Incus.
+func mergeConfig(current map[string]string, desired map[string]string, ignore ...string) (merged map[string]string, changed bool) {
+	merged = maps.Clone(current)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+
+	for key, value := range desired {
+		currentValue, ok := merged[key]
+		if ok && slices.Contains(ignore, key) {
+			continue
+		}
+
+		if ok && currentValue == value {
+			continue
+		}
+
+		merged[key] = value
+		changed = true
+	}
+
+	return merged, changed
+}
+
+func mergeDescription(current string, desired string) (string, bool) {
+	if desired == "" || current == desired {
+		return current, false
+	}
+
+	return desired, true
+}
+
+func isNotFound(err error) bool {
+	return incusapi.StatusErrorCheck(err, http.StatusNotFound)
+}
+
+func withProject(client incus.InstanceServer, project string) incus.InstanceServer {
+	if project == "" {
+		return client
+	}
+
+	return client.UseProject(project)
+}
+
+func applyProjects(client incus.InstanceServer, projects []incusapi.ProjectsPost) error {
+	for _, project := range projects {
+		current, etag, err := client.GetProject(project.Name)
+		if isNotFound(err) {
+			err = client.CreateProject(project)
+			if err != nil {
+				return fmt.Errorf("Failed to create project %q: %w", project.Name, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed to get project %q: %w", project.Name, err)
+		}
+
+		put := current.Writable()
+
+		var configChanged, descriptionChanged bool
+		put.Config, configChanged = mergeConfig(put.Config, project.Config)
+		put.Description, descriptionChanged = mergeDescription(put.Description, project.Description)
+		if !configChanged && !descriptionChanged {
+			continue
+		}
+
+		err = client.UpdateProject(project.Name, put, etag)
+		if err != nil {
+			return fmt.Errorf("Failed to update project %q: %w", project.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyStoragePool(client incus.InstanceServer, members []string, pool incusapi.StoragePoolsPost, nodeSpecificConfigKeys map[string]map[string]bool) error {
+	specific, global := splitConfig(nodeSpecificConfigKeys, pool.Config, "storage_"+pool.Driver)
+
+	current, etag, err := client.GetStoragePool(pool.Name)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Failed to get storage pool %q: %w", pool.Name, err)
+	}
+
+	// The storage pool is missing or has not yet been created on all the
+	// members.
+	if err != nil || current.Status == "Pending" {
+		for _, member := range members {
+			if current != nil && slices.Contains(current.Locations, member) {
+				continue
+			}
+
+			err = client.UseTarget(member).CreateStoragePool(incusapi.StoragePoolsPost{
+				Name:   pool.Name,
+				Driver: pool.Driver,
+				StoragePoolPut: incusapi.StoragePoolPut{
+					Config: specific,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to create storage pool %q on member %q: %w", pool.Name, member, err)
+			}
+		}
+
+		post := pool
+		post.Config = global
+		err = client.CreateStoragePool(post)
+		if err != nil {
+			return fmt.Errorf("Failed to create storage pool %q: %w", pool.Name, err)
+		}
+
+		return nil
+	}
+
+	// The source is changed by Incus during the creation of the storage pool.
+	for _, member := range members {
+		target := client.UseTarget(member)
+
+		memberPool, memberETag, err := target.GetStoragePool(pool.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to get storage pool %q on member %q: %w", pool.Name, member, err)
+		}
+
+		put := memberPool.Writable()
+
+		var changed bool
+		put.Config, changed = mergeConfig(put.Config, specific, "source")
+		if !changed {
+			continue
+		}
+
+		err = target.UpdateStoragePool(pool.Name, put, memberETag)
+		if err != nil {
+			return fmt.Errorf("Failed to update storage pool %q on member %q: %w", pool.Name, member, err)
+		}
+	}
+
+	put := current.Writable()
+
+	var configChanged, descriptionChanged bool
+	put.Config, configChanged = mergeConfig(put.Config, global, "source")
+	put.Description, descriptionChanged = mergeDescription(put.Description, pool.Description)
+	if !configChanged && !descriptionChanged {
+		return nil
+	}
+
+	err = client.UpdateStoragePool(pool.Name, put, etag)
+	if err != nil {
+		return fmt.Errorf("Failed to update storage pool %q: %w", pool.Name, err)
+	}
+
+	return nil
+}
+
+func applyNetwork(client incus.InstanceServer, members []string, network incusapi.InitNetworksProjectPost, nodeSpecificConfigKeys map[string]map[string]bool) error {
+	specific, global := splitConfig(nodeSpecificConfigKeys, network.Config, "network_"+network.Type)
+
+	memberConfigs := make(map[string]map[string]string, len(members))
+	for _, member := range members {
+		memberConfigs[member] = specific
+	}
+
+	return applyClusteredNetwork(withProject(client, network.Project), members, network.NetworksPost, memberConfigs, global)
+}
+
+// applyMeshNetwork applies the meshbr0 network, which is reserved for the
+// communication between the cluster members and therefore fully managed by
+// Operations Center.
+func applyMeshNetwork(client incus.InstanceServer, members []string, clusterID int64, meshTunnelInterfaces map[string]string) error {
+	memberConfigs := make(map[string]map[string]string, len(members))
+	for _, member := range members {
+		memberConfigs[member] = map[string]string{
+			"tunnel.mesh.interface": meshTunnelInterfaces[member],
+		}
+	}
+
+	subnet, err := randomMeshSubnet()
+	if err != nil {
+		return err
+	}
+
+	global := map[string]string{
+		"ipv4.address":         "none",
+		"ipv6.address":         subnet,
+		"ipv6.nat":             "true",
+		"tunnel.mesh.id":       strconv.FormatInt(999+clusterID, 10),
+		"tunnel.mesh.protocol": "vxlan",
+	}
+
+	network := incusapi.NetworksPost{
+		Name: "meshbr0",
+		Type: "bridge",
+		NetworkPut: incusapi.NetworkPut{
+			Description: "Internal mesh network bridge",
+		},
+	}
+
+	// The subnet is randomly chosen on creation and must never change
+	// afterwards.
+	return applyClusteredNetwork(client, members, network, memberConfigs, global, "ipv6.address")
+}
+
+func randomMeshSubnet() (string, error) {
+	segments := make([]byte, 6)
+	_, err := rand.Read(segments)
+	if err != nil {
+		return "", fmt.Errorf("Failed to generate subnet for network meshbr0: %w", err)
+	}
+
+	return fmt.Sprintf("fd42:%x:%x:%x::/64",
+		binary.BigEndian.Uint16(segments[0:2]),
+		binary.BigEndian.Uint16(segments[2:4]),
+		binary.BigEndian.Uint16(segments[4:6]),
+	), nil
+}
+
+func applyClusteredNetwork(client incus.InstanceServer, members []string, network incusapi.NetworksPost, memberConfigs map[string]map[string]string, global map[string]string, ignore ...string) error {
+	current, etag, err := client.GetNetwork(network.Name)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Failed to get network %q: %w", network.Name, err)
+	}
+
+	// The network is missing or has not yet been created on all the members.
+	if err != nil || current.Status == "Pending" {
+		for _, member := range members {
+			if current != nil && slices.Contains(current.Locations, member) {
+				continue
+			}
+
+			err = client.UseTarget(member).CreateNetwork(incusapi.NetworksPost{
+				Name: network.Name,
+				Type: network.Type,
+				NetworkPut: incusapi.NetworkPut{
+					Config: memberConfigs[member],
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to create network %q on member %q: %w", network.Name, member, err)
+			}
+		}
+
+		post := network
+		post.Config = global
+		err = client.CreateNetwork(post)
+		if err != nil {
+			return fmt.Errorf("Failed to create network %q: %w", network.Name, err)
+		}
+
+		return nil
+	}
+
+	for _, member := range members {
+		target := client.UseTarget(member)
+
+		memberNetwork, memberETag, err := target.GetNetwork(network.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to get network %q on member %q: %w", network.Name, member, err)
+		}
+
+		put := memberNetwork.Writable()
+
+		var changed bool
+		put.Config, changed = mergeConfig(put.Config, memberConfigs[member], ignore...)
+		if !changed {
+			continue
+		}
+
+		err = target.UpdateNetwork(network.Name, put, memberETag)
+		if err != nil {
+			return fmt.Errorf("Failed to update network %q on member %q: %w", network.Name, member, err)
+		}
+	}
+
+	put := current.Writable()
+
+	var configChanged, descriptionChanged bool
+	put.Config, configChanged = mergeConfig(put.Config, global, ignore...)
+	put.Description, descriptionChanged = mergeDescription(put.Description, network.Description)
+	if !configChanged && !descriptionChanged {
+		return nil
+	}
+
+	err = client.UpdateNetwork(network.Name, put, etag)
+	if err != nil {
+		return fmt.Errorf("Failed to update network %q: %w", network.Name, err)
+	}
+
+	return nil
+}
+
+func applyStorageVolume(client incus.InstanceServer, members []string, volume incusapi.InitStorageVolumesProjectPost) error {
+	client = withProject(client, volume.Project)
+
+	volumeType := volume.Type
+	if volumeType == "" {
+		volumeType = "custom"
+	}
+
+	pool, _, err := client.GetStoragePool(volume.Pool)
+	if err != nil {
+		return fmt.Errorf("Failed to get storage pool %q of storage volume %q: %w", volume.Pool, volume.Name, err)
+	}
+
+	// Volumes on local storage pools are created on each member, volumes on
+	// remote storage pools only once for the whole cluster.
+	targets := []string{""}
+	if !slices.Contains(remoteStorageDrivers, pool.Driver) && len(members) > 0 {
+		targets = members
+	}
+
+	for _, member := range targets {
+		target := client
+		if member != "" {
+			target = client.UseTarget(member)
+		}
+
+		current, etag, err := target.GetStoragePoolVolume(volume.Pool, volumeType, volume.Name)
+		if isNotFound(err) {
+			post := volume.StorageVolumesPost
+			post.Type = volumeType
+
+			err = target.CreateStoragePoolVolume(volume.Pool, post)
+			if err != nil {
+				return fmt.Errorf("Failed to create storage volume %q in storage pool %q: %w", volume.Name, volume.Pool, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed to get storage volume %q in storage pool %q: %w", volume.Name, volume.Pool, err)
+		}
+
+		put := current.Writable()
+
+		var configChanged, descriptionChanged bool
+		put.Config, configChanged = mergeConfig(put.Config, volume.Config)
+		put.Description, descriptionChanged = mergeDescription(put.Description, volume.Description)
+		if !configChanged && !descriptionChanged {
+			continue
+		}
+
+		err = target.UpdateStoragePoolVolume(volume.Pool, volumeType, volume.Name, put, etag)
+		if err != nil {
+			return fmt.Errorf("Failed to update storage volume %q in storage pool %q: %w", volume.Name, volume.Pool, err)
+		}
+	}
+
+	return nil
+}
+
+func applyProfile(client incus.InstanceServer, profile incusapi.InitProfileProjectPost) error {
+	client = withProject(client, profile.Project)
+
+	current, etag, err := client.GetProfile(profile.Name)
+	if isNotFound(err) {
+		err = client.CreateProfile(profile.ProfilesPost)
+		if err != nil {
+			return fmt.Errorf("Failed to create profile %q: %w", profile.Name, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to get profile %q: %w", profile.Name, err)
+	}
+
+	put := current.Writable()
+
+	var configChanged, descriptionChanged, devicesChanged bool
+	put.Config, configChanged = mergeConfig(put.Config, profile.Config)
+	put.Description, descriptionChanged = mergeDescription(put.Description, profile.Description)
+
+	if put.Devices == nil {
+		put.Devices = map[string]map[string]string{}
+	}
+
+	for name, device := range profile.Devices {
+		if maps.Equal(put.Devices[name], device) {
+			continue
+		}
+
+		put.Devices[name] = device
+		devicesChanged = true
+	}
+
+	if !configChanged && !descriptionChanged && !devicesChanged {
+		return nil
+	}
+
+	err = client.UpdateProfile(profile.Name, put, etag)
+	if err != nil {
+		return fmt.Errorf("Failed to update profile %q: %w", profile.Name, err)
+	}
+
+	return nil
+}
+
+func applyServer(client incus.InstanceServer, members []string, server incusapi.ServerPut, nodeSpecificConfigKeys map[string]map[string]bool) error {
+	specific, global := splitConfig(nodeSpecificConfigKeys, server.Config, "server")
+
+	// The member specific configuration is applied first, since the global
+	// configuration might depend on it, e.g. storage volumes for backups and
+	// images.
+	for _, member := range members {
+		target := client.UseTarget(member)
+
+		current, etag, err := target.GetServer()
+		if err != nil {
+			return fmt.Errorf("Failed to get server configuration of member %q: %w", member, err)
+		}
+
+		put := current.Writable()
+
+		var changed bool
+		put.Config, changed = mergeConfig(put.Config, specific)
+		if !changed {
+			continue
+		}
+
+		err = target.UpdateServer(put, etag)
+		if err != nil {
+			return fmt.Errorf("Failed to update server configuration of member %q: %w", member, err)
+		}
+	}
+
+	current, etag, err := client.GetServer()
+	if err != nil {
+		return fmt.Errorf("Failed to get server configuration: %w", err)
+	}
+
+	put := current.Writable()
+
+	var changed bool
+	put.Config, changed = mergeConfig(put.Config, global)
+	if !changed {
+		return nil
+	}
+
+	err = client.UpdateServer(put, etag)
+	if err != nil {
+		return fmt.Errorf("Failed to update server configuration: %w", err)
+	}
+
+	return nil
+}
+
+func applyClusterGroup(client incus.InstanceServer, clusterGroup incusapi.ClusterGroupsPost) error {
+	current, etag, err := client.GetClusterGroup(clusterGroup.Name)
+	if isNotFound(err) {
+		err = client.CreateClusterGroup(clusterGroup)
+		if err != nil {
+			return fmt.Errorf("Failed to create cluster group %q: %w", clusterGroup.Name, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster group %q: %w", clusterGroup.Name, err)
+	}
+
+	put := current.Writable()
+
+	var configChanged, descriptionChanged, membersChanged bool
+	put.Config, configChanged = mergeConfig(put.Config, clusterGroup.Config)
+	put.Description, descriptionChanged = mergeDescription(put.Description, clusterGroup.Description)
+
+	for _, member := range clusterGroup.Members {
+		if slices.Contains(put.Members, member) {
+			continue
+		}
+
+		put.Members = append(put.Members, member)
+		membersChanged = true
+	}
+
+	if !configChanged && !descriptionChanged && !membersChanged {
+		return nil
+	}
+
+	err = client.UpdateClusterGroup(clusterGroup.Name, put, etag)
+	if err != nil {
+		return fmt.Errorf("Failed to update cluster group %q: %w", clusterGroup.Name, err)
+	}
+
+	return nil
+}
+
+func applyCertificate(client incus.InstanceServer, certificate incusapi.CertificatesPost) error {
+	fingerprint, err := incustls.CertFingerprintStr(certificate.Certificate)
+	if err != nil {
+		return fmt.Errorf("Failed to get fingerprint of certificate %q: %w", certificate.Name, err)
+	}
+
+	current, etag, err := client.GetCertificate(fingerprint)
+	if isNotFound(err) {
+		err = client.CreateCertificate(certificate)
+		if err != nil {
+			return fmt.Errorf("Failed to create certificate %q: %w", certificate.Name, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to get certificate %q: %w", certificate.Name, err)
+	}
+
+	put := current.Writable()
+	if put.Name == certificate.Name &&
+		put.Description == certificate.Description &&
+		put.Restricted == certificate.Restricted &&
+		slices.Equal(put.Projects, certificate.Projects) {
+		return nil
+	}
+
+	put.Name = certificate.Name
+	put.Description = certificate.Description
+	put.Restricted = certificate.Restricted
+	put.Projects = certificate.Projects
+
+	err = client.UpdateCertificate(fingerprint, put, etag)
+	if err != nil {
+		return fmt.Errorf("Failed to update certificate %q: %w", certificate.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package incusprovisioner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	incusapi "github.com/lxc/incus/v7/shared/api"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+)
+
+const (
+	// preseedFilename is the name of the file, the effective Incus preseed is
+	// stored in.
+	preseedFilename = "preseed.yaml"
+
+	// serverMemberConfigFilename is the name of the file, the member specific
+	// server configuration is stored in.
+	serverMemberConfigFilename = "server_member_config.yaml"
+)
+
+// clusterConfig holds the configuration of a cluster between the calls to
+// Init and Apply.
+type clusterConfig struct {
+	clusterID              int64
+	endpoint               provisioning.ClusterEndpoint
+	preseed                incusapi.InitLocalPreseed
+	meshTunnelInterfaces   map[string]string
+	nodeSpecificConfigKeys map[string]map[string]bool
+
+	// skipMeshNetwork is set for the plan-only pass, the tunnel interfaces of
+	// the members are only known on the initial apply.
+	skipMeshNetwork bool
+}
+
+type incusProvisioner struct {
+	storageDir string
+	client     provisioning.ClusterClientPort
+
+	mu       *sync.Mutex
+	clusters map[string]clusterConfig
+}
+
+var _ provisioning.ClusterProvisioningPort = incusProvisioner{}
+
+// New returns a cluster provisioner, which applies the application seed config
+// of a cluster directly through the Incus API, without the need for a
+// Terraform toolchain.
+func New(tmpDir string, client provisioning.ClusterClientPort) (incusProvisioner, error) {
+	storageDir := filepath.Join(tmpDir, "cluster-configs")
+	err := os.MkdirAll(storageDir, 0o700)
+	if err != nil {
+		return incusProvisioner{}, fmt.Errorf("Failed to create directory for incus provisioner: %w", err)
+	}
+
+	return incusProvisioner{
+		storageDir: storageDir,
+		client:     client,
+
+		mu:       &sync.Mutex{},
+		clusters: map[string]clusterConfig{},
+	}, nil
+}
+
+func (p incusProvisioner) Init(ctx context.Context, name string, config provisioning.ClusterProvisioningConfig) (string, func() error, error) {
+	incusPreseed, err := provisioning.IncusPreseedWithDefaults(config.Cluster.ApplicationSeedConfig)
+	if err != nil {
+		return "", nil, fmt.Errorf("Application seed config is not valid: %w", err)
+	}
+
+	configDir := filepath.Join(p.storageDir, name)
+	err = os.MkdirAll(configDir, 0o700)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to create directory for the incus configuration of cluster %q: %w", name, err)
+	}
+
+	meshTunnelInterfaces := make(map[string]string, len(config.Servers))
+	for _, server := range config.Servers {
+		meshTunnelInterfaces[server.Name] = provisioning.DetectClusterInterface(server.OSData.Network)
+	}
+
+	// Keep the effective configuration for future reference.
+	serverMemberConfig, _ := splitConfig(config.NodeSpecificConfigKeys, incusPreseed.Config, "server")
+
+	files := map[string]any{
+		preseedFilename:            incusPreseed,
+		serverMemberConfigFilename: serverMemberConfig,
+	}
+
+	for filename, content := range files {
+		body, err := yaml.Marshal(content)
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed to marshal %q for cluster %q: %w", filename, name, err)
+		}
+
+		err = os.WriteFile(filepath.Join(configDir, filename), body, 0o600)
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed to write %q for cluster %q: %w", filename, name, err)
+		}
+	}
+
+	p.mu.Lock()
+	p.clusters[name] = clusterConfig{
+		clusterID:              config.Cluster.ID,
+		endpoint:               config.ClusterEndpoint,
+		preseed:                incusPreseed,
+		meshTunnelInterfaces:   meshTunnelInterfaces,
+		nodeSpecificConfigKeys: config.NodeSpecificConfigKeys,
+	}
+	p.mu.Unlock()
+
+	return configDir, p.cleanup(name, configDir), nil
+}
+
+func (p incusProvisioner) SeedCertificate(ctx context.Context, clusterName string, certificatePEM string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cluster, ok := p.clusters[clusterName]
+	if !ok {
+		// Nothing to do, the certificate is passed to the Incus client on Apply.
+		return nil
+	}
+
+	endpoint := make(provisioning.ClusterEndpoint, 0, len(cluster.endpoint))
+	for _, server := range cluster.endpoint {
+		server.ClusterCertificate = &certificatePEM
+		endpoint = append(endpoint, server)
+	}
+
+	cluster.endpoint = endpoint
+	p.clusters[clusterName] = cluster
+
+	return nil
+}
+
+var certificateValidationErrRegexp = regexp.MustCompile("(?s)tls: failed.*to verify certificate: x509:")
+
+func (p incusProvisioner) Apply(ctx context.Context, cluster provisioning.Cluster) error {
+	p.mu.Lock()
+	config, ok := p.clusters[cluster.Name]
+	p.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("Initialized Incus configuration not found")
+	}
+
+	client, err := p.client.IncusClient(ctx, config.endpoint)
+	if err != nil {
+		return fmt.Errorf("Failed to get Incus client for cluster %q: %w", cluster.Name, err)
+	}
+
+	err = apply(client, config)
+	if err != nil {
+		// The certificate of the cluster might change while the configuration is
+		// applied, e.g. due to ACME configuration.
+		if certificateValidationErrRegexp.MatchString(err.Error()) {
+			return fmt.Errorf("Failed to apply Incus configuration: %w", domain.NewRetryableErr(err))
+		}
+
+		return fmt.Errorf("Failed to apply Incus configuration: %w", err)
+	}
+
+	return nil
+}
+
+func (p incusProvisioner) cleanup(name string, path string) func() error {
+	return func() error {
+		p.mu.Lock()
+		delete(p.clusters, name)
+		p.mu.Unlock()
+
+		return os.RemoveAll(path)
+	}
+}
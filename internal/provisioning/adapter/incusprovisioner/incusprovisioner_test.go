@@ -0,0 +1,538 @@
+package incusprovisioner_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	incus "github.com/lxc/incus/v7/client"
+	incusapi "github.com/lxc/incus/v7/shared/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/incusprovisioner"
+	adapterMock "github.com/FuturFusion/operations-center/internal/provisioning/adapter/mock"
+	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
+)
+
+func TestIncusProvisioner_Init(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterName string
+		seedConfig  map[string]any
+
+		assertErr require.ErrorAssertionFunc
+		wantFiles []string
+	}{
+		{
+			name:        "success",
+			clusterName: "foobar",
+			seedConfig: map[string]any{
+				"config": map[string]any{
+					"core.https_address": ":8443",
+				},
+			},
+
+			assertErr: require.NoError,
+			wantFiles: []string{"preseed.yaml", "server_member_config.yaml"},
+		},
+		{
+			name:        "error - invalid application seed config",
+			clusterName: "foobar",
+			seedConfig: map[string]any{
+				"config": "invalid",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, "Application seed config is not valid")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			tmpDir := t.TempDir()
+
+			provisioner, err := incusprovisioner.New(tmpDir, &adapterMock.ClusterClientPortMock{})
+			require.NoError(t, err)
+
+			// Run test
+			temporaryPath, cleanup, err := provisioner.Init(t.Context(), tc.clusterName, provisioning.ClusterProvisioningConfig{
+				Cluster: provisioning.Cluster{
+					Name:                  tc.clusterName,
+					ApplicationSeedConfig: tc.seedConfig,
+				},
+			})
+
+			// Assert
+			tc.assertErr(t, err)
+
+			for _, filename := range tc.wantFiles {
+				require.FileExists(t, filepath.Join(temporaryPath, filename))
+			}
+
+			if cleanup != nil {
+				err = cleanup()
+				require.NoError(t, err)
+				require.NoDirExists(t, temporaryPath)
+			}
+		})
+	}
+}
+
+func TestIncusProvisioner_Apply(t *testing.T) {
+	nodeSpecificConfigKeys := map[string]map[string]bool{
+		"server": {
+			"storage.backups_volume": true,
+			"storage.images_volume":  true,
+			"storage.logs_volume":    true,
+		},
+		"storage_zfs": {
+			"source": true,
+		},
+		"network_bridge": {
+			"bridge.external_interfaces": true,
+		},
+	}
+
+	defaultCalls := []string{
+		"create project internal",
+		"create storage pool local (target server1)",
+		"create storage pool local (target server2)",
+		"create storage pool local",
+		"create network incusbr0 (target server1)",
+		"create network incusbr0 (target server2)",
+		"create network incusbr0",
+		"create network meshbr0 (target server1)",
+		"create network meshbr0 (target server2)",
+		"create network meshbr0",
+		"create storage volume local/backups (target server1)",
+		"create storage volume local/backups (target server2)",
+		"create storage volume local/images (target server1)",
+		"create storage volume local/images (target server2)",
+		"create storage volume local/logs (target server1)",
+		"create storage volume local/logs (target server2)",
+		"create profile default",
+		"create profile default (project internal)",
+		"update server (target server1)",
+		"update server (target server2)",
+	}
+
+	tests := []struct {
+		name                 string
+		skipInit             bool
+		seedConfig           map[string]any
+		existing             map[string]fakeEntity
+		clusterMembers       []incusapi.ClusterMember
+		clusterMembersErr    error
+		clientIncusClientErr error
+
+		assertErr          require.ErrorAssertionFunc
+		wantCalls          []string
+		wantReapplyNoCalls bool
+	}{
+		{
+			name: "success - new cluster",
+
+			assertErr:          require.NoError,
+			wantCalls:          defaultCalls,
+			wantReapplyNoCalls: true,
+		},
+		{
+			name: "success - existing configuration is merged",
+			seedConfig: map[string]any{
+				"projects": []any{
+					map[string]any{
+						"name": "internal",
+						"config": map[string]any{
+							"features.images": "false",
+						},
+					},
+				},
+			},
+			existing: map[string]fakeEntity{
+				"project internal": {
+					config: map[string]string{
+						"features.profiles": "true",
+					},
+				},
+			},
+
+			assertErr:          require.NoError,
+			wantCalls:          append([]string{"update project internal"}, defaultCalls[1:]...),
+			wantReapplyNoCalls: true,
+		},
+		{
+			name:     "error - not initialized",
+			skipInit: true,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, "Initialized Incus configuration not found")
+			},
+		},
+		{
+			name:                 "error - client.IncusClient",
+			clientIncusClientErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - cluster member not online",
+			clusterMembers: []incusapi.ClusterMember{
+				{ServerName: "server1", Status: "Online"},
+				{ServerName: "server2", Status: "Offline"},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, `Cluster member "server2" is not online`)
+			},
+		},
+		{
+			name:              "error - certificate validation is retryable",
+			clusterMembersErr: errors.New(`Get "https://192.168.0.100:8443/1.0": tls: failed to verify certificate: x509: certificate signed by unknown authority`),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var retryableErr domain.ErrRetryable
+				require.ErrorAs(tt, err, &retryableErr)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			clusterMembers := tc.clusterMembers
+			if clusterMembers == nil {
+				clusterMembers = []incusapi.ClusterMember{
+					{ServerName: "server2", Status: "Online"},
+					{ServerName: "server1", Status: "Online"},
+				}
+			}
+
+			fake := &fakeIncus{
+				entities:          map[string]fakeEntity{},
+				clusterMembers:    clusterMembers,
+				clusterMembersErr: tc.clusterMembersErr,
+			}
+
+			maps.Copy(fake.entities, tc.existing)
+
+			client := &adapterMock.ClusterClientPortMock{
+				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (provisioning.InstanceServer, error) {
+					return fake.client("", ""), tc.clientIncusClientErr
+				},
+			}
+
+			provisioner, err := incusprovisioner.New(t.TempDir(), client)
+			require.NoError(t, err)
+
+			cluster := provisioning.Cluster{
+				ID:                    1,
+				Name:                  "one",
+				ApplicationSeedConfig: tc.seedConfig,
+			}
+
+			if !tc.skipInit {
+				_, cleanup, err := provisioner.Init(t.Context(), cluster.Name, provisioning.ClusterProvisioningConfig{
+					Cluster:                cluster,
+					NodeSpecificConfigKeys: nodeSpecificConfigKeys,
+				})
+				require.NoError(t, err)
+
+				defer func() {
+					require.NoError(t, cleanup())
+				}()
+			}
+
+			// Run test
+			err = provisioner.Apply(t.Context(), cluster)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantCalls, fake.calls)
+
+			if tc.wantCalls != nil {
+				require.Equal(t, "none", fake.entities["network meshbr0"].config["ipv4.address"])
+				require.Equal(t, "1000", fake.entities["network meshbr0"].config["tunnel.mesh.id"])
+				require.Regexp(t, `^fd42:[0-9a-f]{1,4}:[0-9a-f]{1,4}:[0-9a-f]{1,4}::/64$`, fake.entities["network meshbr0"].config["ipv6.address"])
+				require.Equal(t, "local/backups", fake.entities["server (target server1)"].config["storage.backups_volume"])
+			}
+
+			if tc.wantReapplyNoCalls {
+				meshSubnet := fake.entities["network meshbr0"].config["ipv6.address"]
+				fake.calls = nil
+
+				err = provisioner.Apply(t.Context(), cluster)
+				require.NoError(t, err)
+				require.Empty(t, fake.calls)
+				require.Equal(t, meshSubnet, fake.entities["network meshbr0"].config["ipv6.address"])
+			}
+		})
+	}
+}
+
+func TestIncusProvisioner_Plan(t *testing.T) {
+	nodeSpecificConfigKeys := map[string]map[string]bool{
+		"server": {
+			"storage.backups_volume": true,
+			"storage.images_volume":  true,
+			"storage.logs_volume":    true,
+		},
+		"storage_zfs": {
+			"source": true,
+		},
+		"network_bridge": {
+			"bridge.external_interfaces": true,
+		},
+	}
+
+	tests := []struct {
+		name                               string
+		skipApply                          bool
+		drift                              func(fake *fakeIncus)
+		clientGetNodeSpecificConfigKeysErr error
+		clientIncusClientErr               error
+
+		assertErr            require.ErrorAssertionFunc
+		wantChangedResources []string
+	}{
+		{
+			name: "success - no drift",
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - drift",
+			drift: func(fake *fakeIncus) {
+				profile := fake.entities["profile default"]
+				profile.devices = nil
+				fake.entities["profile default"] = profile
+			},
+
+			assertErr:            require.NoError,
+			wantChangedResources: []string{"profile.default"},
+		},
+		{
+			name:      "success - resources missing",
+			skipApply: true,
+
+			assertErr: require.NoError,
+			wantChangedResources: []string{
+				"network.incusbr0",
+				"profile.default",
+				"profile.default (project internal)",
+				"project.internal",
+				"server.server1",
+				"server.server2",
+				"storage_pool.local",
+				"storage_volume.local/backups",
+				"storage_volume.local/images",
+				"storage_volume.local/logs",
+			},
+		},
+		{
+			name:                               "error - client.GetNodeSpecificConfigKeys",
+			clientGetNodeSpecificConfigKeysErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                 "error - client.IncusClient",
+			clientIncusClientErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			fake := &fakeIncus{
+				entities: map[string]fakeEntity{},
+				clusterMembers: []incusapi.ClusterMember{
+					{ServerName: "server1", Status: "Online"},
+					{ServerName: "server2", Status: "Online"},
+				},
+			}
+
+			var planning bool
+			client := &adapterMock.ClusterClientPortMock{
+				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (provisioning.InstanceServer, error) {
+					if planning {
+						return fake.client("", ""), tc.clientIncusClientErr
+					}
+
+					return fake.client("", ""), nil
+				},
+				GetNodeSpecificConfigKeysFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (map[string]map[string]bool, error) {
+					return nodeSpecificConfigKeys, tc.clientGetNodeSpecificConfigKeysErr
+				},
+			}
+
+			provisioner, err := incusprovisioner.New(t.TempDir(), client)
+			require.NoError(t, err)
+
+			cluster := provisioning.Cluster{
+				ID:            1,
+				Name:          "one",
+				ConnectionURL: "https://one",
+			}
+
+			configDir, cleanup, err := provisioner.Init(t.Context(), cluster.Name, provisioning.ClusterProvisioningConfig{
+				Cluster:                cluster,
+				NodeSpecificConfigKeys: nodeSpecificConfigKeys,
+			})
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, cleanup())
+			}()
+
+			if !tc.skipApply {
+				err = provisioner.Apply(t.Context(), cluster)
+				require.NoError(t, err)
+			}
+
+			if tc.drift != nil {
+				tc.drift(fake)
+			}
+
+			fake.calls = nil
+			planning = true
+
+			// Run test
+			plan, err := provisioner.Plan(t.Context(), cluster, configDir)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantChangedResources, plan.ChangedResources)
+			require.Empty(t, fake.calls)
+		})
+	}
+}
+
+type fakeEntity struct {
+	description string
+	driver      string
+	config      map[string]string
+	devices     map[string]map[string]string
+}
+
+// fakeIncus keeps the state of a fake Incus cluster, such that the
+// idempotency of apply can be asserted.
+type fakeIncus struct {
+	entities          map[string]fakeEntity
+	calls             []string
+	clusterMembers    []incusapi.ClusterMember
+	clusterMembersErr error
+}
+
+func (f *fakeIncus) key(kind string, name string, project string, target string) string {
+	key := kind
+	if name != "" {
+		key += " " + name
+	}
+
+	if project != "" {
+		key += " (project " + project + ")"
+	}
+
+	if target != "" {
+		key += " (target " + target + ")"
+	}
+
+	return key
+}
+
+func (f *fakeIncus) get(key string) (fakeEntity, string, error) {
+	entity, ok := f.entities[key]
+	if !ok {
+		return fakeEntity{}, "", incusapi.StatusErrorf(http.StatusNotFound, "Not found")
+	}
+
+	return entity, "etag", nil
+}
+
+func (f *fakeIncus) set(action string, key string, entity fakeEntity) error {
+	f.calls = append(f.calls, fmt.Sprintf("%s %s", action, key))
+	f.entities[key] = entity
+
+	return nil
+}
+
+func (f *fakeIncus) client(project string, target string) *adapterMock.InstanceServerMock {
+	return &adapterMock.InstanceServerMock{
+		UseTargetFunc: func(name string) incus.InstanceServer {
+			return f.client(project, name)
+		},
+		UseProjectFunc: func(name string) incus.InstanceServer {
+			return f.client(name, target)
+		},
+		GetClusterMembersFunc: func() ([]incusapi.ClusterMember, error) {
+			return f.clusterMembers, f.clusterMembersErr
+		},
+		GetProjectFunc: func(name string) (*incusapi.Project, string, error) {
+			entity, etag, err := f.get(f.key("project", name, "", ""))
+			return &incusapi.Project{Name: name, ProjectPut: incusapi.ProjectPut{Config: entity.config, Description: entity.description}}, etag, err
+		},
+		CreateProjectFunc: func(project incusapi.ProjectsPost) error {
+			return f.set("create", f.key("project", project.Name, "", ""), fakeEntity{config: project.Config, description: project.Description})
+		},
+		UpdateProjectFunc: func(name string, project incusapi.ProjectPut, ETag string) error {
+			return f.set("update", f.key("project", name, "", ""), fakeEntity{config: project.Config, description: project.Description})
+		},
+		GetStoragePoolFunc: func(name string) (*incusapi.StoragePool, string, error) {
+			entity, etag, err := f.get(f.key("storage pool", name, "", target))
+			return &incusapi.StoragePool{Name: name, Driver: entity.driver, Status: "Created", StoragePoolPut: incusapi.StoragePoolPut{Config: entity.config, Description: entity.description}}, etag, err
+		},
+		CreateStoragePoolFunc: func(pool incusapi.StoragePoolsPost) error {
+			return f.set("create", f.key("storage pool", pool.Name, "", target), fakeEntity{driver: pool.Driver, config: pool.Config, description: pool.Description})
+		},
+		UpdateStoragePoolFunc: func(name string, pool incusapi.StoragePoolPut, ETag string) error {
+			return f.set("update", f.key("storage pool", name, "", target), fakeEntity{driver: f.entities[f.key("storage pool", name, "", target)].driver, config: pool.Config, description: pool.Description})
+		},
+		GetNetworkFunc: func(name string) (*incusapi.Network, string, error) {
+			entity, etag, err := f.get(f.key("network", name, project, target))
+			return &incusapi.Network{Name: name, Status: "Created", NetworkPut: incusapi.NetworkPut{Config: entity.config, Description: entity.description}}, etag, err
+		},
+		CreateNetworkFunc: func(network incusapi.NetworksPost) error {
+			return f.set("create", f.key("network", network.Name, project, target), fakeEntity{config: network.Config, description: network.Description})
+		},
+		UpdateNetworkFunc: func(name string, network incusapi.NetworkPut, ETag string) error {
+			return f.set("update", f.key("network", name, project, target), fakeEntity{config: network.Config, description: network.Description})
+		},
+		GetStoragePoolVolumeFunc: func(pool string, volType string, name string) (*incusapi.StorageVolume, string, error) {
+			entity, etag, err := f.get(f.key("storage volume", pool+"/"+name, project, target))
+			return &incusapi.StorageVolume{Name: name, Type: volType, StorageVolumePut: incusapi.StorageVolumePut{Config: entity.config, Description: entity.description}}, etag, err
+		},
+		CreateStoragePoolVolumeFunc: func(pool string, volume incusapi.StorageVolumesPost) error {
+			return f.set("create", f.key("storage volume", pool+"/"+volume.Name, project, target), fakeEntity{config: volume.Config, description: volume.Description})
+		},
+		UpdateStoragePoolVolumeFunc: func(pool string, volType string, name string, volume incusapi.StorageVolumePut, ETag string) error {
+			return f.set("update", f.key("storage volume", pool+"/"+name, project, target), fakeEntity{config: volume.Config, description: volume.Description})
+		},
+		GetProfileFunc: func(name string) (*incusapi.Profile, string, error) {
+			entity, etag, err := f.get(f.key("profile", name, project, ""))
+			return &incusapi.Profile{Name: name, ProfilePut: incusapi.ProfilePut{Config: entity.config, Description: entity.description, Devices: entity.devices}}, etag, err
+		},
+		CreateProfileFunc: func(profile incusapi.ProfilesPost) error {
+			return f.set("create", f.key("profile", profile.Name, project, ""), fakeEntity{config: profile.Config, description: profile.Description, devices: profile.Devices})
+		},
+		UpdateProfileFunc: func(name string, profile incusapi.ProfilePut, ETag string) error {
+			return f.set("update", f.key("profile", name, project, ""), fakeEntity{config: profile.Config, description: profile.Description, devices: profile.Devices})
+		},
+		GetServerFunc: func() (*incusapi.Server, string, error) {
+			entity := f.entities[f.key("server", "", "", target)]
+			return &incusapi.Server{ServerPut: incusapi.ServerPut{Config: entity.config}}, "etag", nil
+		},
+		UpdateServerFunc: func(server incusapi.ServerPut, ETag string) error {
+			return f.set("update", f.key("server", "", "", target), fakeEntity{config: server.Config})
+		},
+	}
+}
@@ -0,0 +1,239 @@
+package incusprovisioner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	incus "github.com/lxc/incus/v7/client"
+	incusapi "github.com/lxc/incus/v7/shared/api"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+)
+
+var _ provisioning.ClusterProvisioningDriftPort = incusProvisioner{}
+
+// Plan performs a plan-only pass of the Incus configuration in configDir,
+// which is a copy of the Incus configuration artifact of the cluster, against
+// the cluster.
+//
+// The configuration is applied to the cluster the same way as on the initial
+// apply, but all the changes are only recorded instead of performed. Same as
+// for apply, configuration, which is not part of the preseed, is not
+// considered a drift. The mesh network is fully managed by Operations Center
+// and is therefore not part of the plan.
+func (p incusProvisioner) Plan(ctx context.Context, cluster provisioning.Cluster, configDir string) (provisioning.ClusterProvisioningPlan, error) {
+	body, err := os.ReadFile(filepath.Join(configDir, preseedFilename))
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to read Incus configuration of cluster %q: %w", cluster.Name, err)
+	}
+
+	var preseed incusapi.InitLocalPreseed
+	err = yaml.Unmarshal(body, &preseed)
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to parse Incus configuration of cluster %q: %w", cluster.Name, err)
+	}
+
+	endpoint := provisioning.ClusterEndpoint{
+		provisioning.Server{
+			ConnectionURL:        cluster.ConnectionURL,
+			Cluster:              &cluster.Name,
+			ClusterCertificate:   cluster.Certificate,
+			ClusterConnectionURL: &cluster.ConnectionURL,
+		},
+	}
+
+	nodeSpecificConfigKeys, err := p.client.GetNodeSpecificConfigKeys(ctx, endpoint)
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to get node specific config keys of cluster %q: %w", cluster.Name, err)
+	}
+
+	client, err := p.client.IncusClient(ctx, endpoint)
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to get Incus client for cluster %q: %w", cluster.Name, err)
+	}
+
+	recorder := &planRecorder{
+		changes:      map[string]string{},
+		storagePools: map[string]incusapi.StoragePool{},
+	}
+
+	err = apply(recorder.client(client, "", ""), clusterConfig{
+		clusterID:              cluster.ID,
+		preseed:                preseed,
+		nodeSpecificConfigKeys: nodeSpecificConfigKeys,
+		skipMeshNetwork:        true,
+	})
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to plan Incus configuration: %w", err)
+	}
+
+	return recorder.plan(), nil
+}
+
+// planRecorder records the changes of the resources, which would be
+// performed, if the configuration would be applied again.
+type planRecorder struct {
+	// changes maps the addresses of the changed resources to the action
+	// ("create" or "update").
+	changes map[string]string
+
+	// storagePools holds the storage pools, which would be created, such that
+	// the storage volumes in these pools can be planned as well.
+	storagePools map[string]incusapi.StoragePool
+}
+
+func (r *planRecorder) record(action string, kind string, name string, project string) error {
+	address := kind
+	if name != "" {
+		address += "." + name
+	}
+
+	if project != "" {
+		address += " (project " + project + ")"
+	}
+
+	// A resource, which is created on each member first, is only reported as
+	// created.
+	if r.changes[address] != "create" {
+		r.changes[address] = action
+	}
+
+	return nil
+}
+
+func (r *planRecorder) client(client incus.InstanceServer, project string, target string) incus.InstanceServer {
+	return planClient{
+		InstanceServer: client,
+		recorder:       r,
+		project:        project,
+		target:         target,
+	}
+}
+
+func (r *planRecorder) plan() provisioning.ClusterProvisioningPlan {
+	changedResources := slices.Sorted(maps.Keys(r.changes))
+
+	if len(changedResources) == 0 {
+		return provisioning.ClusterProvisioningPlan{
+			Output: "No changes. The Incus configuration matches the cluster.\n",
+		}
+	}
+
+	var output strings.Builder
+	output.WriteString("The following resources would be changed:\n")
+	for _, address := range changedResources {
+		symbol := "~"
+		if r.changes[address] == "create" {
+			symbol = "+"
+		}
+
+		fmt.Fprintf(&output, "  %s %s\n", symbol, address)
+	}
+
+	return provisioning.ClusterProvisioningPlan{
+		Output:           output.String(),
+		ChangedResources: changedResources,
+	}
+}
+
+// planClient wraps an Incus client and records all the changes instead of
+// performing them, while the reads are passed through to the cluster.
+type planClient struct {
+	incus.InstanceServer
+
+	recorder *planRecorder
+	project  string
+	target   string
+}
+
+func (c planClient) UseTarget(name string) incus.InstanceServer {
+	return c.recorder.client(c.InstanceServer.UseTarget(name), c.project, name)
+}
+
+func (c planClient) UseProject(name string) incus.InstanceServer {
+	return c.recorder.client(c.InstanceServer.UseProject(name), name, c.target)
+}
+
+func (c planClient) CreateProject(project incusapi.ProjectsPost) error {
+	return c.recorder.record("create", "project", project.Name, "")
+}
+
+func (c planClient) UpdateProject(name string, project incusapi.ProjectPut, ETag string) error {
+	return c.recorder.record("update", "project", name, "")
+}
+
+func (c planClient) GetStoragePool(name string) (*incusapi.StoragePool, string, error) {
+	pool, etag, err := c.InstanceServer.GetStoragePool(name)
+	if isNotFound(err) {
+		plannedPool, ok := c.recorder.storagePools[name]
+		if ok {
+			return &plannedPool, "", nil
+		}
+	}
+
+	return pool, etag, err
+}
+
+func (c planClient) CreateStoragePool(pool incusapi.StoragePoolsPost) error {
+	c.recorder.storagePools[pool.Name] = incusapi.StoragePool{
+		Name:   pool.Name,
+		Driver: pool.Driver,
+		Status: "Created",
+	}
+
+	return c.recorder.record("create", "storage_pool", pool.Name, "")
+}
+
+func (c planClient) UpdateStoragePool(name string, pool incusapi.StoragePoolPut, ETag string) error {
+	return c.recorder.record("update", "storage_pool", name, "")
+}
+
+func (c planClient) CreateNetwork(network incusapi.NetworksPost) error {
+	return c.recorder.record("create", "network", network.Name, c.project)
+}
+
+func (c planClient) UpdateNetwork(name string, network incusapi.NetworkPut, ETag string) error {
+	return c.recorder.record("update", "network", name, c.project)
+}
+
+func (c planClient) CreateStoragePoolVolume(pool string, volume incusapi.StorageVolumesPost) error {
+	return c.recorder.record("create", "storage_volume", pool+"/"+volume.Name, c.project)
+}
+
+func (c planClient) UpdateStoragePoolVolume(pool string, volType string, name string, volume incusapi.StorageVolumePut, ETag string) error {
+	return c.recorder.record("update", "storage_volume", pool+"/"+name, c.project)
+}
+
+func (c planClient) CreateProfile(profile incusapi.ProfilesPost) error {
+	return c.recorder.record("create", "profile", profile.Name, c.project)
+}
+
+func (c planClient) UpdateProfile(name string, profile incusapi.ProfilePut, ETag string) error {
+	return c.recorder.record("update", "profile", name, c.project)
+}
+
+func (c planClient) UpdateServer(server incusapi.ServerPut, ETag string) error {
+	return c.recorder.record("update", "server", c.target, "")
+}
+
+func (c planClient) CreateClusterGroup(group incusapi.ClusterGroupsPost) error {
+	return c.recorder.record("create", "cluster_group", group.Name, "")
+}
+
+func (c planClient) UpdateClusterGroup(name string, group incusapi.ClusterGroupPut, ETag string) error {
+	return c.recorder.record("update", "cluster_group", name, "")
+}
+
+func (c planClient) CreateCertificate(certificate incusapi.CertificatesPost) error {
+	return c.recorder.record("create", "certificate", certificate.Name, "")
+}
+
+func (c planClient) UpdateCertificate(fingerprint string, certificate incusapi.CertificatePut, ETag string) error {
+	return c.recorder.record("update", "certificate", certificate.Name, "")
+}
@@ -76,6 +76,26 @@ func (_d BMCServerClientPortWithErrorWrapper) Dump(ctx context.Context, server p
 	return _d._base.Dump(ctx, server, additionalEndpoints, skipPredefined, trace)
 }
 
+// EjectVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) EjectVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string) (err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.EjectVirtualMedia(ctx, server, virtualMediaID)
+}
+
+// FirmwareInventory implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) FirmwareInventory(ctx context.Context, server provisioning.Server) (m1 map[string]api.BMCFirmware, err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.FirmwareInventory(ctx, server)
+}
+
 // GetData implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithErrorWrapper) GetData(ctx context.Context, server provisioning.Server) (bMCData api.BMCData, err error) {
 	defer func() {
@@ -86,6 +106,16 @@ func (_d BMCServerClientPortWithErrorWrapper) GetData(ctx context.Context, serve
 	return _d._base.GetData(ctx, server)
 }
 
+// InsertVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) InsertVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (insertedVirtualMediaID string, bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.InsertVirtualMedia(ctx, server, virtualMediaID, imageURL)
+}
+
 // LogEntriesBySource implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithErrorWrapper) LogEntriesBySource(ctx context.Context, server provisioning.Server, logSource string) (bMCLogEvents []api.BMCLogEvent, err error) {
 	defer func() {
@@ -106,6 +136,16 @@ func (_d BMCServerClientPortWithErrorWrapper) LogSources(ctx context.Context, se
 	return _d._base.LogSources(ctx, server)
 }
 
+// SecureEraseDrives implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) SecureEraseDrives(ctx context.Context, server provisioning.Server) (taskMonitors map[string]*provisioning.BMCTaskMonitor, err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.SecureEraseDrives(ctx, server)
+}
+
 // ServerPowerOff implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithErrorWrapper) ServerPowerOff(ctx context.Context, server provisioning.Server, force bool) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
 	defer func() {
@@ -146,6 +186,36 @@ func (_d BMCServerClientPortWithErrorWrapper) ServerSetLocationIndicator(ctx con
 	return _d._base.ServerSetLocationIndicator(ctx, server, active)
 }
 
+// SetOneTimeBootFromVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) SetOneTimeBootFromVirtualMedia(ctx context.Context, server provisioning.Server) (err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.SetOneTimeBootFromVirtualMedia(ctx, server)
+}
+
+// UpdateFirmwareFromFile implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) UpdateFirmwareFromFile(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.UpdateFirmwareFromFile(ctx, server, imageFilename, targets)
+}
+
+// UpdateFirmwareFromURI implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithErrorWrapper) UpdateFirmwareFromURI(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.UpdateFirmwareFromURI(ctx, server, imageURI, targets)
+}
+
 // WaitForTask implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithErrorWrapper) WaitForTask(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) (err error) {
 	defer func() {
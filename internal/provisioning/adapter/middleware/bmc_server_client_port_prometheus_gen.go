@@ -109,6 +109,34 @@ func (_d BMCServerClientPortWithPrometheus) Dump(ctx context.Context, server pro
 	return _d.base.Dump(ctx, server, additionalEndpoints, skipPredefined, trace)
 }
 
+// EjectVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) EjectVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "EjectVirtualMedia", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.EjectVirtualMedia(ctx, server, virtualMediaID)
+}
+
+// FirmwareInventory implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) FirmwareInventory(ctx context.Context, server provisioning.Server) (m1 map[string]api.BMCFirmware, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "FirmwareInventory", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.FirmwareInventory(ctx, server)
+}
+
 // GetData implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithPrometheus) GetData(ctx context.Context, server provisioning.Server) (bMCData api.BMCData, err error) {
 	_since := time.Now()
@@ -123,6 +151,20 @@ func (_d BMCServerClientPortWithPrometheus) GetData(ctx context.Context, server
 	return _d.base.GetData(ctx, server)
 }
 
+// InsertVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) InsertVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (insertedVirtualMediaID string, bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "InsertVirtualMedia", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.InsertVirtualMedia(ctx, server, virtualMediaID, imageURL)
+}
+
 // LogEntriesBySource implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithPrometheus) LogEntriesBySource(ctx context.Context, server provisioning.Server, logSource string) (bMCLogEvents []api.BMCLogEvent, err error) {
 	_since := time.Now()
@@ -151,6 +193,20 @@ func (_d BMCServerClientPortWithPrometheus) LogSources(ctx context.Context, serv
 	return _d.base.LogSources(ctx, server)
 }
 
+// SecureEraseDrives implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) SecureEraseDrives(ctx context.Context, server provisioning.Server) (taskMonitors map[string]*provisioning.BMCTaskMonitor, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "SecureEraseDrives", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.SecureEraseDrives(ctx, server)
+}
+
 // ServerPowerOff implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithPrometheus) ServerPowerOff(ctx context.Context, server provisioning.Server, force bool) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
 	_since := time.Now()
@@ -207,6 +263,48 @@ func (_d BMCServerClientPortWithPrometheus) ServerSetLocationIndicator(ctx conte
 	return _d.base.ServerSetLocationIndicator(ctx, server, active)
 }
 
+// SetOneTimeBootFromVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) SetOneTimeBootFromVirtualMedia(ctx context.Context, server provisioning.Server) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "SetOneTimeBootFromVirtualMedia", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.SetOneTimeBootFromVirtualMedia(ctx, server)
+}
+
+// UpdateFirmwareFromFile implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) UpdateFirmwareFromFile(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "UpdateFirmwareFromFile", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.UpdateFirmwareFromFile(ctx, server, imageFilename, targets)
+}
+
+// UpdateFirmwareFromURI implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithPrometheus) UpdateFirmwareFromURI(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		bmcserverClientPortDurationSummaryVec.WithLabelValues(_d.instanceName, "UpdateFirmwareFromURI", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.UpdateFirmwareFromURI(ctx, server, imageURI, targets)
+}
+
 // WaitForTask implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithPrometheus) WaitForTask(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) (err error) {
 	_since := time.Now()
@@ -220,6 +220,76 @@ func (_d BMCServerClientPortWithSlog) Dump(ctx context.Context, server provision
 	return _d._base.Dump(ctx, server, additionalEndpoints, skipPredefined, trace)
 }
 
+// EjectVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) EjectVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+			slog.String("virtualMediaID", virtualMediaID),
+		)
+	}
+	log.DebugContext(ctx, "=> calling EjectVirtualMedia")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method EjectVirtualMedia returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method EjectVirtualMedia returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method EjectVirtualMedia finished")
+		}
+	}()
+	return _d._base.EjectVirtualMedia(ctx, server, virtualMediaID)
+}
+
+// FirmwareInventory implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) FirmwareInventory(ctx context.Context, server provisioning.Server) (m1 map[string]api.BMCFirmware, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+		)
+	}
+	log.DebugContext(ctx, "=> calling FirmwareInventory")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("m1", m1),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method FirmwareInventory returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method FirmwareInventory returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method FirmwareInventory finished")
+		}
+	}()
+	return _d._base.FirmwareInventory(ctx, server)
+}
+
 // GetData implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithSlog) GetData(ctx context.Context, server provisioning.Server) (bMCData api.BMCData, err error) {
 	log := slog.With()
@@ -255,6 +325,44 @@ func (_d BMCServerClientPortWithSlog) GetData(ctx context.Context, server provis
 	return _d._base.GetData(ctx, server)
 }
 
+// InsertVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) InsertVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (insertedVirtualMediaID string, bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+			slog.String("virtualMediaID", virtualMediaID),
+			slog.String("imageURL", imageURL),
+		)
+	}
+	log.DebugContext(ctx, "=> calling InsertVirtualMedia")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.String("insertedVirtualMediaID", insertedVirtualMediaID),
+				slog.Any("bMCTaskMonitor", bMCTaskMonitor),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method InsertVirtualMedia returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method InsertVirtualMedia returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method InsertVirtualMedia finished")
+		}
+	}()
+	return _d._base.InsertVirtualMedia(ctx, server, virtualMediaID, imageURL)
+}
+
 // LogEntriesBySource implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithSlog) LogEntriesBySource(ctx context.Context, server provisioning.Server, logSource string) (bMCLogEvents []api.BMCLogEvent, err error) {
 	log := slog.With()
@@ -326,6 +434,41 @@ func (_d BMCServerClientPortWithSlog) LogSources(ctx context.Context, server pro
 	return _d._base.LogSources(ctx, server)
 }
 
+// SecureEraseDrives implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) SecureEraseDrives(ctx context.Context, server provisioning.Server) (taskMonitors map[string]*provisioning.BMCTaskMonitor, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+		)
+	}
+	log.DebugContext(ctx, "=> calling SecureEraseDrives")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("taskMonitors", taskMonitors),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method SecureEraseDrives returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method SecureEraseDrives returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method SecureEraseDrives finished")
+		}
+	}()
+	return _d._base.SecureEraseDrives(ctx, server)
+}
+
 // ServerPowerOff implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithSlog) ServerPowerOff(ctx context.Context, server provisioning.Server, force bool) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
 	log := slog.With()
@@ -469,6 +612,114 @@ func (_d BMCServerClientPortWithSlog) ServerSetLocationIndicator(ctx context.Con
 	return _d._base.ServerSetLocationIndicator(ctx, server, active)
 }
 
+// SetOneTimeBootFromVirtualMedia implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) SetOneTimeBootFromVirtualMedia(ctx context.Context, server provisioning.Server) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+		)
+	}
+	log.DebugContext(ctx, "=> calling SetOneTimeBootFromVirtualMedia")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method SetOneTimeBootFromVirtualMedia returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method SetOneTimeBootFromVirtualMedia returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method SetOneTimeBootFromVirtualMedia finished")
+		}
+	}()
+	return _d._base.SetOneTimeBootFromVirtualMedia(ctx, server)
+}
+
+// UpdateFirmwareFromFile implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) UpdateFirmwareFromFile(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+			slog.String("imageFilename", imageFilename),
+			slog.Any("targets", targets),
+		)
+	}
+	log.DebugContext(ctx, "=> calling UpdateFirmwareFromFile")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("bMCTaskMonitor", bMCTaskMonitor),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method UpdateFirmwareFromFile returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method UpdateFirmwareFromFile returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method UpdateFirmwareFromFile finished")
+		}
+	}()
+	return _d._base.UpdateFirmwareFromFile(ctx, server, imageFilename, targets)
+}
+
+// UpdateFirmwareFromURI implements provisioning.BMCServerClientPort.
+func (_d BMCServerClientPortWithSlog) UpdateFirmwareFromURI(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (bMCTaskMonitor *provisioning.BMCTaskMonitor, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+			slog.String("imageURI", imageURI),
+			slog.Any("targets", targets),
+		)
+	}
+	log.DebugContext(ctx, "=> calling UpdateFirmwareFromURI")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("bMCTaskMonitor", bMCTaskMonitor),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method UpdateFirmwareFromURI returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method UpdateFirmwareFromURI returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method UpdateFirmwareFromURI finished")
+		}
+	}()
+	return _d._base.UpdateFirmwareFromURI(ctx, server, imageURI, targets)
+}
+
 // WaitForTask implements provisioning.BMCServerClientPort.
 func (_d BMCServerClientPortWithSlog) WaitForTask(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) (err error) {
 	log := slog.With()
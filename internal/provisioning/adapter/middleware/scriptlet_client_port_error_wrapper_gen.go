@@ -36,6 +36,16 @@ func (_d ScriptletClientPortWithErrorWrapper) AddApplication(ctx context.Context
 	return _d._base.AddApplication(ctx, server, application)
 }
 
+// GetIncusResource implements scriptlet.ScriptletClientPort.
+func (_d ScriptletClientPortWithErrorWrapper) GetIncusResource(ctx context.Context, server provisioning.Server, resourcePath string) (a1 any, err error) {
+	defer func() {
+		if err != nil {
+			err = _d._wrapErrFunc(err)
+		}
+	}()
+	return _d._base.GetIncusResource(ctx, server, resourcePath)
+}
+
 // GetOSService implements scriptlet.ScriptletClientPort.
 func (_d ScriptletClientPortWithErrorWrapper) GetOSService(ctx context.Context, server provisioning.Server, name string) (stringToV map[string]any, err error) {
 	defer func() {
@@ -75,6 +75,42 @@ func (_d ScriptletClientPortWithSlog) AddApplication(ctx context.Context, server
 	return _d._base.AddApplication(ctx, server, application)
 }
 
+// GetIncusResource implements scriptlet.ScriptletClientPort.
+func (_d ScriptletClientPortWithSlog) GetIncusResource(ctx context.Context, server provisioning.Server, resourcePath string) (a1 any, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("server", server),
+			slog.String("resourcePath", resourcePath),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetIncusResource")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("a1", a1),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetIncusResource returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetIncusResource returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetIncusResource finished")
+		}
+	}()
+	return _d._base.GetIncusResource(ctx, server, resourcePath)
+}
+
 // GetOSService implements scriptlet.ScriptletClientPort.
 func (_d ScriptletClientPortWithSlog) GetOSService(ctx context.Context, server provisioning.Server, name string) (stringToV map[string]any, err error) {
 	log := slog.With()
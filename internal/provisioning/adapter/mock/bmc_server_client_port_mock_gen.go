@@ -37,15 +37,27 @@ var _ provisioning.BMCServerClientPort = &BMCServerClientPortMock{}
 //			DumpFunc: func(ctx context.Context, server provisioning.Server, additionalEndpoints []string, skipPredefined bool, trace bool) (api.BMCDump, error) {
 //				panic("mock out the Dump method")
 //			},
+//			EjectVirtualMediaFunc: func(ctx context.Context, server provisioning.Server, virtualMediaID string) error {
+//				panic("mock out the EjectVirtualMedia method")
+//			},
+//			FirmwareInventoryFunc: func(ctx context.Context, server provisioning.Server) (map[string]api.BMCFirmware, error) {
+//				panic("mock out the FirmwareInventory method")
+//			},
 //			GetDataFunc: func(ctx context.Context, server provisioning.Server) (api.BMCData, error) {
 //				panic("mock out the GetData method")
 //			},
+//			InsertVirtualMediaFunc: func(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (string, *provisioning.BMCTaskMonitor, error) {
+//				panic("mock out the InsertVirtualMedia method")
+//			},
 //			LogEntriesBySourceFunc: func(ctx context.Context, server provisioning.Server, logSource string) ([]api.BMCLogEvent, error) {
 //				panic("mock out the LogEntriesBySource method")
 //			},
 //			LogSourcesFunc: func(ctx context.Context, server provisioning.Server) ([]string, error) {
 //				panic("mock out the LogSources method")
 //			},
+//			SecureEraseDrivesFunc: func(ctx context.Context, server provisioning.Server) (map[string]*provisioning.BMCTaskMonitor, error) {
+//				panic("mock out the SecureEraseDrives method")
+//			},
 //			ServerPowerOffFunc: func(ctx context.Context, server provisioning.Server, force bool) (*provisioning.BMCTaskMonitor, error) {
 //				panic("mock out the ServerPowerOff method")
 //			},
@@ -58,6 +70,15 @@ var _ provisioning.BMCServerClientPort = &BMCServerClientPortMock{}
 //			ServerSetLocationIndicatorFunc: func(ctx context.Context, server provisioning.Server, active bool) error {
 //				panic("mock out the ServerSetLocationIndicator method")
 //			},
+//			SetOneTimeBootFromVirtualMediaFunc: func(ctx context.Context, server provisioning.Server) error {
+//				panic("mock out the SetOneTimeBootFromVirtualMedia method")
+//			},
+//			UpdateFirmwareFromFileFunc: func(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+//				panic("mock out the UpdateFirmwareFromFile method")
+//			},
+//			UpdateFirmwareFromURIFunc: func(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+//				panic("mock out the UpdateFirmwareFromURI method")
+//			},
 //			WaitForTaskFunc: func(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error {
 //				panic("mock out the WaitForTask method")
 //			},
@@ -83,15 +104,27 @@ type BMCServerClientPortMock struct {
 	// DumpFunc mocks the Dump method.
 	DumpFunc func(ctx context.Context, server provisioning.Server, additionalEndpoints []string, skipPredefined bool, trace bool) (api.BMCDump, error)
 
+	// EjectVirtualMediaFunc mocks the EjectVirtualMedia method.
+	EjectVirtualMediaFunc func(ctx context.Context, server provisioning.Server, virtualMediaID string) error
+
+	// FirmwareInventoryFunc mocks the FirmwareInventory method.
+	FirmwareInventoryFunc func(ctx context.Context, server provisioning.Server) (map[string]api.BMCFirmware, error)
+
 	// GetDataFunc mocks the GetData method.
 	GetDataFunc func(ctx context.Context, server provisioning.Server) (api.BMCData, error)
 
+	// InsertVirtualMediaFunc mocks the InsertVirtualMedia method.
+	InsertVirtualMediaFunc func(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (string, *provisioning.BMCTaskMonitor, error)
+
 	// LogEntriesBySourceFunc mocks the LogEntriesBySource method.
 	LogEntriesBySourceFunc func(ctx context.Context, server provisioning.Server, logSource string) ([]api.BMCLogEvent, error)
 
 	// LogSourcesFunc mocks the LogSources method.
 	LogSourcesFunc func(ctx context.Context, server provisioning.Server) ([]string, error)
 
+	// SecureEraseDrivesFunc mocks the SecureEraseDrives method.
+	SecureEraseDrivesFunc func(ctx context.Context, server provisioning.Server) (map[string]*provisioning.BMCTaskMonitor, error)
+
 	// ServerPowerOffFunc mocks the ServerPowerOff method.
 	ServerPowerOffFunc func(ctx context.Context, server provisioning.Server, force bool) (*provisioning.BMCTaskMonitor, error)
 
@@ -104,6 +137,15 @@ type BMCServerClientPortMock struct {
 	// ServerSetLocationIndicatorFunc mocks the ServerSetLocationIndicator method.
 	ServerSetLocationIndicatorFunc func(ctx context.Context, server provisioning.Server, active bool) error
 
+	// SetOneTimeBootFromVirtualMediaFunc mocks the SetOneTimeBootFromVirtualMedia method.
+	SetOneTimeBootFromVirtualMediaFunc func(ctx context.Context, server provisioning.Server) error
+
+	// UpdateFirmwareFromFileFunc mocks the UpdateFirmwareFromFile method.
+	UpdateFirmwareFromFileFunc func(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (*provisioning.BMCTaskMonitor, error)
+
+	// UpdateFirmwareFromURIFunc mocks the UpdateFirmwareFromURI method.
+	UpdateFirmwareFromURIFunc func(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (*provisioning.BMCTaskMonitor, error)
+
 	// WaitForTaskFunc mocks the WaitForTask method.
 	WaitForTaskFunc func(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error
 
@@ -154,6 +196,22 @@ type BMCServerClientPortMock struct {
 			// Trace is the trace argument value.
 			Trace bool
 		}
+		// EjectVirtualMedia holds details about calls to the EjectVirtualMedia method.
+		EjectVirtualMedia []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+			// VirtualMediaID is the virtualMediaID argument value.
+			VirtualMediaID string
+		}
+		// FirmwareInventory holds details about calls to the FirmwareInventory method.
+		FirmwareInventory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+		}
 		// GetData holds details about calls to the GetData method.
 		GetData []struct {
 			// Ctx is the ctx argument value.
@@ -161,6 +219,17 @@ type BMCServerClientPortMock struct {
 			// Server is the server argument value.
 			Server provisioning.Server
 		}
+		// InsertVirtualMedia holds details about calls to the InsertVirtualMedia method.
+		InsertVirtualMedia []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+			// VirtualMediaID is the virtualMediaID argument value.
+			VirtualMediaID string
+			// ImageURL is the imageURL argument value.
+			ImageURL string
+		}
 		// LogEntriesBySource holds details about calls to the LogEntriesBySource method.
 		LogEntriesBySource []struct {
 			// Ctx is the ctx argument value.
@@ -177,6 +246,13 @@ type BMCServerClientPortMock struct {
 			// Server is the server argument value.
 			Server provisioning.Server
 		}
+		// SecureEraseDrives holds details about calls to the SecureEraseDrives method.
+		SecureEraseDrives []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+		}
 		// ServerPowerOff holds details about calls to the ServerPowerOff method.
 		ServerPowerOff []struct {
 			// Ctx is the ctx argument value.
@@ -213,6 +289,35 @@ type BMCServerClientPortMock struct {
 			// Active is the active argument value.
 			Active bool
 		}
+		// SetOneTimeBootFromVirtualMedia holds details about calls to the SetOneTimeBootFromVirtualMedia method.
+		SetOneTimeBootFromVirtualMedia []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+		}
+		// UpdateFirmwareFromFile holds details about calls to the UpdateFirmwareFromFile method.
+		UpdateFirmwareFromFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+			// ImageFilename is the imageFilename argument value.
+			ImageFilename string
+			// Targets is the targets argument value.
+			Targets []string
+		}
+		// UpdateFirmwareFromURI holds details about calls to the UpdateFirmwareFromURI method.
+		UpdateFirmwareFromURI []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+			// ImageURI is the imageURI argument value.
+			ImageURI string
+			// Targets is the targets argument value.
+			Targets []string
+		}
 		// WaitForTask holds details about calls to the WaitForTask method.
 		WaitForTask []struct {
 			// Ctx is the ctx argument value.
@@ -223,19 +328,26 @@ type BMCServerClientPortMock struct {
 			TaskMonitor *provisioning.BMCTaskMonitor
 		}
 	}
-	lockApplyBIOSAttributes        sync.RWMutex
-	lockBIOSAttribute              sync.RWMutex
-	lockBIOSAttributes             sync.RWMutex
-	lockConnectionTest             sync.RWMutex
-	lockDump                       sync.RWMutex
-	lockGetData                    sync.RWMutex
-	lockLogEntriesBySource         sync.RWMutex
-	lockLogSources                 sync.RWMutex
-	lockServerPowerOff             sync.RWMutex
-	lockServerPowerOn              sync.RWMutex
-	lockServerRestart              sync.RWMutex
-	lockServerSetLocationIndicator sync.RWMutex
-	lockWaitForTask                sync.RWMutex
+	lockApplyBIOSAttributes            sync.RWMutex
+	lockBIOSAttribute                  sync.RWMutex
+	lockBIOSAttributes                 sync.RWMutex
+	lockConnectionTest                 sync.RWMutex
+	lockDump                           sync.RWMutex
+	lockEjectVirtualMedia              sync.RWMutex
+	lockFirmwareInventory              sync.RWMutex
+	lockGetData                        sync.RWMutex
+	lockInsertVirtualMedia             sync.RWMutex
+	lockLogEntriesBySource             sync.RWMutex
+	lockLogSources                     sync.RWMutex
+	lockSecureEraseDrives              sync.RWMutex
+	lockServerPowerOff                 sync.RWMutex
+	lockServerPowerOn                  sync.RWMutex
+	lockServerRestart                  sync.RWMutex
+	lockServerSetLocationIndicator     sync.RWMutex
+	lockSetOneTimeBootFromVirtualMedia sync.RWMutex
+	lockUpdateFirmwareFromFile         sync.RWMutex
+	lockUpdateFirmwareFromURI          sync.RWMutex
+	lockWaitForTask                    sync.RWMutex
 }
 
 // ApplyBIOSAttributes calls ApplyBIOSAttributesFunc.
@@ -438,6 +550,82 @@ func (mock *BMCServerClientPortMock) DumpCalls() []struct {
 	return calls
 }
 
+// EjectVirtualMedia calls EjectVirtualMediaFunc.
+func (mock *BMCServerClientPortMock) EjectVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string) error {
+	if mock.EjectVirtualMediaFunc == nil {
+		panic("BMCServerClientPortMock.EjectVirtualMediaFunc: method is nil but BMCServerClientPort.EjectVirtualMedia was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Server         provisioning.Server
+		VirtualMediaID string
+	}{
+		Ctx:            ctx,
+		Server:         server,
+		VirtualMediaID: virtualMediaID,
+	}
+	mock.lockEjectVirtualMedia.Lock()
+	mock.calls.EjectVirtualMedia = append(mock.calls.EjectVirtualMedia, callInfo)
+	mock.lockEjectVirtualMedia.Unlock()
+	return mock.EjectVirtualMediaFunc(ctx, server, virtualMediaID)
+}
+
+// EjectVirtualMediaCalls gets all the calls that were made to EjectVirtualMedia.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.EjectVirtualMediaCalls())
+func (mock *BMCServerClientPortMock) EjectVirtualMediaCalls() []struct {
+	Ctx            context.Context
+	Server         provisioning.Server
+	VirtualMediaID string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Server         provisioning.Server
+		VirtualMediaID string
+	}
+	mock.lockEjectVirtualMedia.RLock()
+	calls = mock.calls.EjectVirtualMedia
+	mock.lockEjectVirtualMedia.RUnlock()
+	return calls
+}
+
+// FirmwareInventory calls FirmwareInventoryFunc.
+func (mock *BMCServerClientPortMock) FirmwareInventory(ctx context.Context, server provisioning.Server) (map[string]api.BMCFirmware, error) {
+	if mock.FirmwareInventoryFunc == nil {
+		panic("BMCServerClientPortMock.FirmwareInventoryFunc: method is nil but BMCServerClientPort.FirmwareInventory was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Server provisioning.Server
+	}{
+		Ctx:    ctx,
+		Server: server,
+	}
+	mock.lockFirmwareInventory.Lock()
+	mock.calls.FirmwareInventory = append(mock.calls.FirmwareInventory, callInfo)
+	mock.lockFirmwareInventory.Unlock()
+	return mock.FirmwareInventoryFunc(ctx, server)
+}
+
+// FirmwareInventoryCalls gets all the calls that were made to FirmwareInventory.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.FirmwareInventoryCalls())
+func (mock *BMCServerClientPortMock) FirmwareInventoryCalls() []struct {
+	Ctx    context.Context
+	Server provisioning.Server
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Server provisioning.Server
+	}
+	mock.lockFirmwareInventory.RLock()
+	calls = mock.calls.FirmwareInventory
+	mock.lockFirmwareInventory.RUnlock()
+	return calls
+}
+
 // GetData calls GetDataFunc.
 func (mock *BMCServerClientPortMock) GetData(ctx context.Context, server provisioning.Server) (api.BMCData, error) {
 	if mock.GetDataFunc == nil {
@@ -474,6 +662,50 @@ func (mock *BMCServerClientPortMock) GetDataCalls() []struct {
 	return calls
 }
 
+// InsertVirtualMedia calls InsertVirtualMediaFunc.
+func (mock *BMCServerClientPortMock) InsertVirtualMedia(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (string, *provisioning.BMCTaskMonitor, error) {
+	if mock.InsertVirtualMediaFunc == nil {
+		panic("BMCServerClientPortMock.InsertVirtualMediaFunc: method is nil but BMCServerClientPort.InsertVirtualMedia was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Server         provisioning.Server
+		VirtualMediaID string
+		ImageURL       string
+	}{
+		Ctx:            ctx,
+		Server:         server,
+		VirtualMediaID: virtualMediaID,
+		ImageURL:       imageURL,
+	}
+	mock.lockInsertVirtualMedia.Lock()
+	mock.calls.InsertVirtualMedia = append(mock.calls.InsertVirtualMedia, callInfo)
+	mock.lockInsertVirtualMedia.Unlock()
+	return mock.InsertVirtualMediaFunc(ctx, server, virtualMediaID, imageURL)
+}
+
+// InsertVirtualMediaCalls gets all the calls that were made to InsertVirtualMedia.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.InsertVirtualMediaCalls())
+func (mock *BMCServerClientPortMock) InsertVirtualMediaCalls() []struct {
+	Ctx            context.Context
+	Server         provisioning.Server
+	VirtualMediaID string
+	ImageURL       string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Server         provisioning.Server
+		VirtualMediaID string
+		ImageURL       string
+	}
+	mock.lockInsertVirtualMedia.RLock()
+	calls = mock.calls.InsertVirtualMedia
+	mock.lockInsertVirtualMedia.RUnlock()
+	return calls
+}
+
 // LogEntriesBySource calls LogEntriesBySourceFunc.
 func (mock *BMCServerClientPortMock) LogEntriesBySource(ctx context.Context, server provisioning.Server, logSource string) ([]api.BMCLogEvent, error) {
 	if mock.LogEntriesBySourceFunc == nil {
@@ -550,6 +782,42 @@ func (mock *BMCServerClientPortMock) LogSourcesCalls() []struct {
 	return calls
 }
 
+// SecureEraseDrives calls SecureEraseDrivesFunc.
+func (mock *BMCServerClientPortMock) SecureEraseDrives(ctx context.Context, server provisioning.Server) (map[string]*provisioning.BMCTaskMonitor, error) {
+	if mock.SecureEraseDrivesFunc == nil {
+		panic("BMCServerClientPortMock.SecureEraseDrivesFunc: method is nil but BMCServerClientPort.SecureEraseDrives was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Server provisioning.Server
+	}{
+		Ctx:    ctx,
+		Server: server,
+	}
+	mock.lockSecureEraseDrives.Lock()
+	mock.calls.SecureEraseDrives = append(mock.calls.SecureEraseDrives, callInfo)
+	mock.lockSecureEraseDrives.Unlock()
+	return mock.SecureEraseDrivesFunc(ctx, server)
+}
+
+// SecureEraseDrivesCalls gets all the calls that were made to SecureEraseDrives.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.SecureEraseDrivesCalls())
+func (mock *BMCServerClientPortMock) SecureEraseDrivesCalls() []struct {
+	Ctx    context.Context
+	Server provisioning.Server
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Server provisioning.Server
+	}
+	mock.lockSecureEraseDrives.RLock()
+	calls = mock.calls.SecureEraseDrives
+	mock.lockSecureEraseDrives.RUnlock()
+	return calls
+}
+
 // ServerPowerOff calls ServerPowerOffFunc.
 func (mock *BMCServerClientPortMock) ServerPowerOff(ctx context.Context, server provisioning.Server, force bool) (*provisioning.BMCTaskMonitor, error) {
 	if mock.ServerPowerOffFunc == nil {
@@ -710,6 +978,130 @@ func (mock *BMCServerClientPortMock) ServerSetLocationIndicatorCalls() []struct
 	return calls
 }
 
+// SetOneTimeBootFromVirtualMedia calls SetOneTimeBootFromVirtualMediaFunc.
+func (mock *BMCServerClientPortMock) SetOneTimeBootFromVirtualMedia(ctx context.Context, server provisioning.Server) error {
+	if mock.SetOneTimeBootFromVirtualMediaFunc == nil {
+		panic("BMCServerClientPortMock.SetOneTimeBootFromVirtualMediaFunc: method is nil but BMCServerClientPort.SetOneTimeBootFromVirtualMedia was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Server provisioning.Server
+	}{
+		Ctx:    ctx,
+		Server: server,
+	}
+	mock.lockSetOneTimeBootFromVirtualMedia.Lock()
+	mock.calls.SetOneTimeBootFromVirtualMedia = append(mock.calls.SetOneTimeBootFromVirtualMedia, callInfo)
+	mock.lockSetOneTimeBootFromVirtualMedia.Unlock()
+	return mock.SetOneTimeBootFromVirtualMediaFunc(ctx, server)
+}
+
+// SetOneTimeBootFromVirtualMediaCalls gets all the calls that were made to SetOneTimeBootFromVirtualMedia.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.SetOneTimeBootFromVirtualMediaCalls())
+func (mock *BMCServerClientPortMock) SetOneTimeBootFromVirtualMediaCalls() []struct {
+	Ctx    context.Context
+	Server provisioning.Server
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Server provisioning.Server
+	}
+	mock.lockSetOneTimeBootFromVirtualMedia.RLock()
+	calls = mock.calls.SetOneTimeBootFromVirtualMedia
+	mock.lockSetOneTimeBootFromVirtualMedia.RUnlock()
+	return calls
+}
+
+// UpdateFirmwareFromFile calls UpdateFirmwareFromFileFunc.
+func (mock *BMCServerClientPortMock) UpdateFirmwareFromFile(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+	if mock.UpdateFirmwareFromFileFunc == nil {
+		panic("BMCServerClientPortMock.UpdateFirmwareFromFileFunc: method is nil but BMCServerClientPort.UpdateFirmwareFromFile was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Server        provisioning.Server
+		ImageFilename string
+		Targets       []string
+	}{
+		Ctx:           ctx,
+		Server:        server,
+		ImageFilename: imageFilename,
+		Targets:       targets,
+	}
+	mock.lockUpdateFirmwareFromFile.Lock()
+	mock.calls.UpdateFirmwareFromFile = append(mock.calls.UpdateFirmwareFromFile, callInfo)
+	mock.lockUpdateFirmwareFromFile.Unlock()
+	return mock.UpdateFirmwareFromFileFunc(ctx, server, imageFilename, targets)
+}
+
+// UpdateFirmwareFromFileCalls gets all the calls that were made to UpdateFirmwareFromFile.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.UpdateFirmwareFromFileCalls())
+func (mock *BMCServerClientPortMock) UpdateFirmwareFromFileCalls() []struct {
+	Ctx           context.Context
+	Server        provisioning.Server
+	ImageFilename string
+	Targets       []string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Server        provisioning.Server
+		ImageFilename string
+		Targets       []string
+	}
+	mock.lockUpdateFirmwareFromFile.RLock()
+	calls = mock.calls.UpdateFirmwareFromFile
+	mock.lockUpdateFirmwareFromFile.RUnlock()
+	return calls
+}
+
+// UpdateFirmwareFromURI calls UpdateFirmwareFromURIFunc.
+func (mock *BMCServerClientPortMock) UpdateFirmwareFromURI(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+	if mock.UpdateFirmwareFromURIFunc == nil {
+		panic("BMCServerClientPortMock.UpdateFirmwareFromURIFunc: method is nil but BMCServerClientPort.UpdateFirmwareFromURI was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Server   provisioning.Server
+		ImageURI string
+		Targets  []string
+	}{
+		Ctx:      ctx,
+		Server:   server,
+		ImageURI: imageURI,
+		Targets:  targets,
+	}
+	mock.lockUpdateFirmwareFromURI.Lock()
+	mock.calls.UpdateFirmwareFromURI = append(mock.calls.UpdateFirmwareFromURI, callInfo)
+	mock.lockUpdateFirmwareFromURI.Unlock()
+	return mock.UpdateFirmwareFromURIFunc(ctx, server, imageURI, targets)
+}
+
+// UpdateFirmwareFromURICalls gets all the calls that were made to UpdateFirmwareFromURI.
+// Check the length with:
+//
+//	len(mockedBMCServerClientPort.UpdateFirmwareFromURICalls())
+func (mock *BMCServerClientPortMock) UpdateFirmwareFromURICalls() []struct {
+	Ctx      context.Context
+	Server   provisioning.Server
+	ImageURI string
+	Targets  []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Server   provisioning.Server
+		ImageURI string
+		Targets  []string
+	}
+	mock.lockUpdateFirmwareFromURI.RLock()
+	calls = mock.calls.UpdateFirmwareFromURI
+	mock.lockUpdateFirmwareFromURI.RUnlock()
+	return calls
+}
+
 // WaitForTask calls WaitForTaskFunc.
 func (mock *BMCServerClientPortMock) WaitForTask(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error {
 	if mock.WaitForTaskFunc == nil {
@@ -25,6 +25,9 @@ var _ scriptlet.ScriptletClientPort = &ScriptletClientPortMock{}
 //			AddApplicationFunc: func(ctx context.Context, server provisioning.Server, application string) error {
 //				panic("mock out the AddApplication method")
 //			},
+//			GetIncusResourceFunc: func(ctx context.Context, server provisioning.Server, resourcePath string) (any, error) {
+//				panic("mock out the GetIncusResource method")
+//			},
 //			GetOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string) (map[string]any, error) {
 //				panic("mock out the GetOSService method")
 //			},
@@ -50,6 +53,9 @@ type ScriptletClientPortMock struct {
 	// AddApplicationFunc mocks the AddApplication method.
 	AddApplicationFunc func(ctx context.Context, server provisioning.Server, application string) error
 
+	// GetIncusResourceFunc mocks the GetIncusResource method.
+	GetIncusResourceFunc func(ctx context.Context, server provisioning.Server, resourcePath string) (any, error)
+
 	// GetOSServiceFunc mocks the GetOSService method.
 	GetOSServiceFunc func(ctx context.Context, server provisioning.Server, name string) (map[string]any, error)
 
@@ -76,6 +82,15 @@ type ScriptletClientPortMock struct {
 			// Application is the application argument value.
 			Application string
 		}
+		// GetIncusResource holds details about calls to the GetIncusResource method.
+		GetIncusResource []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Server is the server argument value.
+			Server provisioning.Server
+			// ResourcePath is the resourcePath argument value.
+			ResourcePath string
+		}
 		// GetOSService holds details about calls to the GetOSService method.
 		GetOSService []struct {
 			// Ctx is the ctx argument value.
@@ -131,6 +146,7 @@ type ScriptletClientPortMock struct {
 		}
 	}
 	lockAddApplication      sync.RWMutex
+	lockGetIncusResource    sync.RWMutex
 	lockGetOSService        sync.RWMutex
 	lockGetSystem           sync.RWMutex
 	lockTriggerSystemAction sync.RWMutex
@@ -178,6 +194,46 @@ func (mock *ScriptletClientPortMock) AddApplicationCalls() []struct {
 	return calls
 }
 
+// GetIncusResource calls GetIncusResourceFunc.
+func (mock *ScriptletClientPortMock) GetIncusResource(ctx context.Context, server provisioning.Server, resourcePath string) (any, error) {
+	if mock.GetIncusResourceFunc == nil {
+		panic("ScriptletClientPortMock.GetIncusResourceFunc: method is nil but ScriptletClientPort.GetIncusResource was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Server       provisioning.Server
+		ResourcePath string
+	}{
+		Ctx:          ctx,
+		Server:       server,
+		ResourcePath: resourcePath,
+	}
+	mock.lockGetIncusResource.Lock()
+	mock.calls.GetIncusResource = append(mock.calls.GetIncusResource, callInfo)
+	mock.lockGetIncusResource.Unlock()
+	return mock.GetIncusResourceFunc(ctx, server, resourcePath)
+}
+
+// GetIncusResourceCalls gets all the calls that were made to GetIncusResource.
+// Check the length with:
+//
+//	len(mockedScriptletClientPort.GetIncusResourceCalls())
+func (mock *ScriptletClientPortMock) GetIncusResourceCalls() []struct {
+	Ctx          context.Context
+	Server       provisioning.Server
+	ResourcePath string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Server       provisioning.Server
+		ResourcePath string
+	}
+	mock.lockGetIncusResource.RLock()
+	calls = mock.calls.GetIncusResource
+	mock.lockGetIncusResource.RUnlock()
+	return calls
+}
+
 // GetOSService calls GetOSServiceFunc.
 func (mock *ScriptletClientPortMock) GetOSService(ctx context.Context, server provisioning.Server, name string) (map[string]any, error) {
 	if mock.GetOSServiceFunc == nil {
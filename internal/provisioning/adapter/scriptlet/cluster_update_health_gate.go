@@ -0,0 +1,237 @@
+package scriptlet
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/scriptlet"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+const clusterUpdateHealthGate = "cluster_update_health_gate"
+
+// clusterUpdateHealthGateValidate validates the cluster update health gate scriptlet.
+func clusterUpdateHealthGateValidate(src string) error {
+	if src == "" {
+		return nil
+	}
+
+	err := scriptlet.Validate(clusterUpdateHealthGateCompile, clusterUpdateHealthGate, src, scriptlet.Declaration{
+		scriptlet.Optional(provisioning.ClusterUpdateHealthGatePreStep):  {"cluster", "server"},
+		scriptlet.Optional(provisioning.ClusterUpdateHealthGatePostStep): {"cluster", "server"},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to validate cluster update health gate scriptlet: %w", err)
+	}
+
+	return nil
+}
+
+// clusterUpdateHealthGateCompile compiles the cluster update health gate scriptlet.
+func clusterUpdateHealthGateCompile(name string, src string) (*starlark.Program, error) {
+	return scriptlet.Compile(name, src, []string{
+		"log",
+		"incus",
+		"incusos",
+		"warnings",
+	})
+}
+
+// ClusterUpdateHealthGateRun executes the function of the cluster update
+// health gate scriptlet for the given step. The gate passes, if the function
+// returns None. If the function returns a string, the gate fails with the
+// returned string as reason.
+func (r Runner) ClusterUpdateHealthGateRun(ctx context.Context, step provisioning.ClusterUpdateHealthGateStep, cluster provisioning.Cluster, server provisioning.Server) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prog, thread, err := r.loader.Program("Cluster update health gate", clusterUpdateHealthGate)
+	if err != nil {
+		// The loader does not provide an error type, that can be asserted other than string matching.
+		if strings.Contains(err.Error(), "scriptlet not loaded") {
+			return nil
+		}
+
+		return err
+	}
+
+	logFunc := CreateLogger(slog.Default(), "Cluster update health gate scriptlet")
+
+	logNamespace := starlarkstruct.FromStringDict(
+		starlarkstruct.Default,
+		starlark.StringDict{
+			"info":  starlark.NewBuiltin("info", logFunc),
+			"warn":  starlark.NewBuiltin("warn", logFunc),
+			"error": starlark.NewBuiltin("error", logFunc),
+		},
+	)
+
+	incusNamespace := starlarkstruct.FromStringDict(
+		starlarkstruct.Default,
+		starlark.StringDict{
+			"get": starlark.NewBuiltin("get", r.getIncusResource(ctx, server)),
+		},
+	)
+
+	incusosNamespace := starlarkstruct.FromStringDict(
+		starlarkstruct.Default,
+		starlark.StringDict{
+			"get_system":  starlark.NewBuiltin("get_system", r.getIncusOSSystem(ctx, server)),
+			"get_service": starlark.NewBuiltin("get_service", r.getIncusOSService(ctx, server)),
+		},
+	)
+
+	warningsNamespace := starlarkstruct.FromStringDict(
+		starlarkstruct.Default,
+		starlark.StringDict{
+			"get_recent": starlark.NewBuiltin("get_recent", r.getRecentWarnings(ctx, cluster)),
+		},
+	)
+
+	env := starlark.StringDict{
+		"log":      logNamespace,
+		"incus":    incusNamespace,
+		"incusos":  incusosNamespace,
+		"warnings": warningsNamespace,
+	}
+
+	go func() {
+		<-ctx.Done()
+		thread.Cancel("Request finished")
+	}()
+
+	globals, err := prog.Init(thread, env)
+	if err != nil {
+		return fmt.Errorf("Failed initializing: %w", err)
+	}
+
+	globals.Freeze()
+
+	// Retrieve a global variable from starlark environment. The functions for
+	// the individual steps are optional.
+	gate := globals[string(step)]
+	if gate == nil {
+		return nil
+	}
+
+	clusterv, err := scriptlet.StarlarkMarshal(cluster)
+	if err != nil {
+		return fmt.Errorf("Marshalling cluster failed: %w", err)
+	}
+
+	serverv, err := scriptlet.StarlarkMarshal(server)
+	if err != nil {
+		return fmt.Errorf("Marshalling server failed: %w", err)
+	}
+
+	v, err := starlark.Call(thread, gate, nil, []starlark.Tuple{
+		{starlark.String("cluster"), clusterv},
+		{starlark.String("server"), serverv},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to run: %w", err)
+	}
+
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil
+
+	case starlark.String:
+		return fmt.Errorf("%s", v.GoString())
+
+	default:
+		return fmt.Errorf("Failed with unexpected return value: %v", v)
+	}
+}
+
+func (r Runner) getIncusResource(ctx context.Context, server provisioning.Server) func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var resourcePath string
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "path", &resourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := r.client.GetIncusResource(ctx, server, resourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get Incus resource %q from server %q: %w", resourcePath, server.Name, err)
+		}
+
+		rv, err := scriptlet.StarlarkMarshal(res)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to marshal value for starlark: %w", err)
+		}
+
+		return rv, nil
+	}
+}
+
+// getRecentWarnings returns the warnings, which are related to the cluster or
+// one of its servers and which occurred within the given period. Acknowledged
+// warnings as well as the warnings raised by failing health gates are ignored,
+// otherwise a failing health gate would keep itself failing.
+func (r Runner) getRecentWarnings(ctx context.Context, cluster provisioning.Cluster) func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var periodStr string
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "period", &periodStr)
+		if err != nil {
+			return nil, err
+		}
+
+		period, err := time.ParseDuration(periodStr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid period %q: %w", periodStr, err)
+		}
+
+		if r.warningSvc == nil {
+			return starlark.NewList(nil), nil
+		}
+
+		allWarnings, err := r.warningSvc.GetAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get warnings: %w", err)
+		}
+
+		since := time.Now().Add(-period)
+
+		recentWarnings := []any{}
+		for _, w := range allWarnings {
+			if w.Status == api.WarningStatusAcknowledged || w.Type == api.WarningTypeClusterUpdateHealthGateFailed {
+				continue
+			}
+
+			if w.LastOccurrence.Before(since) {
+				continue
+			}
+
+			if w.Entity != cluster.Name && !slices.Contains(cluster.ServerNames, w.Entity) {
+				continue
+			}
+
+			recentWarnings = append(recentWarnings, map[string]any{
+				"type":            string(w.Type),
+				"scope":           w.Scope,
+				"entity_type":     w.EntityType,
+				"entity":          w.Entity,
+				"last_occurrence": w.LastOccurrence.Format(time.RFC3339),
+				"messages":        w.Messages,
+				"count":           w.Count,
+			})
+		}
+
+		rv, err := scriptlet.StarlarkMarshal(recentWarnings)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to marshal value for starlark: %w", err)
+		}
+
+		return rv, nil
+	}
+}
@@ -0,0 +1,321 @@
+package scriptlet_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	incusScriptlet "github.com/lxc/incus/v7/shared/scriptlet"
+	"github.com/stretchr/testify/require"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	envMock "github.com/FuturFusion/operations-center/internal/environment/mock"
+	"github.com/FuturFusion/operations-center/internal/lifecycle"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	adapterMock "github.com/FuturFusion/operations-center/internal/provisioning/adapter/mock"
+	"github.com/FuturFusion/operations-center/internal/provisioning/adapter/scriptlet"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
+	"github.com/FuturFusion/operations-center/internal/util/testing/log"
+	"github.com/FuturFusion/operations-center/internal/warning"
+	warningMock "github.com/FuturFusion/operations-center/internal/warning/mock"
+	"github.com/FuturFusion/operations-center/shared/api"
+	"github.com/FuturFusion/operations-center/shared/api/system"
+)
+
+func TestRunner_ClusterUpdateHealthGateRun(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name                      string
+		script                    string
+		step                      provisioning.ClusterUpdateHealthGateStep
+		clientGetIncusResource    any
+		clientGetIncusResourceErr error
+		clientGetOSService        map[string]any
+		clientGetOSServiceErr     error
+		warningSvcGetAll          warning.Warnings
+		warningSvcGetAllErr       error
+
+		assertSetScriptletErr require.ErrorAssertionFunc
+		assertRunErr          require.ErrorAssertionFunc
+		assertLog             log.MatcherFunc
+	}{
+		{
+			name:   "success - empty script",
+			script: ``,
+			step:   provisioning.ClusterUpdateHealthGatePreStep,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          require.NoError,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "success - step not defined",
+			script: `
+def post_step(cluster, server):
+	return "not healthy"
+`,
+			step: provisioning.ClusterUpdateHealthGatePreStep,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          require.NoError,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "success - log",
+			script: `
+def pre_step(cluster, server):
+	log.info("checking ", cluster.name, " before ", server.name)
+`,
+			step: provisioning.ClusterUpdateHealthGatePreStep,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          require.NoError,
+			assertLog:             log.Contains("INF Cluster update health gate scriptlet: checking one before server01"),
+		},
+		{
+			name: "success - incus.get all instances running",
+			script: `
+def post_step(cluster, server):
+	for instance in incus.get("/1.0/instances?recursion=1"):
+		if instance["status"] != "Running":
+			return "instance %s is not running" % instance["name"]
+`,
+			step: provisioning.ClusterUpdateHealthGatePostStep,
+			clientGetIncusResource: []any{
+				map[string]any{
+					"name":   "instance01",
+					"status": "Running",
+				},
+			},
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          require.NoError,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "success - warnings.get_recent",
+			script: `
+def pre_step(cluster, server):
+	recent = warnings.get_recent("5m")
+	if len(recent) > 0:
+		return "%d recent warnings, first: %s" % (len(recent), recent[0]["type"])
+`,
+			step: provisioning.ClusterUpdateHealthGatePreStep,
+			warningSvcGetAll: warning.Warnings{
+				// Acknowledged.
+				{
+					Type:           api.WarningTypeUnreachable,
+					Entity:         "server01",
+					Status:         api.WarningStatusAcknowledged,
+					LastOccurrence: now,
+				},
+				// Too old.
+				{
+					Type:           api.WarningTypeUnreachable,
+					Entity:         "server01",
+					Status:         api.WarningStatusNew,
+					LastOccurrence: now.Add(-10 * time.Minute),
+				},
+				// Other cluster.
+				{
+					Type:           api.WarningTypeUnreachable,
+					Entity:         "other",
+					Status:         api.WarningStatusNew,
+					LastOccurrence: now,
+				},
+				// Raised by the health gate itself.
+				{
+					Type:           api.WarningTypeClusterUpdateHealthGateFailed,
+					Entity:         "one",
+					Status:         api.WarningStatusNew,
+					LastOccurrence: now,
+				},
+			},
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          require.NoError,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "error - invalid script",
+			script: `
+def pre_step(cluster, server):
+	invalid
+`,
+
+			assertSetScriptletErr: require.Error,
+		},
+		{
+			name: "error - invalid signature",
+			script: `
+def pre_step(cluster):
+	pass
+`,
+
+			assertSetScriptletErr: require.Error,
+		},
+		{
+			name: "error - gate failed",
+			script: `
+def pre_step(cluster, server):
+	ceph = incusos.get_service("ceph")
+	if ceph["state"]["health"] != "HEALTH_OK":
+		return "ceph is not healthy"
+`,
+			step: provisioning.ClusterUpdateHealthGatePreStep,
+			clientGetOSService: map[string]any{
+				"state": map[string]any{
+					"health": "HEALTH_WARN",
+				},
+			},
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, "ceph is not healthy")
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - warnings.get_recent",
+			script: `
+def pre_step(cluster, server):
+	recent = warnings.get_recent("5m")
+	if len(recent) > 0:
+		return "%d recent warnings, first: %s" % (len(recent), recent[0]["type"])
+`,
+			step: provisioning.ClusterUpdateHealthGatePreStep,
+			warningSvcGetAll: warning.Warnings{
+				{
+					Type:           api.WarningTypeUnreachable,
+					Entity:         "server02",
+					Status:         api.WarningStatusNew,
+					LastOccurrence: now,
+				},
+			},
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, "1 recent warnings, first: Server unreachable")
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - warnings.get_recent - invalid period",
+			script: `
+def pre_step(cluster, server):
+	warnings.get_recent("invalid")
+`,
+			step: provisioning.ClusterUpdateHealthGatePreStep,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          require.Error,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "error - warnings.get_recent - warning service",
+			script: `
+def pre_step(cluster, server):
+	warnings.get_recent("5m")
+`,
+			step:                provisioning.ClusterUpdateHealthGatePreStep,
+			warningSvcGetAllErr: boom.Error,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          boom.ErrorIs,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "error - incus.get - client",
+			script: `
+def post_step(cluster, server):
+	incus.get("/1.0/instances")
+`,
+			step:                      provisioning.ClusterUpdateHealthGatePostStep,
+			clientGetIncusResourceErr: boom.Error,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr:          boom.ErrorIs,
+			assertLog:             log.Empty,
+		},
+		{
+			name: "error - unexpected return value",
+			script: `
+def post_step(cluster, server):
+	return 1
+`,
+			step: provisioning.ClusterUpdateHealthGatePostStep,
+
+			assertSetScriptletErr: require.NoError,
+			assertRunErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, "unexpected return value")
+			},
+			assertLog: log.Empty,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			config.InitTest(t, &envMock.EnvironmentMock{
+				IsIncusOSFunc: func() bool {
+					return false
+				},
+			}, nil)
+
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", true, false, true)
+			require.NoError(t, err)
+
+			client := &adapterMock.ScriptletClientPortMock{
+				GetIncusResourceFunc: func(ctx context.Context, server provisioning.Server, resourcePath string) (any, error) {
+					return tc.clientGetIncusResource, tc.clientGetIncusResourceErr
+				},
+				GetOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string) (map[string]any, error) {
+					return tc.clientGetOSService, tc.clientGetOSServiceErr
+				},
+			}
+
+			warningSvc := &warningMock.WarningServiceMock{
+				GetAllFunc: func(ctx context.Context) (warning.Warnings, error) {
+					return tc.warningSvcGetAll, tc.warningSvcGetAllErr
+				},
+			}
+
+			loader := incusScriptlet.NewLoader()
+			runner, err := scriptlet.New(loader, client, warningSvc)
+			require.NoError(t, err)
+			defer lifecycle.SettingsUpdateSignal.Reset()
+			defer lifecycle.SettingsValidateSignal.Reset()
+
+			// Load script
+			err = config.UpdateSettings(t.Context(), system.SettingsPut{
+				ClusterUpdateHealthGateScriptlet: tc.script,
+			})
+			tc.assertSetScriptletErr(t, err)
+			if err != nil {
+				return
+			}
+
+			// Run test
+			cluster := provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server01", "server02"},
+			}
+
+			server := provisioning.Server{
+				Name:          "server01",
+				Cluster:       &cluster.Name,
+				ConnectionURL: "https://1.2.3.4:8443",
+			}
+
+			err = runner.ClusterUpdateHealthGateRun(t.Context(), tc.step, cluster, server)
+			tc.assertRunErr(t, err)
+
+			// Assertions
+			tc.assertLog(t, logBuf)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	daemonConfig "github.com/FuturFusion/operations-center/internal/config/daemon"
 	"github.com/FuturFusion/operations-center/internal/lifecycle"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/warning"
 	"github.com/FuturFusion/operations-center/shared/api/system"
 )
 
@@ -20,28 +21,43 @@ type ScriptletClientPort interface {
 	GetOSService(ctx context.Context, server provisioning.Server, name string) (map[string]any, error)
 	UpdateOSService(ctx context.Context, server provisioning.Server, name string, config any) error
 	AddApplication(ctx context.Context, server provisioning.Server, application string) error
+	GetIncusResource(ctx context.Context, server provisioning.Server, resourcePath string) (any, error)
 }
 
 type Runner struct {
 	loader *scriptlet.Loader
 
-	client ScriptletClientPort
+	client     ScriptletClientPort
+	warningSvc warning.WarningService
 }
 
-var _ provisioning.ServerScriptletPort = Runner{}
+var (
+	_ provisioning.ServerScriptletPort  = Runner{}
+	_ provisioning.ClusterScriptletPort = Runner{}
+)
 
-func New(loader *scriptlet.Loader, client ScriptletClientPort) (Runner, error) {
+func New(loader *scriptlet.Loader, client ScriptletClientPort, warningSvc warning.WarningService) (Runner, error) {
 	err := loader.Set(serverRegistrationCompile, serverRegistration, daemonConfig.GetSettings().ServerRegistrationScriptlet)
 	if err != nil {
 		return Runner{}, fmt.Errorf("Failed to load server registration scriptlet: %w", err)
 	}
 
+	err = loader.Set(clusterUpdateHealthGateCompile, clusterUpdateHealthGate, daemonConfig.GetSettings().ClusterUpdateHealthGateScriptlet)
+	if err != nil {
+		return Runner{}, fmt.Errorf("Failed to load cluster update health gate scriptlet: %w", err)
+	}
+
 	lifecycle.SettingsValidateSignal.AddListenerWithErr(func(ctx context.Context, settings system.Settings) error {
 		err := serverRegistrationValidate(settings.ServerRegistrationScriptlet)
 		if err != nil {
 			return fmt.Errorf("Failed to validate server registration scriptlet: %w", err)
 		}
 
+		err = clusterUpdateHealthGateValidate(settings.ClusterUpdateHealthGateScriptlet)
+		if err != nil {
+			return fmt.Errorf("Failed to validate cluster update health gate scriptlet: %w", err)
+		}
+
 		return nil
 	})
 
@@ -51,11 +67,17 @@ func New(loader *scriptlet.Loader, client ScriptletClientPort) (Runner, error) {
 			return fmt.Errorf("Failed to compile and cache server registration scriptlet: %w", err)
 		}
 
+		err = loader.Set(clusterUpdateHealthGateCompile, clusterUpdateHealthGate, settings.ClusterUpdateHealthGateScriptlet)
+		if err != nil {
+			return fmt.Errorf("Failed to compile and cache cluster update health gate scriptlet: %w", err)
+		}
+
 		return nil
 	})
 
 	return Runner{
-		loader: loader,
-		client: client,
+		loader:     loader,
+		client:     client,
+		warningSvc: warningSvc,
 	}, nil
 }
@@ -664,7 +664,7 @@ def server_registration(candidate):
 			}
 
 			loader := incusScriptlet.NewLoader()
-			runner, err := scriptlet.New(loader, client)
+			runner, err := scriptlet.New(loader, client, nil)
 			require.NoError(t, err)
 			defer lifecycle.SettingsUpdateSignal.Reset()
 			defer lifecycle.SettingsValidateSignal.Reset()
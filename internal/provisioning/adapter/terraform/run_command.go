@@ -2,14 +2,17 @@ package terraform
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/lxc/incus/v7/shared/subprocess"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
 )
 
 func (t terraform) terraformInit(ctx context.Context, configDir string) error {
@@ -46,6 +49,67 @@ func (t terraform) terraformApply(ctx context.Context, configDir string) error {
 	return nil
 }
 
+const driftPlanFilename = "drift.tfplan"
+
+func (t terraform) terraformPlan(ctx context.Context, configDir string) (provisioning.ClusterProvisioningPlan, error) {
+	env := cleanEnvVars(os.Environ())
+
+	// Make sure, terraform provider uses the client certificate of Operations Center.
+	env = append(env, "INCUS_CONF="+t.tmpDir)
+
+	output, _, err := subprocess.RunCommandSplit(ctx, env, nil, "tofu", "-chdir="+configDir, "plan", "-input=false", "-lock=false", "-no-color", "-out="+driftPlanFilename)
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf(`Failed to run "tofu plan": %w`, err)
+	}
+
+	planJSON, _, err := subprocess.RunCommandSplit(ctx, env, nil, "tofu", "-chdir="+configDir, "show", "-json", driftPlanFilename)
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf(`Failed to run "tofu show": %w`, err)
+	}
+
+	changedResources, err := changedResourcesFromPlan([]byte(planJSON))
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, err
+	}
+
+	return provisioning.ClusterProvisioningPlan{
+		Output:           output,
+		ChangedResources: changedResources,
+	}, nil
+}
+
+// changedResourcesFromPlan returns the sorted addresses of the resources,
+// which would be changed according to the JSON representation of a plan.
+func changedResourcesFromPlan(planJSON []byte) ([]string, error) {
+	var plan struct {
+		ResourceChanges []struct {
+			Address string `json:"address"`
+			Change  struct {
+				Actions []string `json:"actions"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+
+	err := json.Unmarshal(planJSON, &plan)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse terraform plan: %w", err)
+	}
+
+	changedResources := []string{}
+	for _, resourceChange := range plan.ResourceChanges {
+		actions := resourceChange.Change.Actions
+		if len(actions) == 0 || slices.Equal(actions, []string{"no-op"}) || slices.Equal(actions, []string{"read"}) {
+			continue
+		}
+
+		changedResources = append(changedResources, resourceChange.Address)
+	}
+
+	slices.Sort(changedResources)
+
+	return changedResources, nil
+}
+
 func cleanEnvVars(envVars []string) []string {
 	cleanEnv := make([]string, 0, len(envVars))
 
@@ -53,3 +53,53 @@ func Test_cleanEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func Test_changedResourcesFromPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		planJSON string
+
+		assertErr require.ErrorAssertionFunc
+		want      []string
+	}{
+		{
+			name: "success - changes",
+			planJSON: `{
+  "resource_changes": [
+    {"address": "incus_project.internal", "change": {"actions": ["no-op"]}},
+    {"address": "incus_network.incusbr0", "change": {"actions": ["update"]}},
+    {"address": "data.incus_cluster.this", "change": {"actions": ["read"]}},
+    {"address": "incus_profile.default", "change": {"actions": ["delete", "create"]}}
+  ]
+}`,
+
+			assertErr: require.NoError,
+			want: []string{
+				"incus_network.incusbr0",
+				"incus_profile.default",
+			},
+		},
+		{
+			name:     "success - no changes",
+			planJSON: `{"resource_changes": [{"address": "incus_project.internal", "change": {"actions": ["no-op"]}}]}`,
+
+			assertErr: require.NoError,
+			want:      []string{},
+		},
+		{
+			name:     "error - invalid JSON",
+			planJSON: `{`,
+
+			assertErr: require.Error,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := changedResourcesFromPlan([]byte(tc.planJSON))
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
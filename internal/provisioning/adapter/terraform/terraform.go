@@ -3,24 +3,22 @@ package terraform
 import (
 	"context"
 	"embed"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
-	incusapi "github.com/lxc/incus/v7/shared/api"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/FuturFusion/operations-center/internal/environment"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
 	"github.com/FuturFusion/operations-center/internal/util/file"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
 )
 
 var terraformProviders = map[string]struct {
@@ -52,6 +50,7 @@ type terraform struct {
 
 	terraformInitFunc  func(ctx context.Context, configDir string) error
 	terraformApplyFunc func(ctx context.Context, configDir string) error
+	terraformPlanFunc  func(ctx context.Context, configDir string) (provisioning.ClusterProvisioningPlan, error)
 
 	incusProviderVersion  string
 	randomProviderVersion string
@@ -78,7 +77,10 @@ func init() {
 	}
 }
 
-var _ provisioning.ClusterProvisioningPort = &terraform{}
+var (
+	_ provisioning.ClusterProvisioningPort      = &terraform{}
+	_ provisioning.ClusterProvisioningDriftPort = &terraform{}
+)
 
 type Option func(*terraform)
 
@@ -107,6 +109,7 @@ func New(
 
 	t.terraformInitFunc = t.terraformInit
 	t.terraformApplyFunc = t.terraformApply
+	t.terraformPlanFunc = t.terraformPlan
 
 	for _, opt := range opts {
 		opt(&t)
@@ -116,7 +119,7 @@ func New(
 }
 
 func (t terraform) Init(ctx context.Context, name string, config provisioning.ClusterProvisioningConfig) (string, func() error, error) {
-	incusPreseed, err := incusPreseedWithDefaults(config.Cluster.ApplicationSeedConfig)
+	incusPreseed, err := provisioning.IncusPreseedWithDefaults(config.Cluster.ApplicationSeedConfig)
 	if err != nil {
 		return "", nil, fmt.Errorf("Application seed config is not valid: %w", err)
 	}
@@ -165,7 +168,7 @@ func (t terraform) Init(ctx context.Context, name string, config provisioning.Cl
 			case ".gotmpl":
 				meshTunnelInterfaces := make(map[string]string, len(config.Servers))
 				for _, server := range config.Servers {
-					meshTunnelInterfaces[server.Name] = detectClusterInterface(server.OSData.Network)
+					meshTunnelInterfaces[server.Name] = provisioning.DetectClusterInterface(server.OSData.Network)
 				}
 
 				err = tmpl.ExecuteTemplate(
@@ -250,337 +253,52 @@ func cleanup(path string) func() error {
 	}
 }
 
-func incusPreseedWithDefaults(config map[string]any) (incusapi.InitLocalPreseed, error) {
-	body, err := json.Marshal(config)
-	if err != nil {
-		return incusapi.InitLocalPreseed{}, err
-	}
-
-	preseed := incusapi.InitLocalPreseed{
-		ServerPut: incusapi.ServerPut{
-			Config: map[string]string{},
-		},
+func (t terraform) Apply(ctx context.Context, cluster provisioning.Cluster) error {
+	configDir := filepath.Join(t.storageDir, cluster.Name)
+	if !file.PathExists(configDir) {
+		return fmt.Errorf("Initialized Terraform config not found")
 	}
 
-	err = json.Unmarshal(body, &preseed)
+	err := t.terraformApplyFunc(ctx, configDir)
 	if err != nil {
-		return incusapi.InitLocalPreseed{}, err
-	}
-
-	// Default values for server configuration.
-	_, ok := preseed.Config["storage.backups_volume"]
-	if !ok {
-		preseed.Config["storage.backups_volume"] = "local/backups"
-	}
-
-	_, ok = preseed.Config["storage.images_volume"]
-	if !ok {
-		preseed.Config["storage.images_volume"] = "local/images"
-	}
-
-	_, ok = preseed.Config["storage.logs_volume"]
-	if !ok {
-		preseed.Config["storage.logs_volume"] = "local/logs"
-	}
-
-	// Set default configuration for local storage pool, if the local storage pool
-	// exists in the preseed.
-	var hasLocalStoragePool bool
-	for i := range preseed.StoragePools {
-		switch preseed.StoragePools[i].Name {
-		case "local":
-			if preseed.StoragePools[i].Description == "" {
-				preseed.StoragePools[i].Description = "Local storage pool (on system drive)"
-			}
-
-			if preseed.StoragePools[i].Config == nil {
-				preseed.StoragePools[i].Config = map[string]string{}
-			}
-
-			_, ok := preseed.StoragePools[i].Config["source"]
-			if !ok {
-				preseed.StoragePools[i].Config["source"] = "local/incus"
-			}
-
-			hasLocalStoragePool = true
-		}
-	}
-
-	// Add local storage pool, if it is not defined in the preseed.
-	if !hasLocalStoragePool {
-		preseed.StoragePools = append(preseed.StoragePools, incusapi.StoragePoolsPost{
-			Name:   "local",
-			Driver: "zfs",
-			StoragePoolPut: incusapi.StoragePoolPut{
-				Config: map[string]string{
-					"source": "local/incus",
-				},
-				Description: "Local storage pool (on system drive)",
-			},
-		})
-	}
-
-	// Set default configuration for the internal project, if the default project
-	// exists in the preseed.
-	var hasInternalProject bool
-	for i := range preseed.Projects {
-		switch preseed.Projects[i].Name {
-		case "internal":
-			if preseed.Projects[i].Description == "" {
-				preseed.Projects[i].Description = "Internal project to isolate fully managed resources."
-			}
-
-			hasInternalProject = true
-		}
-	}
-
-	// Add internal project, if it is not defined in the preseed.
-	if !hasInternalProject {
-		preseed.Projects = append(preseed.Projects, incusapi.ProjectsPost{
-			Name: "internal",
-			ProjectPut: incusapi.ProjectPut{
-				Description: "Internal project to isolate fully managed resources.",
-			},
-		})
-	}
-
-	// Set default configuration for the incusbr0 network, if the incusbr0 network
-	// exists in the preseed.
-	var hasIncusbr0Network bool
-	for i := range preseed.Networks {
-		switch preseed.Networks[i].Name {
-		case "incusbr0":
-			if preseed.Networks[i].Description == "" {
-				preseed.Networks[i].Description = "Local network bridge (NAT)"
-			}
-
-			hasIncusbr0Network = true
-		}
-	}
-
-	// Network meshbr0 is reserved and can not be overwritten with the seed config.
-	// Ensure, it is not present in the preseed.
-	for i := range preseed.Networks {
-		if preseed.Networks[i].Name == "meshbr0" {
-			preseed.Networks = slices.Delete(preseed.Networks, i, i+1)
-			break
-		}
-	}
-
-	// Add incusbr0 network, if it is not defined in the preseed.
-	if !hasIncusbr0Network {
-		preseed.Networks = append(preseed.Networks, incusapi.InitNetworksProjectPost{
-			NetworksPost: incusapi.NetworksPost{
-				Name: "incusbr0",
-				Type: "bridge",
-				NetworkPut: incusapi.NetworkPut{
-					Description: "Local network bridge (NAT)",
-				},
-			},
-		})
-	}
-
-	// Set default configuration for the backups and images storage volumes on
-	// the local storage pool, if they exist in the preseed.
-	var hasLocalBackupsStorageVolume bool
-	var hasLocalImagesStorageVolume bool
-	var hasLocalLogsStorageVolume bool
-	for i := range preseed.StorageVolumes {
-		switch {
-		case preseed.StorageVolumes[i].Pool == "local" && preseed.StorageVolumes[i].Name == "backups":
-			if preseed.StorageVolumes[i].Description == "" {
-				preseed.StorageVolumes[i].Description = "Volume holding system backups"
-			}
-
-			hasLocalBackupsStorageVolume = true
-
-		case preseed.StorageVolumes[i].Pool == "local" && preseed.StorageVolumes[i].Name == "images":
-			if preseed.StorageVolumes[i].Description == "" {
-				preseed.StorageVolumes[i].Description = "Volume holding system images"
-			}
-
-			hasLocalImagesStorageVolume = true
-
-		case preseed.StorageVolumes[i].Pool == "local" && preseed.StorageVolumes[i].Name == "logs":
-			if preseed.StorageVolumes[i].Description == "" {
-				preseed.StorageVolumes[i].Description = "Volume holding system logs"
-			}
-
-			hasLocalLogsStorageVolume = true
-		}
-	}
-
-	// Add backups storage volume on the local storage pool if it is not defined
-	// in the preseed.
-	if !hasLocalBackupsStorageVolume {
-		preseed.StorageVolumes = append(preseed.StorageVolumes, incusapi.InitStorageVolumesProjectPost{
-			Pool: "local",
-			StorageVolumesPost: incusapi.StorageVolumesPost{
-				Name:        "backups",
-				Type:        "custom",
-				ContentType: "filesystem",
-				StorageVolumePut: incusapi.StorageVolumePut{
-					Description: "Volume holding system backups",
-				},
-			},
-		})
-	}
-
-	// Add images storage volume on the local storage pool if it is not defined
-	// in the preseed.
-	if !hasLocalImagesStorageVolume {
-		preseed.StorageVolumes = append(preseed.StorageVolumes, incusapi.InitStorageVolumesProjectPost{
-			Pool: "local",
-			StorageVolumesPost: incusapi.StorageVolumesPost{
-				Name:        "images",
-				Type:        "custom",
-				ContentType: "filesystem",
-				StorageVolumePut: incusapi.StorageVolumePut{
-					Description: "Volume holding system images",
-				},
-			},
-		})
-	}
-
-	// Add logs storage volume on the local storage pool if it is not defined
-	// in the preseed.
-	if !hasLocalLogsStorageVolume {
-		preseed.StorageVolumes = append(preseed.StorageVolumes, incusapi.InitStorageVolumesProjectPost{
-			Pool: "local",
-			StorageVolumesPost: incusapi.StorageVolumesPost{
-				Name:        "logs",
-				Type:        "custom",
-				ContentType: "filesystem",
-				StorageVolumePut: incusapi.StorageVolumePut{
-					Description: "Volume holding system logs",
-				},
-			},
-		})
+		return fmt.Errorf("Failed to apply Terraform configuration: %w", err)
 	}
 
-	// Set default configuration values for default profiles of the default and
-	// the internal projects, if these profiles exist in the preseed.
-	var hasDefaultProjectDefaultProfile bool
-	var hasInternalProjectDefaultProfile bool
-	for i := range preseed.Profiles {
-		switch {
-		case preseed.Profiles[i].Project == "" && preseed.Profiles[i].Name == "default":
-			if preseed.Profiles[i].Devices == nil {
-				preseed.Profiles[i].Devices = map[string]map[string]string{}
-			}
-
-			_, ok := preseed.Profiles[i].Devices["root"]
-			if !ok {
-				preseed.Profiles[i].Devices["root"] = map[string]string{
-					"type": "disk",
-					"path": "/",
-					"pool": "local",
-				}
-			}
-
-			_, ok = preseed.Profiles[i].Devices["eth0"]
-			if !ok {
-				preseed.Profiles[i].Devices["eth0"] = map[string]string{
-					"type":    "nic",
-					"network": "incusbr0",
-				}
-			}
-
-			hasDefaultProjectDefaultProfile = true
-
-		case preseed.Profiles[i].Project == "internal" && preseed.Profiles[i].Name == "default":
-			if preseed.Profiles[i].Devices == nil {
-				preseed.Profiles[i].Devices = map[string]map[string]string{}
-			}
-
-			_, ok := preseed.Profiles[i].Devices["root"]
-			if !ok {
-				preseed.Profiles[i].Devices["root"] = map[string]string{
-					"type": "disk",
-					"path": "/",
-					"pool": "local",
-				}
-			}
-
-			_, ok = preseed.Profiles[i].Devices["eth0"]
-			if !ok {
-				preseed.Profiles[i].Devices["eth0"] = map[string]string{
-					"type":    "nic",
-					"network": "meshbr0",
-				}
-			}
-
-			hasInternalProjectDefaultProfile = true
-		}
+	err = terraformConfigPostProcessing(configDir, cluster)
+	if err != nil {
+		return fmt.Errorf("Failed to post process terraform configuration: %w", err)
 	}
 
-	// Add default profile for the default project, if it is not defined in the
-	// preseed.
-	if !hasDefaultProjectDefaultProfile {
-		preseed.Profiles = append(preseed.Profiles, incusapi.InitProfileProjectPost{
-			ProfilesPost: incusapi.ProfilesPost{
-				Name: "default",
-				ProfilePut: incusapi.ProfilePut{
-					Devices: map[string]map[string]string{
-						"root": {
-							"type": "disk",
-							"path": "/",
-							"pool": "local",
-						},
-						"eth0": {
-							"type":    "nic",
-							"network": "incusbr0",
-						},
-					},
-				},
-			},
-		})
-	}
+	return nil
+}
 
-	// Add default profile for the internal project, if it is not defined in the
-	// preseed.
-	if !hasInternalProjectDefaultProfile {
-		preseed.Profiles = append(preseed.Profiles, incusapi.InitProfileProjectPost{
-			ProfilesPost: incusapi.ProfilesPost{
-				Name: "default",
-				ProfilePut: incusapi.ProfilePut{
-					Devices: map[string]map[string]string{
-						"root": {
-							"type": "disk",
-							"path": "/",
-							"pool": "local",
-						},
-						"eth0": {
-							"type":    "nic",
-							"network": "meshbr0",
-						},
-					},
-				},
-			},
-			Project: "internal",
-		})
+// Plan performs a plan-only pass of the Terraform configuration in configDir,
+// which is a copy of the Terraform configuration artifact of the cluster,
+// including the Terraform state, against the cluster.
+func (t terraform) Plan(ctx context.Context, cluster provisioning.Cluster, configDir string) (provisioning.ClusterProvisioningPlan, error) {
+	err := t.SeedCertificate(ctx, cluster.Name, ptr.From(cluster.Certificate))
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to seed server certificates: %w", err)
 	}
 
-	return preseed, nil
-}
-
-func (t terraform) Apply(ctx context.Context, cluster provisioning.Cluster) error {
-	configDir := filepath.Join(t.storageDir, cluster.Name)
-	if !file.PathExists(configDir) {
-		return fmt.Errorf("Initialized Terraform config not found")
+	// The connection URL of the cluster might have changed since the Terraform
+	// configuration has been generated.
+	err = terraformConfigPostProcessing(configDir, cluster)
+	if err != nil {
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to update terraform configuration: %w", err)
 	}
 
-	err := t.terraformApplyFunc(ctx, configDir)
+	err = t.terraformInitFunc(ctx, configDir)
 	if err != nil {
-		return fmt.Errorf("Failed to apply Terraform configuration: %w", err)
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to init Terraform: %w", err)
 	}
 
-	err = terraformConfigPostProcessing(configDir, cluster)
+	plan, err := t.terraformPlanFunc(ctx, configDir)
 	if err != nil {
-		return fmt.Errorf("Failed to post process terraform configuration: %w", err)
+		return provisioning.ClusterProvisioningPlan{}, fmt.Errorf("Failed to plan Terraform configuration: %w", err)
 	}
 
-	return nil
+	return plan, nil
 }
 
 // terraformConfigPostProcessing updates the Terraform configuration after
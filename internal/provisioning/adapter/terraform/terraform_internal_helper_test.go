@@ -1,6 +1,10 @@
 package terraform
 
-import "context"
+import (
+	"context"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+)
 
 func WithTerraformInitFunc(initFunc func(ctx context.Context, configDir string) error) Option {
 	return func(t *terraform) {
@@ -13,3 +17,9 @@ func WithTerraformApplyFunc(applyFunc func(ctx context.Context, configDir string
 		t.terraformApplyFunc = applyFunc
 	}
 }
+
+func WithTerraformPlanFunc(planFunc func(ctx context.Context, configDir string) (provisioning.ClusterProvisioningPlan, error)) Option {
+	return func(t *terraform) {
+		t.terraformPlanFunc = planFunc
+	}
+}
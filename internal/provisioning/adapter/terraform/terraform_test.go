@@ -430,3 +430,101 @@ func TestTerraform_Apply(t *testing.T) {
 		})
 	}
 }
+
+func TestTerraform_Plan(t *testing.T) {
+	validProvidersTf := `provider "incus" {
+  default_remote = "mycluster"
+  remote {
+    name    = "mycluster"
+    address = "https://some-host:1234"
+  }
+}`
+
+	tests := []struct {
+		name             string
+		providersTf      string
+		terraformInitErr error
+		terraformPlan    provisioning.ClusterProvisioningPlan
+		terraformPlanErr error
+
+		assertErr require.ErrorAssertionFunc
+		wantPlan  provisioning.ClusterProvisioningPlan
+	}{
+		{
+			name:        "success",
+			providersTf: validProvidersTf,
+			terraformPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "Plan: 0 to add, 1 to change, 0 to destroy.",
+				ChangedResources: []string{"incus_network.incusbr0"},
+			},
+
+			assertErr: require.NoError,
+			wantPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "Plan: 0 to add, 1 to change, 0 to destroy.",
+				ChangedResources: []string{"incus_network.incusbr0"},
+			},
+		},
+		{
+			name:        "error - providers.tf invalid Terraform config",
+			providersTf: `provider "incus" {`, // invalid Terraform configuration.
+
+			assertErr: require.Error,
+		},
+		{
+			name:             "error - terraform init",
+			providersTf:      validProvidersTf,
+			terraformInitErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:             "error - terraform plan",
+			providersTf:      validProvidersTf,
+			terraformPlanErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			tmpDir := t.TempDir()
+			configDir := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(configDir, "providers.tf"), []byte(tc.providersTf), 0o600)
+			require.NoError(t, err)
+
+			tf, err := terraform.New(
+				tmpDir,
+				"",
+				"",
+				terraform.WithTerraformInitFunc(func(ctx context.Context, configDir string) error {
+					return tc.terraformInitErr
+				}),
+				terraform.WithTerraformPlanFunc(func(ctx context.Context, configDir string) (provisioning.ClusterProvisioningPlan, error) {
+					return tc.terraformPlan, tc.terraformPlanErr
+				}),
+			)
+			require.NoError(t, err)
+
+			cluster := provisioning.Cluster{
+				Name:          "mycluster",
+				ConnectionURL: "https://localhost:8443",
+				Certificate:   ptr.To("certificate"),
+			}
+
+			// Run test
+			plan, err := tf.Plan(t.Context(), cluster, configDir)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantPlan, plan)
+
+			if err == nil {
+				fileContains(t, filepath.Join(configDir, "providers.tf"), `"https://localhost:8443"`)
+				fileContains(t, filepath.Join(tmpDir, "servercerts", "mycluster.crt"), "certificate")
+			}
+		})
+	}
+}
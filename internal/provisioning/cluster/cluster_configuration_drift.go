@@ -0,0 +1,266 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/file"
+	"github.com/FuturFusion/operations-center/internal/warning"
+	"github.com/FuturFusion/operations-center/shared/api"
+	"github.com/FuturFusion/operations-center/shared/api/system"
+)
+
+// clusterProvisioningArtifact describes the artifact, which holds the
+// configuration used for the post-clustering initialization by a cluster
+// provisioning backend.
+type clusterProvisioningArtifact struct {
+	backend string
+	name    string
+	title   string
+}
+
+var clusterProvisioningArtifacts = []clusterProvisioningArtifact{
+	{backend: system.ClusterProvisioningBackendTerraform, name: "terraform-configuration", title: "Terraform"},
+	{backend: system.ClusterProvisioningBackendIncus, name: "incus-configuration", title: "Incus"},
+}
+
+// configurationDriftPlanRetention is the number of the most recent revisions
+// of the plan artifact, which are kept for each cluster.
+const configurationDriftPlanRetention = 10
+
+// configurationDriftPlanArtifactPrefix returns the prefix of the artifacts,
+// which hold the revisions of the plan output of the configuration drift
+// detection for the given backend, e.g. "terraform-plan-".
+func configurationDriftPlanArtifactPrefix(backend string) string {
+	return backend + "-plan-"
+}
+
+// DetectConfigurationDrift performs a plan-only pass of the configuration,
+// which has been used for the post-clustering initialization, against each
+// ready cluster. The plan is performed by the drift detector of the backend,
+// the cluster has been initialized with.
+//
+// If the plan output changed since the last pass, it is stored as a new
+// revision of the plan artifact of the cluster. If resources would be changed
+// by the plan, a cluster configuration drift warning listing the changed
+// resources is raised, otherwise a previously raised warning is removed.
+func (s *clusterService) DetectConfigurationDrift(ctx context.Context) error {
+	if len(s.driftDetectors) == 0 {
+		return fmt.Errorf("Cluster configuration drift detection requires a drift detector")
+	}
+
+	clusters, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get clusters for configuration drift detection: %w", err)
+	}
+
+	var errs []error
+	for _, cluster := range clusters {
+		if cluster.Status != api.ClusterStatusReady {
+			continue
+		}
+
+		err = s.detectClusterConfigurationDrift(ctx, cluster)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to detect configuration drift of cluster %q: %w", cluster.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *clusterService) detectClusterConfigurationDrift(ctx context.Context, cluster provisioning.Cluster) error {
+	var provisioningArtifact clusterProvisioningArtifact
+	var artifact *provisioning.ClusterArtifact
+	for _, candidate := range clusterProvisioningArtifacts {
+		var err error
+		artifact, err = s.localartifact.GetClusterArtifactByName(ctx, cluster.Name, candidate.name)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		provisioningArtifact = candidate
+		break
+	}
+
+	// Clusters, which have not been initialized by Operations Center (e.g.
+	// adopted clusters), do not have a configuration to detect drift against.
+	if provisioningArtifact.backend == "" {
+		return nil
+	}
+
+	driftDetector, ok := s.driftDetectors[provisioningArtifact.backend]
+	if !ok {
+		return fmt.Errorf("Configuration drift detection is not supported for clusters initialized with the %q cluster provisioning backend: %w", provisioningArtifact.backend, domain.ErrOperationNotPermitted)
+	}
+
+	configDir, err := os.MkdirTemp("", "operations-center-drift-*")
+	if err != nil {
+		return fmt.Errorf("Failed to create temporary directory: %w", err)
+	}
+
+	defer os.RemoveAll(configDir)
+
+	for _, artifactFile := range artifact.Files {
+		err = copyClusterArtifactFile(artifactFile, filepath.Join(configDir, artifactFile.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	plan, err := driftDetector.Plan(ctx, cluster, configDir)
+	if err != nil {
+		return err
+	}
+
+	err = s.storeConfigurationDriftPlan(ctx, cluster.Name, provisioningArtifact, plan)
+	if err != nil {
+		return err
+	}
+
+	scope := api.WarningScope{
+		Scope:      "configuration_drift",
+		EntityType: "cluster",
+		Entity:     cluster.Name,
+	}
+
+	if len(plan.ChangedResources) == 0 {
+		s.warning.RemoveStale(ctx, scope, nil)
+		return nil
+	}
+
+	slog.WarnContext(ctx, "Cluster configuration drift detected", slog.String("cluster", cluster.Name), slog.Any("changed_resources", plan.ChangedResources))
+
+	driftWarning := warning.NewWarning(
+		api.WarningTypeClusterConfigurationDrift,
+		scope,
+		fmt.Sprintf("Configuration of cluster drifted from its %s configuration, changed resources: %s", provisioningArtifact.title, strings.Join(plan.ChangedResources, ", ")),
+	)
+
+	s.warning.Emit(ctx, driftWarning)
+
+	// Prune the messages of previous passes with a different set of changed
+	// resources.
+	s.warning.RemoveStale(ctx, scope, warning.Warnings{driftWarning})
+
+	return nil
+}
+
+// storeConfigurationDriftPlan stores the plan output as a new revision of the
+// plan artifact of the cluster, unless the changed resources are the same as
+// in the latest revision. Only the most recent revisions are kept, older
+// revisions are removed.
+func (s *clusterService) storeConfigurationDriftPlan(ctx context.Context, clusterName string, provisioningArtifact clusterProvisioningArtifact, plan provisioning.ClusterProvisioningPlan) error {
+	planArtifactPrefix := configurationDriftPlanArtifactPrefix(provisioningArtifact.backend)
+
+	artifacts, err := s.localartifact.GetClusterArtifactAll(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	var latestRevision int
+	var latest *provisioning.ClusterArtifact
+	revisions := map[int]string{}
+	for i, artifact := range artifacts {
+		revisionStr, ok := strings.CutPrefix(artifact.Name, planArtifactPrefix)
+		if !ok {
+			continue
+		}
+
+		revision, err := strconv.Atoi(revisionStr)
+		if err != nil {
+			continue
+		}
+
+		revisions[revision] = artifact.Name
+
+		if revision > latestRevision {
+			latestRevision = revision
+			latest = &artifacts[i]
+		}
+	}
+
+	changedResources := strings.Join(plan.ChangedResources, ",")
+	if latest != nil && latest.Properties["changed_resources"] == changedResources {
+		return nil
+	}
+
+	planDir, err := os.MkdirTemp("", "operations-center-drift-plan-*")
+	if err != nil {
+		return fmt.Errorf("Failed to create temporary directory: %w", err)
+	}
+
+	defer os.RemoveAll(planDir)
+
+	err = os.WriteFile(filepath.Join(planDir, "plan.txt"), []byte(plan.Output), 0o600)
+	if err != nil {
+		return fmt.Errorf("Failed to write %s plan: %w", provisioningArtifact.title, err)
+	}
+
+	revision := latestRevision + 1
+
+	_, err = s.localartifact.CreateClusterArtifactFromPath(ctx, provisioning.ClusterArtifact{
+		Cluster:     clusterName,
+		Name:        fmt.Sprintf("%s%d", planArtifactPrefix, revision),
+		Description: fmt.Sprintf("%s plan of the configuration drift detection.", provisioningArtifact.title),
+		Properties: api.ConfigMap{
+			"revision":          strconv.Itoa(revision),
+			"detected_at":       s.now().UTC().Format(time.RFC3339),
+			"changed_resources": changedResources,
+		},
+	}, planDir, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to store %s plan: %w", provisioningArtifact.title, err)
+	}
+
+	for previousRevision, artifactName := range revisions {
+		if previousRevision > revision-configurationDriftPlanRetention {
+			continue
+		}
+
+		err = s.localartifact.DeleteClusterArtifactByName(ctx, clusterName, artifactName)
+		if err != nil {
+			return fmt.Errorf("Failed to remove outdated %s plan %q: %w", provisioningArtifact.title, artifactName, err)
+		}
+	}
+
+	return nil
+}
+
+func copyClusterArtifactFile(artifactFile provisioning.ClusterArtifactFile, target string) (err error) {
+	source, err := artifactFile.Open()
+	if err != nil {
+		return fmt.Errorf("Failed to open artifact file %q: %w", artifactFile.Name, err)
+	}
+
+	defer source.Close()
+
+	destination, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("Failed to create %q: %w", target, err)
+	}
+
+	defer func() {
+		err = errors.Join(err, destination.Close())
+	}()
+
+	_, err = file.SafeCopy(destination, source)
+	if err != nil {
+		return fmt.Errorf("Failed to copy artifact file %q: %w", artifactFile.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,299 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus-os/incus-osd/api/images"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/internal/warning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// GetClusterConsistency compares the settings of the ready members of the
+// cluster with the settings of the first member (ordered by name).
+//
+// The compared settings are the OS and application versions, the VLAN tags of
+// the network interfaces and bonds, the kernel and logging configuration and
+// the configuration of the OS services. The LVM system_id as well as the
+// linstor listen address and the ovn tunnel address are member dependent and
+// therefore compared with the equivalent address of the reference server.
+func (s *clusterService) GetClusterConsistency(ctx context.Context, name string) (api.ClusterConsistency, error) {
+	if name == "" {
+		return api.ClusterConsistency{}, fmt.Errorf("Cluster name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	_, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return api.ClusterConsistency{}, fmt.Errorf("Failed to get cluster %q: %w", name, err)
+	}
+
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Cluster: ptr.To(name),
+		Status:  ptr.To(api.ServerStatusReady),
+	})
+	if err != nil {
+		return api.ClusterConsistency{}, fmt.Errorf("Failed to get servers of cluster %q: %w", name, err)
+	}
+
+	consistency := api.ClusterConsistency{
+		Cluster:         name,
+		Consistent:      true,
+		Inconsistencies: []api.ClusterInconsistency{},
+	}
+
+	if len(servers) == 0 {
+		return consistency, nil
+	}
+
+	slices.SortFunc(servers, func(a, b provisioning.Server) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	referenceServer := servers[0]
+	consistency.ReferenceServer = referenceServer.Name
+
+	referenceSettings, err := s.memberSettings(ctx, referenceServer)
+	if err != nil {
+		return api.ClusterConsistency{}, err
+	}
+
+	for _, server := range servers[1:] {
+		settings, err := s.memberSettings(ctx, server)
+		if err != nil {
+			return api.ClusterConsistency{}, err
+		}
+
+		expectedSettings, err := memberDependentSettings(referenceServer, referenceSettings, server)
+		if err != nil {
+			return api.ClusterConsistency{}, err
+		}
+
+		for _, inconsistency := range settingsInconsistencies("", expectedSettings, settings) {
+			inconsistency.Server = server.Name
+			consistency.Inconsistencies = append(consistency.Inconsistencies, inconsistency)
+		}
+	}
+
+	consistency.Consistent = len(consistency.Inconsistencies) == 0
+
+	return consistency, nil
+}
+
+// CheckConsistency checks the consistency of the settings of the members of
+// all ready clusters. For each diverging setting, a warning naming the member
+// and the setting is raised. Warnings of previous checks, which no longer
+// apply, are removed.
+func (s *clusterService) CheckConsistency(ctx context.Context) error {
+	clusters, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get clusters for consistency check: %w", err)
+	}
+
+	var errs []error
+	for _, cluster := range clusters {
+		if cluster.Status != api.ClusterStatusReady {
+			continue
+		}
+
+		consistency, err := s.GetClusterConsistency(ctx, cluster.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to check consistency of cluster %q: %w", cluster.Name, err))
+			continue
+		}
+
+		scope := api.WarningScope{
+			Scope:      "consistency",
+			EntityType: "cluster",
+			Entity:     cluster.Name,
+		}
+
+		warnings := make(warning.Warnings, 0, len(consistency.Inconsistencies))
+		for _, inconsistency := range consistency.Inconsistencies {
+			w := warning.NewWarning(
+				api.WarningTypeClusterMembersInconsistent,
+				scope,
+				fmt.Sprintf("Setting %q of cluster member %q diverges from %q, found %s, expected %s", inconsistency.Setting, inconsistency.Server, consistency.ReferenceServer, inconsistency.Actual, inconsistency.Reference),
+			)
+
+			s.warning.Emit(ctx, w)
+			warnings = append(warnings, w)
+		}
+
+		s.warning.RemoveStale(ctx, scope, warnings)
+	}
+
+	return errors.Join(errs...)
+}
+
+// memberSettings collects the settings of server, which are required to be
+// consistent across the members of a cluster, in their generic JSON
+// representation.
+func (s *clusterService) memberSettings(ctx context.Context, server provisioning.Server) (map[string]any, error) {
+	applications := make(map[string]string, len(server.VersionData.Applications))
+	for _, app := range server.VersionData.Applications {
+		if app.Name == string(images.UpdateFileComponentGPUSupport) || app.Name == string(images.UpdateFileComponentDebug) {
+			continue
+		}
+
+		applications[app.Name] = app.Version
+	}
+
+	networkConfig, err := s.client.GetNetworkConfig(ctx, server)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get network configuration from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+	}
+
+	// Interfaces and bonds share a single flat name space.
+	vlanTags := map[string][]int{}
+	if networkConfig.Config != nil {
+		for _, iface := range networkConfig.Config.Interfaces {
+			vlanTags[iface.Name] = iface.VLANTags
+		}
+
+		for _, bond := range networkConfig.Config.Bonds {
+			vlanTags[bond.Name] = bond.VLANTags
+		}
+	}
+
+	kernel, err := s.serverSvc.GetSystemKernel(ctx, server.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get kernel configuration from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+	}
+
+	logging, err := s.serverSvc.GetSystemLogging(ctx, server.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get logging configuration from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+	}
+
+	liveServiceConfigGetters := s.liveServiceConfigGetters()
+
+	services := map[string]any{}
+	for _, service := range slices.Sorted(maps.Keys(liveServiceConfigGetters)) {
+		config, err := liveServiceConfigGetters[service](ctx, server)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get %s service config from server %q (%s): %w", service, server.Name, server.GetConnectionURL(), err)
+		}
+
+		services[service] = config
+	}
+
+	settings, err := normalizeServiceConfig(map[string]any{
+		"os_version":   server.VersionData.OS.Version,
+		"applications": applications,
+		"vlan_tags":    vlanTags,
+		"kernel":       kernel,
+		"logging":      logging,
+		"services":     services,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to normalize settings of server %q: %w", server.Name, err)
+	}
+
+	// Only the configuration of kernel and logging is compared, the state is
+	// specific to each member.
+	for _, system := range []string{"kernel", "logging"} {
+		systemSettings, ok := settings[system].(map[string]any)
+		if ok {
+			settings[system] = systemSettings["config"]
+		}
+	}
+
+	return settings, nil
+}
+
+// memberDependentSettings returns the settings of the reference server
+// adjusted to the member dependent values expected for server.
+func memberDependentSettings(referenceServer provisioning.Server, referenceSettings map[string]any, server provisioning.Server) (map[string]any, error) {
+	// Work on a copy to keep the settings of the reference server untouched.
+	expectedSettings, err := normalizeServiceConfig(referenceSettings)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to copy settings of server %q: %w", referenceServer.Name, err)
+	}
+
+	services, _ := expectedSettings["services"].(map[string]any)
+
+	// If the equivalent address can not be derived, the address of the
+	// reference server is kept, which is then reported as inconsistency.
+	linstor, ok := services["linstor"].(map[string]any)
+	if ok {
+		listenAddress, _ := linstor["listen_address"].(string)
+		expectedListenAddress, err := memberDependentListenAddress(referenceServer, listenAddress, server)
+		if err == nil {
+			linstor["listen_address"] = expectedListenAddress
+		}
+	}
+
+	ovn, ok := services["ovn"].(map[string]any)
+	if ok {
+		tunnelAddress, _ := ovn["tunnel_address"].(string)
+		expectedTunnelAddress, err := memberDependentAddress(referenceServer, tunnelAddress, server)
+		if err == nil {
+			ovn["tunnel_address"] = expectedTunnelAddress
+		}
+	}
+
+	return expectedSettings, nil
+}
+
+// settingsInconsistencies returns the inconsistencies for all the keys of the
+// reference and the actual settings, recursing into nested objects.
+func settingsInconsistencies(prefix string, reference map[string]any, actual map[string]any) []api.ClusterInconsistency {
+	var inconsistencies []api.ClusterInconsistency
+
+	keys := slices.Collect(maps.Keys(reference))
+	for key := range actual {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		// The LVM system_id is controlled by Operations Center and unique per
+		// member.
+		if path == "services.lvm.system_id" {
+			continue
+		}
+
+		referenceObject, referenceIsObject := reference[key].(map[string]any)
+		actualObject, actualIsObject := actual[key].(map[string]any)
+		if referenceIsObject && actualIsObject {
+			inconsistencies = append(inconsistencies, settingsInconsistencies(path, referenceObject, actualObject)...)
+			continue
+		}
+
+		referenceValue := clusterSpecValue(reference[key])
+		actualValue := clusterSpecValue(actual[key])
+		if referenceValue == actualValue {
+			continue
+		}
+
+		// Never disclose keys and keyrings.
+		if key == "key" || strings.HasSuffix(key, "_key") || strings.Contains(path, "keyrings") {
+			referenceValue = provisioning.ClusterTemplateSecretMask
+			actualValue = provisioning.ClusterTemplateSecretMask
+		}
+
+		inconsistencies = append(inconsistencies, api.ClusterInconsistency{
+			Setting:   path,
+			Reference: referenceValue,
+			Actual:    actualValue,
+		})
+	}
+
+	return inconsistencies
+}
@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// clusterOperationRetention is the number of the most recent cluster wide
+// operations, which are kept in the history of each cluster.
+const clusterOperationRetention = 50
+
+// GetOperationAll returns the history of the cluster wide operations of the
+// cluster, most recent first, including the steps performed on each server.
+func (s *clusterService) GetOperationAll(ctx context.Context, name string) (provisioning.ClusterOperations, error) {
+	_, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get cluster %q: %w", name, err)
+	}
+
+	if s.operations == nil {
+		return provisioning.ClusterOperations{}, nil
+	}
+
+	operations, err := s.operations.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get operations of cluster %q: %w", name, err)
+	}
+
+	for i := range operations {
+		events, err := s.operations.GetEventAll(ctx, operations[i].UUID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get events of operation %q of cluster %q: %w", operations[i].UUID, name, err)
+		}
+
+		slices.SortStableFunc(events, func(a, b provisioning.ClusterOperationEvent) int {
+			return a.StartedAt.Compare(b.StartedAt)
+		})
+
+		operations[i].Events = events.WithFinishTimes(operations[i].FinishedAt)
+	}
+
+	slices.SortStableFunc(operations, func(a, b provisioning.ClusterOperation) int {
+		return cmp.Or(
+			b.StartedAt.Compare(a.StartedAt),
+			cmp.Compare(b.ID, a.ID),
+		)
+	})
+
+	return operations, nil
+}
+
+// startOperation records the launch of a cluster wide operation. Operations of
+// the cluster, which are still recorded as running, have been left behind and
+// are marked as canceled. Only the clusterOperationRetention most recent
+// operations of the cluster are kept.
+//
+// Recording the history is best effort, errors are logged, but never prevent
+// the operation itself.
+func (s *clusterService) startOperation(ctx context.Context, clusterName string, operationType api.ClusterUpdateInProgress) {
+	if s.operations == nil {
+		return
+	}
+
+	s.finishOperation(ctx, clusterName, api.ClusterOperationStatusCanceled, "")
+
+	_, err := s.operations.Create(ctx, provisioning.ClusterOperation{
+		UUID:      uuid.New(),
+		Cluster:   clusterName,
+		Type:      operationType,
+		Status:    api.ClusterOperationStatusRunning,
+		StartedAt: s.now(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record start of cluster operation", logger.Err(err), slog.String("cluster", clusterName), slog.String("type", string(operationType)))
+		return
+	}
+
+	err = s.operations.DeleteExceptLatestByCluster(ctx, clusterName, clusterOperationRetention)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to remove outdated cluster operations", logger.Err(err), slog.String("cluster", clusterName))
+	}
+}
+
+// recordOperationEvent records a step of the running cluster wide operation,
+// which has been triggered on the given server.
+func (s *clusterService) recordOperationEvent(ctx context.Context, clusterName string, serverName string, action api.ClusterOperationEventAction) {
+	if s.operations == nil {
+		return
+	}
+
+	operations, err := s.runningOperations(ctx, clusterName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record cluster operation event", logger.Err(err), slog.String("cluster", clusterName), slog.String("server", serverName))
+		return
+	}
+
+	if len(operations) == 0 {
+		// The operation has been launched before the history has been recorded.
+		return
+	}
+
+	_, err = s.operations.CreateEvent(ctx, provisioning.ClusterOperationEvent{
+		Operation: operations[len(operations)-1].UUID,
+		Server:    serverName,
+		Action:    action,
+		StartedAt: s.now(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record cluster operation event", logger.Err(err), slog.String("cluster", clusterName), slog.String("server", serverName))
+	}
+}
+
+// finishOperation records the end of the running cluster wide operations of
+// the cluster with the given status.
+func (s *clusterService) finishOperation(ctx context.Context, clusterName string, status api.ClusterOperationStatus, operationErr string) {
+	if s.operations == nil {
+		return
+	}
+
+	operations, err := s.runningOperations(ctx, clusterName)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record end of cluster operation", logger.Err(err), slog.String("cluster", clusterName))
+		return
+	}
+
+	for _, operation := range operations {
+		operation.Status = status
+		operation.Error = operationErr
+		operation.FinishedAt = s.now()
+
+		err = s.operations.Update(ctx, operation)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to record end of cluster operation", logger.Err(err), slog.String("cluster", clusterName), slog.String("operation", operation.UUID.String()))
+		}
+	}
+}
+
+// runningOperations returns the operations of the cluster, which are recorded
+// as running, the most recently started one last.
+func (s *clusterService) runningOperations(ctx context.Context, clusterName string) (provisioning.ClusterOperations, error) {
+	operations, err := s.operations.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To(clusterName),
+		Status:  ptr.To(api.ClusterOperationStatusRunning),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(operations, func(a, b provisioning.ClusterOperation) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	return operations, nil
+}
@@ -0,0 +1,390 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// replaceServerSteps is the number of steps reported as progress, while a
+// failed server is replaced.
+const replaceServerSteps = 3
+
+// ReplaceServer replaces a failed member of the cluster with a new server. The
+// process has the following steps:
+//
+//   - Forcefully remove the failed server from the Incus cluster and unlink it
+//     from the cluster in Operations Center.
+//   - Join the replacement server to the cluster, after the services config has
+//     been copied from one of the remaining cluster members (see AddServers).
+//   - Apply the member specific server configuration from the Terraform
+//     configuration artifact of the cluster on the replacement server.
+//
+// The progress is reported in the in progress status of the cluster and the
+// operation is recorded in the history of the cluster wide operations, the
+// same way as for a rolling update.
+func (s *clusterService) ReplaceServer(ctx context.Context, name string, serverName string, replacementServerName string) (err error) {
+	if serverName == "" || replacementServerName == "" {
+		return domain.NewValidationErrf("Invalid server replacement, server name and replacement server name can not be empty")
+	}
+
+	if serverName == replacementServerName {
+		return domain.NewValidationErrf("Invalid server replacement, server %q can not replace itself", serverName)
+	}
+
+	cluster, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster %q: %w", name, err)
+	}
+
+	if cluster.IsUpdateInProgress() {
+		return fmt.Errorf("Cluster %q already has an operation in progress: %w", name, domain.ErrOperationNotPermitted)
+	}
+
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Cluster: ptr.To(name),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to get servers of cluster %q: %w", name, err)
+	}
+
+	var failedServer provisioning.Server
+	var found bool
+	remainingServers := make(provisioning.Servers, 0, len(servers))
+	for _, server := range servers {
+		if server.Name == serverName {
+			failedServer = server
+			found = true
+			continue
+		}
+
+		remainingServers = append(remainingServers, server)
+	}
+
+	if !found {
+		return fmt.Errorf("Server %q is not part of the cluster %q: %w", serverName, name, domain.ErrNotFound)
+	}
+
+	if failedServer.Status != api.ServerStatusOffline {
+		return fmt.Errorf("Server %q is not offline, servers, which are still reachable, need to be removed from the cluster instead: %w", serverName, domain.ErrOperationNotPermitted)
+	}
+
+	if len(remainingServers) == 0 {
+		return fmt.Errorf("Cluster %q does not have any remaining servers, which could be used as source for the join: %w", name, domain.ErrOperationNotPermitted)
+	}
+
+	replacementServer, err := s.serverSvc.GetByName(ctx, replacementServerName)
+	if err != nil {
+		return fmt.Errorf("Failed to get server %q: %w", replacementServerName, err)
+	}
+
+	err = serverReadyForJoin(*cluster, *replacementServer)
+	if err != nil {
+		return err
+	}
+
+	err = transaction.Do(ctx, func(ctx context.Context) error {
+		cluster, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster %q: %w", name, err)
+		}
+
+		if cluster.IsUpdateInProgress() {
+			return fmt.Errorf("Cluster %q already has an operation in progress: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		cluster.UpdateStatus.InProgressStatus = api.ClusterUpdateInProgressStatus{
+			InProgress:        api.ClusterUpdateInProgressReplaceServer,
+			StatusDescription: ptr.To(replaceServerStepDescription(1, "removing", serverName)),
+			LastUpdated:       s.now(),
+		}
+
+		err = s.repo.Update(ctx, *cluster)
+		if err != nil {
+			return fmt.Errorf("Failed to update cluster %q: %w", name, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.startOperation(ctx, name, api.ClusterUpdateInProgressReplaceServer)
+
+	defer func() {
+		err = errors.Join(err, s.finishReplaceServer(ctx, name, err))
+	}()
+
+	// Step 1: remove the failed server.
+	s.recordOperationEvent(ctx, name, serverName, api.ClusterOperationEventActionRemove)
+
+	incusClient, err := s.client.IncusClient(ctx, remainingServers[0])
+	if err != nil {
+		return fmt.Errorf("Failed to get incus client for server %q: %w", remainingServers[0].Name, err)
+	}
+
+	err = s.deleteClusterMemberWithRetry(ctx, serverName, 1*time.Minute, incusClient)
+	if err != nil {
+		return fmt.Errorf("Failed to remove server %q from cluster %q: %w", serverName, name, err)
+	}
+
+	// The failed server is not reachable, so the system configuration is not
+	// updated.
+	failedServer.Cluster = nil
+	failedServer.ClusterCertificate = nil
+	failedServer.ClusterConnectionURL = nil
+
+	err = s.serverSvc.Update(ctx, failedServer, true, false, false)
+	if err != nil {
+		return fmt.Errorf("Failed to update server record for %q: %w", serverName, err)
+	}
+
+	s.recordOperationEvent(ctx, name, serverName, api.ClusterOperationEventActionDone)
+
+	// Step 2: join the replacement server.
+	err = s.replaceServerProgress(ctx, name, 2, "joining", replacementServerName)
+	if err != nil {
+		return err
+	}
+
+	s.recordOperationEvent(ctx, name, replacementServerName, api.ClusterOperationEventActionJoin)
+
+	err = s.AddServers(ctx, name, []string{replacementServerName}, false, true)
+	if err != nil {
+		return err
+	}
+
+	// Step 3: apply the member specific configuration.
+	err = s.replaceServerProgress(ctx, name, 3, "configuring", replacementServerName)
+	if err != nil {
+		return err
+	}
+
+	s.recordOperationEvent(ctx, name, replacementServerName, api.ClusterOperationEventActionConfigure)
+
+	memberConfig, err := s.memberSpecificServerConfig(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if len(memberConfig) > 0 {
+		replacementServer, err = s.serverSvc.GetByName(ctx, replacementServerName)
+		if err != nil {
+			return fmt.Errorf("Failed to get server %q: %w", replacementServerName, err)
+		}
+
+		err = s.client.SetServerConfig(ctx, replacementServer, memberConfig)
+		if err != nil {
+			return fmt.Errorf("Failed to set member specific config on server %q: %w", replacementServerName, err)
+		}
+	}
+
+	s.recordOperationEvent(ctx, name, replacementServerName, api.ClusterOperationEventActionDone)
+
+	return nil
+}
+
+func replaceServerStepDescription(step int, action string, serverName string) string {
+	return fmt.Sprintf("[%d/%d] %s server %q", step, replaceServerSteps, action, serverName)
+}
+
+// replaceServerProgress updates the progress of the server replacement. If the
+// replacement has been canceled in the meantime, an error is returned.
+func (s *clusterService) replaceServerProgress(ctx context.Context, name string, step int, action string, serverName string) error {
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		cluster, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster %q: %w", name, err)
+		}
+
+		if cluster.UpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressReplaceServer {
+			return fmt.Errorf("Server replacement for cluster %q has been canceled: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		cluster.UpdateStatus.InProgressStatus.StatusDescription = ptr.To(replaceServerStepDescription(step, action, serverName))
+		cluster.UpdateStatus.InProgressStatus.LastUpdated = s.now()
+
+		err = s.repo.Update(ctx, *cluster)
+		if err != nil {
+			return fmt.Errorf("Failed to update cluster %q: %w", name, err)
+		}
+
+		return nil
+	})
+}
+
+// finishReplaceServer resets the in progress status of the cluster after the
+// server replacement. If the replacement failed, the error is kept in the in
+// progress status, the same way as for a failed rolling update, until it is
+// cleared by canceling the operation.
+func (s *clusterService) finishReplaceServer(ctx context.Context, name string, replaceErr error) error {
+	var canceled bool
+	err := transaction.Do(ctx, func(ctx context.Context) error {
+		cluster, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster %q: %w", name, err)
+		}
+
+		// The cancellation has already reset the in progress status and recorded
+		// the end of the operation.
+		if cluster.UpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressReplaceServer {
+			canceled = true
+			return nil
+		}
+
+		inProgressStatus := api.ClusterUpdateInProgressStatus{
+			LastUpdated: s.now(),
+		}
+
+		if replaceErr != nil {
+			inProgressStatus.InProgress = api.ClusterUpdateInProgressError
+			inProgressStatus.Error = replaceErr.Error()
+		}
+
+		cluster.UpdateStatus.InProgressStatus = inProgressStatus
+
+		err = s.repo.Update(ctx, *cluster)
+		if err != nil {
+			return fmt.Errorf("Failed to update cluster %q: %w", name, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if canceled {
+		return nil
+	}
+
+	if replaceErr != nil {
+		s.finishOperation(ctx, name, api.ClusterOperationStatusFailed, replaceErr.Error())
+		return nil
+	}
+
+	s.finishOperation(ctx, name, api.ClusterOperationStatusSucceeded, "")
+
+	return nil
+}
+
+// memberSpecificServerConfig returns the member specific server configuration
+// of the cluster from the Terraform or Incus configuration, which has been
+// used for the post-clustering initialization.
+func (s *clusterService) memberSpecificServerConfig(ctx context.Context, name string) (map[string]string, error) {
+	file, err := s.GetClusterArtifactFileByName(ctx, name, "terraform-configuration", "resources_server.tf")
+	if errors.Is(err, domain.ErrNotFound) {
+		return s.memberSpecificServerConfigFromIncusConfiguration(ctx, name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open Terraform configuration of cluster %q: %w", name, err)
+	}
+
+	defer rc.Close()
+
+	src, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read Terraform configuration of cluster %q: %w", name, err)
+	}
+
+	return parseMemberSpecificServerConfig(src)
+}
+
+// memberSpecificServerConfigFromIncusConfiguration returns the member
+// specific server configuration of clusters, which have been initialized
+// using the native Incus provisioner.
+func (s *clusterService) memberSpecificServerConfigFromIncusConfiguration(ctx context.Context, name string) (map[string]string, error) {
+	file, err := s.GetClusterArtifactFileByName(ctx, name, "incus-configuration", "server_member_config.yaml")
+	if errors.Is(err, domain.ErrNotFound) {
+		// Clusters, which have not been created by Operations Center, do not have
+		// a post-clustering configuration.
+		return map[string]string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open Incus configuration of cluster %q: %w", name, err)
+	}
+
+	defer rc.Close()
+
+	config := map[string]string{}
+	err = yaml.NewDecoder(rc).Decode(&config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("Failed to read Incus configuration of cluster %q: %w", name, err)
+	}
+
+	return config, nil
+}
+
+// parseMemberSpecificServerConfig extracts the config of the per node
+// "incus_server" resource from the Terraform configuration.
+func parseMemberSpecificServerConfig(src []byte) (map[string]string, error) {
+	f, diags := hclsyntax.ParseConfig(src, "resources_server.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, errors.Join(diags.Errs()...)
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected body type %T in Terraform configuration", f.Body)
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || !slices.Equal(block.Labels, []string{"incus_server", "this_per_node"}) {
+			continue
+		}
+
+		attr, ok := block.Body.Attributes["config"]
+		if !ok {
+			return map[string]string{}, nil
+		}
+
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, errors.Join(diags.Errs()...)
+		}
+
+		if value.IsNull() || !value.CanIterateElements() {
+			return nil, fmt.Errorf("Config of the per node server resource is not a map")
+		}
+
+		config := make(map[string]string, value.LengthInt())
+		for key, v := range value.AsValueMap() {
+			if v.IsNull() || !v.Type().Equals(cty.String) {
+				return nil, fmt.Errorf("Value of config key %q of the per node server resource is not a string", key)
+			}
+
+			config[key] = v.AsString()
+		}
+
+		return config, nil
+	}
+
+	return map[string]string{}, nil
+}
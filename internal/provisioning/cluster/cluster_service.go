@@ -38,6 +38,7 @@ import (
 	"github.com/FuturFusion/operations-center/internal/util/structs"
 	"github.com/FuturFusion/operations-center/internal/warning"
 	"github.com/FuturFusion/operations-center/shared/api"
+	"github.com/FuturFusion/operations-center/shared/api/system"
 )
 
 type clusterService struct {
@@ -48,7 +49,14 @@ type clusterService struct {
 	tokenSvc         provisioning.TokenService
 	inventorySyncers map[domain.ResourceType]provisioning.InventorySyncer
 	provisioner      provisioning.ClusterProvisioningPort
+	provisioners     map[string]provisioning.ClusterProvisioningPort
+	driftDetectors   map[string]provisioning.ClusterProvisioningDriftPort
 	warning          provisioning.WarningServicePort
+	updateSvc        provisioning.UpdateService
+	templateSvc      provisioning.ClusterTemplateService
+	siteSvc          provisioning.SiteService
+	scriptlet        provisioning.ClusterScriptletPort
+	operations       provisioning.ClusterOperationRepo
 	inventorySvc     interface {
 		GetAllWithFilter(ctx context.Context, filter inventory.InventoryAggregateFilter) (inventory.InventoryAggregates, error)
 	}
@@ -119,6 +127,74 @@ func WithWarningEmitter(warn provisioning.WarningServicePort) Option {
 	}
 }
 
+func WithUpdateService(updateSvc provisioning.UpdateService) Option {
+	return func(s *clusterService) {
+		s.updateSvc = updateSvc
+	}
+}
+
+// WithClusterTemplateService sets the cluster template service used to
+// resolve the revision of the cluster template a cluster is created from and
+// to report the drift of a cluster from its cluster template.
+func WithClusterTemplateService(templateSvc provisioning.ClusterTemplateService) Option {
+	return func(s *clusterService) {
+		s.templateSvc = templateSvc
+	}
+}
+
+// WithSiteService sets the site service used to resolve the defaults of the
+// site a cluster belongs to, like the channel and the maintenance windows.
+// Without it, sites are not taken into account.
+func WithSiteService(siteSvc provisioning.SiteService) Option {
+	return func(s *clusterService) {
+		s.siteSvc = siteSvc
+	}
+}
+
+// WithScriptlet sets the scriptlet port used to run the cluster update health
+// gates. Without it, the health gates are skipped.
+func WithScriptlet(scriptlet provisioning.ClusterScriptletPort) Option {
+	return func(s *clusterService) {
+		s.scriptlet = scriptlet
+	}
+}
+
+// WithOperationRepo sets the repository used to record the history of the
+// cluster wide operations. Without it, no history is recorded.
+func WithOperationRepo(operations provisioning.ClusterOperationRepo) Option {
+	return func(s *clusterService) {
+		s.operations = operations
+	}
+}
+
+// WithClusterProvisioner registers an additional provisioner for the
+// post-clustering initialization, which is used, if the given backend is
+// selected in the system settings. Without it, the provisioner passed to New
+// is used for all the clusters.
+func WithClusterProvisioner(backend string, provisioner provisioning.ClusterProvisioningPort) Option {
+	return func(s *clusterService) {
+		if s.provisioners == nil {
+			s.provisioners = map[string]provisioning.ClusterProvisioningPort{}
+		}
+
+		s.provisioners[backend] = provisioner
+	}
+}
+
+// WithConfigurationDriftDetector registers the provisioner used to detect the
+// drift of clusters, which have been initialized with the given backend, from
+// their configuration. Without it, no configuration drift is detected for
+// these clusters.
+func WithConfigurationDriftDetector(backend string, driftDetector provisioning.ClusterProvisioningDriftPort) Option {
+	return func(s *clusterService) {
+		if s.driftDetectors == nil {
+			s.driftDetectors = map[string]provisioning.ClusterProvisioningDriftPort{}
+		}
+
+		s.driftDetectors[backend] = driftDetector
+	}
+}
+
 func New(
 	repo provisioning.ClusterRepo,
 	localartifact provisioning.ClusterArtifactRepo,
@@ -186,7 +262,8 @@ func (s *clusterService) SetInventorySyncers(inventorySyncers map[domain.Resourc
 //   - Update cluster entry with certificate and mark the cluster as ready.
 //   - Update server entries by linking them with the cluster.
 //
-// Perform post-clustering initialization using provisioner (Terraform):
+// Perform post-clustering initialization using the provisioner selected in
+// the system settings (Terraform or the native Incus API):
 //   - Create internal project
 //   - Initialize default storage:
 //     Create local storage pool on each server and finalize it for the cluster.
@@ -200,6 +277,11 @@ func (s *clusterService) SetInventorySyncers(inventorySyncers map[domain.Resourc
 //     Update the default profile in the default project to use incusbr0 for networking.
 //     Update the default profile in the internal project to use internal-mesh for networking.
 func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Cluster) (_ provisioning.Cluster, err error) {
+	err = s.applySiteDefaults(ctx, &newCluster)
+	if err != nil {
+		return provisioning.Cluster{}, err
+	}
+
 	if newCluster.Channel == "" {
 		newCluster.Channel = config.GetUpdates().ServerDefaultChannel
 	}
@@ -209,6 +291,21 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 		return provisioning.Cluster{}, err
 	}
 
+	if newCluster.ClusterTemplate != "" && s.templateSvc != nil {
+		clusterTemplate, err := s.templateSvc.GetByName(ctx, newCluster.ClusterTemplate)
+		if err != nil {
+			return provisioning.Cluster{}, fmt.Errorf("Failed to get cluster template %q: %w", newCluster.ClusterTemplate, err)
+		}
+
+		newCluster.ClusterTemplateRevision = clusterTemplate.Revision
+	}
+
+	// The services config is recorded even if it is empty, such that later
+	// changes of the services config can be detected.
+	if newCluster.ServicesConfig == nil {
+		newCluster.ServicesConfig = provisioning.ClusterServicesConfig{}
+	}
+
 	var bootstrapServer provisioning.Server
 	var servers []provisioning.Server
 
@@ -326,6 +423,10 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 			// system_id is required to be between 1 and 2000. Just using the server.ID
 			// will fail, when we hit values > 2000.
 			if service == "lvm" {
+				// The services config is recorded for the cluster, so the
+				// server specific system_id is only set on a copy.
+				cfg = maps.Clone(cfg)
+
 				enabledAny := cfg["enabled"]
 				enabled, ok := enabledAny.(bool)
 				if !ok {
@@ -434,6 +535,7 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 		servers[i].ClusterCertificate = &clusterCertificate
 		servers[i].ClusterConnectionURL = &newCluster.ConnectionURL
 		servers[i].Channel = newCluster.Channel
+		servers[i].Site = newCluster.Site
 	}
 
 	// 2nd DB transaction.
@@ -488,8 +590,13 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 		return newCluster, err
 	}
 
-	// Perform post-clustering initialization using provisioner (Terraform).
-	temporaryPath, cleanup, err := s.provisioner.Init(ctx, newCluster.Name, provisioning.ClusterProvisioningConfig{
+	provisioner, artifact, err := s.clusterProvisioner()
+	if err != nil {
+		return newCluster, err
+	}
+
+	// Perform post-clustering initialization using provisioner.
+	temporaryPath, cleanup, err := provisioner.Init(ctx, newCluster.Name, provisioning.ClusterProvisioningConfig{
 		ClusterEndpoint: clusterEndpoint,
 		Servers:         servers,
 		Cluster:         newCluster,
@@ -506,25 +613,25 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 
 	var retryCount int
 	for {
-		err = s.provisioner.Apply(ctx, newCluster)
+		err = provisioner.Apply(ctx, newCluster)
 		if err != nil {
 			var retryableErr domain.ErrRetryable
 			if errors.As(err, &retryableErr) {
 				retryCount++
 				if retryCount > 2 {
-					return newCluster, fmt.Errorf("Failed to apply Terraform configuration, retried for %d times: %w", retryCount, err)
+					return newCluster, fmt.Errorf("Failed to apply post-clustering configuration, retried for %d times: %w", retryCount, err)
 				}
 
-				slog.WarnContext(ctx, "Terraform apply failed with a retryable error, will retry", logger.Err(err))
+				slog.WarnContext(ctx, "Post-clustering apply failed with a retryable error, will retry", logger.Err(err))
 
-				// Terraform apply fails, when terraform configuration does update the certificate
+				// Apply fails, when the configuration does update the certificate
 				// e.g. due to ACME configuration. In this case, the cluster certificate is updated
-				// half way through the terraform apply, which causes the client connection in the
-				// provider to fail.
+				// half way through the apply, which causes the client connection in the
+				// provisioner to fail.
 				// Therefore we poll the first server, which will cause the cluster certificate to get
 				// updated in DB in the case it is now a publicly valid certificate (e.g. ACME).
 				// The updated cluster certificate is then fetched from the DB and passed to the
-				// terraform provider and terraform apply is retried.
+				// provisioner and apply is retried.
 				err := s.serverSvc.PollServer(ctx, servers[0], false)
 				if err != nil {
 					return newCluster, fmt.Errorf("Failed to poll server %q: %w", servers[0].Name, err)
@@ -565,7 +672,7 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 					Bytes: cert.Raw,
 				}))
 
-				err = s.provisioner.SeedCertificate(ctx, newCluster.Name, certificate)
+				err = provisioner.SeedCertificate(ctx, newCluster.Name, certificate)
 				if err != nil {
 					return newCluster, fmt.Errorf("Failed to update cluster certificate: %w", err)
 				}
@@ -573,17 +680,14 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 				continue
 			}
 
-			return newCluster, fmt.Errorf("Failed to apply Terraform configuration: %w", err)
+			return newCluster, fmt.Errorf("Failed to apply post-clustering configuration: %w", err)
 		}
 
 		break
 	}
 
-	_, err = s.localartifact.CreateClusterArtifactFromPath(ctx, provisioning.ClusterArtifact{
-		Cluster:     newCluster.Name,
-		Name:        "terraform-configuration",
-		Description: "Initial terraform configuration used for post-clustering.",
-	}, temporaryPath, []string{".terraform.lock.hcl"})
+	artifact.Cluster = newCluster.Name
+	_, err = s.localartifact.CreateClusterArtifactFromPath(ctx, artifact, temporaryPath, []string{".terraform.lock.hcl"})
 	if err != nil {
 		return newCluster, err
 	}
@@ -601,6 +705,211 @@ func (s *clusterService) Create(ctx context.Context, newCluster provisioning.Clu
 	return newCluster, nil
 }
 
+// clusterProvisioner returns the provisioner for the post-clustering
+// initialization according to the system settings together with the artifact,
+// the resulting configuration is stored in.
+func (s *clusterService) clusterProvisioner() (provisioning.ClusterProvisioningPort, provisioning.ClusterArtifact, error) {
+	switch config.GetSettings().ClusterProvisioningBackend {
+	case system.ClusterProvisioningBackendIncus:
+		provisioner, ok := s.provisioners[system.ClusterProvisioningBackendIncus]
+		if !ok {
+			return nil, provisioning.ClusterArtifact{}, fmt.Errorf("Cluster provisioning backend %q is not available", system.ClusterProvisioningBackendIncus)
+		}
+
+		return provisioner, provisioning.ClusterArtifact{
+			Name:        "incus-configuration",
+			Description: "Initial Incus configuration used for post-clustering.",
+		}, nil
+
+	default:
+		return s.provisioner, provisioning.ClusterArtifact{
+			Name:        "terraform-configuration",
+			Description: "Initial terraform configuration used for post-clustering.",
+		}, nil
+	}
+}
+
+// Adopt takes over an existing Incus cluster, which has not been created by
+// Operations Center, e.g. because it has been clustered by hand. All the
+// members of the cluster are required to be registered in Operations Center
+// already. The process has the following phases:
+//
+// 1st DB transaction:
+//   - Ensure name of the cluster is not taken.
+//   - Ensure all listed servers are known, ready and not yet part of a cluster.
+//
+// Discover the cluster using API calls:
+//   - Fetch the certificate presented by each of the listed servers, which
+//     needs to be the same cluster certificate for all of them.
+//   - Fetch the cluster members using the cluster certificate and ensure, they
+//     match the listed servers.
+//
+// 2nd DB transaction:
+//   - Create the cluster entry in ready state with the cluster certificate.
+//
+// Update server entries by linking them with the cluster.
+//
+// In contrast to Create, the servers are not reconfigured and the
+// post-clustering initialization is not performed.
+func (s *clusterService) Adopt(ctx context.Context, newCluster provisioning.Cluster) (provisioning.Cluster, error) {
+	err := s.applySiteDefaults(ctx, &newCluster)
+	if err != nil {
+		return provisioning.Cluster{}, err
+	}
+
+	if newCluster.Channel == "" {
+		newCluster.Channel = config.GetUpdates().ServerDefaultChannel
+	}
+
+	err = newCluster.Validate()
+	if err != nil {
+		return provisioning.Cluster{}, err
+	}
+
+	if len(newCluster.ServerNames) == 0 {
+		return provisioning.Cluster{}, domain.NewValidationErrf("Invalid cluster, list of server names can not be empty")
+	}
+
+	var servers []provisioning.Server
+
+	// 1st DB transaction.
+	err = transaction.Do(ctx, func(ctx context.Context) error {
+		// Ensure there is no name conflict for the adopted cluster.
+		exists, err := s.repo.ExistsByName(ctx, newCluster.Name)
+		if err != nil {
+			return fmt.Errorf("Error while verifying cluster name: %w", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Cluster with name %q already exists: %w", newCluster.Name, domain.ErrOperationNotPermitted)
+		}
+
+		for _, serverName := range newCluster.ServerNames {
+			server, err := s.serverSvc.GetByName(ctx, serverName)
+			if err != nil {
+				return err
+			}
+
+			if server.Cluster != nil {
+				return fmt.Errorf("Server %q is already part of cluster %q: %w", serverName, *server.Cluster, domain.ErrOperationNotPermitted)
+			}
+
+			if server.Status != api.ServerStatusReady {
+				return fmt.Errorf("Server %q is not in ready state and can therefore not be adopted: %w", serverName, domain.ErrOperationNotPermitted)
+			}
+
+			servers = append(servers, *server)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return newCluster, err
+	}
+
+	// The members of an Incus cluster all present the cluster certificate.
+	var clusterCertificate string
+	for _, server := range servers {
+		cert, err := s.client.GetRemoteCertificate(ctx, server)
+		if err != nil {
+			return newCluster, fmt.Errorf("Failed to get remote certificate for %q: %w", server.Name, err)
+		}
+
+		certificate := string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		}))
+
+		if clusterCertificate == "" {
+			clusterCertificate = certificate
+			continue
+		}
+
+		if certificate != clusterCertificate {
+			return newCluster, fmt.Errorf("Server %q does not present the same certificate as server %q, the servers are not part of the same cluster: %w", server.Name, servers[0].Name, domain.ErrOperationNotPermitted)
+		}
+	}
+
+	clusterEndpoint := provisioning.ClusterEndpoint{
+		provisioning.Server{
+			ConnectionURL:        servers[0].ConnectionURL,
+			Cluster:              &newCluster.Name,
+			ClusterCertificate:   &clusterCertificate,
+			ClusterConnectionURL: &newCluster.ConnectionURL,
+		},
+	}
+
+	nodeNames, err := s.client.GetClusterNodeNames(ctx, clusterEndpoint)
+	if err != nil {
+		return newCluster, fmt.Errorf("Failed to get cluster members from %q: %w", servers[0].Name, err)
+	}
+
+	for _, nodeName := range nodeNames {
+		if !slices.Contains(newCluster.ServerNames, nodeName) {
+			return newCluster, fmt.Errorf("Cluster member %q is not part of the list of servers to adopt: %w", nodeName, domain.ErrOperationNotPermitted)
+		}
+	}
+
+	for _, serverName := range newCluster.ServerNames {
+		if !slices.Contains(nodeNames, serverName) {
+			return newCluster, fmt.Errorf("Server %q is not a member of the cluster: %w", serverName, domain.ErrOperationNotPermitted)
+		}
+	}
+
+	// 2nd DB transaction.
+	err = transaction.Do(ctx, func(ctx context.Context) error {
+		// Validate again all listed servers are not yet part of cluster.
+		for _, server := range servers {
+			server, err := s.serverSvc.GetByName(ctx, server.Name)
+			if err != nil {
+				return err
+			}
+
+			if server.Cluster != nil {
+				return fmt.Errorf("Server %q was not part of a cluster, but is now part of %q: %w", server.Name, *server.Cluster, domain.ErrOperationNotPermitted)
+			}
+		}
+
+		newCluster.Status = api.ClusterStatusReady
+		newCluster.Certificate = &clusterCertificate
+
+		newCluster.ID, err = s.repo.Create(ctx, newCluster)
+		if err != nil {
+			return fmt.Errorf("Failed to create cluster record in the repository: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return newCluster, err
+	}
+
+	for _, server := range servers {
+		server.Cluster = &newCluster.Name
+		server.ClusterCertificate = &clusterCertificate
+		server.ClusterConnectionURL = &newCluster.ConnectionURL
+		server.Channel = newCluster.Channel
+		server.Site = newCluster.Site
+
+		err = s.serverSvc.Update(ctx, server, true, true, false)
+		if err != nil {
+			return newCluster, err
+		}
+	}
+
+	err = s.ResyncInventoryByName(ctx, newCluster.Name)
+	if err != nil {
+		slog.WarnContext(ctx, "Post cluster adoption inventory sync failed", logger.Err(err))
+	}
+
+	lifecycle.ClusterUpdateSignal.Emit(ctx, lifecycle.ClusterUpdateMessage{
+		Operation: lifecycle.ClusterUpdateOperationCreate,
+		Name:      newCluster.Name,
+	})
+
+	return newCluster, nil
+}
+
 func determineManagementRoleAddress(server provisioning.Server) string {
 	ip := server.OSData.Network.State.GetInterfaceAddressByRole(incusosapi.SystemNetworkInterfaceRoleManagement)
 	if ip == nil {
@@ -733,32 +1042,9 @@ func (s *clusterService) AddServers(ctx context.Context, name string, serverName
 			return fmt.Errorf("Failed to get server %q: %w", serverName, err)
 		}
 
-		if server.Cluster != nil {
-			return fmt.Errorf("Server %q is already part of cluster %q: %w", serverName, *server.Cluster, domain.ErrOperationNotPermitted)
-		}
-
-		if server.Status != api.ServerStatusReady {
-			return fmt.Errorf("Server %q is not in ready state and can therefore not be used for clustering: %w", serverName, domain.ErrOperationNotPermitted)
-		}
-
-		if cluster.Channel != server.Channel {
-			return fmt.Errorf("Server %q update channel %q does not match channel requested for cluster %q: %w", server.Name, server.Channel, cluster.Channel, domain.ErrOperationNotPermitted)
-		}
-
-		if ptr.From(server.VersionData.NeedsUpdate) || ptr.From(server.VersionData.NeedsReboot) || ptr.From(server.VersionData.InMaintenance) != api.NotInMaintenance {
-			return fmt.Errorf("Server %q not ready to be clustered (needs update: %t, needs reboot: %t, in maintenance: %v): %w", server.Name, ptr.From(server.VersionData.NeedsUpdate), ptr.From(server.VersionData.NeedsReboot), server.VersionData.InMaintenance.String(), domain.ErrOperationNotPermitted)
-		}
-
-		hasIncus := false
-		for _, app := range server.VersionData.Applications {
-			if domain.IsApplicationNameIncusKind(app.Name) {
-				hasIncus = true
-				break
-			}
-		}
-
-		if !hasIncus {
-			return fmt.Errorf("Server %q does not have application Incus: %w", server.Name, domain.ErrOperationNotPermitted)
+		err = serverReadyForJoin(*cluster, *server)
+		if err != nil {
+			return err
 		}
 
 		additionalServers = append(additionalServers, *server)
@@ -861,6 +1147,7 @@ func (s *clusterService) AddServers(ctx context.Context, name string, serverName
 		additionalServers[i].ClusterCertificate = cluster.Certificate
 		additionalServers[i].ClusterConnectionURL = &cluster.ConnectionURL
 		additionalServers[i].Channel = cluster.Channel
+		additionalServers[i].Site = cluster.Site
 	}
 
 	err = transaction.Do(ctx, func(ctx context.Context) error {
@@ -945,6 +1232,40 @@ func (s *clusterService) AddServers(ctx context.Context, name string, serverName
 	return nil
 }
 
+// serverReadyForJoin verifies, that the server has a configuration valid for
+// joining the existing cluster.
+func serverReadyForJoin(cluster provisioning.Cluster, server provisioning.Server) error {
+	if server.Cluster != nil {
+		return fmt.Errorf("Server %q is already part of cluster %q: %w", server.Name, *server.Cluster, domain.ErrOperationNotPermitted)
+	}
+
+	if server.Status != api.ServerStatusReady {
+		return fmt.Errorf("Server %q is not in ready state and can therefore not be used for clustering: %w", server.Name, domain.ErrOperationNotPermitted)
+	}
+
+	if cluster.Channel != server.Channel {
+		return fmt.Errorf("Server %q update channel %q does not match channel requested for cluster %q: %w", server.Name, server.Channel, cluster.Channel, domain.ErrOperationNotPermitted)
+	}
+
+	if ptr.From(server.VersionData.NeedsUpdate) || ptr.From(server.VersionData.NeedsReboot) || ptr.From(server.VersionData.InMaintenance) != api.NotInMaintenance {
+		return fmt.Errorf("Server %q not ready to be clustered (needs update: %t, needs reboot: %t, in maintenance: %v): %w", server.Name, ptr.From(server.VersionData.NeedsUpdate), ptr.From(server.VersionData.NeedsReboot), server.VersionData.InMaintenance.String(), domain.ErrOperationNotPermitted)
+	}
+
+	hasIncus := false
+	for _, app := range server.VersionData.Applications {
+		if domain.IsApplicationNameIncusKind(app.Name) {
+			hasIncus = true
+			break
+		}
+	}
+
+	if !hasIncus {
+		return fmt.Errorf("Server %q does not have application Incus: %w", server.Name, domain.ErrOperationNotPermitted)
+	}
+
+	return nil
+}
+
 func (s *clusterService) copyServicesConfigFromClusterMember(ctx context.Context, sourceServer provisioning.Server, targetServers []provisioning.Server, reverter *revert.Reverter) error {
 	lvmConfig, err := s.client.GetOSServiceLVM(ctx, sourceServer)
 	if err != nil {
@@ -1669,7 +1990,7 @@ func (s *clusterService) GetAllWithFilter(ctx context.Context, filter provisioni
 		}
 
 		for i := range clusters {
-			err = s.getClusterUpdateStatus(ctx, clusters[i].Name, &clusters[i].UpdateStatus)
+			err = s.getClusterUpdateStatus(ctx, &clusters[i])
 			if err != nil {
 				return fmt.Errorf("Failed to get cluster update status for %q: %w", clusters[i].Name, err)
 			}
@@ -1709,9 +2030,23 @@ func (s *clusterService) GetAllNamesWithFilter(ctx context.Context, filter provi
 		}
 	}
 
-	clusterIDs, err := s.repo.GetAllNames(ctx)
-	if err != nil {
-		return nil, err
+	var clusterIDs []string
+	if filter.Site != nil {
+		clusters, err := s.repo.GetAllWithFilter(ctx, provisioning.ClusterFilter{
+			Site: filter.Site,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cluster := range clusters {
+			clusterIDs = append(clusterIDs, cluster.Name)
+		}
+	} else {
+		clusterIDs, err = s.repo.GetAllNames(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var filteredClusterIDs []string
@@ -1746,7 +2081,7 @@ func (s *clusterService) GetByName(ctx context.Context, name string) (*provision
 			return fmt.Errorf("Failed to get cluster %q by name: %w", name, err)
 		}
 
-		err = s.getClusterUpdateStatus(ctx, name, &cluster.UpdateStatus)
+		err = s.getClusterUpdateStatus(ctx, cluster)
 		if err != nil {
 			return fmt.Errorf("Failed to get cluster update status for %q: %w", name, err)
 		}
@@ -1760,7 +2095,10 @@ func (s *clusterService) GetByName(ctx context.Context, name string) (*provision
 	return cluster, nil
 }
 
-func (s *clusterService) getClusterUpdateStatus(ctx context.Context, name string, clusterUpdateStatus *api.ClusterUpdateStatus) error {
+func (s *clusterService) getClusterUpdateStatus(ctx context.Context, cluster *provisioning.Cluster) error {
+	name := cluster.Name
+	clusterUpdateStatus := &cluster.UpdateStatus
+
 	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
 		Cluster: ptr.To(name),
 	})
@@ -1786,12 +2124,28 @@ func (s *clusterService) getClusterUpdateStatus(ctx context.Context, name string
 		}
 	}
 
-	if clusterUpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressInactive {
+	// The replacement of a server is not driven by the control loop and stores its
+	// progress description itself.
+	if clusterUpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressInactive &&
+		clusterUpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressReplaceServer {
 		// The progress is calculated from a live snapshot of the server states, which
 		// are updated asynchronously from several sources. Pass it through the latch,
 		// so the progress reported to the user never moves backwards.
 		progress := s.clusterUpdateProgress.apply(name, clusterUpdateState(clusterUpdateStatus.InProgressStatus, servers))
+
+		// The control loop does not start the work on the next server, while the
+		// cluster is outside of its maintenance windows.
+		if progress.atSafePoint() && !s.inMaintenanceWindow(ctx, *cluster, s.now()) {
+			progress.waitingForWindow = true
+		}
+
+		// The control loop does not proceed, while the health gate is failing.
+		if progress.step != 0 && clusterUpdateStatus.InProgressStatus.HealthGateError != "" {
+			progress.pausedByHealthGate = true
+		}
+
 		clusterUpdateStatus.InProgressStatus.StatusDescription = ptr.To(progress.String())
+		clusterUpdateStatus.InProgressStatus.ServerStates = clusterUpdateServerStates(clusterUpdateStatus.InProgressStatus, servers)
 	} else {
 		s.clusterUpdateProgress.reset(name)
 	}
@@ -1814,6 +2168,17 @@ func (s *clusterService) Update(ctx context.Context, newCluster provisioning.Clu
 			return err
 		}
 
+		// The services config is only used, when the cluster is created, and can
+		// therefore not be changed.
+		newCluster.ServicesConfig = previousCluster.ServicesConfig
+
+		if ptr.From(previousCluster.Site) != ptr.From(newCluster.Site) {
+			err = s.applySiteDefaults(ctx, &newCluster)
+			if err != nil {
+				return err
+			}
+		}
+
 		err = s.repo.Update(ctx, newCluster)
 		if err != nil {
 			return err
@@ -1823,7 +2188,7 @@ func (s *clusterService) Update(ctx context.Context, newCluster provisioning.Clu
 			return nil
 		}
 
-		// Get servers of cluster and update "channel" to same value as cluster.
+		// Get servers of cluster and update "channel" and "site" to same value as cluster.
 		servers, err = s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
 			Cluster: &newCluster.Name,
 		})
@@ -1849,7 +2214,9 @@ func (s *clusterService) Update(ctx context.Context, newCluster provisioning.Clu
 
 	for _, server := range servers {
 		previousChannel := server.Channel
+		previousSite := server.Site
 		server.Channel = newCluster.Channel
+		server.Site = newCluster.Site
 		err = s.serverSvc.Update(ctx, server, true, true, false)
 		if err != nil {
 			return fmt.Errorf("Failed to update member %q of cluster %q: %w", server.Name, newCluster.Name, err)
@@ -1857,6 +2224,7 @@ func (s *clusterService) Update(ctx context.Context, newCluster provisioning.Clu
 
 		reverter.Add(func() {
 			server.Channel = previousChannel
+			server.Site = previousSite
 			err = s.serverSvc.Update(ctx, server, true, false, false)
 			if err != nil {
 				slog.ErrorContext(ctx, "Failed to restore previous server state after failed to update member server of cluster", slog.String("cluster", newCluster.Name), slog.String("server", server.Name), logger.Err(err))
@@ -2243,6 +2611,8 @@ func (s *clusterService) LaunchClusterUpdate(ctx context.Context, name string, r
 
 	reverter.Success()
 
+	s.startOperation(ctx, name, cluster.UpdateStatus.InProgressStatus.InProgress)
+
 	return nil
 }
 
@@ -2278,18 +2648,19 @@ func clusterReadyForRollingUpdate(operation string, name string, servers provisi
 }
 
 // clusterReadyForRollingReboot verifies the additional preconditions, an on
-// demand rolling reboot has on top of clusterReadyForRollingUpdate:
+// demand rolling reboot or rolling firmware update has on top of
+// clusterReadyForRollingUpdate:
 //
 //   - None of the servers is ready but currently busy (e.g. applying an update).
-func clusterReadyForRollingReboot(name string, servers provisioning.Servers) ([]string, error) {
-	evacuatedBefore, err := clusterReadyForRollingUpdate("reboot", name, servers)
+func clusterReadyForRollingReboot(operation string, name string, servers provisioning.Servers) ([]string, error) {
+	evacuatedBefore, err := clusterReadyForRollingUpdate(operation, name, servers)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, server := range servers {
 		if server.StatusDetail != api.ServerStatusDetailNone {
-			return nil, domain.NewValidationErrf("Cluster reboot can not be launched for %q: Server %q (%s) is busy (%s)", name, server.Name, server.ConnectionURL, server.StatusDetail)
+			return nil, domain.NewValidationErrf("Cluster %s can not be launched for %q: Server %q (%s) is busy (%s)", operation, name, server.Name, server.ConnectionURL, server.StatusDetail)
 		}
 	}
 
@@ -2299,6 +2670,24 @@ func clusterReadyForRollingReboot(name string, servers provisioning.Servers) ([]
 // LaunchClusterReboot launches an on demand rolling reboot of all servers of the
 // cluster.
 func (s *clusterService) LaunchClusterReboot(ctx context.Context, name string) error {
+	return s.launchRollingReboot(ctx, name, api.ClusterUpdateInProgressRollingReboot, nil)
+}
+
+// LaunchClusterFirmwareUpdate launches a rolling firmware update of all
+// servers of the cluster. The servers are processed one at a time using the
+// same evacuation and restore cycle as an on demand rolling reboot, where the
+// firmware of each server is updated via its BMC, while the server is
+// evacuated, right before the server is rebooted.
+func (s *clusterService) LaunchClusterFirmwareUpdate(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error {
+	err := provisioning.ValidateBMCFirmwareUpdate(&update)
+	if err != nil {
+		return err
+	}
+
+	return s.launchRollingReboot(ctx, name, api.ClusterUpdateInProgressRollingFirmwareUpdate, &update)
+}
+
+func (s *clusterService) launchRollingReboot(ctx context.Context, name string, operation api.ClusterUpdateInProgress, firmwareUpdate *api.BMCFirmwareUpdatePost) error {
 	cluster, err := s.GetByName(ctx, name)
 	if err != nil {
 		return fmt.Errorf("Failed to get cluster %q: %w", name, err)
@@ -2327,19 +2716,35 @@ func (s *clusterService) LaunchClusterReboot(ctx context.Context, name string) e
 		return fmt.Errorf("Failed to get server details for cluster %q: %w", name, err)
 	}
 
-	evacuatedBefore, err := clusterReadyForRollingReboot(name, servers)
-	if err != nil {
-		return err
-	}
+	operationName := "reboot"
+	if firmwareUpdate != nil {
+		operationName = "firmware update"
 
-	// The control loop is driven by the in progress status, so a rolling reboot,
-	// which is visible without its pending reboot list, would be seen as a run
+		for _, server := range servers {
+			if !server.BMCConfig.HasBMC() {
+				return domain.NewValidationErrf("Cluster %s can not be launched for %q: Server %q (%s) does not have a BMC configured", operationName, name, server.Name, server.ConnectionURL)
+			}
+		}
+	}
+
+	evacuatedBefore, err := clusterReadyForRollingReboot(operationName, name, servers)
+	if err != nil {
+		return err
+	}
+
+	// The control loop is driven by the in progress status, so a rolling reboot,
+	// which is visible without its pending reboot list, would be seen as a run
 	// with nothing left to do and would be cleaned up right away.
 	pendingReboot := make([]string, 0, len(servers))
 	for _, server := range servers {
 		pendingReboot = append(pendingReboot, server.Name)
 	}
 
+	var pendingFirmwareUpdate []string
+	if firmwareUpdate != nil {
+		pendingFirmwareUpdate = slices.Clone(pendingReboot)
+	}
+
 	err = transaction.Do(ctx, func(ctx context.Context) error {
 		cluster, err := s.repo.GetByName(ctx, name)
 		if err != nil {
@@ -2351,10 +2756,12 @@ func (s *clusterService) LaunchClusterReboot(ctx context.Context, name string) e
 		}
 
 		cluster.UpdateStatus.InProgressStatus = api.ClusterUpdateInProgressStatus{
-			InProgress:      api.ClusterUpdateInProgressRollingReboot,
-			EvacuatedBefore: evacuatedBefore,
-			PendingReboot:   pendingReboot,
-			LastUpdated:     s.now(),
+			InProgress:            operation,
+			EvacuatedBefore:       evacuatedBefore,
+			PendingReboot:         pendingReboot,
+			PendingFirmwareUpdate: pendingFirmwareUpdate,
+			FirmwareUpdate:        firmwareUpdate,
+			LastUpdated:           s.now(),
 		}
 
 		err = s.repo.Update(ctx, *cluster)
@@ -2370,6 +2777,8 @@ func (s *clusterService) LaunchClusterReboot(ctx context.Context, name string) e
 
 	s.clusterUpdateProgress.reset(name)
 
+	s.startOperation(ctx, name, operation)
+
 	// Kick the control loop right away instead of waiting for the next tick of the
 	// periodic cluster update control loop.
 	servers[0].SignalLifecycleEvent()
@@ -2377,6 +2786,293 @@ func (s *clusterService) LaunchClusterReboot(ctx context.Context, name string) e
 	return nil
 }
 
+// PlanClusterUpdate calculates the steps, a cluster update would perform, if it
+// would be launched now, without launching it.
+func (s *clusterService) PlanClusterUpdate(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error) {
+	operation := api.ClusterUpdateInProgressApplyUpdate
+	if reboot {
+		operation = api.ClusterUpdateInProgressApplyUpdateWithReboot
+	}
+
+	return s.planClusterOperation(ctx, name, operation)
+}
+
+// PlanClusterReboot calculates the steps, an on demand rolling reboot would
+// perform, if it would be launched now, without launching it.
+func (s *clusterService) PlanClusterReboot(ctx context.Context, name string) (api.ClusterUpdatePlan, error) {
+	return s.planClusterOperation(ctx, name, api.ClusterUpdateInProgressRollingReboot)
+}
+
+func (s *clusterService) planClusterOperation(ctx context.Context, name string, operation api.ClusterUpdateInProgress) (api.ClusterUpdatePlan, error) {
+	cluster, err := s.GetByName(ctx, name)
+	if err != nil {
+		return api.ClusterUpdatePlan{}, fmt.Errorf("Failed to get cluster %q: %w", name, err)
+	}
+
+	if cluster.IsUpdateInProgress() {
+		return api.ClusterUpdatePlan{}, fmt.Errorf("Cluster %q already has an operation in progress: %w", name, domain.ErrOperationNotPermitted)
+	}
+
+	// In contrast to launching the operation, the state information of the
+	// servers is not refreshed, since the dry run must not have any side
+	// effects. The plan is therefore based on the most recent state known.
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Cluster: ptr.To(name),
+	})
+	if err == nil && len(servers) == 0 {
+		err = domain.ErrNotFound
+	}
+
+	if err != nil {
+		return api.ClusterUpdatePlan{}, fmt.Errorf("Failed to get server details for cluster %q: %w", name, err)
+	}
+
+	var evacuatedBefore []string
+	if operation == api.ClusterUpdateInProgressRollingReboot {
+		evacuatedBefore, err = clusterReadyForRollingReboot("reboot", name, servers)
+	} else {
+		evacuatedBefore, err = clusterReadyForRollingUpdate("update", name, servers)
+	}
+
+	if err != nil {
+		return api.ClusterUpdatePlan{}, err
+	}
+
+	instances := map[string][]api.ClusterUpdatePlanInstance{}
+	if s.inventorySvc != nil {
+		clusterResources, err := s.inventorySvc.GetAllWithFilter(ctx, inventory.InventoryAggregateFilter{
+			Kinds:              []string{string(domain.ResourceTypeInstance)},
+			Clusters:           []string{name},
+			ProjectIncludeNull: true,
+			ParentIncludeNull:  true,
+		})
+		if err != nil {
+			return api.ClusterUpdatePlan{}, fmt.Errorf("Failed to get instances from inventory for cluster %q: %w", name, err)
+		}
+
+		for _, clusterResource := range clusterResources {
+			for _, instance := range clusterResource.Instances {
+				instances[instance.Server] = append(instances[instance.Server], api.ClusterUpdatePlanInstance{
+					Name:    instance.Name,
+					Project: instance.ProjectName,
+					Status:  instance.Object.Status,
+				})
+			}
+		}
+	}
+
+	plan := clusterUpdatePlan(operation, servers, evacuatedBefore, instances, clusterUpdatePlanSchedule{
+		maxUnavailable:      cluster.MaxUnavailable(len(servers)),
+		maintenanceWindows:  s.hasMaintenanceWindows(ctx, *cluster),
+		inMaintenanceWindow: s.inMaintenanceWindow(ctx, *cluster, s.now()),
+	})
+
+	if operation == api.ClusterUpdateInProgressRollingReboot || len(plan.Steps) == 0 || s.updateSvc == nil {
+		return plan, nil
+	}
+
+	changelog, err := s.clusterUpdateChangelog(ctx, *cluster, servers)
+	if err != nil {
+		return api.ClusterUpdatePlan{}, err
+	}
+
+	plan.Changelog = changelog
+
+	return plan, nil
+}
+
+// clusterUpdateChangelog returns the changelog of the update, the OS of the
+// servers of the cluster moves to. If this update is not known, nil is
+// returned.
+func (s *clusterService) clusterUpdateChangelog(ctx context.Context, cluster provisioning.Cluster, servers provisioning.Servers) (*api.UpdateChangelog, error) {
+	var targetVersion string
+	for _, server := range servers {
+		availableVersion := ptr.From(server.VersionData.OS.AvailableVersion)
+		if api.AvailableVersionGreaterThan(targetVersion, availableVersion) {
+			targetVersion = availableVersion
+		}
+	}
+
+	if targetVersion == "" {
+		return nil, nil
+	}
+
+	updates, err := s.updateSvc.GetAllWithFilter(ctx, provisioning.UpdateFilter{
+		Channel: ptr.To(cluster.Channel),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get updates for channel %q: %w", cluster.Channel, err)
+	}
+
+	architecture := images.UpdateFileArchitecture(servers[0].HardwareData.CPU.Architecture)
+	_, ok := images.UpdateFileArchitectures[architecture]
+	if !ok || architecture == images.UpdateFileArchitectureUndefined {
+		architecture = images.UpdateFileArchitecture64BitX86
+	}
+
+	for _, update := range updates {
+		if update.Version != targetVersion {
+			continue
+		}
+
+		changelog, err := s.updateSvc.GetChangelogByChannel(ctx, update.UUID, cluster.Channel, false, architecture)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get changelog for update %q: %w", update.Version, err)
+		}
+
+		return &changelog, nil
+	}
+
+	return nil, nil
+}
+
+// autoUpdateLaunchesLimit is the number of automatic cluster update launches,
+// which are kept in the update status of a cluster.
+const autoUpdateLaunchesLimit = 10
+
+// LaunchAutomaticClusterUpdates launches a cluster update for every cluster
+// with an enabled auto update policy, if the channel of the cluster provides
+// an update, which is not yet installed on all the servers of the cluster and
+// which matches the policy. Each automatic launch is recorded in the update
+// status of the cluster and a failed launch is reported as warning.
+func (s *clusterService) LaunchAutomaticClusterUpdates(ctx context.Context) error {
+	if s.updateSvc == nil {
+		return fmt.Errorf("Automatic cluster updates require the update service")
+	}
+
+	clusters, err := s.GetAllWithFilter(ctx, provisioning.ClusterFilter{
+		Expression: ptr.To(`config.auto_update.enabled`),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to get clusters for automatic cluster updates: %w", err)
+	}
+
+	var errs []error
+	for _, cluster := range clusters {
+		if cluster.IsUpdateInProgress() || len(cluster.UpdateStatus.NeedsUpdate) == 0 {
+			continue
+		}
+
+		update, err := s.pendingAutoUpdate(ctx, cluster)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if update == nil {
+			continue
+		}
+
+		log := slog.With(slog.String("cluster", cluster.Name), slog.String("version", update.Version), slog.String("severity", string(update.Severity)))
+		log.InfoContext(ctx, "Launching automatic cluster update")
+
+		launch := api.ClusterAutoUpdateLaunch{
+			LaunchedAt: s.now(),
+			Version:    update.Version,
+			Severity:   update.Severity,
+		}
+
+		scope := api.WarningScope{
+			Scope:      "auto_update",
+			EntityType: "cluster",
+			Entity:     cluster.Name,
+		}
+
+		launchErr := s.LaunchClusterUpdate(ctx, cluster.Name, cluster.Config.AutoUpdate.Reboot)
+		if launchErr != nil {
+			launch.Error = launchErr.Error()
+
+			s.warning.Emit(
+				ctx,
+				warning.NewWarning(
+					api.WarningTypeClusterAutoUpdateFailed,
+					scope,
+					fmt.Sprintf("Automatic cluster update for update %s failed: %v", update.Version, launchErr),
+				),
+			)
+
+			errs = append(errs, fmt.Errorf("Failed to launch automatic cluster update for cluster %q: %w", cluster.Name, launchErr))
+		} else {
+			s.warning.RemoveStale(ctx, scope, nil)
+		}
+
+		err = transaction.Do(ctx, func(ctx context.Context) error {
+			updateCluster, err := s.repo.GetByName(ctx, cluster.Name)
+			if err != nil {
+				return err
+			}
+
+			launches := append(updateCluster.UpdateStatus.AutoUpdateLaunches, launch)
+			if len(launches) > autoUpdateLaunchesLimit {
+				launches = launches[len(launches)-autoUpdateLaunchesLimit:]
+			}
+
+			updateCluster.UpdateStatus.AutoUpdateLaunches = launches
+
+			return s.repo.Update(ctx, *updateCluster)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to record automatic cluster update for cluster %q: %w", cluster.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pendingAutoUpdate returns the most recent update of the channel of the
+// cluster, which is not yet installed on all the servers of the cluster and
+// which matches the auto update policy of the cluster. If there is no such
+// update, nil is returned.
+func (s *clusterService) pendingAutoUpdate(ctx context.Context, cluster provisioning.Cluster) (*provisioning.Update, error) {
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Cluster: ptr.To(cluster.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get servers for cluster %q: %w", cluster.Name, err)
+	}
+
+	// The oldest version installed on any of the servers, which need to be
+	// updated, marks the start of the updates, which are still pending for the
+	// cluster.
+	var oldestVersion string
+	for _, server := range servers {
+		if !ptr.From(server.VersionData.NeedsUpdate) {
+			continue
+		}
+
+		versions := []string{server.VersionData.OS.Version}
+		for _, application := range server.VersionData.Applications {
+			versions = append(versions, application.Version)
+		}
+
+		for _, version := range versions {
+			if oldestVersion == "" || api.AvailableVersionGreaterThan(version, oldestVersion) {
+				oldestVersion = version
+			}
+		}
+	}
+
+	updates, err := s.updateSvc.GetAllWithFilter(ctx, provisioning.UpdateFilter{
+		Channel: ptr.To(cluster.Channel),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get updates for channel %q: %w", cluster.Channel, err)
+	}
+
+	now := s.now()
+	for _, update := range updates {
+		if !api.AvailableVersionGreaterThan(oldestVersion, update.Version) {
+			continue
+		}
+
+		if cluster.AutoUpdateApplies(update, now) {
+			return &update, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (s *clusterService) ClusterUpdateControlLoop(ctx context.Context, clusterNameFilter *string) error {
 	clusters, err := s.GetAllWithFilter(ctx, provisioning.ClusterFilter{
 		Name:       clusterNameFilter,
@@ -2423,6 +3119,11 @@ func (s *clusterService) ClusterUpdateControlLoop(ctx context.Context, clusterNa
 				return nil
 			}
 
+			// The replacement of a server is performed by ReplaceServer itself.
+			if cluster.UpdateStatus.InProgressStatus.InProgress == api.ClusterUpdateInProgressReplaceServer {
+				return nil
+			}
+
 			// Refresh all status information for all servers.
 			err := s.serverSvc.PollServers(ctx, provisioning.ServerFilter{
 				Cluster: &cluster.Name,
@@ -2451,7 +3152,8 @@ func (s *clusterService) ClusterUpdateControlLoop(ctx context.Context, clusterNa
 				err = s.executeRollingUpdate(ctx, cluster, servers)
 
 			case api.ClusterUpdateInProgressRollingRestart,
-				api.ClusterUpdateInProgressRollingReboot:
+				api.ClusterUpdateInProgressRollingReboot,
+				api.ClusterUpdateInProgressRollingFirmwareUpdate:
 				err = s.executeRollingRestartNextStep(ctx, cluster, servers)
 			}
 
@@ -2473,20 +3175,32 @@ func (s *clusterService) ClusterUpdateControlLoop(ctx context.Context, clusterNa
 func (s *clusterService) executeRollingUpdate(ctx context.Context, cluster provisioning.Cluster, servers provisioning.Servers) error {
 	log := slog.With(slog.String("cluster", cluster.Name))
 
-	// Trigger update on each server, applications get updated immediately,
-	// OS is prepared for update on next reboot.
+	// Trigger update on up to max unavailable servers at the same time,
+	// applications get updated immediately, OS is prepared for update on next
+	// reboot.
 	// Also verify, that none of the servers is still updating or has pending
 	// updates for the applications and the next OS.
-	for _, server := range servers {
-		if !ptr.From(server.VersionData.NeedsUpdate) {
-			if server.StatusDetail == api.ServerStatusDetailReadyUpdatingOS {
-				// Server status detail needs to be updated first, not yet ready to proceed.
-				return nil
-			}
+	maxUnavailable := cluster.MaxUnavailable(len(servers))
 
+	var updating int
+	pending := make(provisioning.Servers, 0, len(servers))
+	for _, server := range servers {
+		if server.StatusDetail == api.ServerStatusDetailReadyUpdatingOS {
+			updating++
 			continue
 		}
 
+		if ptr.From(server.VersionData.NeedsUpdate) {
+			pending = append(pending, server)
+		}
+	}
+
+	if len(pending) == 0 && updating > 0 {
+		// The last servers are still updating, not yet ready to proceed.
+		return nil
+	}
+
+	if len(pending) > 0 {
 		// To get a consistent log, print the current state before triggering the next action, since
 		// it will likely update the state.
 		updateState := clusterUpdateState(cluster.UpdateStatus.InProgressStatus, servers).String()
@@ -2494,34 +3208,56 @@ func (s *clusterService) executeRollingUpdate(ctx context.Context, cluster provi
 			log.InfoContext(ctx, "Cluster rolling update next step", slog.String("cluster_update_state", updateState))
 		}
 
-		if server.StatusDetail == api.ServerStatusDetailReadyUpdatingOS {
-			// Update servers one by one, one server already updating, so we have
-			// to wait.
+		if updating >= maxUnavailable {
+			// Already max unavailable servers updating, so we have to wait.
+			return nil
+		}
+
+		// Updates are only triggered within the maintenance windows of the cluster.
+		if !s.inMaintenanceWindow(ctx, cluster, s.now()) {
+			log.InfoContext(ctx, "Cluster rolling update waiting for maintenance window", slog.String("server", pending[0].Name))
 			return nil
 		}
 
-		applicationUpdate := make([]api.ServerUpdateApplication, 0, len(server.VersionData.Applications))
-		for _, app := range server.VersionData.Applications {
-			if ptr.From(app.NeedsUpdate) {
-				applicationUpdate = append(applicationUpdate, api.ServerUpdateApplication{
-					Name:          app.Name,
+		var healthGateErr error
+		for _, server := range pending[:min(len(pending), maxUnavailable-updating)] {
+			err := s.runHealthGate(ctx, provisioning.ClusterUpdateHealthGatePreStep, cluster, servers, server)
+			if err != nil {
+				// The update of the server is held back until the health gate passes.
+				healthGateErr = err
+				break
+			}
+
+			applicationUpdate := make([]api.ServerUpdateApplication, 0, len(server.VersionData.Applications))
+			for _, app := range server.VersionData.Applications {
+				if ptr.From(app.NeedsUpdate) {
+					applicationUpdate = append(applicationUpdate, api.ServerUpdateApplication{
+						Name:          app.Name,
+						TriggerUpdate: true,
+					})
+				}
+			}
+
+			err = s.serverSvc.UpdateSystemByName(ctx, server.Name, api.ServerUpdatePost{
+				OS: api.ServerUpdateApplication{
+					Name:          "os",
 					TriggerUpdate: true,
-				})
+				},
+				Applications: applicationUpdate,
+			}, true)
+			if err != nil {
+				return fmt.Errorf("Failed to trigger server update on %q (%s): %w", server.Name, server.ConnectionURL, err)
 			}
+
+			s.recordOperationEvent(ctx, cluster.Name, server.Name, api.ClusterOperationEventActionUpdate)
 		}
 
-		err := s.serverSvc.UpdateSystemByName(ctx, server.Name, api.ServerUpdatePost{
-			OS: api.ServerUpdateApplication{
-				Name:          "os",
-				TriggerUpdate: true,
-			},
-			Applications: applicationUpdate,
-		}, true)
+		err := s.reportHealthGateResult(ctx, cluster, healthGateErr)
 		if err != nil {
-			return fmt.Errorf("Failed to trigger server update on %q (%s): %w", server.Name, server.ConnectionURL, err)
+			return err
 		}
 
-		// Update servers one by one, so we have to wait.
+		// Servers are updating, so we have to wait.
 		return nil
 	}
 
@@ -2558,6 +3294,8 @@ func (s *clusterService) executeRollingUpdate(ctx context.Context, cluster provi
 	if emitLifecycleSignal {
 		// Use last server as the triggering server, since it was most likely the last one that was updated.
 		servers[len(servers)-1].SignalLifecycleEvent()
+	} else {
+		s.finishOperation(ctx, cluster.Name, api.ClusterOperationStatusSucceeded, "")
 	}
 
 	return nil
@@ -2567,14 +3305,30 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 	log := slog.With(slog.String("cluster", cluster.Name))
 
 	// Calculate, if we are done based on the current state of all servers and the desired target state and
-	// calculate next action if we are not done yet.
+	// calculate the next actions if we are not done yet. Up to max unavailable
+	// servers are going through the restart cycle at the same time, each of
+	// them occupies a slot until it is back in a safe state.
 	var err error
-	var nextAction func(context.Context) error
+	var nextActions []func(context.Context) error
+	maxUnavailable := cluster.MaxUnavailable(len(servers))
+
+	// The firmware is only updated on one server at a time, regardless of max
+	// unavailable, such that a faulty firmware image can not take down more
+	// than one server of the cluster.
+	if cluster.UpdateStatus.InProgressStatus.InProgress == api.ClusterUpdateInProgressRollingFirmwareUpdate {
+		maxUnavailable = 1
+	}
 
 	noop := func(ctx context.Context) error {
 		return nil
 	}
 
+	// Servers, which are in the middle of the restart cycle, are always brought
+	// back to a safe state, but the evacuation of the next server is only
+	// started within the maintenance windows of the cluster.
+	inMaintenanceWindow := s.inMaintenanceWindow(ctx, cluster, s.now())
+	var waitingForWindow bool
+
 	for _, server := range servers {
 		// serverUpdateStateForRollingUpdate intentionally ignores pending updates
 		// during the rolling restart phase. All servers of a cluster have been updated
@@ -2589,7 +3343,9 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 		// disagree with the action taken here.
 		serverUpdateState := serverUpdateStateForRollingUpdate(cluster.UpdateStatus.InProgressStatus, server)
 
-		if nextAction == nil {
+		if len(nextActions) < maxUnavailable {
+			var nextAction func(context.Context) error
+
 			switch serverUpdateState {
 			case api.ServerUpdateStateUndefined:
 				return fmt.Errorf("Server update state for %q (%s) is undefined", server.Name, server.ConnectionURL)
@@ -2605,8 +3361,26 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 				return fmt.Errorf("Server %q is updating while a cluster wide rolling reboot cycle is ongoing", server.Name)
 
 			case api.ServerUpdateStateEvacuationPending:
+				if !inMaintenanceWindow {
+					waitingForWindow = true
+					nextAction = noop
+					break
+				}
+
 				nextAction = func(ctx context.Context) error {
-					return s.serverSvc.EvacuateSystemByName(ctx, server.Name, true, false)
+					err := s.runHealthGate(ctx, provisioning.ClusterUpdateHealthGatePreStep, cluster, servers, server)
+					if err != nil {
+						return err
+					}
+
+					err = s.serverSvc.EvacuateSystemByName(ctx, server.Name, true, false)
+					if err != nil {
+						return err
+					}
+
+					s.recordOperationEvent(ctx, cluster.Name, server.Name, api.ClusterOperationEventActionEvacuate)
+
+					return nil
 				}
 
 			case api.ServerUpdateStateEvacuating:
@@ -2614,11 +3388,23 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 
 			case api.ServerUpdateStateInMaintenanceRebootPending:
 				nextAction = func(ctx context.Context) error {
+					// During a rolling firmware update, the firmware is updated while
+					// the server is evacuated. The following reboot activates the new
+					// firmware.
+					if slices.Contains(cluster.UpdateStatus.InProgressStatus.PendingFirmwareUpdate, server.Name) {
+						err := s.updateServerFirmware(ctx, cluster, server.Name)
+						if err != nil {
+							return err
+						}
+					}
+
 					err := s.serverSvc.RebootSystemByName(ctx, server.Name, true)
 					if err != nil {
 						return err
 					}
 
+					s.recordOperationEvent(ctx, cluster.Name, server.Name, api.ClusterOperationEventActionReboot)
+
 					// During an on demand rolling reboot, the need for the reboot is
 					// synthesized from the pending reboot list. Dropping the server from the
 					// list is therefore what lets it advance to the restore step.
@@ -2637,7 +3423,14 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 
 				restoreModeSkip := cluster.Config.RollingRestart.RestoreMode == "skip"
 				nextAction = func(ctx context.Context) error {
-					return s.serverSvc.RestoreSystemByName(ctx, server.Name, true, false, restoreModeSkip)
+					err := s.serverSvc.RestoreSystemByName(ctx, server.Name, true, false, restoreModeSkip)
+					if err != nil {
+						return err
+					}
+
+					s.recordOperationEvent(ctx, cluster.Name, server.Name, api.ClusterOperationEventActionRestore)
+
+					return nil
 				}
 
 			case api.ServerUpdateStateInMaintenanceRestoring:
@@ -2648,7 +3441,19 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 				postRestoreDelay, _ := time.ParseDuration(cluster.Config.RollingRestart.PostRestoreDelay) // Duration is validated on save, we ignore the error here.
 				if server.LastStatusUpdated.Add(postRestoreDelay).Before(s.now()) {
 					nextAction = func(ctx context.Context) error {
-						return s.serverSvc.PostRestoreSystemDoneByName(ctx, server.Name)
+						err := s.runHealthGate(ctx, provisioning.ClusterUpdateHealthGatePostStep, cluster, servers, server)
+						if err != nil {
+							return err
+						}
+
+						err = s.serverSvc.PostRestoreSystemDoneByName(ctx, server.Name)
+						if err != nil {
+							return err
+						}
+
+						s.recordOperationEvent(ctx, cluster.Name, server.Name, api.ClusterOperationEventActionDone)
+
+						return nil
 					}
 				} else {
 					nextAction = noop
@@ -2658,11 +3463,12 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 				return fmt.Errorf("Server update state %q for %q (%s) is not supported", serverUpdateState, server.Name, server.ConnectionURL)
 			}
 
+			nextActions = append(nextActions, nextAction)
 			continue
 		}
 
-		// We know the next action so we need to determine, if we are allowed
-		// to perform this action as well as the number of steps, that are pending.
+		// We know the next actions so we need to determine, if we are allowed
+		// to perform these actions as well as the number of steps, that are pending.
 		switch serverUpdateState {
 		case api.ServerUpdateStateUpToDate,
 			api.ServerUpdateStateEvacuationPending:
@@ -2705,45 +3511,69 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 		log.InfoContext(ctx, "Cluster rolling update next step", slog.String("cluster_update_state", updateState))
 	}
 
-	done := nextAction == nil
+	if waitingForWindow {
+		log.InfoContext(ctx, "Cluster rolling update waiting for maintenance window")
+	}
+
+	done := len(nextActions) == 0
 	if !done {
 		scope := api.WarningScope{
 			Scope:      updateState,
 			EntityType: "cluster",
 			Entity:     cluster.Name,
 		}
-		// Trigger next update action on the target server
-		err = nextAction(ctx)
-		if err != nil {
-			if domain.IsRetryableError(err) {
-				s.warning.Emit(
-					ctx,
-					warning.NewWarning(
-						api.WarningTypeClusterRollingUpdateNextAction,
-						scope,
-						fmt.Sprintf("Rolling cluster update next action: %v", err),
-					),
-				)
-				return nil
-			}
 
-			if errors.Is(err, domain.ErrTerminal) {
-				inProgressStatus := cluster.UpdateStatus.InProgressStatus
-				inProgressStatus.InProgress = api.ClusterUpdateInProgressError
-				inProgressStatus.Error = err.Error()
+		var retryableErr bool
+		var healthGateErr error
+
+		// Trigger next update action on the target servers
+		for _, nextAction := range nextActions {
+			err = nextAction(ctx)
+			if err != nil {
+				if errors.Is(err, errHealthGateFailed) {
+					// The step is held back until the health gate passes.
+					if healthGateErr == nil {
+						healthGateErr = err
+					}
 
-				updateErr := s.updateInProgressStatus(ctx, cluster.Name, inProgressStatus)
-				if updateErr != nil {
-					err = errors.Join(err, updateErr)
+					continue
+				}
+
+				if domain.IsRetryableError(err) {
+					s.warning.Emit(
+						ctx,
+						warning.NewWarning(
+							api.WarningTypeClusterRollingUpdateNextAction,
+							scope,
+							fmt.Sprintf("Rolling cluster update next action: %v", err),
+						),
+					)
+					retryableErr = true
+					continue
+				}
+
+				if errors.Is(err, domain.ErrTerminal) {
+					inProgressStatus := cluster.UpdateStatus.InProgressStatus
+					inProgressStatus.InProgress = api.ClusterUpdateInProgressError
+					inProgressStatus.Error = err.Error()
+
+					updateErr := s.updateInProgressStatus(ctx, cluster.Name, inProgressStatus)
+					if updateErr != nil {
+						err = errors.Join(err, updateErr)
+					}
+
+					s.finishOperation(ctx, cluster.Name, api.ClusterOperationStatusFailed, inProgressStatus.Error)
 				}
-			}
 
-			return fmt.Errorf("Failed to trigger next action for rolling update of cluster %q: %w", cluster.Name, err)
+				return fmt.Errorf("Failed to trigger next action for rolling update of cluster %q: %w", cluster.Name, err)
+			}
 		}
 
-		s.warning.RemoveStale(ctx, scope, nil)
+		if !retryableErr {
+			s.warning.RemoveStale(ctx, scope, nil)
+		}
 
-		return nil
+		return s.reportHealthGateResult(ctx, cluster, healthGateErr)
 	}
 
 	// Update the cluster update status in the DB, if we are done with the update.
@@ -2752,14 +3582,138 @@ func (s *clusterService) executeRollingRestartNextStep(ctx context.Context, clus
 		return err
 	}
 
+	s.finishOperation(ctx, cluster.Name, api.ClusterOperationStatusSucceeded, "")
+
+	return nil
+}
+
+// errHealthGateFailed is returned, if the cluster update health gate prevents
+// the next step of a rolling cluster update.
+var errHealthGateFailed = errors.New("Health gate failed")
+
+// runHealthGate runs the cluster update health gate for the given step and
+// server. A failing health gate is reported as an error wrapping
+// errHealthGateFailed.
+func (s *clusterService) runHealthGate(ctx context.Context, step provisioning.ClusterUpdateHealthGateStep, cluster provisioning.Cluster, servers provisioning.Servers, server provisioning.Server) error {
+	if s.scriptlet == nil {
+		return nil
+	}
+
+	cluster.ServerNames = make([]string, 0, len(servers))
+	for _, clusterServer := range servers {
+		cluster.ServerNames = append(cluster.ServerNames, clusterServer.Name)
+	}
+
+	err := s.scriptlet.ClusterUpdateHealthGateRun(ctx, step, cluster, server)
+	if err != nil {
+		return fmt.Errorf("%w: %s for server %q: %v", errHealthGateFailed, step, server.Name, err)
+	}
+
 	return nil
 }
 
+// reportHealthGateResult persists the outcome of the health gates of the
+// current control loop iteration with the in progress status of the cluster
+// and raises a warning for a failing health gate. A passing health gate clears
+// a previous health gate error as well as the respective warning.
+func (s *clusterService) reportHealthGateResult(ctx context.Context, cluster provisioning.Cluster, healthGateErr error) error {
+	if s.scriptlet == nil {
+		return nil
+	}
+
+	scope := api.WarningScope{
+		Scope:      "cluster update health gate",
+		EntityType: "cluster",
+		Entity:     cluster.Name,
+	}
+
+	var healthGateError string
+	if healthGateErr != nil {
+		healthGateError = healthGateErr.Error()
+
+		slog.WarnContext(ctx, "Cluster rolling update paused by health gate", slog.String("cluster", cluster.Name), logger.Err(healthGateErr))
+
+		s.warning.Emit(
+			ctx,
+			warning.NewWarning(
+				api.WarningTypeClusterUpdateHealthGateFailed,
+				scope,
+				fmt.Sprintf("Rolling cluster update paused: %v", healthGateErr),
+			),
+		)
+	} else {
+		s.warning.RemoveStale(ctx, scope, nil)
+	}
+
+	if cluster.UpdateStatus.InProgressStatus.HealthGateError == healthGateError {
+		return nil
+	}
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		updateCluster, err := s.repo.GetByName(ctx, cluster.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster %q: %w", cluster.Name, err)
+		}
+
+		updateCluster.UpdateStatus.InProgressStatus.HealthGateError = healthGateError
+		updateCluster.UpdateStatus.InProgressStatus.LastUpdated = s.now()
+
+		err = s.repo.Update(ctx, *updateCluster)
+		if err != nil {
+			return fmt.Errorf("Failed to update cluster %q: %w", cluster.Name, err)
+		}
+
+		return nil
+	})
+}
+
+// updateServerFirmware updates the firmware of the given server via its BMC
+// as part of a rolling firmware update and removes the server from the list of
+// servers, which still need a firmware update. A failed firmware update stops
+// the rolling firmware update, since the server is in an unknown state.
+func (s *clusterService) updateServerFirmware(ctx context.Context, cluster provisioning.Cluster, serverName string) error {
+	firmwareUpdate := cluster.UpdateStatus.InProgressStatus.FirmwareUpdate
+	if firmwareUpdate == nil {
+		return fmt.Errorf("Rolling firmware update of cluster %q is missing the firmware update definition: %w", cluster.Name, domain.ErrTerminal)
+	}
+
+	err := s.serverSvc.BMCFirmwareUpdateByName(ctx, serverName, *firmwareUpdate, true)
+	if err != nil {
+		return fmt.Errorf("Failed to update firmware of server %q: %v: %w", serverName, err, domain.ErrTerminal)
+	}
+
+	s.recordOperationEvent(ctx, cluster.Name, serverName, api.ClusterOperationEventActionFirmware)
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		updateCluster, err := s.repo.GetByName(ctx, cluster.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster %q: %w", cluster.Name, err)
+		}
+
+		updateCluster.UpdateStatus.InProgressStatus.PendingFirmwareUpdate = slices.DeleteFunc(
+			updateCluster.UpdateStatus.InProgressStatus.PendingFirmwareUpdate,
+			func(name string) bool {
+				return name == serverName
+			},
+		)
+
+		updateCluster.UpdateStatus.InProgressStatus.LastUpdated = s.now()
+
+		err = s.repo.Update(ctx, *updateCluster)
+		if err != nil {
+			return fmt.Errorf("Failed to update cluster %q: %w", cluster.Name, err)
+		}
+
+		return nil
+	})
+}
+
 // markServerRebooted removes the server from the list of servers, which still
-// have to be rebooted as part of an on demand rolling reboot. It is a no-op for
-// all other phases.
+// have to be rebooted as part of an on demand rolling reboot or a rolling
+// firmware update. It is a no-op for all other phases.
 func (s *clusterService) markServerRebooted(ctx context.Context, cluster provisioning.Cluster, serverName string) error {
-	if cluster.UpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressRollingReboot {
+	if cluster.UpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressRollingReboot &&
+		cluster.UpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressRollingFirmwareUpdate {
 		return nil
 	}
 
@@ -2831,6 +3785,8 @@ func (s *clusterService) AbortClusterOperation(ctx context.Context, name string)
 
 	s.clusterUpdateProgress.reset(name)
 
+	s.finishOperation(ctx, name, api.ClusterOperationStatusCanceled, "")
+
 	return nil
 }
 
@@ -3450,6 +4406,278 @@ func (s *clusterService) RemoveStorageTargetNVME(ctx context.Context, clusterNam
 	return nil
 }
 
+func (s *clusterService) AddServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string, cephCluster incusosapi.ServiceCephCluster) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Add ceph cluster %q to cluster members for %q failed: %w", cephClusterName, clusterName, err)
+		}
+	}()
+
+	if cephClusterName == "" {
+		return domain.NewValidationErrf("Ceph cluster name cannot be empty")
+	}
+
+	servers, err := s.prepareBulkUpdate(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the ceph cluster is not yet present on all servers.
+	cephConfigs := make(map[string]incusosapi.ServiceCeph, len(servers))
+	for _, server := range servers {
+		var cephConfig incusosapi.ServiceCeph
+		cephConfig, err = s.client.GetOSServiceCeph(ctx, server)
+		if err != nil {
+			return fmt.Errorf("Failed to get ceph service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		_, ok := cephConfig.Config.Clusters[cephClusterName]
+		if ok {
+			return fmt.Errorf("Service ceph cluster %q already defined on server %q (%s): %w", cephClusterName, server.Name, server.GetConnectionURL(), domain.ErrOperationNotPermitted)
+		}
+
+		cephConfigs[server.Name] = cephConfig
+	}
+
+	// Perform change on all servers.
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	for _, server := range servers {
+		currentCephConfig := cephConfigs[server.Name]
+
+		cephClusters := make(map[string]incusosapi.ServiceCephCluster, len(currentCephConfig.Config.Clusters)+1)
+		maps.Copy(cephClusters, currentCephConfig.Config.Clusters)
+		cephClusters[cephClusterName] = cephCluster
+
+		updatedCephConfig := incusosapi.ServiceCeph{
+			Config: incusosapi.ServiceCephConfig{
+				Enabled:  true,
+				Clusters: cephClusters,
+			},
+		}
+
+		err = s.client.UpdateOSService(ctx, server, "ceph", updatedCephConfig)
+		if err != nil {
+			return fmt.Errorf("Failed to update ceph service config on server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		reverter.Add(func() {
+			revertErr := s.client.UpdateOSService(ctx, server, "ceph", currentCephConfig)
+			if revertErr != nil {
+				// The service config contains the keyrings, it is therefore not logged.
+				slog.ErrorContext(ctx, "Failed to revert previously updated ceph service config", logger.Err(revertErr), slog.String("server", server.Name), slog.String("connection_url", server.GetConnectionURL()), slog.String("ceph_cluster", cephClusterName), slog.Any("root_cause", err))
+			}
+		})
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+func (s *clusterService) RemoveServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Remove ceph cluster %q from cluster members for %q failed: %w", cephClusterName, clusterName, err)
+		}
+	}()
+
+	servers, err := s.prepareBulkUpdate(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the ceph cluster is present on all servers.
+	cephConfigs := make(map[string]incusosapi.ServiceCeph, len(servers))
+	for _, server := range servers {
+		var cephConfig incusosapi.ServiceCeph
+		cephConfig, err = s.client.GetOSServiceCeph(ctx, server)
+		if err != nil {
+			return fmt.Errorf("Failed to get ceph service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		_, ok := cephConfig.Config.Clusters[cephClusterName]
+		if !ok {
+			return fmt.Errorf("Service ceph cluster %q does not exist on server %q (%s): %w", cephClusterName, server.Name, server.GetConnectionURL(), domain.ErrOperationNotPermitted)
+		}
+
+		cephConfigs[server.Name] = cephConfig
+	}
+
+	// Perform change on all servers.
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	for _, server := range servers {
+		currentCephConfig := cephConfigs[server.Name]
+
+		cephClusters := maps.Clone(currentCephConfig.Config.Clusters)
+		delete(cephClusters, cephClusterName)
+
+		updatedCephConfig := incusosapi.ServiceCeph{
+			Config: incusosapi.ServiceCephConfig{
+				Enabled:  currentCephConfig.Config.Enabled,
+				Clusters: cephClusters,
+			},
+		}
+
+		err = s.client.UpdateOSService(ctx, server, "ceph", updatedCephConfig)
+		if err != nil {
+			return fmt.Errorf("Failed to update ceph service config on server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		reverter.Add(func() {
+			revertErr := s.client.UpdateOSService(ctx, server, "ceph", currentCephConfig)
+			if revertErr != nil {
+				// The service config contains the keyrings, it is therefore not logged.
+				slog.ErrorContext(ctx, "Failed to revert previously updated ceph service config", logger.Err(revertErr), slog.String("server", server.Name), slog.String("connection_url", server.GetConnectionURL()), slog.String("ceph_cluster", cephClusterName), slog.Any("root_cause", err))
+			}
+		})
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// UpdateServiceOVN applies the given ovn service config to all the members of
+// the cluster.
+//
+// The tunnel address is member dependent. If it is empty, the tunnel address
+// of each member is kept as is. Otherwise only a wildcard address is accepted.
+func (s *clusterService) UpdateServiceOVN(ctx context.Context, clusterName string, ovnConfig incusosapi.ServiceOVNConfig) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Update ovn service config on cluster members for %q failed: %w", clusterName, err)
+		}
+	}()
+
+	if ovnConfig.TunnelAddress != "" {
+		tunnelIP := net.ParseIP(ovnConfig.TunnelAddress)
+		if tunnelIP == nil || !tunnelIP.IsUnspecified() {
+			return domain.NewValidationErrf("Tunnel address %q is member dependent, only a wildcard address can be applied to all cluster members", ovnConfig.TunnelAddress)
+		}
+	}
+
+	servers, err := s.prepareBulkUpdate(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	ovnConfigs := make(map[string]incusosapi.ServiceOVN, len(servers))
+	for _, server := range servers {
+		var currentOVNConfig incusosapi.ServiceOVN
+		currentOVNConfig, err = s.client.GetOSServiceOVN(ctx, server)
+		if err != nil {
+			return fmt.Errorf("Failed to get ovn service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		ovnConfigs[server.Name] = currentOVNConfig
+	}
+
+	// Perform change on all servers.
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	for _, server := range servers {
+		currentOVNConfig := ovnConfigs[server.Name]
+
+		serverOVNConfig := ovnConfig
+		if serverOVNConfig.TunnelAddress == "" {
+			serverOVNConfig.TunnelAddress = currentOVNConfig.Config.TunnelAddress
+		}
+
+		err = s.client.UpdateOSService(ctx, server, "ovn", incusosapi.ServiceOVN{Config: serverOVNConfig})
+		if err != nil {
+			return fmt.Errorf("Failed to update ovn service config on server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		reverter.Add(func() {
+			revertErr := s.client.UpdateOSService(ctx, server, "ovn", currentOVNConfig)
+			if revertErr != nil {
+				// The service config contains the TLS client key, it is therefore not logged.
+				slog.ErrorContext(ctx, "Failed to revert previously updated ovn service config", logger.Err(revertErr), slog.String("server", server.Name), slog.String("connection_url", server.GetConnectionURL()), slog.Any("root_cause", err))
+			}
+		})
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// UpdateServiceLinstor applies the given linstor service config to all the
+// members of the cluster.
+//
+// The listen address is member dependent. If it is empty, the listen address
+// of each member is kept as is. Otherwise only a wildcard address is accepted.
+func (s *clusterService) UpdateServiceLinstor(ctx context.Context, clusterName string, linstorConfig incusosapi.ServiceLinstorConfig) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("Update linstor service config on cluster members for %q failed: %w", clusterName, err)
+		}
+	}()
+
+	if linstorConfig.ListenAddress != "" {
+		var host string
+		host, _, err = net.SplitHostPort(linstorConfig.ListenAddress)
+		if err != nil {
+			return domain.NewValidationErrf("Invalid listen address %q: %v", linstorConfig.ListenAddress, err)
+		}
+
+		listenIP := net.ParseIP(host)
+		if listenIP == nil || !listenIP.IsUnspecified() {
+			return domain.NewValidationErrf("Listen address %q is member dependent, only a wildcard address can be applied to all cluster members", linstorConfig.ListenAddress)
+		}
+	}
+
+	servers, err := s.prepareBulkUpdate(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	linstorConfigs := make(map[string]incusosapi.ServiceLinstor, len(servers))
+	for _, server := range servers {
+		var currentLinstorConfig incusosapi.ServiceLinstor
+		currentLinstorConfig, err = s.client.GetOSServiceLinstor(ctx, server)
+		if err != nil {
+			return fmt.Errorf("Failed to get linstor service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		linstorConfigs[server.Name] = currentLinstorConfig
+	}
+
+	// Perform change on all servers.
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	for _, server := range servers {
+		currentLinstorConfig := linstorConfigs[server.Name]
+
+		serverLinstorConfig := linstorConfig
+		if serverLinstorConfig.ListenAddress == "" {
+			serverLinstorConfig.ListenAddress = currentLinstorConfig.Config.ListenAddress
+		}
+
+		err = s.client.UpdateOSService(ctx, server, "linstor", incusosapi.ServiceLinstor{Config: serverLinstorConfig})
+		if err != nil {
+			return fmt.Errorf("Failed to update linstor service config on server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+		}
+
+		reverter.Add(func() {
+			revertErr := s.client.UpdateOSService(ctx, server, "linstor", currentLinstorConfig)
+			if revertErr != nil {
+				slog.ErrorContext(ctx, "Failed to revert previously updated linstor service config", logger.Err(revertErr), slog.String("server", server.Name), slog.String("connection_url", server.GetConnectionURL()), slog.Any("root_cause", err))
+			}
+		})
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
 func (s *clusterService) prepareBulkUpdate(ctx context.Context, clusterName string) (provisioning.Servers, error) {
 	cluster, err := s.GetByName(ctx, clusterName)
 	if err != nil {
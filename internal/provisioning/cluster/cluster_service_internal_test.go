@@ -906,6 +906,33 @@ func Test_clusterUpdateState_doesNotReorderServers(t *testing.T) {
 // updating its applications. api.Server.UpdateState reports "undefined" for it.
 const serverUpdateStateUpdatingApplication = api.ServerUpdateState("updating application")
 
+func Test_clusterUpdateServerStates(t *testing.T) {
+	servers := provisioning.Servers{
+		clusterUpdateStateTestServer(t, "serverA", api.ServerUpdateStateUpToDate),
+		clusterUpdateStateTestServer(t, "serverB", api.ServerUpdateStateEvacuating),
+		clusterUpdateStateTestServer(t, "serverC", api.ServerUpdateStateInMaintenanceRebooting),
+		clusterUpdateStateTestServer(t, "serverD", api.ServerUpdateStateInMaintenanceRestorePending),
+		clusterUpdateStateTestServer(t, "serverE", api.ServerUpdateStateEvacuationPending),
+	}
+
+	inProgressStatus := api.ClusterUpdateInProgressStatus{
+		InProgress:      api.ClusterUpdateInProgressRollingRestart,
+		EvacuatedBefore: []string{"serverD"},
+	}
+
+	got := clusterUpdateServerStates(inProgressStatus, servers)
+
+	require.Equal(t, map[string]api.ServerUpdateState{
+		"serverB": api.ServerUpdateStateEvacuating,
+		"serverC": api.ServerUpdateStateInMaintenanceRebooting,
+		"serverE": api.ServerUpdateStateEvacuationPending,
+	}, got)
+
+	inProgressStatus.InProgress = api.ClusterUpdateInProgressError
+
+	require.Nil(t, clusterUpdateServerStates(inProgressStatus, servers))
+}
+
 func clusterUpdateStateTestServer(t *testing.T, name string, state api.ServerUpdateState) provisioning.Server {
 	t.Helper()
 
@@ -1022,16 +1049,19 @@ func TestClusterService_getClusterUpdateStatus_progressOnlyMovesForward(t *testi
 
 	got := make([]string, 0, len(serverStates))
 	for range serverStates {
-		clusterUpdateStatus := api.ClusterUpdateStatus{
-			InProgressStatus: api.ClusterUpdateInProgressStatus{
-				InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+		cluster := provisioning.Cluster{
+			Name: "clusterA",
+			UpdateStatus: api.ClusterUpdateStatus{
+				InProgressStatus: api.ClusterUpdateInProgressStatus{
+					InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+				},
 			},
 		}
 
-		err := clusterSvc.getClusterUpdateStatus(ctx, "clusterA", &clusterUpdateStatus)
+		err := clusterSvc.getClusterUpdateStatus(ctx, &cluster)
 		require.NoError(t, err)
 
-		got = append(got, ptr.From(clusterUpdateStatus.InProgressStatus.StatusDescription))
+		got = append(got, ptr.From(cluster.UpdateStatus.InProgressStatus.StatusDescription))
 	}
 
 	require.Equal(t, []string{
@@ -1057,20 +1087,121 @@ func TestClusterService_getClusterUpdateStatus_progressOnlyMovesForward(t *testi
 		},
 	}
 
-	inactive := api.ClusterUpdateStatus{}
-	err := clusterSvc.getClusterUpdateStatus(ctx, "clusterA", &inactive)
+	inactive := provisioning.Cluster{
+		Name: "clusterA",
+	}
+
+	err := clusterSvc.getClusterUpdateStatus(ctx, &inactive)
 	require.NoError(t, err)
-	require.Nil(t, inactive.InProgressStatus.StatusDescription)
+	require.Nil(t, inactive.UpdateStatus.InProgressStatus.StatusDescription)
 
-	relaunched := api.ClusterUpdateStatus{
-		InProgressStatus: api.ClusterUpdateInProgressStatus{
-			InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+	relaunched := provisioning.Cluster{
+		Name: "clusterA",
+		UpdateStatus: api.ClusterUpdateStatus{
+			InProgressStatus: api.ClusterUpdateInProgressStatus{
+				InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			},
 		},
 	}
 
-	err = clusterSvc.getClusterUpdateStatus(ctx, "clusterA", &relaunched)
+	err = clusterSvc.getClusterUpdateStatus(ctx, &relaunched)
 	require.NoError(t, err)
-	require.Equal(t, `[ 1/27] update pending server "serverA"`, ptr.From(relaunched.InProgressStatus.StatusDescription))
+	require.Equal(t, `[ 1/27] update pending server "serverA"`, ptr.From(relaunched.UpdateStatus.InProgressStatus.StatusDescription))
+}
+
+func TestClusterService_getClusterUpdateStatus_waitingForMaintenanceWindow(t *testing.T) {
+	// Saturday, 12:00 UTC.
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		serverState api.ServerUpdateState
+		windows     []api.ClusterConfigMaintenanceWindow
+
+		wantStatusDescription string
+	}{
+		{
+			name:        "no maintenance windows",
+			serverState: api.ServerUpdateStateEvacuationPending,
+
+			wantStatusDescription: `[ 5/18] evacuation pending server "serverA"`,
+		},
+		{
+			name:        "within maintenance window",
+			serverState: api.ServerUpdateStateEvacuationPending,
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"saturday"},
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+
+			wantStatusDescription: `[ 5/18] evacuation pending server "serverA"`,
+		},
+		{
+			name:        "outside maintenance window - safe point",
+			serverState: api.ServerUpdateStateEvacuationPending,
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"sunday"},
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+
+			wantStatusDescription: `[ 5/18] waiting for maintenance window, next: evacuation pending server "serverA"`,
+		},
+		{
+			name:        "outside maintenance window - server in maintenance",
+			serverState: api.ServerUpdateStateInMaintenanceRebootPending,
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"sunday"},
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+
+			wantStatusDescription: `[ 7/18] in maintenance, reboot pending server "serverA"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return provisioning.Servers{
+						clusterUpdateStateTestServer(t, "serverA", tc.serverState),
+						clusterUpdateStateTestServer(t, "serverB", api.ServerUpdateStateEvacuationPending),
+					}, nil
+				},
+			}
+
+			clusterSvc := New(nil, nil, nil, serverSvc, nil, nil, nil, nil,
+				WithNow(func() time.Time {
+					return now
+				}),
+			)
+
+			cluster := provisioning.Cluster{
+				Name: "clusterA",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: tc.windows,
+				},
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressRollingRestart,
+					},
+				},
+			}
+
+			err := clusterSvc.getClusterUpdateStatus(context.Background(), &cluster)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantStatusDescription, ptr.From(cluster.UpdateStatus.InProgressStatus.StatusDescription))
+		})
+	}
 }
 
 func TestClusterService_markServerRebooted(t *testing.T) {
@@ -1154,3 +1285,304 @@ func TestClusterService_markServerRebooted(t *testing.T) {
 		})
 	}
 }
+
+func Test_clusterUpdatePlan(t *testing.T) {
+	servers := provisioning.Servers{
+		{
+			Name: "serverA",
+			VersionData: api.ServerVersionData{
+				OS: api.OSVersionData{
+					Name:             "IncusOS",
+					Version:          "202601010000",
+					AvailableVersion: ptr.To("202602010000"),
+					NeedsUpdate:      ptr.To(true),
+				},
+				Applications: []api.ApplicationVersionData{
+					{
+						Name:             "incus",
+						Version:          "202601010000",
+						AvailableVersion: ptr.To("202602010000"),
+						NeedsUpdate:      ptr.To(true),
+					},
+				},
+				NeedsUpdate: ptr.To(true),
+			},
+		},
+		{
+			Name: "serverB",
+			VersionData: api.ServerVersionData{
+				OS: api.OSVersionData{
+					Name:             "IncusOS",
+					Version:          "202602010000",
+					AvailableVersion: ptr.To("202602010000"),
+					NeedsUpdate:      ptr.To(false),
+				},
+				NeedsUpdate: ptr.To(false),
+				NeedsReboot: ptr.To(false),
+			},
+		},
+		{
+			Name: "serverC",
+			VersionData: api.ServerVersionData{
+				OS: api.OSVersionData{
+					Name:    "IncusOS",
+					Version: "202602010000",
+				},
+				NeedsUpdate: ptr.To(false),
+				NeedsReboot: ptr.To(true),
+			},
+		},
+	}
+
+	instances := map[string][]api.ClusterUpdatePlanInstance{
+		"serverA": {
+			{
+				Name:    "c1",
+				Project: "default",
+				Status:  "Running",
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		operation       api.ClusterUpdateInProgress
+		evacuatedBefore []string
+		schedule        clusterUpdatePlanSchedule
+
+		wantSteps                []api.ClusterUpdatePlanStep
+		wantServerAOSTarget      string
+		wantServerAIncusTarget   string
+		wantServerAEvacuatedFlag bool
+	}{
+		{
+			name:      "update without reboot",
+			operation: api.ClusterUpdateInProgressApplyUpdate,
+			schedule: clusterUpdatePlanSchedule{
+				maxUnavailable:      1,
+				inMaintenanceWindow: true,
+			},
+
+			wantSteps: []api.ClusterUpdatePlanStep{
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionUpdate, Batch: 1},
+			},
+			wantServerAOSTarget:    "202602010000",
+			wantServerAIncusTarget: "202602010000",
+		},
+		{
+			name:      "update with reboot",
+			operation: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			schedule: clusterUpdatePlanSchedule{
+				maxUnavailable:      1,
+				inMaintenanceWindow: true,
+			},
+
+			wantSteps: []api.ClusterUpdatePlanStep{
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionUpdate, Batch: 1},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 2},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionReboot, Batch: 2},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionRestore, Batch: 2},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 3},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionReboot, Batch: 3},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionRestore, Batch: 3},
+			},
+			wantServerAOSTarget:    "202602010000",
+			wantServerAIncusTarget: "202602010000",
+		},
+		{
+			name:      "update with reboot - max unavailable 2 and maintenance windows",
+			operation: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			schedule: clusterUpdatePlanSchedule{
+				maxUnavailable:     2,
+				maintenanceWindows: true,
+			},
+
+			wantSteps: []api.ClusterUpdatePlanStep{
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionUpdate, Batch: 1, WaitForMaintenanceWindow: true},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 2, WaitForMaintenanceWindow: true},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionReboot, Batch: 2},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionRestore, Batch: 2},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 2, WaitForMaintenanceWindow: true},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionReboot, Batch: 2},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionRestore, Batch: 2},
+			},
+			wantServerAOSTarget:    "202602010000",
+			wantServerAIncusTarget: "202602010000",
+		},
+		{
+			name:            "rolling reboot with server evacuated before",
+			operation:       api.ClusterUpdateInProgressRollingReboot,
+			evacuatedBefore: []string{"serverA"},
+			schedule: clusterUpdatePlanSchedule{
+				maxUnavailable:      1,
+				inMaintenanceWindow: true,
+			},
+
+			wantSteps: []api.ClusterUpdatePlanStep{
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionEvacuate, Skipped: true, Reason: "Server has been evacuated before the operation", Batch: 1},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionReboot, Batch: 1},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionRestore, Skipped: true, Reason: "Server is kept evacuated, since it has been evacuated before the operation", Batch: 1},
+				{Server: "serverB", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 2},
+				{Server: "serverB", Action: api.ClusterUpdatePlanActionReboot, Batch: 2},
+				{Server: "serverB", Action: api.ClusterUpdatePlanActionRestore, Batch: 2},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 3},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionReboot, Batch: 3},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionRestore, Batch: 3},
+			},
+			wantServerAOSTarget:      "202601010000",
+			wantServerAIncusTarget:   "202601010000",
+			wantServerAEvacuatedFlag: true,
+		},
+		{
+			name:            "rolling reboot - max unavailable 2 and maintenance windows with server evacuated before",
+			operation:       api.ClusterUpdateInProgressRollingReboot,
+			evacuatedBefore: []string{"serverA"},
+			schedule: clusterUpdatePlanSchedule{
+				maxUnavailable:      2,
+				maintenanceWindows:  true,
+				inMaintenanceWindow: true,
+			},
+
+			wantSteps: []api.ClusterUpdatePlanStep{
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionEvacuate, Skipped: true, Reason: "Server has been evacuated before the operation", Batch: 1},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionReboot, Batch: 1},
+				{Server: "serverA", Action: api.ClusterUpdatePlanActionRestore, Skipped: true, Reason: "Server is kept evacuated, since it has been evacuated before the operation", Batch: 1},
+				{Server: "serverB", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 1, WaitForMaintenanceWindow: true},
+				{Server: "serverB", Action: api.ClusterUpdatePlanActionReboot, Batch: 1},
+				{Server: "serverB", Action: api.ClusterUpdatePlanActionRestore, Batch: 1},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 2, WaitForMaintenanceWindow: true},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionReboot, Batch: 2},
+				{Server: "serverC", Action: api.ClusterUpdatePlanActionRestore, Batch: 2},
+			},
+			wantServerAOSTarget:      "202601010000",
+			wantServerAIncusTarget:   "202601010000",
+			wantServerAEvacuatedFlag: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := clusterUpdatePlan(tc.operation, servers, tc.evacuatedBefore, instances, tc.schedule)
+
+			require.Equal(t, tc.operation, plan.Operation)
+			require.Equal(t, tc.schedule.inMaintenanceWindow, plan.InMaintenanceWindow)
+			require.Equal(t, tc.wantSteps, plan.Steps)
+			require.Len(t, plan.Servers, len(servers))
+
+			serverA := plan.Servers[0]
+			require.Equal(t, "serverA", serverA.Name)
+			require.Equal(t, tc.wantServerAEvacuatedFlag, serverA.EvacuatedBefore)
+			require.Equal(t, "202601010000", serverA.OS.CurrentVersion)
+			require.Equal(t, tc.wantServerAOSTarget, serverA.OS.TargetVersion)
+			require.Len(t, serverA.Applications, 1)
+			require.Equal(t, tc.wantServerAIncusTarget, serverA.Applications[0].TargetVersion)
+			require.Equal(t, instances["serverA"], serverA.Instances)
+
+			require.Empty(t, plan.Servers[1].Instances)
+			require.NotNil(t, plan.Servers[1].Instances)
+		})
+	}
+}
+
+func Test_parseMemberSpecificServerConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+
+		assertErr require.ErrorAssertionFunc
+		want      map[string]string
+	}{
+		{
+			name: "success",
+			src: `resource "incus_server" "this_per_node" {
+  for_each = local.members
+
+  config = {
+    "storage.backups_volume" = "local/backups"
+    "storage.logs_volume"    = "local/logs"
+  }
+
+  target = each.key
+}
+
+resource "incus_server" "this" {
+  config = {
+    "core.https_address" = ":8443"
+  }
+}
+`,
+
+			assertErr: require.NoError,
+			want: map[string]string{
+				"storage.backups_volume": "local/backups",
+				"storage.logs_volume":    "local/logs",
+			},
+		},
+		{
+			name: "success - empty config",
+			src: `resource "incus_server" "this_per_node" {
+  for_each = local.members
+
+  config = {
+  }
+
+  target = each.key
+}
+`,
+
+			assertErr: require.NoError,
+			want:      map[string]string{},
+		},
+		{
+			name: "success - no per node resource",
+			src: `resource "incus_server" "this" {
+  config = {
+    "core.https_address" = ":8443"
+  }
+}
+`,
+
+			assertErr: require.NoError,
+			want:      map[string]string{},
+		},
+		{
+			name: "error - invalid syntax",
+			src:  `resource "incus_server" "this_per_node" {`,
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - config is not a map",
+			src: `resource "incus_server" "this_per_node" {
+  config = "invalid"
+}
+`,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, "Config of the per node server resource is not a map")
+			},
+		},
+		{
+			name: "error - config value is not a string",
+			src: `resource "incus_server" "this_per_node" {
+  config = {
+    "storage.backups_volume" = ["local/backups"]
+  }
+}
+`,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, `Value of config key "storage.backups_volume" of the per node server resource is not a string`)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := parseMemberSpecificServerConfig([]byte(tc.src))
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, config)
+		})
+	}
+}
@@ -154,6 +154,24 @@ func rebootOnlyServerClient(world *serverWorld) *adapterMock.ServerClientPortMoc
 	}
 }
 
+// rebootOnlyBMCClient returns a BMC client mock, which records the firmware
+// updates triggered during a rolling firmware update in the given world.
+func rebootOnlyBMCClient(world *serverWorld) *adapterMock.BMCServerClientPortMock {
+	return &adapterMock.BMCServerClientPortMock{
+		UpdateFirmwareFromURIFunc: func(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+			world.recordFirmwareUpdate(server.Name)
+
+			return nil, nil
+		},
+		WaitForTaskFunc: func(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error {
+			return nil
+		},
+		GetDataFunc: func(ctx context.Context, server provisioning.Server) (api.BMCData, error) {
+			return api.BMCData{}, nil
+		},
+	}
+}
+
 // rebootOnlyWorld returns a world, which reports what the given servers have been
 // registered with, minus the fields, that are calculated during polling.
 func rebootOnlyWorld(servers ...provisioning.Server) *serverWorld {
@@ -265,6 +283,7 @@ func setupRebootOnlyCluster(t *testing.T, ctx context.Context, listenerName stri
 	serverSvc := provisioningServer.New(
 		serverDB, rebootOnlyServerClient(world), nil, nil, nil, channelSvc, updateSvc, tls.Certificate{},
 		provisioningServer.WithRebootStatusUpdateGracePeriod(0),
+		provisioningServer.AddBMCServerClient(api.BMCAPITypeRedfishV1Generic, rebootOnlyBMCClient(world)),
 	)
 
 	clusterSvc := provisioningCluster.New(
@@ -511,3 +530,84 @@ func TestClusterService_LaunchClusterRebootRejectsSecondRun(t *testing.T) {
 	require.Equal(t, api.ClusterUpdateInProgressInactive, c.UpdateStatus.InProgressStatus.InProgress)
 	require.Empty(t, c.UpdateStatus.InProgressStatus.PendingReboot)
 }
+
+func TestClusterService_ClusterRollingFirmwareUpdateControlLoopMultiNodeCluster(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), asyncActionsDelay*100)
+	defer cancel()
+
+	withBMC := func(server provisioning.Server) provisioning.Server {
+		server.BMCConfig = api.BMCConfig{
+			APIType: api.BMCAPITypeRedfishV1Generic,
+		}
+
+		return server
+	}
+
+	clusterSvc, world, logBuf := setupRebootOnlyCluster(
+		t, ctx, "ClusterRollingFirmwareUpdateCycleMultiNodeCluster",
+		withBMC(rebootOnlyServer(t, "serverA")),
+		withBMC(rebootOnlyServer(t, "serverB")),
+	)
+
+	err := clusterSvc.LaunchClusterFirmwareUpdate(ctx, "clusterA", api.BMCFirmwareUpdatePost{
+		ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+	})
+	require.NoError(t, err)
+
+	c, err := clusterSvc.GetByName(ctx, "clusterA")
+	require.NoError(t, err)
+	require.Equal(t, api.ClusterUpdateInProgressRollingFirmwareUpdate, c.UpdateStatus.InProgressStatus.InProgress)
+	require.Equal(t, []string{"serverA", "serverB"}, c.UpdateStatus.InProgressStatus.PendingReboot)
+	require.Equal(t, []string{"serverA", "serverB"}, c.UpdateStatus.InProgressStatus.PendingFirmwareUpdate)
+	require.Equal(t, &api.BMCFirmwareUpdatePost{
+		ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+		Method:   api.BMCFirmwareUpdateMethodSimpleUpdate,
+	}, c.UpdateStatus.InProgressStatus.FirmwareUpdate)
+
+	observed := driveRebootToCompletion(t, ctx, clusterSvc, world, 200)
+
+	requireProgressOnlyMovesForward(t, observed)
+
+	// The firmware is updated on one server at a time, while the server is
+	// evacuated.
+	require.Equal(t, []string{
+		"serverA: evacuated",
+		"serverB: evacuated",
+	}, world.getFirmwareUpdates())
+
+	require.Equal(t, []string{
+		`[ 1/14] evacuation pending server "serverA"`,
+		`[ 2/14] evacuating server "serverA"`,
+		`[ 3/14] in maintenance, reboot pending server "serverA"`,
+		`[ 4/14] in maintenance, rebooting server "serverA"`,
+		`[ 5/14] in maintenance, restore pending server "serverA"`,
+		`[ 6/14] restoring server "serverA"`,
+		`[ 7/14] post restore server "serverA"`,
+		`[ 8/14] evacuation pending server "serverB"`,
+		`[ 9/14] evacuating server "serverB"`,
+		`[10/14] in maintenance, reboot pending server "serverB"`,
+		`[11/14] in maintenance, rebooting server "serverB"`,
+		`[12/14] in maintenance, restore pending server "serverB"`,
+		`[13/14] restoring server "serverB"`,
+		`[14/14] post restore server "serverB"`,
+	}, clusterUpdateStatesFromLog(t, logBuf.String()))
+}
+
+func TestClusterService_LaunchClusterFirmwareUpdateRejectsServerWithoutBMC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), asyncActionsDelay*50)
+	defer cancel()
+
+	clusterSvc, _, _ := setupRebootOnlyCluster(t, ctx, "ClusterRollingFirmwareUpdateRejectsServerWithoutBMC", rebootOnlyServer(t, "one"))
+
+	err := clusterSvc.LaunchClusterFirmwareUpdate(ctx, "clusterA", api.BMCFirmwareUpdatePost{
+		ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+	})
+	var verr domain.ErrValidation
+	require.ErrorAs(t, err, &verr)
+	require.ErrorContains(t, err, `Cluster firmware update can not be launched for "clusterA": Server "one" (https://one/) does not have a BMC configured`)
+
+	c, err := clusterSvc.GetByName(ctx, "clusterA")
+	require.NoError(t, err)
+	require.Equal(t, api.ClusterUpdateInProgressInactive, c.UpdateStatus.InProgressStatus.InProgress)
+	require.Empty(t, c.UpdateStatus.InProgressStatus.PendingFirmwareUpdate)
+}
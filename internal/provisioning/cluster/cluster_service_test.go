@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	incusosapi "github.com/lxc/incus-os/incus-osd/api"
+	"github.com/lxc/incus-os/incus-osd/api/images"
 	incusclient "github.com/lxc/incus/v7/client"
 	incusapi "github.com/lxc/incus/v7/shared/api"
 	incustls "github.com/lxc/incus/v7/shared/tls"
@@ -35,7 +40,9 @@ import (
 	"github.com/FuturFusion/operations-center/internal/util/testing/log"
 	"github.com/FuturFusion/operations-center/internal/util/testing/queue"
 	"github.com/FuturFusion/operations-center/internal/util/testing/uuidgen"
+	"github.com/FuturFusion/operations-center/internal/warning"
 	"github.com/FuturFusion/operations-center/shared/api"
+	"github.com/FuturFusion/operations-center/shared/api/system"
 )
 
 func TestClusterService_Create(t *testing.T) {
@@ -71,6 +78,9 @@ func TestClusterService_Create(t *testing.T) {
 		provisionerApply                                  []queue.Item[struct{}]
 		provisionerInitErr                                error
 		provisionerSeedCertificateErr                     error
+		clusterProvisioningBackend                        string
+		incusProvisionerUnavailable                       bool
+		incusProvisionerInitErr                           error
 		inventorySyncerSyncClusterErr                     error
 
 		assertErr     require.ErrorAssertionFunc
@@ -2198,6 +2208,240 @@ func TestClusterService_Create(t *testing.T) {
 			assertErr:     boom.ErrorIs,
 			signalHandler: requireNoCallSignalHandler,
 		},
+		{
+			name: "error - incus provisioner.Init",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerType:  api.ServerTypeIncus,
+				ServerNames: []string{"server1", "server2"},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Value: &provisioning.Server{
+						Name:    "server1",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+						OSData: api.OSData{
+							Network: incusosapi.SystemNetwork{
+								State: incusosapi.SystemNetworkState{
+									Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+										"eth0": {
+											Addresses: []string{
+												"192.168.0.100",
+											},
+											Roles: []string{
+												"management",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: &provisioning.Server{
+						Name:    "server2",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+						OSData: api.OSData{
+							Network: incusosapi.SystemNetwork{
+								State: incusosapi.SystemNetworkState{
+									Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+										"eth0": {
+											Addresses: []string{
+												"192.168.0.100",
+											},
+											Roles: []string{
+												"management",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: &provisioning.Server{
+						Name:    "server1",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: &provisioning.Server{
+						Name:    "server2",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+			},
+			clientSetServerConfig: []queue.Item[struct{}]{
+				{}, // Server 1
+				{}, // Server 2
+			},
+			clientEnableClusterCertificate: "certificate",
+			clusterProvisioningBackend:     system.ClusterProvisioningBackendIncus,
+			incusProvisionerInitErr:        boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name: "error - incus provisioning backend not available",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerType:  api.ServerTypeIncus,
+				ServerNames: []string{"server1", "server2"},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Value: &provisioning.Server{
+						Name:    "server1",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+						OSData: api.OSData{
+							Network: incusosapi.SystemNetwork{
+								State: incusosapi.SystemNetworkState{
+									Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+										"eth0": {
+											Addresses: []string{
+												"192.168.0.100",
+											},
+											Roles: []string{
+												"management",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: &provisioning.Server{
+						Name:    "server2",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+						OSData: api.OSData{
+							Network: incusosapi.SystemNetwork{
+								State: incusosapi.SystemNetworkState{
+									Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+										"eth0": {
+											Addresses: []string{
+												"192.168.0.100",
+											},
+											Roles: []string{
+												"management",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: &provisioning.Server{
+						Name:    "server1",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: &provisioning.Server{
+						Name:    "server2",
+						Type:    api.ServerTypeIncus,
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+			},
+			clientSetServerConfig: []queue.Item[struct{}]{
+				{}, // Server 1
+				{}, // Server 2
+			},
+			clientEnableClusterCertificate: "certificate",
+			clusterProvisioningBackend:     system.ClusterProvisioningBackendIncus,
+			incusProvisionerUnavailable:    true,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, `Cluster provisioning backend "incus" is not available`)
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
 		{
 			name: "error - provisioner.Apply",
 			cluster: provisioning.Cluster{
@@ -3382,6 +3626,22 @@ func TestClusterService_Create(t *testing.T) {
 				},
 			}
 
+			incusProvisioner := &adapterMock.ClusterProvisioningPortMock{
+				InitFunc: func(ctx context.Context, clusterName string, config provisioning.ClusterProvisioningConfig) (string, func() error, error) {
+					return "", func() error { return nil }, tc.incusProvisionerInitErr
+				},
+			}
+
+			var opts []provisioningCluster.Option
+			if !tc.incusProvisionerUnavailable {
+				opts = append(opts, provisioningCluster.WithClusterProvisioner(system.ClusterProvisioningBackendIncus, incusProvisioner))
+			}
+
+			err := config.UpdateSettings(t.Context(), system.SettingsPut{
+				ClusterProvisioningBackend: tc.clusterProvisioningBackend,
+			})
+			require.NoError(t, err)
+
 			inventorySyncer := &serviceMock.InventorySyncerMock{
 				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
 					return tc.inventorySyncerSyncClusterErr
@@ -3403,15 +3663,17 @@ func TestClusterService_Create(t *testing.T) {
 				map[domain.ResourceType]provisioning.InventorySyncer{domain.ResourceTypeImage: inventorySyncer},
 				provisioner,
 				nil,
-				provisioningCluster.WithCreateRetryTimeout(0),
-				provisioningCluster.WithCreateClusterCertificateNotBeforeDelay(0),
+				append(opts,
+					provisioningCluster.WithCreateRetryTimeout(0),
+					provisioningCluster.WithCreateClusterCertificateNotBeforeDelay(0),
+				)...,
 			)
 
 			var signalHandlerCalled bool
 			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
 
 			// Run test
-			_, err := clusterSvc.Create(context.Background(), tc.cluster)
+			_, err = clusterSvc.Create(context.Background(), tc.cluster)
 
 			// Assert
 			tc.assertErr(t, err)
@@ -3423,808 +3685,480 @@ func TestClusterService_Create(t *testing.T) {
 	}
 }
 
-type osServiceConfig struct {
-	serverName string
-	name       string
-	config     any
-}
+func TestClusterService_Adopt(t *testing.T) {
+	config.InitTest(t, &envMock.EnvironmentMock{}, nil)
 
-func readyServerForClustering(id int64, name string) *provisioning.Server {
-	return &provisioning.Server{
-		ID:      id,
-		Name:    name,
-		Status:  api.ServerStatusReady,
-		Channel: "stable",
-		VersionData: api.ServerVersionData{
-			NeedsUpdate:   ptr.To(false),
-			NeedsReboot:   ptr.To(false),
-			InMaintenance: ptr.To(api.NotInMaintenance),
-			OS: api.OSVersionData{
-				Name:    "os",
-				Version: "1",
-			},
-			Applications: []api.ApplicationVersionData{
-				{
-					Name:    "incus",
-					Version: "1",
-				},
-			},
-		},
-	}
-}
+	clusterCertificate := &x509.Certificate{Raw: []byte("cluster")}
 
-func TestClusterService_AddServers(t *testing.T) {
 	tests := []struct {
-		name                                  string
-		argServerNames                        []string
-		argSkipPostJoinOperations             bool
-		argCopyServicesConfig                 bool
-		repoGetByName                         *provisioning.Cluster
-		repoGetByNameErr                      error
-		serverSvcGetByName                    []queue.Item[*provisioning.Server]
-		serverSvcGetAllWithFilter             provisioning.Servers
-		serverSvcGetAllWithFilterErr          error
-		clientGetOSServiceLVM                 []queue.Item[incusosapi.ServiceLVM]
-		clientGetOSServiceISCSI               []queue.Item[incusosapi.ServiceISCSI]
-		clientGetOSServiceMultipath           []queue.Item[incusosapi.ServiceMultipath]
-		clientGetOSServiceNVME                []queue.Item[incusosapi.ServiceNVME]
-		clientGetOSServiceCeph                []queue.Item[incusosapi.ServiceCeph]
-		clientGetOSServiceLinstor             []queue.Item[incusosapi.ServiceLinstor]
-		clientGetOSServiceOVN                 []queue.Item[incusosapi.ServiceOVN]
-		clientUpdateOSServiceErrs             queue.Errs
-		clientGetNetworkConfigErr             error
-		clientIncusClientErr                  queue.Errs
-		incusClientGetCluster                 *incusapi.Cluster
-		incusClientGetClusterErr              error
-		incusClientGetStoragePool             *incusapi.StoragePool
-		incusClientGetStoragePoolErr          error
-		incusClientGetNetwork                 *incusapi.Network
-		incusClientGetNetworkErr              error
-		incusClientCreateStoragePoolVolumeErr error
-		clientGetClusterJoinTokenErr          error
-		clientJoinClusterErr                  error
-		clientGetOSData                       api.OSData
-		clientGetOSDataErr                    error
-		clientSetServerConfigErr              error
-		serverSvcUpdateErr                    error
+		name                          string
+		cluster                       provisioning.Cluster
+		repoExistsByName              bool
+		repoExistsByNameErr           error
+		repoCreateErr                 error
+		clientGetRemoteCertificate    []queue.Item[*x509.Certificate]
+		clientGetClusterNodeNames     []string
+		clientGetClusterNodeNamesErr  error
+		serverSvcGetByName            []queue.Item[*provisioning.Server]
+		serverSvcUpdateErr            error
+		inventorySyncerSyncClusterErr error
 
-		assertErr           require.ErrorAssertionFunc
-		wantOSServiceConfig []osServiceConfig
+		assertErr     require.ErrorAssertionFunc
+		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
 	}{
 		{
-			name:           "success",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "success",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1", "server2"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
-				// Before update.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
-			},
-			incusClientGetCluster: &incusapi.Cluster{
-				MemberConfig: []incusapi.ClusterMemberConfigKey{
-					{
-						Entity: "storage-pool",
-						Name:   "local",
-						Key:    "source",
-					},
-					{
-						Entity: "network",
-						Name:   "incusbr0",
-						Key:    "nic",
-					},
-				},
-			},
-			incusClientGetStoragePool: &incusapi.StoragePool{
-				StoragePoolPut: incusapi.StoragePoolPut{
-					Config: incusapi.ConfigMap{
-						"source": "incus",
-					},
-				},
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(2, "server2")},
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(2, "server2")},
 			},
-			incusClientGetNetwork: &incusapi.Network{
-				NetworkPut: incusapi.NetworkPut{
-					Config: incusapi.ConfigMap{
-						"nic": "eth0",
-					},
-				},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
+				{Value: clusterCertificate},
 			},
+			clientGetClusterNodeNames: []string{"server2", "server1"},
 
-			assertErr: require.NoError,
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
 		},
 		{
-			name:                      "success - skipPostJoinOperations",
-			argServerNames:            []string{"new"},
-			argSkipPostJoinOperations: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "success - inventory sync failed",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
-				// Before update.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(1, "server1")},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
+			clientGetClusterNodeNames:     []string{"server1"},
+			inventorySyncerSyncClusterErr: boom.Error,
 
-			assertErr: require.NoError,
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
 		},
-
 		{
-			name:             "error - repo.GetByName",
-			repoGetByNameErr: boom.Error,
+			name: "error - validation",
+			cluster: provisioning.Cluster{
+				Name:        "", // invalid
+				ServerNames: []string{"server1"},
+			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:           "error - no additional servers provided to join the cluster",
-			argServerNames: []string{}, // no servers provided
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - validation empty server names",
+			cluster: provisioning.Cluster{
+				Name: "one",
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, "Empty list of servers provided to join the cluster")
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                  "success - copyServicesConfig",
-			argServerNames:        []string{"new-1", "new-2"},
-			argCopyServicesConfig: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
-			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(11, "new-1")},
-				{Value: readyServerForClustering(12, "new-2")},
-				// Before update.
-				{Value: readyServerForClustering(11, "new-1")},
-				{Value: readyServerForClustering(12, "new-2")},
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					ID:      1,
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
+			name: "error - repo.ExistsByName",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// Copy source: "one".
-				{
-					Value: incusosapi.ServiceLVM{
-						State:  incusosapi.ServiceLVMState{VGs: []incusosapi.ServiceLVMVG{{VGName: "vg0"}}},
-						Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1},
-					},
-				},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceLVM{}},
-				{Value: incusosapi.ServiceLVM{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 12}}},
+			repoExistsByNameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name: "error - repo.ExistsByName cluster already exists",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				// Copy source: "one".
-				{
-					Value: incusosapi.ServiceISCSI{
-						State: incusosapi.ServiceISCSIState{InitiatorName: "iqn.one"},
-						Config: incusosapi.ServiceISCSIConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
-						},
-					},
-				},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceISCSI{}},
-				{Value: incusosapi.ServiceISCSI{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}}}}},
-				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}}}}},
-				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}}}}},
+			repoExistsByName: true,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Cluster with name "one" already exists`)
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				// Copy source: "one".
-				{
-					Value: incusosapi.ServiceMultipath{
-						State:  incusosapi.ServiceMultipathState{Devices: map[string]incusosapi.ServiceMultipathDevice{"dev": {Vendor: "vendor"}}},
-						Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}},
-					},
-				},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceMultipath{}},
-				{Value: incusosapi.ServiceMultipath{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
-				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
-				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name: "error - serverSvc.GetByName",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				// Copy source: "one".
-				{
-					Value: incusosapi.ServiceNVME{
-						State:  incusosapi.ServiceNVMEState{HostID: "host-id-one"},
-						Config: incusosapi.ServiceNVMEConfig{Enabled: true},
-					},
-				},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceNVME{}},
-				{Value: incusosapi.ServiceNVME{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
-				{Value: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
-				{Value: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Err: boom.Error},
 			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				// Copy source: "one".
-				{
-					Value: incusosapi.ServiceCeph{
-						State: incusosapi.ServiceCephState{FSID: "fsid-one"},
-						Config: incusosapi.ServiceCephConfig{
-							Enabled:  true,
-							Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
-						},
-					},
-				},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceCeph{}},
-				{Value: incusosapi.ServiceCeph{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Enabled: true, Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}}}}},
-				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Enabled: true, Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}}}}},
-				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Enabled: true, Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}}}}},
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name: "error - server already part of a cluster",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				// Copy source: "one".
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
 				{
-					Value: incusosapi.ServiceLinstor{
-						Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"},
+					Value: &provisioning.Server{
+						Name:    "server1",
+						Status:  api.ServerStatusReady,
+						Cluster: ptr.To("other"),
 					},
 				},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceLinstor{}},
-				{Value: incusosapi.ServiceLinstor{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
-				// Copy targets, current config: "new-1", "new-2".
-				{Value: incusosapi.ServiceOVN{}},
-				{Value: incusosapi.ServiceOVN{}},
-				// Consistency check: "one" (reference), "new-1", "new-2".
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
 
-			assertErr: require.NoError,
-			// The state of the source server is never copied and the LVM system_id is
-			// derived from the ID of the respective target server.
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
-				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
-					Enabled: true,
-					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
-				}}},
-				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
-				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
-				{serverName: "new-1", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
-					Enabled:  true,
-					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
-				}}},
-				{serverName: "new-1", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				{serverName: "new-1", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
-				{serverName: "new-2", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 12}}},
-				{serverName: "new-2", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
-					Enabled: true,
-					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
-				}}},
-				{serverName: "new-2", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
-				{serverName: "new-2", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
-				{serverName: "new-2", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
-					Enabled:  true,
-					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
-				}}},
-				{serverName: "new-2", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				{serverName: "new-2", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server "server1" is already part of cluster "other"`)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                  "success - copyServicesConfig - lvm disabled on source",
-			argServerNames:        []string{"new"},
-			argCopyServicesConfig: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - server not ready",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(11, "new")},
-				// Before update.
-				{Value: readyServerForClustering(11, "new")},
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
-					ID:      1,
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
+					Value: &provisioning.Server{
+						Name:   "server1",
+						Status: api.ServerStatusOffline,
 					},
 				},
 			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// Copy source: "one". A disabled LVM service does not get a system_id
-				// assigned, the system_id of the source server is never copied over.
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 1}}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 91}}},
-				// Consistency check: "one" (reference), "new".
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false}}},
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false}}},
-			},
-			incusClientGetCluster: &incusapi.Cluster{},
 
-			assertErr: require.NoError,
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 91}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
-				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server "server1" is not in ready state`)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                  "error - copyServicesConfig - undone if the consistency check fails",
-			argServerNames:        []string{"new"},
-			argCopyServicesConfig: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - client.GetRemoteCertificate",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(11, "new")},
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					ID:      1,
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-				// Consistency check: "one" (reference), "new" with a conflicting system_id.
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+				{Value: readyServerForClustering(1, "server1")},
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceISCSI{}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.current"}}}}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceMultipath{}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{WWNs: []string{"wwn-current"}}}},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceNVME{}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceNVME{}},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceCeph{}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Clusters: map[string]incusosapi.ServiceCephCluster{"current": {FSID: "fsid-current"}}}}},
-			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceLinstor{}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{ListenAddress: "[::]:3366"}}},
-			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceOVN{}},
-				// Copy target, current config: "new".
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Database: "ssl:[fd00::1]:6641"}}},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Err: boom.Error},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, "configuration inconsistencies")
-			},
-			// The server does not join the cluster, so the copied services config is
-			// restored in reverse order to not leave the server attached to the
-			// storage of the cluster.
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
-				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
-				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Database: "ssl:[fd00::1]:6641"}}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{ListenAddress: "[::]:3366"}}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Clusters: map[string]incusosapi.ServiceCephCluster{"current": {FSID: "fsid-current"}}}}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{WWNs: []string{"wwn-current"}}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.current"}}}}},
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-			},
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                  "error - copyServicesConfig - lvm enabled with server ID > 2000",
-			argServerNames:        []string{"new"},
-			argCopyServicesConfig: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - servers present different certificates",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1", "server2"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(2001, "new")},
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(2, "server2")},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					ID:      1,
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-				},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// Copy source: "one".
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
+				{Value: &x509.Certificate{Raw: []byte("other")}},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, "can not enable LVM on servers with internal ID > 2000")
+				require.ErrorContains(tt, err, `Server "server2" does not present the same certificate as server "server1"`)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                  "error - copyServicesConfig - client.GetOSServiceLVM",
-			argServerNames:        []string{"new"},
-			argCopyServicesConfig: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - client.GetClusterNodeNames",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(11, "new")},
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					ID:      1,
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-				},
+				{Value: readyServerForClustering(1, "server1")},
 			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// Copy source: "one".
-				{Err: boom.Error},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
 			},
+			clientGetClusterNodeNamesErr: boom.Error,
 
-			assertErr: boom.ErrorIs,
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                  "error - copyServicesConfig - client.UpdateOSService",
-			argServerNames:        []string{"new"},
-			argCopyServicesConfig: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - cluster member not listed",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(11, "new")},
+				{Value: readyServerForClustering(1, "server1")},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					ID:      1,
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-				},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{boom.Error},
+			clientGetClusterNodeNames: []string{"server1", "server2"},
 
-			assertErr: boom.ErrorIs,
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{}},
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Cluster member "server2" is not part of the list of servers to adopt`)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:           "error - cluster without any servers",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - server not a cluster member",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1", "server2"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{Value: readyServerForClustering(11, "new")},
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(2, "server2")},
 			},
-			serverSvcGetAllWithFilter: nil,
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
+				{Value: clusterCertificate},
+			},
+			clientGetClusterNodeNames: []string{"server1"},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Cluster "cluster" does not have any servers`)
+				require.ErrorContains(tt, err, `Server "server2" is not a member of the cluster`)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:           "error - serverSvc.GetByName",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
-			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Err: boom.Error,
-				},
-			},
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name:           "error - additional server already clustered",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - server became part of a cluster in the meantime",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
+				{Value: readyServerForClustering(1, "server1")},
 				{
 					Value: &provisioning.Server{
-						Name:    "new",
-						Cluster: ptr.To("some-cluster"), // server already clustered
-						Status:  api.ServerStatusOffline,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(true),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
+						Name:    "server1",
+						Status:  api.ServerStatusReady,
+						Cluster: ptr.To("other"),
 					},
 				},
 			},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
+			},
+			clientGetClusterNodeNames: []string{"server1"},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server "new" is already part of cluster "some-cluster"`)
+				require.ErrorContains(tt, err, `Server "server1" was not part of a cluster, but is now part of "other"`)
 			},
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:           "error - additional server wrong state",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			name: "error - repo.Create",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusOffline, // invalid
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(true),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(1, "server1")},
 			},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
+			},
+			clientGetClusterNodeNames: []string{"server1"},
+			repoCreateErr:             boom.Error,
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server "new" is not in ready state and can therefore not be used for clustering`)
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name: "error - serverSvc.Update",
+			cluster: provisioning.Cluster{
+				Name:        "one",
+				ServerNames: []string{"server1"},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(1, "server1")},
+				{Value: readyServerForClustering(1, "server1")},
+			},
+			clientGetRemoteCertificate: []queue.Item[*x509.Certificate]{
+				{Value: clusterCertificate},
+			},
+			clientGetClusterNodeNames: []string{"server1"},
+			serverSvcUpdateErr:        boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				ExistsByNameFunc: func(ctx context.Context, name string) (bool, error) {
+					return tc.repoExistsByName, tc.repoExistsByNameErr
+				},
+				CreateFunc: func(ctx context.Context, in provisioning.Cluster) (int64, error) {
+					require.Equal(t, api.ClusterStatusReady, in.Status)
+					require.Equal(t, "stable", in.Channel)
+					require.Contains(t, ptr.From(in.Certificate), "BEGIN CERTIFICATE")
+					return 1, tc.repoCreateErr
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				GetRemoteCertificateFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (*x509.Certificate, error) {
+					return queue.Pop(t, &tc.clientGetRemoteCertificate)
+				},
+				GetClusterNodeNamesFunc: func(ctx context.Context, endpoint provisioning.Endpoint) ([]string, error) {
+					require.Contains(t, endpoint.GetCertificate(), "BEGIN CERTIFICATE")
+					return tc.clientGetClusterNodeNames, tc.clientGetClusterNodeNamesErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return queue.Pop(t, &tc.serverSvcGetByName)
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server, force bool, updateSystem bool, bmcConnectionTest bool) error {
+					require.Equal(t, "one", ptr.From(server.Cluster))
+					require.Contains(t, ptr.From(server.ClusterCertificate), "BEGIN CERTIFICATE")
+					return tc.serverSvcUpdateErr
+				},
+			}
+
+			inventorySyncer := &serviceMock.InventorySyncerMock{
+				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
+					return tc.inventorySyncerSyncClusterErr
+				},
+			}
+
+			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
+			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
+			defer func() {
+				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
+			}()
+
+			clusterSvc := provisioningCluster.New(
+				repo,
+				nil,
+				client,
+				serverSvc,
+				nil,
+				map[domain.ResourceType]provisioning.InventorySyncer{domain.ResourceTypeImage: inventorySyncer},
+				nil,
+				nil,
+			)
+
+			var signalHandlerCalled bool
+			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
+
+			// Run test
+			_, err := clusterSvc.Adopt(context.Background(), tc.cluster)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Empty(t, tc.serverSvcGetByName)
+			require.Empty(t, tc.clientGetRemoteCertificate)
+			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
+		})
+	}
+}
+
+type osServiceConfig struct {
+	serverName string
+	name       string
+	config     any
+}
+
+func readyServerForClustering(id int64, name string) *provisioning.Server {
+	return &provisioning.Server{
+		ID:      id,
+		Name:    name,
+		Status:  api.ServerStatusReady,
+		Channel: "stable",
+		VersionData: api.ServerVersionData{
+			NeedsUpdate:   ptr.To(false),
+			NeedsReboot:   ptr.To(false),
+			InMaintenance: ptr.To(api.NotInMaintenance),
+			OS: api.OSVersionData{
+				Name:    "os",
+				Version: "1",
+			},
+			Applications: []api.ApplicationVersionData{
+				{
+					Name:    "incus",
+					Version: "1",
+				},
 			},
 		},
+	}
+}
+
+func TestClusterService_AddServers(t *testing.T) {
+	tests := []struct {
+		name                                  string
+		argServerNames                        []string
+		argSkipPostJoinOperations             bool
+		argCopyServicesConfig                 bool
+		repoGetByName                         *provisioning.Cluster
+		repoGetByNameErr                      error
+		serverSvcGetByName                    []queue.Item[*provisioning.Server]
+		serverSvcGetAllWithFilter             provisioning.Servers
+		serverSvcGetAllWithFilterErr          error
+		clientGetOSServiceLVM                 []queue.Item[incusosapi.ServiceLVM]
+		clientGetOSServiceISCSI               []queue.Item[incusosapi.ServiceISCSI]
+		clientGetOSServiceMultipath           []queue.Item[incusosapi.ServiceMultipath]
+		clientGetOSServiceNVME                []queue.Item[incusosapi.ServiceNVME]
+		clientGetOSServiceCeph                []queue.Item[incusosapi.ServiceCeph]
+		clientGetOSServiceLinstor             []queue.Item[incusosapi.ServiceLinstor]
+		clientGetOSServiceOVN                 []queue.Item[incusosapi.ServiceOVN]
+		clientUpdateOSServiceErrs             queue.Errs
+		clientGetNetworkConfigErr             error
+		clientIncusClientErr                  queue.Errs
+		incusClientGetCluster                 *incusapi.Cluster
+		incusClientGetClusterErr              error
+		incusClientGetStoragePool             *incusapi.StoragePool
+		incusClientGetStoragePoolErr          error
+		incusClientGetNetwork                 *incusapi.Network
+		incusClientGetNetworkErr              error
+		incusClientCreateStoragePoolVolumeErr error
+		clientGetClusterJoinTokenErr          error
+		clientJoinClusterErr                  error
+		clientGetOSData                       api.OSData
+		clientGetOSDataErr                    error
+		clientSetServerConfigErr              error
+		serverSvcUpdateErr                    error
+
+		assertErr           require.ErrorAssertionFunc
+		wantOSServiceConfig []osServiceConfig
+	}{
 		{
-			name:           "error - additional server wrong update channel",
+			name:           "success",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -4236,10 +4170,10 @@ func TestClusterService_AddServers(t *testing.T) {
 					Value: &provisioning.Server{
 						Name:    "new",
 						Status:  api.ServerStatusReady,
-						Channel: "invalid", // mismatch
+						Channel: "stable",
 						VersionData: api.ServerVersionData{
 							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(true),
+							NeedsReboot:   ptr.To(false),
 							InMaintenance: ptr.To(api.NotInMaintenance),
 							OS: api.OSVersionData{
 								Name:    "os",
@@ -4254,22 +4188,7 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server "new" update channel "invalid" does not match channel requested for cluster "stable"`)
-			},
-		},
-		{
-			name:           "error - additional server needs update",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
-			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
+				// Before update.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -4277,7 +4196,7 @@ func TestClusterService_AddServers(t *testing.T) {
 						Channel: "stable",
 						VersionData: api.ServerVersionData{
 							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(true), // needs update
+							NeedsReboot:   ptr.To(false),
 							InMaintenance: ptr.To(api.NotInMaintenance),
 							OS: api.OSVersionData{
 								Name:    "os",
@@ -4293,15 +4212,59 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server "new" not ready to be clustered`)
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+			},
+			incusClientGetCluster: &incusapi.Cluster{
+				MemberConfig: []incusapi.ClusterMemberConfigKey{
+					{
+						Entity: "storage-pool",
+						Name:   "local",
+						Key:    "source",
+					},
+					{
+						Entity: "network",
+						Name:   "incusbr0",
+						Key:    "nic",
+					},
+				},
+			},
+			incusClientGetStoragePool: &incusapi.StoragePool{
+				StoragePoolPut: incusapi.StoragePoolPut{
+					Config: incusapi.ConfigMap{
+						"source": "incus",
+					},
+				},
+			},
+			incusClientGetNetwork: &incusapi.Network{
+				NetworkPut: incusapi.NetworkPut{
+					Config: incusapi.ConfigMap{
+						"nic": "eth0",
+					},
+				},
 			},
+
+			assertErr: require.NoError,
 		},
 		{
-			name:           "error - additional server without incus",
-			argServerNames: []string{"new"},
+			name:                      "success - skipPostJoinOperations",
+			argServerNames:            []string{"new"},
+			argSkipPostJoinOperations: true,
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
 				Channel: "stable",
@@ -4323,65 +4286,14 @@ func TestClusterService_AddServers(t *testing.T) {
 							},
 							Applications: []api.ApplicationVersionData{
 								{
-									Name:    "operations-center", // not incus
+									Name:    "incus",
 									Version: "1",
 								},
 							},
 						},
 					},
 				},
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server "new" does not have application Incus`)
-			},
-		},
-		{
-			name:           "error - checkClusteringServerConsistency",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
-			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
-			},
-			serverSvcGetAllWithFilterErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name:           "error - checkClusteringServerConsistency",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
-			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
+				// Before update.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -4423,100 +4335,49 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			clientGetNetworkConfigErr: boom.Error,
+			incusClientGetCluster: &incusapi.Cluster{},
+
+			assertErr: require.NoError,
+		},
+
+		{
+			name:             "error - repo.GetByName",
+			repoGetByNameErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:           "error - checkClusteringServerConsistency - version mismatch",
-			argServerNames: []string{"new"},
+			name:           "error - no additional servers provided to join the cluster",
+			argServerNames: []string{}, // no servers provided
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
 				Channel: "stable",
 			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "2", // version mismatch
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
-			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, "Failed to add servers (new) due to configuration inconsistencies")
+				require.ErrorContains(tt, err, "Empty list of servers provided to join the cluster")
 			},
 		},
 		{
-			name:           "error - client.IncusClient - 1st",
-			argServerNames: []string{"new"},
+			name:                  "success - copyServicesConfig",
+			argServerNames:        []string{"new-1", "new-2"},
+			argCopyServicesConfig: true,
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
 				Channel: "stable",
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
 				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
+				{Value: readyServerForClustering(11, "new-1")},
+				{Value: readyServerForClustering(12, "new-2")},
+				// Before update.
+				{Value: readyServerForClustering(11, "new-1")},
+				{Value: readyServerForClustering(12, "new-2")},
 			},
 			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
+					ID:      1,
 					Name:    "one",
 					Cluster: ptr.To("cluster"),
 					VersionData: api.ServerVersionData{
@@ -4533,163 +4394,169 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			clientIncusClientErr: queue.Errs{
-				boom.Error,
-			},
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name:           "error - incusClient.GetCluster",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
-			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// Copy source: "one".
 				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
+					Value: incusosapi.ServiceLVM{
+						State:  incusosapi.ServiceLVMState{VGs: []incusosapi.ServiceLVMVG{{VGName: "vg0"}}},
+						Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1},
 					},
 				},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: incusosapi.ServiceLVM{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 12}}},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				// Copy source: "one".
 				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
+					Value: incusosapi.ServiceISCSI{
+						State: incusosapi.ServiceISCSIState{InitiatorName: "iqn.one"},
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
 						},
 					},
 				},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: incusosapi.ServiceISCSI{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}}}}},
+				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}}}}},
+				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}}}}},
 			},
-			incusClientGetClusterErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name:           "error - incusClient.GetStoragePool",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				// Copy source: "one".
+				{
+					Value: incusosapi.ServiceMultipath{
+						State:  incusosapi.ServiceMultipathState{Devices: map[string]incusosapi.ServiceMultipathDevice{"dev": {Vendor: "vendor"}}},
+						Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}},
+					},
+				},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: incusosapi.ServiceMultipath{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
 			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				// Copy source: "one".
 				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
+					Value: incusosapi.ServiceNVME{
+						State:  incusosapi.ServiceNVMEState{HostID: "host-id-one"},
+						Config: incusosapi.ServiceNVMEConfig{Enabled: true},
 					},
 				},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceNVME{}},
+				{Value: incusosapi.ServiceNVME{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+				{Value: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+				{Value: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				// Copy source: "one".
 				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
+					Value: incusosapi.ServiceCeph{
+						State: incusosapi.ServiceCephState{FSID: "fsid-one"},
+						Config: incusosapi.ServiceCephConfig{
+							Enabled:  true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
 						},
 					},
 				},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceCeph{}},
+				{Value: incusosapi.ServiceCeph{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Enabled: true, Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}}}}},
+				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Enabled: true, Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}}}}},
+				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Enabled: true, Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}}}}},
 			},
-			incusClientGetCluster: &incusapi.Cluster{
-				MemberConfig: []incusapi.ClusterMemberConfigKey{
-					{
-						Entity: "storage-pool",
-						Name:   "local",
-						Key:    "source",
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				// Copy source: "one".
+				{
+					Value: incusosapi.ServiceLinstor{
+						Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"},
 					},
 				},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceLinstor{}},
+				{Value: incusosapi.ServiceLinstor{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
 			},
-			incusClientGetStoragePoolErr: boom.Error,
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+				// Copy targets, current config: "new-1", "new-2".
+				{Value: incusosapi.ServiceOVN{}},
+				{Value: incusosapi.ServiceOVN{}},
+				// Consistency check: "one" (reference), "new-1", "new-2".
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+			},
+			incusClientGetCluster: &incusapi.Cluster{},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
+			// The state of the source server is never copied and the LVM system_id is
+			// derived from the ID of the respective target server.
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
+				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
+					Enabled: true,
+					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
+				}}},
+				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+				{serverName: "new-1", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
+					Enabled:  true,
+					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
+				}}},
+				{serverName: "new-1", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				{serverName: "new-1", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+				{serverName: "new-2", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 12}}},
+				{serverName: "new-2", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
+					Enabled: true,
+					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
+				}}},
+				{serverName: "new-2", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+				{serverName: "new-2", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+				{serverName: "new-2", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
+					Enabled:  true,
+					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
+				}}},
+				{serverName: "new-2", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				{serverName: "new-2", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve"}}},
+			},
 		},
 		{
-			name:           "error - incusClient.GetNetwork",
-			argServerNames: []string{"new"},
+			name:                  "success - copyServicesConfig - lvm disabled on source",
+			argServerNames:        []string{"new"},
+			argCopyServicesConfig: true,
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
 				Channel: "stable",
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
 				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
+				{Value: readyServerForClustering(11, "new")},
+				// Before update.
+				{Value: readyServerForClustering(11, "new")},
 			},
 			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
+					ID:      1,
 					Name:    "one",
 					Cluster: ptr.To("cluster"),
 					VersionData: api.ServerVersionData{
@@ -4706,60 +4573,44 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			incusClientGetCluster: &incusapi.Cluster{
-				MemberConfig: []incusapi.ClusterMemberConfigKey{
-					{
-						Entity: "network",
-						Name:   "incusbr0",
-						Key:    "nic",
-					},
-				},
-			},
-			incusClientGetStoragePool: &incusapi.StoragePool{
-				StoragePoolPut: incusapi.StoragePoolPut{
-					Config: incusapi.ConfigMap{
-						"source": "incus",
-					},
-				},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// Copy source: "one". A disabled LVM service does not get a system_id
+				// assigned, the system_id of the source server is never copied over.
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 1}}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 91}}},
+				// Consistency check: "one" (reference), "new".
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false}}},
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false}}},
 			},
-			incusClientGetNetworkErr: boom.Error,
+			incusClientGetCluster: &incusapi.Cluster{},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 91}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
+				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
+			},
 		},
 		{
-			name:           "error - client.GetClusterJoinToken",
-			argServerNames: []string{"new"},
+			name:                  "error - copyServicesConfig - undone if the consistency check fails",
+			argServerNames:        []string{"new"},
+			argCopyServicesConfig: true,
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
 				Channel: "stable",
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
 				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
+				{Value: readyServerForClustering(11, "new")},
 			},
 			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
+					ID:      1,
 					Name:    "one",
 					Cluster: ptr.To("cluster"),
 					VersionData: api.ServerVersionData{
@@ -4776,126 +4627,193 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			incusClientGetCluster:        &incusapi.Cluster{},
-			clientGetClusterJoinTokenErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name:           "error - client.JoinCluster",
-			argServerNames: []string{"new"},
-			repoGetByName: &provisioning.Cluster{
-				Name:    "cluster",
-				Channel: "stable",
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				// Consistency check: "one" (reference), "new" with a conflicting system_id.
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
 			},
-			serverSvcGetByName: []queue.Item[*provisioning.Server]{
-				// Pre check validation.
-				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceISCSI{}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.current"}}}}},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceMultipath{}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{WWNs: []string{"wwn-current"}}}},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceNVME{}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceNVME{}},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceCeph{}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Clusters: map[string]incusosapi.ServiceCephCluster{"current": {FSID: "fsid-current"}}}}},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceLinstor{}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{ListenAddress: "[::]:3366"}}},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceOVN{}},
+				// Copy target, current config: "new".
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Database: "ssl:[fd00::1]:6641"}}},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, "configuration inconsistencies")
+			},
+			// The server does not join the cluster, so the copied services config is
+			// restored in reverse order to not leave the server attached to the
+			// storage of the cluster.
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
+				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
+				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Database: "ssl:[fd00::1]:6641"}}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{ListenAddress: "[::]:3366"}}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Clusters: map[string]incusosapi.ServiceCephCluster{"current": {FSID: "fsid-current"}}}}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{WWNs: []string{"wwn-current"}}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.current"}}}}},
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
-			clientJoinClusterErr:  boom.Error,
-
-			assertErr: boom.ErrorIs,
 		},
 		{
-			name:           "error - serverSvc.GetByName - 2nd",
-			argServerNames: []string{"new"},
+			name:                  "error - copyServicesConfig - lvm enabled with server ID > 2000",
+			argServerNames:        []string{"new"},
+			argCopyServicesConfig: true,
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
 				Channel: "stable",
 			},
 			serverSvcGetByName: []queue.Item[*provisioning.Server]{
 				// Pre check validation.
+				{Value: readyServerForClustering(2001, "new")},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
-					Value: &provisioning.Server{
-						Name:    "new",
-						Status:  api.ServerStatusReady,
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
-							InMaintenance: ptr.To(api.NotInMaintenance),
-							OS: api.OSVersionData{
-								Name:    "os",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
+					ID:      1,
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
 				},
-				// Before update.
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// Copy source: "one".
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, "can not enable LVM on servers with internal ID > 2000")
+			},
+		},
+		{
+			name:                  "error - copyServicesConfig - client.GetOSServiceLVM",
+			argServerNames:        []string{"new"},
+			argCopyServicesConfig: true,
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{Value: readyServerForClustering(11, "new")},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
-					Err: boom.Error,
+					ID:      1,
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
 				},
 			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// Copy source: "one".
+				{Err: boom.Error},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                  "error - copyServicesConfig - client.UpdateOSService",
+			argServerNames:        []string{"new"},
+			argCopyServicesConfig: true,
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{Value: readyServerForClustering(11, "new")},
+			},
 			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
+					ID:      1,
 					Name:    "one",
 					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
 				},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
+			clientUpdateOSServiceErrs: queue.Errs{boom.Error},
 
 			assertErr: boom.ErrorIs,
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{}},
+			},
 		},
 		{
-			name:           "error - serverSvc.GetByName - already clustered",
+			name:           "error - cluster without any servers",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{Value: readyServerForClustering(11, "new")},
+			},
+			serverSvcGetAllWithFilter: nil,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Cluster "cluster" does not have any servers`)
+			},
+		},
+		{
+			name:           "error - serverSvc.GetByName",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - additional server already clustered",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -4906,11 +4824,12 @@ func TestClusterService_AddServers(t *testing.T) {
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
-						Status:  api.ServerStatusReady,
+						Cluster: ptr.To("some-cluster"), // server already clustered
+						Status:  api.ServerStatusOffline,
 						Channel: "stable",
 						VersionData: api.ServerVersionData{
 							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
+							NeedsReboot:   ptr.To(true),
 							InMaintenance: ptr.To(api.NotInMaintenance),
 							OS: api.OSVersionData{
 								Name:    "os",
@@ -4925,16 +4844,30 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-				// Before update.
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server "new" is already part of cluster "some-cluster"`)
+			},
+		},
+		{
+			name:           "error - additional server wrong state",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
-						Cluster: ptr.To("some-cluster"), // already clustered
-						Status:  api.ServerStatusReady,
+						Status:  api.ServerStatusOffline, // invalid
 						Channel: "stable",
 						VersionData: api.ServerVersionData{
 							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
+							NeedsReboot:   ptr.To(true),
 							InMaintenance: ptr.To(api.NotInMaintenance),
 							OS: api.OSVersionData{
 								Name:    "os",
@@ -4950,33 +4883,14 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
-			},
-			incusClientGetCluster: &incusapi.Cluster{},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, "was not part of a cluster, but is now part of")
+				require.ErrorContains(tt, err, `Server "new" is not in ready state and can therefore not be used for clustering`)
 			},
 		},
 		{
-			name:           "error - serverSvc.Update",
+			name:           "error - additional server wrong update channel",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -4988,10 +4902,10 @@ func TestClusterService_AddServers(t *testing.T) {
 					Value: &provisioning.Server{
 						Name:    "new",
 						Status:  api.ServerStatusReady,
-						Channel: "stable",
+						Channel: "invalid", // mismatch
 						VersionData: api.ServerVersionData{
 							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
+							NeedsReboot:   ptr.To(true),
 							InMaintenance: ptr.To(api.NotInMaintenance),
 							OS: api.OSVersionData{
 								Name:    "os",
@@ -5006,7 +4920,22 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-				// Before update.
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server "new" update channel "invalid" does not match channel requested for cluster "stable"`)
+			},
+		},
+		{
+			name:           "error - additional server needs update",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -5014,7 +4943,7 @@ func TestClusterService_AddServers(t *testing.T) {
 						Channel: "stable",
 						VersionData: api.ServerVersionData{
 							NeedsUpdate:   ptr.To(false),
-							NeedsReboot:   ptr.To(false),
+							NeedsReboot:   ptr.To(true), // needs update
 							InMaintenance: ptr.To(api.NotInMaintenance),
 							OS: api.OSVersionData{
 								Name:    "os",
@@ -5030,31 +4959,52 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server "new" not ready to be clustered`)
+			},
+		},
+		{
+			name:           "error - additional server without incus",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
-					Name:    "one",
-					Cluster: ptr.To("cluster"),
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Name:    "os",
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
 								Version: "1",
 							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "operations-center", // not incus
+									Version: "1",
+								},
+							},
 						},
 					},
 				},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
-			serverSvcUpdateErr:    boom.Error,
 
-			assertErr: boom.ErrorIs,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server "new" does not have application Incus`)
+			},
 		},
 		{
-			name:           "error - client.GetOSData",
+			name:           "error - checkClusteringServerConsistency",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -5084,7 +5034,20 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-				// Before update.
+			},
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - checkClusteringServerConsistency",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -5126,13 +5089,12 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
-			clientGetOSDataErr:    boom.Error,
+			clientGetNetworkConfigErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:           "error - client.IncusClient - 2nd",
+			name:           "error - checkClusteringServerConsistency - version mismatch",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -5162,7 +5124,40 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-				// Before update.
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "2", // version mismatch
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, "Failed to add servers (new) due to configuration inconsistencies")
+			},
+		},
+		{
+			name:           "error - client.IncusClient - 1st",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -5204,16 +5199,14 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			incusClientGetCluster: &incusapi.Cluster{},
 			clientIncusClientErr: queue.Errs{
-				nil,
 				boom.Error,
 			},
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:           "error - incusClient.CreateStoragePoolVolume",
+			name:           "error - incusClient.GetCluster",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -5243,7 +5236,38 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-				// Before update.
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+			},
+			incusClientGetClusterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - incusClient.GetStoragePool",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -5285,13 +5309,21 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			incusClientGetCluster:                 &incusapi.Cluster{},
-			incusClientCreateStoragePoolVolumeErr: boom.Error,
+			incusClientGetCluster: &incusapi.Cluster{
+				MemberConfig: []incusapi.ClusterMemberConfigKey{
+					{
+						Entity: "storage-pool",
+						Name:   "local",
+						Key:    "source",
+					},
+				},
+			},
+			incusClientGetStoragePoolErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:           "error - client.SetServerConfig",
+			name:           "error - incusClient.GetNetwork",
 			argServerNames: []string{"new"},
 			repoGetByName: &provisioning.Cluster{
 				Name:    "cluster",
@@ -5321,7 +5353,54 @@ func TestClusterService_AddServers(t *testing.T) {
 						},
 					},
 				},
-				// Before update.
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+			},
+			incusClientGetCluster: &incusapi.Cluster{
+				MemberConfig: []incusapi.ClusterMemberConfigKey{
+					{
+						Entity: "network",
+						Name:   "incusbr0",
+						Key:    "nic",
+					},
+				},
+			},
+			incusClientGetStoragePool: &incusapi.StoragePool{
+				StoragePoolPut: incusapi.StoragePoolPut{
+					Config: incusapi.ConfigMap{
+						"source": "incus",
+					},
+				},
+			},
+			incusClientGetNetworkErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - client.GetClusterJoinToken",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
 					Value: &provisioning.Server{
 						Name:    "new",
@@ -5363,679 +5442,954 @@ func TestClusterService_AddServers(t *testing.T) {
 					},
 				},
 			},
-			incusClientGetCluster:    &incusapi.Cluster{},
-			clientSetServerConfigErr: boom.Error,
+			incusClientGetCluster:        &incusapi.Cluster{},
+			clientGetClusterJoinTokenErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return tc.repoGetByName, tc.repoGetByNameErr
+		{
+			name:           "error - client.JoinCluster",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
 				},
-			}
-
-			var gotOSServiceConfig []osServiceConfig
-
-			var incusClient *adapterMock.InstanceServerMock
-
-			incusClient = &adapterMock.InstanceServerMock{
-				GetClusterFunc: func() (*incusapi.Cluster, string, error) {
-					return tc.incusClientGetCluster, "", tc.incusClientGetClusterErr
-				},
-				UseTargetFunc: func(name string) incusclient.InstanceServer {
-					return incusClient
-				},
-				GetStoragePoolFunc: func(name string) (*incusapi.StoragePool, string, error) {
-					return tc.incusClientGetStoragePool, "", tc.incusClientGetStoragePoolErr
-				},
-				GetNetworkFunc: func(name string) (*incusapi.Network, string, error) {
-					return tc.incusClientGetNetwork, "", tc.incusClientGetNetworkErr
-				},
-				CreateStoragePoolVolumeFunc: func(pool string, volume incusapi.StorageVolumesPost) error {
-					return tc.incusClientCreateStoragePoolVolumeErr
-				},
-			}
-
-			client := &adapterMock.ClusterClientPortMock{
-				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
-					return incusosapi.SystemNetwork{
-						Config: &incusosapi.SystemNetworkConfig{},
-					}, tc.clientGetNetworkConfigErr
-				},
-				GetStorageConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemStorage, error) {
-					return provisioning.ServerSystemStorage{}, nil
-				},
-				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
-					if len(tc.clientGetOSServiceLVM) == 0 {
-						return incusosapi.ServiceLVM{}, nil
-					}
-
-					return queue.Pop(t, &tc.clientGetOSServiceLVM)
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
 				},
-				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
-					if len(tc.clientGetOSServiceISCSI) == 0 {
-						return incusosapi.ServiceISCSI{}, nil
-					}
+			},
+			incusClientGetCluster: &incusapi.Cluster{},
+			clientJoinClusterErr:  boom.Error,
 
-					return queue.Pop(t, &tc.clientGetOSServiceISCSI)
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - serverSvc.GetByName - 2nd",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
 				},
-				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
-					if len(tc.clientGetOSServiceMultipath) == 0 {
-						return incusosapi.ServiceMultipath{}, nil
-					}
-
-					return queue.Pop(t, &tc.clientGetOSServiceMultipath)
+				// Before update.
+				{
+					Err: boom.Error,
 				},
-				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
-					if len(tc.clientGetOSServiceNVME) == 0 {
-						return incusosapi.ServiceNVME{}, nil
-					}
-
-					return queue.Pop(t, &tc.clientGetOSServiceNVME)
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
 				},
-				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
-					if len(tc.clientGetOSServiceCeph) == 0 {
-						return incusosapi.ServiceCeph{}, nil
-					}
+			},
+			incusClientGetCluster: &incusapi.Cluster{},
 
-					return queue.Pop(t, &tc.clientGetOSServiceCeph)
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - serverSvc.GetByName - already clustered",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
 				},
-				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
-					if len(tc.clientGetOSServiceLinstor) == 0 {
-						return incusosapi.ServiceLinstor{}, nil
-					}
-
-					return queue.Pop(t, &tc.clientGetOSServiceLinstor)
+				// Before update.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Cluster: ptr.To("some-cluster"), // already clustered
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
 				},
-				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
-					if len(tc.clientGetOSServiceOVN) == 0 {
-						return incusosapi.ServiceOVN{}, nil
-					}
-
-					return queue.Pop(t, &tc.clientGetOSServiceOVN)
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
 				},
-				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
-					gotOSServiceConfig = append(gotOSServiceConfig, osServiceConfig{
-						serverName: server.Name,
-						name:       name,
-						config:     config,
-					})
+			},
+			incusClientGetCluster: &incusapi.Cluster{},
 
-					return tc.clientUpdateOSServiceErrs.PopOrNil(t)
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, "was not part of a cluster, but is now part of")
+			},
+		},
+		{
+			name:           "error - serverSvc.Update",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
 				},
-				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (incusclient.InstanceServer, error) {
-					return incusClient, tc.clientIncusClientErr.PopOrNil(t)
-				},
-				GetClusterJoinTokenFunc: func(ctx context.Context, endpoint provisioning.Endpoint, memberName string) (string, error) {
-					return "", tc.clientGetClusterJoinTokenErr
-				},
-				JoinClusterFunc: func(ctx context.Context, server provisioning.Server, joinToken, serverAddressOfClusterRole string, endpoint provisioning.Endpoint, config []api.ClusterMemberConfigKey) error {
-					return tc.clientJoinClusterErr
-				},
-				GetOSDataFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.OSData, error) {
-					return tc.clientGetOSData, tc.clientGetOSDataErr
-				},
-				SetServerConfigFunc: func(ctx context.Context, endpoint provisioning.Endpoint, config map[string]string) error {
-					return tc.clientSetServerConfigErr
-				},
-			}
-
-			serverSvc := &serviceMock.ServerServiceMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
-					return queue.Pop(t, &tc.serverSvcGetByName)
-				},
-				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
-					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
-				},
-				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
-					return tc.serverSvcUpdateErr
-				},
-			}
-
-			clusterSvc := provisioningCluster.New(
-				repo,
-				nil,
-				client,
-				serverSvc,
-				nil,
-				nil,
-				nil,
-				nil,
-			)
-
-			// Run test
-			err := clusterSvc.AddServers(t.Context(), "cluster", tc.argServerNames, tc.argSkipPostJoinOperations, tc.argCopyServicesConfig)
-
-			// Assert
-			tc.assertErr(t, err)
-
-			require.Equal(t, tc.wantOSServiceConfig, gotOSServiceConfig)
-
-			require.Empty(t, tc.serverSvcGetByName)
-			require.Empty(t, tc.clientIncusClientErr)
-			require.Empty(t, tc.clientGetOSServiceLVM)
-			require.Empty(t, tc.clientGetOSServiceISCSI)
-			require.Empty(t, tc.clientGetOSServiceMultipath)
-			require.Empty(t, tc.clientGetOSServiceNVME)
-			require.Empty(t, tc.clientGetOSServiceCeph)
-			require.Empty(t, tc.clientGetOSServiceLinstor)
-			require.Empty(t, tc.clientGetOSServiceOVN)
-			require.Empty(t, tc.clientUpdateOSServiceErrs)
-		})
-	}
-}
-
-func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
-	sourceServer := provisioning.Server{
-		ID:   1,
-		Name: "one",
-		OSData: api.OSData{
-			Network: incusosapi.SystemNetwork{
-				State: incusosapi.SystemNetworkState{
-					Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
-						"eth0": {
-							Addresses: []string{"10.0.0.1"},
-							Roles:     []string{"cluster"},
+				// Before update.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
 						},
 					},
 				},
 			},
-		},
-	}
-
-	// Services config, which is already present on the target servers before the
-	// copy. It is restored, if the copy needs to be undone.
-	currentLVM := func(systemID int) incusosapi.ServiceLVM {
-		return incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: systemID}}
-	}
-
-	currentISCSI := func(serverName string) incusosapi.ServiceISCSI {
-		return incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.current." + serverName}}}}
-	}
-
-	currentMultipath := func(serverName string) incusosapi.ServiceMultipath {
-		return incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{WWNs: []string{"wwn-current-" + serverName}}}
-	}
-
-	currentNVME := incusosapi.ServiceNVME{}
-
-	currentCeph := func(serverName string) incusosapi.ServiceCeph {
-		return incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Clusters: map[string]incusosapi.ServiceCephCluster{"current": {FSID: "fsid-current-" + serverName}}}}
-	}
-
-	currentLinstor := incusosapi.ServiceLinstor{}
-
-	currentOVN := incusosapi.ServiceOVN{}
-
-	serverWithClusterRoleAddress := func(id int64, name string, address string) provisioning.Server {
-		return provisioning.Server{
-			ID:   id,
-			Name: name,
-			OSData: api.OSData{
-				Network: incusosapi.SystemNetwork{
-					State: incusosapi.SystemNetworkState{
-						Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
-							"eth0": {
-								Addresses: []string{address},
-								Roles:     []string{"cluster"},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
 							},
 						},
 					},
 				},
 			},
-		}
-	}
-
-	tests := []struct {
-		name                        string
-		targetServers               []provisioning.Server
-		clientGetOSServiceLVM       []queue.Item[incusosapi.ServiceLVM]
-		clientGetOSServiceISCSI     []queue.Item[incusosapi.ServiceISCSI]
-		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
-		clientGetOSServiceNVME      []queue.Item[incusosapi.ServiceNVME]
-		clientGetOSServiceCeph      []queue.Item[incusosapi.ServiceCeph]
-		clientGetOSServiceLinstor   []queue.Item[incusosapi.ServiceLinstor]
-		clientGetOSServiceOVN       []queue.Item[incusosapi.ServiceOVN]
-		clientUpdateOSServiceErrs   queue.Errs
+			incusClientGetCluster: &incusapi.Cluster{},
+			serverSvcUpdateErr:    boom.Error,
 
-		assertErr           require.ErrorAssertionFunc
-		wantOSServiceConfig []osServiceConfig
-	}{
+			assertErr: boom.ErrorIs,
+		},
 		{
-			name: "success",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new-1"},
-				{ID: 12, Name: "new-2"},
+			name:           "error - client.GetOSData",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
 			},
-			// The services config is read from the source server exactly once,
-			// independent of the number of target servers. The current config of the
-			// target servers is read to be able to undo the copy.
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
-					Value: incusosapi.ServiceLVM{
-						State:  incusosapi.ServiceLVMState{VGs: []incusosapi.ServiceLVMVG{{VGName: "vg0"}}},
-						Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1},
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
 					},
 				},
-				{Value: currentLVM(91)},
-				{Value: currentLVM(92)},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				// Before update.
 				{
-					Value: incusosapi.ServiceISCSI{
-						State: incusosapi.ServiceISCSIState{InitiatorName: "iqn.one"},
-						Config: incusosapi.ServiceISCSIConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
 						},
 					},
 				},
-				{Value: currentISCSI("new-1")},
-				{Value: currentISCSI("new-2")},
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
-					Value: incusosapi.ServiceMultipath{
-						State:  incusosapi.ServiceMultipathState{Devices: map[string]incusosapi.ServiceMultipathDevice{"dev": {Vendor: "vendor"}}},
-						Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}},
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
 					},
 				},
-				{Value: currentMultipath("new-1")},
-				{Value: currentMultipath("new-2")},
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{
-					Value: incusosapi.ServiceNVME{
-						State:  incusosapi.ServiceNVMEState{HostID: "host-id-one"},
-						Config: incusosapi.ServiceNVMEConfig{Enabled: true},
-					},
-				},
-				{Value: currentNVME},
-				{Value: currentNVME},
+			incusClientGetCluster: &incusapi.Cluster{},
+			clientGetOSDataErr:    boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:           "error - client.IncusClient - 2nd",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
 			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
 				{
-					Value: incusosapi.ServiceCeph{
-						State: incusosapi.ServiceCephState{FSID: "fsid-one"},
-						Config: incusosapi.ServiceCephConfig{
-							Enabled:  true,
-							Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+				// Before update.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
 						},
 					},
 				},
-				{Value: currentCeph("new-1")},
-				{Value: currentCeph("new-2")},
 			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
-					Value: incusosapi.ServiceLinstor{
-						State:  incusosapi.ServiceLinstorState{},
-						Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"},
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
 					},
 				},
-				{Value: currentLinstor},
-				{Value: currentLinstor},
 			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
-				{Value: currentOVN},
-				{Value: currentOVN},
+			incusClientGetCluster: &incusapi.Cluster{},
+			clientIncusClientErr: queue.Errs{
+				nil,
+				boom.Error,
 			},
 
-			assertErr: require.NoError,
-			// The state of the source server is never copied and the LVM system_id is
-			// derived from the ID of the respective target server.
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
-				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
-					Enabled: true,
-					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
-				}}},
-				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
-				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
-				{serverName: "new-1", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
-					Enabled:  true,
-					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
-				}}},
-				{serverName: "new-1", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				{serverName: "new-1", name: "ovn", config: incusosapi.ServiceOVN{}},
-				{serverName: "new-2", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 12}}},
-				{serverName: "new-2", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
-					Enabled: true,
-					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
-				}}},
-				{serverName: "new-2", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
-				{serverName: "new-2", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
-				{serverName: "new-2", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
-					Enabled:  true,
-					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
-				}}},
-				{serverName: "new-2", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				{serverName: "new-2", name: "ovn", config: incusosapi.ServiceOVN{}},
-			},
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:          "success - without target servers",
-			targetServers: nil,
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
+			name:           "error - incusClient.CreateStoragePoolVolume",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
 			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+				// Before update.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
 			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
 			},
+			incusClientGetCluster:                 &incusapi.Cluster{},
+			incusClientCreateStoragePoolVolumeErr: boom.Error,
 
-			assertErr: require.NoError,
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name: "success - lvm disabled on source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// A disabled LVM service does not get a system_id assigned, the
-				// system_id of the source server is never copied over.
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 1}}},
-				{Value: currentLVM(91)},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new")},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new")},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-				{Value: currentNVME},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new")},
-			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
-				{Value: currentLinstor},
-			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
-				{Value: currentOVN},
+			name:           "error - client.SetServerConfig",
+			argServerNames: []string{"new"},
+			repoGetByName: &provisioning.Cluster{
+				Name:    "cluster",
+				Channel: "stable",
 			},
-
-			assertErr: require.NoError,
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 91}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
-				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
-			},
-		},
-		{
-			name: "success - lvm already enabled on target server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
-				// LVM does not need to be enabled, so the system_id already assigned
-				// to the target server is kept.
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 42}}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new")},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new")},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-				{Value: currentNVME},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new")},
-			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
-				{Value: currentLinstor},
-			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
-				{Value: currentOVN},
-			},
-
-			assertErr: require.NoError,
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 42}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
-				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
-			},
-		},
-		{
-			name: "error - client.GetOSServiceLVM - source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				// Pre check validation.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+				// Before update.
+				{
+					Value: &provisioning.Server{
+						Name:    "new",
+						Status:  api.ServerStatusReady,
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							NeedsUpdate:   ptr.To(false),
+							NeedsReboot:   ptr.To(false),
+							InMaintenance: ptr.To(api.NotInMaintenance),
+							OS: api.OSVersionData{
+								Name:    "os",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
 			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Err: boom.Error},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Name:    "os",
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
 			},
+			incusClientGetCluster:    &incusapi.Cluster{},
+			clientSetServerConfigErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
-		{
-			name: "error - client.GetOSServiceISCSI - source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Err: boom.Error},
-			},
+	}
 
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - client.GetOSServiceMultipath - source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Err: boom.Error},
-			},
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+			}
 
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - client.GetOSServiceNVME - source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Err: boom.Error},
-			},
+			var gotOSServiceConfig []osServiceConfig
 
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - client.GetOSServiceCeph - source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Err: boom.Error},
-			},
+			var incusClient *adapterMock.InstanceServerMock
 
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - client.GetOSServiceLinstor - source server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
-			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Err: boom.Error},
-			},
+			incusClient = &adapterMock.InstanceServerMock{
+				GetClusterFunc: func() (*incusapi.Cluster, string, error) {
+					return tc.incusClientGetCluster, "", tc.incusClientGetClusterErr
+				},
+				UseTargetFunc: func(name string) incusclient.InstanceServer {
+					return incusClient
+				},
+				GetStoragePoolFunc: func(name string) (*incusapi.StoragePool, string, error) {
+					return tc.incusClientGetStoragePool, "", tc.incusClientGetStoragePoolErr
+				},
+				GetNetworkFunc: func(name string) (*incusapi.Network, string, error) {
+					return tc.incusClientGetNetwork, "", tc.incusClientGetNetworkErr
+				},
+				CreateStoragePoolVolumeFunc: func(pool string, volume incusapi.StorageVolumesPost) error {
+					return tc.incusClientCreateStoragePoolVolumeErr
+				},
+			}
 
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - client.GetOSServiceLVM - target server",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new"},
-			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-				{Err: boom.Error},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
-			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
+			client := &adapterMock.ClusterClientPortMock{
+				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
+					return incusosapi.SystemNetwork{
+						Config: &incusosapi.SystemNetworkConfig{},
+					}, tc.clientGetNetworkConfigErr
+				},
+				GetStorageConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemStorage, error) {
+					return provisioning.ServerSystemStorage{}, nil
+				},
+				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
+					if len(tc.clientGetOSServiceLVM) == 0 {
+						return incusosapi.ServiceLVM{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceLVM)
+				},
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					if len(tc.clientGetOSServiceISCSI) == 0 {
+						return incusosapi.ServiceISCSI{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceISCSI)
+				},
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					if len(tc.clientGetOSServiceMultipath) == 0 {
+						return incusosapi.ServiceMultipath{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceMultipath)
+				},
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					if len(tc.clientGetOSServiceNVME) == 0 {
+						return incusosapi.ServiceNVME{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceNVME)
+				},
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					if len(tc.clientGetOSServiceCeph) == 0 {
+						return incusosapi.ServiceCeph{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceCeph)
+				},
+				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
+					if len(tc.clientGetOSServiceLinstor) == 0 {
+						return incusosapi.ServiceLinstor{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceLinstor)
+				},
+				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
+					if len(tc.clientGetOSServiceOVN) == 0 {
+						return incusosapi.ServiceOVN{}, nil
+					}
+
+					return queue.Pop(t, &tc.clientGetOSServiceOVN)
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					gotOSServiceConfig = append(gotOSServiceConfig, osServiceConfig{
+						serverName: server.Name,
+						name:       name,
+						config:     config,
+					})
+
+					return tc.clientUpdateOSServiceErrs.PopOrNil(t)
+				},
+				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (incusclient.InstanceServer, error) {
+					return incusClient, tc.clientIncusClientErr.PopOrNil(t)
+				},
+				GetClusterJoinTokenFunc: func(ctx context.Context, endpoint provisioning.Endpoint, memberName string) (string, error) {
+					return "", tc.clientGetClusterJoinTokenErr
+				},
+				JoinClusterFunc: func(ctx context.Context, server provisioning.Server, joinToken, serverAddressOfClusterRole string, endpoint provisioning.Endpoint, config []api.ClusterMemberConfigKey) error {
+					return tc.clientJoinClusterErr
+				},
+				GetOSDataFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.OSData, error) {
+					return tc.clientGetOSData, tc.clientGetOSDataErr
+				},
+				SetServerConfigFunc: func(ctx context.Context, endpoint provisioning.Endpoint, config map[string]string) error {
+					return tc.clientSetServerConfigErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return queue.Pop(t, &tc.serverSvcGetByName)
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
+					return tc.serverSvcUpdateErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(
+				repo,
+				nil,
+				client,
+				serverSvc,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			// Run test
+			err := clusterSvc.AddServers(t.Context(), "cluster", tc.argServerNames, tc.argSkipPostJoinOperations, tc.argCopyServicesConfig)
+
+			// Assert
+			tc.assertErr(t, err)
+
+			require.Equal(t, tc.wantOSServiceConfig, gotOSServiceConfig)
+
+			require.Empty(t, tc.serverSvcGetByName)
+			require.Empty(t, tc.clientIncusClientErr)
+			require.Empty(t, tc.clientGetOSServiceLVM)
+			require.Empty(t, tc.clientGetOSServiceISCSI)
+			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientGetOSServiceNVME)
+			require.Empty(t, tc.clientGetOSServiceCeph)
+			require.Empty(t, tc.clientGetOSServiceLinstor)
+			require.Empty(t, tc.clientGetOSServiceOVN)
+			require.Empty(t, tc.clientUpdateOSServiceErrs)
+		})
+	}
+}
+
+func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
+	sourceServer := provisioning.Server{
+		ID:   1,
+		Name: "one",
+		OSData: api.OSData{
+			Network: incusosapi.SystemNetwork{
+				State: incusosapi.SystemNetworkState{
+					Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+						"eth0": {
+							Addresses: []string{"10.0.0.1"},
+							Roles:     []string{"cluster"},
+						},
+					},
+				},
 			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
+		},
+	}
+
+	// Services config, which is already present on the target servers before the
+	// copy. It is restored, if the copy needs to be undone.
+	currentLVM := func(systemID int) incusosapi.ServiceLVM {
+		return incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: systemID}}
+	}
+
+	currentISCSI := func(serverName string) incusosapi.ServiceISCSI {
+		return incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.current." + serverName}}}}
+	}
+
+	currentMultipath := func(serverName string) incusosapi.ServiceMultipath {
+		return incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{WWNs: []string{"wwn-current-" + serverName}}}
+	}
+
+	currentNVME := incusosapi.ServiceNVME{}
+
+	currentCeph := func(serverName string) incusosapi.ServiceCeph {
+		return incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{Clusters: map[string]incusosapi.ServiceCephCluster{"current": {FSID: "fsid-current-" + serverName}}}}
+	}
+
+	currentLinstor := incusosapi.ServiceLinstor{}
+
+	currentOVN := incusosapi.ServiceOVN{}
+
+	serverWithClusterRoleAddress := func(id int64, name string, address string) provisioning.Server {
+		return provisioning.Server{
+			ID:   id,
+			Name: name,
+			OSData: api.OSData{
+				Network: incusosapi.SystemNetwork{
+					State: incusosapi.SystemNetworkState{
+						Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+							"eth0": {
+								Addresses: []string{address},
+								Roles:     []string{"cluster"},
+							},
+						},
+					},
+				},
 			},
+		}
+	}
 
-			assertErr: boom.ErrorIs,
-			// The current config of all target servers is read before the first
-			// server is updated, so no config is copied at all.
-			wantOSServiceConfig: nil,
-		},
+	tests := []struct {
+		name                        string
+		targetServers               []provisioning.Server
+		clientGetOSServiceLVM       []queue.Item[incusosapi.ServiceLVM]
+		clientGetOSServiceISCSI     []queue.Item[incusosapi.ServiceISCSI]
+		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
+		clientGetOSServiceNVME      []queue.Item[incusosapi.ServiceNVME]
+		clientGetOSServiceCeph      []queue.Item[incusosapi.ServiceCeph]
+		clientGetOSServiceLinstor   []queue.Item[incusosapi.ServiceLinstor]
+		clientGetOSServiceOVN       []queue.Item[incusosapi.ServiceOVN]
+		clientUpdateOSServiceErrs   queue.Errs
+
+		assertErr           require.ErrorAssertionFunc
+		wantOSServiceConfig []osServiceConfig
+	}{
 		{
-			name: "error - client.GetOSServiceNVME - second target server",
+			name: "success",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new-1"},
 				{ID: 12, Name: "new-2"},
 			},
+			// The services config is read from the source server exactly once,
+			// independent of the number of target servers. The current config of the
+			// target servers is read to be able to undo the copy.
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
+				{
+					Value: incusosapi.ServiceLVM{
+						State:  incusosapi.ServiceLVMState{VGs: []incusosapi.ServiceLVMVG{{VGName: "vg0"}}},
+						Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1},
+					},
+				},
 				{Value: currentLVM(91)},
 				{Value: currentLVM(92)},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
+				{
+					Value: incusosapi.ServiceISCSI{
+						State: incusosapi.ServiceISCSIState{InitiatorName: "iqn.one"},
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
+						},
+					},
+				},
 				{Value: currentISCSI("new-1")},
 				{Value: currentISCSI("new-2")},
 			},
 			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new-1")},
-				{Value: currentMultipath("new-2")},
-			},
+				{
+					Value: incusosapi.ServiceMultipath{
+						State:  incusosapi.ServiceMultipathState{Devices: map[string]incusosapi.ServiceMultipathDevice{"dev": {Vendor: "vendor"}}},
+						Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}},
+					},
+				},
+				{Value: currentMultipath("new-1")},
+				{Value: currentMultipath("new-2")},
+			},
 			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
+				{
+					Value: incusosapi.ServiceNVME{
+						State:  incusosapi.ServiceNVMEState{HostID: "host-id-one"},
+						Config: incusosapi.ServiceNVMEConfig{Enabled: true},
+					},
+				},
+				{Value: currentNVME},
 				{Value: currentNVME},
-				{Err: boom.Error},
 			},
-			// The current config of the second target server is not read anymore
-			// after the failure.
 			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
+				{
+					Value: incusosapi.ServiceCeph{
+						State: incusosapi.ServiceCephState{FSID: "fsid-one"},
+						Config: incusosapi.ServiceCephConfig{
+							Enabled:  true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
+						},
+					},
+				},
 				{Value: currentCeph("new-1")},
+				{Value: currentCeph("new-2")},
 			},
 			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
+				{
+					Value: incusosapi.ServiceLinstor{
+						State:  incusosapi.ServiceLinstorState{},
+						Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"},
+					},
+				},
+				{Value: currentLinstor},
 				{Value: currentLinstor},
 			},
 			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
+				{Value: currentOVN},
 			},
 
-			assertErr:           boom.ErrorIs,
-			wantOSServiceConfig: nil,
+			assertErr: require.NoError,
+			// The state of the source server is never copied and the LVM system_id is
+			// derived from the ID of the respective target server.
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 11}}},
+				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
+					Enabled: true,
+					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
+				}}},
+				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+				{serverName: "new-1", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
+					Enabled:  true,
+					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
+				}}},
+				{serverName: "new-1", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				{serverName: "new-1", name: "ovn", config: incusosapi.ServiceOVN{}},
+				{serverName: "new-2", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 12}}},
+				{serverName: "new-2", name: "iscsi", config: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{
+					Enabled: true,
+					Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.target", Address: "10.0.0.1", Port: 3260}},
+				}}},
+				{serverName: "new-2", name: "multipath", config: incusosapi.ServiceMultipath{Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"wwn-1"}}}},
+				{serverName: "new-2", name: "nvme", config: incusosapi.ServiceNVME{Config: incusosapi.ServiceNVMEConfig{Enabled: true}}},
+				{serverName: "new-2", name: "ceph", config: incusosapi.ServiceCeph{Config: incusosapi.ServiceCephConfig{
+					Enabled:  true,
+					Clusters: map[string]incusosapi.ServiceCephCluster{"ceph": {FSID: "fsid", Monitors: []string{"10.0.0.1"}}},
+				}}},
+				{serverName: "new-2", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				{serverName: "new-2", name: "ovn", config: incusosapi.ServiceOVN{}},
+			},
 		},
 		{
-			name: "error - lvm enabled with server ID > 2000",
-			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new-1"},
-				{ID: 2001, Name: "new-2"},
-			},
+			name:          "success - without target servers",
+			targetServers: nil,
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+				{Value: incusosapi.ServiceLVM{}},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
@@ -6056,84 +6410,65 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: incusosapi.ServiceOVN{}},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, "can not enable LVM on servers with internal ID > 2000")
-			},
-			// The precondition is verified for all target servers before the first
-			// server is updated, so no config is copied at all.
-			wantOSServiceConfig: nil,
+			assertErr: require.NoError,
 		},
 		{
-			name: "success - member dependent linstor listen address and ovn tunnel address",
+			name: "success - lvm disabled on source server",
 			targetServers: []provisioning.Server{
-				serverWithClusterRoleAddress(11, "new-1", "10.0.0.11"),
-				serverWithClusterRoleAddress(12, "new-2", "10.0.0.12"),
+				{ID: 11, Name: "new"},
 			},
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
+				// A disabled LVM service does not get a system_id assigned, the
+				// system_id of the source server is never copied over.
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 1}}},
 				{Value: currentLVM(91)},
-				{Value: currentLVM(92)},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new-1")},
-				{Value: currentISCSI("new-2")},
+				{Value: currentISCSI("new")},
 			},
 			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
 				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new-1")},
-				{Value: currentMultipath("new-2")},
+				{Value: currentMultipath("new")},
 			},
 			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{Value: incusosapi.ServiceNVME{}},
 				{Value: currentNVME},
-				{Value: currentNVME},
 			},
 			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new-1")},
-				{Value: currentCeph("new-2")},
+				{Value: currentCeph("new")},
 			},
 			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.1:3366"}}},
-				{Value: currentLinstor},
+				{Value: incusosapi.ServiceLinstor{}},
 				{Value: currentLinstor},
 			},
 			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.1"}}},
-				{Value: currentOVN},
+				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
 			},
 
 			assertErr: require.NoError,
-			// The linstor listen address and the ovn tunnel address of the source
-			// server are replaced by the respective address of the target server.
 			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new-1", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new-1", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.11:3366"}}},
-				{serverName: "new-1", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.11"}}},
-				{serverName: "new-2", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 92}}},
-				{serverName: "new-2", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new-2", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new-2", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new-2", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new-2", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.12:3366"}}},
-				{serverName: "new-2", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.12"}}},
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: false, SystemID: 91}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
+				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
 			},
 		},
 		{
-			name: "error - target server without an address for the linstor listen address",
+			name: "success - lvm already enabled on target server",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				{Value: incusosapi.ServiceLVM{}},
-				{Value: currentLVM(91)},
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+				// LVM does not need to be enabled, so the system_id already assigned
+				// to the target server is kept.
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 42}}},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
@@ -6152,7 +6487,7 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: currentCeph("new")},
 			},
 			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.1:3366"}}},
+				{Value: incusosapi.ServiceLinstor{}},
 				{Value: currentLinstor},
 			},
 			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
@@ -6160,120 +6495,192 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: currentOVN},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr)
-				require.ErrorContains(tt, err, `Failed to derive the linstor listen address for server "new"`)
+			assertErr: require.NoError,
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 42}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
+				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
 			},
 		},
 		{
-			name: "error - client.UpdateOSService - lvm",
+			name: "error - client.GetOSServiceLVM - source server",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Err: boom.Error},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - client.GetOSServiceISCSI - source server",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Err: boom.Error},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - client.GetOSServiceMultipath - source server",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
 				{Value: incusosapi.ServiceLVM{}},
-				{Value: currentLVM(91)},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new")},
 			},
 			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new")},
+				{Err: boom.Error},
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{Value: incusosapi.ServiceNVME{}},
-				{Value: currentNVME},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - client.GetOSServiceNVME - source server",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
 			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new")},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
 			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
-				{Value: currentLinstor},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
 			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
-				{Value: currentOVN},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Err: boom.Error},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{boom.Error},
 
 			assertErr: boom.ErrorIs,
-			// Nothing has been updated successfully, so there is nothing to undo.
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-			},
 		},
 		{
-			name: "error - client.UpdateOSService - iscsi",
+			name: "error - client.GetOSServiceCeph - source server",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
 				{Value: incusosapi.ServiceLVM{}},
-				{Value: currentLVM(91)},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new")},
 			},
 			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
 				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new")},
 			},
 			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{Value: incusosapi.ServiceNVME{}},
-				{Value: currentNVME},
 			},
 			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new")},
-			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
-				{Value: currentLinstor},
-			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				{Value: incusosapi.ServiceOVN{}},
-				{Value: currentOVN},
+				{Err: boom.Error},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, boom.Error},
 
 			assertErr: boom.ErrorIs,
-			// The previously copied lvm config is restored.
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "lvm", config: currentLVM(91)},
-			},
 		},
 		{
-			name: "error - client.UpdateOSService - multipath",
+			name: "error - client.GetOSServiceLinstor - source server",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
 				{Value: incusosapi.ServiceLVM{}},
-				{Value: currentLVM(91)},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new")},
 			},
 			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
 				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new")},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Err: boom.Error},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - client.GetOSServiceLVM - target server",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Err: boom.Error},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{}},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{}},
+			},
+
+			assertErr: boom.ErrorIs,
+			// The current config of all target servers is read before the first
+			// server is updated, so no config is copied at all.
+			wantOSServiceConfig: nil,
+		},
+		{
+			name: "error - client.GetOSServiceNVME - second target server",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new-1"},
+				{ID: 12, Name: "new-2"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: currentLVM(91)},
+				{Value: currentLVM(92)},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: currentISCSI("new-1")},
+				{Value: currentISCSI("new-2")},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: currentMultipath("new-1")},
+				{Value: currentMultipath("new-2")},
 			},
 			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{Value: incusosapi.ServiceNVME{}},
 				{Value: currentNVME},
+				{Err: boom.Error},
 			},
+			// The current config of the second target server is not read anymore
+			// after the failure.
 			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new")},
+				{Value: currentCeph("new-1")},
 			},
 			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
 				{Value: incusosapi.ServiceLinstor{}},
@@ -6283,20 +6690,110 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, nil, boom.Error},
 
-			assertErr: boom.ErrorIs,
-			// The previously copied configs are restored in reverse order.
+			assertErr:           boom.ErrorIs,
+			wantOSServiceConfig: nil,
+		},
+		{
+			name: "error - lvm enabled with server ID > 2000",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new-1"},
+				{ID: 2001, Name: "new-2"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}}},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{}},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{}},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, "can not enable LVM on servers with internal ID > 2000")
+			},
+			// The precondition is verified for all target servers before the first
+			// server is updated, so no config is copied at all.
+			wantOSServiceConfig: nil,
+		},
+		{
+			name: "success - member dependent linstor listen address and ovn tunnel address",
+			targetServers: []provisioning.Server{
+				serverWithClusterRoleAddress(11, "new-1", "10.0.0.11"),
+				serverWithClusterRoleAddress(12, "new-2", "10.0.0.12"),
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: currentLVM(91)},
+				{Value: currentLVM(92)},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: currentISCSI("new-1")},
+				{Value: currentISCSI("new-2")},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: currentMultipath("new-1")},
+				{Value: currentMultipath("new-2")},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+				{Value: currentNVME},
+				{Value: currentNVME},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+				{Value: currentCeph("new-1")},
+				{Value: currentCeph("new-2")},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.1:3366"}}},
+				{Value: currentLinstor},
+				{Value: currentLinstor},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.1"}}},
+				{Value: currentOVN},
+				{Value: currentOVN},
+			},
+
+			assertErr: require.NoError,
+			// The linstor listen address and the ovn tunnel address of the source
+			// server are replaced by the respective address of the target server.
 			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
-				{serverName: "new", name: "lvm", config: currentLVM(91)},
+				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new-1", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new-1", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.11:3366"}}},
+				{serverName: "new-1", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.11"}}},
+				{serverName: "new-2", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 92}}},
+				{serverName: "new-2", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new-2", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new-2", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new-2", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new-2", name: "linstor", config: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.12:3366"}}},
+				{serverName: "new-2", name: "ovn", config: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.12"}}},
 			},
 		},
 		{
-			name: "error - client.UpdateOSService - nvme",
+			name: "error - target server without an address for the linstor listen address",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
@@ -6321,28 +6818,22 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: currentCeph("new")},
 			},
 			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				{Value: incusosapi.ServiceLinstor{}},
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.1:3366"}}},
 				{Value: currentLinstor},
 			},
 			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, boom.Error},
 
-			assertErr: boom.ErrorIs,
-			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "multipath", config: currentMultipath("new")},
-				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
-				{serverName: "new", name: "lvm", config: currentLVM(91)},
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr)
+				require.ErrorContains(tt, err, `Failed to derive the linstor listen address for server "new"`)
 			},
 		},
 		{
-			name: "error - client.UpdateOSService - ceph",
+			name: "error - client.UpdateOSService - lvm",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
@@ -6374,23 +6865,16 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, boom.Error},
+			clientUpdateOSServiceErrs: queue.Errs{boom.Error},
 
 			assertErr: boom.ErrorIs,
+			// Nothing has been updated successfully, so there is nothing to undo.
 			wantOSServiceConfig: []osServiceConfig{
 				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
-				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "nvme", config: currentNVME},
-				{serverName: "new", name: "multipath", config: currentMultipath("new")},
-				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
-				{serverName: "new", name: "lvm", config: currentLVM(91)},
 			},
 		},
 		{
-			name: "error - client.UpdateOSService - linstor",
+			name: "error - client.UpdateOSService - iscsi",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
@@ -6422,25 +6906,18 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, nil, boom.Error},
+			clientUpdateOSServiceErrs: queue.Errs{nil, boom.Error},
 
 			assertErr: boom.ErrorIs,
+			// The previously copied lvm config is restored.
 			wantOSServiceConfig: []osServiceConfig{
 				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
 				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
-				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
-				{serverName: "new", name: "ceph", config: currentCeph("new")},
-				{serverName: "new", name: "nvme", config: currentNVME},
-				{serverName: "new", name: "multipath", config: currentMultipath("new")},
-				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
 				{serverName: "new", name: "lvm", config: currentLVM(91)},
 			},
 		},
 		{
-			name: "error - client.UpdateOSService - ovn",
+			name: "error - client.UpdateOSService - multipath",
 			targetServers: []provisioning.Server{
 				{ID: 11, Name: "new"},
 			},
@@ -6472,72 +6949,261 @@ func TestClusterService_copyServicesConfigFromClusterMember(t *testing.T) {
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, nil, nil, boom.Error},
+			clientUpdateOSServiceErrs: queue.Errs{nil, nil, boom.Error},
 
 			assertErr: boom.ErrorIs,
+			// The previously copied configs are restored in reverse order.
 			wantOSServiceConfig: []osServiceConfig{
 				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
 				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
 				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
-				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
-				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
-				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
-				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
-				{serverName: "new", name: "linstor", config: currentLinstor},
-				{serverName: "new", name: "ceph", config: currentCeph("new")},
-				{serverName: "new", name: "nvme", config: currentNVME},
-				{serverName: "new", name: "multipath", config: currentMultipath("new")},
 				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
 				{serverName: "new", name: "lvm", config: currentLVM(91)},
 			},
 		},
 		{
-			name: "error - client.UpdateOSService - second target server",
+			name: "error - client.UpdateOSService - nvme",
 			targetServers: []provisioning.Server{
-				{ID: 11, Name: "new-1"},
-				{ID: 12, Name: "new-2"},
+				{ID: 11, Name: "new"},
 			},
 			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
 				{Value: incusosapi.ServiceLVM{}},
 				{Value: currentLVM(91)},
-				{Value: currentLVM(92)},
 			},
 			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
 				{Value: incusosapi.ServiceISCSI{}},
-				{Value: currentISCSI("new-1")},
-				{Value: currentISCSI("new-2")},
+				{Value: currentISCSI("new")},
 			},
 			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
 				{Value: incusosapi.ServiceMultipath{}},
-				{Value: currentMultipath("new-1")},
-				{Value: currentMultipath("new-2")},
+				{Value: currentMultipath("new")},
 			},
 			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{Value: incusosapi.ServiceNVME{}},
 				{Value: currentNVME},
-				{Value: currentNVME},
 			},
 			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{Value: incusosapi.ServiceCeph{}},
-				{Value: currentCeph("new-1")},
-				{Value: currentCeph("new-2")},
+				{Value: currentCeph("new")},
 			},
 			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
 				{Value: incusosapi.ServiceLinstor{}},
 				{Value: currentLinstor},
-				{Value: currentLinstor},
 			},
 			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
 				{Value: incusosapi.ServiceOVN{}},
 				{Value: currentOVN},
-				{Value: currentOVN},
 			},
-			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, nil, nil, nil, boom.Error},
+			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, boom.Error},
 
 			assertErr: boom.ErrorIs,
-			// The config copied to the first target server is restored as well.
 			wantOSServiceConfig: []osServiceConfig{
-				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "multipath", config: currentMultipath("new")},
+				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
+				{serverName: "new", name: "lvm", config: currentLVM(91)},
+			},
+		},
+		{
+			name: "error - client.UpdateOSService - ceph",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: currentLVM(91)},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: currentISCSI("new")},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: currentMultipath("new")},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+				{Value: currentNVME},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+				{Value: currentCeph("new")},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{}},
+				{Value: currentLinstor},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{}},
+				{Value: currentOVN},
+			},
+			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, boom.Error},
+
+			assertErr: boom.ErrorIs,
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "nvme", config: currentNVME},
+				{serverName: "new", name: "multipath", config: currentMultipath("new")},
+				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
+				{serverName: "new", name: "lvm", config: currentLVM(91)},
+			},
+		},
+		{
+			name: "error - client.UpdateOSService - linstor",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: currentLVM(91)},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: currentISCSI("new")},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: currentMultipath("new")},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+				{Value: currentNVME},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+				{Value: currentCeph("new")},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{}},
+				{Value: currentLinstor},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{}},
+				{Value: currentOVN},
+			},
+			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, nil, boom.Error},
+
+			assertErr: boom.ErrorIs,
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
+				{serverName: "new", name: "ceph", config: currentCeph("new")},
+				{serverName: "new", name: "nvme", config: currentNVME},
+				{serverName: "new", name: "multipath", config: currentMultipath("new")},
+				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
+				{serverName: "new", name: "lvm", config: currentLVM(91)},
+			},
+		},
+		{
+			name: "error - client.UpdateOSService - ovn",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: currentLVM(91)},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: currentISCSI("new")},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: currentMultipath("new")},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+				{Value: currentNVME},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+				{Value: currentCeph("new")},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{}},
+				{Value: currentLinstor},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{}},
+				{Value: currentOVN},
+			},
+			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, nil, nil, boom.Error},
+
+			assertErr: boom.ErrorIs,
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
+				{serverName: "new", name: "iscsi", config: incusosapi.ServiceISCSI{}},
+				{serverName: "new", name: "multipath", config: incusosapi.ServiceMultipath{}},
+				{serverName: "new", name: "nvme", config: incusosapi.ServiceNVME{}},
+				{serverName: "new", name: "ceph", config: incusosapi.ServiceCeph{}},
+				{serverName: "new", name: "linstor", config: incusosapi.ServiceLinstor{}},
+				{serverName: "new", name: "ovn", config: incusosapi.ServiceOVN{}},
+				{serverName: "new", name: "linstor", config: currentLinstor},
+				{serverName: "new", name: "ceph", config: currentCeph("new")},
+				{serverName: "new", name: "nvme", config: currentNVME},
+				{serverName: "new", name: "multipath", config: currentMultipath("new")},
+				{serverName: "new", name: "iscsi", config: currentISCSI("new")},
+				{serverName: "new", name: "lvm", config: currentLVM(91)},
+			},
+		},
+		{
+			name: "error - client.UpdateOSService - second target server",
+			targetServers: []provisioning.Server{
+				{ID: 11, Name: "new-1"},
+				{ID: 12, Name: "new-2"},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				{Value: incusosapi.ServiceLVM{}},
+				{Value: currentLVM(91)},
+				{Value: currentLVM(92)},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: currentISCSI("new-1")},
+				{Value: currentISCSI("new-2")},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{Value: incusosapi.ServiceMultipath{}},
+				{Value: currentMultipath("new-1")},
+				{Value: currentMultipath("new-2")},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				{Value: incusosapi.ServiceNVME{}},
+				{Value: currentNVME},
+				{Value: currentNVME},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{Value: incusosapi.ServiceCeph{}},
+				{Value: currentCeph("new-1")},
+				{Value: currentCeph("new-2")},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{Value: incusosapi.ServiceLinstor{}},
+				{Value: currentLinstor},
+				{Value: currentLinstor},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{Value: incusosapi.ServiceOVN{}},
+				{Value: currentOVN},
+				{Value: currentOVN},
+			},
+			clientUpdateOSServiceErrs: queue.Errs{nil, nil, nil, nil, nil, nil, nil, boom.Error},
+
+			assertErr: boom.ErrorIs,
+			// The config copied to the first target server is restored as well.
+			wantOSServiceConfig: []osServiceConfig{
+				{serverName: "new-1", name: "lvm", config: incusosapi.ServiceLVM{Config: incusosapi.ServiceLVMConfig{SystemID: 91}}},
 				{serverName: "new-1", name: "iscsi", config: incusosapi.ServiceISCSI{}},
 				{serverName: "new-1", name: "multipath", config: incusosapi.ServiceMultipath{}},
 				{serverName: "new-1", name: "nvme", config: incusosapi.ServiceNVME{}},
@@ -11874,1184 +12540,5604 @@ func TestClusterService_checkClusteringServerConsistency(t *testing.T) {
 				// two
 				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
 			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				// one (reference)
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.1"}}},
-				// two
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.3"}}},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				// one (reference)
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.1"}}},
+				// two
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.3"}}},
+			},
+
+			assertErr:               require.NoError,
+			wantInconsistencyReason: `OVN tunnel address mismatch, found "10.0.0.1" (one) and "10.0.0.3" (two), expected "10.0.0.2" for two`,
+		},
+		{
+			name: "error - service OVN not equal",
+			servers: []provisioning.Server{
+				{
+					Name: "one",
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+				{
+					Name: "two",
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+			},
+			clientGetNetworkConfig: []queue.Item[provisioning.ServerSystemNetwork]{
+				// one (reference)
+				{
+					Value: incusosapi.SystemNetwork{
+						Config: &incusosapi.SystemNetworkConfig{
+							Interfaces: []incusosapi.SystemNetworkInterface{
+								{
+									VLANTags: []int{
+										1,
+									},
+								},
+							},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.SystemNetwork{
+						Config: &incusosapi.SystemNetworkConfig{
+							Interfaces: []incusosapi.SystemNetworkInterface{
+								{
+									VLANTags: []int{
+										1,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			clientGetStorageConfig: []queue.Item[provisioning.ServerSystemStorage]{
+				// one (reference)
+				{
+					Value: incusosapi.SystemStorage{
+						Config: incusosapi.SystemStorageConfig{
+							Pools: []incusosapi.SystemStoragePool{
+								{
+									Name: "local",
+									Type: "custom",
+								},
+							},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.SystemStorage{
+						Config: incusosapi.SystemStorageConfig{
+							Pools: []incusosapi.SystemStoragePool{
+								{
+									Name: "local",
+									Type: "custom",
+								},
+							},
+						},
+					},
+				},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// one (reference)
+				{
+					Value: incusosapi.ServiceLVM{
+						Config: incusosapi.ServiceLVMConfig{
+							Enabled:  true,
+							SystemID: 1,
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.ServiceLVM{
+						Config: incusosapi.ServiceLVMConfig{
+							Enabled:  true,
+							SystemID: 2,
+						},
+					},
+				},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{Value: incusosapi.ServiceISCSI{}},
+				{Value: incusosapi.ServiceISCSI{}},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				// one (reference)
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"foo"},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"foo"},
+						},
+					},
+				},
+			},
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+				// one (reference)
+				{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceNVMETarget{
+								{
+									Transport: "tcp",
+									Address:   "localhost",
+									Port:      1234,
+								},
+							},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceNVMETarget{
+								{
+									Transport: "tcp",
+									Address:   "localhost",
+									Port:      1234,
+								},
+							},
+						},
+					},
+				},
+			},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				// one (reference)
+				{
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"ceph": {
+									FSID:     "fsid",
+									Monitors: []string{"10.0.0.1"},
+								},
+							},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"ceph": {
+									FSID:     "fsid",
+									Monitors: []string{"10.0.0.1"},
+								},
+							},
+						},
+					},
+				},
+			},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				// one (reference)
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+				// two
+				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+			},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				// one (reference)
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641"}}},
+				// two
+				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::2]:6641"}}},
+			},
+
+			assertErr:               require.NoError,
+			wantInconsistencyReason: "OVN configuration mismatch",
+		},
+		{
+			name: "error - service iSCSI not equal",
+			servers: []provisioning.Server{
+				{
+					Name: "one",
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+				{
+					Name: "two",
+					VersionData: api.ServerVersionData{
+						OS: api.OSVersionData{
+							Version: "1",
+						},
+						Applications: []api.ApplicationVersionData{
+							{
+								Name:    "incus",
+								Version: "1",
+							},
+						},
+					},
+				},
+			},
+			clientGetNetworkConfig: []queue.Item[provisioning.ServerSystemNetwork]{
+				// one (reference)
+				{
+					Value: incusosapi.SystemNetwork{
+						Config: &incusosapi.SystemNetworkConfig{
+							Interfaces: []incusosapi.SystemNetworkInterface{
+								{
+									VLANTags: []int{
+										1,
+									},
+								},
+							},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.SystemNetwork{
+						Config: &incusosapi.SystemNetworkConfig{
+							Interfaces: []incusosapi.SystemNetworkInterface{
+								{
+									VLANTags: []int{
+										1,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			clientGetStorageConfig: []queue.Item[provisioning.ServerSystemStorage]{
+				// one (reference)
+				{
+					Value: incusosapi.SystemStorage{
+						Config: incusosapi.SystemStorageConfig{
+							Pools: []incusosapi.SystemStoragePool{
+								{
+									Name: "local",
+									Type: "custom",
+								},
+							},
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.SystemStorage{
+						Config: incusosapi.SystemStorageConfig{
+							Pools: []incusosapi.SystemStoragePool{
+								{
+									Name: "local",
+									Type: "custom",
+								},
+							},
+						},
+					},
+				},
+			},
+			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
+				// one (reference)
+				{
+					Value: incusosapi.ServiceLVM{
+						Config: incusosapi.ServiceLVMConfig{
+							Enabled:  true,
+							SystemID: 1,
+						},
+					},
+				},
+				// two
+				{
+					Value: incusosapi.ServiceLVM{
+						Config: incusosapi.ServiceLVMConfig{
+							Enabled:  true,
+							SystemID: 2,
+						},
+					},
+				},
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				// one (reference)
+				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.one"}}}}},
+				// two
+				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.two"}}}}},
+			},
+
+			assertErr:               require.NoError,
+			wantInconsistencyReason: "iSCSI configuration mismatch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			client := &adapterMock.ClusterClientPortMock{
+				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
+					return queue.Pop(t, &tc.clientGetNetworkConfig)
+				},
+				GetStorageConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemStorage, error) {
+					return queue.Pop(t, &tc.clientGetStorageConfig)
+				},
+				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceLVM)
+				},
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceISCSI)
+				},
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceMultipath)
+				},
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceNVME)
+				},
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceCeph)
+				},
+				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceLinstor)
+				},
+				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceOVN)
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					return tc.clientUpdateOSServiceErrs.Pop(t)
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(
+				nil,
+				nil,
+				client,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			// Run test
+			isConsistent, reason, err := clusterSvc.CheckClusteringServerConsistency(t.Context(), tc.servers)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantConsistent, isConsistent)
+			require.Contains(t, reason, tc.wantInconsistencyReason)
+
+			require.Empty(t, tc.clientGetNetworkConfig)
+			require.Empty(t, tc.clientGetStorageConfig)
+			require.Empty(t, tc.clientGetOSServiceLVM)
+			require.Empty(t, tc.clientGetOSServiceISCSI)
+			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientGetOSServiceNVME)
+			require.Empty(t, tc.clientGetOSServiceCeph)
+			require.Empty(t, tc.clientGetOSServiceLinstor)
+			require.Empty(t, tc.clientGetOSServiceOVN)
+			require.Empty(t, tc.clientUpdateOSServiceErrs)
+		})
+	}
+}
+
+func TestClusterService_RemoveServer(t *testing.T) {
+	tests := []struct {
+		name                                   string
+		serverSvcGetAllWithFilter              provisioning.Servers
+		serverSvcGetAllWithFilterErr           error
+		inventorySyncerErr                     error
+		inventorySvcGetAllWithFilter           []queue.Item[inventory.InventoryAggregates]
+		clientIncusClientErr                   error
+		serverSvcUpdateErr                     queue.Errs
+		incusClientGetClusterMemberErr         error
+		incusClientUpdateClusterMemberErr      error
+		incusClientGetServerErr                error
+		incusClientUpdateServerErr             error
+		incusClientDeleteStoragePoolVolumeErrs queue.Errs
+		serverSvcFactoryResetByNameErr         error
+		incusClientDeleteClusterMemberErr      error
+		incusClientHasExtension                bool
+
+		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
+	}{
+		{
+			name:                    "success",
+			incusClientHasExtension: true,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{
+						{
+							StorageVolumes: inventory.StorageVolumes{
+								{
+									Name: "custom/backups",
+								},
+								{
+									Name: "custom/images",
+								},
+								{
+									Name: "custom/logs",
+								},
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			assertLog: log.Empty,
+		},
+
+		{
+			name:                         "error - serverSvc.GetAllWithFilter",
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - cluster minimum size",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name: "serverOne",
+				},
+				// cluster only has 1 server
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Cluster "one" does not have enough servers for server removal`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - server not part of cluster",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				// serverOne is not part of cluster
+				{
+					Name: "serverTwo",
+				},
+				{
+					Name: "serverThree",
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrNotFound)
+				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" is not part of the cluster "one"`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - server not evacuated",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" is not in state evacuated`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - ResyncInventoryByName",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySyncerErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - inventorySvc.GetAllWithFilter",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - local resources - instances",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{
+						{
+							Instances: inventory.Instances{
+								{
+									Name: "instance",
+								},
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" still has instances`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - local resources - storage volumes",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{
+						{
+							StorageVolumes: inventory.StorageVolumes{
+								{
+									Name: "custom/some_volume",
+								},
+								{
+									Name: "image/some_image",
+								},
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" still has custom volumes`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - local resources - image only present on removed server",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{},
+				},
+				{
+					Err: boom.Error,
+				},
+			},
+			incusClientHasExtension: true,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - local resources - image only present on removed server",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{
+						{
+							Images: inventory.Images{
+								{
+									Cluster:     "one",
+									ProjectName: "default",
+									Name:        "img-fingerprint",
+									Object: inventory.IncusImageWrapper{
+										Image: incusapi.Image{
+											Locations: []string{"serverOne"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			incusClientHasExtension: true,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `the following images are only present on the server(s) being removed`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "success - image also present on surviving server",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{
+						{
+							Images: inventory.Images{
+								{
+									Cluster:     "one",
+									ProjectName: "default",
+									Name:        "img-fingerprint",
+									Object: inventory.IncusImageWrapper{
+										Image: incusapi.Image{
+											Locations: []string{"serverOne", "serverTwo"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			incusClientHasExtension: true,
+
+			assertErr: require.NoError,
+			assertLog: log.Empty,
+		},
+		{
+			name: "success - image_locations extension not supported, check skipped",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{
+					Value: inventory.InventoryAggregates{},
+				},
+			},
+			incusClientHasExtension: false,
+
+			assertErr: require.NoError,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - client.IncusClient",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			clientIncusClientErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - incusClient.GetClusterMember",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			incusClientGetClusterMemberErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - incusClient.UpdateClusterMember",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			incusClientUpdateClusterMemberErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - incusClient.GetServer",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			incusClientGetServerErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - incusClient.UpdateServer",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			incusClientUpdateServerErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - incusClient.DeleteStoragePoolVolume",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			incusClientDeleteStoragePoolVolumeErrs: queue.Errs{
+				incusapi.StatusErrorf(404, "Storage pool not found"),
+				boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - serverSvc.FactoryResetByName",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			serverSvcFactoryResetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - incusClient.DeleteClusterMember",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "serverOne",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "serverTwo",
+				},
+			},
+			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				{},
+			},
+			incusClientDeleteClusterMemberErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, true, true)
+			require.NoError(t, err)
+
+			repo := &mock.ClusterRepoMock{}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
+					return tc.serverSvcUpdateErr.PopOrNil(t)
+				},
+				FactoryResetByNameFunc: func(ctx context.Context, name string, tokenID *uuid.UUID, tokenSeedName *string, force bool) error {
+					return tc.serverSvcFactoryResetByNameErr
+				},
+			}
+
+			var incusClient *adapterMock.InstanceServerMock
+			incusClient = &adapterMock.InstanceServerMock{
+				HasExtensionFunc: func(extension string) bool {
+					return tc.incusClientHasExtension
+				},
+				GetClusterMemberFunc: func(name string) (*incusapi.ClusterMember, string, error) {
+					return &incusapi.ClusterMember{}, "", tc.incusClientGetClusterMemberErr
+				},
+				UpdateClusterMemberFunc: func(name string, member incusapi.ClusterMemberPut, ETag string) error {
+					return tc.incusClientUpdateClusterMemberErr
+				},
+				UseTargetFunc: func(name string) incusclient.InstanceServer {
+					return incusClient
+				},
+				GetServerFunc: func() (*incusapi.Server, string, error) {
+					return &incusapi.Server{
+						ServerUntrusted: incusapi.ServerUntrusted{
+							ServerPut: incusapi.ServerPut{
+								Config: incusapi.ConfigMap{
+									"storage.backups": "local/backups",
+									"storage.images":  "local/images",
+									"storage.logs":    "local/logs",
+								},
+							},
+						},
+					}, "", tc.incusClientGetServerErr
+				},
+				UpdateServerFunc: func(server incusapi.ServerPut, ETag string) error {
+					return tc.incusClientUpdateServerErr
+				},
+				DeleteStoragePoolVolumeFunc: func(pool, volType, name string) error {
+					return tc.incusClientDeleteStoragePoolVolumeErrs.PopOrNil(t)
+				},
+				DeleteClusterMemberFunc: func(name string, force bool) error {
+					return tc.incusClientDeleteClusterMemberErr
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (provisioning.InstanceServer, error) {
+					return incusClient, tc.clientIncusClientErr
+				},
+			}
+
+			inventorySvc := &inventoryServiceMock.InventoryAggregateServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter inventory.InventoryAggregateFilter) (inventory.InventoryAggregates, error) {
+					return queue.PopRetainLast(t, &tc.inventorySvcGetAllWithFilter)
+				},
+			}
+
+			inventorySyncer := &serviceMock.InventorySyncerMock{
+				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
+					return tc.inventorySyncerErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(
+				repo,
+				nil,
+				client,
+				serverSvc,
+				nil,
+				map[domain.ResourceType]provisioning.InventorySyncer{
+					"test": inventorySyncer,
+				},
+				nil,
+				inventorySvc,
+				provisioningCluster.WithRemoveServerFactoryResetWaitDelay(0),
+				provisioningCluster.WithRemoveServerDeleteClusterMemberRetryDelay(0),
+			)
+
+			// Context with timeout for incusClient.DeleteClusterMember test.
+			ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+			defer cancel()
+
+			// Run test
+			err = clusterSvc.RemoveServer(ctx, "one", []string{"serverOne"})
+
+			// Assert
+			tc.assertErr(t, err)
+			tc.assertLog(t, logBuf)
+		})
+	}
+}
+
+func TestClusterService_ReplaceServer(t *testing.T) {
+	offlineServer := provisioning.Server{
+		Name:    "serverOne",
+		Cluster: ptr.To("one"),
+		Status:  api.ServerStatusOffline,
+	}
+
+	remainingServer := provisioning.Server{
+		Name:    "serverTwo",
+		Cluster: ptr.To("one"),
+		Status:  api.ServerStatusReady,
+		VersionData: api.ServerVersionData{
+			OS: api.OSVersionData{
+				Name:    "os",
+				Version: "1",
+			},
+			Applications: []api.ApplicationVersionData{
+				{
+					Name:    "incus",
+					Version: "1",
+				},
+			},
+		},
+	}
+
+	terraformConfiguration := &provisioning.ClusterArtifact{
+		Cluster: "one",
+		Name:    "terraform-configuration",
+		Files: provisioning.ClusterArtifactFiles{
+			{
+				Name: "resources_server.tf",
+				Open: func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewBufferString(`resource "incus_server" "this_per_node" {
+  for_each = local.members
+
+  config = {
+    "storage.images_volume" = "shared"
+  }
+
+  target = each.key
+}
+`)), nil
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                                     string
+		argServerName                            string
+		argReplacementServerName                 string
+		repoGetByNameErr                         error
+		repoCluster                              provisioning.Cluster
+		repoUpdateErr                            queue.Errs
+		serverSvcGetAllWithFilter                []queue.Item[provisioning.Servers]
+		serverSvcGetByName                       []queue.Item[*provisioning.Server]
+		serverSvcUpdateErr                       queue.Errs
+		abortDuringRemoval                       bool
+		clientIncusClientErr                     error
+		incusClientDeleteClusterMemberErr        error
+		artifactsRepoGetClusterArtifactByName    *provisioning.ClusterArtifact
+		artifactsRepoGetClusterArtifactByNameErr error
+		clientSetServerConfigErr                 queue.Errs
+
+		assertErr            require.ErrorAssertionFunc
+		wantInProgressStatus api.ClusterUpdateInProgress
+		wantSetServerConfig  map[string]string
+	}{
+		{
+			name:                     "success",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+				// Join of the replacement server.
+				{Value: provisioning.Servers{remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Join of the replacement server.
+				{Value: readyServerForClustering(4, "serverFour")},
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Member specific configuration.
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			artifactsRepoGetClusterArtifactByName: terraformConfiguration,
+
+			assertErr:            require.NoError,
+			wantInProgressStatus: api.ClusterUpdateInProgressInactive,
+			wantSetServerConfig: map[string]string{
+				"storage.images_volume": "shared",
+			},
+		},
+		{
+			name:                     "success - without terraform configuration",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+				// Join of the replacement server.
+				{Value: provisioning.Servers{remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Join of the replacement server.
+				{Value: readyServerForClustering(4, "serverFour")},
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			artifactsRepoGetClusterArtifactByNameErr: domain.ErrNotFound,
+
+			assertErr:            require.NoError,
+			wantInProgressStatus: api.ClusterUpdateInProgressInactive,
+			wantSetServerConfig: map[string]string{
+				// Set while joining the replacement server.
+				"storage.backups_volume": "local/backups",
+				"storage.images_volume":  "local/images",
+				"storage.logs_volume":    "local/logs",
+			},
+		},
+		{
+			name:                     "error - empty server name",
+			argServerName:            "",
+			argReplacementServerName: "serverFour",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name:                     "error - server replaces itself",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverOne",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name:                     "error - repo.GetByName",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			repoGetByNameErr:         boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                     "error - operation in progress",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			repoCluster: provisioning.Cluster{
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressRollingUpdate,
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+			wantInProgressStatus: api.ClusterUpdateInProgressRollingUpdate,
+		},
+		{
+			name:                     "error - serverSvc.GetAllWithFilter",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Err: boom.Error},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                     "error - server not part of cluster",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{remainingServer}},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrNotFound, a...)
+			},
+		},
+		{
+			name:                     "error - server not offline",
+			argServerName:            "serverTwo",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, `Server "serverTwo" is not offline`)
+			},
+		},
+		{
+			name:                     "error - no remaining servers",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer}},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                     "error - serverSvc.GetByName",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Err: boom.Error},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                     "error - replacement server not ready",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Value: &provisioning.Server{
+						Name:   "serverFour",
+						Status: api.ServerStatusPending, // not ready
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                     "error - repo.Update",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			repoUpdateErr: queue.Errs{
+				boom.Error,
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                     "error - client.IncusClient",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			clientIncusClientErr: boom.Error,
+
+			assertErr:            boom.ErrorIs,
+			wantInProgressStatus: api.ClusterUpdateInProgressError,
+		},
+		{
+			name:                     "error - incusClient.DeleteClusterMember",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			incusClientDeleteClusterMemberErr: boom.Error,
+
+			assertErr:            boom.ErrorIs,
+			wantInProgressStatus: api.ClusterUpdateInProgressError,
+		},
+		{
+			name:                     "error - serverSvc.Update",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			serverSvcUpdateErr: queue.Errs{
+				boom.Error,
+			},
+
+			assertErr:            boom.ErrorIs,
+			wantInProgressStatus: api.ClusterUpdateInProgressError,
+		},
+		{
+			name:                     "error - canceled",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			abortDuringRemoval: true,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, "has been canceled")
+			},
+			wantInProgressStatus: api.ClusterUpdateInProgressInactive,
+		},
+		{
+			name:                     "error - join of replacement server",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Join of the replacement server.
+				{Err: boom.Error},
+			},
+
+			assertErr:            boom.ErrorIs,
+			wantInProgressStatus: api.ClusterUpdateInProgressError,
+		},
+		{
+			name:                     "error - artifactsRepo.GetClusterArtifactByName",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+				// Join of the replacement server.
+				{Value: provisioning.Servers{remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Join of the replacement server.
+				{Value: readyServerForClustering(4, "serverFour")},
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			artifactsRepoGetClusterArtifactByNameErr: boom.Error,
+
+			assertErr:            boom.ErrorIs,
+			wantInProgressStatus: api.ClusterUpdateInProgressError,
+			wantSetServerConfig: map[string]string{
+				// Set while joining the replacement server.
+				"storage.backups_volume": "local/backups",
+				"storage.images_volume":  "local/images",
+				"storage.logs_volume":    "local/logs",
+			},
+		},
+		{
+			name:                     "error - client.SetServerConfig",
+			argServerName:            "serverOne",
+			argReplacementServerName: "serverFour",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{Value: provisioning.Servers{offlineServer, remainingServer}},
+				// Join of the replacement server.
+				{Value: provisioning.Servers{remainingServer}},
+			},
+			serverSvcGetByName: []queue.Item[*provisioning.Server]{
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Join of the replacement server.
+				{Value: readyServerForClustering(4, "serverFour")},
+				{Value: readyServerForClustering(4, "serverFour")},
+				// Member specific configuration.
+				{Value: readyServerForClustering(4, "serverFour")},
+			},
+			artifactsRepoGetClusterArtifactByName: terraformConfiguration,
+			clientSetServerConfigErr: queue.Errs{
+				nil, // Join of the replacement server.
+				boom.Error,
+			},
+
+			assertErr:            boom.ErrorIs,
+			wantInProgressStatus: api.ClusterUpdateInProgressError,
+			wantSetServerConfig: map[string]string{
+				"storage.images_volume": "shared",
+			},
+		},
+	}
+
+	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			storedCluster := tc.repoCluster
+			storedCluster.Name = "one"
+			storedCluster.Channel = "stable"
+
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					if tc.repoGetByNameErr != nil {
+						return nil, tc.repoGetByNameErr
+					}
+
+					cluster := storedCluster
+					return &cluster, nil
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
+
+					err := tc.repoUpdateErr.PopOrNil(t)
+					if err != nil {
+						return err
+					}
+
+					storedCluster = cluster
+					return nil
+				},
+			}
+
+			artifactsRepo := &mock.ClusterArtifactRepoMock{
+				GetClusterArtifactByNameFunc: func(ctx context.Context, clusterName, artifactName string) (*provisioning.ClusterArtifact, error) {
+					return tc.artifactsRepoGetClusterArtifactByName, tc.artifactsRepoGetClusterArtifactByNameErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return queue.Pop(t, &tc.serverSvcGetByName)
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
+					if tc.abortDuringRemoval {
+						storedCluster.UpdateStatus.InProgressStatus = api.ClusterUpdateInProgressStatus{}
+					}
+
+					return tc.serverSvcUpdateErr.PopOrNil(t)
+				},
+			}
+
+			var incusClient *adapterMock.InstanceServerMock
+			incusClient = &adapterMock.InstanceServerMock{
+				DeleteClusterMemberFunc: func(name string, force bool) error {
+					require.Equal(t, "serverOne", name)
+					require.True(t, force)
+					return tc.incusClientDeleteClusterMemberErr
+				},
+				GetClusterFunc: func() (*incusapi.Cluster, string, error) {
+					return &incusapi.Cluster{}, "", nil
+				},
+				UseTargetFunc: func(name string) incusclient.InstanceServer {
+					return incusClient
+				},
+				CreateStoragePoolVolumeFunc: func(pool string, volume incusapi.StorageVolumesPost) error {
+					return nil
+				},
+			}
+
+			var gotSetServerConfig map[string]string
+
+			client := &adapterMock.ClusterClientPortMock{
+				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (incusclient.InstanceServer, error) {
+					return incusClient, tc.clientIncusClientErr
+				},
+				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
+					return incusosapi.SystemNetwork{
+						Config: &incusosapi.SystemNetworkConfig{},
+					}, nil
+				},
+				GetStorageConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemStorage, error) {
+					return provisioning.ServerSystemStorage{}, nil
+				},
+				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
+					return incusosapi.ServiceLVM{}, nil
+				},
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					return incusosapi.ServiceISCSI{}, nil
+				},
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return incusosapi.ServiceMultipath{}, nil
+				},
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					return incusosapi.ServiceNVME{}, nil
+				},
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					return incusosapi.ServiceCeph{}, nil
+				},
+				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
+					return incusosapi.ServiceLinstor{}, nil
+				},
+				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
+					return incusosapi.ServiceOVN{}, nil
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					return nil
+				},
+				GetClusterJoinTokenFunc: func(ctx context.Context, endpoint provisioning.Endpoint, memberName string) (string, error) {
+					return "token", nil
+				},
+				JoinClusterFunc: func(ctx context.Context, server provisioning.Server, joinToken, serverAddressOfClusterRole string, endpoint provisioning.Endpoint, config []api.ClusterMemberConfigKey) error {
+					return nil
+				},
+				GetOSDataFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.OSData, error) {
+					return api.OSData{}, nil
+				},
+				SetServerConfigFunc: func(ctx context.Context, endpoint provisioning.Endpoint, config map[string]string) error {
+					gotSetServerConfig = config
+					return tc.clientSetServerConfigErr.PopOrNil(t)
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(
+				repo,
+				artifactsRepo,
+				client,
+				serverSvc,
+				nil,
+				nil,
+				nil,
+				nil,
+				provisioningCluster.WithNow(func() time.Time {
+					return fixedTime
+				}),
+				provisioningCluster.WithRemoveServerDeleteClusterMemberRetryDelay(0),
+			)
+
+			// Context with timeout for incusClient.DeleteClusterMember test.
+			ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+			defer cancel()
+
+			// Run test
+			err := clusterSvc.ReplaceServer(ctx, "one", tc.argServerName, tc.argReplacementServerName)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantInProgressStatus, storedCluster.UpdateStatus.InProgressStatus.InProgress)
+			require.Equal(t, tc.wantSetServerConfig, gotSetServerConfig)
+
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.serverSvcGetByName)
+			require.Empty(t, tc.repoUpdateErr)
+			require.Empty(t, tc.serverSvcUpdateErr)
+			require.Empty(t, tc.clientSetServerConfigErr)
+		})
+	}
+}
+
+func TestClusterService_PlanClusterSpec(t *testing.T) {
+	toJSON := func(value any) string {
+		body, err := json.Marshal(value)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	loggingConfig := incusosapi.SystemLogging{
+		Config: incusosapi.SystemLoggingConfig{
+			Syslog: incusosapi.SystemLoggingSyslog{
+				Address: "localhost",
+			},
+		},
+	}
+
+	serverWithVLANTags := func(name string, vlanTags ...int) provisioning.Server {
+		return provisioning.Server{
+			Name:    name,
+			Cluster: ptr.To("one"),
+			Status:  api.ServerStatusReady,
+			OSData: api.OSData{
+				Network: incusosapi.SystemNetwork{
+					Config: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "eth0",
+								VLANTags: vlanTags,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cluster := &provisioning.Cluster{
+		Name:          "one",
+		ConnectionURL: "https://one",
+		Channel:       "stable",
+		Description:   "old",
+		Status:        api.ClusterStatusReady,
+	}
+
+	tests := []struct {
+		name                         string
+		specArg                      api.ClusterSpec
+		repoGetByName                *provisioning.Cluster
+		repoGetByNameErr             error
+		serverSvcGetAllWithFilter    provisioning.Servers
+		serverSvcGetAllWithFilterErr error
+		clientGetOSServiceMultipath  []queue.Item[incusosapi.ServiceMultipath]
+		serverSvcGetSystemLogging    []queue.Item[provisioning.ServerSystemLogging]
+		serverSvcGetSystemKernel     []queue.Item[provisioning.ServerSystemKernel]
+		templateSvcApplyErr          error
+		templateSvcGetRevision       *provisioning.ClusterTemplateRevision
+		templateSvcGetRevisionErr    error
+
+		assertErr            require.ErrorAssertionFunc
+		wantTemplateSvcApply bool
+		wantPlan             api.ClusterSpecPlan
+	}{
+		{
+			name: "success - cluster does not exist",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					Channel: "stable",
+				},
+				ServerNames: []string{"serverOne", "serverTwo"},
+			},
+			repoGetByNameErr: domain.ErrNotFound,
+
+			assertErr: require.NoError,
+			wantPlan: api.ClusterSpecPlan{
+				Name: "one",
+				Changes: []api.ClusterSpecChange{
+					{
+						Action:  api.ClusterSpecChangeActionCreateCluster,
+						Subject: "one",
+						Desired: "serverOne, serverTwo",
+					},
+				},
+			},
+		},
+		{
+			name: "success - cluster does not exist - cluster template",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					Channel: "stable",
+				},
+				ServerNames:     []string{"serverOne", "serverTwo"},
+				ClusterTemplate: "template",
+			},
+			repoGetByNameErr: domain.ErrNotFound,
+
+			assertErr:            require.NoError,
+			wantTemplateSvcApply: true,
+			wantPlan: api.ClusterSpecPlan{
+				Name: "one",
+				Changes: []api.ClusterSpecChange{
+					{
+						Action:  api.ClusterSpecChangeActionCreateCluster,
+						Subject: "one",
+						Desired: "serverOne, serverTwo",
+					},
+				},
+			},
+		},
+		{
+			name: "success - cluster template variable values with defaults of recorded revision",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					ConnectionURL: "https://one",
+					Channel:       "stable",
+					Description:   "old",
+				},
+				ServerNames:     []string{"serverOne"},
+				ClusterTemplate: "template",
+				ClusterTemplateVariableValues: api.ConfigMap{
+					"WWN": "a",
+				},
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:                    "one",
+				ConnectionURL:           "https://one",
+				Channel:                 "stable",
+				Description:             "old",
+				ClusterTemplate:         "template",
+				ClusterTemplateRevision: 1,
+				ClusterTemplateVariableValues: api.ConfigMap{
+					"WWN":    "a",
+					"DOMAIN": "example.com",
+				},
+			},
+			templateSvcGetRevision: &provisioning.ClusterTemplateRevision{
+				ClusterTemplate: "template",
+				Revision:        1,
+				Variables: api.ClusterTemplateVariables{
+					"WWN": {},
+					"DOMAIN": {
+						DefaultValue: "example.com",
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: require.NoError,
+			wantPlan: api.ClusterSpecPlan{
+				Name:    "one",
+				Changes: []api.ClusterSpecChange{},
+			},
+		},
+		{
+			name: "success - services config matches recorded services config",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					ConnectionURL: "https://one",
+					Channel:       "stable",
+					Description:   "old",
+				},
+				ServerNames: []string{"serverOne"},
+				ServicesConfig: map[string]any{
+					"multipath": map[string]any{
+						"enabled": true,
+						"wwns":    []any{"a"},
+					},
+				},
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "https://one",
+				Channel:       "stable",
+				Description:   "old",
+				ServicesConfig: provisioning.ClusterServicesConfig{
+					"multipath": map[string]any{
+						"enabled": true,
+						"wwns":    []any{"a"},
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: require.NoError,
+			wantPlan: api.ClusterSpecPlan{
+				Name:    "one",
+				Changes: []api.ClusterSpecChange{},
+			},
+		},
+		{
+			name: "success - cluster matches spec",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					ConnectionURL: "https://one",
+					Channel:       "stable",
+					Description:   "old",
+				},
+				ServerNames: []string{"serverOne"},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: require.NoError,
+			wantPlan: api.ClusterSpecPlan{
+				Name:    "one",
+				Changes: []api.ClusterSpecChange{},
+			},
+		},
+		{
+			name: "success - services config matches live config",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					ConnectionURL: "https://one",
+					Channel:       "stable",
+					Description:   "old",
+				},
+				ServerNames: []string{"serverOne"},
+				ServicesConfig: map[string]any{
+					"multipath": map[string]any{
+						"enabled": true,
+						"wwns":    []any{"a"},
+					},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"a"},
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			wantPlan: api.ClusterSpecPlan{
+				Name:    "one",
+				Changes: []api.ClusterSpecChange{},
+			},
+		},
+		{
+			name: "success - all changes",
+			specArg: api.ClusterSpec{
+				Name: "one",
+				ClusterPut: api.ClusterPut{
+					ConnectionURL: "https://one",
+					Channel:       "stable",
+					Description:   "new",
+				},
+				ServerNames: []string{"serverOne", "serverThree"},
+				StorageTargets: api.ClusterSpecStorageTargets{
+					Multipath: []string{"b"},
+				},
+				NetworkVLANTags: map[string][]int{
+					"eth0": {10, 20},
+				},
+				Logging: &loggingConfig,
+				Kernel:  &incusosapi.SystemKernel{},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne", 10, 30),
+				serverWithVLANTags("serverTwo"),
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							WWNs: []string{"a"},
+						},
+					},
+				},
+			},
+			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
+				{},
+			},
+			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
+				{},
+			},
+
+			assertErr: require.NoError,
+			wantPlan: api.ClusterSpecPlan{
+				Name: "one",
+				Changes: []api.ClusterSpecChange{
+					{
+						Action:  api.ClusterSpecChangeActionUpdateCluster,
+						Subject: "description",
+						Current: "old",
+						Desired: "new",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionAddServers,
+						Subject: "serverThree",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionRemoveServers,
+						Subject: "serverTwo",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionAddMultipathStorageTarget,
+						Subject: "b",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionRemoveMultipathStorageTarget,
+						Subject: "a",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionAddNetworkInterfaceVLANTags,
+						Subject: "eth0",
+						Current: "10, 30",
+						Desired: "10, 20",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionRemoveNetworkInterfaceVLANTags,
+						Subject: "eth0",
+						Current: "10, 30",
+						Desired: "10, 20",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionUpdateSystemLogging,
+						Subject: "serverOne",
+						Current: toJSON(incusosapi.SystemLogging{}),
+						Desired: toJSON(loggingConfig),
+					},
+				},
+			},
+		},
+		{
+			name: "error - validation name",
+			specArg: api.ClusterSpec{
+				ServerNames: []string{"serverOne"},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - validation server names",
+			specArg: api.ClusterSpec{
+				Name: "one",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - repo.GetByName",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+			},
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - serverSvc.GetAllWithFilter",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+			},
+			repoGetByName:                cluster,
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - cluster template changed",
+			specArg: api.ClusterSpec{
+				Name:            "one",
+				ServerNames:     []string{"serverOne"},
+				ClusterTemplate: "template",
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `cluster template of existing cluster "one" can not be changed from "" to "template"`, a...)
+			},
+		},
+		{
+			name: "error - cluster template variable values changed",
+			specArg: api.ClusterSpec{
+				Name:            "one",
+				ServerNames:     []string{"serverOne"},
+				ClusterTemplate: "template",
+				ClusterTemplateVariableValues: api.ConfigMap{
+					"WWN": "b",
+				},
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:            "one",
+				ClusterTemplate: "template",
+				ClusterTemplateVariableValues: api.ConfigMap{
+					"WWN": "a",
+				},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `cluster template variable values of existing cluster "one" can not be changed`, a...)
+			},
+		},
+		{
+			name: "error - services config changed",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				ServicesConfig: map[string]any{
+					"multipath": map[string]any{
+						"enabled": true,
+						"wwns":    []any{"b"},
+					},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"a"},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `services config of existing cluster "one" can not be changed, key "wwns" of multipath service config differs from server "serverOne"`, a...)
+			},
+		},
+		{
+			name: "error - services config differs from recorded services config",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				ServicesConfig: map[string]any{
+					"multipath": map[string]any{
+						"enabled": true,
+					},
+				},
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name: "one",
+				ServicesConfig: provisioning.ClusterServicesConfig{
+					"multipath": map[string]any{
+						"enabled": true,
+						"wwns":    []any{"a"},
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `services config of existing cluster "one" can not be changed, key "multipath.wwns" differs from the services config the cluster has been created with`, a...)
+			},
+		},
+		{
+			name: "error - cluster does not exist - templateSvc.Apply",
+			specArg: api.ClusterSpec{
+				Name:            "one",
+				ServerNames:     []string{"serverOne"},
+				ClusterTemplate: "template",
+			},
+			repoGetByNameErr:    domain.ErrNotFound,
+			templateSvcApplyErr: boom.Error,
+
+			assertErr:            boom.ErrorIs,
+			wantTemplateSvcApply: true,
+		},
+		{
+			name: "error - templateSvc.GetRevision",
+			specArg: api.ClusterSpec{
+				Name:            "one",
+				ServerNames:     []string{"serverOne"},
+				ClusterTemplate: "template",
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:            "one",
+				ClusterTemplate: "template",
+			},
+			templateSvcGetRevisionErr: boom.Error,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - services config - client.GetOSServiceMultipath",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				ServicesConfig: map[string]any{
+					"multipath": map[string]any{
+						"enabled": true,
+					},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - no remaining servers",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverThree"},
+				StorageTargets: api.ClusterSpecStorageTargets{
+					Multipath: []string{},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name: "error - client.GetOSServiceMultipath",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				StorageTargets: api.ClusterSpecStorageTargets{
+					Multipath: []string{},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - multipath storage targets differ between servers",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne", "serverTwo"},
+				StorageTargets: api.ClusterSpecStorageTargets{
+					Multipath: []string{},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+				serverWithVLANTags("serverTwo"),
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							WWNs: []string{"a"},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							WWNs: []string{"a", "b"},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, `The multipath storage targets of server "serverTwo" differ from server "serverOne"`, a...)
+			},
+		},
+		{
+			name: "error - network interface not found",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				NetworkVLANTags: map[string][]int{
+					"eth1": {10},
+				},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - serverSvc.GetSystemLogging",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				Logging:     &loggingConfig,
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - serverSvc.GetSystemKernel",
+			specArg: api.ClusterSpec{
+				Name:        "one",
+				ServerNames: []string{"serverOne"},
+				Kernel:      &incusosapi.SystemKernel{},
+			},
+			repoGetByName: cluster,
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				serverWithVLANTags("serverOne"),
+			},
+			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceMultipath)
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+				GetSystemLoggingFunc: func(ctx context.Context, name string) (provisioning.ServerSystemLogging, error) {
+					return queue.Pop(t, &tc.serverSvcGetSystemLogging)
+				},
+				GetSystemKernelFunc: func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
+					return queue.Pop(t, &tc.serverSvcGetSystemKernel)
+				},
+			}
+
+			var templateSvcApplied bool
+			templateSvc := &serviceMock.ClusterTemplateServiceMock{
+				ApplyFunc: func(ctx context.Context, name string, templateVariables api.ConfigMap) (map[string]any, map[string]any, error) {
+					templateSvcApplied = true
+					return map[string]any{}, map[string]any{}, tc.templateSvcApplyErr
+				},
+				GetRevisionFunc: func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+					if tc.templateSvcGetRevision == nil && tc.templateSvcGetRevisionErr == nil {
+						return nil, domain.ErrNotFound
+					}
+
+					return tc.templateSvcGetRevision, tc.templateSvcGetRevisionErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil,
+				provisioningCluster.WithClusterTemplateService(templateSvc),
+			)
+
+			// Run test
+			plan, err := clusterSvc.PlanClusterSpec(context.Background(), tc.specArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantPlan, plan)
+			require.Equal(t, tc.wantTemplateSvcApply, templateSvcApplied)
+			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.serverSvcGetSystemLogging)
+			require.Empty(t, tc.serverSvcGetSystemKernel)
+		})
+	}
+}
+
+func TestClusterService_ApplyClusterSpec(t *testing.T) {
+	server := provisioning.Server{
+		Name:         "serverOne",
+		Cluster:      ptr.To("one"),
+		Status:       api.ServerStatusReady,
+		StatusDetail: api.ServerStatusDetailNone,
+		VersionData: api.ServerVersionData{
+			InMaintenance: ptr.To(api.NotInMaintenance),
+		},
+	}
+
+	spec := api.ClusterSpec{
+		Name: "one",
+		ClusterPut: api.ClusterPut{
+			ConnectionURL: "https://one",
+			Channel:       "stable",
+			Description:   "new",
+		},
+		ServerNames: []string{"serverOne"},
+		StorageTargets: api.ClusterSpecStorageTargets{
+			Multipath: []string{"a"},
+		},
+	}
+
+	tests := []struct {
+		name                        string
+		repoGetByNameErr            error
+		repoUpdateErr               queue.Errs
+		serverSvcGetAllWithFilter   []queue.Item[provisioning.Servers]
+		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
+		clientUpdateOSServiceErr    queue.Errs
+
+		assertErr       require.ErrorAssertionFunc
+		wantDescription string
+		wantPlan        api.ClusterSpecPlan
+	}{
+		{
+			name: "success",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// clusterSpecSteps
+				{Value: provisioning.Servers{server}},
+				// GetByName
+				{},
+				// AddStorageTargetMultipath
+				{Value: provisioning.Servers{server}},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				// clusterSpecSteps
+				{},
+				// AddStorageTargetMultipath
+				{},
+			},
+			clientUpdateOSServiceErr: queue.Errs{
+				nil,
+			},
+
+			assertErr:       require.NoError,
+			wantDescription: "new",
+			wantPlan: api.ClusterSpecPlan{
+				Name: "one",
+				Changes: []api.ClusterSpecChange{
+					{
+						Action:  api.ClusterSpecChangeActionUpdateCluster,
+						Subject: "description",
+						Current: "old",
+						Desired: "new",
+					},
+					{
+						Action:  api.ClusterSpecChangeActionAddMultipathStorageTarget,
+						Subject: "a",
+					},
+				},
+			},
+		},
+		{
+			name:             "error - repo.GetByName",
+			repoGetByNameErr: boom.Error,
+
+			assertErr:       boom.ErrorIs,
+			wantDescription: "old",
+		},
+		{
+			name: "error - Update",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// clusterSpecSteps
+				{Value: provisioning.Servers{server}},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				// clusterSpecSteps
+				{},
+			},
+			repoUpdateErr: queue.Errs{
+				boom.Error,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, boom.Error, a...)
+				require.ErrorContains(tt, err, `Failed to apply update_cluster (description) of spec for cluster "one"`, a...)
+			},
+			wantDescription: "old",
+		},
+		{
+			name: "error - AddStorageTargetMultipath",
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// clusterSpecSteps
+				{Value: provisioning.Servers{server}},
+				// GetByName
+				{},
+				// AddStorageTargetMultipath
+				{Value: provisioning.Servers{server}},
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				// clusterSpecSteps
+				{},
+				// AddStorageTargetMultipath
+				{},
+			},
+			clientUpdateOSServiceErr: queue.Errs{
+				boom.Error,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, boom.Error, a...)
+				require.ErrorContains(tt, err, `Failed to apply add_multipath_storage_target (a) of spec for cluster "one"`, a...)
+			},
+			wantDescription: "new",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			cluster := provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "https://one",
+				Channel:       "stable",
+				Description:   "old",
+				Status:        api.ClusterStatusReady,
+			}
+
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					if tc.repoGetByNameErr != nil {
+						return nil, tc.repoGetByNameErr
+					}
+
+					c := cluster
+					return &c, nil
+				},
+				UpdateFunc: func(ctx context.Context, in provisioning.Cluster) error {
+					err := tc.repoUpdateErr.PopOrNil(t)
+					if err != nil {
+						return err
+					}
+
+					cluster = in
+					return nil
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceMultipath)
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					return tc.clientUpdateOSServiceErr.PopOrNil(t)
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return nil
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			plan, err := clusterSvc.ApplyClusterSpec(context.Background(), spec)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantPlan, plan)
+			require.Equal(t, tc.wantDescription, cluster.Description)
+			require.Empty(t, tc.repoUpdateErr)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientUpdateOSServiceErr)
+		})
+	}
+}
+
+func TestClusterService_GetClusterTemplateDrift(t *testing.T) {
+	templatedCluster := provisioning.Cluster{
+		Name:                    "one",
+		ClusterTemplate:         "template",
+		ClusterTemplateRevision: 2,
+		ClusterTemplateVariableValues: api.ConfigMap{
+			"WWN": "a",
+		},
+	}
+
+	servers := provisioning.Servers{
+		{
+			Name:    "serverOne",
+			Cluster: ptr.To("one"),
+		},
+	}
+
+	servicesConfig := map[string]any{
+		"lvm": map[string]any{
+			"enabled":   true,
+			"system_id": 1,
+		},
+		"multipath": map[string]any{
+			"config": map[string]any{
+				"enabled": true,
+				"wwns":    []any{"a"},
+			},
+		},
+		"unknown": map[string]any{
+			"enabled": true,
+		},
+	}
+
+	tests := []struct {
+		name                         string
+		nameArg                      string
+		repoGetByNameCluster         provisioning.Cluster
+		repoGetByNameErr             error
+		templateVariables            api.ClusterTemplateVariables
+		revisionVariables            api.ClusterTemplateVariables
+		templateSvcGetByNameErr      error
+		templateSvcGetRevisionErr    error
+		templateSvcApplyErr          error
+		serverSvcGetAllWithFilter    provisioning.Servers
+		serverSvcGetAllWithFilterErr error
+		clientGetOSServiceLVM        incusosapi.ServiceLVM
+		clientGetOSServiceLVMErr     error
+		clientGetOSServiceMultipath  incusosapi.ServiceMultipath
+
+		assertErr require.ErrorAssertionFunc
+		want      api.ClusterTemplateDrift
+	}{
+		{
+			name:                      "success",
+			nameArg:                   "one",
+			repoGetByNameCluster:      templatedCluster,
+			templateVariables:         api.ClusterTemplateVariables{"WWN": {}},
+			serverSvcGetAllWithFilter: servers,
+			clientGetOSServiceLVM: incusosapi.ServiceLVM{
+				Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 3},
+			},
+			clientGetOSServiceMultipath: incusosapi.ServiceMultipath{
+				Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"b"}},
+			},
+
+			assertErr: require.NoError,
+			want: api.ClusterTemplateDrift{
+				Cluster: "one",
+				Template: api.ClusterTemplateReference{
+					Name:     "template",
+					Revision: 2,
+					VariableValues: api.ConfigMap{
+						"WWN": "a",
+					},
+				},
+				CurrentRevision: 3,
+				Outdated:        true,
+				Differences: []api.ClusterTemplateDriftDifference{
+					{
+						Server:   "serverOne",
+						Service:  "multipath",
+						Key:      "wwns",
+						Expected: `["a"]`,
+						Actual:   `["b"]`,
+					},
+				},
+			},
+		},
+		{
+			name:                      "success - secret variable masked",
+			nameArg:                   "one",
+			repoGetByNameCluster:      templatedCluster,
+			templateVariables:         api.ClusterTemplateVariables{"WWN": {Secret: true}},
+			serverSvcGetAllWithFilter: servers,
+			clientGetOSServiceLVM: incusosapi.ServiceLVM{
+				Config: incusosapi.ServiceLVMConfig{Enabled: true},
+			},
+			clientGetOSServiceMultipath: incusosapi.ServiceMultipath{
+				Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"b"}},
+			},
+
+			assertErr: require.NoError,
+			want: api.ClusterTemplateDrift{
+				Cluster: "one",
+				Template: api.ClusterTemplateReference{
+					Name:     "template",
+					Revision: 2,
+					VariableValues: api.ConfigMap{
+						"WWN": provisioning.ClusterTemplateSecretMask,
+					},
+				},
+				CurrentRevision: 3,
+				Outdated:        true,
+				Differences: []api.ClusterTemplateDriftDifference{
+					{
+						Server:   "serverOne",
+						Service:  "multipath",
+						Key:      "wwns",
+						Expected: provisioning.ClusterTemplateSecretMask,
+						Actual:   provisioning.ClusterTemplateSecretMask,
+					},
+				},
+			},
+		},
+		{
+			name:                      "success - secret variable of recorded revision masked",
+			nameArg:                   "one",
+			repoGetByNameCluster:      templatedCluster,
+			templateVariables:         api.ClusterTemplateVariables{"WWN": {}},
+			revisionVariables:         api.ClusterTemplateVariables{"WWN": {Secret: true}},
+			serverSvcGetAllWithFilter: servers,
+			clientGetOSServiceLVM: incusosapi.ServiceLVM{
+				Config: incusosapi.ServiceLVMConfig{Enabled: true},
+			},
+			clientGetOSServiceMultipath: incusosapi.ServiceMultipath{
+				Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"b"}},
+			},
+
+			assertErr: require.NoError,
+			want: api.ClusterTemplateDrift{
+				Cluster: "one",
+				Template: api.ClusterTemplateReference{
+					Name:     "template",
+					Revision: 2,
+					VariableValues: api.ConfigMap{
+						"WWN": provisioning.ClusterTemplateSecretMask,
+					},
+				},
+				CurrentRevision: 3,
+				Outdated:        true,
+				Differences: []api.ClusterTemplateDriftDifference{
+					{
+						Server:   "serverOne",
+						Service:  "multipath",
+						Key:      "wwns",
+						Expected: provisioning.ClusterTemplateSecretMask,
+						Actual:   provisioning.ClusterTemplateSecretMask,
+					},
+				},
+			},
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - cluster without template",
+			nameArg: "one",
+			repoGetByNameCluster: provisioning.Cluster{
+				Name: "one",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, `Cluster "one" has not been created from a cluster template`, a...)
+			},
+		},
+		{
+			name:                    "error - templateSvc.GetByName",
+			nameArg:                 "one",
+			repoGetByNameCluster:    templatedCluster,
+			templateSvcGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                      "error - templateSvc.GetRevision",
+			nameArg:                   "one",
+			repoGetByNameCluster:      templatedCluster,
+			templateSvcGetRevisionErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                 "error - templateSvc.Apply",
+			nameArg:              "one",
+			repoGetByNameCluster: templatedCluster,
+			templateSvcApplyErr:  boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                         "error - serverSvc.GetAllWithFilter",
+			nameArg:                      "one",
+			repoGetByNameCluster:         templatedCluster,
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                      "error - client.GetOSServiceLVM",
+			nameArg:                   "one",
+			repoGetByNameCluster:      templatedCluster,
+			serverSvcGetAllWithFilter: servers,
+			clientGetOSServiceLVMErr:  boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return &tc.repoGetByNameCluster, tc.repoGetByNameErr
+				},
+			}
+
+			templateSvc := &serviceMock.ClusterTemplateServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterTemplate, error) {
+					require.Equal(t, "template", name)
+					return &provisioning.ClusterTemplate{Name: name, Revision: 3, Variables: tc.templateVariables}, tc.templateSvcGetByNameErr
+				},
+				GetRevisionFunc: func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+					require.Equal(t, "template", name)
+					require.Equal(t, int64(2), revision)
+					revisionVariables := tc.revisionVariables
+					if revisionVariables == nil {
+						revisionVariables = tc.templateVariables
+					}
+
+					return &provisioning.ClusterTemplateRevision{ClusterTemplate: name, Revision: revision, Variables: revisionVariables}, tc.templateSvcGetRevisionErr
+				},
+				ApplyFunc: func(ctx context.Context, name string, templateVariables api.ConfigMap) (map[string]any, map[string]any, error) {
+					require.Equal(t, tc.repoGetByNameCluster.ClusterTemplateVariableValues, templateVariables)
+					templateVariables["DEFAULT"] = "value"
+					return servicesConfig, nil, tc.templateSvcApplyErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					require.Equal(t, ptr.To("one"), filter.Cluster)
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
+					return tc.clientGetOSServiceLVM, tc.clientGetOSServiceLVMErr
+				},
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return tc.clientGetOSServiceMultipath, nil
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil, provisioningCluster.WithClusterTemplateService(templateSvc))
+
+			// Run test
+			drift, err := clusterSvc.GetClusterTemplateDrift(context.Background(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, drift)
+			require.NotContains(t, tc.repoGetByNameCluster.ClusterTemplateVariableValues, "DEFAULT")
+		})
+	}
+}
+
+func TestClusterService_GetClusterConsistency(t *testing.T) {
+	newServer := func(name string, address string, osVersion string, incusVersion string) provisioning.Server {
+		return provisioning.Server{
+			Name:    name,
+			Cluster: ptr.To("one"),
+			Status:  api.ServerStatusReady,
+			VersionData: api.ServerVersionData{
+				OS: api.OSVersionData{
+					Version: osVersion,
+				},
+				Applications: []api.ApplicationVersionData{
+					{
+						Name:    "incus",
+						Version: incusVersion,
+					},
+					{
+						Name:    "debug",
+						Version: name,
+					},
+				},
+			},
+			OSData: api.OSData{
+				Network: incusosapi.SystemNetwork{
+					State: incusosapi.SystemNetworkState{
+						Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+							"eth0": {
+								Addresses: []string{
+									address,
+								},
+								Roles: []string{
+									"cluster",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	consistentServers := provisioning.Servers{
+		newServer("serverTwo", "10.0.0.2", "2", "1"),
+		newServer("serverOne", "10.0.0.1", "2", "1"),
+	}
+
+	networkConfig := func(vlanTags ...int) provisioning.ServerSystemNetwork {
+		return incusosapi.SystemNetwork{
+			Config: &incusosapi.SystemNetworkConfig{
+				Interfaces: []incusosapi.SystemNetworkInterface{
+					{
+						Name:     "eth0",
+						VLANTags: vlanTags,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                         string
+		nameArg                      string
+		repoGetByNameErr             error
+		serverSvcGetAllWithFilter    provisioning.Servers
+		serverSvcGetAllWithFilterErr error
+		serverSvcGetSystemKernelErr  error
+		serverSvcGetSystemLoggingErr error
+		clientGetNetworkConfig       map[string]provisioning.ServerSystemNetwork
+		clientGetNetworkConfigErr    error
+		clientGetOSServiceLVM        map[string]incusosapi.ServiceLVM
+		clientGetOSServiceMultipath  map[string]incusosapi.ServiceMultipath
+		clientGetOSServiceCephErr    error
+		clientGetOSServiceLinstor    map[string]incusosapi.ServiceLinstor
+		clientGetOSServiceOVN        map[string]incusosapi.ServiceOVN
+
+		assertErr require.ErrorAssertionFunc
+		want      api.ClusterConsistency
+	}{
+		{
+			name:                      "success - consistent",
+			nameArg:                   "one",
+			serverSvcGetAllWithFilter: consistentServers,
+			clientGetNetworkConfig: map[string]provisioning.ServerSystemNetwork{
+				"serverOne": networkConfig(10),
+				"serverTwo": networkConfig(10),
+			},
+			clientGetOSServiceLVM: map[string]incusosapi.ServiceLVM{
+				"serverOne": {Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 1}},
+				"serverTwo": {Config: incusosapi.ServiceLVMConfig{Enabled: true, SystemID: 2}},
+			},
+			clientGetOSServiceLinstor: map[string]incusosapi.ServiceLinstor{
+				"serverOne": {Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.1:3366"}},
+				"serverTwo": {Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.2:3366"}},
+			},
+			clientGetOSServiceOVN: map[string]incusosapi.ServiceOVN{
+				"serverOne": {Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.1"}},
+				"serverTwo": {Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.2"}},
+			},
+
+			assertErr: require.NoError,
+			want: api.ClusterConsistency{
+				Cluster:         "one",
+				ReferenceServer: "serverOne",
+				Consistent:      true,
+				Inconsistencies: []api.ClusterInconsistency{},
+			},
+		},
+		{
+			name:    "success - inconsistent",
+			nameArg: "one",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				newServer("serverOne", "10.0.0.1", "2", "1"),
+				newServer("serverTwo", "10.0.0.2", "3", "2"),
+			},
+			clientGetNetworkConfig: map[string]provisioning.ServerSystemNetwork{
+				"serverOne": networkConfig(10),
+				"serverTwo": networkConfig(10, 20),
+			},
+			clientGetOSServiceMultipath: map[string]incusosapi.ServiceMultipath{
+				"serverOne": {Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"a"}}},
+				"serverTwo": {Config: incusosapi.ServiceMultipathConfig{Enabled: true, WWNs: []string{"b"}}},
+			},
+			clientGetOSServiceOVN: map[string]incusosapi.ServiceOVN{
+				"serverOne": {Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.1"}},
+				"serverTwo": {Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "10.0.0.3"}},
+			},
+
+			assertErr: require.NoError,
+			want: api.ClusterConsistency{
+				Cluster:         "one",
+				ReferenceServer: "serverOne",
+				Consistent:      false,
+				Inconsistencies: []api.ClusterInconsistency{
+					{
+						Server:    "serverTwo",
+						Setting:   "applications.incus",
+						Reference: `"1"`,
+						Actual:    `"2"`,
+					},
+					{
+						Server:    "serverTwo",
+						Setting:   "os_version",
+						Reference: `"2"`,
+						Actual:    `"3"`,
+					},
+					{
+						Server:    "serverTwo",
+						Setting:   "services.multipath.wwns",
+						Reference: `["a"]`,
+						Actual:    `["b"]`,
+					},
+					{
+						Server:    "serverTwo",
+						Setting:   "services.ovn.tunnel_address",
+						Reference: `"10.0.0.2"`,
+						Actual:    `"10.0.0.3"`,
+					},
+					{
+						Server:    "serverTwo",
+						Setting:   "vlan_tags.eth0",
+						Reference: `[10]`,
+						Actual:    `[10,20]`,
+					},
+				},
+			},
+		},
+		{
+			name:    "success - no ready servers",
+			nameArg: "one",
+
+			assertErr: require.NoError,
+			want: api.ClusterConsistency{
+				Cluster:         "one",
+				Consistent:      true,
+				Inconsistencies: []api.ClusterInconsistency{},
+			},
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                         "error - serverSvc.GetAllWithFilter",
+			nameArg:                      "one",
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                      "error - client.GetNetworkConfig",
+			nameArg:                   "one",
+			serverSvcGetAllWithFilter: consistentServers,
+			clientGetNetworkConfigErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                        "error - serverSvc.GetSystemKernel",
+			nameArg:                     "one",
+			serverSvcGetAllWithFilter:   consistentServers,
+			serverSvcGetSystemKernelErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                         "error - serverSvc.GetSystemLogging",
+			nameArg:                      "one",
+			serverSvcGetAllWithFilter:    consistentServers,
+			serverSvcGetSystemLoggingErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                      "error - client.GetOSServiceCeph",
+			nameArg:                   "one",
+			serverSvcGetAllWithFilter: consistentServers,
+			clientGetOSServiceCephErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					require.Equal(t, "one", name)
+					return &provisioning.Cluster{Name: name, Status: api.ClusterStatusReady}, tc.repoGetByNameErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					require.Equal(t, ptr.To("one"), filter.Cluster)
+					require.Equal(t, ptr.To(api.ServerStatusReady), filter.Status)
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+				GetSystemKernelFunc: func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
+					return provisioning.ServerSystemKernel{
+						Config: incusosapi.SystemKernelConfig{
+							BlacklistModules: []string{"foobar"},
+						},
+					}, tc.serverSvcGetSystemKernelErr
+				},
+				GetSystemLoggingFunc: func(ctx context.Context, name string) (provisioning.ServerSystemLogging, error) {
+					return provisioning.ServerSystemLogging{
+						Config: incusosapi.SystemLoggingConfig{
+							Syslog: incusosapi.SystemLoggingSyslog{
+								Address: "localhost",
+							},
+						},
+					}, tc.serverSvcGetSystemLoggingErr
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
+					return tc.clientGetNetworkConfig[server.Name], tc.clientGetNetworkConfigErr
+				},
+				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
+					return tc.clientGetOSServiceLVM[server.Name], nil
+				},
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					return incusosapi.ServiceISCSI{}, nil
+				},
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return tc.clientGetOSServiceMultipath[server.Name], nil
+				},
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					return incusosapi.ServiceNVME{}, nil
+				},
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					return incusosapi.ServiceCeph{}, tc.clientGetOSServiceCephErr
+				},
+				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
+					return tc.clientGetOSServiceLinstor[server.Name], nil
+				},
+				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
+					return tc.clientGetOSServiceOVN[server.Name], nil
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			consistency, err := clusterSvc.GetClusterConsistency(context.Background(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, consistency)
+		})
+	}
+}
+
+func TestClusterService_CheckConsistency(t *testing.T) {
+	servers := provisioning.Servers{
+		{
+			Name:    "serverOne",
+			Cluster: ptr.To("one"),
+			VersionData: api.ServerVersionData{
+				OS: api.OSVersionData{
+					Version: "1",
+				},
+			},
+		},
+		{
+			Name:    "serverTwo",
+			Cluster: ptr.To("one"),
+			VersionData: api.ServerVersionData{
+				OS: api.OSVersionData{
+					Version: "2",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                         string
+		repoGetAll                   provisioning.Clusters
+		repoGetAllErr                error
+		serverSvcGetAllWithFilter    provisioning.Servers
+		serverSvcGetAllWithFilterErr error
+
+		assertErr       require.ErrorAssertionFunc
+		wantWarnings    []string
+		wantRemoveStale []api.WarningScope
+	}{
+		{
+			name: "success",
+			repoGetAll: provisioning.Clusters{
+				{
+					Name:   "one",
+					Status: api.ClusterStatusReady,
+				},
+				{
+					Name:   "pending",
+					Status: api.ClusterStatusPending,
+				},
+			},
+			serverSvcGetAllWithFilter: servers,
+
+			assertErr: require.NoError,
+			wantWarnings: []string{
+				`Setting "os_version" of cluster member "serverTwo" diverges from "serverOne", found "2", expected "1"`,
+			},
+			wantRemoveStale: []api.WarningScope{
+				{
+					Scope:      "consistency",
+					EntityType: "cluster",
+					Entity:     "one",
+				},
+			},
+		},
+		{
+			name: "success - consistent",
+			repoGetAll: provisioning.Clusters{
+				{
+					Name:   "one",
+					Status: api.ClusterStatusReady,
+				},
+			},
+			serverSvcGetAllWithFilter: servers[:1],
+
+			assertErr: require.NoError,
+			wantRemoveStale: []api.WarningScope{
+				{
+					Scope:      "consistency",
+					EntityType: "cluster",
+					Entity:     "one",
+				},
+			},
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - serverSvc.GetAllWithFilter",
+			repoGetAll: provisioning.Clusters{
+				{
+					Name:   "one",
+					Status: api.ClusterStatusReady,
+				},
+			},
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return tc.repoGetAll, tc.repoGetAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					require.Equal(t, "one", name)
+					return &tc.repoGetAll[0], nil
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					require.Equal(t, ptr.To("one"), filter.Cluster)
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+				GetSystemKernelFunc: func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
+					return provisioning.ServerSystemKernel{}, nil
+				},
+				GetSystemLoggingFunc: func(ctx context.Context, name string) (provisioning.ServerSystemLogging, error) {
+					return provisioning.ServerSystemLogging{}, nil
+				},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
+					return provisioning.ServerSystemNetwork{}, nil
+				},
+				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
+					return incusosapi.ServiceLVM{}, nil
+				},
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					return incusosapi.ServiceISCSI{}, nil
+				},
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					return incusosapi.ServiceMultipath{}, nil
+				},
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					return incusosapi.ServiceNVME{}, nil
+				},
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					return incusosapi.ServiceCeph{}, nil
+				},
+				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
+					return incusosapi.ServiceLinstor{}, nil
+				},
+				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
+					return incusosapi.ServiceOVN{}, nil
+				},
+			}
+
+			var warnings []string
+			var removeStale []api.WarningScope
+			warningSvc := &adapterMock.WarningServicePortMock{
+				EmitFunc: func(ctx context.Context, w warning.Warning) {
+					require.Equal(t, api.WarningTypeClusterMembersInconsistent, w.Type)
+					warnings = append(warnings, w.Messages...)
+				},
+				RemoveStaleFunc: func(ctx context.Context, scope api.WarningScope, newWarnings warning.Warnings) {
+					require.Len(t, newWarnings, len(warnings))
+					removeStale = append(removeStale, scope)
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil, provisioningCluster.WithWarningEmitter(warningSvc))
+
+			// Run test
+			err := clusterSvc.CheckConsistency(context.Background())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantWarnings, warnings)
+			require.Equal(t, tc.wantRemoveStale, removeStale)
+		})
+	}
+}
+
+func TestClusterService_GetAll(t *testing.T) {
+	tests := []struct {
+		name               string
+		repoGetAllClusters provisioning.Clusters
+		repoGetAllErr      error
+
+		assertErr require.ErrorAssertionFunc
+		count     int
+	}{
+		{
+			name: "success",
+			repoGetAllClusters: provisioning.Clusters{
+				provisioning.Cluster{
+					Name:          "one",
+					ServerNames:   []string{"server1", "server2"},
+					ConnectionURL: "http://one/",
+				},
+				provisioning.Cluster{
+					Name:          "one",
+					ServerNames:   []string{"server1", "server2"},
+					ConnectionURL: "http://one/",
+				},
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
+		{
+			name:          "error - repo",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			count:     0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return tc.repoGetAllClusters, tc.repoGetAllErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
+
+			// Run test
+			clusters, err := clusterSvc.GetAll(context.Background())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Len(t, clusters, tc.count)
+		})
+	}
+}
+
+func TestClusterService_GetAllWithFilter(t *testing.T) {
+	tests := []struct {
+		name                      string
+		filter                    provisioning.ClusterFilter
+		repoGetAllWithFilter      provisioning.Clusters
+		repoGetAllWithFilterErr   error
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+
+		assertErr require.ErrorAssertionFunc
+		count     int
+	}{
+		{
+			name:   "success - no filter expression",
+			filter: provisioning.ClusterFilter{},
+			repoGetAllWithFilter: provisioning.Clusters{
+				provisioning.Cluster{
+					Name: "one",
+				},
+				provisioning.Cluster{
+					Name: "two",
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{
+					Value: provisioning.Servers{
+						{
+							Name: "server1",
+							VersionData: api.ServerVersionData{
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(false),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+						{
+							Name: "server2",
+							VersionData: api.ServerVersionData{
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(false),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
+				},
+				{
+					Value: provisioning.Servers{
+						{
+							Name: "serverA",
+							VersionData: api.ServerVersionData{
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(false),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+						{
+							Name: "serverB",
+							VersionData: api.ServerVersionData{
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(false),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
+		{
+			name: "success - with filter expression",
+			filter: provisioning.ClusterFilter{
+				Expression: ptr.To(`name == "one"`),
+			},
+			repoGetAllWithFilter: provisioning.Clusters{
+				provisioning.Cluster{
+					Name: "one",
+				},
+				provisioning.Cluster{
+					Name: "two",
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{
+					Value: provisioning.Servers{
+						{
+							Name: "server1",
+							VersionData: api.ServerVersionData{
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(false),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+						{
+							Name: "server2",
+							VersionData: api.ServerVersionData{
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(false),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			count:     1,
+		},
+		{
+			name: "error - non bool expression",
+			filter: provisioning.ClusterFilter{
+				Expression: ptr.To(`"string"`), // invalid, does evaluate to string instead of boolean.
+			},
+			repoGetAllWithFilter: provisioning.Clusters{
+				provisioning.Cluster{
+					Name: "one",
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, "Failed to compile filter expression:")
+			},
+			count: 0,
+		},
+		{
+			name: "error - filter expression run",
+			filter: provisioning.ClusterFilter{
+				Expression: ptr.To(`fromBase64("~invalid") == ""`), // invalid, returns runtime error during evauluation of the expression.
+			},
+			repoGetAllWithFilter: provisioning.Clusters{
+				provisioning.Cluster{
+					Name: "one",
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(t, err, "Failed to execute filter expression:")
+			},
+			count: 0,
+		},
+		{
+			name:                    "error - repo",
+			repoGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			count:     0,
+		},
+		{
+			name:   "error - serverSvc.GetAllWithFilter",
+			filter: provisioning.ClusterFilter{},
+			repoGetAllWithFilter: provisioning.Clusters{
+				provisioning.Cluster{
+					Name: "one",
+				},
+				provisioning.Cluster{
+					Name: "two",
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return tc.repoGetAllWithFilter, tc.repoGetAllWithFilterErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			cluster, err := clusterSvc.GetAllWithFilter(context.Background(), tc.filter)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Len(t, cluster, tc.count)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+		})
+	}
+}
+
+func TestClusterService_GetAllNames(t *testing.T) {
+	tests := []struct {
+		name               string
+		repoGetAllNames    []string
+		repoGetAllNamesErr error
+
+		assertErr require.ErrorAssertionFunc
+		count     int
+	}{
+		{
+			name: "success",
+			repoGetAllNames: []string{
+				"one", "two",
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
+		{
+			name:               "error - repo",
+			repoGetAllNamesErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			count:     0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetAllNamesFunc: func(ctx context.Context) ([]string, error) {
+					return tc.repoGetAllNames, tc.repoGetAllNamesErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
+
+			// Run test
+			clusterNames, err := clusterSvc.GetAllNames(context.Background())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Len(t, clusterNames, tc.count)
+		})
+	}
+}
+
+func TestClusterService_GetAllNamesWithFilter(t *testing.T) {
+	tests := []struct {
+		name                         string
+		filter                       provisioning.ClusterFilter
+		repoGetAllNamesWithFilter    []string
+		repoGetAllNamesWithFilterErr error
+
+		assertErr require.ErrorAssertionFunc
+		count     int
+	}{
+		{
+			name:   "success - no filter expression",
+			filter: provisioning.ClusterFilter{},
+			repoGetAllNamesWithFilter: []string{
+				"one", "two",
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
+		{
+			name: "success - with filter expression",
+			filter: provisioning.ClusterFilter{
+				Expression: ptr.To(`name matches "one"`),
+			},
+			repoGetAllNamesWithFilter: []string{
+				"one", "two",
+			},
+
+			assertErr: require.NoError,
+			count:     1,
+		},
+		{
+			name: "error - non bool expression",
+			filter: provisioning.ClusterFilter{
+				Expression: ptr.To(`"string"`), // invalid, does evaluate to string instead of boolean.
+			},
+			repoGetAllNamesWithFilter: []string{
+				"one",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, "Failed to compile filter expression:")
+			},
+			count: 0,
+		},
+		{
+			name: "error - filter expression run",
+			filter: provisioning.ClusterFilter{
+				Expression: ptr.To(`fromBase64("~invalid") == ""`), // invalid, returns runtime error during evauluation of the expression.
+			},
+			repoGetAllNamesWithFilter: []string{
+				"one",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, "Failed to execute filter expression:")
+			},
+			count: 0,
+		},
+		{
+			name:                         "error - repo",
+			repoGetAllNamesWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			count:     0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetAllNamesFunc: func(ctx context.Context) ([]string, error) {
+					return tc.repoGetAllNamesWithFilter, tc.repoGetAllNamesWithFilterErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
+
+			// Run test
+			clusterIDs, err := clusterSvc.GetAllNamesWithFilter(context.Background(), tc.filter)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Len(t, clusterIDs, tc.count)
+		})
+	}
+}
+
+func TestClusterService_GetByName(t *testing.T) {
+	tests := []struct {
+		name                         string
+		nameArg                      string
+		repoGetByNameCluster         *provisioning.Cluster
+		repoGetByNameErr             error
+		serverSvcGetAllWithFilter    provisioning.Servers
+		serverSvcGetAllWithFilterErr error
+
+		assertErr   require.ErrorAssertionFunc
+		wantCluster *provisioning.Cluster
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name: "server1",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(false),
+						NeedsReboot:   ptr.To(false),
+						InMaintenance: ptr.To(api.NotInMaintenance),
+					},
+				},
+				{
+					Name: "server2",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(false),
+						NeedsReboot:   ptr.To(false),
+						InMaintenance: ptr.To(api.NotInMaintenance),
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			wantCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				UpdateStatus: api.ClusterUpdateStatus{
+					NeedsUpdate:   []string{},
+					NeedsReboot:   []string{},
+					InMaintenance: []string{},
+				},
+			},
+		},
+		{
+			name:    "success - with update status",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2", "server3", "server4"},
+				ConnectionURL: "http://one/",
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name: "server1",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(true),
+						NeedsReboot:   ptr.To(false),
+						InMaintenance: ptr.To(api.NotInMaintenance),
+					},
+				},
+				{
+					Name: "server2",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(false),
+						NeedsReboot:   ptr.To(true),
+						InMaintenance: ptr.To(api.NotInMaintenance),
+					},
+				},
+				{
+					Name: "server3",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(false),
+						NeedsReboot:   ptr.To(false),
+						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					},
+				},
+				{
+					Name: "server4",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(false),
+						NeedsReboot:   ptr.To(false),
+						InMaintenance: ptr.To(api.InMaintenanceRestoring),
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			wantCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2", "server3", "server4"},
+				ConnectionURL: "http://one/",
+				UpdateStatus: api.ClusterUpdateStatus{
+					NeedsUpdate:   []string{"server1"},
+					NeedsReboot:   []string{"server2"},
+					InMaintenance: []string{"server3", "server4"},
+				},
+			},
+		},
+		{
+			name:    "success - with update status error",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1"},
+				ConnectionURL: "http://one/",
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressError,
+						Error:      "error",
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name: "server1",
+					VersionData: api.ServerVersionData{
+						NeedsUpdate:   ptr.To(true),
+						NeedsReboot:   ptr.To(false),
+						InMaintenance: ptr.To(api.NotInMaintenance),
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			wantCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1"},
+				ConnectionURL: "http://one/",
+				UpdateStatus: api.ClusterUpdateStatus{
+					NeedsUpdate:   []string{"server1"},
+					NeedsReboot:   []string{},
+					InMaintenance: []string{},
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress:        api.ClusterUpdateInProgressError,
+						Error:             "error",
+						StatusDescription: ptr.To("error"),
+					},
+				},
+			},
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:             "error - repo",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - serverSvc.GetAllWithFilter",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+			},
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByNameCluster, tc.repoGetByNameErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			cluster, err := clusterSvc.GetByName(context.Background(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantCluster, cluster)
+		})
+	}
+}
+
+func TestClusterService_Update(t *testing.T) {
+	tests := []struct {
+		name                         string
+		argUpdateServers             bool
+		cluster                      provisioning.Cluster
+		repoGetByName                *provisioning.Cluster
+		repoGetByNameErr             error
+		repoUpdateErrs               queue.Errs
+		serverSvcGetAllWithFilter    []provisioning.Server
+		serverSvcGetAllWithFilterErr error
+		serverSvcUpdateErrs          queue.Errs
+
+		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
+	}{
+		{
+			name:             "success",
+			argUpdateServers: true,
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			serverSvcGetAllWithFilter: []provisioning.Server{
+				{
+					Name: "one",
+				},
+				{
+					Name: "two",
+				},
+			},
+
+			assertErr: require.NoError,
+			assertLog: log.Noop,
+		},
+		{
+			name:             "success - without servers update",
+			argUpdateServers: false,
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+
+			assertErr: require.NoError,
+			assertLog: log.Noop,
+		},
+		{
+			name: "error - validation",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: ":|\\", // invalid
+				Channel:       "stable",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+			assertLog: log.Noop,
+		},
+		{
+			name: "error - repo.GetByName",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server3"},
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Noop,
+		},
+		{
+			name: "error - repo.Update",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server3"},
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			repoUpdateErrs: queue.Errs{
+				boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Noop,
+		},
+		{
+			name:             "error - serverSvc.GetAllNamesWithFilter",
+			argUpdateServers: true,
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server3"},
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Noop,
+		},
+		{
+			name:             "error - serverSvc.Update",
+			argUpdateServers: true,
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server3"},
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			serverSvcGetAllWithFilter: []provisioning.Server{
+				{
+					Name: "one",
+				},
+				{
+					Name: "two",
+				},
+			},
+			serverSvcUpdateErrs: queue.Errs{
+				boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Noop,
+		},
+		{
+			name:             "error - serverSvc.Update - 2nd update - reverter errors",
+			argUpdateServers: true,
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server3"},
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:          "one",
+				ConnectionURL: "http://one/",
+				Channel:       "stable",
+			},
+			serverSvcGetAllWithFilter: []provisioning.Server{
+				{
+					Name: "one",
+				},
+				{
+					Name: "two",
+				},
+			},
+			repoUpdateErrs: queue.Errs{
+				nil,
+				boom.Error,
+			},
+			serverSvcUpdateErrs: queue.Errs{
+				nil,
+				boom.Error,
+				boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+			assertLog: func(t log.TestifyT, logBuf *bytes.Buffer) {
+				t.Helper()
+				log.Contains("Failed to restore previous cluster state after failed to update servers of the cluster cluster=one err=boom!")(t, logBuf)
+				log.Contains("Failed to restore previous server state after failed to update member server of cluster cluster=one server=one err=boom!")(t, logBuf)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, true, true)
+			require.NoError(t, err)
+
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, in provisioning.Cluster) error {
+					return tc.repoUpdateErrs.PopOrNil(t)
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
+					return tc.serverSvcUpdateErrs.PopOrNil(t)
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			err = clusterSvc.Update(context.Background(), tc.cluster, tc.argUpdateServers)
+
+			// Assert
+			tc.assertErr(t, err)
+			tc.assertLog(t, logBuf)
+
+			require.Empty(t, tc.repoUpdateErrs)
+			require.Empty(t, tc.serverSvcUpdateErrs)
+		})
+	}
+}
+
+func TestClusterService_Rename(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldName       string
+		newName       string
+		repoRenameErr error
+		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			oldName: "one",
+			newName: "one new",
+
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
+		},
+		{
+			name:    "error - old name empty",
+			oldName: "", // invalid
+			newName: "one new",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - new name empty",
+			oldName: "one",
+			newName: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:          "error - repo.Rename",
+			oldName:       "one",
+			newName:       "one new",
+			repoRenameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				RenameFunc: func(ctx context.Context, oldName string, newName string) error {
+					require.Equal(t, tc.oldName, oldName)
+					require.Equal(t, tc.newName, newName)
+					return tc.repoRenameErr
+				},
+			}
+
+			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
+			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
+			defer func() {
+				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
+			}()
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
+
+			var signalHandlerCalled bool
+			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
+
+			// Run test
+			err := clusterSvc.Rename(context.Background(), tc.oldName, tc.newName)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
+		})
+	}
+}
+
+func TestClusterService_DeleteByName(t *testing.T) {
+	tests := []struct {
+		name                                string
+		nameArg                             string
+		force                               bool
+		repoGetByNameCluster                *provisioning.Cluster
+		repoGetByNameErr                    error
+		repoDeleteByNameErr                 error
+		serverSvcGetAllNamesWithFilterNames []string
+		serverSvcGetAllNamesWithFilterErr   error
+
+		assertErr     require.ErrorAssertionFunc
+		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Status: api.ClusterStatusPending,
+			},
+
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
+		},
+		{
+			name:    "success - force",
+			nameArg: "one",
+			force:   true,
+			repoGetByNameCluster: &provisioning.Cluster{
+				Status: api.ClusterStatusPending,
+			},
+
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:                "error - force - repo.DeleteByName",
+			nameArg:             "one",
+			force:               true,
+			repoDeleteByNameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - cluster state ready",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Status: api.ClusterStatusReady,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, `Delete for cluster in state "ready":`)
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:                 "error - cluster state not set",
+			nameArg:              "one",
+			repoGetByNameCluster: &provisioning.Cluster{},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, "Delete for cluster with invalid state:")
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - cluster with linked servers",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Status: api.ClusterStatusPending,
+			},
+			serverSvcGetAllNamesWithFilterNames: []string{"one"},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, "Delete for cluster with 1 linked servers ([one])")
+			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - serverSvc.GetallNamesWithFilter",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Status: api.ClusterStatusPending,
 			},
+			serverSvcGetAllNamesWithFilterErr: boom.Error,
 
-			assertErr:               require.NoError,
-			wantInconsistencyReason: `OVN tunnel address mismatch, found "10.0.0.1" (one) and "10.0.0.3" (two), expected "10.0.0.2" for two`,
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name: "error - service OVN not equal",
-			servers: []provisioning.Server{
-				{
-					Name: "one",
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
+			name:    "error - repo.DeleteByID",
+			nameArg: "one",
+			repoGetByNameCluster: &provisioning.Cluster{
+				Status: api.ClusterStatusPending,
+			},
+			repoDeleteByNameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByNameCluster, tc.repoGetByNameErr
+				},
+				DeleteByNameFunc: func(ctx context.Context, name string) error {
+					return tc.repoDeleteByNameErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllNamesWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) ([]string, error) {
+					return tc.serverSvcGetAllNamesWithFilterNames, tc.serverSvcGetAllNamesWithFilterErr
 				},
+			}
+
+			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
+			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
+			defer func() {
+				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
+			}()
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			var signalHandlerCalled bool
+			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
+
+			// Run test
+			err := clusterSvc.DeleteByName(context.Background(), tc.nameArg, tc.force)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
+		})
+	}
+}
+
+func TestDeleteAndFactoryResetByName(t *testing.T) {
+	tests := []struct {
+		name             string
+		nameArg          string
+		tokenArg         *uuid.UUID
+		tokenSeedNameArg *string
+
+		serverSvcGetAllWithFilter         provisioning.Servers
+		serverSvcGetAllWithFilterErr      error
+		clientPingErr                     error
+		clientSystemFactoryResetErr       error
+		tokenSvcGetTokenSeedByName        *provisioning.TokenSeed
+		tokenSvcGetTokenSeedByNameErr     error
+		tokenSvcCreate                    provisioning.Token
+		tokenSvcCreateErr                 error
+		tokenSvcGetTokenProviderConfig    *api.TokenProviderConfig
+		tokenSvcGetTokenProviderConfigErr error
+		repoDeleteByNameErr               error
+
+		assertErr     require.ErrorAssertionFunc
+		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
 				{
-					Name: "two",
 					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Version: "1",
-						},
 						Applications: []api.ApplicationVersionData{
 							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
-				},
-			},
-			clientGetNetworkConfig: []queue.Item[provisioning.ServerSystemNetwork]{
-				// one (reference)
-				{
-					Value: incusosapi.SystemNetwork{
-						Config: &incusosapi.SystemNetworkConfig{
-							Interfaces: []incusosapi.SystemNetworkInterface{
-								{
-									VLANTags: []int{
-										1,
-									},
-								},
-							},
-						},
-					},
-				},
-				// two
-				{
-					Value: incusosapi.SystemNetwork{
-						Config: &incusosapi.SystemNetworkConfig{
-							Interfaces: []incusosapi.SystemNetworkInterface{
-								{
-									VLANTags: []int{
-										1,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			clientGetStorageConfig: []queue.Item[provisioning.ServerSystemStorage]{
-				// one (reference)
-				{
-					Value: incusosapi.SystemStorage{
-						Config: incusosapi.SystemStorageConfig{
-							Pools: []incusosapi.SystemStoragePool{
-								{
-									Name: "local",
-									Type: "custom",
-								},
+								Name: "debug",
 							},
-						},
-					},
-				},
-				// two
-				{
-					Value: incusosapi.SystemStorage{
-						Config: incusosapi.SystemStorageConfig{
-							Pools: []incusosapi.SystemStoragePool{
-								{
-									Name: "local",
-									Type: "custom",
-								},
+							{
+								Name: "incus-lts-7.0",
 							},
 						},
 					},
 				},
+				{},
 			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// one (reference)
-				{
-					Value: incusosapi.ServiceLVM{
-						Config: incusosapi.ServiceLVMConfig{
-							Enabled:  true,
-							SystemID: 1,
-						},
-					},
-				},
-				// two
-				{
-					Value: incusosapi.ServiceLVM{
-						Config: incusosapi.ServiceLVMConfig{
-							Enabled:  true,
-							SystemID: 2,
-						},
-					},
-				},
-			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				{Value: incusosapi.ServiceISCSI{}},
-				{Value: incusosapi.ServiceISCSI{}},
-			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				// one (reference)
-				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: true,
-							WWNs:    []string{"foo"},
-						},
-					},
-				},
-				// two
-				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: true,
-							WWNs:    []string{"foo"},
-						},
-					},
-				},
+			tokenSvcCreate: provisioning.Token{
+				UUID: uuidgen.FromPattern(t, "1"),
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				// one (reference)
-				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "tcp",
-									Address:   "localhost",
-									Port:      1234,
-								},
-							},
-						},
-					},
-				},
-				// two
-				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "tcp",
-									Address:   "localhost",
-									Port:      1234,
-								},
-							},
-						},
+			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
+				Version: "1",
+				SystemProviderConfig: incusosapi.SystemProviderConfig{
+					Name: "operations-center",
+					Config: map[string]string{
+						"server_url":   "https://1.2.3.4:8443",
+						"server_token": uuidgen.FromPattern(t, "1").String(),
 					},
 				},
 			},
-			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
-				// one (reference)
-				{
-					Value: incusosapi.ServiceCeph{
-						Config: incusosapi.ServiceCephConfig{
-							Enabled: true,
-							Clusters: map[string]incusosapi.ServiceCephCluster{
-								"ceph": {
-									FSID:     "fsid",
-									Monitors: []string{"10.0.0.1"},
-								},
-							},
-						},
+
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
+		},
+		{
+			name:     "success - with token",
+			nameArg:  "one",
+			tokenArg: ptr.To(uuidgen.FromPattern(t, "1")),
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
+				Version: "1",
+				SystemProviderConfig: incusosapi.SystemProviderConfig{
+					Name: "operations-center",
+					Config: map[string]string{
+						"server_url":   "https://1.2.3.4:8443",
+						"server_token": uuidgen.FromPattern(t, "1").String(),
 					},
 				},
-				// two
-				{
-					Value: incusosapi.ServiceCeph{
-						Config: incusosapi.ServiceCephConfig{
-							Enabled: true,
-							Clusters: map[string]incusosapi.ServiceCephCluster{
-								"ceph": {
-									FSID:     "fsid",
-									Monitors: []string{"10.0.0.1"},
-								},
-							},
-						},
+			},
+
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
+		},
+		{
+			name:             "success - with token and tokenSeedName",
+			nameArg:          "one",
+			tokenArg:         ptr.To(uuidgen.FromPattern(t, "1")),
+			tokenSeedNameArg: ptr.To("token-seed-name"),
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcGetTokenSeedByName: &provisioning.TokenSeed{
+				Token: uuidgen.FromPattern(t, "1"),
+			},
+			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
+				Version: "1",
+				SystemProviderConfig: incusosapi.SystemProviderConfig{
+					Name: "operations-center",
+					Config: map[string]string{
+						"server_url":   "https://1.2.3.4:8443",
+						"server_token": uuidgen.FromPattern(t, "1").String(),
 					},
 				},
 			},
-			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
-				// one (reference)
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
-				// two
-				{Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}}},
+
+			assertErr:     require.NoError,
+			signalHandler: requireCallSignalHandler,
+		},
+
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
 			},
-			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
-				// one (reference)
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641"}}},
-				// two
-				{Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::2]:6641"}}},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - serverSvc.GetAllWithFilter",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - no servers",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilter: provisioning.Servers{},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
 			},
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - client.Ping",
+			nameArg: "one",
 
-			assertErr:               require.NoError,
-			wantInconsistencyReason: "OVN configuration mismatch",
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			clientPingErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name: "error - service iSCSI not equal",
-			servers: []provisioning.Server{
-				{
-					Name: "one",
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
+			name:             "error - tokenSvc.GetTokenSeedByName",
+			nameArg:          "one",
+			tokenArg:         ptr.To(uuidgen.FromPattern(t, "1")),
+			tokenSeedNameArg: ptr.To("token-seed-name"),
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcGetTokenSeedByNameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - tokenSvc.Create",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcCreateErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - tokenSvc.GetTokenProviderConfig",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcCreate: provisioning.Token{
+				UUID: uuidgen.FromPattern(t, "1"),
+			},
+			tokenSvcGetTokenProviderConfigErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - client.SystemFactoryReset",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcCreate: provisioning.Token{
+				UUID: uuidgen.FromPattern(t, "1"),
+			},
+			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
+				Version: "1",
+				SystemProviderConfig: incusosapi.SystemProviderConfig{
+					Name: "operations-center",
+					Config: map[string]string{
+						"server_url":   "https://1.2.3.4:8443",
+						"server_token": uuidgen.FromPattern(t, "1").String(),
+					},
+				},
+			},
+			clientSystemFactoryResetErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+		{
+			name:    "error - repo.DeleteByName",
+			nameArg: "one",
+
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
+				{},
+			},
+			tokenSvcCreate: provisioning.Token{
+				UUID: uuidgen.FromPattern(t, "1"),
+			},
+			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
+				Version: "1",
+				SystemProviderConfig: incusosapi.SystemProviderConfig{
+					Name: "operations-center",
+					Config: map[string]string{
+						"server_url":   "https://1.2.3.4:8443",
+						"server_token": uuidgen.FromPattern(t, "1").String(),
 					},
 				},
-				{
-					Name: "two",
-					VersionData: api.ServerVersionData{
-						OS: api.OSVersionData{
-							Version: "1",
-						},
-						Applications: []api.ApplicationVersionData{
-							{
-								Name:    "incus",
-								Version: "1",
-							},
-						},
-					},
+			},
+			repoDeleteByNameErr: boom.Error,
+
+			assertErr:     boom.ErrorIs,
+			signalHandler: requireNoCallSignalHandler,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				DeleteByNameFunc: func(ctx context.Context, name string) error {
+					return tc.repoDeleteByNameErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+			}
+
+			tokenSvc := &serviceMock.TokenServiceMock{
+				GetTokenSeedByNameFunc: func(ctx context.Context, id uuid.UUID, name string) (*provisioning.TokenSeed, error) {
+					return tc.tokenSvcGetTokenSeedByName, tc.tokenSvcGetTokenSeedByNameErr
+				},
+				CreateFunc: func(ctx context.Context, token provisioning.Token) (provisioning.Token, error) {
+					return tc.tokenSvcCreate, tc.tokenSvcCreateErr
 				},
-			},
-			clientGetNetworkConfig: []queue.Item[provisioning.ServerSystemNetwork]{
-				// one (reference)
-				{
-					Value: incusosapi.SystemNetwork{
-						Config: &incusosapi.SystemNetworkConfig{
-							Interfaces: []incusosapi.SystemNetworkInterface{
-								{
-									VLANTags: []int{
-										1,
-									},
-								},
-							},
-						},
-					},
+				GetTokenProviderConfigFunc: func(ctx context.Context, id uuid.UUID) (*api.TokenProviderConfig, error) {
+					return tc.tokenSvcGetTokenProviderConfig, tc.tokenSvcGetTokenProviderConfigErr
 				},
-				// two
-				{
-					Value: incusosapi.SystemNetwork{
-						Config: &incusosapi.SystemNetworkConfig{
-							Interfaces: []incusosapi.SystemNetworkInterface{
-								{
-									VLANTags: []int{
-										1,
-									},
-								},
-							},
-						},
-					},
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
+					return tc.clientPingErr
 				},
-			},
-			clientGetStorageConfig: []queue.Item[provisioning.ServerSystemStorage]{
-				// one (reference)
-				{
-					Value: incusosapi.SystemStorage{
-						Config: incusosapi.SystemStorageConfig{
-							Pools: []incusosapi.SystemStoragePool{
-								{
-									Name: "local",
-									Type: "custom",
-								},
-							},
-						},
-					},
+				SystemFactoryResetFunc: func(ctx context.Context, endpoint provisioning.Endpoint, allowTPMResetFailure bool, seeds provisioning.TokenImageSeedConfigs, providerConfig api.TokenProviderConfig) error {
+					return tc.clientSystemFactoryResetErr
 				},
-				// two
+			}
+
+			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
+			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
+			defer func() {
+				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
+			}()
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, tokenSvc, nil, nil, nil)
+
+			var signalHandlerCalled bool
+			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
+
+			// Run test
+			err := clusterSvc.DeleteAndFactoryResetByName(context.Background(), tc.nameArg, tc.tokenArg, tc.tokenSeedNameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
+		})
+	}
+}
+
+func TestClusterService_ResyncInventory(t *testing.T) {
+	tests := []struct {
+		name               string
+		ctx                context.Context
+		repoGetAllClusters provisioning.Clusters
+		repoGetAllErr      error
+		inventorySyncerErr error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:               "success - empty cluster list",
+			ctx:                context.Background(),
+			repoGetAllClusters: provisioning.Clusters{},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success",
+			ctx:  context.Background(),
+			repoGetAllClusters: provisioning.Clusters{
 				{
-					Value: incusosapi.SystemStorage{
-						Config: incusosapi.SystemStorageConfig{
-							Pools: []incusosapi.SystemStoragePool{
-								{
-									Name: "local",
-									Type: "custom",
-								},
-							},
-						},
-					},
+					Name: "one",
 				},
 			},
-			clientGetOSServiceLVM: []queue.Item[incusosapi.ServiceLVM]{
-				// one (reference)
+
+			assertErr: require.NoError,
+		},
+		{
+			name:          "error - GetAll",
+			ctx:           context.Background(),
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - Context done",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel() // context cancelled
+				return ctx
+			}(),
+			repoGetAllClusters: provisioning.Clusters{
 				{
-					Value: incusosapi.ServiceLVM{
-						Config: incusosapi.ServiceLVMConfig{
-							Enabled:  true,
-							SystemID: 1,
-						},
-					},
+					Name: "one",
 				},
-				// two
+			},
+
+			assertErr: require.Error,
+		},
+		{
+			name: "error - ResyncInventoryByName",
+			ctx:  context.Background(),
+			repoGetAllClusters: provisioning.Clusters{
 				{
-					Value: incusosapi.ServiceLVM{
-						Config: incusosapi.ServiceLVMConfig{
-							Enabled:  true,
-							SystemID: 2,
-						},
-					},
+					Name: "one",
 				},
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
-				// one (reference)
-				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.one"}}}}},
-				// two
-				{Value: incusosapi.ServiceISCSI{Config: incusosapi.ServiceISCSIConfig{Enabled: true, Targets: []incusosapi.ServiceISCSITarget{{Target: "iqn.two"}}}}},
-			},
+			inventorySyncerErr: boom.Error,
 
-			assertErr:               require.NoError,
-			wantInconsistencyReason: "iSCSI configuration mismatch",
+			assertErr: boom.ErrorIs,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
-			client := &adapterMock.ClusterClientPortMock{
-				GetNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemNetwork, error) {
-					return queue.Pop(t, &tc.clientGetNetworkConfig)
-				},
-				GetStorageConfigFunc: func(ctx context.Context, server provisioning.Server) (provisioning.ServerSystemStorage, error) {
-					return queue.Pop(t, &tc.clientGetStorageConfig)
-				},
-				GetOSServiceLVMFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLVM, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceLVM)
-				},
-				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceISCSI)
-				},
-				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceMultipath)
-				},
-				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceNVME)
-				},
-				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceCeph)
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return tc.repoGetAllClusters, tc.repoGetAllErr
 				},
-				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceLinstor)
+			}
+
+			inventorySyncer := &serviceMock.InventorySyncerMock{
+				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
+					return tc.inventorySyncerErr
 				},
-				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
-					return queue.Pop(t, &tc.clientGetOSServiceOVN)
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
+			clusterSvc.SetInventorySyncers(
+				map[domain.ResourceType]provisioning.InventorySyncer{
+					"test": inventorySyncer,
 				},
-				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
-					return tc.clientUpdateOSServiceErrs.Pop(t)
+			)
+
+			// Run test
+			err := clusterSvc.ResyncInventory(tc.ctx)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestClusterService_ResyncInventoryByName(t *testing.T) {
+	tests := []struct {
+		name               string
+		nameArg            string
+		inventorySyncerErr error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:               "error - sync cluster",
+			nameArg:            "one",
+			inventorySyncerErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inventorySyncer := &serviceMock.InventorySyncerMock{
+				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
+					return tc.inventorySyncerErr
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(
-				nil,
-				nil,
-				client,
-				nil,
-				nil,
-				nil,
-				nil,
-				nil,
-			)
+			clusterSvc := provisioningCluster.New(nil, nil, nil, nil, nil, nil, nil, nil)
+			clusterSvc.SetInventorySyncers(map[domain.ResourceType]provisioning.InventorySyncer{"test": inventorySyncer})
 
 			// Run test
-			isConsistent, reason, err := clusterSvc.CheckClusteringServerConsistency(t.Context(), tc.servers)
+			err := clusterSvc.ResyncInventoryByName(context.Background(), tc.nameArg)
 
 			// Assert
 			tc.assertErr(t, err)
-			require.Equal(t, tc.wantConsistent, isConsistent)
-			require.Contains(t, reason, tc.wantInconsistencyReason)
-
-			require.Empty(t, tc.clientGetNetworkConfig)
-			require.Empty(t, tc.clientGetStorageConfig)
-			require.Empty(t, tc.clientGetOSServiceLVM)
-			require.Empty(t, tc.clientGetOSServiceISCSI)
-			require.Empty(t, tc.clientGetOSServiceMultipath)
-			require.Empty(t, tc.clientGetOSServiceNVME)
-			require.Empty(t, tc.clientGetOSServiceCeph)
-			require.Empty(t, tc.clientGetOSServiceLinstor)
-			require.Empty(t, tc.clientGetOSServiceOVN)
-			require.Empty(t, tc.clientUpdateOSServiceErrs)
 		})
 	}
 }
 
-func TestClusterService_RemoveServer(t *testing.T) {
+func TestClusterService_IsInstanceLifecycleOperationPermitted(t *testing.T) {
 	tests := []struct {
-		name                                   string
-		serverSvcGetAllWithFilter              provisioning.Servers
-		serverSvcGetAllWithFilterErr           error
-		inventorySyncerErr                     error
-		inventorySvcGetAllWithFilter           []queue.Item[inventory.InventoryAggregates]
-		clientIncusClientErr                   error
-		serverSvcUpdateErr                     queue.Errs
-		incusClientGetClusterMemberErr         error
-		incusClientUpdateClusterMemberErr      error
-		incusClientGetServerErr                error
-		incusClientUpdateServerErr             error
-		incusClientDeleteStoragePoolVolumeErrs queue.Errs
-		serverSvcFactoryResetByNameErr         error
-		incusClientDeleteClusterMemberErr      error
-		incusClientHasExtension                bool
+		name                      string
+		argName                   string
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		serverSvcPollServersErr   error
+		serverSvcGetAllWithFilter provisioning.Servers
 
-		assertErr require.ErrorAssertionFunc
-		assertLog log.MatcherFunc
+		want bool
 	}{
 		{
-			name:                    "success",
-			incusClientHasExtension: true,
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
-					},
-				},
-				{
-					Name: "serverTwo",
-				},
-			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{
-					Value: inventory.InventoryAggregates{
-						{
-							StorageVolumes: inventory.StorageVolumes{
-								{
-									Name: "custom/backups",
-								},
-								{
-									Name: "custom/images",
-								},
-								{
-									Name: "custom/logs",
-								},
-							},
-						},
-					},
-				},
-			},
-
-			assertErr: require.NoError,
-			assertLog: log.Empty,
-		},
-
-		{
-			name:                         "error - serverSvc.GetAllWithFilter",
-			serverSvcGetAllWithFilterErr: boom.Error,
+			name:    "success - not clustered",
+			argName: "", // no cluster name, therefore not clustered
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
+			want: true,
 		},
 		{
-			name: "error - cluster minimum size",
+			name:          "success - no update in progress",
+			argName:       "one",
+			repoGetByName: &provisioning.Cluster{},
 			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name: "serverOne",
-				},
-				// cluster only has 1 server
+				{},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Cluster "one" does not have enough servers for server removal`)
-			},
-			assertLog: log.Empty,
+			want: true,
 		},
 		{
-			name: "error - server not part of cluster",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				// serverOne is not part of cluster
-				{
-					Name: "serverTwo",
-				},
-				{
-					Name: "serverThree",
+			name:    "success - update in progress",
+			argName: "one",
+			repoGetByName: &provisioning.Cluster{
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressRollingRestart,
+					},
 				},
 			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrNotFound)
-				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" is not part of the cluster "one"`)
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{},
 			},
-			assertLog: log.Empty,
+
+			want: false,
 		},
 		{
-			name: "error - server not evacuated",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
+			name:             "error - repo.GetByName",
+			argName:          "one",
+			repoGetByNameErr: boom.Error,
+
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
 				},
-				{
-					Name: "serverTwo",
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return tc.serverSvcPollServersErr
 				},
-			},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.serverSvcGetAllWithFilter, nil
+				},
+			}
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" is not in state evacuated`)
-			},
-			assertLog: log.Empty,
-		},
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			got := clusterSvc.IsInstanceLifecycleOperationPermitted(t.Context(), tc.argName)
+
+			// Assert
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestClusterService_LaunchClusterUpdate(t *testing.T) {
+	tests := []struct {
+		name                      string
+		rebootArg                 bool
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		repoUpdate                []queue.Item[api.ClusterUpdateInProgressStatus] // api.ClusterUpdateInProgressStatus used for assertions in repo.Update
+		tamperContext             func(ctx context.Context, t *testing.T) context.Context
+		serverSvcPollServers      error
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+
+		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
+	}{
 		{
-			name: "error - ResyncInventoryByName",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+			name: "success - cluster already up to date",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
 					},
 				},
-				{
-					Name: "serverTwo",
-				},
 			},
-			inventorySyncerErr: boom.Error,
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - inventorySvc.GetAllWithFilter",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
-					},
-				},
-				{
-					Name: "serverTwo",
-				},
-			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{
-					Err: boom.Error,
+			name: "success - update servers",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
 			},
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - local resources - instances",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
 					},
 				},
 				{
-					Name: "serverTwo",
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
 				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Value: inventory.InventoryAggregates{
+					Value: provisioning.Servers{
 						{
-							Instances: inventory.Instances{
-								{
-									Name: "instance",
+							Name:         "A",
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "1",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      false,
+								},
+								Applications: []api.ApplicationVersionData{
+									{
+										Name:             "incus",
+										Version:          "1",
+										AvailableVersion: ptr.To("2"),
+										NeedsUpdate:      ptr.To(true),
+									},
 								},
+								NeedsUpdate:   ptr.To(true),
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 					},
 				},
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" still has instances`)
-			},
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - local resources - storage volumes",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
-					},
-				},
-				{
-					Name: "serverTwo",
-				},
-			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+				// GetAllWithFilter, needs update
 				{
-					Value: inventory.InventoryAggregates{
+					Value: provisioning.Servers{
 						{
-							StorageVolumes: inventory.StorageVolumes{
-								{
-									Name: "custom/some_volume",
+							Name:         "A",
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "1",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      false,
 								},
-								{
-									Name: "image/some_image",
+								Applications: []api.ApplicationVersionData{
+									{
+										Name:             "incus",
+										Version:          "1",
+										AvailableVersion: ptr.To("2"),
+										NeedsUpdate:      ptr.To(true),
+									},
 								},
+								NeedsUpdate:   ptr.To(true),
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 					},
 				},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Server removal failed, server "serverOne" still has custom volumes`)
-			},
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - local resources - image only present on removed server",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+			name:      "success - update servers with reboot",
+			rebootArg: true,
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
 					},
 				},
-				{
-					Name: "serverTwo",
-				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
 				{
-					Value: inventory.InventoryAggregates{},
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+					},
 				},
 				{
-					Err: boom.Error,
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+					},
 				},
 			},
-			incusClientHasExtension: true,
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - local resources - image only present on removed server",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: provisioning.Servers{
+						{
+							Name:         "A",
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "1",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      false,
+								},
+								Applications: []api.ApplicationVersionData{
+									{
+										Name:             "incus",
+										Version:          "1",
+										AvailableVersion: ptr.To("2"),
+										NeedsUpdate:      ptr.To(true),
+									},
+								},
+								NeedsUpdate:   ptr.To(true),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
 					},
 				},
+				// GetAllWithFilter, needs update
 				{
-					Name: "serverTwo",
-				},
-			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{
-					Value: inventory.InventoryAggregates{
+					Value: provisioning.Servers{
 						{
-							Images: inventory.Images{
-								{
-									Cluster:     "one",
-									ProjectName: "default",
-									Name:        "img-fingerprint",
-									Object: inventory.IncusImageWrapper{
-										Image: incusapi.Image{
-											Locations: []string{"serverOne"},
-										},
+							Name:         "A",
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "1",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      false,
+								},
+								Applications: []api.ApplicationVersionData{
+									{
+										Name:             "incus",
+										Version:          "1",
+										AvailableVersion: ptr.To("2"),
+										NeedsUpdate:      ptr.To(true),
 									},
 								},
+								NeedsUpdate:   ptr.To(true),
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 					},
 				},
 			},
-			incusClientHasExtension: true,
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `the following images are only present on the server(s) being removed`)
-			},
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
 		{
-			name: "success - image also present on surviving server",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			name: "success - server in maintenance - evacuated manually",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
+				},
+			},
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
+				},
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress:      api.ClusterUpdateInProgressApplyUpdate,
+						EvacuatedBefore: []string{"A"},
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{
+						{
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
 					},
 				},
+				// GetAllWithFilter, needs update
 				{
-					Name: "serverTwo",
-				},
-			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{
-					Value: inventory.InventoryAggregates{
+					Value: provisioning.Servers{
 						{
-							Images: inventory.Images{
-								{
-									Cluster:     "one",
-									ProjectName: "default",
-									Name:        "img-fingerprint",
-									Object: inventory.IncusImageWrapper{
-										Image: incusapi.Image{
-											Locations: []string{"serverOne", "serverTwo"},
-										},
-									},
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
 								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
 							},
 						},
 					},
 				},
 			},
-			incusClientHasExtension: true,
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
+
 		{
-			name: "success - image_locations extension not supported, check skipped",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+			name: "error - GetByName",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
 					},
 				},
-				{
-					Name: "serverTwo",
-				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Value: inventory.InventoryAggregates{},
+					Err: boom.Error,
 				},
 			},
-			incusClientHasExtension: false,
+			serverSvcPollServers: boom.Error,
 
-			assertErr: require.NoError,
+			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - client.IncusClient",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+			name: "error - cluster update already in progress",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressRollingRestart,
 					},
 				},
-				{
-					Name: "serverTwo",
-				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{},
 			},
-			clientIncusClientErr: boom.Error,
+			serverSvcPollServers: boom.Error,
 
-			assertErr: boom.ErrorIs,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - incusClient.GetClusterMember",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+			name: "error - Update - apply update",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
 					},
 				},
-				{
-					Name: "serverTwo",
-				},
-			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
 			},
-			incusClientGetClusterMemberErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - incusClient.UpdateClusterMember",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
 					},
+					Err: boom.Error,
 				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name: "serverTwo",
+					Value: provisioning.Servers{
+						{
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
+					},
 				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
-			},
-			incusClientUpdateClusterMemberErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - incusClient.GetServer",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			name: "error - serverSvc.PollServers",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
+				},
+			},
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
 					},
 				},
+				// Update reverter
 				{
-					Name: "serverTwo",
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{
+						{
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
+					},
+				},
 			},
-			incusClientGetServerErr: boom.Error,
+			serverSvcPollServers: boom.Error,
 
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - incusClient.UpdateServer",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			name: "error - serverSvc.PollServers - revert error",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
+				},
+			},
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
 					},
 				},
+				// Update reverter
 				{
-					Name: "serverTwo",
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
+					Err: boom.Error,
 				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{
+						{
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
+					},
+				},
 			},
-			incusClientUpdateServerErr: boom.Error,
+			serverSvcPollServers: boom.Error,
 
 			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
+			assertLog: log.Contains(boom.Error.Error()),
 		},
 		{
-			name: "error - incusClient.DeleteStoragePoolVolume",
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+			name: "error - serverSvc.GetAllWithFilter does not return any servers",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
 					},
 				},
-				{
-					Name: "serverTwo",
-				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
-			},
-			incusClientDeleteStoragePoolVolumeErrs: queue.Errs{
-				incusapi.StatusErrorf(404, "Storage pool not found"),
-				boom.Error,
-			},
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - serverSvc.FactoryResetByName",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
 					},
 				},
+				// Update reverter
 				{
-					Name: "serverTwo",
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
-			},
-			serverSvcFactoryResetByNameErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - incusClient.DeleteClusterMember",
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name:   "serverOne",
-					Status: api.ServerStatusReady,
-					VersionData: api.ServerVersionData{
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: provisioning.Servers{
+						{
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
 					},
 				},
+				// GetAllWithFilter, needs update
 				{
-					Name: "serverTwo",
+					Value: nil, // No servers found.
 				},
 			},
-			inventorySvcGetAllWithFilter: []queue.Item[inventory.InventoryAggregates]{
-				{},
-			},
-			incusClientDeleteClusterMemberErr: boom.Error,
 
-			assertErr: boom.ErrorIs,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrNotFound, a...)
+			},
 			assertLog: log.Empty,
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			logBuf := &bytes.Buffer{}
-			err := logger.InitLogger(logBuf, "", false, true, true)
-			require.NoError(t, err)
-
-			repo := &mock.ClusterRepoMock{}
-
-			serverSvc := &serviceMock.ServerServiceMock{
-				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
-					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
-				},
-				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
-					return tc.serverSvcUpdateErr.PopOrNil(t)
-				},
-				FactoryResetByNameFunc: func(ctx context.Context, name string, tokenID *uuid.UUID, tokenSeedName *string, force bool) error {
-					return tc.serverSvcFactoryResetByNameErr
-				},
-			}
+		{
+			name: "error - serverSvc.GetAllWithFilter",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
 
-			var incusClient *adapterMock.InstanceServerMock
-			incusClient = &adapterMock.InstanceServerMock{
-				HasExtensionFunc: func(extension string) bool {
-					return tc.incusClientHasExtension
-				},
-				GetClusterMemberFunc: func(name string) (*incusapi.ClusterMember, string, error) {
-					return &incusapi.ClusterMember{}, "", tc.incusClientGetClusterMemberErr
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
-				UpdateClusterMemberFunc: func(name string, member incusapi.ClusterMemberPut, ETag string) error {
-					return tc.incusClientUpdateClusterMemberErr
+			},
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
 				},
-				UseTargetFunc: func(name string) incusclient.InstanceServer {
-					return incusClient
+				// Update reverter
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
-				GetServerFunc: func() (*incusapi.Server, string, error) {
-					return &incusapi.Server{
-						ServerUntrusted: incusapi.ServerUntrusted{
-							ServerPut: incusapi.ServerPut{
-								Config: incusapi.ConfigMap{
-									"storage.backups": "local/backups",
-									"storage.images":  "local/images",
-									"storage.logs":    "local/logs",
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{
+						{
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
 								},
-							},
-						},
-					}, "", tc.incusClientGetServerErr
-				},
-				UpdateServerFunc: func(server incusapi.ServerPut, ETag string) error {
-					return tc.incusClientUpdateServerErr
-				},
-				DeleteStoragePoolVolumeFunc: func(pool, volType, name string) error {
-					return tc.incusClientDeleteStoragePoolVolumeErrs.PopOrNil(t)
-				},
-				DeleteClusterMemberFunc: func(name string, force bool) error {
-					return tc.incusClientDeleteClusterMemberErr
-				},
-			}
-
-			client := &adapterMock.ClusterClientPortMock{
-				IncusClientFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (provisioning.InstanceServer, error) {
-					return incusClient, tc.clientIncusClientErr
-				},
-			}
-
-			inventorySvc := &inventoryServiceMock.InventoryAggregateServiceMock{
-				GetAllWithFilterFunc: func(ctx context.Context, filter inventory.InventoryAggregateFilter) (inventory.InventoryAggregates, error) {
-					return queue.PopRetainLast(t, &tc.inventorySvcGetAllWithFilter)
-				},
-			}
-
-			inventorySyncer := &serviceMock.InventorySyncerMock{
-				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
-					return tc.inventorySyncerErr
-				},
-			}
-
-			clusterSvc := provisioningCluster.New(
-				repo,
-				nil,
-				client,
-				serverSvc,
-				nil,
-				map[domain.ResourceType]provisioning.InventorySyncer{
-					"test": inventorySyncer,
-				},
-				nil,
-				inventorySvc,
-				provisioningCluster.WithRemoveServerFactoryResetWaitDelay(0),
-				provisioningCluster.WithRemoveServerDeleteClusterMemberRetryDelay(0),
-			)
-
-			// Context with timeout for incusClient.DeleteClusterMember test.
-			ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
-			defer cancel()
-
-			// Run test
-			err = clusterSvc.RemoveServer(ctx, "one", []string{"serverOne"})
-
-			// Assert
-			tc.assertErr(t, err)
-			tc.assertLog(t, logBuf)
-		})
-	}
-}
-
-func TestClusterService_GetAll(t *testing.T) {
-	tests := []struct {
-		name               string
-		repoGetAllClusters provisioning.Clusters
-		repoGetAllErr      error
-
-		assertErr require.ErrorAssertionFunc
-		count     int
-	}{
-		{
-			name: "success",
-			repoGetAllClusters: provisioning.Clusters{
-				provisioning.Cluster{
-					Name:          "one",
-					ServerNames:   []string{"server1", "server2"},
-					ConnectionURL: "http://one/",
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
+					},
 				},
-				provisioning.Cluster{
-					Name:          "one",
-					ServerNames:   []string{"server1", "server2"},
-					ConnectionURL: "http://one/",
+				// GetAllWithFilter, needs update
+				{
+					Err: boom.Error,
 				},
 			},
 
-			assertErr: require.NoError,
-			count:     2,
-		},
-		{
-			name:          "error - repo",
-			repoGetAllErr: boom.Error,
-
 			assertErr: boom.ErrorIs,
-			count:     0,
+			assertLog: log.Empty,
 		},
-	}
+		{
+			name: "error - server not ready",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
-					return tc.repoGetAllClusters, tc.repoGetAllErr
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
-			}
-
-			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
-
-			// Run test
-			clusters, err := clusterSvc.GetAll(context.Background())
-
-			// Assert
-			tc.assertErr(t, err)
-			require.Len(t, clusters, tc.count)
-		})
-	}
-}
-
-func TestClusterService_GetAllWithFilter(t *testing.T) {
-	tests := []struct {
-		name                      string
-		filter                    provisioning.ClusterFilter
-		repoGetAllWithFilter      provisioning.Clusters
-		repoGetAllWithFilterErr   error
-		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
-
-		assertErr require.ErrorAssertionFunc
-		count     int
-	}{
-		{
-			name:   "success - no filter expression",
-			filter: provisioning.ClusterFilter{},
-			repoGetAllWithFilter: provisioning.Clusters{
-				provisioning.Cluster{
-					Name: "one",
+			},
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
 				},
-				provisioning.Cluster{
-					Name: "two",
+				// Update reverter
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
 					Value: provisioning.Servers{
 						{
-							Name: "server1",
-							VersionData: api.ServerVersionData{
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(false),
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name: "server2",
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusOffline,
+							StatusDetail:  api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
 								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(false),
-								InMaintenance: ptr.To(api.NotInMaintenance),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
 							},
 						},
 					},
 				},
+				// GetAllWithFilter, needs update
 				{
 					Value: provisioning.Servers{
 						{
-							Name: "serverA",
-							VersionData: api.ServerVersionData{
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(false),
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name: "serverB",
+							Name:   "A",
+							Status: api.ServerStatusOffline,
 							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
 								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
@@ -13059,906 +18145,781 @@ func TestClusterService_GetAllWithFilter(t *testing.T) {
 				},
 			},
 
-			assertErr: require.NoError,
-			count:     2,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(t, err, `is in state`)
+			},
+			assertLog: log.Empty,
 		},
 		{
-			name: "success - with filter expression",
-			filter: provisioning.ClusterFilter{
-				Expression: ptr.To(`name == "one"`),
+			name: "error - server in maintenance - evacuating",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
+
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
+				},
 			},
-			repoGetAllWithFilter: provisioning.Clusters{
-				provisioning.Cluster{
-					Name: "one",
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
 				},
-				provisioning.Cluster{
-					Name: "two",
+				// Update reverter
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
 					Value: provisioning.Servers{
 						{
-							Name: "server1",
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
 								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(false),
-								InMaintenance: ptr.To(api.NotInMaintenance),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuating),
 							},
 						},
+					},
+				},
+				// GetAllWithFilter, needs update
+				{
+					Value: provisioning.Servers{
 						{
-							Name: "server2",
+							Name:          "A",
+							ConnectionURL: "https://a:8443/",
+							Status:        api.ServerStatusReady,
+							StatusDetail:  api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
 								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(false),
-								InMaintenance: ptr.To(api.NotInMaintenance),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.InMaintenanceEvacuating),
 							},
 						},
 					},
 				},
 			},
 
-			assertErr: require.NoError,
-			count:     1,
-		},
-		{
-			name: "error - non bool expression",
-			filter: provisioning.ClusterFilter{
-				Expression: ptr.To(`"string"`), // invalid, does evaluate to string instead of boolean.
-			},
-			repoGetAllWithFilter: provisioning.Clusters{
-				provisioning.Cluster{
-					Name: "one",
-				},
-			},
-
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, "Failed to compile filter expression:")
+				require.ErrorContains(t, err, `Server "A" (https://a:8443/) is in maintenance state "evacuating"`)
 			},
-			count: 0,
+			assertLog: log.Empty,
 		},
 		{
-			name: "error - filter expression run",
-			filter: provisioning.ClusterFilter{
-				Expression: ptr.To(`fromBase64("~invalid") == ""`), // invalid, returns runtime error during evauluation of the expression.
-			},
-			repoGetAllWithFilter: provisioning.Clusters{
-				provisioning.Cluster{
-					Name: "one",
-				},
-			},
+			name: "error - repo.Update - after verification",
+			repoGetByName: &provisioning.Cluster{
+				Name:    "one",
+				Channel: "stable",
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, "Failed to execute filter expression:")
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
+				},
 			},
-			count: 0,
-		},
-		{
-			name:                    "error - repo",
-			repoGetAllWithFilterErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			count:     0,
-		},
-		{
-			name:   "error - serverSvc.GetAllWithFilter",
-			filter: provisioning.ClusterFilter{},
-			repoGetAllWithFilter: provisioning.Clusters{
-				provisioning.Cluster{
-					Name: "one",
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				// Update pre validation
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
 				},
-				provisioning.Cluster{
-					Name: "two",
+				// Update post validation
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					},
+					Err: boom.Error,
+				},
+				// Update reverter
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressInactive,
+					},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Err: boom.Error,
+					Value: provisioning.Servers{
+						{
+							Name:         "A",
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						{
+							Name:         "A",
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								OS: api.OSVersionData{
+									Version:          "1",
+									VersionNext:      "2",
+									AvailableVersion: ptr.To("2"),
+									NeedsReboot:      true,
+								},
+								NeedsUpdate:   ptr.To(false),
+								NeedsReboot:   ptr.To(true),
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
 				},
 			},
 
 			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
 		},
 	}
 
+	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, false, false)
+			require.NoError(t, err)
+
 			repo := &mock.ClusterRepoMock{
-				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
-					return tc.repoGetAllWithFilter, tc.repoGetAllWithFilterErr
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					inProgressStatus, err := queue.Pop(t, &tc.repoUpdate)
+
+					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
+					require.Equal(t, inProgressStatus.InProgress, cluster.UpdateStatus.InProgressStatus.InProgress)
+					require.ElementsMatch(t, cluster.UpdateStatus.InProgressStatus.EvacuatedBefore, inProgressStatus.EvacuatedBefore)
+					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return tc.serverSvcPollServers
+				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			clusterSvc := provisioningCluster.New(
+				repo, nil, nil, serverSvc, nil, nil, nil, nil,
+				provisioningCluster.WithNow(func() time.Time {
+					return fixedTime
+				}),
+				provisioningCluster.WithPendingUpdateRecheckInterval(0),
+			)
 
 			// Run test
-			cluster, err := clusterSvc.GetAllWithFilter(context.Background(), tc.filter)
-
-			// Assert
-			tc.assertErr(t, err)
-			require.Len(t, cluster, tc.count)
-			require.Empty(t, tc.serverSvcGetAllWithFilter)
-		})
-	}
-}
-
-func TestClusterService_GetAllNames(t *testing.T) {
-	tests := []struct {
-		name               string
-		repoGetAllNames    []string
-		repoGetAllNamesErr error
-
-		assertErr require.ErrorAssertionFunc
-		count     int
-	}{
-		{
-			name: "success",
-			repoGetAllNames: []string{
-				"one", "two",
-			},
-
-			assertErr: require.NoError,
-			count:     2,
-		},
-		{
-			name:               "error - repo",
-			repoGetAllNamesErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			count:     0,
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetAllNamesFunc: func(ctx context.Context) ([]string, error) {
-					return tc.repoGetAllNames, tc.repoGetAllNamesErr
-				},
+			ctx := t.Context()
+			if tc.tamperContext != nil {
+				ctx = tc.tamperContext(ctx, t)
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
-
-			// Run test
-			clusterNames, err := clusterSvc.GetAllNames(context.Background())
+			err = clusterSvc.LaunchClusterUpdate(ctx, "one", tc.rebootArg)
 
 			// Assert
 			tc.assertErr(t, err)
-			require.Len(t, clusterNames, tc.count)
+			tc.assertLog(t, logBuf)
+			require.Empty(t, tc.repoUpdate)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
 		})
 	}
 }
 
-func TestClusterService_GetAllNamesWithFilter(t *testing.T) {
-	tests := []struct {
-		name                         string
-		filter                       provisioning.ClusterFilter
-		repoGetAllNamesWithFilter    []string
-		repoGetAllNamesWithFilterErr error
-
-		assertErr require.ErrorAssertionFunc
-		count     int
-	}{
-		{
-			name:   "success - no filter expression",
-			filter: provisioning.ClusterFilter{},
-			repoGetAllNamesWithFilter: []string{
-				"one", "two",
-			},
-
-			assertErr: require.NoError,
-			count:     2,
-		},
-		{
-			name: "success - with filter expression",
-			filter: provisioning.ClusterFilter{
-				Expression: ptr.To(`name matches "one"`),
-			},
-			repoGetAllNamesWithFilter: []string{
-				"one", "two",
-			},
-
-			assertErr: require.NoError,
-			count:     1,
-		},
-		{
-			name: "error - non bool expression",
-			filter: provisioning.ClusterFilter{
-				Expression: ptr.To(`"string"`), // invalid, does evaluate to string instead of boolean.
-			},
-			repoGetAllNamesWithFilter: []string{
-				"one",
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, "Failed to compile filter expression:")
-			},
-			count: 0,
-		},
-		{
-			name: "error - filter expression run",
-			filter: provisioning.ClusterFilter{
-				Expression: ptr.To(`fromBase64("~invalid") == ""`), // invalid, returns runtime error during evauluation of the expression.
-			},
-			repoGetAllNamesWithFilter: []string{
-				"one",
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, "Failed to execute filter expression:")
+func rebootReadyServer(mutators ...func(server *provisioning.Server)) provisioning.Server {
+	server := provisioning.Server{
+		Name:          "A",
+		ConnectionURL: "https://a:8443/",
+		Status:        api.ServerStatusReady,
+		StatusDetail:  api.ServerStatusDetailNone,
+		VersionData: api.ServerVersionData{
+			OS: api.OSVersionData{
+				Version:     "1",
+				VersionNext: "1",
+				NeedsReboot: false,
 			},
-			count: 0,
-		},
-		{
-			name:                         "error - repo",
-			repoGetAllNamesWithFilterErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			count:     0,
+			NeedsUpdate:   ptr.To(false),
+			NeedsReboot:   ptr.To(false),
+			InMaintenance: ptr.To(api.NotInMaintenance),
 		},
 	}
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetAllNamesFunc: func(ctx context.Context) ([]string, error) {
-					return tc.repoGetAllNamesWithFilter, tc.repoGetAllNamesWithFilterErr
-				},
-			}
-
-			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
-
-			// Run test
-			clusterIDs, err := clusterSvc.GetAllNamesWithFilter(context.Background(), tc.filter)
-
-			// Assert
-			tc.assertErr(t, err)
-			require.Len(t, clusterIDs, tc.count)
-		})
+	for _, mutate := range mutators {
+		mutate(&server)
 	}
+
+	return server
 }
 
-func TestClusterService_GetByName(t *testing.T) {
-	tests := []struct {
-		name                         string
-		nameArg                      string
-		repoGetByNameCluster         *provisioning.Cluster
-		repoGetByNameErr             error
-		serverSvcGetAllWithFilter    provisioning.Servers
-		serverSvcGetAllWithFilterErr error
+func TestClusterService_LaunchClusterReboot(t *testing.T) {
+	tests := []struct {
+		name                      string
+		repoGetByName             []queue.Item[*provisioning.Cluster]
+		repoUpdate                []queue.Item[api.ClusterUpdateInProgressStatus] // api.ClusterUpdateInProgressStatus used for assertions in repo.Update
+		serverSvcPollServers      error
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
 
-		assertErr   require.ErrorAssertionFunc
-		wantCluster *provisioning.Cluster
+		assertErr require.ErrorAssertionFunc
 	}{
 		{
-			name:    "success",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server2"},
-				ConnectionURL: "http://one/",
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					Name: "server1",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(false),
-						NeedsReboot:   ptr.To(false),
-						InMaintenance: ptr.To(api.NotInMaintenance),
-					},
-				},
+			name: "error - repo.GetByName",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
 				{
-					Name: "server2",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(false),
-						NeedsReboot:   ptr.To(false),
-						InMaintenance: ptr.To(api.NotInMaintenance),
-					},
+					Err: boom.Error,
 				},
 			},
 
-			assertErr: require.NoError,
-			wantCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server2"},
-				ConnectionURL: "http://one/",
-				UpdateStatus: api.ClusterUpdateStatus{
-					NeedsUpdate:   []string{},
-					NeedsReboot:   []string{},
-					InMaintenance: []string{},
-				},
-			},
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "success - with update status",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server2", "server3", "server4"},
-				ConnectionURL: "http://one/",
-			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			name: "error - GetByName - serverSvc.GetAllWithFilter",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
 				{
-					Name: "server1",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(true),
-						NeedsReboot:   ptr.To(false),
-						InMaintenance: ptr.To(api.NotInMaintenance),
+					Value: &provisioning.Cluster{
+						Name: "one",
 					},
 				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name: "server2",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(false),
-						NeedsReboot:   ptr.To(true),
-						InMaintenance: ptr.To(api.NotInMaintenance),
-					},
+					Err: boom.Error,
 				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - serverSvc.PollServers",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
 				{
-					Name: "server3",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(false),
-						NeedsReboot:   ptr.To(false),
-						InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: &provisioning.Cluster{
+						Name: "one",
 					},
 				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name: "server4",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(false),
-						NeedsReboot:   ptr.To(false),
-						InMaintenance: ptr.To(api.InMaintenanceRestoring),
-					},
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
 			},
+			serverSvcPollServers: boom.Error,
 
-			assertErr: require.NoError,
-			wantCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server2", "server3", "server4"},
-				ConnectionURL: "http://one/",
-				UpdateStatus: api.ClusterUpdateStatus{
-					NeedsUpdate:   []string{"server1"},
-					NeedsReboot:   []string{"server2"},
-					InMaintenance: []string{"server3", "server4"},
-				},
-			},
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "success - with update status error",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1"},
-				ConnectionURL: "http://one/",
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressError,
-						Error:      "error",
+			name: "error - serverSvc.GetAllWithFilter",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name: "server1",
-					VersionData: api.ServerVersionData{
-						NeedsUpdate:   ptr.To(true),
-						NeedsReboot:   ptr.To(false),
-						InMaintenance: ptr.To(api.NotInMaintenance),
-					},
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
-			},
-
-			assertErr: require.NoError,
-			wantCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1"},
-				ConnectionURL: "http://one/",
-				UpdateStatus: api.ClusterUpdateStatus{
-					NeedsUpdate:   []string{"server1"},
-					NeedsReboot:   []string{},
-					InMaintenance: []string{},
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress:        api.ClusterUpdateInProgressError,
-						Error:             "error",
-						StatusDescription: ptr.To("error"),
-					},
+				// GetAllWithFilter
+				{
+					Err: boom.Error,
 				},
 			},
-		},
-		{
-			name:    "error - name empty",
-			nameArg: "", // invalid
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-			},
-		},
-		{
-			name:             "error - repo",
-			nameArg:          "one",
-			repoGetByNameErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - serverSvc.GetAllWithFilter",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server2"},
-				ConnectionURL: "http://one/",
+			name: "error - serverSvc.GetAllWithFilter does not return any servers",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
 			},
-			serverSvcGetAllWithFilterErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return tc.repoGetByNameCluster, tc.repoGetByNameErr
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
-			}
-
-			serverSvc := &serviceMock.ServerServiceMock{
-				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
-					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				// GetAllWithFilter
+				{
+					Value: nil, // No servers found.
 				},
-			}
-
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
-
-			// Run test
-			cluster, err := clusterSvc.GetByName(context.Background(), tc.nameArg)
-
-			// Assert
-			tc.assertErr(t, err)
-			require.Equal(t, tc.wantCluster, cluster)
-		})
-	}
-}
-
-func TestClusterService_Update(t *testing.T) {
-	tests := []struct {
-		name                         string
-		argUpdateServers             bool
-		cluster                      provisioning.Cluster
-		repoGetByName                *provisioning.Cluster
-		repoGetByNameErr             error
-		repoUpdateErrs               queue.Errs
-		serverSvcGetAllWithFilter    []provisioning.Server
-		serverSvcGetAllWithFilterErr error
-		serverSvcUpdateErrs          queue.Errs
+			},
 
-		assertErr require.ErrorAssertionFunc
-		assertLog log.MatcherFunc
-	}{
-		{
-			name:             "success",
-			argUpdateServers: true,
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrNotFound, a...)
 			},
-			repoGetByName: &provisioning.Cluster{
-				Name:          "one",
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+		},
+		{
+			name: "error - server not ready",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
 			},
-			serverSvcGetAllWithFilter: []provisioning.Server{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name: "one",
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
+				// GetAllWithFilter
 				{
-					Name: "two",
+					Value: provisioning.Servers{
+						rebootReadyServer(func(server *provisioning.Server) {
+							server.Status = api.ServerStatusOffline
+						}),
+					},
 				},
 			},
 
-			assertErr: require.NoError,
-			assertLog: log.Noop,
-		},
-		{
-			name:             "success - without servers update",
-			argUpdateServers: false,
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in state "offline"`, a...)
 			},
-
-			assertErr: require.NoError,
-			assertLog: log.Noop,
 		},
 		{
-			name: "error - validation",
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ConnectionURL: ":|\\", // invalid
-				Channel:       "stable",
+			name: "error - server in maintenance - evacuating",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						rebootReadyServer(func(server *provisioning.Server) {
+							server.VersionData.InMaintenance = ptr.To(api.InMaintenanceEvacuating)
+						}),
+					},
+				},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in maintenance state "evacuating"`, a...)
 			},
-			assertLog: log.Noop,
 		},
 		{
-			name: "error - repo.GetByName",
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server3"},
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			name: "error - server in maintenance - restoring",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						rebootReadyServer(func(server *provisioning.Server) {
+							server.VersionData.InMaintenance = ptr.To(api.InMaintenanceRestoring)
+						}),
+					},
+				},
 			},
-			repoGetByNameErr: boom.Error,
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Noop,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in maintenance state "restoring"`, a...)
+			},
 		},
 		{
-			name: "error - repo.Update",
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server3"},
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			name: "error - server with unknown maintenance state",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
 			},
-			repoUpdateErrs: queue.Errs{
-				boom.Error,
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						rebootReadyServer(func(server *provisioning.Server) {
+							server.VersionData.InMaintenance = nil
+						}),
+					},
+				},
 			},
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Noop,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in maintenance state`, a...)
+			},
 		},
 		{
-			name:             "error - serverSvc.GetAllNamesWithFilter",
-			argUpdateServers: true,
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server3"},
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			name: "error - repo.GetByName in transaction",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
+				// GetByName in transaction
+				{
+					Err: boom.Error,
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
 			},
-			serverSvcGetAllWithFilterErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
-			assertLog: log.Noop,
 		},
 		{
-			name:             "error - serverSvc.Update",
-			argUpdateServers: true,
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server3"},
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
-			},
-			repoGetByName: &provisioning.Cluster{
-				Name:          "one",
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
-			},
-			serverSvcGetAllWithFilter: []provisioning.Server{
+			name: "error - cluster update already in progress - started in the meantime",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
 				{
-					Name: "one",
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
 				},
+				// GetByName in transaction
 				{
-					Name: "two",
+					Value: &provisioning.Cluster{
+						Name: "one",
+
+						UpdateStatus: api.ClusterUpdateStatus{
+							InProgressStatus: api.ClusterUpdateInProgressStatus{
+								InProgress: api.ClusterUpdateInProgressApplyUpdate,
+							},
+						},
+					},
 				},
 			},
-			serverSvcUpdateErrs: queue.Errs{
-				boom.Error,
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
 			},
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Noop,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
 		},
 		{
-			name:             "error - serverSvc.Update - 2nd update - reverter errors",
-			argUpdateServers: true,
-			cluster: provisioning.Cluster{
-				Name:          "one",
-				ServerNames:   []string{"server1", "server3"},
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			name: "error - repo.Update",
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// GetByName
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
+				// GetByName in transaction
+				{
+					Value: &provisioning.Cluster{
+						Name: "one",
+					},
+				},
 			},
-			repoGetByName: &provisioning.Cluster{
-				Name:          "one",
-				ConnectionURL: "http://one/",
-				Channel:       "stable",
+			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+				{
+					Value: api.ClusterUpdateInProgressStatus{
+						InProgress:    api.ClusterUpdateInProgressRollingReboot,
+						PendingReboot: []string{"A"},
+					},
+					Err: boom.Error,
+				},
 			},
-			serverSvcGetAllWithFilter: []provisioning.Server{
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
 				{
-					Name: "one",
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
+				// GetAllWithFilter
 				{
-					Name: "two",
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
 			},
-			repoUpdateErrs: queue.Errs{
-				nil,
-				boom.Error,
-			},
-			serverSvcUpdateErrs: queue.Errs{
-				nil,
-				boom.Error,
-				boom.Error,
-			},
 
 			assertErr: boom.ErrorIs,
-			assertLog: func(t log.TestifyT, logBuf *bytes.Buffer) {
-				t.Helper()
-				log.Contains("Failed to restore previous cluster state after failed to update servers of the cluster cluster=one err=boom!")(t, logBuf)
-				log.Contains("Failed to restore previous server state after failed to update member server of cluster cluster=one server=one err=boom!")(t, logBuf)
-			},
 		},
 	}
 
+	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
-			logBuf := &bytes.Buffer{}
-			err := logger.InitLogger(logBuf, "", false, true, true)
-			require.NoError(t, err)
-
 			repo := &mock.ClusterRepoMock{
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return tc.repoGetByName, tc.repoGetByNameErr
+					return queue.Pop(t, &tc.repoGetByName)
 				},
-				UpdateFunc: func(ctx context.Context, in provisioning.Cluster) error {
-					return tc.repoUpdateErrs.PopOrNil(t)
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					inProgressStatus, err := queue.Pop(t, &tc.repoUpdate)
+
+					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
+					require.Equal(t, inProgressStatus.InProgress, cluster.UpdateStatus.InProgressStatus.InProgress)
+					require.ElementsMatch(t, inProgressStatus.EvacuatedBefore, cluster.UpdateStatus.InProgressStatus.EvacuatedBefore)
+					require.Equal(t, inProgressStatus.PendingReboot, cluster.UpdateStatus.InProgressStatus.PendingReboot)
+					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
-				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
-					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return tc.serverSvcPollServers
 				},
-				UpdateFunc: func(ctx context.Context, server provisioning.Server, force, updateSystem, bmcConnectionTest bool) error {
-					return tc.serverSvcUpdateErrs.PopOrNil(t)
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			clusterSvc := provisioningCluster.New(
+				repo, nil, nil, serverSvc, nil, nil, nil, nil,
+				provisioningCluster.WithNow(func() time.Time {
+					return fixedTime
+				}),
+			)
 
 			// Run test
-			err = clusterSvc.Update(context.Background(), tc.cluster, tc.argUpdateServers)
+			err := clusterSvc.LaunchClusterReboot(t.Context(), "one")
 
 			// Assert
 			tc.assertErr(t, err)
-			tc.assertLog(t, logBuf)
-
-			require.Empty(t, tc.repoUpdateErrs)
-			require.Empty(t, tc.serverSvcUpdateErrs)
+			require.Empty(t, tc.repoGetByName)
+			require.Empty(t, tc.repoUpdate)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
 		})
 	}
 }
 
-func TestClusterService_Rename(t *testing.T) {
+func TestClusterService_PlanClusterReboot(t *testing.T) {
 	tests := []struct {
-		name          string
-		oldName       string
-		newName       string
-		repoRenameErr error
-		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
+		name                            string
+		repoGetByName                   *provisioning.Cluster
+		repoGetByNameErr                error
+		serverSvcGetAllWithFilter       []queue.Item[provisioning.Servers]
+		inventorySvcGetAllWithFilter    inventory.InventoryAggregates
+		inventorySvcGetAllWithFilterErr error
 
 		assertErr require.ErrorAssertionFunc
+		wantSteps []api.ClusterUpdatePlanStep
 	}{
 		{
-			name:    "success",
-			oldName: "one",
-			newName: "one new",
-
-			assertErr:     require.NoError,
-			signalHandler: requireCallSignalHandler,
-		},
-		{
-			name:    "error - old name empty",
-			oldName: "", // invalid
-			newName: "one new",
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-			},
-			signalHandler: requireNoCallSignalHandler,
-		},
-		{
-			name:    "error - new name empty",
-			oldName: "one",
-			newName: "", // invalid
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
+			name: "success",
+			repoGetByName: &provisioning.Cluster{
+				Name: "one",
 			},
-			signalHandler: requireNoCallSignalHandler,
-		},
-		{
-			name:          "error - repo.Rename",
-			oldName:       "one",
-			newName:       "one new",
-			repoRenameErr: boom.Error,
-
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				RenameFunc: func(ctx context.Context, oldName string, newName string) error {
-					require.Equal(t, tc.oldName, oldName)
-					require.Equal(t, tc.newName, newName)
-					return tc.repoRenameErr
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
 				},
-			}
-
-			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
-			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
-			defer func() {
-				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
-			}()
-
-			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
-
-			var signalHandlerCalled bool
-			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
-
-			// Run test
-			err := clusterSvc.Rename(context.Background(), tc.oldName, tc.newName)
-
-			// Assert
-			tc.assertErr(t, err)
-			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
-		})
-	}
-}
-
-func TestClusterService_DeleteByName(t *testing.T) {
-	tests := []struct {
-		name                                string
-		nameArg                             string
-		force                               bool
-		repoGetByNameCluster                *provisioning.Cluster
-		repoGetByNameErr                    error
-		repoDeleteByNameErr                 error
-		serverSvcGetAllNamesWithFilterNames []string
-		serverSvcGetAllNamesWithFilterErr   error
-
-		assertErr     require.ErrorAssertionFunc
-		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
-	}{
-		{
-			name:    "success",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Status: api.ClusterStatusPending,
 			},
-
-			assertErr:     require.NoError,
-			signalHandler: requireCallSignalHandler,
-		},
-		{
-			name:    "success - force",
-			nameArg: "one",
-			force:   true,
-			repoGetByNameCluster: &provisioning.Cluster{
-				Status: api.ClusterStatusPending,
+			inventorySvcGetAllWithFilter: inventory.InventoryAggregates{
+				{
+					Cluster: "one",
+					Instances: inventory.Instances{
+						{
+							Server:      "A",
+							ProjectName: "default",
+							Name:        "c1",
+							Object: inventory.IncusInstanceFullWrapper{
+								InstanceFull: incusapi.InstanceFull{
+									Instance: incusapi.Instance{
+										Status: "Running",
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 
-			assertErr:     require.NoError,
-			signalHandler: requireCallSignalHandler,
-		},
-		{
-			name:    "error - name empty",
-			nameArg: "", // invalid
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			assertErr: require.NoError,
+			wantSteps: []api.ClusterUpdatePlanStep{
+				{Server: "A", Action: api.ClusterUpdatePlanActionEvacuate, Batch: 1},
+				{Server: "A", Action: api.ClusterUpdatePlanActionReboot, Batch: 1},
+				{Server: "A", Action: api.ClusterUpdatePlanActionRestore, Batch: 1},
 			},
-			signalHandler: requireNoCallSignalHandler,
-		},
-		{
-			name:                "error - force - repo.DeleteByName",
-			nameArg:             "one",
-			force:               true,
-			repoDeleteByNameErr: boom.Error,
-
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
 		},
 		{
 			name:             "error - repo.GetByName",
-			nameArg:          "one",
 			repoGetByNameErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - cluster state ready",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Status: api.ClusterStatusReady,
+			name: "error - operation in progress",
+			repoGetByName: &provisioning.Cluster{
+				Name: "one",
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: api.ClusterUpdateInProgressRollingReboot,
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-				require.ErrorContains(tt, err, `Delete for cluster in state "ready":`)
 			},
-			signalHandler: requireNoCallSignalHandler,
 		},
 		{
-			name:                 "error - cluster state not set",
-			nameArg:              "one",
-			repoGetByNameCluster: &provisioning.Cluster{},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-				require.ErrorContains(tt, err, "Delete for cluster with invalid state:")
+			name: "error - serverSvc.GetAllWithFilter",
+			repoGetByName: &provisioning.Cluster{
+				Name: "one",
 			},
-			signalHandler: requireNoCallSignalHandler,
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - cluster with linked servers",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Status: api.ClusterStatusPending,
+			name: "error - server not ready",
+			repoGetByName: &provisioning.Cluster{
+				Name: "one",
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						rebootReadyServer(func(server *provisioning.Server) {
+							server.Status = api.ServerStatusOffline
+						}),
+					},
+				},
 			},
-			serverSvcGetAllNamesWithFilterNames: []string{"one"},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-				require.ErrorContains(tt, err, "Delete for cluster with 1 linked servers ([one])")
-			},
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: require.Error,
 		},
 		{
-			name:    "error - serverSvc.GetallNamesWithFilter",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Status: api.ClusterStatusPending,
+			name: "error - inventorySvc.GetAllWithFilter",
+			repoGetByName: &provisioning.Cluster{
+				Name: "one",
 			},
-			serverSvcGetAllNamesWithFilterErr: boom.Error,
-
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
-		},
-		{
-			name:    "error - repo.DeleteByID",
-			nameArg: "one",
-			repoGetByNameCluster: &provisioning.Cluster{
-				Status: api.ClusterStatusPending,
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{rebootReadyServer()},
+				},
 			},
-			repoDeleteByNameErr: boom.Error,
+			inventorySvcGetAllWithFilterErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: boom.ErrorIs,
 		},
 	}
 
@@ -13967,406 +18928,812 @@ func TestClusterService_DeleteByName(t *testing.T) {
 			// Setup
 			repo := &mock.ClusterRepoMock{
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return tc.repoGetByNameCluster, tc.repoGetByNameErr
-				},
-				DeleteByNameFunc: func(ctx context.Context, name string) error {
-					return tc.repoDeleteByNameErr
+					return tc.repoGetByName, tc.repoGetByNameErr
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
-				GetAllNamesWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) ([]string, error) {
-					return tc.serverSvcGetAllNamesWithFilterNames, tc.serverSvcGetAllNamesWithFilterErr
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
 			}
 
-			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
-			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
-			defer func() {
-				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
-			}()
-
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			inventorySvc := &inventoryServiceMock.InventoryAggregateServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter inventory.InventoryAggregateFilter) (inventory.InventoryAggregates, error) {
+					require.Equal(t, []string{"one"}, filter.Clusters)
+					return tc.inventorySvcGetAllWithFilter, tc.inventorySvcGetAllWithFilterErr
+				},
+			}
 
-			var signalHandlerCalled bool
-			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, inventorySvc)
 
 			// Run test
-			err := clusterSvc.DeleteByName(context.Background(), tc.nameArg, tc.force)
+			plan, err := clusterSvc.PlanClusterReboot(t.Context(), "one")
 
 			// Assert
 			tc.assertErr(t, err)
-			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
+			require.Equal(t, tc.wantSteps, plan.Steps)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			if err == nil {
+				require.Equal(t, api.ClusterUpdateInProgressRollingReboot, plan.Operation)
+				require.True(t, plan.InMaintenanceWindow)
+				require.Nil(t, plan.Changelog)
+				require.Len(t, plan.Servers, 1)
+				require.Equal(t, []api.ClusterUpdatePlanInstance{
+					{
+						Name:    "c1",
+						Project: "default",
+						Status:  "Running",
+					},
+				}, plan.Servers[0].Instances)
+			}
 		})
 	}
 }
 
-func TestDeleteAndFactoryResetByName(t *testing.T) {
-	tests := []struct {
-		name             string
-		nameArg          string
-		tokenArg         *uuid.UUID
-		tokenSeedNameArg *string
-
-		serverSvcGetAllWithFilter         provisioning.Servers
-		serverSvcGetAllWithFilterErr      error
-		clientPingErr                     error
-		clientSystemFactoryResetErr       error
-		tokenSvcGetTokenSeedByName        *provisioning.TokenSeed
-		tokenSvcGetTokenSeedByNameErr     error
-		tokenSvcCreate                    provisioning.Token
-		tokenSvcCreateErr                 error
-		tokenSvcGetTokenProviderConfig    *api.TokenProviderConfig
-		tokenSvcGetTokenProviderConfigErr error
-		repoDeleteByNameErr               error
-
-		assertErr     require.ErrorAssertionFunc
-		signalHandler func(t *testing.T, called *bool) func(ctx context.Context, cum lifecycle.ClusterUpdateMessage)
-	}{
-		{
-			name:    "success",
-			nameArg: "one",
+func TestClusterService_LaunchAutomaticClusterUpdates(t *testing.T) {
+	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
 
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{
-					VersionData: api.ServerVersionData{
-						Applications: []api.ApplicationVersionData{
-							{
-								Name: "debug",
-							},
-							{
-								Name: "incus-lts-7.0",
-							},
-						},
-					},
+	autoUpdateCluster := func() *provisioning.Cluster {
+		return &provisioning.Cluster{
+			Name:          "one",
+			ConnectionURL: "https://one/",
+			Channel:       "stable",
+			Config: api.ClusterConfig{
+				AutoUpdate: api.ClusterConfigAutoUpdate{
+					Enabled:     true,
+					MinSeverity: images.UpdateSeverityHigh,
+					MinAge:      "24h",
 				},
-				{},
 			},
-			tokenSvcCreate: provisioning.Token{
-				UUID: uuidgen.FromPattern(t, "1"),
+		}
+	}
+
+	serverNeedsUpdate := provisioning.Server{
+		Name:   "server1",
+		Status: api.ServerStatusReady,
+		VersionData: api.ServerVersionData{
+			OS: api.OSVersionData{
+				Version: "202603010000",
 			},
-			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
-				Version: "1",
-				SystemProviderConfig: incusosapi.SystemProviderConfig{
-					Name: "operations-center",
-					Config: map[string]string{
-						"server_url":   "https://1.2.3.4:8443",
-						"server_token": uuidgen.FromPattern(t, "1").String(),
-					},
+			Applications: []api.ApplicationVersionData{
+				{
+					Name:    "incus",
+					Version: "202602010000",
 				},
 			},
+			NeedsUpdate:   ptr.To(true),
+			InMaintenance: ptr.To(api.NotInMaintenance),
+		},
+	}
 
-			assertErr:     require.NoError,
-			signalHandler: requireCallSignalHandler,
+	serverUpToDate := provisioning.Server{
+		Name:   "server1",
+		Status: api.ServerStatusReady,
+		VersionData: api.ServerVersionData{
+			OS: api.OSVersionData{
+				Version: "202603010000",
+			},
+			NeedsUpdate:   ptr.To(false),
+			InMaintenance: ptr.To(api.NotInMaintenance),
 		},
+	}
+
+	criticalUpdate := provisioning.Update{
+		Version:     "202603100000",
+		Severity:    images.UpdateSeverityCritical,
+		PublishedAt: fixedTime.Add(-48 * time.Hour),
+	}
+
+	tests := []struct {
+		name                      string
+		repoGetAllWithFilter      provisioning.Clusters
+		repoGetAllWithFilterErr   error
+		repoGetByName             []queue.Item[*provisioning.Cluster]
+		repoUpdate                []queue.Item[struct{}]
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+		serverSvcPollServersErr   error
+		updateSvcGetAllWithFilter []queue.Item[provisioning.Updates]
+
+		assertErr              require.ErrorAssertionFunc
+		wantAutoUpdateLaunches []api.ClusterAutoUpdateLaunch
+		wantWarnings           int
+		wantRemoveStale        int
+	}{
 		{
-			name:     "success - with token",
-			nameArg:  "one",
-			tokenArg: ptr.To(uuidgen.FromPattern(t, "1")),
+			name:                 "success - no clusters with auto update",
+			repoGetAllWithFilter: provisioning.Clusters{},
 
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
-				{},
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - cluster update already in progress",
+			repoGetAllWithFilter: provisioning.Clusters{
+				func() provisioning.Cluster {
+					cluster := autoUpdateCluster()
+					cluster.UpdateStatus.InProgressStatus.InProgress = api.ClusterUpdateInProgressApplyUpdate
+					return *cluster
+				}(),
 			},
-			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
-				Version: "1",
-				SystemProviderConfig: incusosapi.SystemProviderConfig{
-					Name: "operations-center",
-					Config: map[string]string{
-						"server_url":   "https://1.2.3.4:8443",
-						"server_token": uuidgen.FromPattern(t, "1").String(),
-					},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
 				},
 			},
 
-			assertErr:     require.NoError,
-			signalHandler: requireCallSignalHandler,
+			assertErr: require.NoError,
 		},
 		{
-			name:             "success - with token and tokenSeedName",
-			nameArg:          "one",
-			tokenArg:         ptr.To(uuidgen.FromPattern(t, "1")),
-			tokenSeedNameArg: ptr.To("token-seed-name"),
-
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
-				{},
-			},
-			tokenSvcGetTokenSeedByName: &provisioning.TokenSeed{
-				Token: uuidgen.FromPattern(t, "1"),
+			name: "success - cluster is up to date",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
 			},
-			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
-				Version: "1",
-				SystemProviderConfig: incusosapi.SystemProviderConfig{
-					Name: "operations-center",
-					Config: map[string]string{
-						"server_url":   "https://1.2.3.4:8443",
-						"server_token": uuidgen.FromPattern(t, "1").String(),
-					},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverUpToDate},
 				},
 			},
 
-			assertErr:     require.NoError,
-			signalHandler: requireCallSignalHandler,
+			assertErr: require.NoError,
 		},
-
 		{
-			name:    "error - name empty",
-			nameArg: "", // invalid
-
-			serverSvcGetAllWithFilterErr: boom.Error,
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			name: "success - no update matching the auto update policy",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				{
+					Value: provisioning.Updates{
+						// severity too low
+						{
+							Version:     "202603110000",
+							Severity:    images.UpdateSeverityLow,
+							PublishedAt: fixedTime.Add(-48 * time.Hour),
+						},
+						// too young
+						{
+							Version:     "202603100000",
+							Severity:    images.UpdateSeverityCritical,
+							PublishedAt: fixedTime.Add(-1 * time.Hour),
+						},
+						// already installed
+						{
+							Version:     "202602010000",
+							Severity:    images.UpdateSeverityCritical,
+							PublishedAt: fixedTime.Add(-720 * time.Hour),
+						},
+					},
+				},
 			},
-			signalHandler: requireNoCallSignalHandler,
-		},
-		{
-			name:    "error - serverSvc.GetAllWithFilter",
-			nameArg: "one",
-
-			serverSvcGetAllWithFilterErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: require.NoError,
 		},
 		{
-			name:    "error - no servers",
-			nameArg: "one",
-
-			serverSvcGetAllWithFilter: provisioning.Servers{},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
+			name: "success - cluster update launched",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
 			},
-			signalHandler: requireNoCallSignalHandler,
-		},
-		{
-			name:    "error - client.Ping",
-			nameArg: "one",
-
-			serverSvcGetAllWithFilter: provisioning.Servers{
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// LaunchClusterUpdate - GetByName
+				{
+					Value: autoUpdateCluster(),
+				},
+				// LaunchClusterUpdate - Update
+				{
+					Value: autoUpdateCluster(),
+				},
+				// record auto update launch
+				{
+					Value: autoUpdateCluster(),
+				},
+			},
+			repoUpdate: []queue.Item[struct{}]{
+				// LaunchClusterUpdate - Update
+				{},
+				// LaunchClusterUpdate - evacuated before
 				{},
+				// record auto update launch
 				{},
 			},
-			clientPingErr: boom.Error,
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// LaunchClusterUpdate - GetByName
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// LaunchClusterUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				{
+					Value: provisioning.Updates{criticalUpdate},
+				},
+			},
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: require.NoError,
+			wantAutoUpdateLaunches: []api.ClusterAutoUpdateLaunch{
+				{
+					LaunchedAt: fixedTime,
+					Version:    "202603100000",
+					Severity:   images.UpdateSeverityCritical,
+				},
+			},
+			wantRemoveStale: 1,
 		},
 		{
-			name:             "error - tokenSvc.GetTokenSeedByName",
-			nameArg:          "one",
-			tokenArg:         ptr.To(uuidgen.FromPattern(t, "1")),
-			tokenSeedNameArg: ptr.To("token-seed-name"),
+			name:                    "error - repo.GetAllWithFilter",
+			repoGetAllWithFilterErr: boom.Error,
 
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
-				{},
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - pendingAutoUpdate - serverSvc.GetAllWithFilter",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Err: boom.Error,
+				},
 			},
-			tokenSvcGetTokenSeedByNameErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - tokenSvc.Create",
-			nameArg: "one",
-
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
-				{},
+			name: "error - pendingAutoUpdate - updateSvc.GetAllWithFilter",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				{
+					Err: boom.Error,
+				},
 			},
-			tokenSvcCreateErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - tokenSvc.GetTokenProviderConfig",
-			nameArg: "one",
-
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
+			name: "error - LaunchClusterUpdate",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
+			},
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// LaunchClusterUpdate - GetByName
+				{
+					Err: boom.Error,
+				},
+				// record auto update launch
+				{
+					Value: autoUpdateCluster(),
+				},
+			},
+			repoUpdate: []queue.Item[struct{}]{
+				// record auto update launch
 				{},
 			},
-			tokenSvcCreate: provisioning.Token{
-				UUID: uuidgen.FromPattern(t, "1"),
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				{
+					Value: provisioning.Updates{criticalUpdate},
+				},
 			},
-			tokenSvcGetTokenProviderConfigErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: boom.ErrorIs,
+			wantAutoUpdateLaunches: []api.ClusterAutoUpdateLaunch{
+				{
+					LaunchedAt: fixedTime,
+					Version:    "202603100000",
+					Severity:   images.UpdateSeverityCritical,
+					Error:      `Failed to get cluster "one": Failed to get cluster "one" by name: boom!`,
+				},
+			},
+			wantWarnings: 1,
 		},
 		{
-			name:    "error - client.SystemFactoryReset",
-			nameArg: "one",
+			name: "error - LaunchClusterUpdate - oldest recorded launch is dropped",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
+			},
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// LaunchClusterUpdate - GetByName
+				{
+					Err: boom.Error,
+				},
+				// record auto update launch
+				{
+					Value: func() *provisioning.Cluster {
+						cluster := autoUpdateCluster()
+						for i := range 10 {
+							cluster.UpdateStatus.AutoUpdateLaunches = append(cluster.UpdateStatus.AutoUpdateLaunches, api.ClusterAutoUpdateLaunch{
+								LaunchedAt: fixedTime.Add(time.Duration(i-10) * time.Hour),
+								Version:    fixedTime.AddDate(0, 0, i-10).Format("200601020000"),
+								Severity:   images.UpdateSeverityCritical,
+							})
+						}
 
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
+						return cluster
+					}(),
+				},
+			},
+			repoUpdate: []queue.Item[struct{}]{
+				// record auto update launch
 				{},
 			},
-			tokenSvcCreate: provisioning.Token{
-				UUID: uuidgen.FromPattern(t, "1"),
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
 			},
-			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
-				Version: "1",
-				SystemProviderConfig: incusosapi.SystemProviderConfig{
-					Name: "operations-center",
-					Config: map[string]string{
-						"server_url":   "https://1.2.3.4:8443",
-						"server_token": uuidgen.FromPattern(t, "1").String(),
-					},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				{
+					Value: provisioning.Updates{criticalUpdate},
 				},
 			},
-			clientSystemFactoryResetErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr: boom.ErrorIs,
+			wantAutoUpdateLaunches: func() []api.ClusterAutoUpdateLaunch {
+				launches := []api.ClusterAutoUpdateLaunch{}
+				for i := 1; i < 10; i++ {
+					launches = append(launches, api.ClusterAutoUpdateLaunch{
+						LaunchedAt: fixedTime.Add(time.Duration(i-10) * time.Hour),
+						Version:    fixedTime.AddDate(0, 0, i-10).Format("200601020000"),
+						Severity:   images.UpdateSeverityCritical,
+					})
+				}
+
+				return append(launches, api.ClusterAutoUpdateLaunch{
+					LaunchedAt: fixedTime,
+					Version:    "202603100000",
+					Severity:   images.UpdateSeverityCritical,
+					Error:      `Failed to get cluster "one": Failed to get cluster "one" by name: boom!`,
+				})
+			}(),
+			wantWarnings: 1,
 		},
 		{
-			name:    "error - repo.DeleteByName",
-			nameArg: "one",
-
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
-				{},
+			name: "error - record auto update launch",
+			repoGetAllWithFilter: provisioning.Clusters{
+				*autoUpdateCluster(),
 			},
-			tokenSvcCreate: provisioning.Token{
-				UUID: uuidgen.FromPattern(t, "1"),
+			repoGetByName: []queue.Item[*provisioning.Cluster]{
+				// LaunchClusterUpdate - GetByName
+				{
+					Err: boom.Error,
+				},
+				// record auto update launch
+				{
+					Err: boom.Error,
+				},
 			},
-			tokenSvcGetTokenProviderConfig: &api.TokenProviderConfig{
-				Version: "1",
-				SystemProviderConfig: incusosapi.SystemProviderConfig{
-					Name: "operations-center",
-					Config: map[string]string{
-						"server_url":   "https://1.2.3.4:8443",
-						"server_token": uuidgen.FromPattern(t, "1").String(),
-					},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetAllWithFilter
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+				// pendingAutoUpdate
+				{
+					Value: provisioning.Servers{serverNeedsUpdate},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				{
+					Value: provisioning.Updates{criticalUpdate},
 				},
 			},
-			repoDeleteByNameErr: boom.Error,
 
-			assertErr:     boom.ErrorIs,
-			signalHandler: requireNoCallSignalHandler,
+			assertErr:    boom.ErrorIs,
+			wantWarnings: 1,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
+			var autoUpdateLaunches []api.ClusterAutoUpdateLaunch
+
 			repo := &mock.ClusterRepoMock{
-				DeleteByNameFunc: func(ctx context.Context, name string) error {
-					return tc.repoDeleteByNameErr
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ClusterFilter) (provisioning.Clusters, error) {
+					return tc.repoGetAllWithFilter, tc.repoGetAllWithFilterErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return queue.Pop(t, &tc.repoGetByName)
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					autoUpdateLaunches = cluster.UpdateStatus.AutoUpdateLaunches
+					return queue.PopErr(t, &tc.repoUpdate)
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
-					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
+				},
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return tc.serverSvcPollServersErr
 				},
 			}
 
-			tokenSvc := &serviceMock.TokenServiceMock{
-				GetTokenSeedByNameFunc: func(ctx context.Context, id uuid.UUID, name string) (*provisioning.TokenSeed, error) {
-					return tc.tokenSvcGetTokenSeedByName, tc.tokenSvcGetTokenSeedByNameErr
-				},
-				CreateFunc: func(ctx context.Context, token provisioning.Token) (provisioning.Token, error) {
-					return tc.tokenSvcCreate, tc.tokenSvcCreateErr
-				},
-				GetTokenProviderConfigFunc: func(ctx context.Context, id uuid.UUID) (*api.TokenProviderConfig, error) {
-					return tc.tokenSvcGetTokenProviderConfig, tc.tokenSvcGetTokenProviderConfigErr
+			updateSvc := &serviceMock.UpdateServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.UpdateFilter) (provisioning.Updates, error) {
+					require.Equal(t, "stable", ptr.From(filter.Channel))
+					return queue.Pop(t, &tc.updateSvcGetAllWithFilter)
 				},
 			}
 
-			client := &adapterMock.ClusterClientPortMock{
-				PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
-					return tc.clientPingErr
+			var warnings int
+			var removeStale int
+			warningSvc := &adapterMock.WarningServicePortMock{
+				EmitFunc: func(ctx context.Context, w warning.Warning) {
+					require.Equal(t, api.WarningTypeClusterAutoUpdateFailed, w.Type)
+					warnings++
 				},
-				SystemFactoryResetFunc: func(ctx context.Context, endpoint provisioning.Endpoint, allowTPMResetFailure bool, seeds provisioning.TokenImageSeedConfigs, providerConfig api.TokenProviderConfig) error {
-					return tc.clientSystemFactoryResetErr
+				RemoveStaleFunc: func(ctx context.Context, scope api.WarningScope, newWarnings warning.Warnings) {
+					removeStale++
 				},
 			}
 
-			oldClusterUpdateSignal := lifecycle.ClusterUpdateSignal
-			lifecycle.ClusterUpdateSignal = signals.NewSync[lifecycle.ClusterUpdateMessage]()
-			defer func() {
-				lifecycle.ClusterUpdateSignal = oldClusterUpdateSignal
-			}()
-
-			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, tokenSvc, nil, nil, nil)
-
-			var signalHandlerCalled bool
-			lifecycle.ClusterUpdateSignal.AddListener(tc.signalHandler(t, &signalHandlerCalled))
+			clusterSvc := provisioningCluster.New(
+				repo, nil, nil, serverSvc, nil, nil, nil, nil,
+				provisioningCluster.WithNow(func() time.Time {
+					return fixedTime
+				}),
+				provisioningCluster.WithUpdateService(updateSvc),
+				provisioningCluster.WithWarningEmitter(warningSvc),
+			)
 
 			// Run test
-			err := clusterSvc.DeleteAndFactoryResetByName(context.Background(), tc.nameArg, tc.tokenArg, tc.tokenSeedNameArg)
+			err := clusterSvc.LaunchAutomaticClusterUpdates(t.Context())
 
 			// Assert
 			tc.assertErr(t, err)
-			require.True(t, signalHandlerCalled, "expected signal handler to called, but it was not OR no call was expected, but it got called")
+			if tc.wantAutoUpdateLaunches != nil {
+				require.Equal(t, tc.wantAutoUpdateLaunches, autoUpdateLaunches)
+			}
+
+			require.Equal(t, tc.wantWarnings, warnings)
+			require.Equal(t, tc.wantRemoveStale, removeStale)
+			require.Empty(t, tc.repoGetByName)
+			require.Empty(t, tc.repoUpdate)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.updateSvcGetAllWithFilter)
 		})
 	}
 }
 
-func TestClusterService_ResyncInventory(t *testing.T) {
+func TestClusterService_DetectConfigurationDrift(t *testing.T) {
+	fixedTime := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	readyCluster := provisioning.Cluster{
+		Name:   "one",
+		Status: api.ClusterStatusReady,
+	}
+
+	terraformConfiguration := &provisioning.ClusterArtifact{
+		Cluster: "one",
+		Name:    "terraform-configuration",
+		Files: provisioning.ClusterArtifactFiles{
+			{
+				Name: "providers.tf",
+				Open: func() (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader(`provider "incus" {}`)), nil
+				},
+			},
+		},
+	}
+
+	incusConfiguration := &provisioning.ClusterArtifact{
+		Cluster: "one",
+		Name:    "incus-configuration",
+		Files: provisioning.ClusterArtifactFiles{
+			{
+				Name: "preseed.yaml",
+				Open: func() (io.ReadCloser, error) {
+					return io.NopCloser(strings.NewReader(`config: {}`)), nil
+				},
+			},
+		},
+	}
+
+	retainedPlanArtifacts := provisioning.ClusterArtifacts{}
+	for i := 1; i <= 10; i++ {
+		retainedPlanArtifacts = append(retainedPlanArtifacts, provisioning.ClusterArtifact{
+			Name:       fmt.Sprintf("terraform-plan-%d", i),
+			Properties: api.ConfigMap{"changed_resources": ""},
+		})
+	}
+
 	tests := []struct {
-		name               string
-		ctx                context.Context
-		repoGetAllClusters provisioning.Clusters
-		repoGetAllErr      error
-		inventorySyncerErr error
+		name                                          string
+		withoutDriftDetector                          bool
+		driftDetectorBackends                         []string
+		repoGetAll                                    provisioning.Clusters
+		repoGetAllErr                                 error
+		localArtifactGetClusterArtifactByName         *provisioning.ClusterArtifact
+		localArtifactGetClusterArtifactByNameErr      error
+		localArtifactGetClusterArtifactAll            provisioning.ClusterArtifacts
+		localArtifactGetClusterArtifactAllErr         error
+		localArtifactCreateClusterArtifactFromPathErr error
+		localArtifactDeleteClusterArtifactByNameErr   error
+		driftDetectorPlan                             provisioning.ClusterProvisioningPlan
+		driftDetectorPlanErr                          error
+
+		assertErr            require.ErrorAssertionFunc
+		wantPlanArtifacts    []provisioning.ClusterArtifact
+		wantDeletedArtifacts []string
+		wantWarnings         int
+		wantRemoveStale      int
+	}{
+		{
+			name: "success - no drift, first revision",
+			repoGetAll: provisioning.Clusters{
+				readyCluster,
+				{
+					Name:   "pending",
+					Status: api.ClusterStatusPending,
+				},
+			},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			driftDetectorPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "No changes.",
+				ChangedResources: []string{},
+			},
+
+			assertErr: require.NoError,
+			wantPlanArtifacts: []provisioning.ClusterArtifact{
+				{
+					Cluster:     "one",
+					Name:        "terraform-plan-1",
+					Description: "Terraform plan of the configuration drift detection.",
+					Properties: api.ConfigMap{
+						"revision":          "1",
+						"detected_at":       "2026-10-17T12:00:00Z",
+						"changed_resources": "",
+					},
+				},
+			},
+			wantRemoveStale: 1,
+		},
+		{
+			name:                                  "success - drift, new revision",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			localArtifactGetClusterArtifactAll: provisioning.ClusterArtifacts{
+				{Name: "terraform-configuration"},
+				{Name: "terraform-plan-1", Properties: api.ConfigMap{"changed_resources": ""}},
+				{Name: "terraform-plan-2", Properties: api.ConfigMap{"changed_resources": "incus_network.incusbr0"}},
+				{Name: "terraform-plan-invalid"},
+			},
+			driftDetectorPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "Plan: 0 to add, 2 to change, 0 to destroy.",
+				ChangedResources: []string{"incus_network.incusbr0", "incus_profile.default"},
+			},
 
-		assertErr require.ErrorAssertionFunc
-	}{
+			assertErr: require.NoError,
+			wantPlanArtifacts: []provisioning.ClusterArtifact{
+				{
+					Cluster:     "one",
+					Name:        "terraform-plan-3",
+					Description: "Terraform plan of the configuration drift detection.",
+					Properties: api.ConfigMap{
+						"revision":          "3",
+						"detected_at":       "2026-10-17T12:00:00Z",
+						"changed_resources": "incus_network.incusbr0,incus_profile.default",
+					},
+				},
+			},
+			wantWarnings:    1,
+			wantRemoveStale: 1,
+		},
 		{
-			name:               "success - empty cluster list",
-			ctx:                context.Background(),
-			repoGetAllClusters: provisioning.Clusters{},
+			name:                                  "success - drift, outdated revisions removed",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			localArtifactGetClusterArtifactAll:    retainedPlanArtifacts,
+			driftDetectorPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "Plan: 0 to add, 1 to change, 0 to destroy.",
+				ChangedResources: []string{"incus_network.incusbr0"},
+			},
 
 			assertErr: require.NoError,
+			wantPlanArtifacts: []provisioning.ClusterArtifact{
+				{
+					Cluster:     "one",
+					Name:        "terraform-plan-11",
+					Description: "Terraform plan of the configuration drift detection.",
+					Properties: api.ConfigMap{
+						"revision":          "11",
+						"detected_at":       "2026-10-17T12:00:00Z",
+						"changed_resources": "incus_network.incusbr0",
+					},
+				},
+			},
+			wantDeletedArtifacts: []string{"terraform-plan-1"},
+			wantWarnings:         1,
+			wantRemoveStale:      1,
 		},
 		{
-			name: "success",
-			ctx:  context.Background(),
-			repoGetAllClusters: provisioning.Clusters{
+			name:                                  "success - drift unchanged, no new revision",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			localArtifactGetClusterArtifactAll: provisioning.ClusterArtifacts{
+				{Name: "terraform-plan-1", Properties: api.ConfigMap{"changed_resources": "incus_network.incusbr0"}},
+			},
+			driftDetectorPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "Plan: 0 to add, 1 to change, 0 to destroy.",
+				ChangedResources: []string{"incus_network.incusbr0"},
+			},
+
+			assertErr:       require.NoError,
+			wantWarnings:    1,
+			wantRemoveStale: 1,
+		},
+		{
+			name:                                  "success - drift, incus configuration",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: incusConfiguration,
+			localArtifactGetClusterArtifactAll: provisioning.ClusterArtifacts{
+				{Name: "incus-configuration"},
+				{Name: "terraform-plan-1", Properties: api.ConfigMap{"changed_resources": "profile.default"}},
+			},
+			driftDetectorPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "The following resources would be changed:\n  ~ profile.default\n",
+				ChangedResources: []string{"profile.default"},
+			},
+
+			assertErr: require.NoError,
+			wantPlanArtifacts: []provisioning.ClusterArtifact{
 				{
-					Name: "one",
+					Cluster:     "one",
+					Name:        "incus-plan-1",
+					Description: "Incus plan of the configuration drift detection.",
+					Properties: api.ConfigMap{
+						"revision":          "1",
+						"detected_at":       "2026-10-17T12:00:00Z",
+						"changed_resources": "profile.default",
+					},
 				},
 			},
+			wantWarnings:    1,
+			wantRemoveStale: 1,
+		},
+		{
+			name:                                     "success - cluster without provisioning configuration",
+			repoGetAll:                               provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByNameErr: domain.ErrNotFound,
 
 			assertErr: require.NoError,
 		},
 		{
-			name:          "error - GetAll",
-			ctx:           context.Background(),
+			name:                                  "error - drift detection not supported for backend",
+			driftDetectorBackends:                 []string{system.ClusterProvisioningBackendTerraform},
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: incusConfiguration,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, `Configuration drift detection is not supported for clusters initialized with the "incus" cluster provisioning backend`, a...)
+			},
+		},
+		{
+			name:                 "error - no drift detector",
+			withoutDriftDetector: true,
+
+			assertErr: require.Error,
+		},
+		{
+			name:          "error - repo.GetAll",
 			repoGetAllErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name: "error - Context done",
-			ctx: func() context.Context {
-				ctx, cancel := context.WithCancel(context.Background())
-				cancel() // context cancelled
-				return ctx
-			}(),
-			repoGetAllClusters: provisioning.Clusters{
-				{
-					Name: "one",
+			name:                                     "error - localArtifact.GetClusterArtifactByName",
+			repoGetAll:                               provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:       "error - artifact file open",
+			repoGetAll: provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: &provisioning.ClusterArtifact{
+				Name: "terraform-configuration",
+				Files: provisioning.ClusterArtifactFiles{
+					{
+						Name: "providers.tf",
+						Open: func() (io.ReadCloser, error) {
+							return nil, boom.Error
+						},
+					},
 				},
 			},
 
-			assertErr: require.Error,
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name: "error - ResyncInventoryByName",
-			ctx:  context.Background(),
-			repoGetAllClusters: provisioning.Clusters{
-				{
-					Name: "one",
-				},
+			name:                                  "error - driftDetector.Plan",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			driftDetectorPlanErr:                  boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                                  "error - localArtifact.GetClusterArtifactAll",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			localArtifactGetClusterArtifactAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                                  "error - localArtifact.CreateClusterArtifactFromPath",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			localArtifactCreateClusterArtifactFromPathErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                                  "error - localArtifact.DeleteClusterArtifactByName",
+			repoGetAll:                            provisioning.Clusters{readyCluster},
+			localArtifactGetClusterArtifactByName: terraformConfiguration,
+			localArtifactGetClusterArtifactAll:    retainedPlanArtifacts,
+			localArtifactDeleteClusterArtifactByNameErr: boom.Error,
+			driftDetectorPlan: provisioning.ClusterProvisioningPlan{
+				Output:           "Plan: 0 to add, 1 to change, 0 to destroy.",
+				ChangedResources: []string{"incus_network.incusbr0"},
 			},
-			inventorySyncerErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
@@ -14377,76 +19744,151 @@ func TestClusterService_ResyncInventory(t *testing.T) {
 			// Setup
 			repo := &mock.ClusterRepoMock{
 				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
-					return tc.repoGetAllClusters, tc.repoGetAllErr
+					return tc.repoGetAll, tc.repoGetAllErr
 				},
 			}
 
-			inventorySyncer := &serviceMock.InventorySyncerMock{
-				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
-					return tc.inventorySyncerErr
+			var planArtifacts []provisioning.ClusterArtifact
+			var deletedArtifacts []string
+			localArtifactRepo := &mock.ClusterArtifactRepoMock{
+				GetClusterArtifactByNameFunc: func(ctx context.Context, clusterName string, artifactName string) (*provisioning.ClusterArtifact, error) {
+					if tc.localArtifactGetClusterArtifactByNameErr != nil {
+						return nil, tc.localArtifactGetClusterArtifactByNameErr
+					}
+
+					if tc.localArtifactGetClusterArtifactByName == nil || tc.localArtifactGetClusterArtifactByName.Name != artifactName {
+						return nil, domain.ErrNotFound
+					}
+
+					return tc.localArtifactGetClusterArtifactByName, nil
+				},
+				GetClusterArtifactAllFunc: func(ctx context.Context, clusterName string) (provisioning.ClusterArtifacts, error) {
+					return tc.localArtifactGetClusterArtifactAll, tc.localArtifactGetClusterArtifactAllErr
+				},
+				CreateClusterArtifactFromPathFunc: func(ctx context.Context, artifact provisioning.ClusterArtifact, path string, ignoredFiles []string) (int64, error) {
+					require.FileExists(t, filepath.Join(path, "plan.txt"))
+					planArtifacts = append(planArtifacts, artifact)
+					return 0, tc.localArtifactCreateClusterArtifactFromPathErr
+				},
+				DeleteClusterArtifactByNameFunc: func(ctx context.Context, clusterName string, artifactName string) error {
+					deletedArtifacts = append(deletedArtifacts, artifactName)
+					return tc.localArtifactDeleteClusterArtifactByNameErr
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil)
-			clusterSvc.SetInventorySyncers(
-				map[domain.ResourceType]provisioning.InventorySyncer{
-					"test": inventorySyncer,
+			driftDetector := &adapterMock.ClusterProvisioningDriftPortMock{
+				PlanFunc: func(ctx context.Context, cluster provisioning.Cluster, configPath string) (provisioning.ClusterProvisioningPlan, error) {
+					for _, artifactFile := range tc.localArtifactGetClusterArtifactByName.Files {
+						require.FileExists(t, filepath.Join(configPath, artifactFile.Name))
+					}
+
+					return tc.driftDetectorPlan, tc.driftDetectorPlanErr
 				},
-			)
+			}
+
+			var warnings int
+			var removeStale int
+			warningSvc := &adapterMock.WarningServicePortMock{
+				EmitFunc: func(ctx context.Context, w warning.Warning) {
+					require.Equal(t, api.WarningTypeClusterConfigurationDrift, w.Type)
+					warnings++
+				},
+				RemoveStaleFunc: func(ctx context.Context, scope api.WarningScope, newWarnings warning.Warnings) {
+					removeStale++
+				},
+			}
+
+			opts := []provisioningCluster.Option{
+				provisioningCluster.WithNow(func() time.Time {
+					return fixedTime
+				}),
+				provisioningCluster.WithWarningEmitter(warningSvc),
+			}
+
+			driftDetectorBackends := tc.driftDetectorBackends
+			if driftDetectorBackends == nil {
+				driftDetectorBackends = []string{system.ClusterProvisioningBackendTerraform, system.ClusterProvisioningBackendIncus}
+			}
+
+			if !tc.withoutDriftDetector {
+				for _, backend := range driftDetectorBackends {
+					opts = append(opts, provisioningCluster.WithConfigurationDriftDetector(backend, driftDetector))
+				}
+			}
+
+			clusterSvc := provisioningCluster.New(repo, localArtifactRepo, nil, nil, nil, nil, nil, nil, opts...)
 
 			// Run test
-			err := clusterSvc.ResyncInventory(tc.ctx)
+			err := clusterSvc.DetectConfigurationDrift(t.Context())
 
 			// Assert
 			tc.assertErr(t, err)
+			if tc.localArtifactCreateClusterArtifactFromPathErr == nil && tc.localArtifactDeleteClusterArtifactByNameErr == nil {
+				require.Equal(t, tc.wantPlanArtifacts, planArtifacts)
+				require.Equal(t, tc.wantDeletedArtifacts, deletedArtifacts)
+			}
+
+			require.Equal(t, tc.wantWarnings, warnings)
+			require.Equal(t, tc.wantRemoveStale, removeStale)
 		})
 	}
 }
 
-func TestClusterService_ResyncInventoryByName(t *testing.T) {
+func TestClusterService_AbortClusterOperation(t *testing.T) {
 	tests := []struct {
-		name               string
-		nameArg            string
-		inventorySyncerErr error
+		name             string
+		repoGetByName    *provisioning.Cluster
+		repoGetByNameErr error
+		repoUpdateErr    error
 
 		assertErr require.ErrorAssertionFunc
 	}{
 		{
-			name:    "success",
-			nameArg: "one",
+			name:          "success",
+			repoGetByName: &provisioning.Cluster{},
 
 			assertErr: require.NoError,
 		},
 		{
-			name:    "error - name empty",
-			nameArg: "", // invalid
+			name:             "error - repo.GetByName",
+			repoGetByNameErr: boom.Error,
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-			},
+			assertErr: boom.ErrorIs,
 		},
 		{
-			name:               "error - sync cluster",
-			nameArg:            "one",
-			inventorySyncerErr: boom.Error,
+			name:          "error - repo.GetByName",
+			repoGetByName: &provisioning.Cluster{},
+			repoUpdateErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
 	}
 
+	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			inventorySyncer := &serviceMock.InventorySyncerMock{
-				SyncClusterFunc: func(ctx context.Context, clusterName string) error {
-					return tc.inventorySyncerErr
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
+					require.False(t, cluster.IsUpdateInProgress())
+					return tc.repoUpdateErr
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(nil, nil, nil, nil, nil, nil, nil, nil)
-			clusterSvc.SetInventorySyncers(map[domain.ResourceType]provisioning.InventorySyncer{"test": inventorySyncer})
+			clusterSvc := provisioningCluster.New(
+				repo, nil, nil, nil, nil, nil, nil, nil,
+				provisioningCluster.WithNow(func() time.Time {
+					return fixedTime
+				}),
+			)
 
 			// Run test
-			err := clusterSvc.ResyncInventoryByName(context.Background(), tc.nameArg)
+			err := clusterSvc.AbortClusterOperation(t.Context(), "one")
 
 			// Assert
 			tc.assertErr(t, err)
@@ -14454,685 +19896,458 @@ func TestClusterService_ResyncInventoryByName(t *testing.T) {
 	}
 }
 
-func TestClusterService_IsInstanceLifecycleOperationPermitted(t *testing.T) {
+func TestClusterService_GetOperationAll(t *testing.T) {
+	start := time.Date(2026, 3, 12, 8, 0, 0, 0, time.UTC)
+
+	operationA := uuidgen.FromPattern(t, "a")
+	operationB := uuidgen.FromPattern(t, "b")
+
 	tests := []struct {
-		name                      string
-		argName                   string
-		repoGetByName             *provisioning.Cluster
-		repoGetByNameErr          error
-		serverSvcPollServersErr   error
-		serverSvcGetAllWithFilter provisioning.Servers
+		name                       string
+		withOperationRepo          bool
+		repoGetByNameErr           error
+		operationsGetAllWithFilter provisioning.ClusterOperations
+		operationsGetAllFilterErr  error
+		operationsGetEventAll      map[uuid.UUID]provisioning.ClusterOperationEvents
+		operationsGetEventAllErr   error
 
-		want bool
+		assertErr require.ErrorAssertionFunc
+		want      provisioning.ClusterOperations
 	}{
 		{
-			name:    "success - not clustered",
-			argName: "", // no cluster name, therefore not clustered
-
-			want: true,
-		},
-		{
-			name:          "success - no update in progress",
-			argName:       "one",
-			repoGetByName: &provisioning.Cluster{},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
+			name:              "success",
+			withOperationRepo: true,
+			operationsGetAllWithFilter: provisioning.ClusterOperations{
+				{
+					ID:         1,
+					UUID:       operationA,
+					Cluster:    "one",
+					Type:       api.ClusterUpdateInProgressRollingReboot,
+					Status:     api.ClusterOperationStatusSucceeded,
+					StartedAt:  start,
+					FinishedAt: start.Add(10 * time.Minute),
+				},
+				{
+					ID:        2,
+					UUID:      operationB,
+					Cluster:   "one",
+					Type:      api.ClusterUpdateInProgressApplyUpdate,
+					Status:    api.ClusterOperationStatusRunning,
+					StartedAt: start.Add(time.Hour),
+				},
+			},
+			operationsGetEventAll: map[uuid.UUID]provisioning.ClusterOperationEvents{
+				operationA: {
+					{Operation: operationA, Server: "one", Action: api.ClusterOperationEventActionReboot, StartedAt: start.Add(2 * time.Minute)},
+					{Operation: operationA, Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: start.Add(time.Minute)},
+				},
+				operationB: {
+					{Operation: operationB, Server: "one", Action: api.ClusterOperationEventActionUpdate, StartedAt: start.Add(time.Hour + time.Minute)},
+				},
 			},
 
-			want: true,
-		},
-		{
-			name:    "success - update in progress",
-			argName: "one",
-			repoGetByName: &provisioning.Cluster{
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressRollingRestart,
+			assertErr: require.NoError,
+			want: provisioning.ClusterOperations{
+				{
+					ID:        2,
+					UUID:      operationB,
+					Cluster:   "one",
+					Type:      api.ClusterUpdateInProgressApplyUpdate,
+					Status:    api.ClusterOperationStatusRunning,
+					StartedAt: start.Add(time.Hour),
+					Events: provisioning.ClusterOperationEvents{
+						{Operation: operationB, Server: "one", Action: api.ClusterOperationEventActionUpdate, StartedAt: start.Add(time.Hour + time.Minute)},
+					},
+				},
+				{
+					ID:         1,
+					UUID:       operationA,
+					Cluster:    "one",
+					Type:       api.ClusterUpdateInProgressRollingReboot,
+					Status:     api.ClusterOperationStatusSucceeded,
+					StartedAt:  start,
+					FinishedAt: start.Add(10 * time.Minute),
+					Events: provisioning.ClusterOperationEvents{
+						{Operation: operationA, Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: start.Add(time.Minute), FinishedAt: start.Add(2 * time.Minute)},
+						{Operation: operationA, Server: "one", Action: api.ClusterOperationEventActionReboot, StartedAt: start.Add(2 * time.Minute), FinishedAt: start.Add(10 * time.Minute)},
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: provisioning.Servers{
-				{},
-			},
+		},
+		{
+			name: "success - without operation repo",
 
-			want: false,
+			assertErr: require.NoError,
+			want:      provisioning.ClusterOperations{},
 		},
 		{
 			name:             "error - repo.GetByName",
-			argName:          "one",
 			repoGetByNameErr: boom.Error,
 
-			want: false,
+			assertErr: boom.ErrorIs,
 		},
-	}
+		{
+			name:                      "error - operations.GetAllWithFilter",
+			withOperationRepo:         true,
+			operationsGetAllFilterErr: boom.Error,
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return tc.repoGetByName, tc.repoGetByNameErr
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:              "error - operations.GetEventAll",
+			withOperationRepo: true,
+			operationsGetAllWithFilter: provisioning.ClusterOperations{
+				{
+					UUID:    operationA,
+					Cluster: "one",
 				},
-			}
+			},
+			operationsGetEventAllErr: boom.Error,
 
-			serverSvc := &serviceMock.ServerServiceMock{
-				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServersErr
-				},
-				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
-					return tc.serverSvcGetAllWithFilter, nil
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return &provisioning.Cluster{Name: name}, tc.repoGetByNameErr
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			var opts []provisioningCluster.Option
+			if tc.withOperationRepo {
+				opts = append(opts, provisioningCluster.WithOperationRepo(&mock.ClusterOperationRepoMock{
+					GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ClusterOperationFilter) (provisioning.ClusterOperations, error) {
+						require.Equal(t, "one", ptr.From(filter.Cluster))
+						require.Nil(t, filter.Status)
+						return tc.operationsGetAllWithFilter, tc.operationsGetAllFilterErr
+					},
+					GetEventAllFunc: func(ctx context.Context, operationUUID uuid.UUID) (provisioning.ClusterOperationEvents, error) {
+						return tc.operationsGetEventAll[operationUUID], tc.operationsGetEventAllErr
+					},
+				}))
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, nil, nil, nil, nil, nil, opts...)
 
 			// Run test
-			got := clusterSvc.IsInstanceLifecycleOperationPermitted(t.Context(), tc.argName)
+			operations, err := clusterSvc.GetOperationAll(t.Context(), "one")
 
 			// Assert
-			require.Equal(t, tc.want, got)
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, operations)
 		})
 	}
 }
 
-func TestClusterService_LaunchClusterUpdate(t *testing.T) {
+func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 	tests := []struct {
 		name                      string
-		rebootArg                 bool
+		nameArg                   string
+		interfaceNameArg          string
+		vlanTagsArg               []int
 		repoGetByName             *provisioning.Cluster
 		repoGetByNameErr          error
-		repoUpdate                []queue.Item[api.ClusterUpdateInProgressStatus] // api.ClusterUpdateInProgressStatus used for assertions in repo.Update
-		tamperContext             func(ctx context.Context, t *testing.T) context.Context
-		serverSvcPollServers      error
+		serverSvcPollServersErr   error
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+		clientUpdateNetworkConfig []queue.Item[*incusosapi.SystemNetworkConfig] // Value is the expected value.
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name: "success - cluster already up to date",
+			name:             "success",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
-			},
-
-			assertErr: require.NoError,
-			assertLog: log.Empty,
-		},
-		{
-			name: "success - update servers",
-			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{
-						{
-							Name:         "A",
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "1",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      false,
-								},
-								Applications: []api.ApplicationVersionData{
-									{
-										Name:             "incus",
-										Version:          "1",
-										AvailableVersion: ptr.To("2"),
-										NeedsUpdate:      ptr.To(true),
-									},
-								},
-								NeedsUpdate:   ptr.To(true),
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
-				},
-				// GetAllWithFilter, needs update
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:         "A",
+							Name:         "one",
+							Cluster:      ptr.To("one"),
 							Status:       api.ServerStatusReady,
 							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "1",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      false,
-								},
-								Applications: []api.ApplicationVersionData{
-									{
-										Name:             "incus",
-										Version:          "1",
-										AvailableVersion: ptr.To("2"),
-										NeedsUpdate:      ptr.To(true),
-									},
-								},
-								NeedsUpdate:   ptr.To(true),
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-						},
-					},
-				},
-			},
-
-			assertErr: require.NoError,
-			assertLog: log.Empty,
-		},
-		{
-			name:      "success - update servers with reboot",
-			rebootArg: true,
-			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
-					},
-				},
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
-					},
-				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{
-						{
-							Name:         "A",
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "1",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      false,
-								},
-								Applications: []api.ApplicationVersionData{
-									{
-										Name:             "incus",
-										Version:          "1",
-										AvailableVersion: ptr.To("2"),
-										NeedsUpdate:      ptr.To(true),
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50}, // vlan tag 10 already present
+											},
+										},
 									},
 								},
-								NeedsUpdate:   ptr.To(true),
-								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
-					},
-				},
-				// GetAllWithFilter, needs update
-				{
-					Value: provisioning.Servers{
 						{
-							Name:         "A",
+							Name:         "two",
+							Cluster:      ptr.To("one"),
 							Status:       api.ServerStatusReady,
 							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "1",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      false,
-								},
-								Applications: []api.ApplicationVersionData{
-									{
-										Name:             "incus",
-										Version:          "1",
-										AvailableVersion: ptr.To("2"),
-										NeedsUpdate:      ptr.To(true),
-									},
-								},
-								NeedsUpdate:   ptr.To(true),
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-						},
-					},
-				},
-			},
-
-			assertErr: require.NoError,
-			assertLog: log.Empty,
-		},
-		{
-			name: "success - server in maintenance - evacuated manually",
-			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress:      api.ClusterUpdateInProgressApplyUpdate,
-						EvacuatedBefore: []string{"A"},
-					},
-				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{
-						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50}, // vlan tag 10 already present
+											},
+										},
+									},
 								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
 							},
 						},
 					},
 				},
-				// GetAllWithFilter, needs update
+			},
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
 				{
-					Value: provisioning.Servers{
-						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLAN tags.
 							},
 						},
 					},
 				},
-			},
-
-			assertErr: require.NoError,
-			assertLog: log.Empty,
-		},
-
-		{
-			name: "error - GetByName",
-			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
 				{
-					Err: boom.Error,
-				},
-			},
-			serverSvcPollServers: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name: "error - cluster update already in progress",
-			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressRollingRestart,
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLAN tags.
+							},
+						},
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-			},
-			serverSvcPollServers: boom.Error,
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-			},
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - Update - apply update",
+			name:             "success - bond without any interfaces",
+			nameArg:          "one",
+			interfaceNameArg: "bond0",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-					Err: boom.Error,
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Bonds: []incusosapi.SystemNetworkBond{
+											{
+												Name:     "bond0",
+												VLANTags: []int{10, 50}, // vlan tag 10 already present
+											},
+										},
+									},
 								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+							},
+						},
+					},
+				},
+			},
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
+				{
+					Value: &incusosapi.SystemNetworkConfig{
+						Bonds: []incusosapi.SystemNetworkBond{
+							{
+								Name:     "bond0",
+								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLAN tags.
 							},
 						},
 					},
 				},
 			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - serverSvc.PollServers",
+			name:             "success - bond next to unrelated interfaces",
+			nameArg:          "one",
+			interfaceNameArg: "bond0",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{50},
+											},
+										},
+										Bonds: []incusosapi.SystemNetworkBond{
+											{
+												Name:     "bond0",
+												VLANTags: []int{10},
+											},
+										},
+									},
 								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
 							},
 						},
 					},
 				},
 			},
-			serverSvcPollServers: boom.Error,
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
+				{
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{50}, // Expect the interface to be left untouched.
+							},
+						},
+						Bonds: []incusosapi.SystemNetworkBond{
+							{
+								Name:     "bond0",
+								VLANTags: []int{10, 20, 100}, // Expect the updated set of VLAN tags.
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			assertLog: log.Empty,
+		},
+
+		{
+			name:             "error - GetByName error",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name:             "error - cluster Status not ready",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusPending, // not ready
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+			},
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - serverSvc.PollServers - revert error",
+			name:             "error - serverSvc.PollServers",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-					Err: boom.Error,
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
+			serverSvcPollServersErr: boom.Error,
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
-				{
-					Value: provisioning.Servers{
-						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
-							},
-						},
-					},
-				},
+				{},
 			},
-			serverSvcPollServers: boom.Error,
 
 			assertErr: boom.ErrorIs,
-			assertLog: log.Contains(boom.Error.Error()),
+			assertLog: log.Empty,
 		},
 		{
-			name: "error - serverSvc.GetAllWithFilter does not return any servers",
+			name:             "error - cluster without members",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
-				{
-					Value: provisioning.Servers{
-						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
-							},
-						},
-					},
-				},
-				// GetAllWithFilter, needs update
-				{
-					Value: nil, // No servers found.
-				},
+				{},
+				// serverSvc.GetAllWithFilter - no servers found
+				{},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrNotFound, a...)
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
 			},
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - serverSvc.GetAllWithFilter",
+			name:             "error - serverSvc.GetAllWithFilter",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
-				{
-					Value: provisioning.Servers{
-						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
-							},
-						},
-					},
-				},
-				// GetAllWithFilter, needs update
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Err: boom.Error,
 				},
@@ -15142,71 +20357,66 @@ func TestClusterService_LaunchClusterUpdate(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - server not ready",
+			name:             "error - server status not ready",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusOffline,
-							StatusDetail:  api.ServerStatusDetailNone,
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusOffline, // server offline
+							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuated),
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 					},
 				},
-				// GetAllWithFilter, needs update
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name:             "error - server without network config",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:   "A",
-							Status: api.ServerStatusOffline,
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: nil, // no network config present
+								},
+							},
 						},
 					},
 				},
@@ -15215,77 +20425,44 @@ func TestClusterService_LaunchClusterUpdate(t *testing.T) {
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, `is in state`)
+				require.ErrorContains(t, err, `does not have any network config`)
 			},
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - server in maintenance - evacuating",
+			name:             "error - network interface and bond missing on server",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuating),
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-						},
-					},
-				},
-				// GetAllWithFilter, needs update
-				{
-					Value: provisioning.Servers{
-						{
-							Name:          "A",
-							ConnectionURL: "https://a:8443/",
-							Status:        api.ServerStatusReady,
-							StatusDetail:  api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{}, // no matching network interface
+										Bonds: []incusosapi.SystemNetworkBond{
+											{
+												Name: "bond0", // no matching bond either
+											},
+										},
+									},
 								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
-								InMaintenance: ptr.To(api.InMaintenanceEvacuating),
 							},
 						},
 					},
@@ -15295,95 +20472,113 @@ func TestClusterService_LaunchClusterUpdate(t *testing.T) {
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, `Server "A" (https://a:8443/) is in maintenance state "evacuating"`)
+				require.ErrorContains(t, err, `does not have interface or bond "uplink"`)
 			},
 			assertLog: log.Empty,
 		},
 		{
-			name: "error - repo.Update - after verification",
+			name:             "error - serverSvc.AddSystemNetworkVLAN - revert serverSvc.ReomveSystemNetworkVLAN",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 20, 100},
 			repoGetByName: &provisioning.Cluster{
-				Name:    "one",
-				Channel: "stable",
-
-				UpdateStatus: api.ClusterUpdateStatus{
-					InProgressStatus: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
-				// Update pre validation
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-				},
-				// Update post validation
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressApplyUpdate,
-					},
-					Err: boom.Error,
-				},
-				// Update reverter
-				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress: api.ClusterUpdateInProgressInactive,
-					},
-				},
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
 						{
-							Name:         "A",
+							Name:         "one",
+							Cluster:      ptr.To("one"),
 							Status:       api.ServerStatusReady,
 							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
+							},
 						},
-					},
-				},
-				// GetAllWithFilter
-				{
-					Value: provisioning.Servers{
 						{
-							Name:         "A",
+							Name:         "two",
+							Cluster:      ptr.To("one"),
 							Status:       api.ServerStatusReady,
 							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
-								OS: api.OSVersionData{
-									Version:          "1",
-									VersionNext:      "2",
-									AvailableVersion: ptr.To("2"),
-									NeedsReboot:      true,
-								},
-								NeedsUpdate:   ptr.To(false),
-								NeedsReboot:   ptr.To(true),
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
+				// Update first server.
+				{
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLANTags
+							},
+						},
+					},
+				},
+				// Update second server fails.
+				{
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLANTags
+							},
+						},
+					},
+					Err: errors.New("error"),
+				},
+				// Revert of update on first server fails.
+				{
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50}, // Expect only the original set of VLANTags
+							},
 						},
 					},
+					Err: boom.Error,
 				},
 			},
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
+			assertErr: require.Error,
+			assertLog: log.Match("Failed to revert previously updated network configuration.*" + boom.Error.Error()),
 		},
 	}
 
-	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
-
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
@@ -15395,266 +20590,250 @@ func TestClusterService_LaunchClusterUpdate(t *testing.T) {
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
 					return tc.repoGetByName, tc.repoGetByNameErr
 				},
-				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
-					inProgressStatus, err := queue.Pop(t, &tc.repoUpdate)
+			}
+
+			client := &adapterMock.ClusterClientPortMock{
+				UpdateNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) error {
+					wantConfig, err := queue.Pop(t, &tc.clientUpdateNetworkConfig)
+
+					require.Equal(t, wantConfig, server.OSData.Network.Config)
 
-					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
-					require.Equal(t, inProgressStatus.InProgress, cluster.UpdateStatus.InProgressStatus.InProgress)
-					require.ElementsMatch(t, cluster.UpdateStatus.InProgressStatus.EvacuatedBefore, inProgressStatus.EvacuatedBefore)
 					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServers
+					return tc.serverSvcPollServersErr
 				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(
-				repo, nil, nil, serverSvc, nil, nil, nil, nil,
-				provisioningCluster.WithNow(func() time.Time {
-					return fixedTime
-				}),
-				provisioningCluster.WithPendingUpdateRecheckInterval(0),
-			)
-
-			// Run test
-			ctx := t.Context()
-			if tc.tamperContext != nil {
-				ctx = tc.tamperContext(ctx, t)
-			}
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
-			err = clusterSvc.LaunchClusterUpdate(ctx, "one", tc.rebootArg)
+			// Run test
+			err = clusterSvc.AddServerSystemNetworkVLANTags(context.Background(), tc.nameArg, tc.interfaceNameArg, tc.vlanTagsArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
-			require.Empty(t, tc.repoUpdate)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.clientUpdateNetworkConfig)
 		})
 	}
 }
 
-func rebootReadyServer(mutators ...func(server *provisioning.Server)) provisioning.Server {
-	server := provisioning.Server{
-		Name:          "A",
-		ConnectionURL: "https://a:8443/",
-		Status:        api.ServerStatusReady,
-		StatusDetail:  api.ServerStatusDetailNone,
-		VersionData: api.ServerVersionData{
-			OS: api.OSVersionData{
-				Version:     "1",
-				VersionNext: "1",
-				NeedsReboot: false,
-			},
-			NeedsUpdate:   ptr.To(false),
-			NeedsReboot:   ptr.To(false),
-			InMaintenance: ptr.To(api.NotInMaintenance),
-		},
-	}
-
-	for _, mutate := range mutators {
-		mutate(&server)
-	}
-
-	return server
-}
-
-func TestClusterService_LaunchClusterReboot(t *testing.T) {
+func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 	tests := []struct {
 		name                      string
-		repoGetByName             []queue.Item[*provisioning.Cluster]
-		repoUpdate                []queue.Item[api.ClusterUpdateInProgressStatus] // api.ClusterUpdateInProgressStatus used for assertions in repo.Update
-		serverSvcPollServers      error
+		nameArg                   string
+		interfaceNameArg          string
+		vlanTagsArg               []int
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		serverSvcPollServersErr   error
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+		clientUpdateNetworkConfig []queue.Item[*incusosapi.SystemNetworkConfig] // Value is the expected value.
 
 		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
 	}{
 		{
-			name: "error - repo.GetByName",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Err: boom.Error,
-				},
-			},
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - GetByName - serverSvc.GetAllWithFilter",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
+			name:             "success",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 30},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
-					Err: boom.Error,
-				},
-			},
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - serverSvc.PollServers",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-			},
-			serverSvcPollServers: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - serverSvc.GetAllWithFilter",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
 				{
-					Value: &provisioning.Cluster{
-						Name: "one",
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
+							},
+						},
 					},
 				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
-				{
-					Err: boom.Error,
-				},
-			},
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - serverSvc.GetAllWithFilter does not return any servers",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
 				{
-					Value: &provisioning.Cluster{
-						Name: "one",
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
+							},
+						},
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
-				{
-					Value: nil, // No servers found.
-				},
-			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrNotFound, a...)
-			},
+			assertErr: require.NoError,
+			assertLog: log.Empty,
 		},
 		{
-			name: "error - server not ready",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
+			name:             "success - bond next to unrelated interfaces",
+			nameArg:          "one",
+			interfaceNameArg: "bond0",
+			vlanTagsArg:      []int{10, 30},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
-						rebootReadyServer(func(server *provisioning.Server) {
-							server.Status = api.ServerStatusOffline
-						}),
-					},
-				},
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in state "offline"`, a...)
-			},
-		},
-		{
-			name: "error - server in maintenance - evacuating",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+										Bonds: []incusosapi.SystemNetworkBond{
+											{
+												Name:     "bond0",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
 				{
-					Value: provisioning.Servers{
-						rebootReadyServer(func(server *provisioning.Server) {
-							server.VersionData.InMaintenance = ptr.To(api.InMaintenanceEvacuating)
-						}),
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50}, // Expect the interface to be left untouched.
+							},
+						},
+						Bonds: []incusosapi.SystemNetworkBond{
+							{
+								Name:     "bond0",
+								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
+							},
+						},
 					},
 				},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in maintenance state "evacuating"`, a...)
-			},
+			assertErr: require.NoError,
+			assertLog: log.Empty,
 		},
+
 		{
-			name: "error - server in maintenance - restoring",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
+			name:                    "error - GetByName error",
+			nameArg:                 "one",
+			interfaceNameArg:        "uplink",
+			vlanTagsArg:             []int{10},
+			repoGetByNameErr:        boom.Error,
+			serverSvcPollServersErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name:             "error - server without network config",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
-						rebootReadyServer(func(server *provisioning.Server) {
-							server.VersionData.InMaintenance = ptr.To(api.InMaintenanceRestoring)
-						}),
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: nil, // no network config present
+								},
+							},
+						},
 					},
 				},
 			},
@@ -15662,30 +20841,46 @@ func TestClusterService_LaunchClusterReboot(t *testing.T) {
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in maintenance state "restoring"`, a...)
+				require.ErrorContains(t, err, `does not have any network config`)
 			},
+			assertLog: log.Empty,
 		},
 		{
-			name: "error - server with unknown maintenance state",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
+			name:             "error - network interface and bond missing on server",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
+				{},
+				// serverSvc.GetAllWithFilter
 				{
 					Value: provisioning.Servers{
-						rebootReadyServer(func(server *provisioning.Server) {
-							server.VersionData.InMaintenance = nil
-						}),
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{}, // no matching network interface
+										Bonds: []incusosapi.SystemNetworkBond{
+											{
+												Name: "bond0", // no matching bond either
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -15693,234 +20888,167 @@ func TestClusterService_LaunchClusterReboot(t *testing.T) {
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, `Server "A" (https://a:8443/) is in maintenance state`, a...)
+				require.ErrorContains(t, err, `does not have interface or bond "uplink"`)
 			},
+			assertLog: log.Empty,
 		},
 		{
-			name: "error - repo.GetByName in transaction",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
-				// GetByName in transaction
-				{
-					Err: boom.Error,
-				},
+			name:             "error - serverSvc.ReomveSystemNetworkVLAN - revert serverSvc.AddSystemNetworkVLAN",
+			nameArg:          "one",
+			interfaceNameArg: "uplink",
+			vlanTagsArg:      []int{10, 30},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-			},
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name: "error - cluster update already in progress - started in the meantime",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
-				// GetByName in transaction
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-
-						UpdateStatus: api.ClusterUpdateStatus{
-							InProgressStatus: api.ClusterUpdateInProgressStatus{
-								InProgress: api.ClusterUpdateInProgressApplyUpdate,
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										Interfaces: []incusosapi.SystemNetworkInterface{
+											{
+												Name:     "uplink",
+												VLANTags: []int{10, 50},
+											},
+										},
+									},
+								},
 							},
 						},
 					},
 				},
 			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-				// GetAllWithFilter
-				{
-					Value: provisioning.Servers{rebootReadyServer()},
-				},
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
-			},
-		},
-		{
-			name: "error - repo.Update",
-			repoGetByName: []queue.Item[*provisioning.Cluster]{
-				// GetByName
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
-				// GetByName in transaction
-				{
-					Value: &provisioning.Cluster{
-						Name: "one",
-					},
-				},
-			},
-			repoUpdate: []queue.Item[api.ClusterUpdateInProgressStatus]{
+			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
+				// Update on first server.
 				{
-					Value: api.ClusterUpdateInProgressStatus{
-						InProgress:    api.ClusterUpdateInProgressRollingReboot,
-						PendingReboot: []string{"A"},
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
+							},
+						},
 					},
-					Err: boom.Error,
 				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
+				// Update on second server fails.
 				{
-					Value: provisioning.Servers{rebootReadyServer()},
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
+							},
+						},
+					},
+					Err: errors.New("error"),
 				},
-				// GetAllWithFilter
+				// Revert on first server fails.
 				{
-					Value: provisioning.Servers{rebootReadyServer()},
+					Value: &incusosapi.SystemNetworkConfig{
+						Interfaces: []incusosapi.SystemNetworkInterface{
+							{
+								Name:     "uplink",
+								VLANTags: []int{10, 50}, // Expect the original set of VLAN tags.
+							},
+						},
+					},
+					Err: boom.Error,
 				},
 			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.Error,
+			assertLog: log.Match("Failed to revert previously updated network configuration.*" + boom.Error.Error()),
 		},
 	}
 
-	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
-
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, false, false)
+			require.NoError(t, err)
+
 			repo := &mock.ClusterRepoMock{
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return queue.Pop(t, &tc.repoGetByName)
+					return tc.repoGetByName, tc.repoGetByNameErr
 				},
-				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
-					inProgressStatus, err := queue.Pop(t, &tc.repoUpdate)
+			}
 
-					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
-					require.Equal(t, inProgressStatus.InProgress, cluster.UpdateStatus.InProgressStatus.InProgress)
-					require.ElementsMatch(t, inProgressStatus.EvacuatedBefore, cluster.UpdateStatus.InProgressStatus.EvacuatedBefore)
-					require.Equal(t, inProgressStatus.PendingReboot, cluster.UpdateStatus.InProgressStatus.PendingReboot)
+			client := &adapterMock.ClusterClientPortMock{
+				UpdateNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) error {
+					_, err := queue.Pop(t, &tc.clientUpdateNetworkConfig)
 					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServers
+					return tc.serverSvcPollServersErr
 				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
 			}
 
-			clusterSvc := provisioningCluster.New(
-				repo, nil, nil, serverSvc, nil, nil, nil, nil,
-				provisioningCluster.WithNow(func() time.Time {
-					return fixedTime
-				}),
-			)
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err := clusterSvc.LaunchClusterReboot(t.Context(), "one")
+			err = clusterSvc.RemoveServerSystemNetworkVLANTags(context.Background(), tc.nameArg, tc.interfaceNameArg, tc.vlanTagsArg)
 
 			// Assert
 			tc.assertErr(t, err)
-			require.Empty(t, tc.repoGetByName)
-			require.Empty(t, tc.repoUpdate)
+			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.clientUpdateNetworkConfig)
 		})
 	}
 }
 
-func TestClusterService_AbortClusterOperation(t *testing.T) {
-	tests := []struct {
-		name             string
-		repoGetByName    *provisioning.Cluster
-		repoGetByNameErr error
-		repoUpdateErr    error
-
-		assertErr require.ErrorAssertionFunc
-	}{
-		{
-			name:          "success",
-			repoGetByName: &provisioning.Cluster{},
-
-			assertErr: require.NoError,
-		},
-		{
-			name:             "error - repo.GetByName",
-			repoGetByNameErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-		{
-			name:          "error - repo.GetByName",
-			repoGetByName: &provisioning.Cluster{},
-			repoUpdateErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-	}
-
-	fixedTime := time.Date(2026, 3, 12, 8, 54, 35, 123, time.UTC)
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &mock.ClusterRepoMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
-					return tc.repoGetByName, tc.repoGetByNameErr
-				},
-				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
-					require.Equal(t, fixedTime, cluster.UpdateStatus.InProgressStatus.LastUpdated)
-					require.False(t, cluster.IsUpdateInProgress())
-					return tc.repoUpdateErr
-				},
-			}
-
-			clusterSvc := provisioningCluster.New(
-				repo, nil, nil, nil, nil, nil, nil, nil,
-				provisioningCluster.WithNow(func() time.Time {
-					return fixedTime
-				}),
-			)
-
-			// Run test
-			err := clusterSvc.AbortClusterOperation(t.Context(), "one")
-
-			// Assert
-			tc.assertErr(t, err)
-		})
-	}
-}
-
-func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
+func TestClusterService_UpdateSystemLogging(t *testing.T) {
 	tests := []struct {
-		name                      string
-		nameArg                   string
-		interfaceNameArg          string
-		vlanTagsArg               []int
-		repoGetByName             *provisioning.Cluster
-		repoGetByNameErr          error
-		serverSvcPollServersErr   error
-		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
-		clientUpdateNetworkConfig []queue.Item[*incusosapi.SystemNetworkConfig] // Value is the expected value.
+		name                         string
+		nameArg                      string
+		loggingConfigArg             provisioning.ServerSystemLogging
+		repoGetByName                *provisioning.Cluster
+		repoGetByNameErr             error
+		serverSvcPollServersErr      error
+		serverSvcGetAllWithFilter    []queue.Item[provisioning.Servers]
+		serverSvcGetSystemLogging    []queue.Item[provisioning.ServerSystemLogging]
+		serverSvcUpdateSystemLogging []queue.Item[struct{}]
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
@@ -15928,8 +21056,7 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 		{
 			name:             "success",
 			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
+			loggingConfigArg: incusosapi.SystemLogging{},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -15951,10 +21078,9 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50}, // vlan tag 10 already present
+												Name: "first",
 											},
 										},
 									},
@@ -15972,10 +21098,9 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50}, // vlan tag 10 already present
+												Name: "first",
 											},
 										},
 									},
@@ -15985,37 +21110,33 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 					},
 				},
 			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLAN tags.
-							},
-						},
-					},
-				},
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLAN tags.
-							},
-						},
-					},
-				},
+			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
+				{},
+				{},
+			},
+			serverSvcUpdateSystemLogging: []queue.Item[struct{}]{
+				{},
+				{},
 			},
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
+
 		{
-			name:             "success - bond without any interfaces",
+			name:                    "error - GetByName error",
+			nameArg:                 "one",
+			loggingConfigArg:        incusosapi.SystemLogging{},
+			repoGetByNameErr:        boom.Error,
+			serverSvcPollServersErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name:             "error - serverSvc.GetSystemLogging",
 			nameArg:          "one",
-			interfaceNameArg: "bond0",
-			vlanTagsArg:      []int{10, 20, 100},
+			loggingConfigArg: incusosapi.SystemLogging{},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -16037,10 +21158,29 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Bonds: []incusosapi.SystemNetworkBond{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "bond0",
-												VLANTags: []int{10, 50}, // vlan tag 10 already present
+												Name: "first",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
 											},
 										},
 									},
@@ -16050,27 +21190,19 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 					},
 				},
 			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
+			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
 				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Bonds: []incusosapi.SystemNetworkBond{
-							{
-								Name:     "bond0",
-								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLAN tags.
-							},
-						},
-					},
+					Err: boom.Error,
 				},
 			},
 
-			assertErr: require.NoError,
+			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:             "success - bond next to unrelated interfaces",
+			name:             "error - serverSvc.UpdateSystemLogging - revert",
 			nameArg:          "one",
-			interfaceNameArg: "bond0",
-			vlanTagsArg:      []int{10, 20, 100},
+			loggingConfigArg: incusosapi.SystemLogging{},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -16092,16 +21224,29 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "uplink",
-												VLANTags: []int{50},
+												Name: "first",
 											},
 										},
-										Bonds: []incusosapi.SystemNetworkBond{
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "bond0",
-												VLANTags: []int{10},
+												Name: "first",
 											},
 										},
 									},
@@ -16111,123 +21256,91 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 					},
 				},
 			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
+			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
+				{},
+				{},
+			},
+			serverSvcUpdateSystemLogging: []queue.Item[struct{}]{
+				// Update server one
+				{},
+				// Update server two
 				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{50}, // Expect the interface to be left untouched.
-							},
-						},
-						Bonds: []incusosapi.SystemNetworkBond{
-							{
-								Name:     "bond0",
-								VLANTags: []int{10, 20, 100}, // Expect the updated set of VLAN tags.
-							},
-						},
-					},
+					Err: errors.New("error"),
+				},
+				// Revert server one
+				{
+					Err: boom.Error,
 				},
 			},
 
-			assertErr: require.NoError,
-			assertLog: log.Empty,
+			assertErr: require.Error,
+			assertLog: log.Match("Failed to revert previously updated logging config.*" + boom.Error.Error()),
 		},
+	}
 
-		{
-			name:             "error - GetByName error",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
-			repoGetByNameErr: boom.Error,
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, false, false)
+			require.NoError(t, err)
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name:             "error - cluster Status not ready",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
-			repoGetByName: &provisioning.Cluster{
-				Name:   "one",
-				Status: api.ClusterStatusPending, // not ready
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-			},
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+			}
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-			},
-			assertLog: log.Empty,
-		},
-		{
-			name:             "error - serverSvc.PollServers",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
-			repoGetByName: &provisioning.Cluster{
-				Name:   "one",
-				Status: api.ClusterStatusReady,
-			},
-			serverSvcPollServersErr: boom.Error,
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-			},
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return tc.serverSvcPollServersErr
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
+				},
+				GetSystemLoggingFunc: func(ctx context.Context, name string) (provisioning.ServerSystemLogging, error) {
+					return queue.Pop(t, &tc.serverSvcGetSystemLogging)
+				},
+				UpdateSystemLoggingFunc: func(ctx context.Context, name string, config provisioning.ServerSystemLogging) error {
+					_, err := queue.Pop(t, &tc.serverSvcUpdateSystemLogging)
+					return err
+				},
+			}
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
-		{
-			name:             "error - cluster without members",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
-			repoGetByName: &provisioning.Cluster{
-				Name:   "one",
-				Status: api.ClusterStatusReady,
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-				// serverSvc.GetAllWithFilter - no servers found
-				{},
-			},
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-			},
-			assertLog: log.Empty,
-		},
-		{
-			name:             "error - serverSvc.GetAllWithFilter",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
-			repoGetByName: &provisioning.Cluster{
-				Name:   "one",
-				Status: api.ClusterStatusReady,
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-				// serverSvc.GetAllWithFilter
-				{
-					Err: boom.Error,
-				},
-			},
+			// Run test
+			err = clusterSvc.UpdateSystemLogging(context.Background(), tc.nameArg, tc.loggingConfigArg)
 
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
+			// Assert
+			tc.assertErr(t, err)
+			tc.assertLog(t, logBuf)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.serverSvcGetSystemLogging)
+			require.Empty(t, tc.serverSvcUpdateSystemLogging)
+		})
+	}
+}
+
+func TestClusterService_UpdateSystemKernel(t *testing.T) {
+	tests := []struct {
+		name                        string
+		nameArg                     string
+		kernelConfigArg             provisioning.ServerSystemKernel
+		repoGetByName               *provisioning.Cluster
+		repoGetByNameErr            error
+		serverSvcPollServersErr     error
+		serverSvcGetAllWithFilter   []queue.Item[provisioning.Servers]
+		serverSvcGetSystemKernel    []queue.Item[provisioning.ServerSystemKernel]
+		serverSvcUpdateSystemKernel []queue.Item[struct{}]
+
+		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
+	}{
 		{
-			name:             "error - server status not ready",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
+			name:            "success",
+			nameArg:         "one",
+			kernelConfigArg: incusosapi.SystemKernel{},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -16241,26 +21354,73 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 						{
 							Name:         "one",
 							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusOffline, // server offline
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
 							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
+											},
+										},
+									},
+								},
+							},
 						},
 					},
 				},
 			},
+			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
+				{},
+				{},
+			},
+			serverSvcUpdateSystemKernel: []queue.Item[struct{}]{
+				{},
+				{},
+			},
+
+			assertErr: require.NoError,
+			assertLog: log.Empty,
+		},
+
+		{
+			name:                    "error - GetByName error",
+			nameArg:                 "one",
+			kernelConfigArg:         incusosapi.SystemKernel{},
+			repoGetByNameErr:        boom.Error,
+			serverSvcPollServersErr: boom.Error,
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-			},
+			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:             "error - server without network config",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
+			name:            "error - serverSvc.GetSystemKernel",
+			nameArg:         "one",
+			kernelConfigArg: incusosapi.SystemKernel{},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -16281,26 +21441,52 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							},
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
-									Config: nil, // no network config present
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
+											},
+										},
+									},
 								},
 							},
 						},
 					},
 				},
 			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, `does not have any network config`)
+			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
+				{
+					Err: boom.Error,
+				},
 			},
+
+			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:             "error - network interface and bond missing on server",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
+			name:            "error - serverSvc.UpdateSystemKernel - revert",
+			nameArg:         "one",
+			kernelConfigArg: incusosapi.SystemKernel{},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -16322,10 +21508,29 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{}, // no matching network interface
-										Bonds: []incusosapi.SystemNetworkBond{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name: "bond0", // no matching bond either
+												Name: "first",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
 											},
 										},
 									},
@@ -16335,19 +21540,90 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 					},
 				},
 			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, `does not have interface or bond "uplink"`)
+			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
+				{},
+				{},
 			},
-			assertLog: log.Empty,
+			serverSvcUpdateSystemKernel: []queue.Item[struct{}]{
+				// Update server one
+				{},
+				// Update server two
+				{
+					Err: errors.New("error"),
+				},
+				// Revert server one
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: require.Error,
+			assertLog: log.Match("Failed to revert previously updated kernel config.*" + boom.Error.Error()),
 		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, false, false)
+			require.NoError(t, err)
+
+			repo := &mock.ClusterRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+			}
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return tc.serverSvcPollServersErr
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
+				},
+				GetSystemKernelFunc: func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
+					return queue.Pop(t, &tc.serverSvcGetSystemKernel)
+				},
+				UpdateSystemKernelFunc: func(ctx context.Context, name string, config provisioning.ServerSystemKernel) error {
+					_, err := queue.Pop(t, &tc.serverSvcUpdateSystemKernel)
+					return err
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			err = clusterSvc.UpdateSystemKernel(context.Background(), tc.nameArg, tc.kernelConfigArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			tc.assertLog(t, logBuf)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.serverSvcGetSystemKernel)
+			require.Empty(t, tc.serverSvcUpdateSystemKernel)
+		})
+	}
+}
+
+func TestClusterService_AddApplication(t *testing.T) {
+	tests := []struct {
+		name                      string
+		nameArg                   string
+		applicationNameArg        string
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		serverSvcPollServersErr   error
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+		serverSvcAddApplication   []queue.Item[struct{}]
+
+		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
+	}{
 		{
-			name:             "error - serverSvc.AddSystemNetworkVLAN - revert serverSvc.ReomveSystemNetworkVLAN",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 20, 100},
+			name:               "success",
+			nameArg:            "one",
+			applicationNameArg: "debug",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
@@ -16369,10 +21645,9 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
+												Name: "first",
 											},
 										},
 									},
@@ -16390,10 +21665,9 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 							OSData: api.OSData{
 								Network: incusosapi.SystemNetwork{
 									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
+										VLANs: []incusosapi.SystemNetworkVLAN{
 											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
+												Name: "first",
 											},
 										},
 									},
@@ -16403,72 +21677,102 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 					},
 				},
 			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
-				// Update first server.
+			serverSvcAddApplication: []queue.Item[struct{}]{
+				{},
+				{},
+			},
+
+			assertErr: require.NoError,
+			assertLog: log.Empty,
+		},
+
+		{
+			name:                    "error - GetByName error",
+			nameArg:                 "one",
+			applicationNameArg:      "debug",
+			repoGetByNameErr:        boom.Error,
+			serverSvcPollServersErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name:               "error - serverSvc.AddApplication",
+			nameArg:            "one",
+			applicationNameArg: "debug",
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
 				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLANTags
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
+											},
+										},
+									},
+								},
 							},
 						},
-					},
-				},
-				// Update second server fails.
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50, 20, 100}, // Expect the updated set of VLANTags
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+							OSData: api.OSData{
+								Network: incusosapi.SystemNetwork{
+									Config: &incusosapi.SystemNetworkConfig{
+										VLANs: []incusosapi.SystemNetworkVLAN{
+											{
+												Name: "first",
+											},
+										},
+									},
+								},
 							},
 						},
 					},
-					Err: errors.New("error"),
 				},
-				// Revert of update on first server fails.
+			},
+			serverSvcAddApplication: []queue.Item[struct{}]{
+				{},
 				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50}, // Expect only the original set of VLANTags
-							},
-						},
-					},
 					Err: boom.Error,
 				},
 			},
 
-			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated network configuration.*" + boom.Error.Error()),
+			assertErr: boom.ErrorIs,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
-			logBuf := &bytes.Buffer{}
-			err := logger.InitLogger(logBuf, "", false, false, false)
-			require.NoError(t, err)
-
 			repo := &mock.ClusterRepoMock{
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
 					return tc.repoGetByName, tc.repoGetByNameErr
 				},
 			}
 
-			client := &adapterMock.ClusterClientPortMock{
-				UpdateNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) error {
-					wantConfig, err := queue.Pop(t, &tc.clientUpdateNetworkConfig)
-
-					require.Equal(t, wantConfig, server.OSData.Network.Config)
-
-					return err
-				},
-			}
-
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
 					return tc.serverSvcPollServersErr
@@ -16476,46 +21780,78 @@ func TestClusterService_AddServerSystemNetworkVLANTags(t *testing.T) {
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
+				AddApplicationFunc: func(ctx context.Context, name, applicationName string) error {
+					_, err := queue.Pop(t, &tc.serverSvcAddApplication)
+					return err
+				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.AddServerSystemNetworkVLANTags(context.Background(), tc.nameArg, tc.interfaceNameArg, tc.vlanTagsArg)
+			err := clusterSvc.AddApplication(context.Background(), tc.nameArg, tc.applicationNameArg)
 
 			// Assert
 			tc.assertErr(t, err)
-			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientUpdateNetworkConfig)
+			require.Empty(t, tc.serverSvcAddApplication)
 		})
 	}
 }
 
-func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
+func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 	tests := []struct {
 		name                      string
 		nameArg                   string
-		interfaceNameArg          string
-		vlanTagsArg               []int
+		targetArg                 incusosapi.ServiceISCSITarget
 		repoGetByName             *provisioning.Cluster
 		repoGetByNameErr          error
+		clientGetOSServiceISCSI   []queue.Item[incusosapi.ServiceISCSI]
+		clientUpdateOSService     []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
 		serverSvcPollServersErr   error
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
-		clientUpdateNetworkConfig []queue.Item[*incusosapi.SystemNetworkConfig] // Value is the expected value.
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name:             "success",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 30},
+			name:    "success",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: false, // false on purpose
+							Targets: []incusosapi.ServiceISCSITarget{},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				{
+					Value: true,
+				},
+				{
+					Value: true,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -16530,18 +21866,6 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
-											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -16551,40 +21875,6 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
-											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
-							},
-						},
-					},
-				},
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
-							},
 						},
 					},
 				},
@@ -16593,15 +21883,38 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
+
 		{
-			name:             "success - bond next to unrelated interfaces",
-			nameArg:          "one",
-			interfaceNameArg: "bond0",
-			vlanTagsArg:      []int{10, 30},
+			name:    "error - GetByName error",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
+			repoGetByNameErr:        boom.Error,
+			serverSvcPollServersErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name:    "error - client.GetOSServiceISCSI",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Err: boom.Error,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -16616,71 +21929,51 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
-											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
-											},
-										},
-										Bonds: []incusosapi.SystemNetworkBond{
-											{
-												Name:     "bond0",
-												VLANTags: []int{10, 50},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50}, // Expect the interface to be left untouched.
-							},
 						},
-						Bonds: []incusosapi.SystemNetworkBond{
-							{
-								Name:     "bond0",
-								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 					},
 				},
 			},
 
-			assertErr: require.NoError,
-			assertLog: log.Empty,
-		},
-
-		{
-			name:                    "error - GetByName error",
-			nameArg:                 "one",
-			interfaceNameArg:        "uplink",
-			vlanTagsArg:             []int{10},
-			repoGetByNameErr:        boom.Error,
-			serverSvcPollServersErr: boom.Error,
-
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:             "error - server without network config",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10},
+			name:    "error - iscsi service target already present",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{
+								{
+									Target:  "target",
+									Address: "address",
+									Port:    1234,
+								},
+							},
+						},
+					},
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -16695,79 +21988,72 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: nil, // no network config present
-								},
-							},
 						},
-					},
-				},
-			},
-
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, `does not have any network config`)
-			},
-			assertLog: log.Empty,
-		},
-		{
-			name:             "error - network interface and bond missing on server",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10},
-			repoGetByName: &provisioning.Cluster{
-				Name:   "one",
-				Status: api.ClusterStatusReady,
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-				// serverSvc.GetAllWithFilter
-				{
-					Value: provisioning.Servers{
 						{
-							Name:         "one",
+							Name:         "two",
 							Cluster:      ptr.To("one"),
 							Status:       api.ServerStatusReady,
 							StatusDetail: api.ServerStatusDetailNone,
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{}, // no matching network interface
-										Bonds: []incusosapi.SystemNetworkBond{
-											{
-												Name: "bond0", // no matching bond either
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
-				var verr domain.ErrValidation
-				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(t, err, `does not have interface or bond "uplink"`)
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Service iscsi target "target" (address:1234) already defined on server`)
 			},
 			assertLog: log.Empty,
 		},
 		{
-			name:             "error - serverSvc.ReomveSystemNetworkVLAN - revert serverSvc.AddSystemNetworkVLAN",
-			nameArg:          "one",
-			interfaceNameArg: "uplink",
-			vlanTagsArg:      []int{10, 30},
+			name:    "error - client.UpdateOSService - revert client.UpdateOSService",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: false, // false on purpose
+							Targets: []incusosapi.ServiceISCSITarget{},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				// First update successful.
+				{
+					Value: true,
+				},
+				// Second update error.
+				{
+					Value: true,
+					Err:   errors.New("error"),
+				},
+				// Revert of first update error.
+				{
+					Value: false,
+					Err:   boom.Error,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -16782,18 +22068,6 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
-											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -16803,62 +22077,13 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										Interfaces: []incusosapi.SystemNetworkInterface{
-											{
-												Name:     "uplink",
-												VLANTags: []int{10, 50},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			clientUpdateNetworkConfig: []queue.Item[*incusosapi.SystemNetworkConfig]{
-				// Update on first server.
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
-							},
-						},
-					},
-				},
-				// Update on second server fails.
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{50}, // Expect the updated set of VLAN tags.
-							},
 						},
 					},
-					Err: errors.New("error"),
-				},
-				// Revert on first server fails.
-				{
-					Value: &incusosapi.SystemNetworkConfig{
-						Interfaces: []incusosapi.SystemNetworkInterface{
-							{
-								Name:     "uplink",
-								VLANTags: []int{10, 50}, // Expect the original set of VLAN tags.
-							},
-						},
-					},
-					Err: boom.Error,
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated network configuration.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated iscsi service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -16876,8 +22101,13 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				UpdateNetworkConfigFunc: func(ctx context.Context, server provisioning.Server) error {
-					_, err := queue.Pop(t, &tc.clientUpdateNetworkConfig)
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					config, err := queue.Pop(t, &tc.clientGetOSServiceISCSI)
+					return config, err
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, wantEnabled, config.(incusosapi.ServiceISCSI).Config.Enabled)
 					return err
 				},
 			}
@@ -16889,44 +22119,97 @@ func TestClusterService_RemoveServerSystemNetworkVLANTags(t *testing.T) {
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
-			}
-
-			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
-
-			// Run test
-			err = clusterSvc.RemoveServerSystemNetworkVLANTags(context.Background(), tc.nameArg, tc.interfaceNameArg, tc.vlanTagsArg)
-
-			// Assert
-			tc.assertErr(t, err)
-			tc.assertLog(t, logBuf)
-			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientUpdateNetworkConfig)
-		})
-	}
-}
-
-func TestClusterService_UpdateSystemLogging(t *testing.T) {
-	tests := []struct {
-		name                         string
-		nameArg                      string
-		loggingConfigArg             provisioning.ServerSystemLogging
-		repoGetByName                *provisioning.Cluster
-		repoGetByNameErr             error
-		serverSvcPollServersErr      error
-		serverSvcGetAllWithFilter    []queue.Item[provisioning.Servers]
-		serverSvcGetSystemLogging    []queue.Item[provisioning.ServerSystemLogging]
-		serverSvcUpdateSystemLogging []queue.Item[struct{}]
-
-		assertErr require.ErrorAssertionFunc
-		assertLog log.MatcherFunc
-	}{
-		{
-			name:             "success",
-			nameArg:          "one",
-			loggingConfigArg: incusosapi.SystemLogging{},
-			repoGetByName: &provisioning.Cluster{
-				Name:   "one",
-				Status: api.ClusterStatusReady,
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			err = clusterSvc.AddStorageTargetISCSI(context.Background(), tc.nameArg, tc.targetArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			tc.assertLog(t, logBuf)
+			require.Empty(t, tc.serverSvcGetAllWithFilter)
+			require.Empty(t, tc.clientGetOSServiceISCSI)
+			require.Empty(t, tc.clientUpdateOSService)
+		})
+	}
+}
+
+func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
+	tests := []struct {
+		name                      string
+		nameArg                   string
+		targetArg                 incusosapi.ServiceISCSITarget
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		clientGetOSServiceISCSI   []queue.Item[incusosapi.ServiceISCSI]
+		clientUpdateOSService     []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
+		serverSvcPollServersErr   error
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
+
+		assertErr require.ErrorAssertionFunc
+		assertLog log.MatcherFunc
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{
+								{
+									Target:  "target",
+									Address: "address",
+									Port:    1234,
+								},
+								{
+									Target:  "keep",
+									Address: "keep",
+									Port:    1234,
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{
+								{
+									Target:  "target",
+									Address: "address",
+									Port:    1234,
+								},
+								{
+									Target:  "keep",
+									Address: "keep",
+									Port:    1234,
+								},
+							},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				{
+					Value: true,
+				},
+				{
+					Value: true,
+				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
@@ -16942,17 +22225,6 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -16962,38 +22234,23 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
-			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
-				{},
-				{},
-			},
-			serverSvcUpdateSystemLogging: []queue.Item[struct{}]{
-				{},
-				{},
-			},
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
 
 		{
-			name:                    "error - GetByName error",
-			nameArg:                 "one",
-			loggingConfigArg:        incusosapi.SystemLogging{},
+			name:    "error - GetByName error",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByNameErr:        boom.Error,
 			serverSvcPollServersErr: boom.Error,
 
@@ -17001,13 +22258,22 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:             "error - serverSvc.GetSystemLogging",
-			nameArg:          "one",
-			loggingConfigArg: incusosapi.SystemLogging{},
+			name:    "error - client.GetOSServiceISCSI",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Err: boom.Error,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17022,17 +22288,6 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -17042,38 +22297,36 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
-			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
-				{
-					Err: boom.Error,
-				},
-			},
 
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:             "error - serverSvc.UpdateSystemLogging - revert",
-			nameArg:          "one",
-			loggingConfigArg: incusosapi.SystemLogging{},
+			name:    "error - iscsi service target missing",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{}, // target missing
+						},
+					},
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17088,17 +22341,6 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -17108,40 +22350,105 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Service iscsi target "target" (address:1234) does not exist on server`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name:    "error - client.UpdateOSService - revert client.UpdateOSService",
+			nameArg: "one",
+			targetArg: incusosapi.ServiceISCSITarget{
+				Target:  "target",
+				Address: "address",
+				Port:    1234,
+			},
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{
+								{
+									Target:  "target",
+									Address: "address",
+									Port:    1234,
+								},
+							},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceISCSI{
+						Config: incusosapi.ServiceISCSIConfig{
+							Enabled: true,
+							Targets: []incusosapi.ServiceISCSITarget{
+								{
+									Target:  "target",
+									Address: "address",
+									Port:    1234,
 								},
 							},
 						},
 					},
 				},
 			},
-			serverSvcGetSystemLogging: []queue.Item[provisioning.ServerSystemLogging]{
-				{},
-				{},
-			},
-			serverSvcUpdateSystemLogging: []queue.Item[struct{}]{
-				// Update server one
-				{},
-				// Update server two
+			clientUpdateOSService: []queue.Item[bool]{
+				// First update successful.
 				{
-					Err: errors.New("error"),
+					Value: true,
 				},
-				// Revert server one
+				// Second update error.
 				{
-					Err: boom.Error,
+					Value: true,
+					Err:   errors.New("error"),
+				},
+				// Revert of first update error.
+				{
+					Value: true,
+					Err:   boom.Error,
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated logging config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated iscsi service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -17158,6 +22465,18 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 				},
 			}
 
+			client := &adapterMock.ClusterClientPortMock{
+				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
+					config, err := queue.Pop(t, &tc.clientGetOSServiceISCSI)
+					return config, err
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, wantEnabled, config.(incusosapi.ServiceISCSI).Config.Enabled)
+					return err
+				},
+			}
+
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
 					return tc.serverSvcPollServersErr
@@ -17165,53 +22484,72 @@ func TestClusterService_UpdateSystemLogging(t *testing.T) {
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
-				GetSystemLoggingFunc: func(ctx context.Context, name string) (provisioning.ServerSystemLogging, error) {
-					return queue.Pop(t, &tc.serverSvcGetSystemLogging)
-				},
-				UpdateSystemLoggingFunc: func(ctx context.Context, name string, config provisioning.ServerSystemLogging) error {
-					_, err := queue.Pop(t, &tc.serverSvcUpdateSystemLogging)
-					return err
-				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.UpdateSystemLogging(context.Background(), tc.nameArg, tc.loggingConfigArg)
+			err = clusterSvc.RemoveStorageTargetISCSI(context.Background(), tc.nameArg, tc.targetArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.serverSvcGetSystemLogging)
-			require.Empty(t, tc.serverSvcUpdateSystemLogging)
+			require.Empty(t, tc.clientGetOSServiceISCSI)
+			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_UpdateSystemKernel(t *testing.T) {
+func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 	tests := []struct {
 		name                        string
 		nameArg                     string
-		kernelConfigArg             provisioning.ServerSystemKernel
+		targetArg                   string
 		repoGetByName               *provisioning.Cluster
 		repoGetByNameErr            error
+		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
+		clientUpdateOSService       []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
 		serverSvcPollServersErr     error
 		serverSvcGetAllWithFilter   []queue.Item[provisioning.Servers]
-		serverSvcGetSystemKernel    []queue.Item[provisioning.ServerSystemKernel]
-		serverSvcUpdateSystemKernel []queue.Item[struct{}]
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name:            "success",
-			nameArg:         "one",
-			kernelConfigArg: incusosapi.SystemKernel{},
+			name:      "success",
+			nameArg:   "one",
+			targetArg: "target",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: false, // false on purpose
+							WWNs:    []string{},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				{
+					Value: true,
+				},
+				{
+					Value: true,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17226,17 +22564,6 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -17246,29 +22573,10 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
-			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
-				{},
-				{},
-			},
-			serverSvcUpdateSystemKernel: []queue.Item[struct{}]{
-				{},
-				{},
-			},
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
@@ -17277,7 +22585,7 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 		{
 			name:                    "error - GetByName error",
 			nameArg:                 "one",
-			kernelConfigArg:         incusosapi.SystemKernel{},
+			targetArg:               "target",
 			repoGetByNameErr:        boom.Error,
 			serverSvcPollServersErr: boom.Error,
 
@@ -17285,13 +22593,18 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:            "error - serverSvc.GetSystemKernel",
-			nameArg:         "one",
-			kernelConfigArg: incusosapi.SystemKernel{},
+			name:      "error - client.GetOSServiceMultipath",
+			nameArg:   "one",
+			targetArg: "target",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Err: boom.Error,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17306,17 +22619,6 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -17326,38 +22628,32 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
-			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
-				{
-					Err: boom.Error,
-				},
-			},
 
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:            "error - serverSvc.UpdateSystemKernel - revert",
-			nameArg:         "one",
-			kernelConfigArg: incusosapi.SystemKernel{},
+			name:      "error - multipath service target already present",
+			nameArg:   "one",
+			targetArg: "target",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"target"},
+						},
+					},
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17372,17 +22668,6 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -17392,40 +22677,89 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
-			serverSvcGetSystemKernel: []queue.Item[provisioning.ServerSystemKernel]{
-				{},
-				{},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Service multipath target "target" already defined on server`)
 			},
-			serverSvcUpdateSystemKernel: []queue.Item[struct{}]{
-				// Update server one
-				{},
-				// Update server two
+			assertLog: log.Empty,
+		},
+		{
+			name:      "error - client.UpdateOSService - revert client.UpdateOSService",
+			nameArg:   "one",
+			targetArg: "target",
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
 				{
-					Err: errors.New("error"),
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: false, // false on purpose
+							WWNs:    []string{},
+						},
+					},
 				},
-				// Revert server one
 				{
-					Err: boom.Error,
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				// First update successful.
+				{
+					Value: true,
+				},
+				// Second update error.
+				{
+					Value: true,
+					Err:   errors.New("error"),
+				},
+				// Revert of first update error.
+				{
+					Value: false,
+					Err:   boom.Error,
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated kernel config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated multipath service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -17442,6 +22776,18 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 				},
 			}
 
+			client := &adapterMock.ClusterClientPortMock{
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					config, err := queue.Pop(t, &tc.clientGetOSServiceMultipath)
+					return config, err
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, wantEnabled, config.(incusosapi.ServiceMultipath).Config.Enabled)
+					return err
+				},
+			}
+
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
 					return tc.serverSvcPollServersErr
@@ -17449,52 +22795,72 @@ func TestClusterService_UpdateSystemKernel(t *testing.T) {
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
-				GetSystemKernelFunc: func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
-					return queue.Pop(t, &tc.serverSvcGetSystemKernel)
-				},
-				UpdateSystemKernelFunc: func(ctx context.Context, name string, config provisioning.ServerSystemKernel) error {
-					_, err := queue.Pop(t, &tc.serverSvcUpdateSystemKernel)
-					return err
-				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.UpdateSystemKernel(context.Background(), tc.nameArg, tc.kernelConfigArg)
+			err = clusterSvc.AddStorageTargetMultipath(context.Background(), tc.nameArg, tc.targetArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.serverSvcGetSystemKernel)
-			require.Empty(t, tc.serverSvcUpdateSystemKernel)
+			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_AddApplication(t *testing.T) {
+func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 	tests := []struct {
-		name                      string
-		nameArg                   string
-		applicationNameArg        string
-		repoGetByName             *provisioning.Cluster
-		repoGetByNameErr          error
-		serverSvcPollServersErr   error
-		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
-		serverSvcAddApplication   []queue.Item[struct{}]
+		name                        string
+		nameArg                     string
+		targetArg                   string
+		repoGetByName               *provisioning.Cluster
+		repoGetByNameErr            error
+		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
+		clientUpdateOSService       []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
+		serverSvcPollServersErr     error
+		serverSvcGetAllWithFilter   []queue.Item[provisioning.Servers]
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name:               "success",
-			nameArg:            "one",
-			applicationNameArg: "debug",
+			name:      "success",
+			nameArg:   "one",
+			targetArg: "target",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: false, // false on purpose
+							WWNs:    []string{"target", "keep"},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"target", "keep"},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				{
+					Value: true,
+				},
+				{
+					Value: true,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17509,17 +22875,6 @@ func TestClusterService_AddApplication(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 						{
 							Name:         "two",
@@ -17529,25 +22884,10 @@ func TestClusterService_AddApplication(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
-							},
 						},
 					},
 				},
 			},
-			serverSvcAddApplication: []queue.Item[struct{}]{
-				{},
-				{},
-			},
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
@@ -17556,7 +22896,7 @@ func TestClusterService_AddApplication(t *testing.T) {
 		{
 			name:                    "error - GetByName error",
 			nameArg:                 "one",
-			applicationNameArg:      "debug",
+			targetArg:               "target",
 			repoGetByNameErr:        boom.Error,
 			serverSvcPollServersErr: boom.Error,
 
@@ -17564,13 +22904,18 @@ func TestClusterService_AddApplication(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:               "error - serverSvc.AddApplication",
-			nameArg:            "one",
-			applicationNameArg: "debug",
+			name:      "error - client.GetOSServiceMultipath",
+			nameArg:   "one",
+			targetArg: "target",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Err: boom.Error,
+				},
+			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
 				// GetByName
 				{},
@@ -17585,16 +22930,54 @@ func TestClusterService_AddApplication(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name:      "error - multipath service target missing",
+			nameArg:   "one",
+			targetArg: "target",
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{}, // target missing
+						},
+					},
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 						{
@@ -17605,41 +22988,117 @@ func TestClusterService_AddApplication(t *testing.T) {
 							VersionData: api.ServerVersionData{
 								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
-							OSData: api.OSData{
-								Network: incusosapi.SystemNetwork{
-									Config: &incusosapi.SystemNetworkConfig{
-										VLANs: []incusosapi.SystemNetworkVLAN{
-											{
-												Name: "first",
-											},
-										},
-									},
-								},
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
+				require.ErrorContains(tt, err, `Service multipath target "target" does not exist on server`)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name:      "error - client.UpdateOSService - revert client.UpdateOSService",
+			nameArg:   "one",
+			targetArg: "target",
+			repoGetByName: &provisioning.Cluster{
+				Name:   "one",
+				Status: api.ClusterStatusReady,
+			},
+			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: false, // false on purpose
+							WWNs:    []string{"target"},
+						},
+					},
+				},
+				{
+					Value: incusosapi.ServiceMultipath{
+						Config: incusosapi.ServiceMultipathConfig{
+							Enabled: true,
+							WWNs:    []string{"target"},
+						},
+					},
+				},
+			},
+			clientUpdateOSService: []queue.Item[bool]{
+				// First update successful.
+				{
+					Value: true,
+				},
+				// Second update error.
+				{
+					Value: true,
+					Err:   errors.New("error"),
+				},
+				// Revert of first update error.
+				{
+					Value: false,
+					Err:   boom.Error,
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
+				{
+					Value: provisioning.Servers{
+						{
+							Name:         "one",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
+							},
+						},
+						{
+							Name:         "two",
+							Cluster:      ptr.To("one"),
+							Status:       api.ServerStatusReady,
+							StatusDetail: api.ServerStatusDetailNone,
+							VersionData: api.ServerVersionData{
+								InMaintenance: ptr.To(api.NotInMaintenance),
 							},
 						},
 					},
 				},
 			},
-			serverSvcAddApplication: []queue.Item[struct{}]{
-				{},
-				{
-					Err: boom.Error,
-				},
-			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.Error,
+			assertLog: log.Match("Failed to revert previously updated multipath service config.*" + boom.Error.Error()),
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, false, false)
+			require.NoError(t, err)
+
 			repo := &mock.ClusterRepoMock{
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
 					return tc.repoGetByName, tc.repoGetByNameErr
 				},
 			}
 
+			client := &adapterMock.ClusterClientPortMock{
+				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
+					config, err := queue.Pop(t, &tc.clientGetOSServiceMultipath)
+					return config, err
+				},
+				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
+					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, wantEnabled, config.(incusosapi.ServiceMultipath).Config.Enabled)
+					return err
+				},
+			}
+
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
 					return tc.serverSvcPollServersErr
@@ -17647,33 +23106,31 @@ func TestClusterService_AddApplication(t *testing.T) {
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
 				},
-				AddApplicationFunc: func(ctx context.Context, name, applicationName string) error {
-					_, err := queue.Pop(t, &tc.serverSvcAddApplication)
-					return err
-				},
 			}
 
-			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err := clusterSvc.AddApplication(context.Background(), tc.nameArg, tc.applicationNameArg)
+			err = clusterSvc.RemoveStorageTargetMultipath(context.Background(), tc.nameArg, tc.targetArg)
 
 			// Assert
 			tc.assertErr(t, err)
+			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.serverSvcAddApplication)
+			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
+func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 	tests := []struct {
 		name                      string
 		nameArg                   string
-		targetArg                 incusosapi.ServiceISCSITarget
+		targetArg                 incusosapi.ServiceNVMETarget
 		repoGetByName             *provisioning.Cluster
 		repoGetByNameErr          error
-		clientGetOSServiceISCSI   []queue.Item[incusosapi.ServiceISCSI]
+		clientGetOSServiceNVM     []queue.Item[incusosapi.ServiceNVME]
 		clientUpdateOSService     []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
 		serverSvcPollServersErr   error
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
@@ -17684,29 +23141,29 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 		{
 			name:    "success",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: false, // false on purpose
-							Targets: []incusosapi.ServiceISCSITarget{},
+							Targets: []incusosapi.ServiceNVMETarget{},
 						},
 					},
 				},
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{},
+							Targets: []incusosapi.ServiceNVMETarget{},
 						},
 					},
 				},
@@ -17754,10 +23211,10 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 		{
 			name:    "error - GetByName error",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByNameErr:        boom.Error,
 			serverSvcPollServersErr: boom.Error,
@@ -17766,18 +23223,18 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:    "error - client.GetOSServiceISCSI",
+			name:    "error - client.GetOSServiceNVME",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
 				{
 					Err: boom.Error,
 				},
@@ -17814,27 +23271,27 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:    "error - iscsi service target already present",
+			name:    "error - nvme service target already present",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{
+							Targets: []incusosapi.ServiceNVMETarget{
 								{
-									Target:  "target",
-									Address: "address",
-									Port:    1234,
+									Transport: "target",
+									Address:   "address",
+									Port:      1234,
 								},
 							},
 						},
@@ -17871,36 +23328,36 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Service iscsi target "target" (address:1234) already defined on server`)
+				require.ErrorContains(tt, err, `Service nvme transport "target" (address:1234) already defined on server`)
 			},
 			assertLog: log.Empty,
 		},
 		{
 			name:    "error - client.UpdateOSService - revert client.UpdateOSService",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: false, // false on purpose
-							Targets: []incusosapi.ServiceISCSITarget{},
+							Targets: []incusosapi.ServiceNVMETarget{},
 						},
 					},
 				},
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{},
+							Targets: []incusosapi.ServiceNVMETarget{},
 						},
 					},
 				},
@@ -17950,7 +23407,7 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated iscsi service config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated nvme service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -17968,13 +23425,13 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
-					config, err := queue.Pop(t, &tc.clientGetOSServiceISCSI)
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					config, err := queue.Pop(t, &tc.clientGetOSServiceNVM)
 					return config, err
 				},
 				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
 					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
-					require.Equal(t, wantEnabled, config.(incusosapi.ServiceISCSI).Config.Enabled)
+					require.Equal(t, wantEnabled, config.(incusosapi.ServiceNVME).Config.Enabled)
 					return err
 				},
 			}
@@ -17991,26 +23448,26 @@ func TestClusterService_AddStorageTargetISCSI(t *testing.T) {
 			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.AddStorageTargetISCSI(context.Background(), tc.nameArg, tc.targetArg)
+			err = clusterSvc.AddStorageTargetNVME(context.Background(), tc.nameArg, tc.targetArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientGetOSServiceISCSI)
+			require.Empty(t, tc.clientGetOSServiceNVM)
 			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
+func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 	tests := []struct {
 		name                      string
 		nameArg                   string
-		targetArg                 incusosapi.ServiceISCSITarget
+		targetArg                 incusosapi.ServiceNVMETarget
 		repoGetByName             *provisioning.Cluster
 		repoGetByNameErr          error
-		clientGetOSServiceISCSI   []queue.Item[incusosapi.ServiceISCSI]
+		clientGetOSServiceNVME    []queue.Item[incusosapi.ServiceNVME]
 		clientUpdateOSService     []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
 		serverSvcPollServersErr   error
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
@@ -18021,49 +23478,49 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 		{
 			name:    "success",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
+							Enabled: false, // false on purpose
+							Targets: []incusosapi.ServiceNVMETarget{
 								{
-									Target:  "target",
-									Address: "address",
-									Port:    1234,
+									Transport: "target",
+									Address:   "address",
+									Port:      1234,
 								},
 								{
-									Target:  "keep",
-									Address: "keep",
-									Port:    1234,
+									Transport: "keep",
+									Address:   "keep",
+									Port:      1234,
 								},
 							},
 						},
 					},
 				},
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{
+							Targets: []incusosapi.ServiceNVMETarget{
 								{
-									Target:  "target",
-									Address: "address",
-									Port:    1234,
+									Transport: "target",
+									Address:   "address",
+									Port:      1234,
 								},
 								{
-									Target:  "keep",
-									Address: "keep",
-									Port:    1234,
+									Transport: "keep",
+									Address:   "keep",
+									Port:      1234,
 								},
 							},
 						},
@@ -18113,10 +23570,10 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 		{
 			name:    "error - GetByName error",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByNameErr:        boom.Error,
 			serverSvcPollServersErr: boom.Error,
@@ -18125,18 +23582,18 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:    "error - client.GetOSServiceISCSI",
+			name:    "error - client.GetOSServiceNVME",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{
 					Err: boom.Error,
 				},
@@ -18173,23 +23630,23 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:    "error - iscsi service target missing",
+			name:    "error - nvme service target missing",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{}, // target missing
+							Targets: []incusosapi.ServiceNVMETarget{}, // target missing
 						},
 					},
 				},
@@ -18224,46 +23681,46 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Service iscsi target "target" (address:1234) does not exist on server`)
+				require.ErrorContains(tt, err, `Service nvme transport "target" (address:1234) does not exist on server`)
 			},
 			assertLog: log.Empty,
 		},
 		{
 			name:    "error - client.UpdateOSService - revert client.UpdateOSService",
 			nameArg: "one",
-			targetArg: incusosapi.ServiceISCSITarget{
-				Target:  "target",
-				Address: "address",
-				Port:    1234,
+			targetArg: incusosapi.ServiceNVMETarget{
+				Transport: "target",
+				Address:   "address",
+				Port:      1234,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceISCSI: []queue.Item[incusosapi.ServiceISCSI]{
+			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
+							Enabled: false, // false on purpose
+							Targets: []incusosapi.ServiceNVMETarget{
 								{
-									Target:  "target",
-									Address: "address",
-									Port:    1234,
+									Transport: "target",
+									Address:   "address",
+									Port:      1234,
 								},
 							},
 						},
 					},
 				},
 				{
-					Value: incusosapi.ServiceISCSI{
-						Config: incusosapi.ServiceISCSIConfig{
+					Value: incusosapi.ServiceNVME{
+						Config: incusosapi.ServiceNVMEConfig{
 							Enabled: true,
-							Targets: []incusosapi.ServiceISCSITarget{
+							Targets: []incusosapi.ServiceNVMETarget{
 								{
-									Target:  "target",
-									Address: "address",
-									Port:    1234,
+									Transport: "target",
+									Address:   "address",
+									Port:      1234,
 								},
 							},
 						},
@@ -18282,7 +23739,7 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 				},
 				// Revert of first update error.
 				{
-					Value: true,
+					Value: false,
 					Err:   boom.Error,
 				},
 			},
@@ -18315,7 +23772,7 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated iscsi service config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated nvme service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -18333,13 +23790,13 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				GetOSServiceISCSIFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceISCSI, error) {
-					config, err := queue.Pop(t, &tc.clientGetOSServiceISCSI)
+				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
+					config, err := queue.Pop(t, &tc.clientGetOSServiceNVME)
 					return config, err
 				},
 				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
 					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
-					require.Equal(t, wantEnabled, config.(incusosapi.ServiceISCSI).Config.Enabled)
+					require.Equal(t, wantEnabled, config.(incusosapi.ServiceNVME).Config.Enabled)
 					return err
 				},
 			}
@@ -18356,65 +23813,104 @@ func TestClusterService_RemoveStorageTargetISCSI(t *testing.T) {
 			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.RemoveStorageTargetISCSI(context.Background(), tc.nameArg, tc.targetArg)
+			err = clusterSvc.RemoveStorageTargetNVME(context.Background(), tc.nameArg, tc.targetArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientGetOSServiceISCSI)
+			require.Empty(t, tc.clientGetOSServiceNVME)
 			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
+func TestClusterService_AddServiceCephCluster(t *testing.T) {
+	readyServers := provisioning.Servers{
+		{
+			Name:         "one",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+		{
+			Name:         "two",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+	}
+
+	cephCluster := incusosapi.ServiceCephCluster{
+		FSID:     "fsid-new",
+		Monitors: []string{"10.0.0.1"},
+	}
+
 	tests := []struct {
-		name                        string
-		nameArg                     string
-		targetArg                   string
-		repoGetByName               *provisioning.Cluster
-		repoGetByNameErr            error
-		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
-		clientUpdateOSService       []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
-		serverSvcPollServersErr     error
-		serverSvcGetAllWithFilter   []queue.Item[provisioning.Servers]
+		name                      string
+		cephClusterNameArg        string
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		clientGetOSServiceCeph    []queue.Item[incusosapi.ServiceCeph]
+		clientUpdateOSService     []queue.Item[incusosapi.ServiceCeph]
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name:      "success",
-			nameArg:   "one",
-			targetArg: "target",
+			name:               "success",
+			cephClusterNameArg: "new",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
 							Enabled: false, // false on purpose
-							WWNs:    []string{},
 						},
 					},
 				},
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
 							Enabled: true,
-							WWNs:    []string{},
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"keep": {FSID: "fsid-keep"},
+							},
 						},
 					},
 				},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: true,
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"new": cephCluster,
+							},
+						},
+					},
 				},
 				{
-					Value: true,
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"keep": {FSID: "fsid-keep"},
+								"new":  cephCluster,
+							},
+						},
+					},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -18422,26 +23918,7 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
@@ -18450,24 +23927,31 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 		},
 
 		{
-			name:                    "error - GetByName error",
-			nameArg:                 "one",
-			targetArg:               "target",
-			repoGetByNameErr:        boom.Error,
-			serverSvcPollServersErr: boom.Error,
+			name:               "error - empty ceph cluster name",
+			cephClusterNameArg: "",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name:               "error - GetByName error",
+			cephClusterNameArg: "new",
+			repoGetByNameErr:   boom.Error,
 
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:      "error - client.GetOSServiceMultipath",
-			nameArg:   "one",
-			targetArg: "target",
+			name:               "error - client.GetOSServiceCeph",
+			cephClusterNameArg: "new",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
 					Err: boom.Error,
 				},
@@ -18477,26 +23961,7 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
@@ -18504,19 +23969,20 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:      "error - multipath service target already present",
-			nameArg:   "one",
-			targetArg: "target",
+			name:               "error - ceph cluster already defined",
+			cephClusterNameArg: "new",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
 							Enabled: true,
-							WWNs:    []string{"target"},
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"new": {FSID: "fsid-existing"},
+							},
 						},
 					},
 				},
@@ -18526,107 +23992,67 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Service multipath target "target" already defined on server`)
+				require.ErrorContains(tt, err, `Service ceph cluster "new" already defined on server "one"`)
 			},
 			assertLog: log.Empty,
 		},
 		{
-			name:      "error - client.UpdateOSService - revert client.UpdateOSService",
-			nameArg:   "one",
-			targetArg: "target",
+			name:               "error - client.UpdateOSService - revert client.UpdateOSService",
+			cephClusterNameArg: "new",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
-				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: false, // false on purpose
-							WWNs:    []string{},
-						},
-					},
-				},
-				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: true,
-							WWNs:    []string{},
-						},
-					},
-				},
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
+				{},
+				{},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceCeph]{
 				// First update successful.
 				{
-					Value: true,
-				},
-				// Second update error.
-				{
-					Value: true,
-					Err:   errors.New("error"),
-				},
-				// Revert of first update error.
-				{
-					Value: false,
-					Err:   boom.Error,
-				},
-			},
-			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
-				// GetByName
-				{},
-				// serverSvc.GetAllWithFilter
-				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"new": cephCluster,
 							},
 						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
+					},
+				},
+				// Second update error.
+				{
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"new": cephCluster,
 							},
 						},
 					},
+					Err: errors.New("error"),
+				},
+				// Revert of first update error.
+				{
+					Err: boom.Error,
+				},
+			},
+			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
+				// GetByName
+				{},
+				// serverSvc.GetAllWithFilter
+				{
+					Value: readyServers,
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated multipath service config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated ceph service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -18644,20 +24070,20 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
-					config, err := queue.Pop(t, &tc.clientGetOSServiceMultipath)
-					return config, err
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceCeph)
 				},
 				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
-					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
-					require.Equal(t, wantEnabled, config.(incusosapi.ServiceMultipath).Config.Enabled)
+					wantConfig, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, "ceph", name)
+					require.Equal(t, wantConfig, config)
 					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServersErr
+					return nil
 				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
@@ -18667,65 +24093,97 @@ func TestClusterService_AddStorageTargetMultipath(t *testing.T) {
 			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.AddStorageTargetMultipath(context.Background(), tc.nameArg, tc.targetArg)
+			err = clusterSvc.AddServiceCephCluster(context.Background(), "one", tc.cephClusterNameArg, cephCluster)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientGetOSServiceCeph)
 			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
+func TestClusterService_RemoveServiceCephCluster(t *testing.T) {
+	readyServers := provisioning.Servers{
+		{
+			Name:         "one",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+		{
+			Name:         "two",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+	}
+
+	cephConfig := func() incusosapi.ServiceCeph {
+		return incusosapi.ServiceCeph{
+			Config: incusosapi.ServiceCephConfig{
+				Enabled: true,
+				Clusters: map[string]incusosapi.ServiceCephCluster{
+					"remove": {FSID: "fsid-remove"},
+					"keep":   {FSID: "fsid-keep"},
+				},
+			},
+		}
+	}
+
 	tests := []struct {
-		name                        string
-		nameArg                     string
-		targetArg                   string
-		repoGetByName               *provisioning.Cluster
-		repoGetByNameErr            error
-		clientGetOSServiceMultipath []queue.Item[incusosapi.ServiceMultipath]
-		clientUpdateOSService       []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
-		serverSvcPollServersErr     error
-		serverSvcGetAllWithFilter   []queue.Item[provisioning.Servers]
+		name                      string
+		repoGetByName             *provisioning.Cluster
+		repoGetByNameErr          error
+		clientGetOSServiceCeph    []queue.Item[incusosapi.ServiceCeph]
+		clientUpdateOSService     []queue.Item[incusosapi.ServiceCeph]
+		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name:      "success",
-			nameArg:   "one",
-			targetArg: "target",
+			name: "success",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: false, // false on purpose
-							WWNs:    []string{"target", "keep"},
-						},
-					},
+					Value: cephConfig(),
 				},
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: true,
-							WWNs:    []string{"target", "keep"},
-						},
-					},
+					Value: cephConfig(),
 				},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: true,
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"keep": {FSID: "fsid-keep"},
+							},
+						},
+					},
 				},
 				{
-					Value: true,
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"keep": {FSID: "fsid-keep"},
+							},
+						},
+					},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -18733,26 +24191,7 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
@@ -18761,24 +24200,19 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 		},
 
 		{
-			name:                    "error - GetByName error",
-			nameArg:                 "one",
-			targetArg:               "target",
-			repoGetByNameErr:        boom.Error,
-			serverSvcPollServersErr: boom.Error,
+			name:             "error - GetByName error",
+			repoGetByNameErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:      "error - client.GetOSServiceMultipath",
-			nameArg:   "one",
-			targetArg: "target",
+			name: "error - client.GetOSServiceCeph",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
 					Err: boom.Error,
 				},
@@ -18788,26 +24222,7 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
@@ -18815,21 +24230,17 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 			assertLog: log.Empty,
 		},
 		{
-			name:      "error - multipath service target missing",
-			nameArg:   "one",
-			targetArg: "target",
+			name: "error - ceph cluster missing",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: true,
-							WWNs:    []string{}, // target missing
-						},
-					},
+					Value: cephConfig(),
+				},
+				{
+					Value: incusosapi.ServiceCeph{}, // ceph cluster missing
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -18837,74 +24248,57 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Service multipath target "target" does not exist on server`)
+				require.ErrorContains(tt, err, `Service ceph cluster "remove" does not exist on server "two"`)
 			},
 			assertLog: log.Empty,
 		},
 		{
-			name:      "error - client.UpdateOSService - revert client.UpdateOSService",
-			nameArg:   "one",
-			targetArg: "target",
+			name: "error - client.UpdateOSService - revert client.UpdateOSService",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceMultipath: []queue.Item[incusosapi.ServiceMultipath]{
+			clientGetOSServiceCeph: []queue.Item[incusosapi.ServiceCeph]{
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: false, // false on purpose
-							WWNs:    []string{"target"},
-						},
-					},
+					Value: cephConfig(),
 				},
 				{
-					Value: incusosapi.ServiceMultipath{
-						Config: incusosapi.ServiceMultipathConfig{
-							Enabled: true,
-							WWNs:    []string{"target"},
-						},
-					},
+					Value: cephConfig(),
 				},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceCeph]{
 				// First update successful.
 				{
-					Value: true,
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"keep": {FSID: "fsid-keep"},
+							},
+						},
+					},
 				},
 				// Second update error.
 				{
-					Value: true,
-					Err:   errors.New("error"),
+					Value: incusosapi.ServiceCeph{
+						Config: incusosapi.ServiceCephConfig{
+							Enabled: true,
+							Clusters: map[string]incusosapi.ServiceCephCluster{
+								"keep": {FSID: "fsid-keep"},
+							},
+						},
+					},
+					Err: errors.New("error"),
 				},
 				// Revert of first update error.
 				{
-					Value: false,
+					Value: cephConfig(),
 					Err:   boom.Error,
 				},
 			},
@@ -18913,31 +24307,12 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated multipath service config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated ceph service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -18955,20 +24330,20 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				GetOSServiceMultipathFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceMultipath, error) {
-					config, err := queue.Pop(t, &tc.clientGetOSServiceMultipath)
-					return config, err
+				GetOSServiceCephFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceCeph, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceCeph)
 				},
 				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
-					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
-					require.Equal(t, wantEnabled, config.(incusosapi.ServiceMultipath).Config.Enabled)
+					wantConfig, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, "ceph", name)
+					require.Equal(t, wantConfig, config)
 					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServersErr
+					return nil
 				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
@@ -18978,69 +24353,77 @@ func TestClusterService_RemoveStorageTargetMultipath(t *testing.T) {
 			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.RemoveStorageTargetMultipath(context.Background(), tc.nameArg, tc.targetArg)
+			err = clusterSvc.RemoveServiceCephCluster(context.Background(), "one", "remove")
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientGetOSServiceMultipath)
+			require.Empty(t, tc.clientGetOSServiceCeph)
 			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
-}
+}
+
+func TestClusterService_UpdateServiceOVN(t *testing.T) {
+	readyServers := provisioning.Servers{
+		{
+			Name:         "one",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+		{
+			Name:         "two",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+	}
 
-func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 	tests := []struct {
 		name                      string
-		nameArg                   string
-		targetArg                 incusosapi.ServiceNVMETarget
+		ovnConfigArg              incusosapi.ServiceOVNConfig
 		repoGetByName             *provisioning.Cluster
 		repoGetByNameErr          error
-		clientGetOSServiceNVM     []queue.Item[incusosapi.ServiceNVME]
-		clientUpdateOSService     []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
-		serverSvcPollServersErr   error
+		clientGetOSServiceOVN     []queue.Item[incusosapi.ServiceOVN]
+		clientUpdateOSService     []queue.Item[incusosapi.ServiceOVN]
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
 
 		assertErr require.ErrorAssertionFunc
 		assertLog log.MatcherFunc
 	}{
 		{
-			name:    "success",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "success - member tunnel address kept",
+			ovnConfigArg: incusosapi.ServiceOVNConfig{
+				Enabled:        true,
+				Database:       "ssl:[fd00::1]:6641",
+				TunnelProtocol: "geneve",
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
 				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: false, // false on purpose
-							Targets: []incusosapi.ServiceNVMETarget{},
-						},
-					},
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{TunnelAddress: "10.0.0.1"}},
 				},
 				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{},
-						},
-					},
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{TunnelAddress: "10.0.0.2"}},
 				},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceOVN]{
 				{
-					Value: true,
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve", TunnelAddress: "10.0.0.1"}},
 				},
 				{
-					Value: true,
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, Database: "ssl:[fd00::1]:6641", TunnelProtocol: "geneve", TunnelAddress: "10.0.0.2"}},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19048,62 +24431,37 @@ func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
-
-		{
-			name:    "error - GetByName error",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
-			},
-			repoGetByNameErr:        boom.Error,
-			serverSvcPollServersErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
 		{
-			name:    "error - client.GetOSServiceNVME",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "success - wildcard tunnel address",
+			ovnConfigArg: incusosapi.ServiceOVNConfig{
+				Enabled:       true,
+				TunnelAddress: "::",
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
 				{
-					Err: boom.Error,
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{TunnelAddress: "10.0.0.1"}},
+				},
+				{
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{TunnelAddress: "10.0.0.2"}},
+				},
+			},
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceOVN]{
+				{
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "::"}},
+				},
+				{
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true, TunnelAddress: "::"}},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19111,58 +24469,43 @@ func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
+
 		{
-			name:    "error - nvme service target already present",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "error - member dependent tunnel address",
+			ovnConfigArg: incusosapi.ServiceOVNConfig{
+				Enabled:       true,
+				TunnelAddress: "10.0.0.1",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
 			},
+			assertLog: log.Empty,
+		},
+		{
+			name:             "error - GetByName error",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - client.GetOSServiceOVN",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
 				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "target",
-									Address:   "address",
-									Port:      1234,
-								},
-							},
-						},
-					},
+					Err: boom.Error,
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19170,79 +24513,39 @@ func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Service nvme transport "target" (address:1234) already defined on server`)
-			},
+			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:    "error - client.UpdateOSService - revert client.UpdateOSService",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "error - client.UpdateOSService - revert client.UpdateOSService",
+			ovnConfigArg: incusosapi.ServiceOVNConfig{
+				Enabled: true,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVM: []queue.Item[incusosapi.ServiceNVME]{
-				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: false, // false on purpose
-							Targets: []incusosapi.ServiceNVMETarget{},
-						},
-					},
-				},
-				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{},
-						},
-					},
-				},
+			clientGetOSServiceOVN: []queue.Item[incusosapi.ServiceOVN]{
+				{},
+				{},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceOVN]{
 				// First update successful.
 				{
-					Value: true,
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true}},
 				},
 				// Second update error.
 				{
-					Value: true,
+					Value: incusosapi.ServiceOVN{Config: incusosapi.ServiceOVNConfig{Enabled: true}},
 					Err:   errors.New("error"),
 				},
 				// Revert of first update error.
 				{
-					Value: false,
-					Err:   boom.Error,
+					Err: boom.Error,
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19250,31 +24553,12 @@ func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated nvme service config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated ovn service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -19292,20 +24576,20 @@ func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
-					config, err := queue.Pop(t, &tc.clientGetOSServiceNVM)
-					return config, err
+				GetOSServiceOVNFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceOVN, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceOVN)
 				},
 				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
-					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
-					require.Equal(t, wantEnabled, config.(incusosapi.ServiceNVME).Config.Enabled)
+					wantConfig, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, "ovn", name)
+					require.Equal(t, wantConfig, config)
 					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServersErr
+					return nil
 				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
@@ -19315,91 +24599,75 @@ func TestClusterService_AddStorageTargetNVME(t *testing.T) {
 			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.AddStorageTargetNVME(context.Background(), tc.nameArg, tc.targetArg)
+			err = clusterSvc.UpdateServiceOVN(context.Background(), "one", tc.ovnConfigArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientGetOSServiceNVM)
+			require.Empty(t, tc.clientGetOSServiceOVN)
 			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
 }
 
-func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
+func TestClusterService_UpdateServiceLinstor(t *testing.T) {
+	readyServers := provisioning.Servers{
+		{
+			Name:         "one",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+		{
+			Name:         "two",
+			Cluster:      ptr.To("one"),
+			Status:       api.ServerStatusReady,
+			StatusDetail: api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				InMaintenance: ptr.To(api.NotInMaintenance),
+			},
+		},
+	}
+
 	tests := []struct {
 		name                      string
-		nameArg                   string
-		targetArg                 incusosapi.ServiceNVMETarget
+		linstorConfigArg          incusosapi.ServiceLinstorConfig
 		repoGetByName             *provisioning.Cluster
 		repoGetByNameErr          error
-		clientGetOSServiceNVME    []queue.Item[incusosapi.ServiceNVME]
-		clientUpdateOSService     []queue.Item[bool] // bool is the expected value for the enabled flag of the service.
-		serverSvcPollServersErr   error
+		clientGetOSServiceLinstor []queue.Item[incusosapi.ServiceLinstor]
+		clientUpdateOSService     []queue.Item[incusosapi.ServiceLinstor]
 		serverSvcGetAllWithFilter []queue.Item[provisioning.Servers]
 
 		assertErr require.ErrorAssertionFunc
-		assertLog log.MatcherFunc
-	}{
-		{
-			name:    "success",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+		assertLog log.MatcherFunc
+	}{
+		{
+			name: "success - member listen address kept",
+			linstorConfigArg: incusosapi.ServiceLinstorConfig{
+				Enabled: true,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
 				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: false, // false on purpose
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "target",
-									Address:   "address",
-									Port:      1234,
-								},
-								{
-									Transport: "keep",
-									Address:   "keep",
-									Port:      1234,
-								},
-							},
-						},
-					},
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{ListenAddress: "10.0.0.1:3366"}},
 				},
 				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "target",
-									Address:   "address",
-									Port:      1234,
-								},
-								{
-									Transport: "keep",
-									Address:   "keep",
-									Port:      1234,
-								},
-							},
-						},
-					},
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{ListenAddress: "10.0.0.2:3366"}},
 				},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceLinstor]{
 				{
-					Value: true,
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.1:3366"}},
 				},
 				{
-					Value: true,
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "10.0.0.2:3366"}},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19407,62 +24675,33 @@ func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
-
-		{
-			name:    "error - GetByName error",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
-			},
-			repoGetByNameErr:        boom.Error,
-			serverSvcPollServersErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-			assertLog: log.Empty,
-		},
 		{
-			name:    "error - client.GetOSServiceNVME",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "success - wildcard listen address",
+			linstorConfigArg: incusosapi.ServiceLinstorConfig{
+				Enabled:       true,
+				ListenAddress: "[::]:3366",
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{},
+				{},
+			},
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceLinstor]{
 				{
-					Err: boom.Error,
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}},
+				},
+				{
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true, ListenAddress: "[::]:3366"}},
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19470,52 +24709,58 @@ func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
 			assertLog: log.Empty,
 		},
+
 		{
-			name:    "error - nvme service target missing",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "error - invalid listen address",
+			linstorConfigArg: incusosapi.ServiceLinstorConfig{
+				Enabled:       true,
+				ListenAddress: "::",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, "Invalid listen address")
+			},
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - member dependent listen address",
+			linstorConfigArg: incusosapi.ServiceLinstorConfig{
+				Enabled:       true,
+				ListenAddress: "10.0.0.1:3366",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, "is member dependent")
 			},
+			assertLog: log.Empty,
+		},
+		{
+			name:             "error - GetByName error",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.Empty,
+		},
+		{
+			name: "error - client.GetOSServiceLinstor",
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
 				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{}, // target missing
-						},
-					},
+					Err: boom.Error,
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19523,91 +24768,39 @@ func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
-			assertErr: func(tt require.TestingT, err error, a ...any) {
-				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted)
-				require.ErrorContains(tt, err, `Service nvme transport "target" (address:1234) does not exist on server`)
-			},
+			assertErr: boom.ErrorIs,
 			assertLog: log.Empty,
 		},
 		{
-			name:    "error - client.UpdateOSService - revert client.UpdateOSService",
-			nameArg: "one",
-			targetArg: incusosapi.ServiceNVMETarget{
-				Transport: "target",
-				Address:   "address",
-				Port:      1234,
+			name: "error - client.UpdateOSService - revert client.UpdateOSService",
+			linstorConfigArg: incusosapi.ServiceLinstorConfig{
+				Enabled: true,
 			},
 			repoGetByName: &provisioning.Cluster{
 				Name:   "one",
 				Status: api.ClusterStatusReady,
 			},
-			clientGetOSServiceNVME: []queue.Item[incusosapi.ServiceNVME]{
-				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: false, // false on purpose
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "target",
-									Address:   "address",
-									Port:      1234,
-								},
-							},
-						},
-					},
-				},
-				{
-					Value: incusosapi.ServiceNVME{
-						Config: incusosapi.ServiceNVMEConfig{
-							Enabled: true,
-							Targets: []incusosapi.ServiceNVMETarget{
-								{
-									Transport: "target",
-									Address:   "address",
-									Port:      1234,
-								},
-							},
-						},
-					},
-				},
+			clientGetOSServiceLinstor: []queue.Item[incusosapi.ServiceLinstor]{
+				{},
+				{},
 			},
-			clientUpdateOSService: []queue.Item[bool]{
+			clientUpdateOSService: []queue.Item[incusosapi.ServiceLinstor]{
 				// First update successful.
 				{
-					Value: true,
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true}},
 				},
 				// Second update error.
 				{
-					Value: true,
+					Value: incusosapi.ServiceLinstor{Config: incusosapi.ServiceLinstorConfig{Enabled: true}},
 					Err:   errors.New("error"),
 				},
 				// Revert of first update error.
 				{
-					Value: false,
-					Err:   boom.Error,
+					Err: boom.Error,
 				},
 			},
 			serverSvcGetAllWithFilter: []queue.Item[provisioning.Servers]{
@@ -19615,31 +24808,12 @@ func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 				{},
 				// serverSvc.GetAllWithFilter
 				{
-					Value: provisioning.Servers{
-						{
-							Name:         "one",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-						{
-							Name:         "two",
-							Cluster:      ptr.To("one"),
-							Status:       api.ServerStatusReady,
-							StatusDetail: api.ServerStatusDetailNone,
-							VersionData: api.ServerVersionData{
-								InMaintenance: ptr.To(api.NotInMaintenance),
-							},
-						},
-					},
+					Value: readyServers,
 				},
 			},
 
 			assertErr: require.Error,
-			assertLog: log.Match("Failed to revert previously updated nvme service config.*" + boom.Error.Error()),
+			assertLog: log.Match("Failed to revert previously updated linstor service config.*" + boom.Error.Error()),
 		},
 	}
 
@@ -19657,20 +24831,20 @@ func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 			}
 
 			client := &adapterMock.ClusterClientPortMock{
-				GetOSServiceNVMEFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceNVME, error) {
-					config, err := queue.Pop(t, &tc.clientGetOSServiceNVME)
-					return config, err
+				GetOSServiceLinstorFunc: func(ctx context.Context, server provisioning.Server) (incusosapi.ServiceLinstor, error) {
+					return queue.Pop(t, &tc.clientGetOSServiceLinstor)
 				},
 				UpdateOSServiceFunc: func(ctx context.Context, server provisioning.Server, name string, config any) error {
-					wantEnabled, err := queue.Pop(t, &tc.clientUpdateOSService)
-					require.Equal(t, wantEnabled, config.(incusosapi.ServiceNVME).Config.Enabled)
+					wantConfig, err := queue.Pop(t, &tc.clientUpdateOSService)
+					require.Equal(t, "linstor", name)
+					require.Equal(t, wantConfig, config)
 					return err
 				},
 			}
 
 			serverSvc := &serviceMock.ServerServiceMock{
 				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
-					return tc.serverSvcPollServersErr
+					return nil
 				},
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 					return queue.Pop(t, &tc.serverSvcGetAllWithFilter)
@@ -19680,13 +24854,13 @@ func TestClusterService_RemoveStorageTargetNVME(t *testing.T) {
 			clusterSvc := provisioningCluster.New(repo, nil, client, serverSvc, nil, nil, nil, nil)
 
 			// Run test
-			err = clusterSvc.RemoveStorageTargetNVME(context.Background(), tc.nameArg, tc.targetArg)
+			err = clusterSvc.UpdateServiceLinstor(context.Background(), "one", tc.linstorConfigArg)
 
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
 			require.Empty(t, tc.serverSvcGetAllWithFilter)
-			require.Empty(t, tc.clientGetOSServiceNVME)
+			require.Empty(t, tc.clientGetOSServiceLinstor)
 			require.Empty(t, tc.clientUpdateOSService)
 		})
 	}
@@ -33,6 +33,7 @@ import (
 	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
 	"github.com/FuturFusion/operations-center/internal/util/testing/queue"
 	"github.com/FuturFusion/operations-center/internal/util/testing/uuidgen"
+	"github.com/FuturFusion/operations-center/internal/warning"
 	"github.com/FuturFusion/operations-center/shared/api"
 )
 
@@ -267,6 +268,7 @@ func TestClusterService_ClusterUpdateControlLoopSingleNodeCluster(t *testing.T)
 		clusterDB, nil, nil, serverSvc, nil, nil, nil, nil,
 		provisioningCluster.WithPendingUpdateRecheckInterval(controlLoopInterval),
 		provisioningCluster.WithWarningEmitter(provisioning.LogWarningService{}),
+		provisioningCluster.WithOperationRepo(sqlite.NewClusterOperation(tx)),
 	)
 
 	serverSvc.SetClusterService(clusterSvc)
@@ -336,6 +338,29 @@ func TestClusterService_ClusterUpdateControlLoopSingleNodeCluster(t *testing.T)
 		`[8/9] restoring server "one"`,
 		`[9/9] post restore server "one"`,
 	}, clusterUpdateStatesFromLog(t, logBuf.String()))
+
+	// The operation is recorded in the history.
+	operations, err := clusterSvc.GetOperationAll(ctx, "clusterA")
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	require.Equal(t, api.ClusterUpdateInProgressApplyUpdateWithReboot, operations[0].Type)
+	require.Equal(t, api.ClusterOperationStatusSucceeded, operations[0].Status)
+	require.False(t, operations[0].FinishedAt.IsZero())
+
+	var actions []api.ClusterOperationEventAction
+	for _, event := range operations[0].Events {
+		require.Equal(t, "one", event.Server)
+		require.False(t, event.FinishedAt.IsZero())
+		actions = append(actions, event.Action)
+	}
+
+	require.Equal(t, []api.ClusterOperationEventAction{
+		api.ClusterOperationEventActionUpdate,
+		api.ClusterOperationEventActionEvacuate,
+		api.ClusterOperationEventActionReboot,
+		api.ClusterOperationEventActionRestore,
+		api.ClusterOperationEventActionDone,
+	}, actions)
 }
 
 func TestClusterService_ClusterUpdateControlLoopMultiNodeCluster(t *testing.T) {
@@ -1602,3 +1627,562 @@ func TestClusterService_ClusterUpdateControlLoop(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterService_ClusterUpdateControlLoopMaintenanceWindow(t *testing.T) {
+	// Saturday, 12:00 UTC.
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	openWindow := []api.ClusterConfigMaintenanceWindow{
+		{
+			Weekdays:  []string{"saturday"},
+			StartTime: "10:00",
+			EndTime:   "14:00",
+		},
+	}
+
+	closedWindow := []api.ClusterConfigMaintenanceWindow{
+		{
+			Weekdays:  []string{"sunday"},
+			StartTime: "10:00",
+			EndTime:   "14:00",
+		},
+	}
+
+	server := func(needsUpdate bool, inMaintenance api.InMaintenanceState) provisioning.Server {
+		return provisioning.Server{
+			Name:          "server",
+			Cluster:       ptr.To("one"),
+			ConnectionURL: "https://server:8443",
+			Status:        api.ServerStatusReady,
+			StatusDetail:  api.ServerStatusDetailNone,
+			VersionData: api.ServerVersionData{
+				NeedsUpdate:   ptr.To(needsUpdate),
+				NeedsReboot:   ptr.To(true),
+				InMaintenance: ptr.To(inMaintenance),
+				Applications: []api.ApplicationVersionData{
+					{
+						Name: "incus",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		inProgress api.ClusterUpdateInProgress
+		windows    []api.ClusterConfigMaintenanceWindow
+		server     provisioning.Server
+
+		wantUpdateCalls   int
+		wantEvacuateCalls int
+		wantRebootCalls   int
+	}{
+		{
+			name:       "update - within maintenance window",
+			inProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			windows:    openWindow,
+			server:     server(true, api.NotInMaintenance),
+
+			wantUpdateCalls: 1,
+		},
+		{
+			name:       "update - outside maintenance window",
+			inProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			windows:    closedWindow,
+			server:     server(true, api.NotInMaintenance),
+		},
+		{
+			name:       "restart - within maintenance window",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			windows:    openWindow,
+			server:     server(false, api.NotInMaintenance),
+
+			wantEvacuateCalls: 1,
+		},
+		{
+			name:       "restart - outside maintenance window - evacuation is not started",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			windows:    closedWindow,
+			server:     server(false, api.NotInMaintenance),
+		},
+		{
+			name:       "restart - outside maintenance window - evacuated server is still rebooted",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			windows:    closedWindow,
+			server:     server(false, api.InMaintenanceEvacuated),
+
+			wantRebootCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			cluster := provisioning.Cluster{
+				Name: "one",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: tc.windows,
+				},
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: tc.inProgress,
+					},
+				},
+			}
+
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return provisioning.Clusters{cluster}, nil
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return &cluster, nil
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					return nil
+				},
+			}
+
+			var updateCalls, evacuateCalls, rebootCalls int
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return nil
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return provisioning.Servers{tc.server}, nil
+				},
+				UpdateSystemByNameFunc: func(ctx context.Context, name string, updateRequest api.ServerUpdatePost, force bool) error {
+					updateCalls++
+					return nil
+				},
+				EvacuateSystemByNameFunc: func(ctx context.Context, name string, clusterUpdate bool, force bool) error {
+					evacuateCalls++
+					return nil
+				},
+				RebootSystemByNameFunc: func(ctx context.Context, name string, force bool) error {
+					rebootCalls++
+					return nil
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil,
+				provisioningCluster.WithNow(func() time.Time {
+					return now
+				}),
+			)
+
+			// Run test
+			err := clusterSvc.ClusterUpdateControlLoop(t.Context(), nil)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, tc.wantUpdateCalls, updateCalls)
+			require.Equal(t, tc.wantEvacuateCalls, evacuateCalls)
+			require.Equal(t, tc.wantRebootCalls, rebootCalls)
+		})
+	}
+}
+
+func TestClusterService_ClusterUpdateControlLoopMaxUnavailable(t *testing.T) {
+	server := func(name string, needsUpdate bool, statusDetail api.ServerStatusDetail, inMaintenance api.InMaintenanceState) provisioning.Server {
+		return provisioning.Server{
+			Name:          name,
+			Cluster:       ptr.To("one"),
+			ConnectionURL: "https://" + name + ":8443",
+			Status:        api.ServerStatusReady,
+			StatusDetail:  statusDetail,
+			VersionData: api.ServerVersionData{
+				NeedsUpdate:   ptr.To(needsUpdate),
+				NeedsReboot:   ptr.To(true),
+				InMaintenance: ptr.To(inMaintenance),
+				Applications: []api.ApplicationVersionData{
+					{
+						Name: "incus",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		inProgress     api.ClusterUpdateInProgress
+		maxUnavailable string
+		servers        provisioning.Servers
+
+		assertErr     require.ErrorAssertionFunc
+		wantUpdated   []string
+		wantEvacuated []string
+		wantRebooted  []string
+	}{
+		{
+			name:           "update - max unavailable count",
+			inProgress:     api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			maxUnavailable: "2",
+			servers: provisioning.Servers{
+				server("server1", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server3", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr:   require.NoError,
+			wantUpdated: []string{"server1", "server2"},
+		},
+		{
+			name:           "update - one server already updating",
+			inProgress:     api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			maxUnavailable: "2",
+			servers: provisioning.Servers{
+				server("server1", true, api.ServerStatusDetailReadyUpdatingOS, api.NotInMaintenance),
+				server("server2", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server3", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr:   require.NoError,
+			wantUpdated: []string{"server2"},
+		},
+		{
+			name:           "update - max unavailable servers already updating",
+			inProgress:     api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			maxUnavailable: "2",
+			servers: provisioning.Servers{
+				server("server1", true, api.ServerStatusDetailReadyUpdatingOS, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailReadyUpdatingOS, api.NotInMaintenance),
+				server("server3", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name:           "restart - max unavailable count",
+			inProgress:     api.ClusterUpdateInProgressRollingRestart,
+			maxUnavailable: "2",
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server3", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr:     require.NoError,
+			wantEvacuated: []string{"server1", "server2"},
+		},
+		{
+			name:           "restart - max unavailable percentage",
+			inProgress:     api.ClusterUpdateInProgressRollingRestart,
+			maxUnavailable: "100%",
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server3", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr:     require.NoError,
+			wantEvacuated: []string{"server1", "server2", "server3"},
+		},
+		{
+			name:           "restart - servers in different steps",
+			inProgress:     api.ClusterUpdateInProgressRollingRestart,
+			maxUnavailable: "2",
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailNone, api.InMaintenanceEvacuated),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server3", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr:     require.NoError,
+			wantEvacuated: []string{"server2"},
+			wantRebooted:  []string{"server1"},
+		},
+		{
+			name:           "restart - error - more than max unavailable servers in maintenance",
+			inProgress:     api.ClusterUpdateInProgressRollingRestart,
+			maxUnavailable: "2",
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailNone, api.InMaintenanceEvacuating),
+				server("server2", false, api.ServerStatusDetailNone, api.InMaintenanceEvacuating),
+				server("server3", false, api.ServerStatusDetailNone, api.InMaintenanceEvacuating),
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorContains(tt, err, `Rolling update blocked, out of order update for server "server3"`)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			cluster := provisioning.Cluster{
+				Name: "one",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: tc.maxUnavailable,
+					},
+				},
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress: tc.inProgress,
+					},
+				},
+			}
+
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return provisioning.Clusters{cluster}, nil
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return &cluster, nil
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					return nil
+				},
+			}
+
+			var updated, evacuated, rebooted []string
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return nil
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.servers, nil
+				},
+				UpdateSystemByNameFunc: func(ctx context.Context, name string, updateRequest api.ServerUpdatePost, force bool) error {
+					updated = append(updated, name)
+					return nil
+				},
+				EvacuateSystemByNameFunc: func(ctx context.Context, name string, clusterUpdate bool, force bool) error {
+					evacuated = append(evacuated, name)
+					return nil
+				},
+				RebootSystemByNameFunc: func(ctx context.Context, name string, force bool) error {
+					rebooted = append(rebooted, name)
+					return nil
+				},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil)
+
+			// Run test
+			err := clusterSvc.ClusterUpdateControlLoop(t.Context(), nil)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantUpdated, updated)
+			require.Equal(t, tc.wantEvacuated, evacuated)
+			require.Equal(t, tc.wantRebooted, rebooted)
+		})
+	}
+}
+
+func TestClusterService_ClusterUpdateControlLoopHealthGate(t *testing.T) {
+	server := func(name string, needsUpdate bool, statusDetail api.ServerStatusDetail, inMaintenance api.InMaintenanceState) provisioning.Server {
+		return provisioning.Server{
+			Name:          name,
+			Cluster:       ptr.To("one"),
+			ConnectionURL: "https://" + name + ":8443",
+			Status:        api.ServerStatusReady,
+			StatusDetail:  statusDetail,
+			VersionData: api.ServerVersionData{
+				NeedsUpdate:   ptr.To(needsUpdate),
+				NeedsReboot:   ptr.To(true),
+				InMaintenance: ptr.To(inMaintenance),
+				Applications: []api.ApplicationVersionData{
+					{
+						Name: "incus",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		inProgress      api.ClusterUpdateInProgress
+		healthGateError string
+		servers         provisioning.Servers
+		healthGateErr   error
+
+		assertErr           require.ErrorAssertionFunc
+		wantHealthGateSteps []provisioning.ClusterUpdateHealthGateStep
+		wantUpdated         []string
+		wantEvacuated       []string
+		wantPostRestoreDone []string
+		wantHealthGateError *string
+		wantWarning         bool
+	}{
+		{
+			name:       "update - health gate passed",
+			inProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			servers: provisioning.Servers{
+				server("server1", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+
+			assertErr:           require.NoError,
+			wantHealthGateSteps: []provisioning.ClusterUpdateHealthGateStep{provisioning.ClusterUpdateHealthGatePreStep},
+			wantUpdated:         []string{"server1"},
+		},
+		{
+			name:       "update - health gate failed",
+			inProgress: api.ClusterUpdateInProgressApplyUpdateWithReboot,
+			servers: provisioning.Servers{
+				server("server1", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", true, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+			healthGateErr: boom.Error,
+
+			assertErr:           require.NoError,
+			wantHealthGateSteps: []provisioning.ClusterUpdateHealthGateStep{provisioning.ClusterUpdateHealthGatePreStep},
+			wantHealthGateError: ptr.To(`Health gate failed: pre_step for server "server1": boom!`),
+			wantWarning:         true,
+		},
+		{
+			name:       "restart - health gate failed before evacuation",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+			healthGateErr: boom.Error,
+
+			assertErr:           require.NoError,
+			wantHealthGateSteps: []provisioning.ClusterUpdateHealthGateStep{provisioning.ClusterUpdateHealthGatePreStep},
+			wantHealthGateError: ptr.To(`Health gate failed: pre_step for server "server1": boom!`),
+			wantWarning:         true,
+		},
+		{
+			name:       "restart - health gate failed after restore",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailReadyRestoring, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+			healthGateErr: boom.Error,
+
+			assertErr:           require.NoError,
+			wantHealthGateSteps: []provisioning.ClusterUpdateHealthGateStep{provisioning.ClusterUpdateHealthGatePostStep},
+			wantHealthGateError: ptr.To(`Health gate failed: post_step for server "server1": boom!`),
+			wantWarning:         true,
+		},
+		{
+			name:       "restart - health gate failed with unchanged error",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+			healthGateError: `Health gate failed: pre_step for server "server1": boom!`,
+			healthGateErr:   boom.Error,
+
+			assertErr:           require.NoError,
+			wantHealthGateSteps: []provisioning.ClusterUpdateHealthGateStep{provisioning.ClusterUpdateHealthGatePreStep},
+			wantWarning:         true,
+		},
+		{
+			name:       "restart - health gate passed after failure",
+			inProgress: api.ClusterUpdateInProgressRollingRestart,
+			servers: provisioning.Servers{
+				server("server1", false, api.ServerStatusDetailReadyRestoring, api.NotInMaintenance),
+				server("server2", false, api.ServerStatusDetailNone, api.NotInMaintenance),
+			},
+			healthGateError: `Health gate failed: post_step for server "server1": boom!`,
+
+			assertErr:           require.NoError,
+			wantHealthGateSteps: []provisioning.ClusterUpdateHealthGateStep{provisioning.ClusterUpdateHealthGatePostStep},
+			wantPostRestoreDone: []string{"server1"},
+			wantHealthGateError: ptr.To(""),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			cluster := provisioning.Cluster{
+				Name: "one",
+				UpdateStatus: api.ClusterUpdateStatus{
+					InProgressStatus: api.ClusterUpdateInProgressStatus{
+						InProgress:      tc.inProgress,
+						HealthGateError: tc.healthGateError,
+					},
+				},
+			}
+
+			var gotHealthGateError *string
+
+			repo := &mock.ClusterRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
+					return provisioning.Clusters{cluster}, nil
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return &cluster, nil
+				},
+				UpdateFunc: func(ctx context.Context, cluster provisioning.Cluster) error {
+					gotHealthGateError = ptr.To(cluster.UpdateStatus.InProgressStatus.HealthGateError)
+					return nil
+				},
+			}
+
+			var updated, evacuated, postRestoreDone []string
+
+			serverSvc := &serviceMock.ServerServiceMock{
+				PollServersFunc: func(ctx context.Context, serverFilter provisioning.ServerFilter, updateServerConfiguration bool) error {
+					return nil
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.servers, nil
+				},
+				UpdateSystemByNameFunc: func(ctx context.Context, name string, updateRequest api.ServerUpdatePost, force bool) error {
+					updated = append(updated, name)
+					return nil
+				},
+				EvacuateSystemByNameFunc: func(ctx context.Context, name string, clusterUpdate bool, force bool) error {
+					evacuated = append(evacuated, name)
+					return nil
+				},
+				PostRestoreSystemDoneByNameFunc: func(ctx context.Context, name string) error {
+					postRestoreDone = append(postRestoreDone, name)
+					return nil
+				},
+			}
+
+			var healthGateSteps []provisioning.ClusterUpdateHealthGateStep
+
+			scriptlet := &adapterMock.ClusterScriptletPortMock{
+				ClusterUpdateHealthGateRunFunc: func(ctx context.Context, step provisioning.ClusterUpdateHealthGateStep, cluster provisioning.Cluster, server provisioning.Server) error {
+					require.Equal(t, []string{"server1", "server2"}, cluster.ServerNames)
+					healthGateSteps = append(healthGateSteps, step)
+					return tc.healthGateErr
+				},
+			}
+
+			var gotWarning bool
+
+			warningSvc := &adapterMock.WarningServicePortMock{
+				EmitFunc: func(ctx context.Context, w warning.Warning) {
+					require.Equal(t, api.WarningTypeClusterUpdateHealthGateFailed, w.Type)
+					gotWarning = true
+				},
+				RemoveStaleFunc: func(ctx context.Context, scope api.WarningScope, newWarnings warning.Warnings) {},
+			}
+
+			clusterSvc := provisioningCluster.New(repo, nil, nil, serverSvc, nil, nil, nil, nil,
+				provisioningCluster.WithScriptlet(scriptlet),
+				provisioningCluster.WithWarningEmitter(warningSvc),
+			)
+
+			// Run test
+			err := clusterSvc.ClusterUpdateControlLoop(t.Context(), nil)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantHealthGateSteps, healthGateSteps)
+			require.Equal(t, tc.wantUpdated, updated)
+			require.Equal(t, tc.wantEvacuated, evacuated)
+			require.Equal(t, tc.wantPostRestoreDone, postRestoreDone)
+			require.Equal(t, tc.wantHealthGateError, gotHealthGateError)
+			require.Equal(t, tc.wantWarning, gotWarning)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+)
+
+// applySiteDefaults ensures, the site of the cluster exists and applies the
+// default channel of the site to the cluster, if the cluster does not define
+// a channel on its own.
+func (s *clusterService) applySiteDefaults(ctx context.Context, cluster *provisioning.Cluster) error {
+	if cluster.Site != nil && *cluster.Site == "" {
+		cluster.Site = nil
+	}
+
+	if cluster.Site == nil || s.siteSvc == nil {
+		return nil
+	}
+
+	site, err := s.siteSvc.GetByName(ctx, *cluster.Site)
+	if err != nil {
+		return fmt.Errorf("Failed to get site %q: %w", *cluster.Site, err)
+	}
+
+	if cluster.Channel == "" {
+		cluster.Channel = site.Channel
+	}
+
+	return nil
+}
+
+// inMaintenanceWindow returns true, if t is within the maintenance windows of
+// the cluster. A cluster without maintenance windows on its own inherits the
+// maintenance windows of its site.
+func (s *clusterService) inMaintenanceWindow(ctx context.Context, cluster provisioning.Cluster, t time.Time) bool {
+	if len(cluster.Config.MaintenanceWindows) > 0 || cluster.Site == nil || s.siteSvc == nil {
+		return cluster.InMaintenanceWindow(t)
+	}
+
+	site, err := s.siteSvc.GetByName(ctx, *cluster.Site)
+	if err != nil {
+		// Without the site, it is unknown, if the cluster is within its
+		// maintenance window, so the cluster is considered to be outside of it.
+		slog.WarnContext(ctx, "Failed to get site of cluster for maintenance window check", slog.String("cluster", cluster.Name), slog.String("site", *cluster.Site), logger.Err(err))
+		return false
+	}
+
+	return site.InMaintenanceWindow(t)
+}
+
+// hasMaintenanceWindows returns true, if maintenance windows apply to the
+// cluster, either defined by the cluster itself or inherited from its site.
+func (s *clusterService) hasMaintenanceWindows(ctx context.Context, cluster provisioning.Cluster) bool {
+	if len(cluster.Config.MaintenanceWindows) > 0 {
+		return true
+	}
+
+	if cluster.Site == nil || s.siteSvc == nil {
+		return false
+	}
+
+	site, err := s.siteSvc.GetByName(ctx, *cluster.Site)
+	if err != nil {
+		// Consistent with inMaintenanceWindow, a cluster with an unknown site is
+		// considered to be restricted to maintenance windows.
+		slog.WarnContext(ctx, "Failed to get site of cluster for maintenance window check", slog.String("cluster", cluster.Name), slog.String("site", *cluster.Site), logger.Err(err))
+		return true
+	}
+
+	return len(site.Config.MaintenanceWindows) > 0
+}
@@ -0,0 +1,764 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	incusosapi "github.com/lxc/incus-os/incus-osd/api"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// clusterSpecStep is a single change of a cluster spec plan together with the
+// function, which applies the change.
+type clusterSpecStep struct {
+	change api.ClusterSpecChange
+
+	// apply performs the change. It is nil for changes, which are applied
+	// together with a preceding change, e.g. all the changed fields of the
+	// cluster record are applied with a single update.
+	apply func(ctx context.Context) error
+}
+
+// PlanClusterSpec returns the changes, which are required to reconcile the
+// live state of the cluster with the given spec, without applying them.
+func (s *clusterService) PlanClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+	steps, err := s.clusterSpecSteps(ctx, spec)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	return clusterSpecPlan(spec.Name, steps), nil
+}
+
+// ApplyClusterSpec reconciles the live state of the cluster with the given
+// spec through the existing operations of the cluster service (e.g.
+// AddServers, AddStorageTargetISCSI or UpdateSystemKernel) and returns the
+// applied changes.
+//
+// The changes are applied one after the other. If a change fails, the
+// remaining changes are not applied. Since the plan is always calculated
+// against the live state, applying the spec again continues with the changes,
+// which are still missing.
+func (s *clusterService) ApplyClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+	steps, err := s.clusterSpecSteps(ctx, spec)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	err = applyClusterSpecSteps(ctx, spec.Name, steps)
+	if err != nil {
+		return api.ClusterSpecPlan{}, err
+	}
+
+	// The live state of a new cluster is only known after it has been created,
+	// so the remaining parts of the spec (e.g. the storage targets) are
+	// reconciled in a second pass.
+	if len(steps) == 1 && steps[0].change.Action == api.ClusterSpecChangeActionCreateCluster {
+		createdSteps, err := s.clusterSpecSteps(ctx, spec)
+		if err != nil {
+			return api.ClusterSpecPlan{}, err
+		}
+
+		err = applyClusterSpecSteps(ctx, spec.Name, createdSteps)
+		if err != nil {
+			return api.ClusterSpecPlan{}, err
+		}
+
+		steps = append(steps, createdSteps...)
+	}
+
+	return clusterSpecPlan(spec.Name, steps), nil
+}
+
+func applyClusterSpecSteps(ctx context.Context, name string, steps []clusterSpecStep) error {
+	for _, step := range steps {
+		if step.apply == nil {
+			continue
+		}
+
+		err := step.apply(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to apply %s (%s) of spec for cluster %q: %w", step.change.Action, step.change.Subject, name, err)
+		}
+	}
+
+	return nil
+}
+
+func clusterSpecPlan(name string, steps []clusterSpecStep) api.ClusterSpecPlan {
+	changes := make([]api.ClusterSpecChange, 0, len(steps))
+	for _, step := range steps {
+		changes = append(changes, step.change)
+	}
+
+	return api.ClusterSpecPlan{
+		Name:    name,
+		Changes: changes,
+	}
+}
+
+func (s *clusterService) clusterSpecSteps(ctx context.Context, spec api.ClusterSpec) ([]clusterSpecStep, error) {
+	if spec.Name == "" {
+		return nil, domain.NewValidationErrf("Invalid cluster spec, name can not be empty")
+	}
+
+	if len(spec.ServerNames) == 0 {
+		return nil, domain.NewValidationErrf("Invalid cluster spec, list of servers can not be empty")
+	}
+
+	if spec.Channel == "" {
+		spec.Channel = config.GetUpdates().ServerDefaultChannel
+	}
+
+	cluster, err := s.repo.GetByName(ctx, spec.Name)
+	if errors.Is(err, domain.ErrNotFound) {
+		spec, err = s.clusterSpecWithTemplate(ctx, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		return []clusterSpecStep{s.clusterSpecCreateStep(spec)}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get cluster %q: %w", spec.Name, err)
+	}
+
+	steps := s.clusterSpecClusterSteps(*cluster, spec)
+
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Cluster: ptr.To(spec.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get servers of cluster %q: %w", spec.Name, err)
+	}
+
+	err = s.clusterSpecCreateOnlyFieldsUnchanged(ctx, *cluster, servers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	currentServerNames := make([]string, 0, len(servers))
+	remainingServers := make(provisioning.Servers, 0, len(servers))
+	for _, server := range servers {
+		currentServerNames = append(currentServerNames, server.Name)
+
+		if slices.Contains(spec.ServerNames, server.Name) {
+			remainingServers = append(remainingServers, server)
+		}
+	}
+
+	addServerNames := missingElements(spec.ServerNames, currentServerNames)
+	slices.Sort(addServerNames)
+
+	if len(addServerNames) > 0 {
+		steps = append(steps, clusterSpecStep{
+			change: api.ClusterSpecChange{
+				Action:  api.ClusterSpecChangeActionAddServers,
+				Subject: strings.Join(addServerNames, ", "),
+			},
+			apply: func(ctx context.Context) error {
+				return s.AddServers(ctx, spec.Name, addServerNames, false, true)
+			},
+		})
+	}
+
+	removeServerNames := missingElements(currentServerNames, spec.ServerNames)
+	slices.Sort(removeServerNames)
+
+	if len(removeServerNames) > 0 {
+		steps = append(steps, clusterSpecStep{
+			change: api.ClusterSpecChange{
+				Action:  api.ClusterSpecChangeActionRemoveServers,
+				Subject: strings.Join(removeServerNames, ", "),
+			},
+			apply: func(ctx context.Context) error {
+				return s.RemoveServer(ctx, spec.Name, removeServerNames)
+			},
+		})
+	}
+
+	if !clusterSpecManagesSystemConfig(spec) {
+		return steps, nil
+	}
+
+	// The added servers get the services config copied from one of the
+	// remaining servers, so the remaining servers represent the live state of
+	// the cluster.
+	if len(remainingServers) == 0 {
+		return nil, fmt.Errorf("Cluster %q does not have any servers, which remain in the cluster and could be used to determine the live state: %w", spec.Name, domain.ErrOperationNotPermitted)
+	}
+
+	systemSteps, err := s.clusterSpecSystemSteps(ctx, remainingServers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(steps, systemSteps...), nil
+}
+
+// clusterSpecWithTemplate renders the cluster template of the spec into the
+// services config and the application seed config. This is only done for new
+// clusters, existing clusters are never rendered again with a later revision
+// of the cluster template.
+func (s *clusterService) clusterSpecWithTemplate(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpec, error) {
+	if spec.ClusterTemplate == "" {
+		return spec, nil
+	}
+
+	if s.templateSvc == nil {
+		return api.ClusterSpec{}, fmt.Errorf("Cluster templates are not available, cluster spec for %q can not be applied: %w", spec.Name, domain.ErrOperationNotPermitted)
+	}
+
+	// Apply fills in the default values of missing variables, work on a copy
+	// to keep the values of the caller untouched.
+	spec.ClusterTemplateVariableValues = maps.Clone(spec.ClusterTemplateVariableValues)
+	if spec.ClusterTemplateVariableValues == nil {
+		spec.ClusterTemplateVariableValues = api.ConfigMap{}
+	}
+
+	var err error
+	spec.ServicesConfig, spec.ApplicationSeedConfig, err = s.templateSvc.Apply(ctx, spec.ClusterTemplate, spec.ClusterTemplateVariableValues)
+	if err != nil {
+		return api.ClusterSpec{}, fmt.Errorf("Failed applying cluster template %q to cluster spec: %w", spec.ClusterTemplate, err)
+	}
+
+	return spec, nil
+}
+
+func (s *clusterService) clusterSpecCreateStep(spec api.ClusterSpec) clusterSpecStep {
+	return clusterSpecStep{
+		change: api.ClusterSpecChange{
+			Action:  api.ClusterSpecChangeActionCreateCluster,
+			Subject: spec.Name,
+			Desired: strings.Join(spec.ServerNames, ", "),
+		},
+		apply: func(ctx context.Context) error {
+			_, err := s.Create(ctx, provisioning.Cluster{
+				Name:                  spec.Name,
+				ConnectionURL:         spec.ConnectionURL,
+				ServerNames:           spec.ServerNames,
+				ServerType:            spec.ServerType,
+				ServicesConfig:        spec.ServicesConfig,
+				ApplicationSeedConfig: spec.ApplicationSeedConfig,
+				Channel:               spec.Channel,
+				Description:           spec.Description,
+				Properties:            spec.Properties,
+				Config:                spec.Config,
+
+				ClusterTemplate:               spec.ClusterTemplate,
+				ClusterTemplateVariableValues: spec.ClusterTemplateVariableValues,
+			})
+			return err
+		},
+	}
+}
+
+// clusterSpecClusterSteps returns the changes of the fields of the cluster
+// record. All the fields are updated together with the first change.
+func (s *clusterService) clusterSpecClusterSteps(cluster provisioning.Cluster, spec api.ClusterSpec) []clusterSpecStep {
+	var changes []api.ClusterSpecChange
+
+	if cluster.ConnectionURL != spec.ConnectionURL {
+		changes = append(changes, api.ClusterSpecChange{
+			Action:  api.ClusterSpecChangeActionUpdateCluster,
+			Subject: "connection_url",
+			Current: cluster.ConnectionURL,
+			Desired: spec.ConnectionURL,
+		})
+	}
+
+	if cluster.Channel != spec.Channel {
+		changes = append(changes, api.ClusterSpecChange{
+			Action:  api.ClusterSpecChangeActionUpdateCluster,
+			Subject: "channel",
+			Current: cluster.Channel,
+			Desired: spec.Channel,
+		})
+	}
+
+	if cluster.Description != spec.Description {
+		changes = append(changes, api.ClusterSpecChange{
+			Action:  api.ClusterSpecChangeActionUpdateCluster,
+			Subject: "description",
+			Current: cluster.Description,
+			Desired: spec.Description,
+		})
+	}
+
+	if !maps.Equal(cluster.Properties, spec.Properties) {
+		changes = append(changes, api.ClusterSpecChange{
+			Action:  api.ClusterSpecChangeActionUpdateCluster,
+			Subject: "properties",
+			Current: clusterSpecValue(cluster.Properties),
+			Desired: clusterSpecValue(spec.Properties),
+		})
+	}
+
+	if !clusterConfigEqual(cluster.Config, spec.Config) {
+		changes = append(changes, api.ClusterSpecChange{
+			Action:  api.ClusterSpecChangeActionUpdateCluster,
+			Subject: "config",
+			Current: clusterSpecValue(cluster.Config),
+			Desired: clusterSpecValue(spec.Config),
+		})
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	updatedCluster := cluster
+	updatedCluster.ConnectionURL = spec.ConnectionURL
+	updatedCluster.Channel = spec.Channel
+	updatedCluster.Description = spec.Description
+	updatedCluster.Properties = spec.Properties
+	updatedCluster.Config = spec.Config
+
+	steps := make([]clusterSpecStep, 0, len(changes))
+	for _, change := range changes {
+		steps = append(steps, clusterSpecStep{
+			change: change,
+		})
+	}
+
+	steps[0].apply = func(ctx context.Context) error {
+		return s.Update(ctx, updatedCluster, cluster.Channel != spec.Channel)
+	}
+
+	return steps
+}
+
+// clusterSpecCreateOnlyFieldsUnchanged ensures, that the spec of an existing
+// cluster does not change the fields, which are only used, when the cluster
+// is created. There are no operations to apply a different cluster template,
+// different variable values or a different services config to an existing
+// cluster, so such a spec is rejected instead of silently ignoring the
+// changes.
+func (s *clusterService) clusterSpecCreateOnlyFieldsUnchanged(ctx context.Context, cluster provisioning.Cluster, servers provisioning.Servers, spec api.ClusterSpec) error {
+	if cluster.ClusterTemplate != spec.ClusterTemplate {
+		return domain.NewValidationErrf("Invalid cluster spec, cluster template of existing cluster %q can not be changed from %q to %q", cluster.Name, cluster.ClusterTemplate, spec.ClusterTemplate)
+	}
+
+	variableValues, err := s.clusterSpecTemplateVariableValues(ctx, cluster, spec)
+	if err != nil {
+		return err
+	}
+
+	// The values are not included in the error, since they might be secret.
+	if !maps.Equal(cluster.ClusterTemplateVariableValues, variableValues) {
+		return domain.NewValidationErrf("Invalid cluster spec, cluster template variable values of existing cluster %q can not be changed", cluster.Name)
+	}
+
+	// The services config is disregarded for clusters created from a cluster
+	// template.
+	if spec.ClusterTemplate != "" || len(spec.ServicesConfig) == 0 {
+		return nil
+	}
+
+	// The services config is not known for adopted clusters and for clusters,
+	// which have been created before the services config has been recorded.
+	// For those, it is compared with the live service config of the servers,
+	// the same way as the drift of a cluster template is detected.
+	if cluster.ServicesConfig == nil {
+		return s.clusterSpecLiveServicesConfigUnchanged(ctx, cluster, servers, spec)
+	}
+
+	specServicesConfig, err := normalizeServiceConfig(spec.ServicesConfig)
+	if err != nil {
+		return domain.NewValidationErrf("Invalid cluster spec, services config: %v", err)
+	}
+
+	recordedServicesConfig, err := normalizeServiceConfig(cluster.ServicesConfig)
+	if err != nil {
+		return fmt.Errorf("Failed to normalize recorded services config of cluster %q: %w", cluster.Name, err)
+	}
+
+	differences := serviceConfigDifferences("", specServicesConfig, recordedServicesConfig)
+	differences = append(differences, serviceConfigDifferences("", recordedServicesConfig, specServicesConfig)...)
+	if len(differences) > 0 {
+		return domain.NewValidationErrf("Invalid cluster spec, services config of existing cluster %q can not be changed, key %q differs from the services config the cluster has been created with", cluster.Name, differences[0].Key)
+	}
+
+	return nil
+}
+
+// clusterSpecTemplateVariableValues returns the cluster template variable
+// values of the spec with the default values filled in from the revision of
+// the cluster template, the cluster has been created with. This makes them
+// comparable with the variable values recorded for the cluster.
+func (s *clusterService) clusterSpecTemplateVariableValues(ctx context.Context, cluster provisioning.Cluster, spec api.ClusterSpec) (api.ConfigMap, error) {
+	if spec.ClusterTemplate == "" || s.templateSvc == nil {
+		return spec.ClusterTemplateVariableValues, nil
+	}
+
+	recordedRevision, err := s.templateSvc.GetRevision(ctx, cluster.ClusterTemplate, cluster.ClusterTemplateRevision)
+	if errors.Is(err, domain.ErrNotFound) {
+		return spec.ClusterTemplateVariableValues, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get revision %d of cluster template %q of cluster %q: %w", cluster.ClusterTemplateRevision, cluster.ClusterTemplate, cluster.Name, err)
+	}
+
+	variableValues := maps.Clone(spec.ClusterTemplateVariableValues)
+	if variableValues == nil {
+		variableValues = api.ConfigMap{}
+	}
+
+	err = provisioning.ResolveClusterTemplateVariableValues(recordedRevision.Variables, variableValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return variableValues, nil
+}
+
+// clusterSpecLiveServicesConfigUnchanged ensures, that the services config of
+// the spec does not differ from the live service config of the servers.
+func (s *clusterService) clusterSpecLiveServicesConfigUnchanged(ctx context.Context, cluster provisioning.Cluster, servers provisioning.Servers, spec api.ClusterSpec) error {
+	expectedServicesConfig, err := expectedServiceConfigs(spec.ServicesConfig)
+	if err != nil {
+		return domain.NewValidationErrf("Invalid cluster spec, services config: %v", err)
+	}
+
+	liveServiceConfigGetters := s.liveServiceConfigGetters()
+
+	for _, server := range servers {
+		for _, service := range slices.Sorted(maps.Keys(expectedServicesConfig)) {
+			getLiveConfig, ok := liveServiceConfigGetters[service]
+			if !ok {
+				continue
+			}
+
+			liveConfigAny, err := getLiveConfig(ctx, server)
+			if err != nil {
+				return fmt.Errorf("Failed to get %s service config from server %q (%s): %w", service, server.Name, server.GetConnectionURL(), err)
+			}
+
+			liveConfig, err := normalizeServiceConfig(liveConfigAny)
+			if err != nil {
+				return fmt.Errorf("Failed to normalize %s service config of server %q: %w", service, server.Name, err)
+			}
+
+			differences := serviceConfigDifferences("", expectedServicesConfig[service], liveConfig)
+			if len(differences) > 0 {
+				return domain.NewValidationErrf("Invalid cluster spec, services config of existing cluster %q can not be changed, key %q of %s service config differs from server %q", cluster.Name, differences[0].Key, service, server.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func clusterSpecManagesSystemConfig(spec api.ClusterSpec) bool {
+	return spec.StorageTargets.ISCSI != nil ||
+		spec.StorageTargets.Multipath != nil ||
+		spec.StorageTargets.NVME != nil ||
+		len(spec.NetworkVLANTags) > 0 ||
+		spec.Logging != nil ||
+		spec.Kernel != nil
+}
+
+// clusterSpecSystemSteps returns the changes of the system and services
+// configuration, which is applied to all the members of the cluster.
+func (s *clusterService) clusterSpecSystemSteps(ctx context.Context, servers provisioning.Servers, spec api.ClusterSpec) ([]clusterSpecStep, error) {
+	var steps []clusterSpecStep
+
+	if spec.StorageTargets.ISCSI != nil {
+		currentTargets, err := sameOnAllServers(servers, "iscsi storage targets", func(server provisioning.Server) ([]incusosapi.ServiceISCSITarget, error) {
+			iscsiConfig, err := s.client.GetOSServiceISCSI(ctx, server)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get iscsi service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+			}
+
+			return iscsiConfig.Config.Targets, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, target := range missingElements(spec.StorageTargets.ISCSI, currentTargets) {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionAddISCSIStorageTarget,
+					Subject: fmt.Sprintf("%s (%s:%d)", target.Target, target.Address, target.Port),
+				},
+				apply: func(ctx context.Context) error {
+					return s.AddStorageTargetISCSI(ctx, spec.Name, target)
+				},
+			})
+		}
+
+		for _, target := range missingElements(currentTargets, spec.StorageTargets.ISCSI) {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionRemoveISCSIStorageTarget,
+					Subject: fmt.Sprintf("%s (%s:%d)", target.Target, target.Address, target.Port),
+				},
+				apply: func(ctx context.Context) error {
+					return s.RemoveStorageTargetISCSI(ctx, spec.Name, target)
+				},
+			})
+		}
+	}
+
+	if spec.StorageTargets.Multipath != nil {
+		currentTargets, err := sameOnAllServers(servers, "multipath storage targets", func(server provisioning.Server) ([]string, error) {
+			multipathConfig, err := s.client.GetOSServiceMultipath(ctx, server)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get multipath service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+			}
+
+			return multipathConfig.Config.WWNs, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, target := range missingElements(spec.StorageTargets.Multipath, currentTargets) {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionAddMultipathStorageTarget,
+					Subject: target,
+				},
+				apply: func(ctx context.Context) error {
+					return s.AddStorageTargetMultipath(ctx, spec.Name, target)
+				},
+			})
+		}
+
+		for _, target := range missingElements(currentTargets, spec.StorageTargets.Multipath) {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionRemoveMultipathStorageTarget,
+					Subject: target,
+				},
+				apply: func(ctx context.Context) error {
+					return s.RemoveStorageTargetMultipath(ctx, spec.Name, target)
+				},
+			})
+		}
+	}
+
+	if spec.StorageTargets.NVME != nil {
+		currentTargets, err := sameOnAllServers(servers, "nvme storage targets", func(server provisioning.Server) ([]incusosapi.ServiceNVMETarget, error) {
+			nvmeConfig, err := s.client.GetOSServiceNVME(ctx, server)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get nvme service config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+			}
+
+			return nvmeConfig.Config.Targets, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, target := range missingElements(spec.StorageTargets.NVME, currentTargets) {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionAddNVMEStorageTarget,
+					Subject: fmt.Sprintf("%s (%s:%d)", target.Transport, target.Address, target.Port),
+				},
+				apply: func(ctx context.Context) error {
+					return s.AddStorageTargetNVME(ctx, spec.Name, target)
+				},
+			})
+		}
+
+		for _, target := range missingElements(currentTargets, spec.StorageTargets.NVME) {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionRemoveNVMEStorageTarget,
+					Subject: fmt.Sprintf("%s (%s:%d)", target.Transport, target.Address, target.Port),
+				},
+				apply: func(ctx context.Context) error {
+					return s.RemoveStorageTargetNVME(ctx, spec.Name, target)
+				},
+			})
+		}
+	}
+
+	for _, interfaceName := range slices.Sorted(maps.Keys(spec.NetworkVLANTags)) {
+		currentVLANTags, err := sameOnAllServers(servers, fmt.Sprintf("VLAN tags of interface %q", interfaceName), func(server provisioning.Server) ([]int, error) {
+			if server.OSData.Network.Config == nil {
+				return nil, domain.NewValidationErrf("Server %q (%s) does not have any network config", server.Name, server.GetConnectionURL())
+			}
+
+			vlanTagsRef := findVLANTags(server.OSData.Network.Config, interfaceName)
+			if vlanTagsRef == nil {
+				return nil, domain.NewValidationErrf("Server %q (%s) does not have interface or bond %q", server.Name, server.GetConnectionURL(), interfaceName)
+			}
+
+			return *vlanTagsRef, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		addVLANTags := missingElements(spec.NetworkVLANTags[interfaceName], currentVLANTags)
+		if len(addVLANTags) > 0 {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionAddNetworkInterfaceVLANTags,
+					Subject: interfaceName,
+					Current: joinInts(currentVLANTags),
+					Desired: joinInts(spec.NetworkVLANTags[interfaceName]),
+				},
+				apply: func(ctx context.Context) error {
+					return s.AddServerSystemNetworkVLANTags(ctx, spec.Name, interfaceName, addVLANTags)
+				},
+			})
+		}
+
+		removeVLANTags := missingElements(currentVLANTags, spec.NetworkVLANTags[interfaceName])
+		if len(removeVLANTags) > 0 {
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionRemoveNetworkInterfaceVLANTags,
+					Subject: interfaceName,
+					Current: joinInts(currentVLANTags),
+					Desired: joinInts(spec.NetworkVLANTags[interfaceName]),
+				},
+				apply: func(ctx context.Context) error {
+					return s.RemoveServerSystemNetworkVLANTags(ctx, spec.Name, interfaceName, removeVLANTags)
+				},
+			})
+		}
+	}
+
+	// The logging and kernel configuration is updated on all the servers, so
+	// it does not need to be the same on all the servers beforehand.
+	if spec.Logging != nil {
+		for _, server := range servers {
+			currentLoggingConfig, err := s.serverSvc.GetSystemLogging(ctx, server.Name)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get current logging config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+			}
+
+			if reflect.DeepEqual(currentLoggingConfig, *spec.Logging) {
+				continue
+			}
+
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionUpdateSystemLogging,
+					Subject: server.Name,
+					Current: clusterSpecValue(currentLoggingConfig),
+					Desired: clusterSpecValue(*spec.Logging),
+				},
+				apply: func(ctx context.Context) error {
+					return s.UpdateSystemLogging(ctx, spec.Name, *spec.Logging)
+				},
+			})
+
+			break
+		}
+	}
+
+	if spec.Kernel != nil {
+		for _, server := range servers {
+			currentKernelConfig, err := s.serverSvc.GetSystemKernel(ctx, server.Name)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get current kernel config from server %q (%s): %w", server.Name, server.GetConnectionURL(), err)
+			}
+
+			if reflect.DeepEqual(currentKernelConfig, *spec.Kernel) {
+				continue
+			}
+
+			steps = append(steps, clusterSpecStep{
+				change: api.ClusterSpecChange{
+					Action:  api.ClusterSpecChangeActionUpdateSystemKernel,
+					Subject: server.Name,
+					Current: clusterSpecValue(currentKernelConfig),
+					Desired: clusterSpecValue(*spec.Kernel),
+				},
+				apply: func(ctx context.Context) error {
+					return s.UpdateSystemKernel(ctx, spec.Name, *spec.Kernel)
+				},
+			})
+
+			break
+		}
+	}
+
+	return steps, nil
+}
+
+// sameOnAllServers fetches a list of values from all the servers and ensures,
+// that all the servers have the same values (in any order). The changes of a
+// cluster spec are applied to all the servers at once, which is only possible,
+// if the servers do not differ.
+func sameOnAllServers[T comparable](servers provisioning.Servers, kind string, get func(server provisioning.Server) ([]T, error)) ([]T, error) {
+	var reference []T
+	for i, server := range servers {
+		values, err := get(server)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			reference = values
+			continue
+		}
+
+		if len(missingElements(values, reference)) > 0 || len(missingElements(reference, values)) > 0 {
+			return nil, fmt.Errorf("The %s of server %q differ from server %q, the cluster spec can not be applied: %w", kind, server.Name, servers[0].Name, domain.ErrOperationNotPermitted)
+		}
+	}
+
+	return reference, nil
+}
+
+// missingElements returns the elements of want, which are not present in have.
+func missingElements[T comparable](want []T, have []T) []T {
+	var missing []T
+	for _, element := range want {
+		if slices.Contains(have, element) || slices.Contains(missing, element) {
+			continue
+		}
+
+		missing = append(missing, element)
+	}
+
+	return missing
+}
+
+func clusterConfigEqual(a api.ClusterConfig, b api.ClusterConfig) bool {
+	return a.RollingRestart == b.RollingRestart &&
+		a.AutoUpdate == b.AutoUpdate &&
+		slices.EqualFunc(a.MaintenanceWindows, b.MaintenanceWindows, func(x api.ClusterConfigMaintenanceWindow, y api.ClusterConfigMaintenanceWindow) bool {
+			return slices.Equal(x.Weekdays, y.Weekdays) &&
+				x.StartTime == y.StartTime &&
+				x.EndTime == y.EndTime &&
+				x.Timezone == y.Timezone
+		})
+}
+
+func clusterSpecValue(value any) string {
+	// Ignore the error, the values are API types, which can always be marshaled.
+	body, _ := json.Marshal(value)
+	return string(body)
+}
+
+func joinInts(values []int) string {
+	s := make([]string, 0, len(values))
+	for _, value := range values {
+		s = append(s, strconv.Itoa(value))
+	}
+
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,262 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// GetClusterTemplateDrift re-renders the current revision of the cluster
+// template, the cluster has been created from, with the recorded variable
+// values and compares the resulting service configuration with the live
+// service configuration of each server of the cluster.
+//
+// Only the configuration keys, which are set by the cluster template, are
+// compared. The LVM system_id is controlled by Operations Center and therefore
+// never reported as drift.
+func (s *clusterService) GetClusterTemplateDrift(ctx context.Context, name string) (api.ClusterTemplateDrift, error) {
+	if name == "" {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Cluster name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	if s.templateSvc == nil {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Cluster template drift reporting is not available: %w", domain.ErrOperationNotPermitted)
+	}
+
+	cluster, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to get cluster %q: %w", name, err)
+	}
+
+	if cluster.ClusterTemplate == "" {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Cluster %q has not been created from a cluster template: %w", name, domain.ErrOperationNotPermitted)
+	}
+
+	clusterTemplate, err := s.templateSvc.GetByName(ctx, cluster.ClusterTemplate)
+	if err != nil {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to get cluster template %q of cluster %q: %w", cluster.ClusterTemplate, name, err)
+	}
+
+	// The template reference is reported based on the definition of the
+	// recorded revision. If the revision is not available, the current
+	// definition is used.
+	recordedVariables := clusterTemplate.Variables
+	recordedRevision, err := s.templateSvc.GetRevision(ctx, cluster.ClusterTemplate, cluster.ClusterTemplateRevision)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to get revision %d of cluster template %q of cluster %q: %w", cluster.ClusterTemplateRevision, cluster.ClusterTemplate, name, err)
+	}
+
+	if recordedRevision != nil {
+		recordedVariables = recordedRevision.Variables
+	}
+
+	// Apply fills in the default values of missing variables, work on a copy
+	// to keep the recorded variable values untouched.
+	variableValues := api.ConfigMap{}
+	maps.Copy(variableValues, cluster.ClusterTemplateVariableValues)
+
+	servicesConfig, _, err := s.templateSvc.Apply(ctx, cluster.ClusterTemplate, variableValues)
+	if err != nil {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to render cluster template %q for cluster %q: %w", cluster.ClusterTemplate, name, err)
+	}
+
+	// A variable is considered secret, if it is flagged as secret in either the
+	// current definition or the recorded revision, such that a secret is never
+	// disclosed because the flag has been removed in one of them.
+	var secretValues []string
+	for _, variables := range []api.ClusterTemplateVariables{clusterTemplate.Variables, recordedVariables} {
+		for variableName, variable := range variables {
+			if variable.Secret && variableValues[variableName] != "" && !slices.Contains(secretValues, variableValues[variableName]) {
+				secretValues = append(secretValues, variableValues[variableName])
+			}
+		}
+	}
+
+	expectedServicesConfig, err := expectedServiceConfigs(servicesConfig)
+	if err != nil {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to normalize rendered service config of cluster %q: %w", name, err)
+	}
+
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Cluster: ptr.To(name),
+	})
+	if err != nil {
+		return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to get servers of cluster %q: %w", name, err)
+	}
+
+	drift := api.ClusterTemplateDrift{
+		Cluster:         name,
+		Template:        *cluster.TemplateReference(recordedVariables),
+		CurrentRevision: clusterTemplate.Revision,
+		Outdated:        cluster.ClusterTemplateRevision != clusterTemplate.Revision,
+		Differences:     []api.ClusterTemplateDriftDifference{},
+	}
+
+	liveServiceConfigGetters := s.liveServiceConfigGetters()
+
+	for _, server := range servers {
+		for _, service := range slices.Sorted(maps.Keys(expectedServicesConfig)) {
+			getLiveConfig, ok := liveServiceConfigGetters[service]
+			if !ok {
+				// Drift is only reported for the services known to Operations Center.
+				continue
+			}
+
+			liveConfigAny, err := getLiveConfig(ctx, server)
+			if err != nil {
+				return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to get %s service config from server %q (%s): %w", service, server.Name, server.GetConnectionURL(), err)
+			}
+
+			liveConfig, err := normalizeServiceConfig(liveConfigAny)
+			if err != nil {
+				return api.ClusterTemplateDrift{}, fmt.Errorf("Failed to normalize %s service config of server %q: %w", service, server.Name, err)
+			}
+
+			for _, difference := range serviceConfigDifferences("", expectedServicesConfig[service], liveConfig) {
+				difference.Server = server.Name
+				difference.Service = service
+
+				// Never disclose the values of secret variables.
+				if containsAny(difference.Expected, secretValues) || containsAny(difference.Actual, secretValues) {
+					difference.Expected = provisioning.ClusterTemplateSecretMask
+					difference.Actual = provisioning.ClusterTemplateSecretMask
+				}
+
+				drift.Differences = append(drift.Differences, difference)
+			}
+		}
+	}
+
+	return drift, nil
+}
+
+func (s *clusterService) liveServiceConfigGetters() map[string]func(ctx context.Context, server provisioning.Server) (any, error) {
+	return map[string]func(ctx context.Context, server provisioning.Server) (any, error){
+		"lvm": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceLVM(ctx, server)
+			return service.Config, err
+		},
+		"iscsi": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceISCSI(ctx, server)
+			return service.Config, err
+		},
+		"multipath": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceMultipath(ctx, server)
+			return service.Config, err
+		},
+		"nvme": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceNVME(ctx, server)
+			return service.Config, err
+		},
+		"ceph": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceCeph(ctx, server)
+			return service.Config, err
+		},
+		"linstor": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceLinstor(ctx, server)
+			return service.Config, err
+		},
+		"ovn": func(ctx context.Context, server provisioning.Server) (any, error) {
+			service, err := s.client.GetOSServiceOVN(ctx, server)
+			return service.Config, err
+		},
+	}
+}
+
+// expectedServiceConfigs normalizes the given services config, such that it
+// can be compared with the live service config of the servers.
+func expectedServiceConfigs(servicesConfig map[string]any) (map[string]map[string]any, error) {
+	expectedServicesConfig := make(map[string]map[string]any, len(servicesConfig))
+	for service, configAny := range servicesConfig {
+		expectedConfig, err := normalizeServiceConfig(configAny)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to normalize %s service config: %w", service, err)
+		}
+
+		// The client wraps the service config into "config", if not already
+		// done by the template.
+		wrappedConfig, ok := expectedConfig["config"].(map[string]any)
+		if ok {
+			expectedConfig = wrappedConfig
+		}
+
+		// LVM system_id is controlled by Operations Center and not the user.
+		if service == "lvm" {
+			delete(expectedConfig, "system_id")
+		}
+
+		expectedServicesConfig[service] = expectedConfig
+	}
+
+	return expectedServicesConfig, nil
+}
+
+// normalizeServiceConfig converts a service config into its generic JSON
+// representation, such that rendered and live configs can be compared.
+func normalizeServiceConfig(config any) (map[string]any, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := map[string]any{}
+	err = json.Unmarshal(body, &normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// serviceConfigDifferences returns the differences for all the keys of the
+// expected config, recursing into nested objects.
+func serviceConfigDifferences(prefix string, expected map[string]any, actual map[string]any) []api.ClusterTemplateDriftDifference {
+	var differences []api.ClusterTemplateDriftDifference
+
+	for _, key := range slices.Sorted(maps.Keys(expected)) {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		expectedObject, expectedIsObject := expected[key].(map[string]any)
+		actualObject, actualIsObject := actual[key].(map[string]any)
+		if expectedIsObject && actualIsObject {
+			differences = append(differences, serviceConfigDifferences(path, expectedObject, actualObject)...)
+			continue
+		}
+
+		expectedValue := clusterSpecValue(expected[key])
+		actualValue := clusterSpecValue(actual[key])
+		if expectedValue == actualValue {
+			continue
+		}
+
+		differences = append(differences, api.ClusterTemplateDriftDifference{
+			Key:      path,
+			Expected: expectedValue,
+			Actual:   actualValue,
+		})
+	}
+
+	return differences
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
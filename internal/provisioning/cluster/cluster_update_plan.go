@@ -0,0 +1,161 @@
+package cluster
+
+import (
+	"slices"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// clusterUpdatePlanSchedule holds the settings of the cluster, which
+// determine, how the steps of a cluster wide operation are scheduled.
+type clusterUpdatePlanSchedule struct {
+	// maxUnavailable is the number of servers, which are processed at the same
+	// time.
+	maxUnavailable int
+
+	// maintenanceWindows is true, if the cluster or its site define maintenance
+	// windows.
+	maintenanceWindows bool
+
+	// inMaintenanceWindow is true, if the cluster is currently within one of its
+	// maintenance windows.
+	inMaintenanceWindow bool
+}
+
+// clusterUpdatePlan calculates the steps, the given cluster wide operation
+// would perform, based on the current state of the servers of the cluster.
+//
+// The steps follow the order of the control loop: the control loop updates all
+// servers before it restarts any of them, and in both phases it processes up to
+// max unavailable servers at the same time in the order of the given servers.
+// These servers form a batch. Servers, which have been evacuated before the
+// operation, are rebooted, but neither evacuated nor restored. The update and
+// the evacuation of a server are only started within the maintenance windows
+// of the cluster, all other steps bring a server back to a safe state and are
+// therefore performed at any time.
+func clusterUpdatePlan(operation api.ClusterUpdateInProgress, servers provisioning.Servers, evacuatedBefore []string, instances map[string][]api.ClusterUpdatePlanInstance, schedule clusterUpdatePlanSchedule) api.ClusterUpdatePlan {
+	plan := api.ClusterUpdatePlan{
+		Operation:           operation,
+		Steps:               []api.ClusterUpdatePlanStep{},
+		Servers:             make([]api.ClusterUpdatePlanServer, 0, len(servers)),
+		InMaintenanceWindow: schedule.inMaintenanceWindow,
+	}
+
+	maxUnavailable := max(schedule.maxUnavailable, 1)
+
+	for _, server := range servers {
+		planServer := api.ClusterUpdatePlanServer{
+			Name:            server.Name,
+			EvacuatedBefore: slices.Contains(evacuatedBefore, server.Name),
+			OS:              clusterUpdatePlanComponent(server.VersionData.OS.Name, server.VersionData.OS.Version, server.VersionData.OS.AvailableVersion, operation),
+			Applications:    make([]api.ClusterUpdatePlanComponent, 0, len(server.VersionData.Applications)),
+			Instances:       instances[server.Name],
+		}
+
+		for _, application := range server.VersionData.Applications {
+			planServer.Applications = append(planServer.Applications, clusterUpdatePlanComponent(application.Name, application.Version, application.AvailableVersion, operation))
+		}
+
+		if planServer.Instances == nil {
+			planServer.Instances = []api.ClusterUpdatePlanInstance{}
+		}
+
+		plan.Servers = append(plan.Servers, planServer)
+	}
+
+	// Batches are numbered continuously across the update and the restart
+	// phase, since the restart phase only starts, once all servers are updated.
+	var batch int
+
+	// Update phase.
+	if operation == api.ClusterUpdateInProgressApplyUpdate || operation == api.ClusterUpdateInProgressApplyUpdateWithReboot {
+		var updated int
+		for _, server := range servers {
+			if !ptr.From(server.VersionData.NeedsUpdate) {
+				continue
+			}
+
+			plan.Steps = append(plan.Steps, api.ClusterUpdatePlanStep{
+				Server:                   server.Name,
+				Action:                   api.ClusterUpdatePlanActionUpdate,
+				Batch:                    batch + updated/maxUnavailable + 1,
+				WaitForMaintenanceWindow: schedule.maintenanceWindows,
+			})
+
+			updated++
+		}
+
+		batch += (updated + maxUnavailable - 1) / maxUnavailable
+	}
+
+	if operation == api.ClusterUpdateInProgressApplyUpdate {
+		return plan
+	}
+
+	// Restart phase.
+	var restarted int
+	for _, server := range servers {
+		// An update of the OS is only activated by a reboot. During a rolling
+		// reboot, all servers are rebooted.
+		needsReboot := operation == api.ClusterUpdateInProgressRollingReboot ||
+			ptr.From(server.VersionData.NeedsReboot) ||
+			ptr.From(server.VersionData.OS.NeedsUpdate)
+		if !needsReboot {
+			continue
+		}
+
+		serverBatch := batch + restarted/maxUnavailable + 1
+		restarted++
+
+		evacuateStep := api.ClusterUpdatePlanStep{
+			Server:                   server.Name,
+			Action:                   api.ClusterUpdatePlanActionEvacuate,
+			Batch:                    serverBatch,
+			WaitForMaintenanceWindow: schedule.maintenanceWindows,
+		}
+
+		restoreStep := api.ClusterUpdatePlanStep{
+			Server: server.Name,
+			Action: api.ClusterUpdatePlanActionRestore,
+			Batch:  serverBatch,
+		}
+
+		if slices.Contains(evacuatedBefore, server.Name) {
+			evacuateStep.Skipped = true
+			evacuateStep.Reason = "Server has been evacuated before the operation"
+			evacuateStep.WaitForMaintenanceWindow = false
+
+			restoreStep.Skipped = true
+			restoreStep.Reason = "Server is kept evacuated, since it has been evacuated before the operation"
+		}
+
+		plan.Steps = append(plan.Steps,
+			evacuateStep,
+			api.ClusterUpdatePlanStep{
+				Server: server.Name,
+				Action: api.ClusterUpdatePlanActionReboot,
+				Batch:  serverBatch,
+			},
+			restoreStep,
+		)
+	}
+
+	return plan
+}
+
+// clusterUpdatePlanComponent returns the version information of a software
+// component. The available version is only applied by a cluster update.
+func clusterUpdatePlanComponent(name string, version string, availableVersion *string, operation api.ClusterUpdateInProgress) api.ClusterUpdatePlanComponent {
+	targetVersion := version
+	if operation != api.ClusterUpdateInProgressRollingReboot && availableVersion != nil && api.AvailableVersionGreaterThan(version, *availableVersion) {
+		targetVersion = *availableVersion
+	}
+
+	return api.ClusterUpdatePlanComponent{
+		Name:           name,
+		CurrentVersion: version,
+		TargetVersion:  targetVersion,
+	}
+}
@@ -44,6 +44,14 @@ type clusterUpdateProgress struct {
 	// text, if set, is reported verbatim instead of the step based description.
 	// It is used for the terminal error case.
 	text string
+
+	// waitingForWindow is true, if the control loop holds back the next step,
+	// because the cluster is outside of its maintenance windows.
+	waitingForWindow bool
+
+	// pausedByHealthGate is true, if the control loop holds back the next step,
+	// because the cluster update health gate is failing.
+	pausedByHealthGate bool
 }
 
 func (p clusterUpdateProgress) String() string {
@@ -56,10 +64,33 @@ func (p clusterUpdateProgress) String() string {
 	}
 
 	format := fmt.Sprintf("[%%%[1]dd/%%%[1]dd] %%s server %%q", len(strconv.Itoa(p.totalSteps)))
+	switch {
+	case p.waitingForWindow:
+		format = fmt.Sprintf("[%%%[1]dd/%%%[1]dd] waiting for maintenance window, next: %%s server %%q", len(strconv.Itoa(p.totalSteps)))
+
+	case p.pausedByHealthGate:
+		format = fmt.Sprintf("[%%%[1]dd/%%%[1]dd] paused by health gate, next: %%s server %%q", len(strconv.Itoa(p.totalSteps)))
+	}
 
 	return fmt.Sprintf(format, p.step, p.totalSteps, p.state, p.serverName)
 }
 
+// atSafePoint returns true, if the next step starts the work on a server, which
+// has not been touched by the control loop yet. This is the point, where an
+// ongoing rolling update is paused, while the cluster is outside of its
+// maintenance windows.
+func (p clusterUpdateProgress) atSafePoint() bool {
+	return p.step != 0 && isMaintenanceWindowGatedState(p.state)
+}
+
+// isMaintenanceWindowGatedState returns true for the server update states, from
+// which the control loop only advances, while the cluster is within one of its
+// maintenance windows. Servers in any of the later states are in the middle of
+// the update or restart cycle and are always brought back to a safe state.
+func isMaintenanceWindowGatedState(state api.ServerUpdateState) bool {
+	return state == api.ServerUpdateStateUpdatePending || state == api.ServerUpdateStateEvacuationPending
+}
+
 // serverPendingSteps returns the number of the perServerSteps steps, that the
 // server in the given state still has ahead of it.
 //
@@ -92,7 +123,8 @@ func serverPendingSteps(state api.ServerUpdateState, perServerSteps int, firstSt
 // During the rolling reboot phase, the need for a reboot is synthesized for all
 // servers, which still have to be rebooted. A server is removed from
 // PendingReboot as soon as its reboot has been triggered, which is what lets it
-// advance to the restore step afterwards.
+// advance to the restore step afterwards. A rolling firmware update is handled
+// the same way, the firmware is updated right before the reboot.
 //
 // During the update phase, a server updating its applications is reported as
 // updating instead of the undefined state, api.Server.UpdateState reports for it,
@@ -102,7 +134,8 @@ func serverUpdateStateForRollingUpdate(inProgressStatus api.ClusterUpdateInProgr
 	case api.ClusterUpdateInProgressRollingRestart:
 		server.VersionData.NeedsUpdate = ptr.To(false)
 
-	case api.ClusterUpdateInProgressRollingReboot:
+	case api.ClusterUpdateInProgressRollingReboot,
+		api.ClusterUpdateInProgressRollingFirmwareUpdate:
 		server.VersionData.NeedsUpdate = ptr.To(false)
 
 		if slices.Contains(inProgressStatus.PendingReboot, server.Name) {
@@ -119,6 +152,36 @@ func serverUpdateStateForRollingUpdate(inProgressStatus api.ClusterUpdateInProgr
 	return server.UpdateState()
 }
 
+// clusterUpdateServerStates returns the update state of each server, which is
+// still taking part in the rolling update of a cluster, indexed by the server
+// name. With max unavailable set, the control loop works on several servers at
+// the same time, which is not reflected by the progress description alone.
+func clusterUpdateServerStates(inProgressStatus api.ClusterUpdateInProgressStatus, servers provisioning.Servers) map[string]api.ServerUpdateState {
+	if inProgressStatus.InProgress == api.ClusterUpdateInProgressError {
+		return nil
+	}
+
+	serverStates := make(map[string]api.ServerUpdateState, len(servers))
+
+	for _, server := range servers {
+		state := serverUpdateStateForRollingUpdate(inProgressStatus, server)
+		if state == api.ServerUpdateStateUpToDate {
+			continue
+		}
+
+		// Servers, which have been evacuated before the update was triggered, are kept
+		// in the evacuated state and are therefore done.
+		if state == api.ServerUpdateStateInMaintenanceRestorePending &&
+			slices.Contains(inProgressStatus.EvacuatedBefore, server.Name) {
+			continue
+		}
+
+		serverStates[server.Name] = state
+	}
+
+	return serverStates
+}
+
 // clusterUpdateState calculates the progress of the rolling update of a cluster
 // from the current state of its servers.
 func clusterUpdateState(clusterUpdateInProgressStatus api.ClusterUpdateInProgressStatus, servers provisioning.Servers) clusterUpdateProgress {
@@ -138,7 +201,8 @@ func clusterUpdateState(clusterUpdateInProgressStatus api.ClusterUpdateInProgres
 		api.ClusterUpdateInProgressRollingRestart:
 		perServerSteps = len(clusterUpdateSteps)
 
-	case api.ClusterUpdateInProgressRollingReboot:
+	case api.ClusterUpdateInProgressRollingReboot,
+		api.ClusterUpdateInProgressRollingFirmwareUpdate:
 		firstStep = clusterUpdateUpdateSteps
 		perServerSteps = len(clusterUpdateSteps) - clusterUpdateUpdateSteps
 
@@ -62,6 +62,10 @@ type serverWorld struct {
 	versionData map[string]api.ServerVersionData
 	rebooting   map[string]bool
 	pending     []serverWorldTransition
+
+	// firmwareUpdates records the servers, the firmware has been updated on via
+	// the BMC, together with their maintenance state at that time.
+	firmwareUpdates []string
 }
 
 // serverWorldTransition is the state a server reaches once it has completed an
@@ -119,6 +123,23 @@ func (w *serverWorld) deferTransition(transition serverWorldTransition) {
 	w.pending = append(w.pending, transition)
 }
 
+// recordFirmwareUpdate records the update of the firmware of the given server
+// together with the maintenance state, the server is in.
+func (w *serverWorld) recordFirmwareUpdate(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.firmwareUpdates = append(w.firmwareUpdates, name+": "+w.versionData[name].Applications[0].InMaintenance.String())
+}
+
+// getFirmwareUpdates returns the firmware updates recorded so far.
+func (w *serverWorld) getFirmwareUpdates() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.firmwareUpdates)
+}
+
 // pendingCount returns the number of actions, that have been triggered but not yet
 // completed.
 func (w *serverWorld) pendingCount() int {
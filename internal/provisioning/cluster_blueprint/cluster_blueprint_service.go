@@ -0,0 +1,330 @@
+package clusterblueprint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type clusterBlueprintService struct {
+	repo               provisioning.ClusterBlueprintRepo
+	clusterSvc         provisioning.ClusterService
+	clusterTemplateSvc provisioning.ClusterTemplateService
+	serverSvc          provisioning.ServerService
+}
+
+var _ provisioning.ClusterBlueprintService = &clusterBlueprintService{}
+
+func New(
+	repo provisioning.ClusterBlueprintRepo,
+	clusterSvc provisioning.ClusterService,
+	clusterTemplateSvc provisioning.ClusterTemplateService,
+	serverSvc provisioning.ServerService,
+) *clusterBlueprintService {
+	return &clusterBlueprintService{
+		repo:               repo,
+		clusterSvc:         clusterSvc,
+		clusterTemplateSvc: clusterTemplateSvc,
+		serverSvc:          serverSvc,
+	}
+}
+
+// Create validates and adds a new cluster blueprint in waiting state. The
+// server filter expression and the cluster template including the variable
+// values are checked upfront, such that errors are reported immediately and
+// not only when the cluster is formed.
+func (s clusterBlueprintService) Create(ctx context.Context, newClusterBlueprint provisioning.ClusterBlueprint) (provisioning.ClusterBlueprint, error) {
+	err := s.validate(ctx, &newClusterBlueprint)
+	if err != nil {
+		return provisioning.ClusterBlueprint{}, err
+	}
+
+	newClusterBlueprint.Status = api.ClusterBlueprintStatusWaiting
+	newClusterBlueprint.ServerNames = provisioning.ClusterBlueprintServerNames{}
+	newClusterBlueprint.Error = ""
+
+	newClusterBlueprint.ID, err = s.repo.Create(ctx, newClusterBlueprint)
+	if err != nil {
+		return provisioning.ClusterBlueprint{}, err
+	}
+
+	return newClusterBlueprint, nil
+}
+
+func (s clusterBlueprintService) GetAll(ctx context.Context) (provisioning.ClusterBlueprints, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s clusterBlueprintService) GetAllNames(ctx context.Context) ([]string, error) {
+	return s.repo.GetAllNames(ctx)
+}
+
+func (s clusterBlueprintService) GetByName(ctx context.Context, name string) (*provisioning.ClusterBlueprint, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Cluster blueprint name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return s.repo.GetByName(ctx, name)
+}
+
+// Update changes the definition of a waiting or failed cluster blueprint.
+// A failed blueprint is put back in waiting state, such that the formation
+// of the cluster is retried.
+func (s clusterBlueprintService) Update(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint) error {
+	err := s.validate(ctx, &clusterBlueprint)
+	if err != nil {
+		return err
+	}
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		currentClusterBlueprint, err := s.repo.GetByName(ctx, clusterBlueprint.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster blueprint %q: %w", clusterBlueprint.Name, err)
+		}
+
+		if currentClusterBlueprint.Status != api.ClusterBlueprintStatusWaiting && currentClusterBlueprint.Status != api.ClusterBlueprintStatusFailed {
+			return fmt.Errorf("Cluster blueprint %q in status %q can not be updated: %w", clusterBlueprint.Name, currentClusterBlueprint.Status, domain.ErrOperationNotPermitted)
+		}
+
+		clusterBlueprint.ID = currentClusterBlueprint.ID
+		clusterBlueprint.Status = api.ClusterBlueprintStatusWaiting
+		clusterBlueprint.ServerNames = provisioning.ClusterBlueprintServerNames{}
+		clusterBlueprint.Error = ""
+
+		return s.repo.Update(ctx, clusterBlueprint)
+	})
+}
+
+func (s clusterBlueprintService) DeleteByName(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("Cluster blueprint name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		clusterBlueprint, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster blueprint %q: %w", name, err)
+		}
+
+		if clusterBlueprint.Status == api.ClusterBlueprintStatusForming {
+			return fmt.Errorf("Cluster blueprint %q can not be deleted while the cluster is formed: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		err = s.repo.DeleteByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to delete cluster blueprint: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ClusterBlueprintControlLoop forms the cluster of every waiting cluster
+// blueprint, for which enough servers matching the server filter are ready
+// and not yet part of a cluster.
+func (s clusterBlueprintService) ClusterBlueprintControlLoop(ctx context.Context) error {
+	clusterBlueprints, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster blueprints: %w", err)
+	}
+
+	var errs []error
+	for _, clusterBlueprint := range clusterBlueprints {
+		if clusterBlueprint.Status != api.ClusterBlueprintStatusWaiting {
+			continue
+		}
+
+		err = s.formCluster(ctx, clusterBlueprint)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to process cluster blueprint %q: %w", clusterBlueprint.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Prune marks the cluster blueprints, for which the formation of the cluster
+// has been interrupted by a shutdown of the service, as failed, such that they
+// can be edited and retried. If the cluster has been created before the
+// interruption, the cluster blueprint is marked as done instead.
+// Prune is normally only called on startup of the service.
+func (s clusterBlueprintService) Prune(ctx context.Context) error {
+	clusterBlueprints, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster blueprints during prune: %w", err)
+	}
+
+	var errs []error
+	for _, clusterBlueprint := range clusterBlueprints {
+		if clusterBlueprint.Status != api.ClusterBlueprintStatusForming {
+			continue
+		}
+
+		_, err = s.clusterSvc.GetByName(ctx, clusterBlueprint.Name)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("Failed to get cluster for cluster blueprint %q: %w", clusterBlueprint.Name, err))
+			continue
+		}
+
+		if err == nil {
+			clusterBlueprint.Status = api.ClusterBlueprintStatusDone
+			err = s.update(ctx, clusterBlueprint, api.ClusterBlueprintStatusForming)
+		} else {
+			err = s.fail(ctx, clusterBlueprint, "Cluster formation has been interrupted by a restart of Operations Center")
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to reset interrupted cluster blueprint %q: %w", clusterBlueprint.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s clusterBlueprintService) validate(ctx context.Context, clusterBlueprint *provisioning.ClusterBlueprint) error {
+	if clusterBlueprint.ServerType == "" {
+		clusterBlueprint.ServerType = api.ServerTypeIncus
+	}
+
+	if clusterBlueprint.Channel == "" {
+		clusterBlueprint.Channel = config.GetUpdates().ServerDefaultChannel
+	}
+
+	if clusterBlueprint.ClusterTemplateVariableValues == nil {
+		clusterBlueprint.ClusterTemplateVariableValues = api.ConfigMap{}
+	}
+
+	err := clusterBlueprint.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Expression: &clusterBlueprint.ServerFilter,
+	})
+	if err != nil {
+		return fmt.Errorf("Invalid server filter: %w", err)
+	}
+
+	// The variable values are checked by applying the cluster template. The
+	// result is discarded, the template is applied again, when the cluster is
+	// formed, such that changes of the template until then are respected.
+	_, _, err = s.clusterTemplateSvc.Apply(ctx, clusterBlueprint.ClusterTemplate, maps.Clone(clusterBlueprint.ClusterTemplateVariableValues))
+	if err != nil {
+		return fmt.Errorf("Invalid cluster template %q: %w", clusterBlueprint.ClusterTemplate, err)
+	}
+
+	return nil
+}
+
+// formCluster selects the servers for the cluster of the blueprint and, if
+// enough candidates are available, creates the cluster from them.
+func (s clusterBlueprintService) formCluster(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint) error {
+	log := slog.With(slog.String("cluster_blueprint", clusterBlueprint.Name))
+
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Expression: &clusterBlueprint.ServerFilter,
+	})
+	if err != nil {
+		return s.fail(ctx, clusterBlueprint, fmt.Sprintf("Failed to get servers matching the server filter: %v", err))
+	}
+
+	candidates := make(provisioning.Servers, 0, len(servers))
+	for _, server := range servers {
+		if clusterBlueprint.IsCandidate(server) {
+			candidates = append(candidates, server)
+		}
+	}
+
+	if len(candidates) < clusterBlueprint.MemberCount {
+		log.DebugContext(ctx, "Not enough servers available to form cluster", slog.Int("candidates", len(candidates)), slog.Int("member_count", clusterBlueprint.MemberCount))
+		return nil
+	}
+
+	slices.SortFunc(candidates, func(a, b provisioning.Server) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	candidates = candidates[:clusterBlueprint.MemberCount]
+
+	clusterBlueprint.Status = api.ClusterBlueprintStatusForming
+	clusterBlueprint.ServerNames = make(provisioning.ClusterBlueprintServerNames, 0, len(candidates))
+	for _, server := range candidates {
+		clusterBlueprint.ServerNames = append(clusterBlueprint.ServerNames, server.Name)
+	}
+
+	err = s.update(ctx, clusterBlueprint, api.ClusterBlueprintStatusWaiting)
+	if err != nil {
+		return err
+	}
+
+	log.InfoContext(ctx, "Forming cluster from blueprint", slog.Any("servers", clusterBlueprint.ServerNames))
+
+	templateVariableValues := maps.Clone(clusterBlueprint.ClusterTemplateVariableValues)
+
+	servicesConfig, applicationSeedConfig, err := s.clusterTemplateSvc.Apply(ctx, clusterBlueprint.ClusterTemplate, templateVariableValues)
+	if err != nil {
+		return s.fail(ctx, clusterBlueprint, fmt.Sprintf("Failed to apply cluster template %q: %v", clusterBlueprint.ClusterTemplate, err))
+	}
+
+	_, err = s.clusterSvc.Create(ctx, provisioning.Cluster{
+		Name:                  clusterBlueprint.Name,
+		ConnectionURL:         clusterBlueprint.ConnectionURL,
+		ServerNames:           clusterBlueprint.ServerNames,
+		ServerType:            clusterBlueprint.ServerType,
+		ServicesConfig:        servicesConfig,
+		ApplicationSeedConfig: applicationSeedConfig,
+		Channel:               clusterBlueprint.Channel,
+		Description:           clusterBlueprint.Description,
+
+		ClusterTemplate:               clusterBlueprint.ClusterTemplate,
+		ClusterTemplateVariableValues: templateVariableValues,
+	})
+	if err != nil {
+		return s.fail(ctx, clusterBlueprint, fmt.Sprintf("Failed to create cluster: %v", err))
+	}
+
+	log.InfoContext(ctx, "Cluster formed from blueprint")
+
+	clusterBlueprint.Status = api.ClusterBlueprintStatusDone
+
+	return s.update(ctx, clusterBlueprint, api.ClusterBlueprintStatusForming)
+}
+
+func (s clusterBlueprintService) fail(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint, reason string) error {
+	slog.WarnContext(ctx, "Cluster blueprint failed", slog.String("cluster_blueprint", clusterBlueprint.Name), slog.String("reason", reason))
+
+	currentStatus := clusterBlueprint.Status
+
+	clusterBlueprint.Status = api.ClusterBlueprintStatusFailed
+	clusterBlueprint.Error = reason
+
+	return s.update(ctx, clusterBlueprint, currentStatus)
+}
+
+// update persists the progress of the cluster blueprint, unless the
+// blueprint has been changed in the meantime.
+func (s clusterBlueprintService) update(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint, expectedStatus api.ClusterBlueprintStatus) error {
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetByName(ctx, clusterBlueprint.Name)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != expectedStatus {
+			return fmt.Errorf("Cluster blueprint %q changed from status %q to %q in the meantime: %w", clusterBlueprint.Name, expectedStatus, current.Status, domain.ErrOperationNotPermitted)
+		}
+
+		return s.repo.Update(ctx, clusterBlueprint)
+	})
+}
@@ -0,0 +1,715 @@
+package clusterblueprint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	"github.com/FuturFusion/operations-center/internal/domain"
+	envMock "github.com/FuturFusion/operations-center/internal/environment/mock"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	provisioningClusterBlueprint "github.com/FuturFusion/operations-center/internal/provisioning/cluster_blueprint"
+	svcMock "github.com/FuturFusion/operations-center/internal/provisioning/mock"
+	repoMock "github.com/FuturFusion/operations-center/internal/provisioning/repo/mock"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestClusterBlueprintService_Create(t *testing.T) {
+	tests := []struct {
+		name                      string
+		clusterBlueprint          provisioning.ClusterBlueprint
+		serverSvcGetAllWithFilter error
+		clusterTemplateSvcApply   error
+		repoCreateErr             error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				Name:            "one",
+				ClusterTemplate: "template",
+				ServerFilter:    `properties.site_code == "zrh01"`,
+				MemberCount:     3,
+				ConnectionURL:   "https://zrh01.local:8443",
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - validation",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				Name: "one", // no cluster template
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - serverSvc.GetAllWithFilter",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				Name:            "one",
+				ClusterTemplate: "template",
+				ServerFilter:    `invalid expression`,
+				MemberCount:     3,
+				ConnectionURL:   "https://zrh01.local:8443",
+			},
+			serverSvcGetAllWithFilter: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - clusterTemplateSvc.Apply",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				Name:            "one",
+				ClusterTemplate: "template",
+				ServerFilter:    `true`,
+				MemberCount:     3,
+				ConnectionURL:   "https://zrh01.local:8443",
+			},
+			clusterTemplateSvcApply: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.Create",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				Name:            "one",
+				ClusterTemplate: "template",
+				ServerFilter:    `true`,
+				MemberCount:     3,
+				ConnectionURL:   "https://zrh01.local:8443",
+			},
+			repoCreateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			config.InitTest(t, &envMock.EnvironmentMock{}, nil)
+
+			var gotClusterBlueprint provisioning.ClusterBlueprint
+			repo := &repoMock.ClusterBlueprintRepoMock{
+				CreateFunc: func(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint) (int64, error) {
+					gotClusterBlueprint = clusterBlueprint
+					return 1, tc.repoCreateErr
+				},
+			}
+
+			serverSvc := &svcMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return nil, tc.serverSvcGetAllWithFilter
+				},
+			}
+
+			clusterTemplateSvc := &svcMock.ClusterTemplateServiceMock{
+				ApplyFunc: func(ctx context.Context, name string, templateVariables api.ConfigMap) (map[string]any, map[string]any, error) {
+					return nil, nil, tc.clusterTemplateSvcApply
+				},
+			}
+
+			clusterBlueprintSvc := provisioningClusterBlueprint.New(repo, nil, clusterTemplateSvc, serverSvc)
+
+			// Run test
+			clusterBlueprint, err := clusterBlueprintSvc.Create(t.Context(), tc.clusterBlueprint)
+
+			// Assert
+			tc.assertErr(t, err)
+			if err == nil {
+				require.Equal(t, int64(1), clusterBlueprint.ID)
+				require.Equal(t, api.ClusterBlueprintStatusWaiting, gotClusterBlueprint.Status)
+				require.Equal(t, api.ServerTypeIncus, gotClusterBlueprint.ServerType)
+				require.Equal(t, "stable", gotClusterBlueprint.Channel)
+				require.Equal(t, api.ConfigMap{}, gotClusterBlueprint.ClusterTemplateVariableValues)
+			}
+		})
+	}
+}
+
+func TestClusterBlueprintService_Update(t *testing.T) {
+	clusterBlueprint := provisioning.ClusterBlueprint{
+		Name:            "one",
+		ClusterTemplate: "template",
+		ServerFilter:    `true`,
+		MemberCount:     3,
+		ConnectionURL:   "https://zrh01.local:8443",
+	}
+
+	tests := []struct {
+		name             string
+		clusterBlueprint provisioning.ClusterBlueprint
+		repoGetByName    *provisioning.ClusterBlueprint
+		repoGetByNameErr error
+		repoUpdateErr    error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:             "success - waiting",
+			clusterBlueprint: clusterBlueprint,
+			repoGetByName: &provisioning.ClusterBlueprint{
+				ID:     1,
+				Name:   "one",
+				Status: api.ClusterBlueprintStatusWaiting,
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name:             "success - failed is retried",
+			clusterBlueprint: clusterBlueprint,
+			repoGetByName: &provisioning.ClusterBlueprint{
+				ID:          1,
+				Name:        "one",
+				Status:      api.ClusterBlueprintStatusFailed,
+				ServerNames: provisioning.ClusterBlueprintServerNames{"one"},
+				Error:       "boom",
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - validation",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				Name: "one", // no cluster template
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name:             "error - repo.GetByName",
+			clusterBlueprint: clusterBlueprint,
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:             "error - done",
+			clusterBlueprint: clusterBlueprint,
+			repoGetByName: &provisioning.ClusterBlueprint{
+				ID:     1,
+				Name:   "one",
+				Status: api.ClusterBlueprintStatusDone,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:             "error - repo.Update",
+			clusterBlueprint: clusterBlueprint,
+			repoGetByName: &provisioning.ClusterBlueprint{
+				ID:     1,
+				Name:   "one",
+				Status: api.ClusterBlueprintStatusWaiting,
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			config.InitTest(t, &envMock.EnvironmentMock{}, nil)
+
+			var gotClusterBlueprint *provisioning.ClusterBlueprint
+			repo := &repoMock.ClusterBlueprintRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterBlueprint, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint) error {
+					gotClusterBlueprint = &clusterBlueprint
+					return tc.repoUpdateErr
+				},
+			}
+
+			serverSvc := &svcMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return nil, nil
+				},
+			}
+
+			clusterTemplateSvc := &svcMock.ClusterTemplateServiceMock{
+				ApplyFunc: func(ctx context.Context, name string, templateVariables api.ConfigMap) (map[string]any, map[string]any, error) {
+					return nil, nil, nil
+				},
+			}
+
+			clusterBlueprintSvc := provisioningClusterBlueprint.New(repo, nil, clusterTemplateSvc, serverSvc)
+
+			// Run test
+			err := clusterBlueprintSvc.Update(t.Context(), tc.clusterBlueprint)
+
+			// Assert
+			tc.assertErr(t, err)
+			if err == nil {
+				require.Equal(t, int64(1), gotClusterBlueprint.ID)
+				require.Equal(t, api.ClusterBlueprintStatusWaiting, gotClusterBlueprint.Status)
+				require.Empty(t, gotClusterBlueprint.ServerNames)
+				require.Empty(t, gotClusterBlueprint.Error)
+			}
+		})
+	}
+}
+
+func TestClusterBlueprintService_DeleteByName(t *testing.T) {
+	tests := []struct {
+		name                 string
+		clusterBlueprintName string
+		repoGetByName        *provisioning.ClusterBlueprint
+		repoGetByNameErr     error
+		repoDeleteErr        error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:                 "success",
+			clusterBlueprintName: "one",
+			repoGetByName: &provisioning.ClusterBlueprint{
+				Name:   "one",
+				Status: api.ClusterBlueprintStatusDone,
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name:                 "error - empty name",
+			clusterBlueprintName: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                 "error - repo.GetByName",
+			clusterBlueprintName: "one",
+			repoGetByNameErr:     boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                 "error - forming",
+			clusterBlueprintName: "one",
+			repoGetByName: &provisioning.ClusterBlueprint{
+				Name:   "one",
+				Status: api.ClusterBlueprintStatusForming,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                 "error - repo.DeleteByName",
+			clusterBlueprintName: "one",
+			repoGetByName: &provisioning.ClusterBlueprint{
+				Name:   "one",
+				Status: api.ClusterBlueprintStatusWaiting,
+			},
+			repoDeleteErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ClusterBlueprintRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterBlueprint, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				DeleteByNameFunc: func(ctx context.Context, name string) error {
+					return tc.repoDeleteErr
+				},
+			}
+
+			clusterBlueprintSvc := provisioningClusterBlueprint.New(repo, nil, nil, nil)
+
+			// Run test
+			err := clusterBlueprintSvc.DeleteByName(t.Context(), tc.clusterBlueprintName)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestClusterBlueprintService_ClusterBlueprintControlLoop(t *testing.T) {
+	waiting := provisioning.ClusterBlueprint{
+		Name:            "one",
+		Description:     "Branch office",
+		ClusterTemplate: "template",
+		ClusterTemplateVariableValues: api.ConfigMap{
+			"MGMT_VLAN": "100",
+		},
+		ServerFilter:  `properties.site_code == "zrh01"`,
+		MemberCount:   2,
+		ConnectionURL: "https://zrh01.local:8443",
+		ServerType:    api.ServerTypeIncus,
+		Channel:       "stable",
+		Status:        api.ClusterBlueprintStatusWaiting,
+	}
+
+	withStatus := func(clusterBlueprint provisioning.ClusterBlueprint, status api.ClusterBlueprintStatus, serverNames provisioning.ClusterBlueprintServerNames, errorMessage string) *provisioning.ClusterBlueprint {
+		clusterBlueprint.Status = status
+		clusterBlueprint.ServerNames = serverNames
+		clusterBlueprint.Error = errorMessage
+		return &clusterBlueprint
+	}
+
+	readyServer := func(name string) provisioning.Server {
+		return provisioning.Server{
+			Name:    name,
+			Type:    api.ServerTypeIncus,
+			Status:  api.ServerStatusReady,
+			Channel: "stable",
+		}
+	}
+
+	tests := []struct {
+		name                         string
+		repoGetAll                   provisioning.ClusterBlueprints
+		repoGetAllErr                error
+		repoUpdateErr                error
+		serverSvcGetAllWithFilter    provisioning.Servers
+		serverSvcGetAllWithFilterErr error
+		clusterTemplateSvcApplyErr   error
+		clusterSvcCreateErr          error
+
+		assertErr             require.ErrorAssertionFunc
+		wantCluster           *provisioning.Cluster
+		wantClusterBlueprints []provisioning.ClusterBlueprint
+	}{
+		{
+			name: "success - no cluster blueprints",
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - cluster blueprint not waiting",
+			repoGetAll: provisioning.ClusterBlueprints{
+				*withStatus(waiting, api.ClusterBlueprintStatusDone, provisioning.ClusterBlueprintServerNames{"a", "b"}, ""),
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - not enough candidates",
+			repoGetAll: provisioning.ClusterBlueprints{
+				waiting,
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				readyServer("a"),
+				func() provisioning.Server {
+					server := readyServer("b")
+					server.Cluster = ptr.To("other")
+					return server
+				}(),
+				func() provisioning.Server {
+					server := readyServer("c")
+					server.Status = api.ServerStatusOffline
+					return server
+				}(),
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - cluster formed",
+			repoGetAll: provisioning.ClusterBlueprints{
+				waiting,
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				readyServer("c"),
+				readyServer("a"),
+				readyServer("b"),
+			},
+
+			assertErr: require.NoError,
+			wantCluster: &provisioning.Cluster{
+				Name:                  "one",
+				ConnectionURL:         "https://zrh01.local:8443",
+				ServerNames:           []string{"a", "b"},
+				ServerType:            api.ServerTypeIncus,
+				ServicesConfig:        map[string]any{"lvm": map[string]any{}},
+				ApplicationSeedConfig: map[string]any{},
+				Channel:               "stable",
+				Description:           "Branch office",
+				ClusterTemplate:       "template",
+				ClusterTemplateVariableValues: api.ConfigMap{
+					"MGMT_VLAN": "100",
+					"DEFAULT":   "default",
+				},
+			},
+			wantClusterBlueprints: []provisioning.ClusterBlueprint{
+				*withStatus(waiting, api.ClusterBlueprintStatusForming, provisioning.ClusterBlueprintServerNames{"a", "b"}, ""),
+				*withStatus(waiting, api.ClusterBlueprintStatusDone, provisioning.ClusterBlueprintServerNames{"a", "b"}, ""),
+			},
+		},
+		{
+			name: "success - serverSvc.GetAllWithFilter error fails the cluster blueprint",
+			repoGetAll: provisioning.ClusterBlueprints{
+				waiting,
+			},
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: require.NoError,
+			wantClusterBlueprints: []provisioning.ClusterBlueprint{
+				*withStatus(waiting, api.ClusterBlueprintStatusFailed, nil, "Failed to get servers matching the server filter: boom!"),
+			},
+		},
+		{
+			name: "success - clusterTemplateSvc.Apply error fails the cluster blueprint",
+			repoGetAll: provisioning.ClusterBlueprints{
+				waiting,
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				readyServer("a"),
+				readyServer("b"),
+			},
+			clusterTemplateSvcApplyErr: boom.Error,
+
+			assertErr: require.NoError,
+			wantClusterBlueprints: []provisioning.ClusterBlueprint{
+				*withStatus(waiting, api.ClusterBlueprintStatusForming, provisioning.ClusterBlueprintServerNames{"a", "b"}, ""),
+				*withStatus(waiting, api.ClusterBlueprintStatusFailed, provisioning.ClusterBlueprintServerNames{"a", "b"}, `Failed to apply cluster template "template": boom!`),
+			},
+		},
+		{
+			name: "success - clusterSvc.Create error fails the cluster blueprint",
+			repoGetAll: provisioning.ClusterBlueprints{
+				waiting,
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				readyServer("a"),
+				readyServer("b"),
+			},
+			clusterSvcCreateErr: boom.Error,
+
+			assertErr: require.NoError,
+			wantClusterBlueprints: []provisioning.ClusterBlueprint{
+				*withStatus(waiting, api.ClusterBlueprintStatusForming, provisioning.ClusterBlueprintServerNames{"a", "b"}, ""),
+				*withStatus(waiting, api.ClusterBlueprintStatusFailed, provisioning.ClusterBlueprintServerNames{"a", "b"}, "Failed to create cluster: boom!"),
+			},
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.Update",
+			repoGetAll: provisioning.ClusterBlueprints{
+				waiting,
+			},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				readyServer("a"),
+				readyServer("b"),
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var gotClusterBlueprints []provisioning.ClusterBlueprint
+			status := api.ClusterBlueprintStatusWaiting
+			repo := &repoMock.ClusterBlueprintRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.ClusterBlueprints, error) {
+					return tc.repoGetAll, tc.repoGetAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterBlueprint, error) {
+					return &provisioning.ClusterBlueprint{
+						Name:   name,
+						Status: status,
+					}, nil
+				},
+				UpdateFunc: func(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint) error {
+					if tc.repoUpdateErr != nil {
+						return tc.repoUpdateErr
+					}
+
+					status = clusterBlueprint.Status
+					gotClusterBlueprints = append(gotClusterBlueprints, clusterBlueprint)
+					return nil
+				},
+			}
+
+			serverSvc := &svcMock.ServerServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					require.Equal(t, `properties.site_code == "zrh01"`, *filter.Expression)
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+			}
+
+			clusterTemplateSvc := &svcMock.ClusterTemplateServiceMock{
+				ApplyFunc: func(ctx context.Context, name string, templateVariables api.ConfigMap) (map[string]any, map[string]any, error) {
+					require.Equal(t, "template", name)
+					templateVariables["DEFAULT"] = "default"
+					return map[string]any{"lvm": map[string]any{}}, map[string]any{}, tc.clusterTemplateSvcApplyErr
+				},
+			}
+
+			var gotCluster *provisioning.Cluster
+			clusterSvc := &svcMock.ClusterServiceMock{
+				CreateFunc: func(ctx context.Context, cluster provisioning.Cluster) (provisioning.Cluster, error) {
+					gotCluster = &cluster
+					return cluster, tc.clusterSvcCreateErr
+				},
+			}
+
+			clusterBlueprintSvc := provisioningClusterBlueprint.New(repo, clusterSvc, clusterTemplateSvc, serverSvc)
+
+			// Run test
+			err := clusterBlueprintSvc.ClusterBlueprintControlLoop(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+			if tc.wantCluster != nil {
+				require.Equal(t, tc.wantCluster, gotCluster)
+			}
+
+			require.Equal(t, tc.wantClusterBlueprints, gotClusterBlueprints)
+			require.Equal(t, api.ConfigMap{"MGMT_VLAN": "100"}, waiting.ClusterTemplateVariableValues)
+		})
+	}
+}
+
+func TestClusterBlueprintService_Prune(t *testing.T) {
+	forming := func(name string) provisioning.ClusterBlueprint {
+		return provisioning.ClusterBlueprint{
+			Name:        name,
+			Status:      api.ClusterBlueprintStatusForming,
+			ServerNames: provisioning.ClusterBlueprintServerNames{"a", "b"},
+		}
+	}
+
+	withStatus := func(clusterBlueprint provisioning.ClusterBlueprint, status api.ClusterBlueprintStatus, errorMessage string) provisioning.ClusterBlueprint {
+		clusterBlueprint.Status = status
+		clusterBlueprint.Error = errorMessage
+		return clusterBlueprint
+	}
+
+	tests := []struct {
+		name                string
+		repoGetAll          provisioning.ClusterBlueprints
+		repoGetAllErr       error
+		clusterSvcGetByName map[string]error
+		repoUpdateErr       error
+
+		assertErr             require.ErrorAssertionFunc
+		wantClusterBlueprints []provisioning.ClusterBlueprint
+	}{
+		{
+			name: "success",
+			repoGetAll: provisioning.ClusterBlueprints{
+				{Name: "waiting", Status: api.ClusterBlueprintStatusWaiting},
+				forming("interrupted"),
+				forming("formed"),
+				{Name: "done", Status: api.ClusterBlueprintStatusDone},
+			},
+			clusterSvcGetByName: map[string]error{
+				"interrupted": domain.ErrNotFound,
+				"formed":      nil,
+			},
+
+			assertErr: require.NoError,
+			wantClusterBlueprints: []provisioning.ClusterBlueprint{
+				withStatus(forming("interrupted"), api.ClusterBlueprintStatusFailed, "Cluster formation has been interrupted by a restart of Operations Center"),
+				withStatus(forming("formed"), api.ClusterBlueprintStatusDone, ""),
+			},
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - clusterSvc.GetByName",
+			repoGetAll: provisioning.ClusterBlueprints{
+				forming("one"),
+			},
+			clusterSvcGetByName: map[string]error{
+				"one": boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.Update",
+			repoGetAll: provisioning.ClusterBlueprints{
+				forming("one"),
+			},
+			clusterSvcGetByName: map[string]error{
+				"one": domain.ErrNotFound,
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var gotClusterBlueprints []provisioning.ClusterBlueprint
+			repo := &repoMock.ClusterBlueprintRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.ClusterBlueprints, error) {
+					return tc.repoGetAll, tc.repoGetAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterBlueprint, error) {
+					return &provisioning.ClusterBlueprint{
+						Name:   name,
+						Status: api.ClusterBlueprintStatusForming,
+					}, nil
+				},
+				UpdateFunc: func(ctx context.Context, clusterBlueprint provisioning.ClusterBlueprint) error {
+					if tc.repoUpdateErr != nil {
+						return tc.repoUpdateErr
+					}
+
+					gotClusterBlueprints = append(gotClusterBlueprints, clusterBlueprint)
+					return nil
+				},
+			}
+
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					err, ok := tc.clusterSvcGetByName[name]
+					require.True(t, ok, "unexpected cluster %q", name)
+					return &provisioning.Cluster{Name: name}, err
+				},
+			}
+
+			clusterBlueprintSvc := provisioningClusterBlueprint.New(repo, clusterSvc, nil, nil)
+
+			// Run test
+			err := clusterBlueprintSvc.Prune(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantClusterBlueprints, gotClusterBlueprints)
+		})
+	}
+}
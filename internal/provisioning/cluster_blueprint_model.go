@@ -0,0 +1,136 @@
+package provisioning
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type ClusterBlueprint struct {
+	ID                            int64
+	Name                          string `db:"primary=yes"`
+	Description                   string
+	ClusterTemplate               string `db:"join=cluster_templates.name"`
+	ClusterTemplateVariableValues api.ConfigMap
+	ServerFilter                  string
+	MemberCount                   int
+	ConnectionURL                 string
+	ServerType                    api.ServerType
+	Channel                       string `db:"join=channels.name"`
+	Status                        api.ClusterBlueprintStatus
+	ServerNames                   ClusterBlueprintServerNames
+	Error                         string
+	LastUpdated                   time.Time `db:"update_timestamp"`
+}
+
+func (c ClusterBlueprint) Validate() error {
+	if c.Name == "" {
+		return domain.NewValidationErrf("Invalid cluster blueprint, name can not be empty")
+	}
+
+	if strings.ContainsAny(c.Name, nameProhibitedCharacters) {
+		return domain.NewValidationErrf("Invalid cluster blueprint, name can not contain any of %q", nameProhibitedCharacters)
+	}
+
+	if c.ClusterTemplate == "" {
+		return domain.NewValidationErrf("Invalid cluster blueprint, cluster template can not be empty")
+	}
+
+	if c.ServerFilter == "" {
+		return domain.NewValidationErrf("Invalid cluster blueprint, server filter can not be empty")
+	}
+
+	if c.MemberCount < 1 {
+		return domain.NewValidationErrf("Invalid cluster blueprint, member count needs to be greater or equal to 1")
+	}
+
+	if c.ConnectionURL == "" {
+		return domain.NewValidationErrf("Invalid cluster blueprint, connection URL can not be empty")
+	}
+
+	_, err := url.Parse(c.ConnectionURL)
+	if err != nil {
+		return domain.NewValidationErrf("Invalid cluster blueprint, connection URL is not valid: %v", err)
+	}
+
+	if c.ServerType == api.ServerTypeUnknown || c.ServerType == "" {
+		return domain.NewValidationErrf("Invalid cluster blueprint, server type can not be %q", c.ServerType)
+	}
+
+	return nil
+}
+
+// IsCandidate returns true, if the given server can be selected to form the
+// cluster of the blueprint. A candidate is ready, not yet part of a cluster,
+// not about to join a cluster on its own and matches the server type and,
+// if defined, the update channel of the blueprint.
+func (c ClusterBlueprint) IsCandidate(server Server) bool {
+	if server.Cluster != nil {
+		return false
+	}
+
+	if server.Status != api.ServerStatusReady || server.StatusDetail != api.ServerStatusDetailNone {
+		return false
+	}
+
+	if server.ClusterJoin.Status == api.ServerClusterJoinStatusPending || server.ClusterJoin.Status == api.ServerClusterJoinStatusJoining {
+		return false
+	}
+
+	if server.Type != c.ServerType {
+		return false
+	}
+
+	if c.Channel != "" && server.Channel != c.Channel {
+		return false
+	}
+
+	return true
+}
+
+type ClusterBlueprints []ClusterBlueprint
+
+type ClusterBlueprintServerNames []string
+
+// Value implements the sql driver.Valuer interface.
+func (c ClusterBlueprintServerNames) Value() (driver.Value, error) {
+	if c == nil {
+		c = ClusterBlueprintServerNames{}
+	}
+
+	return json.Marshal(c)
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *ClusterBlueprintServerNames) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid cluster blueprint server names")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*c = ClusterBlueprintServerNames{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), c)
+
+	case []byte:
+		if len(v) == 0 {
+			*c = ClusterBlueprintServerNames{}
+			return nil
+		}
+
+		return json.Unmarshal(v, c)
+
+	default:
+		return fmt.Errorf("type %T is not supported for cluster blueprint server names", value)
+	}
+}
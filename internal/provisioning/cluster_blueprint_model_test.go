@@ -0,0 +1,268 @@
+package provisioning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestClusterBlueprint_Validate(t *testing.T) {
+	validClusterBlueprint := func(modify func(c *provisioning.ClusterBlueprint)) provisioning.ClusterBlueprint {
+		clusterBlueprint := provisioning.ClusterBlueprint{
+			Name:            "one",
+			ClusterTemplate: "template",
+			ServerFilter:    `properties.site_code == "zrh01"`,
+			MemberCount:     3,
+			ConnectionURL:   "https://zrh01.local:8443",
+			ServerType:      api.ServerTypeIncus,
+		}
+
+		if modify != nil {
+			modify(&clusterBlueprint)
+		}
+
+		return clusterBlueprint
+	}
+
+	tests := []struct {
+		name             string
+		clusterBlueprint provisioning.ClusterBlueprint
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:             "valid",
+			clusterBlueprint: validClusterBlueprint(nil),
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - empty name",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.Name = "" // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid name",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.Name = "one/two" // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - empty cluster template",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.ClusterTemplate = "" // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - empty server filter",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.ServerFilter = "" // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - member count",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.MemberCount = 0 // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - empty connection URL",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.ConnectionURL = "" // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid connection URL",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.ConnectionURL = ":|\\" // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - server type unknown",
+			clusterBlueprint: validClusterBlueprint(func(c *provisioning.ClusterBlueprint) {
+				c.ServerType = api.ServerTypeUnknown // invalid
+			}),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.clusterBlueprint.Validate()
+
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestClusterBlueprint_IsCandidate(t *testing.T) {
+	server := func(modify func(s *provisioning.Server)) provisioning.Server {
+		server := provisioning.Server{
+			Name:    "one",
+			Type:    api.ServerTypeIncus,
+			Status:  api.ServerStatusReady,
+			Channel: "stable",
+		}
+
+		if modify != nil {
+			modify(&server)
+		}
+
+		return server
+	}
+
+	tests := []struct {
+		name             string
+		clusterBlueprint provisioning.ClusterBlueprint
+		server           provisioning.Server
+
+		want bool
+	}{
+		{
+			name: "candidate",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+			},
+			server: server(nil),
+
+			want: true,
+		},
+		{
+			name: "candidate - matching channel",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+				Channel:    "stable",
+			},
+			server: server(nil),
+
+			want: true,
+		},
+		{
+			name: "candidate - failed cluster join",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+			},
+			server: server(func(s *provisioning.Server) {
+				s.ClusterJoin.Status = api.ServerClusterJoinStatusFailed
+			}),
+
+			want: true,
+		},
+		{
+			name: "not candidate - clustered",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+			},
+			server: server(func(s *provisioning.Server) {
+				s.Cluster = ptr.To("cluster")
+			}),
+
+			want: false,
+		},
+		{
+			name: "not candidate - offline",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+			},
+			server: server(func(s *provisioning.Server) {
+				s.Status = api.ServerStatusOffline
+			}),
+
+			want: false,
+		},
+		{
+			name: "not candidate - status detail",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+			},
+			server: server(func(s *provisioning.Server) {
+				s.StatusDetail = api.ServerStatusDetailReadyUpdatingOS
+			}),
+
+			want: false,
+		},
+		{
+			name: "not candidate - pending cluster join",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+			},
+			server: server(func(s *provisioning.Server) {
+				s.ClusterJoin.Status = api.ServerClusterJoinStatusPending
+			}),
+
+			want: false,
+		},
+		{
+			name: "not candidate - server type",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeMigrationManager,
+			},
+			server: server(nil),
+
+			want: false,
+		},
+		{
+			name: "not candidate - channel",
+			clusterBlueprint: provisioning.ClusterBlueprint{
+				ServerType: api.ServerTypeIncus,
+				Channel:    "testing",
+			},
+			server: server(nil),
+
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.clusterBlueprint.IsCandidate(tc.server)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package provisioning
+
+import "context"
+
+type ClusterBlueprintService interface {
+	Create(ctx context.Context, clusterBlueprint ClusterBlueprint) (ClusterBlueprint, error)
+	GetAll(ctx context.Context) (ClusterBlueprints, error)
+	GetAllNames(ctx context.Context) ([]string, error)
+	GetByName(ctx context.Context, name string) (*ClusterBlueprint, error)
+	Update(ctx context.Context, clusterBlueprint ClusterBlueprint) error
+	DeleteByName(ctx context.Context, name string) error
+	ClusterBlueprintControlLoop(ctx context.Context) error
+	Prune(ctx context.Context) error
+}
+
+type ClusterBlueprintRepo interface {
+	Create(ctx context.Context, clusterBlueprint ClusterBlueprint) (int64, error)
+	GetAll(ctx context.Context) (ClusterBlueprints, error)
+	GetAllNames(ctx context.Context) ([]string, error)
+	GetByName(ctx context.Context, name string) (*ClusterBlueprint, error)
+	Update(ctx context.Context, clusterBlueprint ClusterBlueprint) error
+	DeleteByName(ctx context.Context, name string) error
+}
@@ -5,32 +5,69 @@ package provisioning
 import (
 	"time"
 
+	"github.com/lxc/incus-os/incus-osd/api/images"
+
 	"github.com/FuturFusion/operations-center/shared/api"
 )
 
+type ExprApiBMCFirmwareUpdatePost struct {
+	ImageURI string                      `json:"image_uri" yaml:"image_uri" expr:"image_uri"`
+	Method   api.BMCFirmwareUpdateMethod `json:"method" yaml:"method" expr:"method"`
+	Targets  []string                    `json:"targets" yaml:"targets" expr:"targets"`
+}
+
+type ExprApiClusterAutoUpdateLaunch struct {
+	LaunchedAt time.Time             `json:"launched_at" yaml:"launched_at" expr:"launched_at"`
+	Version    string                `json:"version" yaml:"version" expr:"version"`
+	Severity   images.UpdateSeverity `json:"severity" yaml:"severity" expr:"severity"`
+	Error      string                `json:"error" yaml:"error" expr:"error"`
+}
+
 type ExprApiClusterConfig struct {
-	RollingRestart ExprApiClusterConfigRollingRestart `json:"rolling_restart" yaml:"rolling_restart" expr:"rolling_restart"`
+	RollingRestart     ExprApiClusterConfigRollingRestart      `json:"rolling_restart" yaml:"rolling_restart" expr:"rolling_restart"`
+	MaintenanceWindows []ExprApiClusterConfigMaintenanceWindow `json:"maintenance_windows" yaml:"maintenance_windows" expr:"maintenance_windows"`
+	AutoUpdate         ExprApiClusterConfigAutoUpdate          `json:"auto_update" yaml:"auto_update" expr:"auto_update"`
+}
+
+type ExprApiClusterConfigAutoUpdate struct {
+	Enabled     bool                  `json:"enabled" yaml:"enabled" expr:"enabled"`
+	MinSeverity images.UpdateSeverity `json:"min_severity" yaml:"min_severity" expr:"min_severity"`
+	MinAge      string                `json:"min_age" yaml:"min_age" expr:"min_age"`
+	Reboot      bool                  `json:"reboot" yaml:"reboot" expr:"reboot"`
+}
+
+type ExprApiClusterConfigMaintenanceWindow struct {
+	Weekdays  []string `json:"weekdays" yaml:"weekdays" expr:"weekdays"`
+	StartTime string   `json:"start_time" yaml:"start_time" expr:"start_time"`
+	EndTime   string   `json:"end_time" yaml:"end_time" expr:"end_time"`
+	Timezone  string   `json:"timezone" yaml:"timezone" expr:"timezone"`
 }
 
 type ExprApiClusterConfigRollingRestart struct {
 	PostRestoreDelay string `json:"post_restore_delay" yaml:"post_restore_delay" expr:"post_restore_delay"`
 	RestoreMode      string `json:"restore_mode" yaml:"restore_mode" expr:"restore_mode"`
+	MaxUnavailable   string `json:"max_unavailable" yaml:"max_unavailable" expr:"max_unavailable"`
 }
 
 type ExprApiClusterUpdateInProgressStatus struct {
-	InProgress        api.ClusterUpdateInProgress `json:"in_progress" yaml:"in_progress" expr:"in_progress"`
-	Error             string                      `json:"error" yaml:"error" expr:"error"`
-	StatusDescription *string                     `json:"status_description,omitempty" yaml:"status_description" expr:"status_description"`
-	EvacuatedBefore   []string                    `json:"evacuated_before" yaml:"evacuated_before" expr:"evacuated_before"`
-	PendingReboot     []string                    `json:"pending_reboot" yaml:"pending_reboot" expr:"pending_reboot"`
-	LastUpdated       time.Time                   `json:"last_updated" yaml:"last_updated" expr:"last_updated"`
+	InProgress            api.ClusterUpdateInProgress      `json:"in_progress" yaml:"in_progress" expr:"in_progress"`
+	Error                 string                           `json:"error" yaml:"error" expr:"error"`
+	HealthGateError       string                           `json:"health_gate_error" yaml:"health_gate_error" expr:"health_gate_error"`
+	StatusDescription     *string                          `json:"status_description,omitempty" yaml:"status_description" expr:"status_description"`
+	EvacuatedBefore       []string                         `json:"evacuated_before" yaml:"evacuated_before" expr:"evacuated_before"`
+	PendingReboot         []string                         `json:"pending_reboot" yaml:"pending_reboot" expr:"pending_reboot"`
+	PendingFirmwareUpdate []string                         `json:"pending_firmware_update,omitempty" yaml:"pending_firmware_update,omitempty" expr:"pending_firmware_update"`
+	FirmwareUpdate        *ExprApiBMCFirmwareUpdatePost    `json:"firmware_update,omitempty" yaml:"firmware_update,omitempty" expr:"firmware_update"`
+	ServerStates          map[string]api.ServerUpdateState `json:"server_states,omitempty" yaml:"server_states" expr:"server_states"`
+	LastUpdated           time.Time                        `json:"last_updated" yaml:"last_updated" expr:"last_updated"`
 }
 
 type ExprApiClusterUpdateStatus struct {
-	NeedsUpdate      []string                             `json:"needs_update,omitempty" yaml:"needs_update" expr:"needs_update"`
-	NeedsReboot      []string                             `json:"needs_reboot,omitempty" yaml:"needs_reboot" expr:"needs_reboot"`
-	InMaintenance    []string                             `json:"in_maintenance,omitempty" yaml:"in_maintenance" expr:"in_maintenance"`
-	InProgressStatus ExprApiClusterUpdateInProgressStatus `json:"in_progress_status" yaml:"in_progress_status" expr:"in_progress_status"`
+	NeedsUpdate        []string                             `json:"needs_update,omitempty" yaml:"needs_update" expr:"needs_update"`
+	NeedsReboot        []string                             `json:"needs_reboot,omitempty" yaml:"needs_reboot" expr:"needs_reboot"`
+	InMaintenance      []string                             `json:"in_maintenance,omitempty" yaml:"in_maintenance" expr:"in_maintenance"`
+	InProgressStatus   ExprApiClusterUpdateInProgressStatus `json:"in_progress_status" yaml:"in_progress_status" expr:"in_progress_status"`
+	AutoUpdateLaunches []ExprApiClusterAutoUpdateLaunch     `json:"auto_update_launches" yaml:"auto_update_launches" expr:"auto_update_launches"`
 }
 
 type ExprCluster struct {
@@ -43,18 +80,56 @@ type ExprCluster struct {
 	UpdateStatus          ExprApiClusterUpdateStatus `json:"update_status" expr:"update_status"`
 	ServerNames           []string                   `json:"server_names"            db:"ignore" expr:"server_names"`
 	ServerType            api.ServerType             `json:"server_type"             db:"ignore" expr:"server_type"`
-	ServicesConfig        map[string]any             `json:"services_config"         db:"ignore" expr:"services_config"`
+	ServicesConfig        ClusterServicesConfig      `json:"services_config" expr:"services_config"`
 	ApplicationSeedConfig map[string]any             `json:"application_seed_config" db:"ignore" expr:"application_seed_config"`
 	Channel               string                     `json:"channel"                 db:"join=channels.name" expr:"channel"`
+	Site                  *string                    `json:"site"                    db:"leftjoin=sites.name" expr:"site"`
 	Description           string                     `json:"description" expr:"description"`
 	Properties            api.ConfigMap              `json:"properties" expr:"properties"`
 	Config                ExprApiClusterConfig       `json:"config" expr:"config"`
 	LastUpdated           time.Time                  `json:"last_updated"            db:"update_timestamp" expr:"last_updated"`
 }
 
+func ToExprApiBMCFirmwareUpdatePost(b api.BMCFirmwareUpdatePost) ExprApiBMCFirmwareUpdatePost {
+	return ExprApiBMCFirmwareUpdatePost{
+		ImageURI: b.ImageURI,
+		Method:   b.Method,
+		Targets:  b.Targets,
+	}
+}
+
+func ToExprApiClusterAutoUpdateLaunch(c api.ClusterAutoUpdateLaunch) ExprApiClusterAutoUpdateLaunch {
+	return ExprApiClusterAutoUpdateLaunch{
+		LaunchedAt: c.LaunchedAt,
+		Version:    c.Version,
+		Severity:   c.Severity,
+		Error:      c.Error,
+	}
+}
+
 func ToExprApiClusterConfig(c api.ClusterConfig) ExprApiClusterConfig {
 	return ExprApiClusterConfig{
-		RollingRestart: ToExprApiClusterConfigRollingRestart(c.RollingRestart),
+		RollingRestart:     ToExprApiClusterConfigRollingRestart(c.RollingRestart),
+		MaintenanceWindows: sliceConvert(c.MaintenanceWindows, ToExprApiClusterConfigMaintenanceWindow),
+		AutoUpdate:         ToExprApiClusterConfigAutoUpdate(c.AutoUpdate),
+	}
+}
+
+func ToExprApiClusterConfigAutoUpdate(c api.ClusterConfigAutoUpdate) ExprApiClusterConfigAutoUpdate {
+	return ExprApiClusterConfigAutoUpdate{
+		Enabled:     c.Enabled,
+		MinSeverity: c.MinSeverity,
+		MinAge:      c.MinAge,
+		Reboot:      c.Reboot,
+	}
+}
+
+func ToExprApiClusterConfigMaintenanceWindow(c api.ClusterConfigMaintenanceWindow) ExprApiClusterConfigMaintenanceWindow {
+	return ExprApiClusterConfigMaintenanceWindow{
+		Weekdays:  c.Weekdays,
+		StartTime: c.StartTime,
+		EndTime:   c.EndTime,
+		Timezone:  c.Timezone,
 	}
 }
 
@@ -62,26 +137,32 @@ func ToExprApiClusterConfigRollingRestart(c api.ClusterConfigRollingRestart) Exp
 	return ExprApiClusterConfigRollingRestart{
 		PostRestoreDelay: c.PostRestoreDelay,
 		RestoreMode:      c.RestoreMode,
+		MaxUnavailable:   c.MaxUnavailable,
 	}
 }
 
 func ToExprApiClusterUpdateInProgressStatus(c api.ClusterUpdateInProgressStatus) ExprApiClusterUpdateInProgressStatus {
 	return ExprApiClusterUpdateInProgressStatus{
-		InProgress:        c.InProgress,
-		Error:             c.Error,
-		StatusDescription: c.StatusDescription,
-		EvacuatedBefore:   c.EvacuatedBefore,
-		PendingReboot:     c.PendingReboot,
-		LastUpdated:       c.LastUpdated,
+		InProgress:            c.InProgress,
+		Error:                 c.Error,
+		HealthGateError:       c.HealthGateError,
+		StatusDescription:     c.StatusDescription,
+		EvacuatedBefore:       c.EvacuatedBefore,
+		PendingReboot:         c.PendingReboot,
+		PendingFirmwareUpdate: c.PendingFirmwareUpdate,
+		FirmwareUpdate:        toPtr(ToExprApiBMCFirmwareUpdatePost(fromPtr(c.FirmwareUpdate))),
+		ServerStates:          c.ServerStates,
+		LastUpdated:           c.LastUpdated,
 	}
 }
 
 func ToExprApiClusterUpdateStatus(c api.ClusterUpdateStatus) ExprApiClusterUpdateStatus {
 	return ExprApiClusterUpdateStatus{
-		NeedsUpdate:      c.NeedsUpdate,
-		NeedsReboot:      c.NeedsReboot,
-		InMaintenance:    c.InMaintenance,
-		InProgressStatus: ToExprApiClusterUpdateInProgressStatus(c.InProgressStatus),
+		NeedsUpdate:        c.NeedsUpdate,
+		NeedsReboot:        c.NeedsReboot,
+		InMaintenance:      c.InMaintenance,
+		InProgressStatus:   ToExprApiClusterUpdateInProgressStatus(c.InProgressStatus),
+		AutoUpdateLaunches: sliceConvert(c.AutoUpdateLaunches, ToExprApiClusterAutoUpdateLaunch),
 	}
 }
 
@@ -99,6 +180,7 @@ func ToExprCluster(c Cluster) ExprCluster {
 		ServicesConfig:        c.ServicesConfig,
 		ApplicationSeedConfig: c.ApplicationSeedConfig,
 		Channel:               c.Channel,
+		Site:                  c.Site,
 		Description:           c.Description,
 		Properties:            c.Properties,
 		Config:                ToExprApiClusterConfig(c.Config),
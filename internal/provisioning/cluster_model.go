@@ -7,9 +7,11 @@ import (
 	"io"
 	"iter"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lxc/incus-os/incus-osd/api/images"
 	incusapi "github.com/lxc/incus/v7/shared/api"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
@@ -20,22 +22,68 @@ import (
 //generate-expr: Cluster
 
 type Cluster struct {
-	ID                    int64                   `json:"-"`
-	Name                  string                  `json:"name"                    db:"primary=yes"`
-	ConnectionURL         string                  `json:"connection_url"`
-	Certificate           *string                 `json:"certificate"`
-	Fingerprint           string                  `json:"fingerprint"             db:"ignore"`
-	Status                api.ClusterStatus       `json:"status"`
-	UpdateStatus          api.ClusterUpdateStatus `json:"update_status"`
-	ServerNames           []string                `json:"server_names"            db:"ignore"`
-	ServerType            api.ServerType          `json:"server_type"             db:"ignore"`
-	ServicesConfig        map[string]any          `json:"services_config"         db:"ignore"`
-	ApplicationSeedConfig map[string]any          `json:"application_seed_config" db:"ignore"`
-	Channel               string                  `json:"channel"                 db:"join=channels.name"`
-	Description           string                  `json:"description"`
-	Properties            api.ConfigMap           `json:"properties"`
-	Config                api.ClusterConfig       `json:"config"`
-	LastUpdated           time.Time               `json:"last_updated"            db:"update_timestamp"`
+	ID                            int64                   `json:"-"`
+	Name                          string                  `json:"name"                             db:"primary=yes"`
+	ConnectionURL                 string                  `json:"connection_url"`
+	Certificate                   *string                 `json:"certificate"`
+	Fingerprint                   string                  `json:"fingerprint"                      db:"ignore"`
+	Status                        api.ClusterStatus       `json:"status"`
+	UpdateStatus                  api.ClusterUpdateStatus `json:"update_status"`
+	ServerNames                   []string                `json:"server_names"                     db:"ignore"`
+	ServerType                    api.ServerType          `json:"server_type"                      db:"ignore"`
+	ServicesConfig                ClusterServicesConfig   `json:"services_config"`
+	ApplicationSeedConfig         map[string]any          `json:"application_seed_config"          db:"ignore"`
+	Channel                       string                  `json:"channel"                          db:"join=channels.name"`
+	Site                          *string                 `json:"site"                             db:"leftjoin=sites.name"`
+	Description                   string                  `json:"description"`
+	Properties                    api.ConfigMap           `json:"properties"`
+	Config                        api.ClusterConfig       `json:"config"`
+	ClusterTemplate               string                  `json:"cluster_template"`
+	ClusterTemplateRevision       int64                   `json:"cluster_template_revision"`
+	ClusterTemplateVariableValues api.ConfigMap           `json:"cluster_template_variable_values"`
+	LastUpdated                   time.Time               `json:"last_updated"                     db:"update_timestamp"`
+}
+
+// ClusterServicesConfig is the services config, a cluster has been created
+// with. It is nil for clusters, which have been adopted or which have been
+// created before the services config has been recorded.
+type ClusterServicesConfig map[string]any
+
+// Value implements the sql driver.Valuer interface.
+func (c ClusterServicesConfig) Value() (driver.Value, error) {
+	if c == nil {
+		return "", nil
+	}
+
+	return json.Marshal(c)
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *ClusterServicesConfig) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid cluster services config")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*c = nil
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), c)
+
+	case []byte:
+		if len(v) == 0 {
+			*c = nil
+			return nil
+		}
+
+		return json.Unmarshal(v, c)
+
+	default:
+		return fmt.Errorf("type %T is not supported for cluster services config", value)
+	}
 }
 
 const nameProhibitedCharacters = `\/:*?"<>|`
@@ -69,9 +117,264 @@ func (c Cluster) Validate() error {
 		return domain.NewValidationErrf(`Invalid cluster, cluster config for rolling restart restore mode is invalid, only "" and "skip" are supported.`)
 	}
 
+	if c.Config.RollingRestart.MaxUnavailable != "" {
+		n, isPercentage, err := parseMaxUnavailable(c.Config.RollingRestart.MaxUnavailable)
+		if err != nil {
+			return domain.NewValidationErrf("Invalid cluster, cluster config for rolling restart max unavailable is invalid: %v", err)
+		}
+
+		if !isPercentage && len(c.ServerNames) > 0 && n > maxUnavailableServers(len(c.ServerNames)) {
+			return domain.NewValidationErrf("Invalid cluster, cluster config for rolling restart max unavailable %q needs to be at most %d to keep a majority of the %d servers online", c.Config.RollingRestart.MaxUnavailable, maxUnavailableServers(len(c.ServerNames)), len(c.ServerNames))
+		}
+	}
+
+	for i, window := range c.Config.MaintenanceWindows {
+		err = validateMaintenanceWindow(window)
+		if err != nil {
+			return domain.NewValidationErrf("Invalid cluster, cluster config maintenance window %d is invalid: %v", i, err)
+		}
+	}
+
+	if c.Config.AutoUpdate.MinSeverity != "" {
+		_, ok := updateSeverityRanks[c.Config.AutoUpdate.MinSeverity]
+		if !ok {
+			return domain.NewValidationErrf("Invalid cluster, cluster config for auto update min severity %q is invalid", c.Config.AutoUpdate.MinSeverity)
+		}
+	}
+
+	if c.Config.AutoUpdate.MinAge != "" {
+		minAge, err := time.ParseDuration(c.Config.AutoUpdate.MinAge)
+		if err != nil || minAge < 0 {
+			return domain.NewValidationErrf("Invalid cluster, cluster config for auto update min age needs to be a valid positive time duration")
+		}
+	}
+
 	return nil
 }
 
+// ClusterUpdateHealthGateStep identifies the point in the rolling update or
+// reboot of a cluster, at which the health gate scriptlet is executed.
+type ClusterUpdateHealthGateStep string
+
+const (
+	// ClusterUpdateHealthGatePreStep is executed before the work on the next
+	// server is started, e.g. before the server is updated or evacuated.
+	ClusterUpdateHealthGatePreStep ClusterUpdateHealthGateStep = "pre_step"
+
+	// ClusterUpdateHealthGatePostStep is executed after a server has been
+	// restored, before the next server is allowed to take its place.
+	ClusterUpdateHealthGatePostStep ClusterUpdateHealthGateStep = "post_step"
+)
+
+// updateSeverityRanks defines the order of the update severities, a higher
+// rank indicates a more severe update.
+var updateSeverityRanks = map[images.UpdateSeverity]int{
+	images.UpdateSeverityNone:     0,
+	images.UpdateSeverityLow:      1,
+	images.UpdateSeverityMedium:   2,
+	images.UpdateSeverityHigh:     3,
+	images.UpdateSeverityCritical: 4,
+}
+
+// AutoUpdateApplies returns true, if the given update causes a cluster update
+// to be launched automatically at the given time according to the auto update
+// policy of the cluster. This is the case, if the severity of the update is at
+// least the configured minimum severity and if the update has been published
+// for at least the configured minimum age.
+func (c Cluster) AutoUpdateApplies(update Update, now time.Time) bool {
+	policy := c.Config.AutoUpdate
+	if !policy.Enabled {
+		return false
+	}
+
+	if updateSeverityRanks[update.Severity] < updateSeverityRanks[policy.MinSeverity] {
+		return false
+	}
+
+	if policy.MinAge != "" {
+		minAge, err := time.ParseDuration(policy.MinAge)
+		if err != nil {
+			return false
+		}
+
+		if now.Sub(update.PublishedAt) < minAge {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseMaxUnavailable parses the max unavailable setting of the rolling restart
+// config, which is either an absolute number of servers or a percentage.
+func parseMaxUnavailable(value string) (_ int, isPercentage bool, _ error) {
+	number, isPercentage := strings.CutSuffix(value, "%")
+
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, false, fmt.Errorf("%q is neither a number nor a percentage", value)
+	}
+
+	if n < 1 {
+		return 0, false, fmt.Errorf("%q needs to be at least 1", value)
+	}
+
+	if isPercentage && n >= 50 {
+		return 0, false, fmt.Errorf("%q needs to be less than 50%% to keep a majority of the servers online", value)
+	}
+
+	return n, isPercentage, nil
+}
+
+// MaxUnavailable returns the number of servers, that are processed at the same
+// time during a rolling update or a rolling reboot of a cluster with the given
+// number of servers. The result is at least 1, but never more than a minority
+// of the servers, such that a majority of the cluster members stays online.
+func (c Cluster) MaxUnavailable(serverCount int) int {
+	n, isPercentage, err := parseMaxUnavailable(c.Config.RollingRestart.MaxUnavailable)
+	if err != nil {
+		return 1
+	}
+
+	if isPercentage {
+		n = serverCount * n / 100
+	}
+
+	return max(min(n, maxUnavailableServers(serverCount)), 1)
+}
+
+// maxUnavailableServers returns the maximum number of servers of a cluster
+// with the given number of servers, which can be unavailable at the same time
+// while a majority of the servers stays online. At least one server is always
+// allowed to be unavailable, otherwise no rolling update could make progress.
+func maxUnavailableServers(serverCount int) int {
+	return max((serverCount-1)/2, 1)
+}
+
+var maintenanceWindowWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func validateMaintenanceWindow(window api.ClusterConfigMaintenanceWindow) error {
+	for _, weekday := range window.Weekdays {
+		_, ok := maintenanceWindowWeekdays[weekday]
+		if !ok {
+			return fmt.Errorf("weekday %q is not valid", weekday)
+		}
+	}
+
+	start, err := parseTimeOfDay(window.StartTime)
+	if err != nil {
+		return fmt.Errorf("start time: %w", err)
+	}
+
+	end, err := parseTimeOfDay(window.EndTime)
+	if err != nil {
+		return fmt.Errorf("end time: %w", err)
+	}
+
+	if start == end {
+		return fmt.Errorf("start time and end time can not be the same")
+	}
+
+	_, err = time.LoadLocation(window.Timezone)
+	if err != nil {
+		return fmt.Errorf("timezone %q is not valid: %w", window.Timezone, err)
+	}
+
+	return nil
+}
+
+// parseTimeOfDay parses a time of the day in the format HH:MM and returns it as
+// the duration since midnight.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid time of the day (HH:MM)", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// InMaintenanceWindow returns true, if t is within one of the maintenance
+// windows of the cluster. A cluster without maintenance windows is always
+// considered to be within its maintenance window.
+func (c Cluster) InMaintenanceWindow(t time.Time) bool {
+	return inMaintenanceWindows(c.Config.MaintenanceWindows, t)
+}
+
+func inMaintenanceWindows(windows []api.ClusterConfigMaintenanceWindow, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+
+	for _, window := range windows {
+		if maintenanceWindowContains(window, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func maintenanceWindowContains(window api.ClusterConfigMaintenanceWindow, t time.Time) bool {
+	// The maintenance window is validated on save, an invalid window is never
+	// considered to be open.
+	location, err := time.LoadLocation(window.Timezone)
+	if err != nil {
+		return false
+	}
+
+	start, err := parseTimeOfDay(window.StartTime)
+	if err != nil {
+		return false
+	}
+
+	end, err := parseTimeOfDay(window.EndTime)
+	if err != nil {
+		return false
+	}
+
+	t = t.In(location)
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	opensOn := func(weekday time.Weekday) bool {
+		if len(window.Weekdays) == 0 {
+			return true
+		}
+
+		for _, name := range window.Weekdays {
+			if maintenanceWindowWeekdays[name] == weekday {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if start < end {
+		return sinceMidnight >= start && sinceMidnight < end && opensOn(t.Weekday())
+	}
+
+	// The maintenance window spans midnight, the weekdays refer to the day, the
+	// window opens.
+	if sinceMidnight >= start {
+		return opensOn(t.Weekday())
+	}
+
+	if sinceMidnight < end {
+		return opensOn((t.Weekday() + 6) % 7)
+	}
+
+	return false
+}
+
 func (c Cluster) ValidateCreate() error {
 	err := c.Validate()
 	if err != nil {
@@ -109,18 +412,39 @@ func (c Cluster) IsUpdateInProgress() bool {
 	return c.UpdateStatus.InProgressStatus.InProgress != api.ClusterUpdateInProgressInactive
 }
 
+// TemplateReference returns the reference to the cluster template, the
+// cluster has been created from, or nil, if no cluster template has been used.
+// The values of the variables, which are marked as secret in the given
+// variable definitions, are masked.
+func (c Cluster) TemplateReference(variables api.ClusterTemplateVariables) *api.ClusterTemplateReference {
+	if c.ClusterTemplate == "" {
+		return nil
+	}
+
+	return &api.ClusterTemplateReference{
+		Name:           c.ClusterTemplate,
+		Revision:       c.ClusterTemplateRevision,
+		VariableValues: MaskClusterTemplateVariableValues(variables, c.ClusterTemplateVariableValues),
+	}
+}
+
 type Clusters []Cluster
 
 type ClusterFilter struct {
 	Name       *string
+	Site       *string
 	Expression *string `db:"ignore"`
 }
 
 func (f ClusterFilter) IsEmpty() bool {
-	return f.Name == nil
+	return f.Name == nil && f.Site == nil
 }
 
 func (f ClusterFilter) AppendToURLValues(query url.Values) url.Values {
+	if f.Site != nil {
+		query.Add("site", *f.Site)
+	}
+
 	if f.Expression != nil {
 		query.Add("filter", *f.Expression)
 	}
@@ -190,6 +514,16 @@ type ClusterProvisioningConfig struct {
 	NodeSpecificConfigKeys map[string]map[string]bool
 }
 
+// ClusterProvisioningPlan is the result of a plan-only pass of a provisioner.
+type ClusterProvisioningPlan struct {
+	// Output is the human readable output of the plan.
+	Output string
+
+	// ChangedResources holds the sorted addresses of the resources, which
+	// would be changed, if the configuration would be applied again.
+	ChangedResources []string
+}
+
 type ClusterArtifact struct {
 	ID          int64
 	Cluster     string `db:"primary=yes&join=clusters.name"`
@@ -3,7 +3,9 @@ package provisioning_test
 import (
 	"database/sql/driver"
 	"testing"
+	"time"
 
+	"github.com/lxc/incus-os/incus-osd/api/images"
 	"github.com/stretchr/testify/require"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
@@ -161,6 +163,362 @@ func TestCluster_ValidateCreate(t *testing.T) {
 				require.ErrorAs(tt, err, &verr, a...)
 			},
 		},
+		{
+			name: "valid - cluster config rolling restart max unavailable count",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2", "server3", "server4", "server5"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "2",
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "valid - cluster config rolling restart max unavailable percentage",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "25%",
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - invalid cluster config rolling restart max unavailable",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "invalid", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - cluster config rolling restart max unavailable zero",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "0", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - cluster config rolling restart max unavailable percentage above 100",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "101%", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - cluster config rolling restart max unavailable percentage 50",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "50%", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - cluster config rolling restart max unavailable count above minority",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2", "server3", "server4", "server5"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: "3", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "valid - with maintenance window",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							Weekdays:  []string{"saturday", "sunday"},
+							StartTime: "22:00",
+							EndTime:   "04:00",
+							Timezone:  "Europe/Zurich",
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - invalid cluster config maintenance window invalid weekday",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							Weekdays:  []string{"caturday"}, // invalid
+							StartTime: "22:00",
+							EndTime:   "04:00",
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid cluster config maintenance window invalid start time",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "25:00", // invalid
+							EndTime:   "04:00",
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid cluster config maintenance window invalid end time",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "22:00",
+							EndTime:   "4 am", // invalid
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid cluster config maintenance window same start and end time",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "22:00",
+							EndTime:   "22:00", // same as start time
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid cluster config maintenance window invalid timezone",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "22:00",
+							EndTime:   "04:00",
+							Timezone:  "Mars/Olympus_Mons", // invalid
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "valid - with auto update",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					AutoUpdate: api.ClusterConfigAutoUpdate{
+						Enabled:     true,
+						MinSeverity: images.UpdateSeverityHigh,
+						MinAge:      "72h",
+						Reboot:      true,
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - invalid cluster config auto update min severity",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					AutoUpdate: api.ClusterConfigAutoUpdate{
+						Enabled:     true,
+						MinSeverity: "invalid", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid cluster config auto update min age",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					AutoUpdate: api.ClusterConfigAutoUpdate{
+						Enabled: true,
+						MinAge:  "invalid", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid cluster config auto update negative min age",
+			cluster: provisioning.Cluster{
+				Name:          "one",
+				ServerNames:   []string{"server1", "server2"},
+				ConnectionURL: "http://one/",
+				ServerType:    api.ServerTypeIncus,
+				Channel:       "stable",
+				Config: api.ClusterConfig{
+					AutoUpdate: api.ClusterConfigAutoUpdate{
+						Enabled: true,
+						MinAge:  "-1h", // negative
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
 		{
 			name: "error - application seed config marshal",
 			cluster: provisioning.Cluster{
@@ -202,6 +560,369 @@ func TestCluster_ValidateCreate(t *testing.T) {
 	}
 }
 
+func TestCluster_InMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []api.ClusterConfigMaintenanceWindow
+		now     time.Time
+
+		want bool
+	}{
+		{
+			name: "no maintenance windows",
+			now:  time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC), // Saturday
+
+			want: true,
+		},
+		{
+			name: "within window - every day",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+			now: time.Date(2025, 3, 12, 10, 0, 0, 0, time.UTC), // Wednesday
+
+			want: true,
+		},
+		{
+			name: "outside window - end time is exclusive",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+			now: time.Date(2025, 3, 12, 14, 0, 0, 0, time.UTC), // Wednesday
+
+			want: false,
+		},
+		{
+			name: "outside window - wrong weekday",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"sunday"},
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+			now: time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC), // Saturday
+
+			want: false,
+		},
+		{
+			name: "within window - spans midnight, before midnight",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"saturday"},
+					StartTime: "22:00",
+					EndTime:   "04:00",
+				},
+			},
+			now: time.Date(2025, 3, 15, 23, 30, 0, 0, time.UTC), // Saturday
+
+			want: true,
+		},
+		{
+			name: "within window - spans midnight, after midnight",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"saturday"},
+					StartTime: "22:00",
+					EndTime:   "04:00",
+				},
+			},
+			now: time.Date(2025, 3, 16, 3, 59, 0, 0, time.UTC), // Sunday
+
+			want: true,
+		},
+		{
+			name: "outside window - spans midnight, opened on the wrong weekday",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"saturday"},
+					StartTime: "22:00",
+					EndTime:   "04:00",
+				},
+			},
+			now: time.Date(2025, 3, 15, 3, 0, 0, 0, time.UTC), // Saturday, window opened on Friday
+
+			want: false,
+		},
+		{
+			name: "within window - timezone",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"sunday"},
+					StartTime: "00:00",
+					EndTime:   "02:00",
+					Timezone:  "Asia/Tokyo",
+				},
+			},
+			now: time.Date(2025, 3, 15, 16, 0, 0, 0, time.UTC), // Saturday in UTC, Sunday 01:00 in Tokyo
+
+			want: true,
+		},
+		{
+			name: "within window - second of multiple windows",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"monday"},
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+				{
+					Weekdays:  []string{"saturday"},
+					StartTime: "10:00",
+					EndTime:   "14:00",
+				},
+			},
+			now: time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC), // Saturday
+
+			want: true,
+		},
+		{
+			name: "outside window - invalid window is never open",
+			windows: []api.ClusterConfigMaintenanceWindow{
+				{
+					StartTime: "invalid",
+					EndTime:   "14:00",
+				},
+			},
+			now: time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC), // Saturday
+
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := provisioning.Cluster{
+				Config: api.ClusterConfig{
+					MaintenanceWindows: tc.windows,
+				},
+			}
+
+			got := cluster.InMaintenanceWindow(tc.now)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCluster_AutoUpdateApplies(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		autoUpdate api.ClusterConfigAutoUpdate
+		update     provisioning.Update
+
+		want bool
+	}{
+		{
+			name: "disabled",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled: false,
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityCritical,
+				PublishedAt: now.Add(-24 * time.Hour),
+			},
+
+			want: false,
+		},
+		{
+			name: "enabled - default policy applies to every update",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled: true,
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityNone,
+				PublishedAt: now,
+			},
+
+			want: true,
+		},
+		{
+			name: "enabled - severity equal to min severity",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled:     true,
+				MinSeverity: images.UpdateSeverityHigh,
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityHigh,
+				PublishedAt: now,
+			},
+
+			want: true,
+		},
+		{
+			name: "enabled - severity above min severity",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled:     true,
+				MinSeverity: images.UpdateSeverityMedium,
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityCritical,
+				PublishedAt: now,
+			},
+
+			want: true,
+		},
+		{
+			name: "enabled - severity below min severity",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled:     true,
+				MinSeverity: images.UpdateSeverityHigh,
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityLow,
+				PublishedAt: now.Add(-24 * time.Hour),
+			},
+
+			want: false,
+		},
+		{
+			name: "enabled - min age reached",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled: true,
+				MinAge:  "24h",
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityLow,
+				PublishedAt: now.Add(-24 * time.Hour),
+			},
+
+			want: true,
+		},
+		{
+			name: "enabled - min age not yet reached",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled: true,
+				MinAge:  "24h",
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityCritical,
+				PublishedAt: now.Add(-23 * time.Hour),
+			},
+
+			want: false,
+		},
+		{
+			name: "enabled - invalid min age",
+			autoUpdate: api.ClusterConfigAutoUpdate{
+				Enabled: true,
+				MinAge:  "invalid",
+			},
+			update: provisioning.Update{
+				Severity:    images.UpdateSeverityCritical,
+				PublishedAt: now.Add(-24 * time.Hour),
+			},
+
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := provisioning.Cluster{
+				Config: api.ClusterConfig{
+					AutoUpdate: tc.autoUpdate,
+				},
+			}
+
+			got := cluster.AutoUpdateApplies(tc.update, now)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCluster_MaxUnavailable(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxUnavailable string
+		serverCount    int
+
+		want int
+	}{
+		{
+			name:           "default",
+			maxUnavailable: "",
+			serverCount:    5,
+
+			want: 1,
+		},
+		{
+			name:           "count",
+			maxUnavailable: "2",
+			serverCount:    5,
+
+			want: 2,
+		},
+		{
+			name:           "count - limited by majority of servers",
+			maxUnavailable: "10",
+			serverCount:    5,
+
+			want: 2,
+		},
+		{
+			name:           "count - at least one server in small cluster",
+			maxUnavailable: "2",
+			serverCount:    2,
+
+			want: 1,
+		},
+		{
+			name:           "percentage - rounded down",
+			maxUnavailable: "40%",
+			serverCount:    9,
+
+			want: 3,
+		},
+		{
+			name:           "percentage - at least one server",
+			maxUnavailable: "10%",
+			serverCount:    5,
+
+			want: 1,
+		},
+		{
+			name:           "percentage - limited by majority of servers",
+			maxUnavailable: "100%",
+			serverCount:    5,
+
+			want: 2,
+		},
+		{
+			name:           "invalid",
+			maxUnavailable: "invalid",
+			serverCount:    5,
+
+			want: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := provisioning.Cluster{
+				Config: api.ClusterConfig{
+					RollingRestart: api.ClusterConfigRollingRestart{
+						MaxUnavailable: tc.maxUnavailable,
+					},
+				},
+			}
+
+			got := cluster.MaxUnavailable(tc.serverCount)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestCluster_IsUpdateInProgress(t *testing.T) {
 	tests := []struct {
 		name    string
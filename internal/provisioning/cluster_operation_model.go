@@ -0,0 +1,70 @@
+package provisioning
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type ClusterOperation struct {
+	ID         int64
+	UUID       uuid.UUID `db:"primary=yes"`
+	Cluster    string    `db:"join=clusters.name"`
+	Type       api.ClusterUpdateInProgress
+	Status     api.ClusterOperationStatus
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Events     ClusterOperationEvents `db:"ignore"`
+}
+
+type ClusterOperations []ClusterOperation
+
+type ClusterOperationFilter struct {
+	Cluster *string
+	Status  *api.ClusterOperationStatus
+}
+
+type ClusterOperationEvent struct {
+	ID         int64
+	Operation  uuid.UUID `db:"primary=yes&join=cluster_operations.uuid"`
+	Server     string
+	Action     api.ClusterOperationEventAction
+	StartedAt  time.Time
+	FinishedAt time.Time `db:"ignore"`
+}
+
+type ClusterOperationEvents []ClusterOperationEvent
+
+// WithFinishTimes returns the events with the finish time of each step set. A
+// step is finished, when the next step on the same server is started or, for
+// the last step of a server, when the operation has finished. The done event
+// of a server is a point in time and therefore finishes immediately.
+func (e ClusterOperationEvents) WithFinishTimes(operationFinishedAt time.Time) ClusterOperationEvents {
+	events := make(ClusterOperationEvents, len(e))
+	copy(events, e)
+
+	lastStep := map[string]int{}
+	for i := range events {
+		previous, ok := lastStep[events[i].Server]
+		if ok {
+			events[previous].FinishedAt = events[i].StartedAt
+		}
+
+		if events[i].Action == api.ClusterOperationEventActionDone {
+			events[i].FinishedAt = events[i].StartedAt
+			delete(lastStep, events[i].Server)
+			continue
+		}
+
+		lastStep[events[i].Server] = i
+	}
+
+	for _, i := range lastStep {
+		events[i].FinishedAt = operationFinishedAt
+	}
+
+	return events
+}
@@ -0,0 +1,90 @@
+package provisioning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestClusterOperationEvents_WithFinishTimes(t *testing.T) {
+	start := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return start.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	tests := []struct {
+		name                string
+		events              provisioning.ClusterOperationEvents
+		operationFinishedAt time.Time
+
+		want provisioning.ClusterOperationEvents
+	}{
+		{
+			name: "empty",
+
+			want: provisioning.ClusterOperationEvents{},
+		},
+		{
+			name: "single server - completed",
+			events: provisioning.ClusterOperationEvents{
+				{Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(1)},
+				{Server: "one", Action: api.ClusterOperationEventActionReboot, StartedAt: at(3)},
+				{Server: "one", Action: api.ClusterOperationEventActionRestore, StartedAt: at(6)},
+				{Server: "one", Action: api.ClusterOperationEventActionDone, StartedAt: at(8)},
+			},
+			operationFinishedAt: at(9),
+
+			want: provisioning.ClusterOperationEvents{
+				{Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(1), FinishedAt: at(3)},
+				{Server: "one", Action: api.ClusterOperationEventActionReboot, StartedAt: at(3), FinishedAt: at(6)},
+				{Server: "one", Action: api.ClusterOperationEventActionRestore, StartedAt: at(6), FinishedAt: at(8)},
+				{Server: "one", Action: api.ClusterOperationEventActionDone, StartedAt: at(8), FinishedAt: at(8)},
+			},
+		},
+		{
+			name: "multiple servers - interleaved",
+			events: provisioning.ClusterOperationEvents{
+				{Server: "one", Action: api.ClusterOperationEventActionUpdate, StartedAt: at(1)},
+				{Server: "two", Action: api.ClusterOperationEventActionUpdate, StartedAt: at(2)},
+				{Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(5)},
+				{Server: "two", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(7)},
+			},
+			operationFinishedAt: at(10),
+
+			want: provisioning.ClusterOperationEvents{
+				{Server: "one", Action: api.ClusterOperationEventActionUpdate, StartedAt: at(1), FinishedAt: at(5)},
+				{Server: "two", Action: api.ClusterOperationEventActionUpdate, StartedAt: at(2), FinishedAt: at(7)},
+				{Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(5), FinishedAt: at(10)},
+				{Server: "two", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(7), FinishedAt: at(10)},
+			},
+		},
+		{
+			name: "operation running",
+			events: provisioning.ClusterOperationEvents{
+				{Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(1)},
+				{Server: "one", Action: api.ClusterOperationEventActionReboot, StartedAt: at(3)},
+			},
+
+			want: provisioning.ClusterOperationEvents{
+				{Server: "one", Action: api.ClusterOperationEventActionEvacuate, StartedAt: at(1), FinishedAt: at(3)},
+				{Server: "one", Action: api.ClusterOperationEventActionReboot, StartedAt: at(3)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.events.WithFinishTimes(tc.operationFinishedAt)
+
+			require.Equal(t, tc.want, got)
+			// The events are not modified in place.
+			for _, event := range tc.events {
+				require.True(t, event.FinishedAt.IsZero())
+			}
+		})
+	}
+}
@@ -15,8 +15,14 @@ import (
 
 type ClusterService interface {
 	Create(ctx context.Context, cluster Cluster) (Cluster, error)
+	Adopt(ctx context.Context, cluster Cluster) (Cluster, error)
 	AddServers(ctx context.Context, name string, serverNames []string, skipPostJoinOperations bool, copyServicesConfig bool) error
 	RemoveServer(ctx context.Context, name string, removedServerNames []string) error
+	ReplaceServer(ctx context.Context, name string, serverName string, replacementServerName string) error
+	PlanClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error)
+	ApplyClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error)
+	GetClusterTemplateDrift(ctx context.Context, name string) (api.ClusterTemplateDrift, error)
+	GetClusterConsistency(ctx context.Context, name string) (api.ClusterConsistency, error)
 	GetAll(ctx context.Context) (Clusters, error)
 	GetAllWithFilter(ctx context.Context, filter ClusterFilter) (Clusters, error)
 	GetAllNames(ctx context.Context) ([]string, error)
@@ -34,7 +40,14 @@ type ClusterService interface {
 	IsInstanceLifecycleOperationPermitted(ctx context.Context, name string) bool
 	LaunchClusterUpdate(ctx context.Context, name string, reboot bool) error
 	LaunchClusterReboot(ctx context.Context, name string) error
+	LaunchClusterFirmwareUpdate(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error
+	PlanClusterUpdate(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error)
+	PlanClusterReboot(ctx context.Context, name string) (api.ClusterUpdatePlan, error)
+	LaunchAutomaticClusterUpdates(ctx context.Context) error
+	DetectConfigurationDrift(ctx context.Context) error
+	CheckConsistency(ctx context.Context) error
 	AbortClusterOperation(ctx context.Context, name string) error
+	GetOperationAll(ctx context.Context, name string) (ClusterOperations, error)
 	ClusterUpdateControlLoop(ctx context.Context, clusterNameFilter *string) error
 
 	GetClusterArtifactAll(ctx context.Context, clusterName string) (ClusterArtifacts, error)
@@ -55,6 +68,10 @@ type ClusterService interface {
 	RemoveStorageTargetMultipath(ctx context.Context, clusterName string, target string) error
 	AddStorageTargetNVME(ctx context.Context, clusterName string, target incusosapi.ServiceNVMETarget) error
 	RemoveStorageTargetNVME(ctx context.Context, clusterName string, target incusosapi.ServiceNVMETarget) error
+	AddServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string, cephCluster incusosapi.ServiceCephCluster) error
+	RemoveServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string) error
+	UpdateServiceOVN(ctx context.Context, clusterName string, ovnConfig incusosapi.ServiceOVNConfig) error
+	UpdateServiceLinstor(ctx context.Context, clusterName string, linstorConfig incusosapi.ServiceLinstorConfig) error
 }
 
 type ClusterRepo interface {
@@ -69,12 +86,22 @@ type ClusterRepo interface {
 	DeleteByName(ctx context.Context, name string) error
 }
 
+type ClusterOperationRepo interface {
+	Create(ctx context.Context, operation ClusterOperation) (int64, error)
+	GetAllWithFilter(ctx context.Context, filter ClusterOperationFilter) (ClusterOperations, error)
+	Update(ctx context.Context, operation ClusterOperation) error
+	DeleteExceptLatestByCluster(ctx context.Context, clusterName string, keep int) error
+	CreateEvent(ctx context.Context, event ClusterOperationEvent) (int64, error)
+	GetEventAll(ctx context.Context, operationUUID uuid.UUID) (ClusterOperationEvents, error)
+}
+
 type ClusterArtifactRepo interface {
 	CreateClusterArtifactFromPath(ctx context.Context, artifact ClusterArtifact, path string, ignoredFiles []string) (int64, error)
 	GetClusterArtifactAll(ctx context.Context, clusterName string) (ClusterArtifacts, error)
 	GetClusterArtifactAllNames(ctx context.Context, clusterName string) ([]string, error)
 	GetClusterArtifactByName(ctx context.Context, clusterName string, artifactName string) (*ClusterArtifact, error)
 	GetClusterArtifactArchiveByName(ctx context.Context, clusterName string, artifactName string, archiveType ClusterArtifactArchiveType) (_ io.ReadCloser, size int, _ error)
+	DeleteClusterArtifactByName(ctx context.Context, clusterName string, artifactName string) error
 }
 
 type InventorySyncer interface {
@@ -114,8 +141,18 @@ type ClusterClientPort interface {
 
 type InstanceServer = incus.InstanceServer
 
+type ClusterScriptletPort interface {
+	ClusterUpdateHealthGateRun(ctx context.Context, step ClusterUpdateHealthGateStep, cluster Cluster, server Server) error
+}
+
 type ClusterProvisioningPort interface {
 	Init(ctx context.Context, clusterName string, config ClusterProvisioningConfig) (temporaryPath string, cleanup func() error, _ error)
 	SeedCertificate(ctx context.Context, clusterName string, certificate string) error
 	Apply(ctx context.Context, cluster Cluster) error
 }
+
+// ClusterProvisioningDriftPort performs a plan-only pass of a provisioner
+// against an existing cluster, using the configuration stored in configPath.
+type ClusterProvisioningDriftPort interface {
+	Plan(ctx context.Context, cluster Cluster, configPath string) (ClusterProvisioningPlan, error)
+}
@@ -0,0 +1,341 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"slices"
+
+	incusapi "github.com/lxc/incus/v7/shared/api"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// IncusPreseedWithDefaults returns the Incus preseed from the application
+// seed config of a cluster, complemented with the defaults for the resources,
+// which are expected by Operations Center to be present on every cluster.
+func IncusPreseedWithDefaults(config map[string]any) (incusapi.InitLocalPreseed, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return incusapi.InitLocalPreseed{}, err
+	}
+
+	preseed := incusapi.InitLocalPreseed{
+		ServerPut: incusapi.ServerPut{
+			Config: map[string]string{},
+		},
+	}
+
+	err = json.Unmarshal(body, &preseed)
+	if err != nil {
+		return incusapi.InitLocalPreseed{}, err
+	}
+
+	// Default values for server configuration.
+	_, ok := preseed.Config["storage.backups_volume"]
+	if !ok {
+		preseed.Config["storage.backups_volume"] = "local/backups"
+	}
+
+	_, ok = preseed.Config["storage.images_volume"]
+	if !ok {
+		preseed.Config["storage.images_volume"] = "local/images"
+	}
+
+	_, ok = preseed.Config["storage.logs_volume"]
+	if !ok {
+		preseed.Config["storage.logs_volume"] = "local/logs"
+	}
+
+	// Set default configuration for local storage pool, if the local storage pool
+	// exists in the preseed.
+	var hasLocalStoragePool bool
+	for i := range preseed.StoragePools {
+		switch preseed.StoragePools[i].Name {
+		case "local":
+			if preseed.StoragePools[i].Description == "" {
+				preseed.StoragePools[i].Description = "Local storage pool (on system drive)"
+			}
+
+			if preseed.StoragePools[i].Config == nil {
+				preseed.StoragePools[i].Config = map[string]string{}
+			}
+
+			_, ok := preseed.StoragePools[i].Config["source"]
+			if !ok {
+				preseed.StoragePools[i].Config["source"] = "local/incus"
+			}
+
+			hasLocalStoragePool = true
+		}
+	}
+
+	// Add local storage pool, if it is not defined in the preseed.
+	if !hasLocalStoragePool {
+		preseed.StoragePools = append(preseed.StoragePools, incusapi.StoragePoolsPost{
+			Name:   "local",
+			Driver: "zfs",
+			StoragePoolPut: incusapi.StoragePoolPut{
+				Config: map[string]string{
+					"source": "local/incus",
+				},
+				Description: "Local storage pool (on system drive)",
+			},
+		})
+	}
+
+	// Set default configuration for the internal project, if the default project
+	// exists in the preseed.
+	var hasInternalProject bool
+	for i := range preseed.Projects {
+		switch preseed.Projects[i].Name {
+		case "internal":
+			if preseed.Projects[i].Description == "" {
+				preseed.Projects[i].Description = "Internal project to isolate fully managed resources."
+			}
+
+			hasInternalProject = true
+		}
+	}
+
+	// Add internal project, if it is not defined in the preseed.
+	if !hasInternalProject {
+		preseed.Projects = append(preseed.Projects, incusapi.ProjectsPost{
+			Name: "internal",
+			ProjectPut: incusapi.ProjectPut{
+				Description: "Internal project to isolate fully managed resources.",
+			},
+		})
+	}
+
+	// Set default configuration for the incusbr0 network, if the incusbr0 network
+	// exists in the preseed.
+	var hasIncusbr0Network bool
+	for i := range preseed.Networks {
+		switch preseed.Networks[i].Name {
+		case "incusbr0":
+			if preseed.Networks[i].Description == "" {
+				preseed.Networks[i].Description = "Local network bridge (NAT)"
+			}
+
+			hasIncusbr0Network = true
+		}
+	}
+
+	// Network meshbr0 is reserved and can not be overwritten with the seed config.
+	// Ensure, it is not present in the preseed.
+	for i := range preseed.Networks {
+		if preseed.Networks[i].Name == "meshbr0" {
+			preseed.Networks = slices.Delete(preseed.Networks, i, i+1)
+			break
+		}
+	}
+
+	// Add incusbr0 network, if it is not defined in the preseed.
+	if !hasIncusbr0Network {
+		preseed.Networks = append(preseed.Networks, incusapi.InitNetworksProjectPost{
+			NetworksPost: incusapi.NetworksPost{
+				Name: "incusbr0",
+				Type: "bridge",
+				NetworkPut: incusapi.NetworkPut{
+					Description: "Local network bridge (NAT)",
+				},
+			},
+		})
+	}
+
+	// Set default configuration for the backups and images storage volumes on
+	// the local storage pool, if they exist in the preseed.
+	var hasLocalBackupsStorageVolume bool
+	var hasLocalImagesStorageVolume bool
+	var hasLocalLogsStorageVolume bool
+	for i := range preseed.StorageVolumes {
+		switch {
+		case preseed.StorageVolumes[i].Pool == "local" && preseed.StorageVolumes[i].Name == "backups":
+			if preseed.StorageVolumes[i].Description == "" {
+				preseed.StorageVolumes[i].Description = "Volume holding system backups"
+			}
+
+			hasLocalBackupsStorageVolume = true
+
+		case preseed.StorageVolumes[i].Pool == "local" && preseed.StorageVolumes[i].Name == "images":
+			if preseed.StorageVolumes[i].Description == "" {
+				preseed.StorageVolumes[i].Description = "Volume holding system images"
+			}
+
+			hasLocalImagesStorageVolume = true
+
+		case preseed.StorageVolumes[i].Pool == "local" && preseed.StorageVolumes[i].Name == "logs":
+			if preseed.StorageVolumes[i].Description == "" {
+				preseed.StorageVolumes[i].Description = "Volume holding system logs"
+			}
+
+			hasLocalLogsStorageVolume = true
+		}
+	}
+
+	// Add backups storage volume on the local storage pool if it is not defined
+	// in the preseed.
+	if !hasLocalBackupsStorageVolume {
+		preseed.StorageVolumes = append(preseed.StorageVolumes, incusapi.InitStorageVolumesProjectPost{
+			Pool: "local",
+			StorageVolumesPost: incusapi.StorageVolumesPost{
+				Name:        "backups",
+				Type:        "custom",
+				ContentType: "filesystem",
+				StorageVolumePut: incusapi.StorageVolumePut{
+					Description: "Volume holding system backups",
+				},
+			},
+		})
+	}
+
+	// Add images storage volume on the local storage pool if it is not defined
+	// in the preseed.
+	if !hasLocalImagesStorageVolume {
+		preseed.StorageVolumes = append(preseed.StorageVolumes, incusapi.InitStorageVolumesProjectPost{
+			Pool: "local",
+			StorageVolumesPost: incusapi.StorageVolumesPost{
+				Name:        "images",
+				Type:        "custom",
+				ContentType: "filesystem",
+				StorageVolumePut: incusapi.StorageVolumePut{
+					Description: "Volume holding system images",
+				},
+			},
+		})
+	}
+
+	// Add logs storage volume on the local storage pool if it is not defined
+	// in the preseed.
+	if !hasLocalLogsStorageVolume {
+		preseed.StorageVolumes = append(preseed.StorageVolumes, incusapi.InitStorageVolumesProjectPost{
+			Pool: "local",
+			StorageVolumesPost: incusapi.StorageVolumesPost{
+				Name:        "logs",
+				Type:        "custom",
+				ContentType: "filesystem",
+				StorageVolumePut: incusapi.StorageVolumePut{
+					Description: "Volume holding system logs",
+				},
+			},
+		})
+	}
+
+	// Set default configuration values for default profiles of the default and
+	// the internal projects, if these profiles exist in the preseed.
+	var hasDefaultProjectDefaultProfile bool
+	var hasInternalProjectDefaultProfile bool
+	for i := range preseed.Profiles {
+		switch {
+		case preseed.Profiles[i].Project == "" && preseed.Profiles[i].Name == "default":
+			if preseed.Profiles[i].Devices == nil {
+				preseed.Profiles[i].Devices = map[string]map[string]string{}
+			}
+
+			_, ok := preseed.Profiles[i].Devices["root"]
+			if !ok {
+				preseed.Profiles[i].Devices["root"] = map[string]string{
+					"type": "disk",
+					"path": "/",
+					"pool": "local",
+				}
+			}
+
+			_, ok = preseed.Profiles[i].Devices["eth0"]
+			if !ok {
+				preseed.Profiles[i].Devices["eth0"] = map[string]string{
+					"type":    "nic",
+					"network": "incusbr0",
+				}
+			}
+
+			hasDefaultProjectDefaultProfile = true
+
+		case preseed.Profiles[i].Project == "internal" && preseed.Profiles[i].Name == "default":
+			if preseed.Profiles[i].Devices == nil {
+				preseed.Profiles[i].Devices = map[string]map[string]string{}
+			}
+
+			_, ok := preseed.Profiles[i].Devices["root"]
+			if !ok {
+				preseed.Profiles[i].Devices["root"] = map[string]string{
+					"type": "disk",
+					"path": "/",
+					"pool": "local",
+				}
+			}
+
+			_, ok = preseed.Profiles[i].Devices["eth0"]
+			if !ok {
+				preseed.Profiles[i].Devices["eth0"] = map[string]string{
+					"type":    "nic",
+					"network": "meshbr0",
+				}
+			}
+
+			hasInternalProjectDefaultProfile = true
+		}
+	}
+
+	// Add default profile for the default project, if it is not defined in the
+	// preseed.
+	if !hasDefaultProjectDefaultProfile {
+		preseed.Profiles = append(preseed.Profiles, incusapi.InitProfileProjectPost{
+			ProfilesPost: incusapi.ProfilesPost{
+				Name: "default",
+				ProfilePut: incusapi.ProfilePut{
+					Devices: map[string]map[string]string{
+						"root": {
+							"type": "disk",
+							"path": "/",
+							"pool": "local",
+						},
+						"eth0": {
+							"type":    "nic",
+							"network": "incusbr0",
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// Add default profile for the internal project, if it is not defined in the
+	// preseed.
+	if !hasInternalProjectDefaultProfile {
+		preseed.Profiles = append(preseed.Profiles, incusapi.InitProfileProjectPost{
+			ProfilesPost: incusapi.ProfilesPost{
+				Name: "default",
+				ProfilePut: incusapi.ProfilePut{
+					Devices: map[string]map[string]string{
+						"root": {
+							"type": "disk",
+							"path": "/",
+							"pool": "local",
+						},
+						"eth0": {
+							"type":    "nic",
+							"network": "meshbr0",
+						},
+					},
+				},
+			},
+			Project: "internal",
+		})
+	}
+
+	return preseed, nil
+}
+
+// DetectClusterInterface returns the first interface that has the role
+// "cluster" and at least one IP address assigned.
+func DetectClusterInterface(network api.ServerSystemNetwork) string {
+	clusteringInterfaces := network.State.GetInterfaceNamesByRole("cluster")
+	for _, name := range clusteringInterfaces {
+		iface := network.State.Interfaces[name]
+		if len(iface.Addresses) > 0 {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -1,13 +1,16 @@
-package terraform
+package provisioning_test
 
 import (
 	"testing"
 
+	incusosapi "github.com/lxc/incus-os/incus-osd/api"
 	incusapi "github.com/lxc/incus/v7/shared/api"
 	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
 )
 
-func Test_incusPreseedWithDefaults(t *testing.T) {
+func TestIncusPreseedWithDefaults(t *testing.T) {
 	tests := []struct {
 		name   string
 		config map[string]any
@@ -321,10 +324,54 @@ func Test_incusPreseedWithDefaults(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := incusPreseedWithDefaults(tc.config)
+			got, err := provisioning.IncusPreseedWithDefaults(tc.config)
 
 			tc.assertErr(t, err)
 			require.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestDetectClusterInterface(t *testing.T) {
+	tests := []struct {
+		name    string
+		network incusosapi.SystemNetwork
+
+		wantNic string
+	}{
+		{
+			name: "default - empty system network state",
+
+			wantNic: "",
+		},
+		{
+			name: "interface with clustering role and IP address",
+			network: incusosapi.SystemNetwork{
+				State: incusosapi.SystemNetworkState{
+					Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+						"eth0": {
+							Addresses: []string{"192.168.1.2"},
+							Roles:     []string{"cluster"},
+						},
+						"eth1": {
+							Roles: []string{"cluster"},
+						},
+						"eth2": {
+							Addresses: []string{"192.168.1.2"},
+						},
+					},
+				},
+			},
+
+			wantNic: "eth0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nic := provisioning.DetectClusterInterface(tc.network)
+
+			require.Equal(t, tc.wantNic, nic)
+		})
+	}
+}
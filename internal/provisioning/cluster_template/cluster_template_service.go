@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"go.yaml.in/yaml/v4"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
 	"github.com/FuturFusion/operations-center/shared/api"
 )
 
@@ -34,7 +37,21 @@ func (s clusterTemplateService) Create(ctx context.Context, newClusterTemplate p
 		return provisioning.ClusterTemplate{}, err
 	}
 
-	newClusterTemplate.ID, err = s.repo.Create(ctx, newClusterTemplate)
+	newClusterTemplate.Revision = 1
+
+	err = transaction.Do(ctx, func(ctx context.Context) error {
+		newClusterTemplate.ID, err = s.repo.Create(ctx, newClusterTemplate)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.repo.CreateRevision(ctx, provisioning.NewClusterTemplateRevision(newClusterTemplate, time.Now().UTC()))
+		if err != nil {
+			return fmt.Errorf("Failed to create revision of cluster template %q: %w", newClusterTemplate.Name, err)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return provisioning.ClusterTemplate{}, err
 	}
@@ -64,7 +81,25 @@ func (s clusterTemplateService) Update(ctx context.Context, newClusterTemplate p
 		return err
 	}
 
-	return s.repo.Update(ctx, newClusterTemplate)
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		currentClusterTemplate, err := s.repo.GetByName(ctx, newClusterTemplate.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster template %q: %w", newClusterTemplate.Name, err)
+		}
+
+		// Only changes to the definition of the template result in a new revision.
+		newClusterTemplate.Revision = currentClusterTemplate.Revision
+		if !currentClusterTemplate.DefinitionEqual(newClusterTemplate) {
+			newClusterTemplate.Revision++
+
+			_, err = s.repo.CreateRevision(ctx, provisioning.NewClusterTemplateRevision(newClusterTemplate, time.Now().UTC()))
+			if err != nil {
+				return fmt.Errorf("Failed to create revision of cluster template %q: %w", newClusterTemplate.Name, err)
+			}
+		}
+
+		return s.repo.Update(ctx, newClusterTemplate)
+	})
 }
 
 func (s clusterTemplateService) Rename(ctx context.Context, oldName string, newName string) error {
@@ -109,6 +144,13 @@ func (s clusterTemplateService) Apply(ctx context.Context, name string, template
 		templateVariables = make(api.ConfigMap)
 	}
 
+	// Validate all the variable values at once, before the templates are
+	// rendered and any cluster operation is started.
+	err = provisioning.ResolveClusterTemplateVariableValues(clusterTemplate.Variables, templateVariables)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to apply cluster template %q: %w", name, err)
+	}
+
 	templates := []struct {
 		name     string
 		template string
@@ -127,10 +169,7 @@ func (s clusterTemplateService) Apply(ctx context.Context, name string, template
 	}
 
 	for _, tmpl := range templates {
-		serviceConfigFilled, err := applyVariables(tmpl.template, clusterTemplate.Variables, templateVariables)
-		if err != nil {
-			return nil, nil, domain.NewValidationErrf("Failed to apply cluster template variables on %s of %q: %v", tmpl.name, name, err)
-		}
+		serviceConfigFilled := applyVariables(tmpl.template, templateVariables)
 
 		err = yaml.Unmarshal([]byte(serviceConfigFilled), tmpl.config)
 		if err != nil {
@@ -141,23 +180,103 @@ func (s clusterTemplateService) Apply(ctx context.Context, name string, template
 	return servicesConfig, applicationSeedConfig, nil
 }
 
-func applyVariables(template string, variables api.ClusterTemplateVariables, variableValues api.ConfigMap) (string, error) {
-	for variableName, variableDefinition := range variables {
-		_, ok := variableValues[variableName]
-		if !ok {
-			if variableDefinition.DefaultValue == "" {
-				return "", fmt.Errorf("No value provided for variable %q, which is required, since it has no default value defined", variableName)
-			}
+func (s clusterTemplateService) GetRevisionAll(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Cluster template name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return s.repo.GetRevisionAll(ctx, name)
+}
+
+func (s clusterTemplateService) GetRevision(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Cluster template name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return s.repo.GetRevision(ctx, name, revision)
+}
 
-			// Use default value.
-			variableValues[variableName] = variableDefinition.DefaultValue
+func (s clusterTemplateService) GetRevisionDiff(ctx context.Context, name string, fromRevision int64, toRevision int64) (api.ClusterTemplateRevisionDiff, error) {
+	if name == "" {
+		return api.ClusterTemplateRevisionDiff{}, fmt.Errorf("Cluster template name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	from, err := s.repo.GetRevision(ctx, name, fromRevision)
+	if err != nil {
+		return api.ClusterTemplateRevisionDiff{}, fmt.Errorf("Failed to get revision %d of cluster template %q: %w", fromRevision, name, err)
+	}
+
+	to, err := s.repo.GetRevision(ctx, name, toRevision)
+	if err != nil {
+		return api.ClusterTemplateRevisionDiff{}, fmt.Errorf("Failed to get revision %d of cluster template %q: %w", toRevision, name, err)
+	}
+
+	fromVariables, err := yaml.Marshal(from.Variables)
+	if err != nil {
+		return api.ClusterTemplateRevisionDiff{}, fmt.Errorf("Failed to marshal variables of revision %d of cluster template %q: %w", fromRevision, name, err)
+	}
+
+	toVariables, err := yaml.Marshal(to.Variables)
+	if err != nil {
+		return api.ClusterTemplateRevisionDiff{}, fmt.Errorf("Failed to marshal variables of revision %d of cluster template %q: %w", toRevision, name, err)
+	}
+
+	diff := api.ClusterTemplateRevisionDiff{
+		Name:         name,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+	}
+
+	fields := []struct {
+		name string
+		from string
+		to   string
+		diff *string
+	}{
+		{name: "description", from: from.Description, to: to.Description, diff: &diff.Description},
+		{name: "service_config_template", from: from.ServiceConfigTemplate, to: to.ServiceConfigTemplate, diff: &diff.ServiceConfigTemplate},
+		{name: "application_config_template", from: from.ApplicationConfigTemplate, to: to.ApplicationConfigTemplate, diff: &diff.ApplicationConfigTemplate},
+		{name: "variables", from: string(fromVariables), to: string(toVariables), diff: &diff.Variables},
+	}
+
+	for _, field := range fields {
+		*field.diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(field.from),
+			B:        splitLines(field.to),
+			FromFile: fmt.Sprintf("%s@%d", field.name, fromRevision),
+			ToFile:   fmt.Sprintf("%s@%d", field.name, toRevision),
+			Context:  3,
+		})
+		if err != nil {
+			return api.ClusterTemplateRevisionDiff{}, fmt.Errorf("Failed to diff %s of cluster template %q: %w", field.name, name, err)
 		}
 	}
 
+	return diff, nil
+}
+
+// splitLines splits s into lines, each of them terminated by a newline, as
+// expected by difflib.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
+
+func applyVariables(template string, variableValues api.ConfigMap) string {
 	for name, value := range variableValues {
 		variableName := "@" + name + "@"
 		template = strings.ReplaceAll(template, variableName, value)
 	}
 
-	return template, nil
+	return template
 }
@@ -10,15 +10,17 @@ import (
 	"github.com/FuturFusion/operations-center/internal/provisioning"
 	provisioningClusterTemplate "github.com/FuturFusion/operations-center/internal/provisioning/cluster_template"
 	"github.com/FuturFusion/operations-center/internal/provisioning/repo/mock"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
 	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
 	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 func TestClusterTemplateService_Create(t *testing.T) {
 	tests := []struct {
-		name            string
-		clusterTemplate provisioning.ClusterTemplate
-		repoCreateErr   error
+		name                  string
+		clusterTemplate       provisioning.ClusterTemplate
+		repoCreateErr         error
+		repoCreateRevisionErr error
 
 		assertErr require.ErrorAssertionFunc
 	}{
@@ -48,6 +50,15 @@ func TestClusterTemplateService_Create(t *testing.T) {
 			},
 			repoCreateErr: boom.Error,
 
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.CreateRevision",
+			clusterTemplate: provisioning.ClusterTemplate{
+				Name: "A",
+			},
+			repoCreateRevisionErr: boom.Error,
+
 			assertErr: boom.ErrorIs,
 		},
 	}
@@ -57,8 +68,14 @@ func TestClusterTemplateService_Create(t *testing.T) {
 			// Setup
 			repo := &mock.ClusterTemplateRepoMock{
 				CreateFunc: func(ctx context.Context, in provisioning.ClusterTemplate) (int64, error) {
+					require.Equal(t, int64(1), in.Revision)
 					return 1, tc.repoCreateErr
 				},
+				CreateRevisionFunc: func(ctx context.Context, in provisioning.ClusterTemplateRevision) (int64, error) {
+					require.Equal(t, tc.clusterTemplate.Name, in.ClusterTemplate)
+					require.Equal(t, int64(1), in.Revision)
+					return 1, tc.repoCreateRevisionErr
+				},
 			}
 
 			clusterTemplateSvc := provisioningClusterTemplate.New(repo)
@@ -232,19 +249,45 @@ func TestClusterTemplateService_GetByName(t *testing.T) {
 
 func TestClusterTemplateService_Update(t *testing.T) {
 	tests := []struct {
-		name            string
-		clusterTemplate provisioning.ClusterTemplate
-		repoUpdateErr   error
-
-		assertErr require.ErrorAssertionFunc
+		name                  string
+		clusterTemplate       provisioning.ClusterTemplate
+		repoGetByName         *provisioning.ClusterTemplate
+		repoGetByNameErr      error
+		repoCreateRevisionErr error
+		repoUpdateErr         error
+
+		assertErr    require.ErrorAssertionFunc
+		wantRevision int64
 	}{
 		{
-			name: "success",
+			name: "success - definition changed",
 			clusterTemplate: provisioning.ClusterTemplate{
-				Name: "A",
+				Name:        "A",
+				Description: "new",
+			},
+			repoGetByName: &provisioning.ClusterTemplate{
+				Name:        "A",
+				Description: "old",
+				Revision:    2,
 			},
 
-			assertErr: require.NoError,
+			assertErr:    require.NoError,
+			wantRevision: 3,
+		},
+		{
+			name: "success - definition unchanged",
+			clusterTemplate: provisioning.ClusterTemplate{
+				Name:        "A",
+				Description: "same",
+			},
+			repoGetByName: &provisioning.ClusterTemplate{
+				Name:        "A",
+				Description: "same",
+				Revision:    2,
+			},
+
+			assertErr:    require.NoError,
+			wantRevision: 2,
 		},
 		{
 			name: "error - invalid name",
@@ -258,10 +301,37 @@ func TestClusterTemplateService_Update(t *testing.T) {
 			},
 		},
 		{
-			name: "error - repo",
+			name: "error - repo.GetByName",
 			clusterTemplate: provisioning.ClusterTemplate{
 				Name: "A",
 			},
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.CreateRevision",
+			clusterTemplate: provisioning.ClusterTemplate{
+				Name:        "A",
+				Description: "new",
+			},
+			repoGetByName: &provisioning.ClusterTemplate{
+				Name:     "A",
+				Revision: 1,
+			},
+			repoCreateRevisionErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.Update",
+			clusterTemplate: provisioning.ClusterTemplate{
+				Name: "A",
+			},
+			repoGetByName: &provisioning.ClusterTemplate{
+				Name:     "A",
+				Revision: 1,
+			},
 			repoUpdateErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
@@ -272,7 +342,15 @@ func TestClusterTemplateService_Update(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
 			repo := &mock.ClusterTemplateRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterTemplate, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				CreateRevisionFunc: func(ctx context.Context, in provisioning.ClusterTemplateRevision) (int64, error) {
+					require.Equal(t, tc.clusterTemplate.Description, in.Description)
+					return 1, tc.repoCreateRevisionErr
+				},
 				UpdateFunc: func(ctx context.Context, in provisioning.ClusterTemplate) error {
+					require.Equal(t, tc.wantRevision, in.Revision)
 					return tc.repoUpdateErr
 				},
 			}
@@ -284,6 +362,16 @@ func TestClusterTemplateService_Update(t *testing.T) {
 
 			// Assert
 			tc.assertErr(t, err)
+			if tc.wantRevision == 0 || tc.repoGetByName == nil {
+				return
+			}
+
+			wantCreateRevisionCalls := 0
+			if tc.wantRevision != tc.repoGetByName.Revision {
+				wantCreateRevisionCalls = 1
+			}
+
+			require.Len(t, repo.CreateRevisionCalls(), wantCreateRevisionCalls)
 		})
 	}
 }
@@ -477,23 +565,89 @@ default: @VALUE_WITH_DEFAULT@
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:              "error - service config apply variables",
-			nameArg:           "tmpl",
-			templateVariables: api.ConfigMap{},
+			name:    "success - with typed variables",
+			nameArg: "tmpl",
+			templateVariables: api.ConfigMap{
+				"VLAN":    "100",
+				"ENABLED": "true",
+			},
+			repoGetByName: &provisioning.ClusterTemplate{
+				ServiceConfigTemplate: `
+vlan: @VLAN@
+enabled: @ENABLED@
+channel: @CHANNEL@
+comment: "@COMMENT@"
+`,
+				ApplicationConfigTemplate: ``,
+				Variables: api.ClusterTemplateVariables{
+					"VLAN": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeInt,
+						Min:  ptr.To(int64(1)),
+						Max:  ptr.To(int64(4094)),
+					},
+					"ENABLED": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeBool,
+					},
+					"CHANNEL": api.ClusterTemplateVariable{
+						Type:         api.ClusterTemplateVariableTypeEnum,
+						Values:       []string{"stable", "testing"},
+						DefaultValue: "stable",
+					},
+					"COMMENT": api.ClusterTemplateVariable{
+						Optional: true,
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			wantServicesConfig: map[string]any{
+				"vlan":    100,
+				"enabled": true,
+				"channel": "stable",
+				"comment": "",
+			},
+			wantApplicationSeedConfig: map[string]any{},
+		},
+		{
+			name:    "error - invalid variable values",
+			nameArg: "tmpl",
+			templateVariables: api.ConfigMap{
+				"VLAN":     "5000",
+				"SUBNET":   "10.0.0.0",
+				"PASSWORD": "short",
+			},
 			repoGetByName: &provisioning.ClusterTemplate{
 				ServiceConfigTemplate: `
 key: @VALUE@
+vlan: @VLAN@
+subnet: @SUBNET@
+password: @PASSWORD@
 `,
 				ApplicationConfigTemplate: ``,
 				Variables: api.ClusterTemplateVariables{
 					"VALUE": api.ClusterTemplateVariable{},
+					"VLAN": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeInt,
+						Max:  ptr.To(int64(4094)),
+					},
+					"SUBNET": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeCIDR,
+					},
+					"PASSWORD": api.ClusterTemplateVariable{
+						Pattern: "^.{8,}$",
+						Secret:  true,
+					},
 				},
 			},
 
 			assertErr: func(tt require.TestingT, err error, a ...any) {
 				var verr domain.ErrValidation
 				require.ErrorAs(tt, err, &verr, a...)
-				require.ErrorContains(tt, err, "Failed to apply cluster template variables on service config of")
+				require.ErrorContains(tt, err, `No value provided for variable "VALUE"`, a...)
+				require.ErrorContains(tt, err, `value "5000" of variable "VLAN" is greater than the maximum of 4094`, a...)
+				require.ErrorContains(tt, err, `value "10.0.0.0" of variable "SUBNET" is not a valid CIDR`, a...)
+				require.ErrorContains(tt, err, `value of variable "PASSWORD" does not match the pattern`, a...)
+				require.NotContains(tt, err.Error(), "short", a...)
 			},
 		},
 		{
@@ -554,3 +708,169 @@ key: @VALUE@
 		})
 	}
 }
+
+func TestClusterTemplateService_GetRevisionAll(t *testing.T) {
+	tests := []struct {
+		name              string
+		nameArg           string
+		repoGetRevisions  provisioning.ClusterTemplateRevisions
+		repoGetRevisonErr error
+
+		assertErr require.ErrorAssertionFunc
+		count     int
+	}{
+		{
+			name:    "success",
+			nameArg: "A",
+			repoGetRevisions: provisioning.ClusterTemplateRevisions{
+				{ClusterTemplate: "A", Revision: 1},
+				{ClusterTemplate: "A", Revision: 2},
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:              "error - repo",
+			nameArg:           "A",
+			repoGetRevisonErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterTemplateRepoMock{
+				GetRevisionAllFunc: func(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+					require.Equal(t, tc.nameArg, name)
+					return tc.repoGetRevisions, tc.repoGetRevisonErr
+				},
+			}
+
+			clusterTemplateSvc := provisioningClusterTemplate.New(repo)
+
+			// Run test
+			revisions, err := clusterTemplateSvc.GetRevisionAll(t.Context(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Len(t, revisions, tc.count)
+		})
+	}
+}
+
+func TestClusterTemplateService_GetRevisionDiff(t *testing.T) {
+	revisions := map[int64]*provisioning.ClusterTemplateRevision{
+		1: {
+			ClusterTemplate:       "A",
+			Revision:              1,
+			Description:           "same",
+			ServiceConfigTemplate: "lvm:\n  enabled: true\n",
+			Variables: api.ClusterTemplateVariables{
+				"A": {Description: "a"},
+			},
+		},
+		2: {
+			ClusterTemplate:       "A",
+			Revision:              2,
+			Description:           "same",
+			ServiceConfigTemplate: "lvm:\n  enabled: false\n",
+			Variables: api.ClusterTemplateVariables{
+				"A": {Description: "a"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		nameArg        string
+		fromRevision   int64
+		toRevision     int64
+		repoGetRevErrs map[int64]error
+
+		assertErr require.ErrorAssertionFunc
+		want      api.ClusterTemplateRevisionDiff
+	}{
+		{
+			name:         "success",
+			nameArg:      "A",
+			fromRevision: 1,
+			toRevision:   2,
+
+			assertErr: require.NoError,
+			want: api.ClusterTemplateRevisionDiff{
+				Name:         "A",
+				FromRevision: 1,
+				ToRevision:   2,
+				ServiceConfigTemplate: `--- service_config_template@1
++++ service_config_template@2
+@@ -1,2 +1,2 @@
+ lvm:
+-  enabled: true
++  enabled: false
+`,
+			},
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:         "error - repo.GetRevision from",
+			nameArg:      "A",
+			fromRevision: 1,
+			toRevision:   2,
+			repoGetRevErrs: map[int64]error{
+				1: boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:         "error - repo.GetRevision to",
+			nameArg:      "A",
+			fromRevision: 1,
+			toRevision:   2,
+			repoGetRevErrs: map[int64]error{
+				2: boom.Error,
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &mock.ClusterTemplateRepoMock{
+				GetRevisionFunc: func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+					require.Equal(t, tc.nameArg, name)
+					return revisions[revision], tc.repoGetRevErrs[revision]
+				},
+			}
+
+			clusterTemplateSvc := provisioningClusterTemplate.New(repo)
+
+			// Run test
+			diff, err := clusterTemplateSvc.GetRevisionDiff(t.Context(), tc.nameArg, tc.fromRevision, tc.toRevision)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, diff)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package provisioning
 
 import (
+	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +19,7 @@ type ClusterTemplate struct {
 	ServiceConfigTemplate     string
 	ApplicationConfigTemplate string
 	Variables                 api.ClusterTemplateVariables
+	Revision                  int64
 	LastUpdated               time.Time `db:"update_timestamp"`
 }
 
@@ -30,12 +34,18 @@ func (c ClusterTemplate) Validate() error {
 		return domain.NewValidationErrf("Invalid cluster template, name can not contain any of %q", nameProhibitedCharacters)
 	}
 
-	for variable := range c.Variables {
+	// Collect all the problems with the variables to report them at once.
+	var problems []string
+
+	for _, variable := range slices.Sorted(maps.Keys(c.Variables)) {
 		variableName := "@" + variable + "@"
 		if !variablePattern.MatchString(variableName) {
-			return domain.NewValidationErrf("Varible %q does not match the expected pattern or contains invalid characters", variable)
+			problems = append(problems, fmt.Sprintf("Varible %q does not match the expected pattern or contains invalid characters", variable))
+			continue
 		}
 
+		problems = append(problems, validateClusterTemplateVariableDefinition(variable, c.Variables[variable])...)
+
 		found := strings.Contains(c.ServiceConfigTemplate, variableName)
 		if found {
 			continue
@@ -46,14 +56,14 @@ func (c ClusterTemplate) Validate() error {
 			continue
 		}
 
-		return domain.NewValidationErrf("Defined variable %q is not used in any template", variable)
+		problems = append(problems, fmt.Sprintf("Defined variable %q is not used in any template", variable))
 	}
 
 	for _, variable := range variablePattern.FindAllString(c.ServiceConfigTemplate, -1) {
 		variableName := variable[1 : len(variable)-1]
 		_, ok := c.Variables[variableName]
 		if !ok {
-			return domain.NewValidationErrf("Variable %q used in the service config template is not contained in the variable definitions", variableName)
+			problems = append(problems, fmt.Sprintf("Variable %q used in the service config template is not contained in the variable definitions", variableName))
 		}
 	}
 
@@ -61,11 +71,54 @@ func (c ClusterTemplate) Validate() error {
 		variableName := variable[1 : len(variable)-1]
 		_, ok := c.Variables[variableName]
 		if !ok {
-			return domain.NewValidationErrf("Variable %q used in the application config template is not contained in the variable definitions", variableName)
+			problems = append(problems, fmt.Sprintf("Variable %q used in the application config template is not contained in the variable definitions", variableName))
 		}
 	}
 
+	if len(problems) > 0 {
+		return domain.NewValidationErrf("Invalid cluster template variables: %s", strings.Join(slices.Compact(problems), "; "))
+	}
+
 	return nil
 }
 
 type ClusterTemplates []ClusterTemplate
+
+// DefinitionEqual returns true, if the definition of the cluster template,
+// which is recorded in each revision, is the same for both cluster templates.
+func (c ClusterTemplate) DefinitionEqual(other ClusterTemplate) bool {
+	return c.Description == other.Description &&
+		c.ServiceConfigTemplate == other.ServiceConfigTemplate &&
+		c.ApplicationConfigTemplate == other.ApplicationConfigTemplate &&
+		maps.EqualFunc(c.Variables, other.Variables, api.ClusterTemplateVariable.Equal)
+}
+
+// ClusterTemplateRevision is an immutable snapshot of the definition of a
+// cluster template. A new revision is recorded, whenever a cluster template is
+// created or its definition is changed.
+type ClusterTemplateRevision struct {
+	ID                        int64
+	ClusterTemplate           string `db:"primary=yes&join=cluster_templates.name"`
+	Revision                  int64  `db:"primary=yes"`
+	Description               string
+	ServiceConfigTemplate     string
+	ApplicationConfigTemplate string
+	Variables                 api.ClusterTemplateVariables
+	CreatedAt                 time.Time
+}
+
+type ClusterTemplateRevisions []ClusterTemplateRevision
+
+// NewClusterTemplateRevision returns the revision of the cluster template for
+// its current definition.
+func NewClusterTemplateRevision(clusterTemplate ClusterTemplate, createdAt time.Time) ClusterTemplateRevision {
+	return ClusterTemplateRevision{
+		ClusterTemplate:           clusterTemplate.Name,
+		Revision:                  clusterTemplate.Revision,
+		Description:               clusterTemplate.Description,
+		ServiceConfigTemplate:     clusterTemplate.ServiceConfigTemplate,
+		ApplicationConfigTemplate: clusterTemplate.ApplicationConfigTemplate,
+		Variables:                 clusterTemplate.Variables,
+		CreatedAt:                 createdAt,
+	}
+}
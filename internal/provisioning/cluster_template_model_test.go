@@ -7,6 +7,7 @@ import (
 
 	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
 	"github.com/FuturFusion/operations-center/shared/api"
 )
 
@@ -147,6 +148,78 @@ func TestClusterTemplate_Validate(t *testing.T) {
 				require.ErrorAs(tt, err, &verr, a...)
 			},
 		},
+		{
+			name: "valid - typed variables",
+			clusterTemplate: provisioning.ClusterTemplate{
+				Name:                  "one",
+				ServiceConfigTemplate: `{"vlan": @VLAN@, "subnet": "@SUBNET@", "channel": "@CHANNEL@", "hosts": "@HOSTS@"}`,
+				Variables: api.ClusterTemplateVariables{
+					"VLAN": api.ClusterTemplateVariable{
+						Type:         api.ClusterTemplateVariableTypeInt,
+						Min:          ptr.To(int64(1)),
+						Max:          ptr.To(int64(4094)),
+						DefaultValue: "10",
+					},
+					"SUBNET": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeCIDR,
+					},
+					"CHANNEL": api.ClusterTemplateVariable{
+						Type:         api.ClusterTemplateVariableTypeEnum,
+						Values:       []string{"stable", "testing"},
+						DefaultValue: "stable",
+					},
+					"HOSTS": api.ClusterTemplateVariable{
+						Type:     api.ClusterTemplateVariableTypeList,
+						Pattern:  "^[a-z0-9-]+$",
+						Optional: true,
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - invalid variable definitions reported at once",
+			clusterTemplate: provisioning.ClusterTemplate{
+				Name:                  "one",
+				ServiceConfigTemplate: `{"a": "@A@", "b": "@B@", "c": "@C@", "d": "@D@", "e": "@E@", "f": "@F@"}`,
+				Variables: api.ClusterTemplateVariables{
+					"A": api.ClusterTemplateVariable{
+						Type: "invalid",
+					},
+					"B": api.ClusterTemplateVariable{
+						Pattern: "[",
+					},
+					"C": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeBool,
+						Min:  ptr.To(int64(1)),
+					},
+					"D": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeEnum,
+					},
+					"E": api.ClusterTemplateVariable{
+						Type:         api.ClusterTemplateVariableTypeIP,
+						DefaultValue: "not an ip",
+					},
+					"F": api.ClusterTemplateVariable{
+						Type: api.ClusterTemplateVariableTypeInt,
+						Min:  ptr.To(int64(10)),
+						Max:  ptr.To(int64(1)),
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+				require.ErrorContains(tt, err, `Variable "A" has invalid type "invalid"`, a...)
+				require.ErrorContains(tt, err, `Variable "B" has invalid pattern`, a...)
+				require.ErrorContains(tt, err, `Variable "C" of type "bool" can not have a range`, a...)
+				require.ErrorContains(tt, err, `Variable "D" of type "enum" requires a list of allowed values`, a...)
+				require.ErrorContains(tt, err, `Default value "not an ip" of variable "E" is not a valid IP address`, a...)
+				require.ErrorContains(tt, err, `Variable "F" has min 10 greater than max 1`, a...)
+			},
+		},
 	}
 
 	for _, tc := range tests {
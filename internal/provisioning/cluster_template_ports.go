@@ -15,6 +15,9 @@ type ClusterTemplateService interface {
 	Rename(ctx context.Context, oldName string, newName string) error
 	DeleteByName(ctx context.Context, name string) error
 	Apply(ctx context.Context, name string, templateVariables api.ConfigMap) (servicesConfig map[string]any, applicationSeedConfig map[string]any, _ error)
+	GetRevisionAll(ctx context.Context, name string) (ClusterTemplateRevisions, error)
+	GetRevision(ctx context.Context, name string, revision int64) (*ClusterTemplateRevision, error)
+	GetRevisionDiff(ctx context.Context, name string, fromRevision int64, toRevision int64) (api.ClusterTemplateRevisionDiff, error)
 }
 
 type ClusterTemplateRepo interface {
@@ -25,4 +28,7 @@ type ClusterTemplateRepo interface {
 	Update(ctx context.Context, clusterTemplate ClusterTemplate) error
 	Rename(ctx context.Context, oldName string, newName string) error
 	DeleteByName(ctx context.Context, name string) error
+	CreateRevision(ctx context.Context, clusterTemplateRevision ClusterTemplateRevision) (int64, error)
+	GetRevisionAll(ctx context.Context, name string) (ClusterTemplateRevisions, error)
+	GetRevision(ctx context.Context, name string, revision int64) (*ClusterTemplateRevision, error)
 }
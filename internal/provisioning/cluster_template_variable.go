@@ -0,0 +1,237 @@
+package provisioning
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// ClusterTemplateSecretMask replaces the values of secret cluster template
+// variables, whenever they are returned.
+const ClusterTemplateSecretMask = "********"
+
+// validateClusterTemplateVariableDefinition returns all the problems of the
+// definition of a cluster template variable, including its default value.
+func validateClusterTemplateVariableDefinition(name string, variable api.ClusterTemplateVariable) []string {
+	var problems []string
+
+	if !variable.Type.IsValid() {
+		return []string{fmt.Sprintf("Variable %q has invalid type %q", name, variable.Type)}
+	}
+
+	variableType := clusterTemplateVariableType(variable)
+
+	if variable.Pattern != "" {
+		if variableType != api.ClusterTemplateVariableTypeString && variableType != api.ClusterTemplateVariableTypeList {
+			problems = append(problems, fmt.Sprintf("Variable %q of type %q can not have a pattern", name, variableType))
+		}
+
+		_, err := regexp.Compile(variable.Pattern)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Variable %q has invalid pattern: %v", name, err))
+		}
+	}
+
+	if variable.Min != nil || variable.Max != nil {
+		if variableType != api.ClusterTemplateVariableTypeInt {
+			problems = append(problems, fmt.Sprintf("Variable %q of type %q can not have a range", name, variableType))
+		}
+
+		if variable.Min != nil && variable.Max != nil && *variable.Min > *variable.Max {
+			problems = append(problems, fmt.Sprintf("Variable %q has min %d greater than max %d", name, *variable.Min, *variable.Max))
+		}
+	}
+
+	if variableType == api.ClusterTemplateVariableTypeEnum && len(variable.Values) == 0 {
+		problems = append(problems, fmt.Sprintf("Variable %q of type %q requires a list of allowed values", name, variableType))
+	}
+
+	if variableType != api.ClusterTemplateVariableTypeEnum && len(variable.Values) > 0 {
+		problems = append(problems, fmt.Sprintf("Variable %q of type %q can not have a list of allowed values", name, variableType))
+	}
+
+	// Only validate the default value, if the definition itself is sound.
+	if len(problems) > 0 || variable.DefaultValue == "" {
+		return problems
+	}
+
+	err := validateClusterTemplateVariableValue(variable, variable.DefaultValue)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Default %s", describeClusterTemplateVariableValueErr(name, variable, variable.DefaultValue, err)))
+	}
+
+	return problems
+}
+
+// ResolveClusterTemplateVariableValues validates the provided values against
+// the variable definitions and fills in the default values for the variables,
+// no value has been provided for. Variables, which are optional and have
+// neither a value nor a default, are set to the empty string.
+//
+// All the problems are reported at once in a single validation error.
+func ResolveClusterTemplateVariableValues(variables api.ClusterTemplateVariables, values api.ConfigMap) error {
+	var problems []string
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		variable := variables[name]
+
+		value, ok := values[name]
+		if !ok {
+			switch {
+			case variable.DefaultValue != "":
+				values[name] = variable.DefaultValue
+
+			case variable.Optional:
+				values[name] = ""
+
+			default:
+				problems = append(problems, fmt.Sprintf("No value provided for variable %q, which is required, since it has no default value defined", name))
+			}
+
+			continue
+		}
+
+		// The empty value is accepted for optional variables.
+		if value == "" && variable.Optional {
+			continue
+		}
+
+		err := validateClusterTemplateVariableValue(variable, value)
+		if err != nil {
+			problems = append(problems, describeClusterTemplateVariableValueErr(name, variable, value, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return domain.NewValidationErrf("Invalid cluster template variable values: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// MaskClusterTemplateVariableValues returns a copy of the values, where the
+// values of secret variables are masked. Values of variables, which are not
+// contained in the variable definitions, are masked as well, since it is
+// unknown, if they are secret.
+func MaskClusterTemplateVariableValues(variables api.ClusterTemplateVariables, values api.ConfigMap) api.ConfigMap {
+	if values == nil {
+		return nil
+	}
+
+	masked := make(api.ConfigMap, len(values))
+	for name, value := range values {
+		variable, ok := variables[name]
+		if !ok || variable.Secret {
+			masked[name] = ClusterTemplateSecretMask
+			continue
+		}
+
+		masked[name] = value
+	}
+
+	return masked
+}
+
+func clusterTemplateVariableType(variable api.ClusterTemplateVariable) api.ClusterTemplateVariableType {
+	if variable.Type == "" {
+		return api.ClusterTemplateVariableTypeString
+	}
+
+	return variable.Type
+}
+
+func validateClusterTemplateVariableValue(variable api.ClusterTemplateVariable, value string) error {
+	switch clusterTemplateVariableType(variable) {
+	case api.ClusterTemplateVariableTypeString:
+		return matchClusterTemplateVariablePattern(variable, value)
+
+	case api.ClusterTemplateVariableTypeInt:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("is not a valid integer")
+		}
+
+		if variable.Min != nil && number < *variable.Min {
+			return fmt.Errorf("is less than the minimum of %d", *variable.Min)
+		}
+
+		if variable.Max != nil && number > *variable.Max {
+			return fmt.Errorf("is greater than the maximum of %d", *variable.Max)
+		}
+
+	case api.ClusterTemplateVariableTypeBool:
+		_, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("is not a valid boolean")
+		}
+
+	case api.ClusterTemplateVariableTypeEnum:
+		if !slices.Contains(variable.Values, value) {
+			return fmt.Errorf("is not one of %s", strings.Join(variable.Values, ", "))
+		}
+
+	case api.ClusterTemplateVariableTypeCIDR:
+		_, err := netip.ParsePrefix(value)
+		if err != nil {
+			return fmt.Errorf("is not a valid CIDR")
+		}
+
+	case api.ClusterTemplateVariableTypeIP:
+		_, err := netip.ParseAddr(value)
+		if err != nil {
+			return fmt.Errorf("is not a valid IP address")
+		}
+
+	case api.ClusterTemplateVariableTypeDuration:
+		_, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("is not a valid duration")
+		}
+
+	case api.ClusterTemplateVariableTypeList:
+		for _, element := range strings.Split(value, ",") {
+			err := matchClusterTemplateVariablePattern(variable, strings.TrimSpace(element))
+			if err != nil {
+				return fmt.Errorf("contains an element, which %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchClusterTemplateVariablePattern(variable api.ClusterTemplateVariable, value string) error {
+	if variable.Pattern == "" {
+		return nil
+	}
+
+	// The pattern has already been validated with the cluster template.
+	pattern, err := regexp.Compile(variable.Pattern)
+	if err != nil {
+		return fmt.Errorf("can not be matched against the invalid pattern %q", variable.Pattern)
+	}
+
+	if !pattern.MatchString(value) {
+		return fmt.Errorf("does not match the pattern %q", variable.Pattern)
+	}
+
+	return nil
+}
+
+// describeClusterTemplateVariableValueErr describes the problem with the
+// value of a variable, without disclosing the value of secret variables.
+func describeClusterTemplateVariableValueErr(name string, variable api.ClusterTemplateVariable, value string, err error) string {
+	if variable.Secret {
+		return fmt.Sprintf("value of variable %q %v", name, err)
+	}
+
+	return fmt.Sprintf("value %q of variable %q %v", value, name, err)
+}
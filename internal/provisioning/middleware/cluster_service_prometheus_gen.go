@@ -9,12 +9,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/lxc/incus-os/incus-osd/api"
+	api0 "github.com/lxc/incus-os/incus-osd/api"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 // ClusterServiceWithPrometheus implements provisioning.ClusterService interface with all methods wrapped
@@ -98,8 +99,22 @@ func (_d ClusterServiceWithPrometheus) AddServers(ctx context.Context, name stri
 	return _d.base.AddServers(ctx, name, serverNames, skipPostJoinOperations, copyServicesConfig)
 }
 
+// AddServiceCephCluster implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) AddServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string, cephCluster api0.ServiceCephCluster) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "AddServiceCephCluster", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.AddServiceCephCluster(ctx, clusterName, cephClusterName, cephCluster)
+}
+
 // AddStorageTargetISCSI implements provisioning.ClusterService.
-func (_d ClusterServiceWithPrometheus) AddStorageTargetISCSI(ctx context.Context, clusterName string, target api.ServiceISCSITarget) (err error) {
+func (_d ClusterServiceWithPrometheus) AddStorageTargetISCSI(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) (err error) {
 	_since := time.Now()
 	defer func() {
 		result := "ok"
@@ -127,7 +142,7 @@ func (_d ClusterServiceWithPrometheus) AddStorageTargetMultipath(ctx context.Con
 }
 
 // AddStorageTargetNVME implements provisioning.ClusterService.
-func (_d ClusterServiceWithPrometheus) AddStorageTargetNVME(ctx context.Context, clusterName string, target api.ServiceNVMETarget) (err error) {
+func (_d ClusterServiceWithPrometheus) AddStorageTargetNVME(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) (err error) {
 	_since := time.Now()
 	defer func() {
 		result := "ok"
@@ -140,6 +155,48 @@ func (_d ClusterServiceWithPrometheus) AddStorageTargetNVME(ctx context.Context,
 	return _d.base.AddStorageTargetNVME(ctx, clusterName, target)
 }
 
+// Adopt implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) Adopt(ctx context.Context, cluster provisioning.Cluster) (cluster1 provisioning.Cluster, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "Adopt", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.Adopt(ctx, cluster)
+}
+
+// ApplyClusterSpec implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) ApplyClusterSpec(ctx context.Context, spec api.ClusterSpec) (clusterSpecPlan api.ClusterSpecPlan, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "ApplyClusterSpec", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.ApplyClusterSpec(ctx, spec)
+}
+
+// CheckConsistency implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) CheckConsistency(ctx context.Context) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "CheckConsistency", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.CheckConsistency(ctx)
+}
+
 // ClusterUpdateControlLoop implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) ClusterUpdateControlLoop(ctx context.Context, clusterNameFilter *string) (err error) {
 	_since := time.Now()
@@ -196,6 +253,20 @@ func (_d ClusterServiceWithPrometheus) DeleteByName(ctx context.Context, name st
 	return _d.base.DeleteByName(ctx, name, force)
 }
 
+// DetectConfigurationDrift implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) DetectConfigurationDrift(ctx context.Context) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "DetectConfigurationDrift", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.DetectConfigurationDrift(ctx)
+}
+
 // GetAll implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) GetAll(ctx context.Context) (clusters provisioning.Clusters, err error) {
 	_since := time.Now()
@@ -336,6 +407,34 @@ func (_d ClusterServiceWithPrometheus) GetClusterArtifactFileByName(ctx context.
 	return _d.base.GetClusterArtifactFileByName(ctx, clusterName, artifactName, filename)
 }
 
+// GetClusterConsistency implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) GetClusterConsistency(ctx context.Context, name string) (clusterConsistency api.ClusterConsistency, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetClusterConsistency", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetClusterConsistency(ctx, name)
+}
+
+// GetClusterTemplateDrift implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) GetClusterTemplateDrift(ctx context.Context, name string) (clusterTemplateDrift api.ClusterTemplateDrift, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetClusterTemplateDrift", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetClusterTemplateDrift(ctx, name)
+}
+
 // GetEndpoint implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) GetEndpoint(ctx context.Context, name string) (endpoint provisioning.Endpoint, err error) {
 	_since := time.Now()
@@ -350,6 +449,20 @@ func (_d ClusterServiceWithPrometheus) GetEndpoint(ctx context.Context, name str
 	return _d.base.GetEndpoint(ctx, name)
 }
 
+// GetOperationAll implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) GetOperationAll(ctx context.Context, name string) (clusterOperations provisioning.ClusterOperations, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetOperationAll", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetOperationAll(ctx, name)
+}
+
 // IsInstanceLifecycleOperationPermitted implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) IsInstanceLifecycleOperationPermitted(ctx context.Context, name string) (b bool) {
 	_since := time.Now()
@@ -360,6 +473,34 @@ func (_d ClusterServiceWithPrometheus) IsInstanceLifecycleOperationPermitted(ctx
 	return _d.base.IsInstanceLifecycleOperationPermitted(ctx, name)
 }
 
+// LaunchAutomaticClusterUpdates implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) LaunchAutomaticClusterUpdates(ctx context.Context) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "LaunchAutomaticClusterUpdates", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.LaunchAutomaticClusterUpdates(ctx)
+}
+
+// LaunchClusterFirmwareUpdate implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) LaunchClusterFirmwareUpdate(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "LaunchClusterFirmwareUpdate", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.LaunchClusterFirmwareUpdate(ctx, name, update)
+}
+
 // LaunchClusterReboot implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) LaunchClusterReboot(ctx context.Context, name string) (err error) {
 	_since := time.Now()
@@ -388,6 +529,48 @@ func (_d ClusterServiceWithPrometheus) LaunchClusterUpdate(ctx context.Context,
 	return _d.base.LaunchClusterUpdate(ctx, name, reboot)
 }
 
+// PlanClusterReboot implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) PlanClusterReboot(ctx context.Context, name string) (clusterUpdatePlan api.ClusterUpdatePlan, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "PlanClusterReboot", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.PlanClusterReboot(ctx, name)
+}
+
+// PlanClusterSpec implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) PlanClusterSpec(ctx context.Context, spec api.ClusterSpec) (clusterSpecPlan api.ClusterSpecPlan, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "PlanClusterSpec", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.PlanClusterSpec(ctx, spec)
+}
+
+// PlanClusterUpdate implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) PlanClusterUpdate(ctx context.Context, name string, reboot bool) (clusterUpdatePlan api.ClusterUpdatePlan, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "PlanClusterUpdate", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.PlanClusterUpdate(ctx, name, reboot)
+}
+
 // RemoveServer implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) RemoveServer(ctx context.Context, name string, removedServerNames []string) (err error) {
 	_since := time.Now()
@@ -416,8 +599,22 @@ func (_d ClusterServiceWithPrometheus) RemoveServerSystemNetworkVLANTags(ctx con
 	return _d.base.RemoveServerSystemNetworkVLANTags(ctx, clusterName, interfaceName, vlanTags)
 }
 
+// RemoveServiceCephCluster implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) RemoveServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "RemoveServiceCephCluster", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.RemoveServiceCephCluster(ctx, clusterName, cephClusterName)
+}
+
 // RemoveStorageTargetISCSI implements provisioning.ClusterService.
-func (_d ClusterServiceWithPrometheus) RemoveStorageTargetISCSI(ctx context.Context, clusterName string, target api.ServiceISCSITarget) (err error) {
+func (_d ClusterServiceWithPrometheus) RemoveStorageTargetISCSI(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) (err error) {
 	_since := time.Now()
 	defer func() {
 		result := "ok"
@@ -445,7 +642,7 @@ func (_d ClusterServiceWithPrometheus) RemoveStorageTargetMultipath(ctx context.
 }
 
 // RemoveStorageTargetNVME implements provisioning.ClusterService.
-func (_d ClusterServiceWithPrometheus) RemoveStorageTargetNVME(ctx context.Context, clusterName string, target api.ServiceNVMETarget) (err error) {
+func (_d ClusterServiceWithPrometheus) RemoveStorageTargetNVME(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) (err error) {
 	_since := time.Now()
 	defer func() {
 		result := "ok"
@@ -472,6 +669,20 @@ func (_d ClusterServiceWithPrometheus) Rename(ctx context.Context, oldName strin
 	return _d.base.Rename(ctx, oldName, newName)
 }
 
+// ReplaceServer implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) ReplaceServer(ctx context.Context, name string, serverName string, replacementServerName string) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "ReplaceServer", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.ReplaceServer(ctx, name, serverName, replacementServerName)
+}
+
 // ResyncInventory implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) ResyncInventory(ctx context.Context) (err error) {
 	_since := time.Now()
@@ -552,6 +763,34 @@ func (_d ClusterServiceWithPrometheus) UpdateCertificate(ctx context.Context, na
 	return _d.base.UpdateCertificate(ctx, name, certificatePEM, keyPEM)
 }
 
+// UpdateServiceLinstor implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) UpdateServiceLinstor(ctx context.Context, clusterName string, linstorConfig api0.ServiceLinstorConfig) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "UpdateServiceLinstor", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.UpdateServiceLinstor(ctx, clusterName, linstorConfig)
+}
+
+// UpdateServiceOVN implements provisioning.ClusterService.
+func (_d ClusterServiceWithPrometheus) UpdateServiceOVN(ctx context.Context, clusterName string, ovnConfig api0.ServiceOVNConfig) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "UpdateServiceOVN", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.UpdateServiceOVN(ctx, clusterName, ovnConfig)
+}
+
 // UpdateSystemKernel implements provisioning.ClusterService.
 func (_d ClusterServiceWithPrometheus) UpdateSystemKernel(ctx context.Context, clusterName string, kerneConfig provisioning.ServerSystemKernel) (err error) {
 	_since := time.Now()
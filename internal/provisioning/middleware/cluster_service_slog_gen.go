@@ -9,11 +9,12 @@ import (
 	"log/slog"
 
 	"github.com/google/uuid"
-	"github.com/lxc/incus-os/incus-osd/api"
+	api0 "github.com/lxc/incus-os/incus-osd/api"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
 	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 // ClusterServiceWithSlog implements provisioning.ClusterService that is instrumented with slog logger.
@@ -186,8 +187,44 @@ func (_d ClusterServiceWithSlog) AddServers(ctx context.Context, name string, se
 	return _d._base.AddServers(ctx, name, serverNames, skipPostJoinOperations, copyServicesConfig)
 }
 
+// AddServiceCephCluster implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) AddServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string, cephCluster api0.ServiceCephCluster) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("clusterName", clusterName),
+			slog.String("cephClusterName", cephClusterName),
+			slog.Any("cephCluster", cephCluster),
+		)
+	}
+	log.DebugContext(ctx, "=> calling AddServiceCephCluster")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method AddServiceCephCluster returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method AddServiceCephCluster returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method AddServiceCephCluster finished")
+		}
+	}()
+	return _d._base.AddServiceCephCluster(ctx, clusterName, cephClusterName, cephCluster)
+}
+
 // AddStorageTargetISCSI implements provisioning.ClusterService.
-func (_d ClusterServiceWithSlog) AddStorageTargetISCSI(ctx context.Context, clusterName string, target api.ServiceISCSITarget) (err error) {
+func (_d ClusterServiceWithSlog) AddStorageTargetISCSI(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) (err error) {
 	log := slog.With()
 	if slog.Default().Enabled(ctx, logger.LevelTrace) {
 		log = log.With(
@@ -257,7 +294,7 @@ func (_d ClusterServiceWithSlog) AddStorageTargetMultipath(ctx context.Context,
 }
 
 // AddStorageTargetNVME implements provisioning.ClusterService.
-func (_d ClusterServiceWithSlog) AddStorageTargetNVME(ctx context.Context, clusterName string, target api.ServiceNVMETarget) (err error) {
+func (_d ClusterServiceWithSlog) AddStorageTargetNVME(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) (err error) {
 	log := slog.With()
 	if slog.Default().Enabled(ctx, logger.LevelTrace) {
 		log = log.With(
@@ -291,6 +328,109 @@ func (_d ClusterServiceWithSlog) AddStorageTargetNVME(ctx context.Context, clust
 	return _d._base.AddStorageTargetNVME(ctx, clusterName, target)
 }
 
+// Adopt implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) Adopt(ctx context.Context, cluster provisioning.Cluster) (cluster1 provisioning.Cluster, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("cluster", cluster),
+		)
+	}
+	log.DebugContext(ctx, "=> calling Adopt")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("cluster1", cluster1),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method Adopt returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method Adopt returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method Adopt finished")
+		}
+	}()
+	return _d._base.Adopt(ctx, cluster)
+}
+
+// ApplyClusterSpec implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) ApplyClusterSpec(ctx context.Context, spec api.ClusterSpec) (clusterSpecPlan api.ClusterSpecPlan, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("spec", spec),
+		)
+	}
+	log.DebugContext(ctx, "=> calling ApplyClusterSpec")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterSpecPlan", clusterSpecPlan),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method ApplyClusterSpec returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method ApplyClusterSpec returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method ApplyClusterSpec finished")
+		}
+	}()
+	return _d._base.ApplyClusterSpec(ctx, spec)
+}
+
+// CheckConsistency implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) CheckConsistency(ctx context.Context) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling CheckConsistency")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method CheckConsistency returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method CheckConsistency returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method CheckConsistency finished")
+		}
+	}()
+	return _d._base.CheckConsistency(ctx)
+}
+
 // ClusterUpdateControlLoop implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) ClusterUpdateControlLoop(ctx context.Context, clusterNameFilter *string) (err error) {
 	log := slog.With()
@@ -431,6 +571,39 @@ func (_d ClusterServiceWithSlog) DeleteByName(ctx context.Context, name string,
 	return _d._base.DeleteByName(ctx, name, force)
 }
 
+// DetectConfigurationDrift implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) DetectConfigurationDrift(ctx context.Context) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling DetectConfigurationDrift")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method DetectConfigurationDrift returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method DetectConfigurationDrift returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method DetectConfigurationDrift finished")
+		}
+	}()
+	return _d._base.DetectConfigurationDrift(ctx)
+}
+
 // GetAll implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) GetAll(ctx context.Context) (clusters provisioning.Clusters, err error) {
 	log := slog.With()
@@ -785,6 +958,76 @@ func (_d ClusterServiceWithSlog) GetClusterArtifactFileByName(ctx context.Contex
 	return _d._base.GetClusterArtifactFileByName(ctx, clusterName, artifactName, filename)
 }
 
+// GetClusterConsistency implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) GetClusterConsistency(ctx context.Context, name string) (clusterConsistency api.ClusterConsistency, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetClusterConsistency")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterConsistency", clusterConsistency),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetClusterConsistency returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetClusterConsistency returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetClusterConsistency finished")
+		}
+	}()
+	return _d._base.GetClusterConsistency(ctx, name)
+}
+
+// GetClusterTemplateDrift implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) GetClusterTemplateDrift(ctx context.Context, name string) (clusterTemplateDrift api.ClusterTemplateDrift, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetClusterTemplateDrift")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterTemplateDrift", clusterTemplateDrift),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetClusterTemplateDrift returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetClusterTemplateDrift returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetClusterTemplateDrift finished")
+		}
+	}()
+	return _d._base.GetClusterTemplateDrift(ctx, name)
+}
+
 // GetEndpoint implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) GetEndpoint(ctx context.Context, name string) (endpoint provisioning.Endpoint, err error) {
 	log := slog.With()
@@ -820,6 +1063,41 @@ func (_d ClusterServiceWithSlog) GetEndpoint(ctx context.Context, name string) (
 	return _d._base.GetEndpoint(ctx, name)
 }
 
+// GetOperationAll implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) GetOperationAll(ctx context.Context, name string) (clusterOperations provisioning.ClusterOperations, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetOperationAll")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterOperations", clusterOperations),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetOperationAll returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetOperationAll returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetOperationAll finished")
+		}
+	}()
+	return _d._base.GetOperationAll(ctx, name)
+}
+
 // IsInstanceLifecycleOperationPermitted implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) IsInstanceLifecycleOperationPermitted(ctx context.Context, name string) (b bool) {
 	log := slog.With()
@@ -843,6 +1121,74 @@ func (_d ClusterServiceWithSlog) IsInstanceLifecycleOperationPermitted(ctx conte
 	return _d._base.IsInstanceLifecycleOperationPermitted(ctx, name)
 }
 
+// LaunchAutomaticClusterUpdates implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) LaunchAutomaticClusterUpdates(ctx context.Context) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling LaunchAutomaticClusterUpdates")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method LaunchAutomaticClusterUpdates returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method LaunchAutomaticClusterUpdates returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method LaunchAutomaticClusterUpdates finished")
+		}
+	}()
+	return _d._base.LaunchAutomaticClusterUpdates(ctx)
+}
+
+// LaunchClusterFirmwareUpdate implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) LaunchClusterFirmwareUpdate(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Any("update", update),
+		)
+	}
+	log.DebugContext(ctx, "=> calling LaunchClusterFirmwareUpdate")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method LaunchClusterFirmwareUpdate returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method LaunchClusterFirmwareUpdate returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method LaunchClusterFirmwareUpdate finished")
+		}
+	}()
+	return _d._base.LaunchClusterFirmwareUpdate(ctx, name, update)
+}
+
 // LaunchClusterReboot implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) LaunchClusterReboot(ctx context.Context, name string) (err error) {
 	log := slog.With()
@@ -912,6 +1258,112 @@ func (_d ClusterServiceWithSlog) LaunchClusterUpdate(ctx context.Context, name s
 	return _d._base.LaunchClusterUpdate(ctx, name, reboot)
 }
 
+// PlanClusterReboot implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) PlanClusterReboot(ctx context.Context, name string) (clusterUpdatePlan api.ClusterUpdatePlan, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling PlanClusterReboot")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterUpdatePlan", clusterUpdatePlan),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method PlanClusterReboot returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method PlanClusterReboot returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method PlanClusterReboot finished")
+		}
+	}()
+	return _d._base.PlanClusterReboot(ctx, name)
+}
+
+// PlanClusterSpec implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) PlanClusterSpec(ctx context.Context, spec api.ClusterSpec) (clusterSpecPlan api.ClusterSpecPlan, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("spec", spec),
+		)
+	}
+	log.DebugContext(ctx, "=> calling PlanClusterSpec")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterSpecPlan", clusterSpecPlan),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method PlanClusterSpec returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method PlanClusterSpec returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method PlanClusterSpec finished")
+		}
+	}()
+	return _d._base.PlanClusterSpec(ctx, spec)
+}
+
+// PlanClusterUpdate implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) PlanClusterUpdate(ctx context.Context, name string, reboot bool) (clusterUpdatePlan api.ClusterUpdatePlan, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Bool("reboot", reboot),
+		)
+	}
+	log.DebugContext(ctx, "=> calling PlanClusterUpdate")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterUpdatePlan", clusterUpdatePlan),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method PlanClusterUpdate returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method PlanClusterUpdate returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method PlanClusterUpdate finished")
+		}
+	}()
+	return _d._base.PlanClusterUpdate(ctx, name, reboot)
+}
+
 // RemoveServer implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) RemoveServer(ctx context.Context, name string, removedServerNames []string) (err error) {
 	log := slog.With()
@@ -983,8 +1435,43 @@ func (_d ClusterServiceWithSlog) RemoveServerSystemNetworkVLANTags(ctx context.C
 	return _d._base.RemoveServerSystemNetworkVLANTags(ctx, clusterName, interfaceName, vlanTags)
 }
 
+// RemoveServiceCephCluster implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) RemoveServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("clusterName", clusterName),
+			slog.String("cephClusterName", cephClusterName),
+		)
+	}
+	log.DebugContext(ctx, "=> calling RemoveServiceCephCluster")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method RemoveServiceCephCluster returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method RemoveServiceCephCluster returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method RemoveServiceCephCluster finished")
+		}
+	}()
+	return _d._base.RemoveServiceCephCluster(ctx, clusterName, cephClusterName)
+}
+
 // RemoveStorageTargetISCSI implements provisioning.ClusterService.
-func (_d ClusterServiceWithSlog) RemoveStorageTargetISCSI(ctx context.Context, clusterName string, target api.ServiceISCSITarget) (err error) {
+func (_d ClusterServiceWithSlog) RemoveStorageTargetISCSI(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) (err error) {
 	log := slog.With()
 	if slog.Default().Enabled(ctx, logger.LevelTrace) {
 		log = log.With(
@@ -1054,7 +1541,7 @@ func (_d ClusterServiceWithSlog) RemoveStorageTargetMultipath(ctx context.Contex
 }
 
 // RemoveStorageTargetNVME implements provisioning.ClusterService.
-func (_d ClusterServiceWithSlog) RemoveStorageTargetNVME(ctx context.Context, clusterName string, target api.ServiceNVMETarget) (err error) {
+func (_d ClusterServiceWithSlog) RemoveStorageTargetNVME(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) (err error) {
 	log := slog.With()
 	if slog.Default().Enabled(ctx, logger.LevelTrace) {
 		log = log.With(
@@ -1123,6 +1610,42 @@ func (_d ClusterServiceWithSlog) Rename(ctx context.Context, oldName string, new
 	return _d._base.Rename(ctx, oldName, newName)
 }
 
+// ReplaceServer implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) ReplaceServer(ctx context.Context, name string, serverName string, replacementServerName string) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.String("serverName", serverName),
+			slog.String("replacementServerName", replacementServerName),
+		)
+	}
+	log.DebugContext(ctx, "=> calling ReplaceServer")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method ReplaceServer returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method ReplaceServer returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method ReplaceServer finished")
+		}
+	}()
+	return _d._base.ReplaceServer(ctx, name, serverName, replacementServerName)
+}
+
 // ResyncInventory implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) ResyncInventory(ctx context.Context) (err error) {
 	log := slog.With()
@@ -1311,6 +1834,76 @@ func (_d ClusterServiceWithSlog) UpdateCertificate(ctx context.Context, name str
 	return _d._base.UpdateCertificate(ctx, name, certificatePEM, keyPEM)
 }
 
+// UpdateServiceLinstor implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) UpdateServiceLinstor(ctx context.Context, clusterName string, linstorConfig api0.ServiceLinstorConfig) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("clusterName", clusterName),
+			slog.Any("linstorConfig", linstorConfig),
+		)
+	}
+	log.DebugContext(ctx, "=> calling UpdateServiceLinstor")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method UpdateServiceLinstor returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method UpdateServiceLinstor returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method UpdateServiceLinstor finished")
+		}
+	}()
+	return _d._base.UpdateServiceLinstor(ctx, clusterName, linstorConfig)
+}
+
+// UpdateServiceOVN implements provisioning.ClusterService.
+func (_d ClusterServiceWithSlog) UpdateServiceOVN(ctx context.Context, clusterName string, ovnConfig api0.ServiceOVNConfig) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("clusterName", clusterName),
+			slog.Any("ovnConfig", ovnConfig),
+		)
+	}
+	log.DebugContext(ctx, "=> calling UpdateServiceOVN")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method UpdateServiceOVN returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method UpdateServiceOVN returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method UpdateServiceOVN finished")
+		}
+	}()
+	return _d._base.UpdateServiceOVN(ctx, clusterName, ovnConfig)
+}
+
 // UpdateSystemKernel implements provisioning.ClusterService.
 func (_d ClusterServiceWithSlog) UpdateSystemKernel(ctx context.Context, clusterName string, kerneConfig provisioning.ServerSystemKernel) (err error) {
 	log := slog.With()
@@ -123,6 +123,48 @@ func (_d ClusterTemplateServiceWithPrometheus) GetByName(ctx context.Context, na
 	return _d.base.GetByName(ctx, name)
 }
 
+// GetRevision implements provisioning.ClusterTemplateService.
+func (_d ClusterTemplateServiceWithPrometheus) GetRevision(ctx context.Context, name string, revision int64) (clusterTemplateRevision *provisioning.ClusterTemplateRevision, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterTemplateServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetRevision", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetRevision(ctx, name, revision)
+}
+
+// GetRevisionAll implements provisioning.ClusterTemplateService.
+func (_d ClusterTemplateServiceWithPrometheus) GetRevisionAll(ctx context.Context, name string) (clusterTemplateRevisions provisioning.ClusterTemplateRevisions, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterTemplateServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetRevisionAll", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetRevisionAll(ctx, name)
+}
+
+// GetRevisionDiff implements provisioning.ClusterTemplateService.
+func (_d ClusterTemplateServiceWithPrometheus) GetRevisionDiff(ctx context.Context, name string, fromRevision int64, toRevision int64) (clusterTemplateRevisionDiff api.ClusterTemplateRevisionDiff, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterTemplateServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetRevisionDiff", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetRevisionDiff(ctx, name, fromRevision, toRevision)
+}
+
 // Rename implements provisioning.ClusterTemplateService.
 func (_d ClusterTemplateServiceWithPrometheus) Rename(ctx context.Context, oldName string, newName string) (err error) {
 	_since := time.Now()
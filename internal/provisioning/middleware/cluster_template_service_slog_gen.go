@@ -249,6 +249,114 @@ func (_d ClusterTemplateServiceWithSlog) GetByName(ctx context.Context, name str
 	return _d._base.GetByName(ctx, name)
 }
 
+// GetRevision implements provisioning.ClusterTemplateService.
+func (_d ClusterTemplateServiceWithSlog) GetRevision(ctx context.Context, name string, revision int64) (clusterTemplateRevision *provisioning.ClusterTemplateRevision, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Int64("revision", revision),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetRevision")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterTemplateRevision", clusterTemplateRevision),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetRevision returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetRevision returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetRevision finished")
+		}
+	}()
+	return _d._base.GetRevision(ctx, name, revision)
+}
+
+// GetRevisionAll implements provisioning.ClusterTemplateService.
+func (_d ClusterTemplateServiceWithSlog) GetRevisionAll(ctx context.Context, name string) (clusterTemplateRevisions provisioning.ClusterTemplateRevisions, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetRevisionAll")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterTemplateRevisions", clusterTemplateRevisions),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetRevisionAll returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetRevisionAll returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetRevisionAll finished")
+		}
+	}()
+	return _d._base.GetRevisionAll(ctx, name)
+}
+
+// GetRevisionDiff implements provisioning.ClusterTemplateService.
+func (_d ClusterTemplateServiceWithSlog) GetRevisionDiff(ctx context.Context, name string, fromRevision int64, toRevision int64) (clusterTemplateRevisionDiff api.ClusterTemplateRevisionDiff, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Int64("fromRevision", fromRevision),
+			slog.Int64("toRevision", toRevision),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetRevisionDiff")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterTemplateRevisionDiff", clusterTemplateRevisionDiff),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetRevisionDiff returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetRevisionDiff returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetRevisionDiff finished")
+		}
+	}()
+	return _d._base.GetRevisionDiff(ctx, name, fromRevision, toRevision)
+}
+
 // Rename implements provisioning.ClusterTemplateService.
 func (_d ClusterTemplateServiceWithSlog) Rename(ctx context.Context, oldName string, newName string) (err error) {
 	log := slog.With()
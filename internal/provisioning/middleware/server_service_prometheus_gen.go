@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -111,6 +112,48 @@ func (_d ServerServiceWithPrometheus) BMCDumpByName(ctx context.Context, name st
 	return _d.base.BMCDumpByName(ctx, name, additionalEndpoints, skipPredefined, trace)
 }
 
+// BMCFirmwareByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) BMCFirmwareByName(ctx context.Context, name string) (m1 map[string]api.BMCFirmware, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "BMCFirmwareByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.BMCFirmwareByName(ctx, name)
+}
+
+// BMCFirmwareUpdateByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) BMCFirmwareUpdateByName(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "BMCFirmwareUpdateByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.BMCFirmwareUpdateByName(ctx, name, update, clusterUpdate)
+}
+
+// BMCInstallByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) BMCInstallByName(ctx context.Context, name string, install api.ServerBMCInstallPost) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "BMCInstallByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.BMCInstallByName(ctx, name, install)
+}
+
 // BMCLogEntriesByNameAndLogSource implements provisioning.ServerService.
 func (_d ServerServiceWithPrometheus) BMCLogEntriesByNameAndLogSource(ctx context.Context, name string, logSource string) (bMCLogEvents []api.BMCLogEvent, err error) {
 	_since := time.Now()
@@ -209,6 +252,48 @@ func (_d ServerServiceWithPrometheus) BMCServerSetLocationIndicatorByName(ctx co
 	return _d.base.BMCServerSetLocationIndicatorByName(ctx, name, active)
 }
 
+// BulkAction implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) BulkAction(ctx context.Context, action provisioning.ServerBulkAction) (serverBulkActionResults provisioning.ServerBulkActionResults, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "BulkAction", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.BulkAction(ctx, action)
+}
+
+// ClusterJoinControlLoop implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) ClusterJoinControlLoop(ctx context.Context) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "ClusterJoinControlLoop", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.ClusterJoinControlLoop(ctx)
+}
+
+// DecommissionByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) DecommissionByName(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (serverDecommission *provisioning.ServerDecommission, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "DecommissionByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.DecommissionByName(ctx, name, options, confirmDiskWipe, resume)
+}
+
 // DeleteByName implements provisioning.ServerService.
 func (_d ServerServiceWithPrometheus) DeleteByName(ctx context.Context, name string) (err error) {
 	_since := time.Now()
@@ -307,6 +392,20 @@ func (_d ServerServiceWithPrometheus) GetAllWithFilter(ctx context.Context, filt
 	return _d.base.GetAllWithFilter(ctx, filter)
 }
 
+// GetBMCInstallMediaByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetBMCInstallMediaByName(ctx context.Context, name string, id uuid.UUID) (readSeekCloser io.ReadSeekCloser, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetBMCInstallMediaByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetBMCInstallMediaByName(ctx, name, id)
+}
+
 // GetByName implements provisioning.ServerService.
 func (_d ServerServiceWithPrometheus) GetByName(ctx context.Context, name string) (server *provisioning.Server, err error) {
 	_since := time.Now()
@@ -335,6 +434,90 @@ func (_d ServerServiceWithPrometheus) GetChangelogByName(ctx context.Context, na
 	return _d.base.GetChangelogByName(ctx, name)
 }
 
+// GetDecommissionByUUID implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (serverDecommission *provisioning.ServerDecommission, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDecommissionByUUID", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDecommissionByUUID(ctx, id)
+}
+
+// GetDecommissions implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetDecommissions(ctx context.Context) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDecommissions", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDecommissions(ctx)
+}
+
+// GetDecommissionsByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetDecommissionsByName(ctx context.Context, name string) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDecommissionsByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDecommissionsByName(ctx, name)
+}
+
+// GetDiskHealthByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetDiskHealthByName(ctx context.Context, name string) (serverDiskHealths []api.ServerDiskHealth, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDiskHealthByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDiskHealthByName(ctx, name)
+}
+
+// GetDiskHealthWithFilter implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetDiskHealthWithFilter(ctx context.Context, filter provisioning.ServerFilter) (serverDiskHealths []api.ServerDiskHealth, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDiskHealthWithFilter", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDiskHealthWithFilter(ctx, filter)
+}
+
+// GetHardwareHistoryByName implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) GetHardwareHistoryByName(ctx context.Context, name string) (serverHardwareSnapshots provisioning.ServerHardwareSnapshots, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "GetHardwareHistoryByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetHardwareHistoryByName(ctx, name)
+}
+
 // GetSystemKernel implements provisioning.ServerService.
 func (_d ServerServiceWithPrometheus) GetSystemKernel(ctx context.Context, name string) (v provisioning.ServerSystemKernel, err error) {
 	_since := time.Now()
@@ -461,6 +644,34 @@ func (_d ServerServiceWithPrometheus) PreRegister(ctx context.Context, server pr
 	return _d.base.PreRegister(ctx, server)
 }
 
+// Prune implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) Prune(ctx context.Context) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "Prune", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.Prune(ctx)
+}
+
+// PruneBMCInstallMedia implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) PruneBMCInstallMedia(ctx context.Context) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "PruneBMCInstallMedia", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.PruneBMCInstallMedia(ctx)
+}
+
 // RebootSystemByName implements provisioning.ServerService.
 func (_d ServerServiceWithPrometheus) RebootSystemByName(ctx context.Context, name string, force bool) (err error) {
 	_since := time.Now()
@@ -597,6 +808,26 @@ func (_d ServerServiceWithPrometheus) SetClusterService(clusterSvc provisioning.
 	_d.base.SetClusterService(clusterSvc)
 }
 
+// SetRolloutService implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) SetRolloutService(rolloutSvc provisioning.RolloutService) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "SetRolloutService", result).Observe(time.Since(_since).Seconds())
+	}()
+	_d.base.SetRolloutService(rolloutSvc)
+}
+
+// SetSiteService implements provisioning.ServerService.
+func (_d ServerServiceWithPrometheus) SetSiteService(siteSvc provisioning.SiteService) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		serverServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "SetSiteService", result).Observe(time.Since(_since).Seconds())
+	}()
+	_d.base.SetSiteService(siteSvc)
+}
+
 // SyncCluster implements provisioning.ServerService.
 func (_d ServerServiceWithPrometheus) SyncCluster(ctx context.Context, clusterName string) (err error) {
 	_since := time.Now()
@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"io"
 	"log/slog"
 
 	"github.com/google/uuid"
@@ -222,6 +223,112 @@ func (_d ServerServiceWithSlog) BMCDumpByName(ctx context.Context, name string,
 	return _d._base.BMCDumpByName(ctx, name, additionalEndpoints, skipPredefined, trace)
 }
 
+// BMCFirmwareByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) BMCFirmwareByName(ctx context.Context, name string) (m1 map[string]api.BMCFirmware, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling BMCFirmwareByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("m1", m1),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method BMCFirmwareByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method BMCFirmwareByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method BMCFirmwareByName finished")
+		}
+	}()
+	return _d._base.BMCFirmwareByName(ctx, name)
+}
+
+// BMCFirmwareUpdateByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) BMCFirmwareUpdateByName(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Any("update", update),
+			slog.Bool("clusterUpdate", clusterUpdate),
+		)
+	}
+	log.DebugContext(ctx, "=> calling BMCFirmwareUpdateByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method BMCFirmwareUpdateByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method BMCFirmwareUpdateByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method BMCFirmwareUpdateByName finished")
+		}
+	}()
+	return _d._base.BMCFirmwareUpdateByName(ctx, name, update, clusterUpdate)
+}
+
+// BMCInstallByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) BMCInstallByName(ctx context.Context, name string, install api.ServerBMCInstallPost) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Any("install", install),
+		)
+	}
+	log.DebugContext(ctx, "=> calling BMCInstallByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method BMCInstallByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method BMCInstallByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method BMCInstallByName finished")
+		}
+	}()
+	return _d._base.BMCInstallByName(ctx, name, install)
+}
+
 // BMCLogEntriesByNameAndLogSource implements provisioning.ServerService.
 func (_d ServerServiceWithSlog) BMCLogEntriesByNameAndLogSource(ctx context.Context, name string, logSource string) (bMCLogEvents []api.BMCLogEvent, err error) {
 	log := slog.With()
@@ -467,6 +574,112 @@ func (_d ServerServiceWithSlog) BMCServerSetLocationIndicatorByName(ctx context.
 	return _d._base.BMCServerSetLocationIndicatorByName(ctx, name, active)
 }
 
+// BulkAction implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) BulkAction(ctx context.Context, action provisioning.ServerBulkAction) (serverBulkActionResults provisioning.ServerBulkActionResults, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("action", action),
+		)
+	}
+	log.DebugContext(ctx, "=> calling BulkAction")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverBulkActionResults", serverBulkActionResults),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method BulkAction returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method BulkAction returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method BulkAction finished")
+		}
+	}()
+	return _d._base.BulkAction(ctx, action)
+}
+
+// ClusterJoinControlLoop implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) ClusterJoinControlLoop(ctx context.Context) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling ClusterJoinControlLoop")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method ClusterJoinControlLoop returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method ClusterJoinControlLoop returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method ClusterJoinControlLoop finished")
+		}
+	}()
+	return _d._base.ClusterJoinControlLoop(ctx)
+}
+
+// DecommissionByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) DecommissionByName(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (serverDecommission *provisioning.ServerDecommission, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Any("options", options),
+			slog.String("confirmDiskWipe", confirmDiskWipe),
+			slog.Bool("resume", resume),
+		)
+	}
+	log.DebugContext(ctx, "=> calling DecommissionByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommission", serverDecommission),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method DecommissionByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method DecommissionByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method DecommissionByName finished")
+		}
+	}()
+	return _d._base.DecommissionByName(ctx, name, options, confirmDiskWipe, resume)
+}
+
 // DeleteByName implements provisioning.ServerService.
 func (_d ServerServiceWithSlog) DeleteByName(ctx context.Context, name string) (err error) {
 	log := slog.With()
@@ -712,6 +925,42 @@ func (_d ServerServiceWithSlog) GetAllWithFilter(ctx context.Context, filter pro
 	return _d._base.GetAllWithFilter(ctx, filter)
 }
 
+// GetBMCInstallMediaByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetBMCInstallMediaByName(ctx context.Context, name string, id uuid.UUID) (readSeekCloser io.ReadSeekCloser, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Any("id", id),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetBMCInstallMediaByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("readSeekCloser", readSeekCloser),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetBMCInstallMediaByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetBMCInstallMediaByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetBMCInstallMediaByName finished")
+		}
+	}()
+	return _d._base.GetBMCInstallMediaByName(ctx, name, id)
+}
+
 // GetByName implements provisioning.ServerService.
 func (_d ServerServiceWithSlog) GetByName(ctx context.Context, name string) (server *provisioning.Server, err error) {
 	log := slog.With()
@@ -782,6 +1031,215 @@ func (_d ServerServiceWithSlog) GetChangelogByName(ctx context.Context, name str
 	return _d._base.GetChangelogByName(ctx, name)
 }
 
+// GetDecommissionByUUID implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (serverDecommission *provisioning.ServerDecommission, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("id", id),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDecommissionByUUID")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommission", serverDecommission),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDecommissionByUUID returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDecommissionByUUID returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDecommissionByUUID finished")
+		}
+	}()
+	return _d._base.GetDecommissionByUUID(ctx, id)
+}
+
+// GetDecommissions implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetDecommissions(ctx context.Context) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDecommissions")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommissions", serverDecommissions),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDecommissions returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDecommissions returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDecommissions finished")
+		}
+	}()
+	return _d._base.GetDecommissions(ctx)
+}
+
+// GetDecommissionsByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetDecommissionsByName(ctx context.Context, name string) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDecommissionsByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommissions", serverDecommissions),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDecommissionsByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDecommissionsByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDecommissionsByName finished")
+		}
+	}()
+	return _d._base.GetDecommissionsByName(ctx, name)
+}
+
+// GetDiskHealthByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetDiskHealthByName(ctx context.Context, name string) (serverDiskHealths []api.ServerDiskHealth, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDiskHealthByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDiskHealths", serverDiskHealths),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDiskHealthByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDiskHealthByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDiskHealthByName finished")
+		}
+	}()
+	return _d._base.GetDiskHealthByName(ctx, name)
+}
+
+// GetDiskHealthWithFilter implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetDiskHealthWithFilter(ctx context.Context, filter provisioning.ServerFilter) (serverDiskHealths []api.ServerDiskHealth, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("filter", filter),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDiskHealthWithFilter")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDiskHealths", serverDiskHealths),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDiskHealthWithFilter returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDiskHealthWithFilter returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDiskHealthWithFilter finished")
+		}
+	}()
+	return _d._base.GetDiskHealthWithFilter(ctx, filter)
+}
+
+// GetHardwareHistoryByName implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) GetHardwareHistoryByName(ctx context.Context, name string) (serverHardwareSnapshots provisioning.ServerHardwareSnapshots, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetHardwareHistoryByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverHardwareSnapshots", serverHardwareSnapshots),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetHardwareHistoryByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetHardwareHistoryByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetHardwareHistoryByName finished")
+		}
+	}()
+	return _d._base.GetHardwareHistoryByName(ctx, name)
+}
+
 // GetSystemKernel implements provisioning.ServerService.
 func (_d ServerServiceWithSlog) GetSystemKernel(ctx context.Context, name string) (v provisioning.ServerSystemKernel, err error) {
 	log := slog.With()
@@ -1096,6 +1554,72 @@ func (_d ServerServiceWithSlog) PreRegister(ctx context.Context, server provisio
 	return _d._base.PreRegister(ctx, server)
 }
 
+// Prune implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) Prune(ctx context.Context) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling Prune")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method Prune returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method Prune returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method Prune finished")
+		}
+	}()
+	return _d._base.Prune(ctx)
+}
+
+// PruneBMCInstallMedia implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) PruneBMCInstallMedia(ctx context.Context) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling PruneBMCInstallMedia")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method PruneBMCInstallMedia returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method PruneBMCInstallMedia returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method PruneBMCInstallMedia finished")
+		}
+	}()
+	return _d._base.PruneBMCInstallMedia(ctx)
+}
+
 // RebootSystemByName implements provisioning.ServerService.
 func (_d ServerServiceWithSlog) RebootSystemByName(ctx context.Context, name string, force bool) (err error) {
 	log := slog.With()
@@ -1426,6 +1950,40 @@ func (_d ServerServiceWithSlog) SetClusterService(clusterSvc provisioning.Cluste
 	_d._base.SetClusterService(clusterSvc)
 }
 
+// SetRolloutService implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) SetRolloutService(rolloutSvc provisioning.RolloutService) {
+	ctx := context.Background()
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("rolloutSvc", rolloutSvc),
+		)
+	}
+	log.DebugContext(ctx, "=> calling SetRolloutService")
+	defer func() {
+		log := slog.With()
+		log.DebugContext(ctx, "<= method SetRolloutService finished")
+	}()
+	_d._base.SetRolloutService(rolloutSvc)
+}
+
+// SetSiteService implements provisioning.ServerService.
+func (_d ServerServiceWithSlog) SetSiteService(siteSvc provisioning.SiteService) {
+	ctx := context.Background()
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("siteSvc", siteSvc),
+		)
+	}
+	log.DebugContext(ctx, "=> calling SetSiteService")
+	defer func() {
+		log := slog.With()
+		log.DebugContext(ctx, "<= method SetSiteService finished")
+	}()
+	_d._base.SetSiteService(siteSvc)
+}
+
 // SyncCluster implements provisioning.ServerService.
 func (_d ServerServiceWithSlog) SyncCluster(ctx context.Context, clusterName string) (err error) {
 	log := slog.With()
@@ -43,7 +43,7 @@ func NewTokenServiceWithPrometheus(base provisioning.TokenService, instanceName
 }
 
 // Consume implements provisioning.TokenService.
-func (_d TokenServiceWithPrometheus) Consume(ctx context.Context, id uuid.UUID) (channel string, err error) {
+func (_d TokenServiceWithPrometheus) Consume(ctx context.Context, id uuid.UUID) (token *provisioning.Token, err error) {
 	_since := time.Now()
 	defer func() {
 		result := "ok"
@@ -252,6 +252,16 @@ func (_d TokenServiceWithPrometheus) PreparePreSeededImage(ctx context.Context,
 	return _d.base.PreparePreSeededImage(ctx, id, imageType, architecture, seedConfig)
 }
 
+// SetClusterService implements provisioning.TokenService.
+func (_d TokenServiceWithPrometheus) SetClusterService(clusterSvc provisioning.ClusterService) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		tokenServiceDurationSummaryVec.WithLabelValues(_d.instanceName, "SetClusterService", result).Observe(time.Since(_since).Seconds())
+	}()
+	_d.base.SetClusterService(clusterSvc)
+}
+
 // Update implements provisioning.TokenService.
 func (_d TokenServiceWithPrometheus) Update(ctx context.Context, token provisioning.Token) (err error) {
 	_since := time.Now()
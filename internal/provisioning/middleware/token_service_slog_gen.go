@@ -45,7 +45,7 @@ func NewTokenServiceWithSlog(base provisioning.TokenService, opts ...TokenServic
 }
 
 // Consume implements provisioning.TokenService.
-func (_d TokenServiceWithSlog) Consume(ctx context.Context, id uuid.UUID) (channel string, err error) {
+func (_d TokenServiceWithSlog) Consume(ctx context.Context, id uuid.UUID) (token *provisioning.Token, err error) {
 	log := slog.With()
 	if slog.Default().Enabled(ctx, logger.LevelTrace) {
 		log = log.With(
@@ -58,7 +58,7 @@ func (_d TokenServiceWithSlog) Consume(ctx context.Context, id uuid.UUID) (chann
 		log := slog.With()
 		if slog.Default().Enabled(ctx, logger.LevelTrace) {
 			log = slog.With(
-				slog.String("channel", channel),
+				slog.Any("token", token),
 				slog.Any("err", err),
 			)
 		} else {
@@ -576,6 +576,23 @@ func (_d TokenServiceWithSlog) PreparePreSeededImage(ctx context.Context, id uui
 	return _d._base.PreparePreSeededImage(ctx, id, imageType, architecture, seedConfig)
 }
 
+// SetClusterService implements provisioning.TokenService.
+func (_d TokenServiceWithSlog) SetClusterService(clusterSvc provisioning.ClusterService) {
+	ctx := context.Background()
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("clusterSvc", clusterSvc),
+		)
+	}
+	log.DebugContext(ctx, "=> calling SetClusterService")
+	defer func() {
+		log := slog.With()
+		log.DebugContext(ctx, "<= method SetClusterService finished")
+	}()
+	_d._base.SetClusterService(clusterSvc)
+}
+
 // Update implements provisioning.TokenService.
 func (_d TokenServiceWithSlog) Update(ctx context.Context, token provisioning.Token) (err error) {
 	log := slog.With()
@@ -10,10 +10,11 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
-	"github.com/lxc/incus-os/incus-osd/api"
+	api0 "github.com/lxc/incus-os/incus-osd/api"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 // Ensure that ClusterServiceMock does implement provisioning.ClusterService.
@@ -38,15 +39,27 @@ var _ provisioning.ClusterService = &ClusterServiceMock{}
 //			AddServersFunc: func(ctx context.Context, name string, serverNames []string, skipPostJoinOperations bool, copyServicesConfig bool) error {
 //				panic("mock out the AddServers method")
 //			},
-//			AddStorageTargetISCSIFunc: func(ctx context.Context, clusterName string, target api.ServiceISCSITarget) error {
+//			AddServiceCephClusterFunc: func(ctx context.Context, clusterName string, cephClusterName string, cephCluster api0.ServiceCephCluster) error {
+//				panic("mock out the AddServiceCephCluster method")
+//			},
+//			AddStorageTargetISCSIFunc: func(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) error {
 //				panic("mock out the AddStorageTargetISCSI method")
 //			},
 //			AddStorageTargetMultipathFunc: func(ctx context.Context, clusterName string, target string) error {
 //				panic("mock out the AddStorageTargetMultipath method")
 //			},
-//			AddStorageTargetNVMEFunc: func(ctx context.Context, clusterName string, target api.ServiceNVMETarget) error {
+//			AddStorageTargetNVMEFunc: func(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) error {
 //				panic("mock out the AddStorageTargetNVME method")
 //			},
+//			AdoptFunc: func(ctx context.Context, cluster provisioning.Cluster) (provisioning.Cluster, error) {
+//				panic("mock out the Adopt method")
+//			},
+//			ApplyClusterSpecFunc: func(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+//				panic("mock out the ApplyClusterSpec method")
+//			},
+//			CheckConsistencyFunc: func(ctx context.Context) error {
+//				panic("mock out the CheckConsistency method")
+//			},
 //			ClusterUpdateControlLoopFunc: func(ctx context.Context, clusterNameFilter *string) error {
 //				panic("mock out the ClusterUpdateControlLoop method")
 //			},
@@ -59,6 +72,9 @@ var _ provisioning.ClusterService = &ClusterServiceMock{}
 //			DeleteByNameFunc: func(ctx context.Context, name string, force bool) error {
 //				panic("mock out the DeleteByName method")
 //			},
+//			DetectConfigurationDriftFunc: func(ctx context.Context) error {
+//				panic("mock out the DetectConfigurationDrift method")
+//			},
 //			GetAllFunc: func(ctx context.Context) (provisioning.Clusters, error) {
 //				panic("mock out the GetAll method")
 //			},
@@ -89,36 +105,66 @@ var _ provisioning.ClusterService = &ClusterServiceMock{}
 //			GetClusterArtifactFileByNameFunc: func(ctx context.Context, clusterName string, artifactName string, filename string) (*provisioning.ClusterArtifactFile, error) {
 //				panic("mock out the GetClusterArtifactFileByName method")
 //			},
+//			GetClusterConsistencyFunc: func(ctx context.Context, name string) (api.ClusterConsistency, error) {
+//				panic("mock out the GetClusterConsistency method")
+//			},
+//			GetClusterTemplateDriftFunc: func(ctx context.Context, name string) (api.ClusterTemplateDrift, error) {
+//				panic("mock out the GetClusterTemplateDrift method")
+//			},
 //			GetEndpointFunc: func(ctx context.Context, name string) (provisioning.Endpoint, error) {
 //				panic("mock out the GetEndpoint method")
 //			},
+//			GetOperationAllFunc: func(ctx context.Context, name string) (provisioning.ClusterOperations, error) {
+//				panic("mock out the GetOperationAll method")
+//			},
 //			IsInstanceLifecycleOperationPermittedFunc: func(ctx context.Context, name string) bool {
 //				panic("mock out the IsInstanceLifecycleOperationPermitted method")
 //			},
+//			LaunchAutomaticClusterUpdatesFunc: func(ctx context.Context) error {
+//				panic("mock out the LaunchAutomaticClusterUpdates method")
+//			},
+//			LaunchClusterFirmwareUpdateFunc: func(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error {
+//				panic("mock out the LaunchClusterFirmwareUpdate method")
+//			},
 //			LaunchClusterRebootFunc: func(ctx context.Context, name string) error {
 //				panic("mock out the LaunchClusterReboot method")
 //			},
 //			LaunchClusterUpdateFunc: func(ctx context.Context, name string, reboot bool) error {
 //				panic("mock out the LaunchClusterUpdate method")
 //			},
+//			PlanClusterRebootFunc: func(ctx context.Context, name string) (api.ClusterUpdatePlan, error) {
+//				panic("mock out the PlanClusterReboot method")
+//			},
+//			PlanClusterSpecFunc: func(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+//				panic("mock out the PlanClusterSpec method")
+//			},
+//			PlanClusterUpdateFunc: func(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error) {
+//				panic("mock out the PlanClusterUpdate method")
+//			},
 //			RemoveServerFunc: func(ctx context.Context, name string, removedServerNames []string) error {
 //				panic("mock out the RemoveServer method")
 //			},
 //			RemoveServerSystemNetworkVLANTagsFunc: func(ctx context.Context, clusterName string, interfaceName string, vlanTags []int) error {
 //				panic("mock out the RemoveServerSystemNetworkVLANTags method")
 //			},
-//			RemoveStorageTargetISCSIFunc: func(ctx context.Context, clusterName string, target api.ServiceISCSITarget) error {
+//			RemoveServiceCephClusterFunc: func(ctx context.Context, clusterName string, cephClusterName string) error {
+//				panic("mock out the RemoveServiceCephCluster method")
+//			},
+//			RemoveStorageTargetISCSIFunc: func(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) error {
 //				panic("mock out the RemoveStorageTargetISCSI method")
 //			},
 //			RemoveStorageTargetMultipathFunc: func(ctx context.Context, clusterName string, target string) error {
 //				panic("mock out the RemoveStorageTargetMultipath method")
 //			},
-//			RemoveStorageTargetNVMEFunc: func(ctx context.Context, clusterName string, target api.ServiceNVMETarget) error {
+//			RemoveStorageTargetNVMEFunc: func(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) error {
 //				panic("mock out the RemoveStorageTargetNVME method")
 //			},
 //			RenameFunc: func(ctx context.Context, oldName string, newName string) error {
 //				panic("mock out the Rename method")
 //			},
+//			ReplaceServerFunc: func(ctx context.Context, name string, serverName string, replacementServerName string) error {
+//				panic("mock out the ReplaceServer method")
+//			},
 //			ResyncInventoryFunc: func(ctx context.Context) error {
 //				panic("mock out the ResyncInventory method")
 //			},
@@ -137,6 +183,12 @@ var _ provisioning.ClusterService = &ClusterServiceMock{}
 //			UpdateCertificateFunc: func(ctx context.Context, name string, certificatePEM string, keyPEM string) error {
 //				panic("mock out the UpdateCertificate method")
 //			},
+//			UpdateServiceLinstorFunc: func(ctx context.Context, clusterName string, linstorConfig api0.ServiceLinstorConfig) error {
+//				panic("mock out the UpdateServiceLinstor method")
+//			},
+//			UpdateServiceOVNFunc: func(ctx context.Context, clusterName string, ovnConfig api0.ServiceOVNConfig) error {
+//				panic("mock out the UpdateServiceOVN method")
+//			},
 //			UpdateSystemKernelFunc: func(ctx context.Context, clusterName string, kerneConfig provisioning.ServerSystemKernel) error {
 //				panic("mock out the UpdateSystemKernel method")
 //			},
@@ -162,14 +214,26 @@ type ClusterServiceMock struct {
 	// AddServersFunc mocks the AddServers method.
 	AddServersFunc func(ctx context.Context, name string, serverNames []string, skipPostJoinOperations bool, copyServicesConfig bool) error
 
+	// AddServiceCephClusterFunc mocks the AddServiceCephCluster method.
+	AddServiceCephClusterFunc func(ctx context.Context, clusterName string, cephClusterName string, cephCluster api0.ServiceCephCluster) error
+
 	// AddStorageTargetISCSIFunc mocks the AddStorageTargetISCSI method.
-	AddStorageTargetISCSIFunc func(ctx context.Context, clusterName string, target api.ServiceISCSITarget) error
+	AddStorageTargetISCSIFunc func(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) error
 
 	// AddStorageTargetMultipathFunc mocks the AddStorageTargetMultipath method.
 	AddStorageTargetMultipathFunc func(ctx context.Context, clusterName string, target string) error
 
 	// AddStorageTargetNVMEFunc mocks the AddStorageTargetNVME method.
-	AddStorageTargetNVMEFunc func(ctx context.Context, clusterName string, target api.ServiceNVMETarget) error
+	AddStorageTargetNVMEFunc func(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) error
+
+	// AdoptFunc mocks the Adopt method.
+	AdoptFunc func(ctx context.Context, cluster provisioning.Cluster) (provisioning.Cluster, error)
+
+	// ApplyClusterSpecFunc mocks the ApplyClusterSpec method.
+	ApplyClusterSpecFunc func(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error)
+
+	// CheckConsistencyFunc mocks the CheckConsistency method.
+	CheckConsistencyFunc func(ctx context.Context) error
 
 	// ClusterUpdateControlLoopFunc mocks the ClusterUpdateControlLoop method.
 	ClusterUpdateControlLoopFunc func(ctx context.Context, clusterNameFilter *string) error
@@ -183,6 +247,9 @@ type ClusterServiceMock struct {
 	// DeleteByNameFunc mocks the DeleteByName method.
 	DeleteByNameFunc func(ctx context.Context, name string, force bool) error
 
+	// DetectConfigurationDriftFunc mocks the DetectConfigurationDrift method.
+	DetectConfigurationDriftFunc func(ctx context.Context) error
+
 	// GetAllFunc mocks the GetAll method.
 	GetAllFunc func(ctx context.Context) (provisioning.Clusters, error)
 
@@ -213,36 +280,66 @@ type ClusterServiceMock struct {
 	// GetClusterArtifactFileByNameFunc mocks the GetClusterArtifactFileByName method.
 	GetClusterArtifactFileByNameFunc func(ctx context.Context, clusterName string, artifactName string, filename string) (*provisioning.ClusterArtifactFile, error)
 
+	// GetClusterConsistencyFunc mocks the GetClusterConsistency method.
+	GetClusterConsistencyFunc func(ctx context.Context, name string) (api.ClusterConsistency, error)
+
+	// GetClusterTemplateDriftFunc mocks the GetClusterTemplateDrift method.
+	GetClusterTemplateDriftFunc func(ctx context.Context, name string) (api.ClusterTemplateDrift, error)
+
 	// GetEndpointFunc mocks the GetEndpoint method.
 	GetEndpointFunc func(ctx context.Context, name string) (provisioning.Endpoint, error)
 
+	// GetOperationAllFunc mocks the GetOperationAll method.
+	GetOperationAllFunc func(ctx context.Context, name string) (provisioning.ClusterOperations, error)
+
 	// IsInstanceLifecycleOperationPermittedFunc mocks the IsInstanceLifecycleOperationPermitted method.
 	IsInstanceLifecycleOperationPermittedFunc func(ctx context.Context, name string) bool
 
+	// LaunchAutomaticClusterUpdatesFunc mocks the LaunchAutomaticClusterUpdates method.
+	LaunchAutomaticClusterUpdatesFunc func(ctx context.Context) error
+
+	// LaunchClusterFirmwareUpdateFunc mocks the LaunchClusterFirmwareUpdate method.
+	LaunchClusterFirmwareUpdateFunc func(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error
+
 	// LaunchClusterRebootFunc mocks the LaunchClusterReboot method.
 	LaunchClusterRebootFunc func(ctx context.Context, name string) error
 
 	// LaunchClusterUpdateFunc mocks the LaunchClusterUpdate method.
 	LaunchClusterUpdateFunc func(ctx context.Context, name string, reboot bool) error
 
+	// PlanClusterRebootFunc mocks the PlanClusterReboot method.
+	PlanClusterRebootFunc func(ctx context.Context, name string) (api.ClusterUpdatePlan, error)
+
+	// PlanClusterSpecFunc mocks the PlanClusterSpec method.
+	PlanClusterSpecFunc func(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error)
+
+	// PlanClusterUpdateFunc mocks the PlanClusterUpdate method.
+	PlanClusterUpdateFunc func(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error)
+
 	// RemoveServerFunc mocks the RemoveServer method.
 	RemoveServerFunc func(ctx context.Context, name string, removedServerNames []string) error
 
 	// RemoveServerSystemNetworkVLANTagsFunc mocks the RemoveServerSystemNetworkVLANTags method.
 	RemoveServerSystemNetworkVLANTagsFunc func(ctx context.Context, clusterName string, interfaceName string, vlanTags []int) error
 
+	// RemoveServiceCephClusterFunc mocks the RemoveServiceCephCluster method.
+	RemoveServiceCephClusterFunc func(ctx context.Context, clusterName string, cephClusterName string) error
+
 	// RemoveStorageTargetISCSIFunc mocks the RemoveStorageTargetISCSI method.
-	RemoveStorageTargetISCSIFunc func(ctx context.Context, clusterName string, target api.ServiceISCSITarget) error
+	RemoveStorageTargetISCSIFunc func(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) error
 
 	// RemoveStorageTargetMultipathFunc mocks the RemoveStorageTargetMultipath method.
 	RemoveStorageTargetMultipathFunc func(ctx context.Context, clusterName string, target string) error
 
 	// RemoveStorageTargetNVMEFunc mocks the RemoveStorageTargetNVME method.
-	RemoveStorageTargetNVMEFunc func(ctx context.Context, clusterName string, target api.ServiceNVMETarget) error
+	RemoveStorageTargetNVMEFunc func(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) error
 
 	// RenameFunc mocks the Rename method.
 	RenameFunc func(ctx context.Context, oldName string, newName string) error
 
+	// ReplaceServerFunc mocks the ReplaceServer method.
+	ReplaceServerFunc func(ctx context.Context, name string, serverName string, replacementServerName string) error
+
 	// ResyncInventoryFunc mocks the ResyncInventory method.
 	ResyncInventoryFunc func(ctx context.Context) error
 
@@ -261,6 +358,12 @@ type ClusterServiceMock struct {
 	// UpdateCertificateFunc mocks the UpdateCertificate method.
 	UpdateCertificateFunc func(ctx context.Context, name string, certificatePEM string, keyPEM string) error
 
+	// UpdateServiceLinstorFunc mocks the UpdateServiceLinstor method.
+	UpdateServiceLinstorFunc func(ctx context.Context, clusterName string, linstorConfig api0.ServiceLinstorConfig) error
+
+	// UpdateServiceOVNFunc mocks the UpdateServiceOVN method.
+	UpdateServiceOVNFunc func(ctx context.Context, clusterName string, ovnConfig api0.ServiceOVNConfig) error
+
 	// UpdateSystemKernelFunc mocks the UpdateSystemKernel method.
 	UpdateSystemKernelFunc func(ctx context.Context, clusterName string, kerneConfig provisioning.ServerSystemKernel) error
 
@@ -309,6 +412,17 @@ type ClusterServiceMock struct {
 			// CopyServicesConfig is the copyServicesConfig argument value.
 			CopyServicesConfig bool
 		}
+		// AddServiceCephCluster holds details about calls to the AddServiceCephCluster method.
+		AddServiceCephCluster []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterName is the clusterName argument value.
+			ClusterName string
+			// CephClusterName is the cephClusterName argument value.
+			CephClusterName string
+			// CephCluster is the cephCluster argument value.
+			CephCluster api0.ServiceCephCluster
+		}
 		// AddStorageTargetISCSI holds details about calls to the AddStorageTargetISCSI method.
 		AddStorageTargetISCSI []struct {
 			// Ctx is the ctx argument value.
@@ -316,7 +430,7 @@ type ClusterServiceMock struct {
 			// ClusterName is the clusterName argument value.
 			ClusterName string
 			// Target is the target argument value.
-			Target api.ServiceISCSITarget
+			Target api0.ServiceISCSITarget
 		}
 		// AddStorageTargetMultipath holds details about calls to the AddStorageTargetMultipath method.
 		AddStorageTargetMultipath []struct {
@@ -334,7 +448,26 @@ type ClusterServiceMock struct {
 			// ClusterName is the clusterName argument value.
 			ClusterName string
 			// Target is the target argument value.
-			Target api.ServiceNVMETarget
+			Target api0.ServiceNVMETarget
+		}
+		// Adopt holds details about calls to the Adopt method.
+		Adopt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cluster is the cluster argument value.
+			Cluster provisioning.Cluster
+		}
+		// ApplyClusterSpec holds details about calls to the ApplyClusterSpec method.
+		ApplyClusterSpec []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Spec is the spec argument value.
+			Spec api.ClusterSpec
+		}
+		// CheckConsistency holds details about calls to the CheckConsistency method.
+		CheckConsistency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 		}
 		// ClusterUpdateControlLoop holds details about calls to the ClusterUpdateControlLoop method.
 		ClusterUpdateControlLoop []struct {
@@ -370,6 +503,11 @@ type ClusterServiceMock struct {
 			// Force is the force argument value.
 			Force bool
 		}
+		// DetectConfigurationDrift holds details about calls to the DetectConfigurationDrift method.
+		DetectConfigurationDrift []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetAll holds details about calls to the GetAll method.
 		GetAll []struct {
 			// Ctx is the ctx argument value.
@@ -446,6 +584,20 @@ type ClusterServiceMock struct {
 			// Filename is the filename argument value.
 			Filename string
 		}
+		// GetClusterConsistency holds details about calls to the GetClusterConsistency method.
+		GetClusterConsistency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetClusterTemplateDrift holds details about calls to the GetClusterTemplateDrift method.
+		GetClusterTemplateDrift []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// GetEndpoint holds details about calls to the GetEndpoint method.
 		GetEndpoint []struct {
 			// Ctx is the ctx argument value.
@@ -453,6 +605,13 @@ type ClusterServiceMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetOperationAll holds details about calls to the GetOperationAll method.
+		GetOperationAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// IsInstanceLifecycleOperationPermitted holds details about calls to the IsInstanceLifecycleOperationPermitted method.
 		IsInstanceLifecycleOperationPermitted []struct {
 			// Ctx is the ctx argument value.
@@ -460,6 +619,20 @@ type ClusterServiceMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// LaunchAutomaticClusterUpdates holds details about calls to the LaunchAutomaticClusterUpdates method.
+		LaunchAutomaticClusterUpdates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// LaunchClusterFirmwareUpdate holds details about calls to the LaunchClusterFirmwareUpdate method.
+		LaunchClusterFirmwareUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Update is the update argument value.
+			Update api.BMCFirmwareUpdatePost
+		}
 		// LaunchClusterReboot holds details about calls to the LaunchClusterReboot method.
 		LaunchClusterReboot []struct {
 			// Ctx is the ctx argument value.
@@ -476,6 +649,29 @@ type ClusterServiceMock struct {
 			// Reboot is the reboot argument value.
 			Reboot bool
 		}
+		// PlanClusterReboot holds details about calls to the PlanClusterReboot method.
+		PlanClusterReboot []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// PlanClusterSpec holds details about calls to the PlanClusterSpec method.
+		PlanClusterSpec []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Spec is the spec argument value.
+			Spec api.ClusterSpec
+		}
+		// PlanClusterUpdate holds details about calls to the PlanClusterUpdate method.
+		PlanClusterUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Reboot is the reboot argument value.
+			Reboot bool
+		}
 		// RemoveServer holds details about calls to the RemoveServer method.
 		RemoveServer []struct {
 			// Ctx is the ctx argument value.
@@ -496,6 +692,15 @@ type ClusterServiceMock struct {
 			// VlanTags is the vlanTags argument value.
 			VlanTags []int
 		}
+		// RemoveServiceCephCluster holds details about calls to the RemoveServiceCephCluster method.
+		RemoveServiceCephCluster []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterName is the clusterName argument value.
+			ClusterName string
+			// CephClusterName is the cephClusterName argument value.
+			CephClusterName string
+		}
 		// RemoveStorageTargetISCSI holds details about calls to the RemoveStorageTargetISCSI method.
 		RemoveStorageTargetISCSI []struct {
 			// Ctx is the ctx argument value.
@@ -503,7 +708,7 @@ type ClusterServiceMock struct {
 			// ClusterName is the clusterName argument value.
 			ClusterName string
 			// Target is the target argument value.
-			Target api.ServiceISCSITarget
+			Target api0.ServiceISCSITarget
 		}
 		// RemoveStorageTargetMultipath holds details about calls to the RemoveStorageTargetMultipath method.
 		RemoveStorageTargetMultipath []struct {
@@ -521,7 +726,7 @@ type ClusterServiceMock struct {
 			// ClusterName is the clusterName argument value.
 			ClusterName string
 			// Target is the target argument value.
-			Target api.ServiceNVMETarget
+			Target api0.ServiceNVMETarget
 		}
 		// Rename holds details about calls to the Rename method.
 		Rename []struct {
@@ -532,6 +737,17 @@ type ClusterServiceMock struct {
 			// NewName is the newName argument value.
 			NewName string
 		}
+		// ReplaceServer holds details about calls to the ReplaceServer method.
+		ReplaceServer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// ServerName is the serverName argument value.
+			ServerName string
+			// ReplacementServerName is the replacementServerName argument value.
+			ReplacementServerName string
+		}
 		// ResyncInventory holds details about calls to the ResyncInventory method.
 		ResyncInventory []struct {
 			// Ctx is the ctx argument value.
@@ -574,6 +790,24 @@ type ClusterServiceMock struct {
 			// KeyPEM is the keyPEM argument value.
 			KeyPEM string
 		}
+		// UpdateServiceLinstor holds details about calls to the UpdateServiceLinstor method.
+		UpdateServiceLinstor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterName is the clusterName argument value.
+			ClusterName string
+			// LinstorConfig is the linstorConfig argument value.
+			LinstorConfig api0.ServiceLinstorConfig
+		}
+		// UpdateServiceOVN holds details about calls to the UpdateServiceOVN method.
+		UpdateServiceOVN []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterName is the clusterName argument value.
+			ClusterName string
+			// OvnConfig is the ovnConfig argument value.
+			OvnConfig api0.ServiceOVNConfig
+		}
 		// UpdateSystemKernel holds details about calls to the UpdateSystemKernel method.
 		UpdateSystemKernel []struct {
 			// Ctx is the ctx argument value.
@@ -597,13 +831,18 @@ type ClusterServiceMock struct {
 	lockAddApplication                        sync.RWMutex
 	lockAddServerSystemNetworkVLANTags        sync.RWMutex
 	lockAddServers                            sync.RWMutex
+	lockAddServiceCephCluster                 sync.RWMutex
 	lockAddStorageTargetISCSI                 sync.RWMutex
 	lockAddStorageTargetMultipath             sync.RWMutex
 	lockAddStorageTargetNVME                  sync.RWMutex
+	lockAdopt                                 sync.RWMutex
+	lockApplyClusterSpec                      sync.RWMutex
+	lockCheckConsistency                      sync.RWMutex
 	lockClusterUpdateControlLoop              sync.RWMutex
 	lockCreate                                sync.RWMutex
 	lockDeleteAndFactoryResetByName           sync.RWMutex
 	lockDeleteByName                          sync.RWMutex
+	lockDetectConfigurationDrift              sync.RWMutex
 	lockGetAll                                sync.RWMutex
 	lockGetAllNames                           sync.RWMutex
 	lockGetAllNamesWithFilter                 sync.RWMutex
@@ -614,22 +853,34 @@ type ClusterServiceMock struct {
 	lockGetClusterArtifactArchiveByName       sync.RWMutex
 	lockGetClusterArtifactByName              sync.RWMutex
 	lockGetClusterArtifactFileByName          sync.RWMutex
+	lockGetClusterConsistency                 sync.RWMutex
+	lockGetClusterTemplateDrift               sync.RWMutex
 	lockGetEndpoint                           sync.RWMutex
+	lockGetOperationAll                       sync.RWMutex
 	lockIsInstanceLifecycleOperationPermitted sync.RWMutex
+	lockLaunchAutomaticClusterUpdates         sync.RWMutex
+	lockLaunchClusterFirmwareUpdate           sync.RWMutex
 	lockLaunchClusterReboot                   sync.RWMutex
 	lockLaunchClusterUpdate                   sync.RWMutex
+	lockPlanClusterReboot                     sync.RWMutex
+	lockPlanClusterSpec                       sync.RWMutex
+	lockPlanClusterUpdate                     sync.RWMutex
 	lockRemoveServer                          sync.RWMutex
 	lockRemoveServerSystemNetworkVLANTags     sync.RWMutex
+	lockRemoveServiceCephCluster              sync.RWMutex
 	lockRemoveStorageTargetISCSI              sync.RWMutex
 	lockRemoveStorageTargetMultipath          sync.RWMutex
 	lockRemoveStorageTargetNVME               sync.RWMutex
 	lockRename                                sync.RWMutex
+	lockReplaceServer                         sync.RWMutex
 	lockResyncInventory                       sync.RWMutex
 	lockResyncInventoryByName                 sync.RWMutex
 	lockSetInventorySyncers                   sync.RWMutex
 	lockStartLifecycleEventsMonitor           sync.RWMutex
 	lockUpdate                                sync.RWMutex
 	lockUpdateCertificate                     sync.RWMutex
+	lockUpdateServiceLinstor                  sync.RWMutex
+	lockUpdateServiceOVN                      sync.RWMutex
 	lockUpdateSystemKernel                    sync.RWMutex
 	lockUpdateSystemLogging                   sync.RWMutex
 }
@@ -802,15 +1053,59 @@ func (mock *ClusterServiceMock) AddServersCalls() []struct {
 	return calls
 }
 
+// AddServiceCephCluster calls AddServiceCephClusterFunc.
+func (mock *ClusterServiceMock) AddServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string, cephCluster api0.ServiceCephCluster) error {
+	if mock.AddServiceCephClusterFunc == nil {
+		panic("ClusterServiceMock.AddServiceCephClusterFunc: method is nil but ClusterService.AddServiceCephCluster was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		ClusterName     string
+		CephClusterName string
+		CephCluster     api0.ServiceCephCluster
+	}{
+		Ctx:             ctx,
+		ClusterName:     clusterName,
+		CephClusterName: cephClusterName,
+		CephCluster:     cephCluster,
+	}
+	mock.lockAddServiceCephCluster.Lock()
+	mock.calls.AddServiceCephCluster = append(mock.calls.AddServiceCephCluster, callInfo)
+	mock.lockAddServiceCephCluster.Unlock()
+	return mock.AddServiceCephClusterFunc(ctx, clusterName, cephClusterName, cephCluster)
+}
+
+// AddServiceCephClusterCalls gets all the calls that were made to AddServiceCephCluster.
+// Check the length with:
+//
+//	len(mockedClusterService.AddServiceCephClusterCalls())
+func (mock *ClusterServiceMock) AddServiceCephClusterCalls() []struct {
+	Ctx             context.Context
+	ClusterName     string
+	CephClusterName string
+	CephCluster     api0.ServiceCephCluster
+} {
+	var calls []struct {
+		Ctx             context.Context
+		ClusterName     string
+		CephClusterName string
+		CephCluster     api0.ServiceCephCluster
+	}
+	mock.lockAddServiceCephCluster.RLock()
+	calls = mock.calls.AddServiceCephCluster
+	mock.lockAddServiceCephCluster.RUnlock()
+	return calls
+}
+
 // AddStorageTargetISCSI calls AddStorageTargetISCSIFunc.
-func (mock *ClusterServiceMock) AddStorageTargetISCSI(ctx context.Context, clusterName string, target api.ServiceISCSITarget) error {
+func (mock *ClusterServiceMock) AddStorageTargetISCSI(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) error {
 	if mock.AddStorageTargetISCSIFunc == nil {
 		panic("ClusterServiceMock.AddStorageTargetISCSIFunc: method is nil but ClusterService.AddStorageTargetISCSI was just called")
 	}
 	callInfo := struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceISCSITarget
+		Target      api0.ServiceISCSITarget
 	}{
 		Ctx:         ctx,
 		ClusterName: clusterName,
@@ -829,12 +1124,12 @@ func (mock *ClusterServiceMock) AddStorageTargetISCSI(ctx context.Context, clust
 func (mock *ClusterServiceMock) AddStorageTargetISCSICalls() []struct {
 	Ctx         context.Context
 	ClusterName string
-	Target      api.ServiceISCSITarget
+	Target      api0.ServiceISCSITarget
 } {
 	var calls []struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceISCSITarget
+		Target      api0.ServiceISCSITarget
 	}
 	mock.lockAddStorageTargetISCSI.RLock()
 	calls = mock.calls.AddStorageTargetISCSI
@@ -883,14 +1178,14 @@ func (mock *ClusterServiceMock) AddStorageTargetMultipathCalls() []struct {
 }
 
 // AddStorageTargetNVME calls AddStorageTargetNVMEFunc.
-func (mock *ClusterServiceMock) AddStorageTargetNVME(ctx context.Context, clusterName string, target api.ServiceNVMETarget) error {
+func (mock *ClusterServiceMock) AddStorageTargetNVME(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) error {
 	if mock.AddStorageTargetNVMEFunc == nil {
 		panic("ClusterServiceMock.AddStorageTargetNVMEFunc: method is nil but ClusterService.AddStorageTargetNVME was just called")
 	}
 	callInfo := struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceNVMETarget
+		Target      api0.ServiceNVMETarget
 	}{
 		Ctx:         ctx,
 		ClusterName: clusterName,
@@ -909,12 +1204,12 @@ func (mock *ClusterServiceMock) AddStorageTargetNVME(ctx context.Context, cluste
 func (mock *ClusterServiceMock) AddStorageTargetNVMECalls() []struct {
 	Ctx         context.Context
 	ClusterName string
-	Target      api.ServiceNVMETarget
+	Target      api0.ServiceNVMETarget
 } {
 	var calls []struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceNVMETarget
+		Target      api0.ServiceNVMETarget
 	}
 	mock.lockAddStorageTargetNVME.RLock()
 	calls = mock.calls.AddStorageTargetNVME
@@ -922,6 +1217,110 @@ func (mock *ClusterServiceMock) AddStorageTargetNVMECalls() []struct {
 	return calls
 }
 
+// Adopt calls AdoptFunc.
+func (mock *ClusterServiceMock) Adopt(ctx context.Context, cluster provisioning.Cluster) (provisioning.Cluster, error) {
+	if mock.AdoptFunc == nil {
+		panic("ClusterServiceMock.AdoptFunc: method is nil but ClusterService.Adopt was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Cluster provisioning.Cluster
+	}{
+		Ctx:     ctx,
+		Cluster: cluster,
+	}
+	mock.lockAdopt.Lock()
+	mock.calls.Adopt = append(mock.calls.Adopt, callInfo)
+	mock.lockAdopt.Unlock()
+	return mock.AdoptFunc(ctx, cluster)
+}
+
+// AdoptCalls gets all the calls that were made to Adopt.
+// Check the length with:
+//
+//	len(mockedClusterService.AdoptCalls())
+func (mock *ClusterServiceMock) AdoptCalls() []struct {
+	Ctx     context.Context
+	Cluster provisioning.Cluster
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Cluster provisioning.Cluster
+	}
+	mock.lockAdopt.RLock()
+	calls = mock.calls.Adopt
+	mock.lockAdopt.RUnlock()
+	return calls
+}
+
+// ApplyClusterSpec calls ApplyClusterSpecFunc.
+func (mock *ClusterServiceMock) ApplyClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+	if mock.ApplyClusterSpecFunc == nil {
+		panic("ClusterServiceMock.ApplyClusterSpecFunc: method is nil but ClusterService.ApplyClusterSpec was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Spec api.ClusterSpec
+	}{
+		Ctx:  ctx,
+		Spec: spec,
+	}
+	mock.lockApplyClusterSpec.Lock()
+	mock.calls.ApplyClusterSpec = append(mock.calls.ApplyClusterSpec, callInfo)
+	mock.lockApplyClusterSpec.Unlock()
+	return mock.ApplyClusterSpecFunc(ctx, spec)
+}
+
+// ApplyClusterSpecCalls gets all the calls that were made to ApplyClusterSpec.
+// Check the length with:
+//
+//	len(mockedClusterService.ApplyClusterSpecCalls())
+func (mock *ClusterServiceMock) ApplyClusterSpecCalls() []struct {
+	Ctx  context.Context
+	Spec api.ClusterSpec
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Spec api.ClusterSpec
+	}
+	mock.lockApplyClusterSpec.RLock()
+	calls = mock.calls.ApplyClusterSpec
+	mock.lockApplyClusterSpec.RUnlock()
+	return calls
+}
+
+// CheckConsistency calls CheckConsistencyFunc.
+func (mock *ClusterServiceMock) CheckConsistency(ctx context.Context) error {
+	if mock.CheckConsistencyFunc == nil {
+		panic("ClusterServiceMock.CheckConsistencyFunc: method is nil but ClusterService.CheckConsistency was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCheckConsistency.Lock()
+	mock.calls.CheckConsistency = append(mock.calls.CheckConsistency, callInfo)
+	mock.lockCheckConsistency.Unlock()
+	return mock.CheckConsistencyFunc(ctx)
+}
+
+// CheckConsistencyCalls gets all the calls that were made to CheckConsistency.
+// Check the length with:
+//
+//	len(mockedClusterService.CheckConsistencyCalls())
+func (mock *ClusterServiceMock) CheckConsistencyCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCheckConsistency.RLock()
+	calls = mock.calls.CheckConsistency
+	mock.lockCheckConsistency.RUnlock()
+	return calls
+}
+
 // ClusterUpdateControlLoop calls ClusterUpdateControlLoopFunc.
 func (mock *ClusterServiceMock) ClusterUpdateControlLoop(ctx context.Context, clusterNameFilter *string) error {
 	if mock.ClusterUpdateControlLoopFunc == nil {
@@ -1078,6 +1477,38 @@ func (mock *ClusterServiceMock) DeleteByNameCalls() []struct {
 	return calls
 }
 
+// DetectConfigurationDrift calls DetectConfigurationDriftFunc.
+func (mock *ClusterServiceMock) DetectConfigurationDrift(ctx context.Context) error {
+	if mock.DetectConfigurationDriftFunc == nil {
+		panic("ClusterServiceMock.DetectConfigurationDriftFunc: method is nil but ClusterService.DetectConfigurationDrift was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDetectConfigurationDrift.Lock()
+	mock.calls.DetectConfigurationDrift = append(mock.calls.DetectConfigurationDrift, callInfo)
+	mock.lockDetectConfigurationDrift.Unlock()
+	return mock.DetectConfigurationDriftFunc(ctx)
+}
+
+// DetectConfigurationDriftCalls gets all the calls that were made to DetectConfigurationDrift.
+// Check the length with:
+//
+//	len(mockedClusterService.DetectConfigurationDriftCalls())
+func (mock *ClusterServiceMock) DetectConfigurationDriftCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockDetectConfigurationDrift.RLock()
+	calls = mock.calls.DetectConfigurationDrift
+	mock.lockDetectConfigurationDrift.RUnlock()
+	return calls
+}
+
 // GetAll calls GetAllFunc.
 func (mock *ClusterServiceMock) GetAll(ctx context.Context) (provisioning.Clusters, error) {
 	if mock.GetAllFunc == nil {
@@ -1450,6 +1881,78 @@ func (mock *ClusterServiceMock) GetClusterArtifactFileByNameCalls() []struct {
 	return calls
 }
 
+// GetClusterConsistency calls GetClusterConsistencyFunc.
+func (mock *ClusterServiceMock) GetClusterConsistency(ctx context.Context, name string) (api.ClusterConsistency, error) {
+	if mock.GetClusterConsistencyFunc == nil {
+		panic("ClusterServiceMock.GetClusterConsistencyFunc: method is nil but ClusterService.GetClusterConsistency was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetClusterConsistency.Lock()
+	mock.calls.GetClusterConsistency = append(mock.calls.GetClusterConsistency, callInfo)
+	mock.lockGetClusterConsistency.Unlock()
+	return mock.GetClusterConsistencyFunc(ctx, name)
+}
+
+// GetClusterConsistencyCalls gets all the calls that were made to GetClusterConsistency.
+// Check the length with:
+//
+//	len(mockedClusterService.GetClusterConsistencyCalls())
+func (mock *ClusterServiceMock) GetClusterConsistencyCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetClusterConsistency.RLock()
+	calls = mock.calls.GetClusterConsistency
+	mock.lockGetClusterConsistency.RUnlock()
+	return calls
+}
+
+// GetClusterTemplateDrift calls GetClusterTemplateDriftFunc.
+func (mock *ClusterServiceMock) GetClusterTemplateDrift(ctx context.Context, name string) (api.ClusterTemplateDrift, error) {
+	if mock.GetClusterTemplateDriftFunc == nil {
+		panic("ClusterServiceMock.GetClusterTemplateDriftFunc: method is nil but ClusterService.GetClusterTemplateDrift was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetClusterTemplateDrift.Lock()
+	mock.calls.GetClusterTemplateDrift = append(mock.calls.GetClusterTemplateDrift, callInfo)
+	mock.lockGetClusterTemplateDrift.Unlock()
+	return mock.GetClusterTemplateDriftFunc(ctx, name)
+}
+
+// GetClusterTemplateDriftCalls gets all the calls that were made to GetClusterTemplateDrift.
+// Check the length with:
+//
+//	len(mockedClusterService.GetClusterTemplateDriftCalls())
+func (mock *ClusterServiceMock) GetClusterTemplateDriftCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetClusterTemplateDrift.RLock()
+	calls = mock.calls.GetClusterTemplateDrift
+	mock.lockGetClusterTemplateDrift.RUnlock()
+	return calls
+}
+
 // GetEndpoint calls GetEndpointFunc.
 func (mock *ClusterServiceMock) GetEndpoint(ctx context.Context, name string) (provisioning.Endpoint, error) {
 	if mock.GetEndpointFunc == nil {
@@ -1486,6 +1989,42 @@ func (mock *ClusterServiceMock) GetEndpointCalls() []struct {
 	return calls
 }
 
+// GetOperationAll calls GetOperationAllFunc.
+func (mock *ClusterServiceMock) GetOperationAll(ctx context.Context, name string) (provisioning.ClusterOperations, error) {
+	if mock.GetOperationAllFunc == nil {
+		panic("ClusterServiceMock.GetOperationAllFunc: method is nil but ClusterService.GetOperationAll was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetOperationAll.Lock()
+	mock.calls.GetOperationAll = append(mock.calls.GetOperationAll, callInfo)
+	mock.lockGetOperationAll.Unlock()
+	return mock.GetOperationAllFunc(ctx, name)
+}
+
+// GetOperationAllCalls gets all the calls that were made to GetOperationAll.
+// Check the length with:
+//
+//	len(mockedClusterService.GetOperationAllCalls())
+func (mock *ClusterServiceMock) GetOperationAllCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetOperationAll.RLock()
+	calls = mock.calls.GetOperationAll
+	mock.lockGetOperationAll.RUnlock()
+	return calls
+}
+
 // IsInstanceLifecycleOperationPermitted calls IsInstanceLifecycleOperationPermittedFunc.
 func (mock *ClusterServiceMock) IsInstanceLifecycleOperationPermitted(ctx context.Context, name string) bool {
 	if mock.IsInstanceLifecycleOperationPermittedFunc == nil {
@@ -1522,6 +2061,78 @@ func (mock *ClusterServiceMock) IsInstanceLifecycleOperationPermittedCalls() []s
 	return calls
 }
 
+// LaunchAutomaticClusterUpdates calls LaunchAutomaticClusterUpdatesFunc.
+func (mock *ClusterServiceMock) LaunchAutomaticClusterUpdates(ctx context.Context) error {
+	if mock.LaunchAutomaticClusterUpdatesFunc == nil {
+		panic("ClusterServiceMock.LaunchAutomaticClusterUpdatesFunc: method is nil but ClusterService.LaunchAutomaticClusterUpdates was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLaunchAutomaticClusterUpdates.Lock()
+	mock.calls.LaunchAutomaticClusterUpdates = append(mock.calls.LaunchAutomaticClusterUpdates, callInfo)
+	mock.lockLaunchAutomaticClusterUpdates.Unlock()
+	return mock.LaunchAutomaticClusterUpdatesFunc(ctx)
+}
+
+// LaunchAutomaticClusterUpdatesCalls gets all the calls that were made to LaunchAutomaticClusterUpdates.
+// Check the length with:
+//
+//	len(mockedClusterService.LaunchAutomaticClusterUpdatesCalls())
+func (mock *ClusterServiceMock) LaunchAutomaticClusterUpdatesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLaunchAutomaticClusterUpdates.RLock()
+	calls = mock.calls.LaunchAutomaticClusterUpdates
+	mock.lockLaunchAutomaticClusterUpdates.RUnlock()
+	return calls
+}
+
+// LaunchClusterFirmwareUpdate calls LaunchClusterFirmwareUpdateFunc.
+func (mock *ClusterServiceMock) LaunchClusterFirmwareUpdate(ctx context.Context, name string, update api.BMCFirmwareUpdatePost) error {
+	if mock.LaunchClusterFirmwareUpdateFunc == nil {
+		panic("ClusterServiceMock.LaunchClusterFirmwareUpdateFunc: method is nil but ClusterService.LaunchClusterFirmwareUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Name   string
+		Update api.BMCFirmwareUpdatePost
+	}{
+		Ctx:    ctx,
+		Name:   name,
+		Update: update,
+	}
+	mock.lockLaunchClusterFirmwareUpdate.Lock()
+	mock.calls.LaunchClusterFirmwareUpdate = append(mock.calls.LaunchClusterFirmwareUpdate, callInfo)
+	mock.lockLaunchClusterFirmwareUpdate.Unlock()
+	return mock.LaunchClusterFirmwareUpdateFunc(ctx, name, update)
+}
+
+// LaunchClusterFirmwareUpdateCalls gets all the calls that were made to LaunchClusterFirmwareUpdate.
+// Check the length with:
+//
+//	len(mockedClusterService.LaunchClusterFirmwareUpdateCalls())
+func (mock *ClusterServiceMock) LaunchClusterFirmwareUpdateCalls() []struct {
+	Ctx    context.Context
+	Name   string
+	Update api.BMCFirmwareUpdatePost
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Name   string
+		Update api.BMCFirmwareUpdatePost
+	}
+	mock.lockLaunchClusterFirmwareUpdate.RLock()
+	calls = mock.calls.LaunchClusterFirmwareUpdate
+	mock.lockLaunchClusterFirmwareUpdate.RUnlock()
+	return calls
+}
+
 // LaunchClusterReboot calls LaunchClusterRebootFunc.
 func (mock *ClusterServiceMock) LaunchClusterReboot(ctx context.Context, name string) error {
 	if mock.LaunchClusterRebootFunc == nil {
@@ -1598,6 +2209,118 @@ func (mock *ClusterServiceMock) LaunchClusterUpdateCalls() []struct {
 	return calls
 }
 
+// PlanClusterReboot calls PlanClusterRebootFunc.
+func (mock *ClusterServiceMock) PlanClusterReboot(ctx context.Context, name string) (api.ClusterUpdatePlan, error) {
+	if mock.PlanClusterRebootFunc == nil {
+		panic("ClusterServiceMock.PlanClusterRebootFunc: method is nil but ClusterService.PlanClusterReboot was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockPlanClusterReboot.Lock()
+	mock.calls.PlanClusterReboot = append(mock.calls.PlanClusterReboot, callInfo)
+	mock.lockPlanClusterReboot.Unlock()
+	return mock.PlanClusterRebootFunc(ctx, name)
+}
+
+// PlanClusterRebootCalls gets all the calls that were made to PlanClusterReboot.
+// Check the length with:
+//
+//	len(mockedClusterService.PlanClusterRebootCalls())
+func (mock *ClusterServiceMock) PlanClusterRebootCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockPlanClusterReboot.RLock()
+	calls = mock.calls.PlanClusterReboot
+	mock.lockPlanClusterReboot.RUnlock()
+	return calls
+}
+
+// PlanClusterSpec calls PlanClusterSpecFunc.
+func (mock *ClusterServiceMock) PlanClusterSpec(ctx context.Context, spec api.ClusterSpec) (api.ClusterSpecPlan, error) {
+	if mock.PlanClusterSpecFunc == nil {
+		panic("ClusterServiceMock.PlanClusterSpecFunc: method is nil but ClusterService.PlanClusterSpec was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Spec api.ClusterSpec
+	}{
+		Ctx:  ctx,
+		Spec: spec,
+	}
+	mock.lockPlanClusterSpec.Lock()
+	mock.calls.PlanClusterSpec = append(mock.calls.PlanClusterSpec, callInfo)
+	mock.lockPlanClusterSpec.Unlock()
+	return mock.PlanClusterSpecFunc(ctx, spec)
+}
+
+// PlanClusterSpecCalls gets all the calls that were made to PlanClusterSpec.
+// Check the length with:
+//
+//	len(mockedClusterService.PlanClusterSpecCalls())
+func (mock *ClusterServiceMock) PlanClusterSpecCalls() []struct {
+	Ctx  context.Context
+	Spec api.ClusterSpec
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Spec api.ClusterSpec
+	}
+	mock.lockPlanClusterSpec.RLock()
+	calls = mock.calls.PlanClusterSpec
+	mock.lockPlanClusterSpec.RUnlock()
+	return calls
+}
+
+// PlanClusterUpdate calls PlanClusterUpdateFunc.
+func (mock *ClusterServiceMock) PlanClusterUpdate(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error) {
+	if mock.PlanClusterUpdateFunc == nil {
+		panic("ClusterServiceMock.PlanClusterUpdateFunc: method is nil but ClusterService.PlanClusterUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Name   string
+		Reboot bool
+	}{
+		Ctx:    ctx,
+		Name:   name,
+		Reboot: reboot,
+	}
+	mock.lockPlanClusterUpdate.Lock()
+	mock.calls.PlanClusterUpdate = append(mock.calls.PlanClusterUpdate, callInfo)
+	mock.lockPlanClusterUpdate.Unlock()
+	return mock.PlanClusterUpdateFunc(ctx, name, reboot)
+}
+
+// PlanClusterUpdateCalls gets all the calls that were made to PlanClusterUpdate.
+// Check the length with:
+//
+//	len(mockedClusterService.PlanClusterUpdateCalls())
+func (mock *ClusterServiceMock) PlanClusterUpdateCalls() []struct {
+	Ctx    context.Context
+	Name   string
+	Reboot bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Name   string
+		Reboot bool
+	}
+	mock.lockPlanClusterUpdate.RLock()
+	calls = mock.calls.PlanClusterUpdate
+	mock.lockPlanClusterUpdate.RUnlock()
+	return calls
+}
+
 // RemoveServer calls RemoveServerFunc.
 func (mock *ClusterServiceMock) RemoveServer(ctx context.Context, name string, removedServerNames []string) error {
 	if mock.RemoveServerFunc == nil {
@@ -1682,15 +2405,55 @@ func (mock *ClusterServiceMock) RemoveServerSystemNetworkVLANTagsCalls() []struc
 	return calls
 }
 
+// RemoveServiceCephCluster calls RemoveServiceCephClusterFunc.
+func (mock *ClusterServiceMock) RemoveServiceCephCluster(ctx context.Context, clusterName string, cephClusterName string) error {
+	if mock.RemoveServiceCephClusterFunc == nil {
+		panic("ClusterServiceMock.RemoveServiceCephClusterFunc: method is nil but ClusterService.RemoveServiceCephCluster was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		ClusterName     string
+		CephClusterName string
+	}{
+		Ctx:             ctx,
+		ClusterName:     clusterName,
+		CephClusterName: cephClusterName,
+	}
+	mock.lockRemoveServiceCephCluster.Lock()
+	mock.calls.RemoveServiceCephCluster = append(mock.calls.RemoveServiceCephCluster, callInfo)
+	mock.lockRemoveServiceCephCluster.Unlock()
+	return mock.RemoveServiceCephClusterFunc(ctx, clusterName, cephClusterName)
+}
+
+// RemoveServiceCephClusterCalls gets all the calls that were made to RemoveServiceCephCluster.
+// Check the length with:
+//
+//	len(mockedClusterService.RemoveServiceCephClusterCalls())
+func (mock *ClusterServiceMock) RemoveServiceCephClusterCalls() []struct {
+	Ctx             context.Context
+	ClusterName     string
+	CephClusterName string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		ClusterName     string
+		CephClusterName string
+	}
+	mock.lockRemoveServiceCephCluster.RLock()
+	calls = mock.calls.RemoveServiceCephCluster
+	mock.lockRemoveServiceCephCluster.RUnlock()
+	return calls
+}
+
 // RemoveStorageTargetISCSI calls RemoveStorageTargetISCSIFunc.
-func (mock *ClusterServiceMock) RemoveStorageTargetISCSI(ctx context.Context, clusterName string, target api.ServiceISCSITarget) error {
+func (mock *ClusterServiceMock) RemoveStorageTargetISCSI(ctx context.Context, clusterName string, target api0.ServiceISCSITarget) error {
 	if mock.RemoveStorageTargetISCSIFunc == nil {
 		panic("ClusterServiceMock.RemoveStorageTargetISCSIFunc: method is nil but ClusterService.RemoveStorageTargetISCSI was just called")
 	}
 	callInfo := struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceISCSITarget
+		Target      api0.ServiceISCSITarget
 	}{
 		Ctx:         ctx,
 		ClusterName: clusterName,
@@ -1709,12 +2472,12 @@ func (mock *ClusterServiceMock) RemoveStorageTargetISCSI(ctx context.Context, cl
 func (mock *ClusterServiceMock) RemoveStorageTargetISCSICalls() []struct {
 	Ctx         context.Context
 	ClusterName string
-	Target      api.ServiceISCSITarget
+	Target      api0.ServiceISCSITarget
 } {
 	var calls []struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceISCSITarget
+		Target      api0.ServiceISCSITarget
 	}
 	mock.lockRemoveStorageTargetISCSI.RLock()
 	calls = mock.calls.RemoveStorageTargetISCSI
@@ -1763,14 +2526,14 @@ func (mock *ClusterServiceMock) RemoveStorageTargetMultipathCalls() []struct {
 }
 
 // RemoveStorageTargetNVME calls RemoveStorageTargetNVMEFunc.
-func (mock *ClusterServiceMock) RemoveStorageTargetNVME(ctx context.Context, clusterName string, target api.ServiceNVMETarget) error {
+func (mock *ClusterServiceMock) RemoveStorageTargetNVME(ctx context.Context, clusterName string, target api0.ServiceNVMETarget) error {
 	if mock.RemoveStorageTargetNVMEFunc == nil {
 		panic("ClusterServiceMock.RemoveStorageTargetNVMEFunc: method is nil but ClusterService.RemoveStorageTargetNVME was just called")
 	}
 	callInfo := struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceNVMETarget
+		Target      api0.ServiceNVMETarget
 	}{
 		Ctx:         ctx,
 		ClusterName: clusterName,
@@ -1789,12 +2552,12 @@ func (mock *ClusterServiceMock) RemoveStorageTargetNVME(ctx context.Context, clu
 func (mock *ClusterServiceMock) RemoveStorageTargetNVMECalls() []struct {
 	Ctx         context.Context
 	ClusterName string
-	Target      api.ServiceNVMETarget
+	Target      api0.ServiceNVMETarget
 } {
 	var calls []struct {
 		Ctx         context.Context
 		ClusterName string
-		Target      api.ServiceNVMETarget
+		Target      api0.ServiceNVMETarget
 	}
 	mock.lockRemoveStorageTargetNVME.RLock()
 	calls = mock.calls.RemoveStorageTargetNVME
@@ -1842,6 +2605,50 @@ func (mock *ClusterServiceMock) RenameCalls() []struct {
 	return calls
 }
 
+// ReplaceServer calls ReplaceServerFunc.
+func (mock *ClusterServiceMock) ReplaceServer(ctx context.Context, name string, serverName string, replacementServerName string) error {
+	if mock.ReplaceServerFunc == nil {
+		panic("ClusterServiceMock.ReplaceServerFunc: method is nil but ClusterService.ReplaceServer was just called")
+	}
+	callInfo := struct {
+		Ctx                   context.Context
+		Name                  string
+		ServerName            string
+		ReplacementServerName string
+	}{
+		Ctx:                   ctx,
+		Name:                  name,
+		ServerName:            serverName,
+		ReplacementServerName: replacementServerName,
+	}
+	mock.lockReplaceServer.Lock()
+	mock.calls.ReplaceServer = append(mock.calls.ReplaceServer, callInfo)
+	mock.lockReplaceServer.Unlock()
+	return mock.ReplaceServerFunc(ctx, name, serverName, replacementServerName)
+}
+
+// ReplaceServerCalls gets all the calls that were made to ReplaceServer.
+// Check the length with:
+//
+//	len(mockedClusterService.ReplaceServerCalls())
+func (mock *ClusterServiceMock) ReplaceServerCalls() []struct {
+	Ctx                   context.Context
+	Name                  string
+	ServerName            string
+	ReplacementServerName string
+} {
+	var calls []struct {
+		Ctx                   context.Context
+		Name                  string
+		ServerName            string
+		ReplacementServerName string
+	}
+	mock.lockReplaceServer.RLock()
+	calls = mock.calls.ReplaceServer
+	mock.lockReplaceServer.RUnlock()
+	return calls
+}
+
 // ResyncInventory calls ResyncInventoryFunc.
 func (mock *ClusterServiceMock) ResyncInventory(ctx context.Context) error {
 	if mock.ResyncInventoryFunc == nil {
@@ -2058,6 +2865,86 @@ func (mock *ClusterServiceMock) UpdateCertificateCalls() []struct {
 	return calls
 }
 
+// UpdateServiceLinstor calls UpdateServiceLinstorFunc.
+func (mock *ClusterServiceMock) UpdateServiceLinstor(ctx context.Context, clusterName string, linstorConfig api0.ServiceLinstorConfig) error {
+	if mock.UpdateServiceLinstorFunc == nil {
+		panic("ClusterServiceMock.UpdateServiceLinstorFunc: method is nil but ClusterService.UpdateServiceLinstor was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ClusterName   string
+		LinstorConfig api0.ServiceLinstorConfig
+	}{
+		Ctx:           ctx,
+		ClusterName:   clusterName,
+		LinstorConfig: linstorConfig,
+	}
+	mock.lockUpdateServiceLinstor.Lock()
+	mock.calls.UpdateServiceLinstor = append(mock.calls.UpdateServiceLinstor, callInfo)
+	mock.lockUpdateServiceLinstor.Unlock()
+	return mock.UpdateServiceLinstorFunc(ctx, clusterName, linstorConfig)
+}
+
+// UpdateServiceLinstorCalls gets all the calls that were made to UpdateServiceLinstor.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdateServiceLinstorCalls())
+func (mock *ClusterServiceMock) UpdateServiceLinstorCalls() []struct {
+	Ctx           context.Context
+	ClusterName   string
+	LinstorConfig api0.ServiceLinstorConfig
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ClusterName   string
+		LinstorConfig api0.ServiceLinstorConfig
+	}
+	mock.lockUpdateServiceLinstor.RLock()
+	calls = mock.calls.UpdateServiceLinstor
+	mock.lockUpdateServiceLinstor.RUnlock()
+	return calls
+}
+
+// UpdateServiceOVN calls UpdateServiceOVNFunc.
+func (mock *ClusterServiceMock) UpdateServiceOVN(ctx context.Context, clusterName string, ovnConfig api0.ServiceOVNConfig) error {
+	if mock.UpdateServiceOVNFunc == nil {
+		panic("ClusterServiceMock.UpdateServiceOVNFunc: method is nil but ClusterService.UpdateServiceOVN was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ClusterName string
+		OvnConfig   api0.ServiceOVNConfig
+	}{
+		Ctx:         ctx,
+		ClusterName: clusterName,
+		OvnConfig:   ovnConfig,
+	}
+	mock.lockUpdateServiceOVN.Lock()
+	mock.calls.UpdateServiceOVN = append(mock.calls.UpdateServiceOVN, callInfo)
+	mock.lockUpdateServiceOVN.Unlock()
+	return mock.UpdateServiceOVNFunc(ctx, clusterName, ovnConfig)
+}
+
+// UpdateServiceOVNCalls gets all the calls that were made to UpdateServiceOVN.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdateServiceOVNCalls())
+func (mock *ClusterServiceMock) UpdateServiceOVNCalls() []struct {
+	Ctx         context.Context
+	ClusterName string
+	OvnConfig   api0.ServiceOVNConfig
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ClusterName string
+		OvnConfig   api0.ServiceOVNConfig
+	}
+	mock.lockUpdateServiceOVN.RLock()
+	calls = mock.calls.UpdateServiceOVN
+	mock.lockUpdateServiceOVN.RUnlock()
+	return calls
+}
+
 // UpdateSystemKernel calls UpdateSystemKernelFunc.
 func (mock *ClusterServiceMock) UpdateSystemKernel(ctx context.Context, clusterName string, kerneConfig provisioning.ServerSystemKernel) error {
 	if mock.UpdateSystemKernelFunc == nil {
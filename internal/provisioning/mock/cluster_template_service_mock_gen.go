@@ -40,6 +40,15 @@ var _ provisioning.ClusterTemplateService = &ClusterTemplateServiceMock{}
 //			GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterTemplate, error) {
 //				panic("mock out the GetByName method")
 //			},
+//			GetRevisionFunc: func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+//				panic("mock out the GetRevision method")
+//			},
+//			GetRevisionAllFunc: func(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+//				panic("mock out the GetRevisionAll method")
+//			},
+//			GetRevisionDiffFunc: func(ctx context.Context, name string, fromRevision int64, toRevision int64) (api.ClusterTemplateRevisionDiff, error) {
+//				panic("mock out the GetRevisionDiff method")
+//			},
 //			RenameFunc: func(ctx context.Context, oldName string, newName string) error {
 //				panic("mock out the Rename method")
 //			},
@@ -71,6 +80,15 @@ type ClusterTemplateServiceMock struct {
 	// GetByNameFunc mocks the GetByName method.
 	GetByNameFunc func(ctx context.Context, name string) (*provisioning.ClusterTemplate, error)
 
+	// GetRevisionFunc mocks the GetRevision method.
+	GetRevisionFunc func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error)
+
+	// GetRevisionAllFunc mocks the GetRevisionAll method.
+	GetRevisionAllFunc func(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error)
+
+	// GetRevisionDiffFunc mocks the GetRevisionDiff method.
+	GetRevisionDiffFunc func(ctx context.Context, name string, fromRevision int64, toRevision int64) (api.ClusterTemplateRevisionDiff, error)
+
 	// RenameFunc mocks the Rename method.
 	RenameFunc func(ctx context.Context, oldName string, newName string) error
 
@@ -119,6 +137,33 @@ type ClusterTemplateServiceMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetRevision holds details about calls to the GetRevision method.
+		GetRevision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Revision is the revision argument value.
+			Revision int64
+		}
+		// GetRevisionAll holds details about calls to the GetRevisionAll method.
+		GetRevisionAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetRevisionDiff holds details about calls to the GetRevisionDiff method.
+		GetRevisionDiff []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// FromRevision is the fromRevision argument value.
+			FromRevision int64
+			// ToRevision is the toRevision argument value.
+			ToRevision int64
+		}
 		// Rename holds details about calls to the Rename method.
 		Rename []struct {
 			// Ctx is the ctx argument value.
@@ -136,14 +181,17 @@ type ClusterTemplateServiceMock struct {
 			ClusterTemplate provisioning.ClusterTemplate
 		}
 	}
-	lockApply        sync.RWMutex
-	lockCreate       sync.RWMutex
-	lockDeleteByName sync.RWMutex
-	lockGetAll       sync.RWMutex
-	lockGetAllNames  sync.RWMutex
-	lockGetByName    sync.RWMutex
-	lockRename       sync.RWMutex
-	lockUpdate       sync.RWMutex
+	lockApply           sync.RWMutex
+	lockCreate          sync.RWMutex
+	lockDeleteByName    sync.RWMutex
+	lockGetAll          sync.RWMutex
+	lockGetAllNames     sync.RWMutex
+	lockGetByName       sync.RWMutex
+	lockGetRevision     sync.RWMutex
+	lockGetRevisionAll  sync.RWMutex
+	lockGetRevisionDiff sync.RWMutex
+	lockRename          sync.RWMutex
+	lockUpdate          sync.RWMutex
 }
 
 // Apply calls ApplyFunc.
@@ -358,6 +406,126 @@ func (mock *ClusterTemplateServiceMock) GetByNameCalls() []struct {
 	return calls
 }
 
+// GetRevision calls GetRevisionFunc.
+func (mock *ClusterTemplateServiceMock) GetRevision(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+	if mock.GetRevisionFunc == nil {
+		panic("ClusterTemplateServiceMock.GetRevisionFunc: method is nil but ClusterTemplateService.GetRevision was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Name     string
+		Revision int64
+	}{
+		Ctx:      ctx,
+		Name:     name,
+		Revision: revision,
+	}
+	mock.lockGetRevision.Lock()
+	mock.calls.GetRevision = append(mock.calls.GetRevision, callInfo)
+	mock.lockGetRevision.Unlock()
+	return mock.GetRevisionFunc(ctx, name, revision)
+}
+
+// GetRevisionCalls gets all the calls that were made to GetRevision.
+// Check the length with:
+//
+//	len(mockedClusterTemplateService.GetRevisionCalls())
+func (mock *ClusterTemplateServiceMock) GetRevisionCalls() []struct {
+	Ctx      context.Context
+	Name     string
+	Revision int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Name     string
+		Revision int64
+	}
+	mock.lockGetRevision.RLock()
+	calls = mock.calls.GetRevision
+	mock.lockGetRevision.RUnlock()
+	return calls
+}
+
+// GetRevisionAll calls GetRevisionAllFunc.
+func (mock *ClusterTemplateServiceMock) GetRevisionAll(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+	if mock.GetRevisionAllFunc == nil {
+		panic("ClusterTemplateServiceMock.GetRevisionAllFunc: method is nil but ClusterTemplateService.GetRevisionAll was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetRevisionAll.Lock()
+	mock.calls.GetRevisionAll = append(mock.calls.GetRevisionAll, callInfo)
+	mock.lockGetRevisionAll.Unlock()
+	return mock.GetRevisionAllFunc(ctx, name)
+}
+
+// GetRevisionAllCalls gets all the calls that were made to GetRevisionAll.
+// Check the length with:
+//
+//	len(mockedClusterTemplateService.GetRevisionAllCalls())
+func (mock *ClusterTemplateServiceMock) GetRevisionAllCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetRevisionAll.RLock()
+	calls = mock.calls.GetRevisionAll
+	mock.lockGetRevisionAll.RUnlock()
+	return calls
+}
+
+// GetRevisionDiff calls GetRevisionDiffFunc.
+func (mock *ClusterTemplateServiceMock) GetRevisionDiff(ctx context.Context, name string, fromRevision int64, toRevision int64) (api.ClusterTemplateRevisionDiff, error) {
+	if mock.GetRevisionDiffFunc == nil {
+		panic("ClusterTemplateServiceMock.GetRevisionDiffFunc: method is nil but ClusterTemplateService.GetRevisionDiff was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Name         string
+		FromRevision int64
+		ToRevision   int64
+	}{
+		Ctx:          ctx,
+		Name:         name,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+	}
+	mock.lockGetRevisionDiff.Lock()
+	mock.calls.GetRevisionDiff = append(mock.calls.GetRevisionDiff, callInfo)
+	mock.lockGetRevisionDiff.Unlock()
+	return mock.GetRevisionDiffFunc(ctx, name, fromRevision, toRevision)
+}
+
+// GetRevisionDiffCalls gets all the calls that were made to GetRevisionDiff.
+// Check the length with:
+//
+//	len(mockedClusterTemplateService.GetRevisionDiffCalls())
+func (mock *ClusterTemplateServiceMock) GetRevisionDiffCalls() []struct {
+	Ctx          context.Context
+	Name         string
+	FromRevision int64
+	ToRevision   int64
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Name         string
+		FromRevision int64
+		ToRevision   int64
+	}
+	mock.lockGetRevisionDiff.RLock()
+	calls = mock.calls.GetRevisionDiff
+	mock.lockGetRevisionDiff.RUnlock()
+	return calls
+}
+
 // Rename calls RenameFunc.
 func (mock *ClusterTemplateServiceMock) Rename(ctx context.Context, oldName string, newName string) error {
 	if mock.RenameFunc == nil {
@@ -6,6 +6,7 @@ package mock
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/google/uuid"
@@ -40,6 +41,15 @@ var _ provisioning.ServerService = &ServerServiceMock{}
 //			BMCDumpByNameFunc: func(ctx context.Context, name string, additionalEndpoints []string, skipPredefined bool, trace bool) (api.BMCDump, error) {
 //				panic("mock out the BMCDumpByName method")
 //			},
+//			BMCFirmwareByNameFunc: func(ctx context.Context, name string) (map[string]api.BMCFirmware, error) {
+//				panic("mock out the BMCFirmwareByName method")
+//			},
+//			BMCFirmwareUpdateByNameFunc: func(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) error {
+//				panic("mock out the BMCFirmwareUpdateByName method")
+//			},
+//			BMCInstallByNameFunc: func(ctx context.Context, name string, install api.ServerBMCInstallPost) error {
+//				panic("mock out the BMCInstallByName method")
+//			},
 //			BMCLogEntriesByNameAndLogSourceFunc: func(ctx context.Context, name string, logSource string) ([]api.BMCLogEvent, error) {
 //				panic("mock out the BMCLogEntriesByNameAndLogSource method")
 //			},
@@ -61,6 +71,15 @@ var _ provisioning.ServerService = &ServerServiceMock{}
 //			BMCServerSetLocationIndicatorByNameFunc: func(ctx context.Context, name string, active bool) error {
 //				panic("mock out the BMCServerSetLocationIndicatorByName method")
 //			},
+//			BulkActionFunc: func(ctx context.Context, action provisioning.ServerBulkAction) (provisioning.ServerBulkActionResults, error) {
+//				panic("mock out the BulkAction method")
+//			},
+//			ClusterJoinControlLoopFunc: func(ctx context.Context) error {
+//				panic("mock out the ClusterJoinControlLoop method")
+//			},
+//			DecommissionByNameFunc: func(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (*provisioning.ServerDecommission, error) {
+//				panic("mock out the DecommissionByName method")
+//			},
 //			DeleteByNameFunc: func(ctx context.Context, name string) error {
 //				panic("mock out the DeleteByName method")
 //			},
@@ -82,12 +101,33 @@ var _ provisioning.ServerService = &ServerServiceMock{}
 //			GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
 //				panic("mock out the GetAllWithFilter method")
 //			},
+//			GetBMCInstallMediaByNameFunc: func(ctx context.Context, name string, id uuid.UUID) (io.ReadSeekCloser, error) {
+//				panic("mock out the GetBMCInstallMediaByName method")
+//			},
 //			GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
 //				panic("mock out the GetByName method")
 //			},
 //			GetChangelogByNameFunc: func(ctx context.Context, name string) (api.UpdateChangelog, error) {
 //				panic("mock out the GetChangelogByName method")
 //			},
+//			GetDecommissionByUUIDFunc: func(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+//				panic("mock out the GetDecommissionByUUID method")
+//			},
+//			GetDecommissionsFunc: func(ctx context.Context) (provisioning.ServerDecommissions, error) {
+//				panic("mock out the GetDecommissions method")
+//			},
+//			GetDecommissionsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+//				panic("mock out the GetDecommissionsByName method")
+//			},
+//			GetDiskHealthByNameFunc: func(ctx context.Context, name string) ([]api.ServerDiskHealth, error) {
+//				panic("mock out the GetDiskHealthByName method")
+//			},
+//			GetDiskHealthWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) ([]api.ServerDiskHealth, error) {
+//				panic("mock out the GetDiskHealthWithFilter method")
+//			},
+//			GetHardwareHistoryByNameFunc: func(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+//				panic("mock out the GetHardwareHistoryByName method")
+//			},
 //			GetSystemKernelFunc: func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
 //				panic("mock out the GetSystemKernel method")
 //			},
@@ -115,6 +155,12 @@ var _ provisioning.ServerService = &ServerServiceMock{}
 //			PreRegisterFunc: func(ctx context.Context, server provisioning.Server) (provisioning.Server, error) {
 //				panic("mock out the PreRegister method")
 //			},
+//			PruneFunc: func(ctx context.Context) error {
+//				panic("mock out the Prune method")
+//			},
+//			PruneBMCInstallMediaFunc: func(ctx context.Context) error {
+//				panic("mock out the PruneBMCInstallMedia method")
+//			},
 //			RebootSystemByNameFunc: func(ctx context.Context, name string, force bool) error {
 //				panic("mock out the RebootSystemByName method")
 //			},
@@ -145,6 +191,12 @@ var _ provisioning.ServerService = &ServerServiceMock{}
 //			SetClusterServiceFunc: func(clusterSvc provisioning.ClusterService)  {
 //				panic("mock out the SetClusterService method")
 //			},
+//			SetRolloutServiceFunc: func(rolloutSvc provisioning.RolloutService) {
+//				panic("mock out the SetRolloutService method")
+//			},
+//			SetSiteServiceFunc: func(siteSvc provisioning.SiteService) {
+//				panic("mock out the SetSiteService method")
+//			},
 //			SyncClusterFunc: func(ctx context.Context, clusterName string) error {
 //				panic("mock out the SyncCluster method")
 //			},
@@ -194,6 +246,15 @@ type ServerServiceMock struct {
 	// BMCDumpByNameFunc mocks the BMCDumpByName method.
 	BMCDumpByNameFunc func(ctx context.Context, name string, additionalEndpoints []string, skipPredefined bool, trace bool) (api.BMCDump, error)
 
+	// BMCFirmwareByNameFunc mocks the BMCFirmwareByName method.
+	BMCFirmwareByNameFunc func(ctx context.Context, name string) (map[string]api.BMCFirmware, error)
+
+	// BMCFirmwareUpdateByNameFunc mocks the BMCFirmwareUpdateByName method.
+	BMCFirmwareUpdateByNameFunc func(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) error
+
+	// BMCInstallByNameFunc mocks the BMCInstallByName method.
+	BMCInstallByNameFunc func(ctx context.Context, name string, install api.ServerBMCInstallPost) error
+
 	// BMCLogEntriesByNameAndLogSourceFunc mocks the BMCLogEntriesByNameAndLogSource method.
 	BMCLogEntriesByNameAndLogSourceFunc func(ctx context.Context, name string, logSource string) ([]api.BMCLogEvent, error)
 
@@ -215,6 +276,15 @@ type ServerServiceMock struct {
 	// BMCServerSetLocationIndicatorByNameFunc mocks the BMCServerSetLocationIndicatorByName method.
 	BMCServerSetLocationIndicatorByNameFunc func(ctx context.Context, name string, active bool) error
 
+	// BulkActionFunc mocks the BulkAction method.
+	BulkActionFunc func(ctx context.Context, action provisioning.ServerBulkAction) (provisioning.ServerBulkActionResults, error)
+
+	// ClusterJoinControlLoopFunc mocks the ClusterJoinControlLoop method.
+	ClusterJoinControlLoopFunc func(ctx context.Context) error
+
+	// DecommissionByNameFunc mocks the DecommissionByName method.
+	DecommissionByNameFunc func(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (*provisioning.ServerDecommission, error)
+
 	// DeleteByNameFunc mocks the DeleteByName method.
 	DeleteByNameFunc func(ctx context.Context, name string) error
 
@@ -236,12 +306,33 @@ type ServerServiceMock struct {
 	// GetAllWithFilterFunc mocks the GetAllWithFilter method.
 	GetAllWithFilterFunc func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error)
 
+	// GetBMCInstallMediaByNameFunc mocks the GetBMCInstallMediaByName method.
+	GetBMCInstallMediaByNameFunc func(ctx context.Context, name string, id uuid.UUID) (io.ReadSeekCloser, error)
+
 	// GetByNameFunc mocks the GetByName method.
 	GetByNameFunc func(ctx context.Context, name string) (*provisioning.Server, error)
 
 	// GetChangelogByNameFunc mocks the GetChangelogByName method.
 	GetChangelogByNameFunc func(ctx context.Context, name string) (api.UpdateChangelog, error)
 
+	// GetDecommissionByUUIDFunc mocks the GetDecommissionByUUID method.
+	GetDecommissionByUUIDFunc func(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error)
+
+	// GetDecommissionsFunc mocks the GetDecommissions method.
+	GetDecommissionsFunc func(ctx context.Context) (provisioning.ServerDecommissions, error)
+
+	// GetDecommissionsByNameFunc mocks the GetDecommissionsByName method.
+	GetDecommissionsByNameFunc func(ctx context.Context, name string) (provisioning.ServerDecommissions, error)
+
+	// GetDiskHealthByNameFunc mocks the GetDiskHealthByName method.
+	GetDiskHealthByNameFunc func(ctx context.Context, name string) ([]api.ServerDiskHealth, error)
+
+	// GetDiskHealthWithFilterFunc mocks the GetDiskHealthWithFilter method.
+	GetDiskHealthWithFilterFunc func(ctx context.Context, filter provisioning.ServerFilter) ([]api.ServerDiskHealth, error)
+
+	// GetHardwareHistoryByNameFunc mocks the GetHardwareHistoryByName method.
+	GetHardwareHistoryByNameFunc func(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error)
+
 	// GetSystemKernelFunc mocks the GetSystemKernel method.
 	GetSystemKernelFunc func(ctx context.Context, name string) (provisioning.ServerSystemKernel, error)
 
@@ -269,6 +360,12 @@ type ServerServiceMock struct {
 	// PreRegisterFunc mocks the PreRegister method.
 	PreRegisterFunc func(ctx context.Context, server provisioning.Server) (provisioning.Server, error)
 
+	// PruneFunc mocks the Prune method.
+	PruneFunc func(ctx context.Context) error
+
+	// PruneBMCInstallMediaFunc mocks the PruneBMCInstallMedia method.
+	PruneBMCInstallMediaFunc func(ctx context.Context) error
+
 	// RebootSystemByNameFunc mocks the RebootSystemByName method.
 	RebootSystemByNameFunc func(ctx context.Context, name string, force bool) error
 
@@ -299,6 +396,12 @@ type ServerServiceMock struct {
 	// SetClusterServiceFunc mocks the SetClusterService method.
 	SetClusterServiceFunc func(clusterSvc provisioning.ClusterService)
 
+	// SetRolloutServiceFunc mocks the SetRolloutService method.
+	SetRolloutServiceFunc func(rolloutSvc provisioning.RolloutService)
+
+	// SetSiteServiceFunc mocks the SetSiteService method.
+	SetSiteServiceFunc func(siteSvc provisioning.SiteService)
+
 	// SyncClusterFunc mocks the SyncCluster method.
 	SyncClusterFunc func(ctx context.Context, clusterName string) error
 
@@ -375,6 +478,33 @@ type ServerServiceMock struct {
 			// Trace is the trace argument value.
 			Trace bool
 		}
+		// BMCFirmwareByName holds details about calls to the BMCFirmwareByName method.
+		BMCFirmwareByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// BMCFirmwareUpdateByName holds details about calls to the BMCFirmwareUpdateByName method.
+		BMCFirmwareUpdateByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Update is the update argument value.
+			Update api.BMCFirmwareUpdatePost
+			// ClusterUpdate is the clusterUpdate argument value.
+			ClusterUpdate bool
+		}
+		// BMCInstallByName holds details about calls to the BMCInstallByName method.
+		BMCInstallByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Install is the install argument value.
+			Install api.ServerBMCInstallPost
+		}
 		// BMCLogEntriesByNameAndLogSource holds details about calls to the BMCLogEntriesByNameAndLogSource method.
 		BMCLogEntriesByNameAndLogSource []struct {
 			// Ctx is the ctx argument value.
@@ -434,6 +564,31 @@ type ServerServiceMock struct {
 			// Active is the active argument value.
 			Active bool
 		}
+		// BulkAction holds details about calls to the BulkAction method.
+		BulkAction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Action is the action argument value.
+			Action provisioning.ServerBulkAction
+		}
+		// ClusterJoinControlLoop holds details about calls to the ClusterJoinControlLoop method.
+		ClusterJoinControlLoop []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// DecommissionByName holds details about calls to the DecommissionByName method.
+		DecommissionByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Options is the options argument value.
+			Options api.ServerDecommissionOptions
+			// ConfirmDiskWipe is the confirmDiskWipe argument value.
+			ConfirmDiskWipe string
+			// Resume is the resume argument value.
+			Resume bool
+		}
 		// DeleteByName holds details about calls to the DeleteByName method.
 		DeleteByName []struct {
 			// Ctx is the ctx argument value.
@@ -489,6 +644,15 @@ type ServerServiceMock struct {
 			// Filter is the filter argument value.
 			Filter provisioning.ServerFilter
 		}
+		// GetBMCInstallMediaByName holds details about calls to the GetBMCInstallMediaByName method.
+		GetBMCInstallMediaByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Id is the id argument value.
+			Id uuid.UUID
+		}
 		// GetByName holds details about calls to the GetByName method.
 		GetByName []struct {
 			// Ctx is the ctx argument value.
@@ -503,6 +667,46 @@ type ServerServiceMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetDecommissionByUUID holds details about calls to the GetDecommissionByUUID method.
+		GetDecommissionByUUID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id uuid.UUID
+		}
+		// GetDecommissions holds details about calls to the GetDecommissions method.
+		GetDecommissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetDecommissionsByName holds details about calls to the GetDecommissionsByName method.
+		GetDecommissionsByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetDiskHealthByName holds details about calls to the GetDiskHealthByName method.
+		GetDiskHealthByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetDiskHealthWithFilter holds details about calls to the GetDiskHealthWithFilter method.
+		GetDiskHealthWithFilter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter provisioning.ServerFilter
+		}
+		// GetHardwareHistoryByName holds details about calls to the GetHardwareHistoryByName method.
+		GetHardwareHistoryByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// GetSystemKernel holds details about calls to the GetSystemKernel method.
 		GetSystemKernel []struct {
 			// Ctx is the ctx argument value.
@@ -572,6 +776,16 @@ type ServerServiceMock struct {
 			// Server is the server argument value.
 			Server provisioning.Server
 		}
+		// Prune holds details about calls to the Prune method.
+		Prune []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PruneBMCInstallMedia holds details about calls to the PruneBMCInstallMedia method.
+		PruneBMCInstallMedia []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// RebootSystemByName holds details about calls to the RebootSystemByName method.
 		RebootSystemByName []struct {
 			// Ctx is the ctx argument value.
@@ -652,6 +866,16 @@ type ServerServiceMock struct {
 			// ClusterSvc is the clusterSvc argument value.
 			ClusterSvc provisioning.ClusterService
 		}
+		// SetRolloutService holds details about calls to the SetRolloutService method.
+		SetRolloutService []struct {
+			// RolloutSvc is the rolloutSvc argument value.
+			RolloutSvc provisioning.RolloutService
+		}
+		// SetSiteService holds details about calls to the SetSiteService method.
+		SetSiteService []struct {
+			// SiteSvc is the siteSvc argument value.
+			SiteSvc provisioning.SiteService
+		}
 		// SyncCluster holds details about calls to the SyncCluster method.
 		SyncCluster []struct {
 			// Ctx is the ctx argument value.
@@ -743,6 +967,9 @@ type ServerServiceMock struct {
 	lockBMCBIOSAttributeByName              sync.RWMutex
 	lockBMCBIOSAttributesByName             sync.RWMutex
 	lockBMCDumpByName                       sync.RWMutex
+	lockBMCFirmwareByName                   sync.RWMutex
+	lockBMCFirmwareUpdateByName             sync.RWMutex
+	lockBMCInstallByName                    sync.RWMutex
 	lockBMCLogEntriesByNameAndLogSource     sync.RWMutex
 	lockBMCLogSourcesByName                 sync.RWMutex
 	lockBMCRefreshByName                    sync.RWMutex
@@ -750,6 +977,9 @@ type ServerServiceMock struct {
 	lockBMCServerPowerOnByName              sync.RWMutex
 	lockBMCServerRestartByName              sync.RWMutex
 	lockBMCServerSetLocationIndicatorByName sync.RWMutex
+	lockBulkAction                          sync.RWMutex
+	lockClusterJoinControlLoop              sync.RWMutex
+	lockDecommissionByName                  sync.RWMutex
 	lockDeleteByName                        sync.RWMutex
 	lockEvacuateSystemByName                sync.RWMutex
 	lockFactoryResetByName                  sync.RWMutex
@@ -757,8 +987,15 @@ type ServerServiceMock struct {
 	lockGetAllNames                         sync.RWMutex
 	lockGetAllNamesWithFilter               sync.RWMutex
 	lockGetAllWithFilter                    sync.RWMutex
+	lockGetBMCInstallMediaByName            sync.RWMutex
 	lockGetByName                           sync.RWMutex
 	lockGetChangelogByName                  sync.RWMutex
+	lockGetDecommissionByUUID               sync.RWMutex
+	lockGetDecommissions                    sync.RWMutex
+	lockGetDecommissionsByName              sync.RWMutex
+	lockGetDiskHealthByName                 sync.RWMutex
+	lockGetDiskHealthWithFilter             sync.RWMutex
+	lockGetHardwareHistoryByName            sync.RWMutex
 	lockGetSystemKernel                     sync.RWMutex
 	lockGetSystemLogging                    sync.RWMutex
 	lockGetSystemProvider                   sync.RWMutex
@@ -768,6 +1005,8 @@ type ServerServiceMock struct {
 	lockPostRestoreSystemDoneByName         sync.RWMutex
 	lockPoweroffSystemByName                sync.RWMutex
 	lockPreRegister                         sync.RWMutex
+	lockPrune                               sync.RWMutex
+	lockPruneBMCInstallMedia                sync.RWMutex
 	lockRebootSystemByName                  sync.RWMutex
 	lockRegister                            sync.RWMutex
 	lockRename                              sync.RWMutex
@@ -778,6 +1017,8 @@ type ServerServiceMock struct {
 	lockSelfRegisterOperationsCenter        sync.RWMutex
 	lockSelfUpdate                          sync.RWMutex
 	lockSetClusterService                   sync.RWMutex
+	lockSetRolloutService                   sync.RWMutex
+	lockSetSiteService                      sync.RWMutex
 	lockSyncCluster                         sync.RWMutex
 	lockUpdate                              sync.RWMutex
 	lockUpdateSystemByName                  sync.RWMutex
@@ -993,6 +1234,126 @@ func (mock *ServerServiceMock) BMCDumpByNameCalls() []struct {
 	return calls
 }
 
+// BMCFirmwareByName calls BMCFirmwareByNameFunc.
+func (mock *ServerServiceMock) BMCFirmwareByName(ctx context.Context, name string) (map[string]api.BMCFirmware, error) {
+	if mock.BMCFirmwareByNameFunc == nil {
+		panic("ServerServiceMock.BMCFirmwareByNameFunc: method is nil but ServerService.BMCFirmwareByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockBMCFirmwareByName.Lock()
+	mock.calls.BMCFirmwareByName = append(mock.calls.BMCFirmwareByName, callInfo)
+	mock.lockBMCFirmwareByName.Unlock()
+	return mock.BMCFirmwareByNameFunc(ctx, name)
+}
+
+// BMCFirmwareByNameCalls gets all the calls that were made to BMCFirmwareByName.
+// Check the length with:
+//
+//	len(mockedServerService.BMCFirmwareByNameCalls())
+func (mock *ServerServiceMock) BMCFirmwareByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockBMCFirmwareByName.RLock()
+	calls = mock.calls.BMCFirmwareByName
+	mock.lockBMCFirmwareByName.RUnlock()
+	return calls
+}
+
+// BMCFirmwareUpdateByName calls BMCFirmwareUpdateByNameFunc.
+func (mock *ServerServiceMock) BMCFirmwareUpdateByName(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) error {
+	if mock.BMCFirmwareUpdateByNameFunc == nil {
+		panic("ServerServiceMock.BMCFirmwareUpdateByNameFunc: method is nil but ServerService.BMCFirmwareUpdateByName was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Name          string
+		Update        api.BMCFirmwareUpdatePost
+		ClusterUpdate bool
+	}{
+		Ctx:           ctx,
+		Name:          name,
+		Update:        update,
+		ClusterUpdate: clusterUpdate,
+	}
+	mock.lockBMCFirmwareUpdateByName.Lock()
+	mock.calls.BMCFirmwareUpdateByName = append(mock.calls.BMCFirmwareUpdateByName, callInfo)
+	mock.lockBMCFirmwareUpdateByName.Unlock()
+	return mock.BMCFirmwareUpdateByNameFunc(ctx, name, update, clusterUpdate)
+}
+
+// BMCFirmwareUpdateByNameCalls gets all the calls that were made to BMCFirmwareUpdateByName.
+// Check the length with:
+//
+//	len(mockedServerService.BMCFirmwareUpdateByNameCalls())
+func (mock *ServerServiceMock) BMCFirmwareUpdateByNameCalls() []struct {
+	Ctx           context.Context
+	Name          string
+	Update        api.BMCFirmwareUpdatePost
+	ClusterUpdate bool
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Name          string
+		Update        api.BMCFirmwareUpdatePost
+		ClusterUpdate bool
+	}
+	mock.lockBMCFirmwareUpdateByName.RLock()
+	calls = mock.calls.BMCFirmwareUpdateByName
+	mock.lockBMCFirmwareUpdateByName.RUnlock()
+	return calls
+}
+
+// BMCInstallByName calls BMCInstallByNameFunc.
+func (mock *ServerServiceMock) BMCInstallByName(ctx context.Context, name string, install api.ServerBMCInstallPost) error {
+	if mock.BMCInstallByNameFunc == nil {
+		panic("ServerServiceMock.BMCInstallByNameFunc: method is nil but ServerService.BMCInstallByName was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Name    string
+		Install api.ServerBMCInstallPost
+	}{
+		Ctx:     ctx,
+		Name:    name,
+		Install: install,
+	}
+	mock.lockBMCInstallByName.Lock()
+	mock.calls.BMCInstallByName = append(mock.calls.BMCInstallByName, callInfo)
+	mock.lockBMCInstallByName.Unlock()
+	return mock.BMCInstallByNameFunc(ctx, name, install)
+}
+
+// BMCInstallByNameCalls gets all the calls that were made to BMCInstallByName.
+// Check the length with:
+//
+//	len(mockedServerService.BMCInstallByNameCalls())
+func (mock *ServerServiceMock) BMCInstallByNameCalls() []struct {
+	Ctx     context.Context
+	Name    string
+	Install api.ServerBMCInstallPost
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Name    string
+		Install api.ServerBMCInstallPost
+	}
+	mock.lockBMCInstallByName.RLock()
+	calls = mock.calls.BMCInstallByName
+	mock.lockBMCInstallByName.RUnlock()
+	return calls
+}
+
 // BMCLogEntriesByNameAndLogSource calls BMCLogEntriesByNameAndLogSourceFunc.
 func (mock *ServerServiceMock) BMCLogEntriesByNameAndLogSource(ctx context.Context, name string, logSource string) ([]api.BMCLogEvent, error) {
 	if mock.BMCLogEntriesByNameAndLogSourceFunc == nil {
@@ -1265,6 +1626,122 @@ func (mock *ServerServiceMock) BMCServerSetLocationIndicatorByNameCalls() []stru
 	return calls
 }
 
+// BulkAction calls BulkActionFunc.
+func (mock *ServerServiceMock) BulkAction(ctx context.Context, action provisioning.ServerBulkAction) (provisioning.ServerBulkActionResults, error) {
+	if mock.BulkActionFunc == nil {
+		panic("ServerServiceMock.BulkActionFunc: method is nil but ServerService.BulkAction was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Action provisioning.ServerBulkAction
+	}{
+		Ctx:    ctx,
+		Action: action,
+	}
+	mock.lockBulkAction.Lock()
+	mock.calls.BulkAction = append(mock.calls.BulkAction, callInfo)
+	mock.lockBulkAction.Unlock()
+	return mock.BulkActionFunc(ctx, action)
+}
+
+// BulkActionCalls gets all the calls that were made to BulkAction.
+// Check the length with:
+//
+//	len(mockedServerService.BulkActionCalls())
+func (mock *ServerServiceMock) BulkActionCalls() []struct {
+	Ctx    context.Context
+	Action provisioning.ServerBulkAction
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Action provisioning.ServerBulkAction
+	}
+	mock.lockBulkAction.RLock()
+	calls = mock.calls.BulkAction
+	mock.lockBulkAction.RUnlock()
+	return calls
+}
+
+// ClusterJoinControlLoop calls ClusterJoinControlLoopFunc.
+func (mock *ServerServiceMock) ClusterJoinControlLoop(ctx context.Context) error {
+	if mock.ClusterJoinControlLoopFunc == nil {
+		panic("ServerServiceMock.ClusterJoinControlLoopFunc: method is nil but ServerService.ClusterJoinControlLoop was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockClusterJoinControlLoop.Lock()
+	mock.calls.ClusterJoinControlLoop = append(mock.calls.ClusterJoinControlLoop, callInfo)
+	mock.lockClusterJoinControlLoop.Unlock()
+	return mock.ClusterJoinControlLoopFunc(ctx)
+}
+
+// ClusterJoinControlLoopCalls gets all the calls that were made to ClusterJoinControlLoop.
+// Check the length with:
+//
+//	len(mockedServerService.ClusterJoinControlLoopCalls())
+func (mock *ServerServiceMock) ClusterJoinControlLoopCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockClusterJoinControlLoop.RLock()
+	calls = mock.calls.ClusterJoinControlLoop
+	mock.lockClusterJoinControlLoop.RUnlock()
+	return calls
+}
+
+// DecommissionByName calls DecommissionByNameFunc.
+func (mock *ServerServiceMock) DecommissionByName(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (*provisioning.ServerDecommission, error) {
+	if mock.DecommissionByNameFunc == nil {
+		panic("ServerServiceMock.DecommissionByNameFunc: method is nil but ServerService.DecommissionByName was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Name            string
+		Options         api.ServerDecommissionOptions
+		ConfirmDiskWipe string
+		Resume          bool
+	}{
+		Ctx:             ctx,
+		Name:            name,
+		Options:         options,
+		ConfirmDiskWipe: confirmDiskWipe,
+		Resume:          resume,
+	}
+	mock.lockDecommissionByName.Lock()
+	mock.calls.DecommissionByName = append(mock.calls.DecommissionByName, callInfo)
+	mock.lockDecommissionByName.Unlock()
+	return mock.DecommissionByNameFunc(ctx, name, options, confirmDiskWipe, resume)
+}
+
+// DecommissionByNameCalls gets all the calls that were made to DecommissionByName.
+// Check the length with:
+//
+//	len(mockedServerService.DecommissionByNameCalls())
+func (mock *ServerServiceMock) DecommissionByNameCalls() []struct {
+	Ctx             context.Context
+	Name            string
+	Options         api.ServerDecommissionOptions
+	ConfirmDiskWipe string
+	Resume          bool
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Name            string
+		Options         api.ServerDecommissionOptions
+		ConfirmDiskWipe string
+		Resume          bool
+	}
+	mock.lockDecommissionByName.RLock()
+	calls = mock.calls.DecommissionByName
+	mock.lockDecommissionByName.RUnlock()
+	return calls
+}
+
 // DeleteByName calls DeleteByNameFunc.
 func (mock *ServerServiceMock) DeleteByName(ctx context.Context, name string) error {
 	if mock.DeleteByNameFunc == nil {
@@ -1529,6 +2006,46 @@ func (mock *ServerServiceMock) GetAllWithFilterCalls() []struct {
 	return calls
 }
 
+// GetBMCInstallMediaByName calls GetBMCInstallMediaByNameFunc.
+func (mock *ServerServiceMock) GetBMCInstallMediaByName(ctx context.Context, name string, id uuid.UUID) (io.ReadSeekCloser, error) {
+	if mock.GetBMCInstallMediaByNameFunc == nil {
+		panic("ServerServiceMock.GetBMCInstallMediaByNameFunc: method is nil but ServerService.GetBMCInstallMediaByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		Id   uuid.UUID
+	}{
+		Ctx:  ctx,
+		Name: name,
+		Id:   id,
+	}
+	mock.lockGetBMCInstallMediaByName.Lock()
+	mock.calls.GetBMCInstallMediaByName = append(mock.calls.GetBMCInstallMediaByName, callInfo)
+	mock.lockGetBMCInstallMediaByName.Unlock()
+	return mock.GetBMCInstallMediaByNameFunc(ctx, name, id)
+}
+
+// GetBMCInstallMediaByNameCalls gets all the calls that were made to GetBMCInstallMediaByName.
+// Check the length with:
+//
+//	len(mockedServerService.GetBMCInstallMediaByNameCalls())
+func (mock *ServerServiceMock) GetBMCInstallMediaByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+	Id   uuid.UUID
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		Id   uuid.UUID
+	}
+	mock.lockGetBMCInstallMediaByName.RLock()
+	calls = mock.calls.GetBMCInstallMediaByName
+	mock.lockGetBMCInstallMediaByName.RUnlock()
+	return calls
+}
+
 // GetByName calls GetByNameFunc.
 func (mock *ServerServiceMock) GetByName(ctx context.Context, name string) (*provisioning.Server, error) {
 	if mock.GetByNameFunc == nil {
@@ -1601,6 +2118,218 @@ func (mock *ServerServiceMock) GetChangelogByNameCalls() []struct {
 	return calls
 }
 
+// GetDecommissionByUUID calls GetDecommissionByUUIDFunc.
+func (mock *ServerServiceMock) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+	if mock.GetDecommissionByUUIDFunc == nil {
+		panic("ServerServiceMock.GetDecommissionByUUIDFunc: method is nil but ServerService.GetDecommissionByUUID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  uuid.UUID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockGetDecommissionByUUID.Lock()
+	mock.calls.GetDecommissionByUUID = append(mock.calls.GetDecommissionByUUID, callInfo)
+	mock.lockGetDecommissionByUUID.Unlock()
+	return mock.GetDecommissionByUUIDFunc(ctx, id)
+}
+
+// GetDecommissionByUUIDCalls gets all the calls that were made to GetDecommissionByUUID.
+// Check the length with:
+//
+//	len(mockedServerService.GetDecommissionByUUIDCalls())
+func (mock *ServerServiceMock) GetDecommissionByUUIDCalls() []struct {
+	Ctx context.Context
+	Id  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  uuid.UUID
+	}
+	mock.lockGetDecommissionByUUID.RLock()
+	calls = mock.calls.GetDecommissionByUUID
+	mock.lockGetDecommissionByUUID.RUnlock()
+	return calls
+}
+
+// GetDecommissions calls GetDecommissionsFunc.
+func (mock *ServerServiceMock) GetDecommissions(ctx context.Context) (provisioning.ServerDecommissions, error) {
+	if mock.GetDecommissionsFunc == nil {
+		panic("ServerServiceMock.GetDecommissionsFunc: method is nil but ServerService.GetDecommissions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetDecommissions.Lock()
+	mock.calls.GetDecommissions = append(mock.calls.GetDecommissions, callInfo)
+	mock.lockGetDecommissions.Unlock()
+	return mock.GetDecommissionsFunc(ctx)
+}
+
+// GetDecommissionsCalls gets all the calls that were made to GetDecommissions.
+// Check the length with:
+//
+//	len(mockedServerService.GetDecommissionsCalls())
+func (mock *ServerServiceMock) GetDecommissionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetDecommissions.RLock()
+	calls = mock.calls.GetDecommissions
+	mock.lockGetDecommissions.RUnlock()
+	return calls
+}
+
+// GetDecommissionsByName calls GetDecommissionsByNameFunc.
+func (mock *ServerServiceMock) GetDecommissionsByName(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+	if mock.GetDecommissionsByNameFunc == nil {
+		panic("ServerServiceMock.GetDecommissionsByNameFunc: method is nil but ServerService.GetDecommissionsByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetDecommissionsByName.Lock()
+	mock.calls.GetDecommissionsByName = append(mock.calls.GetDecommissionsByName, callInfo)
+	mock.lockGetDecommissionsByName.Unlock()
+	return mock.GetDecommissionsByNameFunc(ctx, name)
+}
+
+// GetDecommissionsByNameCalls gets all the calls that were made to GetDecommissionsByName.
+// Check the length with:
+//
+//	len(mockedServerService.GetDecommissionsByNameCalls())
+func (mock *ServerServiceMock) GetDecommissionsByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetDecommissionsByName.RLock()
+	calls = mock.calls.GetDecommissionsByName
+	mock.lockGetDecommissionsByName.RUnlock()
+	return calls
+}
+
+// GetDiskHealthByName calls GetDiskHealthByNameFunc.
+func (mock *ServerServiceMock) GetDiskHealthByName(ctx context.Context, name string) ([]api.ServerDiskHealth, error) {
+	if mock.GetDiskHealthByNameFunc == nil {
+		panic("ServerServiceMock.GetDiskHealthByNameFunc: method is nil but ServerService.GetDiskHealthByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetDiskHealthByName.Lock()
+	mock.calls.GetDiskHealthByName = append(mock.calls.GetDiskHealthByName, callInfo)
+	mock.lockGetDiskHealthByName.Unlock()
+	return mock.GetDiskHealthByNameFunc(ctx, name)
+}
+
+// GetDiskHealthByNameCalls gets all the calls that were made to GetDiskHealthByName.
+// Check the length with:
+//
+//	len(mockedServerService.GetDiskHealthByNameCalls())
+func (mock *ServerServiceMock) GetDiskHealthByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetDiskHealthByName.RLock()
+	calls = mock.calls.GetDiskHealthByName
+	mock.lockGetDiskHealthByName.RUnlock()
+	return calls
+}
+
+// GetDiskHealthWithFilter calls GetDiskHealthWithFilterFunc.
+func (mock *ServerServiceMock) GetDiskHealthWithFilter(ctx context.Context, filter provisioning.ServerFilter) ([]api.ServerDiskHealth, error) {
+	if mock.GetDiskHealthWithFilterFunc == nil {
+		panic("ServerServiceMock.GetDiskHealthWithFilterFunc: method is nil but ServerService.GetDiskHealthWithFilter was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter provisioning.ServerFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockGetDiskHealthWithFilter.Lock()
+	mock.calls.GetDiskHealthWithFilter = append(mock.calls.GetDiskHealthWithFilter, callInfo)
+	mock.lockGetDiskHealthWithFilter.Unlock()
+	return mock.GetDiskHealthWithFilterFunc(ctx, filter)
+}
+
+// GetDiskHealthWithFilterCalls gets all the calls that were made to GetDiskHealthWithFilter.
+// Check the length with:
+//
+//	len(mockedServerService.GetDiskHealthWithFilterCalls())
+func (mock *ServerServiceMock) GetDiskHealthWithFilterCalls() []struct {
+	Ctx    context.Context
+	Filter provisioning.ServerFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter provisioning.ServerFilter
+	}
+	mock.lockGetDiskHealthWithFilter.RLock()
+	calls = mock.calls.GetDiskHealthWithFilter
+	mock.lockGetDiskHealthWithFilter.RUnlock()
+	return calls
+}
+
+// GetHardwareHistoryByName calls GetHardwareHistoryByNameFunc.
+func (mock *ServerServiceMock) GetHardwareHistoryByName(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+	if mock.GetHardwareHistoryByNameFunc == nil {
+		panic("ServerServiceMock.GetHardwareHistoryByNameFunc: method is nil but ServerService.GetHardwareHistoryByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetHardwareHistoryByName.Lock()
+	mock.calls.GetHardwareHistoryByName = append(mock.calls.GetHardwareHistoryByName, callInfo)
+	mock.lockGetHardwareHistoryByName.Unlock()
+	return mock.GetHardwareHistoryByNameFunc(ctx, name)
+}
+
+// GetHardwareHistoryByNameCalls gets all the calls that were made to GetHardwareHistoryByName.
+// Check the length with:
+//
+//	len(mockedServerService.GetHardwareHistoryByNameCalls())
+func (mock *ServerServiceMock) GetHardwareHistoryByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetHardwareHistoryByName.RLock()
+	calls = mock.calls.GetHardwareHistoryByName
+	mock.lockGetHardwareHistoryByName.RUnlock()
+	return calls
+}
+
 // GetSystemKernel calls GetSystemKernelFunc.
 func (mock *ServerServiceMock) GetSystemKernel(ctx context.Context, name string) (provisioning.ServerSystemKernel, error) {
 	if mock.GetSystemKernelFunc == nil {
@@ -1937,6 +2666,70 @@ func (mock *ServerServiceMock) PreRegisterCalls() []struct {
 	return calls
 }
 
+// Prune calls PruneFunc.
+func (mock *ServerServiceMock) Prune(ctx context.Context) error {
+	if mock.PruneFunc == nil {
+		panic("ServerServiceMock.PruneFunc: method is nil but ServerService.Prune was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPrune.Lock()
+	mock.calls.Prune = append(mock.calls.Prune, callInfo)
+	mock.lockPrune.Unlock()
+	return mock.PruneFunc(ctx)
+}
+
+// PruneCalls gets all the calls that were made to Prune.
+// Check the length with:
+//
+//	len(mockedServerService.PruneCalls())
+func (mock *ServerServiceMock) PruneCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPrune.RLock()
+	calls = mock.calls.Prune
+	mock.lockPrune.RUnlock()
+	return calls
+}
+
+// PruneBMCInstallMedia calls PruneBMCInstallMediaFunc.
+func (mock *ServerServiceMock) PruneBMCInstallMedia(ctx context.Context) error {
+	if mock.PruneBMCInstallMediaFunc == nil {
+		panic("ServerServiceMock.PruneBMCInstallMediaFunc: method is nil but ServerService.PruneBMCInstallMedia was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPruneBMCInstallMedia.Lock()
+	mock.calls.PruneBMCInstallMedia = append(mock.calls.PruneBMCInstallMedia, callInfo)
+	mock.lockPruneBMCInstallMedia.Unlock()
+	return mock.PruneBMCInstallMediaFunc(ctx)
+}
+
+// PruneBMCInstallMediaCalls gets all the calls that were made to PruneBMCInstallMedia.
+// Check the length with:
+//
+//	len(mockedServerService.PruneBMCInstallMediaCalls())
+func (mock *ServerServiceMock) PruneBMCInstallMediaCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPruneBMCInstallMedia.RLock()
+	calls = mock.calls.PruneBMCInstallMedia
+	mock.lockPruneBMCInstallMedia.RUnlock()
+	return calls
+}
+
 // RebootSystemByName calls RebootSystemByNameFunc.
 func (mock *ServerServiceMock) RebootSystemByName(ctx context.Context, name string, force bool) error {
 	if mock.RebootSystemByNameFunc == nil {
@@ -2317,6 +3110,70 @@ func (mock *ServerServiceMock) SetClusterServiceCalls() []struct {
 	return calls
 }
 
+// SetRolloutService calls SetRolloutServiceFunc.
+func (mock *ServerServiceMock) SetRolloutService(rolloutSvc provisioning.RolloutService) {
+	if mock.SetRolloutServiceFunc == nil {
+		panic("ServerServiceMock.SetRolloutServiceFunc: method is nil but ServerService.SetRolloutService was just called")
+	}
+	callInfo := struct {
+		RolloutSvc provisioning.RolloutService
+	}{
+		RolloutSvc: rolloutSvc,
+	}
+	mock.lockSetRolloutService.Lock()
+	mock.calls.SetRolloutService = append(mock.calls.SetRolloutService, callInfo)
+	mock.lockSetRolloutService.Unlock()
+	mock.SetRolloutServiceFunc(rolloutSvc)
+}
+
+// SetRolloutServiceCalls gets all the calls that were made to SetRolloutService.
+// Check the length with:
+//
+//	len(mockedServerService.SetRolloutServiceCalls())
+func (mock *ServerServiceMock) SetRolloutServiceCalls() []struct {
+	RolloutSvc provisioning.RolloutService
+} {
+	var calls []struct {
+		RolloutSvc provisioning.RolloutService
+	}
+	mock.lockSetRolloutService.RLock()
+	calls = mock.calls.SetRolloutService
+	mock.lockSetRolloutService.RUnlock()
+	return calls
+}
+
+// SetSiteService calls SetSiteServiceFunc.
+func (mock *ServerServiceMock) SetSiteService(siteSvc provisioning.SiteService) {
+	if mock.SetSiteServiceFunc == nil {
+		panic("ServerServiceMock.SetSiteServiceFunc: method is nil but ServerService.SetSiteService was just called")
+	}
+	callInfo := struct {
+		SiteSvc provisioning.SiteService
+	}{
+		SiteSvc: siteSvc,
+	}
+	mock.lockSetSiteService.Lock()
+	mock.calls.SetSiteService = append(mock.calls.SetSiteService, callInfo)
+	mock.lockSetSiteService.Unlock()
+	mock.SetSiteServiceFunc(siteSvc)
+}
+
+// SetSiteServiceCalls gets all the calls that were made to SetSiteService.
+// Check the length with:
+//
+//	len(mockedServerService.SetSiteServiceCalls())
+func (mock *ServerServiceMock) SetSiteServiceCalls() []struct {
+	SiteSvc provisioning.SiteService
+} {
+	var calls []struct {
+		SiteSvc provisioning.SiteService
+	}
+	mock.lockSetSiteService.RLock()
+	calls = mock.calls.SetSiteService
+	mock.lockSetSiteService.RUnlock()
+	return calls
+}
+
 // SyncCluster calls SyncClusterFunc.
 func (mock *ServerServiceMock) SyncCluster(ctx context.Context, clusterName string) error {
 	if mock.SyncClusterFunc == nil {
@@ -26,7 +26,7 @@ var _ provisioning.TokenService = &TokenServiceMock{}
 //
 //		// make and configure a mocked provisioning.TokenService
 //		mockedTokenService := &TokenServiceMock{
-//			ConsumeFunc: func(ctx context.Context, id uuid.UUID) (string, error) {
+//			ConsumeFunc: func(ctx context.Context, id uuid.UUID) (*provisioning.Token, error) {
 //				panic("mock out the Consume method")
 //			},
 //			CreateFunc: func(ctx context.Context, token provisioning.Token) (provisioning.Token, error) {
@@ -71,6 +71,9 @@ var _ provisioning.TokenService = &TokenServiceMock{}
 //			PreparePreSeededImageFunc: func(ctx context.Context, id uuid.UUID, imageType api.ImageType, architecture images.UpdateFileArchitecture, seedConfig provisioning.TokenImageSeedConfigs) (uuid.UUID, error) {
 //				panic("mock out the PreparePreSeededImage method")
 //			},
+//			SetClusterServiceFunc: func(clusterSvc provisioning.ClusterService)  {
+//				panic("mock out the SetClusterService method")
+//			},
 //			UpdateFunc: func(ctx context.Context, token provisioning.Token) error {
 //				panic("mock out the Update method")
 //			},
@@ -85,7 +88,7 @@ var _ provisioning.TokenService = &TokenServiceMock{}
 //	}
 type TokenServiceMock struct {
 	// ConsumeFunc mocks the Consume method.
-	ConsumeFunc func(ctx context.Context, id uuid.UUID) (string, error)
+	ConsumeFunc func(ctx context.Context, id uuid.UUID) (*provisioning.Token, error)
 
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, token provisioning.Token) (provisioning.Token, error)
@@ -129,6 +132,9 @@ type TokenServiceMock struct {
 	// PreparePreSeededImageFunc mocks the PreparePreSeededImage method.
 	PreparePreSeededImageFunc func(ctx context.Context, id uuid.UUID, imageType api.ImageType, architecture images.UpdateFileArchitecture, seedConfig provisioning.TokenImageSeedConfigs) (uuid.UUID, error)
 
+	// SetClusterServiceFunc mocks the SetClusterService method.
+	SetClusterServiceFunc func(clusterSvc provisioning.ClusterService)
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, token provisioning.Token) error
 
@@ -258,6 +264,11 @@ type TokenServiceMock struct {
 			// SeedConfig is the seedConfig argument value.
 			SeedConfig provisioning.TokenImageSeedConfigs
 		}
+		// SetClusterService holds details about calls to the SetClusterService method.
+		SetClusterService []struct {
+			// ClusterSvc is the clusterSvc argument value.
+			ClusterSvc provisioning.ClusterService
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -288,12 +299,13 @@ type TokenServiceMock struct {
 	lockGetTokenSeedAllNames       sync.RWMutex
 	lockGetTokenSeedByName         sync.RWMutex
 	lockPreparePreSeededImage      sync.RWMutex
+	lockSetClusterService          sync.RWMutex
 	lockUpdate                     sync.RWMutex
 	lockUpdateTokenSeed            sync.RWMutex
 }
 
 // Consume calls ConsumeFunc.
-func (mock *TokenServiceMock) Consume(ctx context.Context, id uuid.UUID) (string, error) {
+func (mock *TokenServiceMock) Consume(ctx context.Context, id uuid.UUID) (*provisioning.Token, error) {
 	if mock.ConsumeFunc == nil {
 		panic("TokenServiceMock.ConsumeFunc: method is nil but TokenService.Consume was just called")
 	}
@@ -864,6 +876,38 @@ func (mock *TokenServiceMock) PreparePreSeededImageCalls() []struct {
 	return calls
 }
 
+// SetClusterService calls SetClusterServiceFunc.
+func (mock *TokenServiceMock) SetClusterService(clusterSvc provisioning.ClusterService) {
+	if mock.SetClusterServiceFunc == nil {
+		panic("TokenServiceMock.SetClusterServiceFunc: method is nil but TokenService.SetClusterService was just called")
+	}
+	callInfo := struct {
+		ClusterSvc provisioning.ClusterService
+	}{
+		ClusterSvc: clusterSvc,
+	}
+	mock.lockSetClusterService.Lock()
+	mock.calls.SetClusterService = append(mock.calls.SetClusterService, callInfo)
+	mock.lockSetClusterService.Unlock()
+	mock.SetClusterServiceFunc(clusterSvc)
+}
+
+// SetClusterServiceCalls gets all the calls that were made to SetClusterService.
+// Check the length with:
+//
+//	len(mockedTokenService.SetClusterServiceCalls())
+func (mock *TokenServiceMock) SetClusterServiceCalls() []struct {
+	ClusterSvc provisioning.ClusterService
+} {
+	var calls []struct {
+		ClusterSvc provisioning.ClusterService
+	}
+	mock.lockSetClusterService.RLock()
+	calls = mock.calls.SetClusterService
+	mock.lockSetClusterService.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *TokenServiceMock) Update(ctx context.Context, token provisioning.Token) error {
 	if mock.UpdateFunc == nil {
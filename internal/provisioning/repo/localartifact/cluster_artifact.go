@@ -246,6 +246,20 @@ func (c clusterArtifact) GetClusterArtifactByName(ctx context.Context, clusterNa
 	return artifact, nil
 }
 
+func (c clusterArtifact) DeleteClusterArtifactByName(ctx context.Context, clusterName string, artifactName string) error {
+	err := entities.DeleteClusterArtifact(ctx, transaction.GetDBTX(ctx, c.db), clusterName, artifactName)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(filepath.Join(c.storageDir, clusterName, artifactName))
+	if err != nil {
+		return fmt.Errorf("Failed to remove files of artifact %q of cluster %q: %w", artifactName, clusterName, err)
+	}
+
+	return nil
+}
+
 func (c clusterArtifact) GetClusterArtifactArchiveByName(ctx context.Context, clusterName string, artifactName string, archiveType provisioning.ClusterArtifactArchiveType) (_ io.ReadCloser, size int, _ error) {
 	if archiveType.Ext != provisioning.ClusterArtifactArchiveTypeExtZip {
 		return nil, 0, fmt.Errorf("Archive type %q (%s) not supported", archiveType.Ext, archiveType.MimeType)
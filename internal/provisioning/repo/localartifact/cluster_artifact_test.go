@@ -13,6 +13,7 @@ import (
 	"github.com/maniartech/signals"
 	"github.com/stretchr/testify/require"
 
+	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/lifecycle"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
 	"github.com/FuturFusion/operations-center/internal/provisioning/repo/localartifact"
@@ -251,6 +252,18 @@ func TestLocalArtifactDatabaseActions(t *testing.T) {
 	for filename, found := range expectedFilesFound {
 		require.True(t, found, "file %q not found in zip archive", filename)
 	}
+
+	// Delete artifact
+	err = artifactRepo.DeleteClusterArtifactByName(ctx, "clusterOne", "two")
+	require.NoError(t, err)
+
+	names, err = artifactRepo.GetClusterArtifactAllNames(ctx, "clusterOne")
+	require.NoError(t, err)
+	require.Equal(t, []string{"one"}, names)
+	require.NoDirExists(t, filepath.Join(tmpDir, "artifacts", "clusterOne", "two"))
+
+	err = artifactRepo.DeleteClusterArtifactByName(ctx, "clusterOne", "two")
+	require.ErrorIs(t, err, domain.ErrNotFound)
 }
 
 func assertArtifactOne(t *testing.T, artifact *provisioning.ClusterArtifact) {
@@ -47,6 +47,10 @@ INSERT INTO cluster_artifacts (cluster_id, name, description, properties, files,
   VALUES ((SELECT clusters.id FROM clusters WHERE clusters.name = ?), ?, ?, ?, ?, ?)
 `)
 
+var clusterArtifactDeleteByClusterAndName = RegisterStmt(`
+DELETE FROM cluster_artifacts WHERE cluster_id = (SELECT clusters.id FROM clusters WHERE clusters.name = ?) AND name = ?
+`)
+
 // GetClusterArtifactID return the ID of the cluster_artifact with the given key.
 // generator: cluster_artifact ID
 func GetClusterArtifactID(ctx context.Context, db tx, cluster string, name string) (_ int64, _err error) {
@@ -314,3 +318,34 @@ func CreateClusterArtifact(ctx context.Context, db dbtx, object provisioning.Clu
 
 	return id, nil
 }
+
+// DeleteClusterArtifact deletes the cluster_artifact matching the given key parameters.
+// generator: cluster_artifact DeleteOne-by-Cluster-and-Name
+func DeleteClusterArtifact(ctx context.Context, db dbtx, cluster string, name string) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Cluster_artifact")
+	}()
+
+	stmt, err := Stmt(db, clusterArtifactDeleteByClusterAndName)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"clusterArtifactDeleteByClusterAndName\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(cluster, name)
+	if err != nil {
+		return fmt.Errorf("Delete \"cluster_artifacts\": %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	} else if n > 1 {
+		return fmt.Errorf("Query deleted %d ClusterArtifact rows instead of 1", n)
+	}
+
+	return nil
+}
@@ -53,6 +53,20 @@ func (_d ClusterArtifactRepoWithPrometheus) CreateClusterArtifactFromPath(ctx co
 	return _d.base.CreateClusterArtifactFromPath(ctx, artifact, path, ignoredFiles)
 }
 
+// DeleteClusterArtifactByName implements provisioning.ClusterArtifactRepo.
+func (_d ClusterArtifactRepoWithPrometheus) DeleteClusterArtifactByName(ctx context.Context, clusterName string, artifactName string) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterArtifactRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "DeleteClusterArtifactByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.DeleteClusterArtifactByName(ctx, clusterName, artifactName)
+}
+
 // GetClusterArtifactAll implements provisioning.ClusterArtifactRepo.
 func (_d ClusterArtifactRepoWithPrometheus) GetClusterArtifactAll(ctx context.Context, clusterName string) (clusterArtifacts provisioning.ClusterArtifacts, err error) {
 	_since := time.Now()
@@ -77,6 +77,41 @@ func (_d ClusterArtifactRepoWithSlog) CreateClusterArtifactFromPath(ctx context.
 	return _d._base.CreateClusterArtifactFromPath(ctx, artifact, path, ignoredFiles)
 }
 
+// DeleteClusterArtifactByName implements provisioning.ClusterArtifactRepo.
+func (_d ClusterArtifactRepoWithSlog) DeleteClusterArtifactByName(ctx context.Context, clusterName string, artifactName string) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("clusterName", clusterName),
+			slog.String("artifactName", artifactName),
+		)
+	}
+	log.DebugContext(ctx, "=> calling DeleteClusterArtifactByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method DeleteClusterArtifactByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method DeleteClusterArtifactByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method DeleteClusterArtifactByName finished")
+		}
+	}()
+	return _d._base.DeleteClusterArtifactByName(ctx, clusterName, artifactName)
+}
+
 // GetClusterArtifactAll implements provisioning.ClusterArtifactRepo.
 func (_d ClusterArtifactRepoWithSlog) GetClusterArtifactAll(ctx context.Context, clusterName string) (clusterArtifacts provisioning.ClusterArtifacts, err error) {
 	log := slog.With()
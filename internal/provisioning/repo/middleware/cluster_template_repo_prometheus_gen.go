@@ -52,6 +52,20 @@ func (_d ClusterTemplateRepoWithPrometheus) Create(ctx context.Context, clusterT
 	return _d.base.Create(ctx, clusterTemplate)
 }
 
+// CreateRevision implements provisioning.ClusterTemplateRepo.
+func (_d ClusterTemplateRepoWithPrometheus) CreateRevision(ctx context.Context, clusterTemplateRevision provisioning.ClusterTemplateRevision) (n int64, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterTemplateRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "CreateRevision", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.CreateRevision(ctx, clusterTemplateRevision)
+}
+
 // DeleteByName implements provisioning.ClusterTemplateRepo.
 func (_d ClusterTemplateRepoWithPrometheus) DeleteByName(ctx context.Context, name string) (err error) {
 	_since := time.Now()
@@ -108,6 +122,34 @@ func (_d ClusterTemplateRepoWithPrometheus) GetByName(ctx context.Context, name
 	return _d.base.GetByName(ctx, name)
 }
 
+// GetRevision implements provisioning.ClusterTemplateRepo.
+func (_d ClusterTemplateRepoWithPrometheus) GetRevision(ctx context.Context, name string, revision int64) (clusterTemplateRevision *provisioning.ClusterTemplateRevision, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterTemplateRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetRevision", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetRevision(ctx, name, revision)
+}
+
+// GetRevisionAll implements provisioning.ClusterTemplateRepo.
+func (_d ClusterTemplateRepoWithPrometheus) GetRevisionAll(ctx context.Context, name string) (clusterTemplateRevisions provisioning.ClusterTemplateRevisions, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		clusterTemplateRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetRevisionAll", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetRevisionAll(ctx, name)
+}
+
 // Rename implements provisioning.ClusterTemplateRepo.
 func (_d ClusterTemplateRepoWithPrometheus) Rename(ctx context.Context, oldName string, newName string) (err error) {
 	_since := time.Now()
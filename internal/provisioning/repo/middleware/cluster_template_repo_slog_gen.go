@@ -74,6 +74,41 @@ func (_d ClusterTemplateRepoWithSlog) Create(ctx context.Context, clusterTemplat
 	return _d._base.Create(ctx, clusterTemplate)
 }
 
+// CreateRevision implements provisioning.ClusterTemplateRepo.
+func (_d ClusterTemplateRepoWithSlog) CreateRevision(ctx context.Context, clusterTemplateRevision provisioning.ClusterTemplateRevision) (n int64, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("clusterTemplateRevision", clusterTemplateRevision),
+		)
+	}
+	log.DebugContext(ctx, "=> calling CreateRevision")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Int64("n", n),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method CreateRevision returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method CreateRevision returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method CreateRevision finished")
+		}
+	}()
+	return _d._base.CreateRevision(ctx, clusterTemplateRevision)
+}
+
 // DeleteByName implements provisioning.ClusterTemplateRepo.
 func (_d ClusterTemplateRepoWithSlog) DeleteByName(ctx context.Context, name string) (err error) {
 	log := slog.With()
@@ -211,6 +246,77 @@ func (_d ClusterTemplateRepoWithSlog) GetByName(ctx context.Context, name string
 	return _d._base.GetByName(ctx, name)
 }
 
+// GetRevision implements provisioning.ClusterTemplateRepo.
+func (_d ClusterTemplateRepoWithSlog) GetRevision(ctx context.Context, name string, revision int64) (clusterTemplateRevision *provisioning.ClusterTemplateRevision, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Int64("revision", revision),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetRevision")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterTemplateRevision", clusterTemplateRevision),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetRevision returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetRevision returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetRevision finished")
+		}
+	}()
+	return _d._base.GetRevision(ctx, name, revision)
+}
+
+// GetRevisionAll implements provisioning.ClusterTemplateRepo.
+func (_d ClusterTemplateRepoWithSlog) GetRevisionAll(ctx context.Context, name string) (clusterTemplateRevisions provisioning.ClusterTemplateRevisions, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetRevisionAll")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("clusterTemplateRevisions", clusterTemplateRevisions),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetRevisionAll returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetRevisionAll returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetRevisionAll finished")
+		}
+	}()
+	return _d._base.GetRevisionAll(ctx, name)
+}
+
 // Rename implements provisioning.ClusterTemplateRepo.
 func (_d ClusterTemplateRepoWithSlog) Rename(ctx context.Context, oldName string, newName string) (err error) {
 	log := slog.With()
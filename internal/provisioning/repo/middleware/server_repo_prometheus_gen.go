@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
@@ -52,6 +53,48 @@ func (_d ServerRepoWithPrometheus) Create(ctx context.Context, server provisioni
 	return _d.base.Create(ctx, server)
 }
 
+// CreateDecommission implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) CreateDecommission(ctx context.Context, decommission provisioning.ServerDecommission) (n int64, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "CreateDecommission", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.CreateDecommission(ctx, decommission)
+}
+
+// CreateDiskHealthSample implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) CreateDiskHealthSample(ctx context.Context, sample provisioning.ServerDiskHealthSample) (n int64, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "CreateDiskHealthSample", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.CreateDiskHealthSample(ctx, sample)
+}
+
+// CreateHardwareSnapshot implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) CreateHardwareSnapshot(ctx context.Context, snapshot provisioning.ServerHardwareSnapshot) (n int64, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "CreateHardwareSnapshot", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.CreateHardwareSnapshot(ctx, snapshot)
+}
+
 // DeleteByName implements provisioning.ServerRepo.
 func (_d ServerRepoWithPrometheus) DeleteByName(ctx context.Context, name string) (err error) {
 	_since := time.Now()
@@ -66,6 +109,34 @@ func (_d ServerRepoWithPrometheus) DeleteByName(ctx context.Context, name string
 	return _d.base.DeleteByName(ctx, name)
 }
 
+// DeleteDiskHealthSamplesRecordedBeforeByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) DeleteDiskHealthSamplesRecordedBeforeByName(ctx context.Context, name string, before time.Time) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "DeleteDiskHealthSamplesRecordedBeforeByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.DeleteDiskHealthSamplesRecordedBeforeByName(ctx, name, before)
+}
+
+// DeleteHardwareSnapshotsExceptLatestByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) DeleteHardwareSnapshotsExceptLatestByName(ctx context.Context, name string, keep int) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "DeleteHardwareSnapshotsExceptLatestByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.DeleteHardwareSnapshotsExceptLatestByName(ctx, name, keep)
+}
+
 // GetAll implements provisioning.ServerRepo.
 func (_d ServerRepoWithPrometheus) GetAll(ctx context.Context) (servers provisioning.Servers, err error) {
 	_since := time.Now()
@@ -178,6 +249,76 @@ func (_d ServerRepoWithPrometheus) GetBySystemUUID(ctx context.Context, systemUU
 	return _d.base.GetBySystemUUID(ctx, systemUUID)
 }
 
+// GetDecommissionByUUID implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (serverDecommission *provisioning.ServerDecommission, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDecommissionByUUID", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDecommissionByUUID(ctx, id)
+}
+
+// GetDecommissions implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) GetDecommissions(ctx context.Context) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDecommissions", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDecommissions(ctx)
+}
+
+// GetDecommissionsByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) GetDecommissionsByName(ctx context.Context, name string) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDecommissionsByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDecommissionsByName(ctx, name)
+}
+
+// GetDiskHealthSamplesByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) GetDiskHealthSamplesByName(ctx context.Context, name string) (serverDiskHealthSamples provisioning.ServerDiskHealthSamples, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetDiskHealthSamplesByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetDiskHealthSamplesByName(ctx, name)
+}
+
+// GetHardwareSnapshotsByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) GetHardwareSnapshotsByName(ctx context.Context, name string) (serverHardwareSnapshots provisioning.ServerHardwareSnapshots, err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "GetHardwareSnapshotsByName", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.GetHardwareSnapshotsByName(ctx, name)
+}
+
 // Rename implements provisioning.ServerRepo.
 func (_d ServerRepoWithPrometheus) Rename(ctx context.Context, oldName string, newName string) (err error) {
 	_since := time.Now()
@@ -205,3 +346,17 @@ func (_d ServerRepoWithPrometheus) Update(ctx context.Context, server provisioni
 	}()
 	return _d.base.Update(ctx, server)
 }
+
+// UpdateDecommission implements provisioning.ServerRepo.
+func (_d ServerRepoWithPrometheus) UpdateDecommission(ctx context.Context, decommission provisioning.ServerDecommission) (err error) {
+	_since := time.Now()
+	defer func() {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		serverRepoDurationSummaryVec.WithLabelValues(_d.instanceName, "UpdateDecommission", result).Observe(time.Since(_since).Seconds())
+	}()
+	return _d.base.UpdateDecommission(ctx, decommission)
+}
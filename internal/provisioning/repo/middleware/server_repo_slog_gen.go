@@ -6,6 +6,9 @@ package middleware
 import (
 	"context"
 	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/FuturFusion/operations-center/internal/provisioning"
 	"github.com/FuturFusion/operations-center/internal/util/logger"
@@ -74,6 +77,111 @@ func (_d ServerRepoWithSlog) Create(ctx context.Context, server provisioning.Ser
 	return _d._base.Create(ctx, server)
 }
 
+// CreateDecommission implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) CreateDecommission(ctx context.Context, decommission provisioning.ServerDecommission) (n int64, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("decommission", decommission),
+		)
+	}
+	log.DebugContext(ctx, "=> calling CreateDecommission")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Int64("n", n),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method CreateDecommission returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method CreateDecommission returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method CreateDecommission finished")
+		}
+	}()
+	return _d._base.CreateDecommission(ctx, decommission)
+}
+
+// CreateDiskHealthSample implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) CreateDiskHealthSample(ctx context.Context, sample provisioning.ServerDiskHealthSample) (n int64, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("sample", sample),
+		)
+	}
+	log.DebugContext(ctx, "=> calling CreateDiskHealthSample")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Int64("n", n),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method CreateDiskHealthSample returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method CreateDiskHealthSample returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method CreateDiskHealthSample finished")
+		}
+	}()
+	return _d._base.CreateDiskHealthSample(ctx, sample)
+}
+
+// CreateHardwareSnapshot implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) CreateHardwareSnapshot(ctx context.Context, snapshot provisioning.ServerHardwareSnapshot) (n int64, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("snapshot", snapshot),
+		)
+	}
+	log.DebugContext(ctx, "=> calling CreateHardwareSnapshot")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Int64("n", n),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method CreateHardwareSnapshot returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method CreateHardwareSnapshot returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method CreateHardwareSnapshot finished")
+		}
+	}()
+	return _d._base.CreateHardwareSnapshot(ctx, snapshot)
+}
+
 // DeleteByName implements provisioning.ServerRepo.
 func (_d ServerRepoWithSlog) DeleteByName(ctx context.Context, name string) (err error) {
 	log := slog.With()
@@ -108,6 +216,76 @@ func (_d ServerRepoWithSlog) DeleteByName(ctx context.Context, name string) (err
 	return _d._base.DeleteByName(ctx, name)
 }
 
+// DeleteDiskHealthSamplesRecordedBeforeByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) DeleteDiskHealthSamplesRecordedBeforeByName(ctx context.Context, name string, before time.Time) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Any("before", before),
+		)
+	}
+	log.DebugContext(ctx, "=> calling DeleteDiskHealthSamplesRecordedBeforeByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method DeleteDiskHealthSamplesRecordedBeforeByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method DeleteDiskHealthSamplesRecordedBeforeByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method DeleteDiskHealthSamplesRecordedBeforeByName finished")
+		}
+	}()
+	return _d._base.DeleteDiskHealthSamplesRecordedBeforeByName(ctx, name, before)
+}
+
+// DeleteHardwareSnapshotsExceptLatestByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) DeleteHardwareSnapshotsExceptLatestByName(ctx context.Context, name string, keep int) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+			slog.Int("keep", keep),
+		)
+	}
+	log.DebugContext(ctx, "=> calling DeleteHardwareSnapshotsExceptLatestByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method DeleteHardwareSnapshotsExceptLatestByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method DeleteHardwareSnapshotsExceptLatestByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method DeleteHardwareSnapshotsExceptLatestByName finished")
+		}
+	}()
+	return _d._base.DeleteHardwareSnapshotsExceptLatestByName(ctx, name, keep)
+}
+
 // GetAll implements provisioning.ServerRepo.
 func (_d ServerRepoWithSlog) GetAll(ctx context.Context) (servers provisioning.Servers, err error) {
 	log := slog.With()
@@ -386,6 +564,180 @@ func (_d ServerRepoWithSlog) GetBySystemUUID(ctx context.Context, systemUUID str
 	return _d._base.GetBySystemUUID(ctx, systemUUID)
 }
 
+// GetDecommissionByUUID implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (serverDecommission *provisioning.ServerDecommission, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("id", id),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDecommissionByUUID")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommission", serverDecommission),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDecommissionByUUID returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDecommissionByUUID returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDecommissionByUUID finished")
+		}
+	}()
+	return _d._base.GetDecommissionByUUID(ctx, id)
+}
+
+// GetDecommissions implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) GetDecommissions(ctx context.Context) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDecommissions")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommissions", serverDecommissions),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDecommissions returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDecommissions returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDecommissions finished")
+		}
+	}()
+	return _d._base.GetDecommissions(ctx)
+}
+
+// GetDecommissionsByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) GetDecommissionsByName(ctx context.Context, name string) (serverDecommissions provisioning.ServerDecommissions, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDecommissionsByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDecommissions", serverDecommissions),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDecommissionsByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDecommissionsByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDecommissionsByName finished")
+		}
+	}()
+	return _d._base.GetDecommissionsByName(ctx, name)
+}
+
+// GetDiskHealthSamplesByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) GetDiskHealthSamplesByName(ctx context.Context, name string) (serverDiskHealthSamples provisioning.ServerDiskHealthSamples, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetDiskHealthSamplesByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverDiskHealthSamples", serverDiskHealthSamples),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetDiskHealthSamplesByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetDiskHealthSamplesByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetDiskHealthSamplesByName finished")
+		}
+	}()
+	return _d._base.GetDiskHealthSamplesByName(ctx, name)
+}
+
+// GetHardwareSnapshotsByName implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) GetHardwareSnapshotsByName(ctx context.Context, name string) (serverHardwareSnapshots provisioning.ServerHardwareSnapshots, err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.String("name", name),
+		)
+	}
+	log.DebugContext(ctx, "=> calling GetHardwareSnapshotsByName")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("serverHardwareSnapshots", serverHardwareSnapshots),
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method GetHardwareSnapshotsByName returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method GetHardwareSnapshotsByName returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method GetHardwareSnapshotsByName finished")
+		}
+	}()
+	return _d._base.GetHardwareSnapshotsByName(ctx, name)
+}
+
 // Rename implements provisioning.ServerRepo.
 func (_d ServerRepoWithSlog) Rename(ctx context.Context, oldName string, newName string) (err error) {
 	log := slog.With()
@@ -454,3 +806,37 @@ func (_d ServerRepoWithSlog) Update(ctx context.Context, server provisioning.Ser
 	}()
 	return _d._base.Update(ctx, server)
 }
+
+// UpdateDecommission implements provisioning.ServerRepo.
+func (_d ServerRepoWithSlog) UpdateDecommission(ctx context.Context, decommission provisioning.ServerDecommission) (err error) {
+	log := slog.With()
+	if slog.Default().Enabled(ctx, logger.LevelTrace) {
+		log = log.With(
+			slog.Any("ctx", ctx),
+			slog.Any("decommission", decommission),
+		)
+	}
+	log.DebugContext(ctx, "=> calling UpdateDecommission")
+	defer func() {
+		log := slog.With()
+		if slog.Default().Enabled(ctx, logger.LevelTrace) {
+			log = slog.With(
+				slog.Any("err", err),
+			)
+		} else {
+			if err != nil {
+				log = slog.With("err", err)
+			}
+		}
+		if err != nil {
+			if _d._isInformativeErrFunc(err) {
+				log.DebugContext(ctx, "<= method UpdateDecommission returned an informative error")
+			} else {
+				log.ErrorContext(ctx, "<= method UpdateDecommission returned an error")
+			}
+		} else {
+			log.DebugContext(ctx, "<= method UpdateDecommission finished")
+		}
+	}()
+	return _d._base.UpdateDecommission(ctx, decommission)
+}
@@ -25,6 +25,9 @@ var _ provisioning.ClusterArtifactRepo = &ClusterArtifactRepoMock{}
 //			CreateClusterArtifactFromPathFunc: func(ctx context.Context, artifact provisioning.ClusterArtifact, path string, ignoredFiles []string) (int64, error) {
 //				panic("mock out the CreateClusterArtifactFromPath method")
 //			},
+//			DeleteClusterArtifactByNameFunc: func(ctx context.Context, clusterName string, artifactName string) error {
+//				panic("mock out the DeleteClusterArtifactByName method")
+//			},
 //			GetClusterArtifactAllFunc: func(ctx context.Context, clusterName string) (provisioning.ClusterArtifacts, error) {
 //				panic("mock out the GetClusterArtifactAll method")
 //			},
@@ -47,6 +50,9 @@ type ClusterArtifactRepoMock struct {
 	// CreateClusterArtifactFromPathFunc mocks the CreateClusterArtifactFromPath method.
 	CreateClusterArtifactFromPathFunc func(ctx context.Context, artifact provisioning.ClusterArtifact, path string, ignoredFiles []string) (int64, error)
 
+	// DeleteClusterArtifactByNameFunc mocks the DeleteClusterArtifactByName method.
+	DeleteClusterArtifactByNameFunc func(ctx context.Context, clusterName string, artifactName string) error
+
 	// GetClusterArtifactAllFunc mocks the GetClusterArtifactAll method.
 	GetClusterArtifactAllFunc func(ctx context.Context, clusterName string) (provisioning.ClusterArtifacts, error)
 
@@ -72,6 +78,15 @@ type ClusterArtifactRepoMock struct {
 			// IgnoredFiles is the ignoredFiles argument value.
 			IgnoredFiles []string
 		}
+		// DeleteClusterArtifactByName holds details about calls to the DeleteClusterArtifactByName method.
+		DeleteClusterArtifactByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterName is the clusterName argument value.
+			ClusterName string
+			// ArtifactName is the artifactName argument value.
+			ArtifactName string
+		}
 		// GetClusterArtifactAll holds details about calls to the GetClusterArtifactAll method.
 		GetClusterArtifactAll []struct {
 			// Ctx is the ctx argument value.
@@ -108,6 +123,7 @@ type ClusterArtifactRepoMock struct {
 		}
 	}
 	lockCreateClusterArtifactFromPath   sync.RWMutex
+	lockDeleteClusterArtifactByName     sync.RWMutex
 	lockGetClusterArtifactAll           sync.RWMutex
 	lockGetClusterArtifactAllNames      sync.RWMutex
 	lockGetClusterArtifactArchiveByName sync.RWMutex
@@ -158,6 +174,46 @@ func (mock *ClusterArtifactRepoMock) CreateClusterArtifactFromPathCalls() []stru
 	return calls
 }
 
+// DeleteClusterArtifactByName calls DeleteClusterArtifactByNameFunc.
+func (mock *ClusterArtifactRepoMock) DeleteClusterArtifactByName(ctx context.Context, clusterName string, artifactName string) error {
+	if mock.DeleteClusterArtifactByNameFunc == nil {
+		panic("ClusterArtifactRepoMock.DeleteClusterArtifactByNameFunc: method is nil but ClusterArtifactRepo.DeleteClusterArtifactByName was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		ClusterName  string
+		ArtifactName string
+	}{
+		Ctx:          ctx,
+		ClusterName:  clusterName,
+		ArtifactName: artifactName,
+	}
+	mock.lockDeleteClusterArtifactByName.Lock()
+	mock.calls.DeleteClusterArtifactByName = append(mock.calls.DeleteClusterArtifactByName, callInfo)
+	mock.lockDeleteClusterArtifactByName.Unlock()
+	return mock.DeleteClusterArtifactByNameFunc(ctx, clusterName, artifactName)
+}
+
+// DeleteClusterArtifactByNameCalls gets all the calls that were made to DeleteClusterArtifactByName.
+// Check the length with:
+//
+//	len(mockedClusterArtifactRepo.DeleteClusterArtifactByNameCalls())
+func (mock *ClusterArtifactRepoMock) DeleteClusterArtifactByNameCalls() []struct {
+	Ctx          context.Context
+	ClusterName  string
+	ArtifactName string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		ClusterName  string
+		ArtifactName string
+	}
+	mock.lockDeleteClusterArtifactByName.RLock()
+	calls = mock.calls.DeleteClusterArtifactByName
+	mock.lockDeleteClusterArtifactByName.RUnlock()
+	return calls
+}
+
 // GetClusterArtifactAll calls GetClusterArtifactAllFunc.
 func (mock *ClusterArtifactRepoMock) GetClusterArtifactAll(ctx context.Context, clusterName string) (provisioning.ClusterArtifacts, error) {
 	if mock.GetClusterArtifactAllFunc == nil {
@@ -24,6 +24,9 @@ var _ provisioning.ClusterTemplateRepo = &ClusterConfigTemplateRepoMock{}
 //			CreateFunc: func(ctx context.Context, clusterConfigTemplate provisioning.ClusterConfigTemplate) (int64, error) {
 //				panic("mock out the Create method")
 //			},
+//			CreateRevisionFunc: func(ctx context.Context, clusterTemplateRevision provisioning.ClusterTemplateRevision) (int64, error) {
+//				panic("mock out the CreateRevision method")
+//			},
 //			DeleteByNameFunc: func(ctx context.Context, name string) error {
 //				panic("mock out the DeleteByName method")
 //			},
@@ -36,6 +39,12 @@ var _ provisioning.ClusterTemplateRepo = &ClusterConfigTemplateRepoMock{}
 //			GetByNameFunc: func(ctx context.Context, name string) (*provisioning.ClusterConfigTemplate, error) {
 //				panic("mock out the GetByName method")
 //			},
+//			GetRevisionFunc: func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+//				panic("mock out the GetRevision method")
+//			},
+//			GetRevisionAllFunc: func(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+//				panic("mock out the GetRevisionAll method")
+//			},
 //			RenameFunc: func(ctx context.Context, oldName string, newName string) error {
 //				panic("mock out the Rename method")
 //			},
@@ -52,6 +61,9 @@ type ClusterConfigTemplateRepoMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, clusterConfigTemplate provisioning.ClusterTemplate) (int64, error)
 
+	// CreateRevisionFunc mocks the CreateRevision method.
+	CreateRevisionFunc func(ctx context.Context, clusterTemplateRevision provisioning.ClusterTemplateRevision) (int64, error)
+
 	// DeleteByNameFunc mocks the DeleteByName method.
 	DeleteByNameFunc func(ctx context.Context, name string) error
 
@@ -64,6 +76,12 @@ type ClusterConfigTemplateRepoMock struct {
 	// GetByNameFunc mocks the GetByName method.
 	GetByNameFunc func(ctx context.Context, name string) (*provisioning.ClusterTemplate, error)
 
+	// GetRevisionFunc mocks the GetRevision method.
+	GetRevisionFunc func(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error)
+
+	// GetRevisionAllFunc mocks the GetRevisionAll method.
+	GetRevisionAllFunc func(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error)
+
 	// RenameFunc mocks the Rename method.
 	RenameFunc func(ctx context.Context, oldName string, newName string) error
 
@@ -79,6 +97,13 @@ type ClusterConfigTemplateRepoMock struct {
 			// ClusterConfigTemplate is the clusterConfigTemplate argument value.
 			ClusterConfigTemplate provisioning.ClusterTemplate
 		}
+		// CreateRevision holds details about calls to the CreateRevision method.
+		CreateRevision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ClusterTemplateRevision is the clusterTemplateRevision argument value.
+			ClusterTemplateRevision provisioning.ClusterTemplateRevision
+		}
 		// DeleteByName holds details about calls to the DeleteByName method.
 		DeleteByName []struct {
 			// Ctx is the ctx argument value.
@@ -103,6 +128,22 @@ type ClusterConfigTemplateRepoMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetRevision holds details about calls to the GetRevision method.
+		GetRevision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Revision is the revision argument value.
+			Revision int64
+		}
+		// GetRevisionAll holds details about calls to the GetRevisionAll method.
+		GetRevisionAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// Rename holds details about calls to the Rename method.
 		Rename []struct {
 			// Ctx is the ctx argument value.
@@ -120,13 +161,16 @@ type ClusterConfigTemplateRepoMock struct {
 			ClusterConfigTemplate provisioning.ClusterTemplate
 		}
 	}
-	lockCreate       sync.RWMutex
-	lockDeleteByName sync.RWMutex
-	lockGetAll       sync.RWMutex
-	lockGetAllNames  sync.RWMutex
-	lockGetByName    sync.RWMutex
-	lockRename       sync.RWMutex
-	lockUpdate       sync.RWMutex
+	lockCreate         sync.RWMutex
+	lockCreateRevision sync.RWMutex
+	lockDeleteByName   sync.RWMutex
+	lockGetAll         sync.RWMutex
+	lockGetAllNames    sync.RWMutex
+	lockGetByName      sync.RWMutex
+	lockGetRevision    sync.RWMutex
+	lockGetRevisionAll sync.RWMutex
+	lockRename         sync.RWMutex
+	lockUpdate         sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -165,6 +209,42 @@ func (mock *ClusterConfigTemplateRepoMock) CreateCalls() []struct {
 	return calls
 }
 
+// CreateRevision calls CreateRevisionFunc.
+func (mock *ClusterConfigTemplateRepoMock) CreateRevision(ctx context.Context, clusterTemplateRevision provisioning.ClusterTemplateRevision) (int64, error) {
+	if mock.CreateRevisionFunc == nil {
+		panic("ClusterConfigTemplateRepoMock.CreateRevisionFunc: method is nil but ClusterConfigTemplateRepo.CreateRevision was just called")
+	}
+	callInfo := struct {
+		Ctx                     context.Context
+		ClusterTemplateRevision provisioning.ClusterTemplateRevision
+	}{
+		Ctx:                     ctx,
+		ClusterTemplateRevision: clusterTemplateRevision,
+	}
+	mock.lockCreateRevision.Lock()
+	mock.calls.CreateRevision = append(mock.calls.CreateRevision, callInfo)
+	mock.lockCreateRevision.Unlock()
+	return mock.CreateRevisionFunc(ctx, clusterTemplateRevision)
+}
+
+// CreateRevisionCalls gets all the calls that were made to CreateRevision.
+// Check the length with:
+//
+//	len(mockedClusterConfigTemplateRepo.CreateRevisionCalls())
+func (mock *ClusterConfigTemplateRepoMock) CreateRevisionCalls() []struct {
+	Ctx                     context.Context
+	ClusterTemplateRevision provisioning.ClusterTemplateRevision
+} {
+	var calls []struct {
+		Ctx                     context.Context
+		ClusterTemplateRevision provisioning.ClusterTemplateRevision
+	}
+	mock.lockCreateRevision.RLock()
+	calls = mock.calls.CreateRevision
+	mock.lockCreateRevision.RUnlock()
+	return calls
+}
+
 // DeleteByName calls DeleteByNameFunc.
 func (mock *ClusterConfigTemplateRepoMock) DeleteByName(ctx context.Context, name string) error {
 	if mock.DeleteByNameFunc == nil {
@@ -301,6 +381,82 @@ func (mock *ClusterConfigTemplateRepoMock) GetByNameCalls() []struct {
 	return calls
 }
 
+// GetRevision calls GetRevisionFunc.
+func (mock *ClusterConfigTemplateRepoMock) GetRevision(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+	if mock.GetRevisionFunc == nil {
+		panic("ClusterConfigTemplateRepoMock.GetRevisionFunc: method is nil but ClusterConfigTemplateRepo.GetRevision was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Name     string
+		Revision int64
+	}{
+		Ctx:      ctx,
+		Name:     name,
+		Revision: revision,
+	}
+	mock.lockGetRevision.Lock()
+	mock.calls.GetRevision = append(mock.calls.GetRevision, callInfo)
+	mock.lockGetRevision.Unlock()
+	return mock.GetRevisionFunc(ctx, name, revision)
+}
+
+// GetRevisionCalls gets all the calls that were made to GetRevision.
+// Check the length with:
+//
+//	len(mockedClusterConfigTemplateRepo.GetRevisionCalls())
+func (mock *ClusterConfigTemplateRepoMock) GetRevisionCalls() []struct {
+	Ctx      context.Context
+	Name     string
+	Revision int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Name     string
+		Revision int64
+	}
+	mock.lockGetRevision.RLock()
+	calls = mock.calls.GetRevision
+	mock.lockGetRevision.RUnlock()
+	return calls
+}
+
+// GetRevisionAll calls GetRevisionAllFunc.
+func (mock *ClusterConfigTemplateRepoMock) GetRevisionAll(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+	if mock.GetRevisionAllFunc == nil {
+		panic("ClusterConfigTemplateRepoMock.GetRevisionAllFunc: method is nil but ClusterConfigTemplateRepo.GetRevisionAll was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetRevisionAll.Lock()
+	mock.calls.GetRevisionAll = append(mock.calls.GetRevisionAll, callInfo)
+	mock.lockGetRevisionAll.Unlock()
+	return mock.GetRevisionAllFunc(ctx, name)
+}
+
+// GetRevisionAllCalls gets all the calls that were made to GetRevisionAll.
+// Check the length with:
+//
+//	len(mockedClusterConfigTemplateRepo.GetRevisionAllCalls())
+func (mock *ClusterConfigTemplateRepoMock) GetRevisionAllCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetRevisionAll.RLock()
+	calls = mock.calls.GetRevisionAll
+	mock.lockGetRevisionAll.RUnlock()
+	return calls
+}
+
 // Rename calls RenameFunc.
 func (mock *ClusterConfigTemplateRepoMock) Rename(ctx context.Context, oldName string, newName string) error {
 	if mock.RenameFunc == nil {
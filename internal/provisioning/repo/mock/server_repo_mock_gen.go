@@ -7,6 +7,9 @@ package mock
 import (
 	"context"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/FuturFusion/operations-center/internal/provisioning"
 )
@@ -24,9 +27,24 @@ var _ provisioning.ServerRepo = &ServerRepoMock{}
 //			CreateFunc: func(ctx context.Context, server provisioning.Server) (int64, error) {
 //				panic("mock out the Create method")
 //			},
+//			CreateDecommissionFunc: func(ctx context.Context, decommission provisioning.ServerDecommission) (int64, error) {
+//				panic("mock out the CreateDecommission method")
+//			},
+//			CreateDiskHealthSampleFunc: func(ctx context.Context, sample provisioning.ServerDiskHealthSample) (int64, error) {
+//				panic("mock out the CreateDiskHealthSample method")
+//			},
+//			CreateHardwareSnapshotFunc: func(ctx context.Context, snapshot provisioning.ServerHardwareSnapshot) (int64, error) {
+//				panic("mock out the CreateHardwareSnapshot method")
+//			},
 //			DeleteByNameFunc: func(ctx context.Context, name string) error {
 //				panic("mock out the DeleteByName method")
 //			},
+//			DeleteDiskHealthSamplesRecordedBeforeByNameFunc: func(ctx context.Context, name string, before time.Time) error {
+//				panic("mock out the DeleteDiskHealthSamplesRecordedBeforeByName method")
+//			},
+//			DeleteHardwareSnapshotsExceptLatestByNameFunc: func(ctx context.Context, name string, keep int) error {
+//				panic("mock out the DeleteHardwareSnapshotsExceptLatestByName method")
+//			},
 //			GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
 //				panic("mock out the GetAll method")
 //			},
@@ -51,12 +69,30 @@ var _ provisioning.ServerRepo = &ServerRepoMock{}
 //			GetBySystemUUIDFunc: func(ctx context.Context, systemUUID string) (*provisioning.Server, error) {
 //				panic("mock out the GetBySystemUUID method")
 //			},
+//			GetDecommissionByUUIDFunc: func(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+//				panic("mock out the GetDecommissionByUUID method")
+//			},
+//			GetDecommissionsFunc: func(ctx context.Context) (provisioning.ServerDecommissions, error) {
+//				panic("mock out the GetDecommissions method")
+//			},
+//			GetDecommissionsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+//				panic("mock out the GetDecommissionsByName method")
+//			},
+//			GetDiskHealthSamplesByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error) {
+//				panic("mock out the GetDiskHealthSamplesByName method")
+//			},
+//			GetHardwareSnapshotsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+//				panic("mock out the GetHardwareSnapshotsByName method")
+//			},
 //			RenameFunc: func(ctx context.Context, oldName string, newName string) error {
 //				panic("mock out the Rename method")
 //			},
 //			UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
 //				panic("mock out the Update method")
 //			},
+//			UpdateDecommissionFunc: func(ctx context.Context, decommission provisioning.ServerDecommission) error {
+//				panic("mock out the UpdateDecommission method")
+//			},
 //		}
 //
 //		// use mockedServerRepo in code that requires provisioning.ServerRepo
@@ -67,9 +103,24 @@ type ServerRepoMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, server provisioning.Server) (int64, error)
 
+	// CreateDecommissionFunc mocks the CreateDecommission method.
+	CreateDecommissionFunc func(ctx context.Context, decommission provisioning.ServerDecommission) (int64, error)
+
+	// CreateDiskHealthSampleFunc mocks the CreateDiskHealthSample method.
+	CreateDiskHealthSampleFunc func(ctx context.Context, sample provisioning.ServerDiskHealthSample) (int64, error)
+
+	// CreateHardwareSnapshotFunc mocks the CreateHardwareSnapshot method.
+	CreateHardwareSnapshotFunc func(ctx context.Context, snapshot provisioning.ServerHardwareSnapshot) (int64, error)
+
 	// DeleteByNameFunc mocks the DeleteByName method.
 	DeleteByNameFunc func(ctx context.Context, name string) error
 
+	// DeleteDiskHealthSamplesRecordedBeforeByNameFunc mocks the DeleteDiskHealthSamplesRecordedBeforeByName method.
+	DeleteDiskHealthSamplesRecordedBeforeByNameFunc func(ctx context.Context, name string, before time.Time) error
+
+	// DeleteHardwareSnapshotsExceptLatestByNameFunc mocks the DeleteHardwareSnapshotsExceptLatestByName method.
+	DeleteHardwareSnapshotsExceptLatestByNameFunc func(ctx context.Context, name string, keep int) error
+
 	// GetAllFunc mocks the GetAll method.
 	GetAllFunc func(ctx context.Context) (provisioning.Servers, error)
 
@@ -94,12 +145,30 @@ type ServerRepoMock struct {
 	// GetBySystemUUIDFunc mocks the GetBySystemUUID method.
 	GetBySystemUUIDFunc func(ctx context.Context, systemUUID string) (*provisioning.Server, error)
 
+	// GetDecommissionByUUIDFunc mocks the GetDecommissionByUUID method.
+	GetDecommissionByUUIDFunc func(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error)
+
+	// GetDecommissionsFunc mocks the GetDecommissions method.
+	GetDecommissionsFunc func(ctx context.Context) (provisioning.ServerDecommissions, error)
+
+	// GetDecommissionsByNameFunc mocks the GetDecommissionsByName method.
+	GetDecommissionsByNameFunc func(ctx context.Context, name string) (provisioning.ServerDecommissions, error)
+
+	// GetDiskHealthSamplesByNameFunc mocks the GetDiskHealthSamplesByName method.
+	GetDiskHealthSamplesByNameFunc func(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error)
+
+	// GetHardwareSnapshotsByNameFunc mocks the GetHardwareSnapshotsByName method.
+	GetHardwareSnapshotsByNameFunc func(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error)
+
 	// RenameFunc mocks the Rename method.
 	RenameFunc func(ctx context.Context, oldName string, newName string) error
 
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, server provisioning.Server) error
 
+	// UpdateDecommissionFunc mocks the UpdateDecommission method.
+	UpdateDecommissionFunc func(ctx context.Context, decommission provisioning.ServerDecommission) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Create holds details about calls to the Create method.
@@ -109,6 +178,27 @@ type ServerRepoMock struct {
 			// Server is the server argument value.
 			Server provisioning.Server
 		}
+		// CreateDecommission holds details about calls to the CreateDecommission method.
+		CreateDecommission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Decommission is the decommission argument value.
+			Decommission provisioning.ServerDecommission
+		}
+		// CreateDiskHealthSample holds details about calls to the CreateDiskHealthSample method.
+		CreateDiskHealthSample []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Sample is the sample argument value.
+			Sample provisioning.ServerDiskHealthSample
+		}
+		// CreateHardwareSnapshot holds details about calls to the CreateHardwareSnapshot method.
+		CreateHardwareSnapshot []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Snapshot is the snapshot argument value.
+			Snapshot provisioning.ServerHardwareSnapshot
+		}
 		// DeleteByName holds details about calls to the DeleteByName method.
 		DeleteByName []struct {
 			// Ctx is the ctx argument value.
@@ -116,6 +206,24 @@ type ServerRepoMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// DeleteDiskHealthSamplesRecordedBeforeByName holds details about calls to the DeleteDiskHealthSamplesRecordedBeforeByName method.
+		DeleteDiskHealthSamplesRecordedBeforeByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// DeleteHardwareSnapshotsExceptLatestByName holds details about calls to the DeleteHardwareSnapshotsExceptLatestByName method.
+		DeleteHardwareSnapshotsExceptLatestByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Keep is the keep argument value.
+			Keep int
+		}
 		// GetAll holds details about calls to the GetAll method.
 		GetAll []struct {
 			// Ctx is the ctx argument value.
@@ -168,6 +276,39 @@ type ServerRepoMock struct {
 			// SystemUUID is the systemUUID argument value.
 			SystemUUID string
 		}
+		// GetDecommissionByUUID holds details about calls to the GetDecommissionByUUID method.
+		GetDecommissionByUUID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id uuid.UUID
+		}
+		// GetDecommissions holds details about calls to the GetDecommissions method.
+		GetDecommissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetDecommissionsByName holds details about calls to the GetDecommissionsByName method.
+		GetDecommissionsByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetDiskHealthSamplesByName holds details about calls to the GetDiskHealthSamplesByName method.
+		GetDiskHealthSamplesByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetHardwareSnapshotsByName holds details about calls to the GetHardwareSnapshotsByName method.
+		GetHardwareSnapshotsByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// Rename holds details about calls to the Rename method.
 		Rename []struct {
 			// Ctx is the ctx argument value.
@@ -184,19 +325,37 @@ type ServerRepoMock struct {
 			// Server is the server argument value.
 			Server provisioning.Server
 		}
+		// UpdateDecommission holds details about calls to the UpdateDecommission method.
+		UpdateDecommission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Decommission is the decommission argument value.
+			Decommission provisioning.ServerDecommission
+		}
 	}
-	lockCreate                sync.RWMutex
-	lockDeleteByName          sync.RWMutex
-	lockGetAll                sync.RWMutex
-	lockGetAllNames           sync.RWMutex
-	lockGetAllNamesWithFilter sync.RWMutex
-	lockGetAllWithFilter      sync.RWMutex
-	lockGetByCertificate      sync.RWMutex
-	lockGetByMachineID        sync.RWMutex
-	lockGetByName             sync.RWMutex
-	lockGetBySystemUUID       sync.RWMutex
-	lockRename                sync.RWMutex
-	lockUpdate                sync.RWMutex
+	lockCreate                                      sync.RWMutex
+	lockCreateDecommission                          sync.RWMutex
+	lockCreateDiskHealthSample                      sync.RWMutex
+	lockCreateHardwareSnapshot                      sync.RWMutex
+	lockDeleteByName                                sync.RWMutex
+	lockDeleteDiskHealthSamplesRecordedBeforeByName sync.RWMutex
+	lockDeleteHardwareSnapshotsExceptLatestByName   sync.RWMutex
+	lockGetAll                                      sync.RWMutex
+	lockGetAllNames                                 sync.RWMutex
+	lockGetAllNamesWithFilter                       sync.RWMutex
+	lockGetAllWithFilter                            sync.RWMutex
+	lockGetByCertificate                            sync.RWMutex
+	lockGetByMachineID                              sync.RWMutex
+	lockGetByName                                   sync.RWMutex
+	lockGetBySystemUUID                             sync.RWMutex
+	lockGetDecommissionByUUID                       sync.RWMutex
+	lockGetDecommissions                            sync.RWMutex
+	lockGetDecommissionsByName                      sync.RWMutex
+	lockGetDiskHealthSamplesByName                  sync.RWMutex
+	lockGetHardwareSnapshotsByName                  sync.RWMutex
+	lockRename                                      sync.RWMutex
+	lockUpdate                                      sync.RWMutex
+	lockUpdateDecommission                          sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -235,6 +394,114 @@ func (mock *ServerRepoMock) CreateCalls() []struct {
 	return calls
 }
 
+// CreateDecommission calls CreateDecommissionFunc.
+func (mock *ServerRepoMock) CreateDecommission(ctx context.Context, decommission provisioning.ServerDecommission) (int64, error) {
+	if mock.CreateDecommissionFunc == nil {
+		panic("ServerRepoMock.CreateDecommissionFunc: method is nil but ServerRepo.CreateDecommission was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Decommission provisioning.ServerDecommission
+	}{
+		Ctx:          ctx,
+		Decommission: decommission,
+	}
+	mock.lockCreateDecommission.Lock()
+	mock.calls.CreateDecommission = append(mock.calls.CreateDecommission, callInfo)
+	mock.lockCreateDecommission.Unlock()
+	return mock.CreateDecommissionFunc(ctx, decommission)
+}
+
+// CreateDecommissionCalls gets all the calls that were made to CreateDecommission.
+// Check the length with:
+//
+//	len(mockedServerRepo.CreateDecommissionCalls())
+func (mock *ServerRepoMock) CreateDecommissionCalls() []struct {
+	Ctx          context.Context
+	Decommission provisioning.ServerDecommission
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Decommission provisioning.ServerDecommission
+	}
+	mock.lockCreateDecommission.RLock()
+	calls = mock.calls.CreateDecommission
+	mock.lockCreateDecommission.RUnlock()
+	return calls
+}
+
+// CreateDiskHealthSample calls CreateDiskHealthSampleFunc.
+func (mock *ServerRepoMock) CreateDiskHealthSample(ctx context.Context, sample provisioning.ServerDiskHealthSample) (int64, error) {
+	if mock.CreateDiskHealthSampleFunc == nil {
+		panic("ServerRepoMock.CreateDiskHealthSampleFunc: method is nil but ServerRepo.CreateDiskHealthSample was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Sample provisioning.ServerDiskHealthSample
+	}{
+		Ctx:    ctx,
+		Sample: sample,
+	}
+	mock.lockCreateDiskHealthSample.Lock()
+	mock.calls.CreateDiskHealthSample = append(mock.calls.CreateDiskHealthSample, callInfo)
+	mock.lockCreateDiskHealthSample.Unlock()
+	return mock.CreateDiskHealthSampleFunc(ctx, sample)
+}
+
+// CreateDiskHealthSampleCalls gets all the calls that were made to CreateDiskHealthSample.
+// Check the length with:
+//
+//	len(mockedServerRepo.CreateDiskHealthSampleCalls())
+func (mock *ServerRepoMock) CreateDiskHealthSampleCalls() []struct {
+	Ctx    context.Context
+	Sample provisioning.ServerDiskHealthSample
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Sample provisioning.ServerDiskHealthSample
+	}
+	mock.lockCreateDiskHealthSample.RLock()
+	calls = mock.calls.CreateDiskHealthSample
+	mock.lockCreateDiskHealthSample.RUnlock()
+	return calls
+}
+
+// CreateHardwareSnapshot calls CreateHardwareSnapshotFunc.
+func (mock *ServerRepoMock) CreateHardwareSnapshot(ctx context.Context, snapshot provisioning.ServerHardwareSnapshot) (int64, error) {
+	if mock.CreateHardwareSnapshotFunc == nil {
+		panic("ServerRepoMock.CreateHardwareSnapshotFunc: method is nil but ServerRepo.CreateHardwareSnapshot was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Snapshot provisioning.ServerHardwareSnapshot
+	}{
+		Ctx:      ctx,
+		Snapshot: snapshot,
+	}
+	mock.lockCreateHardwareSnapshot.Lock()
+	mock.calls.CreateHardwareSnapshot = append(mock.calls.CreateHardwareSnapshot, callInfo)
+	mock.lockCreateHardwareSnapshot.Unlock()
+	return mock.CreateHardwareSnapshotFunc(ctx, snapshot)
+}
+
+// CreateHardwareSnapshotCalls gets all the calls that were made to CreateHardwareSnapshot.
+// Check the length with:
+//
+//	len(mockedServerRepo.CreateHardwareSnapshotCalls())
+func (mock *ServerRepoMock) CreateHardwareSnapshotCalls() []struct {
+	Ctx      context.Context
+	Snapshot provisioning.ServerHardwareSnapshot
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Snapshot provisioning.ServerHardwareSnapshot
+	}
+	mock.lockCreateHardwareSnapshot.RLock()
+	calls = mock.calls.CreateHardwareSnapshot
+	mock.lockCreateHardwareSnapshot.RUnlock()
+	return calls
+}
+
 // DeleteByName calls DeleteByNameFunc.
 func (mock *ServerRepoMock) DeleteByName(ctx context.Context, name string) error {
 	if mock.DeleteByNameFunc == nil {
@@ -271,6 +538,86 @@ func (mock *ServerRepoMock) DeleteByNameCalls() []struct {
 	return calls
 }
 
+// DeleteDiskHealthSamplesRecordedBeforeByName calls DeleteDiskHealthSamplesRecordedBeforeByNameFunc.
+func (mock *ServerRepoMock) DeleteDiskHealthSamplesRecordedBeforeByName(ctx context.Context, name string, before time.Time) error {
+	if mock.DeleteDiskHealthSamplesRecordedBeforeByNameFunc == nil {
+		panic("ServerRepoMock.DeleteDiskHealthSamplesRecordedBeforeByNameFunc: method is nil but ServerRepo.DeleteDiskHealthSamplesRecordedBeforeByName was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Name   string
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Name:   name,
+		Before: before,
+	}
+	mock.lockDeleteDiskHealthSamplesRecordedBeforeByName.Lock()
+	mock.calls.DeleteDiskHealthSamplesRecordedBeforeByName = append(mock.calls.DeleteDiskHealthSamplesRecordedBeforeByName, callInfo)
+	mock.lockDeleteDiskHealthSamplesRecordedBeforeByName.Unlock()
+	return mock.DeleteDiskHealthSamplesRecordedBeforeByNameFunc(ctx, name, before)
+}
+
+// DeleteDiskHealthSamplesRecordedBeforeByNameCalls gets all the calls that were made to DeleteDiskHealthSamplesRecordedBeforeByName.
+// Check the length with:
+//
+//	len(mockedServerRepo.DeleteDiskHealthSamplesRecordedBeforeByNameCalls())
+func (mock *ServerRepoMock) DeleteDiskHealthSamplesRecordedBeforeByNameCalls() []struct {
+	Ctx    context.Context
+	Name   string
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Name   string
+		Before time.Time
+	}
+	mock.lockDeleteDiskHealthSamplesRecordedBeforeByName.RLock()
+	calls = mock.calls.DeleteDiskHealthSamplesRecordedBeforeByName
+	mock.lockDeleteDiskHealthSamplesRecordedBeforeByName.RUnlock()
+	return calls
+}
+
+// DeleteHardwareSnapshotsExceptLatestByName calls DeleteHardwareSnapshotsExceptLatestByNameFunc.
+func (mock *ServerRepoMock) DeleteHardwareSnapshotsExceptLatestByName(ctx context.Context, name string, keep int) error {
+	if mock.DeleteHardwareSnapshotsExceptLatestByNameFunc == nil {
+		panic("ServerRepoMock.DeleteHardwareSnapshotsExceptLatestByNameFunc: method is nil but ServerRepo.DeleteHardwareSnapshotsExceptLatestByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		Keep int
+	}{
+		Ctx:  ctx,
+		Name: name,
+		Keep: keep,
+	}
+	mock.lockDeleteHardwareSnapshotsExceptLatestByName.Lock()
+	mock.calls.DeleteHardwareSnapshotsExceptLatestByName = append(mock.calls.DeleteHardwareSnapshotsExceptLatestByName, callInfo)
+	mock.lockDeleteHardwareSnapshotsExceptLatestByName.Unlock()
+	return mock.DeleteHardwareSnapshotsExceptLatestByNameFunc(ctx, name, keep)
+}
+
+// DeleteHardwareSnapshotsExceptLatestByNameCalls gets all the calls that were made to DeleteHardwareSnapshotsExceptLatestByName.
+// Check the length with:
+//
+//	len(mockedServerRepo.DeleteHardwareSnapshotsExceptLatestByNameCalls())
+func (mock *ServerRepoMock) DeleteHardwareSnapshotsExceptLatestByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+	Keep int
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		Keep int
+	}
+	mock.lockDeleteHardwareSnapshotsExceptLatestByName.RLock()
+	calls = mock.calls.DeleteHardwareSnapshotsExceptLatestByName
+	mock.lockDeleteHardwareSnapshotsExceptLatestByName.RUnlock()
+	return calls
+}
+
 // GetAll calls GetAllFunc.
 func (mock *ServerRepoMock) GetAll(ctx context.Context) (provisioning.Servers, error) {
 	if mock.GetAllFunc == nil {
@@ -551,6 +898,182 @@ func (mock *ServerRepoMock) GetBySystemUUIDCalls() []struct {
 	return calls
 }
 
+// GetDecommissionByUUID calls GetDecommissionByUUIDFunc.
+func (mock *ServerRepoMock) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+	if mock.GetDecommissionByUUIDFunc == nil {
+		panic("ServerRepoMock.GetDecommissionByUUIDFunc: method is nil but ServerRepo.GetDecommissionByUUID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  uuid.UUID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockGetDecommissionByUUID.Lock()
+	mock.calls.GetDecommissionByUUID = append(mock.calls.GetDecommissionByUUID, callInfo)
+	mock.lockGetDecommissionByUUID.Unlock()
+	return mock.GetDecommissionByUUIDFunc(ctx, id)
+}
+
+// GetDecommissionByUUIDCalls gets all the calls that were made to GetDecommissionByUUID.
+// Check the length with:
+//
+//	len(mockedServerRepo.GetDecommissionByUUIDCalls())
+func (mock *ServerRepoMock) GetDecommissionByUUIDCalls() []struct {
+	Ctx context.Context
+	Id  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  uuid.UUID
+	}
+	mock.lockGetDecommissionByUUID.RLock()
+	calls = mock.calls.GetDecommissionByUUID
+	mock.lockGetDecommissionByUUID.RUnlock()
+	return calls
+}
+
+// GetDecommissions calls GetDecommissionsFunc.
+func (mock *ServerRepoMock) GetDecommissions(ctx context.Context) (provisioning.ServerDecommissions, error) {
+	if mock.GetDecommissionsFunc == nil {
+		panic("ServerRepoMock.GetDecommissionsFunc: method is nil but ServerRepo.GetDecommissions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetDecommissions.Lock()
+	mock.calls.GetDecommissions = append(mock.calls.GetDecommissions, callInfo)
+	mock.lockGetDecommissions.Unlock()
+	return mock.GetDecommissionsFunc(ctx)
+}
+
+// GetDecommissionsCalls gets all the calls that were made to GetDecommissions.
+// Check the length with:
+//
+//	len(mockedServerRepo.GetDecommissionsCalls())
+func (mock *ServerRepoMock) GetDecommissionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetDecommissions.RLock()
+	calls = mock.calls.GetDecommissions
+	mock.lockGetDecommissions.RUnlock()
+	return calls
+}
+
+// GetDecommissionsByName calls GetDecommissionsByNameFunc.
+func (mock *ServerRepoMock) GetDecommissionsByName(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+	if mock.GetDecommissionsByNameFunc == nil {
+		panic("ServerRepoMock.GetDecommissionsByNameFunc: method is nil but ServerRepo.GetDecommissionsByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetDecommissionsByName.Lock()
+	mock.calls.GetDecommissionsByName = append(mock.calls.GetDecommissionsByName, callInfo)
+	mock.lockGetDecommissionsByName.Unlock()
+	return mock.GetDecommissionsByNameFunc(ctx, name)
+}
+
+// GetDecommissionsByNameCalls gets all the calls that were made to GetDecommissionsByName.
+// Check the length with:
+//
+//	len(mockedServerRepo.GetDecommissionsByNameCalls())
+func (mock *ServerRepoMock) GetDecommissionsByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetDecommissionsByName.RLock()
+	calls = mock.calls.GetDecommissionsByName
+	mock.lockGetDecommissionsByName.RUnlock()
+	return calls
+}
+
+// GetDiskHealthSamplesByName calls GetDiskHealthSamplesByNameFunc.
+func (mock *ServerRepoMock) GetDiskHealthSamplesByName(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error) {
+	if mock.GetDiskHealthSamplesByNameFunc == nil {
+		panic("ServerRepoMock.GetDiskHealthSamplesByNameFunc: method is nil but ServerRepo.GetDiskHealthSamplesByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetDiskHealthSamplesByName.Lock()
+	mock.calls.GetDiskHealthSamplesByName = append(mock.calls.GetDiskHealthSamplesByName, callInfo)
+	mock.lockGetDiskHealthSamplesByName.Unlock()
+	return mock.GetDiskHealthSamplesByNameFunc(ctx, name)
+}
+
+// GetDiskHealthSamplesByNameCalls gets all the calls that were made to GetDiskHealthSamplesByName.
+// Check the length with:
+//
+//	len(mockedServerRepo.GetDiskHealthSamplesByNameCalls())
+func (mock *ServerRepoMock) GetDiskHealthSamplesByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetDiskHealthSamplesByName.RLock()
+	calls = mock.calls.GetDiskHealthSamplesByName
+	mock.lockGetDiskHealthSamplesByName.RUnlock()
+	return calls
+}
+
+// GetHardwareSnapshotsByName calls GetHardwareSnapshotsByNameFunc.
+func (mock *ServerRepoMock) GetHardwareSnapshotsByName(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+	if mock.GetHardwareSnapshotsByNameFunc == nil {
+		panic("ServerRepoMock.GetHardwareSnapshotsByNameFunc: method is nil but ServerRepo.GetHardwareSnapshotsByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetHardwareSnapshotsByName.Lock()
+	mock.calls.GetHardwareSnapshotsByName = append(mock.calls.GetHardwareSnapshotsByName, callInfo)
+	mock.lockGetHardwareSnapshotsByName.Unlock()
+	return mock.GetHardwareSnapshotsByNameFunc(ctx, name)
+}
+
+// GetHardwareSnapshotsByNameCalls gets all the calls that were made to GetHardwareSnapshotsByName.
+// Check the length with:
+//
+//	len(mockedServerRepo.GetHardwareSnapshotsByNameCalls())
+func (mock *ServerRepoMock) GetHardwareSnapshotsByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetHardwareSnapshotsByName.RLock()
+	calls = mock.calls.GetHardwareSnapshotsByName
+	mock.lockGetHardwareSnapshotsByName.RUnlock()
+	return calls
+}
+
 // Rename calls RenameFunc.
 func (mock *ServerRepoMock) Rename(ctx context.Context, oldName string, newName string) error {
 	if mock.RenameFunc == nil {
@@ -626,3 +1149,39 @@ func (mock *ServerRepoMock) UpdateCalls() []struct {
 	mock.lockUpdate.RUnlock()
 	return calls
 }
+
+// UpdateDecommission calls UpdateDecommissionFunc.
+func (mock *ServerRepoMock) UpdateDecommission(ctx context.Context, decommission provisioning.ServerDecommission) error {
+	if mock.UpdateDecommissionFunc == nil {
+		panic("ServerRepoMock.UpdateDecommissionFunc: method is nil but ServerRepo.UpdateDecommission was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Decommission provisioning.ServerDecommission
+	}{
+		Ctx:          ctx,
+		Decommission: decommission,
+	}
+	mock.lockUpdateDecommission.Lock()
+	mock.calls.UpdateDecommission = append(mock.calls.UpdateDecommission, callInfo)
+	mock.lockUpdateDecommission.Unlock()
+	return mock.UpdateDecommissionFunc(ctx, decommission)
+}
+
+// UpdateDecommissionCalls gets all the calls that were made to UpdateDecommission.
+// Check the length with:
+//
+//	len(mockedServerRepo.UpdateDecommissionCalls())
+func (mock *ServerRepoMock) UpdateDecommissionCalls() []struct {
+	Ctx          context.Context
+	Decommission provisioning.ServerDecommission
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Decommission provisioning.ServerDecommission
+	}
+	mock.lockUpdateDecommission.RLock()
+	calls = mock.calls.UpdateDecommission
+	mock.lockUpdateDecommission.RUnlock()
+	return calls
+}
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+)
+
+type clusterBlueprint struct {
+	db sqlite.DBTX
+}
+
+var _ provisioning.ClusterBlueprintRepo = &clusterBlueprint{}
+
+func NewClusterBlueprint(db sqlite.DBTX) *clusterBlueprint {
+	return &clusterBlueprint{
+		db: db,
+	}
+}
+
+func (r clusterBlueprint) Create(ctx context.Context, in provisioning.ClusterBlueprint) (int64, error) {
+	return entities.CreateClusterBlueprint(ctx, transaction.GetDBTX(ctx, r.db), in)
+}
+
+func (r clusterBlueprint) GetAll(ctx context.Context) (provisioning.ClusterBlueprints, error) {
+	return entities.GetClusterBlueprints(ctx, transaction.GetDBTX(ctx, r.db))
+}
+
+func (r clusterBlueprint) GetAllNames(ctx context.Context) ([]string, error) {
+	return entities.GetClusterBlueprintNames(ctx, transaction.GetDBTX(ctx, r.db))
+}
+
+func (r clusterBlueprint) GetByName(ctx context.Context, name string) (*provisioning.ClusterBlueprint, error) {
+	return entities.GetClusterBlueprint(ctx, transaction.GetDBTX(ctx, r.db), name)
+}
+
+func (r clusterBlueprint) Update(ctx context.Context, in provisioning.ClusterBlueprint) error {
+	return transaction.ForceTx(ctx, transaction.GetDBTX(ctx, r.db), func(ctx context.Context, tx transaction.TX) error {
+		return entities.UpdateClusterBlueprint(ctx, tx, in.Name, in)
+	})
+}
+
+func (r clusterBlueprint) DeleteByName(ctx context.Context, name string) error {
+	return entities.DeleteClusterBlueprint(ctx, transaction.GetDBTX(ctx, r.db), name)
+}
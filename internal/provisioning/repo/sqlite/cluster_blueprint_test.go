@@ -0,0 +1,155 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/dbschema"
+	dbdriver "github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestClusterBlueprintDatabaseActions(t *testing.T) {
+	clusterBlueprintA := provisioning.ClusterBlueprint{
+		Name:            "A",
+		Description:     "A",
+		ClusterTemplate: "template",
+		ClusterTemplateVariableValues: api.ConfigMap{
+			"MGMT_VLAN": "100",
+		},
+		ServerFilter:  `properties.site_code == "zrh01"`,
+		MemberCount:   3,
+		ConnectionURL: "https://zrh01.local:8443",
+		ServerType:    api.ServerTypeIncus,
+		Channel:       "stable",
+		Status:        api.ClusterBlueprintStatusWaiting,
+		ServerNames:   provisioning.ClusterBlueprintServerNames{},
+	}
+
+	clusterBlueprintB := provisioning.ClusterBlueprint{
+		Name:                          "B",
+		ClusterTemplate:               "template",
+		ClusterTemplateVariableValues: api.ConfigMap{},
+		ServerFilter:                  `true`,
+		MemberCount:                   1,
+		ConnectionURL:                 "https://b.local:8443",
+		ServerType:                    api.ServerTypeIncus,
+		Channel:                       "stable",
+		Status:                        api.ClusterBlueprintStatusFailed,
+		ServerNames:                   provisioning.ClusterBlueprintServerNames{"one"},
+		Error:                         "boom",
+	}
+
+	ctx := context.Background()
+
+	// Create a new temporary database.
+	tmpDir := t.TempDir()
+	db, err := dbdriver.Open(tmpDir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = db.Close()
+		require.NoError(t, err)
+	})
+
+	_, err = dbschema.Ensure(ctx, db, tmpDir)
+	require.NoError(t, err)
+
+	tx := transaction.Enable(db)
+	entities.PreparedStmts, err = entities.PrepareStmts(tx, false)
+	require.NoError(t, err)
+
+	clusterTemplate := sqlite.NewClusterTemplate(tx)
+	clusterBlueprint := sqlite.NewClusterBlueprint(tx)
+
+	// Can't add a cluster blueprint referencing a cluster template, that doesn't exist.
+	_, err = clusterBlueprint.Create(ctx, clusterBlueprintA)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+
+	_, err = clusterTemplate.Create(ctx, provisioning.ClusterTemplate{
+		Name:                      "template",
+		ServiceConfigTemplate:     `{}`,
+		ApplicationConfigTemplate: `{}`,
+		Variables:                 api.ClusterTemplateVariables{},
+		Revision:                  1,
+	})
+	require.NoError(t, err)
+
+	// Can't add a cluster blueprint referencing a channel, that doesn't exist.
+	clusterBlueprintInvalidChannel := clusterBlueprintB
+	clusterBlueprintInvalidChannel.Channel = "invalid"
+	_, err = clusterBlueprint.Create(ctx, clusterBlueprintInvalidChannel)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+
+	// Add cluster blueprints
+	_, err = clusterBlueprint.Create(ctx, clusterBlueprintA)
+	require.NoError(t, err)
+	_, err = clusterBlueprint.Create(ctx, clusterBlueprintB)
+	require.NoError(t, err)
+
+	// Ensure we have two entries
+	clusterBlueprints, err := clusterBlueprint.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, clusterBlueprints, 2)
+
+	clusterBlueprintNames, err := clusterBlueprint.GetAllNames(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"A", "B"}, clusterBlueprintNames)
+
+	// Should get back clusterBlueprintA unchanged.
+	dbClusterBlueprintA, err := clusterBlueprint.GetByName(ctx, clusterBlueprintA.Name)
+	require.NoError(t, err)
+	clusterBlueprintA.ID = dbClusterBlueprintA.ID
+	clusterBlueprintA.LastUpdated = dbClusterBlueprintA.LastUpdated
+	require.Equal(t, clusterBlueprintA, *dbClusterBlueprintA)
+
+	dbClusterBlueprintB, err := clusterBlueprint.GetByName(ctx, clusterBlueprintB.Name)
+	require.NoError(t, err)
+	clusterBlueprintB.ID = dbClusterBlueprintB.ID
+	clusterBlueprintB.LastUpdated = dbClusterBlueprintB.LastUpdated
+	require.Equal(t, clusterBlueprintB, *dbClusterBlueprintB)
+
+	// Test updating a cluster blueprint.
+	clusterBlueprintA.Status = api.ClusterBlueprintStatusForming
+	clusterBlueprintA.ServerNames = provisioning.ClusterBlueprintServerNames{"one", "two", "three"}
+	err = clusterBlueprint.Update(ctx, clusterBlueprintA)
+	require.NoError(t, err)
+	dbClusterBlueprintA, err = clusterBlueprint.GetByName(ctx, clusterBlueprintA.Name)
+	require.NoError(t, err)
+	clusterBlueprintA.LastUpdated = dbClusterBlueprintA.LastUpdated
+	require.Equal(t, clusterBlueprintA, *dbClusterBlueprintA)
+
+	// Can't delete a cluster template referenced by a cluster blueprint.
+	err = clusterTemplate.DeleteByName(ctx, "template")
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+
+	// Delete a cluster blueprint.
+	err = clusterBlueprint.DeleteByName(ctx, clusterBlueprintA.Name)
+	require.NoError(t, err)
+	_, err = clusterBlueprint.GetByName(ctx, clusterBlueprintA.Name)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Should have one cluster blueprint remaining.
+	clusterBlueprints, err = clusterBlueprint.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, clusterBlueprints, 1)
+
+	// Can't delete a cluster blueprint that doesn't exist.
+	err = clusterBlueprint.DeleteByName(ctx, "three")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't update a cluster blueprint that doesn't exist.
+	err = clusterBlueprint.Update(ctx, clusterBlueprintA)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't add a duplicate cluster blueprint.
+	_, err = clusterBlueprint.Create(ctx, clusterBlueprintB)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+}
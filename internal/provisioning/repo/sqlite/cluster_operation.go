@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+)
+
+type clusterOperation struct {
+	db sqlite.DBTX
+}
+
+var _ provisioning.ClusterOperationRepo = &clusterOperation{}
+
+func NewClusterOperation(db sqlite.DBTX) *clusterOperation {
+	return &clusterOperation{
+		db: db,
+	}
+}
+
+func (c clusterOperation) Create(ctx context.Context, in provisioning.ClusterOperation) (int64, error) {
+	return entities.CreateClusterOperation(ctx, transaction.GetDBTX(ctx, c.db), in)
+}
+
+func (c clusterOperation) GetAllWithFilter(ctx context.Context, filter provisioning.ClusterOperationFilter) (provisioning.ClusterOperations, error) {
+	return entities.GetClusterOperations(ctx, transaction.GetDBTX(ctx, c.db), filter)
+}
+
+func (c clusterOperation) Update(ctx context.Context, in provisioning.ClusterOperation) error {
+	return transaction.ForceTx(ctx, transaction.GetDBTX(ctx, c.db), func(ctx context.Context, tx transaction.TX) error {
+		return entities.UpdateClusterOperation(ctx, tx, in.UUID, in)
+	})
+}
+
+func (c clusterOperation) DeleteExceptLatestByCluster(ctx context.Context, clusterName string, keep int) error {
+	return entities.DeleteClusterOperationsExceptLatest(ctx, transaction.GetDBTX(ctx, c.db), clusterName, keep)
+}
+
+func (c clusterOperation) CreateEvent(ctx context.Context, in provisioning.ClusterOperationEvent) (int64, error) {
+	return entities.CreateClusterOperationEvent(ctx, transaction.GetDBTX(ctx, c.db), in)
+}
+
+func (c clusterOperation) GetEventAll(ctx context.Context, operationUUID uuid.UUID) (provisioning.ClusterOperationEvents, error) {
+	return entities.GetClusterOperationEvents(ctx, transaction.GetDBTX(ctx, c.db), entities.ClusterOperationEventFilter{
+		Operation: &operationUUID,
+	})
+}
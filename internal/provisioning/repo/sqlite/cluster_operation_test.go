@@ -0,0 +1,180 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/dbschema"
+	dbdriver "github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestClusterOperationDatabaseActions(t *testing.T) {
+	startedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	clusterA := provisioning.Cluster{
+		Name:          "one",
+		ConnectionURL: "https://cluster-one/",
+		Status:        api.ClusterStatusReady,
+		Channel:       "stable",
+	}
+
+	operationA := provisioning.ClusterOperation{
+		UUID:       uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861"),
+		Cluster:    "one",
+		Type:       api.ClusterUpdateInProgressApplyUpdateWithReboot,
+		Status:     api.ClusterOperationStatusFailed,
+		Error:      "boom",
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(30 * time.Minute),
+	}
+
+	operationB := provisioning.ClusterOperation{
+		UUID:      uuid.MustParse("68a29fbb-29c4-47ac-bf1b-e04d6a1b4cc1"),
+		Cluster:   "one",
+		Type:      api.ClusterUpdateInProgressRollingReboot,
+		Status:    api.ClusterOperationStatusRunning,
+		StartedAt: startedAt.Add(time.Hour),
+	}
+
+	ctx := context.Background()
+
+	// Create a new temporary database.
+	tmpDir := t.TempDir()
+	db, err := dbdriver.Open(tmpDir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = db.Close()
+		require.NoError(t, err)
+	})
+
+	_, err = dbschema.Ensure(ctx, db, tmpDir)
+	require.NoError(t, err)
+
+	tx := transaction.Enable(db)
+	entities.PreparedStmts, err = entities.PrepareStmts(tx, false)
+	require.NoError(t, err)
+
+	cluster := sqlite.NewCluster(tx)
+	clusterOperation := sqlite.NewClusterOperation(tx)
+
+	_, err = cluster.Create(ctx, clusterA)
+	require.NoError(t, err)
+
+	// Add operations
+	_, err = clusterOperation.Create(ctx, operationA)
+	require.NoError(t, err)
+	_, err = clusterOperation.Create(ctx, operationB)
+	require.NoError(t, err)
+
+	// Can't add a duplicate operation.
+	_, err = clusterOperation.Create(ctx, operationA)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+
+	// Ensure we have two entries.
+	operations, err := clusterOperation.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To("one"),
+	})
+	require.NoError(t, err)
+	require.Len(t, operations, 2)
+
+	// Get running operation.
+	operations, err = clusterOperation.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To("one"),
+		Status:  ptr.To(api.ClusterOperationStatusRunning),
+	})
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	operationB.ID = operations[0].ID
+	require.Equal(t, operationB, operations[0])
+
+	// Add events.
+	events := provisioning.ClusterOperationEvents{
+		{
+			Operation: operationB.UUID,
+			Server:    "server01",
+			Action:    api.ClusterOperationEventActionEvacuate,
+			StartedAt: startedAt.Add(time.Hour + time.Minute),
+		},
+		{
+			Operation: operationB.UUID,
+			Server:    "server01",
+			Action:    api.ClusterOperationEventActionReboot,
+			StartedAt: startedAt.Add(time.Hour + 2*time.Minute),
+		},
+	}
+
+	for _, event := range events {
+		_, err = clusterOperation.CreateEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	dbEvents, err := clusterOperation.GetEventAll(ctx, operationB.UUID)
+	require.NoError(t, err)
+	require.Len(t, dbEvents, 2)
+	for i := range dbEvents {
+		events[i].ID = dbEvents[i].ID
+	}
+
+	require.ElementsMatch(t, events, dbEvents)
+
+	dbEvents, err = clusterOperation.GetEventAll(ctx, operationA.UUID)
+	require.NoError(t, err)
+	require.Empty(t, dbEvents)
+
+	// Finish operation.
+	operationB.Status = api.ClusterOperationStatusSucceeded
+	operationB.FinishedAt = startedAt.Add(2 * time.Hour)
+	err = clusterOperation.Update(ctx, operationB)
+	require.NoError(t, err)
+
+	operations, err = clusterOperation.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To("one"),
+		Status:  ptr.To(api.ClusterOperationStatusRunning),
+	})
+	require.NoError(t, err)
+	require.Empty(t, operations)
+
+	// Can't update an operation that doesn't exist.
+	err = clusterOperation.Update(ctx, provisioning.ClusterOperation{
+		UUID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Cluster: "one",
+	})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Only keep the most recent operation.
+	err = clusterOperation.DeleteExceptLatestByCluster(ctx, "one", 1)
+	require.NoError(t, err)
+
+	operations, err = clusterOperation.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To("one"),
+	})
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	require.Equal(t, operationB.UUID, operations[0].UUID)
+
+	dbEvents, err = clusterOperation.GetEventAll(ctx, operationB.UUID)
+	require.NoError(t, err)
+	require.Len(t, dbEvents, 2)
+
+	// Operations are removed together with the cluster.
+	err = cluster.DeleteByName(ctx, clusterA.Name)
+	require.NoError(t, err)
+
+	operations, err = clusterOperation.GetAllWithFilter(ctx, provisioning.ClusterOperationFilter{
+		Cluster: ptr.To("one"),
+	})
+	require.NoError(t, err)
+	require.Empty(t, operations)
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
 	"github.com/FuturFusion/operations-center/internal/provisioning"
@@ -65,3 +66,32 @@ func (c clusterTemplate) Rename(ctx context.Context, oldName string, newName str
 func (c clusterTemplate) DeleteByName(ctx context.Context, name string) error {
 	return entities.DeleteClusterTemplate(ctx, transaction.GetDBTX(ctx, c.db), name)
 }
+
+func (c clusterTemplate) CreateRevision(ctx context.Context, in provisioning.ClusterTemplateRevision) (int64, error) {
+	return entities.CreateClusterTemplateRevision(ctx, transaction.GetDBTX(ctx, c.db), in)
+}
+
+func (c clusterTemplate) GetRevisionAll(ctx context.Context, name string) (provisioning.ClusterTemplateRevisions, error) {
+	return entities.GetClusterTemplateRevisions(ctx, transaction.GetDBTX(ctx, c.db), entities.ClusterTemplateRevisionFilter{
+		ClusterTemplate: &name,
+	})
+}
+
+func (c clusterTemplate) GetRevision(ctx context.Context, name string, revision int64) (*provisioning.ClusterTemplateRevision, error) {
+	revisions, err := entities.GetClusterTemplateRevisions(ctx, transaction.GetDBTX(ctx, c.db), entities.ClusterTemplateRevisionFilter{
+		ClusterTemplate: &name,
+		Revision:        &revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(revisions) {
+	case 0:
+		return nil, domain.ErrNotFound
+	case 1:
+		return &revisions[0], nil
+	default:
+		return nil, fmt.Errorf("More than one revision %d found for cluster template %q", revision, name)
+	}
+}
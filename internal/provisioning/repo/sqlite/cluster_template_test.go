@@ -3,6 +3,7 @@ package sqlite_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -30,6 +31,7 @@ func TestClusterTemplateDatabaseActions(t *testing.T) {
 				DefaultValue: "1",
 			},
 		},
+		Revision: 1,
 	}
 
 	clusterTemplateB := provisioning.ClusterTemplate{
@@ -44,6 +46,7 @@ func TestClusterTemplateDatabaseActions(t *testing.T) {
 				Description: "BAR",
 			},
 		},
+		Revision: 1,
 	}
 
 	ctx := context.Background()
@@ -73,6 +76,13 @@ func TestClusterTemplateDatabaseActions(t *testing.T) {
 	_, err = clusterTemplate.Create(ctx, clusterTemplateB)
 	require.NoError(t, err)
 
+	// Add cluster template revisions
+	createdAt := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+	_, err = clusterTemplate.CreateRevision(ctx, provisioning.NewClusterTemplateRevision(clusterTemplateA, createdAt))
+	require.NoError(t, err)
+	_, err = clusterTemplate.CreateRevision(ctx, provisioning.NewClusterTemplateRevision(clusterTemplateB, createdAt))
+	require.NoError(t, err)
+
 	// Ensure we have two entries
 	clusters, err := clusterTemplate.GetAll(ctx)
 	require.NoError(t, err)
@@ -98,8 +108,11 @@ func TestClusterTemplateDatabaseActions(t *testing.T) {
 
 	// Test updating a cluster template.
 	clusterTemplateB.Description = "updated"
+	clusterTemplateB.Revision = 2
 	err = clusterTemplate.Update(ctx, clusterTemplateB)
 	require.NoError(t, err)
+	_, err = clusterTemplate.CreateRevision(ctx, provisioning.NewClusterTemplateRevision(clusterTemplateB, createdAt))
+	require.NoError(t, err)
 	clusterTemplateB.Name = "B new"
 	err = clusterTemplate.Rename(ctx, "B", clusterTemplateB.Name)
 	require.NoError(t, err)
@@ -109,12 +122,39 @@ func TestClusterTemplateDatabaseActions(t *testing.T) {
 	clusterTemplateB.LastUpdated = dbClusterB.LastUpdated
 	require.Equal(t, clusterTemplateB, *dbClusterB)
 
+	// Revisions follow the renamed cluster template.
+	revisions, err := clusterTemplate.GetRevisionAll(ctx, clusterTemplateB.Name)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, int64(1), revisions[0].Revision)
+	require.Equal(t, "B", revisions[0].Description)
+	require.Equal(t, int64(2), revisions[1].Revision)
+	require.Equal(t, "updated", revisions[1].Description)
+
+	revision, err := clusterTemplate.GetRevision(ctx, clusterTemplateB.Name, 2)
+	require.NoError(t, err)
+	wantRevision := provisioning.NewClusterTemplateRevision(clusterTemplateB, createdAt)
+	wantRevision.ID = revision.ID
+	require.Equal(t, wantRevision, *revision)
+
+	_, err = clusterTemplate.GetRevision(ctx, clusterTemplateB.Name, 3)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't add a duplicate cluster template revision.
+	_, err = clusterTemplate.CreateRevision(ctx, provisioning.NewClusterTemplateRevision(clusterTemplateB, createdAt))
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+
 	// Delete a cluster template.
 	err = clusterTemplate.DeleteByName(ctx, clusterTemplateA.Name)
 	require.NoError(t, err)
 	_, err = clusterTemplate.GetByName(ctx, clusterTemplateA.Name)
 	require.ErrorIs(t, err, domain.ErrNotFound)
 
+	// Revisions are deleted together with the cluster template.
+	revisions, err = clusterTemplate.GetRevisionAll(ctx, clusterTemplateA.Name)
+	require.NoError(t, err)
+	require.Empty(t, revisions)
+
 	// Should have one cluster templates remaining.
 	clusters, err = clusterTemplate.GetAll(ctx)
 	require.NoError(t, err)
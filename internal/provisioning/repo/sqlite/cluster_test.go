@@ -38,7 +38,12 @@ func TestClusterDatabaseActions(t *testing.T) {
 		Fingerprint:   fingerprintA,
 		Status:        api.ClusterStatusReady,
 		ServerNames:   []string{"server1", "server2"},
-		Channel:       "stable",
+		ServicesConfig: provisioning.ClusterServicesConfig{
+			"lvm": map[string]any{
+				"enabled": true,
+			},
+		},
+		Channel: "stable",
 	}
 
 	clusterB := provisioning.Cluster{
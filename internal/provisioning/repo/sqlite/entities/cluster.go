@@ -7,6 +7,7 @@ package entities
 //
 //generate-database:mapper stmt -e cluster objects
 //generate-database:mapper stmt -e cluster objects-by-Name
+//generate-database:mapper stmt -e cluster objects-by-Site
 //generate-database:mapper stmt -e cluster names
 //generate-database:mapper stmt -e cluster id
 //generate-database:mapper stmt -e cluster create
@@ -14,20 +14,31 @@ import (
 )
 
 var clusterObjects = RegisterStmt(`
-SELECT clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, channels.name AS channel, clusters.description, clusters.properties, clusters.config, clusters.last_updated
+SELECT clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, clusters.services_config, channels.name AS channel, sites.name AS site, clusters.description, clusters.properties, clusters.config, clusters.cluster_template, clusters.cluster_template_revision, clusters.cluster_template_variable_values, clusters.last_updated
   FROM clusters
   JOIN channels ON clusters.channel_id = channels.id
+  LEFT JOIN sites ON clusters.site_id = sites.id
   ORDER BY clusters.name
 `)
 
 var clusterObjectsByName = RegisterStmt(`
-SELECT clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, channels.name AS channel, clusters.description, clusters.properties, clusters.config, clusters.last_updated
+SELECT clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, clusters.services_config, channels.name AS channel, sites.name AS site, clusters.description, clusters.properties, clusters.config, clusters.cluster_template, clusters.cluster_template_revision, clusters.cluster_template_variable_values, clusters.last_updated
   FROM clusters
   JOIN channels ON clusters.channel_id = channels.id
+  LEFT JOIN sites ON clusters.site_id = sites.id
   WHERE ( clusters.name = ? )
   ORDER BY clusters.name
 `)
 
+var clusterObjectsBySite = RegisterStmt(`
+SELECT clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, clusters.services_config, channels.name AS channel, sites.name AS site, clusters.description, clusters.properties, clusters.config, clusters.cluster_template, clusters.cluster_template_revision, clusters.cluster_template_variable_values, clusters.last_updated
+  FROM clusters
+  JOIN channels ON clusters.channel_id = channels.id
+  LEFT JOIN sites ON clusters.site_id = sites.id
+  WHERE ( site = ? )
+  ORDER BY clusters.name
+`)
+
 var clusterNames = RegisterStmt(`
 SELECT clusters.name
   FROM clusters
@@ -40,13 +51,13 @@ SELECT clusters.id FROM clusters
 `)
 
 var clusterCreate = RegisterStmt(`
-INSERT INTO clusters (name, connection_url, certificate, status, update_status, channel_id, description, properties, config, last_updated)
-  VALUES (?, ?, ?, ?, ?, (SELECT channels.id FROM channels WHERE channels.name = ?), ?, ?, ?, ?)
+INSERT INTO clusters (name, connection_url, certificate, status, update_status, services_config, channel_id, site_id, description, properties, config, cluster_template, cluster_template_revision, cluster_template_variable_values, last_updated)
+  VALUES (?, ?, ?, ?, ?, ?, (SELECT channels.id FROM channels WHERE channels.name = ?), (SELECT sites.id FROM sites WHERE sites.name = ?), ?, ?, ?, ?, ?, ?, ?)
 `)
 
 var clusterUpdate = RegisterStmt(`
 UPDATE clusters
-  SET name = ?, connection_url = ?, certificate = ?, status = ?, update_status = ?, channel_id = (SELECT channels.id FROM channels WHERE channels.name = ?), description = ?, properties = ?, config = ?, last_updated = ?
+  SET name = ?, connection_url = ?, certificate = ?, status = ?, update_status = ?, services_config = ?, channel_id = (SELECT channels.id FROM channels WHERE channels.name = ?), site_id = (SELECT sites.id FROM sites WHERE sites.name = ?), description = ?, properties = ?, config = ?, cluster_template = ?, cluster_template_revision = ?, cluster_template_variable_values = ?, last_updated = ?
  WHERE id = ?
 `)
 
@@ -138,7 +149,7 @@ func GetCluster(ctx context.Context, db dbtx, name string) (_ *provisioning.Clus
 // clusterColumns returns a string of column names to be used with a SELECT statement for the entity.
 // Use this function when building statements to retrieve database entries matching the Cluster entity.
 func clusterColumns() string {
-	return "clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, channels.name AS channel, clusters.description, clusters.properties, clusters.config, clusters.last_updated"
+	return "clusters.id, clusters.name, clusters.connection_url, clusters.certificate, clusters.status, clusters.update_status, clusters.services_config, channels.name AS channel, sites.name AS site, clusters.description, clusters.properties, clusters.config, clusters.cluster_template, clusters.cluster_template_revision, clusters.cluster_template_variable_values, clusters.last_updated"
 }
 
 // getClusters can be used to run handwritten sql.Stmts to return a slice of objects.
@@ -147,7 +158,7 @@ func getClusters(ctx context.Context, stmt *sql.Stmt, args ...any) ([]provisioni
 
 	dest := func(scan func(dest ...any) error) error {
 		c := provisioning.Cluster{}
-		err := scan(&c.ID, &c.Name, &c.ConnectionURL, &c.Certificate, &c.Status, &c.UpdateStatus, &c.Channel, &c.Description, &c.Properties, &c.Config, &c.LastUpdated)
+		err := scan(&c.ID, &c.Name, &c.ConnectionURL, &c.Certificate, &c.Status, &c.UpdateStatus, &c.ServicesConfig, &c.Channel, &c.Site, &c.Description, &c.Properties, &c.Config, &c.ClusterTemplate, &c.ClusterTemplateRevision, &c.ClusterTemplateVariableValues, &c.LastUpdated)
 		if err != nil {
 			return err
 		}
@@ -171,7 +182,7 @@ func getClustersRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]pr
 
 	dest := func(scan func(dest ...any) error) error {
 		c := provisioning.Cluster{}
-		err := scan(&c.ID, &c.Name, &c.ConnectionURL, &c.Certificate, &c.Status, &c.UpdateStatus, &c.Channel, &c.Description, &c.Properties, &c.Config, &c.LastUpdated)
+		err := scan(&c.ID, &c.Name, &c.ConnectionURL, &c.Certificate, &c.Status, &c.UpdateStatus, &c.ServicesConfig, &c.Channel, &c.Site, &c.Description, &c.Properties, &c.Config, &c.ClusterTemplate, &c.ClusterTemplateRevision, &c.ClusterTemplateVariableValues, &c.LastUpdated)
 		if err != nil {
 			return err
 		}
@@ -214,7 +225,31 @@ func GetClusters(ctx context.Context, db dbtx, filters ...provisioning.ClusterFi
 	}
 
 	for i, filter := range filters {
-		if filter.Name != nil {
+		if filter.Site != nil && filter.Name == nil {
+			args = append(args, []any{filter.Site}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, clusterObjectsBySite)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"clusterObjectsBySite\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(clusterObjectsBySite)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"clusterObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.Name != nil && filter.Site == nil {
 			args = append(args, []any{filter.Name}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, clusterObjectsByName)
@@ -238,7 +273,7 @@ func GetClusters(ctx context.Context, db dbtx, filters ...provisioning.ClusterFi
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Name == nil {
+		} else if filter.Name == nil && filter.Site == nil {
 			return nil, fmt.Errorf("Cannot filter on empty ClusterFilter")
 		} else {
 			return nil, errors.New("No statement exists for the given Filter")
@@ -285,7 +320,7 @@ func GetClusterNames(ctx context.Context, db dbtx, filters ...provisioning.Clust
 	}
 
 	for _, filter := range filters {
-		if filter.Name == nil {
+		if filter.Name == nil && filter.Site == nil {
 			return nil, fmt.Errorf("Cannot filter on empty ClusterFilter")
 		} else {
 			return nil, errors.New("No statement exists for the given Filter")
@@ -331,7 +366,7 @@ func CreateCluster(ctx context.Context, db dbtx, object provisioning.Cluster) (_
 		_err = mapErr(_err, "Cluster")
 	}()
 
-	args := make([]any, 10)
+	args := make([]any, 15)
 
 	// Populate the statement arguments.
 	args[0] = object.Name
@@ -339,11 +374,16 @@ func CreateCluster(ctx context.Context, db dbtx, object provisioning.Cluster) (_
 	args[2] = object.Certificate
 	args[3] = object.Status
 	args[4] = object.UpdateStatus
-	args[5] = object.Channel
-	args[6] = object.Description
-	args[7] = object.Properties
-	args[8] = object.Config
-	args[9] = time.Now().UTC().Format(time.RFC3339)
+	args[5] = object.ServicesConfig
+	args[6] = object.Channel
+	args[7] = object.Site
+	args[8] = object.Description
+	args[9] = object.Properties
+	args[10] = object.Config
+	args[11] = object.ClusterTemplate
+	args[12] = object.ClusterTemplateRevision
+	args[13] = object.ClusterTemplateVariableValues
+	args[14] = time.Now().UTC().Format(time.RFC3339)
 
 	// Prepared statement to use.
 	stmt, err := Stmt(db, clusterCreate)
@@ -386,7 +426,7 @@ func UpdateCluster(ctx context.Context, db tx, name string, object provisioning.
 		return fmt.Errorf("Failed to get \"clusterUpdate\" prepared statement: %w", err)
 	}
 
-	result, err := stmt.Exec(object.Name, object.ConnectionURL, object.Certificate, object.Status, object.UpdateStatus, object.Channel, object.Description, object.Properties, object.Config, time.Now().UTC().Format(time.RFC3339), id)
+	result, err := stmt.Exec(object.Name, object.ConnectionURL, object.Certificate, object.Status, object.UpdateStatus, object.ServicesConfig, object.Channel, object.Site, object.Description, object.Properties, object.Config, object.ClusterTemplate, object.ClusterTemplateRevision, object.ClusterTemplateVariableValues, time.Now().UTC().Format(time.RFC3339), id)
 	if err != nil {
 		return fmt.Errorf("Update \"clusters\" entry failed: %w", err)
 	}
@@ -0,0 +1,27 @@
+package entities
+
+// Code generation directives.
+//
+//generate-database:mapper target cluster_blueprint.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e cluster_blueprint objects table=cluster_blueprints
+//generate-database:mapper stmt -e cluster_blueprint objects-by-Name table=cluster_blueprints
+//generate-database:mapper stmt -e cluster_blueprint names table=cluster_blueprints
+//generate-database:mapper stmt -e cluster_blueprint id table=cluster_blueprints
+//generate-database:mapper stmt -e cluster_blueprint create table=cluster_blueprints
+//generate-database:mapper stmt -e cluster_blueprint update table=cluster_blueprints
+//generate-database:mapper stmt -e cluster_blueprint delete-by-Name table=cluster_blueprints
+//
+//generate-database:mapper method -e cluster_blueprint ID table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint Exists table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint GetOne table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint GetMany table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint GetNames table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint Create table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint Update table=cluster_blueprints
+//generate-database:mapper method -e cluster_blueprint DeleteOne-by-Name table=cluster_blueprints
+
+type ClusterBlueprintFilter struct {
+	Name *string
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target cluster_operation.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e cluster_operation objects
+//generate-database:mapper stmt -e cluster_operation objects-by-Cluster
+//generate-database:mapper stmt -e cluster_operation objects-by-Cluster-and-Status
+//generate-database:mapper stmt -e cluster_operation id
+//generate-database:mapper stmt -e cluster_operation create
+//generate-database:mapper stmt -e cluster_operation update
+//
+//generate-database:mapper method -e cluster_operation ID
+//generate-database:mapper method -e cluster_operation GetMany
+//generate-database:mapper method -e cluster_operation Create
+//generate-database:mapper method -e cluster_operation Update
+
+// DeleteClusterOperationsExceptLatest deletes the operations of the given
+// cluster, except the keep most recent ones. The events of the deleted
+// operations are removed as well.
+func DeleteClusterOperationsExceptLatest(ctx context.Context, db dbtx, cluster string, keep int) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Cluster_operation")
+	}()
+
+	const stmt = `DELETE FROM cluster_operations
+  WHERE cluster_id = (SELECT clusters.id FROM clusters WHERE clusters.name = ?)
+  AND id NOT IN (
+    SELECT cluster_operations.id
+      FROM cluster_operations
+      JOIN clusters ON cluster_operations.cluster_id = clusters.id
+      WHERE clusters.name = ?
+      ORDER BY cluster_operations.started_at DESC, cluster_operations.id DESC
+      LIMIT ?
+  )
+`
+
+	_, err := db.ExecContext(ctx, stmt, cluster, cluster, keep)
+	if err != nil {
+		return fmt.Errorf("Delete \"cluster_operations\": %w", err)
+	}
+
+	return nil
+}
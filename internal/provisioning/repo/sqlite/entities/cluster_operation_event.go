@@ -0,0 +1,19 @@
+package entities
+
+import "github.com/google/uuid"
+
+// Code generation directives.
+//
+//generate-database:mapper target cluster_operation_event.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e cluster_operation_event objects
+//generate-database:mapper stmt -e cluster_operation_event objects-by-Operation
+//generate-database:mapper stmt -e cluster_operation_event create
+//
+//generate-database:mapper method -e cluster_operation_event GetMany
+//generate-database:mapper method -e cluster_operation_event Create
+
+type ClusterOperationEventFilter struct {
+	Operation *uuid.UUID
+}
@@ -14,13 +14,13 @@ import (
 )
 
 var clusterTemplateObjects = RegisterStmt(`
-SELECT cluster_templates.id, cluster_templates.name, cluster_templates.description, cluster_templates.service_config_template, cluster_templates.application_config_template, cluster_templates.variables, cluster_templates.last_updated
+SELECT cluster_templates.id, cluster_templates.name, cluster_templates.description, cluster_templates.service_config_template, cluster_templates.application_config_template, cluster_templates.variables, cluster_templates.revision, cluster_templates.last_updated
   FROM cluster_templates
   ORDER BY cluster_templates.name
 `)
 
 var clusterTemplateObjectsByName = RegisterStmt(`
-SELECT cluster_templates.id, cluster_templates.name, cluster_templates.description, cluster_templates.service_config_template, cluster_templates.application_config_template, cluster_templates.variables, cluster_templates.last_updated
+SELECT cluster_templates.id, cluster_templates.name, cluster_templates.description, cluster_templates.service_config_template, cluster_templates.application_config_template, cluster_templates.variables, cluster_templates.revision, cluster_templates.last_updated
   FROM cluster_templates
   WHERE ( cluster_templates.name = ? )
   ORDER BY cluster_templates.name
@@ -38,13 +38,13 @@ SELECT cluster_templates.id FROM cluster_templates
 `)
 
 var clusterTemplateCreate = RegisterStmt(`
-INSERT INTO cluster_templates (name, description, service_config_template, application_config_template, variables, last_updated)
-  VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO cluster_templates (name, description, service_config_template, application_config_template, variables, revision, last_updated)
+  VALUES (?, ?, ?, ?, ?, ?, ?)
 `)
 
 var clusterTemplateUpdate = RegisterStmt(`
 UPDATE cluster_templates
-  SET name = ?, description = ?, service_config_template = ?, application_config_template = ?, variables = ?, last_updated = ?
+  SET name = ?, description = ?, service_config_template = ?, application_config_template = ?, variables = ?, revision = ?, last_updated = ?
  WHERE id = ?
 `)
 
@@ -136,7 +136,7 @@ func GetClusterTemplate(ctx context.Context, db dbtx, name string) (_ *provision
 // clusterTemplateColumns returns a string of column names to be used with a SELECT statement for the entity.
 // Use this function when building statements to retrieve database entries matching the ClusterTemplate entity.
 func clusterTemplateColumns() string {
-	return "cluster_templates.id, cluster_templates.name, cluster_templates.description, cluster_templates.service_config_template, cluster_templates.application_config_template, cluster_templates.variables, cluster_templates.last_updated"
+	return "cluster_templates.id, cluster_templates.name, cluster_templates.description, cluster_templates.service_config_template, cluster_templates.application_config_template, cluster_templates.variables, cluster_templates.revision, cluster_templates.last_updated"
 }
 
 // getClusterTemplates can be used to run handwritten sql.Stmts to return a slice of objects.
@@ -145,7 +145,7 @@ func getClusterTemplates(ctx context.Context, stmt *sql.Stmt, args ...any) ([]pr
 
 	dest := func(scan func(dest ...any) error) error {
 		c := provisioning.ClusterTemplate{}
-		err := scan(&c.ID, &c.Name, &c.Description, &c.ServiceConfigTemplate, &c.ApplicationConfigTemplate, &c.Variables, &c.LastUpdated)
+		err := scan(&c.ID, &c.Name, &c.Description, &c.ServiceConfigTemplate, &c.ApplicationConfigTemplate, &c.Variables, &c.Revision, &c.LastUpdated)
 		if err != nil {
 			return err
 		}
@@ -169,7 +169,7 @@ func getClusterTemplatesRaw(ctx context.Context, db dbtx, sql string, args ...an
 
 	dest := func(scan func(dest ...any) error) error {
 		c := provisioning.ClusterTemplate{}
-		err := scan(&c.ID, &c.Name, &c.Description, &c.ServiceConfigTemplate, &c.ApplicationConfigTemplate, &c.Variables, &c.LastUpdated)
+		err := scan(&c.ID, &c.Name, &c.Description, &c.ServiceConfigTemplate, &c.ApplicationConfigTemplate, &c.Variables, &c.Revision, &c.LastUpdated)
 		if err != nil {
 			return err
 		}
@@ -329,7 +329,7 @@ func CreateClusterTemplate(ctx context.Context, db dbtx, object provisioning.Clu
 		_err = mapErr(_err, "Cluster_template")
 	}()
 
-	args := make([]any, 6)
+	args := make([]any, 7)
 
 	// Populate the statement arguments.
 	args[0] = object.Name
@@ -337,7 +337,8 @@ func CreateClusterTemplate(ctx context.Context, db dbtx, object provisioning.Clu
 	args[2] = object.ServiceConfigTemplate
 	args[3] = object.ApplicationConfigTemplate
 	args[4] = object.Variables
-	args[5] = time.Now().UTC().Format(time.RFC3339)
+	args[5] = object.Revision
+	args[6] = time.Now().UTC().Format(time.RFC3339)
 
 	// Prepared statement to use.
 	stmt, err := Stmt(db, clusterTemplateCreate)
@@ -380,7 +381,7 @@ func UpdateClusterTemplate(ctx context.Context, db tx, name string, object provi
 		return fmt.Errorf("Failed to get \"clusterTemplateUpdate\" prepared statement: %w", err)
 	}
 
-	result, err := stmt.Exec(object.Name, object.Description, object.ServiceConfigTemplate, object.ApplicationConfigTemplate, object.Variables, time.Now().UTC().Format(time.RFC3339), id)
+	result, err := stmt.Exec(object.Name, object.Description, object.ServiceConfigTemplate, object.ApplicationConfigTemplate, object.Variables, object.Revision, time.Now().UTC().Format(time.RFC3339), id)
 	if err != nil {
 		return fmt.Errorf("Update \"cluster_templates\" entry failed: %w", err)
 	}
@@ -0,0 +1,19 @@
+package entities
+
+// Code generation directives.
+//
+//generate-database:mapper target cluster_template_revision.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e cluster_template_revision objects
+//generate-database:mapper stmt -e cluster_template_revision objects-by-ClusterTemplate
+//generate-database:mapper stmt -e cluster_template_revision objects-by-ClusterTemplate-and-Revision
+//generate-database:mapper stmt -e cluster_template_revision create
+//
+//generate-database:mapper method -e cluster_template_revision GetMany
+//generate-database:mapper method -e cluster_template_revision Create
+
+type ClusterTemplateRevisionFilter struct {
+	ClusterTemplate *string
+	Revision        *int64
+}
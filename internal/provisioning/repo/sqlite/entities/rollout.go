@@ -0,0 +1,27 @@
+package entities
+
+// Code generation directives.
+//
+//generate-database:mapper target rollout.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e rollout objects table=rollouts
+//generate-database:mapper stmt -e rollout objects-by-Name table=rollouts
+//generate-database:mapper stmt -e rollout names table=rollouts
+//generate-database:mapper stmt -e rollout id table=rollouts
+//generate-database:mapper stmt -e rollout create table=rollouts
+//generate-database:mapper stmt -e rollout update table=rollouts
+//generate-database:mapper stmt -e rollout delete-by-Name table=rollouts
+//
+//generate-database:mapper method -e rollout ID table=rollouts
+//generate-database:mapper method -e rollout Exists table=rollouts
+//generate-database:mapper method -e rollout GetOne table=rollouts
+//generate-database:mapper method -e rollout GetMany table=rollouts
+//generate-database:mapper method -e rollout GetNames table=rollouts
+//generate-database:mapper method -e rollout Create table=rollouts
+//generate-database:mapper method -e rollout Update table=rollouts
+//generate-database:mapper method -e rollout DeleteOne-by-Name table=rollouts
+
+type RolloutFilter struct {
+	Name *string
+}
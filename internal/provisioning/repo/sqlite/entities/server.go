@@ -16,8 +16,10 @@ package entities
 //generate-database:mapper stmt -e server objects-by-Type
 //generate-database:mapper stmt -e server objects-by-SystemUUID
 //generate-database:mapper stmt -e server objects-by-MachineID
+//generate-database:mapper stmt -e server objects-by-Site
 //generate-database:mapper stmt -e server names
 //generate-database:mapper stmt -e server names-by-Cluster
+//generate-database:mapper stmt -e server names-by-Site
 //generate-database:mapper stmt -e server id
 //generate-database:mapper stmt -e server create
 //generate-database:mapper stmt -e server update
@@ -14,103 +14,124 @@ import (
 )
 
 var serverObjects = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   ORDER BY servers.name
 `)
 
 var serverObjectsByName = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.name = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByCluster = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( cluster = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByClusterAndName = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( cluster = ? AND servers.name = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByClusterAndStatus = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( cluster = ? AND servers.status = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByStatus = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.status = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByStatusAndStatusDetail = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.status = ? AND servers.status_detail = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByCertificate = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.certificate = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByType = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.type = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsBySystemUUID = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.system_uuid = ? )
   ORDER BY servers.name
 `)
 
 var serverObjectsByMachineID = RegisterStmt(`
-SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
   FROM servers
   LEFT JOIN clusters ON servers.cluster_id = clusters.id
   JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
   WHERE ( servers.machine_id = ? )
   ORDER BY servers.name
 `)
 
+var serverObjectsBySite = RegisterStmt(`
+SELECT servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated
+  FROM servers
+  LEFT JOIN clusters ON servers.cluster_id = clusters.id
+  JOIN channels ON servers.channel_id = channels.id
+  LEFT JOIN sites ON servers.site_id = sites.id
+  WHERE ( site = ? )
+  ORDER BY servers.name
+`)
+
 var serverNames = RegisterStmt(`
 SELECT servers.name
   FROM servers
@@ -125,19 +146,27 @@ SELECT servers.name
   ORDER BY servers.name
 `)
 
+var serverNamesBySite = RegisterStmt(`
+SELECT servers.name
+  FROM servers
+  LEFT JOIN sites ON servers.site_id = sites.id
+  WHERE ( sites.name = ? )
+  ORDER BY servers.name
+`)
+
 var serverID = RegisterStmt(`
 SELECT servers.id FROM servers
   WHERE servers.name = ?
 `)
 
 var serverCreate = RegisterStmt(`
-INSERT INTO servers (cluster_id, name, type, connection_url, public_connection_url, certificate, hardware_data, os_data, version_data, channel_id, status, status_detail, description, properties, bmc_config, registration_token, system_uuid, machine_id, bmc_data, last_updated, last_seen, last_status_updated)
-  VALUES ((SELECT clusters.id FROM clusters WHERE clusters.name = ?), ?, ?, ?, ?, ?, ?, ?, ?, (SELECT channels.id FROM channels WHERE channels.name = ?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO servers (cluster_id, name, type, connection_url, public_connection_url, certificate, hardware_data, os_data, version_data, channel_id, site_id, status, status_detail, description, properties, bmc_config, registration_token, system_uuid, machine_id, bmc_data, cluster_join, bmc_install, last_updated, last_seen, last_status_updated)
+  VALUES ((SELECT clusters.id FROM clusters WHERE clusters.name = ?), ?, ?, ?, ?, ?, ?, ?, ?, (SELECT channels.id FROM channels WHERE channels.name = ?), (SELECT sites.id FROM sites WHERE sites.name = ?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `)
 
 var serverUpdate = RegisterStmt(`
 UPDATE servers
-  SET cluster_id = (SELECT clusters.id FROM clusters WHERE clusters.name = ?), name = ?, type = ?, connection_url = ?, public_connection_url = ?, certificate = ?, hardware_data = ?, os_data = ?, version_data = ?, channel_id = (SELECT channels.id FROM channels WHERE channels.name = ?), status = ?, status_detail = ?, description = ?, properties = ?, bmc_config = ?, registration_token = ?, system_uuid = ?, machine_id = ?, bmc_data = ?, last_updated = ?, last_seen = ?, last_status_updated = ?
+  SET cluster_id = (SELECT clusters.id FROM clusters WHERE clusters.name = ?), name = ?, type = ?, connection_url = ?, public_connection_url = ?, certificate = ?, hardware_data = ?, os_data = ?, version_data = ?, channel_id = (SELECT channels.id FROM channels WHERE channels.name = ?), site_id = (SELECT sites.id FROM sites WHERE sites.name = ?), status = ?, status_detail = ?, description = ?, properties = ?, bmc_config = ?, registration_token = ?, system_uuid = ?, machine_id = ?, bmc_data = ?, cluster_join = ?, bmc_install = ?, last_updated = ?, last_seen = ?, last_status_updated = ?
  WHERE id = ?
 `)
 
@@ -229,7 +258,7 @@ func GetServer(ctx context.Context, db dbtx, name string) (_ *provisioning.Serve
 // serverColumns returns a string of column names to be used with a SELECT statement for the entity.
 // Use this function when building statements to retrieve database entries matching the Server entity.
 func serverColumns() string {
-	return "servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.last_updated, servers.last_seen, servers.last_status_updated"
+	return "servers.id, clusters.name AS cluster, servers.name, servers.type, servers.connection_url, servers.public_connection_url, servers.certificate, clusters.certificate AS cluster_certificate, clusters.connection_url AS cluster_connection_url, servers.hardware_data, servers.os_data, servers.version_data, channels.name AS channel, sites.name AS site, servers.status, servers.status_detail, servers.description, servers.properties, servers.bmc_config, servers.registration_token, servers.system_uuid, servers.machine_id, servers.bmc_data, servers.cluster_join, servers.bmc_install, servers.last_updated, servers.last_seen, servers.last_status_updated"
 }
 
 // getServers can be used to run handwritten sql.Stmts to return a slice of objects.
@@ -240,7 +269,9 @@ func getServers(ctx context.Context, stmt *sql.Stmt, args ...any) ([]provisionin
 		s := provisioning.Server{}
 		var bMCConfigStr string
 		var bMCDataStr string
-		err := scan(&s.ID, &s.Cluster, &s.Name, &s.Type, &s.ConnectionURL, &s.PublicConnectionURL, &s.Certificate, &s.ClusterCertificate, &s.ClusterConnectionURL, &s.HardwareData, &s.OSData, &s.VersionData, &s.Channel, &s.Status, &s.StatusDetail, &s.Description, &s.Properties, &bMCConfigStr, &s.RegistrationToken, &s.SystemUUID, &s.MachineID, &bMCDataStr, &s.LastUpdated, &s.LastSeen, &s.LastStatusUpdated)
+		var clusterJoinStr string
+		var bMCInstallStr string
+		err := scan(&s.ID, &s.Cluster, &s.Name, &s.Type, &s.ConnectionURL, &s.PublicConnectionURL, &s.Certificate, &s.ClusterCertificate, &s.ClusterConnectionURL, &s.HardwareData, &s.OSData, &s.VersionData, &s.Channel, &s.Site, &s.Status, &s.StatusDetail, &s.Description, &s.Properties, &bMCConfigStr, &s.RegistrationToken, &s.SystemUUID, &s.MachineID, &bMCDataStr, &clusterJoinStr, &bMCInstallStr, &s.LastUpdated, &s.LastSeen, &s.LastStatusUpdated)
 		if err != nil {
 			return err
 		}
@@ -255,6 +286,16 @@ func getServers(ctx context.Context, stmt *sql.Stmt, args ...any) ([]provisionin
 			return err
 		}
 
+		err = unmarshalJSON(clusterJoinStr, &s.ClusterJoin)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(bMCInstallStr, &s.BMCInstall)
+		if err != nil {
+			return err
+		}
+
 		objects = append(objects, s)
 
 		return nil
@@ -276,7 +317,9 @@ func getServersRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]pro
 		s := provisioning.Server{}
 		var bMCConfigStr string
 		var bMCDataStr string
-		err := scan(&s.ID, &s.Cluster, &s.Name, &s.Type, &s.ConnectionURL, &s.PublicConnectionURL, &s.Certificate, &s.ClusterCertificate, &s.ClusterConnectionURL, &s.HardwareData, &s.OSData, &s.VersionData, &s.Channel, &s.Status, &s.StatusDetail, &s.Description, &s.Properties, &bMCConfigStr, &s.RegistrationToken, &s.SystemUUID, &s.MachineID, &bMCDataStr, &s.LastUpdated, &s.LastSeen, &s.LastStatusUpdated)
+		var clusterJoinStr string
+		var bMCInstallStr string
+		err := scan(&s.ID, &s.Cluster, &s.Name, &s.Type, &s.ConnectionURL, &s.PublicConnectionURL, &s.Certificate, &s.ClusterCertificate, &s.ClusterConnectionURL, &s.HardwareData, &s.OSData, &s.VersionData, &s.Channel, &s.Site, &s.Status, &s.StatusDetail, &s.Description, &s.Properties, &bMCConfigStr, &s.RegistrationToken, &s.SystemUUID, &s.MachineID, &bMCDataStr, &clusterJoinStr, &bMCInstallStr, &s.LastUpdated, &s.LastSeen, &s.LastStatusUpdated)
 		if err != nil {
 			return err
 		}
@@ -291,6 +334,16 @@ func getServersRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]pro
 			return err
 		}
 
+		err = unmarshalJSON(clusterJoinStr, &s.ClusterJoin)
+		if err != nil {
+			return err
+		}
+
+		err = unmarshalJSON(bMCInstallStr, &s.BMCInstall)
+		if err != nil {
+			return err
+		}
+
 		objects = append(objects, s)
 
 		return nil
@@ -329,7 +382,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 	}
 
 	for i, filter := range filters {
-		if filter.Status != nil && filter.StatusDetail != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		if filter.Status != nil && filter.StatusDetail != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Status, filter.StatusDetail}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByStatusAndStatusDetail)
@@ -353,7 +406,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Cluster != nil && filter.Status != nil && filter.ID == nil && filter.Name == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Cluster != nil && filter.Status != nil && filter.ID == nil && filter.Name == nil && filter.Site == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Cluster, filter.Status}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByClusterAndStatus)
@@ -377,7 +430,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Cluster != nil && filter.Name != nil && filter.ID == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Cluster != nil && filter.Name != nil && filter.ID == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Cluster, filter.Name}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByClusterAndName)
@@ -401,7 +454,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Type != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Type != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Type}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByType)
@@ -425,7 +478,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.SystemUUID != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.MachineID == nil {
+		} else if filter.SystemUUID != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.SystemUUID}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsBySystemUUID)
@@ -449,7 +502,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Status != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Status != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Status}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByStatus)
@@ -473,7 +526,31 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Name != nil && filter.ID == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Site != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+			args = append(args, []any{filter.Site}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, serverObjectsBySite)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"serverObjectsBySite\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(serverObjectsBySite)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"serverObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.Name != nil && filter.ID == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Name}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByName)
@@ -497,7 +574,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.MachineID != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil {
+		} else if filter.MachineID != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil {
 			args = append(args, []any{filter.MachineID}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByMachineID)
@@ -521,7 +598,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Cluster != nil && filter.ID == nil && filter.Name == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Cluster != nil && filter.ID == nil && filter.Name == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Cluster}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByCluster)
@@ -545,7 +622,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.Certificate != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.Certificate != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Certificate}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverObjectsByCertificate)
@@ -569,7 +646,7 @@ func GetServers(ctx context.Context, db dbtx, filters ...provisioning.ServerFilt
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			return nil, fmt.Errorf("Cannot filter on empty ServerFilter")
 		} else {
 			return nil, errors.New("No statement exists for the given Filter")
@@ -616,7 +693,31 @@ func GetServerNames(ctx context.Context, db dbtx, filters ...provisioning.Server
 	}
 
 	for i, filter := range filters {
-		if filter.Cluster != nil && filter.ID == nil && filter.Name == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		if filter.Site != nil && filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+			args = append(args, []any{filter.Site}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, serverNamesBySite)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"serverNamesBySite\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(serverNamesBySite)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"serverNames\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.Cluster != nil && filter.ID == nil && filter.Name == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			args = append(args, []any{filter.Cluster}...)
 			if len(filters) == 1 {
 				sqlStmt, err = Stmt(db, serverNamesByCluster)
@@ -640,7 +741,7 @@ func GetServerNames(ctx context.Context, db dbtx, filters ...provisioning.Server
 
 			_, where, _ := strings.Cut(parts[0], "WHERE")
 			queryParts[0] += "OR" + where
-		} else if filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
+		} else if filter.ID == nil && filter.Name == nil && filter.Cluster == nil && filter.Site == nil && filter.Status == nil && filter.StatusDetail == nil && filter.Certificate == nil && filter.Type == nil && filter.SystemUUID == nil && filter.MachineID == nil {
 			return nil, fmt.Errorf("Cannot filter on empty ServerFilter")
 		} else {
 			return nil, errors.New("No statement exists for the given Filter")
@@ -686,7 +787,7 @@ func CreateServer(ctx context.Context, db dbtx, object provisioning.Server) (_ i
 		_err = mapErr(_err, "Server")
 	}()
 
-	args := make([]any, 22)
+	args := make([]any, 25)
 
 	// Populate the statement arguments.
 	args[0] = object.Cluster
@@ -699,28 +800,41 @@ func CreateServer(ctx context.Context, db dbtx, object provisioning.Server) (_ i
 	args[7] = object.OSData
 	args[8] = object.VersionData
 	args[9] = object.Channel
-	args[10] = object.Status
-	args[11] = object.StatusDetail
-	args[12] = object.Description
-	args[13] = object.Properties
+	args[10] = object.Site
+	args[11] = object.Status
+	args[12] = object.StatusDetail
+	args[13] = object.Description
+	args[14] = object.Properties
 	marshaledBMCConfig, err := marshalJSON(object.BMCConfig)
 	if err != nil {
 		return -1, err
 	}
 
-	args[14] = marshaledBMCConfig
-	args[15] = object.RegistrationToken
-	args[16] = object.SystemUUID
-	args[17] = object.MachineID
+	args[15] = marshaledBMCConfig
+	args[16] = object.RegistrationToken
+	args[17] = object.SystemUUID
+	args[18] = object.MachineID
 	marshaledBMCData, err := marshalJSON(object.BMCData)
 	if err != nil {
 		return -1, err
 	}
 
-	args[18] = marshaledBMCData
-	args[19] = time.Now().UTC().Format(time.RFC3339)
-	args[20] = object.LastSeen
-	args[21] = object.LastStatusUpdated
+	args[19] = marshaledBMCData
+	marshaledClusterJoin, err := marshalJSON(object.ClusterJoin)
+	if err != nil {
+		return -1, err
+	}
+
+	args[20] = marshaledClusterJoin
+	marshaledBMCInstall, err := marshalJSON(object.BMCInstall)
+	if err != nil {
+		return -1, err
+	}
+
+	args[21] = marshaledBMCInstall
+	args[22] = time.Now().UTC().Format(time.RFC3339)
+	args[23] = object.LastSeen
+	args[24] = object.LastStatusUpdated
 
 	// Prepared statement to use.
 	stmt, err := Stmt(db, serverCreate)
@@ -773,7 +887,17 @@ func UpdateServer(ctx context.Context, db tx, name string, object provisioning.S
 		return err
 	}
 
-	result, err := stmt.Exec(object.Cluster, object.Name, object.Type, object.ConnectionURL, object.PublicConnectionURL, object.Certificate, object.HardwareData, object.OSData, object.VersionData, object.Channel, object.Status, object.StatusDetail, object.Description, object.Properties, marshaledBMCConfig, object.RegistrationToken, object.SystemUUID, object.MachineID, marshaledBMCData, time.Now().UTC().Format(time.RFC3339), object.LastSeen, object.LastStatusUpdated, id)
+	marshaledClusterJoin, err := marshalJSON(object.ClusterJoin)
+	if err != nil {
+		return err
+	}
+
+	marshaledBMCInstall, err := marshalJSON(object.BMCInstall)
+	if err != nil {
+		return err
+	}
+
+	result, err := stmt.Exec(object.Cluster, object.Name, object.Type, object.ConnectionURL, object.PublicConnectionURL, object.Certificate, object.HardwareData, object.OSData, object.VersionData, object.Channel, object.Site, object.Status, object.StatusDetail, object.Description, object.Properties, marshaledBMCConfig, object.RegistrationToken, object.SystemUUID, object.MachineID, marshaledBMCData, marshaledClusterJoin, marshaledBMCInstall, time.Now().UTC().Format(time.RFC3339), object.LastSeen, object.LastStatusUpdated, id)
 	if err != nil {
 		return fmt.Errorf("Update \"servers\" entry failed: %w", err)
 	}
@@ -0,0 +1,26 @@
+package entities
+
+import "github.com/google/uuid"
+
+// Code generation directives.
+//
+//generate-database:mapper target server_decommission.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e server_decommission objects
+//generate-database:mapper stmt -e server_decommission objects-by-UUID
+//generate-database:mapper stmt -e server_decommission objects-by-Server
+//generate-database:mapper stmt -e server_decommission id
+//generate-database:mapper stmt -e server_decommission create
+//generate-database:mapper stmt -e server_decommission update
+//
+//generate-database:mapper method -e server_decommission ID
+//generate-database:mapper method -e server_decommission GetOne
+//generate-database:mapper method -e server_decommission GetMany
+//generate-database:mapper method -e server_decommission Create
+//generate-database:mapper method -e server_decommission Update
+
+type ServerDecommissionFilter struct {
+	UUID   *uuid.UUID
+	Server *string
+}
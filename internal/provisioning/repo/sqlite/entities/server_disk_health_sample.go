@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target server_disk_health_sample.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e server_disk_health_sample objects
+//generate-database:mapper stmt -e server_disk_health_sample objects-by-Server
+//generate-database:mapper stmt -e server_disk_health_sample create
+//
+//generate-database:mapper method -e server_disk_health_sample GetMany
+//generate-database:mapper method -e server_disk_health_sample Create
+
+type ServerDiskHealthSampleFilter struct {
+	Server *string
+}
+
+// DeleteServerDiskHealthSamplesRecordedBefore deletes the disk health samples
+// of the given server, which have been recorded before the given time. The
+// most recent sample of each drive is always kept.
+func DeleteServerDiskHealthSamplesRecordedBefore(ctx context.Context, db dbtx, server string, before time.Time) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Server_disk_health_sample")
+	}()
+
+	const stmt = `DELETE FROM server_disk_health_samples
+  WHERE server_id = (SELECT servers.id FROM servers WHERE servers.name = ?)
+  AND julianday(recorded_at) < julianday(?)
+  AND id NOT IN (
+    SELECT MAX(server_disk_health_samples.id)
+      FROM server_disk_health_samples
+      JOIN servers ON server_disk_health_samples.server_id = servers.id
+      WHERE servers.name = ?
+      GROUP BY server_disk_health_samples.drive
+  )
+`
+
+	_, err := db.ExecContext(ctx, stmt, server, before, server)
+	if err != nil {
+		return fmt.Errorf("Delete \"server_disk_health_samples\": %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target server_hardware_snapshot.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e server_hardware_snapshot objects
+//generate-database:mapper stmt -e server_hardware_snapshot objects-by-Server
+//generate-database:mapper stmt -e server_hardware_snapshot create
+//
+//generate-database:mapper method -e server_hardware_snapshot GetMany
+//generate-database:mapper method -e server_hardware_snapshot Create
+
+type ServerHardwareSnapshotFilter struct {
+	Server *string
+}
+
+// DeleteServerHardwareSnapshotsExceptLatest deletes the hardware snapshots of
+// the given server, except the keep most recent ones.
+func DeleteServerHardwareSnapshotsExceptLatest(ctx context.Context, db dbtx, server string, keep int) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "Server_hardware_snapshot")
+	}()
+
+	const stmt = `DELETE FROM server_hardware_snapshots
+  WHERE server_id = (SELECT servers.id FROM servers WHERE servers.name = ?)
+  AND id NOT IN (
+    SELECT server_hardware_snapshots.id
+      FROM server_hardware_snapshots
+      JOIN servers ON server_hardware_snapshots.server_id = servers.id
+      WHERE servers.name = ?
+      ORDER BY server_hardware_snapshots.created_at DESC, server_hardware_snapshots.id DESC
+      LIMIT ?
+  )
+`
+
+	_, err := db.ExecContext(ctx, stmt, server, server, keep)
+	if err != nil {
+		return fmt.Errorf("Delete \"server_hardware_snapshots\": %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package entities
+
+// Code generation directives.
+//
+//generate-database:mapper target site.mapper.go
+//generate-database:mapper reset
+//
+//generate-database:mapper stmt -e site objects table=sites
+//generate-database:mapper stmt -e site objects-by-Name table=sites
+//generate-database:mapper stmt -e site names table=sites
+//generate-database:mapper stmt -e site id table=sites
+//generate-database:mapper stmt -e site create table=sites
+//generate-database:mapper stmt -e site update table=sites
+//generate-database:mapper stmt -e site delete-by-Name table=sites
+//
+//generate-database:mapper method -e site ID table=sites
+//generate-database:mapper method -e site Exists table=sites
+//generate-database:mapper method -e site GetOne table=sites
+//generate-database:mapper method -e site GetMany table=sites
+//generate-database:mapper method -e site GetNames table=sites
+//generate-database:mapper method -e site Create table=sites
+//generate-database:mapper method -e site Update table=sites
+//generate-database:mapper method -e site DeleteOne-by-Name table=sites
+
+type SiteFilter struct {
+	Name *string
+}
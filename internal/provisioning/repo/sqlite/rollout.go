@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+)
+
+type rollout struct {
+	db sqlite.DBTX
+}
+
+var _ provisioning.RolloutRepo = &rollout{}
+
+func NewRollout(db sqlite.DBTX) *rollout {
+	return &rollout{
+		db: db,
+	}
+}
+
+func (r rollout) Create(ctx context.Context, in provisioning.Rollout) (int64, error) {
+	return entities.CreateRollout(ctx, transaction.GetDBTX(ctx, r.db), in)
+}
+
+func (r rollout) GetAll(ctx context.Context) (provisioning.Rollouts, error) {
+	return entities.GetRollouts(ctx, transaction.GetDBTX(ctx, r.db))
+}
+
+func (r rollout) GetAllNames(ctx context.Context) ([]string, error) {
+	return entities.GetRolloutNames(ctx, transaction.GetDBTX(ctx, r.db))
+}
+
+func (r rollout) GetByName(ctx context.Context, name string) (*provisioning.Rollout, error) {
+	return entities.GetRollout(ctx, transaction.GetDBTX(ctx, r.db), name)
+}
+
+func (r rollout) Update(ctx context.Context, in provisioning.Rollout) error {
+	return transaction.ForceTx(ctx, transaction.GetDBTX(ctx, r.db), func(ctx context.Context, tx transaction.TX) error {
+		return entities.UpdateRollout(ctx, tx, in.Name, in)
+	})
+}
+
+func (r rollout) DeleteByName(ctx context.Context, name string) error {
+	return entities.DeleteRollout(ctx, transaction.GetDBTX(ctx, r.db), name)
+}
@@ -0,0 +1,139 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/dbschema"
+	dbdriver "github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestRolloutDatabaseActions(t *testing.T) {
+	rolloutA := provisioning.Rollout{
+		Name:          "A",
+		Description:   "A",
+		Reboot:        true,
+		TargetVersion: "202501020304",
+		Waves: api.RolloutWaves{
+			{
+				Name:          "lab",
+				ClusterFilter: `properties.stage == "lab"`,
+			},
+			{
+				Name:          "prod",
+				ClusterFilter: `properties.stage == "prod"`,
+				SoakTime:      "48h",
+			},
+		},
+		Status:       api.RolloutStatusRunning,
+		WaveStatus:   api.RolloutWaveStatusPending,
+		WaveClusters: provisioning.RolloutWaveClusters{},
+	}
+
+	rolloutB := provisioning.Rollout{
+		Name: "B",
+		Waves: api.RolloutWaves{
+			{
+				ClusterFilter: `true`,
+			},
+		},
+		Status:         api.RolloutStatusFailed,
+		WaveStatus:     api.RolloutWaveStatusUpdating,
+		WaveClusters:   provisioning.RolloutWaveClusters{"one", "two"},
+		WaveFinishedAt: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+		Error:          "boom",
+	}
+
+	ctx := context.Background()
+
+	// Create a new temporary database.
+	tmpDir := t.TempDir()
+	db, err := dbdriver.Open(tmpDir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = db.Close()
+		require.NoError(t, err)
+	})
+
+	_, err = dbschema.Ensure(ctx, db, tmpDir)
+	require.NoError(t, err)
+
+	tx := transaction.Enable(db)
+	entities.PreparedStmts, err = entities.PrepareStmts(tx, false)
+	require.NoError(t, err)
+
+	rollout := sqlite.NewRollout(tx)
+
+	// Add rollouts
+	_, err = rollout.Create(ctx, rolloutA)
+	require.NoError(t, err)
+	_, err = rollout.Create(ctx, rolloutB)
+	require.NoError(t, err)
+
+	// Ensure we have two entries
+	rollouts, err := rollout.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, rollouts, 2)
+
+	rolloutNames, err := rollout.GetAllNames(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"A", "B"}, rolloutNames)
+
+	// Should get back rolloutA unchanged.
+	dbRolloutA, err := rollout.GetByName(ctx, rolloutA.Name)
+	require.NoError(t, err)
+	rolloutA.ID = dbRolloutA.ID
+	rolloutA.LastUpdated = dbRolloutA.LastUpdated
+	require.Equal(t, rolloutA, *dbRolloutA)
+
+	dbRolloutB, err := rollout.GetByName(ctx, rolloutB.Name)
+	require.NoError(t, err)
+	rolloutB.ID = dbRolloutB.ID
+	rolloutB.LastUpdated = dbRolloutB.LastUpdated
+	require.Equal(t, rolloutB, *dbRolloutB)
+
+	// Test updating a rollout.
+	rolloutA.CurrentWave = 1
+	rolloutA.WaveStatus = api.RolloutWaveStatusSoaking
+	rolloutA.WaveClusters = provisioning.RolloutWaveClusters{"three"}
+	rolloutA.WaveFinishedAt = time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC)
+	err = rollout.Update(ctx, rolloutA)
+	require.NoError(t, err)
+	dbRolloutA, err = rollout.GetByName(ctx, rolloutA.Name)
+	require.NoError(t, err)
+	rolloutA.LastUpdated = dbRolloutA.LastUpdated
+	require.Equal(t, rolloutA, *dbRolloutA)
+
+	// Delete a rollout.
+	err = rollout.DeleteByName(ctx, rolloutA.Name)
+	require.NoError(t, err)
+	_, err = rollout.GetByName(ctx, rolloutA.Name)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Should have one rollout remaining.
+	rollouts, err = rollout.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, rollouts, 1)
+
+	// Can't delete a rollout that doesn't exist.
+	err = rollout.DeleteByName(ctx, "three")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't update a rollout that doesn't exist.
+	err = rollout.Update(ctx, rolloutA)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't add a duplicate rollout.
+	_, err = rollout.Create(ctx, rolloutB)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	incustls "github.com/lxc/incus/v7/shared/tls"
 
 	"github.com/FuturFusion/operations-center/internal/domain"
@@ -163,3 +165,55 @@ func (s server) Rename(ctx context.Context, oldName string, newName string) erro
 func (s server) DeleteByName(ctx context.Context, name string) error {
 	return entities.DeleteServer(ctx, transaction.GetDBTX(ctx, s.db), name)
 }
+
+func (s server) CreateHardwareSnapshot(ctx context.Context, in provisioning.ServerHardwareSnapshot) (int64, error) {
+	return entities.CreateServerHardwareSnapshot(ctx, transaction.GetDBTX(ctx, s.db), in)
+}
+
+func (s server) GetHardwareSnapshotsByName(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+	return entities.GetServerHardwareSnapshots(ctx, transaction.GetDBTX(ctx, s.db), entities.ServerHardwareSnapshotFilter{
+		Server: &name,
+	})
+}
+
+func (s server) DeleteHardwareSnapshotsExceptLatestByName(ctx context.Context, name string, keep int) error {
+	return entities.DeleteServerHardwareSnapshotsExceptLatest(ctx, transaction.GetDBTX(ctx, s.db), name, keep)
+}
+
+func (s server) CreateDiskHealthSample(ctx context.Context, in provisioning.ServerDiskHealthSample) (int64, error) {
+	return entities.CreateServerDiskHealthSample(ctx, transaction.GetDBTX(ctx, s.db), in)
+}
+
+func (s server) GetDiskHealthSamplesByName(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error) {
+	return entities.GetServerDiskHealthSamples(ctx, transaction.GetDBTX(ctx, s.db), entities.ServerDiskHealthSampleFilter{
+		Server: &name,
+	})
+}
+
+func (s server) DeleteDiskHealthSamplesRecordedBeforeByName(ctx context.Context, name string, before time.Time) error {
+	return entities.DeleteServerDiskHealthSamplesRecordedBefore(ctx, transaction.GetDBTX(ctx, s.db), name, before)
+}
+
+func (s server) CreateDecommission(ctx context.Context, in provisioning.ServerDecommission) (int64, error) {
+	return entities.CreateServerDecommission(ctx, transaction.GetDBTX(ctx, s.db), in)
+}
+
+func (s server) UpdateDecommission(ctx context.Context, in provisioning.ServerDecommission) error {
+	return transaction.ForceTx(ctx, transaction.GetDBTX(ctx, s.db), func(ctx context.Context, tx transaction.TX) error {
+		return entities.UpdateServerDecommission(ctx, tx, in.UUID, in)
+	})
+}
+
+func (s server) GetDecommissions(ctx context.Context) (provisioning.ServerDecommissions, error) {
+	return entities.GetServerDecommissions(ctx, transaction.GetDBTX(ctx, s.db))
+}
+
+func (s server) GetDecommissionsByName(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+	return entities.GetServerDecommissions(ctx, transaction.GetDBTX(ctx, s.db), entities.ServerDecommissionFilter{
+		Server: &name,
+	})
+}
+
+func (s server) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+	return entities.GetServerDecommission(ctx, transaction.GetDBTX(ctx, s.db), id)
+}
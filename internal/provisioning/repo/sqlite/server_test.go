@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	incusosapi "github.com/lxc/incus-os/incus-osd/api"
 	incustls "github.com/lxc/incus/v7/shared/tls"
 	"github.com/stretchr/testify/require"
@@ -286,12 +288,151 @@ func TestServerDatabaseActions(t *testing.T) {
 	serverB.LastUpdated = dbServerB.LastUpdated
 	require.Equal(t, serverB, *dbServerB)
 
+	// Record hardware snapshots.
+	snapshot := provisioning.ServerHardwareSnapshot{
+		Server:       serverA.Name,
+		HardwareData: serverA.HardwareData,
+		Changes: api.HardwareChanges{
+			{
+				Component:  api.HardwareComponentDisk,
+				Action:     api.HardwareChangeActionRemoved,
+				Identifier: "S1",
+				Previous:   "Disk (1.00TB)",
+			},
+		},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	snapshot.ID, err = server.CreateHardwareSnapshot(ctx, snapshot)
+	require.NoError(t, err)
+
+	snapshots, err := server.GetHardwareSnapshotsByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, snapshot, snapshots[0])
+
+	snapshots, err = server.GetHardwareSnapshotsByName(ctx, serverB.Name)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+
+	// Only keep the most recent hardware snapshot.
+	newerSnapshot := snapshot
+	newerSnapshot.Changes = api.HardwareChanges{}
+	newerSnapshot.CreatedAt = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	newerSnapshot.ID, err = server.CreateHardwareSnapshot(ctx, newerSnapshot)
+	require.NoError(t, err)
+
+	err = server.DeleteHardwareSnapshotsExceptLatestByName(ctx, serverA.Name, 1)
+	require.NoError(t, err)
+
+	snapshots, err = server.GetHardwareSnapshotsByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Equal(t, provisioning.ServerHardwareSnapshots{newerSnapshot}, snapshots)
+
+	// Record disk health samples.
+	sample := provisioning.ServerDiskHealthSample{
+		Server:             serverA.Name,
+		Drive:              "S1",
+		Passed:             true,
+		PercentageUsed:     12,
+		AvailableSpare:     100,
+		ReallocatedSectors: 0,
+		PowerOnHours:       1000,
+		RecordedAt:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	sample.ID, err = server.CreateDiskHealthSample(ctx, sample)
+	require.NoError(t, err)
+
+	samples, err := server.GetDiskHealthSamplesByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Equal(t, provisioning.ServerDiskHealthSamples{sample}, samples)
+
+	// Remove outdated disk health samples, the most recent sample of each drive is kept.
+	newerSample := sample
+	newerSample.PercentageUsed = 13
+	newerSample.RecordedAt = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	newerSample.ID, err = server.CreateDiskHealthSample(ctx, newerSample)
+	require.NoError(t, err)
+
+	otherDriveSample := sample
+	otherDriveSample.Drive = "S2"
+	otherDriveSample.RecordedAt = time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	otherDriveSample.ID, err = server.CreateDiskHealthSample(ctx, otherDriveSample)
+	require.NoError(t, err)
+
+	err = server.DeleteDiskHealthSamplesRecordedBeforeByName(ctx, serverA.Name, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	samples, err = server.GetDiskHealthSamplesByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Equal(t, provisioning.ServerDiskHealthSamples{newerSample, otherDriveSample}, samples)
+
+	// Record a decommission.
+	decommission := provisioning.ServerDecommission{
+		UUID:   uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861"),
+		Server: serverA.Name,
+		Options: api.ServerDecommissionOptions{
+			Evacuate: true,
+			Delete:   true,
+		},
+		Status:    api.ServerDecommissionStatusRunning,
+		Steps:     api.ServerDecommissionStepResults{},
+		StartedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	decommission.ID, err = server.CreateDecommission(ctx, decommission)
+	require.NoError(t, err)
+
+	decommission.Status = api.ServerDecommissionStatusSucceeded
+	decommission.Steps = api.ServerDecommissionStepResults{
+		{
+			Step:       api.ServerDecommissionStepEvacuate,
+			Status:     api.ServerDecommissionStepStatusSkipped,
+			Detail:     "server is not part of a cluster",
+			StartedAt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			FinishedAt: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+		},
+	}
+	decommission.FinishedAt = time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC)
+
+	err = server.UpdateDecommission(ctx, decommission)
+	require.NoError(t, err)
+
+	// Can't add a duplicate decommission.
+	_, err = server.CreateDecommission(ctx, decommission)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+
 	// Delete a server.
 	err = server.DeleteByName(ctx, serverA.Name)
 	require.NoError(t, err)
 	_, err = server.GetByName(ctx, serverA.Name)
 	require.ErrorIs(t, err, domain.ErrNotFound)
 
+	// Decommission records are kept after the server has been deleted.
+	decommissions, err := server.GetDecommissionsByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Equal(t, provisioning.ServerDecommissions{decommission}, decommissions)
+
+	decommissions, err = server.GetDecommissions(ctx)
+	require.NoError(t, err)
+	require.Len(t, decommissions, 1)
+
+	gotDecommission, err := server.GetDecommissionByUUID(ctx, decommission.UUID)
+	require.NoError(t, err)
+	require.Equal(t, decommission, *gotDecommission)
+
+	_, err = server.GetDecommissionByUUID(ctx, uuid.New())
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Hardware snapshots and disk health samples are removed together with the server.
+	snapshots, err = server.GetHardwareSnapshotsByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+	samples, err = server.GetDiskHealthSamplesByName(ctx, serverA.Name)
+	require.NoError(t, err)
+	require.Empty(t, samples)
+
 	// Should have one servers remaining.
 	servers, err = server.GetAll(ctx)
 	require.NoError(t, err)
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+)
+
+type site struct {
+	db sqlite.DBTX
+}
+
+var _ provisioning.SiteRepo = &site{}
+
+func NewSite(db sqlite.DBTX) *site {
+	return &site{
+		db: db,
+	}
+}
+
+func (r site) Create(ctx context.Context, in provisioning.Site) (int64, error) {
+	return entities.CreateSite(ctx, transaction.GetDBTX(ctx, r.db), in)
+}
+
+func (r site) GetAll(ctx context.Context) (provisioning.Sites, error) {
+	return entities.GetSites(ctx, transaction.GetDBTX(ctx, r.db))
+}
+
+func (r site) GetAllNames(ctx context.Context) ([]string, error) {
+	return entities.GetSiteNames(ctx, transaction.GetDBTX(ctx, r.db))
+}
+
+func (r site) GetByName(ctx context.Context, name string) (*provisioning.Site, error) {
+	return entities.GetSite(ctx, transaction.GetDBTX(ctx, r.db), name)
+}
+
+func (r site) Update(ctx context.Context, in provisioning.Site) error {
+	return transaction.ForceTx(ctx, transaction.GetDBTX(ctx, r.db), func(ctx context.Context, tx transaction.TX) error {
+		return entities.UpdateSite(ctx, tx, in.Name, in)
+	})
+}
+
+func (r site) DeleteByName(ctx context.Context, name string) error {
+	return entities.DeleteSite(ctx, transaction.GetDBTX(ctx, r.db), name)
+}
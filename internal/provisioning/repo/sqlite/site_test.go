@@ -0,0 +1,126 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite"
+	"github.com/FuturFusion/operations-center/internal/provisioning/repo/sqlite/entities"
+	"github.com/FuturFusion/operations-center/internal/sql/dbschema"
+	dbdriver "github.com/FuturFusion/operations-center/internal/sql/sqlite"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestSiteDatabaseActions(t *testing.T) {
+	siteA := provisioning.Site{
+		Name:        "A",
+		Description: "A",
+		Channel:     "stable",
+		Properties: api.ConfigMap{
+			"region": "eu",
+		},
+		Config: api.SiteConfig{
+			MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+				{
+					Weekdays:  []string{"saturday"},
+					StartTime: "22:00",
+					EndTime:   "04:00",
+					Timezone:  "UTC",
+				},
+			},
+		},
+	}
+
+	siteB := provisioning.Site{
+		Name:       "B",
+		Channel:    "stable",
+		Properties: api.ConfigMap{},
+	}
+
+	ctx := context.Background()
+
+	// Create a new temporary database.
+	tmpDir := t.TempDir()
+	db, err := dbdriver.Open(tmpDir)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = db.Close()
+		require.NoError(t, err)
+	})
+
+	_, err = dbschema.Ensure(ctx, db, tmpDir)
+	require.NoError(t, err)
+
+	tx := transaction.Enable(db)
+	entities.PreparedStmts, err = entities.PrepareStmts(tx, false)
+	require.NoError(t, err)
+
+	site := sqlite.NewSite(tx)
+
+	// Add sites
+	_, err = site.Create(ctx, siteA)
+	require.NoError(t, err)
+	_, err = site.Create(ctx, siteB)
+	require.NoError(t, err)
+
+	// Ensure we have two entries
+	sites, err := site.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, sites, 2)
+
+	siteNames, err := site.GetAllNames(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"A", "B"}, siteNames)
+
+	// Should get back siteA unchanged.
+	dbSiteA, err := site.GetByName(ctx, siteA.Name)
+	require.NoError(t, err)
+	siteA.ID = dbSiteA.ID
+	siteA.LastUpdated = dbSiteA.LastUpdated
+	require.Equal(t, siteA, *dbSiteA)
+
+	dbSiteB, err := site.GetByName(ctx, siteB.Name)
+	require.NoError(t, err)
+	siteB.ID = dbSiteB.ID
+	siteB.LastUpdated = dbSiteB.LastUpdated
+	require.Equal(t, siteB, *dbSiteB)
+
+	// Test updating a site.
+	siteA.Description = "Site A"
+	siteA.Config.MaintenanceWindows = nil
+	err = site.Update(ctx, siteA)
+	require.NoError(t, err)
+	dbSiteA, err = site.GetByName(ctx, siteA.Name)
+	require.NoError(t, err)
+	siteA.LastUpdated = dbSiteA.LastUpdated
+	require.Equal(t, siteA, *dbSiteA)
+
+	// Delete a site.
+	err = site.DeleteByName(ctx, siteA.Name)
+	require.NoError(t, err)
+	_, err = site.GetByName(ctx, siteA.Name)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Should have one site remaining.
+	sites, err = site.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+
+	// Can't delete a site that doesn't exist.
+	err = site.DeleteByName(ctx, "three")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't update a site that doesn't exist.
+	err = site.Update(ctx, siteA)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Can't add a duplicate site.
+	_, err = site.Create(ctx, siteB)
+	require.ErrorIs(t, err, domain.ErrConstraintViolation)
+}
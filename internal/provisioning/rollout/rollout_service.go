@@ -0,0 +1,440 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type rolloutService struct {
+	repo       provisioning.RolloutRepo
+	clusterSvc provisioning.ClusterService
+	updateSvc  provisioning.UpdateService
+
+	now func() time.Time
+}
+
+var _ provisioning.RolloutService = &rolloutService{}
+
+type Option func(s *rolloutService)
+
+func WithNow(nowFunc func() time.Time) Option {
+	return func(s *rolloutService) {
+		s.now = nowFunc
+	}
+}
+
+func New(
+	repo provisioning.RolloutRepo,
+	clusterSvc provisioning.ClusterService,
+	updateSvc provisioning.UpdateService,
+	opts ...Option,
+) *rolloutService {
+	rolloutSvc := &rolloutService{
+		repo:       repo,
+		clusterSvc: clusterSvc,
+		updateSvc:  updateSvc,
+
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+
+	for _, opt := range opts {
+		opt(rolloutSvc)
+	}
+
+	return rolloutSvc
+}
+
+// Create validates and starts a new rollout. The cluster filter expressions of
+// all the waves are evaluated upfront, such that an invalid expression is
+// reported immediately and not only when the rollout reaches the wave.
+//
+// The most recent update, which is available in the channels of the matching
+// clusters, is recorded as the target version of the rollout. This pins the
+// rollout to the updates known at creation time, such that the later waves do
+// not roll out an update, which has not been soaked by the earlier waves.
+func (s rolloutService) Create(ctx context.Context, newRollout provisioning.Rollout) (provisioning.Rollout, error) {
+	err := newRollout.Validate()
+	if err != nil {
+		return provisioning.Rollout{}, err
+	}
+
+	channels := map[string]struct{}{}
+	for i, wave := range newRollout.Waves {
+		clusters, err := s.clusterSvc.GetAllWithFilter(ctx, provisioning.ClusterFilter{
+			Expression: &wave.ClusterFilter,
+		})
+		if err != nil {
+			return provisioning.Rollout{}, fmt.Errorf("Invalid cluster filter of wave %d: %w", i, err)
+		}
+
+		for _, cluster := range clusters {
+			channels[cluster.Channel] = struct{}{}
+		}
+	}
+
+	newRollout.TargetVersion = ""
+	for _, channel := range slices.Sorted(maps.Keys(channels)) {
+		updates, err := s.updateSvc.GetAllWithFilter(ctx, provisioning.UpdateFilter{
+			Channel: &channel,
+		})
+		if err != nil {
+			return provisioning.Rollout{}, fmt.Errorf("Failed to get updates for channel %q: %w", channel, err)
+		}
+
+		for _, update := range updates {
+			if api.AvailableVersionGreaterThan(newRollout.TargetVersion, update.Version) {
+				newRollout.TargetVersion = update.Version
+			}
+		}
+	}
+
+	if newRollout.TargetVersion == "" {
+		return provisioning.Rollout{}, fmt.Errorf("No update available in the channels of the clusters of the rollout: %w", domain.ErrOperationNotPermitted)
+	}
+
+	newRollout.Status = api.RolloutStatusRunning
+	newRollout.CurrentWave = 0
+	newRollout.WaveStatus = api.RolloutWaveStatusPending
+	newRollout.WaveClusters = provisioning.RolloutWaveClusters{}
+	newRollout.WaveFinishedAt = time.Time{}
+	newRollout.Error = ""
+
+	newRollout.ID, err = s.repo.Create(ctx, newRollout)
+	if err != nil {
+		return provisioning.Rollout{}, err
+	}
+
+	return newRollout, nil
+}
+
+func (s rolloutService) GetAll(ctx context.Context) (provisioning.Rollouts, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s rolloutService) GetAllNames(ctx context.Context) ([]string, error) {
+	return s.repo.GetAllNames(ctx)
+}
+
+func (s rolloutService) GetByName(ctx context.Context, name string) (*provisioning.Rollout, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Rollout name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return s.repo.GetByName(ctx, name)
+}
+
+// GetTargetVersionByCluster returns the target version of the running rollout,
+// the given cluster is part of the current wave of. If the cluster is not part
+// of any running rollout, an empty string is returned.
+//
+// The updates offered to the servers of the cluster are limited to this
+// version, such that the cluster update launched by the rollout applies the
+// version the rollout has been pinned to, even if the channel of the cluster
+// has received a newer update in the meantime.
+func (s rolloutService) GetTargetVersionByCluster(ctx context.Context, clusterName string) (string, error) {
+	rollouts, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get rollouts: %w", err)
+	}
+
+	var targetVersion string
+	for _, rollout := range rollouts {
+		if rollout.Status != api.RolloutStatusRunning || !slices.Contains(rollout.WaveClusters, clusterName) {
+			continue
+		}
+
+		// If the cluster is part of multiple running rollouts, the oldest
+		// target version wins.
+		if targetVersion == "" || api.AvailableVersionGreaterThan(rollout.TargetVersion, targetVersion) {
+			targetVersion = rollout.TargetVersion
+		}
+	}
+
+	return targetVersion, nil
+}
+
+// Cancel stops a running rollout. Cluster updates, which have already been
+// launched by the rollout, are not affected.
+func (s rolloutService) Cancel(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("Rollout name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		rollout, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get rollout %q: %w", name, err)
+		}
+
+		if rollout.Status != api.RolloutStatusRunning {
+			return fmt.Errorf("Rollout %q is not running: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		rollout.Status = api.RolloutStatusCanceled
+
+		return s.repo.Update(ctx, *rollout)
+	})
+}
+
+func (s rolloutService) DeleteByName(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("Rollout name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		rollout, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get rollout %q: %w", name, err)
+		}
+
+		if rollout.Status == api.RolloutStatusRunning {
+			return fmt.Errorf("Running rollout %q can not be deleted, cancel it first: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		err = s.repo.DeleteByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to delete rollout: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RolloutControlLoop advances all the running rollouts by one step:
+//
+//   - A pending wave is started by launching the cluster update on all the
+//     clusters matching the cluster filter of the wave.
+//   - An updating wave is checked for the cluster updates to be finished. If
+//     any of the cluster updates ends in an error, the rollout is stopped.
+//   - A soaking wave waits for the soak time to pass, before the rollout moves
+//     on to the next wave or, if it was the last wave, succeeds.
+func (s rolloutService) RolloutControlLoop(ctx context.Context) error {
+	rollouts, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get rollouts: %w", err)
+	}
+
+	var errs []error
+	for _, rollout := range rollouts {
+		if rollout.Status != api.RolloutStatusRunning {
+			continue
+		}
+
+		err = s.step(ctx, rollout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to process rollout %q: %w", rollout.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s rolloutService) step(ctx context.Context, rollout provisioning.Rollout) error {
+	log := slog.With(slog.String("rollout", rollout.Name), slog.Int("wave", rollout.CurrentWave))
+
+	switch rollout.WaveStatus {
+	case api.RolloutWaveStatusPending:
+		log.InfoContext(ctx, "Starting rollout wave")
+		return s.startWave(ctx, rollout)
+
+	case api.RolloutWaveStatusUpdating:
+		return s.checkWave(ctx, rollout)
+
+	case api.RolloutWaveStatusSoaking:
+		soakDuration, err := rollout.Wave().SoakDuration()
+		if err != nil {
+			return s.fail(ctx, rollout, fmt.Sprintf("Invalid soak time of wave %d: %v", rollout.CurrentWave, err))
+		}
+
+		if s.now().Before(rollout.WaveFinishedAt.Add(soakDuration)) {
+			return nil
+		}
+
+		if rollout.IsLastWave() {
+			log.InfoContext(ctx, "Rollout completed")
+			rollout.Status = api.RolloutStatusSucceeded
+			return s.update(ctx, rollout)
+		}
+
+		rollout.CurrentWave++
+		rollout.WaveStatus = api.RolloutWaveStatusPending
+		rollout.WaveClusters = provisioning.RolloutWaveClusters{}
+		rollout.WaveFinishedAt = time.Time{}
+
+		return s.update(ctx, rollout)
+
+	default:
+		return s.fail(ctx, rollout, fmt.Sprintf("Unknown wave status %q", rollout.WaveStatus))
+	}
+}
+
+// startWave launches the cluster update on all the clusters of the current
+// wave, which are not up to date. Clusters, which already have a cluster
+// update in progress, are not launched again, but the rollout waits for them
+// as well. Clusters, which are already up to date, are not part of the wave.
+//
+// All the clusters of the wave are validated, before any cluster update is
+// launched, such that the rollout fails without touching any of the clusters,
+// if one of them is not ready for the cluster update. The clusters of the wave
+// are recorded before the cluster updates are launched, such that the servers
+// of these clusters are only offered the updates up to the target version of
+// the rollout (see GetTargetVersionByCluster). If launching any of the cluster
+// updates fails, the cluster updates already launched for the wave are
+// aborted, such that the wave is never left partially launched.
+func (s rolloutService) startWave(ctx context.Context, rollout provisioning.Rollout) error {
+	wave := rollout.Wave()
+
+	clusters, err := s.clusterSvc.GetAllWithFilter(ctx, provisioning.ClusterFilter{
+		Expression: &wave.ClusterFilter,
+	})
+	if err != nil {
+		return s.fail(ctx, rollout, fmt.Sprintf("Failed to get clusters of wave %d: %v", rollout.CurrentWave, err))
+	}
+
+	rollout.WaveClusters = make(provisioning.RolloutWaveClusters, 0, len(clusters))
+	rollout.WaveStatus = api.RolloutWaveStatusUpdating
+
+	var launchClusterNames []string
+	for _, cluster := range clusters {
+		switch cluster.UpdateStatus.InProgressStatus.InProgress {
+		case api.ClusterUpdateInProgressError:
+			return s.fail(ctx, rollout, fmt.Sprintf("Cluster update of %q failed: %s", cluster.Name, cluster.UpdateStatus.InProgressStatus.Error))
+
+		case api.ClusterUpdateInProgressApplyUpdate, api.ClusterUpdateInProgressApplyUpdateWithReboot:
+			// Wait for the cluster update, which is already in progress.
+
+		case api.ClusterUpdateInProgressInactive:
+			if len(cluster.UpdateStatus.NeedsUpdate) == 0 && len(cluster.UpdateStatus.NeedsReboot) == 0 {
+				continue
+			}
+
+			_, err := s.clusterSvc.PlanClusterUpdate(ctx, cluster.Name, rollout.Reboot)
+			if err != nil {
+				return s.fail(ctx, rollout, fmt.Sprintf("Cluster %q is not ready for the cluster update: %v", cluster.Name, err))
+			}
+
+			launchClusterNames = append(launchClusterNames, cluster.Name)
+
+		default:
+			return s.fail(ctx, rollout, fmt.Sprintf("Cluster %q has another operation in progress: %s", cluster.Name, cluster.UpdateStatus.InProgressStatus.InProgress))
+		}
+
+		rollout.WaveClusters = append(rollout.WaveClusters, cluster.Name)
+	}
+
+	err = s.update(ctx, rollout)
+	if err != nil {
+		return err
+	}
+
+	for i, clusterName := range launchClusterNames {
+		err = s.clusterSvc.LaunchClusterUpdate(ctx, clusterName, rollout.Reboot)
+		if err != nil {
+			s.abortClusterUpdates(ctx, rollout, launchClusterNames[:i])
+			return s.fail(ctx, rollout, fmt.Sprintf("Failed to launch cluster update of %q: %v", clusterName, err))
+		}
+	}
+
+	return nil
+}
+
+// abortClusterUpdates aborts the cluster updates, which have been launched for
+// the current wave. Errors are only logged, since the rollout fails anyway.
+func (s rolloutService) abortClusterUpdates(ctx context.Context, rollout provisioning.Rollout, clusterNames []string) {
+	for _, clusterName := range clusterNames {
+		err := s.clusterSvc.AbortClusterOperation(ctx, clusterName)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to abort cluster update of rollout wave", slog.String("rollout", rollout.Name), slog.Int("wave", rollout.CurrentWave), slog.String("cluster", clusterName), logger.Err(err))
+		}
+	}
+}
+
+// checkWave checks the cluster updates of the current wave. As soon as all of
+// them have completed, the soak time of the wave starts. A cluster update has
+// only completed, if the most recent operation of the cluster has succeeded
+// and the cluster is up to date. A cluster update, which has failed, has been
+// canceled or did not bring the cluster up to date, stops the rollout.
+func (s rolloutService) checkWave(ctx context.Context, rollout provisioning.Rollout) error {
+	for _, clusterName := range rollout.WaveClusters {
+		cluster, err := s.clusterSvc.GetByName(ctx, clusterName)
+		if errors.Is(err, domain.ErrNotFound) {
+			// The cluster has been removed in the meantime.
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster %q: %w", clusterName, err)
+		}
+
+		if cluster.UpdateStatus.InProgressStatus.InProgress == api.ClusterUpdateInProgressError {
+			return s.fail(ctx, rollout, fmt.Sprintf("Cluster update of %q failed: %s", cluster.Name, cluster.UpdateStatus.InProgressStatus.Error))
+		}
+
+		if cluster.IsUpdateInProgress() {
+			return nil
+		}
+
+		operations, err := s.clusterSvc.GetOperationAll(ctx, clusterName)
+		if err != nil {
+			return fmt.Errorf("Failed to get operations of cluster %q: %w", clusterName, err)
+		}
+
+		// Operations are ordered from the most recent to the oldest.
+		if len(operations) > 0 && operations[0].Status != api.ClusterOperationStatusSucceeded {
+			return s.fail(ctx, rollout, fmt.Sprintf("Cluster update of %q did not complete: %s %s", cluster.Name, operations[0].Type, operations[0].Status))
+		}
+
+		if len(cluster.UpdateStatus.NeedsUpdate) > 0 {
+			return s.fail(ctx, rollout, fmt.Sprintf("Cluster update of %q did not complete: servers %s still need an update", cluster.Name, strings.Join(cluster.UpdateStatus.NeedsUpdate, ", ")))
+		}
+
+		if rollout.Reboot && len(cluster.UpdateStatus.NeedsReboot) > 0 {
+			return s.fail(ctx, rollout, fmt.Sprintf("Cluster update of %q did not complete: servers %s still need a reboot", cluster.Name, strings.Join(cluster.UpdateStatus.NeedsReboot, ", ")))
+		}
+	}
+
+	rollout.WaveStatus = api.RolloutWaveStatusSoaking
+	rollout.WaveFinishedAt = s.now()
+
+	return s.update(ctx, rollout)
+}
+
+func (s rolloutService) fail(ctx context.Context, rollout provisioning.Rollout, reason string) error {
+	slog.WarnContext(ctx, "Rollout failed", slog.String("rollout", rollout.Name), slog.Int("wave", rollout.CurrentWave), slog.String("reason", reason))
+
+	rollout.Status = api.RolloutStatusFailed
+	rollout.Error = reason
+
+	return s.update(ctx, rollout)
+}
+
+// update persists the progress of the rollout, unless the rollout has been
+// canceled in the meantime.
+func (s rolloutService) update(ctx context.Context, rollout provisioning.Rollout) error {
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetByName(ctx, rollout.Name)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != api.RolloutStatusRunning {
+			return nil
+		}
+
+		return s.repo.Update(ctx, rollout)
+	})
+}
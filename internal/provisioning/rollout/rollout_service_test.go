@@ -0,0 +1,953 @@
+package rollout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	svcMock "github.com/FuturFusion/operations-center/internal/provisioning/mock"
+	repoMock "github.com/FuturFusion/operations-center/internal/provisioning/repo/mock"
+	provisioningRollout "github.com/FuturFusion/operations-center/internal/provisioning/rollout"
+	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestRolloutService_Create(t *testing.T) {
+	tests := []struct {
+		name                          string
+		rollout                       provisioning.Rollout
+		clusterSvcGetAllWithFilter    provisioning.Clusters
+		clusterSvcGetAllWithFilterErr error
+		updateSvcGetAllWithFilter     map[string]provisioning.Updates
+		updateSvcGetAllWithFilterErr  error
+		repoCreateErr                 error
+
+		assertErr         require.ErrorAssertionFunc
+		wantTargetVersion string
+	}{
+		{
+			name: "success",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						Name:          "lab",
+						ClusterFilter: `properties.stage == "lab"`,
+						SoakTime:      "48h",
+					},
+				},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				{Name: "one", Channel: "stable"},
+				{Name: "two", Channel: "testing"},
+			},
+			updateSvcGetAllWithFilter: map[string]provisioning.Updates{
+				"stable":  {{Version: "202501020304"}, {Version: "202412020304"}},
+				"testing": {{Version: "202501100304"}},
+			},
+
+			assertErr:         require.NoError,
+			wantTargetVersion: "202501100304",
+		},
+		{
+			name: "error - validation",
+			rollout: provisioning.Rollout{
+				Name: "one", // no waves
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid soak time",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+						SoakTime:      "invalid", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - clusterSvc.GetAllWithFilter",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `invalid expression`,
+					},
+				},
+			},
+			clusterSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - updateSvc.GetAllWithFilter",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+					},
+				},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				{Name: "one", Channel: "stable"},
+			},
+			updateSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - no update available",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+					},
+				},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				{Name: "one", Channel: "stable"},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name: "error - repo.Create",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+					},
+				},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				{Name: "one", Channel: "stable"},
+			},
+			updateSvcGetAllWithFilter: map[string]provisioning.Updates{
+				"stable": {{Version: "202501020304"}},
+			},
+			repoCreateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var gotRollout provisioning.Rollout
+			repo := &repoMock.RolloutRepoMock{
+				CreateFunc: func(ctx context.Context, rollout provisioning.Rollout) (int64, error) {
+					gotRollout = rollout
+					return 1, tc.repoCreateErr
+				},
+			}
+
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ClusterFilter) (provisioning.Clusters, error) {
+					return tc.clusterSvcGetAllWithFilter, tc.clusterSvcGetAllWithFilterErr
+				},
+			}
+
+			updateSvc := &svcMock.UpdateServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.UpdateFilter) (provisioning.Updates, error) {
+					return tc.updateSvcGetAllWithFilter[*filter.Channel], tc.updateSvcGetAllWithFilterErr
+				},
+			}
+
+			rolloutSvc := provisioningRollout.New(repo, clusterSvc, updateSvc)
+
+			// Run test
+			rollout, err := rolloutSvc.Create(t.Context(), tc.rollout)
+
+			// Assert
+			tc.assertErr(t, err)
+			if err == nil {
+				require.Equal(t, int64(1), rollout.ID)
+				require.Equal(t, api.RolloutStatusRunning, gotRollout.Status)
+				require.Equal(t, api.RolloutWaveStatusPending, gotRollout.WaveStatus)
+				require.Equal(t, 0, gotRollout.CurrentWave)
+				require.Equal(t, tc.wantTargetVersion, gotRollout.TargetVersion)
+			}
+		})
+	}
+}
+
+func TestRolloutService_GetTargetVersionByCluster(t *testing.T) {
+	tests := []struct {
+		name          string
+		clusterName   string
+		repoGetAll    provisioning.Rollouts
+		repoGetAllErr error
+
+		assertErr         require.ErrorAssertionFunc
+		wantTargetVersion string
+	}{
+		{
+			name:        "success",
+			clusterName: "one",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "running", Status: api.RolloutStatusRunning, TargetVersion: "202501100304", WaveClusters: provisioning.RolloutWaveClusters{"one", "two"}},
+				{Name: "older", Status: api.RolloutStatusRunning, TargetVersion: "202501020304", WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+				{Name: "other cluster", Status: api.RolloutStatusRunning, TargetVersion: "202412020304", WaveClusters: provisioning.RolloutWaveClusters{"two"}},
+				{Name: "canceled", Status: api.RolloutStatusCanceled, TargetVersion: "202412020304", WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+
+			assertErr:         require.NoError,
+			wantTargetVersion: "202501020304",
+		},
+		{
+			name:        "success - cluster not part of a running rollout",
+			clusterName: "one",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "succeeded", Status: api.RolloutStatusSucceeded, TargetVersion: "202501100304", WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name:          "error - repo.GetAll",
+			clusterName:   "one",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.RolloutRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Rollouts, error) {
+					return tc.repoGetAll, tc.repoGetAllErr
+				},
+			}
+
+			rolloutSvc := provisioningRollout.New(repo, nil, nil)
+
+			// Run test
+			targetVersion, err := rolloutSvc.GetTargetVersionByCluster(t.Context(), tc.clusterName)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantTargetVersion, targetVersion)
+		})
+	}
+}
+
+func TestRolloutService_Cancel(t *testing.T) {
+	tests := []struct {
+		name             string
+		rolloutName      string
+		repoGetByName    *provisioning.Rollout
+		repoGetByNameErr error
+		repoUpdateErr    error
+
+		assertErr  require.ErrorAssertionFunc
+		wantStatus api.RolloutStatus
+	}{
+		{
+			name:        "success",
+			rolloutName: "one",
+			repoGetByName: &provisioning.Rollout{
+				Name:   "one",
+				Status: api.RolloutStatusRunning,
+			},
+
+			assertErr:  require.NoError,
+			wantStatus: api.RolloutStatusCanceled,
+		},
+		{
+			name:        "error - empty name",
+			rolloutName: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:             "error - repo.GetByName",
+			rolloutName:      "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:        "error - not running",
+			rolloutName: "one",
+			repoGetByName: &provisioning.Rollout{
+				Name:   "one",
+				Status: api.RolloutStatusFailed,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:        "error - repo.Update",
+			rolloutName: "one",
+			repoGetByName: &provisioning.Rollout{
+				Name:   "one",
+				Status: api.RolloutStatusRunning,
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var gotStatus api.RolloutStatus
+			repo := &repoMock.RolloutRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Rollout, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, rollout provisioning.Rollout) error {
+					gotStatus = rollout.Status
+					return tc.repoUpdateErr
+				},
+			}
+
+			rolloutSvc := provisioningRollout.New(repo, nil, nil)
+
+			// Run test
+			err := rolloutSvc.Cancel(t.Context(), tc.rolloutName)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantStatus, gotStatus)
+		})
+	}
+}
+
+func TestRolloutService_DeleteByName(t *testing.T) {
+	tests := []struct {
+		name             string
+		rolloutName      string
+		repoGetByName    *provisioning.Rollout
+		repoGetByNameErr error
+		repoDeleteErr    error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:        "success",
+			rolloutName: "one",
+			repoGetByName: &provisioning.Rollout{
+				Name:   "one",
+				Status: api.RolloutStatusSucceeded,
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name:        "error - empty name",
+			rolloutName: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:             "error - repo.GetByName",
+			rolloutName:      "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:        "error - running",
+			rolloutName: "one",
+			repoGetByName: &provisioning.Rollout{
+				Name:   "one",
+				Status: api.RolloutStatusRunning,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:        "error - repo.DeleteByName",
+			rolloutName: "one",
+			repoGetByName: &provisioning.Rollout{
+				Name:   "one",
+				Status: api.RolloutStatusCanceled,
+			},
+			repoDeleteErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.RolloutRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Rollout, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				DeleteByNameFunc: func(ctx context.Context, name string) error {
+					return tc.repoDeleteErr
+				},
+			}
+
+			rolloutSvc := provisioningRollout.New(repo, nil, nil)
+
+			// Run test
+			err := rolloutSvc.DeleteByName(t.Context(), tc.rolloutName)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestRolloutService_RolloutControlLoop(t *testing.T) {
+	now := time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC)
+
+	waves := api.RolloutWaves{
+		{
+			Name:          "lab",
+			ClusterFilter: `properties.stage == "lab"`,
+			SoakTime:      "1h",
+		},
+		{
+			Name:          "prod",
+			ClusterFilter: `properties.stage == "prod"`,
+			SoakTime:      "48h",
+		},
+	}
+
+	inProgress := func(name string, inProgress api.ClusterUpdateInProgress) provisioning.Cluster {
+		return provisioning.Cluster{
+			Name:    name,
+			Channel: "stable",
+			UpdateStatus: api.ClusterUpdateStatus{
+				NeedsUpdate: []string{name + "-server"},
+				InProgressStatus: api.ClusterUpdateInProgressStatus{
+					InProgress: inProgress,
+					Error:      "boom",
+				},
+			},
+		}
+	}
+
+	updated := func(name string) provisioning.Cluster {
+		cluster := inProgress(name, api.ClusterUpdateInProgressInactive)
+		cluster.UpdateStatus.NeedsUpdate = nil
+		return cluster
+	}
+
+	operation := func(status api.ClusterOperationStatus) provisioning.ClusterOperations {
+		return provisioning.ClusterOperations{
+			{Type: api.ClusterUpdateInProgressApplyUpdate, Status: status},
+			{Type: api.ClusterUpdateInProgressApplyUpdate, Status: api.ClusterOperationStatusSucceeded},
+		}
+	}
+
+	tests := []struct {
+		name                               string
+		repoGetAll                         provisioning.Rollouts
+		repoGetAllErr                      error
+		repoGetByNameStatus                api.RolloutStatus
+		clusterSvcGetAllWithFilter         provisioning.Clusters
+		clusterSvcGetAllWithFilterErr      error
+		clusterSvcPlanClusterUpdate        map[string]string
+		clusterSvcPlanClusterUpdateErr     error
+		clusterSvcLaunchClusterUpdateErr   map[string]error
+		clusterSvcAbortClusterOperationErr error
+		clusterSvcGetByName                map[string]provisioning.Cluster
+		clusterSvcGetByNameErr             error
+		clusterSvcGetOperationAll          map[string]provisioning.ClusterOperations
+		clusterSvcGetOperationAllErr       error
+		repoUpdateErr                      error
+		wantPlannedClusters                []string
+		wantLaunchedClusters               []string
+		wantAbortedClusters                []string
+		wantClusterSvcGetAllWithFilterArg  string
+
+		assertErr   require.ErrorAssertionFunc
+		wantRollout *provisioning.Rollout
+	}{
+		{
+			name: "success - no rollouts",
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - rollout not running",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusSucceeded},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - pending wave is started",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Reboot: true, TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, CurrentWave: 1, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				inProgress("one", api.ClusterUpdateInProgressInactive),
+				inProgress("two", api.ClusterUpdateInProgressApplyUpdate), // already in progress, not launched again.
+				updated("three"), // up to date, not part of the wave.
+				inProgress("four", api.ClusterUpdateInProgressInactive),
+			},
+			clusterSvcPlanClusterUpdate: map[string]string{
+				"one":  "202501020304",
+				"four": "202412020304", // older than the target version.
+			},
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "prod"`,
+			wantPlannedClusters:               []string{"one", "four"},
+			wantLaunchedClusters:              []string{"one", "four"},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Reboot: true, TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, CurrentWave: 1, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one", "two", "four"},
+			},
+		},
+		{
+			name: "success - pending wave with cluster not ready stops the rollout before any launch",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				inProgress("one", api.ClusterUpdateInProgressInactive),
+			},
+			clusterSvcPlanClusterUpdateErr:    boom.Error,
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "lab"`,
+			wantPlannedClusters:               []string{"one"},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{},
+				Error:        `Cluster "one" is not ready for the cluster update: boom!`,
+			},
+		},
+		{
+			name: "success - pending wave with channel moved past target version is launched",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				inProgress("one", api.ClusterUpdateInProgressInactive),
+				inProgress("two", api.ClusterUpdateInProgressInactive),
+			},
+			clusterSvcPlanClusterUpdate: map[string]string{
+				"one": "202501020304",
+				"two": "202501100304", // the servers are only offered the target version.
+			},
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "lab"`,
+			wantPlannedClusters:               []string{"one", "two"},
+			wantLaunchedClusters:              []string{"one", "two"},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one", "two"},
+			},
+		},
+		{
+			name: "success - pending wave with other operation in progress stops the rollout before any launch",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				inProgress("one", api.ClusterUpdateInProgressInactive),
+				inProgress("two", api.ClusterUpdateInProgressRollingReboot),
+			},
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "lab"`,
+			wantPlannedClusters:               []string{"one"},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one"},
+				Error:        `Cluster "two" has another operation in progress: rolling reboot`,
+			},
+		},
+		{
+			name: "success - pending wave without clusters",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "lab"`,
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{},
+			},
+		},
+		{
+			name: "success - pending wave with failed cluster update stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				inProgress("one", api.ClusterUpdateInProgressError),
+			},
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "lab"`,
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{},
+				Error:        `Cluster update of "one" failed: boom`,
+			},
+		},
+		{
+			name: "success - pending wave with clusterSvc.GetAllWithFilter error stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilterErr:     boom.Error,
+			wantClusterSvcGetAllWithFilterArg: `properties.stage == "lab"`,
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusPending,
+				Error: "Failed to get clusters of wave 0: boom!",
+			},
+		},
+		{
+			name: "success - pending wave with clusterSvc.LaunchClusterUpdate error stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusPending},
+			},
+			clusterSvcGetAllWithFilter: provisioning.Clusters{
+				inProgress("one", api.ClusterUpdateInProgressInactive),
+				inProgress("two", api.ClusterUpdateInProgressInactive),
+				inProgress("three", api.ClusterUpdateInProgressInactive),
+			},
+			clusterSvcPlanClusterUpdate: map[string]string{
+				"one":   "202501020304",
+				"two":   "202501020304",
+				"three": "202501020304",
+			},
+			clusterSvcLaunchClusterUpdateErr: map[string]error{
+				"three": boom.Error,
+			},
+			clusterSvcAbortClusterOperationErr: boom.Error, // only logged.
+			wantClusterSvcGetAllWithFilterArg:  `properties.stage == "lab"`,
+			wantPlannedClusters:                []string{"one", "two", "three"},
+			wantLaunchedClusters:               []string{"one", "two", "three"},
+			wantAbortedClusters:                []string{"one", "two"},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", TargetVersion: "202501020304", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one", "two", "three"},
+				Error:        `Failed to launch cluster update of "three": boom!`,
+			},
+		},
+		{
+			name: "success - updating wave still in progress",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one", "two"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": updated("one"),
+				"two": inProgress("two", api.ClusterUpdateInProgressApplyUpdate),
+			},
+			clusterSvcGetOperationAll: map[string]provisioning.ClusterOperations{
+				"one": operation(api.ClusterOperationStatusSucceeded),
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - updating wave finished",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one", "removed"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": updated("one"),
+			},
+			clusterSvcGetOperationAll: map[string]provisioning.ClusterOperations{
+				"one": operation(api.ClusterOperationStatusSucceeded),
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusSoaking,
+				WaveClusters: provisioning.RolloutWaveClusters{"one", "removed"}, WaveFinishedAt: now,
+			},
+		},
+		{
+			name: "success - updating wave with canceled cluster update stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": updated("one"),
+			},
+			clusterSvcGetOperationAll: map[string]provisioning.ClusterOperations{
+				"one": operation(api.ClusterOperationStatusCanceled),
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one"},
+				Error:        `Cluster update of "one" did not complete: applying updates canceled`,
+			},
+		},
+		{
+			name: "success - updating wave with inactive cluster, which is not up to date, stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": inProgress("one", api.ClusterUpdateInProgressInactive),
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one"},
+				Error:        `Cluster update of "one" did not complete: servers one-server still need an update`,
+			},
+		},
+		{
+			name: "success - updating wave with cluster, which still needs a reboot, stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Reboot: true, Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": func() provisioning.Cluster {
+					cluster := updated("one")
+					cluster.UpdateStatus.NeedsReboot = []string{"one-server"}
+					return cluster
+				}(),
+			},
+			clusterSvcGetOperationAll: map[string]provisioning.ClusterOperations{
+				"one": operation(api.ClusterOperationStatusSucceeded),
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Reboot: true, Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one"},
+				Error:        `Cluster update of "one" did not complete: servers one-server still need a reboot`,
+			},
+		},
+		{
+			name: "success - updating wave with failed cluster update stops the rollout",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one", "two"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": updated("one"),
+				"two": inProgress("two", api.ClusterUpdateInProgressError),
+			},
+			clusterSvcGetOperationAll: map[string]provisioning.ClusterOperations{
+				"one": operation(api.ClusterOperationStatusSucceeded),
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusFailed, WaveStatus: api.RolloutWaveStatusUpdating,
+				WaveClusters: provisioning.RolloutWaveClusters{"one", "two"},
+				Error:        `Cluster update of "two" failed: boom`,
+			},
+		},
+		{
+			name: "success - soaking wave, soak time not yet passed",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusSoaking, WaveFinishedAt: now.Add(-30 * time.Minute)},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "success - soaking wave, move on to next wave",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusSoaking, WaveClusters: provisioning.RolloutWaveClusters{"one"}, WaveFinishedAt: now.Add(-time.Hour)},
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusRunning, CurrentWave: 1, WaveStatus: api.RolloutWaveStatusPending,
+				WaveClusters: provisioning.RolloutWaveClusters{},
+			},
+		},
+		{
+			name: "success - soaking last wave, rollout succeeded",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, CurrentWave: 1, WaveStatus: api.RolloutWaveStatusSoaking, WaveFinishedAt: now.Add(-48 * time.Hour)},
+			},
+
+			assertErr: require.NoError,
+			wantRollout: &provisioning.Rollout{
+				Name: "one", Waves: waves, Status: api.RolloutStatusSucceeded, CurrentWave: 1, WaveStatus: api.RolloutWaveStatusSoaking, WaveFinishedAt: now.Add(-48 * time.Hour),
+			},
+		},
+		{
+			name: "success - rollout canceled in the meantime",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusSoaking, WaveFinishedAt: now.Add(-time.Hour)},
+			},
+			repoGetByNameStatus: api.RolloutStatusCanceled,
+
+			assertErr: require.NoError,
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - clusterSvc.GetByName",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+			clusterSvcGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - clusterSvc.GetOperationAll",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusUpdating, WaveClusters: provisioning.RolloutWaveClusters{"one"}},
+			},
+			clusterSvcGetByName: map[string]provisioning.Cluster{
+				"one": updated("one"),
+			},
+			clusterSvcGetOperationAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.Update",
+			repoGetAll: provisioning.Rollouts{
+				{Name: "one", Waves: waves, Status: api.RolloutStatusRunning, WaveStatus: api.RolloutWaveStatusSoaking, WaveFinishedAt: now.Add(-time.Hour)},
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var gotRollout *provisioning.Rollout
+			repo := &repoMock.RolloutRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Rollouts, error) {
+					return tc.repoGetAll, tc.repoGetAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Rollout, error) {
+					status := api.RolloutStatusRunning
+					if tc.repoGetByNameStatus != "" {
+						status = tc.repoGetByNameStatus
+					}
+
+					return &provisioning.Rollout{
+						Name:   name,
+						Status: status,
+					}, nil
+				},
+				UpdateFunc: func(ctx context.Context, rollout provisioning.Rollout) error {
+					gotRollout = &rollout
+					return tc.repoUpdateErr
+				},
+			}
+
+			var plannedClusters []string
+			var launchedClusters []string
+			var abortedClusters []string
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ClusterFilter) (provisioning.Clusters, error) {
+					require.Equal(t, tc.wantClusterSvcGetAllWithFilterArg, *filter.Expression)
+					return tc.clusterSvcGetAllWithFilter, tc.clusterSvcGetAllWithFilterErr
+				},
+				PlanClusterUpdateFunc: func(ctx context.Context, name string, reboot bool) (api.ClusterUpdatePlan, error) {
+					plannedClusters = append(plannedClusters, name)
+					return api.ClusterUpdatePlan{
+						Servers: []api.ClusterUpdatePlanServer{
+							{
+								Name: name + "-server",
+								OS: api.ClusterUpdatePlanComponent{
+									Name:           "os",
+									CurrentVersion: "202412010304",
+									TargetVersion:  tc.clusterSvcPlanClusterUpdate[name],
+								},
+							},
+						},
+					}, tc.clusterSvcPlanClusterUpdateErr
+				},
+				LaunchClusterUpdateFunc: func(ctx context.Context, name string, reboot bool) error {
+					launchedClusters = append(launchedClusters, name)
+					return tc.clusterSvcLaunchClusterUpdateErr[name]
+				},
+				AbortClusterOperationFunc: func(ctx context.Context, name string) error {
+					abortedClusters = append(abortedClusters, name)
+					return tc.clusterSvcAbortClusterOperationErr
+				},
+				GetOperationAllFunc: func(ctx context.Context, name string) (provisioning.ClusterOperations, error) {
+					return tc.clusterSvcGetOperationAll[name], tc.clusterSvcGetOperationAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					if tc.clusterSvcGetByNameErr != nil {
+						return nil, tc.clusterSvcGetByNameErr
+					}
+
+					cluster, ok := tc.clusterSvcGetByName[name]
+					if !ok {
+						return nil, domain.ErrNotFound
+					}
+
+					return &cluster, nil
+				},
+			}
+
+			rolloutSvc := provisioningRollout.New(repo, clusterSvc, nil,
+				provisioningRollout.WithNow(func() time.Time {
+					return now
+				}),
+			)
+
+			// Run test
+			err := rolloutSvc.RolloutControlLoop(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantPlannedClusters, plannedClusters)
+			require.Equal(t, tc.wantLaunchedClusters, launchedClusters)
+			require.Equal(t, tc.wantAbortedClusters, abortedClusters)
+			if tc.wantRollout != nil {
+				require.Equal(t, tc.wantRollout, gotRollout)
+			} else if tc.repoUpdateErr == nil {
+				require.Nil(t, gotRollout)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package provisioning
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type Rollout struct {
+	ID             int64
+	Name           string `db:"primary=yes"`
+	Description    string
+	Reboot         bool
+	TargetVersion  string
+	Waves          api.RolloutWaves
+	Status         api.RolloutStatus
+	CurrentWave    int
+	WaveStatus     api.RolloutWaveStatus
+	WaveClusters   RolloutWaveClusters
+	WaveFinishedAt time.Time
+	Error          string
+	LastUpdated    time.Time `db:"update_timestamp"`
+}
+
+func (r Rollout) Validate() error {
+	if r.Name == "" {
+		return domain.NewValidationErrf("Invalid rollout, name can not be empty")
+	}
+
+	if strings.ContainsAny(r.Name, nameProhibitedCharacters) {
+		return domain.NewValidationErrf("Invalid rollout, name can not contain any of %q", nameProhibitedCharacters)
+	}
+
+	if len(r.Waves) == 0 {
+		return domain.NewValidationErrf("Invalid rollout, at least one wave is required")
+	}
+
+	for i, wave := range r.Waves {
+		if wave.ClusterFilter == "" {
+			return domain.NewValidationErrf("Invalid rollout, cluster filter of wave %d can not be empty", i)
+		}
+
+		_, err := wave.SoakDuration()
+		if err != nil {
+			return domain.NewValidationErrf("Invalid rollout, soak time of wave %d: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Wave returns the current wave of the rollout.
+func (r Rollout) Wave() api.RolloutWave {
+	return r.Waves[r.CurrentWave]
+}
+
+// IsLastWave returns true, if the current wave is the last wave of the
+// rollout.
+func (r Rollout) IsLastWave() bool {
+	return r.CurrentWave >= len(r.Waves)-1
+}
+
+type Rollouts []Rollout
+
+type RolloutWaveClusters []string
+
+// Value implements the sql driver.Valuer interface.
+func (r RolloutWaveClusters) Value() (driver.Value, error) {
+	if r == nil {
+		r = RolloutWaveClusters{}
+	}
+
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *RolloutWaveClusters) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid rollout wave clusters")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*r = RolloutWaveClusters{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), r)
+
+	case []byte:
+		if len(v) == 0 {
+			*r = RolloutWaveClusters{}
+			return nil
+		}
+
+		return json.Unmarshal(v, r)
+
+	default:
+		return fmt.Errorf("type %T is not supported for rollout wave clusters", value)
+	}
+}
@@ -0,0 +1,141 @@
+package provisioning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestRollout_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rollout provisioning.Rollout
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						Name:          "lab",
+						ClusterFilter: `properties.stage == "lab"`,
+					},
+					{
+						Name:          "prod",
+						ClusterFilter: `properties.stage == "prod"`,
+						SoakTime:      "48h",
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - empty name",
+			rollout: provisioning.Rollout{
+				Name: "", // invalid
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid name",
+			rollout: provisioning.Rollout{
+				Name: "one/two", // invalid
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - no waves",
+			rollout: provisioning.Rollout{
+				Name: "one",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - empty cluster filter",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: "", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid soak time",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+						SoakTime:      "two days", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - negative soak time",
+			rollout: provisioning.Rollout{
+				Name: "one",
+				Waves: api.RolloutWaves{
+					{
+						ClusterFilter: `true`,
+						SoakTime:      "-1h", // invalid
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rollout.Validate()
+
+			tc.assertErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package provisioning
+
+import "context"
+
+type RolloutService interface {
+	Create(ctx context.Context, rollout Rollout) (Rollout, error)
+	GetAll(ctx context.Context) (Rollouts, error)
+	GetAllNames(ctx context.Context) ([]string, error)
+	GetByName(ctx context.Context, name string) (*Rollout, error)
+	GetTargetVersionByCluster(ctx context.Context, clusterName string) (string, error)
+	Cancel(ctx context.Context, name string) error
+	DeleteByName(ctx context.Context, name string) error
+	RolloutControlLoop(ctx context.Context) error
+}
+
+type RolloutRepo interface {
+	Create(ctx context.Context, rollout Rollout) (int64, error)
+	GetAll(ctx context.Context) (Rollouts, error)
+	GetAllNames(ctx context.Context) ([]string, error)
+	GetByName(ctx context.Context, name string) (*Rollout, error)
+	Update(ctx context.Context, rollout Rollout) error
+	DeleteByName(ctx context.Context, name string) error
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// bmcFirmwareDefaultImageFilename is the file name used for firmware images
+// pushed to the BMC, if the image URI does not contain a file name.
+const bmcFirmwareDefaultImageFilename = "firmware.bin"
+
+const (
+	// bmcFirmwareDownloadTimeout is the maximum duration of the download of a
+	// firmware image, which is pushed to the BMC.
+	bmcFirmwareDownloadTimeout = 30 * time.Minute
+
+	// bmcFirmwareImageSizeLimit is the maximum size of a firmware image, which
+	// is pushed to the BMC.
+	bmcFirmwareImageSizeLimit = 1024 * 1024 * 1024
+)
+
+// BMCFirmwareByName returns the firmware inventory as currently reported by
+// the BMC of the server.
+func (s *serverService) BMCFirmwareByName(ctx context.Context, name string) (map[string]api.BMCFirmware, error) {
+	server, client, err := s.getServerAndBMCClientByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	firmware, err := client.FirmwareInventory(ctx, *server)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get firmware inventory of server %q via BMC: %w", server.Name, err)
+	}
+
+	return firmware, nil
+}
+
+// BMCFirmwareUpdateByName updates the firmware of the server using the update
+// service of its BMC.
+//
+// The firmware of cluster members is only updated as part of a rolling
+// firmware update of the cluster (clusterUpdate set to true), such that only
+// one member of the cluster is updated at a time. In this case, the call
+// blocks until the BMC reports the update as completed. Otherwise, the update
+// is triggered and its completion is awaited in the background.
+func (s *serverService) BMCFirmwareUpdateByName(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) error {
+	err := provisioning.ValidateBMCFirmwareUpdate(&update)
+	if err != nil {
+		return err
+	}
+
+	server, client, err := s.getServerAndBMCClientByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if server.Cluster != nil && !clusterUpdate {
+		return fmt.Errorf("Server %q is member of cluster %q, the firmware of cluster members is updated using a rolling firmware update of the cluster: %w", name, *server.Cluster, domain.ErrOperationNotPermitted)
+	}
+
+	taskMonitor, err := s.triggerBMCFirmwareUpdate(ctx, *server, client, update)
+	if err != nil {
+		return fmt.Errorf("Failed to trigger firmware update of server %q via BMC: %w", server.Name, err)
+	}
+
+	if clusterUpdate {
+		err = client.WaitForTask(ctx, *server, taskMonitor)
+		if err != nil {
+			return fmt.Errorf("Failed to wait for firmware update of server %q via BMC to complete: %w", server.Name, err)
+		}
+
+		err = s.resyncBMCData(ctx, *server)
+		if err != nil {
+			slog.WarnContext(ctx, "Resync of BMC data after firmware update failed", logger.Err(err), slog.String("name", server.Name))
+		}
+
+		return nil
+	}
+
+	go func() {
+		// Use a detached context in order to make sure, no existing DB transaction is inherited.
+		ctx := context.Background()
+
+		err := client.WaitForTask(ctx, *server, taskMonitor)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to wait for task monitor to complete after firmware update operation", logger.Err(err))
+		}
+
+		err = s.resyncBMCData(ctx, *server)
+		if err != nil {
+			slog.WarnContext(ctx, "Resync of BMC data after firmware update failed", logger.Err(err), slog.String("name", server.Name))
+		}
+	}()
+
+	return nil
+}
+
+func (s *serverService) triggerBMCFirmwareUpdate(ctx context.Context, server provisioning.Server, client provisioning.BMCServerClientPort, update api.BMCFirmwareUpdatePost) (*provisioning.BMCTaskMonitor, error) {
+	if update.Method != api.BMCFirmwareUpdateMethodPush {
+		return client.UpdateFirmwareFromURI(ctx, server, update.ImageURI, update.Targets)
+	}
+
+	// The image is stored in a dedicated directory using its original file
+	// name, since the file name is passed on to the BMC and some BMCs derive
+	// the kind of the update package from the file extension.
+	tmpDir, err := os.MkdirTemp("", "bmc-firmware-")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create temporary directory for firmware image: %w", err)
+	}
+
+	defer func() {
+		err := os.RemoveAll(tmpDir)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to remove temporary firmware image", logger.Err(err), slog.String("path", tmpDir))
+		}
+	}()
+
+	imageFilename := filepath.Join(tmpDir, bmcFirmwareImageFilename(update.ImageURI))
+
+	err = s.downloadBMCFirmwareImage(ctx, update.ImageURI, update.SHA256, imageFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.UpdateFirmwareFromFile(ctx, server, imageFilename, update.Targets)
+}
+
+// downloadBMCFirmwareImage downloads the firmware image from imageURI to
+// imageFilename. The download is limited in duration and size and the image
+// is verified against the expected sha256 checksum.
+func (s *serverService) downloadBMCFirmwareImage(ctx context.Context, imageURI string, expectedSHA256 string, imageFilename string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.bmcFirmwareDownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURI, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("Failed to create request for firmware image %q: %w", imageURI, err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to download firmware image %q: %w", imageURI, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to download firmware image %q: unexpected status %d", imageURI, resp.StatusCode)
+	}
+
+	if resp.ContentLength > s.bmcFirmwareImageSizeLimit {
+		return fmt.Errorf("Failed to download firmware image %q: image size of %d bytes exceeds the limit of %d bytes", imageURI, resp.ContentLength, s.bmcFirmwareImageSizeLimit)
+	}
+
+	f, err := os.Create(imageFilename)
+	if err != nil {
+		return fmt.Errorf("Failed to create firmware image file %q: %w", imageFilename, err)
+	}
+
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	h := sha256.New()
+
+	// Read one byte more than the limit in order to detect images exceeding
+	// the limit without a content length.
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(resp.Body, s.bmcFirmwareImageSizeLimit+1))
+	if err != nil {
+		return fmt.Errorf("Failed to download firmware image %q: %w", imageURI, err)
+	}
+
+	if n > s.bmcFirmwareImageSizeLimit {
+		return fmt.Errorf("Failed to download firmware image %q: image size exceeds the limit of %d bytes", imageURI, s.bmcFirmwareImageSizeLimit)
+	}
+
+	checksum := hex.EncodeToString(h.Sum(nil))
+	if checksum != expectedSHA256 {
+		return fmt.Errorf("Invalid firmware image %q, sha256 mismatch, expected: %s, actual: %s", imageURI, expectedSHA256, checksum)
+	}
+
+	return nil
+}
+
+func bmcFirmwareImageFilename(imageURI string) string {
+	u, err := url.Parse(imageURI)
+	if err != nil {
+		return bmcFirmwareDefaultImageFilename
+	}
+
+	filename := path.Base(u.Path)
+	if filename == "." || filename == "/" {
+		return bmcFirmwareDefaultImageFilename
+	}
+
+	return filename
+}
@@ -0,0 +1,560 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lxc/incus-os/incus-osd/api/images"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// bmcInstallMediaFilename is the file name used in the URL, the install
+// image is served from. Some BMCs only accept URLs ending with ".iso".
+const bmcInstallMediaFilename = "install.iso"
+
+// bmcInstallMediaTTL is the duration, the install image is served for after
+// the installation via BMC has been initiated. The install image contains the
+// registration token, it is therefore only served for a limited time and only
+// until it has been downloaded completely.
+const bmcInstallMediaTTL = 1 * time.Hour
+
+// BMCInstallByName installs IncusOS on a bare server using the virtual media
+// of its BMC. The request is validated and recorded synchronously, the
+// following steps are performed in the background:
+//
+//   - Build the install image pre-seeded with the given token seed, unless an
+//     up to date image is already available.
+//   - Insert the install image served by Operations Center as virtual media.
+//   - Make the server boot from the virtual media once.
+//   - Power on or restart the server.
+//
+// The installation remains in status booting until the server registers. The
+// install image is served until it has been downloaded completely by the BMC,
+// but at most for bmcInstallMediaTTL.
+func (s *serverService) BMCInstallByName(ctx context.Context, name string, install api.ServerBMCInstallPost) error {
+	if name == "" {
+		return fmt.Errorf("Server name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	server, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	if !server.BMCConfig.HasBMC() {
+		return fmt.Errorf("Server %q does not have a BMC configured: %w", name, domain.ErrOperationNotPermitted)
+	}
+
+	client, ok := s.bmcServerClients[server.BMCConfig.APIType]
+	if !ok {
+		return fmt.Errorf("Failed to get BMC server client for type %q", server.BMCConfig.APIType)
+	}
+
+	if server.Status != api.ServerStatusUnregistered {
+		return fmt.Errorf("Server %q in status %q can not be installed via BMC, only unregistered servers are supported: %w", name, server.Status, domain.ErrOperationNotPermitted)
+	}
+
+	if server.BMCInstall.Status == api.ServerBMCInstallStatusPreparing {
+		return fmt.Errorf("Installation of server %q via BMC is already being prepared: %w", name, domain.ErrOperationNotPermitted)
+	}
+
+	token, err := s.tokenSvc.GetByUUID(ctx, install.Token)
+	if err != nil {
+		return fmt.Errorf("Failed to get token %q: %w", install.Token.String(), err)
+	}
+
+	if token.UsesRemaining < 1 || token.ExpireAt.Before(s.now()) {
+		return fmt.Errorf("Token %q is exhausted or expired: %w", install.Token.String(), domain.ErrOperationNotPermitted)
+	}
+
+	_, err = s.tokenSvc.GetTokenSeedByName(ctx, install.Token, install.TokenSeed)
+	if err != nil {
+		return fmt.Errorf("Failed to get token seed %q of token %q: %w", install.TokenSeed, install.Token.String(), err)
+	}
+
+	if install.Architecture == "" {
+		install.Architecture = images.UpdateFileArchitecture64BitX86
+		if server.BMCData.ServerProcessorInstructionSet == "ARM-A64" {
+			install.Architecture = images.UpdateFileArchitecture64BitARM
+		}
+	}
+
+	_, ok = images.UpdateFileArchitectures[install.Architecture]
+	if !ok {
+		return domain.NewValidationErrf("Invalid BMC install, architecture %q is not valid", install.Architecture)
+	}
+
+	if install.Channel == "" {
+		install.Channel = token.Channel
+	}
+
+	operationsCenterAddress := config.GetNetwork().OperationsCenterAddress
+	if operationsCenterAddress == "" {
+		return fmt.Errorf("Operations Center address is not configured, the BMC can not fetch the install image: %w", domain.ErrOperationNotPermitted)
+	}
+
+	id := uuid.New()
+
+	mediaURL, err := url.JoinPath(operationsCenterAddress, "/1.0/provisioning/servers", name, "bmc/install-media", id.String(), bmcInstallMediaFilename)
+	if err != nil {
+		return fmt.Errorf("Failed to build URL for install image: %w", err)
+	}
+
+	bmcInstall := api.ServerBMCInstall{
+		ID:             id,
+		Token:          install.Token,
+		TokenSeed:      install.TokenSeed,
+		Architecture:   install.Architecture,
+		Channel:        install.Channel,
+		VirtualMedia:   install.VirtualMedia,
+		MediaURL:       mediaURL,
+		MediaExpiresAt: s.now().Add(bmcInstallMediaTTL),
+		Status:         api.ServerBMCInstallStatusPreparing,
+		LastUpdated:    s.now(),
+	}
+
+	err = transaction.Do(ctx, func(ctx context.Context) error {
+		server, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get server %q: %w", name, err)
+		}
+
+		if server.BMCInstall.Status == api.ServerBMCInstallStatusPreparing {
+			return fmt.Errorf("Installation of server %q via BMC is already being prepared: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		server.BMCInstall = bmcInstall
+
+		return s.repo.Update(ctx, *server)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to record installation of server %q via BMC: %w", name, err)
+	}
+
+	slog.InfoContext(ctx, "Installation via BMC initiated", slog.String("name", name), slog.String("token_seed", install.TokenSeed), slog.String("architecture", string(install.Architecture)))
+
+	go func() {
+		// Use a detached context in order to make sure, no existing DB transaction is inherited.
+		ctx := context.Background()
+
+		installErr := s.bmcInstall(ctx, *server, client, bmcInstall, install.Rebuild)
+		if installErr == nil {
+			return
+		}
+
+		slog.WarnContext(ctx, "Installation via BMC failed", logger.Err(installErr), slog.String("name", name))
+
+		err := s.updateBMCInstall(ctx, name, id, func(bmcInstall *api.ServerBMCInstall) {
+			bmcInstall.Status = api.ServerBMCInstallStatusFailed
+			bmcInstall.Error = installErr.Error()
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to record failed installation via BMC", logger.Err(err), slog.String("name", name))
+			return
+		}
+
+		err = s.PruneBMCInstallMedia(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to remove install images after failed installation via BMC", logger.Err(err), slog.String("name", name))
+		}
+	}()
+
+	return nil
+}
+
+func (s *serverService) bmcInstall(ctx context.Context, server provisioning.Server, client provisioning.BMCServerClientPort, bmcInstall api.ServerBMCInstall, rebuild bool) error {
+	err := s.buildBMCInstallMedia(ctx, bmcInstall, rebuild)
+	if err != nil {
+		return err
+	}
+
+	virtualMediaID, taskMonitor, err := client.InsertVirtualMedia(ctx, server, bmcInstall.VirtualMedia, bmcInstall.MediaURL)
+	if err != nil {
+		return fmt.Errorf("Failed to insert virtual media: %w", err)
+	}
+
+	err = s.updateBMCInstall(ctx, server.Name, bmcInstall.ID, func(bmcInstall *api.ServerBMCInstall) {
+		bmcInstall.VirtualMedia = virtualMediaID
+	})
+	if err != nil {
+		return err
+	}
+
+	err = client.WaitForTask(ctx, server, taskMonitor)
+	if err != nil {
+		return fmt.Errorf("Failed to wait for insertion of virtual media: %w", err)
+	}
+
+	err = client.SetOneTimeBootFromVirtualMedia(ctx, server)
+	if err != nil {
+		return fmt.Errorf("Failed to set one-time boot from virtual media: %w", err)
+	}
+
+	// The power state is queried right before the server is started, since the
+	// BMC data recorded in the inventory might be outdated.
+	bmcData, err := client.GetData(ctx, server)
+	if err != nil {
+		return fmt.Errorf("Failed to get power state: %w", err)
+	}
+
+	if bmcData.ServerPowerState == "On" {
+		taskMonitor, err = client.ServerRestart(ctx, server, true)
+	} else {
+		taskMonitor, err = client.ServerPowerOn(ctx, server, true)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to boot server: %w", err)
+	}
+
+	err = client.WaitForTask(ctx, server, taskMonitor)
+	if err != nil {
+		return fmt.Errorf("Failed to wait for boot of server: %w", err)
+	}
+
+	return s.updateBMCInstall(ctx, server.Name, bmcInstall.ID, func(bmcInstall *api.ServerBMCInstall) {
+		bmcInstall.Status = api.ServerBMCInstallStatusBooting
+	})
+}
+
+// updateBMCInstall applies the given change to the installation via BMC of
+// the server, unless it has been replaced by a new installation in the
+// meantime.
+func (s *serverService) updateBMCInstall(ctx context.Context, name string, id uuid.UUID, change func(bmcInstall *api.ServerBMCInstall)) error {
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		server, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get server %q: %w", name, err)
+		}
+
+		if server.BMCInstall.ID != id {
+			return fmt.Errorf("Installation of server %q via BMC has been replaced in the meantime: %w", name, domain.ErrOperationNotPermitted)
+		}
+
+		change(&server.BMCInstall)
+		server.BMCInstall.LastUpdated = s.now()
+
+		return s.repo.Update(ctx, *server)
+	})
+}
+
+// buildBMCInstallMedia builds the install image for the installation via BMC
+// and stores it in the install media directory. An already existing image is
+// reused, unless it is older than the last change of the token seed or a
+// rebuild is requested.
+func (s *serverService) buildBMCInstallMedia(ctx context.Context, bmcInstall api.ServerBMCInstall, rebuild bool) error {
+	tokenSeed, err := s.tokenSvc.GetTokenSeedByName(ctx, bmcInstall.Token, bmcInstall.TokenSeed)
+	if err != nil {
+		return fmt.Errorf("Failed to get token seed %q of token %q: %w", bmcInstall.TokenSeed, bmcInstall.Token.String(), err)
+	}
+
+	filename := s.bmcInstallMediaPath(bmcInstall)
+
+	fileInfo, err := os.Stat(filename)
+	if err == nil && !rebuild && fileInfo.ModTime().After(tokenSeed.LastUpdated) {
+		return nil
+	}
+
+	err = os.MkdirAll(s.bmcInstallMediaDir, 0o700)
+	if err != nil {
+		return fmt.Errorf("Failed to create directory for install images: %w", err)
+	}
+
+	rc, err := s.tokenSvc.GetTokenImageFromTokenSeed(ctx, bmcInstall.Token, bmcInstall.TokenSeed, api.ImageTypeISO, bmcInstall.Architecture, bmcInstall.Channel)
+	if err != nil {
+		return fmt.Errorf("Failed to get install image: %w", err)
+	}
+
+	defer rc.Close()
+
+	// The image is written to a temporary file first, such that a partially
+	// written image is never served to a BMC.
+	tmpFile, err := os.CreateTemp(s.bmcInstallMediaDir, ".install-*.iso")
+	if err != nil {
+		return fmt.Errorf("Failed to create temporary file for install image: %w", err)
+	}
+
+	defer func() {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	_, err = io.Copy(tmpFile, rc)
+	if err != nil {
+		return fmt.Errorf("Failed to write install image: %w", err)
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return fmt.Errorf("Failed to write install image: %w", err)
+	}
+
+	err = os.Rename(tmpFile.Name(), filename)
+	if err != nil {
+		return fmt.Errorf("Failed to store install image: %w", err)
+	}
+
+	return nil
+}
+
+// bmcInstallMediaPath returns the path of the install image for the given
+// installation via BMC. Images are shared between all installations using the
+// same token seed, architecture and channel.
+func (s *serverService) bmcInstallMediaPath(bmcInstall api.ServerBMCInstall) string {
+	key := sha256.Sum256([]byte(strings.Join([]string{
+		bmcInstall.Token.String(),
+		bmcInstall.TokenSeed,
+		string(bmcInstall.Architecture),
+		bmcInstall.Channel,
+	}, "\x00")))
+
+	return filepath.Join(s.bmcInstallMediaDir, hex.EncodeToString(key[:])+".iso")
+}
+
+// GetBMCInstallMediaByName returns the install image for the installation via
+// BMC with the given ID. The image is only available, while the installation
+// is in progress and until the media has expired. Once the image has been
+// downloaded completely, the media expires right away.
+func (s *serverService) GetBMCInstallMediaByName(ctx context.Context, name string, id uuid.UUID) (io.ReadSeekCloser, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Server name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	server, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	if server.BMCInstall.ID != id || !server.BMCInstall.Status.IsInProgress() || !s.now().Before(server.BMCInstall.MediaExpiresAt) {
+		return nil, fmt.Errorf("Install image %q for server %q: %w", id.String(), name, domain.ErrNotFound)
+	}
+
+	file, err := os.Open(s.bmcInstallMediaPath(server.BMCInstall))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("Install image %q for server %q: %w", id.String(), name, domain.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open install image: %w", err)
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("Failed to get size of install image: %w", err)
+	}
+
+	return &bmcInstallMedia{
+		file: file,
+		size: fileInfo.Size(),
+		read: func(n int) {
+			s.bmcInstallMediaMu.Lock()
+			s.bmcInstallMediaServed[id] += int64(n)
+			s.bmcInstallMediaMu.Unlock()
+		},
+		close: func() {
+			s.bmcInstallMediaMu.Lock()
+			served := s.bmcInstallMediaServed[id]
+			s.bmcInstallMediaMu.Unlock()
+
+			if served < fileInfo.Size() {
+				return
+			}
+
+			// Use a detached context, the request might already be canceled.
+			s.expireBMCInstallMedia(context.Background(), name, id)
+		},
+	}, nil
+}
+
+// bmcInstallMedia is the install image served to a BMC. The data read from
+// the image is accounted to the installation, such that the media can be
+// expired once the image has been downloaded completely, even if the BMC
+// fetches the image in chunks using range requests.
+type bmcInstallMedia struct {
+	file  *os.File
+	size  int64
+	read  func(n int)
+	close func()
+}
+
+func (m *bmcInstallMedia) Read(p []byte) (int, error) {
+	n, err := m.file.Read(p)
+	m.read(n)
+
+	return n, err
+}
+
+func (m *bmcInstallMedia) Seek(offset int64, whence int) (int64, error) {
+	return m.file.Seek(offset, whence)
+}
+
+func (m *bmcInstallMedia) Close() error {
+	err := m.file.Close()
+	m.close()
+
+	return err
+}
+
+// expireBMCInstallMedia expires the media of the installation via BMC after
+// the install image has been downloaded completely, such that the media URL
+// can not be used again.
+func (s *serverService) expireBMCInstallMedia(ctx context.Context, name string, id uuid.UUID) {
+	err := s.updateBMCInstall(ctx, name, id, func(bmcInstall *api.ServerBMCInstall) {
+		bmcInstall.MediaExpiresAt = s.now()
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to expire install image after complete download", logger.Err(err), slog.String("name", name))
+		return
+	}
+
+	slog.InfoContext(ctx, "Install image downloaded completely, media expired", slog.String("name", name))
+
+	err = s.PruneBMCInstallMedia(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to remove install images after complete download", logger.Err(err), slog.String("name", name))
+	}
+}
+
+// PruneBMCInstallMedia removes the install images, which are no longer served
+// for any installation via BMC. This is the case once an installation has
+// finished or failed or once its media has expired.
+func (s *serverService) PruneBMCInstallMedia(ctx context.Context) error {
+	servers, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get servers: %w", err)
+	}
+
+	inUse := map[string]bool{}
+	served := map[uuid.UUID]bool{}
+	for _, server := range servers {
+		if !server.BMCInstall.Status.IsInProgress() || !s.now().Before(server.BMCInstall.MediaExpiresAt) {
+			continue
+		}
+
+		inUse[s.bmcInstallMediaPath(server.BMCInstall)] = true
+		served[server.BMCInstall.ID] = true
+	}
+
+	s.bmcInstallMediaMu.Lock()
+	for id := range s.bmcInstallMediaServed {
+		if !served[id] {
+			delete(s.bmcInstallMediaServed, id)
+		}
+	}
+
+	s.bmcInstallMediaMu.Unlock()
+
+	entries, err := os.ReadDir(s.bmcInstallMediaDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to read directory for install images: %w", err)
+	}
+
+	for _, entry := range entries {
+		// Temporary files belong to install images, which are currently built.
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		filename := filepath.Join(s.bmcInstallMediaDir, entry.Name())
+		if inUse[filename] {
+			continue
+		}
+
+		err = os.Remove(filename)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Failed to remove install image %q: %w", entry.Name(), err)
+		}
+
+		slog.InfoContext(ctx, "Removed install image, which is no longer served", slog.String("file", entry.Name()))
+	}
+
+	return nil
+}
+
+// pruneBMCInstalls marks the installations via BMC as failed, which are still
+// recorded as being prepared. This is the case, if Operations Center has been
+// stopped while an installation has been prepared in the background. The
+// failed installations can then be retried. Left over temporary files and
+// install images, which are no longer served, are removed.
+func (s *serverService) pruneBMCInstalls(ctx context.Context) error {
+	err := transaction.Do(ctx, func(ctx context.Context) error {
+		servers, err := s.repo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to get servers during prune: %w", err)
+		}
+
+		for _, server := range servers {
+			if server.BMCInstall.Status != api.ServerBMCInstallStatusPreparing {
+				continue
+			}
+
+			server.BMCInstall.Status = api.ServerBMCInstallStatusFailed
+			server.BMCInstall.Error = "Installation via BMC has been interrupted by a restart of Operations Center"
+			server.BMCInstall.LastUpdated = s.now()
+
+			err = s.repo.Update(ctx, server)
+			if err != nil {
+				return fmt.Errorf("Failed to mark interrupted installation of server %q via BMC as failed: %w", server.Name, err)
+			}
+
+			slog.WarnContext(ctx, "Marked interrupted installation via BMC as failed", slog.String("name", server.Name))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tmpFiles, err := filepath.Glob(filepath.Join(s.bmcInstallMediaDir, ".install-*.iso"))
+	if err != nil {
+		return fmt.Errorf("Failed to find temporary install images: %w", err)
+	}
+
+	for _, tmpFile := range tmpFiles {
+		err = os.Remove(tmpFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Failed to remove temporary install image %q: %w", filepath.Base(tmpFile), err)
+		}
+	}
+
+	return s.PruneBMCInstallMedia(ctx)
+}
+
+// ejectBMCInstallMedia ejects the install image from the virtual media of the
+// server after the installation via BMC has completed.
+func (s *serverService) ejectBMCInstallMedia(ctx context.Context, server provisioning.Server) {
+	client, ok := s.bmcServerClients[server.BMCConfig.APIType]
+	if !ok {
+		slog.WarnContext(ctx, "Failed to eject install image, no BMC server client available", slog.String("name", server.Name), slog.String("type", server.BMCConfig.APIType.String()))
+		return
+	}
+
+	err := client.EjectVirtualMedia(ctx, server, server.BMCInstall.VirtualMedia)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to eject install image from virtual media", logger.Err(err), slog.String("name", server.Name))
+	}
+}
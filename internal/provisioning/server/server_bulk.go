@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// defaultBulkActionConcurrency is the maximum number of servers, a bulk
+// action is performed on at the same time, if no limit is provided.
+const defaultBulkActionConcurrency = 5
+
+func (s *serverService) BulkAction(ctx context.Context, action provisioning.ServerBulkAction) (provisioning.ServerBulkActionResults, error) {
+	err := action.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	servers, err := s.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Expression: ptr.To(action.Filter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get servers for bulk action: %w", err)
+	}
+
+	slices.SortFunc(servers, func(a, b provisioning.Server) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// Reboots and updates of cluster members need to be coordinated across
+	// the cluster (evacuation and restore of the members one by one), which is
+	// only done by the cluster wide operations.
+	if action.Action == api.ServerBulkActionReboot || action.Action == api.ServerBulkActionUpdate {
+		clusters := map[string]struct{}{}
+		for _, server := range servers {
+			if ptr.From(server.Cluster) != "" {
+				clusters[ptr.From(server.Cluster)] = struct{}{}
+			}
+		}
+
+		if len(clusters) > 0 {
+			return nil, fmt.Errorf("Bulk action %q is not permitted for cluster members, use the cluster wide %s of the clusters %s instead: %w", action.Action, action.Action, strings.Join(slices.Sorted(maps.Keys(clusters)), ", "), domain.ErrOperationNotPermitted)
+		}
+	}
+
+	results := make(provisioning.ServerBulkActionResults, len(servers))
+	for i, server := range servers {
+		results[i] = provisioning.ServerBulkActionResult{
+			Server:  server.Name,
+			Cluster: ptr.From(server.Cluster),
+			Status:  api.ServerBulkActionStatusDryRun,
+		}
+	}
+
+	if action.DryRun {
+		return results, nil
+	}
+
+	// The action is not canceled, if the client disconnects, such that it is
+	// not aborted partway through on some of the servers.
+	ctx = context.WithoutCancel(ctx)
+
+	slog.InfoContext(ctx, "Bulk action initiated", slog.String("action", action.Action.String()), slog.String("filter", action.Filter), slog.Int("servers", len(servers)))
+
+	concurrency := action.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultBulkActionConcurrency
+	}
+
+	var group errgroup.Group
+	group.SetLimit(concurrency)
+
+	for i := range results {
+		group.Go(func() error {
+			err := s.bulkActionForServer(ctx, results[i].Server, action)
+			if err != nil {
+				slog.WarnContext(ctx, "Bulk action failed for server", slog.String("action", action.Action.String()), slog.String("server", results[i].Server), logger.Err(err))
+
+				results[i].Status = api.ServerBulkActionStatusFailed
+				results[i].Error = err.Error()
+
+				return nil
+			}
+
+			results[i].Status = api.ServerBulkActionStatusSucceeded
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == api.ServerBulkActionStatusFailed {
+			failed++
+		}
+	}
+
+	slog.InfoContext(ctx, "Bulk action finished", slog.String("action", action.Action.String()), slog.Int("servers", len(results)), slog.Int("failed", failed))
+
+	return results, nil
+}
+
+func (s *serverService) bulkActionForServer(ctx context.Context, name string, action provisioning.ServerBulkAction) error {
+	switch action.Action {
+	case api.ServerBulkActionReboot:
+		return s.RebootSystemByName(ctx, name, action.Force)
+
+	case api.ServerBulkActionUpdate:
+		return s.UpdateSystemByName(ctx, name, action.UpdateRequest, action.Force)
+
+	case api.ServerBulkActionUpdateSystemLogging:
+		return s.UpdateSystemLogging(ctx, name, action.LoggingConfig)
+
+	case api.ServerBulkActionAddApplication:
+		return s.AddApplication(ctx, name, action.ApplicationName)
+
+	default:
+		return fmt.Errorf("Unsupported bulk action %q", action.Action)
+	}
+}
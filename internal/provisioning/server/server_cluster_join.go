@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/internal/warning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+const (
+	// clusterJoinRetryDelay is the time, after which a failed join of a server
+	// to the cluster defined by its registration token is attempted again.
+	clusterJoinRetryDelay = 15 * time.Minute
+
+	// clusterJoinStaleTimeout is the time, after which a join, which is still
+	// in joining state, is considered interrupted and attempted again.
+	clusterJoinStaleTimeout = 1 * time.Hour
+)
+
+// ClusterJoinControlLoop joins the servers, which have been registered with a
+// token defining a cluster join, to the respective cluster, once they are
+// ready. Failed joins are retried after clusterJoinRetryDelay and joins, which
+// did not complete within clusterJoinStaleTimeout, are attempted again.
+func (s *serverService) ClusterJoinControlLoop(ctx context.Context) error {
+	servers, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get servers for cluster join: %w", err)
+	}
+
+	var errs []error
+	for _, server := range servers {
+		if !s.isClusterJoinDue(server) {
+			continue
+		}
+
+		clusterJoin, err := s.startClusterJoin(ctx, server.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if clusterJoin == nil {
+			continue
+		}
+
+		err = s.joinClusterFromToken(ctx, server.Name, *clusterJoin)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// isClusterJoinDue reports, if the server is due for an attempt to join the
+// cluster defined by its registration token.
+func (s *serverService) isClusterJoinDue(server provisioning.Server) bool {
+	switch server.ClusterJoin.Status {
+	case api.ServerClusterJoinStatusPending:
+		return true
+
+	case api.ServerClusterJoinStatusFailed:
+		return server.ClusterJoin.LastUpdated.Before(s.now().Add(-clusterJoinRetryDelay))
+
+	case api.ServerClusterJoinStatusJoining:
+		return server.ClusterJoin.LastUpdated.Before(s.now().Add(-clusterJoinStaleTimeout))
+
+	default:
+		return false
+	}
+}
+
+// startClusterJoin marks the join of the server as in progress, if the server
+// is ready to join the cluster defined by its registration token. If the
+// server is already member of this cluster, e.g. because the outcome of a
+// previous join has not been recorded, the join is marked as completed. The
+// returned cluster join is nil, if no join needs to be performed.
+func (s *serverService) startClusterJoin(ctx context.Context, name string) (*api.ServerClusterJoin, error) {
+	var clusterJoin *api.ServerClusterJoin
+
+	err := transaction.Do(ctx, func(ctx context.Context) error {
+		server, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get server %q by name: %w", name, err)
+		}
+
+		if !s.isClusterJoinDue(*server) {
+			return nil
+		}
+
+		if server.Cluster != nil {
+			if *server.Cluster != server.ClusterJoin.Cluster {
+				return nil
+			}
+
+			server.ClusterJoin.Status = api.ServerClusterJoinStatusJoined
+			server.ClusterJoin.Error = ""
+			server.ClusterJoin.LastUpdated = s.now()
+
+			return s.repo.Update(ctx, *server)
+		}
+
+		if server.Status != api.ServerStatusReady || server.StatusDetail != api.ServerStatusDetailNone {
+			return nil
+		}
+
+		server.ClusterJoin.Status = api.ServerClusterJoinStatusJoining
+		server.ClusterJoin.LastUpdated = s.now()
+		clusterJoin = ptr.To(server.ClusterJoin)
+
+		return s.repo.Update(ctx, *server)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to start cluster join of server %q: %w", name, err)
+	}
+
+	return clusterJoin, nil
+}
+
+// joinClusterFromToken adds the server to the cluster defined by the token,
+// the server has been registered with, and records the outcome of the join
+// on the server. A failed join is reported as warning.
+func (s *serverService) joinClusterFromToken(ctx context.Context, name string, clusterJoin api.ServerClusterJoin) error {
+	slog.InfoContext(ctx, "Joining cluster defined by registration token", slog.String("server", name), slog.String("cluster", clusterJoin.Cluster))
+
+	scope := api.WarningScope{
+		Scope:      "cluster_join",
+		EntityType: "server",
+		Entity:     name,
+	}
+
+	joinErr := s.clusterSvc.AddServers(ctx, clusterJoin.Cluster, []string{name}, clusterJoin.SkipPostJoinOperations, clusterJoin.CopyServicesConfig)
+	if joinErr != nil {
+		clusterJoin.Status = api.ServerClusterJoinStatusFailed
+		clusterJoin.Error = joinErr.Error()
+
+		s.warning.Emit(ctx, warning.NewWarning(
+			api.WarningTypeServerClusterJoinFailed,
+			scope,
+			fmt.Sprintf("Failed to join cluster %q: %v", clusterJoin.Cluster, joinErr),
+		))
+	} else {
+		clusterJoin.Status = api.ServerClusterJoinStatusJoined
+		clusterJoin.Error = ""
+
+		s.warning.RemoveStale(ctx, scope, nil)
+	}
+
+	clusterJoin.LastUpdated = s.now()
+
+	err := transaction.Do(ctx, func(ctx context.Context) error {
+		server, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get server %q by name: %w", name, err)
+		}
+
+		server.ClusterJoin = clusterJoin
+
+		return s.repo.Update(ctx, *server)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to record cluster join of server %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// pruneClusterJoins resets the joins, which have been interrupted by a restart
+// of Operations Center, to pending, such that they are attempted again.
+func (s *serverService) pruneClusterJoins(ctx context.Context) error {
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		servers, err := s.repo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to get servers during prune: %w", err)
+		}
+
+		for _, server := range servers {
+			if server.ClusterJoin.Status != api.ServerClusterJoinStatusJoining {
+				continue
+			}
+
+			server.ClusterJoin.Status = api.ServerClusterJoinStatusPending
+			server.ClusterJoin.LastUpdated = s.now()
+
+			err = s.repo.Update(ctx, server)
+			if err != nil {
+				return fmt.Errorf("Failed to reset interrupted cluster join of server %q: %w", server.Name, err)
+			}
+
+			slog.WarnContext(ctx, "Reset interrupted cluster join to pending", slog.String("server", server.Name), slog.String("cluster", server.ClusterJoin.Cluster))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,430 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/internal/util/logger"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// decommissionStepFunc performs a single step of the decommission of a server.
+// If the step is not applicable for the server, it is reported as skipped with
+// the reason as detail.
+type decommissionStepFunc func(ctx context.Context, name string) (_ api.ServerDecommissionStepStatus, detail string, _ error)
+
+// DecommissionByName retires a server by running the following steps in
+// order, each of them only if enabled in the options:
+//
+//   - Evacuate the server.
+//   - Remove the server from its cluster.
+//   - Factory reset IncusOS on the server.
+//   - Power off the server via its BMC.
+//   - Securely erase the disks of the server via its BMC.
+//   - Remove the server from the inventory.
+//
+// The steps are performed in the background and the initiated decommission is
+// returned immediately. The progress is recorded in an audit record, which is
+// kept after the decommission has finished. If resume is set, the last failed
+// decommission of the server is continued with its original options and the
+// steps, which have already been completed, are not performed again.
+func (s *serverService) DecommissionByName(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (*provisioning.ServerDecommission, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Server name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	var decommission provisioning.ServerDecommission
+
+	// The check for a running decommission and the recording of the new one
+	// are performed in the same transaction, such that concurrent requests for
+	// the same server can not both pass the check.
+	err := transaction.Do(ctx, func(ctx context.Context) error {
+		previousDecommissions, err := s.repo.GetDecommissionsByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get decommissions of server %q: %w", name, err)
+		}
+
+		var previousDecommission *provisioning.ServerDecommission
+		for i := range previousDecommissions {
+			if previousDecommissions[i].Status == api.ServerDecommissionStatusRunning {
+				return fmt.Errorf("Decommission of server %q is already running: %w", name, domain.ErrOperationNotPermitted)
+			}
+
+			if previousDecommission == nil || previousDecommissions[i].StartedAt.After(previousDecommission.StartedAt) {
+				previousDecommission = &previousDecommissions[i]
+			}
+		}
+
+		decommission = provisioning.ServerDecommission{
+			UUID:      uuid.New(),
+			Server:    name,
+			Options:   options,
+			Status:    api.ServerDecommissionStatusRunning,
+			Steps:     api.ServerDecommissionStepResults{},
+			StartedAt: s.now(),
+		}
+
+		if resume {
+			if previousDecommission == nil || previousDecommission.Status != api.ServerDecommissionStatusFailed {
+				return fmt.Errorf("Server %q does not have a failed decommission, which could be resumed: %w", name, domain.ErrOperationNotPermitted)
+			}
+
+			decommission = *previousDecommission
+			decommission.Status = api.ServerDecommissionStatusRunning
+			decommission.Error = ""
+		}
+
+		server, err := s.repo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to get server %q: %w", name, err)
+		}
+
+		if server.Type == api.ServerTypeOperationsCenter {
+			return fmt.Errorf("Decommission of Operations Center: %w", domain.ErrOperationNotPermitted)
+		}
+
+		pending := func(step api.ServerDecommissionStep) bool {
+			return decommission.Options.Enabled(step) && !decommission.IsDone(step)
+		}
+
+		if pending(api.ServerDecommissionStepWipeDisks) && confirmDiskWipe != name {
+			return domain.NewValidationErrf("Invalid decommission, the erasure of the disks of server %q needs to be confirmed with the name of the server", name)
+		}
+
+		if pending(api.ServerDecommissionStepBMCPowerOff) || pending(api.ServerDecommissionStepWipeDisks) {
+			if !server.BMCConfig.HasBMC() {
+				return fmt.Errorf("Server %q does not have a BMC configured: %w", name, domain.ErrOperationNotPermitted)
+			}
+
+			_, ok := s.bmcServerClients[server.BMCConfig.APIType]
+			if !ok {
+				return fmt.Errorf("Failed to get BMC server client for type %q", server.BMCConfig.APIType)
+			}
+		}
+
+		if resume {
+			err = s.repo.UpdateDecommission(ctx, decommission)
+		} else {
+			_, err = s.repo.CreateDecommission(ctx, decommission)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed to record decommission of server %q: %w", name, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Decommission initiated", slog.String("server", name), slog.Bool("resume", resume))
+
+	initiated := decommission
+	initiated.Steps = slices.Clone(decommission.Steps)
+
+	go func() {
+		// Use a detached context in order to make sure, no existing DB transaction is inherited.
+		ctx := context.Background()
+
+		err := s.decommission(ctx, decommission)
+		if err != nil {
+			slog.WarnContext(ctx, "Decommission failed", logger.Err(err), slog.String("server", name))
+			return
+		}
+
+		slog.InfoContext(ctx, "Decommission completed", slog.String("server", name))
+	}()
+
+	return &initiated, nil
+}
+
+// decommission runs the pending steps of the decommission and records the
+// progress after each step.
+func (s *serverService) decommission(ctx context.Context, decommission provisioning.ServerDecommission) error {
+	name := decommission.Server
+
+	steps := map[api.ServerDecommissionStep]decommissionStepFunc{
+		api.ServerDecommissionStepEvacuate:          s.decommissionEvacuate,
+		api.ServerDecommissionStepRemoveFromCluster: s.decommissionRemoveFromCluster,
+		api.ServerDecommissionStepFactoryReset:      s.decommissionFactoryReset,
+		api.ServerDecommissionStepBMCPowerOff:       s.decommissionBMCPowerOff,
+		api.ServerDecommissionStepWipeDisks:         s.decommissionWipeDisks,
+		api.ServerDecommissionStepDelete:            s.decommissionDelete,
+	}
+
+	for _, step := range api.ServerDecommissionSteps {
+		if decommission.IsDone(step) {
+			continue
+		}
+
+		result := api.ServerDecommissionStepResult{
+			Step:      step,
+			StartedAt: s.now(),
+		}
+
+		var stepErr error
+		if decommission.Options.Enabled(step) {
+			result.Status, result.Detail, stepErr = steps[step](ctx, name)
+			if stepErr != nil {
+				result.Status = api.ServerDecommissionStepStatusFailed
+				result.Detail = stepErr.Error()
+			}
+		} else {
+			result.Status = api.ServerDecommissionStepStatusSkipped
+			result.Detail = "step not enabled"
+		}
+
+		result.FinishedAt = s.now()
+		decommission.Steps = append(decommission.Steps, result)
+
+		if stepErr != nil {
+			decommission.Status = api.ServerDecommissionStatusFailed
+			decommission.Error = stepErr.Error()
+			decommission.FinishedAt = s.now()
+		}
+
+		err := s.repo.UpdateDecommission(ctx, decommission)
+		if err != nil {
+			return errors.Join(stepErr, fmt.Errorf("Failed to record decommission step %q of server %q: %w", step, name, err))
+		}
+
+		if stepErr != nil {
+			return fmt.Errorf("Decommission step %q of server %q failed: %w", step, name, stepErr)
+		}
+	}
+
+	decommission.Status = api.ServerDecommissionStatusSucceeded
+	decommission.FinishedAt = s.now()
+
+	err := s.repo.UpdateDecommission(ctx, decommission)
+	if err != nil {
+		return fmt.Errorf("Failed to record decommission of server %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// pruneDecommissions marks the decommissions as failed, which are still
+// recorded as running. This is the case, if Operations Center has been stopped
+// while the steps of a decommission have been performed in the background.
+// The failed decommissions can then be resumed.
+func (s *serverService) pruneDecommissions(ctx context.Context) error {
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		decommissions, err := s.repo.GetDecommissions(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to get decommissions during prune: %w", err)
+		}
+
+		for _, decommission := range decommissions {
+			if decommission.Status != api.ServerDecommissionStatusRunning {
+				continue
+			}
+
+			decommission.Status = api.ServerDecommissionStatusFailed
+			decommission.Error = "Decommission has been interrupted by a restart of Operations Center"
+			decommission.FinishedAt = s.now()
+
+			err = s.repo.UpdateDecommission(ctx, decommission)
+			if err != nil {
+				return fmt.Errorf("Failed to mark interrupted decommission of server %q as failed: %w", decommission.Server, err)
+			}
+
+			slog.WarnContext(ctx, "Marked interrupted decommission as failed", slog.String("server", decommission.Server))
+		}
+
+		return nil
+	})
+}
+
+func (s *serverService) decommissionEvacuate(ctx context.Context, name string) (api.ServerDecommissionStepStatus, string, error) {
+	server, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	if !server.Type.IsIncus() {
+		return api.ServerDecommissionStepStatusSkipped, fmt.Sprintf("server is not of type %q", api.ServerTypeIncus), nil
+	}
+
+	if server.Cluster == nil {
+		return api.ServerDecommissionStepStatusSkipped, "server is not part of a cluster", nil
+	}
+
+	for _, application := range server.VersionData.Applications {
+		if domain.IsApplicationNameIncusKind(application.Name) && application.InMaintenance == api.InMaintenanceEvacuated {
+			return api.ServerDecommissionStepStatusSkipped, "server is already evacuated", nil
+		}
+	}
+
+	evacuated := make(chan error, 1)
+	err = s.client.Evacuate(ctx, *server, func(ctx context.Context, err error) {
+		evacuated <- err
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to evacuate server %q: %w", name, err)
+	}
+
+	select {
+	case err = <-evacuated:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to evacuate server %q: %w", name, err)
+	}
+
+	err = s.handleMaintenanceUpdate(ctx, server, api.InMaintenanceEvacuated)
+	if err != nil {
+		return "", "", err
+	}
+
+	return api.ServerDecommissionStepStatusDone, "", nil
+}
+
+func (s *serverService) decommissionRemoveFromCluster(ctx context.Context, name string) (api.ServerDecommissionStepStatus, string, error) {
+	server, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	if server.Cluster == nil {
+		return api.ServerDecommissionStepStatusSkipped, "server is not part of a cluster", nil
+	}
+
+	err = s.clusterSvc.RemoveServer(ctx, *server.Cluster, []string{name})
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to remove server %q from cluster %q: %w", name, *server.Cluster, err)
+	}
+
+	return api.ServerDecommissionStepStatusDone, fmt.Sprintf("removed from cluster %q", *server.Cluster), nil
+}
+
+func (s *serverService) decommissionFactoryReset(ctx context.Context, name string) (api.ServerDecommissionStepStatus, string, error) {
+	server, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	if server.Type.IsIncus() && server.Cluster != nil {
+		return "", "", fmt.Errorf("Factory reset of clustered server: %w", domain.ErrOperationNotPermitted)
+	}
+
+	err = s.factoryReset(ctx, *server, nil, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	return api.ServerDecommissionStepStatusDone, "", nil
+}
+
+func (s *serverService) decommissionBMCPowerOff(ctx context.Context, name string) (api.ServerDecommissionStepStatus, string, error) {
+	server, client, err := s.getServerAndBMCClientByName(ctx, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	taskMonitor, err := client.ServerPowerOff(ctx, *server, false)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to trigger power off of server %q via BMC: %w", name, err)
+	}
+
+	err = client.WaitForTask(ctx, *server, taskMonitor)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to wait for power off of server %q via BMC: %w", name, err)
+	}
+
+	return api.ServerDecommissionStepStatusDone, "", nil
+}
+
+func (s *serverService) decommissionWipeDisks(ctx context.Context, name string) (api.ServerDecommissionStepStatus, string, error) {
+	server, client, err := s.getServerAndBMCClientByName(ctx, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	taskMonitors, err := client.SecureEraseDrives(ctx, *server)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to trigger erasure of the disks of server %q via BMC: %w", name, err)
+	}
+
+	drives := make([]string, 0, len(taskMonitors))
+	for drive := range taskMonitors {
+		drives = append(drives, drive)
+	}
+
+	slices.Sort(drives)
+
+	// The erasure of the disks is only considered done, once the BMC has
+	// confirmed the completion for every disk.
+	for _, drive := range drives {
+		err = client.WaitForTask(ctx, *server, taskMonitors[drive])
+		if err != nil {
+			return "", "", fmt.Errorf("Failed to wait for erasure of disk %q of server %q via BMC: %w", drive, name, err)
+		}
+
+		slog.InfoContext(ctx, "Disk erasure confirmed by BMC", slog.String("server", name), slog.String("drive", drive))
+	}
+
+	return api.ServerDecommissionStepStatusDone, fmt.Sprintf("erased disks: %s", strings.Join(drives, ", ")), nil
+}
+
+func (s *serverService) decommissionDelete(ctx context.Context, name string) (api.ServerDecommissionStepStatus, string, error) {
+	err := s.DeleteByName(ctx, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	return api.ServerDecommissionStepStatusDone, "", nil
+}
+
+func (s *serverService) GetDecommissions(ctx context.Context) (provisioning.ServerDecommissions, error) {
+	decommissions, err := s.repo.GetDecommissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get server decommissions: %w", err)
+	}
+
+	sortDecommissions(decommissions)
+
+	return decommissions, nil
+}
+
+func (s *serverService) GetDecommissionsByName(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Server name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	decommissions, err := s.repo.GetDecommissionsByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get decommissions of server %q: %w", name, err)
+	}
+
+	sortDecommissions(decommissions)
+
+	return decommissions, nil
+}
+
+func (s *serverService) GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+	decommission, err := s.repo.GetDecommissionByUUID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get server decommission %q: %w", id.String(), err)
+	}
+
+	return decommission, nil
+}
+
+func sortDecommissions(decommissions provisioning.ServerDecommissions) {
+	slices.SortStableFunc(decommissions, func(a, b provisioning.ServerDecommission) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+}
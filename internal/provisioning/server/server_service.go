@@ -11,6 +11,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -39,6 +41,10 @@ import (
 
 const rebootStatusUpdateGracePeriod = 30 * time.Second
 
+// hardwareSnapshotRetention is the number of the most recent hardware
+// snapshots, which are kept for each server.
+const hardwareSnapshotRetention = 50
+
 type serverService struct {
 	repo             provisioning.ServerRepo
 	client           provisioning.ServerClientPort
@@ -47,6 +53,8 @@ type serverService struct {
 	tokenSvc         provisioning.TokenService
 	clusterSvc       provisioning.ClusterService
 	channelSvc       provisioning.ChannelService
+	siteSvc          provisioning.SiteService
+	rolloutSvc       provisioning.RolloutService
 	updateSvc        provisioning.UpdateService
 	warning          provisioning.WarningServicePort
 
@@ -63,6 +71,14 @@ type serverService struct {
 	selfUpdateSignal signals.Signal[provisioning.Server]
 
 	rebootStatusUpdateGracePeriod time.Duration
+
+	bmcInstallMediaDir string
+
+	bmcFirmwareDownloadTimeout time.Duration
+	bmcFirmwareImageSizeLimit  int64
+
+	bmcInstallMediaMu     sync.Mutex
+	bmcInstallMediaServed map[uuid.UUID]int64
 }
 
 var _ provisioning.ServerService = &serverService{}
@@ -93,6 +109,30 @@ func WithRebootStatusUpdateGracePeriod(rebootStatusUpdateGracePeriod time.Durati
 	}
 }
 
+// WithBMCInstallMediaDir sets the directory, where the install images for the
+// installation of servers via BMC are stored.
+func WithBMCInstallMediaDir(dir string) Option {
+	return func(s *serverService) {
+		s.bmcInstallMediaDir = dir
+	}
+}
+
+// WithBMCFirmwareDownloadTimeout sets the maximum duration of the download of
+// a firmware image, which is pushed to the BMC.
+func WithBMCFirmwareDownloadTimeout(timeout time.Duration) Option {
+	return func(s *serverService) {
+		s.bmcFirmwareDownloadTimeout = timeout
+	}
+}
+
+// WithBMCFirmwareImageSizeLimit sets the maximum size in bytes of a firmware
+// image, which is pushed to the BMC.
+func WithBMCFirmwareImageSizeLimit(limit int64) Option {
+	return func(s *serverService) {
+		s.bmcFirmwareImageSizeLimit = limit
+	}
+}
+
 func AddBMCServerClient(bmcAPIType api.BMCAPIType, client provisioning.BMCServerClientPort) Option {
 	return func(s *serverService) {
 		s.bmcServerClients[bmcAPIType] = client
@@ -144,6 +184,12 @@ func New(
 		selfUpdateSignal: signals.New[provisioning.Server](),
 
 		rebootStatusUpdateGracePeriod: rebootStatusUpdateGracePeriod,
+
+		bmcInstallMediaDir:    filepath.Join(os.TempDir(), "operations-center-bmc-install"),
+		bmcInstallMediaServed: map[uuid.UUID]int64{},
+
+		bmcFirmwareDownloadTimeout: bmcFirmwareDownloadTimeout,
+		bmcFirmwareImageSizeLimit:  bmcFirmwareImageSizeLimit,
 	}
 
 	for _, opt := range opts {
@@ -157,12 +203,27 @@ func (s *serverService) SetClusterService(clusterSvc provisioning.ClusterService
 	s.clusterSvc = clusterSvc
 }
 
+func (s *serverService) SetSiteService(siteSvc provisioning.SiteService) {
+	s.siteSvc = siteSvc
+}
+
+func (s *serverService) SetRolloutService(rolloutSvc provisioning.RolloutService) {
+	s.rolloutSvc = rolloutSvc
+}
+
 func (s *serverService) PreRegister(ctx context.Context, newServer provisioning.Server) (provisioning.Server, error) {
 	err := newServer.Validate()
 	if err != nil {
 		return provisioning.Server{}, err
 	}
 
+	if newServer.Site != nil && s.siteSvc != nil {
+		_, err = s.siteSvc.GetByName(ctx, *newServer.Site)
+		if err != nil {
+			return provisioning.Server{}, fmt.Errorf("Failed to get site %q for server %q: %w", *newServer.Site, newServer.Name, err)
+		}
+	}
+
 	if newServer.BMCConfig.HasBMC() {
 		client, ok := s.bmcServerClients[newServer.BMCConfig.APIType]
 		if !ok {
@@ -200,8 +261,10 @@ func (s *serverService) PreRegister(ctx context.Context, newServer provisioning.
 }
 
 func (s *serverService) Register(ctx context.Context, token uuid.UUID, newServer provisioning.Server) (provisioning.Server, error) {
+	var ejectBMCInstallMedia bool
+
 	err := transaction.Do(ctx, func(ctx context.Context) error {
-		channel, err := s.tokenSvc.Consume(ctx, token)
+		consumedToken, err := s.tokenSvc.Consume(ctx, token)
 		if err != nil {
 			return fmt.Errorf("Consume token for server creation: %w", err)
 		}
@@ -227,11 +290,31 @@ func (s *serverService) Register(ctx context.Context, token uuid.UUID, newServer
 			newServer = *preRegisteredServer
 		}
 
+		// The server has been installed via its BMC, the install image is no
+		// longer needed.
+		if newServer.BMCInstall.Status.IsInProgress() {
+			newServer.BMCInstall.Status = api.ServerBMCInstallStatusRegistered
+			newServer.BMCInstall.LastUpdated = s.now()
+			ejectBMCInstallMedia = true
+		}
+
 		newServer.Status = api.ServerStatusPending
 		newServer.StatusDetail = api.ServerStatusDetailPendingRegistering
 		newServer.LastStatusUpdated = s.now()
 		newServer.LastSeen = s.now()
-		newServer.Channel = channel
+		newServer.Channel = consumedToken.Channel
+
+		// The server joins the cluster defined by the token automatically, as
+		// soon as it is ready.
+		if consumedToken.ClusterJoin.Cluster != "" && newServer.Cluster == nil {
+			newServer.ClusterJoin = api.ServerClusterJoin{
+				Cluster:                consumedToken.ClusterJoin.Cluster,
+				SkipPostJoinOperations: consumedToken.ClusterJoin.SkipPostJoinOperations,
+				CopyServicesConfig:     consumedToken.ClusterJoin.CopyServicesConfig,
+				Status:                 api.ServerClusterJoinStatusPending,
+				LastUpdated:            s.now(),
+			}
+		}
 
 		if newServer.Type == "" {
 			newServer.Type = api.ServerTypeUnknown
@@ -264,6 +347,20 @@ func (s *serverService) Register(ctx context.Context, token uuid.UUID, newServer
 		return provisioning.Server{}, err
 	}
 
+	if ejectBMCInstallMedia {
+		go func() {
+			// Use a detached context in order to make sure, no existing DB transaction is inherited.
+			ctx := context.Background()
+
+			s.ejectBMCInstallMedia(ctx, newServer)
+
+			err := s.PruneBMCInstallMedia(ctx)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to remove install images after installation via BMC", logger.Err(err), slog.String("name", newServer.Name))
+			}
+		}()
+	}
+
 	// Perform initial connection test to server right after registration.
 	// Since we have the background task to update the server state, we do not
 	// care about graceful shutdown for this "one off" check.
@@ -379,7 +476,7 @@ func (s *serverService) GetAllNamesWithFilter(ctx context.Context, filter provis
 
 	var serverIDs []string
 
-	if filter.Name == nil && filter.Cluster == nil {
+	if filter.Name == nil && filter.Cluster == nil && filter.Site == nil {
 		serverIDs, err = s.repo.GetAllNames(ctx)
 	} else {
 		serverIDs, err = s.repo.GetAllNamesWithFilter(ctx, filter)
@@ -427,9 +524,24 @@ func (s *serverService) GetByName(ctx context.Context, name string) (*provisioni
 }
 
 func (s *serverService) enrichServerWithVersionDetails(ctx context.Context, server *provisioning.Server) error {
-	updates, err := s.updateSvc.GetAllWithFilter(ctx, provisioning.UpdateFilter{
+	filter := provisioning.UpdateFilter{
 		Channel: &server.Channel,
-	})
+	}
+
+	// The updates of the servers of a cluster, which is part of a running
+	// rollout, are limited to the target version of the rollout.
+	if server.Cluster != nil && s.rolloutSvc != nil {
+		targetVersion, err := s.rolloutSvc.GetTargetVersionByCluster(ctx, *server.Cluster)
+		if err != nil {
+			return fmt.Errorf("Failed to get rollout target version for server %q: %w", server.Name, err)
+		}
+
+		if targetVersion != "" {
+			filter.MaxVersion = &targetVersion
+		}
+	}
+
+	updates, err := s.updateSvc.GetAllWithFilter(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("Failed to get channel for server %q: %w", server.Name, err)
 	}
@@ -557,6 +669,19 @@ func (s *serverService) Update(ctx context.Context, server provisioning.Server,
 			return fmt.Errorf("Update of channel not allowed for clustered server %q: %w", server.Name, domain.ErrOperationNotPermitted)
 		}
 
+		if ptr.From(previousServer.Site) != ptr.From(server.Site) {
+			if !force && previousServer.Cluster != nil {
+				return fmt.Errorf("Update of site not allowed for clustered server %q: %w", server.Name, domain.ErrOperationNotPermitted)
+			}
+
+			if server.Site != nil && s.siteSvc != nil {
+				_, err = s.siteSvc.GetByName(ctx, *server.Site)
+				if err != nil {
+					return fmt.Errorf("Failed to get site %q for server %q: %w", *server.Site, server.Name, err)
+				}
+			}
+		}
+
 		err = s.repo.Update(ctx, server)
 		if err != nil {
 			return fmt.Errorf("Failed to update server %q: %w", server.Name, err)
@@ -1630,7 +1755,25 @@ func (s *serverService) FactoryResetByName(ctx context.Context, name string, tok
 		return fmt.Errorf("Factory reset of clustered server: %w", domain.ErrOperationNotPermitted)
 	}
 
-	err = s.client.Ping(ctx, server)
+	err = s.factoryReset(ctx, *server, tokenID, tokenSeedName)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Factory reset failed to remove server from inventory: %w", err)
+	}
+
+	return nil
+}
+
+// factoryReset performs the factory reset of IncusOS on the given server. The
+// server is not removed from the inventory.
+func (s *serverService) factoryReset(ctx context.Context, server provisioning.Server, tokenID *uuid.UUID, tokenSeedName *string) error {
+	name := server.Name
+
+	err := s.client.Ping(ctx, server)
 	if err != nil {
 		return fmt.Errorf("Pre factory reset connection test to server %q: %w", name, err)
 	}
@@ -1697,11 +1840,6 @@ func (s *serverService) FactoryResetByName(ctx context.Context, name string, tok
 		return fmt.Errorf("Factory reset on server %s: %w", server.Name, err)
 	}
 
-	err = s.repo.DeleteByName(ctx, name)
-	if err != nil {
-		return fmt.Errorf("Factory reset failed to remove server from inventory: %w", err)
-	}
-
 	return nil
 }
 
@@ -1914,6 +2052,98 @@ func (s *serverService) GetChangelogByName(ctx context.Context, name string) (ap
 	return changelog, nil
 }
 
+func (s *serverService) GetHardwareHistoryByName(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Server name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	_, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	snapshots, err := s.repo.GetHardwareSnapshotsByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get hardware snapshots of server %q: %w", name, err)
+	}
+
+	return snapshots, nil
+}
+
+func (s *serverService) GetDiskHealthWithFilter(ctx context.Context, filter provisioning.ServerFilter) ([]api.ServerDiskHealth, error) {
+	var servers provisioning.Servers
+	var err error
+	if filter.IsEmpty() {
+		servers, err = s.repo.GetAll(ctx)
+	} else {
+		servers, err = s.repo.GetAllWithFilter(ctx, filter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get servers: %w", err)
+	}
+
+	diskHealth := []api.ServerDiskHealth{}
+	for _, server := range servers {
+		samples, err := s.repo.GetDiskHealthSamplesByName(ctx, server.Name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get disk health samples of server %q: %w", server.Name, err)
+		}
+
+		for _, drive := range server.OSData.Storage.State.Drives {
+			if drive.Remote || drive.Removable {
+				continue
+			}
+
+			diskHealth = append(diskHealth, provisioning.EvaluateDiskHealth(server, drive, samples.ByDrive(provisioning.DiskHealthDriveID(drive))))
+		}
+	}
+
+	return diskHealth, nil
+}
+
+func (s *serverService) GetDiskHealthByName(ctx context.Context, name string) ([]api.ServerDiskHealth, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Server name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	server, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get server %q: %w", name, err)
+	}
+
+	samples, err := s.repo.GetDiskHealthSamplesByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get disk health samples of server %q: %w", name, err)
+	}
+
+	diskHealth := []api.ServerDiskHealth{}
+	for _, drive := range server.OSData.Storage.State.Drives {
+		if drive.Remote || drive.Removable {
+			continue
+		}
+
+		history := samples.ByDrive(provisioning.DiskHealthDriveID(drive))
+
+		health := provisioning.EvaluateDiskHealth(*server, drive, history)
+		health.History = make([]api.DiskHealthSample, 0, len(history))
+		for _, sample := range history {
+			health.History = append(health.History, api.DiskHealthSample{
+				Passed:             sample.Passed,
+				PercentageUsed:     sample.PercentageUsed,
+				AvailableSpare:     sample.AvailableSpare,
+				ReallocatedSectors: sample.ReallocatedSectors,
+				PowerOnHours:       sample.PowerOnHours,
+				RecordedAt:         sample.RecordedAt,
+			})
+		}
+
+		diskHealth = append(diskHealth, health)
+	}
+
+	return diskHealth, nil
+}
+
 func (s *serverService) PollServer(ctx context.Context, server provisioning.Server, updateServerConfiguration bool) error {
 	log := slog.With(slog.String("name", server.Name), slog.String("url", server.ConnectionURL))
 
@@ -2173,6 +2403,16 @@ func (s *serverService) PollServer(ctx context.Context, server provisioning.Serv
 		}
 
 		if updateServerConfiguration {
+			err = s.recordHardwareChanges(ctx, *server, hardwareData, runServerRegistrationScriptlet)
+			if err != nil {
+				return err
+			}
+
+			err = s.recordDiskHealth(ctx, *server, osData)
+			if err != nil {
+				return err
+			}
+
 			server.HardwareData = hardwareData
 			server.OSData = osData
 			server.VersionData = versionData
@@ -2215,6 +2455,135 @@ func (s *serverService) PollServer(ctx context.Context, server provisioning.Serv
 	return nil
 }
 
+// recordHardwareChanges records a new hardware snapshot for the server, if
+// this is the first hardware data received from the server or if the
+// hardware of the server did change since the last poll. Only the
+// hardwareSnapshotRetention most recent snapshots are kept.
+//
+// Every change is reported as warning, since hardware changes are not
+// expected to happen without notice. The exception are changes, which are
+// observed while the server is registering again, e.g. after it has been
+// replaced or reinstalled, or while the server is decommissioned. In this
+// case, the snapshot is only recorded as the new baseline.
+func (s *serverService) recordHardwareChanges(ctx context.Context, server provisioning.Server, hardwareData api.HardwareData, registering bool) error {
+	if !provisioning.HasHardwareData(hardwareData) {
+		return nil
+	}
+
+	changes := provisioning.DiffHardware(server.HardwareData, hardwareData)
+	if provisioning.HasHardwareData(server.HardwareData) && len(changes) == 0 {
+		return nil
+	}
+
+	if changes == nil {
+		changes = api.HardwareChanges{}
+	}
+
+	_, err := s.repo.CreateHardwareSnapshot(ctx, provisioning.ServerHardwareSnapshot{
+		Server:       server.Name,
+		HardwareData: hardwareData,
+		Changes:      changes,
+		CreatedAt:    s.now(),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to record hardware snapshot for server %q: %w", server.Name, err)
+	}
+
+	err = s.repo.DeleteHardwareSnapshotsExceptLatestByName(ctx, server.Name, hardwareSnapshotRetention)
+	if err != nil {
+		return fmt.Errorf("Failed to remove outdated hardware snapshots of server %q: %w", server.Name, err)
+	}
+
+	if len(changes) == 0 || registering {
+		return nil
+	}
+
+	decommissions, err := s.repo.GetDecommissionsByName(ctx, server.Name)
+	if err != nil {
+		return fmt.Errorf("Failed to get decommissions of server %q: %w", server.Name, err)
+	}
+
+	for _, decommission := range decommissions {
+		if decommission.Status == api.ServerDecommissionStatusRunning {
+			return nil
+		}
+	}
+
+	scope := api.WarningScope{
+		Scope:      "hardware_change",
+		EntityType: "server",
+		Entity:     server.Name,
+	}
+
+	for _, change := range changes {
+		s.warning.Emit(ctx, warning.NewWarning(
+			api.WarningTypeServerHardwareChanged,
+			scope,
+			fmt.Sprintf("Unexpected hardware change: %s", change),
+		))
+	}
+
+	return nil
+}
+
+// recordDiskHealth records a sample of the SMART health attributes for every
+// local drive of the server, if the attributes did change since the last
+// sample, and reports drives, which crossed a health threshold, as warnings.
+// Samples older than the retention period are removed afterwards.
+func (s *serverService) recordDiskHealth(ctx context.Context, server provisioning.Server, osData api.OSData) error {
+	samples, err := s.repo.GetDiskHealthSamplesByName(ctx, server.Name)
+	if err != nil {
+		return fmt.Errorf("Failed to get disk health samples of server %q: %w", server.Name, err)
+	}
+
+	scope := api.WarningScope{
+		Scope:      "disk_health",
+		EntityType: "server",
+		Entity:     server.Name,
+	}
+
+	warnings := warning.Warnings{}
+	for _, drive := range osData.Storage.State.Drives {
+		if drive.Remote || drive.Removable {
+			continue
+		}
+
+		driveID := provisioning.DiskHealthDriveID(drive)
+		history := samples.ByDrive(driveID)
+
+		sample, ok := provisioning.NewServerDiskHealthSample(server.Name, drive, s.now())
+		if ok && sample.NeedsRecording(history.Latest()) {
+			_, err = s.repo.CreateDiskHealthSample(ctx, sample)
+			if err != nil {
+				return fmt.Errorf("Failed to record disk health sample for drive %q of server %q: %w", driveID, server.Name, err)
+			}
+		}
+
+		health := provisioning.EvaluateDiskHealth(server, drive, history)
+		if health.Status != api.DiskHealthStatusWarning && health.Status != api.DiskHealthStatusFailing {
+			continue
+		}
+
+		w := warning.NewWarning(
+			api.WarningTypeServerDiskHealth,
+			scope,
+			fmt.Sprintf("Disk %q (%s) is %s: %s", driveID, drive.ModelName, health.Status, strings.Join(health.Reasons, ", ")),
+		)
+
+		s.warning.Emit(ctx, w)
+		warnings = append(warnings, w)
+	}
+
+	s.warning.RemoveStale(ctx, scope, warnings)
+
+	err = s.repo.DeleteDiskHealthSamplesRecordedBeforeByName(ctx, server.Name, s.now().Add(-provisioning.DiskHealthSampleRetention))
+	if err != nil {
+		return fmt.Errorf("Failed to remove outdated disk health samples of server %q: %w", server.Name, err)
+	}
+
+	return nil
+}
+
 func (s *serverService) connectionTestWithCertificateUpdate(ctx context.Context, server provisioning.Server, log *slog.Logger) error {
 	// Since we re-try frequently, we only grant a short timeout for the
 	// connection attept.
@@ -2364,6 +2733,36 @@ func (s *serverService) connectionTestWithCertificateUpdate(ctx context.Context,
 	return domain.NewRetryableErr(err)
 }
 
+// Prune resets the state of operations, which have been performed in the
+// background and which have been interrupted by a shutdown of the service.
+// Prune is normally only called on startup of the service.
+// Prune resets the following states:
+//
+//   - Decommissions in running state are marked as failed, such that they can
+//     be resumed.
+//   - Installations via BMC in preparing state are marked as failed, such that
+//     they can be retried. Left over install images are removed.
+//   - Cluster joins in joining state are reset to pending, such that they are
+//     attempted again by the cluster join control loop.
+func (s *serverService) Prune(ctx context.Context) error {
+	err := s.pruneDecommissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.pruneClusterJoins(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.pruneBMCInstalls(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *serverService) ResyncBMCData(ctx context.Context) error {
 	servers, err := s.repo.GetAll(ctx)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/maniartech/signals"
 
 	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
 )
 
 func WithSelfUpdateSignal(signal signals.Signal[provisioning.Server]) Option {
@@ -19,3 +20,7 @@ func WithHTTPClient(httpClient *http.Client) Option {
 		s.httpClient = httpClient
 	}
 }
+
+func (s *serverService) BMCInstallMediaPath(bmcInstall api.ServerBMCInstall) string {
+	return s.bmcInstallMediaPath(bmcInstall)
+}
@@ -7,9 +7,14 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +22,7 @@ import (
 	"github.com/google/uuid"
 	incusosapi "github.com/lxc/incus-os/incus-osd/api"
 	"github.com/lxc/incus-os/incus-osd/api/images"
+	incusapi "github.com/lxc/incus/v7/shared/api"
 	incustls "github.com/lxc/incus/v7/shared/tls"
 	"github.com/maniartech/signals"
 	"github.com/stretchr/testify/require"
@@ -38,6 +44,7 @@ import (
 	"github.com/FuturFusion/operations-center/internal/util/testing/log"
 	"github.com/FuturFusion/operations-center/internal/util/testing/queue"
 	"github.com/FuturFusion/operations-center/internal/util/testing/uuidgen"
+	"github.com/FuturFusion/operations-center/internal/warning"
 	"github.com/FuturFusion/operations-center/shared/api"
 	"github.com/FuturFusion/operations-center/shared/api/system"
 )
@@ -414,6 +421,7 @@ func TestServerService_Register(t *testing.T) {
 		name                   string
 		server                 provisioning.Server
 		repoCreateErr          error
+		tokenSvcConsumeToken   provisioning.Token
 		tokenSvcConsumeErr     error
 		repoGetBySystemUUID    *provisioning.Server
 		repoGetBySystemUUIDErr error
@@ -421,7 +429,8 @@ func TestServerService_Register(t *testing.T) {
 		repoGetByMachineIDErr  error
 		repoUpdateErr          error
 
-		assertErr require.ErrorAssertionFunc
+		assertErr       require.ErrorAssertionFunc
+		wantClusterJoin api.ServerClusterJoin
 	}{
 		{
 			name: "success - new registration",
@@ -436,6 +445,32 @@ one
 
 			assertErr: require.NoError,
 		},
+		{
+			name: "success - new registration with cluster join",
+			server: provisioning.Server{
+				Name:          "one",
+				ConnectionURL: "http://one/",
+				Certificate: `-----BEGIN CERTIFICATE-----
+one
+-----END CERTIFICATE-----
+`,
+			},
+			tokenSvcConsumeToken: provisioning.Token{
+				Channel: "stable",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster:            "cluster01",
+					CopyServicesConfig: true,
+				},
+			},
+
+			assertErr: require.NoError,
+			wantClusterJoin: api.ServerClusterJoin{
+				Cluster:            "cluster01",
+				CopyServicesConfig: true,
+				Status:             api.ServerClusterJoinStatusPending,
+				LastUpdated:        fixedDate,
+			},
+		},
 		{
 			name: "success - pre registered server by system UUID",
 			server: provisioning.Server{
@@ -593,6 +628,7 @@ one
 			repo := &repoMock.ServerRepoMock{
 				CreateFunc: func(ctx context.Context, in provisioning.Server) (int64, error) {
 					require.Equal(t, fixedDate, in.LastSeen)
+					require.Equal(t, tc.wantClusterJoin, in.ClusterJoin)
 					return 1, tc.repoCreateErr
 				},
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
@@ -646,8 +682,8 @@ one
 			}
 
 			tokenSvc := &svcMock.TokenServiceMock{
-				ConsumeFunc: func(ctx context.Context, id uuid.UUID) (string, error) {
-					return "stable", tc.tokenSvcConsumeErr
+				ConsumeFunc: func(ctx context.Context, id uuid.UUID) (*provisioning.Token, error) {
+					return &tc.tokenSvcConsumeToken, tc.tokenSvcConsumeErr
 				},
 			}
 
@@ -1014,9 +1050,12 @@ func TestServerService_GetByName(t *testing.T) {
 		repoGetByNameErr             error
 		updateSvcGetAllWithFilter    provisioning.Updates
 		updateSvcGetAllWithFilterErr error
+		rolloutSvcTargetVersion      string
+		rolloutSvcTargetVersionErr   error
 
-		assertErr  require.ErrorAssertionFunc
-		wantServer *provisioning.Server
+		assertErr      require.ErrorAssertionFunc
+		wantServer     *provisioning.Server
+		wantMaxVersion *string
 	}{
 		{
 			name:    "success - no updates",
@@ -1318,6 +1357,32 @@ func TestServerService_GetByName(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "success - cluster part of running rollout",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name:          "one",
+				Cluster:       ptr.To("one"),
+				ConnectionURL: "http://one/",
+			},
+			rolloutSvcTargetVersion: "202501020304",
+
+			assertErr: require.NoError,
+			wantServer: &provisioning.Server{
+				Name:          "one",
+				Cluster:       ptr.To("one"),
+				ConnectionURL: "http://one/",
+				VersionData: api.ServerVersionData{
+					NeedsUpdate:   ptr.To(false),
+					NeedsReboot:   ptr.To(false),
+					InMaintenance: ptr.To(api.NotInMaintenance),
+					OS: api.OSVersionData{
+						NeedsUpdate: ptr.To(false),
+					},
+				},
+			},
+			wantMaxVersion: ptr.To("202501020304"),
+		},
 		{
 			name:    "error - name empty",
 			nameArg: "", // invalid
@@ -1341,6 +1406,18 @@ func TestServerService_GetByName(t *testing.T) {
 			},
 			updateSvcGetAllWithFilterErr: boom.Error,
 
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - rolloutSvc.GetTargetVersionByCluster",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name:          "one",
+				Cluster:       ptr.To("one"),
+				ConnectionURL: "http://one/",
+			},
+			rolloutSvcTargetVersionErr: boom.Error,
+
 			assertErr: boom.ErrorIs,
 		},
 	}
@@ -1354,16 +1431,25 @@ func TestServerService_GetByName(t *testing.T) {
 				},
 			}
 
+			var gotMaxVersion *string
 			updateSvc := &svcMock.UpdateServiceMock{
 				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.UpdateFilter) (provisioning.Updates, error) {
+					gotMaxVersion = filter.MaxVersion
 					return tc.updateSvcGetAllWithFilter, tc.updateSvcGetAllWithFilterErr
 				},
 			}
 
+			rolloutSvc := &svcMock.RolloutServiceMock{
+				GetTargetVersionByClusterFunc: func(ctx context.Context, clusterName string) (string, error) {
+					return tc.rolloutSvcTargetVersion, tc.rolloutSvcTargetVersionErr
+				},
+			}
+
 			serverSvc := provisioningServer.New(
 				repo, nil, nil, nil, nil, nil, updateSvc, tls.Certificate{},
 				provisioningServer.WithWarningEmitter(provisioning.NoopWarningService{}),
 			)
+			serverSvc.SetRolloutService(rolloutSvc)
 
 			// Run test
 			server, err := serverSvc.GetByName(t.Context(), tc.nameArg)
@@ -1371,6 +1457,7 @@ func TestServerService_GetByName(t *testing.T) {
 			// Assert
 			tc.assertErr(t, err)
 			require.Equal(t, tc.wantServer, server)
+			require.Equal(t, tc.wantMaxVersion, gotMaxVersion)
 		})
 	}
 }
@@ -4586,11 +4673,55 @@ foobar
 func TestServerService_PollServer(t *testing.T) {
 	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
 
+	hardwareData := func(diskSerials ...string) api.HardwareData {
+		hardwareData := api.HardwareData{
+			Resources: incusapi.Resources{
+				CPU: incusapi.ResourcesCPU{
+					Architecture: "x86_64",
+				},
+			},
+		}
+
+		for _, serial := range diskSerials {
+			hardwareData.Storage.Disks = append(hardwareData.Storage.Disks, incusapi.ResourcesStorageDisk{
+				Model:  "Disk",
+				Serial: serial,
+				Size:   1000 * 1000 * 1000 * 1000,
+			})
+		}
+
+		return hardwareData
+	}
+
+	managementOSData := api.OSData{
+		Network: incusosapi.SystemNetwork{
+			State: incusosapi.SystemNetworkState{
+				Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+					"eth0": {
+						Addresses: []string{
+							"192.168.0.100",
+						},
+						Roles: []string{
+							"management",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	osDataWithDrives := func(drives ...incusosapi.SystemStorageDrive) api.OSData {
+		osData := managementOSData
+		osData.Storage.State.Drives = drives
+		return osData
+	}
+
 	tests := []struct {
 		name                           string
 		serverArg                      provisioning.Server
 		updateServerConfigArg          bool
 		clientIsReadyErr               error
+		clientGetResources             api.HardwareData
 		clientGetResourcesErr          error
 		clientGetOSData                api.OSData
 		clientGetOSDataErr             error
@@ -4602,12 +4733,24 @@ func TestServerService_PollServer(t *testing.T) {
 		clusterSvcGetByName            *provisioning.Cluster
 		clusterSvcGetByNameErr         error
 		repoUpdateErr                  error
+		repoCreateHardwareSnapshotErr  error
+		repoDeleteHardwareSnapshotsErr error
+		repoGetDecommissionsByName     provisioning.ServerDecommissions
+		repoGetDecommissionsByNameErr  error
+		repoGetDiskHealthSamples       provisioning.ServerDiskHealthSamples
+		repoGetDiskHealthSamplesErr    error
+		repoCreateDiskHealthSampleErr  error
+		repoDeleteDiskHealthSamplesErr error
 		updateSvcGetAllWithFilter      provisioning.Updates
 		updateSvcGetAllWithFilterErr   error
 
 		assertErr              require.ErrorAssertionFunc
 		assertLog              log.MatcherFunc
 		wantServerStatusDetail *api.ServerStatusDetail
+		wantHardwareSnapshots  []api.HardwareChanges
+		wantHardwareWarnings   []string
+		wantDiskHealthSamples  int
+		wantDiskHealthWarnings []string
 	}{
 		{
 			name: "success",
@@ -5240,118 +5383,489 @@ func TestServerService_PollServer(t *testing.T) {
 			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
 		},
 		{
-			name: "error - Update",
+			name: "success - initial hardware snapshot",
 			serverArg: provisioning.Server{
 				Name:   "one",
-				Status: api.ServerStatusPending,
+				Status: api.ServerStatusReady,
 			},
+			updateServerConfigArg: true,
 			repoGetByName: &provisioning.Server{
 				Name:   "one",
-				Status: api.ServerStatusPending,
-			},
-			updateServerConfigArg: true,
-			clientGetOSData: api.OSData{
-				Network: incusosapi.SystemNetwork{
-					State: incusosapi.SystemNetworkState{
-						Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
-							"eth0": {
-								Addresses: []string{
-									"192.168.0.100",
-								},
-								Roles: []string{
-									"management",
-								},
-							},
-						},
-					},
-				},
+				Status: api.ServerStatusReady,
 			},
-			repoUpdateErr: boom.Error,
+			clientGetResources: hardwareData("S1", "S2"),
+			clientGetOSData:    managementOSData,
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
 			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantHardwareSnapshots: []api.HardwareChanges{
+				{},
+			},
 		},
 		{
-			name: "error - clusterSvc.GetByName",
+			name: "success - hardware unchanged",
 			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
 				Name:         "one",
 				Status:       api.ServerStatusReady,
-				StatusDetail: api.ServerStatusDetailReadyRestoring,
+				HardwareData: hardwareData("S1", "S2"),
 			},
-			updateServerConfigArg: false,
+			clientGetResources: hardwareData("S1", "S2"),
+			clientGetOSData:    managementOSData,
+
+			assertErr: require.NoError,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+		},
+		{
+			name: "success - hardware changed",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
 			repoGetByName: &provisioning.Server{
 				Name:         "one",
-				Cluster:      ptr.To("cluster"),
 				Status:       api.ServerStatusReady,
-				StatusDetail: api.ServerStatusDetailReadyRestoring,
-				VersionData: api.ServerVersionData{
-					Applications: []api.ApplicationVersionData{
-						{
-							Name:          "incus",
-							Version:       "1",
-							InMaintenance: api.NotInMaintenance,
-						},
+				HardwareData: hardwareData("S1", "S2"),
+			},
+			clientGetResources: hardwareData("S1"),
+			clientGetOSData:    managementOSData,
+
+			assertErr: require.NoError,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantHardwareSnapshots: []api.HardwareChanges{
+				{
+					{
+						Component:  api.HardwareComponentDisk,
+						Action:     api.HardwareChangeActionRemoved,
+						Identifier: "S2",
+						Previous:   "Disk (1.00TB)",
 					},
 				},
 			},
-			clusterSvcGetByNameErr: boom.Error,
+			wantHardwareWarnings: []string{
+				`Unexpected hardware change: disk "S2" removed: Disk (1.00TB)`,
+			},
+		},
+		{
+			name: "success - hardware changed while registering",
+			serverArg: provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusPending,
+				StatusDetail: api.ServerStatusDetailPendingRegistering,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusPending,
+				StatusDetail: api.ServerStatusDetailPendingRegistering,
+				HardwareData: hardwareData("S1", "S2"),
+			},
+			clientGetResources: hardwareData("S1"),
+			clientGetOSData:    managementOSData,
 
-			assertErr: boom.ErrorIs,
+			assertErr: require.NoError,
 			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantHardwareSnapshots: []api.HardwareChanges{
+				{
+					{
+						Component:  api.HardwareComponentDisk,
+						Action:     api.HardwareChangeActionRemoved,
+						Identifier: "S2",
+						Previous:   "Disk (1.00TB)",
+					},
+				},
+			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			logBuf := &bytes.Buffer{}
-			err := logger.InitLogger(logBuf, "", false, true, true)
-			require.NoError(t, err)
-
-			repo := &repoMock.ServerRepoMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
-					return tc.repoGetByName, tc.repoGetByNameErr
+		{
+			name: "success - hardware changed while decommissioning",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusReady,
+				HardwareData: hardwareData("S1", "S2"),
+			},
+			clientGetResources: hardwareData("S1"),
+			clientGetOSData:    managementOSData,
+			repoGetDecommissionsByName: provisioning.ServerDecommissions{
+				{
+					Server: "one",
+					Status: api.ServerDecommissionStatusFailed,
 				},
-				UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
-					require.Equal(t, api.ServerStatusReady, server.Status)
-					require.Equal(t, fixedDate, server.LastSeen)
-					if tc.wantServerStatusDetail != nil {
-						require.Equal(t, *tc.wantServerStatusDetail, server.StatusDetail)
-					}
-
-					return tc.repoUpdateErr
+				{
+					Server: "one",
+					Status: api.ServerDecommissionStatusRunning,
 				},
-			}
+			},
 
-			client := &adapterMock.ServerClientPortMock{
-				PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
-					return nil
-				},
-				IsReadyFunc: func(ctx context.Context, server provisioning.Server) error {
-					return tc.clientIsReadyErr
+			assertErr: require.NoError,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantHardwareSnapshots: []api.HardwareChanges{
+				{
+					{
+						Component:  api.HardwareComponentDisk,
+						Action:     api.HardwareChangeActionRemoved,
+						Identifier: "S2",
+						Previous:   "Disk (1.00TB)",
+					},
 				},
-				GetResourcesFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.HardwareData, error) {
-					return api.HardwareData{}, tc.clientGetResourcesErr
+			},
+		},
+		{
+			name: "success - disk health degraded",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			clientGetOSData: osDataWithDrives(
+				incusosapi.SystemStorageDrive{
+					ID:           "nvme0",
+					ModelName:    "Disk",
+					SerialNumber: "S1",
+					SMART: &incusosapi.SystemStorageDriveSMART{
+						Enabled:        true,
+						Passed:         true,
+						PercentageUsed: 85,
+						AvailableSpare: 100,
+					},
 				},
-				GetOSDataFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.OSData, error) {
-					return tc.clientGetOSData, tc.clientGetOSDataErr
+				incusosapi.SystemStorageDrive{
+					ID:           "nvme1",
+					ModelName:    "Disk",
+					SerialNumber: "S2",
+					SMART: &incusosapi.SystemStorageDriveSMART{
+						Enabled:        true,
+						Passed:         true,
+						PercentageUsed: 10,
+						AvailableSpare: 100,
+					},
 				},
-				GetVersionDataFunc: func(ctx context.Context, server provisioning.Server) (api.ServerVersionData, error) {
-					return tc.clientGetVersionData, tc.clientGetVersionDataErr
+				incusosapi.SystemStorageDrive{
+					ID:        "usb",
+					Removable: true,
 				},
-				GetServerTypeFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.ServerType, error) {
-					return api.ServerTypeIncus, nil
+			),
+			repoGetDiskHealthSamples: provisioning.ServerDiskHealthSamples{
+				{
+					Server:         "one",
+					Drive:          "S2",
+					Passed:         true,
+					PercentageUsed: 10,
+					AvailableSpare: 100,
+					RecordedAt:     fixedDate.Add(-time.Hour),
 				},
-			}
+			},
 
-			runner := &adapterMock.ServerScriptletPortMock{
-				ServerRegistrationRunFunc: func(ctx context.Context, server *provisioning.Server) error {
-					return tc.runnerServerRegistrationRunErr
+			assertErr:             require.NoError,
+			assertLog:             log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantDiskHealthSamples: 1,
+			wantDiskHealthWarnings: []string{
+				`Disk "S1" (Disk) is warning: percentage used 85% reached the threshold of 80%`,
+			},
+		},
+		{
+			name: "error - GetDiskHealthSamplesByName",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			clientGetOSData:             managementOSData,
+			repoGetDiskHealthSamplesErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+		},
+		{
+			name: "error - CreateDiskHealthSample",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			clientGetOSData: osDataWithDrives(
+				incusosapi.SystemStorageDrive{
+					ID:           "nvme0",
+					SerialNumber: "S1",
+					SMART: &incusosapi.SystemStorageDriveSMART{
+						Enabled: true,
+						Passed:  true,
+					},
 				},
-			}
+			),
+			repoCreateDiskHealthSampleErr: boom.Error,
 
-			clusterSvc := &svcMock.ClusterServiceMock{
-				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+		},
+		{
+			name: "error - DeleteDiskHealthSamplesRecordedBeforeByName",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			clientGetOSData: osDataWithDrives(
+				incusosapi.SystemStorageDrive{
+					ID:           "nvme0",
+					SerialNumber: "S1",
+					SMART: &incusosapi.SystemStorageDriveSMART{
+						Enabled: true,
+						Passed:  true,
+					},
+				},
+			),
+			repoDeleteDiskHealthSamplesErr: boom.Error,
+
+			assertErr:             boom.ErrorIs,
+			assertLog:             log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantDiskHealthSamples: 1,
+		},
+		{
+			name: "error - CreateHardwareSnapshot",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusReady,
+				HardwareData: hardwareData("S1", "S2"),
+			},
+			clientGetResources:            hardwareData("S1"),
+			clientGetOSData:               managementOSData,
+			repoCreateHardwareSnapshotErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+		},
+		{
+			name: "error - DeleteHardwareSnapshotsExceptLatestByName",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusReady,
+				HardwareData: hardwareData("S1", "S2"),
+			},
+			clientGetResources:             hardwareData("S1"),
+			clientGetOSData:                managementOSData,
+			repoDeleteHardwareSnapshotsErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantHardwareSnapshots: []api.HardwareChanges{
+				{
+					{
+						Component:  api.HardwareComponentDisk,
+						Action:     api.HardwareChangeActionRemoved,
+						Identifier: "S2",
+						Previous:   "Disk (1.00TB)",
+					},
+				},
+			},
+		},
+		{
+			name: "error - GetDecommissionsByName",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+			},
+			updateServerConfigArg: true,
+			repoGetByName: &provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusReady,
+				HardwareData: hardwareData("S1", "S2"),
+			},
+			clientGetResources:            hardwareData("S1"),
+			clientGetOSData:               managementOSData,
+			repoGetDecommissionsByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+			wantHardwareSnapshots: []api.HardwareChanges{
+				{
+					{
+						Component:  api.HardwareComponentDisk,
+						Action:     api.HardwareChangeActionRemoved,
+						Identifier: "S2",
+						Previous:   "Disk (1.00TB)",
+					},
+				},
+			},
+		},
+		{
+			name: "error - Update",
+			serverArg: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusPending,
+			},
+			repoGetByName: &provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusPending,
+			},
+			updateServerConfigArg: true,
+			clientGetOSData: api.OSData{
+				Network: incusosapi.SystemNetwork{
+					State: incusosapi.SystemNetworkState{
+						Interfaces: map[string]incusosapi.SystemNetworkInterfaceState{
+							"eth0": {
+								Addresses: []string{
+									"192.168.0.100",
+								},
+								Roles: []string{
+									"management",
+								},
+							},
+						},
+					},
+				},
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+		},
+		{
+			name: "error - clusterSvc.GetByName",
+			serverArg: provisioning.Server{
+				Name:         "one",
+				Status:       api.ServerStatusReady,
+				StatusDetail: api.ServerStatusDetailReadyRestoring,
+			},
+			updateServerConfigArg: false,
+			repoGetByName: &provisioning.Server{
+				Name:         "one",
+				Cluster:      ptr.To("cluster"),
+				Status:       api.ServerStatusReady,
+				StatusDetail: api.ServerStatusDetailReadyRestoring,
+				VersionData: api.ServerVersionData{
+					Applications: []api.ApplicationVersionData{
+						{
+							Name:          "incus",
+							Version:       "1",
+							InMaintenance: api.NotInMaintenance,
+						},
+					},
+				},
+			},
+			clusterSvcGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			assertLog: log.EmptyWithIgnorePattern(log.IgnorePatternDebugLines),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			logBuf := &bytes.Buffer{}
+			err := logger.InitLogger(logBuf, "", false, true, true)
+			require.NoError(t, err)
+
+			var hardwareSnapshots []api.HardwareChanges
+			var diskHealthSamples int
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return tc.repoGetByName, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
+					require.Equal(t, api.ServerStatusReady, server.Status)
+					require.Equal(t, fixedDate, server.LastSeen)
+					if tc.wantServerStatusDetail != nil {
+						require.Equal(t, *tc.wantServerStatusDetail, server.StatusDetail)
+					}
+
+					return tc.repoUpdateErr
+				},
+				CreateHardwareSnapshotFunc: func(ctx context.Context, snapshot provisioning.ServerHardwareSnapshot) (int64, error) {
+					require.Equal(t, "one", snapshot.Server)
+					require.Equal(t, tc.clientGetResources, snapshot.HardwareData)
+					require.Equal(t, fixedDate, snapshot.CreatedAt)
+					hardwareSnapshots = append(hardwareSnapshots, snapshot.Changes)
+					return 1, tc.repoCreateHardwareSnapshotErr
+				},
+				DeleteHardwareSnapshotsExceptLatestByNameFunc: func(ctx context.Context, name string, keep int) error {
+					require.Equal(t, "one", name)
+					require.Equal(t, 50, keep)
+					return tc.repoDeleteHardwareSnapshotsErr
+				},
+				GetDecommissionsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+					return tc.repoGetDecommissionsByName, tc.repoGetDecommissionsByNameErr
+				},
+				GetDiskHealthSamplesByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error) {
+					return tc.repoGetDiskHealthSamples, tc.repoGetDiskHealthSamplesErr
+				},
+				CreateDiskHealthSampleFunc: func(ctx context.Context, sample provisioning.ServerDiskHealthSample) (int64, error) {
+					require.Equal(t, fixedDate, sample.RecordedAt)
+					diskHealthSamples++
+					return 1, tc.repoCreateDiskHealthSampleErr
+				},
+				DeleteDiskHealthSamplesRecordedBeforeByNameFunc: func(ctx context.Context, name string, before time.Time) error {
+					require.Equal(t, "one", name)
+					require.Equal(t, fixedDate.Add(-provisioning.DiskHealthSampleRetention), before)
+					return tc.repoDeleteDiskHealthSamplesErr
+				},
+			}
+
+			client := &adapterMock.ServerClientPortMock{
+				PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
+					return nil
+				},
+				IsReadyFunc: func(ctx context.Context, server provisioning.Server) error {
+					return tc.clientIsReadyErr
+				},
+				GetResourcesFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.HardwareData, error) {
+					return tc.clientGetResources, tc.clientGetResourcesErr
+				},
+				GetOSDataFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.OSData, error) {
+					return tc.clientGetOSData, tc.clientGetOSDataErr
+				},
+				GetVersionDataFunc: func(ctx context.Context, server provisioning.Server) (api.ServerVersionData, error) {
+					return tc.clientGetVersionData, tc.clientGetVersionDataErr
+				},
+				GetServerTypeFunc: func(ctx context.Context, endpoint provisioning.Endpoint) (api.ServerType, error) {
+					return api.ServerTypeIncus, nil
+				},
+			}
+
+			runner := &adapterMock.ServerScriptletPortMock{
+				ServerRegistrationRunFunc: func(ctx context.Context, server *provisioning.Server) error {
+					return tc.runnerServerRegistrationRunErr
+				},
+			}
+
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
 					return tc.clusterSvcGetByName, tc.clusterSvcGetByNameErr
 				},
 			}
@@ -5362,10 +5876,25 @@ func TestServerService_PollServer(t *testing.T) {
 				},
 			}
 
+			var hardwareWarnings []string
+			var diskHealthWarnings []string
+			warningSvc := &adapterMock.WarningServicePortMock{
+				EmitFunc: func(ctx context.Context, w warning.Warning) {
+					switch w.Type {
+					case api.WarningTypeServerHardwareChanged:
+						hardwareWarnings = append(hardwareWarnings, w.Messages...)
+					case api.WarningTypeServerDiskHealth:
+						diskHealthWarnings = append(diskHealthWarnings, w.Messages...)
+					}
+				},
+				RemoveStaleFunc: func(ctx context.Context, scope api.WarningScope, newWarnings warning.Warnings) {},
+			}
+
 			serverSvc := provisioningServer.New(
 				repo, client, runner, nil, clusterSvc, nil, updateSvc, tls.Certificate{},
 				provisioningServer.WithNow(func() time.Time { return fixedDate }),
 				provisioningServer.WithRebootStatusUpdateGracePeriod(5*time.Second),
+				provisioningServer.WithWarningEmitter(warningSvc),
 			)
 
 			// Run test
@@ -5374,6 +5903,15 @@ func TestServerService_PollServer(t *testing.T) {
 			// Assert
 			tc.assertErr(t, err)
 			tc.assertLog(t, logBuf)
+			if tc.repoCreateHardwareSnapshotErr == nil {
+				require.Equal(t, tc.wantHardwareSnapshots, hardwareSnapshots)
+				require.Equal(t, tc.wantHardwareWarnings, hardwareWarnings)
+			}
+
+			if tc.repoCreateDiskHealthSampleErr == nil {
+				require.Equal(t, tc.wantDiskHealthSamples, diskHealthSamples)
+				require.Equal(t, tc.wantDiskHealthWarnings, diskHealthWarnings)
+			}
 		})
 	}
 }
@@ -5621,46 +6159,294 @@ func TestServerService_PollServer_resyncBMCData(t *testing.T) {
 	}
 }
 
-func TestServerService_PollServer_in_transaction(t *testing.T) {
-	// Setup
-	logBuf := &bytes.Buffer{}
-	err := logger.InitLogger(logBuf, "", false, true, true)
-	require.NoError(t, err)
+func TestServerService_ClusterJoinControlLoop(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
 
-	repo := &repoMock.ServerRepoMock{
-		GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
-			return &provisioning.Server{
+	clusterJoinWithStatus := func(status api.ServerClusterJoinStatus, lastUpdated time.Time) api.ServerClusterJoin {
+		return api.ServerClusterJoin{
+			Cluster:            "cluster01",
+			CopyServicesConfig: true,
+			Status:             status,
+			LastUpdated:        lastUpdated,
+		}
+	}
+
+	joinedClusterJoin := clusterJoinWithStatus(api.ServerClusterJoinStatusJoined, fixedDate)
+
+	tests := []struct {
+		name                    string
+		repoGetAllErr           error
+		repoGetByNameServer     provisioning.Server
+		repoGetByNameErr        error
+		clusterSvcAddServersErr error
+		repoUpdateErr           error
+
+		assertErr                require.ErrorAssertionFunc
+		wantClusterSvcAddServers bool
+		wantClusterJoin          api.ServerClusterJoin
+		wantClusterJoinWarnings  int
+	}{
+		{
+			name: "success - server without cluster join",
+			repoGetByNameServer: provisioning.Server{
 				Name:   "one",
-				Status: api.ServerStatusPending,
-			}, nil
-		},
-		UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
-			return nil
+				Status: api.ServerStatusReady,
+			},
+
+			assertErr:       require.NoError,
+			wantClusterJoin: api.ServerClusterJoin{},
 		},
-	}
+		{
+			name: "success - server not yet ready",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusPending,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+			},
 
-	client := &adapterMock.ServerClientPortMock{
-		PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
-			return nil
+			assertErr:       require.NoError,
+			wantClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
 		},
-		IsReadyFunc: func(ctx context.Context, server provisioning.Server) error {
-			return nil
+		{
+			name: "success - server already part of an other cluster",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Cluster:     ptr.To("cluster02"),
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+			},
+
+			assertErr:       require.NoError,
+			wantClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
 		},
-	}
+		{
+			name: "success - server already part of the cluster",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Cluster:     ptr.To("cluster01"),
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusJoining, fixedDate.Add(-2*time.Hour)),
+			},
 
-	updateSvc := &svcMock.UpdateServiceMock{
-		GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.UpdateFilter) (provisioning.Updates, error) {
-			return provisioning.Updates{}, nil
+			assertErr:       require.NoError,
+			wantClusterJoin: joinedClusterJoin,
 		},
-	}
+		{
+			name: "success - cluster joined",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+			},
 
-	serverSvc := provisioningServer.New(
-		repo, client, nil, nil, nil, nil, updateSvc, tls.Certificate{},
-		provisioningServer.WithWarningEmitter(provisioning.NoopWarningService{}),
-	)
+			assertErr:                require.NoError,
+			wantClusterSvcAddServers: true,
+			wantClusterJoin:          joinedClusterJoin,
+		},
+		{
+			name: "success - failed cluster join retried",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusFailed, fixedDate.Add(-20*time.Minute)),
+			},
 
-	// Run test
-	err = transaction.Do(t.Context(), func(ctx context.Context) error {
+			assertErr:                require.NoError,
+			wantClusterSvcAddServers: true,
+			wantClusterJoin:          joinedClusterJoin,
+		},
+		{
+			name: "success - failed cluster join within retry delay",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusFailed, fixedDate.Add(-5*time.Minute)),
+			},
+
+			assertErr:       require.NoError,
+			wantClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusFailed, fixedDate.Add(-5*time.Minute)),
+		},
+		{
+			name: "success - stale cluster join retried",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusJoining, fixedDate.Add(-2*time.Hour)),
+			},
+
+			assertErr:                require.NoError,
+			wantClusterSvcAddServers: true,
+			wantClusterJoin:          joinedClusterJoin,
+		},
+		{
+			name: "success - cluster join in progress",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusJoining, fixedDate.Add(-5*time.Minute)),
+			},
+
+			assertErr:       require.NoError,
+			wantClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusJoining, fixedDate.Add(-5*time.Minute)),
+		},
+		{
+			name: "error - cluster join failed",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+			},
+			clusterSvcAddServersErr: boom.Error,
+
+			assertErr:                require.NoError, // Failed join is recorded on the server and reported as warning.
+			wantClusterSvcAddServers: true,
+			wantClusterJoin: api.ServerClusterJoin{
+				Cluster:            "cluster01",
+				CopyServicesConfig: true,
+				Status:             api.ServerClusterJoinStatusFailed,
+				Error:              boom.Error.Error(),
+				LastUpdated:        fixedDate,
+			},
+			wantClusterJoinWarnings: 1,
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.GetByName",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+			},
+			repoGetByNameErr: boom.Error,
+
+			assertErr:       boom.ErrorIs,
+			wantClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+		},
+		{
+			name: "error - repo.Update",
+			repoGetByNameServer: provisioning.Server{
+				Name:        "one",
+				Status:      api.ServerStatusReady,
+				ClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr:       boom.ErrorIs,
+			wantClusterJoin: clusterJoinWithStatus(api.ServerClusterJoinStatusPending, time.Time{}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			gotClusterJoin := tc.repoGetByNameServer.ClusterJoin
+			repo := &repoMock.ServerRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					return provisioning.Servers{tc.repoGetByNameServer}, tc.repoGetAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					server := tc.repoGetByNameServer
+					server.ClusterJoin = gotClusterJoin
+					return &server, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
+					if tc.repoUpdateErr != nil {
+						return tc.repoUpdateErr
+					}
+
+					gotClusterJoin = server.ClusterJoin
+					return nil
+				},
+			}
+
+			clusterSvcAddServersCalled := false
+			clusterSvc := &svcMock.ClusterServiceMock{
+				AddServersFunc: func(ctx context.Context, name string, serverNames []string, skipPostJoinOperations bool, copyServicesConfig bool) error {
+					clusterSvcAddServersCalled = true
+
+					require.Equal(t, "cluster01", name)
+					require.Equal(t, []string{"one"}, serverNames)
+					require.False(t, skipPostJoinOperations)
+					require.True(t, copyServicesConfig)
+					require.Equal(t, api.ServerClusterJoinStatusJoining, gotClusterJoin.Status)
+
+					return tc.clusterSvcAddServersErr
+				},
+			}
+
+			clusterJoinWarnings := 0
+			warningSvc := &adapterMock.WarningServicePortMock{
+				EmitFunc: func(ctx context.Context, w warning.Warning) {
+					if w.Type == api.WarningTypeServerClusterJoinFailed {
+						clusterJoinWarnings++
+					}
+				},
+				RemoveStaleFunc: func(ctx context.Context, scope api.WarningScope, newWarnings warning.Warnings) {},
+			}
+
+			serverSvc := provisioningServer.New(
+				repo, nil, nil, nil, clusterSvc, nil, nil, tls.Certificate{},
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+				provisioningServer.WithWarningEmitter(warningSvc),
+			)
+
+			// Run test
+			err := serverSvc.ClusterJoinControlLoop(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantClusterSvcAddServers, clusterSvcAddServersCalled)
+			require.Equal(t, tc.wantClusterJoin, gotClusterJoin)
+			require.Equal(t, tc.wantClusterJoinWarnings, clusterJoinWarnings)
+		})
+	}
+}
+
+func TestServerService_PollServer_in_transaction(t *testing.T) {
+	// Setup
+	logBuf := &bytes.Buffer{}
+	err := logger.InitLogger(logBuf, "", false, true, true)
+	require.NoError(t, err)
+
+	repo := &repoMock.ServerRepoMock{
+		GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+			return &provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusPending,
+			}, nil
+		},
+		UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
+			return nil
+		},
+	}
+
+	client := &adapterMock.ServerClientPortMock{
+		PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
+			return nil
+		},
+		IsReadyFunc: func(ctx context.Context, server provisioning.Server) error {
+			return nil
+		},
+	}
+
+	updateSvc := &svcMock.UpdateServiceMock{
+		GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.UpdateFilter) (provisioning.Updates, error) {
+			return provisioning.Updates{}, nil
+		},
+	}
+
+	serverSvc := provisioningServer.New(
+		repo, client, nil, nil, nil, nil, updateSvc, tls.Certificate{},
+		provisioningServer.WithWarningEmitter(provisioning.NoopWarningService{}),
+	)
+
+	// Run test
+	err = transaction.Do(t.Context(), func(ctx context.Context) error {
 		return serverSvc.PollServer(ctx, provisioning.Server{
 			Name:   "one",
 			Status: api.ServerStatusPending,
@@ -5957,260 +6743,361 @@ func TestServerService_ResyncByName(t *testing.T) {
 	}
 }
 
-func TestServerService_GetChangelogByName(t *testing.T) {
-	updateV1UUID := uuidgen.FromPattern(t, "1")
-	updateV2UUID := uuidgen.FromPattern(t, "2")
-
+func TestServerService_GetHardwareHistoryByName(t *testing.T) {
 	tests := []struct {
-		name                      string
-		nameArg                   string
-		repoGetByName             []queue.Item[*provisioning.Server]
-		updateSvcGetAllWithFilter []queue.Item[provisioning.Updates]
-		updateSvcGetChangelog     api.UpdateChangelog
-		updateSvcGetChangelogErr  error
+		name                              string
+		nameArg                           string
+		repoGetByNameErr                  error
+		repoGetHardwareSnapshotsByName    provisioning.ServerHardwareSnapshots
+		repoGetHardwareSnapshotsByNameErr error
 
 		assertErr     require.ErrorAssertionFunc
-		wantChangelog api.UpdateChangelog
+		wantSnapshots provisioning.ServerHardwareSnapshots
 	}{
 		{
 			name:    "success",
 			nameArg: "one",
-			repoGetByName: []queue.Item[*provisioning.Server]{
-				{
-					Value: &provisioning.Server{
-						Name:    "one",
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							OS: api.OSVersionData{
-								Name:    "IncusOS",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
-					},
-				},
-			},
-			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
-				// GetByName
+			repoGetHardwareSnapshotsByName: provisioning.ServerHardwareSnapshots{
 				{
-					Value: provisioning.Updates{
-						{
-							UUID:     updateV2UUID,
-							Version:  "2",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
-								},
-							},
-						},
-						{
-							UUID:     updateV1UUID,
-							Version:  "1",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
-								},
-							},
-						},
-					},
+					Server:  "one",
+					Changes: api.HardwareChanges{},
 				},
-				// updateSvc.GetAllWithFilter
 				{
-					Value: provisioning.Updates{
-						{
-							UUID:     updateV2UUID,
-							Version:  "2",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
-								},
-							},
-						},
+					Server: "one",
+					Changes: api.HardwareChanges{
 						{
-							UUID:     updateV1UUID,
-							Version:  "1",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
-								},
-							},
+							Component:  api.HardwareComponentNIC,
+							Action:     api.HardwareChangeActionRemoved,
+							Identifier: "00:11:22:33:44:55",
 						},
 					},
 				},
 			},
-			updateSvcGetChangelog: api.UpdateChangelog{
-				CurrentVersion: "2",
-				PriorVersion:   "1",
-				Components: map[string]images.ChangelogEntries{
-					"IncusOS": {
-						Updated: []string{"file version 1 to version 2"},
-					},
-					"incus": {
-						Updated: []string{"file version 1 to version 2"},
-					},
-				},
-			},
 
 			assertErr: require.NoError,
-			wantChangelog: images.Changelog{
-				CurrentVersion: "2",
-				PriorVersion:   "1",
-				Channel:        "stable",
-				Components: map[string]images.ChangelogEntries{
-					"IncusOS": {
-						Updated: []string{"file version 1 to version 2"},
-					},
-					"incus": {
-						Updated: []string{"file version 1 to version 2"},
-					},
+			wantSnapshots: provisioning.ServerHardwareSnapshots{
+				{
+					Server:  "one",
+					Changes: api.HardwareChanges{},
 				},
-			},
-		},
-		{
-			name:    "success - no update available",
-			nameArg: "one",
-			repoGetByName: []queue.Item[*provisioning.Server]{
 				{
-					Value: &provisioning.Server{
-						Name:    "one",
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							OS: api.OSVersionData{
-								Name:    "IncusOS",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
+					Server: "one",
+					Changes: api.HardwareChanges{
+						{
+							Component:  api.HardwareComponentNIC,
+							Action:     api.HardwareChangeActionRemoved,
+							Identifier: "00:11:22:33:44:55",
 						},
 					},
 				},
 			},
-			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
-				// GetByName
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                              "error - repo.GetHardwareSnapshotsByName",
+			nameArg:                           "one",
+			repoGetHardwareSnapshotsByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return &provisioning.Server{Name: name}, tc.repoGetByNameErr
+				},
+				GetHardwareSnapshotsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerHardwareSnapshots, error) {
+					require.Equal(t, tc.nameArg, name)
+					return tc.repoGetHardwareSnapshotsByName, tc.repoGetHardwareSnapshotsByNameErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+
+			// Run test
+			snapshots, err := serverSvc.GetHardwareHistoryByName(context.Background(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantSnapshots, snapshots)
+		})
+	}
+}
+
+func TestServerService_GetDiskHealthWithFilter(t *testing.T) {
+	drives := incusosapi.SystemStorage{
+		State: incusosapi.SystemStorageState{
+			Drives: []incusosapi.SystemStorageDrive{
 				{
-					Value: provisioning.Updates{
-						// No update available.
-						{
-							UUID:     updateV1UUID,
-							Version:  "1",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
-								},
-							},
-						},
+					ID:           "nvme0",
+					SerialNumber: "S1",
+					SMART: &incusosapi.SystemStorageDriveSMART{
+						Enabled:        true,
+						Passed:         true,
+						AvailableSpare: 100,
 					},
 				},
+				{
+					ID:     "remote",
+					Remote: true,
+				},
 			},
+		},
+	}
 
-			assertErr: require.NoError,
+	tests := []struct {
+		name                        string
+		filterArg                   provisioning.ServerFilter
+		repoGetAll                  provisioning.Servers
+		repoGetAllErr               error
+		repoGetAllWithFilter        provisioning.Servers
+		repoGetAllWithFilterErr     error
+		repoGetDiskHealthSamplesErr error
+
+		assertErr          require.ErrorAssertionFunc
+		wantDiskHealthKeys []string
+	}{
+		{
+			name: "success",
+			repoGetAll: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					OSData: api.OSData{
+						Storage: drives,
+					},
+				},
+				{
+					Name: "two",
+				},
+			},
+
+			assertErr:          require.NoError,
+			wantDiskHealthKeys: []string{"cluster/one/nvme0"},
+		},
+		{
+			name: "success - with filter",
+			filterArg: provisioning.ServerFilter{
+				Cluster: ptr.To("cluster"),
+			},
+			repoGetAllWithFilter: provisioning.Servers{
+				{
+					Name:    "one",
+					Cluster: ptr.To("cluster"),
+					OSData: api.OSData{
+						Storage: drives,
+					},
+				},
+			},
+
+			assertErr:          require.NoError,
+			wantDiskHealthKeys: []string{"cluster/one/nvme0"},
 		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
 
+			assertErr: boom.ErrorIs,
+		},
 		{
-			name:    "error - GetByName",
-			nameArg: "one",
-			repoGetByName: []queue.Item[*provisioning.Server]{
+			name: "error - repo.GetAllWithFilter",
+			filterArg: provisioning.ServerFilter{
+				Cluster: ptr.To("cluster"),
+			},
+			repoGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.GetDiskHealthSamplesByName",
+			repoGetAll: provisioning.Servers{
 				{
-					Err: boom.Error,
+					Name: "one",
 				},
 			},
+			repoGetDiskHealthSamplesErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					return tc.repoGetAll, tc.repoGetAllErr
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					return tc.repoGetAllWithFilter, tc.repoGetAllWithFilterErr
+				},
+				GetDiskHealthSamplesByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error) {
+					return nil, tc.repoGetDiskHealthSamplesErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+
+			// Run test
+			diskHealth, err := serverSvc.GetDiskHealthWithFilter(context.Background(), tc.filterArg)
+
+			// Assert
+			tc.assertErr(t, err)
+
+			var keys []string
+			for _, health := range diskHealth {
+				require.Equal(t, api.DiskHealthStatusOK, health.Status)
+				require.Empty(t, health.History)
+				keys = append(keys, health.Cluster+"/"+health.Server+"/"+health.Drive)
+			}
+
+			require.Equal(t, tc.wantDiskHealthKeys, keys)
+		})
+	}
+}
+
+func TestServerService_GetDiskHealthByName(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	tests := []struct {
+		name                        string
+		nameArg                     string
+		repoGetByNameErr            error
+		repoGetDiskHealthSamples    provisioning.ServerDiskHealthSamples
+		repoGetDiskHealthSamplesErr error
+
+		assertErr      require.ErrorAssertionFunc
+		wantDiskHealth []api.ServerDiskHealth
+	}{
 		{
-			name:    "error - updateSvc.GetAllWithFitler",
+			name:    "success",
 			nameArg: "one",
-			repoGetByName: []queue.Item[*provisioning.Server]{
+			repoGetDiskHealthSamples: provisioning.ServerDiskHealthSamples{
+				{Server: "one", Drive: "S1", Passed: true, ReallocatedSectors: 1, PowerOnHours: 10, RecordedAt: fixedDate},
+				{Server: "one", Drive: "S2", Passed: true, RecordedAt: fixedDate},
+				{Server: "one", Drive: "S1", Passed: true, ReallocatedSectors: 4, PowerOnHours: 20, RecordedAt: fixedDate.Add(time.Hour)},
+			},
+
+			assertErr: require.NoError,
+			wantDiskHealth: []api.ServerDiskHealth{
 				{
-					Value: &provisioning.Server{
-						Name:    "one",
-						Channel: "stable",
-						VersionData: api.ServerVersionData{
-							OS: api.OSVersionData{
-								Name:    "IncusOS",
-								Version: "1",
-							},
-							Applications: []api.ApplicationVersionData{
-								{
-									Name:    "incus",
-									Version: "1",
-								},
-							},
-						},
+					Server:             "one",
+					Drive:              "sda",
+					ModelName:          "Disk",
+					SerialNumber:       "S1",
+					Bus:                "sata",
+					Status:             api.DiskHealthStatusWarning,
+					Reasons:            []string{"4 reallocated sectors", "reallocated sectors increased from 1 to 4 since 2025-03-12"},
+					Passed:             true,
+					ReallocatedSectors: 4,
+					PowerOnHours:       20,
+					History: []api.DiskHealthSample{
+						{Passed: true, ReallocatedSectors: 1, PowerOnHours: 10, RecordedAt: fixedDate},
+						{Passed: true, ReallocatedSectors: 4, PowerOnHours: 20, RecordedAt: fixedDate.Add(time.Hour)},
 					},
 				},
 			},
-			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
-				// GetByName
-				{
-					Value: provisioning.Updates{
-						{
-							UUID:     updateV2UUID,
-							Version:  "2",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
-								},
-							},
-						},
-						{
-							UUID:     updateV1UUID,
-							Version:  "1",
-							Channels: []string{"stable"},
-							Files: provisioning.UpdateFiles{
-								{
-									Filename: "x86_64/IncusOS_20260610.img.gz",
-								},
-								{
-									Filename: "x86_64/incus.raw.gz",
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                        "error - repo.GetDiskHealthSamplesByName",
+			nameArg:                     "one",
+			repoGetDiskHealthSamplesErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return &provisioning.Server{
+						Name: name,
+						OSData: api.OSData{
+							Storage: incusosapi.SystemStorage{
+								State: incusosapi.SystemStorageState{
+									Drives: []incusosapi.SystemStorageDrive{
+										{
+											ID:           "sda",
+											ModelName:    "Disk",
+											SerialNumber: "S1",
+											Bus:          "sata",
+											SMART: &incusosapi.SystemStorageDriveSMART{
+												Enabled:            true,
+												Passed:             true,
+												ReallocatedSectors: 4,
+												PowerOnHours:       20,
+											},
+										},
+									},
 								},
 							},
 						},
-					},
+					}, tc.repoGetByNameErr
 				},
-				// updateSvc.GetAllWithFilter
-				{
-					Err: boom.Error,
+				GetDiskHealthSamplesByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDiskHealthSamples, error) {
+					return tc.repoGetDiskHealthSamples, tc.repoGetDiskHealthSamplesErr
 				},
-			},
+			}
 
-			assertErr: boom.ErrorIs,
-		},
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+
+			// Run test
+			diskHealth, err := serverSvc.GetDiskHealthByName(context.Background(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantDiskHealth, diskHealth)
+		})
+	}
+}
+
+func TestServerService_GetChangelogByName(t *testing.T) {
+	updateV1UUID := uuidgen.FromPattern(t, "1")
+	updateV2UUID := uuidgen.FromPattern(t, "2")
+
+	tests := []struct {
+		name                      string
+		nameArg                   string
+		repoGetByName             []queue.Item[*provisioning.Server]
+		updateSvcGetAllWithFilter []queue.Item[provisioning.Updates]
+		updateSvcGetChangelog     api.UpdateChangelog
+		updateSvcGetChangelogErr  error
+
+		assertErr     require.ErrorAssertionFunc
+		wantChangelog api.UpdateChangelog
+	}{
 		{
-			name:    "error - updateSvc.GetChangelog",
+			name:    "success",
 			nameArg: "one",
 			repoGetByName: []queue.Item[*provisioning.Server]{
 				{
@@ -6286,7 +7173,7 @@ func TestServerService_GetChangelogByName(t *testing.T) {
 							Channels: []string{"stable"},
 							Files: provisioning.UpdateFiles{
 								{
-									Filename: "x86_64/IncusOS_202606100326.img.gz",
+									Filename: "x86_64/IncusOS_20260610.img.gz",
 								},
 								{
 									Filename: "x86_64/incus.raw.gz",
@@ -6296,16 +7183,253 @@ func TestServerService_GetChangelogByName(t *testing.T) {
 					},
 				},
 			},
-			updateSvcGetChangelogErr: boom.Error,
-
-			assertErr: boom.ErrorIs,
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup
-			repo := &repoMock.ServerRepoMock{
+			updateSvcGetChangelog: api.UpdateChangelog{
+				CurrentVersion: "2",
+				PriorVersion:   "1",
+				Components: map[string]images.ChangelogEntries{
+					"IncusOS": {
+						Updated: []string{"file version 1 to version 2"},
+					},
+					"incus": {
+						Updated: []string{"file version 1 to version 2"},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+			wantChangelog: images.Changelog{
+				CurrentVersion: "2",
+				PriorVersion:   "1",
+				Channel:        "stable",
+				Components: map[string]images.ChangelogEntries{
+					"IncusOS": {
+						Updated: []string{"file version 1 to version 2"},
+					},
+					"incus": {
+						Updated: []string{"file version 1 to version 2"},
+					},
+				},
+			},
+		},
+		{
+			name:    "success - no update available",
+			nameArg: "one",
+			repoGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Value: &provisioning.Server{
+						Name:    "one",
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							OS: api.OSVersionData{
+								Name:    "IncusOS",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				// GetByName
+				{
+					Value: provisioning.Updates{
+						// No update available.
+						{
+							UUID:     updateV1UUID,
+							Version:  "1",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_20260610.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+
+		{
+			name:    "error - GetByName",
+			nameArg: "one",
+			repoGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - updateSvc.GetAllWithFitler",
+			nameArg: "one",
+			repoGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Value: &provisioning.Server{
+						Name:    "one",
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							OS: api.OSVersionData{
+								Name:    "IncusOS",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				// GetByName
+				{
+					Value: provisioning.Updates{
+						{
+							UUID:     updateV2UUID,
+							Version:  "2",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_20260610.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+						{
+							UUID:     updateV1UUID,
+							Version:  "1",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_20260610.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+					},
+				},
+				// updateSvc.GetAllWithFilter
+				{
+					Err: boom.Error,
+				},
+			},
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - updateSvc.GetChangelog",
+			nameArg: "one",
+			repoGetByName: []queue.Item[*provisioning.Server]{
+				{
+					Value: &provisioning.Server{
+						Name:    "one",
+						Channel: "stable",
+						VersionData: api.ServerVersionData{
+							OS: api.OSVersionData{
+								Name:    "IncusOS",
+								Version: "1",
+							},
+							Applications: []api.ApplicationVersionData{
+								{
+									Name:    "incus",
+									Version: "1",
+								},
+							},
+						},
+					},
+				},
+			},
+			updateSvcGetAllWithFilter: []queue.Item[provisioning.Updates]{
+				// GetByName
+				{
+					Value: provisioning.Updates{
+						{
+							UUID:     updateV2UUID,
+							Version:  "2",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_20260610.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+						{
+							UUID:     updateV1UUID,
+							Version:  "1",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_20260610.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+					},
+				},
+				// updateSvc.GetAllWithFilter
+				{
+					Value: provisioning.Updates{
+						{
+							UUID:     updateV2UUID,
+							Version:  "2",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_20260610.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+						{
+							UUID:     updateV1UUID,
+							Version:  "1",
+							Channels: []string{"stable"},
+							Files: provisioning.UpdateFiles{
+								{
+									Filename: "x86_64/IncusOS_202606100326.img.gz",
+								},
+								{
+									Filename: "x86_64/incus.raw.gz",
+								},
+							},
+						},
+					},
+				},
+			},
+			updateSvcGetChangelogErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
 				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
 					return queue.Pop(t, &tc.repoGetByName)
 				},
@@ -8811,17 +9935,163 @@ func TestServerService_RestartApplication(t *testing.T) {
 	}
 }
 
-func TestServerService_ResyncBMCData(t *testing.T) {
+func TestServerService_Prune(t *testing.T) {
 	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
 
 	tests := []struct {
-		name string
-
-		repoGetAllServers provisioning.Servers
-		repoGetAllErr     error
+		name                      string
+		repoGetDecommissions      provisioning.ServerDecommissions
+		repoGetDecommissionsErr   error
+		repoUpdateDecommissionErr error
+		repoGetAllServers         provisioning.Servers
+		repoGetAllErr             error
+		repoUpdateErr             error
 
-		bmcClientGetData    api.BMCData
-		bmcClientGetDataErr error
+		assertErr                require.ErrorAssertionFunc
+		wantUpdatedDecommissions provisioning.ServerDecommissions
+		wantUpdatedServers       provisioning.Servers
+	}{
+		{
+			name: "success",
+			repoGetDecommissions: provisioning.ServerDecommissions{
+				{Server: "one", Status: api.ServerDecommissionStatusRunning},
+				{Server: "two", Status: api.ServerDecommissionStatusFailed},
+				{Server: "three", Status: api.ServerDecommissionStatusSucceeded},
+			},
+			repoGetAllServers: provisioning.Servers{
+				{Name: "one", BMCInstall: api.ServerBMCInstall{Status: api.ServerBMCInstallStatusPreparing}},
+				{Name: "two", BMCInstall: api.ServerBMCInstall{Status: api.ServerBMCInstallStatusBooting}},
+				{Name: "three"},
+				{Name: "four", ClusterJoin: api.ServerClusterJoin{Cluster: "cluster01", Status: api.ServerClusterJoinStatusJoining}},
+				{Name: "five", ClusterJoin: api.ServerClusterJoin{Cluster: "cluster01", Status: api.ServerClusterJoinStatusFailed}},
+			},
+
+			assertErr: require.NoError,
+			wantUpdatedDecommissions: provisioning.ServerDecommissions{
+				{
+					Server:     "one",
+					Status:     api.ServerDecommissionStatusFailed,
+					Error:      "Decommission has been interrupted by a restart of Operations Center",
+					FinishedAt: fixedDate,
+				},
+			},
+			wantUpdatedServers: provisioning.Servers{
+				{
+					Name: "four",
+					ClusterJoin: api.ServerClusterJoin{
+						Cluster:     "cluster01",
+						Status:      api.ServerClusterJoinStatusPending,
+						LastUpdated: fixedDate,
+					},
+				},
+				{
+					Name: "one",
+					BMCInstall: api.ServerBMCInstall{
+						Status:      api.ServerBMCInstallStatusFailed,
+						Error:       "Installation via BMC has been interrupted by a restart of Operations Center",
+						LastUpdated: fixedDate,
+					},
+				},
+			},
+		},
+		{
+			name:                    "error - repo.GetDecommissions",
+			repoGetDecommissionsErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.UpdateDecommission",
+			repoGetDecommissions: provisioning.ServerDecommissions{
+				{Server: "one", Status: api.ServerDecommissionStatusRunning},
+			},
+			repoUpdateDecommissionErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name: "error - repo.Update",
+			repoGetAllServers: provisioning.Servers{
+				{Name: "one", BMCInstall: api.ServerBMCInstall{Status: api.ServerBMCInstallStatusPreparing}},
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var updatedDecommissions provisioning.ServerDecommissions
+			var updatedServers provisioning.Servers
+			repo := &repoMock.ServerRepoMock{
+				GetDecommissionsFunc: func(ctx context.Context) (provisioning.ServerDecommissions, error) {
+					return tc.repoGetDecommissions, tc.repoGetDecommissionsErr
+				},
+				UpdateDecommissionFunc: func(ctx context.Context, decommission provisioning.ServerDecommission) error {
+					if tc.repoUpdateDecommissionErr != nil {
+						return tc.repoUpdateDecommissionErr
+					}
+
+					updatedDecommissions = append(updatedDecommissions, decommission)
+					return nil
+				},
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					return tc.repoGetAllServers, tc.repoGetAllErr
+				},
+				UpdateFunc: func(ctx context.Context, server provisioning.Server) error {
+					if tc.repoUpdateErr != nil {
+						return tc.repoUpdateErr
+					}
+
+					updatedServers = append(updatedServers, server)
+					return nil
+				},
+			}
+
+			mediaDir := t.TempDir()
+			tmpImage := filepath.Join(mediaDir, ".install-1234.iso")
+			err := os.WriteFile(tmpImage, []byte("partial install image"), 0o600)
+			require.NoError(t, err)
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{},
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+				provisioningServer.WithBMCInstallMediaDir(mediaDir),
+			)
+
+			// Run test
+			err = serverSvc.Prune(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantUpdatedDecommissions, updatedDecommissions)
+			require.Equal(t, tc.wantUpdatedServers, updatedServers)
+
+			if err == nil {
+				require.NoFileExists(t, tmpImage)
+			}
+		})
+	}
+}
+
+func TestServerService_ResyncBMCData(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	tests := []struct {
+		name string
+
+		repoGetAllServers provisioning.Servers
+		repoGetAllErr     error
+
+		bmcClientGetData    api.BMCData
+		bmcClientGetDataErr error
 
 		repoGetByNameServer *provisioning.Server
 		repoGetByNameErr    error
@@ -10215,7 +11485,1812 @@ func TestServerService_BMCDumpByName(t *testing.T) {
 			},
 
 			assertErr: require.NoError,
-			want:      dump,
+			want:      dump,
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - no BMC server client registered for type",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPIType("unknown"),
+				},
+			},
+
+			assertErr: errassert.Contains(`Failed to get BMC server client for type "unknown"`),
+		},
+		{
+			name:    "error - client.Dump",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			bmcClientDumpErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return tc.repoGetByNameServer, tc.repoGetByNameErr
+				},
+			}
+
+			bmcClient := &adapterMock.BMCServerClientPortMock{
+				DumpFunc: func(ctx context.Context, server provisioning.Server, additionalEndpoints []string, skipPredefined bool, trace bool) (api.BMCDump, error) {
+					return dump, tc.bmcClientDumpErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(
+				repo, nil, nil, nil, nil, nil, nil, tls.Certificate{},
+				provisioningServer.AddBMCServerClient(api.BMCAPITypeRedfishV1Generic, bmcClient),
+			)
+
+			// Run test
+			gotDump, err := serverSvc.BMCDumpByName(t.Context(), tc.nameArg, tc.additionalEndpointsArg, tc.skipPredefinedArg, tc.traceArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, gotDump)
+
+			if tc.repoGetByNameServer != nil && tc.repoGetByNameServer.BMCConfig.APIType == api.BMCAPITypeRedfishV1Generic {
+				require.Len(t, bmcClient.DumpCalls(), 1)
+				require.Equal(t, tc.additionalEndpointsArg, bmcClient.DumpCalls()[0].AdditionalEndpoints)
+				require.Equal(t, tc.skipPredefinedArg, bmcClient.DumpCalls()[0].SkipPredefined)
+			}
+		})
+	}
+}
+
+func TestServerService_SyncCluster(t *testing.T) {
+	s := provisioningServer.New(nil, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+	err := s.SyncCluster(t.Context(), "")
+	require.NoError(t, err)
+}
+
+func TestServerService_BMCRefreshByName(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		nameArg string
+
+		repoGetByNameServer *provisioning.Server
+		repoGetByNameErr    error
+
+		bmcClientGetData    api.BMCData
+		bmcClientGetDataErr error
+
+		repoUpdateErr error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType:  api.BMCAPITypeRedfishV1Generic,
+					Endpoint: "https://bmc.local",
+				},
+			},
+			bmcClientGetData: api.BMCData{
+				ServerUUID: "e9de436e-b94e-4aef-8563-883aec84096e",
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - no BMC server client registered for type",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType:  api.BMCAPIType("unknown"),
+					Endpoint: "https://bmc.local",
+				},
+			},
+
+			assertErr: errassert.Contains(`Failed to get BMC server client for type "unknown"`),
+		},
+		{
+			name:    "error - client.GetData",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType:  api.BMCAPITypeRedfishV1Generic,
+					Endpoint: "https://bmc.local",
+				},
+			},
+			bmcClientGetDataErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - repo.Update",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType:  api.BMCAPITypeRedfishV1Generic,
+					Endpoint: "https://bmc.local",
+				},
+			},
+			bmcClientGetData: api.BMCData{
+				ServerUUID: "e9de436e-b94e-4aef-8563-883aec84096e",
+			},
+			repoUpdateErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					return tc.repoGetByNameServer, tc.repoGetByNameErr
+				},
+				UpdateFunc: func(ctx context.Context, in provisioning.Server) error {
+					wantDetails := tc.bmcClientGetData
+					wantDetails.LastUpdated = fixedDate
+					require.Equal(t, wantDetails, in.BMCData)
+					require.Equal(t, &wantDetails.ServerUUID, in.SystemUUID)
+
+					return tc.repoUpdateErr
+				},
+			}
+
+			bmcClient := &adapterMock.BMCServerClientPortMock{
+				GetDataFunc: func(ctx context.Context, server provisioning.Server) (api.BMCData, error) {
+					return tc.bmcClientGetData, tc.bmcClientGetDataErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(
+				repo, nil, nil, nil, nil, nil, nil, tls.Certificate{},
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+				provisioningServer.AddBMCServerClient(api.BMCAPITypeRedfishV1Generic, bmcClient),
+			)
+
+			// Run test
+			err := serverSvc.BMCRefreshByName(t.Context(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func pollServerVersionData(incusVersion string) api.ServerVersionData {
+	return api.ServerVersionData{
+		OS: api.OSVersionData{
+			Name:        "IncusOS",
+			Version:     "1",
+			VersionNext: incusVersion,
+		},
+		Applications: []api.ApplicationVersionData{
+			{
+				Name:    "incus",
+				Version: incusVersion,
+			},
+		},
+		UpdateChannel: "stable",
+	}
+}
+
+func TestServerService_DecommissionByName(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	allOptions := api.ServerDecommissionOptions{
+		Evacuate:          true,
+		RemoveFromCluster: true,
+		FactoryReset:      true,
+		BMCPowerOff:       true,
+		WipeDisks:         true,
+		Delete:            true,
+	}
+
+	clusteredServer := provisioning.Server{
+		Name:    "one",
+		Type:    api.ServerTypeIncus,
+		Cluster: ptr.To("cluster"),
+		BMCConfig: api.BMCConfig{
+			APIType: api.BMCAPITypeRedfishV1Generic,
+		},
+		VersionData: api.ServerVersionData{
+			Applications: []api.ApplicationVersionData{
+				{
+					Name: "incus",
+				},
+			},
+		},
+	}
+
+	standaloneServer := provisioning.Server{
+		Name: "one",
+		Type: api.ServerTypeIncus,
+		BMCConfig: api.BMCConfig{
+			APIType: api.BMCAPITypeRedfishV1Generic,
+		},
+	}
+
+	step := func(step api.ServerDecommissionStep, status api.ServerDecommissionStepStatus, detail string) api.ServerDecommissionStepResult {
+		return api.ServerDecommissionStepResult{
+			Step:       step,
+			Status:     status,
+			Detail:     detail,
+			StartedAt:  fixedDate,
+			FinishedAt: fixedDate,
+		}
+	}
+
+	closedChannel := func() chan struct{} {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+
+	failedDecommission := provisioning.ServerDecommission{
+		UUID:    uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861"),
+		Server:  "one",
+		Options: allOptions,
+		Status:  api.ServerDecommissionStatusFailed,
+		Steps: api.ServerDecommissionStepResults{
+			step(api.ServerDecommissionStepEvacuate, api.ServerDecommissionStepStatusDone, ""),
+			step(api.ServerDecommissionStepRemoveFromCluster, api.ServerDecommissionStepStatusDone, `removed from cluster "cluster"`),
+			step(api.ServerDecommissionStepFactoryReset, api.ServerDecommissionStepStatusFailed, "boom!"),
+		},
+		Error:      "boom!",
+		StartedAt:  fixedDate.Add(-1 * time.Hour),
+		FinishedAt: fixedDate.Add(-1 * time.Hour),
+	}
+
+	tests := []struct {
+		name               string
+		nameArg            string
+		optionsArg         api.ServerDecommissionOptions
+		confirmDiskWipeArg string
+		resumeArg          bool
+
+		repoGetDecommissionsByName    provisioning.ServerDecommissions
+		repoGetDecommissionsByNameErr error
+		repoGetByNameServer           provisioning.Server
+		repoGetByNameErr              error
+		repoCreateDecommissionErr     error
+		repoUpdateDecommissionErr     error
+		clusterSvcRemoveServerErr     error
+		bmcSecureEraseDrives          map[string]*provisioning.BMCTaskMonitor
+		decommissionDone              chan struct{}
+
+		assertErr         require.ErrorAssertionFunc
+		wantStatus        api.ServerDecommissionStatus
+		wantSteps         api.ServerDecommissionStepResults
+		wantEvacuateCalls int
+	}{
+		{
+			name:                "success - all steps on clustered server",
+			nameArg:             "one",
+			optionsArg:          allOptions,
+			confirmDiskWipeArg:  "one",
+			repoGetByNameServer: clusteredServer,
+			bmcSecureEraseDrives: map[string]*provisioning.BMCTaskMonitor{
+				"S2": {URI: "/redfish/v1/TaskMonitor/2"},
+				"S1": nil,
+			},
+			decommissionDone: make(chan struct{}),
+
+			assertErr:  require.NoError,
+			wantStatus: api.ServerDecommissionStatusSucceeded,
+			wantSteps: api.ServerDecommissionStepResults{
+				step(api.ServerDecommissionStepEvacuate, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepRemoveFromCluster, api.ServerDecommissionStepStatusDone, `removed from cluster "cluster"`),
+				step(api.ServerDecommissionStepFactoryReset, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepBMCPowerOff, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepWipeDisks, api.ServerDecommissionStepStatusDone, "erased disks: S1, S2"),
+				step(api.ServerDecommissionStepDelete, api.ServerDecommissionStepStatusDone, ""),
+			},
+			wantEvacuateCalls: 1,
+		},
+		{
+			name:    "success - standalone server without disk wipe",
+			nameArg: "one",
+			optionsArg: api.ServerDecommissionOptions{
+				Evacuate:          true,
+				RemoveFromCluster: true,
+				Delete:            true,
+			},
+			repoGetByNameServer: standaloneServer,
+			decommissionDone:    make(chan struct{}),
+
+			assertErr:  require.NoError,
+			wantStatus: api.ServerDecommissionStatusSucceeded,
+			wantSteps: api.ServerDecommissionStepResults{
+				step(api.ServerDecommissionStepEvacuate, api.ServerDecommissionStepStatusSkipped, "server is not part of a cluster"),
+				step(api.ServerDecommissionStepRemoveFromCluster, api.ServerDecommissionStepStatusSkipped, "server is not part of a cluster"),
+				step(api.ServerDecommissionStepFactoryReset, api.ServerDecommissionStepStatusSkipped, "step not enabled"),
+				step(api.ServerDecommissionStepBMCPowerOff, api.ServerDecommissionStepStatusSkipped, "step not enabled"),
+				step(api.ServerDecommissionStepWipeDisks, api.ServerDecommissionStepStatusSkipped, "step not enabled"),
+				step(api.ServerDecommissionStepDelete, api.ServerDecommissionStepStatusDone, ""),
+			},
+		},
+		{
+			name:                       "success - resume failed decommission",
+			nameArg:                    "one",
+			confirmDiskWipeArg:         "one",
+			resumeArg:                  true,
+			repoGetDecommissionsByName: provisioning.ServerDecommissions{failedDecommission},
+			repoGetByNameServer:        standaloneServer,
+			bmcSecureEraseDrives: map[string]*provisioning.BMCTaskMonitor{
+				"S1": nil,
+			},
+			decommissionDone: make(chan struct{}),
+
+			assertErr:  require.NoError,
+			wantStatus: api.ServerDecommissionStatusSucceeded,
+			wantSteps: api.ServerDecommissionStepResults{
+				step(api.ServerDecommissionStepEvacuate, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepRemoveFromCluster, api.ServerDecommissionStepStatusDone, `removed from cluster "cluster"`),
+				step(api.ServerDecommissionStepFactoryReset, api.ServerDecommissionStepStatusFailed, "boom!"),
+				step(api.ServerDecommissionStepFactoryReset, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepBMCPowerOff, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepWipeDisks, api.ServerDecommissionStepStatusDone, "erased disks: S1"),
+				step(api.ServerDecommissionStepDelete, api.ServerDecommissionStepStatusDone, ""),
+			},
+		},
+		{
+			name:             "error - name empty",
+			nameArg:          "", // invalid
+			decommissionDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:                          "error - repo.GetDecommissionsByName",
+			nameArg:                       "one",
+			repoGetDecommissionsByNameErr: boom.Error,
+			decommissionDone:              closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - decommission already running",
+			nameArg: "one",
+			repoGetDecommissionsByName: provisioning.ServerDecommissions{
+				{
+					Server: "one",
+					Status: api.ServerDecommissionStatusRunning,
+				},
+			},
+			decommissionDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains(`Decommission of server "one" is already running`),
+		},
+		{
+			name:             "error - resume without failed decommission",
+			nameArg:          "one",
+			resumeArg:        true,
+			decommissionDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains(`Server "one" does not have a failed decommission`),
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+			decommissionDone: closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - decommission of Operations Center",
+			nameArg: "one",
+			repoGetByNameServer: provisioning.Server{
+				Name: "one",
+				Type: api.ServerTypeOperationsCenter,
+			},
+			decommissionDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:                "error - disk wipe not confirmed",
+			nameArg:             "one",
+			optionsArg:          allOptions,
+			confirmDiskWipeArg:  "two", // invalid
+			repoGetByNameServer: clusteredServer,
+			decommissionDone:    closedChannel(),
+
+			assertErr: errassert.ValidationErrorContains("needs to be confirmed with the name of the server"),
+		},
+		{
+			name:    "error - BMC power off without BMC",
+			nameArg: "one",
+			optionsArg: api.ServerDecommissionOptions{
+				BMCPowerOff: true,
+			},
+			repoGetByNameServer: provisioning.Server{
+				Name: "one",
+				Type: api.ServerTypeIncus,
+			},
+			decommissionDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains(`Server "one" does not have a BMC configured`),
+		},
+		{
+			name:    "error - no BMC server client registered for type",
+			nameArg: "one",
+			optionsArg: api.ServerDecommissionOptions{
+				BMCPowerOff: true,
+			},
+			repoGetByNameServer: provisioning.Server{
+				Name: "one",
+				Type: api.ServerTypeIncus,
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPIType("unknown"),
+				},
+			},
+			decommissionDone: closedChannel(),
+
+			assertErr: errassert.Contains(`Failed to get BMC server client for type "unknown"`),
+		},
+		{
+			name:                      "error - repo.CreateDecommission",
+			nameArg:                   "one",
+			optionsArg:                allOptions,
+			confirmDiskWipeArg:        "one",
+			repoGetByNameServer:       clusteredServer,
+			repoCreateDecommissionErr: boom.Error,
+			decommissionDone:          closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                      "success - clusterSvc.RemoveServer fails in the background",
+			nameArg:                   "one",
+			optionsArg:                allOptions,
+			confirmDiskWipeArg:        "one",
+			repoGetByNameServer:       clusteredServer,
+			clusterSvcRemoveServerErr: boom.Error,
+			decommissionDone:          make(chan struct{}),
+
+			assertErr:  require.NoError,
+			wantStatus: api.ServerDecommissionStatusFailed,
+			wantSteps: api.ServerDecommissionStepResults{
+				step(api.ServerDecommissionStepEvacuate, api.ServerDecommissionStepStatusDone, ""),
+				step(api.ServerDecommissionStepRemoveFromCluster, api.ServerDecommissionStepStatusFailed, `Failed to remove server "one" from cluster "cluster": boom!`),
+			},
+			wantEvacuateCalls: 1,
+		},
+		{
+			name:                      "success - repo.UpdateDecommission fails in the background",
+			nameArg:                   "one",
+			optionsArg:                allOptions,
+			confirmDiskWipeArg:        "one",
+			repoGetByNameServer:       clusteredServer,
+			repoUpdateDecommissionErr: boom.Error,
+			decommissionDone:          make(chan struct{}),
+
+			assertErr:         require.NoError,
+			wantEvacuateCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			server := tc.repoGetByNameServer.Clone()
+
+			var recorded []provisioning.ServerDecommission
+			repo := &repoMock.ServerRepoMock{
+				GetDecommissionsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+					return tc.repoGetDecommissionsByName, tc.repoGetDecommissionsByNameErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					server := server.Clone()
+					return &server, tc.repoGetByNameErr
+				},
+				CreateDecommissionFunc: func(ctx context.Context, decommission provisioning.ServerDecommission) (int64, error) {
+					recorded = append(recorded, decommission)
+					return 1, tc.repoCreateDecommissionErr
+				},
+				UpdateDecommissionFunc: func(ctx context.Context, decommission provisioning.ServerDecommission) error {
+					recorded = append(recorded, decommission)
+
+					if decommission.Status != api.ServerDecommissionStatusRunning || tc.repoUpdateDecommissionErr != nil {
+						close(tc.decommissionDone)
+					}
+
+					return tc.repoUpdateDecommissionErr
+				},
+				UpdateFunc: func(ctx context.Context, in provisioning.Server) error {
+					server = in.Clone()
+					return nil
+				},
+				DeleteByNameFunc: func(ctx context.Context, name string) error {
+					return nil
+				},
+			}
+
+			client := &adapterMock.ServerClientPortMock{
+				EvacuateFunc: func(ctx context.Context, server provisioning.Server, callback func(ctx context.Context, err error)) error {
+					go callback(ctx, nil)
+					return nil
+				},
+				PingFunc: func(ctx context.Context, endpoint provisioning.Endpoint) error {
+					return nil
+				},
+				SystemFactoryResetFunc: func(ctx context.Context, endpoint provisioning.Endpoint, allowTPMResetFailure bool, seeds provisioning.TokenImageSeedConfigs, providerConfig api.TokenProviderConfig) error {
+					return nil
+				},
+			}
+
+			tokenSvc := &svcMock.TokenServiceMock{
+				CreateFunc: func(ctx context.Context, token provisioning.Token) (provisioning.Token, error) {
+					return provisioning.Token{}, nil
+				},
+				GetTokenProviderConfigFunc: func(ctx context.Context, id uuid.UUID) (*api.TokenProviderConfig, error) {
+					return &api.TokenProviderConfig{}, nil
+				},
+			}
+
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					return &provisioning.Cluster{Name: name}, nil
+				},
+				RemoveServerFunc: func(ctx context.Context, name string, removedServerNames []string) error {
+					if tc.clusterSvcRemoveServerErr != nil {
+						return tc.clusterSvcRemoveServerErr
+					}
+
+					server.Cluster = nil
+					return nil
+				},
+			}
+
+			bmcClient := &adapterMock.BMCServerClientPortMock{
+				ServerPowerOffFunc: func(ctx context.Context, server provisioning.Server, force bool) (*provisioning.BMCTaskMonitor, error) {
+					return &provisioning.BMCTaskMonitor{URI: "/redfish/v1/TaskMonitor/1"}, nil
+				},
+				WaitForTaskFunc: func(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error {
+					return nil
+				},
+				SecureEraseDrivesFunc: func(ctx context.Context, server provisioning.Server) (map[string]*provisioning.BMCTaskMonitor, error) {
+					return tc.bmcSecureEraseDrives, nil
+				},
+			}
+
+			serverSvc := provisioningServer.New(
+				repo, client, nil, tokenSvc, clusterSvc, nil, nil, tls.Certificate{},
+				provisioningServer.AddBMCServerClient(api.BMCAPITypeRedfishV1Generic, bmcClient),
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+			)
+
+			// Run test
+			decommission, err := serverSvc.DecommissionByName(t.Context(), tc.nameArg, tc.optionsArg, tc.confirmDiskWipeArg, tc.resumeArg)
+
+			// Assert
+			tc.assertErr(t, err)
+
+			select {
+			case <-tc.decommissionDone:
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("Decommission did not complete in time")
+			}
+
+			require.Len(t, client.EvacuateCalls(), tc.wantEvacuateCalls)
+
+			if tc.wantStatus == "" {
+				return
+			}
+
+			require.NotNil(t, decommission)
+			require.Equal(t, api.ServerDecommissionStatusRunning, decommission.Status)
+			require.Equal(t, recorded[0], *decommission)
+
+			finished := recorded[len(recorded)-1]
+			require.Equal(t, tc.nameArg, finished.Server)
+			require.Equal(t, tc.wantStatus, finished.Status)
+			require.Equal(t, tc.wantSteps, finished.Steps)
+			require.Equal(t, fixedDate, finished.FinishedAt)
+			require.Equal(t, decommission.UUID, finished.UUID)
+
+			if tc.resumeArg {
+				require.Equal(t, failedDecommission.UUID, decommission.UUID)
+				require.Empty(t, repo.CreateDecommissionCalls())
+			}
+		})
+	}
+}
+
+func TestServerService_GetDecommissions(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		repoGetDecommissions provisioning.ServerDecommissions
+		repoGetErr           error
+
+		assertErr require.ErrorAssertionFunc
+		want      []string
+	}{
+		{
+			name: "success",
+			repoGetDecommissions: provisioning.ServerDecommissions{
+				{Server: "two", StartedAt: fixedDate.Add(time.Hour)},
+				{Server: "one", StartedAt: fixedDate},
+			},
+
+			assertErr: require.NoError,
+			want:      []string{"one", "two"},
+		},
+		{
+			name:       "error - repo.GetDecommissions",
+			repoGetErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			want:      []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetDecommissionsFunc: func(ctx context.Context) (provisioning.ServerDecommissions, error) {
+					return tc.repoGetDecommissions, tc.repoGetErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+
+			// Run test
+			decommissions, err := serverSvc.GetDecommissions(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+
+			servers := []string{}
+			for _, decommission := range decommissions {
+				servers = append(servers, decommission.Server)
+			}
+
+			require.Equal(t, tc.want, servers)
+		})
+	}
+}
+
+func TestServerService_GetDecommissionsByName(t *testing.T) {
+	tests := []struct {
+		name       string
+		nameArg    string
+		repoGetErr error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:       "error - repo.GetDecommissionsByName",
+			nameArg:    "one",
+			repoGetErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetDecommissionsByNameFunc: func(ctx context.Context, name string) (provisioning.ServerDecommissions, error) {
+					require.Equal(t, tc.nameArg, name)
+					return provisioning.ServerDecommissions{{Server: name}}, tc.repoGetErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+
+			// Run test
+			_, err := serverSvc.GetDecommissionsByName(t.Context(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestServerService_GetDecommissionByUUID(t *testing.T) {
+	id := uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861")
+
+	tests := []struct {
+		name       string
+		repoGetErr error
+
+		assertErr require.ErrorAssertionFunc
+		want      *provisioning.ServerDecommission
+	}{
+		{
+			name: "success",
+
+			assertErr: require.NoError,
+			want: &provisioning.ServerDecommission{
+				UUID:   id,
+				Server: "one",
+			},
+		},
+		{
+			name:       "error - repo.GetDecommissionByUUID",
+			repoGetErr: domain.ErrNotFound,
+
+			assertErr: errassert.NotFoundError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetDecommissionByUUIDFunc: func(ctx context.Context, id uuid.UUID) (*provisioning.ServerDecommission, error) {
+					return tc.want, tc.repoGetErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{})
+
+			// Run test
+			decommission, err := serverSvc.GetDecommissionByUUID(t.Context(), id)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, decommission)
+		})
+	}
+}
+
+func TestServerService_BulkAction(t *testing.T) {
+	servers := provisioning.Servers{
+		{
+			Name:       "two",
+			Cluster:    ptr.To("one"),
+			Channel:    "stable",
+			Properties: api.ConfigMap{"rack": "r12"},
+		},
+		{
+			Name:       "one",
+			Channel:    "stable",
+			Properties: api.ConfigMap{"rack": "r12"},
+		},
+		{
+			Name:       "three",
+			Channel:    "stable",
+			Properties: api.ConfigMap{"rack": "r13"},
+		},
+	}
+
+	tests := []struct {
+		name                          string
+		argAction                     provisioning.ServerBulkAction
+		cancelContext                 bool
+		repoGetAllErr                 error
+		clientAddApplicationErr       map[string]error
+		clientUpdateSystemLoggingErr  error
+		wantClientAddApplicationCalls int
+
+		assertErr require.ErrorAssertionFunc
+		want      provisioning.ServerBulkActionResults
+	}{
+		{
+			name: "success - add_application",
+			argAction: provisioning.ServerBulkAction{
+				Filter:          `properties.rack == "r12"`,
+				Action:          api.ServerBulkActionAddApplication,
+				ApplicationName: "incus",
+			},
+			wantClientAddApplicationCalls: 2,
+
+			assertErr: require.NoError,
+			want: provisioning.ServerBulkActionResults{
+				{Server: "one", Status: api.ServerBulkActionStatusSucceeded},
+				{Server: "two", Cluster: "one", Status: api.ServerBulkActionStatusSucceeded},
+			},
+		},
+		{
+			name: "success - add_application with concurrency 1 and failed server",
+			argAction: provisioning.ServerBulkAction{
+				Filter:          `true`,
+				Action:          api.ServerBulkActionAddApplication,
+				ApplicationName: "incus",
+				Concurrency:     1,
+			},
+			clientAddApplicationErr: map[string]error{
+				"three": boom.Error,
+			},
+			wantClientAddApplicationCalls: 3,
+
+			assertErr: require.NoError,
+			want: provisioning.ServerBulkActionResults{
+				{Server: "one", Status: api.ServerBulkActionStatusSucceeded},
+				{Server: "three", Status: api.ServerBulkActionStatusFailed, Error: `Failed to add application "incus" to server "three": boom!`},
+				{Server: "two", Cluster: "one", Status: api.ServerBulkActionStatusSucceeded},
+			},
+		},
+		{
+			name: "success - add_application not canceled with request context",
+			argAction: provisioning.ServerBulkAction{
+				Filter:          `name == "one"`,
+				Action:          api.ServerBulkActionAddApplication,
+				ApplicationName: "incus",
+			},
+			cancelContext:                 true,
+			wantClientAddApplicationCalls: 1,
+
+			assertErr: require.NoError,
+			want: provisioning.ServerBulkActionResults{
+				{Server: "one", Status: api.ServerBulkActionStatusSucceeded},
+			},
+		},
+		{
+			name: "success - dry run",
+			argAction: provisioning.ServerBulkAction{
+				Filter:          `name != "two"`,
+				Action:          api.ServerBulkActionAddApplication,
+				ApplicationName: "incus",
+				DryRun:          true,
+			},
+
+			assertErr: require.NoError,
+			want: provisioning.ServerBulkActionResults{
+				{Server: "one", Status: api.ServerBulkActionStatusDryRun},
+				{Server: "three", Status: api.ServerBulkActionStatusDryRun},
+			},
+		},
+		{
+			name: "success - update_system_logging with failed servers",
+			argAction: provisioning.ServerBulkAction{
+				Filter: `name == "one"`,
+				Action: api.ServerBulkActionUpdateSystemLogging,
+			},
+			clientUpdateSystemLoggingErr: boom.Error,
+
+			assertErr: require.NoError,
+			want: provisioning.ServerBulkActionResults{
+				{Server: "one", Status: api.ServerBulkActionStatusFailed, Error: `Failed to update logging config for server "one": boom!`},
+			},
+		},
+		{
+			name: "success - no matching servers",
+			argAction: provisioning.ServerBulkAction{
+				Filter: `properties.rack == "r99"`,
+				Action: api.ServerBulkActionReboot,
+			},
+
+			assertErr: require.NoError,
+			want:      provisioning.ServerBulkActionResults{},
+		},
+		{
+			name: "error - reboot of cluster members",
+			argAction: provisioning.ServerBulkAction{
+				Filter: `properties.rack == "r12"`,
+				Action: api.ServerBulkActionReboot,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+				require.ErrorContains(tt, err, `use the cluster wide reboot of the clusters one instead`, a...)
+			},
+		},
+		{
+			name: "error - update of cluster members with dry run",
+			argAction: provisioning.ServerBulkAction{
+				Filter: `name == "two"`,
+				Action: api.ServerBulkActionUpdate,
+				DryRun: true,
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name: "error - validate",
+			argAction: provisioning.ServerBulkAction{
+				Action: api.ServerBulkActionReboot,
+			},
+
+			assertErr: errassert.ValidationErrorContains("filter expression can not be empty"),
+		},
+		{
+			name: "error - invalid filter expression",
+			argAction: provisioning.ServerBulkAction{
+				Filter: `invalid filter expression`,
+				Action: api.ServerBulkActionReboot,
+			},
+
+			assertErr: errassert.ValidationErrorContains("Failed to compile filter expression"),
+		},
+		{
+			name: "error - repo.GetAll",
+			argAction: provisioning.ServerBulkAction{
+				Filter: `true`,
+				Action: api.ServerBulkActionReboot,
+			},
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					return slices.Clone(servers), tc.repoGetAllErr
+				},
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					for _, server := range servers {
+						if server.Name == name {
+							return &server, nil
+						}
+					}
+
+					return nil, domain.ErrNotFound
+				},
+			}
+
+			client := &adapterMock.ServerClientPortMock{
+				AddApplicationFunc: func(ctx context.Context, server provisioning.Server, application string) error {
+					require.NoError(t, ctx.Err())
+					return tc.clientAddApplicationErr[server.Name]
+				},
+				UpdateSystemLoggingFunc: func(ctx context.Context, server provisioning.Server, config provisioning.ServerSystemLogging) error {
+					return tc.clientUpdateSystemLoggingErr
+				},
+			}
+
+			updateSvc := &svcMock.UpdateServiceMock{
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.UpdateFilter) (provisioning.Updates, error) {
+					return provisioning.Updates{}, nil
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, client, nil, nil, nil, nil, updateSvc, tls.Certificate{})
+
+			ctx := t.Context()
+			if tc.cancelContext {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				cancel()
+			}
+
+			// Run test
+			results, err := serverSvc.BulkAction(ctx, tc.argAction)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.want, results)
+			require.Len(t, client.AddApplicationCalls(), tc.wantClientAddApplicationCalls)
+		})
+	}
+}
+
+func TestServerService_BMCInstallByName(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	tokenUUID := uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861")
+
+	taskMonitor := &provisioning.BMCTaskMonitor{
+		URI: "https://bmc.local/task/1",
+	}
+
+	closedChannel := func() chan struct{} {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+
+	unregisteredServer := provisioning.Server{
+		Name:   "one",
+		Status: api.ServerStatusUnregistered,
+		BMCConfig: api.BMCConfig{
+			APIType: api.BMCAPITypeRedfishV1Generic,
+		},
+	}
+
+	validToken := &provisioning.Token{
+		UUID:          tokenUUID,
+		UsesRemaining: 1,
+		ExpireAt:      fixedDate.Add(24 * time.Hour),
+		Channel:       "stable",
+	}
+
+	tests := []struct {
+		name                           string
+		nameArg                        string
+		installArg                     api.ServerBMCInstallPost
+		operationsCenterAddress        string
+		repoGetByNameServer            provisioning.Server
+		repoGetByNameErr               error
+		repoUpdateErr                  error
+		tokenSvcGetByUUID              *provisioning.Token
+		tokenSvcGetByUUIDErr           error
+		tokenSvcGetTokenSeedByNameErr  error
+		tokenSvcGetTokenImageErr       error
+		bmcClientInsertVirtualMediaErr error
+		bmcClientGetData               api.BMCData
+		installDone                    chan struct{}
+
+		assertErr        require.ErrorAssertionFunc
+		wantArchitecture images.UpdateFileArchitecture
+		wantChannel      string
+		wantStatus       api.ServerBMCInstallStatus
+		wantError        string
+		wantRestart      bool
+	}{
+		{
+			name:    "success - server powered off",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			operationsCenterAddress: "https://192.168.1.200:8443",
+			repoGetByNameServer:     unregisteredServer,
+			tokenSvcGetByUUID:       validToken,
+			bmcClientGetData: api.BMCData{
+				ServerPowerState: "Off",
+			},
+			installDone: make(chan struct{}),
+
+			assertErr:        require.NoError,
+			wantArchitecture: images.UpdateFileArchitecture64BitX86,
+			wantChannel:      "stable",
+			wantStatus:       api.ServerBMCInstallStatusBooting,
+		},
+		{
+			name:    "success - server powered on, ARM architecture and explicit channel",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:        tokenUUID,
+				TokenSeed:    "unattended",
+				Channel:      "testing",
+				VirtualMedia: "system:1",
+			},
+			operationsCenterAddress: "https://192.168.1.200:8443",
+			repoGetByNameServer: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusUnregistered,
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+				BMCData: api.BMCData{
+					ServerProcessorInstructionSet: "ARM-A64",
+				},
+				BMCInstall: api.ServerBMCInstall{
+					ID:     uuid.MustParse("4c5b1b0e-8f0d-4c1e-9f3a-2f8d1c6e7a90"),
+					Status: api.ServerBMCInstallStatusFailed,
+				},
+			},
+			tokenSvcGetByUUID: validToken,
+			bmcClientGetData: api.BMCData{
+				ServerPowerState: "On",
+			},
+			installDone: make(chan struct{}),
+
+			assertErr:        require.NoError,
+			wantArchitecture: images.UpdateFileArchitecture64BitARM,
+			wantChannel:      "testing",
+			wantStatus:       api.ServerBMCInstallStatusBooting,
+			wantRestart:      true,
+		},
+		{
+			name:    "success - tokenSvc.GetTokenImageFromTokenSeed fails in the background",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			operationsCenterAddress:  "https://192.168.1.200:8443",
+			repoGetByNameServer:      unregisteredServer,
+			tokenSvcGetByUUID:        validToken,
+			tokenSvcGetTokenImageErr: boom.Error,
+			installDone:              make(chan struct{}),
+
+			assertErr:        require.NoError,
+			wantArchitecture: images.UpdateFileArchitecture64BitX86,
+			wantChannel:      "stable",
+			wantStatus:       api.ServerBMCInstallStatusFailed,
+			wantError:        "Failed to get install image: boom!",
+		},
+		{
+			name:    "success - client.InsertVirtualMedia fails in the background",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			operationsCenterAddress:        "https://192.168.1.200:8443",
+			repoGetByNameServer:            unregisteredServer,
+			tokenSvcGetByUUID:              validToken,
+			bmcClientInsertVirtualMediaErr: boom.Error,
+			installDone:                    make(chan struct{}),
+
+			assertErr:        require.NoError,
+			wantArchitecture: images.UpdateFileArchitecture64BitX86,
+			wantChannel:      "stable",
+			wantStatus:       api.ServerBMCInstallStatusFailed,
+			wantError:        "Failed to insert virtual media: boom!",
+		},
+		{
+			name:        "error - name empty",
+			nameArg:     "", // invalid
+			installDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			repoGetByNameErr: boom.Error,
+			installDone:      closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - no BMC configured",
+			nameArg: "one",
+			repoGetByNameServer: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusUnregistered,
+			},
+			installDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains(`Server "one" does not have a BMC configured`),
+		},
+		{
+			name:    "error - no BMC server client registered for type",
+			nameArg: "one",
+			repoGetByNameServer: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusUnregistered,
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPIType("unknown"),
+				},
+			},
+			installDone: closedChannel(),
+
+			assertErr: errassert.Contains(`Failed to get BMC server client for type "unknown"`),
+		},
+		{
+			name:    "error - server not unregistered",
+			nameArg: "one",
+			repoGetByNameServer: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusReady,
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			installDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains("only unregistered servers are supported"),
+		},
+		{
+			name:    "error - installation already being prepared",
+			nameArg: "one",
+			repoGetByNameServer: provisioning.Server{
+				Name:   "one",
+				Status: api.ServerStatusUnregistered,
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+				BMCInstall: api.ServerBMCInstall{
+					Status: api.ServerBMCInstallStatusPreparing,
+				},
+			},
+			installDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains("already being prepared"),
+		},
+		{
+			name:    "error - tokenSvc.GetByUUID",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			repoGetByNameServer:  unregisteredServer,
+			tokenSvcGetByUUIDErr: boom.Error,
+			installDone:          closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - token exhausted",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			repoGetByNameServer: unregisteredServer,
+			tokenSvcGetByUUID: &provisioning.Token{
+				UUID:          tokenUUID,
+				UsesRemaining: 0,
+				ExpireAt:      fixedDate.Add(24 * time.Hour),
+			},
+			installDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains("is exhausted or expired"),
+		},
+		{
+			name:    "error - token expired",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			repoGetByNameServer: unregisteredServer,
+			tokenSvcGetByUUID: &provisioning.Token{
+				UUID:          tokenUUID,
+				UsesRemaining: 1,
+				ExpireAt:      fixedDate.Add(-1 * time.Hour),
+			},
+			installDone: closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains("is exhausted or expired"),
+		},
+		{
+			name:    "error - tokenSvc.GetTokenSeedByName",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			repoGetByNameServer:           unregisteredServer,
+			tokenSvcGetByUUID:             validToken,
+			tokenSvcGetTokenSeedByNameErr: boom.Error,
+			installDone:                   closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - invalid architecture",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:        tokenUUID,
+				TokenSeed:    "unattended",
+				Architecture: images.UpdateFileArchitecture("invalid"), // invalid
+			},
+			repoGetByNameServer: unregisteredServer,
+			tokenSvcGetByUUID:   validToken,
+			installDone:         closedChannel(),
+
+			assertErr: errassert.ValidationErrorContains(`architecture "invalid" is not valid`),
+		},
+		{
+			name:    "error - Operations Center address not configured",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			repoGetByNameServer: unregisteredServer,
+			tokenSvcGetByUUID:   validToken,
+			installDone:         closedChannel(),
+
+			assertErr: errassert.OperationNotPermittedErrorContains("Operations Center address is not configured"),
+		},
+		{
+			name:    "error - repo.Update",
+			nameArg: "one",
+			installArg: api.ServerBMCInstallPost{
+				Token:     tokenUUID,
+				TokenSeed: "unattended",
+			},
+			operationsCenterAddress: "https://192.168.1.200:8443",
+			repoGetByNameServer:     unregisteredServer,
+			repoUpdateErr:           boom.Error,
+			tokenSvcGetByUUID:       validToken,
+			installDone:             closedChannel(),
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config.InitTest(t, &envMock.EnvironmentMock{
+				IsIncusOSFunc: func() bool {
+					return false
+				},
+			}, nil)
+			if tc.operationsCenterAddress != "" {
+				err := config.UpdateNetwork(t.Context(), system.NetworkPut{
+					OperationsCenterAddress: tc.operationsCenterAddress,
+					RestServerAddress:       "[::]:8443",
+				})
+				require.NoError(t, err)
+			}
+
+			// Setup
+			var mu sync.Mutex
+			server := tc.repoGetByNameServer
+			mediaDir := t.TempDir()
+
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					current := server
+					return &current, tc.repoGetByNameErr
+				},
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					return provisioning.Servers{server}, nil
+				},
+				UpdateFunc: func(ctx context.Context, in provisioning.Server) error {
+					if tc.repoUpdateErr != nil {
+						return tc.repoUpdateErr
+					}
+
+					mu.Lock()
+					defer mu.Unlock()
+
+					server = in
+
+					if in.BMCInstall.Status == api.ServerBMCInstallStatusBooting || in.BMCInstall.Status == api.ServerBMCInstallStatusFailed {
+						close(tc.installDone)
+					}
+
+					return nil
+				},
+			}
+
+			tokenSvc := &svcMock.TokenServiceMock{
+				GetByUUIDFunc: func(ctx context.Context, id uuid.UUID) (*provisioning.Token, error) {
+					return tc.tokenSvcGetByUUID, tc.tokenSvcGetByUUIDErr
+				},
+				GetTokenSeedByNameFunc: func(ctx context.Context, id uuid.UUID, name string) (*provisioning.TokenSeed, error) {
+					return &provisioning.TokenSeed{
+						Token:       tokenUUID,
+						Name:        name,
+						LastUpdated: fixedDate,
+					}, tc.tokenSvcGetTokenSeedByNameErr
+				},
+				GetTokenImageFromTokenSeedFunc: func(ctx context.Context, id uuid.UUID, name string, imageType api.ImageType, architecture images.UpdateFileArchitecture, channel string) (io.ReadCloser, error) {
+					require.Equal(t, api.ImageTypeISO, imageType)
+					require.Equal(t, tc.wantArchitecture, architecture)
+					require.Equal(t, tc.wantChannel, channel)
+
+					return io.NopCloser(strings.NewReader("install image")), tc.tokenSvcGetTokenImageErr
+				},
+			}
+
+			var restarted bool
+			bmcClient := &adapterMock.BMCServerClientPortMock{
+				InsertVirtualMediaFunc: func(ctx context.Context, server provisioning.Server, virtualMediaID string, imageURL string) (string, *provisioning.BMCTaskMonitor, error) {
+					require.Equal(t, tc.installArg.VirtualMedia, virtualMediaID)
+					require.Regexp(t, `^https://192\.168\.1\.200:8443/1\.0/provisioning/servers/one/bmc/install-media/[0-9a-f-]{36}/install\.iso$`, imageURL)
+
+					return "system:1", taskMonitor, tc.bmcClientInsertVirtualMediaErr
+				},
+				WaitForTaskFunc: func(ctx context.Context, server provisioning.Server, taskMonitor *provisioning.BMCTaskMonitor) error {
+					return nil
+				},
+				SetOneTimeBootFromVirtualMediaFunc: func(ctx context.Context, server provisioning.Server) error {
+					return nil
+				},
+				GetDataFunc: func(ctx context.Context, server provisioning.Server) (api.BMCData, error) {
+					return tc.bmcClientGetData, nil
+				},
+				ServerRestartFunc: func(ctx context.Context, server provisioning.Server, force bool) (*provisioning.BMCTaskMonitor, error) {
+					require.True(t, force)
+					restarted = true
+
+					return taskMonitor, nil
+				},
+				ServerPowerOnFunc: func(ctx context.Context, server provisioning.Server, force bool) (*provisioning.BMCTaskMonitor, error) {
+					require.True(t, force)
+
+					return taskMonitor, nil
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, tokenSvc, nil, nil, nil, tls.Certificate{},
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+				provisioningServer.WithBMCInstallMediaDir(mediaDir),
+				provisioningServer.AddBMCServerClient(api.BMCAPITypeRedfishV1Generic, bmcClient),
+			)
+
+			// Run test
+			err := serverSvc.BMCInstallByName(t.Context(), tc.nameArg, tc.installArg)
+
+			// Assert
+			tc.assertErr(t, err)
+
+			select {
+			case <-tc.installDone:
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("Installation via BMC did not complete in time")
+			}
+
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			require.Equal(t, tc.installArg.Token, server.BMCInstall.Token)
+			require.Equal(t, tc.installArg.TokenSeed, server.BMCInstall.TokenSeed)
+			require.Equal(t, tc.wantArchitecture, server.BMCInstall.Architecture)
+			require.Equal(t, tc.wantChannel, server.BMCInstall.Channel)
+			require.Equal(t, tc.wantStatus, server.BMCInstall.Status)
+			require.Equal(t, tc.wantError, server.BMCInstall.Error)
+			require.Equal(t, fixedDate, server.BMCInstall.LastUpdated)
+			require.Equal(t, fixedDate.Add(time.Hour), server.BMCInstall.MediaExpiresAt)
+			require.Equal(t, tc.wantRestart, restarted)
+
+			if tc.wantStatus == api.ServerBMCInstallStatusBooting {
+				require.Equal(t, "system:1", server.BMCInstall.VirtualMedia)
+
+				image, err := os.ReadFile(serverSvc.BMCInstallMediaPath(server.BMCInstall))
+				require.NoError(t, err)
+				require.Equal(t, "install image", string(image))
+			}
+		})
+	}
+}
+
+func TestServerService_GetBMCInstallMediaByName(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+	installID := uuid.MustParse("4c5b1b0e-8f0d-4c1e-9f3a-2f8d1c6e7a90")
+
+	bmcInstall := api.ServerBMCInstall{
+		ID:             installID,
+		Token:          uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861"),
+		TokenSeed:      "unattended",
+		Architecture:   images.UpdateFileArchitecture64BitX86,
+		Channel:        "stable",
+		MediaExpiresAt: fixedDate.Add(time.Minute),
+		Status:         api.ServerBMCInstallStatusBooting,
+	}
+
+	tests := []struct {
+		name                string
+		nameArg             string
+		idArg               uuid.UUID
+		repoGetByNameServer provisioning.Server
+		repoGetByNameErr    error
+		imageMissing        bool
+		readLimit           int64
+
+		assertErr          require.ErrorAssertionFunc
+		want               string
+		wantMediaExpiresAt time.Time
+		wantImageRemoved   bool
+	}{
+		{
+			name:    "success - complete download expires media",
+			nameArg: "one",
+			idArg:   installID,
+			repoGetByNameServer: provisioning.Server{
+				Name:       "one",
+				BMCInstall: bmcInstall,
+			},
+
+			assertErr:          require.NoError,
+			want:               "install image",
+			wantMediaExpiresAt: fixedDate,
+			wantImageRemoved:   true,
+		},
+		{
+			name:    "success - partial download",
+			nameArg: "one",
+			idArg:   installID,
+			repoGetByNameServer: provisioning.Server{
+				Name:       "one",
+				BMCInstall: bmcInstall,
+			},
+			readLimit: 7,
+
+			assertErr:          require.NoError,
+			want:               "install",
+			wantMediaExpiresAt: fixedDate.Add(time.Minute),
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+			idArg:   installID,
+
+			assertErr: errassert.OperationNotPermittedError,
+		},
+		{
+			name:             "error - repo.GetByName",
+			nameArg:          "one",
+			idArg:            installID,
+			repoGetByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:    "error - installation ID does not match",
+			nameArg: "one",
+			idArg:   uuid.MustParse("e9de436e-b94e-4aef-8563-883aec84096e"),
+			repoGetByNameServer: provisioning.Server{
+				Name:       "one",
+				BMCInstall: bmcInstall,
+			},
+
+			assertErr: errassert.NotFoundErrorContains(`Install image "e9de436e-b94e-4aef-8563-883aec84096e" for server "one"`),
+		},
+		{
+			name:    "error - installation not in progress",
+			nameArg: "one",
+			idArg:   installID,
+			repoGetByNameServer: provisioning.Server{
+				Name: "one",
+				BMCInstall: func() api.ServerBMCInstall {
+					registered := bmcInstall
+					registered.Status = api.ServerBMCInstallStatusRegistered
+					return registered
+				}(),
+			},
+
+			assertErr: errassert.NotFoundError,
+		},
+		{
+			name:    "error - media expired",
+			nameArg: "one",
+			idArg:   installID,
+			repoGetByNameServer: provisioning.Server{
+				Name: "one",
+				BMCInstall: func() api.ServerBMCInstall {
+					expired := bmcInstall
+					expired.MediaExpiresAt = fixedDate
+					return expired
+				}(),
+			},
+
+			assertErr: errassert.NotFoundError,
+		},
+		{
+			name:    "error - install image missing",
+			nameArg: "one",
+			idArg:   installID,
+			repoGetByNameServer: provisioning.Server{
+				Name:       "one",
+				BMCInstall: bmcInstall,
+			},
+			imageMissing: true,
+
+			assertErr: errassert.NotFoundError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			server := tc.repoGetByNameServer
+			repo := &repoMock.ServerRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Server, error) {
+					current := server
+					return &current, tc.repoGetByNameErr
+				},
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					return provisioning.Servers{server}, nil
+				},
+				UpdateFunc: func(ctx context.Context, in provisioning.Server) error {
+					server = in
+					return nil
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{},
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+				provisioningServer.WithBMCInstallMediaDir(t.TempDir()),
+			)
+
+			if !tc.imageMissing {
+				err := os.WriteFile(serverSvc.BMCInstallMediaPath(bmcInstall), []byte("install image"), 0o600)
+				require.NoError(t, err)
+			}
+
+			// Run test
+			media, err := serverSvc.GetBMCInstallMediaByName(t.Context(), tc.nameArg, tc.idArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+
+			var reader io.Reader = media
+			if tc.readLimit > 0 {
+				reader = io.LimitReader(media, tc.readLimit)
+			}
+
+			got, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(got))
+
+			err = media.Close()
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantMediaExpiresAt, server.BMCInstall.MediaExpiresAt)
+
+			if tc.wantImageRemoved {
+				require.NoFileExists(t, serverSvc.BMCInstallMediaPath(bmcInstall))
+			} else {
+				require.FileExists(t, serverSvc.BMCInstallMediaPath(bmcInstall))
+			}
+		})
+	}
+}
+
+func TestServerService_PruneBMCInstallMedia(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	bmcInstall := func(tokenSeed string, status api.ServerBMCInstallStatus, mediaExpiresAt time.Time) api.ServerBMCInstall {
+		return api.ServerBMCInstall{
+			ID:             uuid.New(),
+			Token:          uuid.MustParse("b32d0079-c48b-4957-b1cb-bef54125c861"),
+			TokenSeed:      tokenSeed,
+			Architecture:   images.UpdateFileArchitecture64BitX86,
+			Channel:        "stable",
+			MediaExpiresAt: mediaExpiresAt,
+			Status:         status,
+		}
+	}
+
+	tests := []struct {
+		name             string
+		repoGetAllServer provisioning.Servers
+		repoGetAllErr    error
+
+		assertErr         require.ErrorAssertionFunc
+		wantKeptImages    []string
+		wantRemovedImages []string
+	}{
+		{
+			name: "success",
+			repoGetAllServer: provisioning.Servers{
+				{Name: "one", BMCInstall: bmcInstall("booting", api.ServerBMCInstallStatusBooting, fixedDate.Add(time.Minute))},
+				{Name: "two", BMCInstall: bmcInstall("preparing", api.ServerBMCInstallStatusPreparing, fixedDate.Add(time.Minute))},
+				{Name: "three", BMCInstall: bmcInstall("expired", api.ServerBMCInstallStatusBooting, fixedDate)},
+				{Name: "four", BMCInstall: bmcInstall("registered", api.ServerBMCInstallStatusRegistered, fixedDate.Add(time.Minute))},
+				{Name: "five", BMCInstall: bmcInstall("failed", api.ServerBMCInstallStatusFailed, fixedDate.Add(time.Minute))},
+			},
+
+			assertErr:         require.NoError,
+			wantKeptImages:    []string{"one", "two"},
+			wantRemovedImages: []string{"three", "four", "five"},
+		},
+		{
+			name:          "error - repo.GetAll",
+			repoGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.ServerRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Servers, error) {
+					return tc.repoGetAllServer, tc.repoGetAllErr
+				},
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{},
+				provisioningServer.WithNow(func() time.Time { return fixedDate }),
+				provisioningServer.WithBMCInstallMediaDir(t.TempDir()),
+			)
+
+			imagePaths := map[string]string{}
+			for _, server := range tc.repoGetAllServer {
+				imagePaths[server.Name] = serverSvc.BMCInstallMediaPath(server.BMCInstall)
+				err := os.WriteFile(imagePaths[server.Name], []byte("install image"), 0o600)
+				require.NoError(t, err)
+			}
+
+			// Run test
+			err := serverSvc.PruneBMCInstallMedia(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+
+			for _, name := range tc.wantKeptImages {
+				require.FileExists(t, imagePaths[name])
+			}
+
+			for _, name := range tc.wantRemovedImages {
+				require.NoFileExists(t, imagePaths[name])
+			}
+		})
+	}
+}
+
+func TestServerService_BMCFirmwareByName(t *testing.T) {
+	firmware := map[string]api.BMCFirmware{
+		"BIOS": {ID: "BIOS", Name: "BIOS", Component: api.BMCFirmwareComponentBIOS, Version: "1.7.5", Updateable: true},
+		"BMC":  {ID: "BMC", Name: "BMC", Component: api.BMCFirmwareComponentBMC, Version: "7.10.30.00", Updateable: true},
+	}
+
+	tests := []struct {
+		name                          string
+		nameArg                       string
+		repoGetByNameServer           *provisioning.Server
+		repoGetByNameErr              error
+		bmcClientFirmwareInventoryErr error
+
+		assertErr require.ErrorAssertionFunc
+		want      map[string]api.BMCFirmware
+	}{
+		{
+			name:    "success",
+			nameArg: "one",
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+
+			assertErr: require.NoError,
+			want:      firmware,
 		},
 		{
 			name:    "error - name empty",
@@ -10243,7 +13318,7 @@ func TestServerService_BMCDumpByName(t *testing.T) {
 			assertErr: errassert.Contains(`Failed to get BMC server client for type "unknown"`),
 		},
 		{
-			name:    "error - client.Dump",
+			name:    "error - client.FirmwareInventory",
 			nameArg: "one",
 			repoGetByNameServer: &provisioning.Server{
 				Name: "one",
@@ -10251,7 +13326,7 @@ func TestServerService_BMCDumpByName(t *testing.T) {
 					APIType: api.BMCAPITypeRedfishV1Generic,
 				},
 			},
-			bmcClientDumpErr: boom.Error,
+			bmcClientFirmwareInventoryErr: boom.Error,
 
 			assertErr: boom.ErrorIs,
 		},
@@ -10267,8 +13342,12 @@ func TestServerService_BMCDumpByName(t *testing.T) {
 			}
 
 			bmcClient := &adapterMock.BMCServerClientPortMock{
-				DumpFunc: func(ctx context.Context, server provisioning.Server, additionalEndpoints []string, skipPredefined bool, trace bool) (api.BMCDump, error) {
-					return dump, tc.bmcClientDumpErr
+				FirmwareInventoryFunc: func(ctx context.Context, server provisioning.Server) (map[string]api.BMCFirmware, error) {
+					if tc.bmcClientFirmwareInventoryErr != nil {
+						return nil, tc.bmcClientFirmwareInventoryErr
+					}
+
+					return firmware, nil
 				},
 			}
 
@@ -10278,114 +13357,318 @@ func TestServerService_BMCDumpByName(t *testing.T) {
 			)
 
 			// Run test
-			gotDump, err := serverSvc.BMCDumpByName(t.Context(), tc.nameArg, tc.additionalEndpointsArg, tc.skipPredefinedArg, tc.traceArg)
+			got, err := serverSvc.BMCFirmwareByName(t.Context(), tc.nameArg)
 
 			// Assert
 			tc.assertErr(t, err)
-			require.Equal(t, tc.want, gotDump)
-
-			if tc.repoGetByNameServer != nil && tc.repoGetByNameServer.BMCConfig.APIType == api.BMCAPITypeRedfishV1Generic {
-				require.Len(t, bmcClient.DumpCalls(), 1)
-				require.Equal(t, tc.additionalEndpointsArg, bmcClient.DumpCalls()[0].AdditionalEndpoints)
-				require.Equal(t, tc.skipPredefinedArg, bmcClient.DumpCalls()[0].SkipPredefined)
-			}
+			require.Equal(t, tc.want, got)
 		})
 	}
 }
 
-func TestServerService_SyncCluster(t *testing.T) {
-	s := provisioningServer.New(nil, nil, nil, nil, nil, nil, nil, tls.Certificate{})
-	err := s.SyncCluster(t.Context(), "")
-	require.NoError(t, err)
-}
-
-func TestServerService_BMCRefreshByName(t *testing.T) {
+func TestServerService_BMCFirmwareUpdateByName(t *testing.T) {
 	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
 
-	tests := []struct {
-		name    string
-		nameArg string
+	taskMonitor := &provisioning.BMCTaskMonitor{
+		URI: "https://bmc.local/task/1",
+	}
 
-		repoGetByNameServer *provisioning.Server
-		repoGetByNameErr    error
+	closedChannel := func() chan struct{} {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
 
-		bmcClientGetData    api.BMCData
-		bmcClientGetDataErr error
+	firmwareImageSHA256 := "1df2f3853d10a305aa52d36fd4a03f5721d7ce7daef6f7e5e8d51074d31361f1"
 
-		repoUpdateErr error
+	firmwareSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/firmware/BIOS_1.8.2.exe":
+			_, _ = w.Write([]byte("firmware image"))
+
+		case "/firmware/slow.exe":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(firmwareSvr.Close)
+
+	tests := []struct {
+		name                     string
+		nameArg                  string
+		update                   api.BMCFirmwareUpdatePost
+		clusterUpdate            bool
+		downloadTimeout          time.Duration
+		imageSizeLimit           int64
+		repoGetByNameServer      *provisioning.Server
+		repoGetByNameErr         error
+		bmcClientUpdateErr       error
+		bmcClientWaitErr         error
+		bmcClientGetDataErr      error
+		resyncDone               chan struct{}
+		wantUpdateFirmwareMethod api.BMCFirmwareUpdateMethod
 
 		assertErr require.ErrorAssertionFunc
 	}{
 		{
-			name:    "success",
+			name:    "success - simple update",
 			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Targets:  []string{"BIOS"},
+			},
 			repoGetByNameServer: &provisioning.Server{
 				Name: "one",
 				BMCConfig: api.BMCConfig{
-					APIType:  api.BMCAPITypeRedfishV1Generic,
-					Endpoint: "https://bmc.local",
+					APIType: api.BMCAPITypeRedfishV1Generic,
 				},
 			},
-			bmcClientGetData: api.BMCData{
-				ServerUUID: "e9de436e-b94e-4aef-8563-883aec84096e",
+			resyncDone:               make(chan struct{}),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodSimpleUpdate,
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "success - push",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: firmwareSvr.URL + "/firmware/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   firmwareImageSHA256,
+				Targets:  []string{"BIOS"},
+			},
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			resyncDone:               make(chan struct{}),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodPush,
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "success - cluster update of cluster member",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
+			clusterUpdate: true,
+			repoGetByNameServer: &provisioning.Server{
+				Name:    "one",
+				Cluster: ptr.To("cluster-one"),
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			resyncDone:               make(chan struct{}),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodSimpleUpdate,
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "success - task monitor wait fails but resync still runs",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
 			},
+			bmcClientWaitErr:         boom.Error,
+			resyncDone:               make(chan struct{}),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodSimpleUpdate,
 
 			assertErr: require.NoError,
 		},
+		{
+			name:    "success - cluster update resync fails",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
+			clusterUpdate: true,
+			repoGetByNameServer: &provisioning.Server{
+				Name:    "one",
+				Cluster: ptr.To("cluster-one"),
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			bmcClientGetDataErr:      boom.Error,
+			resyncDone:               make(chan struct{}),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodSimpleUpdate,
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "error - invalid update",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "", // invalid
+			},
+			resyncDone: closedChannel(),
+
+			assertErr: errassert.ValidationErrorContains("image URI can not be empty"),
+		},
 		{
 			name:    "error - name empty",
 			nameArg: "", // invalid
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
+			resyncDone: closedChannel(),
 
 			assertErr: errassert.OperationNotPermittedError,
 		},
 		{
-			name:             "error - repo.GetByName",
-			nameArg:          "one",
+			name:    "error - repo.GetByName",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
 			repoGetByNameErr: boom.Error,
+			resyncDone:       closedChannel(),
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - no BMC server client registered for type",
+			name:    "error - cluster member",
 			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
 			repoGetByNameServer: &provisioning.Server{
-				Name: "one",
+				Name:    "one",
+				Cluster: ptr.To("cluster-one"),
 				BMCConfig: api.BMCConfig{
-					APIType:  api.BMCAPIType("unknown"),
-					Endpoint: "https://bmc.local",
+					APIType: api.BMCAPITypeRedfishV1Generic,
 				},
 			},
+			resyncDone: closedChannel(),
 
-			assertErr: errassert.Contains(`Failed to get BMC server client for type "unknown"`),
+			assertErr: errassert.OperationNotPermittedErrorContains(`Server "one" is member of cluster "cluster-one"`),
 		},
 		{
-			name:    "error - client.GetData",
+			name:    "error - client.UpdateFirmwareFromURI",
 			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
 			repoGetByNameServer: &provisioning.Server{
 				Name: "one",
 				BMCConfig: api.BMCConfig{
-					APIType:  api.BMCAPITypeRedfishV1Generic,
-					Endpoint: "https://bmc.local",
+					APIType: api.BMCAPITypeRedfishV1Generic,
 				},
 			},
-			bmcClientGetDataErr: boom.Error,
+			bmcClientUpdateErr:       boom.Error,
+			resyncDone:               closedChannel(),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodSimpleUpdate,
 
 			assertErr: boom.ErrorIs,
 		},
 		{
-			name:    "error - repo.Update",
+			name:    "error - push download fails",
 			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: firmwareSvr.URL + "/firmware/missing.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   firmwareImageSHA256,
+			},
 			repoGetByNameServer: &provisioning.Server{
 				Name: "one",
 				BMCConfig: api.BMCConfig{
-					APIType:  api.BMCAPITypeRedfishV1Generic,
-					Endpoint: "https://bmc.local",
+					APIType: api.BMCAPITypeRedfishV1Generic,
 				},
 			},
-			bmcClientGetData: api.BMCData{
-				ServerUUID: "e9de436e-b94e-4aef-8563-883aec84096e",
+			resyncDone: closedChannel(),
+
+			assertErr: errassert.Contains("unexpected status 404"),
+		},
+		{
+			name:    "error - push download timeout",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: firmwareSvr.URL + "/firmware/slow.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   firmwareImageSHA256,
 			},
-			repoUpdateErr: boom.Error,
+			downloadTimeout: 10 * time.Millisecond,
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			resyncDone: closedChannel(),
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, context.DeadlineExceeded, a...)
+			},
+		},
+		{
+			name:    "error - push image exceeds size limit",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: firmwareSvr.URL + "/firmware/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   firmwareImageSHA256,
+			},
+			imageSizeLimit: 4,
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			resyncDone: closedChannel(),
+
+			assertErr: errassert.Contains("exceeds the limit of 4 bytes"),
+		},
+		{
+			name:    "error - push image sha256 mismatch",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: firmwareSvr.URL + "/firmware/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   strings.Repeat("0", 64),
+			},
+			repoGetByNameServer: &provisioning.Server{
+				Name: "one",
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			resyncDone: closedChannel(),
+
+			assertErr: errassert.Contains("sha256 mismatch"),
+		},
+		{
+			name:    "error - cluster update task monitor wait fails",
+			nameArg: "one",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+			},
+			clusterUpdate: true,
+			repoGetByNameServer: &provisioning.Server{
+				Name:    "one",
+				Cluster: ptr.To("cluster-one"),
+				BMCConfig: api.BMCConfig{
+					APIType: api.BMCAPITypeRedfishV1Generic,
+				},
+			},
+			bmcClientWaitErr:         boom.Error,
+			resyncDone:               closedChannel(),
+			wantUpdateFirmwareMethod: api.BMCFirmwareUpdateMethodSimpleUpdate,
 
 			assertErr: boom.ErrorIs,
 		},
@@ -10399,49 +13682,79 @@ func TestServerService_BMCRefreshByName(t *testing.T) {
 					return tc.repoGetByNameServer, tc.repoGetByNameErr
 				},
 				UpdateFunc: func(ctx context.Context, in provisioning.Server) error {
-					wantDetails := tc.bmcClientGetData
-					wantDetails.LastUpdated = fixedDate
-					require.Equal(t, wantDetails, in.BMCData)
-					require.Equal(t, &wantDetails.ServerUUID, in.SystemUUID)
+					defer close(tc.resyncDone)
 
-					return tc.repoUpdateErr
+					require.Equal(t, fixedDate, in.BMCData.LastUpdated)
+
+					return nil
 				},
 			}
 
+			var gotUpdateFirmwareMethod api.BMCFirmwareUpdateMethod
+
 			bmcClient := &adapterMock.BMCServerClientPortMock{
+				UpdateFirmwareFromURIFunc: func(ctx context.Context, server provisioning.Server, imageURI string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+					gotUpdateFirmwareMethod = api.BMCFirmwareUpdateMethodSimpleUpdate
+
+					require.Equal(t, tc.update.ImageURI, imageURI)
+					require.Equal(t, tc.update.Targets, targets)
+
+					return taskMonitor, tc.bmcClientUpdateErr
+				},
+				UpdateFirmwareFromFileFunc: func(ctx context.Context, server provisioning.Server, imageFilename string, targets []string) (*provisioning.BMCTaskMonitor, error) {
+					gotUpdateFirmwareMethod = api.BMCFirmwareUpdateMethodPush
+
+					require.Equal(t, "BIOS_1.8.2.exe", filepath.Base(imageFilename))
+					require.Equal(t, tc.update.Targets, targets)
+
+					content, err := os.ReadFile(imageFilename)
+					require.NoError(t, err)
+					require.Equal(t, "firmware image", string(content))
+
+					return taskMonitor, tc.bmcClientUpdateErr
+				},
+				WaitForTaskFunc: func(ctx context.Context, server provisioning.Server, monitor *provisioning.BMCTaskMonitor) error {
+					require.Same(t, taskMonitor, monitor)
+
+					return tc.bmcClientWaitErr
+				},
 				GetDataFunc: func(ctx context.Context, server provisioning.Server) (api.BMCData, error) {
-					return tc.bmcClientGetData, tc.bmcClientGetDataErr
+					if tc.bmcClientGetDataErr != nil {
+						close(tc.resyncDone)
+					}
+
+					return api.BMCData{}, tc.bmcClientGetDataErr
 				},
 			}
 
-			serverSvc := provisioningServer.New(
-				repo, nil, nil, nil, nil, nil, nil, tls.Certificate{},
+			opts := []provisioningServer.Option{
 				provisioningServer.WithNow(func() time.Time { return fixedDate }),
 				provisioningServer.AddBMCServerClient(api.BMCAPITypeRedfishV1Generic, bmcClient),
-			)
+			}
+
+			if tc.downloadTimeout != 0 {
+				opts = append(opts, provisioningServer.WithBMCFirmwareDownloadTimeout(tc.downloadTimeout))
+			}
+
+			if tc.imageSizeLimit != 0 {
+				opts = append(opts, provisioningServer.WithBMCFirmwareImageSizeLimit(tc.imageSizeLimit))
+			}
+
+			serverSvc := provisioningServer.New(repo, nil, nil, nil, nil, nil, nil, tls.Certificate{}, opts...)
 
 			// Run test
-			err := serverSvc.BMCRefreshByName(t.Context(), tc.nameArg)
+			err := serverSvc.BMCFirmwareUpdateByName(t.Context(), tc.nameArg, tc.update, tc.clusterUpdate)
 
 			// Assert
 			tc.assertErr(t, err)
-		})
-	}
-}
 
-func pollServerVersionData(incusVersion string) api.ServerVersionData {
-	return api.ServerVersionData{
-		OS: api.OSVersionData{
-			Name:        "IncusOS",
-			Version:     "1",
-			VersionNext: incusVersion,
-		},
-		Applications: []api.ApplicationVersionData{
-			{
-				Name:    "incus",
-				Version: incusVersion,
-			},
-		},
-		UpdateChannel: "stable",
+			select {
+			case <-tc.resyncDone:
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("timed out waiting for asynchronous BMC resync")
+			}
+
+			require.Equal(t, tc.wantUpdateFirmwareMethod, gotUpdateFirmwareMethod)
+		})
 	}
 }
@@ -0,0 +1,54 @@
+package provisioning
+
+import (
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// ValidateBMCFirmwareUpdate validates the given request to update the
+// firmware of a server via its BMC and applies the defaults.
+func ValidateBMCFirmwareUpdate(update *api.BMCFirmwareUpdatePost) error {
+	if update.ImageURI == "" {
+		return domain.NewValidationErrf("Invalid BMC firmware update, image URI can not be empty")
+	}
+
+	imageURI, err := url.Parse(update.ImageURI)
+	if err != nil || imageURI.Scheme == "" || imageURI.Host == "" {
+		return domain.NewValidationErrf("Invalid BMC firmware update, image URI %q is not a valid URL", update.ImageURI)
+	}
+
+	if update.Method == "" {
+		update.Method = api.BMCFirmwareUpdateMethodSimpleUpdate
+	}
+
+	switch update.Method {
+	case api.BMCFirmwareUpdateMethodSimpleUpdate:
+	case api.BMCFirmwareUpdateMethodPush:
+		// The image is downloaded by Operations Center before it is pushed to
+		// the BMC.
+		if imageURI.Scheme != "http" && imageURI.Scheme != "https" {
+			return domain.NewValidationErrf("Invalid BMC firmware update, image URI %q must use http or https to be pushed to the BMC", update.ImageURI)
+		}
+
+		// The downloaded image is verified before it is pushed to the BMC.
+		if update.SHA256 == "" {
+			return domain.NewValidationErrf("Invalid BMC firmware update, sha256 checksum of the image is required for method %q", update.Method)
+		}
+
+		update.SHA256 = strings.ToLower(update.SHA256)
+
+		checksum, err := hex.DecodeString(update.SHA256)
+		if err != nil || len(checksum) != 32 {
+			return domain.NewValidationErrf("Invalid BMC firmware update, sha256 checksum %q is not valid", update.SHA256)
+		}
+
+	default:
+		return domain.NewValidationErrf("Invalid BMC firmware update, method %q is not valid", update.Method)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package provisioning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/testing/errassert"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestValidateBMCFirmwareUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update api.BMCFirmwareUpdatePost
+
+		assertErr  require.ErrorAssertionFunc
+		wantUpdate api.BMCFirmwareUpdatePost
+	}{
+		{
+			name: "valid - method defaults to simple-update",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "tftp://firmware.local/BIOS_1.8.2.exe",
+			},
+
+			assertErr: require.NoError,
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "tftp://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodSimpleUpdate,
+			},
+		},
+		{
+			name: "valid - push",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   "5d5b6a1d4d8b3d3c2f56b0f9c3b54f5f2f6f2d2e7c1c5d2a0d8b5f1c6e9a7b3c",
+				Targets:  []string{"BIOS"},
+			},
+
+			assertErr: require.NoError,
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   "5d5b6a1d4d8b3d3c2f56b0f9c3b54f5f2f6f2d2e7c1c5d2a0d8b5f1c6e9a7b3c",
+				Targets:  []string{"BIOS"},
+			},
+		},
+		{
+			name: "valid - push with upper case sha256",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   "5D5B6A1D4D8B3D3C2F56B0F9C3B54F5F2F6F2D2E7C1C5D2A0D8B5F1C6E9A7B3C",
+			},
+
+			assertErr: require.NoError,
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   "5d5b6a1d4d8b3d3c2f56b0f9c3b54f5f2f6f2d2e7c1c5d2a0d8b5f1c6e9a7b3c",
+			},
+		},
+		{
+			name: "error - image URI empty",
+
+			assertErr: errassert.ValidationErrorContains("image URI can not be empty"),
+		},
+		{
+			name: "error - image URI invalid",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "BIOS_1.8.2.exe",
+			},
+
+			assertErr: errassert.ValidationErrorContains(`image URI "BIOS_1.8.2.exe" is not a valid URL`),
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "BIOS_1.8.2.exe",
+			},
+		},
+		{
+			name: "error - push with unsupported scheme",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "tftp://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+			},
+
+			assertErr: errassert.ValidationErrorContains("must use http or https"),
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "tftp://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+			},
+		},
+		{
+			name: "error - push without sha256",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+			},
+
+			assertErr: errassert.ValidationErrorContains("sha256 checksum of the image is required"),
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+			},
+		},
+		{
+			name: "error - push with invalid sha256",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   "invalid",
+			},
+
+			assertErr: errassert.ValidationErrorContains(`sha256 checksum "invalid" is not valid`),
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   api.BMCFirmwareUpdateMethodPush,
+				SHA256:   "invalid",
+			},
+		},
+		{
+			name: "error - method invalid",
+			update: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   "invalid",
+			},
+
+			assertErr: errassert.ValidationErrorContains(`method "invalid" is not valid`),
+			wantUpdate: api.BMCFirmwareUpdatePost{
+				ImageURI: "https://firmware.local/BIOS_1.8.2.exe",
+				Method:   "invalid",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := provisioning.ValidateBMCFirmwareUpdate(&tc.update)
+
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantUpdate, tc.update)
+		})
+	}
+}
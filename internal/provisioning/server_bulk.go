@@ -0,0 +1,55 @@
+package provisioning
+
+import (
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// ServerBulkAction defines an action, which is performed on all the servers
+// matching the filter expression. Only the arguments relevant for the given
+// action are considered.
+type ServerBulkAction struct {
+	Filter      string
+	Action      api.ServerBulkAction
+	Concurrency int
+	DryRun      bool
+
+	// Force is used by the reboot and update actions.
+	Force bool
+
+	// UpdateRequest is used by the update action.
+	UpdateRequest api.ServerUpdatePost
+
+	// LoggingConfig is used by the update_system_logging action.
+	LoggingConfig ServerSystemLogging
+
+	// ApplicationName is used by the add_application action.
+	ApplicationName string
+}
+
+func (a ServerBulkAction) Validate() error {
+	if a.Filter == "" {
+		return domain.NewValidationErrf("Invalid server bulk action, filter expression can not be empty")
+	}
+
+	switch a.Action {
+	case api.ServerBulkActionReboot, api.ServerBulkActionUpdate, api.ServerBulkActionUpdateSystemLogging:
+	case api.ServerBulkActionAddApplication:
+		if a.ApplicationName == "" {
+			return domain.NewValidationErrf("Invalid server bulk action, application name can not be empty")
+		}
+
+	default:
+		return domain.NewValidationErrf("Invalid server bulk action, action %q is not supported", a.Action)
+	}
+
+	if a.Concurrency < 0 {
+		return domain.NewValidationErrf("Invalid server bulk action, concurrency can not be negative")
+	}
+
+	return nil
+}
+
+type ServerBulkActionResult = api.ServerBulkActionResult
+
+type ServerBulkActionResults []ServerBulkActionResult
@@ -0,0 +1,86 @@
+package provisioning_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/testing/errassert"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestServerBulkAction_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		action provisioning.ServerBulkAction
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid - reboot",
+			action: provisioning.ServerBulkAction{
+				Filter: `properties.rack == "r12"`,
+				Action: api.ServerBulkActionReboot,
+				Force:  true,
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "valid - add_application",
+			action: provisioning.ServerBulkAction{
+				Filter:          `status == "ready"`,
+				Action:          api.ServerBulkActionAddApplication,
+				ApplicationName: "incus",
+				Concurrency:     10,
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - filter empty",
+			action: provisioning.ServerBulkAction{
+				Action: api.ServerBulkActionReboot,
+			},
+
+			assertErr: errassert.ValidationErrorContains("filter expression can not be empty"),
+		},
+		{
+			name: "error - action invalid",
+			action: provisioning.ServerBulkAction{
+				Filter: `true`,
+				Action: api.ServerBulkActionInvalid,
+			},
+
+			assertErr: errassert.ValidationErrorContains(`action "" is not supported`),
+		},
+		{
+			name: "error - add_application without application name",
+			action: provisioning.ServerBulkAction{
+				Filter: `true`,
+				Action: api.ServerBulkActionAddApplication,
+			},
+
+			assertErr: errassert.ValidationErrorContains("application name can not be empty"),
+		},
+		{
+			name: "error - concurrency negative",
+			action: provisioning.ServerBulkAction{
+				Filter:      `true`,
+				Action:      api.ServerBulkActionUpdate,
+				Concurrency: -1,
+			},
+
+			assertErr: errassert.ValidationErrorContains("concurrency can not be negative"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.action.Validate()
+
+			tc.assertErr(t, err)
+		})
+	}
+}
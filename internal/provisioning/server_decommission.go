@@ -0,0 +1,39 @@
+package provisioning
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// ServerDecommission is the record of the decommission of a server. Since
+// the server is usually removed from the inventory as the last step of the
+// decommission, the record refers to the server by name only and is kept
+// as audit record after the server has been deleted.
+type ServerDecommission struct {
+	ID         int64
+	UUID       uuid.UUID `db:"primary=yes"`
+	Server     string
+	Options    api.ServerDecommissionOptions
+	Status     api.ServerDecommissionStatus
+	Steps      api.ServerDecommissionStepResults
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+type ServerDecommissions []ServerDecommission
+
+// IsDone returns true, if the given step has already been completed (done or
+// skipped) by the decommission.
+func (d ServerDecommission) IsDone(step api.ServerDecommissionStep) bool {
+	for _, result := range d.Steps {
+		if result.Step == step && result.Status != api.ServerDecommissionStepStatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
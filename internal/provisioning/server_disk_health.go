@@ -0,0 +1,203 @@
+package provisioning
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	incusosapi "github.com/lxc/incus-os/incus-osd/api"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+const (
+	// DiskHealthPercentageUsedWarning is the threshold for the used life of an
+	// NVMe disk in percent, above which the disk is reported as "warning".
+	DiskHealthPercentageUsedWarning = 80
+
+	// DiskHealthPercentageUsedFailing is the threshold for the used life of an
+	// NVMe disk in percent, above which the disk is reported as "failing".
+	DiskHealthPercentageUsedFailing = 100
+
+	// DiskHealthAvailableSpareWarning is the threshold for the available spare
+	// of an NVMe disk in percent, below which the disk is reported as "warning".
+	DiskHealthAvailableSpareWarning = 20
+
+	// DiskHealthAvailableSpareFailing is the threshold for the available spare
+	// of an NVMe disk in percent, below which the disk is reported as "failing".
+	DiskHealthAvailableSpareFailing = 10
+
+	// DiskHealthReallocatedSectorsFailing is the number of reallocated sectors
+	// of a SATA disk, above which the disk is reported as "failing". Any
+	// reallocated sector causes the disk to be reported as "warning".
+	DiskHealthReallocatedSectorsFailing = 100
+
+	// DiskHealthSampleInterval is the interval, after which a new sample is
+	// recorded for a disk, even if none of the health attributes did change.
+	DiskHealthSampleInterval = 24 * time.Hour
+
+	// DiskHealthSampleRetention is the period, for which the disk health
+	// samples are kept. The most recent sample of each disk is always kept.
+	DiskHealthSampleRetention = 180 * 24 * time.Hour
+)
+
+// ServerDiskHealthSample is a recorded sample of the SMART health attributes
+// of a disk of a server.
+type ServerDiskHealthSample struct {
+	ID                 int64
+	Server             string `db:"join=servers.name"`
+	Drive              string
+	Passed             bool
+	PercentageUsed     int
+	AvailableSpare     int
+	ReallocatedSectors int
+	PowerOnHours       int
+	RecordedAt         time.Time
+}
+
+type ServerDiskHealthSamples []ServerDiskHealthSample
+
+// DiskHealthDriveID returns the identifier used to track the health of a
+// drive over time. The serial number is preferred, since it is stable, even
+// if the drive is moved to a different slot.
+func DiskHealthDriveID(drive incusosapi.SystemStorageDrive) string {
+	return cmp.Or(drive.SerialNumber, drive.WWN, drive.ID)
+}
+
+// NewServerDiskHealthSample returns a new sample from the SMART data of the
+// given drive. If SMART data is not available for the drive, false is
+// returned.
+func NewServerDiskHealthSample(server string, drive incusosapi.SystemStorageDrive, recordedAt time.Time) (ServerDiskHealthSample, bool) {
+	if drive.SMART == nil || !drive.SMART.Enabled || drive.SMART.Error != "" {
+		return ServerDiskHealthSample{}, false
+	}
+
+	return ServerDiskHealthSample{
+		Server:             server,
+		Drive:              DiskHealthDriveID(drive),
+		Passed:             drive.SMART.Passed,
+		PercentageUsed:     drive.SMART.PercentageUsed,
+		AvailableSpare:     drive.SMART.AvailableSpare,
+		ReallocatedSectors: drive.SMART.ReallocatedSectors,
+		PowerOnHours:       drive.SMART.PowerOnHours,
+		RecordedAt:         recordedAt,
+	}, true
+}
+
+// NeedsRecording returns true, if the sample should be recorded in regard to
+// the previously recorded sample of the same drive. This is the case, if
+// one of the health attributes did change or if the previous sample is older
+// than DiskHealthSampleInterval. Power on hours are not considered, since
+// they change constantly.
+func (s ServerDiskHealthSample) NeedsRecording(previous *ServerDiskHealthSample) bool {
+	if previous == nil {
+		return true
+	}
+
+	return s.Passed != previous.Passed ||
+		s.PercentageUsed != previous.PercentageUsed ||
+		s.AvailableSpare != previous.AvailableSpare ||
+		s.ReallocatedSectors != previous.ReallocatedSectors ||
+		s.RecordedAt.Sub(previous.RecordedAt) >= DiskHealthSampleInterval
+}
+
+// ByDrive returns the samples for the given drive in chronological order.
+func (s ServerDiskHealthSamples) ByDrive(drive string) ServerDiskHealthSamples {
+	samples := make(ServerDiskHealthSamples, 0, len(s))
+	for _, sample := range s {
+		if sample.Drive == drive {
+			samples = append(samples, sample)
+		}
+	}
+
+	return samples
+}
+
+// Latest returns the most recent sample or nil, if there are no samples.
+func (s ServerDiskHealthSamples) Latest() *ServerDiskHealthSample {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return &s[len(s)-1]
+}
+
+// EvaluateDiskHealth evaluates the health of a drive based on the current
+// SMART data and the recorded history of samples of the drive.
+func EvaluateDiskHealth(server Server, drive incusosapi.SystemStorageDrive, history ServerDiskHealthSamples) api.ServerDiskHealth {
+	health := api.ServerDiskHealth{
+		Server:       server.Name,
+		Drive:        drive.ID,
+		ModelName:    drive.ModelName,
+		SerialNumber: drive.SerialNumber,
+		Bus:          drive.Bus,
+		Status:       api.DiskHealthStatusOK,
+		Reasons:      []string{},
+	}
+
+	if server.Cluster != nil {
+		health.Cluster = *server.Cluster
+	}
+
+	if drive.SMART == nil || !drive.SMART.Enabled {
+		health.Status = api.DiskHealthStatusUnknown
+		health.Reasons = append(health.Reasons, "SMART data not available")
+		return health
+	}
+
+	if drive.SMART.Error != "" {
+		health.Status = api.DiskHealthStatusUnknown
+		health.Reasons = append(health.Reasons, fmt.Sprintf("failed to read SMART data: %s", drive.SMART.Error))
+		return health
+	}
+
+	smart := drive.SMART
+	health.Passed = smart.Passed
+	health.PercentageUsed = smart.PercentageUsed
+	health.AvailableSpare = smart.AvailableSpare
+	health.ReallocatedSectors = smart.ReallocatedSectors
+	health.PowerOnHours = smart.PowerOnHours
+
+	escalate := func(status api.DiskHealthStatus, reason string) {
+		if status == api.DiskHealthStatusFailing || health.Status == api.DiskHealthStatusOK {
+			health.Status = status
+		}
+
+		health.Reasons = append(health.Reasons, reason)
+	}
+
+	if !smart.Passed {
+		escalate(api.DiskHealthStatusFailing, "SMART overall health self-assessment test failed")
+	}
+
+	switch {
+	case smart.PercentageUsed >= DiskHealthPercentageUsedFailing:
+		escalate(api.DiskHealthStatusFailing, fmt.Sprintf("percentage used %d%% reached the threshold of %d%%", smart.PercentageUsed, DiskHealthPercentageUsedFailing))
+	case smart.PercentageUsed >= DiskHealthPercentageUsedWarning:
+		escalate(api.DiskHealthStatusWarning, fmt.Sprintf("percentage used %d%% reached the threshold of %d%%", smart.PercentageUsed, DiskHealthPercentageUsedWarning))
+	}
+
+	// Available spare is only reported by NVMe disks, a value of 0 is
+	// therefore only considered in combination with a reported usage.
+	if smart.AvailableSpare > 0 || smart.PercentageUsed > 0 {
+		switch {
+		case smart.AvailableSpare < DiskHealthAvailableSpareFailing:
+			escalate(api.DiskHealthStatusFailing, fmt.Sprintf("available spare %d%% below the threshold of %d%%", smart.AvailableSpare, DiskHealthAvailableSpareFailing))
+		case smart.AvailableSpare < DiskHealthAvailableSpareWarning:
+			escalate(api.DiskHealthStatusWarning, fmt.Sprintf("available spare %d%% below the threshold of %d%%", smart.AvailableSpare, DiskHealthAvailableSpareWarning))
+		}
+	}
+
+	switch {
+	case smart.ReallocatedSectors > DiskHealthReallocatedSectorsFailing:
+		escalate(api.DiskHealthStatusFailing, fmt.Sprintf("%d reallocated sectors exceed the threshold of %d", smart.ReallocatedSectors, DiskHealthReallocatedSectorsFailing))
+	case smart.ReallocatedSectors > 0:
+		escalate(api.DiskHealthStatusWarning, fmt.Sprintf("%d reallocated sectors", smart.ReallocatedSectors))
+	}
+
+	if len(history) > 0 && smart.ReallocatedSectors > history[0].ReallocatedSectors {
+		escalate(api.DiskHealthStatusWarning, fmt.Sprintf("reallocated sectors increased from %d to %d since %s", history[0].ReallocatedSectors, smart.ReallocatedSectors, history[0].RecordedAt.Format(time.DateOnly)))
+	}
+
+	return health
+}
@@ -0,0 +1,221 @@
+package provisioning_test
+
+import (
+	"testing"
+	"time"
+
+	incusosapi "github.com/lxc/incus-os/incus-osd/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestEvaluateDiskHealth(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	drive := func(smart *incusosapi.SystemStorageDriveSMART) incusosapi.SystemStorageDrive {
+		return incusosapi.SystemStorageDrive{
+			ID:           "nvme-eui.0025388b91b12345",
+			ModelName:    "Samsung SSD 970 EVO 1TB",
+			SerialNumber: "S1",
+			Bus:          "nvme",
+			SMART:        smart,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		server  provisioning.Server
+		drive   incusosapi.SystemStorageDrive
+		history provisioning.ServerDiskHealthSamples
+
+		wantStatus  api.DiskHealthStatus
+		wantReasons []string
+	}{
+		{
+			name: "ok",
+			server: provisioning.Server{
+				Name:    "one",
+				Cluster: ptr.To("cluster"),
+			},
+			drive: drive(&incusosapi.SystemStorageDriveSMART{
+				Enabled:        true,
+				Passed:         true,
+				PercentageUsed: 10,
+				AvailableSpare: 100,
+			}),
+
+			wantStatus:  api.DiskHealthStatusOK,
+			wantReasons: []string{},
+		},
+		{
+			name:   "unknown - no SMART data",
+			server: provisioning.Server{Name: "one"},
+			drive:  drive(nil),
+
+			wantStatus:  api.DiskHealthStatusUnknown,
+			wantReasons: []string{"SMART data not available"},
+		},
+		{
+			name:   "unknown - SMART error",
+			server: provisioning.Server{Name: "one"},
+			drive: drive(&incusosapi.SystemStorageDriveSMART{
+				Enabled: true,
+				Error:   "boom!",
+			}),
+
+			wantStatus:  api.DiskHealthStatusUnknown,
+			wantReasons: []string{"failed to read SMART data: boom!"},
+		},
+		{
+			name:   "warning - wear and spare",
+			server: provisioning.Server{Name: "one"},
+			drive: drive(&incusosapi.SystemStorageDriveSMART{
+				Enabled:        true,
+				Passed:         true,
+				PercentageUsed: 85,
+				AvailableSpare: 15,
+			}),
+
+			wantStatus: api.DiskHealthStatusWarning,
+			wantReasons: []string{
+				"percentage used 85% reached the threshold of 80%",
+				"available spare 15% below the threshold of 20%",
+			},
+		},
+		{
+			name:   "warning - reallocated sectors increased",
+			server: provisioning.Server{Name: "one"},
+			drive: drive(&incusosapi.SystemStorageDriveSMART{
+				Enabled:            true,
+				Passed:             true,
+				ReallocatedSectors: 8,
+			}),
+			history: provisioning.ServerDiskHealthSamples{
+				{Drive: "S1", Passed: true, ReallocatedSectors: 2, RecordedAt: fixedDate},
+				{Drive: "S1", Passed: true, ReallocatedSectors: 8, RecordedAt: fixedDate.Add(time.Hour)},
+			},
+
+			wantStatus: api.DiskHealthStatusWarning,
+			wantReasons: []string{
+				"8 reallocated sectors",
+				"reallocated sectors increased from 2 to 8 since 2025-03-12",
+			},
+		},
+		{
+			name:   "failing - self-assessment failed and worn out",
+			server: provisioning.Server{Name: "one"},
+			drive: drive(&incusosapi.SystemStorageDriveSMART{
+				Enabled:        true,
+				Passed:         false,
+				PercentageUsed: 101,
+				AvailableSpare: 5,
+			}),
+
+			wantStatus: api.DiskHealthStatusFailing,
+			wantReasons: []string{
+				"SMART overall health self-assessment test failed",
+				"percentage used 101% reached the threshold of 100%",
+				"available spare 5% below the threshold of 10%",
+			},
+		},
+		{
+			name:   "failing - reallocated sectors",
+			server: provisioning.Server{Name: "one"},
+			drive: drive(&incusosapi.SystemStorageDriveSMART{
+				Enabled:            true,
+				Passed:             true,
+				ReallocatedSectors: 150,
+			}),
+
+			wantStatus: api.DiskHealthStatusFailing,
+			wantReasons: []string{
+				"150 reallocated sectors exceed the threshold of 100",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			health := provisioning.EvaluateDiskHealth(tc.server, tc.drive, tc.history)
+
+			require.Equal(t, tc.server.Name, health.Server)
+			require.Equal(t, ptr.From(tc.server.Cluster), health.Cluster)
+			require.Equal(t, tc.drive.ID, health.Drive)
+			require.Equal(t, tc.wantStatus, health.Status)
+			require.Equal(t, tc.wantReasons, health.Reasons)
+		})
+	}
+}
+
+func TestServerDiskHealthSample_NeedsRecording(t *testing.T) {
+	fixedDate := time.Date(2025, 3, 12, 10, 57, 43, 0, time.UTC)
+
+	previous := provisioning.ServerDiskHealthSample{
+		Drive:          "S1",
+		Passed:         true,
+		PercentageUsed: 10,
+		AvailableSpare: 100,
+		PowerOnHours:   1000,
+		RecordedAt:     fixedDate,
+	}
+
+	tests := []struct {
+		name     string
+		sample   provisioning.ServerDiskHealthSample
+		previous *provisioning.ServerDiskHealthSample
+
+		want bool
+	}{
+		{
+			name:   "no previous sample",
+			sample: previous,
+
+			want: true,
+		},
+		{
+			name: "only power on hours changed",
+			sample: func() provisioning.ServerDiskHealthSample {
+				s := previous
+				s.PowerOnHours = 1005
+				s.RecordedAt = fixedDate.Add(5 * time.Hour)
+				return s
+			}(),
+			previous: &previous,
+
+			want: false,
+		},
+		{
+			name: "percentage used changed",
+			sample: func() provisioning.ServerDiskHealthSample {
+				s := previous
+				s.PercentageUsed = 11
+				return s
+			}(),
+			previous: &previous,
+
+			want: true,
+		},
+		{
+			name: "sample interval passed",
+			sample: func() provisioning.ServerDiskHealthSample {
+				s := previous
+				s.RecordedAt = fixedDate.Add(provisioning.DiskHealthSampleInterval)
+				return s
+			}(),
+			previous: &previous,
+
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.sample.NeedsRecording(tc.previous)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
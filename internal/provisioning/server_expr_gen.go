@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	osapi "github.com/lxc/incus-os/incus-osd/api"
+	"github.com/lxc/incus-os/incus-osd/api/images"
 
 	"github.com/FuturFusion/operations-center/shared/api"
 )
@@ -52,9 +53,20 @@ type ExprApiBMCData struct {
 	ServerLocationIndicatorActive bool                              `json:"server_location_indicator_active" yaml:"server_location_indicator_active" expr:"server_location_indicator_active"`
 	ServerHealthStatus            string                            `json:"server_health_status" yaml:"server_health_status" expr:"server_health_status"`
 	VirtualMedia                  map[string]ExprApiBMCVirtualMedia `json:"virtual_media" yaml:"virtual_media" expr:"virtual_media"`
+	Firmware                      map[string]ExprApiBMCFirmware     `json:"firmware" yaml:"firmware" expr:"firmware"`
 	LastUpdated                   time.Time                         `json:"last_updated" yaml:"last_updated" expr:"last_updated"`
 }
 
+type ExprApiBMCFirmware struct {
+	ID           string                   `json:"id" yaml:"id" expr:"id"`
+	Name         string                   `json:"name" yaml:"name" expr:"name"`
+	Component    api.BMCFirmwareComponent `json:"component" yaml:"component" expr:"component"`
+	Version      string                   `json:"version" yaml:"version" expr:"version"`
+	Manufacturer string                   `json:"manufacturer" yaml:"manufacturer" expr:"manufacturer"`
+	ReleaseDate  string                   `json:"release_date" yaml:"release_date" expr:"release_date"`
+	Updateable   bool                     `json:"updateable" yaml:"updateable" expr:"updateable"`
+}
+
 type ExprApiBMCVirtualMedia struct {
 	ID                   string   `json:"id" yaml:"id" expr:"id"`
 	Inserted             bool     `json:"inserted" yaml:"inserted" expr:"inserted"`
@@ -83,6 +95,28 @@ type ExprApiOSVersionData struct {
 	NeedsUpdate      *bool   `json:"needs_update,omitempty" yaml:"needs_update,omitempty" expr:"needs_update"`
 }
 
+type ExprApiServerBMCInstall struct {
+	ID           uuid.UUID                     `json:"id" yaml:"id" expr:"id"`
+	Token        uuid.UUID                     `json:"token" yaml:"token" expr:"token"`
+	TokenSeed    string                        `json:"token_seed" yaml:"token_seed" expr:"token_seed"`
+	Architecture images.UpdateFileArchitecture `json:"architecture" yaml:"architecture" expr:"architecture"`
+	Channel      string                        `json:"channel" yaml:"channel" expr:"channel"`
+	VirtualMedia string                        `json:"virtual_media" yaml:"virtual_media" expr:"virtual_media"`
+	MediaURL     string                        `json:"media_url" yaml:"media_url" expr:"media_url"`
+	Status       api.ServerBMCInstallStatus    `json:"status" yaml:"status" expr:"status"`
+	Error        string                        `json:"error,omitempty" yaml:"error,omitempty" expr:"error"`
+	LastUpdated  time.Time                     `json:"last_updated" yaml:"last_updated" expr:"last_updated"`
+}
+
+type ExprApiServerClusterJoin struct {
+	Cluster                string                      `json:"cluster" yaml:"cluster" expr:"cluster"`
+	SkipPostJoinOperations bool                        `json:"skip_post_join_operations" yaml:"skip_post_join_operations" expr:"skip_post_join_operations"`
+	CopyServicesConfig     bool                        `json:"copy_services_config" yaml:"copy_services_config" expr:"copy_services_config"`
+	Status                 api.ServerClusterJoinStatus `json:"status" yaml:"status" expr:"status"`
+	Error                  string                      `json:"error,omitempty" yaml:"error,omitempty" expr:"error"`
+	LastUpdated            time.Time                   `json:"last_updated" yaml:"last_updated" expr:"last_updated"`
+}
+
 type ExprApiServerVersionData struct {
 	OS            ExprApiOSVersionData            `json:"os" yaml:"os" expr:"os"`
 	Applications  []ExprApiApplicationVersionData `json:"applications" yaml:"applications" expr:"applications"`
@@ -422,6 +456,7 @@ type ExprServer struct {
 	OSData               ExprApiOSData            `json:"os_data" expr:"os_data"`
 	VersionData          ExprApiServerVersionData `json:"version_data" expr:"version_data"`
 	Channel              string                   `json:"channel"                db:"join=channels.name" expr:"channel"`
+	Site                 *string                  `json:"site"                   db:"leftjoin=sites.name" expr:"site"`
 	Status               api.ServerStatus         `json:"status" expr:"status"`
 	StatusDetail         api.ServerStatusDetail   `json:"status_detail" expr:"status_detail"`
 	Description          string                   `json:"description" expr:"description"`
@@ -431,6 +466,8 @@ type ExprServer struct {
 	SystemUUID           *string                  `json:"system_uuid" expr:"system_uuid"`
 	MachineID            *string                  `json:"machine_id" expr:"machine_id"`
 	BMCData              ExprApiBMCData           `json:"bmc_data"               db:"marshal=json" expr:"bmc_data"`
+	ClusterJoin          ExprApiServerClusterJoin `json:"cluster_join"           db:"marshal=json" expr:"cluster_join"`
+	BMCInstall           ExprApiServerBMCInstall  `json:"bmc_install"            db:"marshal=json" expr:"bmc_install"`
 	LastUpdated          time.Time                `json:"last_updated"           db:"update_timestamp" expr:"last_updated"`
 	LastSeen             time.Time                `json:"last_seen" expr:"last_seen"`
 	LastStatusUpdated    time.Time                `json:"last_status_updated" expr:"last_status_updated"`
@@ -482,10 +519,23 @@ func ToExprApiBMCData(b api.BMCData) ExprApiBMCData {
 		ServerLocationIndicatorActive: b.ServerLocationIndicatorActive,
 		ServerHealthStatus:            b.ServerHealthStatus,
 		VirtualMedia:                  mapConvert(b.VirtualMedia, ToExprApiBMCVirtualMedia),
+		Firmware:                      mapConvert(b.Firmware, ToExprApiBMCFirmware),
 		LastUpdated:                   b.LastUpdated,
 	}
 }
 
+func ToExprApiBMCFirmware(b api.BMCFirmware) ExprApiBMCFirmware {
+	return ExprApiBMCFirmware{
+		ID:           b.ID,
+		Name:         b.Name,
+		Component:    b.Component,
+		Version:      b.Version,
+		Manufacturer: b.Manufacturer,
+		ReleaseDate:  b.ReleaseDate,
+		Updateable:   b.Updateable,
+	}
+}
+
 func ToExprApiBMCVirtualMedia(b api.BMCVirtualMedia) ExprApiBMCVirtualMedia {
 	return ExprApiBMCVirtualMedia{
 		ID:                   b.ID,
@@ -520,6 +570,32 @@ func ToExprApiOSVersionData(o api.OSVersionData) ExprApiOSVersionData {
 	}
 }
 
+func ToExprApiServerBMCInstall(s api.ServerBMCInstall) ExprApiServerBMCInstall {
+	return ExprApiServerBMCInstall{
+		ID:           s.ID,
+		Token:        s.Token,
+		TokenSeed:    s.TokenSeed,
+		Architecture: s.Architecture,
+		Channel:      s.Channel,
+		VirtualMedia: s.VirtualMedia,
+		MediaURL:     s.MediaURL,
+		Status:       s.Status,
+		Error:        s.Error,
+		LastUpdated:  s.LastUpdated,
+	}
+}
+
+func ToExprApiServerClusterJoin(s api.ServerClusterJoin) ExprApiServerClusterJoin {
+	return ExprApiServerClusterJoin{
+		Cluster:                s.Cluster,
+		SkipPostJoinOperations: s.SkipPostJoinOperations,
+		CopyServicesConfig:     s.CopyServicesConfig,
+		Status:                 s.Status,
+		Error:                  s.Error,
+		LastUpdated:            s.LastUpdated,
+	}
+}
+
 func ToExprApiServerVersionData(s api.ServerVersionData) ExprApiServerVersionData {
 	return ExprApiServerVersionData{
 		OS:            ToExprApiOSVersionData(s.OS),
@@ -934,6 +1010,7 @@ func ToExprServer(s Server) ExprServer {
 		OSData:               ToExprApiOSData(s.OSData),
 		VersionData:          ToExprApiServerVersionData(s.VersionData),
 		Channel:              s.Channel,
+		Site:                 s.Site,
 		Status:               s.Status,
 		StatusDetail:         s.StatusDetail,
 		Description:          s.Description,
@@ -943,6 +1020,8 @@ func ToExprServer(s Server) ExprServer {
 		SystemUUID:           s.SystemUUID,
 		MachineID:            s.MachineID,
 		BMCData:              ToExprApiBMCData(s.BMCData),
+		ClusterJoin:          ToExprApiServerClusterJoin(s.ClusterJoin),
+		BMCInstall:           ToExprApiServerBMCInstall(s.BMCInstall),
 		LastUpdated:          s.LastUpdated,
 		LastSeen:             s.LastSeen,
 		LastStatusUpdated:    s.LastStatusUpdated,
@@ -0,0 +1,256 @@
+package provisioning
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v7/shared/units"
+
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+// ServerHardwareSnapshot is a recorded snapshot of the hardware data of
+// a server. A new snapshot is recorded for the first hardware data received
+// from a server and whenever the hardware of the server did change.
+type ServerHardwareSnapshot struct {
+	ID           int64
+	Server       string `db:"join=servers.name"`
+	HardwareData api.HardwareData
+	Changes      api.HardwareChanges
+	CreatedAt    time.Time
+}
+
+type ServerHardwareSnapshots []ServerHardwareSnapshot
+
+// memoryChangeTolerance is the amount of memory, a server's memory may vary
+// between two polls without being considered a change. The total memory
+// reported by the kernel is subject to small variations (e.g. after kernel
+// updates), while adding or removing a memory module changes the total by
+// at least a full GiB.
+const memoryChangeTolerance = 1024 * 1024 * 1024
+
+// HasHardwareData returns true, if the given hardware data has been populated
+// from a server.
+func HasHardwareData(hardwareData api.HardwareData) bool {
+	return hardwareData.CPU.Architecture != ""
+}
+
+// DiffHardware compares the hardware data of a server with its previous
+// hardware data and returns the changes of the CPUs, the memory, the disks
+// (identified by their serial number) and the NICs (identified by their MAC
+// address).
+//
+// Only properties, which are expected to be stable, are considered, while
+// volatile properties like memory usage, link state or firmware versions are
+// ignored. Removable disks (e.g. USB sticks or virtual media) are ignored as
+// well. If there is no previous hardware data, no changes are reported.
+func DiffHardware(previous api.HardwareData, current api.HardwareData) api.HardwareChanges {
+	if !HasHardwareData(previous) || !HasHardwareData(current) {
+		return nil
+	}
+
+	changes := api.HardwareChanges{}
+	changes = append(changes, diffCPU(previous, current)...)
+	changes = append(changes, diffMemory(previous, current)...)
+	changes = append(changes, diffDisks(previous, current)...)
+	changes = append(changes, diffNICs(previous, current)...)
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return changes
+}
+
+func diffCPU(previous api.HardwareData, current api.HardwareData) api.HardwareChanges {
+	changes := api.HardwareChanges{}
+
+	if previous.CPU.Architecture != current.CPU.Architecture {
+		changes = append(changes, api.HardwareChange{
+			Component:  api.HardwareComponentCPU,
+			Action:     api.HardwareChangeActionChanged,
+			Identifier: "architecture",
+			Previous:   previous.CPU.Architecture,
+			Current:    current.CPU.Architecture,
+		})
+	}
+
+	previousSockets := map[string]string{}
+	for _, socket := range previous.CPU.Sockets {
+		previousSockets[fmt.Sprintf("socket %d", socket.Socket)] = fmt.Sprintf("%s (%d cores)", strings.TrimSpace(socket.Name), len(socket.Cores))
+	}
+
+	currentSockets := map[string]string{}
+	for _, socket := range current.CPU.Sockets {
+		currentSockets[fmt.Sprintf("socket %d", socket.Socket)] = fmt.Sprintf("%s (%d cores)", strings.TrimSpace(socket.Name), len(socket.Cores))
+	}
+
+	return append(changes, diffComponents(api.HardwareComponentCPU, previousSockets, currentSockets)...)
+}
+
+func diffMemory(previous api.HardwareData, current api.HardwareData) api.HardwareChanges {
+	changes := api.HardwareChanges{}
+
+	// Without information about the NUMA nodes, only the total memory can be
+	// compared.
+	if len(previous.Memory.Nodes) == 0 || len(current.Memory.Nodes) == 0 {
+		if memoryDiffers(previous.Memory.Total, current.Memory.Total) {
+			changes = append(changes, api.HardwareChange{
+				Component:  api.HardwareComponentMemory,
+				Action:     api.HardwareChangeActionChanged,
+				Identifier: "total",
+				Previous:   units.GetByteSizeStringIEC(int64(previous.Memory.Total), 2),
+				Current:    units.GetByteSizeStringIEC(int64(current.Memory.Total), 2),
+			})
+		}
+
+		return changes
+	}
+
+	previousNodes := map[string]uint64{}
+	for _, node := range previous.Memory.Nodes {
+		previousNodes[fmt.Sprintf("numa node %d", node.NUMANode)] = node.Total
+	}
+
+	currentNodes := map[string]uint64{}
+	for _, node := range current.Memory.Nodes {
+		currentNodes[fmt.Sprintf("numa node %d", node.NUMANode)] = node.Total
+	}
+
+	for _, identifier := range sortedKeys(previousNodes, currentNodes) {
+		previousTotal, inPrevious := previousNodes[identifier]
+		currentTotal, inCurrent := currentNodes[identifier]
+
+		change := api.HardwareChange{
+			Component:  api.HardwareComponentMemory,
+			Identifier: identifier,
+		}
+
+		switch {
+		case !inCurrent:
+			change.Action = api.HardwareChangeActionRemoved
+			change.Previous = units.GetByteSizeStringIEC(int64(previousTotal), 2)
+
+		case !inPrevious:
+			change.Action = api.HardwareChangeActionAdded
+			change.Current = units.GetByteSizeStringIEC(int64(currentTotal), 2)
+
+		case memoryDiffers(previousTotal, currentTotal):
+			change.Action = api.HardwareChangeActionChanged
+			change.Previous = units.GetByteSizeStringIEC(int64(previousTotal), 2)
+			change.Current = units.GetByteSizeStringIEC(int64(currentTotal), 2)
+
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func memoryDiffers(previous uint64, current uint64) bool {
+	if previous > current {
+		return previous-current >= memoryChangeTolerance
+	}
+
+	return current-previous >= memoryChangeTolerance
+}
+
+func diffDisks(previous api.HardwareData, current api.HardwareData) api.HardwareChanges {
+	disks := func(hardwareData api.HardwareData) map[string]string {
+		disks := map[string]string{}
+		for _, disk := range hardwareData.Storage.Disks {
+			if disk.Removable {
+				continue
+			}
+
+			identifier := cmp.Or(disk.Serial, disk.WWN, disk.ID)
+			disks[identifier] = fmt.Sprintf("%s (%s)", strings.TrimSpace(disk.Model), units.GetByteSizeString(int64(disk.Size), 2))
+		}
+
+		return disks
+	}
+
+	return diffComponents(api.HardwareComponentDisk, disks(previous), disks(current))
+}
+
+func diffNICs(previous api.HardwareData, current api.HardwareData) api.HardwareChanges {
+	nics := func(hardwareData api.HardwareData) map[string]string {
+		nics := map[string]string{}
+		for _, card := range hardwareData.Network.Cards {
+			for _, port := range card.Ports {
+				if port.Address == "" {
+					continue
+				}
+
+				nics[strings.ToLower(port.Address)] = strings.TrimSpace(fmt.Sprintf("%s %s", card.Vendor, card.Product))
+			}
+		}
+
+		return nics
+	}
+
+	return diffComponents(api.HardwareComponentNIC, nics(previous), nics(current))
+}
+
+// diffComponents compares the descriptions of the hardware components of
+// a kind, identified by the keys of the given maps.
+func diffComponents(component api.HardwareComponent, previous map[string]string, current map[string]string) api.HardwareChanges {
+	changes := api.HardwareChanges{}
+
+	for _, identifier := range sortedKeys(previous, current) {
+		previousDescription, inPrevious := previous[identifier]
+		currentDescription, inCurrent := current[identifier]
+
+		switch {
+		case !inCurrent:
+			changes = append(changes, api.HardwareChange{
+				Component:  component,
+				Action:     api.HardwareChangeActionRemoved,
+				Identifier: identifier,
+				Previous:   previousDescription,
+			})
+
+		case !inPrevious:
+			changes = append(changes, api.HardwareChange{
+				Component:  component,
+				Action:     api.HardwareChangeActionAdded,
+				Identifier: identifier,
+				Current:    currentDescription,
+			})
+
+		case previousDescription != currentDescription:
+			changes = append(changes, api.HardwareChange{
+				Component:  component,
+				Action:     api.HardwareChangeActionChanged,
+				Identifier: identifier,
+				Previous:   previousDescription,
+				Current:    currentDescription,
+			})
+		}
+	}
+
+	return changes
+}
+
+func sortedKeys[T any](previous map[string]T, current map[string]T) []string {
+	keys := make([]string, 0, len(previous)+len(current))
+	for key := range previous {
+		keys = append(keys, key)
+	}
+
+	for key := range current {
+		if _, ok := previous[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,176 @@
+package provisioning_test
+
+import (
+	"testing"
+
+	incusapi "github.com/lxc/incus/v7/shared/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestDiffHardware(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+
+	baseline := func(modify ...func(r *incusapi.Resources)) api.HardwareData {
+		resources := incusapi.Resources{
+			CPU: incusapi.ResourcesCPU{
+				Architecture: "x86_64",
+				Sockets: []incusapi.ResourcesCPUSocket{
+					{
+						Name:   "AMD EPYC 7302",
+						Socket: 0,
+						Cores:  make([]incusapi.ResourcesCPUCore, 16),
+					},
+				},
+			},
+			Memory: incusapi.ResourcesMemory{
+				Nodes: []incusapi.ResourcesMemoryNode{
+					{NUMANode: 0, Total: 64 * gib},
+					{NUMANode: 1, Total: 64 * gib},
+				},
+				Used:  20 * gib,
+				Total: 128 * gib,
+			},
+			Storage: incusapi.ResourcesStorage{
+				Disks: []incusapi.ResourcesStorageDisk{
+					{ID: "nvme0n1", Model: "Samsung SSD 970 EVO", Serial: "S1", Size: 1000 * 1000 * 1000 * 1000},
+					{ID: "nvme1n1", Model: "Samsung SSD 970 EVO", Serial: "S2", Size: 1000 * 1000 * 1000 * 1000},
+					{ID: "sda", Model: "USB Stick", Removable: true, Size: 16 * 1000 * 1000 * 1000},
+				},
+			},
+			Network: incusapi.ResourcesNetwork{
+				Cards: []incusapi.ResourcesNetworkCard{
+					{
+						Vendor:  "Intel Corporation",
+						Product: "Ethernet Controller X710",
+						Ports: []incusapi.ResourcesNetworkCardPort{
+							{ID: "enp1s0f0", Address: "00:11:22:33:44:55", LinkDetected: true},
+							{ID: "enp1s0f1", Address: "00:11:22:33:44:56", LinkDetected: true},
+						},
+					},
+				},
+			},
+		}
+
+		for _, fn := range modify {
+			fn(&resources)
+		}
+
+		return api.HardwareData{
+			Resources: resources,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		previous api.HardwareData
+		current  api.HardwareData
+
+		want api.HardwareChanges
+	}{
+		{
+			name:     "no changes",
+			previous: baseline(),
+			current:  baseline(),
+
+			want: nil,
+		},
+		{
+			name:     "no previous hardware data",
+			previous: api.HardwareData{},
+			current:  baseline(),
+
+			want: nil,
+		},
+		{
+			name:     "volatile properties and removable disks are ignored",
+			previous: baseline(),
+			current: baseline(func(r *incusapi.Resources) {
+				r.Memory.Used = 30 * gib
+				r.Memory.Nodes[0].Total = 64*gib - 100*1024*1024
+				r.Network.Cards[0].Ports[0].LinkDetected = false
+				r.Storage.Disks[0].FirmwareVersion = "2B2QEXE7"
+				r.Storage.Disks = append(r.Storage.Disks[:2], incusapi.ResourcesStorageDisk{ID: "sdb", Model: "Virtual Media", Removable: true})
+			}),
+
+			want: nil,
+		},
+		{
+			name:     "cpu changed",
+			previous: baseline(),
+			current: baseline(func(r *incusapi.Resources) {
+				r.CPU.Sockets[0].Name = "AMD EPYC 7402"
+				r.CPU.Sockets[0].Cores = make([]incusapi.ResourcesCPUCore, 24)
+				r.CPU.Sockets = append(r.CPU.Sockets, incusapi.ResourcesCPUSocket{
+					Name:   "AMD EPYC 7402",
+					Socket: 1,
+					Cores:  make([]incusapi.ResourcesCPUCore, 24),
+				})
+			}),
+
+			want: api.HardwareChanges{
+				{Component: api.HardwareComponentCPU, Action: api.HardwareChangeActionChanged, Identifier: "socket 0", Previous: "AMD EPYC 7302 (16 cores)", Current: "AMD EPYC 7402 (24 cores)"},
+				{Component: api.HardwareComponentCPU, Action: api.HardwareChangeActionAdded, Identifier: "socket 1", Current: "AMD EPYC 7402 (24 cores)"},
+			},
+		},
+		{
+			name:     "memory module removed",
+			previous: baseline(),
+			current: baseline(func(r *incusapi.Resources) {
+				r.Memory.Nodes[1].Total = 48 * gib
+				r.Memory.Total = 112 * gib
+			}),
+
+			want: api.HardwareChanges{
+				{Component: api.HardwareComponentMemory, Action: api.HardwareChangeActionChanged, Identifier: "numa node 1", Previous: "64.00GiB", Current: "48.00GiB"},
+			},
+		},
+		{
+			name: "memory changed without numa nodes",
+			previous: baseline(func(r *incusapi.Resources) {
+				r.Memory.Nodes = nil
+			}),
+			current: baseline(func(r *incusapi.Resources) {
+				r.Memory.Nodes = nil
+				r.Memory.Total = 96 * gib
+			}),
+
+			want: api.HardwareChanges{
+				{Component: api.HardwareComponentMemory, Action: api.HardwareChangeActionChanged, Identifier: "total", Previous: "128.00GiB", Current: "96.00GiB"},
+			},
+		},
+		{
+			name:     "disk swapped",
+			previous: baseline(),
+			current: baseline(func(r *incusapi.Resources) {
+				r.Storage.Disks[1] = incusapi.ResourcesStorageDisk{ID: "nvme1n1", Model: "Samsung SSD 990 PRO", Serial: "S3", Size: 2000 * 1000 * 1000 * 1000}
+			}),
+
+			want: api.HardwareChanges{
+				{Component: api.HardwareComponentDisk, Action: api.HardwareChangeActionRemoved, Identifier: "S2", Previous: "Samsung SSD 970 EVO (1.00TB)"},
+				{Component: api.HardwareComponentDisk, Action: api.HardwareChangeActionAdded, Identifier: "S3", Current: "Samsung SSD 990 PRO (2.00TB)"},
+			},
+		},
+		{
+			name:     "nic vanished",
+			previous: baseline(),
+			current: baseline(func(r *incusapi.Resources) {
+				r.Network.Cards[0].Ports = r.Network.Cards[0].Ports[:1]
+			}),
+
+			want: api.HardwareChanges{
+				{Component: api.HardwareComponentNIC, Action: api.HardwareChangeActionRemoved, Identifier: "00:11:22:33:44:56", Previous: "Intel Corporation Ethernet Controller X710"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := provisioning.DiffHardware(tc.previous, tc.current)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
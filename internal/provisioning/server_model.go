@@ -39,6 +39,7 @@ type Server struct {
 	OSData               api.OSData             `json:"os_data"`
 	VersionData          api.ServerVersionData  `json:"version_data"`
 	Channel              string                 `json:"channel"                db:"join=channels.name"`
+	Site                 *string                `json:"site"                   db:"leftjoin=sites.name"`
 	Status               api.ServerStatus       `json:"status"`
 	StatusDetail         api.ServerStatusDetail `json:"status_detail"`
 	Description          string                 `json:"description"`
@@ -48,6 +49,8 @@ type Server struct {
 	SystemUUID           *string                `json:"system_uuid"`
 	MachineID            *string                `json:"machine_id"`
 	BMCData              api.BMCData            `json:"bmc_data"               db:"marshal=json"`
+	ClusterJoin          api.ServerClusterJoin  `json:"cluster_join"           db:"marshal=json"`
+	BMCInstall           api.ServerBMCInstall   `json:"bmc_install"            db:"marshal=json"`
 	LastUpdated          time.Time              `json:"last_updated"           db:"update_timestamp"`
 	LastSeen             time.Time              `json:"last_seen"`
 	LastStatusUpdated    time.Time              `json:"last_status_updated"`
@@ -219,6 +222,7 @@ type ServerFilter struct {
 	ID           *int
 	Name         *string
 	Cluster      *string
+	Site         *string
 	Status       *api.ServerStatus
 	StatusDetail *api.ServerStatusDetail
 	Certificate  *string
@@ -231,6 +235,7 @@ type ServerFilter struct {
 func (f ServerFilter) IsEmpty() bool {
 	return f.Name == nil &&
 		f.Cluster == nil &&
+		f.Site == nil &&
 		f.Status == nil &&
 		f.StatusDetail == nil &&
 		f.Certificate == nil &&
@@ -242,6 +247,10 @@ func (f ServerFilter) AppendToURLValues(query url.Values) url.Values {
 		query.Add("cluster", *f.Cluster)
 	}
 
+	if f.Site != nil {
+		query.Add("site", *f.Site)
+	}
+
 	if f.Status != nil {
 		query.Add("status", string(*f.Status))
 	}
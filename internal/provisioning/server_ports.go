@@ -2,6 +2,8 @@ package provisioning
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,6 +13,8 @@ import (
 
 type ServerService interface {
 	SetClusterService(clusterSvc ClusterService)
+	SetSiteService(siteSvc SiteService)
+	SetRolloutService(rolloutSvc RolloutService)
 	PreRegister(ctx context.Context, server Server) (Server, error)
 	Register(ctx context.Context, token uuid.UUID, server Server) (Server, error)
 	GetAll(ctx context.Context) (Servers, error)
@@ -31,11 +35,20 @@ type ServerService interface {
 	DeleteByName(ctx context.Context, name string) error
 	ResyncByName(ctx context.Context, clusterName string, event domain.LifecycleEvent) error
 	GetChangelogByName(ctx context.Context, name string) (api.UpdateChangelog, error)
+	GetHardwareHistoryByName(ctx context.Context, name string) (ServerHardwareSnapshots, error)
+	GetDiskHealthWithFilter(ctx context.Context, filter ServerFilter) ([]api.ServerDiskHealth, error)
+	GetDiskHealthByName(ctx context.Context, name string) ([]api.ServerDiskHealth, error)
+	DecommissionByName(ctx context.Context, name string, options api.ServerDecommissionOptions, confirmDiskWipe string, resume bool) (*ServerDecommission, error)
+	GetDecommissions(ctx context.Context) (ServerDecommissions, error)
+	GetDecommissionsByName(ctx context.Context, name string) (ServerDecommissions, error)
+	GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (*ServerDecommission, error)
 	SyncCluster(ctx context.Context, clusterName string) error
 
 	PollServers(ctx context.Context, serverFilter ServerFilter, updateServerConfiguration bool) error
 	PollServer(ctx context.Context, server Server, updateServerConfiguration bool) error
 	ResyncBMCData(ctx context.Context) error
+	Prune(ctx context.Context) error
+	ClusterJoinControlLoop(ctx context.Context) error
 
 	EvacuateSystemByName(ctx context.Context, name string, clusterUpdate bool, force bool) error
 	PoweroffSystemByName(ctx context.Context, name string, force bool) error
@@ -51,6 +64,7 @@ type ServerService interface {
 	UpdateSystemKernel(ctx context.Context, name string, kernelConfig ServerSystemKernel) error
 	AddApplication(ctx context.Context, name string, applicationName string) error
 	RestartApplication(ctx context.Context, name string, applicationName string) error
+	BulkAction(ctx context.Context, action ServerBulkAction) (ServerBulkActionResults, error)
 
 	BMCRefreshByName(ctx context.Context, name string) error
 	BMCServerPowerOnByName(ctx context.Context, name string, force bool) error
@@ -63,6 +77,11 @@ type ServerService interface {
 	ApplyBIOSAttributesByName(ctx context.Context, name string, attributes map[string]any) error
 	BMCBIOSAttributesByName(ctx context.Context, name string) ([]api.BIOSAttribute, error)
 	BMCBIOSAttributeByName(ctx context.Context, name string, attributeName string) (api.BIOSAttribute, error)
+	BMCInstallByName(ctx context.Context, name string, install api.ServerBMCInstallPost) error
+	GetBMCInstallMediaByName(ctx context.Context, name string, id uuid.UUID) (io.ReadSeekCloser, error)
+	PruneBMCInstallMedia(ctx context.Context) error
+	BMCFirmwareByName(ctx context.Context, name string) (map[string]api.BMCFirmware, error)
+	BMCFirmwareUpdateByName(ctx context.Context, name string, update api.BMCFirmwareUpdatePost, clusterUpdate bool) error
 }
 
 type ServerRepo interface {
@@ -78,6 +97,17 @@ type ServerRepo interface {
 	Update(ctx context.Context, server Server) error
 	Rename(ctx context.Context, oldName string, newName string) error
 	DeleteByName(ctx context.Context, name string) error
+	CreateHardwareSnapshot(ctx context.Context, snapshot ServerHardwareSnapshot) (int64, error)
+	GetHardwareSnapshotsByName(ctx context.Context, name string) (ServerHardwareSnapshots, error)
+	DeleteHardwareSnapshotsExceptLatestByName(ctx context.Context, name string, keep int) error
+	CreateDiskHealthSample(ctx context.Context, sample ServerDiskHealthSample) (int64, error)
+	GetDiskHealthSamplesByName(ctx context.Context, name string) (ServerDiskHealthSamples, error)
+	DeleteDiskHealthSamplesRecordedBeforeByName(ctx context.Context, name string, before time.Time) error
+	CreateDecommission(ctx context.Context, decommission ServerDecommission) (int64, error)
+	UpdateDecommission(ctx context.Context, decommission ServerDecommission) error
+	GetDecommissions(ctx context.Context) (ServerDecommissions, error)
+	GetDecommissionsByName(ctx context.Context, name string) (ServerDecommissions, error)
+	GetDecommissionByUUID(ctx context.Context, id uuid.UUID) (*ServerDecommission, error)
 }
 
 type ServerClientPort interface {
@@ -127,4 +157,11 @@ type BMCServerClientPort interface {
 	ApplyBIOSAttributes(ctx context.Context, server Server, attributes map[string]any) (*BMCTaskMonitor, error)
 	BIOSAttributes(ctx context.Context, server Server) ([]api.BIOSAttribute, error)
 	BIOSAttribute(ctx context.Context, server Server, attributeName string) (api.BIOSAttribute, error)
+	SecureEraseDrives(ctx context.Context, server Server) (map[string]*BMCTaskMonitor, error)
+	InsertVirtualMedia(ctx context.Context, server Server, virtualMediaID string, imageURL string) (insertedVirtualMediaID string, _ *BMCTaskMonitor, _ error)
+	EjectVirtualMedia(ctx context.Context, server Server, virtualMediaID string) error
+	SetOneTimeBootFromVirtualMedia(ctx context.Context, server Server) error
+	FirmwareInventory(ctx context.Context, server Server) (map[string]api.BMCFirmware, error)
+	UpdateFirmwareFromURI(ctx context.Context, server Server, imageURI string, targets []string) (*BMCTaskMonitor, error)
+	UpdateFirmwareFromFile(ctx context.Context, server Server, imageFilename string, targets []string) (*BMCTaskMonitor, error)
 }
@@ -0,0 +1,195 @@
+package site
+
+import (
+	"context"
+	"fmt"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/internal/sql/transaction"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type siteService struct {
+	repo       provisioning.SiteRepo
+	serverSvc  provisioning.ServerService
+	clusterSvc provisioning.ClusterService
+	warnings   provisioning.SiteWarningPort
+}
+
+var _ provisioning.SiteService = &siteService{}
+
+func New(repo provisioning.SiteRepo, serverSvc provisioning.ServerService, warnings provisioning.SiteWarningPort) *siteService {
+	return &siteService{
+		repo:      repo,
+		serverSvc: serverSvc,
+		warnings:  warnings,
+	}
+}
+
+func (s *siteService) SetClusterService(clusterSvc provisioning.ClusterService) {
+	s.clusterSvc = clusterSvc
+}
+
+func (s *siteService) Create(ctx context.Context, newSite provisioning.Site) (provisioning.Site, error) {
+	if newSite.Channel == "" {
+		newSite.Channel = config.GetUpdates().ServerDefaultChannel
+	}
+
+	err := newSite.Validate()
+	if err != nil {
+		return provisioning.Site{}, err
+	}
+
+	newSite.ID, err = s.repo.Create(ctx, newSite)
+	if err != nil {
+		return provisioning.Site{}, err
+	}
+
+	return newSite, nil
+}
+
+func (s *siteService) GetAll(ctx context.Context) (provisioning.Sites, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *siteService) GetAllNames(ctx context.Context) ([]string, error) {
+	return s.repo.GetAllNames(ctx)
+}
+
+func (s *siteService) GetByName(ctx context.Context, name string) (*provisioning.Site, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Site name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return s.repo.GetByName(ctx, name)
+}
+
+// GetMembersByName returns the names of the clusters and the servers, which
+// belong to the site. Clustered servers belong to the site of their cluster.
+func (s *siteService) GetMembersByName(ctx context.Context, name string) (provisioning.SiteMembers, error) {
+	if name == "" {
+		return provisioning.SiteMembers{}, fmt.Errorf("Site name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	_, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return provisioning.SiteMembers{}, fmt.Errorf("Failed to get site %q: %w", name, err)
+	}
+
+	clusterNames, err := s.clusterSvc.GetAllNamesWithFilter(ctx, provisioning.ClusterFilter{
+		Site: &name,
+	})
+	if err != nil {
+		return provisioning.SiteMembers{}, fmt.Errorf("Failed to get clusters of site %q: %w", name, err)
+	}
+
+	serverNames, err := s.serverSvc.GetAllNamesWithFilter(ctx, provisioning.ServerFilter{
+		Site: &name,
+	})
+	if err != nil {
+		return provisioning.SiteMembers{}, fmt.Errorf("Failed to get servers of site %q: %w", name, err)
+	}
+
+	return provisioning.SiteMembers{
+		Clusters: clusterNames,
+		Servers:  serverNames,
+	}, nil
+}
+
+// GetStatusByName aggregates the health and update status over the clusters
+// and servers of the site.
+func (s *siteService) GetStatusByName(ctx context.Context, name string) (api.SiteStatus, error) {
+	members, err := s.GetMembersByName(ctx, name)
+	if err != nil {
+		return api.SiteStatus{}, err
+	}
+
+	servers, err := s.serverSvc.GetAllWithFilter(ctx, provisioning.ServerFilter{
+		Site: &name,
+	})
+	if err != nil {
+		return api.SiteStatus{}, fmt.Errorf("Failed to get servers of site %q: %w", name, err)
+	}
+
+	status := api.SiteStatus{
+		Clusters: len(members.Clusters),
+		Servers:  len(servers),
+	}
+
+	for _, server := range servers {
+		if server.VersionData.NeedsUpdate != nil && *server.VersionData.NeedsUpdate {
+			status.ServersNeedingUpdate++
+		}
+
+		if server.Status == api.ServerStatusOffline {
+			status.ServersOffline++
+		}
+	}
+
+	warnings, err := s.warnings.GetAll(ctx)
+	if err != nil {
+		return api.SiteStatus{}, fmt.Errorf("Failed to get warnings: %w", err)
+	}
+
+	entities := map[string]map[string]struct{}{
+		"cluster": toSet(members.Clusters),
+		"server":  toSet(members.Servers),
+	}
+
+	for _, warning := range warnings {
+		if warning.Status != api.WarningStatusNew {
+			continue
+		}
+
+		_, ok := entities[warning.EntityType][warning.Entity]
+		if ok {
+			status.OpenWarnings++
+		}
+	}
+
+	return status, nil
+}
+
+func (s *siteService) Update(ctx context.Context, newSite provisioning.Site) error {
+	err := newSite.Validate()
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, newSite)
+}
+
+func (s *siteService) DeleteByName(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("Site name cannot be empty: %w", domain.ErrOperationNotPermitted)
+	}
+
+	return transaction.Do(ctx, func(ctx context.Context) error {
+		members, err := s.GetMembersByName(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if len(members.Clusters) > 0 || len(members.Servers) > 0 {
+			return fmt.Errorf("Delete of site not supported, if in use by any cluster or server: %w", domain.ErrOperationNotPermitted)
+		}
+
+		err = s.repo.DeleteByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Failed to delete site: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
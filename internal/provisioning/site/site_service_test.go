@@ -0,0 +1,526 @@
+package site_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	config "github.com/FuturFusion/operations-center/internal/config/daemon"
+	"github.com/FuturFusion/operations-center/internal/domain"
+	envMock "github.com/FuturFusion/operations-center/internal/environment/mock"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	svcMock "github.com/FuturFusion/operations-center/internal/provisioning/mock"
+	repoMock "github.com/FuturFusion/operations-center/internal/provisioning/repo/mock"
+	provisioningSite "github.com/FuturFusion/operations-center/internal/provisioning/site"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
+	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
+	"github.com/FuturFusion/operations-center/internal/warning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestSiteService_Create(t *testing.T) {
+	config.InitTest(t, &envMock.EnvironmentMock{}, nil)
+
+	tests := []struct {
+		name              string
+		site              provisioning.Site
+		repoCreateSiteErr error
+
+		assertErr   require.ErrorAssertionFunc
+		wantChannel string
+	}{
+		{
+			name: "success",
+			site: provisioning.Site{
+				Name:    "A",
+				Channel: "lts",
+			},
+
+			assertErr:   require.NoError,
+			wantChannel: "lts",
+		},
+		{
+			name: "success - default channel",
+			site: provisioning.Site{
+				Name: "A",
+			},
+
+			assertErr:   require.NoError,
+			wantChannel: "stable",
+		},
+		{
+			name: "error - validation",
+			site: provisioning.Site{
+				Name: "", // invalid
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - repo.Create",
+			site: provisioning.Site{
+				Name:    "A",
+				Channel: "stable",
+			},
+			repoCreateSiteErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.SiteRepoMock{
+				CreateFunc: func(ctx context.Context, newSite provisioning.Site) (int64, error) {
+					return 1, tc.repoCreateSiteErr
+				},
+			}
+
+			siteSvc := provisioningSite.New(repo, nil, nil)
+
+			// Run test
+			site, err := siteSvc.Create(t.Context(), tc.site)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantChannel, site.Channel)
+		})
+	}
+}
+
+func TestSiteService_GetAll(t *testing.T) {
+	tests := []struct {
+		name               string
+		repoGetAllSites    provisioning.Sites
+		repoGetAllSitesErr error
+
+		assertErr require.ErrorAssertionFunc
+		count     int
+	}{
+		{
+			name: "success",
+			repoGetAllSites: provisioning.Sites{
+				provisioning.Site{
+					Name: "A",
+				},
+				provisioning.Site{
+					Name: "B",
+				},
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
+		{
+			name:               "error - repo",
+			repoGetAllSitesErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+			count:     0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.SiteRepoMock{
+				GetAllFunc: func(ctx context.Context) (provisioning.Sites, error) {
+					return tc.repoGetAllSites, tc.repoGetAllSitesErr
+				},
+			}
+
+			siteSvc := provisioningSite.New(repo, nil, nil)
+
+			// Run test
+			sites, err := siteSvc.GetAll(t.Context())
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Len(t, sites, tc.count)
+		})
+	}
+}
+
+func TestSiteService_GetByName(t *testing.T) {
+	tests := []struct {
+		name                 string
+		nameArg              string
+		repoGetSiteByName    *provisioning.Site
+		repoGetSiteByNameErr error
+
+		assertErr require.ErrorAssertionFunc
+		wantSite  *provisioning.Site
+	}{
+		{
+			name:    "success",
+			nameArg: "A",
+			repoGetSiteByName: &provisioning.Site{
+				Name: "A",
+			},
+
+			assertErr: require.NoError,
+			wantSite: &provisioning.Site{
+				Name: "A",
+			},
+		},
+		{
+			name:    "error - name empty",
+			nameArg: "", // invalid
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                 "error - repo",
+			nameArg:              "A",
+			repoGetSiteByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.SiteRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Site, error) {
+					return tc.repoGetSiteByName, tc.repoGetSiteByNameErr
+				},
+			}
+
+			siteSvc := provisioningSite.New(repo, nil, nil)
+
+			// Run test
+			site, err := siteSvc.GetByName(t.Context(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantSite, site)
+		})
+	}
+}
+
+func TestSiteService_GetStatusByName(t *testing.T) {
+	tests := []struct {
+		name                               string
+		repoGetSiteByNameErr               error
+		clusterSvcGetAllNamesWithFilter    []string
+		clusterSvcGetAllNamesWithFilterErr error
+		serverSvcGetAllNamesWithFilter     []string
+		serverSvcGetAllNamesWithFilterErr  error
+		serverSvcGetAllWithFilter          provisioning.Servers
+		serverSvcGetAllWithFilterErr       error
+		warningsGetAll                     warning.Warnings
+		warningsGetAllErr                  error
+
+		assertErr  require.ErrorAssertionFunc
+		wantStatus api.SiteStatus
+	}{
+		{
+			name:                            "success",
+			clusterSvcGetAllNamesWithFilter: []string{"cluster-one"},
+			serverSvcGetAllNamesWithFilter:  []string{"server-one", "server-two", "server-three"},
+			serverSvcGetAllWithFilter: provisioning.Servers{
+				{
+					Name:   "server-one",
+					Status: api.ServerStatusReady,
+					VersionData: api.ServerVersionData{
+						NeedsUpdate: ptr.To(true),
+					},
+				},
+				{
+					Name:   "server-two",
+					Status: api.ServerStatusOffline,
+					VersionData: api.ServerVersionData{
+						NeedsUpdate: ptr.To(false),
+					},
+				},
+				{
+					Name:   "server-three",
+					Status: api.ServerStatusReady,
+				},
+			},
+			warningsGetAll: warning.Warnings{
+				{
+					EntityType: "cluster",
+					Entity:     "cluster-one",
+					Status:     api.WarningStatusNew,
+				},
+				{
+					EntityType: "server",
+					Entity:     "server-two",
+					Status:     api.WarningStatusNew,
+				},
+				{
+					EntityType: "server",
+					Entity:     "server-three",
+					Status:     api.WarningStatusAcknowledged, // not open
+				},
+				{
+					EntityType: "server",
+					Entity:     "other", // other site
+					Status:     api.WarningStatusNew,
+				},
+				{
+					EntityType: "update",
+					Entity:     "cluster-one", // different entity type
+					Status:     api.WarningStatusNew,
+				},
+			},
+
+			assertErr: require.NoError,
+			wantStatus: api.SiteStatus{
+				Clusters:             1,
+				Servers:              3,
+				ServersNeedingUpdate: 1,
+				ServersOffline:       1,
+				OpenWarnings:         2,
+			},
+		},
+		{
+			name:                 "error - repo.GetByName",
+			repoGetSiteByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                               "error - clusterSvc.GetAllNamesWithFilter",
+			clusterSvcGetAllNamesWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                              "error - serverSvc.GetAllNamesWithFilter",
+			serverSvcGetAllNamesWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                         "error - serverSvc.GetAllWithFilter",
+			serverSvcGetAllWithFilterErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:              "error - warnings.GetAll",
+			warningsGetAllErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.SiteRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Site, error) {
+					return &provisioning.Site{Name: name}, tc.repoGetSiteByNameErr
+				},
+			}
+
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetAllNamesWithFilterFunc: func(ctx context.Context, filter provisioning.ClusterFilter) ([]string, error) {
+					require.Equal(t, "A", *filter.Site)
+					return tc.clusterSvcGetAllNamesWithFilter, tc.clusterSvcGetAllNamesWithFilterErr
+				},
+			}
+
+			serverSvc := &svcMock.ServerServiceMock{
+				GetAllNamesWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) ([]string, error) {
+					require.Equal(t, "A", *filter.Site)
+					return tc.serverSvcGetAllNamesWithFilter, tc.serverSvcGetAllNamesWithFilterErr
+				},
+				GetAllWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) (provisioning.Servers, error) {
+					require.Equal(t, "A", *filter.Site)
+					return tc.serverSvcGetAllWithFilter, tc.serverSvcGetAllWithFilterErr
+				},
+			}
+
+			warnings := &siteWarningMock{
+				getAll: func(ctx context.Context) (warning.Warnings, error) {
+					return tc.warningsGetAll, tc.warningsGetAllErr
+				},
+			}
+
+			siteSvc := provisioningSite.New(repo, serverSvc, warnings)
+			siteSvc.SetClusterService(clusterSvc)
+
+			// Run test
+			status, err := siteSvc.GetStatusByName(t.Context(), "A")
+
+			// Assert
+			tc.assertErr(t, err)
+			require.Equal(t, tc.wantStatus, status)
+		})
+	}
+}
+
+func TestSiteService_Update(t *testing.T) {
+	tests := []struct {
+		name              string
+		site              provisioning.Site
+		repoUpdateSiteErr error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			site: provisioning.Site{
+				Name:    "A",
+				Channel: "stable",
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - validation",
+			site: provisioning.Site{
+				Name:    "A",
+				Channel: "", // invalid
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - repo.Update",
+			site: provisioning.Site{
+				Name:    "A",
+				Channel: "stable",
+			},
+			repoUpdateSiteErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.SiteRepoMock{
+				UpdateFunc: func(ctx context.Context, newSite provisioning.Site) error {
+					return tc.repoUpdateSiteErr
+				},
+			}
+
+			siteSvc := provisioningSite.New(repo, nil, nil)
+
+			// Run test
+			err := siteSvc.Update(t.Context(), tc.site)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestSiteService_DeleteByName(t *testing.T) {
+	tests := []struct {
+		name                            string
+		nameArg                         string
+		clusterSvcGetAllNamesWithFilter []string
+		serverSvcGetAllNamesWithFilter  []string
+		serverSvcGetAllNamesErr         error
+		repoDeleteSiteByNameErr         error
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			nameArg: "A",
+
+			assertErr: require.NoError,
+		},
+		{
+			name:    "error - empty name",
+			nameArg: "",
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                            "error - site in use by cluster",
+			nameArg:                         "A",
+			clusterSvcGetAllNamesWithFilter: []string{"cluster"},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                           "error - site in use by server",
+			nameArg:                        "A",
+			serverSvcGetAllNamesWithFilter: []string{"server"},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				require.ErrorIs(tt, err, domain.ErrOperationNotPermitted, a...)
+			},
+		},
+		{
+			name:                    "error - serverSvc.GetAllNamesWithFilter",
+			nameArg:                 "A",
+			serverSvcGetAllNamesErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+		{
+			name:                    "error - repo",
+			nameArg:                 "A",
+			repoDeleteSiteByNameErr: boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			repo := &repoMock.SiteRepoMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Site, error) {
+					return &provisioning.Site{Name: name}, nil
+				},
+				DeleteByNameFunc: func(ctx context.Context, name string) error {
+					return tc.repoDeleteSiteByNameErr
+				},
+			}
+
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetAllNamesWithFilterFunc: func(ctx context.Context, filter provisioning.ClusterFilter) ([]string, error) {
+					return tc.clusterSvcGetAllNamesWithFilter, nil
+				},
+			}
+
+			serverSvc := &svcMock.ServerServiceMock{
+				GetAllNamesWithFilterFunc: func(ctx context.Context, filter provisioning.ServerFilter) ([]string, error) {
+					return tc.serverSvcGetAllNamesWithFilter, tc.serverSvcGetAllNamesErr
+				},
+			}
+
+			siteSvc := provisioningSite.New(repo, serverSvc, nil)
+			siteSvc.SetClusterService(clusterSvc)
+
+			// Run test
+			err := siteSvc.DeleteByName(t.Context(), tc.nameArg)
+
+			// Assert
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+type siteWarningMock struct {
+	getAll func(ctx context.Context) (warning.Warnings, error)
+}
+
+func (m *siteWarningMock) GetAll(ctx context.Context) (warning.Warnings, error) {
+	return m.getAll(ctx)
+}
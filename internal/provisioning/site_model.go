@@ -0,0 +1,58 @@
+package provisioning
+
+import (
+	"strings"
+	"time"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type Site struct {
+	ID          int64
+	Name        string `db:"primary=yes"`
+	Description string
+	Channel     string `db:"join=channels.name"`
+	Properties  api.ConfigMap
+	Config      api.SiteConfig
+	LastUpdated time.Time `db:"update_timestamp"`
+}
+
+func (s Site) Validate() error {
+	if s.Name == "" {
+		return domain.NewValidationErrf("Invalid site, name can not be empty")
+	}
+
+	if strings.ContainsAny(s.Name, nameProhibitedCharacters) {
+		return domain.NewValidationErrf("Invalid site, name can not contain any of %q", nameProhibitedCharacters)
+	}
+
+	if s.Channel == "" {
+		return domain.NewValidationErrf("Invalid site, channel can not be empty")
+	}
+
+	for i, window := range s.Config.MaintenanceWindows {
+		err := validateMaintenanceWindow(window)
+		if err != nil {
+			return domain.NewValidationErrf("Invalid site, site config maintenance window %d is invalid: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+// InMaintenanceWindow returns true, if t is within one of the maintenance
+// windows of the site. A site without maintenance windows is always
+// considered to be within its maintenance window.
+func (s Site) InMaintenanceWindow(t time.Time) bool {
+	return inMaintenanceWindows(s.Config.MaintenanceWindows, t)
+}
+
+type Sites []Site
+
+// SiteMembers holds the names of the clusters and servers, which belong to a
+// site.
+type SiteMembers struct {
+	Clusters []string
+	Servers  []string
+}
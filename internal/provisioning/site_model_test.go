@@ -0,0 +1,165 @@
+package provisioning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/FuturFusion/operations-center/internal/domain"
+	"github.com/FuturFusion/operations-center/internal/provisioning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+func TestSite_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		site provisioning.Site
+
+		assertErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "valid",
+			site: provisioning.Site{
+				Name:    "one",
+				Channel: "stable",
+				Config: api.SiteConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							Weekdays:  []string{"saturday"},
+							StartTime: "22:00",
+							EndTime:   "04:00",
+							Timezone:  "UTC",
+						},
+					},
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - empty name",
+			site: provisioning.Site{
+				Name:    "", // invalid
+				Channel: "stable",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid name",
+			site: provisioning.Site{
+				Name:    "one/two", // invalid
+				Channel: "stable",
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - empty channel",
+			site: provisioning.Site{
+				Name:    "one",
+				Channel: "", // invalid
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - invalid maintenance window",
+			site: provisioning.Site{
+				Name:    "one",
+				Channel: "stable",
+				Config: api.SiteConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "22:00",
+							EndTime:   "22:00", // invalid
+							Timezone:  "UTC",
+						},
+					},
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.site.Validate()
+
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestSite_InMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		site provisioning.Site
+		now  time.Time
+
+		want bool
+	}{
+		{
+			name: "no maintenance windows",
+			now:  time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+
+			want: true,
+		},
+		{
+			name: "within maintenance window",
+			site: provisioning.Site{
+				Config: api.SiteConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "10:00",
+							EndTime:   "14:00",
+							Timezone:  "UTC",
+						},
+					},
+				},
+			},
+			now: time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+
+			want: true,
+		},
+		{
+			name: "outside maintenance window",
+			site: provisioning.Site{
+				Config: api.SiteConfig{
+					MaintenanceWindows: []api.ClusterConfigMaintenanceWindow{
+						{
+							StartTime: "10:00",
+							EndTime:   "14:00",
+							Timezone:  "UTC",
+						},
+					},
+				},
+			},
+			now: time.Date(2025, 1, 4, 16, 0, 0, 0, time.UTC),
+
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.site.InMaintenanceWindow(tc.now)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/FuturFusion/operations-center/internal/warning"
+	"github.com/FuturFusion/operations-center/shared/api"
+)
+
+type SiteService interface {
+	SetClusterService(clusterSvc ClusterService)
+
+	Create(ctx context.Context, newSite Site) (Site, error)
+	GetAll(ctx context.Context) (Sites, error)
+	GetAllNames(ctx context.Context) ([]string, error)
+	GetByName(ctx context.Context, name string) (*Site, error)
+	GetMembersByName(ctx context.Context, name string) (SiteMembers, error)
+	GetStatusByName(ctx context.Context, name string) (api.SiteStatus, error)
+	Update(ctx context.Context, newSite Site) error
+	DeleteByName(ctx context.Context, name string) error
+}
+
+type SiteRepo interface {
+	Create(ctx context.Context, newSite Site) (int64, error)
+	GetAll(ctx context.Context) (Sites, error)
+	GetAllNames(ctx context.Context) ([]string, error)
+	GetByName(ctx context.Context, name string) (*Site, error)
+	Update(ctx context.Context, newSite Site) error
+	DeleteByName(ctx context.Context, name string) error
+}
+
+// SiteWarningPort provides access to the warnings, which are considered for
+// the aggregated status of a site.
+type SiteWarningPort interface {
+	GetAll(ctx context.Context) (warning.Warnings, error)
+}
@@ -26,6 +26,7 @@ type tokenService struct {
 	channelSvc provisioning.ChannelService
 	flasher    provisioning.FlasherPort
 	client     provisioning.TokenClientPort
+	clusterSvc provisioning.ClusterService
 
 	randomUUID func() (uuid.UUID, error)
 
@@ -56,6 +57,10 @@ func New(repo provisioning.TokenRepo, updateSvc provisioning.UpdateService, chan
 	return tokenSvc
 }
 
+func (s *tokenService) SetClusterService(clusterSvc provisioning.ClusterService) {
+	s.clusterSvc = clusterSvc
+}
+
 func (s *tokenService) Create(ctx context.Context, newToken provisioning.Token) (provisioning.Token, error) {
 	var err error
 	newToken.UUID, err = s.randomUUID()
@@ -72,6 +77,11 @@ func (s *tokenService) Create(ctx context.Context, newToken provisioning.Token)
 		return provisioning.Token{}, fmt.Errorf("Validation failed for new token: %w", err)
 	}
 
+	err = s.validateClusterJoin(ctx, newToken)
+	if err != nil {
+		return provisioning.Token{}, fmt.Errorf("Validation failed for new token: %w", err)
+	}
+
 	newToken.ID, err = s.repo.Create(ctx, newToken)
 	if err != nil {
 		return provisioning.Token{}, fmt.Errorf("Failed to create token: %w", err)
@@ -98,16 +108,44 @@ func (s *tokenService) Update(ctx context.Context, newToken provisioning.Token)
 		return fmt.Errorf("Validation failed for token update: %w", err)
 	}
 
+	err = s.validateClusterJoin(ctx, newToken)
+	if err != nil {
+		return fmt.Errorf("Validation failed for token update: %w", err)
+	}
+
 	return s.repo.Update(ctx, newToken)
 }
 
+// validateClusterJoin ensures, that the cluster, servers registered with the
+// token join automatically, does exist.
+func (s *tokenService) validateClusterJoin(ctx context.Context, token provisioning.Token) error {
+	if token.ClusterJoin.Cluster == "" {
+		return nil
+	}
+
+	_, err := s.clusterSvc.GetByName(ctx, token.ClusterJoin.Cluster)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.NewValidationErrf("Cluster %q defined for cluster join does not exist", token.ClusterJoin.Cluster)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster %q defined for cluster join: %w", token.ClusterJoin.Cluster, err)
+	}
+
+	return nil
+}
+
 func (s *tokenService) DeleteByUUID(ctx context.Context, id uuid.UUID) error {
 	return s.repo.DeleteByUUID(ctx, id)
 }
 
-func (s *tokenService) Consume(ctx context.Context, id uuid.UUID) (channel string, _ error) {
+func (s *tokenService) Consume(ctx context.Context, id uuid.UUID) (*provisioning.Token, error) {
+	var token *provisioning.Token
+
 	err := transaction.Do(ctx, func(ctx context.Context) error {
-		token, err := s.repo.GetByUUID(ctx, id)
+		var err error
+
+		token, err = s.repo.GetByUUID(ctx, id)
 		if err != nil {
 			if errors.Is(err, domain.ErrNotFound) {
 				return fmt.Errorf("Consume token: %w", domain.ErrNotAuthorized)
@@ -136,15 +174,13 @@ func (s *tokenService) Consume(ctx context.Context, id uuid.UUID) (channel strin
 			return fmt.Errorf("Update token %s: %w", id.String(), err)
 		}
 
-		channel = token.Channel
-
 		return nil
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return channel, nil
+	return token, nil
 }
 
 type imageRecord struct {
@@ -21,6 +21,7 @@ import (
 	svcMock "github.com/FuturFusion/operations-center/internal/provisioning/mock"
 	"github.com/FuturFusion/operations-center/internal/provisioning/repo/mock"
 	provisioningToken "github.com/FuturFusion/operations-center/internal/provisioning/token"
+	"github.com/FuturFusion/operations-center/internal/util/ptr"
 	"github.com/FuturFusion/operations-center/internal/util/testing/boom"
 	"github.com/FuturFusion/operations-center/internal/util/testing/errassert"
 	"github.com/FuturFusion/operations-center/internal/util/testing/uuidgen"
@@ -38,6 +39,7 @@ func TestTokenService_Create(t *testing.T) {
 		token           provisioning.Token
 		randomUUIDValue uuid.UUID
 		randomUUIDErr   error
+		clusterSvcErr   error
 		repoCreateErr   error
 
 		assertErr   require.ErrorAssertionFunc
@@ -67,6 +69,21 @@ func TestTokenService_Create(t *testing.T) {
 			assertErr:   require.NoError,
 			wantChannel: "stable",
 		},
+		{
+			name: "success - with cluster join",
+			token: provisioning.Token{
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Channel:       "testing",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster: "cluster01",
+				},
+			},
+			randomUUIDValue: uuidA,
+
+			assertErr:   require.NoError,
+			wantChannel: "testing",
+		},
 		{
 			name: "error - random uuid",
 			token: provisioning.Token{
@@ -89,6 +106,39 @@ func TestTokenService_Create(t *testing.T) {
 				require.ErrorAs(tt, err, &verr, a...)
 			},
 		},
+		{
+			name: "error - cluster join with not existing cluster",
+			token: provisioning.Token{
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Channel:       "testing",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster: "cluster01",
+				},
+			},
+			randomUUIDValue: uuidA,
+			clusterSvcErr:   domain.ErrNotFound,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
+		{
+			name: "error - clusterSvc.GetByName",
+			token: provisioning.Token{
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Channel:       "testing",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster: "cluster01",
+				},
+			},
+			randomUUIDValue: uuidA,
+			clusterSvcErr:   boom.Error,
+
+			assertErr: boom.ErrorIs,
+		},
 		{
 			name: "error - repo.Create",
 			token: provisioning.Token{
@@ -115,10 +165,18 @@ func TestTokenService_Create(t *testing.T) {
 				},
 			}
 
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					require.Equal(t, "cluster01", name)
+					return &provisioning.Cluster{Name: name}, tc.clusterSvcErr
+				},
+			}
+
 			tokenSvc := provisioningToken.New(
 				repo, nil, nil, nil, nil,
 				provisioningToken.WithRandomUUID(func() (uuid.UUID, error) { return tc.randomUUIDValue, tc.randomUUIDErr }),
 			)
+			tokenSvc.SetClusterService(clusterSvc)
 
 			// Run test
 			_, err := tokenSvc.Create(context.Background(), tc.token)
@@ -292,6 +350,7 @@ func TestTokenService_Update(t *testing.T) {
 	tests := []struct {
 		name          string
 		token         provisioning.Token
+		clusterSvcErr error
 		repoUpdateErr error
 
 		assertErr require.ErrorAssertionFunc
@@ -308,6 +367,40 @@ func TestTokenService_Update(t *testing.T) {
 
 			assertErr: require.NoError,
 		},
+		{
+			name: "success - with cluster join",
+			token: provisioning.Token{
+				UUID:          uuidA,
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Description:   "A",
+				Channel:       "stable",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster: "cluster01",
+				},
+			},
+
+			assertErr: require.NoError,
+		},
+		{
+			name: "error - cluster join with not existing cluster",
+			token: provisioning.Token{
+				UUID:          uuidA,
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Description:   "A",
+				Channel:       "stable",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster: "cluster01",
+				},
+			},
+			clusterSvcErr: domain.ErrNotFound,
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
 		{
 			name: "error - invalid value for uses remaining",
 			token: provisioning.Token{
@@ -347,7 +440,15 @@ func TestTokenService_Update(t *testing.T) {
 				},
 			}
 
+			clusterSvc := &svcMock.ClusterServiceMock{
+				GetByNameFunc: func(ctx context.Context, name string) (*provisioning.Cluster, error) {
+					require.Equal(t, "cluster01", name)
+					return &provisioning.Cluster{Name: name}, tc.clusterSvcErr
+				},
+			}
+
 			tokenSvc := provisioningToken.New(repo, nil, nil, nil, nil)
+			tokenSvc.SetClusterService(clusterSvc)
 
 			// Run test
 			err := tokenSvc.Update(context.Background(), tc.token)
@@ -416,6 +517,7 @@ func TestTokenService_Consume(t *testing.T) {
 		assertErr       require.ErrorAssertionFunc
 		wantUsesRemaing int
 		wantChannel     string
+		wantClusterJoin api.TokenClusterJoin
 	}{
 		{
 			name:     "success",
@@ -433,6 +535,30 @@ func TestTokenService_Consume(t *testing.T) {
 			wantUsesRemaing: 9,
 			wantChannel:     "testing",
 		},
+		{
+			name:     "success - with cluster join",
+			tokenArg: token,
+
+			repoGetByUUIDToken: &provisioning.Token{
+				ID:            1,
+				UUID:          token,
+				UsesRemaining: 10,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Channel:       "testing",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster:            "cluster01",
+					CopyServicesConfig: true,
+				},
+			},
+
+			assertErr:       require.NoError,
+			wantUsesRemaing: 9,
+			wantChannel:     "testing",
+			wantClusterJoin: api.TokenClusterJoin{
+				Cluster:            "cluster01",
+				CopyServicesConfig: true,
+			},
+		},
 		{
 			name:     "success - auto remove",
 			tokenArg: token,
@@ -555,11 +681,12 @@ func TestTokenService_Consume(t *testing.T) {
 			tokenSvc := provisioningToken.New(repo, nil, nil, nil, nil)
 
 			// Run test
-			channel, err := tokenSvc.Consume(context.Background(), tc.tokenArg)
+			consumedToken, err := tokenSvc.Consume(context.Background(), tc.tokenArg)
 
 			// Assert
 			tc.assertErr(t, err)
-			require.Equal(t, tc.wantChannel, channel)
+			require.Equal(t, tc.wantChannel, ptr.From(consumedToken).Channel)
+			require.Equal(t, tc.wantClusterJoin, ptr.From(consumedToken).ClusterJoin)
 		})
 	}
 }
@@ -25,6 +25,7 @@ type Token struct {
 	Description   string
 	Channel       string `db:"join=channels.name"`
 	AutoRemove    bool
+	ClusterJoin   api.TokenClusterJoin `db:"marshal=json"`
 }
 
 func (t Token) Validate() error {
@@ -40,6 +41,10 @@ func (t Token) Validate() error {
 		return domain.NewValidationErrf(`Channel can not be empty`)
 	}
 
+	if t.ClusterJoin.Cluster == "" && (t.ClusterJoin.SkipPostJoinOperations || t.ClusterJoin.CopyServicesConfig) {
+		return domain.NewValidationErrf(`Cluster join options require a cluster`)
+	}
+
 	return nil
 }
 
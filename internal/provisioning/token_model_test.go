@@ -29,6 +29,20 @@ func TestToken_Validate(t *testing.T) {
 
 			assertErr: require.NoError,
 		},
+		{
+			name: "valid - with cluster join",
+			token: provisioning.Token{
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Channel:       "stable",
+				ClusterJoin: api.TokenClusterJoin{
+					Cluster:            "cluster01",
+					CopyServicesConfig: true,
+				},
+			},
+
+			assertErr: require.NoError,
+		},
 		{
 			name: "error - remaining uses",
 			token: provisioning.Token{
@@ -55,6 +69,22 @@ func TestToken_Validate(t *testing.T) {
 				require.ErrorAs(tt, err, &verr, a...)
 			},
 		},
+		{
+			name: "error - cluster join options without cluster",
+			token: provisioning.Token{
+				UsesRemaining: 1,
+				ExpireAt:      time.Now().Add(1 * time.Minute),
+				Channel:       "stable",
+				ClusterJoin: api.TokenClusterJoin{
+					CopyServicesConfig: true,
+				},
+			},
+
+			assertErr: func(tt require.TestingT, err error, a ...any) {
+				var verr domain.ErrValidation
+				require.ErrorAs(tt, err, &verr, a...)
+			},
+		},
 		{
 			name: "error - channel",
 			token: provisioning.Token{
@@ -11,13 +11,14 @@ import (
 )
 
 type TokenService interface {
+	SetClusterService(clusterSvc ClusterService)
 	Create(ctx context.Context, token Token) (Token, error)
 	GetAll(ctx context.Context) (Tokens, error)
 	GetAllUUIDs(ctx context.Context) ([]uuid.UUID, error)
 	GetByUUID(ctx context.Context, id uuid.UUID) (*Token, error)
 	Update(ctx context.Context, token Token) error
 	DeleteByUUID(ctx context.Context, id uuid.UUID) error
-	Consume(ctx context.Context, id uuid.UUID) (channel string, _ error)
+	Consume(ctx context.Context, id uuid.UUID) (*Token, error)
 	PreparePreSeededImage(ctx context.Context, id uuid.UUID, imageType api.ImageType, architecture images.UpdateFileArchitecture, seedConfig TokenImageSeedConfigs) (uuid.UUID, error)
 	GetPreSeededImage(ctx context.Context, id uuid.UUID, imageUUID uuid.UUID) (_ io.ReadCloser, filename string, _ error)
 	GetTokenProviderConfig(ctx context.Context, id uuid.UUID) (*api.TokenProviderConfig, error)
@@ -267,10 +267,14 @@ func (s updateService) GetAllWithFilter(ctx context.Context, filter provisioning
 		return nil, err
 	}
 
-	if filter.UpstreamChannel != nil {
+	if filter.UpstreamChannel != nil || filter.MaxVersion != nil {
 		n := 0
 		for i := range updates {
-			if !slices.Contains(updates[i].UpstreamChannels, *filter.UpstreamChannel) {
+			if filter.UpstreamChannel != nil && !slices.Contains(updates[i].UpstreamChannels, *filter.UpstreamChannel) {
+				continue
+			}
+
+			if filter.MaxVersion != nil && api.AvailableVersionGreaterThan(*filter.MaxVersion, updates[i].Version) {
 				continue
 			}
 
@@ -291,7 +295,7 @@ func (s updateService) GetByUUID(ctx context.Context, id uuid.UUID) (*provisioni
 }
 
 func (s updateService) GetAllUUIDsWithFilter(ctx context.Context, filter provisioning.UpdateFilter) ([]uuid.UUID, error) {
-	if filter.UpstreamChannel == nil {
+	if filter.UpstreamChannel == nil && filter.MaxVersion == nil {
 		updateIDs, err := s.repo.GetAllUUIDs(ctx)
 		if err != nil {
 			return nil, err
@@ -307,7 +311,11 @@ func (s updateService) GetAllUUIDsWithFilter(ctx context.Context, filter provisi
 
 	updateIDs := make([]uuid.UUID, 0, len(updates))
 	for _, update := range updates {
-		if !slices.Contains(update.UpstreamChannels, *filter.UpstreamChannel) {
+		if filter.UpstreamChannel != nil && !slices.Contains(update.UpstreamChannels, *filter.UpstreamChannel) {
+			continue
+		}
+
+		if filter.MaxVersion != nil && api.AvailableVersionGreaterThan(*filter.MaxVersion, update.Version) {
 			continue
 		}
 
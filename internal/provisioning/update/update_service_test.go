@@ -534,6 +534,30 @@ func TestUpdateService_GetAllWithFilter(t *testing.T) {
 			assertErr: require.NoError,
 			count:     2,
 		},
+		{
+			name: "success - with channel and max version",
+			filter: provisioning.UpdateFilter{
+				Channel:    ptr.To("stable"),
+				MaxVersion: ptr.To("202501020304"),
+			},
+			repoGetAllXXX: provisioning.Updates{
+				provisioning.Update{
+					UUID:    uuid.MustParse(`1b6b5509-a9a6-419f-855f-7a8618ce76ad`),
+					Version: "202501100304", // newer than max version
+				},
+				provisioning.Update{
+					UUID:    uuid.MustParse(`689396f9-cf05-4776-a567-38014d37f861`),
+					Version: "202501020304",
+				},
+				provisioning.Update{
+					UUID:    uuid.MustParse(`27a1ee88-32a5-4f13-8f3b-4b0a1b9d4d4c`),
+					Version: "202412020304",
+				},
+			},
+
+			assertErr: require.NoError,
+			count:     2,
+		},
 		{
 			name:          "error - repo",
 			repoGetXXXErr: boom.Error,
@@ -660,6 +684,25 @@ func TestUpdateService_GetAllUUIDsWithFilter(t *testing.T) {
 			assertErr: require.NoError,
 			count:     1,
 		},
+		{
+			name: "success - with max version",
+			filter: provisioning.UpdateFilter{
+				MaxVersion: ptr.To("202501020304"),
+			},
+			repoGetAll: provisioning.Updates{
+				{
+					UUID:    uuid.MustParse(`8926daa1-3a48-4739-9a82-e32ebd22d343`),
+					Version: "202501100304", // newer than max version
+				},
+				{
+					UUID:    uuid.MustParse(`84156d67-0bcb-4b60-ac23-2c67f552fb8c`),
+					Version: "202501020304",
+				},
+			},
+
+			assertErr: require.NoError,
+			count:     1,
+		},
 		{
 			name:               "error - repo",
 			repoGetAllUUIDsErr: boom.Error,
@@ -133,6 +133,10 @@ type UpdateFilter struct {
 	UpstreamChannel *string `db:"ignore"`
 	Origin          *string
 	Status          *api.UpdateStatus
+
+	// MaxVersion limits the updates to the ones with a version not greater
+	// than the given version.
+	MaxVersion *string `db:"ignore"`
 }
 
 func (f UpdateFilter) AppendToURLValues(query url.Values) url.Values {
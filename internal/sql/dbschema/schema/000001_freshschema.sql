@@ -20,6 +20,7 @@ CREATE TABLE tokens (
   description TEXT NOT NULL,
   channel_id INTEGER NOT NULL DEFAULT 0,
   auto_remove BOOLEAN NOT NULL DEFAULT 0,
+  cluster_join TEXT NOT NULL DEFAULT '{}',
   UNIQUE(uuid),
   FOREIGN KEY (channel_id) REFERENCES channels(id)
 );
@@ -36,10 +37,16 @@ CREATE TABLE clusters (
   properties TEXT NOT NULL DEFAULT '',
   update_status TEXT NOT NULL DEFAULT '',
   config TEXT NOT NULL DEFAULT '',
+  cluster_template TEXT NOT NULL DEFAULT '',
+  cluster_template_revision INTEGER NOT NULL DEFAULT 0,
+  cluster_template_variable_values TEXT NOT NULL DEFAULT '',
+  site_id INTEGER,
+  services_config TEXT NOT NULL DEFAULT '',
   UNIQUE (name),
   UNIQUE (certificate),
   CHECK (name <> ''),
-  FOREIGN KEY (channel_id) REFERENCES channels(id)
+  FOREIGN KEY (channel_id) REFERENCES channels(id),
+  FOREIGN KEY (site_id) REFERENCES sites(id)
 );
 
 CREATE TABLE servers (
@@ -66,12 +73,16 @@ CREATE TABLE servers (
   system_uuid TEXT,
   machine_id TEXT,
   bmc_data TEXT NOT NULL DEFAULT '{}',
+  site_id INTEGER,
+  cluster_join TEXT NOT NULL DEFAULT '{}',
+  bmc_install TEXT NOT NULL DEFAULT '{}',
   UNIQUE (name),
   UNIQUE (certificate),
   UNIQUE (system_uuid),
   UNIQUE (machine_id),
   FOREIGN KEY (cluster_id) REFERENCES clusters(id) ON DELETE CASCADE,
   FOREIGN KEY (channel_id) REFERENCES channels(id),
+  FOREIGN KEY (site_id) REFERENCES sites(id),
   CHECK (name <> '')
 );
 
@@ -334,10 +345,24 @@ CREATE TABLE cluster_templates (
   application_config_template TEXT NOT NULL,
   variables TEXT NOT NULL,
   last_updated DATETIME NOT NULL,
+  revision INTEGER NOT NULL DEFAULT 1,
   UNIQUE (name),
   CHECK (name <> '')
 );
 
+CREATE TABLE cluster_template_revisions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  cluster_template_id INTEGER NOT NULL,
+  revision INTEGER NOT NULL,
+  description TEXT NOT NULL,
+  service_config_template TEXT NOT NULL,
+  application_config_template TEXT NOT NULL,
+  variables TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE (cluster_template_id, revision),
+  FOREIGN KEY (cluster_template_id) REFERENCES cluster_templates(id) ON DELETE CASCADE
+);
+
 CREATE TABLE cluster_artifacts (
   id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
   cluster_id INTEGER NOT NULL,
@@ -396,6 +421,113 @@ CREATE TABLE incus_images (
   CHECK (name <> '')
 );
 
+CREATE TABLE cluster_operations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  uuid TEXT NOT NULL,
+  cluster_id INTEGER NOT NULL,
+  type TEXT NOT NULL,
+  status TEXT NOT NULL,
+  error TEXT NOT NULL,
+  started_at DATETIME NOT NULL,
+  finished_at DATETIME NOT NULL,
+  UNIQUE (uuid),
+  FOREIGN KEY (cluster_id) REFERENCES clusters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE cluster_operation_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  cluster_operation_id INTEGER NOT NULL,
+  server TEXT NOT NULL,
+  action TEXT NOT NULL,
+  started_at DATETIME NOT NULL,
+  FOREIGN KEY (cluster_operation_id) REFERENCES cluster_operations(id) ON DELETE CASCADE
+);
+
+CREATE TABLE rollouts (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  reboot BOOLEAN NOT NULL,
+  target_version TEXT NOT NULL DEFAULT '',
+  waves TEXT NOT NULL,
+  status TEXT NOT NULL,
+  current_wave INTEGER NOT NULL,
+  wave_status TEXT NOT NULL,
+  wave_clusters TEXT NOT NULL,
+  wave_finished_at DATETIME NOT NULL,
+  error TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name)
+);
+
+CREATE TABLE sites (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  channel_id INTEGER NOT NULL,
+  properties TEXT NOT NULL,
+  config TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name),
+  CHECK (name <> ''),
+  FOREIGN KEY (channel_id) REFERENCES channels(id)
+);
+
+CREATE TABLE server_hardware_snapshots (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  server_id INTEGER NOT NULL,
+  hardware_data TEXT NOT NULL,
+  changes TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+
+CREATE TABLE server_disk_health_samples (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  server_id INTEGER NOT NULL,
+  drive TEXT NOT NULL,
+  passed BOOLEAN NOT NULL,
+  percentage_used INTEGER NOT NULL,
+  available_spare INTEGER NOT NULL,
+  reallocated_sectors INTEGER NOT NULL,
+  power_on_hours INTEGER NOT NULL,
+  recorded_at DATETIME NOT NULL,
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+
+CREATE TABLE server_decommissions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  uuid TEXT NOT NULL,
+  server TEXT NOT NULL,
+  options TEXT NOT NULL,
+  status TEXT NOT NULL,
+  steps TEXT NOT NULL,
+  error TEXT NOT NULL,
+  started_at DATETIME NOT NULL,
+  finished_at DATETIME NOT NULL,
+  UNIQUE (uuid)
+);
+
+CREATE TABLE cluster_blueprints (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  cluster_template_id INTEGER NOT NULL,
+  cluster_template_variable_values TEXT NOT NULL,
+  server_filter TEXT NOT NULL,
+  member_count INTEGER NOT NULL,
+  connection_url TEXT NOT NULL,
+  server_type TEXT NOT NULL,
+  channel_id INTEGER NOT NULL,
+  status TEXT NOT NULL,
+  server_names TEXT NOT NULL,
+  error TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name),
+  FOREIGN KEY (cluster_template_id) REFERENCES cluster_templates(id),
+  FOREIGN KEY (channel_id) REFERENCES channels(id)
+);
+
 CREATE VIEW resources AS
     SELECT 'image' AS kind, images.id, clusters.name AS cluster_name, NULL AS server_name, images.project_name, NULL AS parent_name, images.name, images.object, images.last_updated
     FROM images
@@ -460,4 +592,4 @@ CREATE VIEW resources AS
     LEFT JOIN servers ON storage_volumes.server_id = servers.id
 ;
 
-INSERT INTO schema (version, updated_at) VALUES (39, strftime("%s"));
+INSERT INTO schema (version, updated_at) VALUES (52, strftime("%s"));
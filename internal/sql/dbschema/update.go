@@ -67,6 +67,290 @@ var updates = map[int]update{
 	37: updateFromV36,
 	38: updateFromV37,
 	39: updateFromV38,
+	40: updateFromV39,
+	41: updateFromV40,
+	42: updateFromV41,
+	43: updateFromV42,
+	44: updateFromV43,
+	45: updateFromV44,
+	46: updateFromV45,
+	47: updateFromV46,
+	48: updateFromV47,
+	49: updateFromV48,
+	50: updateFromV49,
+	51: updateFromV50,
+	52: updateFromV51,
+}
+
+func updateFromV51(ctx context.Context, tx *sql.Tx) error {
+	// v51..v52 record the services config, a cluster has been created with.
+	// The services config of existing clusters is not known and therefore left
+	// empty.
+	stmt := `
+ALTER TABLE clusters ADD COLUMN services_config TEXT NOT NULL DEFAULT '';
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV50(ctx context.Context, tx *sql.Tx) error {
+	// v50..v51 reference cluster templates and channels of cluster blueprints by foreign keys.
+	// Cluster blueprints without a channel get the channel 'stable'. Cluster
+	// blueprints referencing a cluster template, which does no longer exist,
+	// can not be formed anymore and are therefore dropped.
+	stmt := `
+CREATE TABLE cluster_blueprints_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  cluster_template_id INTEGER NOT NULL,
+  cluster_template_variable_values TEXT NOT NULL,
+  server_filter TEXT NOT NULL,
+  member_count INTEGER NOT NULL,
+  connection_url TEXT NOT NULL,
+  server_type TEXT NOT NULL,
+  channel_id INTEGER NOT NULL,
+  status TEXT NOT NULL,
+  server_names TEXT NOT NULL,
+  error TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name),
+  FOREIGN KEY (cluster_template_id) REFERENCES cluster_templates(id),
+  FOREIGN KEY (channel_id) REFERENCES channels(id)
+);
+WITH stable_channel AS (
+  -- Find the id for the channel 'stable', if not found, fall back to the channel with the lowest id.
+  SELECT id FROM (SELECT id, 1 AS prio FROM channels WHERE name = 'stable' UNION SELECT * FROM (SELECT id, 2 AS prio FROM channels ORDER BY id LIMIT 1)) ORDER BY prio LIMIT 1
+)
+INSERT INTO cluster_blueprints_new
+  SELECT cluster_blueprints.id, cluster_blueprints.name, cluster_blueprints.description, cluster_templates.id,
+  cluster_blueprints.cluster_template_variable_values, cluster_blueprints.server_filter, cluster_blueprints.member_count,
+  cluster_blueprints.connection_url, cluster_blueprints.server_type,
+  COALESCE((SELECT channels.id FROM channels WHERE channels.name = cluster_blueprints.channel), (SELECT id FROM stable_channel)),
+  cluster_blueprints.status, cluster_blueprints.server_names, cluster_blueprints.error, cluster_blueprints.last_updated
+  FROM cluster_blueprints
+  JOIN cluster_templates ON cluster_blueprints.cluster_template = cluster_templates.name;
+DROP TABLE cluster_blueprints;
+ALTER TABLE cluster_blueprints_new RENAME TO cluster_blueprints;
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV49(ctx context.Context, tx *sql.Tx) error {
+	// v49..v50 add target version to rollouts.
+	stmt := `
+ALTER TABLE rollouts ADD COLUMN target_version TEXT NOT NULL DEFAULT '';
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV48(ctx context.Context, tx *sql.Tx) error {
+	// v48..v49 add bmc install to servers.
+	stmt := `
+ALTER TABLE servers ADD COLUMN bmc_install TEXT NOT NULL DEFAULT '{}';
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV47(ctx context.Context, tx *sql.Tx) error {
+	// v47..v48 add cluster blueprints table.
+	stmt := `
+CREATE TABLE cluster_blueprints (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  cluster_template TEXT NOT NULL,
+  cluster_template_variable_values TEXT NOT NULL,
+  server_filter TEXT NOT NULL,
+  member_count INTEGER NOT NULL,
+  connection_url TEXT NOT NULL,
+  server_type TEXT NOT NULL,
+  channel TEXT NOT NULL,
+  status TEXT NOT NULL,
+  server_names TEXT NOT NULL,
+  error TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name)
+);
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV46(ctx context.Context, tx *sql.Tx) error {
+	// v46..v47 add cluster join to tokens and servers.
+	stmt := `
+ALTER TABLE tokens ADD COLUMN cluster_join TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE servers ADD COLUMN cluster_join TEXT NOT NULL DEFAULT '{}';
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV45(ctx context.Context, tx *sql.Tx) error {
+	// v45..v46 add server decommissions table.
+	stmt := `
+CREATE TABLE server_decommissions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  uuid TEXT NOT NULL,
+  server TEXT NOT NULL,
+  options TEXT NOT NULL,
+  status TEXT NOT NULL,
+  steps TEXT NOT NULL,
+  error TEXT NOT NULL,
+  started_at DATETIME NOT NULL,
+  finished_at DATETIME NOT NULL,
+  UNIQUE (uuid)
+);
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV44(ctx context.Context, tx *sql.Tx) error {
+	// v44..v45 add server disk health samples table.
+	stmt := `
+CREATE TABLE server_disk_health_samples (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  server_id INTEGER NOT NULL,
+  drive TEXT NOT NULL,
+  passed BOOLEAN NOT NULL,
+  percentage_used INTEGER NOT NULL,
+  available_spare INTEGER NOT NULL,
+  reallocated_sectors INTEGER NOT NULL,
+  power_on_hours INTEGER NOT NULL,
+  recorded_at DATETIME NOT NULL,
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV43(ctx context.Context, tx *sql.Tx) error {
+	// v43..v44 add server hardware snapshots table.
+	stmt := `
+CREATE TABLE server_hardware_snapshots (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  server_id INTEGER NOT NULL,
+  hardware_data TEXT NOT NULL,
+  changes TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY (server_id) REFERENCES servers(id) ON DELETE CASCADE
+);
+
+INSERT INTO server_hardware_snapshots (server_id, hardware_data, changes, created_at)
+  SELECT id, hardware_data, '[]', last_updated FROM servers WHERE json_extract(hardware_data, '$.cpu.architecture') <> '';
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV42(ctx context.Context, tx *sql.Tx) error {
+	// v42..v43 add sites table and assign clusters and servers to sites.
+	stmt := `
+CREATE TABLE sites (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  channel_id INTEGER NOT NULL,
+  properties TEXT NOT NULL,
+  config TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name),
+  CHECK (name <> ''),
+  FOREIGN KEY (channel_id) REFERENCES channels(id)
+);
+
+ALTER TABLE clusters ADD COLUMN site_id INTEGER REFERENCES sites(id);
+ALTER TABLE servers ADD COLUMN site_id INTEGER REFERENCES sites(id);
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV41(ctx context.Context, tx *sql.Tx) error {
+	// v41..v42 add cluster template revisions and record the cluster template on clusters.
+	stmt := `
+ALTER TABLE cluster_templates ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE cluster_template_revisions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  cluster_template_id INTEGER NOT NULL,
+  revision INTEGER NOT NULL,
+  description TEXT NOT NULL,
+  service_config_template TEXT NOT NULL,
+  application_config_template TEXT NOT NULL,
+  variables TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE (cluster_template_id, revision),
+  FOREIGN KEY (cluster_template_id) REFERENCES cluster_templates(id) ON DELETE CASCADE
+);
+
+INSERT INTO cluster_template_revisions (cluster_template_id, revision, description, service_config_template, application_config_template, variables, created_at)
+  SELECT id, 1, description, service_config_template, application_config_template, variables, last_updated FROM cluster_templates;
+
+ALTER TABLE clusters ADD COLUMN cluster_template TEXT NOT NULL DEFAULT '';
+ALTER TABLE clusters ADD COLUMN cluster_template_revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE clusters ADD COLUMN cluster_template_variable_values TEXT NOT NULL DEFAULT '';
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV40(ctx context.Context, tx *sql.Tx) error {
+	// v40..v41 add rollouts table.
+	stmt := `
+CREATE TABLE rollouts (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  reboot BOOLEAN NOT NULL,
+  waves TEXT NOT NULL,
+  status TEXT NOT NULL,
+  current_wave INTEGER NOT NULL,
+  wave_status TEXT NOT NULL,
+  wave_clusters TEXT NOT NULL,
+  wave_finished_at DATETIME NOT NULL,
+  error TEXT NOT NULL,
+  last_updated DATETIME NOT NULL,
+  UNIQUE (name)
+);
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
+}
+
+func updateFromV39(ctx context.Context, tx *sql.Tx) error {
+	// v39..v40 add cluster_operations and cluster_operation_events tables.
+	stmt := `
+CREATE TABLE cluster_operations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  uuid TEXT NOT NULL,
+  cluster_id INTEGER NOT NULL,
+  type TEXT NOT NULL,
+  status TEXT NOT NULL,
+  error TEXT NOT NULL,
+  started_at DATETIME NOT NULL,
+  finished_at DATETIME NOT NULL,
+  UNIQUE (uuid),
+  FOREIGN KEY (cluster_id) REFERENCES clusters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE cluster_operation_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  cluster_operation_id INTEGER NOT NULL,
+  server TEXT NOT NULL,
+  action TEXT NOT NULL,
+  started_at DATETIME NOT NULL,
+  FOREIGN KEY (cluster_operation_id) REFERENCES cluster_operations(id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(stmt)
+	return MapDBError(err)
 }
 
 func updateFromV38(ctx context.Context, tx *sql.Tx) error {
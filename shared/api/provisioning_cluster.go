@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lxc/incus-os/incus-osd/api/images"
 	incusapi "github.com/lxc/incus/v7/shared/api"
 )
 
@@ -99,6 +100,12 @@ type ClusterUpdateInProgressStatus struct {
 	// Error contains the error description, if the cluster update failed permanently.
 	Error string `json:"error" yaml:"error"`
 
+	// HealthGateError contains the reason, why the cluster update health gate
+	// scriptlet prevented the next step of the cluster update. The cluster
+	// update is paused as long as the health gate is failing.
+	// Example: Health gate failed: pre_step for server "server01": ceph is not healthy
+	HealthGateError string `json:"health_gate_error" yaml:"health_gate_error"`
+
 	// StatusDescription contains progress information for the user in plain text
 	// form.
 	// Example: [3/20] Evacuating server xyz
@@ -115,6 +122,22 @@ type ClusterUpdateInProgressStatus struct {
 	// only populated during the rolling reboot, it is empty for all other phases.
 	PendingReboot []string `json:"pending_reboot" yaml:"pending_reboot"`
 
+	// PendingFirmwareUpdate contains the list of server names of the servers,
+	// which still have to receive the firmware update as part of a rolling
+	// firmware update. A server is removed from the list as soon as the BMC
+	// reports the firmware update as completed. The list is only populated
+	// during the rolling firmware update, it is empty for all other phases.
+	PendingFirmwareUpdate []string `json:"pending_firmware_update,omitempty" yaml:"pending_firmware_update,omitempty"`
+
+	// FirmwareUpdate holds the firmware update, which is applied to the
+	// servers during a rolling firmware update.
+	FirmwareUpdate *BMCFirmwareUpdatePost `json:"firmware_update,omitempty" yaml:"firmware_update,omitempty"`
+
+	// ServerStates contains the update state of each server, which is currently
+	// taking part in the cluster update, indexed by the server name. Servers,
+	// which are up to date, are omitted.
+	ServerStates map[string]ServerUpdateState `json:"server_states,omitempty" yaml:"server_states,omitempty"`
+
 	// LastUpdated is the time, when this information has been updated for the
 	// last time in RFC3339 format.
 	// Example: 2024-11-12T16:15:00Z
@@ -129,6 +152,8 @@ const (
 	ClusterUpdateInProgressApplyUpdateWithReboot ClusterUpdateInProgress = "applying updates with reboot"
 	ClusterUpdateInProgressRollingRestart        ClusterUpdateInProgress = "restarting servers"
 	ClusterUpdateInProgressRollingReboot         ClusterUpdateInProgress = "rolling reboot"
+	ClusterUpdateInProgressRollingFirmwareUpdate ClusterUpdateInProgress = "rolling firmware update"
+	ClusterUpdateInProgressReplaceServer         ClusterUpdateInProgress = "replacing server"
 	ClusterUpdateInProgressError                 ClusterUpdateInProgress = "error"
 )
 
@@ -154,6 +179,32 @@ type ClusterUpdateStatus struct {
 	// InProgressStatus holds the status information about an ongoing cluster
 	// update, if any.
 	InProgressStatus ClusterUpdateInProgressStatus `json:"in_progress_status" yaml:"in_progress_status"`
+
+	// AutoUpdateLaunches holds the automatic launches of cluster updates based
+	// on the auto update policy of the cluster, including the failed ones,
+	// ordered from the oldest to the most recent. Only the most recent launches
+	// are kept.
+	AutoUpdateLaunches []ClusterAutoUpdateLaunch `json:"auto_update_launches" yaml:"auto_update_launches"`
+}
+
+// ClusterAutoUpdateLaunch records an automatic launch of a cluster update.
+type ClusterAutoUpdateLaunch struct {
+	// LaunchedAt is the time, when the cluster update has been launched
+	// automatically in RFC3339 format.
+	// Example: 2024-11-12T16:15:00Z
+	LaunchedAt time.Time `json:"launched_at" yaml:"launched_at"`
+
+	// Version of the update, which caused the cluster update to be launched.
+	// Example: 202512250102
+	Version string `json:"version" yaml:"version"`
+
+	// Severity of the update, which caused the cluster update to be launched.
+	// Example: high
+	Severity images.UpdateSeverity `json:"severity" yaml:"severity"`
+
+	// Error contains the error description, if the automatic launch of the
+	// cluster update failed.
+	Error string `json:"error" yaml:"error"`
 }
 
 // Value implements the sql driver.Valuer interface.
@@ -165,6 +216,7 @@ func (c ClusterUpdateStatus) Value() (driver.Value, error) {
 	clusterUpdateStatus.NeedsReboot = nil
 	clusterUpdateStatus.InMaintenance = nil
 	clusterUpdateStatus.InProgressStatus.StatusDescription = nil
+	clusterUpdateStatus.InProgressStatus.ServerStates = nil
 
 	return json.Marshal(clusterUpdateStatus)
 }
@@ -210,12 +262,87 @@ type ClusterConfigRollingRestart struct {
 	// previously) and "skip" (skip moving evacuated instances back).
 	// Example: skip
 	RestoreMode string `json:"restore_mode" yaml:"restore_mode"`
+
+	// MaxUnavailable is the maximum number of servers, that are updated,
+	// evacuated, rebooted and restored at the same time during a rolling update
+	// or a rolling reboot. It is either an absolute number of servers (e.g. "2")
+	// or a percentage of the servers of the cluster (e.g. "25%"), which is
+	// rounded down. Valid values are "" (default, one server at a time), a
+	// positive number or a percentage between 1% and 49%. At least one server
+	// is processed at a time, but never more than a minority of the servers, such
+	// that a majority of the cluster members stays online.
+	// Example: 25%
+	MaxUnavailable string `json:"max_unavailable" yaml:"max_unavailable"`
+}
+
+// ClusterConfigMaintenanceWindow defines a recurring time window, during which
+// Operations Center is allowed to perform the disruptive steps of cluster wide
+// operations like rolling updates and rolling reboots.
+type ClusterConfigMaintenanceWindow struct {
+	// Weekdays holds the days of the week, on which the maintenance window
+	// opens. Valid values are the English names of the days of the week in
+	// lower case. If empty, the maintenance window opens on every day.
+	// Example: ["saturday", "sunday"]
+	Weekdays []string `json:"weekdays" yaml:"weekdays"`
+
+	// StartTime is the time of the day (24h format, HH:MM), when the maintenance
+	// window opens.
+	// Example: 22:00
+	StartTime string `json:"start_time" yaml:"start_time"`
+
+	// EndTime is the time of the day (24h format, HH:MM), when the maintenance
+	// window closes. If EndTime is before StartTime, the maintenance window
+	// spans midnight and closes on the day following the day it opened.
+	// Example: 04:00
+	EndTime string `json:"end_time" yaml:"end_time"`
+
+	// Timezone is the name of the IANA time zone, StartTime and EndTime are
+	// interpreted in. Defaults to UTC, if empty.
+	// Example: Europe/Zurich
+	Timezone string `json:"timezone" yaml:"timezone"`
+}
+
+// ClusterConfigAutoUpdate defines the policy, based on which Operations Center
+// launches cluster updates automatically.
+type ClusterConfigAutoUpdate struct {
+	// Enabled is true, if cluster updates are launched automatically.
+	// Example: true
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// MinSeverity is the minimum severity of an update, which causes the
+	// cluster update to be launched automatically. Valid values are "none"
+	// (default, every update), "low", "medium", "high" and "critical".
+	// Example: high
+	MinSeverity images.UpdateSeverity `json:"min_severity" yaml:"min_severity"`
+
+	// MinAge holds the time.Duration (as string, e.g. "72h"), which needs to
+	// pass after an update has been published, before it causes the cluster
+	// update to be launched automatically.
+	// Example: 72h
+	MinAge string `json:"min_age" yaml:"min_age"`
+
+	// Reboot is true, if the automatically launched cluster update includes the
+	// rolling reboot of the servers.
+	// Example: true
+	Reboot bool `json:"reboot" yaml:"reboot"`
 }
 
 // ClusterConfig contains cluster wide configuration used by Operations Center
 // when interacting with the cluster.
 type ClusterConfig struct {
 	RollingRestart ClusterConfigRollingRestart `json:"rolling_restart" yaml:"rolling_restart"`
+
+	// MaintenanceWindows holds the recurring time windows, during which cluster
+	// wide operations like rolling updates and rolling reboots are allowed to
+	// evacuate, update, reboot and restore servers. Outside of these windows, an
+	// ongoing operation is paused at the next safe point, which is when no
+	// server is in maintenance. If empty, the cluster wide operations are not
+	// restricted.
+	MaintenanceWindows []ClusterConfigMaintenanceWindow `json:"maintenance_windows" yaml:"maintenance_windows"`
+
+	// AutoUpdate holds the policy, based on which cluster updates are launched
+	// automatically, if the channel of the cluster provides a matching update.
+	AutoUpdate ClusterConfigAutoUpdate `json:"auto_update" yaml:"auto_update"`
 }
 
 func (c ClusterConfig) Value() (driver.Value, error) {
@@ -265,6 +392,11 @@ type ClusterPut struct {
 	// Example: stable
 	Channel string `json:"channel" yaml:"channel"`
 
+	// Site the cluster belongs to. Empty, if the cluster is not assigned to a
+	// site.
+	// Example: zurich
+	Site string `json:"site" yaml:"site"`
+
 	// Description of the cluster.
 	// Example: Lab cluster with limited resources.
 	Description string `json:"description" yaml:"description"`
@@ -314,11 +446,33 @@ type Cluster struct {
 	// process if any.
 	UpdateStatus ClusterUpdateStatus `json:"update_status" yaml:"update_status"`
 
+	// Template contains the cluster config template, which has been used to
+	// create the cluster. It is not set, if the cluster has been created
+	// without a cluster config template.
+	Template *ClusterTemplateReference `json:"template,omitempty" yaml:"template,omitempty"`
+
 	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
 	// Example: 2024-11-12T16:15:00Z
 	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
 }
 
+// ClusterTemplateReference references the revision of the cluster config
+// template as well as the variable values, which have been used to create a
+// cluster.
+type ClusterTemplateReference struct {
+	// Name of the cluster config template.
+	// Example: MyTemplate
+	Name string `json:"name" yaml:"name"`
+
+	// Revision of the cluster config template.
+	// Example: 3
+	Revision int64 `json:"revision" yaml:"revision"`
+
+	// VariableValues contains the variable values, which have been applied to
+	// the cluster config template.
+	VariableValues ConfigMap `json:"variable_values" yaml:"variable_values"`
+}
+
 // ClusterPost represents the fields available for a new cluster of servers running Hypervisor OS.
 //
 // swagger:model
@@ -355,6 +509,25 @@ type ClusterPost struct {
 	ClusterTemplateVariableValues ConfigMap `json:"cluster_template_variable_values" yaml:"cluster_template_variable_values"`
 }
 
+// ClusterAdoptPost represents the fields available to adopt an existing
+// cluster of servers running Hypervisor OS, which has not been created by
+// Operations Center.
+//
+// swagger:model
+type ClusterAdoptPost struct {
+	ClusterPut `yaml:",inline"`
+
+	// A human-friendly name for this cluster.
+	// Example: MyCluster
+	Name string `json:"name" yaml:"name"`
+
+	// Names of the servers beloning to the cluster. All the members of the
+	// existing cluster need to be listed and need to be registered in
+	// Operations Center.
+	// Example: ["server1", "server2"]
+	ServerNames []string `json:"server_names" yaml:"server_names"`
+}
+
 // ClusterCertificatePut represents the certificate and key pair for all cluster members.
 //
 // swagger:model
@@ -383,6 +556,10 @@ const (
 	ClusterBulkUpdateActionRemoveMultipathStorageTarget   ClusterBulkUpdateAction = "remove_multipath_storage_target"
 	ClusterBulkUpdateActionAddNVMEStorageTarget           ClusterBulkUpdateAction = "add_nvme_storage_target"
 	ClusterBulkUpdateActionRemoveNVMEStorageTarget        ClusterBulkUpdateAction = "remove_nvme_storage_target"
+	ClusterBulkUpdateActionAddCephCluster                 ClusterBulkUpdateAction = "add_ceph_cluster"
+	ClusterBulkUpdateActionRemoveCephCluster              ClusterBulkUpdateAction = "remove_ceph_cluster"
+	ClusterBulkUpdateActionUpdateOVNConfig                ClusterBulkUpdateAction = "update_ovn_config"
+	ClusterBulkUpdateActionUpdateLinstorConfig            ClusterBulkUpdateAction = "update_linstor_config"
 )
 
 // ClusterAddServersPost represents a cluster add servers request containing
@@ -415,6 +592,20 @@ type ClusterRemoveServersPost struct {
 	ServerNames []string `json:"server_names" yaml:"server_names"`
 }
 
+// ClusterReplaceServerPost represents a replace server request containing the
+// name of the failed cluster member and the name of the server replacing it.
+//
+// swagger:model
+type ClusterReplaceServerPost struct {
+	// Name of the failed server to be removed from the cluster.
+	// Example: server1
+	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// Name of the server, which replaces the failed server in the cluster.
+	// Example: server4
+	ReplacementServerName string `json:"replacement_server_name" yaml:"replacement_server_name"`
+}
+
 type ClusterMemberConfigKey = incusapi.ClusterMemberConfigKey
 
 // ClusterBulkUpdatePost represents a cluster bulk update request containing
@@ -430,6 +621,52 @@ type ClusterBulkUpdatePost struct {
 	Arguments *json.RawMessage `json:"arguments" yaml:"arguments"`
 }
 
+// ClusterConsistency reports the settings, in which the members of a cluster
+// diverge from each other.
+//
+// swagger:model
+type ClusterConsistency struct {
+	// Name of the cluster.
+	// Example: MyCluster
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// ReferenceServer is the name of the cluster member, the settings of the
+	// other members are compared with.
+	// Example: server1
+	ReferenceServer string `json:"reference_server" yaml:"reference_server"`
+
+	// Consistent is true, if the settings of all the members of the cluster
+	// are consistent.
+	// Example: false
+	Consistent bool `json:"consistent" yaml:"consistent"`
+
+	// Inconsistencies contains the settings, which diverge from the reference
+	// server.
+	Inconsistencies []ClusterInconsistency `json:"inconsistencies" yaml:"inconsistencies"`
+}
+
+// ClusterInconsistency is a single setting of a cluster member, which diverges
+// from the setting of the reference server.
+type ClusterInconsistency struct {
+	// Server is the name of the diverging cluster member.
+	// Example: server2
+	Server string `json:"server" yaml:"server"`
+
+	// Setting is the path of the diverging setting.
+	// Example: services.iscsi.enabled
+	Setting string `json:"setting" yaml:"setting"`
+
+	// Reference is the value of the setting on the reference server in JSON
+	// format.
+	// Example: true
+	Reference string `json:"reference" yaml:"reference"`
+
+	// Actual is the value of the setting on the diverging cluster member in
+	// JSON format.
+	// Example: false
+	Actual string `json:"actual" yaml:"actual"`
+}
+
 // ClusterUpdatePost represents a cluster update request.
 //
 // swagger:model
@@ -440,3 +677,127 @@ type ClusterUpdatePost struct {
 	// will require a reboot at a later stage).
 	Reboot bool `json:"reboot" yaml:"reboot"`
 }
+
+// ClusterUpdatePlanAction is the action of a step of a cluster update plan.
+type ClusterUpdatePlanAction string
+
+const (
+	ClusterUpdatePlanActionUpdate   ClusterUpdatePlanAction = "update"
+	ClusterUpdatePlanActionEvacuate ClusterUpdatePlanAction = "evacuate"
+	ClusterUpdatePlanActionReboot   ClusterUpdatePlanAction = "reboot"
+	ClusterUpdatePlanActionRestore  ClusterUpdatePlanAction = "restore"
+)
+
+// ClusterUpdatePlan describes, what a cluster wide update or reboot would do,
+// if it would be launched now.
+//
+// swagger:model
+type ClusterUpdatePlan struct {
+	// Operation is the cluster wide operation, the plan has been calculated for.
+	// Example: applying updates with reboot
+	Operation ClusterUpdateInProgress `json:"operation" yaml:"operation"`
+
+	// Steps is the ordered list of steps, the operation would perform. An empty
+	// list indicates, that the cluster is up to date and nothing would be done.
+	Steps []ClusterUpdatePlanStep `json:"steps" yaml:"steps"`
+
+	// Servers holds the details about the servers of the cluster.
+	Servers []ClusterUpdatePlanServer `json:"servers" yaml:"servers"`
+
+	// Changelog is the consolidated changelog of the update, the cluster moves
+	// to. It is only present for cluster updates.
+	Changelog *UpdateChangelog `json:"changelog,omitempty" yaml:"changelog,omitempty"`
+
+	// InMaintenanceWindow reports, if the cluster is currently within one of
+	// its maintenance windows. If not, the steps, which wait for a maintenance
+	// window, are only started, once the next maintenance window opens.
+	// Example: true
+	InMaintenanceWindow bool `json:"in_maintenance_window" yaml:"in_maintenance_window"`
+}
+
+// ClusterUpdatePlanStep is a single step of a cluster update plan.
+type ClusterUpdatePlanStep struct {
+	// Server is the name of the server, the step is performed on.
+	// Example: server01
+	Server string `json:"server" yaml:"server"`
+
+	// Action is the action performed in this step.
+	// Example: evacuate
+	Action ClusterUpdatePlanAction `json:"action" yaml:"action"`
+
+	// Skipped is true, if the step is skipped.
+	// Example: false
+	Skipped bool `json:"skipped" yaml:"skipped"`
+
+	// Reason holds the reason, why the step is skipped.
+	// Example: Server has been evacuated before the operation
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// Batch is the number of the batch, the step belongs to. Up to max
+	// unavailable servers of the cluster form a batch and are processed at the
+	// same time. The servers of the next batch take over, as soon as the
+	// servers of the current batch have completed their steps.
+	// Example: 1
+	Batch int `json:"batch" yaml:"batch"`
+
+	// WaitForMaintenanceWindow is true, if the step is only started within a
+	// maintenance window of the cluster. If no maintenance window is open,
+	// when the step is due, the operation waits for the next one.
+	// Example: false
+	WaitForMaintenanceWindow bool `json:"wait_for_maintenance_window" yaml:"wait_for_maintenance_window"`
+}
+
+// ClusterUpdatePlanServer holds the details about a server of a cluster
+// update plan.
+type ClusterUpdatePlanServer struct {
+	// Name of the server.
+	// Example: server01
+	Name string `json:"name" yaml:"name"`
+
+	// EvacuatedBefore is true, if the server has been evacuated manually before.
+	// Such a server is neither evacuated nor restored by the operation.
+	// Example: false
+	EvacuatedBefore bool `json:"evacuated_before" yaml:"evacuated_before"`
+
+	// OS holds the version information for the operating system.
+	OS ClusterUpdatePlanComponent `json:"os" yaml:"os"`
+
+	// Applications holds the version information for the installed applications.
+	Applications []ClusterUpdatePlanComponent `json:"applications" yaml:"applications"`
+
+	// Instances holds the instances located on the server according to the
+	// inventory. These instances are affected by the evacuation of the server.
+	Instances []ClusterUpdatePlanInstance `json:"instances" yaml:"instances"`
+}
+
+// ClusterUpdatePlanComponent holds the version information of a software
+// component of a server.
+type ClusterUpdatePlanComponent struct {
+	// Name of the software component.
+	// Example: incus
+	Name string `json:"name" yaml:"name"`
+
+	// CurrentVersion is the version currently installed on the server.
+	// Example: 202512250102
+	CurrentVersion string `json:"current_version" yaml:"current_version"`
+
+	// TargetVersion is the version the server moves to. If it matches
+	// CurrentVersion, the component is not updated.
+	// Example: 202601050102
+	TargetVersion string `json:"target_version" yaml:"target_version"`
+}
+
+// ClusterUpdatePlanInstance is an instance affected by a cluster update plan.
+type ClusterUpdatePlanInstance struct {
+	// Name of the instance.
+	// Example: c1
+	Name string `json:"name" yaml:"name"`
+
+	// Project of the instance.
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Status of the instance.
+	// Example: Running
+	Status string `json:"status" yaml:"status"`
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClusterBlueprintStatus is the status of a cluster blueprint.
+type ClusterBlueprintStatus string
+
+const (
+	// ClusterBlueprintStatusWaiting is the status of a blueprint, which waits
+	// for enough matching servers to be ready and unclustered.
+	ClusterBlueprintStatusWaiting ClusterBlueprintStatus = "waiting"
+
+	// ClusterBlueprintStatusForming is the status of a blueprint, while the
+	// cluster is created from the selected servers.
+	ClusterBlueprintStatusForming ClusterBlueprintStatus = "forming"
+
+	// ClusterBlueprintStatusDone is the status of a blueprint, for which the
+	// cluster has been created successfully.
+	ClusterBlueprintStatusDone ClusterBlueprintStatus = "done"
+
+	// ClusterBlueprintStatusFailed is the status of a blueprint, for which the
+	// creation of the cluster failed.
+	ClusterBlueprintStatusFailed ClusterBlueprintStatus = "failed"
+)
+
+var clusterBlueprintStatuses = map[ClusterBlueprintStatus]struct{}{
+	ClusterBlueprintStatusWaiting: {},
+	ClusterBlueprintStatusForming: {},
+	ClusterBlueprintStatusDone:    {},
+	ClusterBlueprintStatusFailed:  {},
+}
+
+func (s ClusterBlueprintStatus) String() string {
+	return string(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ClusterBlueprintStatus) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ClusterBlueprintStatus) UnmarshalText(text []byte) error {
+	_, ok := clusterBlueprintStatuses[ClusterBlueprintStatus(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid cluster blueprint status", string(text))
+	}
+
+	*s = ClusterBlueprintStatus(text)
+
+	return nil
+}
+
+// ClusterBlueprintPut defines the configurable properties of a cluster
+// blueprint.
+//
+// swagger:model
+type ClusterBlueprintPut struct {
+	// Description of the cluster blueprint.
+	// Example: Branch office cluster
+	Description string `json:"description" yaml:"description"`
+
+	// ClusterTemplate is the name of the cluster template, which is applied
+	// to the cluster.
+	// Example: branch-office
+	ClusterTemplate string `json:"cluster_template" yaml:"cluster_template"`
+
+	// ClusterTemplateVariableValues contains the values for the variables of
+	// the cluster template.
+	// Example: {"MGMT_VLAN": "100"}
+	ClusterTemplateVariableValues ConfigMap `json:"cluster_template_variable_values" yaml:"cluster_template_variable_values"`
+
+	// ServerFilter is an expression over the properties of a server, which
+	// selects the servers, that are candidates to form the cluster.
+	// Example: properties.site_code == "zrh01"
+	ServerFilter string `json:"server_filter" yaml:"server_filter"`
+
+	// MemberCount is the number of servers, the cluster is formed with.
+	// Example: 3
+	MemberCount int `json:"member_count" yaml:"member_count"`
+
+	// URL, hostname or IP address of the cluster endpoint.
+	// Example: https://zrh01.local:8443
+	ConnectionURL string `json:"connection_url" yaml:"connection_url"`
+
+	// ServerType is the type of the servers, the cluster is formed with. If
+	// not set, incus is used.
+	// Example: incus
+	ServerType ServerType `json:"server_type" yaml:"server_type"`
+
+	// Channel is the update channel of the cluster. Only servers following
+	// this channel are selected. If not set, the default server channel is
+	// used.
+	// Example: stable
+	Channel string `json:"channel" yaml:"channel"`
+}
+
+// ClusterBlueprintPost defines a new cluster blueprint. The cluster is
+// created with the name of the blueprint.
+//
+// swagger:model
+type ClusterBlueprintPost struct {
+	ClusterBlueprintPut `yaml:",inline"`
+
+	// Name of the cluster blueprint, which is also used as name of the
+	// cluster.
+	// Example: zrh01
+	Name string `json:"name" yaml:"name"`
+}
+
+// ClusterBlueprint defines a cluster, which is formed automatically, as soon
+// as enough servers matching the server filter are ready and not yet part
+// of a cluster.
+//
+// swagger:model
+type ClusterBlueprint struct {
+	ClusterBlueprintPost `yaml:",inline"`
+
+	// Status of the cluster blueprint.
+	// Example: waiting
+	Status ClusterBlueprintStatus `json:"status" yaml:"status"`
+
+	// ServerNames holds the names of the servers, which have been selected to
+	// form the cluster.
+	// Example: ["server01", "server02", "server03"]
+	ServerNames []string `json:"server_names" yaml:"server_names"`
+
+	// Error contains the error description, if the creation of the cluster
+	// failed.
+	// Example: Server "server01" does not have application Incus
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
+	// Example: 2024-11-12T16:15:00Z
+	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
+}
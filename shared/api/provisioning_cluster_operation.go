@@ -0,0 +1,94 @@
+package api
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClusterOperationStatus is the status of a cluster wide operation.
+type ClusterOperationStatus string
+
+const (
+	ClusterOperationStatusRunning   ClusterOperationStatus = "running"
+	ClusterOperationStatusSucceeded ClusterOperationStatus = "succeeded"
+	ClusterOperationStatusFailed    ClusterOperationStatus = "failed"
+	ClusterOperationStatusCanceled  ClusterOperationStatus = "canceled"
+)
+
+// ClusterOperationEventAction is the action of a step of a cluster wide
+// operation.
+type ClusterOperationEventAction string
+
+const (
+	ClusterOperationEventActionUpdate    ClusterOperationEventAction = "update"
+	ClusterOperationEventActionEvacuate  ClusterOperationEventAction = "evacuate"
+	ClusterOperationEventActionReboot    ClusterOperationEventAction = "reboot"
+	ClusterOperationEventActionFirmware  ClusterOperationEventAction = "firmware"
+	ClusterOperationEventActionRestore   ClusterOperationEventAction = "restore"
+	ClusterOperationEventActionJoin      ClusterOperationEventAction = "join"
+	ClusterOperationEventActionConfigure ClusterOperationEventAction = "configure"
+	ClusterOperationEventActionRemove    ClusterOperationEventAction = "remove"
+	ClusterOperationEventActionDone      ClusterOperationEventAction = "done"
+)
+
+// ClusterOperation is the record of a cluster wide operation, e.g. an update,
+// a reboot or the replacement of a server, performed on a cluster.
+//
+// swagger:model
+type ClusterOperation struct {
+	// UUID of the operation.
+	// Example: b32d0079-c48b-4957-b1cb-bef54125c861
+	UUID uuid.UUID `json:"uuid" yaml:"uuid"`
+
+	// Cluster is the name of the cluster, the operation has been performed on.
+	// Example: one
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// Type is the cluster wide operation, that has been launched.
+	// Example: applying updates with reboot
+	Type ClusterUpdateInProgress `json:"type" yaml:"type"`
+
+	// Status of the operation.
+	// Example: succeeded
+	Status ClusterOperationStatus `json:"status" yaml:"status"`
+
+	// Error contains the error description, if the operation failed.
+	// Example: Failed to trigger next action
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// StartedAt is the time, when the operation has been launched.
+	// Example: 2025-01-02T10:00:00Z
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// FinishedAt is the time, when the operation has finished. It is not set
+	// as long as the operation is running.
+	// Example: 2025-01-02T11:30:00Z
+	FinishedAt time.Time `json:"finished_at,omitzero" yaml:"finished_at,omitempty"`
+
+	// Events holds the steps, the operation has performed on the servers of
+	// the cluster, in the order they have been started.
+	Events []ClusterOperationEvent `json:"events" yaml:"events"`
+}
+
+// ClusterOperationEvent is a single step of a cluster wide operation performed
+// on a server.
+type ClusterOperationEvent struct {
+	// Server is the name of the server, the step has been performed on.
+	// Example: server01
+	Server string `json:"server" yaml:"server"`
+
+	// Action is the action performed in this step.
+	// Example: evacuate
+	Action ClusterOperationEventAction `json:"action" yaml:"action"`
+
+	// StartedAt is the time, when the step has been triggered.
+	// Example: 2025-01-02T10:05:00Z
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// FinishedAt is the time, when the step has finished. A step is finished,
+	// when the next step on the same server is started or when the operation
+	// has finished. It is not set as long as the step is ongoing.
+	// Example: 2025-01-02T10:07:30Z
+	FinishedAt time.Time `json:"finished_at,omitzero" yaml:"finished_at,omitempty"`
+}
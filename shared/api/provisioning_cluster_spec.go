@@ -0,0 +1,142 @@
+package api
+
+import (
+	incusosapi "github.com/lxc/incus-os/incus-osd/api"
+)
+
+// ClusterSpec is the declarative definition of a cluster of servers running
+// Hypervisor OS, e.g. kept as YAML in a git repository. Applying the spec
+// reconciles the live state of the cluster with the definition.
+//
+// swagger:model
+type ClusterSpec struct {
+	ClusterPut `yaml:",inline"`
+
+	// A human-friendly name for this cluster.
+	// Example: MyCluster
+	Name string `json:"name" yaml:"name"`
+
+	// Names of the servers belonging to the cluster.
+	// Example: ["server1", "server2"]
+	ServerNames []string `json:"server_names" yaml:"server_names"`
+
+	// ServerType is the expected type of servers to be clustered. It is only
+	// used, when the cluster is created.
+	ServerType ServerType `json:"server_type" yaml:"server_type"`
+
+	// ServicesConfig contains the configuration for each service, which should
+	// be configured on Hypervisor OS. It is only used, when the cluster is
+	// created. For an existing cluster, it must match the live service
+	// configuration of the servers.
+	ServicesConfig map[string]any `json:"services_config,omitempty" yaml:"services_config,omitempty"`
+
+	// ApplicationSeedConfig contains the seed configuration for the application,
+	// which is applied during post clustering. It is only used, when the cluster
+	// is created.
+	ApplicationSeedConfig map[string]any `json:"application_seed_config,omitempty" yaml:"application_seed_config,omitempty"`
+
+	// ClusterTemplate contains the name of a cluster template, which should be
+	// used for the cluster creation. If set, the values in ServicesConfig and
+	// ApplicationSeedConfig are disregarded. It is only used, when the cluster
+	// is created. For an existing cluster, it must match the cluster template,
+	// the cluster has been created from.
+	ClusterTemplate string `json:"cluster_template,omitempty" yaml:"cluster_template,omitempty"`
+
+	// ClusterTemplateVariableValues contains the variable values, which should
+	// be applied to the respective placeholders in the cluster template. For an
+	// existing cluster, they must match the recorded variable values.
+	ClusterTemplateVariableValues ConfigMap `json:"cluster_template_variable_values,omitempty" yaml:"cluster_template_variable_values,omitempty"`
+
+	// StorageTargets contains the storage targets, which are configured on all
+	// the members of the cluster.
+	StorageTargets ClusterSpecStorageTargets `json:"storage_targets" yaml:"storage_targets"`
+
+	// NetworkVLANTags contains the VLAN tags per network interface or bond,
+	// which are configured on all the members of the cluster. Interfaces and
+	// bonds, which are not listed, are not managed by the spec.
+	// Example (in YAML notation for readability):
+	//   network_vlan_tags:
+	//     eth0: [10, 20]
+	NetworkVLANTags map[string][]int `json:"network_vlan_tags,omitempty" yaml:"network_vlan_tags,omitempty"`
+
+	// Logging contains the logging configuration of all the members of the
+	// cluster. If not set, the logging configuration is not managed by the
+	// spec.
+	Logging *ServerSystemLogging `json:"logging,omitempty" yaml:"logging,omitempty"`
+
+	// Kernel contains the kernel configuration of all the members of the
+	// cluster. If not set, the kernel configuration is not managed by the spec.
+	Kernel *ServerSystemKernel `json:"kernel,omitempty" yaml:"kernel,omitempty"`
+}
+
+// ClusterSpecStorageTargets contains the storage targets of a cluster spec.
+// A list, which is not set, is not managed by the spec, an empty list removes
+// all the targets of the respective kind.
+type ClusterSpecStorageTargets struct {
+	// ISCSI contains the iSCSI targets.
+	ISCSI []incusosapi.ServiceISCSITarget `json:"iscsi,omitempty" yaml:"iscsi,omitempty"`
+
+	// Multipath contains the WWNs of the multipath targets.
+	// Example: ["360014051e5ef4a6d7a7a4e4d7c1e3e05"]
+	Multipath []string `json:"multipath,omitempty" yaml:"multipath,omitempty"`
+
+	// NVME contains the NVMe targets.
+	NVME []incusosapi.ServiceNVMETarget `json:"nvme,omitempty" yaml:"nvme,omitempty"`
+}
+
+// ClusterSpecChangeAction is the action of a change of a cluster spec plan.
+type ClusterSpecChangeAction string
+
+const (
+	ClusterSpecChangeActionCreateCluster                  ClusterSpecChangeAction = "create_cluster"
+	ClusterSpecChangeActionUpdateCluster                  ClusterSpecChangeAction = "update_cluster"
+	ClusterSpecChangeActionAddServers                     ClusterSpecChangeAction = "add_servers"
+	ClusterSpecChangeActionRemoveServers                  ClusterSpecChangeAction = "remove_servers"
+	ClusterSpecChangeActionAddISCSIStorageTarget          ClusterSpecChangeAction = "add_iscsi_storage_target"
+	ClusterSpecChangeActionRemoveISCSIStorageTarget       ClusterSpecChangeAction = "remove_iscsi_storage_target"
+	ClusterSpecChangeActionAddMultipathStorageTarget      ClusterSpecChangeAction = "add_multipath_storage_target"
+	ClusterSpecChangeActionRemoveMultipathStorageTarget   ClusterSpecChangeAction = "remove_multipath_storage_target"
+	ClusterSpecChangeActionAddNVMEStorageTarget           ClusterSpecChangeAction = "add_nvme_storage_target"
+	ClusterSpecChangeActionRemoveNVMEStorageTarget        ClusterSpecChangeAction = "remove_nvme_storage_target"
+	ClusterSpecChangeActionAddNetworkInterfaceVLANTags    ClusterSpecChangeAction = "add_network_interface_vlan_tags"
+	ClusterSpecChangeActionRemoveNetworkInterfaceVLANTags ClusterSpecChangeAction = "remove_network_interface_vlan_tags"
+	ClusterSpecChangeActionUpdateSystemLogging            ClusterSpecChangeAction = "update_system_logging"
+	ClusterSpecChangeActionUpdateSystemKernel             ClusterSpecChangeAction = "update_system_kernel"
+)
+
+// ClusterSpecChange is a single change, which is required to reconcile the
+// live state of a cluster with its spec.
+//
+// swagger:model
+type ClusterSpecChange struct {
+	// Action to be performed.
+	// Example: add_servers
+	Action ClusterSpecChangeAction `json:"action" yaml:"action"`
+
+	// Subject of the change, e.g. the changed field of the cluster, the names of
+	// the servers, the storage target or the network interface.
+	// Example: server3, server4
+	Subject string `json:"subject" yaml:"subject"`
+
+	// Current value of the subject, if applicable.
+	// Example: stable
+	Current string `json:"current,omitempty" yaml:"current,omitempty"`
+
+	// Desired value of the subject, if applicable.
+	// Example: testing
+	Desired string `json:"desired,omitempty" yaml:"desired,omitempty"`
+}
+
+// ClusterSpecPlan is the ordered list of changes, which are required to
+// reconcile the live state of a cluster with its spec.
+//
+// swagger:model
+type ClusterSpecPlan struct {
+	// Name of the cluster.
+	// Example: MyCluster
+	Name string `json:"name" yaml:"name"`
+
+	// Changes to be performed, in the order they are applied. An empty list
+	// means, the cluster matches its spec.
+	Changes []ClusterSpecChange `json:"changes" yaml:"changes"`
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 )
 
@@ -83,6 +84,42 @@ func (c *ClusterTemplateVariables) Scan(value any) error {
 	}
 }
 
+// ClusterTemplateVariableType is the type of a cluster template variable.
+type ClusterTemplateVariableType string
+
+const (
+	ClusterTemplateVariableTypeString   ClusterTemplateVariableType = "string"
+	ClusterTemplateVariableTypeInt      ClusterTemplateVariableType = "int"
+	ClusterTemplateVariableTypeBool     ClusterTemplateVariableType = "bool"
+	ClusterTemplateVariableTypeEnum     ClusterTemplateVariableType = "enum"
+	ClusterTemplateVariableTypeCIDR     ClusterTemplateVariableType = "cidr"
+	ClusterTemplateVariableTypeIP       ClusterTemplateVariableType = "ip"
+	ClusterTemplateVariableTypeDuration ClusterTemplateVariableType = "duration"
+	ClusterTemplateVariableTypeList     ClusterTemplateVariableType = "list"
+)
+
+var clusterTemplateVariableTypes = map[ClusterTemplateVariableType]struct{}{
+	ClusterTemplateVariableTypeString:   {},
+	ClusterTemplateVariableTypeInt:      {},
+	ClusterTemplateVariableTypeBool:     {},
+	ClusterTemplateVariableTypeEnum:     {},
+	ClusterTemplateVariableTypeCIDR:     {},
+	ClusterTemplateVariableTypeIP:       {},
+	ClusterTemplateVariableTypeDuration: {},
+	ClusterTemplateVariableTypeList:     {},
+}
+
+// IsValid returns true, if the type is one of the known cluster template
+// variable types. The empty type is valid and treated as string.
+func (c ClusterTemplateVariableType) IsValid() bool {
+	if c == "" {
+		return true
+	}
+
+	_, ok := clusterTemplateVariableTypes[c]
+	return ok
+}
+
 // ClusterTemplateVariable defines the properties of a variable, that
 // can be used in a cluster config template.
 type ClusterTemplateVariable struct {
@@ -94,6 +131,64 @@ type ClusterTemplateVariable struct {
 	// if no value is provided for the variable.
 	// Example: Incus cluster
 	DefaultValue string `json:"default" yaml:"default"`
+
+	// Type of the variable, the provided value is validated against.
+	// Possible values for type are: string, int, bool, enum, cidr, ip, duration,
+	// list. If not set, the variable is of type string.
+	// Example: int
+	Type ClusterTemplateVariableType `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Optional marks a variable without default value as optional. If no value
+	// is provided for an optional variable, the placeholder is replaced with an
+	// empty string. Variables without default value, which are not optional,
+	// are required.
+	// Example: true
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
+
+	// Pattern is a regular expression, the value of a variable of type string
+	// needs to match. For variables of type list, each element of the list needs
+	// to match the pattern.
+	// Example: ^[a-z][a-z0-9-]*$
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+
+	// Min is the minimum value (inclusive) of a variable of type int.
+	// Example: 1
+	Min *int64 `json:"min,omitempty" yaml:"min,omitempty"`
+
+	// Max is the maximum value (inclusive) of a variable of type int.
+	// Example: 4094
+	Max *int64 `json:"max,omitempty" yaml:"max,omitempty"`
+
+	// Values contains the allowed values of a variable of type enum.
+	// Example: ["stable", "testing"]
+	Values []string `json:"values,omitempty" yaml:"values,omitempty"`
+
+	// Secret marks the value of the variable as sensitive. Values of secret
+	// variables are masked, when they are returned by the API, and are never
+	// included in error messages.
+	// Example: true
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// Equal returns true, if both variable definitions are the same.
+func (c ClusterTemplateVariable) Equal(other ClusterTemplateVariable) bool {
+	return c.Description == other.Description &&
+		c.DefaultValue == other.DefaultValue &&
+		c.Type == other.Type &&
+		c.Optional == other.Optional &&
+		c.Pattern == other.Pattern &&
+		equalInt64Ptr(c.Min, other.Min) &&
+		equalInt64Ptr(c.Max, other.Max) &&
+		slices.Equal(c.Values, other.Values) &&
+		c.Secret == other.Secret
+}
+
+func equalInt64Ptr(a *int64, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
 }
 
 // ClusterTemplate defines a template, which can be used to form a cluster
@@ -103,7 +198,120 @@ type ClusterTemplateVariable struct {
 type ClusterTemplate struct {
 	ClusterTemplatePost `yaml:",inline"`
 
+	// Revision is the current revision of the cluster config template. The
+	// revision is incremented on every update, which changes the definition of
+	// the template.
+	// Example: 3
+	Revision int64 `json:"revision" yaml:"revision"`
+
 	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
 	// Example: 2024-11-12T16:15:00Z
 	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
 }
+
+// ClusterTemplateRevision is an immutable snapshot of the definition of a
+// cluster config template.
+//
+// swagger:model
+type ClusterTemplateRevision struct {
+	ClusterTemplatePut `yaml:",inline"`
+
+	// Name of the cluster config template.
+	// Example: MyTemplate
+	Name string `json:"name" yaml:"name"`
+
+	// Revision of the cluster config template.
+	// Example: 3
+	Revision int64 `json:"revision" yaml:"revision"`
+
+	// CreatedAt is the time, when this revision has been created in RFC3339 format.
+	// Example: 2024-11-12T16:15:00Z
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// ClusterTemplateRevisionDiff contains the differences between two revisions
+// of a cluster config template. The differences of the templates and the
+// variables are provided in unified diff format. Empty values indicate no
+// difference.
+//
+// swagger:model
+type ClusterTemplateRevisionDiff struct {
+	// Name of the cluster config template.
+	// Example: MyTemplate
+	Name string `json:"name" yaml:"name"`
+
+	// FromRevision is the revision, the diff starts from.
+	// Example: 2
+	FromRevision int64 `json:"from_revision" yaml:"from_revision"`
+
+	// ToRevision is the revision, the diff leads to.
+	// Example: 3
+	ToRevision int64 `json:"to_revision" yaml:"to_revision"`
+
+	// Description contains the differences of the description.
+	Description string `json:"description" yaml:"description"`
+
+	// ServiceConfigTemplate contains the differences of the service config
+	// template.
+	ServiceConfigTemplate string `json:"service_config_template" yaml:"service_config_template"`
+
+	// ApplicationConfigTemplate contains the differences of the application
+	// config template.
+	ApplicationConfigTemplate string `json:"application_config_template" yaml:"application_config_template"`
+
+	// Variables contains the differences of the variables.
+	Variables string `json:"variables" yaml:"variables"`
+}
+
+// ClusterTemplateDrift reports the differences between the service
+// configuration rendered from the cluster config template of a cluster and the
+// live service configuration of the servers of the cluster.
+//
+// swagger:model
+type ClusterTemplateDrift struct {
+	// Name of the cluster.
+	// Example: MyCluster
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// Template contains the cluster config template, which has been used to
+	// create the cluster.
+	Template ClusterTemplateReference `json:"template" yaml:"template"`
+
+	// CurrentRevision is the current revision of the cluster config template.
+	// Example: 4
+	CurrentRevision int64 `json:"current_revision" yaml:"current_revision"`
+
+	// Outdated is true, if the cluster config template has been changed since
+	// the cluster has been created.
+	// Example: true
+	Outdated bool `json:"outdated" yaml:"outdated"`
+
+	// Differences contains the differences between the rendered and the live
+	// service configuration. An empty list means, there is no drift.
+	Differences []ClusterTemplateDriftDifference `json:"differences" yaml:"differences"`
+}
+
+// ClusterTemplateDriftDifference is a single difference between the rendered
+// and the live service configuration of a server.
+type ClusterTemplateDriftDifference struct {
+	// Server is the name of the server.
+	// Example: server1
+	Server string `json:"server" yaml:"server"`
+
+	// Service is the name of the Hypervisor OS service.
+	// Example: lvm
+	Service string `json:"service" yaml:"service"`
+
+	// Key is the path of the differing configuration key.
+	// Example: enabled
+	Key string `json:"key" yaml:"key"`
+
+	// Expected is the value rendered from the cluster config template in JSON
+	// format.
+	// Example: true
+	Expected string `json:"expected" yaml:"expected"`
+
+	// Actual is the live value on the server in JSON format.
+	// Example: false
+	Actual string `json:"actual" yaml:"actual"`
+}
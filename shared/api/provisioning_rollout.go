@@ -0,0 +1,179 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RolloutStatus is the status of a fleet rollout.
+type RolloutStatus string
+
+const (
+	RolloutStatusRunning   RolloutStatus = "running"
+	RolloutStatusSucceeded RolloutStatus = "succeeded"
+	RolloutStatusFailed    RolloutStatus = "failed"
+	RolloutStatusCanceled  RolloutStatus = "canceled"
+)
+
+// RolloutWaveStatus is the status of the current wave of a fleet rollout.
+type RolloutWaveStatus string
+
+const (
+	// RolloutWaveStatusPending is the status of a wave, for which the cluster
+	// updates have not yet been launched.
+	RolloutWaveStatusPending RolloutWaveStatus = "pending"
+
+	// RolloutWaveStatusUpdating is the status of a wave, while the cluster
+	// updates of the clusters in the wave are in progress.
+	RolloutWaveStatusUpdating RolloutWaveStatus = "updating"
+
+	// RolloutWaveStatusSoaking is the status of a wave, where all the cluster
+	// updates have finished successfully and the rollout waits for the soak
+	// time of the wave to pass, before it moves on to the next wave.
+	RolloutWaveStatusSoaking RolloutWaveStatus = "soaking"
+)
+
+// RolloutWave defines a group of clusters, which are updated together as
+// part of a fleet rollout.
+type RolloutWave struct {
+	// Name of the wave.
+	// Example: lab
+	Name string `json:"name" yaml:"name"`
+
+	// ClusterFilter is an expression over the properties of a cluster, which
+	// selects the clusters, that are part of the wave.
+	// Example: properties.stage == "lab"
+	ClusterFilter string `json:"cluster_filter" yaml:"cluster_filter"`
+
+	// SoakTime is the time, the rollout waits after all the cluster updates of
+	// the wave have finished successfully, before it moves on to the next wave.
+	// The value is a duration (e.g. "48h"), an empty value means, the rollout
+	// moves on to the next wave immediately.
+	// Example: 48h
+	SoakTime string `json:"soak_time" yaml:"soak_time"`
+}
+
+// SoakDuration returns the soak time of the wave as duration.
+func (r RolloutWave) SoakDuration() (time.Duration, error) {
+	if r.SoakTime == "" {
+		return 0, nil
+	}
+
+	soakDuration, err := time.ParseDuration(r.SoakTime)
+	if err != nil {
+		return 0, err
+	}
+
+	if soakDuration < 0 {
+		return 0, fmt.Errorf("soak time %q is negative", r.SoakTime)
+	}
+
+	return soakDuration, nil
+}
+
+// RolloutWaves is the ordered list of waves of a fleet rollout.
+type RolloutWaves []RolloutWave
+
+// Value implements the sql driver.Valuer interface.
+func (r RolloutWaves) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *RolloutWaves) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid rollout waves")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*r = RolloutWaves{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), r)
+
+	case []byte:
+		if len(v) == 0 {
+			*r = RolloutWaves{}
+			return nil
+		}
+
+		return json.Unmarshal(v, r)
+
+	default:
+		return fmt.Errorf("type %T is not supported for rollout waves", value)
+	}
+}
+
+// RolloutPost defines a fleet rollout, which updates clusters in waves.
+//
+// swagger:model
+type RolloutPost struct {
+	// A human-friendly name for this rollout.
+	// Example: 2025-01
+	Name string `json:"name" yaml:"name"`
+
+	// Description of the rollout.
+	// Example: Roll out the January updates.
+	Description string `json:"description" yaml:"description"`
+
+	// Reboot defines, if the servers are rebooted as part of the cluster
+	// updates.
+	// Example: true
+	Reboot bool `json:"reboot" yaml:"reboot"`
+
+	// Waves is the ordered list of waves of the rollout.
+	Waves RolloutWaves `json:"waves" yaml:"waves"`
+}
+
+// Rollout defines a fleet rollout, which updates clusters in waves.
+//
+// swagger:model
+type Rollout struct {
+	RolloutPost `yaml:",inline"`
+
+	// TargetVersion is the version of the most recent update, which has been
+	// available in the channels of the clusters of the rollout, when the
+	// rollout has been created. The rollout only rolls out updates up to this
+	// version.
+	// Example: 202501020304
+	TargetVersion string `json:"target_version" yaml:"target_version"`
+
+	// Status of the rollout.
+	// Example: running
+	Status RolloutStatus `json:"status" yaml:"status"`
+
+	// CurrentWave is the index of the wave, the rollout is currently
+	// processing, starting with 0.
+	// Example: 1
+	CurrentWave int `json:"current_wave" yaml:"current_wave"`
+
+	// WaveStatus is the status of the current wave.
+	// Example: updating
+	WaveStatus RolloutWaveStatus `json:"wave_status" yaml:"wave_status"`
+
+	// WaveClusters holds the names of the clusters, which are part of the
+	// current wave. The clusters are selected, when the wave is started.
+	// Clusters, which are already up to date at this time, are not part of
+	// the wave.
+	// Example: ["one", "two"]
+	WaveClusters []string `json:"wave_clusters" yaml:"wave_clusters"`
+
+	// WaveFinishedAt is the time, when all the cluster updates of the current
+	// wave have finished successfully. The soak time of the wave starts at
+	// this time.
+	// Example: 2025-01-02T10:00:00Z
+	WaveFinishedAt time.Time `json:"wave_finished_at,omitzero" yaml:"wave_finished_at,omitempty"`
+
+	// Error contains the error description, if the rollout failed.
+	// Example: Cluster update of "one" failed: Failed to trigger next action
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
+	// Example: 2024-11-12T16:15:00Z
+	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
+}
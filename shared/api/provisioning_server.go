@@ -518,7 +518,7 @@ func (s *ServerVersionData) Compute(osName string, latestAvailableVersions map[s
 			currentOrPendingVersion = s.OS.VersionNext
 		}
 
-		s.OS.NeedsUpdate = ptr.To(availableVersionGreaterThan(currentOrPendingVersion, osLatestAvailableVersion))
+		s.OS.NeedsUpdate = ptr.To(AvailableVersionGreaterThan(currentOrPendingVersion, osLatestAvailableVersion))
 	}
 
 	// Set per application AvailableVersion and NeedsUpdate.
@@ -526,7 +526,7 @@ func (s *ServerVersionData) Compute(osName string, latestAvailableVersions map[s
 		appLatestAvailableVersion, ok := latestAvailableVersions[s.Applications[i].Name]
 		if ok {
 			s.Applications[i].AvailableVersion = &appLatestAvailableVersion
-			s.Applications[i].NeedsUpdate = ptr.To(availableVersionGreaterThan(s.Applications[i].Version, appLatestAvailableVersion))
+			s.Applications[i].NeedsUpdate = ptr.To(AvailableVersionGreaterThan(s.Applications[i].Version, appLatestAvailableVersion))
 		}
 	}
 
@@ -545,7 +545,10 @@ func (s *ServerVersionData) Compute(osName string, latestAvailableVersions map[s
 	}
 }
 
-func availableVersionGreaterThan(currentVersion string, availableVersion string) bool {
+// AvailableVersionGreaterThan returns true, if availableVersion is more recent
+// than currentVersion. Invalid versions are considered to be older than any
+// valid version.
+func AvailableVersionGreaterThan(currentVersion string, availableVersion string) bool {
 	current, err := strconv.ParseInt(currentVersion, 16, 64)
 	if err != nil {
 		current = math.MinInt // invalid versions are moved to the end.
@@ -694,6 +697,11 @@ type BMCData struct {
 	// reported by the BMC system or manager, keyed by "<service>:<id>" (e.g. "system:1").
 	VirtualMedia map[string]BMCVirtualMedia `json:"virtual_media" yaml:"virtual_media"`
 
+	// Firmware holds the firmware inventory (e.g. BIOS, BMC, NIC, RAID)
+	// reported by the update service of the BMC, keyed by the ID of the
+	// inventory entry.
+	Firmware map[string]BMCFirmware `json:"firmware" yaml:"firmware"`
+
 	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
 	// Example: 2024-11-12T16:15:00Z
 	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
@@ -739,6 +747,11 @@ type ServerPut struct {
 	// Example: stable
 	Channel string `json:"channel" yaml:"channel"`
 
+	// Site the server belongs to. Empty, if the server is not assigned to a
+	// site. For clustered servers, the site is inherited from the cluster.
+	// Example: zurich
+	Site string `json:"site" yaml:"site"`
+
 	// Description of the server.
 	// Example: Lab server with limited resources.
 	Description string `json:"description" yaml:"description"`
@@ -801,6 +814,14 @@ type Server struct {
 	// Example: rebooting
 	StatusDetail ServerStatusDetail `json:"server_status_detail" yaml:"server_status_detail"`
 
+	// ClusterJoin holds the automatic join of the server to the cluster defined
+	// by the token, the server has been registered with.
+	ClusterJoin ServerClusterJoin `json:"cluster_join" yaml:"cluster_join"`
+
+	// BMCInstall holds the state of the installation of IncusOS on the server
+	// using the virtual media of its BMC.
+	BMCInstall ServerBMCInstall `json:"bmc_install" yaml:"bmc_install"`
+
 	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
 	// Example: 2024-11-12T16:15:00Z
 	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -170,3 +171,124 @@ type BIOSAttribute struct {
 	// Example: ["UserMode", "SetupMode"]
 	AcceptableValues []string `json:"acceptable_values" yaml:"acceptable_values"`
 }
+
+// BMCFirmwareComponent is the kind of component, an entry of the firmware
+// inventory reported by the BMC belongs to.
+type BMCFirmwareComponent string
+
+const (
+	BMCFirmwareComponentBIOS  BMCFirmwareComponent = "bios"
+	BMCFirmwareComponentBMC   BMCFirmwareComponent = "bmc"
+	BMCFirmwareComponentNIC   BMCFirmwareComponent = "nic"
+	BMCFirmwareComponentRAID  BMCFirmwareComponent = "raid"
+	BMCFirmwareComponentOther BMCFirmwareComponent = "other"
+)
+
+func (c BMCFirmwareComponent) String() string {
+	return string(c)
+}
+
+// BMCFirmware defines a single entry of the firmware inventory reported by
+// the update service of the BMC.
+//
+// swagger:model
+type BMCFirmware struct {
+	// ID identifies the entry in the firmware inventory of the BMC.
+	// Example: BIOS
+	ID string `json:"id" yaml:"id"`
+
+	// Name holds the name of the firmware as reported by the BMC.
+	// Example: BIOS
+	Name string `json:"name" yaml:"name"`
+
+	// Component holds the kind of component, the firmware belongs to. One of
+	// bios, bmc, nic, raid or other.
+	// Example: bios
+	Component BMCFirmwareComponent `json:"component" yaml:"component"`
+
+	// Version holds the version of the firmware.
+	// Example: 1.7.5
+	Version string `json:"version" yaml:"version"`
+
+	// Manufacturer holds the manufacturer of the firmware.
+	// Example: Dell Inc.
+	Manufacturer string `json:"manufacturer" yaml:"manufacturer"`
+
+	// ReleaseDate holds the release date of the firmware as reported by the BMC.
+	// Example: 2025-01-15T00:00:00Z
+	ReleaseDate string `json:"release_date" yaml:"release_date"`
+
+	// Updateable reports, if the firmware can be updated through the update
+	// service of the BMC.
+	// Example: true
+	Updateable bool `json:"updateable" yaml:"updateable"`
+}
+
+// BMCFirmwareUpdateMethod defines, how a firmware image is transferred to the
+// BMC.
+type BMCFirmwareUpdateMethod string
+
+const (
+	// BMCFirmwareUpdateMethodSimpleUpdate lets the BMC fetch the firmware image
+	// from the image URI by itself (Redfish SimpleUpdate).
+	BMCFirmwareUpdateMethodSimpleUpdate BMCFirmwareUpdateMethod = "simple-update"
+
+	// BMCFirmwareUpdateMethodPush makes Operations Center fetch the firmware
+	// image from the image URI and push it to the BMC (Redfish multipart HTTP
+	// push). This is useful, if the BMC can not reach the image URI.
+	BMCFirmwareUpdateMethodPush BMCFirmwareUpdateMethod = "push"
+)
+
+var bmcFirmwareUpdateMethods = map[BMCFirmwareUpdateMethod]struct{}{
+	"":                                  {},
+	BMCFirmwareUpdateMethodSimpleUpdate: {},
+	BMCFirmwareUpdateMethodPush:         {},
+}
+
+func (m BMCFirmwareUpdateMethod) String() string {
+	return string(m)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (m BMCFirmwareUpdateMethod) MarshalText() ([]byte, error) {
+	return []byte(m), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (m *BMCFirmwareUpdateMethod) UnmarshalText(text []byte) error {
+	_, ok := bmcFirmwareUpdateMethods[BMCFirmwareUpdateMethod(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid BMC firmware update method", string(text))
+	}
+
+	*m = BMCFirmwareUpdateMethod(text)
+
+	return nil
+}
+
+// BMCFirmwareUpdatePost represents a request to update the firmware of a
+// server or of all servers of a cluster via their BMC.
+//
+// swagger:model
+type BMCFirmwareUpdatePost struct {
+	// ImageURI is the URI of the firmware image.
+	// Example: https://downloads.example.com/firmware/BIOS_1.8.2.exe
+	ImageURI string `json:"image_uri" yaml:"image_uri"`
+
+	// Method defines, how the firmware image is transferred to the BMC. One of
+	// simple-update (the BMC fetches the image) or push (Operations Center
+	// fetches the image and pushes it to the BMC). Defaults to simple-update.
+	// Example: simple-update
+	Method BMCFirmwareUpdateMethod `json:"method" yaml:"method"`
+
+	// SHA256 is the SHA-256 checksum of the firmware image as hex string. It is
+	// required for the push method, the downloaded image is verified against
+	// it before it is pushed to the BMC.
+	// Example: 5d5b6a1d4d8b3d3c2f56b0f9c3b54f5f2f6f2d2e7c1c5d2a0d8b5f1c6e9a7b3c
+	SHA256 string `json:"sha256" yaml:"sha256"`
+
+	// Targets holds the IDs of the firmware inventory entries, the image is
+	// applied to. If empty, the BMC determines the applicable components.
+	// Example: ["BIOS"]
+	Targets []string `json:"targets" yaml:"targets"`
+}
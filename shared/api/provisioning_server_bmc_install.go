@@ -0,0 +1,152 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lxc/incus-os/incus-osd/api/images"
+)
+
+// ServerBMCInstallStatus is the status of the installation of IncusOS on a
+// server using the virtual media of its BMC.
+type ServerBMCInstallStatus string
+
+const (
+	ServerBMCInstallStatusNone       ServerBMCInstallStatus = ""
+	ServerBMCInstallStatusPreparing  ServerBMCInstallStatus = "preparing"
+	ServerBMCInstallStatusBooting    ServerBMCInstallStatus = "booting"
+	ServerBMCInstallStatusRegistered ServerBMCInstallStatus = "registered"
+	ServerBMCInstallStatusFailed     ServerBMCInstallStatus = "failed"
+)
+
+var serverBMCInstallStatuses = map[ServerBMCInstallStatus]struct{}{
+	ServerBMCInstallStatusNone:       {},
+	ServerBMCInstallStatusPreparing:  {},
+	ServerBMCInstallStatusBooting:    {},
+	ServerBMCInstallStatusRegistered: {},
+	ServerBMCInstallStatusFailed:     {},
+}
+
+func (s ServerBMCInstallStatus) String() string {
+	return string(s)
+}
+
+// IsInProgress returns true, if the installation has been started and the
+// server did not yet register.
+func (s ServerBMCInstallStatus) IsInProgress() bool {
+	return s == ServerBMCInstallStatusPreparing || s == ServerBMCInstallStatusBooting
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ServerBMCInstallStatus) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ServerBMCInstallStatus) UnmarshalText(text []byte) error {
+	_, ok := serverBMCInstallStatuses[ServerBMCInstallStatus(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid server BMC install status", string(text))
+	}
+
+	*s = ServerBMCInstallStatus(text)
+
+	return nil
+}
+
+// ServerBMCInstallPost represents a request to install IncusOS on a bare
+// server using the virtual media of its BMC.
+//
+// swagger:model
+type ServerBMCInstallPost struct {
+	// Token is the UUID of the token, the pre-seeded install image is built
+	// for and the server registers with.
+	// Example: b32d0079-c48b-4957-b1cb-bef54125c861
+	Token uuid.UUID `json:"token" yaml:"token"`
+
+	// TokenSeed is the name of the token seed, the install image is
+	// pre-seeded with.
+	// Example: unattended-install
+	TokenSeed string `json:"token_seed" yaml:"token_seed"`
+
+	// Architecture of the install image. If not set, the architecture is
+	// derived from the processor instruction set reported by the BMC.
+	// Example: x86_64
+	Architecture images.UpdateFileArchitecture `json:"architecture" yaml:"architecture"`
+
+	// Channel, the install image is taken from. If not set, the channel of the
+	// token is used.
+	// Example: stable
+	Channel string `json:"channel" yaml:"channel"`
+
+	// VirtualMedia is the ID of the virtual media slot as reported in the BMC
+	// data, the install image is inserted into. If not set, the first slot
+	// supporting CD or DVD media is used.
+	// Example: system:1
+	VirtualMedia string `json:"virtual_media" yaml:"virtual_media"`
+
+	// If set to true, the install image is built again, even if an image built
+	// for the same token seed is available.
+	// Example: false
+	Rebuild bool `json:"rebuild" yaml:"rebuild"`
+}
+
+// ServerBMCInstall holds the state of the installation of IncusOS on a server
+// using the virtual media of its BMC.
+//
+// swagger:model
+type ServerBMCInstall struct {
+	// ID identifies the installation. It is part of the URL, the install
+	// image is served from.
+	// Example: 4c5b1b0e-8f0d-4c1e-9f3a-2f8d1c6e7a90
+	ID uuid.UUID `json:"id" yaml:"id"`
+
+	// Token is the UUID of the token, the install image is built for.
+	// Example: b32d0079-c48b-4957-b1cb-bef54125c861
+	Token uuid.UUID `json:"token" yaml:"token"`
+
+	// TokenSeed is the name of the token seed, the install image is
+	// pre-seeded with.
+	// Example: unattended-install
+	TokenSeed string `json:"token_seed" yaml:"token_seed"`
+
+	// Architecture of the install image.
+	// Example: x86_64
+	Architecture images.UpdateFileArchitecture `json:"architecture" yaml:"architecture"`
+
+	// Channel, the install image is taken from.
+	// Example: stable
+	Channel string `json:"channel" yaml:"channel"`
+
+	// VirtualMedia is the ID of the virtual media slot, the install image is
+	// inserted into.
+	// Example: system:1
+	VirtualMedia string `json:"virtual_media" yaml:"virtual_media"`
+
+	// MediaURL is the URL, the BMC fetches the install image from. The URL is
+	// only valid, while the installation is in progress and until the media
+	// expires.
+	// Example: https://operations-center.local:8443/1.0/provisioning/servers/server01/bmc/install-media/4c5b1b0e-8f0d-4c1e-9f3a-2f8d1c6e7a90/install.iso
+	MediaURL string `json:"media_url" yaml:"media_url"`
+
+	// MediaExpiresAt is the time, after which the install image is no longer
+	// served from the media URL. Once the install image has been downloaded
+	// completely, the media expires right away.
+	// Example: 2025-01-02T11:00:00Z
+	MediaExpiresAt time.Time `json:"media_expires_at" yaml:"media_expires_at"`
+
+	// Status of the installation. Empty, if the server has not been installed
+	// using the virtual media of its BMC.
+	// Example: booting
+	Status ServerBMCInstallStatus `json:"status" yaml:"status"`
+
+	// Error contains the error description, if the installation failed.
+	// Example: Failed to insert virtual media: no virtual media slot supporting CD or DVD media found
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// LastUpdated is the time, when the status of the installation has been
+	// updated for the last time.
+	// Example: 2025-01-02T10:00:00Z
+	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ServerBulkAction is an action, which is performed on every server selected
+// by a server bulk action request.
+type ServerBulkAction string
+
+const (
+	ServerBulkActionInvalid             ServerBulkAction = ""
+	ServerBulkActionReboot              ServerBulkAction = "reboot"
+	ServerBulkActionUpdate              ServerBulkAction = "update"
+	ServerBulkActionUpdateSystemLogging ServerBulkAction = "update_system_logging"
+	ServerBulkActionAddApplication      ServerBulkAction = "add_application"
+)
+
+var serverBulkActions = map[ServerBulkAction]struct{}{
+	ServerBulkActionReboot:              {},
+	ServerBulkActionUpdate:              {},
+	ServerBulkActionUpdateSystemLogging: {},
+	ServerBulkActionAddApplication:      {},
+}
+
+func (a ServerBulkAction) String() string {
+	return string(a)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a ServerBulkAction) MarshalText() ([]byte, error) {
+	return []byte(a), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *ServerBulkAction) UnmarshalText(text []byte) error {
+	_, ok := serverBulkActions[ServerBulkAction(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid server bulk action", string(text))
+	}
+
+	*a = ServerBulkAction(text)
+
+	return nil
+}
+
+// ServerBulkActionPost represents a request to perform an action on all the
+// servers matching a filter expression. Reboots and updates are only
+// permitted for standalone servers, cluster members are rebooted and updated
+// by the cluster wide operations.
+//
+// swagger:model
+type ServerBulkActionPost struct {
+	// Filter is the filter expression, which selects the servers, the action
+	// is performed on. The expression is evaluated in the same way as the
+	// "filter" query parameter of the servers list endpoint.
+	// Example: properties.rack == "r12" && status == "ready"
+	Filter string `json:"filter" yaml:"filter"`
+
+	// Action to be performed on the selected servers.
+	// Example: reboot
+	Action ServerBulkAction `json:"action" yaml:"action"`
+
+	// Arguments for the action, the exact structure depends on the
+	// defined action.
+	Arguments *json.RawMessage `json:"arguments" yaml:"arguments"`
+
+	// Concurrency is the maximum number of servers, the action is performed
+	// on at the same time. If not set, the default limit is used.
+	// Example: 5
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+}
+
+// ServerBulkActionStatus is the status of a bulk action for a single server.
+type ServerBulkActionStatus string
+
+const (
+	ServerBulkActionStatusSucceeded ServerBulkActionStatus = "succeeded"
+	ServerBulkActionStatusFailed    ServerBulkActionStatus = "failed"
+	ServerBulkActionStatusDryRun    ServerBulkActionStatus = "dry-run"
+)
+
+var serverBulkActionStatuses = map[ServerBulkActionStatus]struct{}{
+	ServerBulkActionStatusSucceeded: {},
+	ServerBulkActionStatusFailed:    {},
+	ServerBulkActionStatusDryRun:    {},
+}
+
+func (s ServerBulkActionStatus) String() string {
+	return string(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ServerBulkActionStatus) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ServerBulkActionStatus) UnmarshalText(text []byte) error {
+	_, ok := serverBulkActionStatuses[ServerBulkActionStatus(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid server bulk action status", string(text))
+	}
+
+	*s = ServerBulkActionStatus(text)
+
+	return nil
+}
+
+// ServerBulkActionResult is the result of a bulk action for a single server.
+//
+// swagger:model
+type ServerBulkActionResult struct {
+	// Server is the name of the server.
+	// Example: server01
+	Server string `json:"server" yaml:"server"`
+
+	// Cluster is the name of the cluster the server belongs to. Empty for
+	// standalone servers.
+	// Example: cluster01
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// Status of the action on the server.
+	// Example: succeeded
+	Status ServerBulkActionStatus `json:"status" yaml:"status"`
+
+	// Error contains the error description, if the action failed on the server.
+	// Example: server operation in flight
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// ServerClusterJoinStatus is the status of the automatic join of a server to
+// the cluster defined by its registration token.
+type ServerClusterJoinStatus string
+
+const (
+	ServerClusterJoinStatusNone    ServerClusterJoinStatus = ""
+	ServerClusterJoinStatusPending ServerClusterJoinStatus = "pending"
+	ServerClusterJoinStatusJoining ServerClusterJoinStatus = "joining"
+	ServerClusterJoinStatusJoined  ServerClusterJoinStatus = "joined"
+	ServerClusterJoinStatusFailed  ServerClusterJoinStatus = "failed"
+)
+
+var serverClusterJoinStatuses = map[ServerClusterJoinStatus]struct{}{
+	ServerClusterJoinStatusNone:    {},
+	ServerClusterJoinStatusPending: {},
+	ServerClusterJoinStatusJoining: {},
+	ServerClusterJoinStatusJoined:  {},
+	ServerClusterJoinStatusFailed:  {},
+}
+
+func (s ServerClusterJoinStatus) String() string {
+	return string(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ServerClusterJoinStatus) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ServerClusterJoinStatus) UnmarshalText(text []byte) error {
+	_, ok := serverClusterJoinStatuses[ServerClusterJoinStatus(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid server cluster join status", string(text))
+	}
+
+	*s = ServerClusterJoinStatus(text)
+
+	return nil
+}
+
+// ServerClusterJoin holds the automatic join of a server to the cluster
+// defined by the token, the server has been registered with.
+//
+// swagger:model
+type ServerClusterJoin struct {
+	// Cluster is the name of the cluster, the server joins.
+	// Example: cluster01
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// If set to true, the post join operations are skipped.
+	// Example: false
+	SkipPostJoinOperations bool `json:"skip_post_join_operations" yaml:"skip_post_join_operations"`
+
+	// If set to true, the IncusOS services configuration is copied from an
+	// existing member of the cluster to the server, before it joins the cluster.
+	// Example: true
+	CopyServicesConfig bool `json:"copy_services_config" yaml:"copy_services_config"`
+
+	// Status of the join. Empty, if the server does not join a cluster
+	// automatically.
+	// Example: joined
+	Status ServerClusterJoinStatus `json:"status" yaml:"status"`
+
+	// Error contains the error description, if the join failed.
+	// Example: Failed to add servers due to configuration inconsistencies
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// LastUpdated is the time, when the status of the join has been updated
+	// for the last time.
+	// Example: 2025-01-02T10:00:00Z
+	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
+}
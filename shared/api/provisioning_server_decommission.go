@@ -0,0 +1,290 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServerDecommissionStep is a step of the decommission of a server.
+type ServerDecommissionStep string
+
+const (
+	ServerDecommissionStepEvacuate          ServerDecommissionStep = "evacuate"
+	ServerDecommissionStepRemoveFromCluster ServerDecommissionStep = "remove-from-cluster"
+	ServerDecommissionStepFactoryReset      ServerDecommissionStep = "factory-reset"
+	ServerDecommissionStepBMCPowerOff       ServerDecommissionStep = "bmc-power-off"
+	ServerDecommissionStepWipeDisks         ServerDecommissionStep = "wipe-disks"
+	ServerDecommissionStepDelete            ServerDecommissionStep = "delete"
+)
+
+// ServerDecommissionSteps contains all the steps of the decommission of a
+// server in the order, they are performed.
+var ServerDecommissionSteps = []ServerDecommissionStep{
+	ServerDecommissionStepEvacuate,
+	ServerDecommissionStepRemoveFromCluster,
+	ServerDecommissionStepFactoryReset,
+	ServerDecommissionStepBMCPowerOff,
+	ServerDecommissionStepWipeDisks,
+	ServerDecommissionStepDelete,
+}
+
+// ServerDecommissionStepStatus is the status of a step of the decommission of
+// a server.
+type ServerDecommissionStepStatus string
+
+const (
+	ServerDecommissionStepStatusDone    ServerDecommissionStepStatus = "done"
+	ServerDecommissionStepStatusSkipped ServerDecommissionStepStatus = "skipped"
+	ServerDecommissionStepStatusFailed  ServerDecommissionStepStatus = "failed"
+)
+
+// ServerDecommissionStatus is the status of the decommission of a server.
+type ServerDecommissionStatus string
+
+const (
+	ServerDecommissionStatusRunning   ServerDecommissionStatus = "running"
+	ServerDecommissionStatusSucceeded ServerDecommissionStatus = "succeeded"
+	ServerDecommissionStatusFailed    ServerDecommissionStatus = "failed"
+)
+
+var serverDecommissionStatuses = map[ServerDecommissionStatus]struct{}{
+	ServerDecommissionStatusRunning:   {},
+	ServerDecommissionStatusSucceeded: {},
+	ServerDecommissionStatusFailed:    {},
+}
+
+func (s ServerDecommissionStatus) String() string {
+	return string(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ServerDecommissionStatus) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ServerDecommissionStatus) UnmarshalText(text []byte) error {
+	_, ok := serverDecommissionStatuses[ServerDecommissionStatus(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid server decommission status", string(text))
+	}
+
+	*s = ServerDecommissionStatus(text)
+
+	return nil
+}
+
+// ServerDecommissionOptions defines, which steps are performed during the
+// decommission of a server. Steps, which are not enabled, are skipped.
+//
+// swagger:model
+type ServerDecommissionOptions struct {
+	// Evacuate defines, if the server is evacuated before it is removed from
+	// its cluster.
+	// Example: true
+	Evacuate bool `json:"evacuate" yaml:"evacuate"`
+
+	// RemoveFromCluster defines, if the server is removed from its cluster.
+	// Example: true
+	RemoveFromCluster bool `json:"remove_from_cluster" yaml:"remove_from_cluster"`
+
+	// FactoryReset defines, if a factory reset of IncusOS is performed on the
+	// server.
+	// Example: true
+	FactoryReset bool `json:"factory_reset" yaml:"factory_reset"`
+
+	// BMCPowerOff defines, if the server is powered off via its BMC.
+	// Example: true
+	BMCPowerOff bool `json:"bmc_power_off" yaml:"bmc_power_off"`
+
+	// WipeDisks defines, if the disks of the server are securely erased via its
+	// BMC. The erasure of the disks is only considered done, once the BMC has
+	// confirmed the completion for every disk.
+	// Example: false
+	WipeDisks bool `json:"wipe_disks" yaml:"wipe_disks"`
+
+	// Delete defines, if the server is removed from the inventory of
+	// Operations Center.
+	// Example: true
+	Delete bool `json:"delete" yaml:"delete"`
+}
+
+// Enabled returns true, if the given step is enabled.
+func (o ServerDecommissionOptions) Enabled(step ServerDecommissionStep) bool {
+	switch step {
+	case ServerDecommissionStepEvacuate:
+		return o.Evacuate
+	case ServerDecommissionStepRemoveFromCluster:
+		return o.RemoveFromCluster
+	case ServerDecommissionStepFactoryReset:
+		return o.FactoryReset
+	case ServerDecommissionStepBMCPowerOff:
+		return o.BMCPowerOff
+	case ServerDecommissionStepWipeDisks:
+		return o.WipeDisks
+	case ServerDecommissionStepDelete:
+		return o.Delete
+	default:
+		return false
+	}
+}
+
+// Value implements the sql driver.Valuer interface.
+func (o ServerDecommissionOptions) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+
+// Scan implements the sql.Scanner interface.
+func (o *ServerDecommissionOptions) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid server decommission options")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*o = ServerDecommissionOptions{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), o)
+
+	case []byte:
+		if len(v) == 0 {
+			*o = ServerDecommissionOptions{}
+			return nil
+		}
+
+		return json.Unmarshal(v, o)
+
+	default:
+		return fmt.Errorf("type %T is not supported for server decommission options", value)
+	}
+}
+
+// ServerDecommissionPost represents the request to decommission a server.
+//
+// swagger:model
+type ServerDecommissionPost struct {
+	ServerDecommissionOptions `yaml:",inline"`
+
+	// ConfirmDiskWipe needs to be set to the name of the server, if WipeDisks is
+	// enabled, to confirm the irreversible erasure of the disks.
+	// Example: server01
+	ConfirmDiskWipe string `json:"confirm_disk_wipe" yaml:"confirm_disk_wipe"`
+
+	// Resume defines, if the last failed decommission of the server is resumed.
+	// If set, the options of the failed decommission are used and the steps,
+	// which have already been done, are not performed again.
+	// Example: false
+	Resume bool `json:"resume" yaml:"resume"`
+}
+
+// ServerDecommissionStepResult is the record of a single step of the
+// decommission of a server.
+//
+// swagger:model
+type ServerDecommissionStepResult struct {
+	// Step is the step of the decommission.
+	// Example: evacuate
+	Step ServerDecommissionStep `json:"step" yaml:"step"`
+
+	// Status is the status of the step.
+	// Example: done
+	Status ServerDecommissionStepStatus `json:"status" yaml:"status"`
+
+	// Detail holds additional information about the step, e.g. the reason,
+	// why it has been skipped, or the disks, which have been erased.
+	// Example: server is not part of a cluster
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+
+	// StartedAt is the time, when the step has been started.
+	// Example: 2025-01-02T10:05:00Z
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// FinishedAt is the time, when the step has finished.
+	// Example: 2025-01-02T10:07:30Z
+	FinishedAt time.Time `json:"finished_at" yaml:"finished_at"`
+}
+
+// ServerDecommissionStepResults is the list of the records of the steps of
+// the decommission of a server.
+type ServerDecommissionStepResults []ServerDecommissionStepResult
+
+// Value implements the sql driver.Valuer interface.
+func (r ServerDecommissionStepResults) Value() (driver.Value, error) {
+	if r == nil {
+		r = ServerDecommissionStepResults{}
+	}
+
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *ServerDecommissionStepResults) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid server decommission step results")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*r = ServerDecommissionStepResults{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), r)
+
+	case []byte:
+		if len(v) == 0 {
+			*r = ServerDecommissionStepResults{}
+			return nil
+		}
+
+		return json.Unmarshal(v, r)
+
+	default:
+		return fmt.Errorf("type %T is not supported for server decommission step results", value)
+	}
+}
+
+// ServerDecommission is the audit record of the decommission of a server.
+//
+// swagger:model
+type ServerDecommission struct {
+	// UUID of the decommission.
+	// Example: b32d0079-c48b-4957-b1cb-bef54125c861
+	UUID uuid.UUID `json:"uuid" yaml:"uuid"`
+
+	// Server is the name of the decommissioned server.
+	// Example: server01
+	Server string `json:"server" yaml:"server"`
+
+	// Options holds the steps, which have been requested for the decommission.
+	Options ServerDecommissionOptions `json:"options" yaml:"options"`
+
+	// Status of the decommission.
+	// Example: succeeded
+	Status ServerDecommissionStatus `json:"status" yaml:"status"`
+
+	// Steps holds the record of the steps, which have been performed or
+	// skipped, in the order they have been processed.
+	Steps ServerDecommissionStepResults `json:"steps" yaml:"steps"`
+
+	// Error contains the error description, if the decommission failed.
+	// Example: Failed to remove server from cluster
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// StartedAt is the time, when the decommission has been started.
+	// Example: 2025-01-02T10:00:00Z
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// FinishedAt is the time, when the decommission has finished. It is not
+	// set as long as the decommission is running.
+	// Example: 2025-01-02T11:30:00Z
+	FinishedAt time.Time `json:"finished_at,omitzero" yaml:"finished_at,omitempty"`
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// DiskHealthStatus is the evaluated health status of a disk.
+type DiskHealthStatus string
+
+const (
+	DiskHealthStatusOK      DiskHealthStatus = "ok"
+	DiskHealthStatusWarning DiskHealthStatus = "warning"
+	DiskHealthStatusFailing DiskHealthStatus = "failing"
+	DiskHealthStatusUnknown DiskHealthStatus = "unknown"
+)
+
+var diskHealthStatuses = map[DiskHealthStatus]struct{}{
+	DiskHealthStatusOK:      {},
+	DiskHealthStatusWarning: {},
+	DiskHealthStatusFailing: {},
+	DiskHealthStatusUnknown: {},
+}
+
+func (s DiskHealthStatus) String() string {
+	return string(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s DiskHealthStatus) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *DiskHealthStatus) UnmarshalText(text []byte) error {
+	_, ok := diskHealthStatuses[DiskHealthStatus(text)]
+	if !ok {
+		return fmt.Errorf("%q is not a valid disk health status", string(text))
+	}
+
+	*s = DiskHealthStatus(text)
+
+	return nil
+}
+
+// ServerDiskHealth holds the health information of a single disk of a server.
+//
+// swagger:model
+type ServerDiskHealth struct {
+	// Server is the name of the server the disk belongs to.
+	// Example: server01
+	Server string `json:"server" yaml:"server"`
+
+	// Cluster is the name of the cluster the server belongs to. Empty for
+	// standalone servers.
+	// Example: cluster01
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// Drive is the identifier of the disk on the server.
+	// Example: nvme-eui.0025388b91b12345
+	Drive string `json:"drive" yaml:"drive"`
+
+	// ModelName is the model name of the disk.
+	// Example: Samsung SSD 970 EVO 1TB
+	ModelName string `json:"model_name" yaml:"model_name"`
+
+	// SerialNumber is the serial number of the disk.
+	// Example: S4EVNF0M123456
+	SerialNumber string `json:"serial_number" yaml:"serial_number"`
+
+	// Bus is the bus, the disk is connected to.
+	// Example: nvme
+	Bus string `json:"bus" yaml:"bus"`
+
+	// Status is the evaluated health status of the disk.
+	// Example: warning
+	Status DiskHealthStatus `json:"status" yaml:"status"`
+
+	// Reasons contains the reasons for a status other than "ok".
+	// Example: ["percentage used 85% reached the threshold of 80%"]
+	Reasons []string `json:"reasons" yaml:"reasons"`
+
+	// Passed is true, if the SMART overall health self-assessment test has
+	// been passed.
+	// Example: true
+	Passed bool `json:"passed" yaml:"passed"`
+
+	// PercentageUsed is the estimated percentage of the life of the disk,
+	// which has been used (NVMe only).
+	// Example: 85
+	PercentageUsed int `json:"percentage_used" yaml:"percentage_used"`
+
+	// AvailableSpare is the remaining spare capacity in percent (NVMe only).
+	// Example: 100
+	AvailableSpare int `json:"available_spare" yaml:"available_spare"`
+
+	// ReallocatedSectors is the number of reallocated sectors (SATA only).
+	// Example: 0
+	ReallocatedSectors int `json:"reallocated_sectors" yaml:"reallocated_sectors"`
+
+	// PowerOnHours is the number of hours, the disk has been powered on.
+	// Example: 17520
+	PowerOnHours int `json:"power_on_hours" yaml:"power_on_hours"`
+
+	// History contains the recorded health samples of the disk in
+	// chronological order. Only provided for the disk health of a single
+	// server.
+	History []DiskHealthSample `json:"history,omitempty" yaml:"history,omitempty"`
+}
+
+// DiskHealthSample is a recorded sample of the health attributes of a disk.
+//
+// swagger:model
+type DiskHealthSample struct {
+	// Passed is true, if the SMART overall health self-assessment test has
+	// been passed.
+	// Example: true
+	Passed bool `json:"passed" yaml:"passed"`
+
+	// PercentageUsed is the estimated percentage of the life of the disk,
+	// which has been used (NVMe only).
+	// Example: 85
+	PercentageUsed int `json:"percentage_used" yaml:"percentage_used"`
+
+	// AvailableSpare is the remaining spare capacity in percent (NVMe only).
+	// Example: 100
+	AvailableSpare int `json:"available_spare" yaml:"available_spare"`
+
+	// ReallocatedSectors is the number of reallocated sectors (SATA only).
+	// Example: 0
+	ReallocatedSectors int `json:"reallocated_sectors" yaml:"reallocated_sectors"`
+
+	// PowerOnHours is the number of hours, the disk has been powered on.
+	// Example: 17520
+	PowerOnHours int `json:"power_on_hours" yaml:"power_on_hours"`
+
+	// RecordedAt is the time, when the sample has been recorded.
+	// Example: 2024-11-12T16:15:00Z
+	RecordedAt time.Time `json:"recorded_at" yaml:"recorded_at"`
+}
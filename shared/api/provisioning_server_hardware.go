@@ -0,0 +1,122 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// HardwareComponent is the kind of hardware component affected by a hardware
+// change.
+type HardwareComponent string
+
+const (
+	HardwareComponentCPU    HardwareComponent = "cpu"
+	HardwareComponentMemory HardwareComponent = "memory"
+	HardwareComponentDisk   HardwareComponent = "disk"
+	HardwareComponentNIC    HardwareComponent = "nic"
+)
+
+// HardwareChangeAction describes, how a hardware component did change.
+type HardwareChangeAction string
+
+const (
+	HardwareChangeActionAdded   HardwareChangeAction = "added"
+	HardwareChangeActionRemoved HardwareChangeAction = "removed"
+	HardwareChangeActionChanged HardwareChangeAction = "changed"
+)
+
+// HardwareChange describes a single change of a hardware component of a server
+// between two subsequent hardware snapshots.
+//
+// swagger:model
+type HardwareChange struct {
+	// Component is the kind of the affected hardware component.
+	// Example: disk
+	Component HardwareComponent `json:"component" yaml:"component"`
+
+	// Action describes, how the hardware component did change.
+	// Example: removed
+	Action HardwareChangeAction `json:"action" yaml:"action"`
+
+	// Identifier of the hardware component, e.g. the serial number of a disk
+	// or the MAC address of a network interface.
+	// Example: S4EVNF0M123456
+	Identifier string `json:"identifier" yaml:"identifier"`
+
+	// Previous describes the hardware component before the change. Empty if
+	// the component has been added.
+	// Example: Samsung SSD 970 EVO (1.00TB)
+	Previous string `json:"previous" yaml:"previous"`
+
+	// Current describes the hardware component after the change. Empty if
+	// the component has been removed.
+	// Example: Samsung SSD 990 PRO (2.00TB)
+	Current string `json:"current" yaml:"current"`
+}
+
+// String returns a human readable representation of the hardware change.
+func (h HardwareChange) String() string {
+	switch h.Action {
+	case HardwareChangeActionAdded:
+		return fmt.Sprintf("%s %q added: %s", h.Component, h.Identifier, h.Current)
+	case HardwareChangeActionRemoved:
+		return fmt.Sprintf("%s %q removed: %s", h.Component, h.Identifier, h.Previous)
+	default:
+		return fmt.Sprintf("%s %q changed: %s -> %s", h.Component, h.Identifier, h.Previous, h.Current)
+	}
+}
+
+// HardwareChanges is a list of hardware changes.
+type HardwareChanges []HardwareChange
+
+// Value implements the sql driver.Valuer interface.
+func (h HardwareChanges) Value() (driver.Value, error) {
+	return json.Marshal(h)
+}
+
+// Scan implements the sql.Scanner interface.
+func (h *HardwareChanges) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid hardware changes")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*h = HardwareChanges{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), h)
+
+	case []byte:
+		if len(v) == 0 {
+			*h = HardwareChanges{}
+			return nil
+		}
+
+		return json.Unmarshal(v, h)
+
+	default:
+		return fmt.Errorf("type %T is not supported for hardware changes", value)
+	}
+}
+
+// ServerHardwareSnapshot is a recorded snapshot of the hardware data of a
+// server together with the changes compared to the previous snapshot.
+//
+// swagger:model
+type ServerHardwareSnapshot struct {
+	// HardwareData contains the hardware data of the server at the time the
+	// snapshot has been recorded.
+	HardwareData HardwareData `json:"hardware_data" yaml:"hardware_data"`
+
+	// Changes compared to the previous snapshot. Empty for the initial snapshot.
+	Changes HardwareChanges `json:"changes" yaml:"changes"`
+
+	// CreatedAt is the time, when the snapshot has been recorded.
+	// Example: 2024-11-12T16:15:00Z
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
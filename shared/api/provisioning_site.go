@@ -0,0 +1,123 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SiteConfig contains site wide configuration, which is inherited by the
+// clusters of the site, unless overridden on the cluster itself.
+type SiteConfig struct {
+	// MaintenanceWindows holds the recurring time windows, during which cluster
+	// wide operations are allowed for the clusters of the site, which do not
+	// define maintenance windows on their own. If empty, the cluster wide
+	// operations are not restricted by the site.
+	MaintenanceWindows []ClusterConfigMaintenanceWindow `json:"maintenance_windows" yaml:"maintenance_windows"`
+}
+
+func (c SiteConfig) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *SiteConfig) Scan(value any) error {
+	if value == nil {
+		return fmt.Errorf("null is not a valid site config")
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) == 0 {
+			*c = SiteConfig{}
+			return nil
+		}
+
+		return json.Unmarshal([]byte(v), c)
+
+	case []byte:
+		if len(v) == 0 {
+			*c = SiteConfig{}
+			return nil
+		}
+
+		return json.Unmarshal(v, c)
+
+	default:
+		return fmt.Errorf("type %T is not supported for site config", value)
+	}
+}
+
+// SitePut represents the fields available for update for a site.
+//
+// swagger:model
+type SitePut struct {
+	// Description of the site.
+	// Example: Datacenter Zurich, room 2.
+	Description string `json:"description" yaml:"description"`
+
+	// Channel is the default update channel for the clusters and standalone
+	// servers of the site.
+	// Example: stable
+	Channel string `json:"channel" yaml:"channel"`
+
+	// Properties contains properties of the site as key/value pairs.
+	// Example (in YAML notation for readability):
+	//   properties:
+	//     region: eu
+	Properties ConfigMap `json:"properties" yaml:"properties"`
+
+	// Config contains site wide configuration, which is inherited by the
+	// clusters of the site.
+	Config SiteConfig `json:"config" yaml:"config"`
+}
+
+// SitePost represents the fields available when creating a site.
+//
+// swagger:model
+type SitePost struct {
+	SitePut `yaml:",inline"`
+
+	// Name of the site.
+	// Example: zurich
+	Name string `json:"name" yaml:"name"`
+}
+
+// SiteStatus contains the aggregated health and update status of the
+// clusters and servers of a site.
+type SiteStatus struct {
+	// Number of clusters, which belong to the site.
+	// Example: 2
+	Clusters int `json:"clusters" yaml:"clusters"`
+
+	// Number of servers, which belong to the site, clustered or standalone.
+	// Example: 7
+	Servers int `json:"servers" yaml:"servers"`
+
+	// Number of servers of the site, which need an update.
+	// Example: 3
+	ServersNeedingUpdate int `json:"servers_needing_update" yaml:"servers_needing_update"`
+
+	// Number of servers of the site, which are offline.
+	// Example: 1
+	ServersOffline int `json:"servers_offline" yaml:"servers_offline"`
+
+	// Number of open (not acknowledged) warnings, which relate to the clusters
+	// and servers of the site.
+	// Example: 4
+	OpenWarnings int `json:"open_warnings" yaml:"open_warnings"`
+}
+
+// Site defines a site, which groups clusters and standalone servers.
+//
+// swagger:model
+type Site struct {
+	SitePost `yaml:",inline"`
+
+	// Status contains the aggregated health and update status of the site.
+	Status SiteStatus `json:"status" yaml:"status"`
+
+	// LastUpdated is the time, when this information has been updated for the last time in RFC3339 format.
+	// Example: 2024-11-12T16:15:00Z
+	LastUpdated time.Time `json:"last_updated" yaml:"last_updated"`
+}
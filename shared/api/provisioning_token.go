@@ -40,6 +40,31 @@ type TokenPut struct {
 	// using this token during provisioning.
 	// Example: "stable"
 	Channel string `json:"channel" yaml:"channel"`
+
+	// ClusterJoin defines the cluster, servers registered with this token
+	// automatically join, as soon as they are ready.
+	ClusterJoin TokenClusterJoin `json:"cluster_join" yaml:"cluster_join"`
+}
+
+// TokenClusterJoin defines the cluster, servers registered with a token
+// automatically join, as soon as they are ready. If Cluster is empty, the
+// servers are not added to any cluster.
+//
+// swagger:model
+type TokenClusterJoin struct {
+	// Cluster is the name of the cluster, the servers join.
+	// Example: cluster01
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// If set to true, the post join operations (namely the creation of the
+	// local storage volumes for backups, images and logs) are skipped.
+	// Example: false
+	SkipPostJoinOperations bool `json:"skip_post_join_operations" yaml:"skip_post_join_operations"`
+
+	// If set to true, the IncusOS services configuration is copied from an
+	// existing member of the cluster to the server, before it joins the cluster.
+	// Example: true
+	CopyServicesConfig bool `json:"copy_services_config" yaml:"copy_services_config"`
 }
 
 type ImageType string
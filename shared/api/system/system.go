@@ -163,8 +163,28 @@ type SettingsPut struct {
 
 	// ServerRegistrationScriptlet hold the server registration scriptlet.
 	ServerRegistrationScriptlet string `json:"server_registration_scriptlet" yaml:"server_registration_scriptlet"`
+
+	// ClusterUpdateHealthGateScriptlet holds the scriptlet, which is executed
+	// before and after each step of a rolling cluster update or reboot.
+	ClusterUpdateHealthGateScriptlet string `json:"cluster_update_health_gate_scriptlet" yaml:"cluster_update_health_gate_scriptlet"`
+
+	// ClusterProvisioningBackend selects the backend, which applies the
+	// application seed config to newly created clusters.
+	// Possible values are: terraform, incus. If empty, terraform is used.
+	// Example: incus
+	ClusterProvisioningBackend string `json:"cluster_provisioning_backend" yaml:"cluster_provisioning_backend"`
 }
 
+const (
+	// ClusterProvisioningBackendTerraform applies the application seed config
+	// using Terraform (OpenTofu) and the Incus Terraform provider.
+	ClusterProvisioningBackendTerraform = "terraform"
+
+	// ClusterProvisioningBackendIncus applies the application seed config
+	// directly through the Incus API.
+	ClusterProvisioningBackendIncus = "incus"
+)
+
 // Updates represents the system's updates configuration.
 //
 // swagger:model
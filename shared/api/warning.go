@@ -57,6 +57,35 @@ const (
 	// WarningTypeVersionDatailsMissing indicates a warning where version details
 	// for a given update (OS or application) is missing.
 	WarningTypeVersionDatailsMissing WarningType = "Update version details missing"
+
+	// WarningTypeClusterAutoUpdateFailed indicates a warning during the
+	// automatic launch of a cluster update.
+	WarningTypeClusterAutoUpdateFailed WarningType = "Cluster auto update failed"
+
+	// WarningTypeClusterUpdateHealthGateFailed indicates that a health gate
+	// scriptlet prevented the next step of a cluster update.
+	WarningTypeClusterUpdateHealthGateFailed WarningType = "Cluster update health gate failed"
+
+	// WarningTypeClusterConfigurationDrift indicates that the configuration of
+	// a cluster drifted from the Terraform or Incus configuration, the cluster
+	// has been provisioned with.
+	WarningTypeClusterConfigurationDrift WarningType = "Cluster configuration drift"
+
+	// WarningTypeClusterMembersInconsistent indicates that the settings of the
+	// members of a cluster diverge from each other.
+	WarningTypeClusterMembersInconsistent WarningType = "Cluster members inconsistent"
+
+	// WarningTypeServerHardwareChanged indicates that an unexpected change of
+	// the hardware of a server has been detected.
+	WarningTypeServerHardwareChanged WarningType = "Server hardware changed"
+
+	// WarningTypeServerDiskHealth indicates that the health of a disk of
+	// a server crossed a threshold and the disk is likely to fail.
+	WarningTypeServerDiskHealth WarningType = "Server disk health degraded"
+
+	// WarningTypeServerClusterJoinFailed indicates that the automatic join of
+	// a server to the cluster defined by its registration token failed.
+	WarningTypeServerClusterJoinFailed WarningType = "Server cluster join failed"
 )
 
 // WarningScope represents a scope for a warning.
@@ -43,6 +43,18 @@ const ClusterBulkUpdateForm: FC<Props> = ({ formik }) => {
             <option key="" value="remove_multipath_storage_target">
               Remove a multipath LUN
             </option>
+            <option key="" value="add_ceph_cluster">
+              Add a Ceph cluster
+            </option>
+            <option key="" value="remove_ceph_cluster">
+              Remove a Ceph cluster
+            </option>
+            <option key="" value="update_ovn_config">
+              Apply OVN configuration
+            </option>
+            <option key="" value="update_linstor_config">
+              Apply Linstor configuration
+            </option>
             <option key="" value="add_application">
               Install an application
             </option>
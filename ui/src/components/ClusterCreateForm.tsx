@@ -61,6 +61,7 @@ const ClusterCreateForm: FC<Props> = ({ mode, onSubmit }) => {
       rolling_restart: {
         post_restore_delay: "",
         restore_mode: "",
+        max_unavailable: "",
       },
     },
   };
@@ -109,6 +110,7 @@ const ClusterCreateForm: FC<Props> = ({ mode, onSubmit }) => {
           rolling_restart: {
             post_restore_delay: "",
             restore_mode: "",
+            max_unavailable: "",
           },
         },
       });
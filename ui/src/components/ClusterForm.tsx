@@ -22,6 +22,7 @@ const ClusterForm: FC<Props> = ({ cluster, onRename, onSubmit }) => {
     post_restore_delay:
       cluster?.config.rolling_restart.post_restore_delay || "",
     restore_mode: cluster?.config.rolling_restart.restore_mode || "",
+    max_unavailable: cluster?.config.rolling_restart.max_unavailable || "",
   };
 
   const formik = useFormik({
@@ -106,6 +107,17 @@ const ClusterForm: FC<Props> = ({ cluster, onRename, onSubmit }) => {
               onBlur={formik.handleBlur}
             />
           </Form.Group>
+          <Form.Group className="mb-3" controlId="max_unavailable">
+            <Form.Label>Max unavailable</Form.Label>
+            <Form.Control
+              type="text"
+              name="max_unavailable"
+              placeholder="1"
+              value={formik.values.max_unavailable}
+              onChange={formik.handleChange}
+              onBlur={formik.handleBlur}
+            />
+          </Form.Group>
         </Form>
       </div>
       <div className="fixed-footer p-3">
@@ -14,6 +14,8 @@ const SystemSettingsForm: FC<Props> = ({ settings, onSubmit }) => {
     log_level: settings?.log_level ?? "",
     server_registration_scriptlet:
       settings?.server_registration_scriptlet ?? "",
+    cluster_update_health_gate_scriptlet:
+      settings?.cluster_update_health_gate_scriptlet ?? "",
   };
 
   const formik = useFormik({
@@ -65,6 +67,22 @@ const SystemSettingsForm: FC<Props> = ({ settings, onSubmit }) => {
               disabled={formik.isSubmitting}
             />
           </Form.Group>
+          <Form.Group
+            className="mb-3"
+            controlId="cluster_update_health_gate_scriptlet"
+          >
+            <Form.Label>Cluster Update Health Gate Scriptlet</Form.Label>
+            <Form.Control
+              type="text"
+              as="textarea"
+              rows={10}
+              name="cluster_update_health_gate_scriptlet"
+              value={formik.values.cluster_update_health_gate_scriptlet}
+              onChange={formik.handleChange}
+              onBlur={formik.handleBlur}
+              disabled={formik.isSubmitting}
+            />
+          </Form.Group>
         </Form>
       </div>
       <div className="fixed-footer p-3">
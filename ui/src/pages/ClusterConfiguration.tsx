@@ -20,9 +20,11 @@ const ClusterConfiguration = () => {
           connection_url: values.connection_url,
           channel: values.channel,
           config: {
+            ...cluster?.config,
             rolling_restart: {
               post_restore_delay: values.post_restore_delay,
               restore_mode: values.restore_mode,
+              max_unavailable: values.max_unavailable,
             },
           },
         },
@@ -80,6 +80,12 @@ const ClusterOverview = () => {
           {cluster?.config.rolling_restart.post_restore_delay}
         </div>
       </div>
+      <div className="row">
+        <div className="col-2 detail-table-header">Max unavailable</div>
+        <div className="col-10 detail-table-cell">
+          {cluster?.config.rolling_restart.max_unavailable || "1"}
+        </div>
+      </div>
       <div className="row">
         <div className="col-2 detail-table-header">Certificate</div>
         <div className="col-10 detail-table-cell">
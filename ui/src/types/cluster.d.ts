@@ -3,6 +3,7 @@ export interface ClusterUpdateStatusProgress {
   status_description: string;
   evacuated_before?: string[];
   pending_reboot?: string[];
+  server_states?: { [key: string]: string };
 }
 
 export interface ClusterUpdateStatus {
@@ -19,6 +20,7 @@ export interface ClusterProperty {
 export interface ClusterConfigRollingRestart {
   post_restore_delay: string;
   restore_mode: string;
+  max_unavailable: string;
 }
 
 export interface ClusterConfig {
@@ -62,6 +64,7 @@ export interface ClusterFormValues {
   properties: ClusterProperty;
   restore_mode: string;
   post_restore_delay: string;
+  max_unavailable: string;
 }
 
 export interface ClusterCertFormValues {
@@ -44,6 +44,7 @@ export interface SystemSecurityACME {
 export interface SystemSettings {
   log_level: string;
   server_registration_scriptlet: string;
+  cluster_update_health_gate_scriptlet: string;
 }
 
 export interface SystemCertificate {
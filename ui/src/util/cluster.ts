@@ -9,5 +9,6 @@ export const ClusterUpdateInProgress = {
   ApplyUpdateWithReboot: "applying updates with reboot",
   RollingRestart: "restarting servers",
   RollingReboot: "rolling reboot",
+  ReplaceServer: "replacing server",
   Error: "error",
 } as const;